@@ -0,0 +1,114 @@
+// Package admincli performs administrative operations against the GoAlert
+// GraphQL API using an API key.
+package admincli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Client makes GraphQL requests to a GoAlert instance.
+type Client struct {
+	// URL is the base URL of the GoAlert instance (e.g., https://goalert.example.com).
+	URL string
+
+	// APIKey is the GraphQL API key token used to authenticate requests.
+	APIKey string
+
+	// HTTPClient is used to make requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Error is an error returned by the GraphQL API.
+type Error struct {
+	Message string
+	Path    []interface{}
+}
+
+func (e Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	path := make([]string, len(e.Path))
+	for i, p := range e.Path {
+		path[i] = fmt.Sprint(p)
+	}
+	return strings.Join(path, ".") + ": " + e.Message
+}
+
+// query will perform a GraphQL request and decode the resulting data into out (if non-nil).
+func (c *Client) query(ctx context.Context, query string, vars map[string]interface{}, out interface{}) error {
+	if c.URL == "" {
+		return errors.New("API URL is required")
+	}
+	if c.APIKey == "" {
+		return errors.New("API key is required")
+	}
+
+	data, err := json.Marshal(struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{Query: query, Variables: vars})
+	if err != nil {
+		return errors.Wrap(err, "encode request")
+	}
+
+	u, err := url.JoinPath(c.URL, "/api/graphql")
+	if err != nil {
+		return errors.Wrap(err, "parse API URL")
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+	cl := c.HTTPClient
+	if cl == nil {
+		cl = http.DefaultClient
+	}
+	resp, err := cl.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("non-200 response: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var res struct {
+		Data   json.RawMessage
+		Errors []Error
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return errors.Wrap(err, "decode response")
+	}
+	if len(res.Errors) == 1 {
+		return res.Errors[0]
+	}
+	if len(res.Errors) > 1 {
+		msgs := make([]string, len(res.Errors))
+		for i, e := range res.Errors {
+			msgs[i] = e.Error()
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	if out == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(res.Data, out), "decode data")
+}
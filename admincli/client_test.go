@@ -0,0 +1,79 @@
+package admincli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gqlReq struct {
+	Query     string
+	Variables map[string]interface{}
+}
+
+func newTestClient(t *testing.T, fn func(req gqlReq) string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/graphql", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var req gqlReq
+		err := json.NewDecoder(r.Body).Decode(&req)
+		require.NoError(t, err)
+
+		_, _ = w.Write([]byte(fn(req)))
+	}))
+	t.Cleanup(srv.Close)
+
+	return &Client{URL: srv.URL, APIKey: "secret"}
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := newTestClient(t, func(req gqlReq) string {
+		return `{"errors":[{"message":"field not allowed by API key","path":["setConfig"]}]}`
+	})
+
+	err := c.SetConfig(context.Background(), "General.PublicURL", "http://example.com")
+	assert.EqualError(t, err, "setConfig: field not allowed by API key")
+}
+
+func TestClient_AddScheduleRule(t *testing.T) {
+	var updated gqlReq
+	c := newTestClient(t, func(req gqlReq) string {
+		if strings.HasPrefix(req.Query, "query") {
+			return `{"data":{"schedule":{"target":{"rules":[{"start":"00:00","end":"08:00","weekdayFilter":[true,true,true,true,true,true,true]}]}}}}`
+		}
+		updated = req
+		return `{"data":{"updateScheduleTarget":true}}`
+	})
+
+	err := c.AddScheduleRule(context.Background(), "sched", Target{Type: "user", ID: "bob"}, ScheduleRule{
+		Start:         "09:00",
+		End:           "17:00",
+		WeekdayFilter: [7]bool{false, true, true, true, true, true, false},
+	})
+	require.NoError(t, err)
+
+	input := updated.Variables["input"].(map[string]interface{})
+	assert.Equal(t, "sched", input["scheduleID"])
+	rules := input["rules"].([]interface{})
+	require.Len(t, rules, 2, "existing rule should be kept")
+	assert.Equal(t, "00:00", rules[0].(map[string]interface{})["start"])
+	assert.Equal(t, "09:00", rules[1].(map[string]interface{})["start"])
+}
+
+func TestRotatedName(t *testing.T) {
+	ts := time.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, "ci deploy rotated 20231001123000", rotatedName("ci deploy", ts))
+
+	name := rotatedName(strings.Repeat("a", 40)+" "+strings.Repeat("b", 20), ts)
+	assert.Len(t, name, 64-1, "trailing space trimmed before suffix")
+	assert.True(t, strings.HasSuffix(name, " rotated 20231001123000"))
+}
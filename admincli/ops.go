@@ -0,0 +1,353 @@
+package admincli
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Fields lists the GraphQL fields used by each operation. An API key must
+// allow all fields of an operation to perform it.
+var Fields = map[string][]string{
+	"create-service": {
+		"Mutation.createService",
+		"Service.id",
+		"Service.name",
+	},
+	"add-schedule-rule": {
+		"Query.schedule",
+		"Schedule.target",
+		"ScheduleTarget.rules",
+		"ScheduleRule.start",
+		"ScheduleRule.end",
+		"ScheduleRule.weekdayFilter",
+		"Mutation.updateScheduleTarget",
+	},
+	"list-alerts": {
+		"Query.alerts",
+		"AlertConnection.nodes",
+		"Alert.alertID",
+		"Alert.status",
+		"Alert.severity",
+		"Alert.summary",
+		"Alert.serviceID",
+		"Alert.createdAt",
+	},
+	"set-config-key": {
+		"Mutation.setConfig",
+	},
+	"rotate-keys": {
+		"Query.gqlAPIKeys",
+		"GQLAPIKey.id",
+		"GQLAPIKey.name",
+		"GQLAPIKey.description",
+		"GQLAPIKey.allowedFields",
+		"GQLAPIKey.role",
+		"GQLAPIKey.expiresAt",
+		"Mutation.updateGQLAPIKey",
+		"Mutation.createGQLAPIKey",
+		"CreatedGQLAPIKey.id",
+		"CreatedGQLAPIKey.token",
+		"Mutation.deleteGQLAPIKey",
+	},
+	"import-users": {
+		"Mutation.importUsers",
+		"UserImportResult.line",
+		"UserImportResult.name",
+		"UserImportResult.email",
+		"UserImportResult.status",
+		"UserImportResult.userID",
+		"UserImportResult.contactMethodID",
+		"UserImportResult.verificationSent",
+		"UserImportResult.message",
+	},
+}
+
+// Service is a newly created service.
+type Service struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateService will create a new service, optionally assigned to an existing escalation policy.
+func (c *Client) CreateService(ctx context.Context, name, description, escalationPolicyID string) (*Service, error) {
+	input := map[string]interface{}{
+		"name":        name,
+		"description": description,
+	}
+	if escalationPolicyID != "" {
+		input["escalationPolicyID"] = escalationPolicyID
+	}
+
+	var res struct {
+		CreateService *Service
+	}
+	err := c.query(ctx, `mutation AdminCreateService($input: CreateServiceInput!) {
+		createService(input: $input) { id name }
+	}`, map[string]interface{}{"input": input}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.CreateService == nil {
+		return nil, errors.New("service not created")
+	}
+
+	return res.CreateService, nil
+}
+
+// Target identifies a user or rotation on a schedule.
+type Target struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ScheduleRule is a time-of-day rule for a schedule target.
+type ScheduleRule struct {
+	// Start and End are 24-hour clock times (e.g., 09:00).
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	// WeekdayFilter indicates if the rule is active on each weekday, starting with Sunday.
+	WeekdayFilter [7]bool `json:"weekdayFilter"`
+}
+
+// AddScheduleRule will add a rule for the target on a schedule, keeping any existing rules.
+func (c *Client) AddScheduleRule(ctx context.Context, scheduleID string, tgt Target, rule ScheduleRule) error {
+	var res struct {
+		Schedule *struct {
+			Target *struct {
+				Rules []ScheduleRule
+			}
+		}
+	}
+	err := c.query(ctx, `query AdminScheduleTarget($id: ID!, $tgt: TargetInput!) {
+		schedule(id: $id) { target(input: $tgt) { rules { start end weekdayFilter } } }
+	}`, map[string]interface{}{"id": scheduleID, "tgt": tgt}, &res)
+	if err != nil {
+		return err
+	}
+	if res.Schedule == nil {
+		return errors.Errorf("schedule %s not found", scheduleID)
+	}
+
+	var rules []ScheduleRule
+	if res.Schedule.Target != nil {
+		rules = res.Schedule.Target.Rules
+	}
+	rules = append(rules, rule)
+
+	return c.query(ctx, `mutation AdminUpdateScheduleTarget($input: ScheduleTargetInput!) {
+		updateScheduleTarget(input: $input)
+	}`, map[string]interface{}{"input": map[string]interface{}{
+		"scheduleID": scheduleID,
+		"target":     tgt,
+		"rules":      rules,
+	}}, nil)
+}
+
+// Alert is a summary of an alert.
+type Alert struct {
+	AlertID   int       `json:"alertID"`
+	Status    string    `json:"status"`
+	Severity  string    `json:"severity"`
+	Summary   string    `json:"summary"`
+	ServiceID string    `json:"serviceID"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AlertOptions filter the results of ListAlerts.
+type AlertOptions struct {
+	// Status limits results to the given statuses (e.g., StatusUnacknowledged).
+	Status []string
+
+	ServiceIDs []string
+	Search     string
+
+	// Limit is the maximum number of alerts returned (1-1000).
+	Limit int
+}
+
+// ListAlerts will return alerts matching the provided options.
+func (c *Client) ListAlerts(ctx context.Context, opts AlertOptions) ([]Alert, error) {
+	input := map[string]interface{}{
+		"search":          opts.Search,
+		"includeNotified": true,
+	}
+	if opts.Limit > 0 {
+		input["first"] = opts.Limit
+	}
+	if len(opts.Status) > 0 {
+		input["filterByStatus"] = opts.Status
+	}
+	if len(opts.ServiceIDs) > 0 {
+		input["filterByServiceID"] = opts.ServiceIDs
+	}
+
+	var res struct {
+		Alerts struct {
+			Nodes []Alert
+		}
+	}
+	err := c.query(ctx, `query AdminListAlerts($input: AlertSearchOptions) {
+		alerts(input: $input) { nodes { alertID status severity summary serviceID createdAt } }
+	}`, map[string]interface{}{"input": input}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Alerts.Nodes, nil
+}
+
+// SetConfig will update a single config value (e.g., `General.PublicURL`).
+func (c *Client) SetConfig(ctx context.Context, id, value string) error {
+	return c.query(ctx, `mutation AdminSetConfig($input: [ConfigValueInput!]) {
+		setConfig(input: $input)
+	}`, map[string]interface{}{"input": []map[string]string{{"id": id, "value": value}}}, nil)
+}
+
+// APIKey is an existing GraphQL API key.
+type APIKey struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	AllowedFields []string  `json:"allowedFields"`
+	Role          string    `json:"role"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// RotatedKey is the result of rotating an API key.
+type RotatedKey struct {
+	OldID     string
+	NewID     string
+	Name      string
+	ExpiresAt time.Time
+
+	// Token is the secret for the new key, it can not be retrieved again.
+	Token string
+}
+
+// APIKeys will return all GraphQL API keys.
+func (c *Client) APIKeys(ctx context.Context) ([]APIKey, error) {
+	var res struct {
+		GQLAPIKeys []APIKey
+	}
+	err := c.query(ctx, `query AdminAPIKeys {
+		gqlAPIKeys { id name description allowedFields role expiresAt }
+	}`, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.GQLAPIKeys, nil
+}
+
+// RotateKeys will replace each key with a new key having the same name, description, role,
+// and allowed fields, that expires at the provided time.
+//
+// Since key names must be unique, old keys are renamed with a "rotated" suffix first.
+// Unless keepOld is set, the old keys are deleted after all new keys are created, so a key
+// may be used to rotate itself.
+func (c *Client) RotateKeys(ctx context.Context, keys []APIKey, expiresAt time.Time, keepOld bool) ([]RotatedKey, error) {
+	now := time.Now()
+	rename := func(id, name string) error {
+		return c.query(ctx, `mutation AdminRenameAPIKey($input: UpdateGQLAPIKeyInput!) {
+			updateGQLAPIKey(input: $input)
+		}`, map[string]interface{}{"input": map[string]interface{}{"id": id, "name": name}}, nil)
+	}
+
+	result := make([]RotatedKey, 0, len(keys))
+	for _, k := range keys {
+		err := rename(k.ID, rotatedName(k.Name, now))
+		if err != nil {
+			return result, errors.Wrapf(err, "rename key %s", k.ID)
+		}
+
+		var res struct {
+			CreateGQLAPIKey struct {
+				ID    string
+				Token string
+			}
+		}
+		err = c.query(ctx, `mutation AdminCreateAPIKey($input: CreateGQLAPIKeyInput!) {
+			createGQLAPIKey(input: $input) { id token }
+		}`, map[string]interface{}{"input": map[string]interface{}{
+			"name":          k.Name,
+			"description":   k.Description,
+			"allowedFields": k.AllowedFields,
+			"role":          k.Role,
+			"expiresAt":     expiresAt.UTC().Format(time.RFC3339),
+		}}, &res)
+		if err != nil {
+			// restore the original name, so the key can be rotated again
+			_ = rename(k.ID, k.Name)
+			return result, errors.Wrapf(err, "create replacement for key %s", k.ID)
+		}
+
+		result = append(result, RotatedKey{
+			OldID:     k.ID,
+			NewID:     res.CreateGQLAPIKey.ID,
+			Name:      k.Name,
+			ExpiresAt: expiresAt,
+			Token:     res.CreateGQLAPIKey.Token,
+		})
+	}
+
+	if keepOld {
+		return result, nil
+	}
+
+	for _, r := range result {
+		err := c.query(ctx, `mutation AdminDeleteAPIKey($id: ID!) {
+			deleteGQLAPIKey(id: $id)
+		}`, map[string]interface{}{"id": r.OldID}, nil)
+		if err != nil {
+			return result, errors.Wrapf(err, "delete old key %s", r.OldID)
+		}
+	}
+
+	return result, nil
+}
+
+// rotatedName returns the name for a key that has been replaced, keeping
+// within the 64 character limit for key names.
+func rotatedName(name string, t time.Time) string {
+	suffix := " rotated " + t.UTC().Format("20060102150405")
+	if len(name)+len(suffix) > 64 {
+		name = strings.TrimRight(name[:64-len(suffix)], " ")
+	}
+
+	return name + suffix
+}
+
+// UserImportResult is the outcome of importing a single CSV row.
+type UserImportResult struct {
+	Line             int    `json:"line"`
+	Name             string `json:"name"`
+	Email            string `json:"email"`
+	Status           string `json:"status"`
+	UserID           string `json:"userID,omitempty"`
+	ContactMethodID  string `json:"contactMethodID,omitempty"`
+	VerificationSent bool   `json:"verificationSent"`
+	Message          string `json:"message,omitempty"`
+}
+
+// ImportUsers will create users from CSV data. If dryRun is set, rows are only validated.
+func (c *Client) ImportUsers(ctx context.Context, csv string, dryRun, sendVerification bool) ([]UserImportResult, error) {
+	var res struct {
+		ImportUsers []UserImportResult
+	}
+	err := c.query(ctx, `mutation AdminImportUsers($input: ImportUsersInput!) {
+		importUsers(input: $input) { line name email status userID contactMethodID verificationSent message }
+	}`, map[string]interface{}{"input": map[string]interface{}{
+		"csv":              csv,
+		"dryRun":           dryRun,
+		"sendVerification": sendVerification,
+	}}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ImportUsers, nil
+}
@@ -19,6 +19,7 @@ const (
 type Alert struct {
 	ID        int       `json:"_id"`
 	Status    Status    `json:"status"`
+	Severity  Severity  `json:"severity"`
 	Summary   string    `json:"summary"`
 	Details   string    `json:"details"`
 	Source    Source    `json:"source"`
@@ -45,7 +46,7 @@ func (a *Alert) DedupKey() *DedupID {
 }
 
 func (a *Alert) scanFrom(scanFn func(...interface{}) error) error {
-	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup)
+	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup, &a.Severity)
 }
 
 func (a Alert) Normalize() (*Alert, error) {
@@ -55,13 +56,17 @@ func (a Alert) Normalize() (*Alert, error) {
 	if string(a.Status) == "" {
 		a.Status = StatusTriggered
 	}
+	if string(a.Severity) == "" {
+		a.Severity = SeverityHigh
+	}
 	a.Summary = strings.Replace(a.Summary, "\n", " ", -1)
 	a.Summary = strings.Replace(a.Summary, "  ", " ", -1)
 	err := validate.Many(
 		validate.Text("Summary", a.Summary, 1, MaxSummaryLength),
 		validate.Text("Details", a.Details, 0, MaxDetailsLength),
-		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric),
+		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceDatadog, SourceAmazonSNS, SourceSentry, SourceZabbix, SourceAzureMonitor, SourceGCPMonitoring, SourceNewRelic, SourceSplunk, SourcePagerDutyEvents, SourceNagios, SourceSNMP, SourceSyslog, SourceKafka, SourceMQTT, SourceGitHubActions, SourceGrafanaOnCall, SourceEmail, SourceGeneric),
 		validate.OneOf("Status", a.Status, StatusTriggered, StatusActive, StatusClosed),
+		validate.OneOf("Severity", a.Severity, SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow),
 		validate.UUID("ServiceID", a.ServiceID),
 	)
 	if err != nil {
@@ -40,6 +40,8 @@ func (e Entry) Meta(ctx context.Context) interface{} {
 		dest = &EscalationMetaData{}
 	case TypeNotificationSent:
 		dest = &NotificationMetaData{}
+	case TypeNotificationFailover:
+		dest = &NotificationFailoverMetaData{}
 	case TypeCreated:
 		dest = &CreatedMetaData{}
 	case TypeClosed:
@@ -125,7 +127,7 @@ func escalationMsg(m *EscalationMetaData) string {
 }
 
 func (e Entry) String(ctx context.Context) string {
-	var msg string
+	var msg, suffix string
 	var infinitive bool
 	switch e.Type() {
 	case TypeCreated:
@@ -151,6 +153,17 @@ func (e Entry) String(ctx context.Context) string {
 	case TypeNoNotificationSent:
 		msg = "No notification sent"
 		infinitive = true
+	case TypeNotificationFailover:
+		msg = "Notification failed over"
+		infinitive = true
+		meta, ok := e.Meta(ctx).(*NotificationFailoverMetaData)
+		if ok && meta.Paused {
+			suffix = " (contact method type paused)"
+		} else if ok && meta.ProviderFailing {
+			suffix = " (contact method type failing)"
+		} else if ok {
+			suffix = " (previous contact method failing)"
+		}
 	case TypePolicyUpdated:
 		msg = "Policy updated"
 	case TypeDuplicateSupressed:
@@ -164,7 +177,7 @@ func (e Entry) String(ctx context.Context) string {
 	// include subject, if available
 	msg += subjectString(infinitive, e.Subject())
 
-	return msg
+	return msg + suffix
 }
 
 func (e *Entry) scanWith(scan func(...interface{}) error) error {
@@ -1,5 +1,12 @@
 package alertlog
 
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
 type EscalationMetaData struct {
 	NewStepIndex    int
 	Repeat          bool
@@ -13,8 +20,58 @@ type NotificationMetaData struct {
 	MessageID string
 }
 
+// NotificationFailoverMetaData is recorded when a failed notification is sent to the
+// next contact method of a user.
+type NotificationFailoverMetaData struct {
+	MessageID       string
+	FailedMessageID string
+
+	// ProviderFailing is true if the failover was due to the contact method type failing,
+	// rather than the original contact method itself.
+	ProviderFailing bool
+
+	// Paused is true if the contact method type was paused by an administrator.
+	Paused bool
+}
+
 type CreatedMetaData struct {
 	EPNoSteps bool
+
+	// TraceParent is the W3C trace context of the request that created the alert, if it was traced.
+	TraceParent string `json:",omitempty"`
+}
+
+// SpanContext returns the span context of the request that created the alert, it will be
+// invalid if the request was not traced.
+func (m CreatedMetaData) SpanContext() trace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": m.TraceParent})
+	return trace.SpanContextFromContext(ctx)
+}
+
+// withTraceParent will return meta with TraceParent set from the span in ctx, if any.
+func withTraceParent(ctx context.Context, meta interface{}) interface{} {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return meta
+	}
+
+	c := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, c)
+
+	var m CreatedMetaData
+	switch v := meta.(type) {
+	case *CreatedMetaData:
+		if v != nil {
+			m = *v
+		}
+	case CreatedMetaData:
+		m = v
+	case nil:
+	default:
+		return meta
+	}
+	m.TraceParent = c.Get("traceparent")
+
+	return &m
 }
 
 type AutoClose struct {
@@ -48,7 +48,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		lookupNCTypeName: p.P(`
-			select "type", name from notification_channels where id = $1
+			select nc."type", nc.name, t.name
+			from notification_channels nc
+			left join teams t on t.id = nc.team_id
+			where nc.id = $1
 		`),
 
 		lookupIKeyType: p.P(`select "type" from integration_keys where id = $1`),
@@ -274,6 +277,10 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 		_type = TypeClosed
 	}
 
+	if _type == TypeCreated {
+		meta = withTraceParent(ctx, meta)
+	}
+
 	var r Entry
 	r._type = _type
 	var err error
@@ -290,8 +297,8 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 		case permission.SourceTypeNotificationChannel:
 			r.subject._type = SubjectTypeChannel
 			var ncType notificationchannel.Type
-			var name string
-			err = txWrap(ctx, tx, s.lookupNCTypeName).QueryRowContext(ctx, src.ID).Scan(&ncType, &name)
+			var name, teamName sql.NullString
+			err = txWrap(ctx, tx, s.lookupNCTypeName).QueryRowContext(ctx, src.ID).Scan(&ncType, &name, &teamName)
 			if err != nil {
 				return nil, errors.Wrap(err, "lookup contact method type for callback ID")
 			}
@@ -301,6 +308,16 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Slack"
 			case notificationchannel.TypeWebhook:
 				r.subject.classifier = "Webhook"
+			case notificationchannel.TypeSMS:
+				r.subject.classifier = "SMS"
+			case notificationchannel.TypeVoice:
+				r.subject.classifier = "Voice"
+			case notificationchannel.TypeEmail:
+				r.subject.classifier = "Email"
+			}
+			if teamName.Valid {
+				// team contact method, attribute to the team
+				r.subject.classifier = teamName.String + " team " + r.subject.classifier
 			}
 			r.subject.channelID.UUID = uuid.MustParse(src.ID)
 			r.subject.channelID.Valid = true
@@ -347,7 +364,7 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 			if err != nil {
 				return nil, errors.Wrap(err, "lookup notification type for callback ID")
 			}
-			switch dt.DestType() {
+			switch dt.DestType().SenderType() {
 			case notification.DestTypeVoice:
 				r.subject.classifier = "Voice"
 			case notification.DestTypeSMS:
@@ -398,6 +415,38 @@ func (s *Store) logEntry(ctx context.Context, tx *sql.Tx, _type Type, meta inter
 				r.subject.classifier = "Grafana"
 			case integrationkey.TypeSite24x7:
 				r.subject.classifier = "Site24x7"
+			case integrationkey.TypeDatadog:
+				r.subject.classifier = "Datadog"
+			case integrationkey.TypeAmazonSNS:
+				r.subject.classifier = "Amazon SNS"
+			case integrationkey.TypeSentry:
+				r.subject.classifier = "Sentry"
+			case integrationkey.TypeZabbix:
+				r.subject.classifier = "Zabbix"
+			case integrationkey.TypeAzureMonitor:
+				r.subject.classifier = "Azure Monitor"
+			case integrationkey.TypeGCPMonitoring:
+				r.subject.classifier = "Google Cloud Monitoring"
+			case integrationkey.TypeNewRelic:
+				r.subject.classifier = "New Relic"
+			case integrationkey.TypeSplunk:
+				r.subject.classifier = "Splunk"
+			case integrationkey.TypePagerDutyEvents:
+				r.subject.classifier = "PagerDuty Events API"
+			case integrationkey.TypeNagios:
+				r.subject.classifier = "Nagios/Icinga"
+			case integrationkey.TypeSNMP:
+				r.subject.classifier = "SNMP"
+			case integrationkey.TypeSyslog:
+				r.subject.classifier = "Syslog"
+			case integrationkey.TypeKafka:
+				r.subject.classifier = "Kafka"
+			case integrationkey.TypeMQTT:
+				r.subject.classifier = "MQTT"
+			case integrationkey.TypeGitHubActions:
+				r.subject.classifier = "GitHub Actions"
+			case integrationkey.TypeGrafanaOnCall:
+				r.subject.classifier = "Grafana OnCall"
 			case integrationkey.TypeEmail:
 				r.subject.classifier = "Email"
 			}
@@ -0,0 +1,111 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirBackend keeps archived alerts in a local (or shared) directory.
+type DirBackend struct {
+	dir string
+}
+
+var _ Backend = (*DirBackend)(nil)
+
+// NewDirBackend will return a DirBackend that stores files under dir, creating it if necessary.
+func NewDirBackend(dir string) (*DirBackend, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("create archive dir: %w", err)
+	}
+
+	return &DirBackend{dir: dir}, nil
+}
+
+// syncDir will fsync a directory, so that entries created or renamed within it are durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// mkdirAll creates rel (relative to the backend) and any missing parents, syncing each parent
+// after a new directory is added.
+func (d *DirBackend) mkdirAll(rel string) error {
+	dir := d.dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		parent := dir
+		dir = filepath.Join(dir, part)
+		err := os.Mkdir(dir, 0o700)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = syncDir(parent)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Put implements Backend. The file is written to a temporary name and synced before being renamed,
+// so a partial file is never read.
+func (d *DirBackend) Put(ctx context.Context, key string, data []byte) error {
+	rel := filepath.FromSlash(key)
+	err := d.mkdirAll(filepath.Dir(rel))
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(d.dir, rel)
+	dir := filepath.Dir(fileName)
+
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	_, err = f.Write(data)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.Name(), fileName)
+	if err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// Get implements Backend.
+func (d *DirBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(d.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return data, err
+}
@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Backend keeps archived alerts in an S3 (or S3-compatible) bucket.
+type S3Backend struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+var _ Backend = (*S3Backend)(nil)
+
+// NewS3Backend will return an S3Backend for a URL of the form `s3://bucket/optional/prefix`.
+//
+// Credentials are loaded the same way as the AWS CLI (environment, shared config, or instance role).
+// The optional `region` and `endpoint` query parameters override the region and endpoint; setting an
+// endpoint (e.g., for MinIO) also enables path-style requests.
+func NewS3Backend(ctx context.Context, rawURL string) (*S3Backend, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse archive URL: %w", err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return nil, errors.New("parse archive URL: must be of the form s3://bucket/prefix")
+	}
+
+	q := u.Query()
+	var opts []func(*config.LoadOptions) error
+	if region := q.Get("region"); region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+
+	return newS3Backend(cfg, u.Host, strings.Trim(u.Path, "/"), q.Get("endpoint")), nil
+}
+
+func newS3Backend(cfg aws.Config, bucket, prefix, endpoint string) *S3Backend {
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint == "" {
+			return
+		}
+		o.BaseEndpoint = aws.String(endpoint)
+		o.UsePathStyle = true
+	})
+
+	return &S3Backend{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (b *S3Backend) objectKey(key string) string {
+	return path.Join(b.prefix, key)
+}
+
+// Put implements Backend. S3 only acknowledges a PUT once the object is stored durably.
+func (b *S3Backend) Put(ctx context.Context, key string, data []byte) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(b.bucket),
+		Key:           aws.String(b.objectKey(key)),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+		ContentType:   aws.String("application/gzip"),
+	})
+	if err != nil {
+		return fmt.Errorf("put s3://%s/%s: %w", b.bucket, b.objectKey(key), err)
+	}
+
+	return nil
+}
+
+// Get implements Backend.
+func (b *S3Backend) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get s3://%s/%s: %w", b.bucket, b.objectKey(key), err)
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Alert is a closed alert, and its log entries, that has been moved out of the database.
+type Alert struct {
+	ID         int
+	ServiceID  string
+	Summary    string
+	Details    string
+	Source     alert.Source
+	Severity   alert.Severity
+	CreatedAt  time.Time
+	ArchivedAt time.Time
+
+	Logs []Log
+}
+
+// Log is an archived alert log entry.
+type Log struct {
+	ID        int
+	Timestamp time.Time
+	Event     string
+	Message   string
+}
+
+// Backend is where archived alert files are kept.
+type Backend interface {
+	// Put will write data to key, replacing any existing value. It must not return until
+	// the data is durable.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get will return the data stored at key, or nil if it does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Store keeps archived alerts as gzip-compressed JSON files in a Backend, partitioned by the month
+// each alert was created (e.g., 2023/01/000001/12345.json.gz), so old partitions can be moved to
+// colder storage or removed as a whole.
+//
+// The alert_archive table records the creation time of each archived alert, so that Get can find
+// the file without listing partitions.
+//
+// When running multiple instances, the backend must be shared by all of them: any engine instance
+// may archive alerts, and any API instance may be asked to retrieve them.
+type Store struct {
+	b Backend
+
+	insert *sql.Stmt
+	find   *sql.Stmt
+}
+
+// NewStore will return a Store that archives alerts to b.
+func NewStore(ctx context.Context, db *sql.DB, b Backend) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		b: b,
+
+		insert: p.P(`
+			insert into alert_archive (alert_id, created_at, archived_at)
+			values ($1, $2, $3)
+			on conflict (alert_id) do update
+			set created_at = excluded.created_at, archived_at = excluded.archived_at
+		`),
+		find: p.P(`select created_at from alert_archive where alert_id = $1`),
+	}, p.Err
+}
+
+// key returns the location of an alert within the backend; files are partitioned by creation month, and
+// grouped within a partition so no directory holds more than 10000.
+func key(id int, createdAt time.Time) string {
+	createdAt = createdAt.UTC()
+	return path.Join(
+		fmt.Sprintf("%04d", createdAt.Year()),
+		fmt.Sprintf("%02d", createdAt.Month()),
+		fmt.Sprintf("%06d", id/10000),
+		fmt.Sprintf("%d.json.gz", id),
+	)
+}
+
+// PutTx will write an archived alert to the backend, and record it in the index using tx. The file is
+// durable before returning, so it is safe to delete the alert from the database in the same transaction.
+func (s *Store) PutTx(ctx context.Context, tx *sql.Tx, a Alert) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	err = json.NewEncoder(gz).Encode(a)
+	if err != nil {
+		return err
+	}
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	err = s.b.Put(ctx, key(a.ID, a.CreatedAt), buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.insert).ExecContext(ctx, a.ID, a.CreatedAt, a.ArchivedAt)
+	if err != nil {
+		return fmt.Errorf("update archive index: %w", err)
+	}
+
+	return nil
+}
+
+// Get will return the archived alert with the given ID, or nil if it does not exist.
+func (s *Store) Get(ctx context.Context, id int) (*Alert, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("AlertID", id, 1, 1<<62)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdAt time.Time
+	err = s.find.QueryRowContext(ctx, id).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup archived alert %d: %w", id, err)
+	}
+
+	data, err := s.b.Get(ctx, key(id, createdAt))
+	if err != nil {
+		return nil, fmt.Errorf("read archived alert %d: %w", id, err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("archived alert %d is missing from storage", id)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("read archived alert %d: %w", id, err)
+	}
+	defer gz.Close()
+
+	var a Alert
+	err = json.NewDecoder(gz).Decode(&a)
+	if err != nil {
+		return nil, fmt.Errorf("decode archived alert %d: %w", id, err)
+	}
+
+	return &a, nil
+}
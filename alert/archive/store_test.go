@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "2023/01/000001/12345.json.gz", key(12345, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	// partitioned by UTC month
+	loc := time.FixedZone("UTC-5", -5*3600)
+	assert.Equal(t, "2023/02/000000/42.json.gz", key(42, time.Date(2023, 1, 31, 22, 0, 0, 0, loc)))
+}
+
+func testBackend(t *testing.T, b Backend) {
+	t.Helper()
+	ctx := context.Background()
+
+	data, err := b.Get(ctx, "2023/01/000001/12345.json.gz")
+	require.NoError(t, err)
+	assert.Nil(t, data, "missing file")
+
+	require.NoError(t, b.Put(ctx, "2023/01/000001/12345.json.gz", []byte("first")))
+	require.NoError(t, b.Put(ctx, "2023/01/000001/12345.json.gz", []byte("second")))
+	require.NoError(t, b.Put(ctx, "2023/03/000001/12346.json.gz", []byte("other")))
+
+	data, err = b.Get(ctx, "2023/01/000001/12345.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data), "replaced")
+
+	data, err = b.Get(ctx, "2023/03/000001/12346.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "other", string(data))
+}
+
+func TestDirBackend(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewDirBackend(dir)
+	require.NoError(t, err)
+
+	testBackend(t, b)
+
+	_, err = os.Stat(filepath.Join(dir, "2023", "01", "000001", "12345.json.gz"))
+	assert.NoError(t, err, "partitioned by creation month")
+
+	tmp, err := filepath.Glob(filepath.Join(dir, "2023", "01", "000001", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp, "temp files should be removed")
+}
+
+func TestS3Backend(t *testing.T) {
+	var mx sync.Mutex
+	objects := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch r.Method {
+		case "PUT":
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			objects[r.URL.Path] = data
+		case "GET":
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := aws.Config{Region: "us-east-1", Credentials: aws.AnonymousCredentials{}}
+	testBackend(t, newS3Backend(cfg, "goalert", "archive/prod", srv.URL))
+
+	mx.Lock()
+	defer mx.Unlock()
+	assert.Contains(t, objects, "/goalert/archive/prod/2023/01/000001/12345.json.gz", "path-style request with prefix")
+}
+
+func TestNewS3Backend(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewS3Backend(ctx, "https://goalert/archive")
+	assert.Error(t, err, "wrong scheme")
+	_, err = NewS3Backend(ctx, "s3:///archive")
+	assert.Error(t, err, "missing bucket")
+
+	b, err := NewS3Backend(ctx, "s3://goalert/archive/prod/?region=us-east-2")
+	require.NoError(t, err)
+	assert.Equal(t, "goalert", b.bucket)
+	assert.Equal(t, "archive/prod/2023/01/000001/12345.json.gz", b.objectKey("2023/01/000001/12345.json.gz"))
+}
@@ -0,0 +1,103 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxBatchSize is the maximum number of alerts that can be created or updated in a single batch.
+const MaxBatchSize = 100
+
+// BatchResult is the result of a single item in a batch.
+type BatchResult struct {
+	// Alert is the created or updated alert, it is nil if Err is set or if a close/ack request
+	// did not match an open alert.
+	Alert *Alert
+	IsNew bool
+
+	// Err is set if the item failed validation, the remaining items are still processed.
+	Err error
+}
+
+// CreateOrUpdateBatch will call CreateOrUpdate for each alert in a single transaction, returning
+// a result for each item, in order.
+//
+// Items that fail validation are reported individually via BatchResult.Err; any other error
+// will fail the entire batch.
+func (s *Store) CreateOrUpdateBatch(ctx context.Context, alerts []Alert) ([]BatchResult, error) {
+	err := validate.Range("Alerts", len(alerts), 1, MaxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]BatchResult, len(alerts))
+	var valid int
+	for i, a := range alerts {
+		res[i].Err = permission.LimitCheckAny(ctx,
+			permission.System,
+			permission.Admin,
+			permission.User,
+			permission.MatchService(a.ServiceID),
+		)
+		if permission.IsUnauthorized(res[i].Err) {
+			// missing auth entirely is not a per-item problem
+			return nil, res[i].Err
+		}
+		if res[i].Err != nil {
+			continue
+		}
+
+		_, res[i].Err = a.Normalize()
+		if res[i].Err != nil {
+			continue
+		}
+		valid++
+	}
+	if valid == 0 {
+		return res, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlutil.Rollback(ctx, "alert: batch upsert", tx)
+
+	for i := range alerts {
+		if res[i].Err != nil {
+			continue
+		}
+
+		res[i].Alert, res[i].IsNew, err = s.CreateOrUpdateTx(ctx, tx, &alerts[i])
+		if validation.IsValidationError(err) {
+			// should not happen, since items were validated above, but must not abort the batch
+			res[i].Err = err
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range res {
+		if !r.IsNew || r.Alert == nil {
+			continue
+		}
+		logCtx := log.WithFields(ctx, log.Fields{log.FieldAlertID: r.Alert.ID, log.FieldServiceID: r.Alert.ServiceID})
+		log.Logf(logCtx, "Alert created.")
+		metricCreatedTotal.Inc()
+	}
+
+	return res, nil
+}
@@ -99,7 +99,8 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 		a.source,
 		a.status,
 		created_at,
-		a.dedup_key
+		a.dedup_key,
+		a.severity
 	FROM alerts a
 	WHERE true
 	{{ if .Omit }}
@@ -116,7 +117,7 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 	{{ if .ServiceFilter.Valid }}
 		AND (a.service_id = any(:services)
 			{{ if .NotifiedUserID }}
-				OR a.id = any(select alert_id from alert_logs where event in ('notification_sent', 'no_notification_sent') and sub_user_id = :notifiedUserID)
+				OR a.id = any(select alert_id from alert_logs where event in ('notification_sent', 'no_notification_sent', 'notification_failover') and sub_user_id = :notifiedUserID)
 			{{ end }}
 		)
 	{{ end }}
@@ -235,7 +236,7 @@ func (s *Store) serviceNameSearch(ctx context.Context, data *renderData) error {
 		return fmt.Errorf("render service-search query: %w", err)
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.readDB.QueryContext(ctx, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		data.serviceNameIDs = nil
 		return nil
@@ -283,7 +284,7 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Alert, error
 		return nil, errors.Wrap(err, "render query")
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.readDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "query")
 	}
@@ -0,0 +1,42 @@
+package alert
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Severity indicates the urgency of an Alert.
+type Severity string
+
+// Alert severity levels
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+)
+
+// IsLow returns true for severities below high, which may be delayed (e.g., by digests).
+func (s Severity) IsLow() bool { return s == SeverityMedium || s == SeverityLow }
+
+func (s Severity) Value() (driver.Value, error) {
+	str := string(s)
+	if str == "" {
+		str = string(SeverityHigh)
+	}
+	return str, nil
+}
+
+func (s *Severity) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*s = Severity(t)
+	case string:
+		*s = Severity(t)
+	case nil:
+		*s = SeverityHigh
+	default:
+		return fmt.Errorf("could not process unknown type for Severity(%T)", t)
+	}
+	return nil
+}
@@ -14,6 +14,22 @@ const (
 	SourceGrafana                Source = "grafana"                // grafana alert
 	SourceSite24x7               Source = "site24x7"               // site24x7 alert
 	SourcePrometheusAlertmanager Source = "prometheusAlertmanager" // prometheus alertmanager alert
+	SourceDatadog                Source = "datadog"                // datadog alert
+	SourceAmazonSNS              Source = "amazonSNS"              // amazon sns alert
+	SourceSentry                 Source = "sentry"                 // sentry alert
+	SourceZabbix                 Source = "zabbix"                 // zabbix alert
+	SourceAzureMonitor           Source = "azureMonitor"           // azure monitor alert
+	SourceGCPMonitoring          Source = "gcpMonitoring"          // google cloud monitoring alert
+	SourceNewRelic               Source = "newRelic"               // new relic alert
+	SourceSplunk                 Source = "splunk"                 // splunk alert
+	SourcePagerDutyEvents        Source = "pagerDutyEvents"        // pagerduty events api alert
+	SourceNagios                 Source = "nagios"                 // nagios/icinga alert
+	SourceSNMP                   Source = "snmp"                   // snmp trap
+	SourceSyslog                 Source = "syslog"                 // syslog alert
+	SourceKafka                  Source = "kafka"                  // kafka alert
+	SourceMQTT                   Source = "mqtt"                   // mqtt alert
+	SourceGitHubActions          Source = "githubActions"          // github actions alert
+	SourceGrafanaOnCall          Source = "grafanaOnCall"          // grafana oncall alert
 	SourceManual                 Source = "manual"                 // manually triggered
 	SourceGeneric                Source = "generic"                // generic API
 )
@@ -24,12 +24,17 @@ type Store struct {
 	db    *sql.DB
 	logDB *alertlog.Store
 
+	// readDB is used for searches, and may be a read replica.
+	readDB *sql.DB
+
 	insert       *sql.Stmt
 	update       *sql.Stmt
 	logs         *sql.Stmt
 	findMany     *sql.Stmt
 	getServiceID *sql.Stmt
 
+	appendDetails *sql.Stmt
+
 	lockSvc      *sql.Stmt
 	lockAlertSvc *sql.Stmt
 
@@ -49,6 +54,9 @@ type Store struct {
 	escalate *sql.Stmt
 	epState  *sql.Stmt
 	svcInfo  *sql.Stmt
+
+	shadowAlert *sql.Stmt
+	shadowSvc   *sql.Stmt
 }
 
 // A Trigger signals that an alert needs to be processed
@@ -62,21 +70,13 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 	p := prep.P
 
 	return &Store{
-		db:    db,
-		logDB: logDB,
-
-		noStepsBySvc: p(`
-			SELECT coalesce(
-				(SELECT true
-				FROM escalation_policies pol
-				JOIN services svc ON svc.id = $1
-				WHERE
-					pol.id = svc.escalation_policy_id
-					AND pol.step_count = 0)
-			, false)
-		`),
+		db:     db,
+		readDB: db,
+		logDB:  logDB,
+
+		noStepsBySvc: p(noStepsBySvcQuery),
 
-		lockSvc:      p(`select 1 from services where id = $1 for update`),
+		lockSvc:      p(lockSvcQuery),
 		lockAlertSvc: p(`SELECT 1 FROM services s JOIN alerts a ON a.id = ANY ($1) AND s.id = a.service_id FOR UPDATE`),
 		getStatusAndLockSvc: p(`
 			SELECT a.status
@@ -94,7 +94,7 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		`),
 
 		insert: p(`
-			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at
+			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key, severity, request_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at
 		`),
 		update: p("UPDATE alerts SET status = $2 WHERE id = $1"),
 		logs:   p("SELECT timestamp, event, message FROM alert_logs WHERE alert_id = $1"),
@@ -108,54 +108,18 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				a.source,
 				a.status,
 				created_at,
-				a.dedup_key
+				a.dedup_key,
+				a.severity
 			FROM alerts a
 			WHERE a.id = ANY ($1)
 		`),
-		createUpdNew: p(`
-			WITH existing as (
-				SELECT id, summary, details, status, source, created_at, false
-				FROM alerts
-				WHERE service_id = $3 AND dedup_key = $5
-			), to_insert as (
-				SELECT 1
-				EXCEPT
-				SELECT 1
-				FROM existing
-			), inserted as (
-				INSERT INTO alerts (
-					summary, details, service_id, source, dedup_key
-				)
-				SELECT $1, $2, $3, $4, $5
-				FROM to_insert
-				RETURNING id, summary, details, status, source, created_at, true
-			)
-			SELECT * FROM existing
-			UNION
-			SELECT * FROM inserted
-		`),
-		createUpdAck: p(`
-			UPDATE alerts a
-			SET status = 'active'
-			FROM alerts old
-			WHERE
-				old.id = a.id AND
-				a.service_id = $1 AND
-				a.dedup_key = $2 AND
-				a.status != 'closed'
-			RETURNING a.id, a.summary, a.details, old.status, a.created_at
-		`),
-		createUpdClose: p(`
-			UPDATE alerts a
-			SET status = 'closed'
-			WHERE
-				service_id = $1 and
-				dedup_key = $2 and
-				status != 'closed'
-			RETURNING id, summary, details, created_at
-		`),
+		createUpdNew:   p(createUpdNewQuery),
+		createUpdAck:   p(createUpdAckQuery),
+		createUpdClose: p(createUpdCloseQuery),
 
 		getServiceID: p("SELECT service_id FROM alerts WHERE id = $1"),
+
+		appendDetails: p("UPDATE alerts SET details = left(details || $3, $4) WHERE service_id = $1 AND dedup_key = $2"),
 		updateByStatusAndService: p(`
 			UPDATE
 				alerts
@@ -202,9 +166,37 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 			FROM services
 			WHERE id = $1
 		`),
+
+		shadowAlert: p(`
+			SELECT a.id
+			FROM alerts a
+			JOIN services svc ON svc.id = a.service_id
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+			JOIN ep_step_on_call_users oc ON
+				oc.ep_step_id = step.id AND
+				oc.end_time ISNULL AND
+				oc.user_id = $1
+			WHERE a.id = ANY ($2)
+			GROUP BY a.id
+			HAVING bool_and(oc.is_shadow)
+			LIMIT 1
+		`),
+		shadowSvc: p(`
+			SELECT coalesce(bool_and(oc.is_shadow), false)
+			FROM services svc
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+			JOIN ep_step_on_call_users oc ON
+				oc.ep_step_id = step.id AND
+				oc.end_time ISNULL AND
+				oc.user_id = $1
+			WHERE svc.id = $2
+		`),
 	}, prep.Err
 }
 
+// UseReadDB will route alert searches to db (e.g., a read replica). Results may lag slightly behind the primary.
+func (s *Store) UseReadDB(db *sql.DB) { s.readDB = db }
+
 // ServiceInfo will return the name of the given service ID as well as the current number
 // of unacknowledged alerts.
 func (s *Store) ServiceInfo(ctx context.Context, serviceID string) (string, int, error) {
@@ -261,6 +253,50 @@ func (s *Store) canTouchAlert(ctx context.Context, alertID int) error {
 	return permission.LimitCheckAny(ctx, checks...)
 }
 
+// errShadowOnly is returned when a user that is only on-call as a shadow attempts to
+// acknowledge or close an alert.
+var errShadowOnly = permission.NewAccessDenied("shadow users cannot acknowledge or close alerts")
+
+// checkShadowAlerts will return an error if the current user is only on-call as a shadow
+// for any of the given alerts, as shadows may not acknowledge or close alerts on their own.
+func (s *Store) checkShadowAlerts(ctx context.Context, tx *sql.Tx, alertIDs []int) error {
+	userID := permission.UserID(ctx)
+	if userID == "" {
+		return nil
+	}
+
+	var id int
+	err := tx.StmtContext(ctx, s.shadowAlert).QueryRowContext(ctx, userID, sqlutil.IntArray(alertIDs)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "check shadow status")
+	}
+
+	return errShadowOnly
+}
+
+// checkShadowService will return an error if the current user is only on-call as a shadow
+// for the given service.
+func (s *Store) checkShadowService(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	userID := permission.UserID(ctx)
+	if userID == "" {
+		return nil
+	}
+
+	var isShadow bool
+	err := tx.StmtContext(ctx, s.shadowSvc).QueryRowContext(ctx, userID, serviceID).Scan(&isShadow)
+	if err != nil {
+		return errors.Wrap(err, "check shadow status")
+	}
+	if isShadow {
+		return errShadowOnly
+	}
+
+	return nil
+}
+
 // EscalateAsOf will request escalation for the given alert ID as-of the given time.
 //
 // An error will be returned if the alert is already closed, if the service is
@@ -381,6 +417,11 @@ func (s *Store) UpdateStatusByService(ctx context.Context, serviceID string, sta
 	}
 	defer sqlutil.Rollback(ctx, "alert: update status by service", tx)
 
+	err = s.checkShadowService(ctx, tx, serviceID)
+	if err != nil {
+		return err
+	}
+
 	t := alertlog.TypeAcknowledged
 	if status == StatusClosed {
 		t = alertlog.TypeClosed
@@ -442,6 +483,11 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 		return nil, err
 	}
 
+	err = s.checkShadowAlerts(ctx, tx, alertIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, status, ids)
 	if err != nil {
 		return nil, err
@@ -512,7 +558,7 @@ func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 		return nil, err
 	}
 
-	ctx = log.WithFields(ctx, log.Fields{"AlertID": n.ID, "ServiceID": n.ServiceID})
+	ctx = log.WithFields(ctx, log.Fields{log.FieldAlertID: n.ID, log.FieldServiceID: n.ServiceID})
 	log.Logf(ctx, "Alert created.")
 	metricCreatedTotal.Inc()
 
@@ -521,7 +567,7 @@ func (s *Store) Create(ctx context.Context, a *Alert) (*Alert, error) {
 
 func (s *Store) _create(ctx context.Context, tx *sql.Tx, a Alert) (*Alert, *alertlog.CreatedMetaData, error) {
 	var meta alertlog.CreatedMetaData
-	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey())
+	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey(), a.Severity, requestID(ctx))
 	err := row.Scan(&a.ID, &a.CreatedAt)
 	if err != nil {
 		return nil, nil, err
@@ -538,6 +584,12 @@ func (s *Store) _create(ctx context.Context, tx *sql.Tx, a Alert) (*Alert, *aler
 // CreateOrUpdateTx returns `isNew` to indicate if the returned alert was a new one.
 // It is the caller's responsibility to log alert creation if the transaction is committed (and isNew is true).
 func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, bool, error) {
+	return s.createOrUpdateTx(ctx, nil, tx, a)
+}
+
+// createOrUpdateTx implements CreateOrUpdateTx. If conn is not nil, it must be the connection tx was started on,
+// and the service lock and upsert will be sent as a single batch.
+func (s *Store) createOrUpdateTx(ctx context.Context, conn *sql.Conn, tx *sql.Tx, a *Alert) (*Alert, bool, error) {
 	err := permission.LimitCheckAny(ctx,
 		permission.System,
 		permission.Admin,
@@ -563,56 +615,66 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 		return nil, false, err
 	}
 
-	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, n.ServiceID)
+	var res upsertResult
+	if conn != nil {
+		res, err = s.upsertBatch(ctx, conn, n)
+	} else {
+		res, err = s.upsertTx(ctx, tx, n)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		// already closed/doesn't exist
+		return nil, false, nil
+	}
 	if err != nil {
 		return nil, false, err
 	}
+	if res.logType != "" {
+		s.logDB.MustLogTx(ctx, tx, n.ID, res.logType, res.meta)
+	}
+
+	return n, res.inserted, nil
+}
+
+// upsertTx will lock the service and create or update n (according to its status), returning sql.ErrNoRows if
+// there is no open alert to update.
+func (s *Store) upsertTx(ctx context.Context, tx *sql.Tx, n *Alert) (res upsertResult, err error) {
+	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, n.ServiceID)
+	if err != nil {
+		return res, err
+	}
 
-	var inserted bool
-	var logType alertlog.Type
-	var meta interface{}
 	switch n.Status {
 	case StatusTriggered:
 		var m alertlog.CreatedMetaData
 		err = tx.Stmt(s.createUpdNew).
-			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &inserted)
-		if !inserted {
-			logType = alertlog.TypeDuplicateSupressed
+			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey(), n.Severity, requestID(ctx)).
+			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.Severity, &n.CreatedAt, &res.inserted)
+		if !res.inserted {
+			res.logType = alertlog.TypeDuplicateSupressed
 		} else {
-			logType = alertlog.TypeCreated
+			res.logType = alertlog.TypeCreated
 			stepErr := tx.StmtContext(ctx, s.noStepsBySvc).QueryRowContext(ctx, n.ServiceID).Scan(&m.EPNoSteps)
 			if stepErr != nil {
-				return nil, false, err
+				return res, err
 			}
 		}
-		meta = &m
+		res.meta = &m
 	case StatusActive:
 		var oldStatus Status
 		err = tx.Stmt(s.createUpdAck).
 			QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
 			Scan(&n.ID, &n.Summary, &n.Details, &oldStatus, &n.CreatedAt)
 		if oldStatus != n.Status {
-			logType = alertlog.TypeAcknowledged
+			res.logType = alertlog.TypeAcknowledged
 		}
 	case StatusClosed:
 		err = tx.Stmt(s.createUpdClose).
 			QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
 			Scan(&n.ID, &n.Summary, &n.Details, &n.CreatedAt)
-		logType = alertlog.TypeClosed
-	}
-	if errors.Is(err, sql.ErrNoRows) {
-		// already closed/doesn't exist
-		return nil, false, nil
-	}
-	if err != nil {
-		return nil, false, err
-	}
-	if logType != "" {
-		s.logDB.MustLogTx(ctx, tx, n.ID, logType, meta)
+		res.logType = alertlog.TypeClosed
 	}
 
-	return n, inserted, nil
+	return res, err
 }
 
 // CreateOrUpdate will create an alert or log a "duplicate suppressed message" if
@@ -631,13 +693,19 @@ func (s *Store) CreateOrUpdate(ctx context.Context, a *Alert) (*Alert, bool, err
 		return nil, false, err
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, false, err
 	}
 	defer sqlutil.Rollback(ctx, "alert: upsert", tx)
 
-	n, isNew, err := s.CreateOrUpdateTx(ctx, tx, a)
+	n, isNew, err := s.createOrUpdateTx(ctx, conn, tx, a)
 	if err != nil {
 		return nil, false, err
 	}
@@ -650,7 +718,7 @@ func (s *Store) CreateOrUpdate(ctx context.Context, a *Alert) (*Alert, bool, err
 		return nil, false, nil
 	}
 	if isNew {
-		ctx = log.WithFields(ctx, log.Fields{"AlertID": n.ID, "ServiceID": n.ServiceID})
+		ctx = log.WithFields(ctx, log.Fields{log.FieldAlertID: n.ID, log.FieldServiceID: n.ServiceID})
 		log.Logf(ctx, "Alert created.")
 		metricCreatedTotal.Inc()
 	}
@@ -670,6 +738,12 @@ func (s *Store) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, stat Sta
 	if _stat == StatusActive && stat == StatusActive {
 		return logError{isAlreadyAcknowledged: true, alertID: id, _type: alertlog.TypeAcknowledged, logDB: s.logDB}
 	}
+	if stat != StatusTriggered {
+		err = s.checkShadowAlerts(ctx, tx, []int{id})
+		if err != nil {
+			return err
+		}
+	}
 
 	_, err = tx.Stmt(s.update).ExecContext(ctx, id, stat)
 	if err != nil {
@@ -709,6 +783,34 @@ func (s *Store) UpdateStatus(ctx context.Context, id int, stat Status) error {
 	return tx.Commit()
 }
 
+// AppendDetails will append text, as a new paragraph, to the details of the open alert
+// with the given dedup key. The result is truncated to MaxDetailsLength.
+//
+// It is a no-op if there is no matching open alert.
+func (s *Store) AppendDetails(ctx context.Context, serviceID string, dedup *DedupID, text string) error {
+	err := permission.LimitCheckAny(ctx,
+		permission.System,
+		permission.Admin,
+		permission.User,
+		permission.MatchService(serviceID),
+	)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	text = validate.SanitizeText(text, MaxDetailsLength)
+	if text == "" {
+		return nil
+	}
+
+	_, err = s.appendDetails.ExecContext(ctx, serviceID, dedup, "\n\n"+text, MaxDetailsLength)
+	return err
+}
+
 func (s *Store) FindOne(ctx context.Context, id int) (*Alert, error) {
 	alerts, err := s.FindMany(ctx, []int{id})
 	if err != nil {
@@ -0,0 +1,146 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	lockSvcQuery = `select 1 from services where id = $1 for update`
+
+	noStepsBySvcQuery = `
+	SELECT coalesce(
+		(SELECT true
+		FROM escalation_policies pol
+		JOIN services svc ON svc.id = $1
+		WHERE
+			pol.id = svc.escalation_policy_id
+			AND pol.step_count = 0)
+	, false)
+	`
+
+	createUpdNewQuery = `
+	WITH existing as (
+		SELECT id, summary, details, status, source, severity, created_at, false
+		FROM alerts
+		WHERE service_id = $3 AND dedup_key = $5
+	), to_insert as (
+		SELECT 1
+		EXCEPT
+		SELECT 1
+		FROM existing
+	), inserted as (
+		INSERT INTO alerts (
+			summary, details, service_id, source, dedup_key, severity, request_id
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		FROM to_insert
+		RETURNING id, summary, details, status, source, severity, created_at, true
+	)
+	SELECT * FROM existing
+	UNION
+	SELECT * FROM inserted
+	`
+
+	createUpdAckQuery = `
+	UPDATE alerts a
+	SET status = 'active'
+	FROM alerts old
+	WHERE
+		old.id = a.id AND
+		a.service_id = $1 AND
+		a.dedup_key = $2 AND
+		a.status != 'closed'
+	RETURNING a.id, a.summary, a.details, old.status, a.created_at
+	`
+
+	createUpdCloseQuery = `
+	UPDATE alerts a
+	SET status = 'closed'
+	WHERE
+		service_id = $1 and
+		dedup_key = $2 and
+		status != 'closed'
+	RETURNING id, summary, details, created_at
+	`
+)
+
+// requestID returns the request ID of ctx to record with new alerts, or NULL if there is none.
+func requestID(ctx context.Context) sql.NullString {
+	id := log.RequestID(ctx)
+	return sql.NullString{String: id, Valid: id != ""}
+}
+
+type upsertResult struct {
+	inserted bool
+	logType  alertlog.Type
+	meta     interface{}
+}
+
+// upsertBatch is like upsertTx, but sends all statements in a single round trip using conn.
+func (s *Store) upsertBatch(ctx context.Context, conn *sql.Conn, n *Alert) (res upsertResult, err error) {
+	var found bool
+	scanRow := func(row pgx.Row, dest ...interface{}) error {
+		err := row.Scan(dest...)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		found = err == nil
+		return err
+	}
+
+	// n.Status is overwritten with the existing alert's status for duplicates
+	status := n.Status
+
+	var b pgx.Batch
+	b.Queue(lockSvcQuery, n.ServiceID)
+	switch status {
+	case StatusTriggered:
+		var m alertlog.CreatedMetaData
+		b.Queue(createUpdNewQuery, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey(), n.Severity, requestID(ctx)).QueryRow(func(row pgx.Row) error {
+			return scanRow(row, &n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.Severity, &n.CreatedAt, &res.inserted)
+		})
+		// only needed for new alerts, but it's cheaper to always fetch than to make another round trip
+		b.Queue(noStepsBySvcQuery, n.ServiceID).QueryRow(func(row pgx.Row) error {
+			return row.Scan(&m.EPNoSteps)
+		})
+		res.meta = &m
+	case StatusActive:
+		var oldStatus Status
+		b.Queue(createUpdAckQuery, n.ServiceID, n.DedupKey()).QueryRow(func(row pgx.Row) error {
+			err := scanRow(row, &n.ID, &n.Summary, &n.Details, &oldStatus, &n.CreatedAt)
+			if found && oldStatus != n.Status {
+				res.logType = alertlog.TypeAcknowledged
+			}
+			return err
+		})
+	case StatusClosed:
+		b.Queue(createUpdCloseQuery, n.ServiceID, n.DedupKey()).QueryRow(func(row pgx.Row) error {
+			res.logType = alertlog.TypeClosed
+			return scanRow(row, &n.ID, &n.Summary, &n.Details, &n.CreatedAt)
+		})
+	}
+
+	err = sqlutil.SendBatch(ctx, conn, &b)
+	if err != nil {
+		return res, err
+	}
+	if !found {
+		return res, sql.ErrNoRows
+	}
+	if status == StatusTriggered {
+		if res.inserted {
+			res.logType = alertlog.TypeCreated
+		} else {
+			res.logType = alertlog.TypeDuplicateSupressed
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package alert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/util/sqlutil/sqlbench"
+)
+
+func BenchmarkStore_CreateOrUpdate(b *testing.B) {
+	db, rt := sqlbench.DB(b)
+	ctx := permission.SystemContext(context.Background(), "Benchmark")
+
+	epID, svcID := uuid.NewString(), uuid.NewString()
+	_, err := db.ExecContext(ctx, `insert into escalation_policies (id, name) values ($1, $1)`, epID)
+	require.NoError(b, err)
+	_, err = db.ExecContext(ctx, `insert into services (id, name, escalation_policy_id) values ($1, $1, $2)`, svcID, epID)
+	require.NoError(b, err)
+	b.Cleanup(func() {
+		_, _ = db.Exec(`delete from services where id = $1`, svcID)
+		_, _ = db.Exec(`delete from escalation_policies where id = $1`, epID)
+	})
+
+	logStore, err := alertlog.NewStore(ctx, db)
+	require.NoError(b, err)
+	s, err := NewStore(ctx, db, logStore)
+	require.NoError(b, err)
+
+	a := Alert{
+		ServiceID: svcID,
+		Summary:   "benchmark",
+		Status:    StatusTriggered,
+		Dedup:     NewUserDedup("benchmark"),
+	}
+	run := func(b *testing.B, fn func() error) {
+		require.NoError(b, fn()) // warm up (create, and prepare statements)
+
+		rt.Reset()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, fn())
+		}
+		b.ReportMetric(float64(rt.RoundTrips())/float64(b.N), "roundtrips/op")
+	}
+
+	b.Run("Batch", func(b *testing.B) {
+		run(b, func() error {
+			_, _, err := s.CreateOrUpdate(ctx, &a)
+			return err
+		})
+	})
+
+	// baseline: same operation using individual statements
+	b.Run("Tx", func(b *testing.B) {
+		run(b, func() error {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			defer sqlutil.Rollback(ctx, "benchmark", tx)
+
+			_, _, err = s.CreateOrUpdateTx(ctx, tx, &a)
+			if err != nil {
+				return err
+			}
+			return tx.Commit()
+		})
+	})
+}
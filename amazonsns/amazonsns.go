@@ -0,0 +1,208 @@
+package amazonsns
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// snsMessage is the envelope for all messages delivered by an SNS HTTPS subscription.
+type snsMessage struct {
+	Type         string
+	MessageId    string
+	TopicArn     string
+	Subject      string
+	Message      string
+	SubscribeURL string
+}
+
+// cloudWatchAlarm is the message body published by CloudWatch alarm state changes.
+type cloudWatchAlarm struct {
+	AlarmName        string
+	AlarmDescription string
+	AWSAccountId     string
+	Region           string
+	AlarmArn         string
+	NewStateValue    string
+	NewStateReason   string
+	OldStateValue    string
+}
+
+var subscribeHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// validSubscribeURL ensures we only confirm subscriptions against SNS itself.
+func validSubscribeURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "https" && subscribeHost.MatchString(u.Hostname())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func (a cloudWatchAlarm) details() string {
+	var s strings.Builder
+	if a.AlarmDescription != "" {
+		s.WriteString(a.AlarmDescription + "\n\n")
+	}
+	fmt.Fprintf(&s, "State: %s (was %s)\n\n", a.NewStateValue, a.OldStateValue)
+	if a.NewStateReason != "" {
+		fmt.Fprintf(&s, "Reason: %s\n\n", a.NewStateReason)
+	}
+	if a.Region != "" {
+		fmt.Fprintf(&s, "Region: %s\n\n", a.Region)
+	}
+	if a.AWSAccountId != "" {
+		fmt.Fprintf(&s, "Account: %s\n\n", a.AWSAccountId)
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// alertFromNotification will return the alert for an SNS notification, or nil if it should be ignored.
+func alertFromNotification(serviceID string, msg snsMessage) (*alert.Alert, error) {
+	var alarm cloudWatchAlarm
+	if json.Unmarshal([]byte(msg.Message), &alarm) != nil || alarm.AlarmName == "" || alarm.NewStateValue == "" {
+		// not a CloudWatch alarm, create an alert from the raw notification
+		summary := msg.Subject
+		if summary == "" {
+			summary = "SNS notification from " + msg.TopicArn
+		}
+		return &alert.Alert{
+			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(msg.Message, alert.MaxDetailsLength),
+			Status:    alert.StatusTriggered,
+			Source:    alert.SourceAmazonSNS,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(msg.MessageId),
+		}, nil
+	}
+
+	var status alert.Status
+	switch alarm.NewStateValue {
+	case "ALARM":
+		status = alert.StatusTriggered
+	case "OK":
+		status = alert.StatusClosed
+	case "INSUFFICIENT_DATA":
+		return nil, nil
+	default:
+		return nil, errors.Errorf("amazon sns: unknown alarm state: %s", alarm.NewStateValue)
+	}
+
+	dedup := alarm.AlarmArn
+	if dedup == "" {
+		dedup = alarm.AlarmName
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(alarm.AlarmName, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(alarm.details(), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceAmazonSNS,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+	}, nil
+}
+
+func AmazonSNSToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		// SNS sends JSON with a text/plain content type
+		var msg snsMessage
+		err = json.NewDecoder(r.Body).Decode(&msg)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from amazon sns: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"MessageType": msg.Type,
+			"TopicArn":    msg.TopicArn,
+		})
+
+		switch msg.Type {
+		case "SubscriptionConfirmation":
+			if !validSubscribeURL(msg.SubscribeURL) {
+				log.Logf(ctx, "bad request from amazon sns: invalid SubscribeURL")
+				http.Error(w, "invalid SubscribeURL", http.StatusBadRequest)
+				return
+			}
+
+			req, err := http.NewRequestWithContext(ctx, "GET", msg.SubscribeURL, nil)
+			if errutil.HTTPError(ctx, w, err) {
+				return
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if errutil.HTTPError(ctx, w, errors.Wrap(err, "confirm sns subscription")) {
+				return
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				errutil.HTTPError(ctx, w, errors.Errorf("confirm sns subscription: unexpected status %s", resp.Status))
+				return
+			}
+			log.Logf(ctx, "confirmed amazon sns subscription")
+			return
+		case "UnsubscribeConfirmation":
+			return
+		case "Notification":
+		default:
+			log.Logf(ctx, "bad request from amazon sns: unknown message type")
+			http.Error(w, "unknown message type", http.StatusBadRequest)
+			return
+		}
+
+		a, err := alertFromNotification(serviceID, msg)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from amazon sns: %v", err)
+			return
+		}
+		if a == nil {
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, a)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for amazon sns")) {
+			return
+		}
+	}
+}
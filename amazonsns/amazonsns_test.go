@@ -0,0 +1,15 @@
+package amazonsns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSubscribeURL(t *testing.T) {
+	assert.True(t, validSubscribeURL("https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=abc"))
+	assert.True(t, validSubscribeURL("https://sns.cn-north-1.amazonaws.com.cn/?Action=ConfirmSubscription"))
+	assert.False(t, validSubscribeURL("http://sns.us-east-1.amazonaws.com/"))
+	assert.False(t, validSubscribeURL("https://sns.us-east-1.amazonaws.com.evil.example/"))
+	assert.False(t, validSubscribeURL("https://169.254.169.254/latest/meta-data"))
+}
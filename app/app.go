@@ -12,6 +12,7 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/archive"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/auth"
@@ -21,33 +22,55 @@ import (
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine"
+	"github.com/target/goalert/engine/eventstream"
+	"github.com/target/goalert/engine/jobqueue"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/kafkaingest"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/mqttsub"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/i18n"
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
+	"github.com/target/goalert/pdimport"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/requesttrace"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/servicenow"
+	"github.com/target/goalert/slo"
 	"github.com/target/goalert/smtpsrv"
+	"github.com/target/goalert/snmptrap"
+	"github.com/target/goalert/statuscallback"
+	"github.com/target/goalert/statuspage"
+	"github.com/target/goalert/svctemplate"
+	"github.com/target/goalert/syslogsrv"
+	"github.com/target/goalert/team"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/delegation"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
+	"github.com/target/goalert/userimport"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqldrv"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/version"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 )
@@ -62,19 +85,36 @@ type App struct {
 	l      net.Listener
 	events *sqlutil.Listener
 
+	// replicaDB is a read-only replica for heavy queries, if configured.
+	replicaDB *sql.DB
+
 	doneCh chan struct{}
 
 	sysAPIL   net.Listener
 	sysAPISrv *grpc.Server
 	hSrv      *health.Server
 
+	drain  drainState
+	dbPool dbPoolState
+	flags  flagState
+
 	srv        *http.Server
 	smtpsrv    *smtpsrv.Server
 	smtpsrvL   net.Listener
 	startupErr error
 
+	snmpTrapSrv  *snmptrap.Server
+	snmpTrapConn net.PacketConn
+
+	syslogSrv *syslogsrv.Server
+	syslogL   []net.Listener
+
+	kafkaConsumer *kafkaingest.Consumer
+	mqttSub       *mqttsub.Subscriber
+
 	notificationManager *notification.Manager
 	Engine              *engine.Engine
+	engineEvents        *eventstream.Broker
 	graphql2            *graphqlapp.App
 	AuthHandler         *auth.Handler
 
@@ -84,21 +124,35 @@ type App struct {
 
 	slackChan *slack.ChannelSender
 
+	smtpSender *email.Sender
+
 	ConfigStore *config.Store
 
 	AlertStore        *alert.Store
 	AlertLogStore     *alertlog.Store
 	AlertMetricsStore *alertmetrics.Store
+	AlertArchiveStore *archive.Store
+
+	JobStore *jobqueue.Store
 
 	AuthBasicStore        *basic.Store
 	UserStore             *user.Store
 	ContactMethodStore    *contactmethod.Store
 	NotificationRuleStore *notificationrule.Store
 	FavoriteStore         *favorite.Store
+	UnavailabilityStore   *unavailability.Store
+	DelegationStore       *delegation.Store
+	TeamStore             *team.Store
 
 	ServiceStore        *service.Store
 	EscalationStore     *escalation.Store
 	IntegrationKeyStore *integrationkey.Store
+	JiraStore           *jira.Store
+	ServiceNowStore     *servicenow.Store
+	StatuspageStore     *statuspage.Store
+	StatusCallbackStore *statuscallback.Store
+	SLOStore            *slo.Store
+	RequestTraceStore   *requesttrace.Store
 	ScheduleRuleStore   *rule.Store
 	NotificationStore   *notification.Store
 	ScheduleStore       *schedule.Store
@@ -122,6 +176,11 @@ type App struct {
 	NoticeStore   *notice.Store
 	AuthLinkStore *authlink.Store
 	APIKeyStore   *apikey.Store
+
+	PDImporter   *pdimport.Importer
+	UserImporter *userimport.Importer
+
+	ServiceTemplateStore *svctemplate.Store
 }
 
 // NewApp constructs a new App and binds the listening socket.
@@ -150,6 +209,13 @@ func NewApp(c Config, db *sql.DB) (*App, error) {
 		return nil, err
 	}
 
+	if c.TranslationsDir != "" {
+		err = i18n.LoadDir(c.TranslationsDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "load notification translations")
+		}
+	}
+
 	l, err := net.Listen("tcp", c.ListenAddr)
 	if err != nil {
 		return nil, errors.Wrapf(err, "bind address %s", c.ListenAddr)
@@ -177,6 +243,7 @@ func NewApp(c Config, db *sql.DB) (*App, error) {
 		cfg:    c,
 		doneCh: make(chan struct{}),
 	}
+	app.flags.set(c)
 
 	if c.StatusAddr != "" {
 		err = listenStatus(c.StatusAddr, app.doneCh)
@@ -188,6 +255,16 @@ func NewApp(c Config, db *sql.DB) (*App, error) {
 	app.db.SetMaxIdleConns(c.DBMaxIdle)
 	app.db.SetMaxOpenConns(c.DBMaxOpen)
 
+	if c.DBURLReadReplica != "" {
+		app.replicaDB, err = sqldrv.NewDB(c.DBURLReadReplica, fmt.Sprintf("GoAlert %s (read replica)", version.GitVersion()))
+		if err != nil {
+			return nil, errors.Wrap(err, "connect to read replica")
+		}
+		app.replicaDB.SetMaxIdleConns(c.DBMaxIdle)
+		app.replicaDB.SetMaxOpenConns(c.DBMaxOpen)
+	}
+	app.registerDBStats()
+
 	app.mgr = lifecycle.NewManager(app._Run, app._Shutdown)
 	err = app.mgr.SetStartupFunc(app.startup)
 	if err != nil {
@@ -205,6 +282,15 @@ func (a *App) WaitForStartup(ctx context.Context) error {
 // DB returns the sql.DB instance used by the application.
 func (a *App) DB() *sql.DB { return a.db }
 
+// readDB returns the read replica, if configured, or the primary DB.
+func (a *App) readDB() *sql.DB {
+	if a.replicaDB != nil {
+		return a.replicaDB
+	}
+
+	return a.db
+}
+
 // URL returns the non-TLS listener URL of the application.
 func (a *App) URL() string {
 	return "http://" + a.l.Addr().String()
@@ -217,3 +303,12 @@ func (a *App) SMTPAddr() string {
 
 	return a.smtpsrvL.Addr().String()
 }
+
+// SNMPTrapAddr returns the address of the SNMP trap listener, if enabled.
+func (a *App) SNMPTrapAddr() string {
+	if a.snmpTrapConn == nil {
+		return ""
+	}
+
+	return a.snmpTrapConn.LocalAddr().String()
+}
@@ -122,6 +122,14 @@ Available Flags:
 				log.Logf(ctx, "Applied %d migrations in %s.", n, time.Since(s))
 			}
 
+			// backfills for online migrations run in batches, while the app is in use
+			go func() {
+				err := migrate.Backfill(ctx, url)
+				if err != nil {
+					log.Log(ctx, errors.Wrap(err, "backfill online migrations"))
+				}
+			}()
+
 			return nil
 		}
 
@@ -137,7 +145,13 @@ Available Flags:
 				return errors.Wrap(err, "nextdb")
 			}
 
-			mgr, err := swo.NewManager(swo.Config{OldDBURL: cfg.DBURL, NewDBURL: cfg.DBURLNext, CanExec: !cfg.APIOnly, Logger: cfg.Logger})
+			mgr, err := swo.NewManager(swo.Config{
+				OldDBURL:          cfg.DBURL,
+				NewDBURL:          cfg.DBURLNext,
+				CanExec:           !cfg.APIOnly,
+				Logger:            cfg.Logger,
+				LogicalSubscriber: cfg.DBNextLogicalSubscriber,
+			})
 			if err != nil {
 				return errors.Wrap(err, "init switchover handler")
 			}
@@ -150,6 +164,12 @@ Available Flags:
 			}
 		}
 
+		flushTraces, err := initTracing(ctx, cfg)
+		if err != nil {
+			return errors.Wrap(err, "init tracing")
+		}
+		defer flushTraces()
+
 		app, err := NewApp(cfg, db)
 		if err != nil {
 			return errors.Wrap(err, "init app")
@@ -166,6 +186,34 @@ Available Flags:
 			}
 		}()
 
+		// drain in preparation for shutdown by process signal
+		drainCh := make(chan os.Signal, 1)
+		signal.Notify(drainCh, drainSignals...)
+		go func() {
+			for range drainCh {
+				app.StartDrain()
+			}
+		}()
+
+		// re-read the config file (--config-file) and apply runtime-changeable settings by process signal
+		reloadCh := make(chan os.Signal, 1)
+		signal.Notify(reloadCh, reloadSignals...)
+		go func() {
+			for range reloadCh {
+				err := viper.ReadInConfig()
+				if err != nil && !isCfgNotFound(err) {
+					log.Log(ctx, errors.Wrap(err, "reload config file"))
+					continue
+				}
+				newCfg, err := getConfig(ctx)
+				if err != nil {
+					log.Log(ctx, errors.Wrap(err, "reload config"))
+					continue
+				}
+				app.ReloadFlags(ctx, newCfg)
+			}
+		}()
+
 		return errors.Wrap(app.Run(ctx), "run app")
 	},
 }
@@ -449,6 +497,11 @@ Migration: %s (#%d)
 				if n > 0 {
 					log.Debugf(ctx, "Applied %d UP migrations.", n)
 				}
+
+				err = migrate.Backfill(ctx, c.DBURL)
+				if err != nil {
+					return errors.Wrap(err, "backfill online migrations")
+				}
 			}
 
 			return nil
@@ -629,6 +682,28 @@ func getConfig(ctx context.Context) (Config, error) {
 		SMTPListenAddrTLS:     viper.GetString("smtp-listen-tls"),
 		SMTPAdditionalDomains: viper.GetString("smtp-additional-domains"),
 		SMTPMaxRecipients:     viper.GetInt("smtp-max-recipients"),
+		SNMPTrapListenAddr:    viper.GetString("snmp-trap-listen"),
+		SNMPv3AuthProtocol:    viper.GetString("snmp-v3-auth-protocol"),
+		SNMPv3AuthPassword:    viper.GetString("snmp-v3-auth-password"),
+		SNMPv3PrivProtocol:    viper.GetString("snmp-v3-priv-protocol"),
+		SNMPv3PrivPassword:    viper.GetString("snmp-v3-priv-password"),
+		SyslogListenAddr:      viper.GetString("syslog-listen"),
+		SyslogListenAddrTLS:   viper.GetString("syslog-listen-tls"),
+
+		KafkaBrokers:       viper.GetString("kafka-brokers"),
+		KafkaTopic:         viper.GetString("kafka-topic"),
+		KafkaGroupID:       viper.GetString("kafka-group-id"),
+		KafkaTLS:           viper.GetBool("kafka-tls"),
+		KafkaTLSCAFile:     viper.GetString("kafka-tls-ca-file"),
+		KafkaSASLMechanism: viper.GetString("kafka-sasl-mechanism"),
+		KafkaSASLUsername:  viper.GetString("kafka-sasl-username"),
+		KafkaSASLPassword:  viper.GetString("kafka-sasl-password"),
+
+		MQTTBroker:    viper.GetString("mqtt-broker"),
+		MQTTClientID:  viper.GetString("mqtt-client-id"),
+		MQTTUsername:  viper.GetString("mqtt-username"),
+		MQTTPassword:  viper.GetString("mqtt-password"),
+		MQTTTLSCAFile: viper.GetString("mqtt-tls-ca-file"),
 
 		EmailIntegrationDomain: viper.GetString("email-integration-domain"),
 
@@ -639,11 +714,21 @@ func getConfig(ctx context.Context) (Config, error) {
 		SlackBaseURL:  viper.GetString("slack-base-url"),
 		TwilioBaseURL: viper.GetString("twilio-base-url"),
 
-		DBURL:     viper.GetString("db-url"),
-		DBURLNext: viper.GetString("db-url-next"),
+		DBURL:            viper.GetString("db-url"),
+		DBURLNext:        viper.GetString("db-url-next"),
+		DBURLReadReplica: viper.GetString("db-url-read-replica"),
+
+		DBNextLogicalSubscriber: viper.GetBool("db-next-logical-subscriber"),
+
+		AlertArchiveDir:   viper.GetString("alert-archive-dir"),
+		AlertArchiveS3URL: viper.GetString("alert-archive-s3-url"),
+		TranslationsDir:   viper.GetString("translations-dir"),
 
 		StatusAddr: viper.GetString("status-addr"),
 
+		OTLPEndpoint:       viper.GetString("otlp-endpoint"),
+		TracingProbability: viper.GetFloat64("tracing-probability"),
+
 		EncryptionKeys: keyring.Keys{[]byte(viper.GetString("data-encryption-key")), []byte(viper.GetString("data-encryption-key-old"))},
 
 		RegionName: viper.GetString("region-name"),
@@ -682,6 +767,15 @@ func getConfig(ctx context.Context) (Config, error) {
 	if cfg.DBURL == "" {
 		return cfg, ErrDBRequired
 	}
+	if cfg.DBNextLogicalSubscriber && cfg.DBURLNext == "" {
+		return cfg, errors.New("db-next-logical-subscriber requires db-url-next")
+	}
+	if cfg.DBURLReadReplica != "" && cfg.DBURLNext != "" {
+		return cfg, errors.New("db-url-read-replica and db-url-next cannot be used together")
+	}
+	if cfg.AlertArchiveDir != "" && cfg.AlertArchiveS3URL != "" {
+		return cfg, errors.New("alert-archive-dir and alert-archive-s3-url cannot be used together")
+	}
 
 	var err error
 	cfg.TLSConfig, err = getTLSConfig("")
@@ -703,6 +797,23 @@ func getConfig(ctx context.Context) (Config, error) {
 		return cfg, err
 	}
 
+	cfg.TLSConfigSyslog, err = getTLSConfig("syslog-")
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.SyslogListenAddrTLS != "" && cfg.TLSConfigSyslog == nil {
+		return cfg, errors.New("syslog-tls-cert-file and syslog-tls-key-file OR syslog-tls-cert-data and syslog-tls-key-data are required when syslog-listen-tls is set")
+	}
+
+	err = cfg.snmpUSM().Validate()
+	if err != nil {
+		return cfg, fmt.Errorf("invalid SNMPv3 credentials: %w", err)
+	}
+
+	if cfg.KafkaBrokers != "" && cfg.KafkaTopic == "" {
+		return cfg, errors.New("kafka-topic is required when kafka-brokers is set")
+	}
+
 	if viper.GetBool("stack-traces") {
 		log.FromContext(ctx).EnableStacks()
 	}
@@ -746,15 +857,48 @@ func init() {
 	RootCmd.Flags().Int("smtp-max-recipients", def.SMTPMaxRecipients, "Specifies the maximum number of recipients allowed per message.")
 	RootCmd.Flags().String("smtp-additional-domains", "", "Specifies additional destination domains that are allowed for the SMTP server.  For multiple domains, separate them with a comma, e.g., \"domain1.com,domain2.org,domain3.net\".")
 
+	RootCmd.Flags().String("syslog-listen", "", "Listen address:port (TCP) for an internal RFC 5424 syslog receiver.")
+	RootCmd.Flags().String("syslog-listen-tls", "", "Syslog over TLS listen address:port.  Requires setting --syslog-tls-cert-data and --syslog-tls-key-data OR --syslog-tls-cert-file and --syslog-tls-key-file.")
+	RootCmd.Flags().String("syslog-tls-cert-file", "", "Specifies a path to a PEM-encoded certificate.  Has no effect if --syslog-listen-tls is unset.")
+	RootCmd.Flags().String("syslog-tls-key-file", "", "Specifies a path to a PEM-encoded private key file.  Has no effect if --syslog-listen-tls is unset.")
+	RootCmd.Flags().String("syslog-tls-cert-data", "", "Specifies a PEM-encoded certificate.  Has no effect if --syslog-listen-tls is unset.")
+	RootCmd.Flags().String("syslog-tls-key-data", "", "Specifies a PEM-encoded private key.  Has no effect if --syslog-listen-tls is unset.")
+
+	RootCmd.Flags().String("kafka-brokers", "", "Comma-separated list of Kafka broker addresses (host:port). Enables consuming alert events from --kafka-topic.")
+	RootCmd.Flags().String("kafka-topic", "", "Kafka topic to consume alert events from.")
+	RootCmd.Flags().String("kafka-group-id", def.KafkaGroupID, "Kafka consumer group ID. All instances should use the same value.")
+	RootCmd.Flags().Bool("kafka-tls", false, "Use TLS when connecting to Kafka brokers.")
+	RootCmd.Flags().String("kafka-tls-ca-file", "", "Specifies a path to PEM-encoded CA certificate(s) used to verify Kafka brokers.  Implies --kafka-tls.")
+	RootCmd.Flags().String("kafka-sasl-mechanism", "", "SASL mechanism for Kafka authentication: plain, scram-sha-256, or scram-sha-512.")
+	RootCmd.Flags().String("kafka-sasl-username", "", "Username for Kafka SASL authentication.")
+	RootCmd.Flags().String("kafka-sasl-password", "", "Password for Kafka SASL authentication.")
+
+	RootCmd.Flags().String("mqtt-broker", "", "MQTT broker URL (e.g., tcp://localhost:1883 or tls://localhost:8883). Enables subscribing to topics used by MQTT integration key rules.")
+	RootCmd.Flags().String("mqtt-client-id", "", "MQTT client ID. Must be unique per instance; if unset, a random ID is generated.")
+	RootCmd.Flags().String("mqtt-username", "", "Username for MQTT broker authentication.")
+	RootCmd.Flags().String("mqtt-password", "", "Password for MQTT broker authentication.")
+	RootCmd.Flags().String("mqtt-tls-ca-file", "", "Specifies a path to PEM-encoded CA certificate(s) used to verify the MQTT broker.")
+
+	RootCmd.Flags().String("snmp-trap-listen", "", "Listen address:port (UDP) for an internal SNMP trap receiver. The community string (or SNMPv3 user name) must be an SNMP integration key.")
+	RootCmd.Flags().String("snmp-v3-auth-protocol", "", "SNMPv3 authentication protocol (MD5 or SHA). If set, unauthenticated SNMPv3 traps are rejected.")
+	RootCmd.Flags().String("snmp-v3-auth-password", "", "SNMPv3 authentication password (at least 8 characters).")
+	RootCmd.Flags().String("snmp-v3-priv-protocol", "", "SNMPv3 privacy protocol (DES or AES). If set, unencrypted SNMPv3 traps are rejected. Requires --snmp-v3-auth-protocol.")
+	RootCmd.Flags().String("snmp-v3-priv-password", "", "SNMPv3 privacy password (at least 8 characters).")
+
 	RootCmd.Flags().Duration("engine-cycle-time", def.EngineCycleTime, "Time between engine cycles.")
 
 	RootCmd.Flags().String("http-prefix", def.HTTPPrefix, "Specify the HTTP prefix of the application.")
 	_ = RootCmd.Flags().MarkDeprecated("http-prefix", "use --public-url instead")
 
+	RootCmd.Flags().String("alert-archive-dir", def.AlertArchiveDir, "Directory to store archived alerts in, enables Maintenance.AlertArchiveDays. Must be shared by all instances.")
+	RootCmd.Flags().String("alert-archive-s3-url", def.AlertArchiveS3URL, "S3 bucket to store archived alerts in (e.g., s3://bucket/prefix), enables Maintenance.AlertArchiveDays. Optional region and endpoint query parameters are supported.")
+	RootCmd.Flags().String("translations-dir", def.TranslationsDir, "Directory of <locale>.json notification message catalogs. Adds new languages or replaces built-in translations.")
+
 	RootCmd.Flags().Bool("api-only", def.APIOnly, "Starts in API-only mode (schedules & notifications will not be processed). Useful in clusters.")
 
 	RootCmd.Flags().Int("db-max-open", def.DBMaxOpen, "Max open DB connections.")
 	RootCmd.Flags().Int("db-max-idle", def.DBMaxIdle, "Max idle DB connections.")
+	RootCmd.Flags().String("db-url-read-replica", def.DBURLReadReplica, "Connection string for a read-only Postgres replica, used for alert search, message logs, and alert metrics.")
 
 	RootCmd.Flags().Int64("max-request-body-bytes", def.MaxReqBodyBytes, "Max body size for all incoming requests (in bytes). Set to 0 to disable limit.")
 	RootCmd.Flags().Int("max-request-header-bytes", def.MaxReqHeaderBytes, "Max header size for all incoming requests (in bytes). Set to 0 to disable limit.")
@@ -769,6 +913,7 @@ func init() {
 
 	RootCmd.PersistentFlags().String("db-url", def.DBURL, "Connection string for Postgres.")
 	RootCmd.PersistentFlags().String("db-url-next", def.DBURLNext, "Connection string for the *next* Postgres server (enables DB switchover mode).")
+	RootCmd.PersistentFlags().Bool("db-next-logical-subscriber", def.DBNextLogicalSubscriber, "Indicates the *next* Postgres server is a logical replication subscriber of the current one; switchover will wait for it to catch up instead of copying data.")
 
 	RootCmd.Flags().String("jaeger-endpoint", "", "Jaeger HTTP Thrift endpoint")
 	RootCmd.Flags().String("jaeger-agent-endpoint", "", "Instructs Jaeger exporter to send spans to jaeger-agent at this address.")
@@ -786,8 +931,8 @@ func init() {
 	_ = RootCmd.Flags().MarkDeprecated("tracing-container-name", "Tracing support has been removed.")
 	RootCmd.Flags().String("tracing-node-name", "", "Node name to use for tracing.")
 	_ = RootCmd.Flags().MarkDeprecated("tracing-node-name", "Tracing support has been removed.")
-	RootCmd.Flags().Float64("tracing-probability", 0, "Probability of a new trace to be recorded.")
-	_ = RootCmd.Flags().MarkDeprecated("tracing-probability", "Tracing support has been removed.")
+	RootCmd.Flags().String("otlp-endpoint", def.OTLPEndpoint, "OTLP/HTTP endpoint (e.g., http://localhost:4318) to export traces to. Standard OTEL_EXPORTER_OTLP_* environment variables (e.g., for headers) are also supported.")
+	RootCmd.Flags().Float64("tracing-probability", def.TracingProbability, "Probability of a new trace to be recorded (requires --otlp-endpoint). Traces continued from an inbound request follow the sampling decision of the caller.")
 
 	RootCmd.Flags().Duration("kubernetes-cooldown", 0, "Cooldown period, from the last TCP connection, before terminating the listener when receiving a shutdown signal.")
 	_ = RootCmd.Flags().MarkDeprecated("kubernetes-cooldown", "Use lifecycle hooks (preStop) instead.")
@@ -796,6 +941,7 @@ func init() {
 	RootCmd.PersistentFlags().String("data-encryption-key", "", "Used to generate an encryption key for sensitive data like signing keys. Can be any length. Only use this when performing a switchover.")
 	RootCmd.PersistentFlags().String("data-encryption-key-old", "", "Fallback key. Used for decrypting existing data only. Only necessary when changing --data-encryption-key.")
 	RootCmd.PersistentFlags().Bool("stack-traces", false, "Enables stack traces with all error logs.")
+	RootCmd.PersistentFlags().String("config-file", "", "Read settings from a YAML, JSON, or TOML file, keyed by flag name (e.g., log-requests: true). Flags and environment variables take precedence. The file is re-read on SIGHUP.")
 
 	RootCmd.Flags().Bool("stub-notifiers", def.StubNotifiers, "If true, notification senders will be replaced with a stub notifier that always succeeds (useful for staging/sandbox environments).")
 
@@ -826,7 +972,16 @@ func init() {
 
 	monitorCmd.Flags().StringP("config-file", "f", "", "Configuration file for monitoring (required).")
 	initCertCommands()
-	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, addUserCmd, getConfigCmd, setConfigCmd, genCerts)
+	initImportCommands()
+	initDebugBundleCommands()
+	initAdminCommands()
+	initDevCommands()
+	initDoctorCommands()
+	initMigrateCommands()
+	initBackupCommands()
+	initScrubCommands()
+	initConfigDriftCommands()
+	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, addUserCmd, getConfigCmd, setConfigCmd, genCerts, importPagerDutyCmd, debugBundleCmd, adminCmd, devCmd, doctorCmd, backupCmd, restoreCmd, scrubCmd, configCmd)
 
 	err := viper.BindPFlags(RootCmd.Flags())
 	if err != nil {
@@ -852,6 +1007,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	err = viper.BindPFlags(adminCmd.PersistentFlags())
+	if err != nil {
+		panic(err)
+	}
 	err = viper.BindPFlags(RootCmd.PersistentFlags())
 	if err != nil {
 		panic(err)
@@ -863,4 +1022,10 @@ func init() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
 	viper.AutomaticEnv()
+
+	cobra.OnInitialize(func() {
+		if file := viper.GetString("config-file"); file != "" {
+			viper.SetConfigFile(file)
+		}
+	})
 }
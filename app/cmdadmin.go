@@ -0,0 +1,310 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/admincli"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Manage resources through the GraphQL API using an API key.",
+	Long: `Manage resources through the GraphQL API using an API key.
+
+The API key (Admin > API Keys) must allow each field used by a command, and rotate-keys
+requires an admin role key. Run "goalert admin fields" to list the fields for each command.`,
+}
+
+func newAdminClient() *admincli.Client {
+	return &admincli.Client{
+		URL:    viper.GetString("api-url"),
+		APIKey: viper.GetString("api-key"),
+	}
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+var adminFieldsCmd = &cobra.Command{
+	Use:   "fields [command...]",
+	Short: "List the GraphQL fields an API key must allow for each admin command.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			for name := range admincli.Fields {
+				args = append(args, name)
+			}
+			sort.Strings(args)
+		}
+		for _, name := range args {
+			fields, ok := admincli.Fields[name]
+			if !ok {
+				return errors.Errorf("unknown command '%s'", name)
+			}
+			fmt.Printf("%s:\n  %s\n", name, strings.Join(fields, "\n  "))
+		}
+		return nil
+	},
+}
+
+var adminCreateServiceCmd = &cobra.Command{
+	Use:   "create-service <name>",
+	Short: "Create a new service.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		desc, _ := cmd.Flags().GetString("description")
+		epID, _ := cmd.Flags().GetString("escalation-policy-id")
+
+		svc, err := newAdminClient().CreateService(cmd.Context(), args[0], desc, epID)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(svc)
+	},
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func parseWeekdays(s string) (f [7]bool, err error) {
+	for _, day := range strings.Split(s, ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+		if day == "" {
+			continue
+		}
+
+		var found bool
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(day, name) {
+				f[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return f, errors.Errorf("invalid weekday '%s'", day)
+		}
+	}
+
+	return f, nil
+}
+
+var adminAddScheduleRuleCmd = &cobra.Command{
+	Use:   "add-schedule-rule",
+	Short: "Add an on-call rule for a user or rotation on a schedule.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedID, _ := cmd.Flags().GetString("schedule-id")
+		userID, _ := cmd.Flags().GetString("user-id")
+		rotID, _ := cmd.Flags().GetString("rotation-id")
+		if schedID == "" {
+			return errors.New("--schedule-id is required")
+		}
+
+		var tgt admincli.Target
+		switch {
+		case userID != "" && rotID != "":
+			return errors.New("only one of --user-id or --rotation-id may be specified")
+		case userID != "":
+			tgt = admincli.Target{Type: "user", ID: userID}
+		case rotID != "":
+			tgt = admincli.Target{Type: "rotation", ID: rotID}
+		default:
+			return errors.New("one of --user-id or --rotation-id is required")
+		}
+
+		var rule admincli.ScheduleRule
+		rule.Start, _ = cmd.Flags().GetString("start")
+		rule.End, _ = cmd.Flags().GetString("end")
+		days, _ := cmd.Flags().GetString("days")
+		var err error
+		rule.WeekdayFilter, err = parseWeekdays(days)
+		if err != nil {
+			return err
+		}
+
+		return newAdminClient().AddScheduleRule(cmd.Context(), schedID, tgt, rule)
+	},
+}
+
+var adminListAlertsCmd = &cobra.Command{
+	Use:   "list-alerts",
+	Short: "List alerts as JSON.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts admincli.AlertOptions
+		opts.Search, _ = cmd.Flags().GetString("search")
+		opts.ServiceIDs, _ = cmd.Flags().GetStringSlice("service-id")
+		opts.Limit, _ = cmd.Flags().GetInt("limit")
+
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		for _, s := range statuses {
+			switch strings.ToLower(s) {
+			case "unacknowledged", "triggered":
+				opts.Status = append(opts.Status, "StatusUnacknowledged")
+			case "acknowledged", "active":
+				opts.Status = append(opts.Status, "StatusAcknowledged")
+			case "closed":
+				opts.Status = append(opts.Status, "StatusClosed")
+			default:
+				return errors.Errorf("invalid status '%s'", s)
+			}
+		}
+
+		alerts, err := newAdminClient().ListAlerts(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(alerts)
+	},
+}
+
+var adminSetConfigKeyCmd = &cobra.Command{
+	Use:   "set-config-key <id> <value>",
+	Short: "Set a single config value (e.g., General.PublicURL).",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return newAdminClient().SetConfig(cmd.Context(), args[0], args[1])
+	},
+}
+
+var adminRotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys [key-id...]",
+	Short: "Replace API keys with new keys, printing the new tokens as JSON.",
+	Long: `Replace API keys with new keys, printing the new tokens as JSON.
+
+Each new key has the same name, description, role, and allowed fields as the key it replaces.
+Keys can be selected by ID, or with --expiring-within to rotate all keys that expire soon.
+Old keys are renamed, and then deleted after all new keys are created unless --keep-old is set.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		within, _ := cmd.Flags().GetDuration("expiring-within")
+		if len(args) == 0 && within == 0 {
+			return errors.New("key IDs or --expiring-within are required")
+		}
+		validFor, _ := cmd.Flags().GetDuration("valid-for")
+		if validFor <= 0 {
+			return errors.New("--valid-for must be positive")
+		}
+		keepOld, _ := cmd.Flags().GetBool("keep-old")
+
+		c := newAdminClient()
+		keys, err := c.APIKeys(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		var toRotate []admincli.APIKey
+		for _, id := range args {
+			idx := -1
+			for i, k := range keys {
+				if k.ID == id {
+					idx = i
+					break
+				}
+			}
+			if idx == -1 {
+				return errors.Errorf("API key %s not found", id)
+			}
+			toRotate = append(toRotate, keys[idx])
+		}
+		if len(args) == 0 {
+			cutoff := time.Now().Add(within)
+			for _, k := range keys {
+				if k.ExpiresAt.Before(cutoff) {
+					toRotate = append(toRotate, k)
+				}
+			}
+		}
+
+		res, rotErr := c.RotateKeys(cmd.Context(), toRotate, time.Now().Add(validFor), keepOld)
+
+		// always print new tokens, even on error, since they can't be retrieved later
+		err = printJSON(res)
+		if rotErr != nil {
+			return rotErr
+		}
+		return err
+	},
+}
+
+var adminImportUsersCmd = &cobra.Command{
+	Use:   "import-users <file.csv>",
+	Short: "Create users from a CSV file, printing the result of each row as JSON.",
+	Long: `Create users from a CSV file, printing the result of each row as JSON.
+
+The first line must be a header naming the columns: name and email (required), and role
+(user or admin) and phone (E.164 format). Rows with an email matching an existing user (or
+an earlier row) are skipped. Use --dry-run to validate the file without creating anything.
+Use "-" to read from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		sendVerification, _ := cmd.Flags().GetBool("send-verification")
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		res, err := newAdminClient().ImportUsers(cmd.Context(), string(data), dryRun, sendVerification)
+		if err != nil {
+			return err
+		}
+
+		err = printJSON(res)
+		if err != nil {
+			return err
+		}
+		for _, r := range res {
+			if r.Status == "INVALID" {
+				return errors.New("some rows are invalid")
+			}
+		}
+		return nil
+	},
+}
+
+func initAdminCommands() {
+	adminCmd.PersistentFlags().String("api-url", "", "Base URL of the GoAlert instance (e.g., https://goalert.example.com).")
+	adminCmd.PersistentFlags().String("api-key", "", "GraphQL API key token.")
+
+	adminCreateServiceCmd.Flags().String("description", "", "Service description.")
+	adminCreateServiceCmd.Flags().String("escalation-policy-id", "", "Existing escalation policy to assign to the service.")
+
+	adminAddScheduleRuleCmd.Flags().String("schedule-id", "", "Schedule to add the rule to (required).")
+	adminAddScheduleRuleCmd.Flags().String("user-id", "", "User to add the rule for.")
+	adminAddScheduleRuleCmd.Flags().String("rotation-id", "", "Rotation to add the rule for.")
+	adminAddScheduleRuleCmd.Flags().String("start", "00:00", "Start time of the rule (24-hour, schedule time zone).")
+	adminAddScheduleRuleCmd.Flags().String("end", "00:00", "End time of the rule (24-hour, schedule time zone). Equal start and end times mean all day.")
+	adminAddScheduleRuleCmd.Flags().String("days", "sun,mon,tue,wed,thu,fri,sat", "Comma-separated weekdays the rule is active.")
+
+	adminListAlertsCmd.Flags().StringSlice("status", nil, "Only include alerts with the given status (unacknowledged, acknowledged, closed).")
+	adminListAlertsCmd.Flags().StringSlice("service-id", nil, "Only include alerts for the given service IDs.")
+	adminListAlertsCmd.Flags().String("search", "", "Search string for alert summaries.")
+	adminListAlertsCmd.Flags().Int("limit", 15, "Maximum number of alerts to return (1-1000).")
+
+	adminRotateKeysCmd.Flags().Duration("expiring-within", 0, "Rotate all keys expiring within this duration (e.g., 720h).")
+	adminRotateKeysCmd.Flags().Duration("valid-for", 90*24*time.Hour, "How long the new keys are valid for.")
+	adminRotateKeysCmd.Flags().Bool("keep-old", false, "Keep the old keys instead of deleting them.")
+
+	adminImportUsersCmd.Flags().Bool("dry-run", false, "Validate the file without creating any users.")
+	adminImportUsersCmd.Flags().Bool("send-verification", false, "Send a verification code to each new phone number.")
+
+	adminCmd.AddCommand(adminFieldsCmd, adminCreateServiceCmd, adminAddScheduleRuleCmd, adminListAlertsCmd, adminSetConfigKeyCmd, adminRotateKeysCmd, adminImportUsersCmd)
+}
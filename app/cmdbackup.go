@@ -0,0 +1,295 @@
+package app
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"os"
+	"os/exec"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/backup"
+	"github.com/target/goalert/util/log"
+)
+
+// backupPassphraseEnv is the environment variable used for the backup passphrase if no flag is set.
+const backupPassphraseEnv = "GOALERT_BACKUP_PASSPHRASE"
+
+// backupSecret will return the contents of --<name>-file or the output of --<name>-command, or nil if neither is set.
+func backupSecret(cmd *cobra.Command, name string) ([]byte, error) {
+	file, _ := cmd.Flags().GetString(name + "-file")
+	command, _ := cmd.Flags().GetString(name + "-command")
+
+	switch {
+	case file != "" && command != "":
+		return nil, errors.Errorf("only one of --%s-file or --%s-command may be specified", name, name)
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read %s file", name)
+		}
+		return data, nil
+	case command != "":
+		c := exec.CommandContext(cmd.Context(), "sh", "-c", command)
+		c.Stderr = os.Stderr
+		data, err := c.Output()
+		if err != nil {
+			return nil, errors.Wrapf(err, "run %s command", name)
+		}
+		return data, nil
+	}
+
+	return nil, nil
+}
+
+// backupPassphrase will return the passphrase from --passphrase-file, --passphrase-command, or the environment,
+// or nil if none is set.
+func backupPassphrase(cmd *cobra.Command) ([]byte, error) {
+	pass, err := backupSecret(cmd, "passphrase")
+	if err != nil {
+		return nil, err
+	}
+	if pass == nil {
+		pass = []byte(os.Getenv(backupPassphraseEnv))
+	}
+
+	return bytes.TrimRight(pass, "\r\n"), nil
+}
+
+// backupRecipients will return the recipients from --recipient and --recipients-file, or the passphrase
+// if none are set.
+func backupRecipients(cmd *cobra.Command) ([]age.Recipient, error) {
+	keys, _ := cmd.Flags().GetStringArray("recipient")
+	file, _ := cmd.Flags().GetString("recipients-file")
+
+	var recipients []age.Recipient
+	for _, key := range keys {
+		r, err := age.ParseX25519Recipient(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse recipient")
+		}
+		recipients = append(recipients, r)
+	}
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "open recipients file")
+		}
+		defer f.Close()
+		r, err := age.ParseRecipients(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse recipients file")
+		}
+		recipients = append(recipients, r...)
+	}
+
+	pass, err := backupPassphrase(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) > 0 {
+		if len(pass) > 0 {
+			return nil, errors.New("a passphrase cannot be combined with --recipient or --recipients-file")
+		}
+		return recipients, nil
+	}
+	if len(pass) == 0 {
+		return nil, errors.New("a passphrase or recipient is required: use --recipient, --recipients-file, --passphrase-file, --passphrase-command, or set " + backupPassphraseEnv)
+	}
+
+	r, err := backup.PassphraseRecipient(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	return []age.Recipient{r}, nil
+}
+
+// backupIdentities will return the identities from --identity-file or --identity-command, and the passphrase if set.
+func backupIdentities(cmd *cobra.Command) ([]age.Identity, error) {
+	data, err := backupSecret(cmd, "identity")
+	if err != nil {
+		return nil, err
+	}
+	pass, err := backupPassphrase(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil && len(pass) == 0 {
+		return nil, errors.New("a passphrase or identity is required: use --identity-file, --identity-command, --passphrase-file, --passphrase-command, or set " + backupPassphraseEnv)
+	}
+
+	var ids []age.Identity
+	if data != nil {
+		ids, err = age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrap(err, "parse identities")
+		}
+	}
+	if len(pass) > 0 {
+		id, err := backup.PassphraseIdentity(pass)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// backupDB will return a connection to the configured database.
+func backupDB(cmd *cobra.Command) (*sql.DB, error) {
+	l := log.FromContext(cmd.Context())
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return nil, errors.Wrap(err, "read config")
+	}
+
+	c, err := getConfig(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("pgx", c.DBURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "connect to postgres")
+	}
+
+	return db, nil
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Export an encrypted logical backup of the database.",
+	Long: `Export an encrypted logical backup of the database.
+
+All GoAlert data is read from a single consistent snapshot, using only the privileges
+GoAlert itself requires. The backup is compressed and encrypted with age
+(https://age-encryption.org), either to one or more public keys given by --recipient or
+--recipients-file, or with a passphrase provided by --passphrase-file, --passphrase-command
+(e.g., to fetch a secret from a secret manager), or the ` + backupPassphraseEnv + ` environment
+variable.
+
+Encrypted config values are copied as-is, so the same --data-encryption-key must be used
+with the restored database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		recipients, err := backupRecipients(cmd)
+		if err != nil {
+			return err
+		}
+		db, err := backupDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var w io.Writer = os.Stdout
+		fileName, _ := cmd.Flags().GetString("output")
+		var f *os.File
+		if fileName != "-" {
+			f, err = os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return errors.Wrap(err, "create output file")
+			}
+			defer f.Close()
+			w = f
+		}
+
+		excludeClosed, _ := cmd.Flags().GetBool("exclude-closed-alerts")
+		err = backup.Write(cmd.Context(), db, w, backup.Options{
+			Recipients:          recipients,
+			ExcludeClosedAlerts: excludeClosed,
+		})
+		if err != nil {
+			if f != nil {
+				f.Close()
+				_ = os.Remove(fileName)
+			}
+			return err
+		}
+
+		if f != nil {
+			err = f.Close()
+			if err != nil {
+				return errors.Wrap(err, "close output file")
+			}
+			log.Logf(cmd.Context(), "Wrote backup to %s", fileName)
+		}
+
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore an encrypted backup created with the backup command.",
+	Long: `Restore an encrypted backup created with the backup command.
+
+The destination database must be migrated to the same migration as the backup (e.g., with
+"goalert migrate --up <name>", using the same version of GoAlert) and should otherwise be
+empty; use --overwrite to replace existing data. The restore is performed in a single
+transaction, so a failed restore leaves the database unchanged.
+
+The backup is decrypted with the passphrase it was created with, or an age identity (private key)
+from --identity-file or --identity-command (e.g., to decrypt a key wrapped with a KMS).
+
+GoAlert instances should be stopped while restoring.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := backupIdentities(cmd)
+		if err != nil {
+			return err
+		}
+		db, err := backupDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var r io.Reader = os.Stdin
+		fileName, _ := cmd.Flags().GetString("input")
+		if fileName != "-" {
+			f, err := os.Open(fileName)
+			if err != nil {
+				return errors.Wrap(err, "open input file")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+		hdr, err := backup.Restore(cmd.Context(), db, r, backup.RestoreOptions{
+			Identities: ids,
+			Overwrite:  overwrite,
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Logf(cmd.Context(), "Restored backup from %s (migration %s)", hdr.CreatedAt.Format("2006-01-02 15:04:05 MST"), hdr.Migration)
+		return nil
+	},
+}
+
+func initBackupCommands() {
+	for _, c := range []*cobra.Command{backupCmd, restoreCmd} {
+		c.Flags().String("passphrase-file", "", "Read the encryption passphrase from a file.")
+		c.Flags().String("passphrase-command", "", "Run a shell command and use its output as the encryption passphrase.")
+	}
+
+	backupCmd.Flags().StringArray("recipient", nil, "Encrypt to an age public key (age1...) instead of a passphrase. Can be repeated.")
+	backupCmd.Flags().String("recipients-file", "", "Encrypt to the age public keys listed in a file, one per line.")
+	backupCmd.Flags().StringP("output", "o", "goalert-backup.enc", "Output file for the backup, or - for stdout. Existing files are not overwritten.")
+	backupCmd.Flags().Bool("exclude-closed-alerts", false, "Omit closed alerts and their logs and messages.")
+
+	restoreCmd.Flags().StringP("input", "i", "goalert-backup.enc", "Backup file to restore, or - for stdin.")
+	restoreCmd.Flags().String("identity-file", "", "Decrypt with the age identities (private keys) in a file.")
+	restoreCmd.Flags().String("identity-command", "", "Run a shell command and use its output as age identities (private keys).")
+	restoreCmd.Flags().Bool("overwrite", false, "Replace existing data in the destination database.")
+}
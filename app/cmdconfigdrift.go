@@ -0,0 +1,122 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/target/goalert/drift"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Export declarative configuration and detect drift.",
+	Long: `Export declarative configuration and detect drift.
+
+Services (with labels, integration keys, and heartbeat monitors), escalation policies,
+rotations, and schedules are exported as YAML, identified by name. Users are referenced
+by email address. Keeping an export in source control and running "goalert config diff"
+in CI reports any changes made outside of source control.`,
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the current configuration as YAML.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := backupDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		s, err := drift.Load(cmd.Context(), db)
+		if err != nil {
+			return err
+		}
+		data, err := s.Marshal()
+		if err != nil {
+			return errors.Wrap(err, "marshal YAML")
+		}
+
+		fileName, _ := cmd.Flags().GetString("output")
+		if fileName == "-" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+
+		return os.WriteFile(fileName, data, 0o644)
+	},
+}
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Compare the current configuration against a YAML export.",
+	Long: `Compare the current configuration against a YAML export.
+
+Each difference is reported from the perspective of the live instance:
+  +  the object exists but is not in the export
+  -  the object is in the export but does not exist
+  ~  a field of the object differs from the export
+
+Use --exit-code to exit with a non-zero status if any drift is detected (e.g., to fail a CI job).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return errors.Errorf("invalid format '%s'", format)
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return errors.Wrap(err, "read export")
+		}
+		expected, err := drift.Parse(data)
+		if err != nil {
+			return err
+		}
+
+		db, err := backupDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		actual, err := drift.Load(cmd.Context(), db)
+		if err != nil {
+			return err
+		}
+
+		changes := drift.Diff(expected, actual)
+		if format == "json" {
+			if changes == nil {
+				changes = []drift.Change{}
+			}
+			err = printJSON(changes)
+			if err != nil {
+				return err
+			}
+		} else if len(changes) == 0 {
+			fmt.Println("No drift detected.")
+		} else {
+			for _, c := range changes {
+				fmt.Println(c.String())
+			}
+		}
+
+		if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode && len(changes) > 0 {
+			return errors.Errorf("drift detected: %d change(s)", len(changes))
+		}
+
+		return nil
+	},
+}
+
+func initConfigDriftCommands() {
+	configExportCmd.Flags().StringP("output", "o", "-", "Output file for the export, or - for stdout.")
+
+	configDiffCmd.Flags().String("format", "text", "Output format (text or json).")
+	configDiffCmd.Flags().Bool("exit-code", false, "Exit with a non-zero status if any drift is detected.")
+
+	configCmd.AddCommand(configExportCmd, configDiffCmd)
+}
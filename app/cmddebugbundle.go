@@ -0,0 +1,86 @@
+package app
+
+import (
+	"database/sql"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/debugbundle"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "debug-bundle",
+	Short: "Export a diagnostic bundle for troubleshooting.",
+	Long: `Export a diagnostic bundle for troubleshooting.
+
+The bundle is a gzipped tar archive containing build information, the config with secrets redacted,
+migration status, engine module versions and lag, message queue depths, and recent failures.
+Message destinations and contents are not included.
+
+Recent log errors are only available when downloading the bundle from a running instance
+(Admin > System Health or /api/v2/debug-bundle).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := log.FromContext(cmd.Context())
+		if viper.GetBool("verbose") {
+			l.EnableDebug()
+		}
+
+		err := viper.ReadInConfig()
+		// ignore file not found error
+		if err != nil && !isCfgNotFound(err) {
+			return errors.Wrap(err, "read config")
+		}
+
+		c, err := getConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+		db, err := sql.Open("pgx", c.DBURL)
+		if err != nil {
+			return errors.Wrap(err, "connect to postgres")
+		}
+		defer db.Close()
+
+		ctx := permission.SystemContext(cmd.Context(), "DebugBundle")
+
+		s, err := config.NewStore(ctx, config.StoreConfig{
+			DB:   db,
+			Keys: c.EncryptionKeys,
+		})
+		if err != nil {
+			return errors.Wrap(err, "init config store")
+		}
+
+		fileName := cmd.Flag("output").Value.String()
+		f, err := os.Create(fileName)
+		if err != nil {
+			return errors.Wrap(err, "create output file")
+		}
+		defer f.Close()
+
+		err = debugbundle.Write(ctx, f, debugbundle.Options{
+			DB:     db,
+			Config: s.Config(),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return errors.Wrap(err, "close output file")
+		}
+
+		log.Logf(ctx, "Wrote debug bundle to %s", fileName)
+		return nil
+	},
+}
+
+func initDebugBundleCommands() {
+	debugBundleCmd.Flags().StringP("output", "o", "goalert-debug.tar.gz", "Output file for the debug bundle.")
+}
@@ -0,0 +1,299 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/target/goalert/auth/basic"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqldrv"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+//go:embed devseed.sql
+var devSeedSQL string
+
+const (
+	devAdminID       = "00000000-0000-0000-0000-000000000001"
+	devAdminUsername = "admin"
+	devAdminPassword = "admin123"
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Start a local development environment with demo data and mock providers.",
+	Long: `Start a local development environment with demo data and mock providers.
+
+An ephemeral Postgres container is started (using podman or docker) unless --db-url
+is provided, in which case a new database is created on that server. The database is
+seeded with demo users, a schedule, an escalation policy, and services, and is removed
+on exit.
+
+Twilio, Slack, and SMTP are replaced with in-process mock servers; notifications they
+receive are printed to the log instead of being delivered.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		l := log.FromContext(ctx)
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		if verbose {
+			l.EnableDebug()
+		}
+
+		listen, _ := cmd.Flags().GetString("listen")
+		dbURL, _ := cmd.Flags().GetString("db-url")
+		image, _ := cmd.Flags().GetString("postgres-image")
+		uiDir, _ := cmd.Flags().GetString("ui-dir")
+
+		var cleanup func()
+		var err error
+		if dbURL == "" {
+			dbURL, cleanup, err = devStartPostgres(ctx, image)
+		} else {
+			dbURL, cleanup, err = devCreateDB(ctx, dbURL)
+		}
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		log.Logf(ctx, "Applying migrations...")
+		_, err = migrate.ApplyAll(ctx, dbURL)
+		if err != nil {
+			return errors.Wrap(err, "apply migrations")
+		}
+
+		db, err := sqldrv.NewDB(dbURL, "GoAlert Dev")
+		if err != nil {
+			return errors.Wrap(err, "connect to postgres")
+		}
+		defer db.Close()
+
+		err = devSeed(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "seed demo data")
+		}
+
+		mocks, err := newDevMocks(ctx)
+		if err != nil {
+			return errors.Wrap(err, "start mock providers")
+		}
+		defer mocks.Close()
+
+		cfg := Defaults()
+		cfg.Logger = l
+		cfg.ListenAddr = listen
+		cfg.DBURL = dbURL
+		cfg.UIDir = uiDir
+		cfg.TwilioBaseURL = mocks.TwilioURL()
+		cfg.SlackBaseURL = mocks.SlackURL()
+		cfg.SMTPListenAddr = "localhost:0"
+		cfg.EmailIntegrationDomain = "localhost"
+
+		var appCfg config.Config
+		appCfg.General.PublicURL = "http://" + listen
+		mocks.Configure(&appCfg)
+		cfg.InitialConfig = &appCfg
+
+		app, err := NewApp(cfg, db)
+		if err != nil {
+			return errors.Wrap(err, "init app")
+		}
+		go handleShutdown(ctx, app.Shutdown)
+
+		go func() {
+			err := app.WaitForStartup(ctx)
+			if err != nil {
+				return
+			}
+			err = mocks.RegisterCallbacks(app.URL())
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "register mock twilio callbacks"))
+			}
+
+			fmt.Fprintf(os.Stderr, `
+GoAlert development environment is ready.
+
+  URL:       %s
+  Username:  %s
+  Password:  %s
+
+  Send a test alert:
+    curl -XPOST '%s/api/v2/generic/incoming?token=00000000-0000-0000-0006-000000000001&summary=Test+Alert'
+
+  Mock SMTP:   %s
+  Mock Slack:  %s
+  Mock Twilio: %s (from number %s)
+
+Notifications are printed below as they are sent. Press Ctrl+C to stop and remove all data.
+
+`, app.URL(), devAdminUsername, devAdminPassword, app.URL(), mocks.SMTPAddr(), mocks.SlackURL(), mocks.TwilioURL(), mocks.FromNumber())
+		}()
+
+		return errors.Wrap(app.Run(ctx), "run app")
+	},
+}
+
+func initDevCommands() {
+	devCmd.Flags().String("listen", "localhost:3030", "Listen address for the GoAlert server.")
+	devCmd.Flags().String("db-url", "", "Use an existing Postgres server instead of starting a container. A new database is created on it, and dropped on exit.")
+	devCmd.Flags().String("postgres-image", "docker.io/library/postgres:13-alpine", "Postgres container image to use.")
+	devCmd.Flags().String("ui-dir", "", "Serve UI assets from a local directory instead of the embedded bundle.")
+	devCmd.Flags().Bool("verbose", false, "Enable verbose logging.")
+}
+
+// devSeed will insert demo data and create the admin login.
+func devSeed(ctx context.Context, db *sql.DB) error {
+	ctx = permission.SystemContext(ctx, "DevSeed")
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+	defer sqlutil.Rollback(ctx, "dev seed", tx)
+
+	for _, q := range sqlutil.SplitQuery(devSeedSQL) {
+		_, err = tx.ExecContext(ctx, q)
+		if err != nil {
+			return err
+		}
+	}
+
+	basicStore, err := basic.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init basic auth store")
+	}
+	pw, err := basicStore.NewHashedPassword(ctx, devAdminPassword)
+	if err != nil {
+		return errors.Wrap(err, "hash password")
+	}
+	err = basicStore.CreateTx(ctx, tx, devAdminID, devAdminUsername, pw)
+	if err != nil {
+		return errors.Wrap(err, "add basic auth entry")
+	}
+
+	return tx.Commit()
+}
+
+// devContainerTool returns the available container CLI (podman or docker).
+func devContainerTool() (string, error) {
+	for _, name := range []string{"podman", "docker"} {
+		if p, err := exec.LookPath(name); err == nil {
+			return p, nil
+		}
+	}
+	return "", errors.New("podman or docker is required to start Postgres (or use --db-url)")
+}
+
+// devStartPostgres will start an ephemeral Postgres container, returning its URL and a function to remove it.
+func devStartPostgres(ctx context.Context, image string) (string, func(), error) {
+	tool, err := devContainerTool()
+	if err != nil {
+		return "", nil, err
+	}
+
+	log.Logf(ctx, "Starting Postgres container (%s)...", image)
+	out, err := exec.CommandContext(ctx, tool, "run", "--rm", "-d",
+		"-e", "POSTGRES_USER=goalert",
+		"-e", "POSTGRES_HOST_AUTH_METHOD=trust",
+		"-p", "127.0.0.1::5432",
+		image,
+	).Output()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "start postgres container")
+	}
+	id := strings.TrimSpace(string(out))
+	cleanup := func() {
+		// not bound to ctx, as it is likely canceled by now
+		err := exec.Command(tool, "rm", "-f", id).Run()
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "remove postgres container"))
+		}
+	}
+
+	out, err = exec.CommandContext(ctx, tool, "port", id, "5432/tcp").Output()
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "get postgres port")
+	}
+	addr := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	dbURL := "postgres://goalert@" + addr + "/goalert?sslmode=disable"
+
+	err = devWaitForDB(ctx, dbURL)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return dbURL, cleanup, nil
+}
+
+// devCreateDB will create a new database on an existing server, returning its URL and a function to drop it.
+func devCreateDB(ctx context.Context, serverURL string) (string, func(), error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "parse db-url")
+	}
+	name := fmt.Sprintf("goalert_dev_%d", time.Now().Unix())
+
+	conn, err := pgx.Connect(ctx, serverURL)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "connect to postgres")
+	}
+	_, err = conn.Exec(ctx, "create database "+sqlutil.QuoteID(name))
+	conn.Close(ctx)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "create database")
+	}
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		conn, err := pgx.Connect(ctx, serverURL)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "connect to postgres"))
+			return
+		}
+		defer conn.Close(ctx)
+		_, err = conn.Exec(ctx, "drop database if exists "+sqlutil.QuoteID(name)+" with (force)")
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "drop database"))
+		}
+	}
+
+	u.Path = "/" + name
+	return u.String(), cleanup, nil
+}
+
+// devWaitForDB will wait for the database to accept connections.
+func devWaitForDB(ctx context.Context, dbURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	t := time.NewTicker(250 * time.Millisecond)
+	defer t.Stop()
+	for {
+		conn, err := pgx.Connect(ctx, dbURL)
+		if err == nil {
+			return conn.Close(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "wait for postgres")
+		case <-t.C:
+		}
+	}
+}
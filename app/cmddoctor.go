@@ -0,0 +1,83 @@
+package app
+
+import (
+	"database/sql"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/doctor"
+	"github.com/target/goalert/util/log"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the database, config, and notification providers for common problems.",
+	Long: `Check the database, config, and notification providers for common problems.
+
+Checks database connectivity and version, required Postgres extensions, migration status,
+config validity, that the public URL is reachable, and that credentials for each enabled
+notification provider are accepted. Each problem found is printed with a suggested fix.
+
+The public URL and providers are contacted from the host running this command; use
+--skip-network where outbound access differs from the GoAlert servers.
+
+Exits with a non-zero status if any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return errors.Errorf("invalid format '%s'", format)
+		}
+
+		l := log.FromContext(cmd.Context())
+		if viper.GetBool("verbose") {
+			l.EnableDebug()
+		}
+
+		err := viper.ReadInConfig()
+		// ignore file not found error
+		if err != nil && !isCfgNotFound(err) {
+			return errors.Wrap(err, "read config")
+		}
+
+		c, err := getConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+		db, err := sql.Open("pgx", c.DBURL)
+		if err != nil {
+			return errors.Wrap(err, "connect to postgres")
+		}
+		defer db.Close()
+
+		skipNet, _ := cmd.Flags().GetBool("skip-network")
+		findings := doctor.Run(cmd.Context(), doctor.Options{
+			DB:            db,
+			Keys:          c.EncryptionKeys,
+			ExplicitURL:   c.PublicURL,
+			TwilioBaseURL: c.TwilioBaseURL,
+			SlackBaseURL:  c.SlackBaseURL,
+			SkipNetwork:   skipNet,
+		})
+
+		if format == "json" {
+			err = printJSON(findings)
+		} else {
+			err = doctor.WriteText(os.Stdout, findings)
+		}
+		if err != nil {
+			return err
+		}
+
+		if n := doctor.Failed(findings); n > 0 {
+			return errors.Errorf("%d check(s) failed", n)
+		}
+		return nil
+	},
+}
+
+func initDoctorCommands() {
+	doctorCmd.Flags().Bool("skip-network", false, "Skip checks that connect to the public URL or notification providers.")
+	doctorCmd.Flags().String("format", "text", "Output format for findings (text or json).")
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/pdimport"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+var importPagerDutyCmd = &cobra.Command{
+	Use:   "import-pagerduty",
+	Short: "Imports users, schedules, escalation policies, and services from a PagerDuty REST API export.",
+	Long: `Imports users, schedules, escalation policies, and services from a PagerDuty REST API export.
+
+The export file must be a JSON object containing the "users", "schedules" (including schedule_layers),
+"escalation_policies", and "services" arrays as returned by the PagerDuty REST API.
+
+A report of created resources, and anything that could not be translated, is written to stdout as JSON.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := log.FromContext(cmd.Context())
+		if viper.GetBool("verbose") {
+			l.EnableDebug()
+		}
+
+		err := viper.ReadInConfig()
+		// ignore file not found error
+		if err != nil && !isCfgNotFound(err) {
+			return errors.Wrap(err, "read config")
+		}
+
+		fileName := cmd.Flag("file").Value.String()
+		if fileName == "" {
+			return errors.New("--file is required")
+		}
+		f, err := os.Open(fileName)
+		if err != nil {
+			return errors.Wrap(err, "open export file")
+		}
+		defer f.Close()
+
+		exp, err := pdimport.ParseExport(f)
+		if err != nil {
+			return err
+		}
+
+		c, err := getConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+		db, err := sql.Open("pgx", c.DBURL)
+		if err != nil {
+			return errors.Wrap(err, "connect to postgres")
+		}
+		defer db.Close()
+
+		ctx := permission.SystemContext(cmd.Context(), "ImportPagerDuty")
+
+		var cfg pdimport.Config
+		cfg.UserStore, err = user.NewStore(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "init user store")
+		}
+		cfg.ScheduleStore, err = schedule.NewStore(ctx, db, cfg.UserStore)
+		if err != nil {
+			return errors.Wrap(err, "init schedule store")
+		}
+		cfg.RotationStore, err = rotation.NewStore(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "init rotation store")
+		}
+		cfg.RuleStore, err = rule.NewStore(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "init schedule rule store")
+		}
+		logStore, err := alertlog.NewStore(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "init alert log store")
+		}
+		cfg.EscalationStore, err = escalation.NewStore(ctx, db, escalation.Config{LogStore: logStore})
+		if err != nil {
+			return errors.Wrap(err, "init escalation policy store")
+		}
+		cfg.ServiceStore, err = service.NewStore(ctx, db)
+		if err != nil {
+			return errors.Wrap(err, "init service store")
+		}
+
+		imp, err := pdimport.NewImporter(ctx, db, cfg)
+		if err != nil {
+			return errors.Wrap(err, "init importer")
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "begin tx")
+		}
+		defer sqlutil.Rollback(ctx, "import-pagerduty", tx)
+
+		rep, err := imp.Import(ctx, tx, exp)
+		if err != nil {
+			return errors.Wrap(err, "import")
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); !dryRun {
+			err = tx.Commit()
+			if err != nil {
+				return errors.Wrap(err, "commit tx")
+			}
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	},
+}
+
+func initImportCommands() {
+	importPagerDutyCmd.Flags().StringP("file", "f", "", "PagerDuty export file (JSON) to import (required).")
+	importPagerDutyCmd.Flags().Bool("dry-run", false, "Generate the report without saving any changes.")
+}
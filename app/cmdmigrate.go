@@ -0,0 +1,160 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/util/log"
+)
+
+// migrateSchemaStatus will return the migration status of the configured database.
+func migrateSchemaStatus(cmd *cobra.Command) (*migrate.SchemaStatus, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		return nil, errors.Errorf("invalid format '%s'", format)
+	}
+
+	l := log.FromContext(cmd.Context())
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return nil, errors.Wrap(err, "read config")
+	}
+
+	c, err := getConfig(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("pgx", c.DBURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "connect to postgres")
+	}
+	defer db.Close()
+
+	s, err := migrate.Status(cmd.Context(), db)
+	if err != nil {
+		return nil, errors.Wrap(err, "get migration status")
+	}
+
+	return s, nil
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := migrateSchemaStatus(cmd)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			return printJSON(s)
+		}
+
+		if s.Latest == "" {
+			fmt.Println("Applied:  (none)")
+		} else {
+			fmt.Printf("Applied:  %s (%s)\n", s.Latest, s.LatestAppliedAt.Format("2006-01-02 15:04:05 MST"))
+		}
+		fmt.Printf("Expected: %s\n", s.Expected)
+		fmt.Printf("Pending:  %d\n", len(s.Pending))
+		for _, name := range s.Pending {
+			fmt.Printf("  %s\n", name)
+		}
+		if len(s.Unknown) > 0 {
+			fmt.Printf("Unknown:  %d (applied by a newer version of GoAlert)\n", len(s.Unknown))
+			for _, id := range s.Unknown {
+				fmt.Printf("  %s\n", id)
+			}
+		}
+
+		return nil
+	},
+}
+
+var migratePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "List pending migrations with their estimated lock impact and switchover safety.",
+	Long: `List pending migrations with their estimated lock impact and switchover safety.
+
+Lock impact is one of:
+  none      no locks that block other queries on existing tables
+  brief     an exclusive lock held only long enough to update the catalog
+  blocking  reads or writes blocked for a time proportional to table size
+
+A migration is switchover-safe if instances running the previous version can keep
+operating against the migrated schema (e.g., it does not drop or rename columns).
+
+Estimates are based on the SQL statements of each migration and should be reviewed
+before upgrading large databases. To upgrade in controlled steps, use --target to
+plan up to a specific migration, then apply it with "goalert migrate --up <name>".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := migrateSchemaStatus(cmd)
+		if err != nil {
+			return err
+		}
+		if len(s.Unknown) > 0 {
+			return errors.Errorf("database has %d migration(s) unknown to this version of GoAlert", len(s.Unknown))
+		}
+
+		target, _ := cmd.Flags().GetString("target")
+		steps, err := migrate.Plan(s.Pending, target)
+		if err != nil {
+			return err
+		}
+
+		if format, _ := cmd.Flags().GetString("format"); format == "json" {
+			if steps == nil {
+				steps = []migrate.PlanStep{}
+			}
+			return printJSON(steps)
+		}
+
+		if len(steps) == 0 {
+			fmt.Println("No pending migrations.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MIGRATION\tLOCK\tSWITCHOVER-SAFE\tNOTES")
+		for _, step := range steps {
+			safe := "yes"
+			if !step.SwitchoverSafe {
+				safe = "no"
+			}
+			notes := append(append([]string{}, step.UnsafeReasons...), step.LockReasons...)
+			if step.NoTransaction {
+				notes = append(notes, "runs outside a transaction")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", step.Name, step.Lock, safe, strings.Join(notes, "; "))
+		}
+		err = w.Flush()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%d migration(s) pending; apply with: goalert migrate --up %s\n", len(steps), steps[len(steps)-1].Name)
+		return nil
+	},
+}
+
+func initMigrateCommands() {
+	migrateStatusCmd.Flags().String("format", "text", "Output format (text or json).")
+
+	migratePlanCmd.Flags().String("target", "", "Only plan migrations up to, and including, this migration name.")
+	migratePlanCmd.Flags().String("format", "text", "Output format (text or json).")
+
+	migrateCmd.AddCommand(migrateStatusCmd, migratePlanCmd)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/target/goalert/scrub"
+	"github.com/target/goalert/util/log"
+)
+
+var scrubCmd = &cobra.Command{
+	Use:   "scrub",
+	Short: "Anonymize personal data in a copy of a production database.",
+	Long: `Anonymize personal data in a copy of a production database.
+
+Names, emails, phone numbers, and other contact details of users and notification channels
+are replaced with placeholder values, alert summaries, details, and log messages are cleared,
+and all login credentials, sessions, and tokens are removed. IDs are not changed, so
+schedules, escalation policies, services, and alert history remain intact.
+
+Scrubbing is irreversible and intended for a restored copy of production data (e.g., from
+"goalert restore"). The name of the database must be passed to --confirm-db-name to
+guard against scrubbing the wrong database. Use "goalert add-user" afterwards to create
+a login.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirm, _ := cmd.Flags().GetString("confirm-db-name")
+		if confirm == "" {
+			return errors.New("--confirm-db-name is required")
+		}
+
+		db, err := backupDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var dbName string
+		err = db.QueryRowContext(cmd.Context(), "select current_database()").Scan(&dbName)
+		if err != nil {
+			return errors.Wrap(err, "get database name")
+		}
+		if dbName != confirm {
+			return fmt.Errorf("refusing to scrub: connected to database '%s' but --confirm-db-name is '%s'", dbName, confirm)
+		}
+
+		results, err := scrub.Run(cmd.Context(), db)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			log.Logf(cmd.Context(), "Scrubbed %s: %d rows", r.Description, r.Rows)
+		}
+
+		return nil
+	},
+}
+
+func initScrubCommands() {
+	scrubCmd.Flags().String("confirm-db-name", "", "Name of the database being scrubbed, must match the connected database.")
+}
@@ -39,6 +39,31 @@ type Config struct {
 	TLSConfigSMTP         *tls.Config
 	SMTPAdditionalDomains string
 
+	SNMPTrapListenAddr string
+	SNMPv3AuthProtocol string
+	SNMPv3AuthPassword string
+	SNMPv3PrivProtocol string
+	SNMPv3PrivPassword string
+
+	SyslogListenAddr    string
+	SyslogListenAddrTLS string
+	TLSConfigSyslog     *tls.Config
+
+	KafkaBrokers       string
+	KafkaTopic         string
+	KafkaGroupID       string
+	KafkaTLS           bool
+	KafkaTLSCAFile     string
+	KafkaSASLMechanism string
+	KafkaSASLUsername  string
+	KafkaSASLPassword  string
+
+	MQTTBroker    string
+	MQTTClientID  string
+	MQTTUsername  string
+	MQTTPassword  string
+	MQTTTLSCAFile string
+
 	EmailIntegrationDomain string
 
 	HTTPPrefix string
@@ -57,8 +82,29 @@ type Config struct {
 	DBURL     string
 	DBURLNext string
 
+	// DBNextLogicalSubscriber indicates DBURLNext is a logical replication subscriber of DBURL.
+	DBNextLogicalSubscriber bool
+
+	// DBURLReadReplica, if set, is used for heavy read-only queries.
+	DBURLReadReplica string
+
+	// AlertArchiveDir, if set, is where closed alerts are archived (see Maintenance.AlertArchiveDays).
+	AlertArchiveDir string
+
+	// AlertArchiveS3URL, if set, is the S3 bucket and prefix where closed alerts are archived.
+	AlertArchiveS3URL string
+
+	// TranslationsDir, if set, contains <locale>.json notification message catalogs.
+	TranslationsDir string
+
 	StatusAddr string
 
+	// OTLPEndpoint, if set, is the OTLP/HTTP endpoint traces are exported to.
+	OTLPEndpoint string
+
+	// TracingProbability is the probability a new trace is recorded.
+	TracingProbability float64
+
 	EngineCycleTime time.Duration
 
 	EncryptionKeys keyring.Keys
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqldrv"
+)
+
+// dbPoolState tracks connection pool overrides from config, so they can be applied at runtime
+// without interfering with the reduced pool used while paused.
+type dbPoolState struct {
+	mx sync.Mutex
+
+	paused  bool
+	maxOpen int
+	maxIdle int
+}
+
+// registerDBStats will export query stats, and connection pool stats for the primary DB and read replica
+// (if configured).
+func (app *App) registerDBStats() {
+	register := func(c prometheus.Collector) {
+		err := prometheus.Register(c)
+		var regErr prometheus.AlreadyRegisteredError
+		if errors.As(err, &regErr) {
+			// multiple apps in the same process (e.g., smoke tests) only export stats for the first
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	register(sqldrv.DefaultQueryStats)
+	register(collectors.NewDBStatsCollector(app.db, "goalert"))
+	if app.replicaDB != nil {
+		register(collectors.NewDBStatsCollector(app.replicaDB, "goalert_replica"))
+	}
+}
+
+// poolSize returns the max open and idle connections, using config overrides if set.
+//
+// Caller must hold app.dbPool.mx.
+func (app *App) poolSize() (maxOpen, maxIdle int) {
+	maxOpen, maxIdle = app.cfg.DBMaxOpen, app.cfg.DBMaxIdle
+	if app.dbPool.maxOpen > 0 {
+		maxOpen = app.dbPool.maxOpen
+	}
+	if app.dbPool.maxIdle > 0 {
+		maxIdle = app.dbPool.maxIdle
+	}
+
+	return maxOpen, maxIdle
+}
+
+// applyPoolSize sets the current pool size on the primary DB and read replica.
+//
+// Caller must hold app.dbPool.mx.
+func (app *App) applyPoolSize() {
+	maxOpen, maxIdle := app.poolSize()
+	app.db.SetMaxOpenConns(maxOpen)
+	app.db.SetMaxIdleConns(maxIdle)
+	if app.replicaDB != nil {
+		app.replicaDB.SetMaxOpenConns(maxOpen)
+		app.replicaDB.SetMaxIdleConns(maxIdle)
+	}
+}
+
+// dbConfigChanged will apply pool size overrides from the Database config section.
+func (app *App) dbConfigChanged(ctx context.Context, oldCfg, newCfg config.Config) {
+	app.dbPool.mx.Lock()
+	defer app.dbPool.mx.Unlock()
+
+	if app.dbPool.maxOpen == newCfg.Database.MaxOpenConns && app.dbPool.maxIdle == newCfg.Database.MaxIdleConns {
+		return
+	}
+	app.dbPool.maxOpen = newCfg.Database.MaxOpenConns
+	app.dbPool.maxIdle = newCfg.Database.MaxIdleConns
+	if app.dbPool.paused {
+		// applied on resume
+		return
+	}
+
+	app.applyPoolSize()
+	maxOpen, maxIdle := app.poolSize()
+	log.Logf(log.WithFields(ctx, log.Fields{
+		"MaxOpen": maxOpen,
+		"MaxIdle": maxIdle,
+	}), "Updated DB connection pool size.")
+}
@@ -13,5 +13,8 @@ func Defaults() Config {
 		RegionName:        "default",
 		EngineCycleTime:   5 * time.Second,
 		SMTPMaxRecipients: 1,
+		KafkaGroupID:      "goalert",
+
+		TracingProbability: 1,
 	}
 }
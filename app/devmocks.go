@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/mailhog/MailHog-Server/smtp"
+	"github.com/mailhog/data"
+	"github.com/mailhog/storage"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/devtools/mockslack"
+	"github.com/target/goalert/devtools/mocktwilio"
+	"github.com/target/goalert/util/log"
+)
+
+const (
+	devTwilioAccountSID = "AC00000000000000000000000000000000"
+	devTwilioFromNumber = "+12015550100"
+)
+
+// devMocks runs mock Twilio, Slack, and SMTP servers for `goalert dev`.
+type devMocks struct {
+	ctx context.Context
+
+	twilio      *mocktwilio.Server
+	twilioToken string
+	twilioL     net.Listener
+
+	slack    *mockslack.Server
+	slackApp *mockslack.AppInfo
+	slackL   net.Listener
+
+	smtpL net.Listener
+}
+
+func devRandHex() string {
+	var buf [16]byte
+	_, err := rand.Read(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+func newDevMocks(ctx context.Context) (*devMocks, error) {
+	m := &devMocks{ctx: ctx, twilioToken: devRandHex()}
+
+	var err error
+	m.twilioL, err = net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "listen (twilio)")
+	}
+	m.twilio = mocktwilio.NewServer(mocktwilio.Config{
+		AccountSID: devTwilioAccountSID,
+		AuthToken:  m.twilioToken,
+	})
+	go func() { _ = http.Serve(m.twilioL, m.twilio) }()
+	go m.logTwilio()
+
+	m.slackL, err = net.Listen("tcp", "localhost:0")
+	if err != nil {
+		m.Close()
+		return nil, errors.Wrap(err, "listen (slack)")
+	}
+	m.slack = mockslack.NewServer()
+	m.slack.SetURLPrefix(m.SlackURL())
+	m.slackApp, err = m.slack.InstallStaticApp(mockslack.AppInfo{Name: "GoAlert Dev", SigningSecret: devRandHex()}, "bot")
+	if err != nil {
+		m.Close()
+		return nil, errors.Wrap(err, "install slack app")
+	}
+	m.slack.NewChannel("general")
+	m.slack.NewChannel("alerts")
+	go func() { _ = http.Serve(m.slackL, m.slack) }()
+
+	m.smtpL, err = net.Listen("tcp", "localhost:0")
+	if err != nil {
+		m.Close()
+		return nil, errors.Wrap(err, "listen (smtp)")
+	}
+	go m.serveSMTP()
+
+	return m, nil
+}
+
+// TwilioURL returns the base URL of the mock Twilio server.
+func (m *devMocks) TwilioURL() string { return "http://" + m.twilioL.Addr().String() }
+
+// SlackURL returns the base URL of the mock Slack server.
+func (m *devMocks) SlackURL() string { return "http://" + m.slackL.Addr().String() }
+
+// SMTPAddr returns the address of the mock SMTP server.
+func (m *devMocks) SMTPAddr() string { return m.smtpL.Addr().String() }
+
+// FromNumber returns the phone number notifications are sent from.
+func (m *devMocks) FromNumber() string { return devTwilioFromNumber }
+
+// Configure will update cfg to use the mock providers.
+func (m *devMocks) Configure(cfg *config.Config) {
+	cfg.Twilio.Enable = true
+	cfg.Twilio.AccountSID = devTwilioAccountSID
+	cfg.Twilio.AuthToken = m.twilioToken
+	cfg.Twilio.FromNumber = devTwilioFromNumber
+
+	cfg.Slack.Enable = true
+	cfg.Slack.ClientID = m.slackApp.ClientID
+	cfg.Slack.ClientSecret = m.slackApp.ClientSecret
+	cfg.Slack.AccessToken = m.slackApp.AccessToken
+	cfg.Slack.SigningSecret = m.slackApp.SigningSecret
+
+	cfg.SMTP.Enable = true
+	cfg.SMTP.Address = m.SMTPAddr()
+	cfg.SMTP.DisableTLS = true
+	cfg.SMTP.From = "goalert@localhost"
+}
+
+// RegisterCallbacks will point inbound SMS and voice for the from number at the GoAlert server.
+func (m *devMocks) RegisterCallbacks(appURL string) error {
+	err := m.twilio.RegisterSMSCallback(devTwilioFromNumber, appURL+"/api/v2/twilio/message")
+	if err != nil {
+		return err
+	}
+	return m.twilio.RegisterVoiceCallback(devTwilioFromNumber, appURL+"/api/v2/twilio/call?type=alert")
+}
+
+// logTwilio will log, and accept, all SMS messages and voice calls.
+func (m *devMocks) logTwilio() {
+	for {
+		select {
+		case err := <-m.twilio.Errors():
+			log.Log(m.ctx, errors.Wrap(err, "mock twilio"))
+		case sms := <-m.twilio.SMS():
+			log.Logf(m.ctx, "[SMS] to %s: %s", sms.To(), sms.Body())
+			go sms.Accept()
+		case vc := <-m.twilio.VoiceCalls():
+			go func() {
+				log.Logf(m.ctx, "[Voice] to %s: %s", vc.To(), strings.ReplaceAll(vc.Body(), "\n", " "))
+				vc.Accept()
+				vc.Hangup()
+			}()
+		}
+	}
+}
+
+// serveSMTP will accept and log all email messages.
+func (m *devMocks) serveSMTP() {
+	msgCh := make(chan *data.Message)
+	go func() {
+		for msg := range msgCh {
+			var to []string
+			for _, p := range msg.To {
+				to = append(to, p.Mailbox+"@"+p.Domain)
+			}
+			log.Logf(m.ctx, "[Email] to %s: %s", strings.Join(to, ", "), strings.Join(msg.Content.Headers["Subject"], " "))
+		}
+	}()
+
+	store := storage.CreateInMemory()
+	for {
+		conn, err := m.smtpL.Accept()
+		if err != nil {
+			return
+		}
+		go smtp.Accept(conn.RemoteAddr().String(), conn, store, msgCh, "goalert-dev.local", nil)
+	}
+}
+
+// Close will stop all mock servers.
+func (m *devMocks) Close() {
+	if m.twilioL != nil {
+		m.twilioL.Close()
+	}
+	if m.twilio != nil {
+		m.twilio.Close()
+	}
+	if m.slackL != nil {
+		m.slackL.Close()
+	}
+	if m.smtpL != nil {
+		m.smtpL.Close()
+	}
+}
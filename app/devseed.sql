@@ -0,0 +1,67 @@
+-- Demo data used by `goalert dev`.
+insert into users (id, name, email, role)
+values
+    ('00000000-0000-0000-0000-000000000001', 'Admin McAdminFace', 'admin@example.com', 'admin'),
+    ('00000000-0000-0000-0000-000000000011', 'Alice Example', 'alice@example.com', 'user'),
+    ('00000000-0000-0000-0000-000000000012', 'Bob Example', 'bob@example.com', 'user'),
+    ('00000000-0000-0000-0000-000000000013', 'Carol Example', 'carol@example.com', 'user');
+
+insert into user_contact_methods (id, user_id, name, type, value)
+values
+    ('00000000-0000-0000-0001-000000000001', '00000000-0000-0000-0000-000000000001', 'Mobile', 'SMS', '+12015550101'),
+    ('00000000-0000-0000-0001-000000000002', '00000000-0000-0000-0000-000000000001', 'Email', 'EMAIL', 'admin@example.com'),
+    ('00000000-0000-0000-0001-000000000011', '00000000-0000-0000-0000-000000000011', 'Mobile', 'SMS', '+12015550111'),
+    ('00000000-0000-0000-0001-000000000012', '00000000-0000-0000-0000-000000000011', 'Voice', 'VOICE', '+12015550111'),
+    ('00000000-0000-0000-0001-000000000021', '00000000-0000-0000-0000-000000000012', 'Mobile', 'SMS', '+12015550112'),
+    ('00000000-0000-0000-0001-000000000031', '00000000-0000-0000-0000-000000000013', 'Email', 'EMAIL', 'carol@example.com');
+
+insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+values
+    ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0001-000000000001', 0),
+    ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0001-000000000002', 0),
+    ('00000000-0000-0000-0000-000000000011', '00000000-0000-0000-0001-000000000011', 0),
+    ('00000000-0000-0000-0000-000000000011', '00000000-0000-0000-0001-000000000012', 5),
+    ('00000000-0000-0000-0000-000000000012', '00000000-0000-0000-0001-000000000021', 0),
+    ('00000000-0000-0000-0000-000000000013', '00000000-0000-0000-0001-000000000031', 0);
+
+insert into rotations (id, name, description, type, shift_length, time_zone)
+values
+    ('00000000-0000-0000-0002-000000000001', 'Demo Rotation', 'Weekly primary on-call rotation.', 'weekly', 1, 'UTC');
+
+insert into rotation_participants (id, rotation_id, user_id, position)
+values
+    ('00000000-0000-0000-0002-000000000011', '00000000-0000-0000-0002-000000000001', '00000000-0000-0000-0000-000000000011', 0),
+    ('00000000-0000-0000-0002-000000000012', '00000000-0000-0000-0002-000000000001', '00000000-0000-0000-0000-000000000012', 1),
+    ('00000000-0000-0000-0002-000000000013', '00000000-0000-0000-0002-000000000001', '00000000-0000-0000-0000-000000000013', 2);
+
+insert into schedules (id, name, description, time_zone)
+values
+    ('00000000-0000-0000-0003-000000000001', 'Demo Schedule', 'Primary on-call schedule.', 'UTC');
+
+insert into schedule_rules (id, schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_rotation_id)
+values
+    ('00000000-0000-0000-0003-000000000011', '00000000-0000-0000-0003-000000000001', true, true, true, true, true, true, true, '00:00:00', '00:00:00', '00000000-0000-0000-0002-000000000001');
+
+insert into escalation_policies (id, name, description, repeat)
+values
+    ('00000000-0000-0000-0004-000000000001', 'Demo Policy', 'Notifies the on-call user, then the admin.', 2);
+
+insert into escalation_policy_steps (id, escalation_policy_id, step_number, delay)
+values
+    ('00000000-0000-0000-0004-000000000011', '00000000-0000-0000-0004-000000000001', 0, 5),
+    ('00000000-0000-0000-0004-000000000012', '00000000-0000-0000-0004-000000000001', 1, 10);
+
+insert into escalation_policy_actions (escalation_policy_step_id, schedule_id, user_id)
+values
+    ('00000000-0000-0000-0004-000000000011', '00000000-0000-0000-0003-000000000001', null),
+    ('00000000-0000-0000-0004-000000000012', null, '00000000-0000-0000-0000-000000000001');
+
+insert into services (id, name, description, escalation_policy_id)
+values
+    ('00000000-0000-0000-0005-000000000001', 'Demo Web App', 'Example customer-facing service.', '00000000-0000-0000-0004-000000000001'),
+    ('00000000-0000-0000-0005-000000000002', 'Demo Database', 'Example backend service.', '00000000-0000-0000-0004-000000000001');
+
+insert into integration_keys (id, name, type, service_id)
+values
+    ('00000000-0000-0000-0006-000000000001', 'Generic API', 'generic', '00000000-0000-0000-0005-000000000001'),
+    ('00000000-0000-0000-0006-000000000002', 'Generic API', 'generic', '00000000-0000-0000-0005-000000000002');
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/util/log"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type drainState struct {
+	mx sync.Mutex
+
+	doneCh     chan struct{}
+	startedAt  time.Time
+	finishedAt time.Time
+	err        error
+}
+
+// StartDrain will begin draining the instance (if not already started) in preparation for shutdown, returning
+// a channel that is closed once complete.
+//
+// While draining, the instance reports not-ready and the engine stops claiming new work; in-flight work (e.g.,
+// outgoing messages) is allowed to finish. HTTP requests, like incoming webhooks, continue to be served until
+// shutdown.
+func (app *App) StartDrain() <-chan struct{} {
+	app.drain.mx.Lock()
+	defer app.drain.mx.Unlock()
+	if app.drain.doneCh != nil {
+		return app.drain.doneCh
+	}
+
+	app.drain.doneCh = make(chan struct{})
+	app.drain.startedAt = time.Now()
+
+	ctx := app.LogBackgroundContext()
+	log.Logf(ctx, "Drain started.")
+	go func() {
+		err := app.mgr.WaitForStartup(ctx)
+		if app.hSrv != nil {
+			app.hSrv.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		}
+		if err == nil && app.Engine != nil {
+			err = errors.Wrap(app.Engine.Drain(ctx), "drain engine")
+		}
+
+		app.drain.mx.Lock()
+		app.drain.finishedAt = time.Now()
+		app.drain.err = err
+		close(app.drain.doneCh)
+		app.drain.mx.Unlock()
+
+		if err != nil {
+			log.Log(ctx, err)
+			return
+		}
+		log.Logf(ctx, "Drain complete.")
+	}()
+
+	return app.drain.doneCh
+}
+
+// Drain will start draining the instance and wait for it to complete.
+func (app *App) Drain(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-app.StartDrain():
+	}
+
+	_, _, err := app.DrainStatus()
+	return err
+}
+
+// DrainStatus returns the time draining started and finished (zero if not started or still in progress), and
+// any error encountered.
+func (app *App) DrainStatus() (startedAt, finishedAt time.Time, err error) {
+	app.drain.mx.Lock()
+	defer app.drain.mx.Unlock()
+
+	return app.drain.startedAt, app.drain.finishedAt, app.drain.err
+}
+
+// IsDraining returns true if draining has started.
+func (app *App) IsDraining() bool {
+	startedAt, _, _ := app.DrainStatus()
+	return !startedAt.IsZero()
+}
@@ -22,6 +22,17 @@ func (app *App) healthCheck(w http.ResponseWriter, req *http.Request) {
 	// Good to go
 }
 
+// readyCheck is like healthCheck, but also fails while the instance is draining, so that
+// load balancers stop sending new requests before shutdown.
+func (app *App) readyCheck(w http.ResponseWriter, req *http.Request) {
+	if app.IsDraining() {
+		http.Error(w, "server draining", http.StatusServiceUnavailable)
+		return
+	}
+
+	app.healthCheck(w, req)
+}
+
 func (app *App) engineStatus(w http.ResponseWriter, req *http.Request) {
 	if app.mgr.Status() == lifecycle.StatusShutdown {
 		http.Error(w, "server shutting down", http.StatusInternalServerError)
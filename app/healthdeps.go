@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// depCheckInterval is the minimum time between checks of a dependency, so frequent health probes
+// don't overload external APIs.
+const depCheckInterval = 15 * time.Second
+
+// depCheckTimeout is the maximum time a single dependency check may take.
+const depCheckTimeout = 5 * time.Second
+
+// errDepDisabled is returned by a check when the dependency is not configured.
+var errDepDisabled = errors.New("disabled")
+
+// depCheck is a health check for a single external dependency.
+type depCheck struct {
+	name  string
+	check func(ctx context.Context) error
+
+	mx  sync.Mutex
+	res depCheckResult
+}
+
+type depCheckResult struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	LatencyMS   float64    `json:"latencyMs"`
+	CheckedAt   time.Time  `json:"checkedAt"`
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+}
+
+// Dependency check statuses.
+const (
+	depStatusOK       = "ok"
+	depStatusFailed   = "failed"
+	depStatusDisabled = "disabled"
+)
+
+// Result will return the result of the last check, running it first if it is older than depCheckInterval.
+func (c *depCheck) Result(ctx context.Context) depCheckResult {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if time.Since(c.res.CheckedAt) < depCheckInterval {
+		return c.res
+	}
+
+	// the result is shared between requests, so it must not be canceled with this one
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), depCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.check(ctx)
+	c.res.Name = c.name
+	c.res.CheckedAt = time.Now()
+	c.res.LatencyMS = float64(c.res.CheckedAt.Sub(start).Microseconds()) / 1000
+	c.res.Error = ""
+	switch {
+	case errors.Is(err, errDepDisabled):
+		c.res.Status = depStatusDisabled
+		c.res.LatencyMS = 0
+	case err != nil:
+		c.res.Status = depStatusFailed
+		c.res.Error = err.Error()
+	default:
+		c.res.Status = depStatusOK
+		t := c.res.CheckedAt
+		c.res.LastSuccess = &t
+	}
+
+	return c.res
+}
+
+type depChecks []*depCheck
+
+// ServeHTTP will run all checks concurrently, and respond with a 500 status if any failed.
+//
+// Results are returned as text, or as JSON if requested with `?format=json` or an `Accept: application/json` header.
+func (checks depChecks) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	results := make([]depCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *depCheck) {
+			defer wg.Done()
+			results[i] = c.Result(req.Context())
+		}(i, c)
+	}
+	wg.Wait()
+
+	status := http.StatusOK
+	for _, r := range results {
+		if r.Status == depStatusFailed {
+			status = http.StatusInternalServerError
+		}
+	}
+
+	if req.FormValue("format") == "json" || strings.Contains(req.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(results)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	for _, r := range results {
+		switch r.Status {
+		case depStatusOK:
+			fmt.Fprintf(w, "%s: ok (%.1fms)\n", r.Name, r.LatencyMS)
+		case depStatusFailed:
+			fmt.Fprintf(w, "%s: failed (%.1fms): %s\n", r.Name, r.LatencyMS, r.Error)
+		default:
+			fmt.Fprintf(w, "%s: %s\n", r.Name, r.Status)
+		}
+	}
+}
+
+// dependencyChecks returns health checks for the DB and each configured notification provider.
+func (app *App) dependencyChecks() depChecks {
+	return depChecks{
+		{name: "db", check: app.db.PingContext},
+		{name: "db-replica", check: func(ctx context.Context) error {
+			if app.replicaDB == nil {
+				return errDepDisabled
+			}
+			return app.replicaDB.PingContext(ctx)
+		}},
+		{name: "twilio", check: func(ctx context.Context) error {
+			if !config.FromContext(ctx).Twilio.Enable || app.twilioConfig == nil {
+				return errDepDisabled
+			}
+			return app.twilioConfig.CheckAccount(ctx)
+		}},
+		{name: "slack", check: func(ctx context.Context) error {
+			if !config.FromContext(ctx).Slack.Enable || app.slackChan == nil {
+				return errDepDisabled
+			}
+			return app.slackChan.CheckAuth(ctx)
+		}},
+		{name: "smtp", check: func(ctx context.Context) error {
+			if !config.FromContext(ctx).SMTP.Enable || app.smtpSender == nil {
+				return errDepDisabled
+			}
+			return app.smtpSender.CheckServer(ctx)
+		}},
+	}
+}
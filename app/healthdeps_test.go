@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepChecks(t *testing.T) {
+	var calls int
+	checks := depChecks{
+		{name: "ok", check: func(context.Context) error { calls++; return nil }},
+		{name: "off", check: func(context.Context) error { return errDepDisabled }},
+		{name: "bad", check: func(context.Context) error { return errors.New("unreachable") }},
+	}
+
+	req := httptest.NewRequest("GET", "/health/dependencies?format=json", nil)
+	rec := httptest.NewRecorder()
+	checks.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	var res []depCheckResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res, 3)
+	assert.Equal(t, depStatusOK, res[0].Status)
+	assert.NotNil(t, res[0].LastSuccess)
+	assert.Equal(t, depStatusDisabled, res[1].Status)
+	assert.Equal(t, depStatusFailed, res[2].Status)
+	assert.Equal(t, "unreachable", res[2].Error)
+	assert.Nil(t, res[2].LastSuccess)
+
+	// results are cached
+	rec = httptest.NewRecorder()
+	depChecks{checks[0], checks[1]}.ServeHTTP(rec, httptest.NewRequest("GET", "/health/dependencies", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, rec.Body.String(), "off: disabled\n")
+}
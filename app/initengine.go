@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/target/goalert/engine"
+	"github.com/target/goalert/engine/eventstream"
 
 	"github.com/pkg/errors"
 )
@@ -27,9 +28,12 @@ func (app *App) initEngine(ctx context.Context) error {
 		return errors.Wrap(err, "get region index")
 	}
 
+	app.engineEvents = eventstream.NewBroker()
 	app.Engine, err = engine.NewEngine(ctx, app.db, &engine.Config{
 		AlertStore:          app.AlertStore,
 		AlertLogStore:       app.AlertLogStore,
+		AlertArchiveStore:   app.AlertArchiveStore,
+		JobStore:            app.JobStore,
 		ContactMethodStore:  app.ContactMethodStore,
 		NotificationManager: app.notificationManager,
 		UserStore:           app.UserStore,
@@ -39,8 +43,10 @@ func (app *App) initEngine(ctx context.Context) error {
 		ScheduleStore:       app.ScheduleStore,
 		AuthLinkStore:       app.AuthLinkStore,
 		SlackStore:          app.slackChan,
+		TwilioConfig:        app.twilioConfig,
 
 		ConfigSource: app.ConfigStore,
+		Events:       app.engineEvents,
 
 		Keys: app.cfg.EncryptionKeys,
 
@@ -55,6 +61,10 @@ func (app *App) initEngine(ctx context.Context) error {
 		return errors.Wrap(err, "init engine")
 	}
 
+	// apply config changes immediately, rather than waiting for caches to expire or the next cycle
+	app.ConfigStore.OnChange(app.notificationManager.ConfigChanged)
+	app.ConfigStore.OnChange(app.Engine.ConfigChanged)
+
 	app.notificationManager.SetResultReceiver(app.Engine)
 
 	return nil
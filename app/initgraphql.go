@@ -8,40 +8,55 @@ import (
 
 func (app *App) initGraphQL(ctx context.Context) error {
 	app.graphql2 = &graphqlapp.App{
-		DB:                  app.db,
-		AuthBasicStore:      app.AuthBasicStore,
-		UserStore:           app.UserStore,
-		CMStore:             app.ContactMethodStore,
-		NRStore:             app.NotificationRuleStore,
-		NCStore:             app.NCStore,
-		AlertStore:          app.AlertStore,
-		AlertLogStore:       app.AlertLogStore,
-		AlertMetricsStore:   app.AlertMetricsStore,
-		ServiceStore:        app.ServiceStore,
-		FavoriteStore:       app.FavoriteStore,
-		PolicyStore:         app.EscalationStore,
-		ScheduleStore:       app.ScheduleStore,
-		CalSubStore:         app.CalSubStore,
-		RotationStore:       app.RotationStore,
-		OnCallStore:         app.OnCallStore,
-		TimeZoneStore:       app.TimeZoneStore,
-		IntKeyStore:         app.IntegrationKeyStore,
-		LabelStore:          app.LabelStore,
-		RuleStore:           app.ScheduleRuleStore,
-		OverrideStore:       app.OverrideStore,
-		ConfigStore:         app.ConfigStore,
-		LimitStore:          app.LimitStore,
-		NotificationStore:   app.NotificationStore,
-		SlackStore:          app.slackChan,
-		HeartbeatStore:      app.HeartbeatStore,
-		NoticeStore:         app.NoticeStore,
-		Twilio:              app.twilioConfig,
-		AuthHandler:         app.AuthHandler,
-		FormatDestFunc:      app.notificationManager.FormatDestValue,
-		NotificationManager: app.notificationManager,
-		AuthLinkStore:       app.AuthLinkStore,
-		SWO:                 app.cfg.SWO,
-		APIKeyStore:         app.APIKeyStore,
+		DB:                   app.db,
+		AuthBasicStore:       app.AuthBasicStore,
+		UserStore:            app.UserStore,
+		CMStore:              app.ContactMethodStore,
+		NRStore:              app.NotificationRuleStore,
+		NCStore:              app.NCStore,
+		AlertStore:           app.AlertStore,
+		AlertLogStore:        app.AlertLogStore,
+		AlertMetricsStore:    app.AlertMetricsStore,
+		AlertArchiveStore:    app.AlertArchiveStore,
+		JobStore:             app.JobStore,
+		ServiceStore:         app.ServiceStore,
+		FavoriteStore:        app.FavoriteStore,
+		UnavailabilityStore:  app.UnavailabilityStore,
+		DelegationStore:      app.DelegationStore,
+		TeamStore:            app.TeamStore,
+		PolicyStore:          app.EscalationStore,
+		ScheduleStore:        app.ScheduleStore,
+		CalSubStore:          app.CalSubStore,
+		RotationStore:        app.RotationStore,
+		OnCallStore:          app.OnCallStore,
+		TimeZoneStore:        app.TimeZoneStore,
+		IntKeyStore:          app.IntegrationKeyStore,
+		JiraStore:            app.JiraStore,
+		ServiceNowStore:      app.ServiceNowStore,
+		StatuspageStore:      app.StatuspageStore,
+		StatusCallbackStore:  app.StatusCallbackStore,
+		SLOStore:             app.SLOStore,
+		RequestTraceStore:    app.RequestTraceStore,
+		LabelStore:           app.LabelStore,
+		RuleStore:            app.ScheduleRuleStore,
+		OverrideStore:        app.OverrideStore,
+		ConfigStore:          app.ConfigStore,
+		LimitStore:           app.LimitStore,
+		NotificationStore:    app.NotificationStore,
+		SlackStore:           app.slackChan,
+		HeartbeatStore:       app.HeartbeatStore,
+		NoticeStore:          app.NoticeStore,
+		Twilio:               app.twilioConfig,
+		AuthHandler:          app.AuthHandler,
+		FormatDestFunc:       app.notificationManager.FormatDestValue,
+		NotificationManager:  app.notificationManager,
+		AuthLinkStore:        app.AuthLinkStore,
+		SWO:                  app.cfg.SWO,
+		Engine:               app.Engine,
+		APIKeyStore:          app.APIKeyStore,
+		PDImporter:           app.PDImporter,
+		UserImporter:         app.UserImporter,
+		ServiceTemplateStore: app.ServiceTemplateStore,
 	}
 
 	return nil
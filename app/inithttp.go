@@ -8,16 +8,31 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/target/goalert/amazonsns"
+	"github.com/target/goalert/azuremonitor"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/datadog"
+	"github.com/target/goalert/debugbundle"
+	"github.com/target/goalert/gcpmonitoring"
 	"github.com/target/goalert/genericapi"
+	"github.com/target/goalert/githubactions"
 	"github.com/target/goalert/grafana"
 	"github.com/target/goalert/mailgun"
+	"github.com/target/goalert/nagios"
+	"github.com/target/goalert/newrelic"
+	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/pdevents"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
+	"github.com/target/goalert/restapi"
+	"github.com/target/goalert/sentry"
+	"github.com/target/goalert/servicenow"
 	"github.com/target/goalert/site24x7"
+	"github.com/target/goalert/splunk"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/web"
+	"github.com/target/goalert/zabbix"
 )
 
 func (app *App) initHTTP(ctx context.Context) error {
@@ -54,7 +69,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 				req.URL.Host = req.Host
 				cfg := config.FromContext(req.Context())
 
-				if app.cfg.DisableHTTPSRedirect || cfg.ValidReferer(req.URL.String(), req.URL.String()) {
+				if app.flags.disableHTTPSRedirect.Load() || cfg.ValidReferer(req.URL.String(), req.URL.String()) {
 					next.ServeHTTP(w, req)
 					return
 				}
@@ -77,8 +92,11 @@ func (app *App) initHTTP(ctx context.Context) error {
 		// limit auth check counts (fail-safe for loops or DB access)
 		authCheckLimit(100),
 
+		// continue or start a trace
+		traceRequest,
+
 		// request logging
-		logRequest(app.cfg.LogRequests),
+		logRequest(app.flags.logRequests.Load),
 
 		// max request time
 		timeout(2 * time.Minute),
@@ -94,7 +112,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 		},
 
 		// limit max request size
-		maxBodySizeMiddleware(app.cfg.MaxReqBodyBytes),
+		maxBodySizeMiddleware(app.flags.maxReqBodyBytes.Load),
 
 		// authenticate requests
 		app.AuthHandler.WrapHandler,
@@ -102,6 +120,8 @@ func (app *App) initHTTP(ctx context.Context) error {
 		// add auth info to request logs
 		logRequestAuth,
 
+		newLoadShedder().Middleware,
+
 		LimitConcurrencyByAuthSource,
 
 		wrapGzip,
@@ -127,6 +147,16 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.Handle("/api/graphql", app.graphql2.Handler())
 
 	mux.HandleFunc("/api/v2/config", app.ConfigStore.ServeConfig)
+	mux.HandleFunc("/api/v2/debug-bundle", debugbundle.Handler(app.db, app.ConfigStore, app.cfg.Logger))
+	mux.Handle("/api/v2/engine/events", app.engineEvents)
+	mux.Handle(restapi.Prefix, restapi.NewHandler(restapi.Config{
+		DB:            app.db,
+		ServiceStore:  app.ServiceStore,
+		PolicyStore:   app.EscalationStore,
+		ScheduleStore: app.ScheduleStore,
+		IntKeyStore:   app.IntegrationKeyStore,
+		UserStore:     app.UserStore,
+	}))
 
 	mux.HandleFunc("/api/v2/identity/providers", app.AuthHandler.ServeProviders)
 	mux.HandleFunc("/api/v2/identity/logout", app.AuthHandler.ServeLogout)
@@ -142,12 +172,27 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/identity/providers/oidc", oidcAuth)
 	mux.HandleFunc("/api/v2/identity/providers/oidc/callback", oidcAuth)
 
-	mux.HandleFunc("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore))
+	mux.Handle("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore, app.NonceStore))
 	mux.HandleFunc("/api/v2/grafana/incoming", grafana.GrafanaToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/site24x7/incoming", site24x7.Site24x7ToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/prometheusalertmanager/incoming", prometheus.PrometheusAlertmanagerEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/datadog/incoming", datadog.DatadogToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/amazonsns/incoming", amazonsns.AmazonSNSToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/sentry/incoming", sentry.SentryToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/zabbix/incoming", zabbix.ZabbixToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/azuremonitor/incoming", azuremonitor.AzureMonitorToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/gcpmonitoring/incoming", gcpmonitoring.GCPMonitoringToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/newrelic/incoming", newrelic.NewRelicToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/splunk/incoming", splunk.SplunkToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/nagios/incoming", nagios.NagiosToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/githubactions/incoming", githubactions.GitHubActionsToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/grafanaoncall/incoming", grafana.GrafanaOnCallToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/pagerduty/enqueue", pdevents.EnqueueHandler(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/v2/enqueue", pdevents.EnqueueHandler(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/servicenow/incident", servicenow.CallbackHandler(app.ServiceNowStore, app.AlertStore))
 
 	mux.HandleFunc("/api/v2/generic/incoming", generic.ServeCreateAlert)
+	mux.HandleFunc("/api/v2/generic/batch", generic.ServeCreateAlertBatch)
 	mux.HandleFunc("/api/v2/heartbeat/", generic.ServeHeartbeatCheck)
 	mux.HandleFunc("/api/v2/user-avatar/", generic.ServeUserAvatar)
 	mux.HandleFunc("/api/v2/calendar", app.CalSubStore.ServeICalData)
@@ -157,7 +202,8 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/twilio/call", app.twilioVoice.ServeCall)
 	mux.HandleFunc("/api/v2/twilio/call/status", app.twilioVoice.ServeStatusCallback)
 
-	mux.HandleFunc("/api/v2/slack/message-action", app.slackChan.ServeMessageAction)
+	mux.Handle("/api/v2/slack/message-action", slack.WrapValidation(http.HandlerFunc(app.slackChan.ServeMessageAction), app.NonceStore))
+	mux.Handle("/api/v2/slack/command", slack.WrapValidation(http.HandlerFunc(app.slackChan.ServeSlashCommand), app.NonceStore))
 
 	middleware = append(middleware,
 		httpRewrite(app.cfg.HTTPPrefix, "/v1/graphql2", "/api/graphql"),
@@ -205,8 +251,14 @@ func (app *App) initHTTP(ctx context.Context) error {
 	)
 
 	mux.HandleFunc("/health", app.healthCheck)
+	mux.HandleFunc("/health/ready", app.readyCheck)
 	mux.HandleFunc("/health/engine", app.engineStatus)
 	mux.HandleFunc("/health/engine/cycle", app.engineCycle)
+	deps := app.dependencyChecks()
+	mux.HandleFunc("/health/dependencies", deps.ServeHTTP)
+	for _, c := range deps {
+		mux.HandleFunc("/health/"+c.name, depChecks{c}.ServeHTTP)
+	}
 
 	webH, err := web.NewHandler(app.cfg.UIDir, app.cfg.HTTPPrefix)
 	if err != nil {
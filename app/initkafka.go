@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/kafkaingest"
+)
+
+func (app *App) initKafkaConsumer(ctx context.Context) error {
+	if app.cfg.KafkaBrokers == "" {
+		return nil
+	}
+
+	cfg := kafkaingest.Config{
+		BackgroundContext: app.LogBackgroundContext,
+		Topic:             app.cfg.KafkaTopic,
+		GroupID:           app.cfg.KafkaGroupID,
+		SASLMechanism:     app.cfg.KafkaSASLMechanism,
+		SASLUsername:      app.cfg.KafkaSASLUsername,
+		SASLPassword:      app.cfg.KafkaSASLPassword,
+		AuthorizeFunc: func(ctx context.Context, id string) (context.Context, error) {
+			tok, _, err := authtoken.Parse(id, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			return app.IntegrationKeyStore.Authorize(ctx, *tok, integrationkey.TypeKafka)
+		},
+		CreateAlertFunc: func(ctx context.Context, a *alert.Alert) error {
+			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
+			return err
+		},
+	}
+	for _, b := range strings.Split(app.cfg.KafkaBrokers, ",") {
+		b = strings.TrimSpace(b)
+		if b == "" {
+			continue
+		}
+		cfg.Brokers = append(cfg.Brokers, b)
+	}
+
+	var err error
+	if app.cfg.KafkaTLS || app.cfg.KafkaTLSCAFile != "" {
+		cfg.TLS, err = getClientTLSConfig(app.cfg.KafkaTLSCAFile)
+		if err != nil {
+			return fmt.Errorf("kafka: %w", err)
+		}
+	}
+
+	app.kafkaConsumer, err = kafkaingest.NewConsumer(cfg)
+	return err
+}
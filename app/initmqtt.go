@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/mqttsub"
+	"github.com/target/goalert/permission"
+)
+
+func (app *App) initMQTTSubscriber(ctx context.Context) error {
+	if app.cfg.MQTTBroker == "" {
+		return nil
+	}
+
+	clientID := app.cfg.MQTTClientID
+	if clientID == "" {
+		// MQTT 3.1.1 brokers are only required to accept IDs up to 23 characters
+		buf := make([]byte, 6)
+		_, err := rand.Read(buf)
+		if err != nil {
+			return fmt.Errorf("generate MQTT client ID: %w", err)
+		}
+		clientID = "goalert-" + hex.EncodeToString(buf)
+	}
+
+	tlsCfg, err := getClientTLSConfig(app.cfg.MQTTTLSCAFile)
+	if err != nil {
+		return fmt.Errorf("mqtt: %w", err)
+	}
+
+	app.mqttSub, err = mqttsub.NewSubscriber(mqttsub.Config{
+		BackgroundContext: app.LogBackgroundContext,
+		Broker:            app.cfg.MQTTBroker,
+		ClientID:          clientID,
+		Username:          app.cfg.MQTTUsername,
+		Password:          app.cfg.MQTTPassword,
+		TLS:               tlsCfg,
+		RulesFunc: func(ctx context.Context) (rules []integrationkey.MQTTRule, err error) {
+			permission.SudoContext(ctx, func(ctx context.Context) {
+				rules, err = app.IntegrationKeyStore.AllMQTTRules(ctx)
+			})
+			return rules, err
+		},
+		AuthorizeFunc: func(ctx context.Context, id string) (context.Context, error) {
+			tok, _, err := authtoken.Parse(id, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			return app.IntegrationKeyStore.Authorize(ctx, *tok, integrationkey.TypeMQTT)
+		},
+		CreateAlertFunc: func(ctx context.Context, a *alert.Alert) error {
+			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
+			return err
+		},
+	})
+	return err
+}
@@ -12,6 +12,11 @@ func (app *App) initSlack(ctx context.Context) error {
 	app.slackChan, err = slack.NewChannelSender(ctx, slack.Config{
 		BaseURL:   app.cfg.SlackBaseURL,
 		UserStore: app.UserStore,
+
+		AlertStore:    app.AlertStore,
+		ServiceStore:  app.ServiceStore,
+		ScheduleStore: app.ScheduleStore,
+		OnCallStore:   app.OnCallStore,
 	})
 	if err != nil {
 		return err
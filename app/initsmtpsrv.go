@@ -42,6 +42,7 @@ func (app *App) initSMTPServer(ctx context.Context) error {
 			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
 			return err
 		},
+		RulesFunc: app.IntegrationKeyStore.FindEmailRules,
 	}
 
 	app.smtpsrv = smtpsrv.NewServer(cfg)
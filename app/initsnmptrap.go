@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/snmptrap"
+)
+
+// snmpUSM returns the SNMPv3 credentials for the trap listener.
+func (cfg Config) snmpUSM() *snmptrap.USM {
+	return &snmptrap.USM{
+		AuthProtocol: snmptrap.AuthProtocol(strings.ToUpper(cfg.SNMPv3AuthProtocol)),
+		AuthPassword: cfg.SNMPv3AuthPassword,
+		PrivProtocol: snmptrap.PrivProtocol(strings.ToUpper(cfg.SNMPv3PrivProtocol)),
+		PrivPassword: cfg.SNMPv3PrivPassword,
+	}
+}
+
+func (app *App) initSNMPTrapServer(ctx context.Context) error {
+	if app.cfg.SNMPTrapListenAddr == "" {
+		return nil
+	}
+
+	cfg := snmptrap.Config{
+		BackgroundContext: app.LogBackgroundContext,
+		USM:               app.cfg.snmpUSM(),
+		AuthorizeFunc: func(ctx context.Context, id string) (context.Context, error) {
+			tok, _, err := authtoken.Parse(id, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			return app.IntegrationKeyStore.Authorize(ctx, *tok, integrationkey.TypeSNMP)
+		},
+		RulesFunc: app.IntegrationKeyStore.FindSNMPRules,
+		CreateAlertFunc: func(ctx context.Context, a *alert.Alert) error {
+			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
+			return err
+		},
+	}
+
+	var err error
+	app.snmpTrapConn, err = net.ListenPacket("udp", app.cfg.SNMPTrapListenAddr)
+	if err != nil {
+		return err
+	}
+	app.snmpTrapSrv = snmptrap.NewServer(cfg)
+
+	return nil
+}
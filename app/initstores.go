@@ -7,15 +7,18 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/archive"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/engine/jobqueue"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
@@ -25,16 +28,27 @@ import (
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
+	"github.com/target/goalert/pdimport"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/requesttrace"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/servicenow"
+	"github.com/target/goalert/slo"
+	"github.com/target/goalert/statuscallback"
+	"github.com/target/goalert/statuspage"
+	"github.com/target/goalert/svctemplate"
+	"github.com/target/goalert/team"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/delegation"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
+	"github.com/target/goalert/userimport"
 
 	"github.com/pkg/errors"
 )
@@ -53,12 +67,18 @@ func (app *App) initStores(ctx context.Context) error {
 			FallbackURL:        fallback.String(),
 			ExplicitURL:        app.cfg.PublicURL,
 			IngressEmailDomain: app.cfg.EmailIntegrationDomain,
+			SNMPTrapEnabled:    app.cfg.SNMPTrapListenAddr != "",
+			SyslogEnabled:      app.cfg.SyslogListenAddr != "" || app.cfg.SyslogListenAddrTLS != "",
+			KafkaEnabled:       app.cfg.KafkaBrokers != "",
+			MQTTEnabled:        app.cfg.MQTTBroker != "",
 		}
 		app.ConfigStore, err = config.NewStore(ctx, storeCfg)
 	}
 	if err != nil {
 		return errors.Wrap(err, "init config store")
 	}
+	app.dbConfigChanged(ctx, config.Config{}, app.ConfigStore.Config())
+	app.ConfigStore.OnChange(app.dbConfigChanged)
 	if app.cfg.InitialConfig != nil {
 		permission.SudoContext(ctx, func(ctx context.Context) {
 			err = app.ConfigStore.SetConfig(ctx, *app.cfg.InitialConfig)
@@ -130,7 +150,7 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.AlertMetricsStore == nil {
-		app.AlertMetricsStore, err = alertmetrics.NewStore(ctx, app.db)
+		app.AlertMetricsStore, err = alertmetrics.NewStore(ctx, app.readDB())
 	}
 	if err != nil {
 		return errors.Wrap(err, "init alert metrics store")
@@ -149,6 +169,23 @@ func (app *App) initStores(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "init alert store")
 	}
+	app.AlertStore.UseReadDB(app.readDB())
+
+	if app.AlertArchiveStore == nil {
+		var b archive.Backend
+		switch {
+		case app.cfg.AlertArchiveS3URL != "":
+			b, err = archive.NewS3Backend(ctx, app.cfg.AlertArchiveS3URL)
+		case app.cfg.AlertArchiveDir != "":
+			b, err = archive.NewDirBackend(app.cfg.AlertArchiveDir)
+		}
+		if err == nil && b != nil {
+			app.AlertArchiveStore, err = archive.NewStore(ctx, app.db, b)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "init alert archive store")
+	}
 
 	if app.ContactMethodStore == nil {
 		app.ContactMethodStore = &contactmethod.Store{}
@@ -233,6 +270,7 @@ func (app *App) initStores(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "init notification store")
 	}
+	app.NotificationStore.UseReadDB(app.readDB())
 
 	if app.FavoriteStore == nil {
 		app.FavoriteStore, err = favorite.NewStore(ctx, app.db)
@@ -241,6 +279,27 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init favorite store")
 	}
 
+	if app.UnavailabilityStore == nil {
+		app.UnavailabilityStore, err = unavailability.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init user unavailability store")
+	}
+
+	if app.DelegationStore == nil {
+		app.DelegationStore, err = delegation.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init user delegation store")
+	}
+
+	if app.TeamStore == nil {
+		app.TeamStore, err = team.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init team store")
+	}
+
 	if app.OverrideStore == nil {
 		app.OverrideStore, err = override.NewStore(ctx, app.db)
 	}
@@ -254,6 +313,42 @@ func (app *App) initStores(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "init limit config store")
 	}
+	if app.JiraStore == nil {
+		app.JiraStore, err = jira.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init jira store")
+	}
+	if app.ServiceNowStore == nil {
+		app.ServiceNowStore, err = servicenow.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init servicenow store")
+	}
+	if app.StatuspageStore == nil {
+		app.StatuspageStore, err = statuspage.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init statuspage store")
+	}
+	if app.StatusCallbackStore == nil {
+		app.StatusCallbackStore, err = statuscallback.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init status callback store")
+	}
+	if app.SLOStore == nil {
+		app.SLOStore, err = slo.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init SLO store")
+	}
+	if app.RequestTraceStore == nil {
+		app.RequestTraceStore, err = requesttrace.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init request trace store")
+	}
 	if app.HeartbeatStore == nil {
 		app.HeartbeatStore, err = heartbeat.NewStore(ctx, app.db)
 	}
@@ -268,7 +363,7 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.OnCallStore == nil {
-		app.OnCallStore, err = oncall.NewStore(ctx, app.db, app.ScheduleRuleStore, app.ScheduleStore)
+		app.OnCallStore, err = oncall.NewStore(ctx, app.db, app.ScheduleRuleStore, app.ScheduleStore, app.UnavailabilityStore)
 	}
 	if err != nil {
 		return errors.Wrap(err, "init on-call store")
@@ -299,5 +394,44 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init API key store")
 	}
 
+	if app.PDImporter == nil {
+		app.PDImporter, err = pdimport.NewImporter(ctx, app.db, pdimport.Config{
+			UserStore:       app.UserStore,
+			ScheduleStore:   app.ScheduleStore,
+			RotationStore:   app.RotationStore,
+			RuleStore:       app.ScheduleRuleStore,
+			EscalationStore: app.EscalationStore,
+			ServiceStore:    app.ServiceStore,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init PagerDuty importer")
+	}
+
+	if app.UserImporter == nil {
+		app.UserImporter, err = userimport.NewImporter(ctx, app.db, userimport.Config{
+			UserStore: app.UserStore,
+			CMStore:   app.ContactMethodStore,
+			NRStore:   app.NotificationRuleStore,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init user importer")
+	}
+
+	if app.ServiceTemplateStore == nil {
+		app.ServiceTemplateStore, err = svctemplate.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init service template store")
+	}
+
+	if app.JobStore == nil {
+		app.JobStore, err = jobqueue.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init job store")
+	}
+
 	return nil
 }
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/syslogsrv"
+)
+
+func (app *App) initSyslogServer(ctx context.Context) error {
+	if app.cfg.SyslogListenAddr == "" && app.cfg.SyslogListenAddrTLS == "" {
+		return nil
+	}
+
+	cfg := syslogsrv.Config{
+		BackgroundContext: app.LogBackgroundContext,
+		FiltersFunc: func(ctx context.Context) (filters []integrationkey.SyslogFilter, err error) {
+			permission.SudoContext(ctx, func(ctx context.Context) {
+				filters, err = app.IntegrationKeyStore.AllSyslogFilters(ctx)
+			})
+			return filters, err
+		},
+		AuthorizeFunc: func(ctx context.Context, id string) (context.Context, error) {
+			tok, _, err := authtoken.Parse(id, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			return app.IntegrationKeyStore.Authorize(ctx, *tok, integrationkey.TypeSyslog)
+		},
+		CreateAlertFunc: func(ctx context.Context, a *alert.Alert) error {
+			_, _, err := app.AlertStore.CreateOrUpdate(ctx, a)
+			return err
+		},
+	}
+
+	app.syslogSrv = syslogsrv.NewServer(cfg)
+	if app.cfg.SyslogListenAddr != "" {
+		l, err := net.Listen("tcp", app.cfg.SyslogListenAddr)
+		if err != nil {
+			return err
+		}
+		app.syslogL = append(app.syslogL, l)
+	}
+
+	if app.cfg.SyslogListenAddrTLS != "" {
+		l, err := tls.Listen("tcp", app.cfg.SyslogListenAddrTLS, app.cfg.TLSConfigSyslog)
+		if err != nil {
+			return err
+		}
+		app.syslogL = append(app.syslogL, l)
+	}
+
+	return nil
+}
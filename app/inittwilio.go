@@ -14,6 +14,10 @@ func (app *App) initTwilio(ctx context.Context) error {
 		BaseURL: app.cfg.TwilioBaseURL,
 		CMStore: app.ContactMethodStore,
 		DB:      app.db,
+
+		AlertStore:  app.AlertStore,
+		OnCallStore: app.OnCallStore,
+		NonceStore:  app.NonceStore,
 	}
 
 	var err error
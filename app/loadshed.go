@@ -0,0 +1,121 @@
+package app
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+// tokenBucket allows bursts up to the per-minute limit, refilling continuously.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take will consume a token if available, otherwise it returns the time until one will be.
+func (b *tokenBucket) take(now time.Time, perMin int) (bool, time.Duration) {
+	rate := float64(perMin) / float64(time.Minute)
+	b.tokens = math.Min(float64(perMin), b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) / rate)
+}
+
+// loadShedder limits integration key requests, so a single misbehaving monitoring system can't
+// take down alert ingestion for everyone.
+type loadShedder struct {
+	mx        sync.Mutex
+	buckets   map[string]*tokenBucket
+	active    int
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+func newLoadShedder() *loadShedder {
+	return &loadShedder{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// prune removes buckets that have been idle long enough to be full again.
+func (l *loadShedder) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+
+	for id, b := range l.buckets {
+		if now.Sub(b.last) > time.Minute {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// acquire returns true if a request for keyID should be processed; release must be called when it is done.
+// Otherwise, it returns the reason and how long the client should wait before retrying.
+func (l *loadShedder) acquire(cfg config.Config, keyID string) (ok bool, reason string, retryAfter time.Duration) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := l.now()
+	if limit := cfg.RateLimit.IntegrationMaxConcurrent; limit > 0 && l.active >= limit {
+		return false, "concurrency", time.Second
+	}
+
+	if perMin := cfg.RateLimit.IntegrationRequestsPerMinute; perMin > 0 {
+		l.prune(now)
+		b := l.buckets[keyID]
+		if b == nil {
+			b = &tokenBucket{tokens: float64(perMin), last: now}
+			l.buckets[keyID] = b
+		}
+		ok, retryAfter = b.take(now, perMin)
+		if !ok {
+			return false, "rate", retryAfter
+		}
+	}
+
+	l.active++
+	return true, "", 0
+}
+
+func (l *loadShedder) release() {
+	l.mx.Lock()
+	l.active--
+	l.mx.Unlock()
+}
+
+// Middleware will reject integration key requests over the configured limits with a 429 and Retry-After header.
+func (l *loadShedder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		src := permission.Source(ctx)
+		if src == nil || src.Type != permission.SourceTypeIntegrationKey {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		ok, reason, retryAfter := l.acquire(config.FromContext(ctx), src.ID)
+		if !ok {
+			metricIntegrationShedTotal.WithLabelValues(reason).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		defer l.release()
+
+		next.ServeHTTP(w, req)
+	})
+}
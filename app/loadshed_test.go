@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+)
+
+func TestLoadShedder(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newLoadShedder()
+	l.now = func() time.Time { return now }
+
+	var cfg config.Config
+	cfg.RateLimit.IntegrationRequestsPerMinute = 2
+	cfg.RateLimit.IntegrationMaxConcurrent = 3
+
+	check := func(key string, expOK bool, expReason string, expRetry time.Duration) {
+		t.Helper()
+		ok, reason, retry := l.acquire(cfg, key)
+		assert.Equal(t, expOK, ok, "ok")
+		assert.Equal(t, expReason, reason, "reason")
+		assert.Equal(t, expRetry, retry, "retry after")
+	}
+
+	// burst up to the limit
+	check("a", true, "", 0)
+	check("a", true, "", 0)
+	check("a", false, "rate", 30*time.Second)
+
+	// other keys are unaffected
+	check("b", true, "", 0)
+
+	// global concurrency
+	check("c", false, "concurrency", time.Second)
+	l.release()
+	check("c", true, "", 0)
+	l.release()
+	l.release()
+	l.release()
+
+	// refill
+	now = now.Add(30 * time.Second)
+	check("a", true, "", 0)
+	check("a", false, "rate", 30*time.Second)
+	l.release()
+}
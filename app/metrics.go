@@ -18,4 +18,10 @@ var (
 		Name:      "requests_total",
 		Help:      "Total number of requests by status code.",
 	}, []string{"method", "code"})
+	metricIntegrationShedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "http_server",
+		Name:      "integration_shed_total",
+		Help:      "Total number of integration key requests rejected by load shedding, by reason (rate or concurrency).",
+	}, []string{"reason"})
 )
@@ -16,13 +16,12 @@ type _reqInfoCtxKey string
 
 const reqInfoCtxKey = _reqInfoCtxKey("request-info-fields")
 
-func maxBodySizeMiddleware(size int64) func(next http.Handler) http.Handler {
+func maxBodySizeMiddleware(size func() int64) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		if size == 0 {
-			return next
-		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Body = http.MaxBytesReader(w, r.Body, size)
+			if n := size(); n > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -47,11 +46,15 @@ func logRequestAuth(next http.Handler) http.Handler {
 	})
 }
 
-func logRequest(alwaysLog bool) func(http.Handler) http.Handler {
+func logRequest(alwaysLog func() bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			ctx := req.Context()
+			// Always assign our own ID, since it is stored with alerts and messages; the caller's is only logged.
 			ctx = log.SetRequestID(ctx)
+			ctx = log.WithClientRequestID(ctx, req.Header.Get("X-Request-ID"))
+			w.Header().Set("X-Request-ID", log.RequestID(ctx))
+			ctx = log.WithSubsystem(ctx, "HTTP")
 			ctx = log.WithFields(ctx, log.Fields{
 				"http_method":      req.Method,
 				"http_proto":       req.Proto,
@@ -107,7 +110,7 @@ func logRequest(alwaysLog bool) func(http.Handler) http.Handler {
 				}
 				return
 			}
-			if alwaysLog && req.URL.Path != "/health" {
+			if alwaysLog() && req.URL.Path != "/health" {
 				log.Logf(ctx, "request complete")
 			} else {
 				log.Debugf(ctx, "request complete")
@@ -17,6 +17,10 @@ func (app *App) Resume(ctx context.Context) error {
 }
 
 func (app *App) _pause(ctx context.Context) error {
+	app.dbPool.mx.Lock()
+	app.dbPool.paused = true
+	app.dbPool.mx.Unlock()
+
 	app.db.SetMaxIdleConns(0)
 	app.db.SetConnMaxLifetime(time.Second)
 	app.db.SetMaxOpenConns(3)
@@ -25,8 +29,10 @@ func (app *App) _pause(ctx context.Context) error {
 }
 
 func (app *App) _resume(ctx context.Context) error {
-	app.db.SetMaxOpenConns(app.cfg.DBMaxOpen)
-	app.db.SetMaxIdleConns(app.cfg.DBMaxIdle)
+	app.dbPool.mx.Lock()
+	app.dbPool.paused = false
+	app.applyPoolSize()
+	app.dbPool.mx.Unlock()
 	app.db.SetConnMaxLifetime(0)
 	app.events.Start()
 
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/target/goalert/util/log"
+)
+
+// flagState holds the settings from flags (or the config file and environment) that can be changed
+// at runtime with ReloadFlags.
+type flagState struct {
+	mx  sync.Mutex
+	cfg Config
+
+	logRequests          atomic.Bool
+	disableHTTPSRedirect atomic.Bool
+	maxReqBodyBytes      atomic.Int64
+}
+
+// reloadableFlags are the Config fields applied by ReloadFlags; changes to any other field require a restart.
+var reloadableFlags = map[string]bool{
+	"LogRequests":          true,
+	"LogEngine":            true,
+	"DisableHTTPSRedirect": true,
+	"MaxReqBodyBytes":      true,
+}
+
+// set will update the runtime-changeable settings from c.
+//
+// Caller must hold f.mx, or be initializing the App.
+func (f *flagState) set(c Config) {
+	f.cfg = c
+	f.logRequests.Store(c.LogRequests)
+	f.disableHTTPSRedirect.Store(c.DisableHTTPSRedirect)
+	f.maxReqBodyBytes.Store(c.MaxReqBodyBytes)
+}
+
+// changedFlags returns the names of Config fields that differ between a and b, separated by whether they
+// are applied at runtime. Pointer and func fields (e.g., TLS config and the logger) are not compared.
+func changedFlags(a, b Config) (reloaded, restart []string) {
+	aVal, bVal := reflect.ValueOf(a), reflect.ValueOf(b)
+	t := aVal.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		switch t.Field(i).Type.Kind() {
+		case reflect.Pointer, reflect.Func, reflect.Interface:
+			continue
+		}
+		if reflect.DeepEqual(aVal.Field(i).Interface(), bVal.Field(i).Interface()) {
+			continue
+		}
+
+		name := t.Field(i).Name
+		if reloadableFlags[name] {
+			reloaded = append(reloaded, name)
+		} else {
+			restart = append(restart, name)
+		}
+	}
+	sort.Strings(reloaded)
+	sort.Strings(restart)
+
+	return reloaded, restart
+}
+
+// ReloadFlags will apply settings from c that can be changed at runtime (request and engine cycle logging,
+// HTTPS redirects, and the max request body size). Changes to other settings (e.g., listen addresses or
+// DB URLs) are logged, but require a restart.
+func (app *App) ReloadFlags(ctx context.Context, c Config) {
+	app.flags.mx.Lock()
+	defer app.flags.mx.Unlock()
+
+	reloaded, restart := changedFlags(app.flags.cfg, c)
+	if len(restart) > 0 {
+		log.Logf(log.WithField(ctx, "Settings", strings.Join(restart, ", ")), "WARNING: Changed settings require a restart to take effect.")
+	}
+	if len(reloaded) == 0 {
+		return
+	}
+
+	// keep settings that were not applied, so they are reported again until restarted
+	cfg := app.flags.cfg
+	cfg.LogRequests = c.LogRequests
+	cfg.LogEngine = c.LogEngine
+	cfg.DisableHTTPSRedirect = c.DisableHTTPSRedirect
+	cfg.MaxReqBodyBytes = c.MaxReqBodyBytes
+	app.flags.set(cfg)
+	if app.Engine != nil {
+		app.Engine.SetLogCycles(c.LogEngine)
+	}
+
+	log.Logf(log.WithField(ctx, "Settings", strings.Join(reloaded, ", ")), "Reloaded settings.")
+}
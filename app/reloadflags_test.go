@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedFlags(t *testing.T) {
+	a := Config{ListenAddr: ":8081", MaxReqBodyBytes: 1024, TLSConfig: &tls.Config{}}
+	b := a
+	b.TLSConfig = &tls.Config{} // pointers are not compared
+	reloaded, restart := changedFlags(a, b)
+	assert.Empty(t, reloaded)
+	assert.Empty(t, restart)
+
+	b.ListenAddr = ":8082"
+	b.MaxReqBodyBytes = 2048
+	b.LogRequests = true
+	reloaded, restart = changedFlags(a, b)
+	assert.Equal(t, []string{"LogRequests", "MaxReqBodyBytes"}, reloaded)
+	assert.Equal(t, []string{"ListenAddr"}, restart)
+}
+
+func TestApp_ReloadFlags(t *testing.T) {
+	cfg := Config{ListenAddr: ":8081", MaxReqBodyBytes: 1024}
+	var app App
+	app.flags.set(cfg)
+
+	cfg.ListenAddr = ":8082"
+	cfg.MaxReqBodyBytes = 0
+	cfg.LogRequests = true
+	cfg.DisableHTTPSRedirect = true
+	app.ReloadFlags(context.Background(), cfg)
+
+	assert.Equal(t, int64(0), app.flags.maxReqBodyBytes.Load())
+	assert.True(t, app.flags.logRequests.Load())
+	assert.True(t, app.flags.disableHTTPSRedirect.Load())
+
+	_, restart := changedFlags(app.flags.cfg, cfg)
+	assert.Equal(t, []string{"ListenAddr"}, restart, "still requires a restart")
+}
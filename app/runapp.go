@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 
@@ -10,7 +11,11 @@ import (
 	"github.com/pkg/errors"
 )
 
-var triggerSignals []os.Signal
+var (
+	triggerSignals []os.Signal
+	drainSignals   []os.Signal
+	reloadSignals  []os.Signal
+)
 
 // Run will start the application and start serving traffic.
 func (app *App) Run(ctx context.Context) error {
@@ -50,6 +55,42 @@ func (app *App) _Run(ctx context.Context) error {
 		}()
 	}
 
+	if app.snmpTrapSrv != nil {
+		log.Logf(log.WithField(ctx, "address", app.snmpTrapConn.LocalAddr().String()), "SNMP trap listener started.")
+		go func() {
+			if err := app.snmpTrapSrv.ServeSNMP(app.snmpTrapConn); err != nil {
+				log.Log(ctx, err)
+			}
+		}()
+	}
+
+	for _, l := range app.syslogL {
+		log.Logf(log.WithField(ctx, "address", l.Addr().String()), "Syslog listener started.")
+		go func(l net.Listener) {
+			if err := app.syslogSrv.ServeSyslog(l); err != nil {
+				log.Log(ctx, err)
+			}
+		}(l)
+	}
+
+	if app.kafkaConsumer != nil {
+		log.Logf(log.WithField(ctx, "topic", app.cfg.KafkaTopic), "Kafka consumer started.")
+		go func() {
+			if err := app.kafkaConsumer.Consume(); err != nil {
+				log.Log(ctx, err)
+			}
+		}()
+	}
+
+	if app.mqttSub != nil {
+		log.Logf(log.WithField(ctx, "broker", app.cfg.MQTTBroker), "MQTT subscriber started.")
+		go func() {
+			if err := app.mqttSub.Run(); err != nil {
+				log.Log(ctx, err)
+			}
+		}()
+	}
+
 	log.Logf(
 		log.WithFields(ctx, log.Fields{
 			"address": app.l.Addr().String(),
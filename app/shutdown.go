@@ -18,6 +18,9 @@ func (app *App) Shutdown(ctx context.Context) error {
 func (app *App) _Shutdown(ctx context.Context) error {
 	defer close(app.doneCh)
 	defer app.db.Close()
+	if app.replicaDB != nil {
+		defer app.replicaDB.Close()
+	}
 	var errs []error
 	if app.hSrv != nil {
 		app.hSrv.Shutdown()
@@ -58,6 +61,10 @@ func (app *App) _Shutdown(ctx context.Context) error {
 	// shutting down things like the engine or notification manager
 	// that would still need to process them.
 	shut(app.smtpsrv, "SMTP receiver server")
+	shut(app.snmpTrapSrv, "SNMP trap listener")
+	shut(app.syslogSrv, "syslog listener")
+	shut(app.kafkaConsumer, "Kafka consumer")
+	shut(app.mqttSub, "MQTT subscriber")
 	shut(app.srv, "HTTP server")
 	shut(app.Engine, "engine")
 	shut(app.events, "event listener")
@@ -10,4 +10,6 @@ import (
 func init() {
 	shutdownSignals = append(shutdownSignals, syscall.SIGTERM)
 	triggerSignals = append(triggerSignals, syscall.SIGUSR2)
+	drainSignals = append(drainSignals, syscall.SIGUSR1)
+	reloadSignals = append(reloadSignals, syscall.SIGHUP)
 }
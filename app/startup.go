@@ -69,7 +69,8 @@ func (app *App) startup(ctx context.Context) error {
 		ctx, "Startup.Twilio", app.initTwilio)
 
 	app.initStartup(ctx, "Startup.Slack", app.initSlack)
-	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", email.NewSender(ctx))
+	app.smtpSender = email.NewSender(ctx)
+	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", app.smtpSender)
 	app.notificationManager.RegisterSender(notification.DestTypeUserWebhook, "webhook-user", webhook.NewSender(ctx))
 	app.notificationManager.RegisterSender(notification.DestTypeChanWebhook, "webhook-channel", webhook.NewSender(ctx))
 
@@ -81,6 +82,10 @@ func (app *App) startup(ctx context.Context) error {
 	app.initStartup(ctx, "Startup.SysAPI", app.initSysAPI)
 
 	app.initStartup(ctx, "Startup.SMTPServer", app.initSMTPServer)
+	app.initStartup(ctx, "Startup.SNMPTrapServer", app.initSNMPTrapServer)
+	app.initStartup(ctx, "Startup.SyslogServer", app.initSyslogServer)
+	app.initStartup(ctx, "Startup.KafkaConsumer", app.initKafkaConsumer)
+	app.initStartup(ctx, "Startup.MQTTSubscriber", app.initMQTTSubscriber)
 
 	if app.startupErr != nil {
 		return app.startupErr
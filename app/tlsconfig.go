@@ -2,7 +2,9 @@ package app
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 
 	"github.com/spf13/viper"
 )
@@ -51,3 +53,23 @@ func getTLSConfig(t tlsFlagPrefix) (*tls.Config, error) {
 
 	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
 }
+
+// getClientTLSConfig creates a TLS config for connecting to external services. If caFile
+// is set, only the certificate(s) it contains are trusted.
+func getClientTLSConfig(caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	cfg.RootCAs = x509.NewCertPool()
+	if !cfg.RootCAs.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("read CA file: no certificates found in %s", caFile)
+	}
+
+	return cfg, nil
+}
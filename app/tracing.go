@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/target/goalert/app")
+
+// initTracing will configure the global tracer provider to export spans to the OTLP endpoint, if set,
+// and wrap http.DefaultTransport so outgoing requests (e.g., to Twilio, Slack, and webhooks) are traced.
+//
+// The returned function will flush any pending spans.
+func initTracing(ctx context.Context, cfg Config) (func(), error) {
+	// always propagate, so requests passing through GoAlert keep their trace, even if we don't record it
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.OTLPEndpoint == "" {
+		return func() {}, nil
+	}
+
+	u, err := url.Parse(cfg.OTLPEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse OTLP endpoint")
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, otlptracehttp.WithInsecure())
+	case "https":
+	default:
+		return nil, errors.Errorf("parse OTLP endpoint: unsupported scheme '%s' (must be http or https)", u.Scheme)
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+
+	exp, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "init OTLP exporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("goalert"),
+		semconv.ServiceVersion(version.GitVersion()),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "init trace resource")
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingProbability))),
+	)
+	otel.SetTracerProvider(tp)
+	http.DefaultTransport = &traceTransport{RoundTripper: http.DefaultTransport}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := tp.Shutdown(ctx)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "flush traces"))
+		}
+	}, nil
+}
+
+// traceTransport will record a span for each request and propagate the trace context to the remote server.
+type traceTransport struct {
+	http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethod(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.HTTPRoute(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// traceRequest will continue (or start) a trace for each inbound request.
+func traceRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer.Start(ctx, "HTTP "+req.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(req.Method),
+				semconv.HTTPTarget(req.URL.Path),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsSampled() {
+			ctx = log.WithField(ctx, "TraceID", sc.TraceID().String())
+		}
+
+		m := httpsnoop.CaptureMetricsFn(w, func(w http.ResponseWriter) {
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+
+		span.SetAttributes(semconv.HTTPStatusCode(m.Code))
+		if m.Code >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", m.Code))
+		}
+	})
+}
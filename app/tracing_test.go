@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracePropagation(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var got trace.SpanContext
+	srv := httptest.NewServer(traceRequest(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = trace.SpanContextFromContext(req.Context())
+	})))
+	defer srv.Close()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: &traceTransport{RoundTripper: http.DefaultTransport}}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, sc.TraceID(), got.TraceID(), "trace ID")
+	assert.True(t, got.IsRemote(), "remote")
+}
@@ -61,6 +61,8 @@ type (
 	CalendarSubscriptionTarget string
 	// UserSessionTarget implements the Target interface by wrapping a UserSession ID.
 	UserSessionTarget string
+	// TeamTarget implements the Target interface by wrapping a Team ID.
+	TeamTarget string
 )
 
 // TargetType implements the Target interface.
@@ -146,3 +148,9 @@ func (UserSessionTarget) TargetType() TargetType { return TargetTypeUserSession
 
 // TargetID implements the Target interface.
 func (s UserSessionTarget) TargetID() string { return string(s) }
+
+// TargetType implements the Target interface.
+func (TeamTarget) TargetType() TargetType { return TargetTypeTeam }
+
+// TargetID implements the Target interface.
+func (t TeamTarget) TargetID() string { return string(t) }
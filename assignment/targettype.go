@@ -33,6 +33,7 @@ const (
 	TargetTypeContactMethod
 	TargetTypeHeartbeatMonitor
 	TargetTypeUserSession
+	TargetTypeTeam
 )
 
 var (
@@ -79,6 +80,8 @@ func (tt *TargetType) UnmarshalText(data []byte) error {
 		*tt = TargetTypeHeartbeatMonitor
 	case "userSession":
 		*tt = TargetTypeUserSession
+	case "team":
+		*tt = TargetTypeTeam
 	default:
 		return validation.NewFieldError("TargetType", "unknown target type "+str)
 	}
@@ -131,6 +134,8 @@ func (tt TargetType) MarshalText() ([]byte, error) {
 		return []byte("heartbeatMonitor"), nil
 	case TargetTypeUserSession:
 		return []byte("userSession"), nil
+	case TargetTypeTeam:
+		return []byte("team"), nil
 	}
 
 	return nil, validation.NewFieldError("TargetType", "unknown target type "+tt.String())
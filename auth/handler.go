@@ -98,7 +98,9 @@ func NewHandler(ctx context.Context, db *sql.DB, cfg HandlerConfig) (*Handler, e
 		`),
 		startSession: p.P(`
 			insert into auth_user_sessions (id, user_agent, user_id)
-			values ($1, $2, $3)
+			select $1, $2, id
+			from users
+			where id = $3 and deactivated_at isnull
 		`),
 		endSession: p.P(`
 			delete from auth_user_sessions
@@ -119,7 +121,7 @@ func NewHandler(ctx context.Context, db *sql.DB, cfg HandlerConfig) (*Handler, e
 			)
 			select sess.user_id, u.role
 			from auth_user_sessions sess
-			join users u on u.id = sess.user_id
+			join users u on u.id = sess.user_id and u.deactivated_at isnull
 			where sess.id = $1
 		`),
 
@@ -531,10 +533,17 @@ func (h *Handler) CreateSession(ctx context.Context, userAgent, userID string) (
 		Type:    authtoken.TypeSession,
 		ID:      uuid.New(),
 	}
-	_, err := h.startSession.ExecContext(ctx, tok.ID.String(), userAgent, userID)
+	res, err := h.startSession.ExecContext(ctx, tok.ID.String(), userAgent, userID)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
 	if err != nil {
 		return nil, err
 	}
+	if n == 0 {
+		return nil, Error("This account has been deactivated.")
+	}
 
 	return tok, nil
 }
@@ -556,7 +565,8 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 	}
 
 	ctx := req.Context()
-	if expflag.ContextHas(ctx, expflag.GQLAPIKey) && req.URL.Path == "/api/graphql" && strings.HasPrefix(tokStr, "ey") {
+	isAPIKeyPath := req.URL.Path == "/api/graphql" || strings.HasPrefix(req.URL.Path, "/api/rest/v1/")
+	if expflag.ContextHas(ctx, expflag.GQLAPIKey) && isAPIKeyPath && strings.HasPrefix(tokStr, "ey") {
 		ctx, err = h.cfg.APIKeyStore.AuthorizeGraphQL(ctx, tokStr, req.UserAgent(), req.RemoteAddr)
 		if errutil.HTTPError(req.Context(), w, err) {
 			return true
@@ -578,7 +588,7 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 	}
 
 	switch req.URL.Path {
-	case "/v1/api/alerts", "/api/v2/generic/incoming":
+	case "/v1/api/alerts", "/api/v2/generic/incoming", "/api/v2/generic/batch":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGeneric)
 	case "/v1/webhooks/grafana", "/api/v2/grafana/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGrafana)
@@ -586,6 +596,28 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSite24x7)
 	case "/api/v2/prometheusalertmanager/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypePrometheusAlertmanager)
+	case "/api/v2/datadog/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeDatadog)
+	case "/api/v2/amazonsns/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeAmazonSNS)
+	case "/api/v2/sentry/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSentry)
+	case "/api/v2/zabbix/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeZabbix)
+	case "/api/v2/azuremonitor/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeAzureMonitor)
+	case "/api/v2/gcpmonitoring/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGCPMonitoring)
+	case "/api/v2/newrelic/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeNewRelic)
+	case "/api/v2/splunk/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSplunk)
+	case "/api/v2/nagios/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeNagios)
+	case "/api/v2/githubactions/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGitHubActions)
+	case "/api/v2/grafanaoncall/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGrafanaOnCall)
 	case "/api/v2/calendar":
 		ctx, err = h.cfg.CalSubStore.Authorize(ctx, *tok)
 	default:
@@ -651,6 +683,18 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			wrapped.ServeHTTP(w, req)
 			return
 		}
+		if req.URL.Path == "/api/v2/pagerduty/enqueue" || req.URL.Path == "/v2/enqueue" {
+			// PagerDuty Events API clients send the integration key
+			// in the request body as the routing key.
+			wrapped.ServeHTTP(w, req)
+			return
+		}
+		if req.URL.Path == "/api/v2/servicenow/incident" {
+			// ServiceNow callbacks authenticate with the configured
+			// callback secret.
+			wrapped.ServeHTTP(w, req)
+			return
+		}
 		if req.URL.Path == "/api/v2/mailgun/incoming" || req.URL.Path == "/v1/webhooks/mailgun" {
 			// Mailgun handles it's own auth and has special
 			// requirements on status codes, so we pass it through
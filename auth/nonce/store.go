@@ -19,6 +19,7 @@ type Store struct {
 	shutdown chan context.Context
 
 	consume *sql.Stmt
+	release *sql.Stmt
 	cleanup *sql.Stmt
 }
 
@@ -36,6 +37,7 @@ func NewStore(ctx context.Context, logger *log.Logger, db *sql.DB) (*Store, erro
 			values ($1)
 			on conflict do nothing
 		`),
+		release: p.P(`delete from auth_nonce where id = $1`),
 		cleanup: p.P(`
 			delete from auth_nonce
 			where created_at < now() - '1 week'::interval
@@ -97,3 +99,10 @@ func (s *Store) Consume(ctx context.Context, id [16]byte) (bool, error) {
 	n, _ := res.RowsAffected()
 	return n == 1, nil
 }
+
+// Release will forget a consumed nonce value, so the next call to Consume for it
+// will return true.
+func (s *Store) Release(ctx context.Context, id [16]byte) error {
+	_, err := s.release.ExecContext(ctx, uuid.UUID(id).String())
+	return err
+}
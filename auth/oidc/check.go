@@ -0,0 +1,21 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/target/goalert/config"
+)
+
+// CheckDiscovery will verify the configured issuer URL serves a valid OIDC discovery document.
+func CheckDiscovery(ctx context.Context) error {
+	cfg := config.FromContext(ctx)
+
+	_, err := oidc.NewProvider(ctx, cfg.OIDC.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("discovery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package azuremonitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// CommonAlertSchemaID is the schemaId of Azure Monitor's common alert schema.
+const CommonAlertSchemaID = "azureMonitorCommonAlertSchema"
+
+type postBody struct {
+	SchemaID string `json:"schemaId"`
+	Data     struct {
+		Essentials   essentials      `json:"essentials"`
+		AlertContext json.RawMessage `json:"alertContext"`
+	} `json:"data"`
+}
+
+type essentials struct {
+	AlertID           string   `json:"alertId"`
+	AlertRule         string   `json:"alertRule"`
+	Severity          string   `json:"severity"`
+	SignalType        string   `json:"signalType"`
+	MonitorCondition  string   `json:"monitorCondition"`
+	MonitoringService string   `json:"monitoringService"`
+	AlertTargetIDs    []string `json:"alertTargetIDs"`
+	Description       string   `json:"description"`
+	FiredDateTime     string   `json:"firedDateTime"`
+}
+
+// severityLevel returns the numeric level of an Azure severity (Sev0 is the most severe),
+// or -1 if it is invalid.
+func severityLevel(s string) int {
+	if !strings.HasPrefix(s, "Sev") {
+		return -1
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(s, "Sev"))
+	if err != nil || n < 0 || n > 4 {
+		return -1
+	}
+	return n
+}
+
+func (e essentials) details(alertContext []byte) string {
+	var s strings.Builder
+	if e.Description != "" {
+		s.WriteString(e.Description + "\n\n")
+	}
+	fmt.Fprintf(&s, "Severity: %s\n\n", e.Severity)
+	if e.SignalType != "" {
+		fmt.Fprintf(&s, "Signal: %s (%s)\n\n", e.SignalType, e.MonitoringService)
+	}
+	for _, id := range e.AlertTargetIDs {
+		fmt.Fprintf(&s, "Target: %s\n\n", id)
+	}
+	if len(alertContext) > 0 {
+		var buf bytes.Buffer
+		if json.Indent(&buf, alertContext, "", "  ") == nil {
+			fmt.Fprintf(&s, "## Alert Context\n\n```json\n%s\n```\n", buf.String())
+		}
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// alertFromBody will return the alert for a common alert schema payload, or nil if a fired
+// alert is less severe than minSev and should be ignored.
+func alertFromBody(serviceID string, body postBody, minSev int) (*alert.Alert, error) {
+	e := body.Data.Essentials
+	if e.AlertID == "" {
+		return nil, errors.New("azure monitor: missing alertId")
+	}
+
+	var status alert.Status
+	switch e.MonitorCondition {
+	case "Fired":
+		status = alert.StatusTriggered
+		if lvl := severityLevel(e.Severity); lvl > minSev {
+			return nil, nil
+		}
+	case "Resolved":
+		status = alert.StatusClosed
+	default:
+		return nil, errors.Errorf("azure monitor: missing or invalid monitorCondition: %s", e.MonitorCondition)
+	}
+
+	summary := e.AlertRule
+	if e.Severity != "" {
+		summary = "[" + e.Severity + "] " + summary
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(e.details(body.Data.AlertContext), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceAzureMonitor,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(e.AlertID),
+	}, nil
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// AzureMonitorToEventsAPI handles action group webhooks using the common alert schema.
+//
+// The optional `minSeverity` query parameter (e.g., `Sev2`) causes fired alerts of lower
+// severity to be ignored.
+func AzureMonitorToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		minSev := 4
+		if s := r.FormValue("minSeverity"); s != "" {
+			minSev = severityLevel(s)
+			if minSev == -1 {
+				http.Error(w, "invalid minSeverity", http.StatusBadRequest)
+				return
+			}
+		}
+
+		var body postBody
+		err = json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from azure monitor: %v", err)
+			return
+		}
+		if body.SchemaID != CommonAlertSchemaID {
+			log.Logf(ctx, "bad request from azure monitor: unsupported schema '%s'", body.SchemaID)
+			http.Error(w, "common alert schema must be enabled", http.StatusBadRequest)
+			return
+		}
+
+		e := body.Data.Essentials
+		ctx = log.WithFields(ctx, log.Fields{
+			"AzureAlertID":     e.AlertID,
+			"MonitorCondition": e.MonitorCondition,
+		})
+		msg, err := alertFromBody(serviceID, body, minSev)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from azure monitor: %v", err)
+			return
+		}
+		if msg == nil {
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for azure monitor")) {
+			return
+		}
+	}
+}
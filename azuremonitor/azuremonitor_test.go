@@ -0,0 +1,77 @@
+package azuremonitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestAlertFromBody(t *testing.T) {
+	parse := func(data string) postBody {
+		t.Helper()
+		var body postBody
+		require.NoError(t, json.Unmarshal([]byte(data), &body))
+		return body
+	}
+
+	const alertID = "/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.AlertsManagement/alerts/12345"
+	a, err := alertFromBody("svc", parse(`{
+		"schemaId": "azureMonitorCommonAlertSchema",
+		"data": {
+			"essentials": {"alertId": "`+alertID+`", "alertRule": "High CPU", "severity": "Sev1", "signalType": "Metric", "monitorCondition": "Fired", "monitoringService": "Platform"},
+			"alertContext": {"condition": {"windowSize": "PT5M"}}
+		}
+	}`), 4)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "[Sev1] High CPU", a.Summary)
+	assert.Equal(t, alert.NewUserDedup(alertID), a.Dedup)
+	assert.Contains(t, a.Details, `"windowSize": "PT5M"`)
+
+	a, err = alertFromBody("svc", parse(`{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"alertId": "`+alertID+`", "alertRule": "High CPU", "severity": "Sev1", "monitorCondition": "Resolved"}}}`), 4)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup(alertID), a.Dedup)
+
+	// fired alerts less severe than the minimum are ignored, resolutions are not
+	a, err = alertFromBody("svc", parse(`{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"alertId": "a", "severity": "Sev3", "monitorCondition": "Fired"}}}`), 2)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+	a, err = alertFromBody("svc", parse(`{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"alertId": "a", "severity": "Sev3", "monitorCondition": "Resolved"}}}`), 2)
+	require.NoError(t, err)
+	assert.NotNil(t, a)
+
+	_, err = alertFromBody("svc", parse(`{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"monitorCondition": "Fired"}}}`), 4)
+	assert.Error(t, err, "missing alertId")
+	_, err = alertFromBody("svc", parse(`{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"alertId": "a", "monitorCondition": "Unknown"}}}`), 4)
+	assert.Error(t, err, "invalid monitorCondition")
+}
+
+func TestAzureMonitorToEventsAPI_BadRequest(t *testing.T) {
+	h := AzureMonitorToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, query, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/azuremonitor/incoming"+query, strings.NewReader(body)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", "", `{`)
+	check("legacy schema", "", `{"schemaId": "AzureMonitorMetricAlert", "data": {}}`)
+	check("missing alertId", "", `{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"monitorCondition": "Fired"}}}`)
+	check("invalid monitorCondition", "", `{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {"alertId": "a"}}}`)
+	check("invalid minSeverity", "?minSeverity=Sev9", `{}`)
+}
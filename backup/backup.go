@@ -0,0 +1,427 @@
+// Package backup implements encrypted logical backups of the GoAlert database.
+//
+// A backup contains the rows of every GoAlert table, read from a single consistent
+// snapshot, along with sequence values. It only requires the privileges GoAlert itself
+// uses, so it can be taken and restored without database administrator access.
+//
+// Encrypted values in the database (e.g., config secrets) are copied as-is, so the
+// restored instance must use the same --data-encryption-key.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// FormatVersion is the version of the backup file format.
+const FormatVersion = 1
+
+// maxBatchSize is the maximum size of rows inserted in a single statement during restore.
+const maxBatchSize = 1024 * 1024 // 1MB
+
+// Header is the first record of a backup.
+type Header struct {
+	Version   int
+	CreatedAt time.Time
+
+	// Migration is the name of the latest migration applied to the source database. It must
+	// match the destination database when restoring.
+	Migration string
+
+	ExcludeClosedAlerts bool
+}
+
+// tableRecord precedes the rows of each table.
+type tableRecord struct {
+	Table string
+	Rows  int64
+}
+
+// sequenceRecord is the final record of a backup.
+type sequenceRecord struct {
+	Sequences []sequence
+}
+
+type sequence struct {
+	Name      string
+	LastValue int64
+	IsCalled  bool
+}
+
+// Options configures a backup.
+type Options struct {
+	// Recipients the backup is encrypted to (e.g., from PassphraseRecipient or age.ParseRecipients).
+	Recipients []age.Recipient
+
+	// ExcludeClosedAlerts will omit closed alerts, and rows that refer to them (e.g., logs).
+	ExcludeClosedAlerts bool
+}
+
+// Write will write an encrypted backup of the database to w.
+func Write(ctx context.Context, db *sql.DB, w io.Writer, opts Options) error {
+	s, err := migrate.Status(ctx, db)
+	if err != nil {
+		return fmt.Errorf("get migration status: %w", err)
+	}
+	if len(s.Pending) > 0 || len(s.Unknown) > 0 {
+		return fmt.Errorf("database must be migrated to '%s' (the latest migration for this version) before backup, but is at '%s'", s.Expected, s.Latest)
+	}
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "backup: write", tx)
+
+	// deferrable waits for a snapshot that can't conflict, so the backup never fails
+	// due to concurrent writes
+	_, err = tx.ExecContext(ctx, "set transaction isolation level serializable, read only, deferrable")
+	if err != nil {
+		return fmt.Errorf("set tx mode: %w", err)
+	}
+
+	tables, err := scanTables(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	enc, err := newEncryptWriter(w, opts.Recipients)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(enc)
+	bw := bufio.NewWriter(gz)
+	out := json.NewEncoder(bw)
+
+	err = out.Encode(Header{
+		Version:             FormatVersion,
+		CreatedAt:           time.Now(),
+		Migration:           s.Latest,
+		ExcludeClosedAlerts: opts.ExcludeClosedAlerts,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tables {
+		where := "true"
+		if opts.ExcludeClosedAlerts && t.filter != "" {
+			where = t.filter
+		}
+
+		n, err := writeTable(ctx, tx, out, t.name, where)
+		if err != nil {
+			return fmt.Errorf("backup table %s: %w", t.name, err)
+		}
+		log.Debugf(ctx, "backup: %s: %d rows", t.name, n)
+	}
+
+	seqs, err := readSequences(ctx, tx)
+	if err != nil {
+		return err
+	}
+	err = out.Encode(sequenceRecord{Sequences: seqs})
+	if err != nil {
+		return err
+	}
+
+	err = bw.Flush()
+	if err != nil {
+		return err
+	}
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func writeTable(ctx context.Context, tx *sql.Tx, out *json.Encoder, name, where string) (int64, error) {
+	var count int64
+	err := tx.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s where %s", sqlutil.QuoteID(name), where)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count: %w", err)
+	}
+	err = out.Encode(tableRecord{Table: name, Rows: count})
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("select to_jsonb(tbl_row) from %s as tbl_row where %s", sqlutil.QuoteID(name), where))
+	if err != nil {
+		return 0, fmt.Errorf("select: %w", err)
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		var row json.RawMessage
+		err = rows.Scan(&row)
+		if err != nil {
+			return 0, fmt.Errorf("scan: %w", err)
+		}
+		err = out.Encode(row)
+		if err != nil {
+			return 0, err
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if n != count {
+		// should be impossible within a serializable snapshot
+		return 0, fmt.Errorf("row count changed during backup (expected %d, got %d)", count, n)
+	}
+
+	return n, nil
+}
+
+func readSequences(ctx context.Context, tx *sql.Tx) ([]sequence, error) {
+	rows, err := tx.QueryContext(ctx, `
+		select sequence_name::text
+		from information_schema.sequences
+		where sequence_catalog = current_database()
+			and sequence_schema = 'public'
+			and sequence_name != 'change_log_id_seq'
+		order by sequence_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list sequences: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan sequence name: %w", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	seqs := make([]sequence, 0, len(names))
+	for _, name := range names {
+		seq := sequence{Name: name}
+		err = tx.QueryRowContext(ctx, "select last_value, is_called from "+sqlutil.QuoteID(name)).Scan(&seq.LastValue, &seq.IsCalled)
+		if err != nil {
+			return nil, fmt.Errorf("read sequence %s: %w", name, err)
+		}
+		seqs = append(seqs, seq)
+	}
+
+	return seqs, nil
+}
+
+// RestoreOptions configures a restore.
+type RestoreOptions struct {
+	// Identities used to decrypt the backup (e.g., from PassphraseIdentity or age.ParseIdentities).
+	Identities []age.Identity
+
+	// Overwrite allows restoring into a database that already contains data, replacing it.
+	Overwrite bool
+}
+
+// inUseTables are checked for rows to determine if a database is already in use.
+var inUseTables = []string{"users", "services", "alerts"}
+
+// Restore will restore an encrypted backup from r into db, in a single transaction.
+//
+// The database must already be migrated to the same migration as the backup.
+func Restore(ctx context.Context, db *sql.DB, r io.Reader, opts RestoreOptions) (*Header, error) {
+	dec, err := newDecryptReader(r, opts.Identities)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(dec)
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	in := json.NewDecoder(bufio.NewReader(gz))
+
+	var hdr Header
+	err = in.Decode(&hdr)
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if hdr.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", hdr.Version)
+	}
+
+	s, err := migrate.Status(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("get migration status: %w", err)
+	}
+	if s.Latest != hdr.Migration {
+		return nil, fmt.Errorf("backup was taken at migration '%s' but the database is at '%s'; run `goalert migrate --up %s` against an empty database first", hdr.Migration, s.Latest, hdr.Migration)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "backup: restore", tx)
+
+	for _, name := range inUseTables {
+		var hasRows bool
+		err = tx.QueryRowContext(ctx, fmt.Sprintf("select exists (select 1 from %s)", sqlutil.QuoteID(name))).Scan(&hasRows)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", name, err)
+		}
+		if hasRows && !opts.Overwrite {
+			return nil, fmt.Errorf("database is not empty (%s has rows); restore to a new database or use overwrite", name)
+		}
+	}
+
+	tables, err := scanTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		names = append(names, sqlutil.QuoteID(t.name))
+	}
+	if len(names) > 0 {
+		// clears rows created by migrations (or existing data, when overwriting)
+		_, err = tx.ExecContext(ctx, "truncate "+strings.Join(names, ", "))
+		if err != nil {
+			return nil, fmt.Errorf("truncate: %w", err)
+		}
+	}
+
+	// restored rows already include anything triggers would have created (e.g., alert logs)
+	for _, name := range names {
+		_, err = tx.ExecContext(ctx, "alter table "+name+" disable trigger user")
+		if err != nil {
+			return nil, fmt.Errorf("disable triggers: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "set constraints all deferred")
+	if err != nil {
+		return nil, fmt.Errorf("defer constraints: %w", err)
+	}
+
+	for {
+		var rec struct {
+			tableRecord
+			sequenceRecord
+		}
+		err = in.Decode(&rec)
+		if err != nil {
+			return nil, fmt.Errorf("read backup: %w", err)
+		}
+		if rec.Table == "" {
+			err = restoreSequences(ctx, tx, rec.Sequences)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		err = restoreTable(ctx, tx, in, rec.tableRecord)
+		if err != nil {
+			return nil, fmt.Errorf("restore table %s: %w", rec.Table, err)
+		}
+		log.Debugf(ctx, "restore: %s: %d rows", rec.Table, rec.Rows)
+	}
+
+	// ensure the stream is fully consumed so the final chunk is authenticated
+	_, err = io.Copy(io.Discard, gz)
+	if err != nil {
+		return nil, fmt.Errorf("read backup: %w", err)
+	}
+
+	for _, name := range names {
+		_, err = tx.ExecContext(ctx, "alter table "+name+" enable trigger user")
+		if err != nil {
+			return nil, fmt.Errorf("enable triggers: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, "analyze")
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("analyze after restore: %w", err))
+	}
+
+	return &hdr, nil
+}
+
+func restoreTable(ctx context.Context, tx *sql.Tx, in *json.Decoder, rec tableRecord) error {
+	name := sqlutil.QuoteID(rec.Table)
+	query := fmt.Sprintf("insert into %s select * from jsonb_populate_recordset(null::%s, $1::jsonb)", name, name)
+
+	var batch []json.RawMessage
+	var size int
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query, string(data))
+		if err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+		batch = batch[:0]
+		size = 0
+		return nil
+	}
+
+	for i := int64(0); i < rec.Rows; i++ {
+		var row json.RawMessage
+		err := in.Decode(&row)
+		if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+		batch = append(batch, row)
+		size += len(row)
+		if size < maxBatchSize {
+			continue
+		}
+		err = flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return flush()
+}
+
+func restoreSequences(ctx context.Context, tx *sql.Tx, seqs []sequence) error {
+	for _, seq := range seqs {
+		_, err := tx.ExecContext(ctx, "select setval($1, $2, $3)", sqlutil.QuoteID(seq.Name), seq.LastValue, seq.IsCalled)
+		if err != nil {
+			return fmt.Errorf("restore sequence %s: %w", seq.Name, err)
+		}
+	}
+	return nil
+}
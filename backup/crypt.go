@@ -0,0 +1,80 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/age"
+)
+
+// Backups are encrypted with age (https://age-encryption.org/v1), to either a passphrase (scrypt)
+// or one or more X25519 recipients, so they can also be decrypted with the standard age tool.
+
+// ErrDecrypt is returned when a backup cannot be decrypted, typically due to the wrong passphrase or identity.
+var ErrDecrypt = errors.New("decrypt backup: wrong passphrase or identity, or corrupt data")
+
+// PassphraseRecipient will return a recipient that encrypts with a passphrase.
+func PassphraseRecipient(passphrase []byte) (age.Recipient, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+
+	return age.NewScryptRecipient(string(passphrase))
+}
+
+// PassphraseIdentity will return an identity that decrypts backups encrypted with PassphraseRecipient.
+func PassphraseIdentity(passphrase []byte) (age.Identity, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+
+	return age.NewScryptIdentity(string(passphrase))
+}
+
+// newEncryptWriter returns a writer that encrypts data to w. Close must be called to write the final chunk,
+// it does not close w.
+func newEncryptWriter(w io.Writer, recipients []age.Recipient) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("a passphrase or recipient is required")
+	}
+
+	enc, err := age.Encrypt(w, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt backup: %w", err)
+	}
+
+	return enc, nil
+}
+
+type decryptReader struct {
+	r io.Reader
+}
+
+// newDecryptReader returns a reader that decrypts data from r.
+func newDecryptReader(r io.Reader, identities []age.Identity) (io.Reader, error) {
+	if len(identities) == 0 {
+		return nil, errors.New("a passphrase or identity is required")
+	}
+
+	dec, err := age.Decrypt(r, identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, ErrDecrypt
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backup: %w", err)
+	}
+
+	return &decryptReader{r: dec}, nil
+}
+
+// Read implements io.Reader, reporting a corrupt or truncated payload as ErrDecrypt.
+func (d *decryptReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+
+	return n, err
+}
@@ -0,0 +1,107 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encrypt(t *testing.T, data []byte, r ...age.Recipient) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := newEncryptWriter(&buf, r)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(data []byte, id ...age.Identity) ([]byte, error) {
+	r, err := newDecryptReader(bytes.NewReader(data), id)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func passphrase(t *testing.T, pass string) (age.Recipient, age.Identity) {
+	t.Helper()
+	r, err := PassphraseRecipient([]byte(pass))
+	require.NoError(t, err)
+	// keep tests fast; the default work factor takes about a second
+	r.(*age.ScryptRecipient).SetWorkFactor(10)
+	id, err := PassphraseIdentity([]byte(pass))
+	require.NoError(t, err)
+	return r, id
+}
+
+func TestCrypt(t *testing.T) {
+	r, id := passphrase(t, "correct horse battery staple")
+	_, wrong := passphrase(t, "wrong")
+
+	const chunkSize = 64 * 1024 // age payload chunk size
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize} {
+		data := bytes.Repeat([]byte{'x'}, size)
+		enc := encrypt(t, data, r)
+
+		dec, err := decrypt(enc, id)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, data, dec, "size %d", size)
+
+		_, err = decrypt(enc, wrong)
+		assert.ErrorIs(t, err, ErrDecrypt, "wrong passphrase, size %d", size)
+	}
+}
+
+func TestCrypt_X25519(t *testing.T) {
+	a, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	b, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	data := []byte("hello")
+	enc := encrypt(t, data, a.Recipient(), b.Recipient())
+
+	for _, id := range []age.Identity{a, b} {
+		dec, err := decrypt(enc, id)
+		require.NoError(t, err)
+		assert.Equal(t, data, dec)
+	}
+
+	_, err = decrypt(enc, other)
+	assert.ErrorIs(t, err, ErrDecrypt, "wrong identity")
+
+	_, id := passphrase(t, "secret")
+	_, err = decrypt(enc, id)
+	assert.ErrorIs(t, err, ErrDecrypt, "passphrase for key-encrypted backup")
+}
+
+func TestCrypt_Truncated(t *testing.T) {
+	r, id := passphrase(t, "secret")
+	enc := encrypt(t, bytes.Repeat([]byte{'x'}, 200*1024), r)
+
+	_, err := decrypt(enc[:len(enc)-1], id)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = decrypt(enc[:len(enc)/2], id)
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestCrypt_Required(t *testing.T) {
+	_, err := PassphraseRecipient(nil)
+	assert.Error(t, err)
+	_, err = PassphraseIdentity(nil)
+	assert.Error(t, err)
+
+	_, err = newEncryptWriter(io.Discard, nil)
+	assert.Error(t, err)
+	_, err = newDecryptReader(bytes.NewReader(nil), nil)
+	assert.Error(t, err)
+}
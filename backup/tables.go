@@ -0,0 +1,174 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/target/goalert/util/sqlutil"
+)
+
+type table struct {
+	name string
+
+	// filter is a condition matching rows to keep when closed alerts are excluded, or
+	// empty if all rows are kept.
+	filter string
+}
+
+type foreignKey struct {
+	src, srcCol string
+	dst, dstCol string
+	deferrable  bool
+}
+
+// skipTables are not included in backups; they are managed by migrations or only
+// used during switchover.
+var skipTables = map[string]bool{
+	"engine_processing_versions": true,
+	"gorp_migrations":            true,
+	"migrate_backfills":          true,
+	"switchover_state":           true,
+	"switchover_log":             true,
+	"change_log":                 true,
+}
+
+// scanTables returns all tables to back up in insert-safe order, meaning a table is
+// returned after any tables it has a (non-deferrable) foreign key to.
+func scanTables(ctx context.Context, tx *sql.Tx) ([]table, error) {
+	rows, err := tx.QueryContext(ctx, `
+		select table_name::text
+		from information_schema.tables
+		where table_catalog = current_database()
+			and table_schema = 'public'
+			and table_type = 'BASE TABLE'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("scan tables: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan tables: %w", err)
+		}
+		if skipTables[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		select
+			src.relname::text,
+			coalesce(src_col.attname::text, ''),
+			dst.relname::text,
+			coalesce(dst_col.attname::text, ''),
+			con.condeferrable
+		from pg_catalog.pg_constraint con
+		join pg_catalog.pg_namespace ns on ns.nspname = 'public' and ns.oid = con.connamespace
+		join pg_catalog.pg_class src on src.oid = con.conrelid
+		join pg_catalog.pg_class dst on dst.oid = con.confrelid
+		left join pg_catalog.pg_attribute src_col on
+			array_length(con.conkey, 1) = 1 and src_col.attrelid = con.conrelid and src_col.attnum = con.conkey[1]
+		left join pg_catalog.pg_attribute dst_col on
+			array_length(con.confkey, 1) = 1 and dst_col.attrelid = con.confrelid and dst_col.attnum = con.confkey[1]
+		where con.contype = 'f'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("scan foreign keys: %w", err)
+	}
+	var fkeys []foreignKey
+	for rows.Next() {
+		var fk foreignKey
+		err = rows.Scan(&fk.src, &fk.srcCol, &fk.dst, &fk.dstCol, &fk.deferrable)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan foreign keys: %w", err)
+		}
+		fkeys = append(fkeys, fk)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orderTables(names, fkeys), nil
+}
+
+// orderTables will sort tables in insert-safe order and set the closed-alert filter for each.
+//
+// Deferrable and self-referencing foreign keys are ignored for ordering, as they are checked
+// at commit. Tables in a dependency cycle are appended in name order.
+func orderTables(names []string, fkeys []foreignKey) []table {
+	deps := make(map[string]map[string]bool, len(names))
+	for _, name := range names {
+		deps[name] = make(map[string]bool)
+	}
+	for _, fk := range fkeys {
+		if fk.deferrable || fk.src == fk.dst || deps[fk.src] == nil || deps[fk.dst] == nil {
+			continue
+		}
+		deps[fk.src][fk.dst] = true
+	}
+
+	remaining := append([]string(nil), names...)
+	sort.Strings(remaining)
+
+	var ordered []string
+	for len(remaining) > 0 {
+		idx := -1
+		for i, name := range remaining {
+			if len(deps[name]) == 0 {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			// cycle, take the remaining tables as-is
+			ordered = append(ordered, remaining...)
+			break
+		}
+
+		name := remaining[idx]
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+		ordered = append(ordered, name)
+		for _, d := range deps {
+			delete(d, name)
+		}
+	}
+
+	// filters are built in order, so a table's references are resolved before it
+	filters := map[string]string{"alerts": "status != 'closed'"}
+	result := make([]table, 0, len(ordered))
+	for _, name := range ordered {
+		var conds []string
+		if f := filters[name]; f != "" {
+			conds = append(conds, f)
+		}
+		for _, fk := range fkeys {
+			if fk.src != name || fk.src == fk.dst || fk.srcCol == "" || filters[fk.dst] == "" {
+				continue
+			}
+			conds = append(conds, fmt.Sprintf("(%s isnull or %s in (select %s from %s where %s))",
+				sqlutil.QuoteID(fk.srcCol),
+				sqlutil.QuoteID(fk.srcCol),
+				sqlutil.QuoteID(fk.dstCol),
+				sqlutil.QuoteID(fk.dst),
+				filters[fk.dst],
+			))
+		}
+		filters[name] = strings.Join(conds, " and ")
+		result = append(result, table{name: name, filter: filters[name]})
+	}
+
+	return result
+}
@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderTables(t *testing.T) {
+	tables := orderTables(
+		[]string{"alert_logs", "alerts", "services", "users", "user_last_alert_log"},
+		[]foreignKey{
+			{src: "alerts", srcCol: "service_id", dst: "services", dstCol: "id"},
+			{src: "alert_logs", srcCol: "alert_id", dst: "alerts", dstCol: "id"},
+			{src: "user_last_alert_log", srcCol: "log_id", dst: "alert_logs", dstCol: "id"},
+			{src: "user_last_alert_log", srcCol: "user_id", dst: "users", dstCol: "id"},
+			{src: "users", srcCol: "alert_id", dst: "alerts", dstCol: "id", deferrable: true},
+		},
+	)
+
+	var names []string
+	filters := make(map[string]string)
+	for _, tbl := range tables {
+		names = append(names, tbl.name)
+		filters[tbl.name] = tbl.filter
+	}
+
+	assert.Equal(t, []string{"services", "alerts", "alert_logs", "users", "user_last_alert_log"}, names)
+	assert.Equal(t, "status != 'closed'", filters["alerts"])
+	assert.Equal(t, `("alert_id" isnull or "alert_id" in (select "id" from "alerts" where status != 'closed'))`, filters["alert_logs"])
+	assert.Contains(t, filters["user_last_alert_log"], `select "id" from "alert_logs" where ("alert_id" isnull`)
+	assert.Empty(t, filters["services"])
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/version"
@@ -27,17 +28,28 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shifts, err := s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(1, 0, 0))
-	if errutil.HTTPError(ctx, w, err) {
-		return
-	}
-
 	var subCfg SubscriptionConfig
 	err = json.Unmarshal(info.Config, &subCfg)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
+	var shifts []oncall.Shift
+	var rotName string
+	if subCfg.RotationID != "" {
+		rot, err := s.oc.ScheduleRotation(ctx, info.ScheduleID.String(), subCfg.RotationID)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		rotName = rot.Name
+		shifts = rot.Shifts(info.Now, info.Now.AddDate(1, 0, 0))
+	} else {
+		shifts, err = s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(1, 0, 0))
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+	}
+
 	if !subCfg.FullSchedule {
 		// filter out other users
 		filtered := shifts[:0]
@@ -51,14 +63,16 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 	}
 
 	data := renderData{
-		ApplicationName: cfg.ApplicationName(),
-		ScheduleID:      info.ScheduleID,
-		ScheduleName:    info.ScheduleName,
-		Shifts:          shifts,
-		ReminderMinutes: subCfg.ReminderMinutes,
-		Version:         version.GitVersion(),
-		GeneratedAt:     info.Now,
-		FullSchedule:    subCfg.FullSchedule,
+		ApplicationName:    cfg.ApplicationName(),
+		ScheduleID:         info.ScheduleID,
+		ScheduleName:       info.ScheduleName,
+		RotationName:       rotName,
+		Shifts:             shifts,
+		ReminderMinutes:    subCfg.ReminderMinutes,
+		Version:            version.GitVersion(),
+		GeneratedAt:        info.Now,
+		FullSchedule:       subCfg.FullSchedule,
+		EndReminderMinutes: subCfg.EndReminderMinutes,
 	}
 
 	if subCfg.FullSchedule {
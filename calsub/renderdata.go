@@ -8,13 +8,15 @@ import (
 )
 
 type renderData struct {
-	ApplicationName string
-	ScheduleID      uuid.UUID
-	ScheduleName    string
-	Shifts          []oncall.Shift
-	ReminderMinutes []int
-	Version         string
-	GeneratedAt     time.Time
-	FullSchedule    bool
-	UserNames       map[string]string
+	ApplicationName    string
+	ScheduleID         uuid.UUID
+	ScheduleName       string
+	RotationName       string
+	Shifts             []oncall.Shift
+	ReminderMinutes    []int
+	EndReminderMinutes []int
+	Version            string
+	GeneratedAt        time.Time
+	FullSchedule       bool
+	UserNames          map[string]string
 }
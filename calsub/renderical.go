@@ -18,12 +18,13 @@ VERSION:2.0
 CALSCALE:GREGORIAN
 METHOD:PUBLISH
 {{- $mins := .ReminderMinutes }}
+{{- $endMins := .EndReminderMinutes }}
 {{- $genTime := .GeneratedAt }}
 {{- $eventUIDs := .EventUIDs}}
 {{- range $i, $s := .Shifts}}
 BEGIN:VEVENT
 UID:{{index $eventUIDs $i}}
-SUMMARY:{{if $.FullSchedule}}{{index $.UserNames $s.UserID}} {{end}}On-Call ({{$.ApplicationName}}: {{$.ScheduleName}}){{if $s.Truncated}} Begins*
+SUMMARY:{{if $.FullSchedule}}{{index $.UserNames $s.UserID}} {{end}}On-Call ({{$.ApplicationName}}: {{$.ScheduleName}}{{if $.RotationName}} / {{$.RotationName}}{{end}}){{if $s.Truncated}} Begins*
 DESCRIPTION:The end time of this shift is unknown and will continue beyond what is displayed.
 {{- end }}
 DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
@@ -36,6 +37,13 @@ DESCRIPTION:REMINDER
 TRIGGER:-PT{{.}}M
 END:VALARM
 {{- end}}
+{{- range $endMins}}
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:REMINDER
+TRIGGER;RELATED=END:-PT{{.}}M
+END:VALARM
+{{- end}}
 END:VEVENT
 {{- end}}
 END:VCALENDAR
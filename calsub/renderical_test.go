@@ -80,3 +80,24 @@ func TestRenderData_RenderICal(t *testing.T) {
 	}, "\r\n")
 	assert.Equal(t, expected, string(iCal))
 }
+
+func TestRenderData_RenderICal_Rotation(t *testing.T) {
+	r := renderData{
+		ApplicationName: "GoAlert",
+		ScheduleID:      uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+		ScheduleName:    "Sched",
+		RotationName:    "Primary",
+		Shifts: []oncall.Shift{{
+			UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+			Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+			End:    time.Date(2020, 1, 15, 8, 0, 0, 0, time.UTC),
+		}},
+		EndReminderMinutes: []int{30},
+		Version:            "dev",
+		GeneratedAt:        time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC),
+	}
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+	assert.Contains(t, string(iCal), "SUMMARY:On-Call (GoAlert: Sched / Primary)\r\n")
+	assert.Contains(t, string(iCal), "TRIGGER;RELATED=END:-PT30M\r\n")
+}
@@ -1,12 +1,16 @@
 package calsub
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/validation/validate"
 )
 
+// maxReminderMinutes is the largest alarm offset allowed (one week).
+const maxReminderMinutes = 7 * 24 * 60
+
 // Subscription stores the information from user subscriptions
 type Subscription struct {
 	ID         string
@@ -35,6 +39,7 @@ func (cs Subscription) Normalize() (*Subscription, error) {
 
 	err := validate.Many(
 		validate.Range("ReminderMinutes", len(cs.Config.ReminderMinutes), 0, 15),
+		validate.Range("EndReminderMinutes", len(cs.Config.EndReminderMinutes), 0, 15),
 		validate.IDName("Name", cs.Name),
 		validate.UUID("ID", cs.ID),
 		validate.UUID("UserID", cs.UserID),
@@ -42,6 +47,24 @@ func (cs Subscription) Normalize() (*Subscription, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cs.Config.RotationID != "" {
+		err = validate.UUID("RotationID", cs.Config.RotationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, m := range cs.Config.ReminderMinutes {
+		err = validate.Range(fmt.Sprintf("ReminderMinutes[%d]", i), m, 0, maxReminderMinutes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, m := range cs.Config.EndReminderMinutes {
+		err = validate.Range(fmt.Sprintf("EndReminderMinutes[%d]", i), m, 0, maxReminderMinutes)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &cs, nil
 }
@@ -9,8 +9,19 @@ import (
 
 // SubscriptionConfig is the configuration for a calendar subscription.
 type SubscriptionConfig struct {
+	// ReminderMinutes are alarm offsets, in minutes, before the start of each shift.
 	ReminderMinutes []int
-	FullSchedule    bool
+
+	// EndReminderMinutes are alarm offsets, in minutes, before the end of each shift.
+	EndReminderMinutes []int `json:",omitempty"`
+
+	// FullSchedule will include shifts for all users, rather than only the subscribing user's
+	// final shifts (after overrides and temporary schedules are applied).
+	FullSchedule bool
+
+	// RotationID, if set, limits the feed to turns of the given rotation (which must be
+	// assigned to the schedule), regardless of schedule rules or overrides.
+	RotationID string `json:",omitempty"`
 }
 
 var (
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"context"
+	"reflect"
+)
+
+// A ChangeFunc is called after a new config version is loaded.
+type ChangeFunc func(ctx context.Context, oldCfg, newCfg Config)
+
+// ChangedSections returns the names of the top-level sections (e.g., "Slack") that differ between a and b.
+func ChangedSections(a, b Config) []string {
+	aVal, bVal := reflect.ValueOf(a), reflect.ValueOf(b)
+	t := aVal.Type()
+
+	var changed []string
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		if !reflect.DeepEqual(aVal.Field(i).Interface(), bVal.Field(i).Interface()) {
+			changed = append(changed, t.Field(i).Name)
+		}
+	}
+
+	return changed
+}
+
+// SectionChanged returns true if the named top-level section differs between a and b.
+func SectionChanged(a, b Config, name string) bool {
+	for _, s := range ChangedSections(a, b) {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OnChange will register fn to be called, in order of registration, each time a new config version is
+// loaded. Changes are loaded immediately on all instances, since each update is broadcast with NOTIFY.
+//
+// The initial config load does not call fn, and fn must not call Reload.
+func (s *Store) OnChange(fn ChangeFunc) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.onChange = append(s.onChange, fn)
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedSections(t *testing.T) {
+	var a, b Config
+	assert.Empty(t, ChangedSections(a, b))
+
+	b.Slack.AccessToken = "xoxb-new"
+	b.Twilio.Enable = true
+	b.fallbackURL = "http://example.com" // unexported fields are ignored
+	assert.Equal(t, []string{"Slack", "Twilio"}, ChangedSections(a, b))
+	assert.True(t, SectionChanged(a, b, "Slack"))
+	assert.False(t, SectionChanged(a, b, "SMTP"))
+}
+
+func TestStore_OnChange(t *testing.T) {
+	type call struct {
+		name     string
+		old, new string
+	}
+	var calls []call
+	hook := func(name string) ChangeFunc {
+		return func(ctx context.Context, oldCfg, newCfg Config) {
+			calls = append(calls, call{name: name, old: oldCfg.Slack.AccessToken, new: newCfg.Slack.AccessToken})
+			assert.Equal(t, newCfg.Slack.AccessToken, FromContext(ctx).Slack.AccessToken, "context has new config")
+		}
+	}
+
+	var s Store
+	s.OnChange(hook("first"))
+	s.OnChange(hook("second"))
+
+	ctx := context.Background()
+	var cfg Config
+	cfg.Slack.AccessToken = "v1"
+	s.apply(ctx, cfg, 1)
+	assert.Empty(t, calls, "initial load")
+
+	cfg.Slack.AccessToken = "v2"
+	s.apply(ctx, cfg, 2)
+	require.Equal(t, []call{
+		{name: "first", old: "v1", new: "v2"},
+		{name: "second", old: "v1", new: "v2"},
+	}, calls, "called in order of registration")
+	assert.Equal(t, "v2", s.Config().Slack.AccessToken)
+
+	calls = nil
+	s.apply(ctx, cfg, 2)
+	assert.Empty(t, calls, "same version")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -21,6 +22,10 @@ type Config struct {
 	explicitURL string
 
 	intEmailDomain string
+	intSNMPTrap    bool
+	intSyslog      bool
+	intKafka       bool
+	intMQTT        bool
 
 	General struct {
 		ApplicationName              string `public:"true" info:"The name used in messaging and page titles. Defaults to \"GoAlert\"."`
@@ -32,13 +37,46 @@ type Config struct {
 		DisableSMSLinks              bool   `public:"true" info:"If set, SMS messages will not contain a URL pointing to GoAlert."`
 		DisableLabelCreation         bool   `public:"true" info:"Disables the ability to create new labels for services."`
 		DisableCalendarSubscriptions bool   `public:"true" info:"If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions."`
+		MessageSendingPartitions     int    `info:"Split outgoing message sending into this many partitions (by destination) so multiple engine instances can send concurrently. Other engine processing is not partitioned. 0 or 1 means a single instance sends all messages."`
 	}
 
 	Maintenance struct {
-		AlertCleanupDays    int `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
-		AlertAutoCloseDays  int `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
-		APIKeyExpireDays    int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
-		ScheduleCleanupDays int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		AlertCleanupDays      int `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
+		AlertArchiveDays      int `public:"true" info:"Closed alerts will be moved to the alert archive after this many days (0 means disable archival). Requires --alert-archive-dir or --alert-archive-s3-url."`
+		AlertAutoCloseDays    int `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
+		APIKeyExpireDays      int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
+		ScheduleCleanupDays   int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		MessageLogCleanupDays int `public:"true" info:"Sent and failed outgoing messages, SMS reply codes for closed alerts, and Twilio error logs will be deleted after this many days (0 means disable cleanup)."`
+	}
+
+	Database struct {
+		MaxOpenConns int `info:"If set, overrides the --db-max-open flag on all instances without a restart."`
+		MaxIdleConns int `info:"If set, overrides the --db-max-idle flag on all instances without a restart."`
+	}
+
+	RateLimit struct {
+		VoiceAlertsPerHour       int `info:"Maximum alert notifications per hour to a single voice contact method. 0 uses the default of 7."`
+		SMSAlertsPerHour         int `info:"Maximum alert notifications per hour to a single SMS contact method. 0 uses the default of 11."`
+		EmailAlertsPerHour       int `info:"Maximum alert notifications per hour to a single email contact method. 0 means email is only limited to one message per minute."`
+		LowSeverityAlertsPerHour int `info:"Maximum notifications per hour to a single contact method for medium and low severity alerts. 0 means no additional limit."`
+
+		IntegrationRequestsPerMinute int `info:"Maximum requests per minute accepted from a single integration key, allowing bursts up to the same amount. Additional requests get a 429 response. 0 means no limit."`
+		IntegrationMaxConcurrent     int `info:"Maximum integration key requests processed at once by each GoAlert instance. Additional requests get a 429 response. 0 means no limit."`
+	}
+
+	Failover struct {
+		Enable              bool `info:"Automatically notify the next contact method of a user when alert notifications fail, according to the thresholds below."`
+		ProviderFailures    int  `info:"Fail over when this many consecutive messages to a contact method type (e.g., SMS) have failed. 0 means disabled."`
+		DestinationFailures int  `info:"Fail over when this many consecutive messages to a single contact method have failed. 0 means disabled."`
+	}
+
+	Pause struct {
+		Voice          bool `info:"Hold all outgoing voice calls on every instance (e.g., during a provider incident). Held messages are sent once resumed."`
+		SMS            bool `info:"Hold all outgoing SMS messages on every instance. Held messages are sent once resumed."`
+		Email          bool `info:"Hold all outgoing email messages on every instance. Held messages are sent once resumed."`
+		Slack          bool `info:"Hold all outgoing Slack messages (channels, DMs, and user groups) on every instance. Held messages are sent once resumed."`
+		Webhook        bool `info:"Hold all outgoing webhook requests (user and channel) on every instance. Held messages are sent once resumed."`
+		FailoverAlerts bool `info:"Instead of holding alert notifications for paused contact method types, send them to the user's next contact method that is not paused."`
 	}
 
 	Auth struct {
@@ -58,6 +96,8 @@ type Config struct {
 		AllowedOrgs  []string `info:"Allow any member of any listed GitHub org (or team, using the format 'org/team') to authenticate."`
 
 		EnterpriseURL string `info:"GitHub URL (without /api) when used with GitHub Enterprise."`
+
+		WebhookSecret string `password:"true" info:"Secret used to verify webhook deliveries from the GitHub App to GitHub Actions integration keys. If empty, signatures are not checked."`
 	}
 
 	OIDC struct {
@@ -95,6 +135,7 @@ type Config struct {
 
 		SigningSecret       string `password:"true" info:"Signing secret to verify requests from slack."`
 		InteractiveMessages bool   `info:"Enable interactive messages (e.g. buttons)."`
+		SlashCommands       bool   `info:"Enable the /goalert slash command. The command's Request URL must be set to the SlashCommandURL hint."`
 	}
 
 	Twilio struct {
@@ -114,6 +155,16 @@ type Config struct {
 		DisableTwoWaySMS      bool     `info:"Disables SMS reply codes for alert messages."`
 		SMSCarrierLookup      bool     `info:"Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply."`
 		SMSFromNumberOverride []string `info:"List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number."`
+
+		CallForwardServiceID string `info:"If set, inbound callers can be connected to the current on-call users of this service (by ID), falling back to the next escalation step if unanswered."`
+		CallForwardSkipMenu  bool   `info:"Forward inbound calls to the on-call users immediately, instead of presenting a menu."`
+
+		IncidentReportServiceID string `info:"If set, callers to the Twilio number can record an incident report that creates an alert on this service (by ID)."`
+
+		SMSRates             []string `info:"List of 'region=cost' pairs used to estimate the cost of each outgoing SMS, by ISO region code of the destination (e.g., US=0.0079). Use * for all other regions."`
+		VoiceRates           []string `info:"List of 'region=cost' pairs used to estimate the cost of each outgoing voice call (per call), by ISO region code of the destination (e.g., US=0.014). Use * for all other regions."`
+		DailyBudget          int      `info:"If set, an alert is created on the Budget Alert Service when the estimated spend for the current day (UTC) exceeds this amount."`
+		BudgetAlertServiceID string   `info:"The service (by ID) to create an alert on when the Daily Budget is exceeded."`
 	}
 
 	SMTP struct {
@@ -134,6 +185,27 @@ type Config struct {
 		AllowedURLs []string `public:"true" info:"If set, allows webhooks for these domains only."`
 	}
 
+	Jira struct {
+		Enable   bool   `public:"true" info:"Enables creating Jira issues for alerts on services with a Jira config."`
+		BaseURL  string `info:"Base URL of the Jira instance (e.g. https://example.atlassian.net)."`
+		Username string `info:"Jira user (email address for Jira Cloud) used for API requests."`
+		APIToken string `password:"true" info:"API token (or password) for the Jira user."`
+	}
+
+	ServiceNow struct {
+		Enable         bool   `public:"true" info:"Enables creating ServiceNow incidents for alerts on services with a ServiceNow config."`
+		InstanceURL    string `info:"Base URL of the ServiceNow instance (e.g. https://example.service-now.com)."`
+		Username       string `info:"ServiceNow user used for Table API requests."`
+		Password       string `password:"true" info:"Password for the ServiceNow user."`
+		CallbackSecret string `password:"true" info:"Shared secret ServiceNow must send as a Bearer token to the incident callback endpoint."`
+	}
+
+	Statuspage struct {
+		Enable bool   `public:"true" info:"Enables updating Statuspage components from open alerts on mapped services."`
+		PageID string `info:"ID of the Statuspage page containing the mapped components."`
+		APIKey string `password:"true" info:"Statuspage API key."`
+	}
+
 	Feedback struct {
 		Enable      bool   `public:"true" info:"Enables Feedback link in nav bar."`
 		OverrideURL string `public:"true" info:"Use a custom URL for Feedback link in nav bar."`
@@ -148,6 +220,18 @@ func (cfg Config) EmailIngressEnabled() bool {
 	return false
 }
 
+// SNMPTrapEnabled will return true if the SNMP trap listener is enabled.
+func (cfg Config) SNMPTrapEnabled() bool { return cfg.intSNMPTrap }
+
+// SyslogEnabled will return true if the syslog listener is enabled.
+func (cfg Config) SyslogEnabled() bool { return cfg.intSyslog }
+
+// KafkaEnabled will return true if the Kafka consumer is enabled.
+func (cfg Config) KafkaEnabled() bool { return cfg.intKafka }
+
+// MQTTEnabled will return true if the MQTT subscriber is enabled.
+func (cfg Config) MQTTEnabled() bool { return cfg.intMQTT }
+
 // EmailIngressDomain returns the domain configured to receive email for alert generation
 func (cfg Config) EmailIngressDomain() string {
 	if cfg.intEmailDomain != "" {
@@ -182,6 +266,57 @@ func (cfg Config) TwilioSMSFromNumber(carrier string) string {
 	return cfg.Twilio.FromNumber
 }
 
+// TwilioRate returns the estimated cost of a message to the given region (ISO region code), using a list
+// of 'region=cost' pairs (e.g., Twilio.SMSRates). The '*' entry applies to any region not listed.
+func TwilioRate(rates []string, region string) float64 {
+	var def float64
+	for _, s := range rates {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		cost, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.EqualFold(parts[0], region):
+			return cost
+		case parts[0] == "*":
+			def = cost
+		}
+	}
+
+	return def
+}
+
+func validateRates(fname string, rates []string) error {
+	var err error
+	m := make(map[string]bool)
+	for i, str := range rates {
+		parts := strings.SplitN(str, "=", 2)
+		name := fmt.Sprintf("%s[%d]", fname, i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(name, "must be in the format 'region=cost'"))
+			continue
+		}
+		if parts[0] != "*" {
+			err = validate.Many(err, validate.ASCII(name+".Region", parts[0], 2, 2))
+		}
+		cost, pErr := strconv.ParseFloat(parts[1], 64)
+		if pErr != nil || cost < 0 {
+			err = validate.Many(err, validation.NewFieldError(name+".Cost", "must be a non-negative number"))
+		}
+		region := strings.ToUpper(parts[0])
+		if m[region] {
+			err = validate.Many(err, validation.NewFieldError(name, fmt.Sprintf("rate for region '%s' already set", parts[0])))
+		}
+		m[region] = true
+	}
+
+	return err
+}
+
 // RequestURL returns the full URL for the given request based on the current public url.
 func RequestURL(req *http.Request) string {
 	cfg := FromContext(req.Context())
@@ -461,11 +596,25 @@ func (cfg Config) Validate() error {
 		validate.ASCII("Twilio.VoiceLanguage", cfg.Twilio.VoiceLanguage, 0, 10),
 		validateKey("GitHub.ClientID", cfg.GitHub.ClientID),
 		validateKey("GitHub.ClientSecret", cfg.GitHub.ClientSecret),
+		validateKey("GitHub.WebhookSecret", cfg.GitHub.WebhookSecret),
 		validateKey("Slack.AccessToken", cfg.Slack.AccessToken),
+		validate.Range("General.MessageSendingPartitions", cfg.General.MessageSendingPartitions, 0, 64),
+		validate.Range("RateLimit.VoiceAlertsPerHour", cfg.RateLimit.VoiceAlertsPerHour, 0, 1000),
+		validate.Range("RateLimit.SMSAlertsPerHour", cfg.RateLimit.SMSAlertsPerHour, 0, 1000),
+		validate.Range("RateLimit.EmailAlertsPerHour", cfg.RateLimit.EmailAlertsPerHour, 0, 1000),
+		validate.Range("RateLimit.LowSeverityAlertsPerHour", cfg.RateLimit.LowSeverityAlertsPerHour, 0, 1000),
+		validate.Range("RateLimit.IntegrationRequestsPerMinute", cfg.RateLimit.IntegrationRequestsPerMinute, 0, 100000),
+		validate.Range("RateLimit.IntegrationMaxConcurrent", cfg.RateLimit.IntegrationMaxConcurrent, 0, 10000),
+		validate.Range("Failover.ProviderFailures", cfg.Failover.ProviderFailures, 0, 1000),
+		validate.Range("Failover.DestinationFailures", cfg.Failover.DestinationFailures, 0, 1000),
 		validate.Range("Maintenance.AlertCleanupDays", cfg.Maintenance.AlertCleanupDays, 0, 9000),
+		validate.Range("Maintenance.AlertArchiveDays", cfg.Maintenance.AlertArchiveDays, 0, 9000),
 		validate.Range("Maintenance.AlertAutoCloseDays", cfg.Maintenance.AlertAutoCloseDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
 		validate.Range("Maintenance.ScheduleCleanupDays", cfg.Maintenance.ScheduleCleanupDays, 0, 9000),
+		validate.Range("Maintenance.MessageLogCleanupDays", cfg.Maintenance.MessageLogCleanupDays, 0, 9000),
+		validate.Range("Database.MaxOpenConns", cfg.Database.MaxOpenConns, 0, 1000),
+		validate.Range("Database.MaxIdleConns", cfg.Database.MaxIdleConns, 0, 1000),
 		validateScopes("OIDC.Scopes", cfg.OIDC.Scopes),
 		validatePath("OIDC.UserInfoEmailPath", cfg.OIDC.UserInfoEmailPath),
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
@@ -473,6 +622,10 @@ func (cfg Config) Validate() error {
 		validateKey("Slack.SigningSecret", cfg.Slack.SigningSecret),
 	)
 
+	if cfg.Maintenance.AlertArchiveDays > 0 && cfg.Maintenance.AlertCleanupDays > 0 && cfg.Maintenance.AlertArchiveDays >= cfg.Maintenance.AlertCleanupDays {
+		err = validate.Many(err, validation.NewFieldError("Maintenance.AlertArchiveDays", "must be less than AlertCleanupDays, or closed alerts will be deleted before they are archived"))
+	}
+
 	if cfg.General.GoogleAnalyticsID != "" {
 		err = validate.Many(err, validate.MeasurementID("General.GoogleAnalyticsID", cfg.General.GoogleAnalyticsID))
 	}
@@ -493,6 +646,26 @@ func (cfg Config) Validate() error {
 	if cfg.Twilio.FromNumber != "" {
 		err = validate.Many(err, validate.Phone("Twilio.FromNumber", cfg.Twilio.FromNumber))
 	}
+	if cfg.Twilio.CallForwardServiceID != "" {
+		err = validate.Many(err, validate.UUID("Twilio.CallForwardServiceID", cfg.Twilio.CallForwardServiceID))
+	}
+	if cfg.Twilio.CallForwardSkipMenu && cfg.Twilio.CallForwardServiceID == "" {
+		err = validate.Many(err, validation.NewFieldError("Twilio.CallForwardSkipMenu", "requires Twilio.CallForwardServiceID to be set"))
+	}
+	if cfg.Twilio.IncidentReportServiceID != "" {
+		err = validate.Many(err, validate.UUID("Twilio.IncidentReportServiceID", cfg.Twilio.IncidentReportServiceID))
+	}
+	if cfg.Twilio.BudgetAlertServiceID != "" {
+		err = validate.Many(err, validate.UUID("Twilio.BudgetAlertServiceID", cfg.Twilio.BudgetAlertServiceID))
+	}
+	if cfg.Twilio.DailyBudget > 0 && cfg.Twilio.BudgetAlertServiceID == "" {
+		err = validate.Many(err, validation.NewFieldError("Twilio.BudgetAlertServiceID", "required when Twilio.DailyBudget is set"))
+	}
+	err = validate.Many(err,
+		validateRates("Twilio.SMSRates", cfg.Twilio.SMSRates),
+		validateRates("Twilio.VoiceRates", cfg.Twilio.VoiceRates),
+		validate.Range("Twilio.DailyBudget", cfg.Twilio.DailyBudget, 0, 1000000),
+	)
 	if cfg.Twilio.MessagingServiceSID != "" {
 		err = validate.Many(err, validate.TwilioSID("Twilio.MessagingServiceSID", "MG", cfg.Twilio.MessagingServiceSID))
 	}
@@ -563,6 +736,22 @@ func (cfg Config) Validate() error {
 		)
 	}
 
+	if cfg.Jira.BaseURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Jira.BaseURL", cfg.Jira.BaseURL))
+	}
+	if cfg.Jira.Enable && cfg.Jira.BaseURL == "" {
+		err = validate.Many(err, validation.NewFieldError("Jira.BaseURL", "required when Jira is enabled"))
+	}
+	if cfg.ServiceNow.InstanceURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("ServiceNow.InstanceURL", cfg.ServiceNow.InstanceURL))
+	}
+	if cfg.ServiceNow.Enable && cfg.ServiceNow.InstanceURL == "" {
+		err = validate.Many(err, validation.NewFieldError("ServiceNow.InstanceURL", "required when ServiceNow is enabled"))
+	}
+	if cfg.Statuspage.Enable && cfg.Statuspage.PageID == "" {
+		err = validate.Many(err, validation.NewFieldError("Statuspage.PageID", "required when Statuspage is enabled"))
+	}
+
 	for i, urlStr := range cfg.Webhook.AllowedURLs {
 		field := fmt.Sprintf("Webhook.AllowedURLs[%d]", i)
 		err = validate.Many(err, validate.AbsoluteURL(field, urlStr))
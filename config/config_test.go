@@ -119,4 +119,36 @@ func TestConfig_Validate(t *testing.T) {
 		cfg.Twilio.VoiceLanguage = "\x00" // non-ASCII value
 		assert.Error(t, cfg.Validate(), "language must be a valid string")
 	})
+
+	t.Run("Twilio.SMSRates", func(t *testing.T) {
+		var cfg Config
+		cfg.Twilio.SMSRates = []string{"US=0.0079", "*=0.05"}
+		assert.NoError(t, cfg.Validate())
+
+		cfg.Twilio.SMSRates = []string{"US=0.0079", "us=0.01"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSRates[1]", "duplicate region")
+
+		cfg.Twilio.SMSRates = []string{"US=free"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSRates[0].Cost")
+
+		cfg.Twilio.SMSRates = []string{"USA=0.01"}
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.SMSRates[0].Region")
+	})
+
+	t.Run("Twilio.DailyBudget", func(t *testing.T) {
+		var cfg Config
+		cfg.Twilio.DailyBudget = 100
+		assert.ErrorContains(t, cfg.Validate(), "Twilio.BudgetAlertServiceID", "service required if budget is set")
+
+		cfg.Twilio.BudgetAlertServiceID = "00000000-0000-0000-0000-000000000001"
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestTwilioRate(t *testing.T) {
+	rates := []string{"US=0.0079", "*=0.05", "CA=0.0075"}
+	assert.Equal(t, 0.0079, TwilioRate(rates, "US"))
+	assert.Equal(t, 0.0075, TwilioRate(rates, "CA"))
+	assert.Equal(t, 0.05, TwilioRate(rates, "GB"), "default rate")
+	assert.Equal(t, 0.0, TwilioRate(nil, "US"), "no rates")
 }
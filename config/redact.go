@@ -0,0 +1,46 @@
+package config
+
+import "reflect"
+
+// RedactedValue replaces secret values in a redacted Config.
+const RedactedValue = "REDACTED"
+
+// Redacted returns a copy of the Config with all non-empty secret (`password:"true"`) fields
+// replaced with RedactedValue, suitable for sharing (e.g., in a debug bundle).
+func (cfg Config) Redacted() Config {
+	redactStruct(reflect.ValueOf(&cfg).Elem())
+	return cfg
+}
+
+func redactStruct(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		if f.Kind() == reflect.Struct {
+			redactStruct(f)
+			continue
+		}
+		if t.Field(i).Tag.Get("password") != "true" {
+			continue
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			if f.String() != "" {
+				f.SetString(RedactedValue)
+			}
+		case reflect.Slice:
+			if f.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			redacted := reflect.MakeSlice(f.Type(), f.Len(), f.Len())
+			for j := 0; j < f.Len(); j++ {
+				redacted.Index(j).SetString(RedactedValue)
+			}
+			f.Set(redacted)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Redacted(t *testing.T) {
+	var cfg Config
+	cfg.Slack.ClientID = "client-id"
+	cfg.Slack.ClientSecret = "client-secret"
+	cfg.Twilio.AuthToken = "token"
+
+	red := cfg.Redacted()
+	assert.Equal(t, "client-id", red.Slack.ClientID)
+	assert.Equal(t, RedactedValue, red.Slack.ClientSecret)
+	assert.Equal(t, RedactedValue, red.Twilio.AuthToken)
+	assert.Empty(t, red.Twilio.AlternateAuthToken, "empty secrets should remain empty")
+
+	assert.Equal(t, "client-secret", cfg.Slack.ClientSecret, "original should not be modified")
+}
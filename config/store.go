@@ -10,6 +10,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,13 +31,21 @@ type Store struct {
 	fallbackURL        string
 	explicitURL        string
 	ingressEmailDomain string
+	snmpTrap           bool
+	syslog             bool
+	kafka              bool
+	mqtt               bool
 	mx                 sync.RWMutex
 	db                 *sql.DB
 	keys               keyring.Keys
 	latestConfig       *sql.Stmt
+	latestConfigID     *sql.Stmt
 	setConfig          *sql.Stmt
 	lock               *sql.Stmt
 
+	onChange []ChangeFunc
+	reloadMx sync.Mutex
+
 	closeCh chan struct{}
 }
 
@@ -52,6 +61,18 @@ type StoreConfig struct {
 
 	// IngressEmailDomain is the domain to use for ingress email addresses.
 	IngressEmailDomain string
+
+	// SNMPTrapEnabled indicates the SNMP trap listener is running.
+	SNMPTrapEnabled bool
+
+	// SyslogEnabled indicates the syslog listener is running.
+	SyslogEnabled bool
+
+	// KafkaEnabled indicates the Kafka consumer is running.
+	KafkaEnabled bool
+
+	// MQTTEnabled indicates the MQTT subscriber is running.
+	MQTTEnabled bool
 }
 
 // NewStore will create a new Store with the given StoreConfig parameters. It will automatically detect
@@ -64,7 +85,12 @@ func NewStore(ctx context.Context, cfg StoreConfig) (*Store, error) {
 		fallbackURL:        cfg.FallbackURL,
 		explicitURL:        cfg.ExplicitURL,
 		ingressEmailDomain: cfg.IngressEmailDomain,
+		snmpTrap:           cfg.SNMPTrapEnabled,
+		syslog:             cfg.SyslogEnabled,
+		kafka:              cfg.KafkaEnabled,
+		mqtt:               cfg.MQTTEnabled,
 		latestConfig:       p.P(`select id, data, schema from config where schema <= $1 order by id desc limit 1`),
+		latestConfigID:     p.P(`select coalesce(max(id), 0) from config where schema <= $1`),
 		setConfig:          p.P(`insert into config (id, schema, data) values (DEFAULT, $1, $2) returning (id)`),
 		lock:               p.P(`lock config in exclusive mode`),
 		keys:               cfg.Keys,
@@ -138,6 +164,23 @@ func wrapTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
 
 // Reload will re-read and update the current config state from the DB.
 func (s *Store) Reload(ctx context.Context) error {
+	// serialize reloads, so change notifications are delivered in order
+	s.reloadMx.Lock()
+	defer s.reloadMx.Unlock()
+
+	// Periodic reloads usually find the same version, so skip decrypting and parsing it again.
+	var latestID int
+	err := s.latestConfigID.QueryRowContext(ctx, SchemaVersion).Scan(&latestID)
+	if err != nil {
+		return errors.Wrap(err, "get latest config version")
+	}
+	s.mx.RLock()
+	unchanged := s.cfgVers != 0 && s.cfgVers == latestID
+	s.mx.RUnlock()
+	if unchanged {
+		return nil
+	}
+
 	cfg, id, err := s.reloadTx(ctx, nil)
 	if err != nil {
 		return err
@@ -146,22 +189,46 @@ func (s *Store) Reload(ctx context.Context) error {
 	rawCfg.fallbackURL = s.fallbackURL
 	rawCfg.explicitURL = s.explicitURL
 	rawCfg.intEmailDomain = s.ingressEmailDomain
+	rawCfg.intSNMPTrap = s.snmpTrap
+	rawCfg.intSyslog = s.syslog
+	rawCfg.intKafka = s.kafka
+	rawCfg.intMQTT = s.mqtt
 
 	err = cfg.Validate()
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "validate config"))
 	}
 
+	s.apply(ctx, rawCfg, id)
+	return nil
+}
+
+// apply will make rawCfg the current config, and call OnChange funcs if it is a new version (other than the
+// initial load).
+//
+// Caller must hold s.reloadMx.
+func (s *Store) apply(ctx context.Context, rawCfg Config, id int) {
 	s.mx.Lock()
 	oldVers := s.cfgVers
+	oldCfg := s.rawCfg
 	s.cfgVers = id
 	s.rawCfg = rawCfg
+	onChange := s.onChange
 	s.mx.Unlock()
-	if oldVers != id {
+	if oldVers == id {
+		return
+	}
+	if oldVers == 0 {
+		// initial load
 		log.Logf(ctx, "Loaded config version %d ", id)
+		return
 	}
 
-	return nil
+	ctx = log.WithField(ctx, "ConfigVersion", id)
+	log.Logf(ctx, "Loaded config version %d (changed: %s)", id, strings.Join(ChangedSections(oldCfg, rawCfg), ", "))
+	for _, fn := range onChange {
+		fn(rawCfg.Context(ctx), oldCfg, rawCfg)
+	}
 }
 
 // ServeConfig handles requests to read and write the config json.
@@ -0,0 +1,148 @@
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+/* Expected payload (configured as the Datadog webhook's custom payload)
+
+```
+{
+  "alert_id": "$ALERT_ID",
+  "aggregate_key": "$AGGREG_KEY",
+  "title": "$EVENT_TITLE",
+  "message": "$EVENT_MSG",
+  "transition": "$ALERT_TRANSITION",
+  "priority": "$PRIORITY",
+  "hostname": "$HOSTNAME",
+  "link": "$LINK"
+}
+```
+*/
+
+type post struct {
+	AlertID      string `json:"alert_id"`
+	AggregateKey string `json:"aggregate_key"`
+	Title        string `json:"title"`
+	Message      string `json:"message"`
+	Transition   string `json:"transition"`
+	Priority     string `json:"priority"`
+	Hostname     string `json:"hostname"`
+	Link         string `json:"link"`
+}
+
+// status maps a Datadog alert transition to an alert status. Recovery
+// transitions (e.g., "Recovered", "Warn Recovered") close the alert.
+func (p post) status() (alert.Status, error) {
+	switch {
+	case strings.Contains(p.Transition, "Recovered"):
+		return alert.StatusClosed, nil
+	case p.Transition == "Triggered", p.Transition == "Re-Triggered", p.Transition == "Renotify",
+		p.Transition == "Warn", p.Transition == "Re-Warn", p.Transition == "No Data":
+		return alert.StatusTriggered, nil
+	}
+
+	return "", errors.Errorf("datadog: unknown transition: %s", p.Transition)
+}
+
+func (p post) dedup() string {
+	if p.AggregateKey != "" {
+		return p.AggregateKey
+	}
+
+	return p.AlertID
+}
+
+func (p post) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("Link", p.Link) == nil {
+		fmt.Fprintf(&s, "[View in Datadog](%s)\n\n", p.Link)
+	}
+	if p.Hostname != "" {
+		fmt.Fprintf(&s, "Host: %s\n\n", p.Hostname)
+	}
+	if p.Priority != "" {
+		fmt.Fprintf(&s, "Priority: %s\n\n", p.Priority)
+	}
+	s.WriteString(p.Message)
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func DatadogToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from datadog: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"DatadogAlertID": p.AlertID,
+			"Transition":     p.Transition,
+		})
+
+		status, err := p.status()
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from datadog: %v", err)
+			return
+		}
+
+		dedup := p.dedup()
+		if dedup == "" {
+			dedup = r.FormValue("dedup")
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(p.Title, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(p.details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceDatadog,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(dedup),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for datadog")) {
+			return
+		}
+	}
+}
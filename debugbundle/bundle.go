@@ -0,0 +1,281 @@
+package debugbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/version"
+)
+
+// Options configures the contents of a debug bundle.
+type Options struct {
+	DB     *sql.DB
+	Config config.Config
+
+	// RecentErrors are errors logged by the running instance, if available.
+	RecentErrors []log.ErrorEntry
+}
+
+type buildInfo struct {
+	Version   string
+	GitCommit string
+	BuildDate time.Time
+	GoVersion string
+	CreatedAt time.Time
+}
+
+type sectionError struct {
+	Error string
+}
+
+// Write will write a gzipped tar archive of diagnostic information to w.
+//
+// Secrets are redacted from the config, and no message destinations or contents are included.
+// A section that fails to collect is recorded in the archive with its error rather than
+// aborting the bundle.
+func Write(ctx context.Context, w io.Writer, opts Options) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	if opts.DB == nil {
+		return errors.New("debug bundle: DB is required")
+	}
+
+	now := time.Now()
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	add := func(name string, fn func() (interface{}, error)) error {
+		v, err := fn()
+		if err != nil {
+			v = sectionError{Error: err.Error()}
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "marshal %s", name)
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "write %s header", name)
+		}
+		_, err = tw.Write(data)
+		return errors.Wrapf(err, "write %s", name)
+	}
+
+	sections := []struct {
+		name string
+		fn   func() (interface{}, error)
+	}{
+		{"info.json", func() (interface{}, error) {
+			return buildInfo{
+				Version:   version.GitVersion(),
+				GitCommit: version.GitCommit(),
+				BuildDate: version.BuildDate(),
+				GoVersion: runtime.Version(),
+				CreatedAt: now,
+			}, nil
+		}},
+		{"config.json", func() (interface{}, error) { return opts.Config.Redacted(), nil }},
+		{"schema.json", func() (interface{}, error) { return migrate.Status(ctx, opts.DB) }},
+		{"engine.json", func() (interface{}, error) { return engineStatus(ctx, opts.DB) }},
+		{"queues.json", func() (interface{}, error) { return queueStatus(ctx, opts.DB, now) }},
+		{"errors.json", func() (interface{}, error) { return recentErrors(ctx, opts.DB, opts.RecentErrors) }},
+	}
+	for _, s := range sections {
+		err = add(s.name, s.fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return errors.Wrap(err, "close tar")
+	}
+
+	return errors.Wrap(gz.Close(), "close gzip")
+}
+
+type engineInfo struct {
+	ModuleVersions       map[string]int
+	EscalationLagSeconds float64
+	JobStates            map[string]int
+}
+
+func engineStatus(ctx context.Context, db *sql.DB) (*engineInfo, error) {
+	info := engineInfo{
+		ModuleVersions: make(map[string]int),
+		JobStates:      make(map[string]int),
+	}
+
+	rows, err := db.QueryContext(ctx, `select type_id::text, version from engine_processing_versions`)
+	if err != nil {
+		return nil, errors.Wrap(err, "query module versions")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var vers int
+		err = rows.Scan(&name, &vers)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan module version")
+		}
+		info.ModuleVersions[name] = vers
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "read module versions")
+	}
+
+	info.EscalationLagSeconds, err = gadb.New(db).EngineEscalationLag(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get escalation lag")
+	}
+
+	rows, err = db.QueryContext(ctx, `select state::text, count(*) from engine_jobs group by state`)
+	if err != nil {
+		return nil, errors.Wrap(err, "query job states")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var state string
+		var n int
+		err = rows.Scan(&state, &n)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan job state")
+		}
+		info.JobStates[state] = n
+	}
+
+	return &info, errors.Wrap(rows.Err(), "read job states")
+}
+
+type queueInfo struct {
+	DestType        string
+	Pending         int
+	OldestPendingAt time.Time
+	SentLastHour    int
+}
+
+func queueStatus(ctx context.Context, db *sql.DB, now time.Time) ([]queueInfo, error) {
+	store, err := notification.NewStore(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := store.MessageQueueStats(ctx, now.Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]queueInfo, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, queueInfo{
+			DestType:        s.DestType.String(),
+			Pending:         s.Pending,
+			OldestPendingAt: s.OldestPendingAt,
+			SentLastHour:    s.RecentlySent,
+		})
+	}
+
+	return result, nil
+}
+
+type failedMessage struct {
+	FailedAt      time.Time
+	MessageType   string
+	DestType      string
+	StatusDetails string
+}
+
+type failedJob struct {
+	Kind       string
+	State      string
+	Attempts   int
+	FinishedAt *time.Time
+	LastError  string
+}
+
+type errorInfo struct {
+	// Logged is only available when the bundle is generated by a running instance.
+	Logged         []log.ErrorEntry
+	FailedMessages []failedMessage
+	FailedJobs     []failedJob
+}
+
+func recentErrors(ctx context.Context, db *sql.DB, logged []log.ErrorEntry) (*errorInfo, error) {
+	info := errorInfo{Logged: logged}
+
+	// destination values are intentionally omitted, only the type is included
+	rows, err := db.QueryContext(ctx, `
+		select
+			coalesce(om.last_status_at, om.created_at),
+			om.message_type::text,
+			coalesce(cm.type::text, nc.type::text, ''),
+			om.status_details
+		from outgoing_messages om
+		left join user_contact_methods cm on cm.id = om.contact_method_id
+		left join notification_channels nc on nc.id = om.channel_id
+		where om.last_status = 'failed'
+		order by coalesce(om.last_status_at, om.created_at) desc
+		limit 50
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed messages")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m failedMessage
+		err = rows.Scan(&m.FailedAt, &m.MessageType, &m.DestType, &m.StatusDetails)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed message")
+		}
+		info.FailedMessages = append(info.FailedMessages, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "read failed messages")
+	}
+
+	rows, err = db.QueryContext(ctx, `
+		select kind, state::text, attempts, finished_at, last_error
+		from engine_jobs
+		where last_error notnull
+		order by coalesce(finished_at, created_at) desc
+		limit 20
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "query failed jobs")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var j failedJob
+		var finished sql.NullTime
+		err = rows.Scan(&j.Kind, &j.State, &j.Attempts, &finished, &j.LastError)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan failed job")
+		}
+		if finished.Valid {
+			j.FinishedAt = &finished.Time
+		}
+		info.FailedJobs = append(info.FailedJobs, j)
+	}
+
+	return &info, errors.Wrap(rows.Err(), "read failed jobs")
+}
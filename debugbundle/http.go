@@ -0,0 +1,39 @@
+package debugbundle
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// Handler returns an http.HandlerFunc that serves a debug bundle for the running instance.
+func Handler(db *sql.DB, cfgStore *config.Store, logger *log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if req.Method != "GET" {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		// buffer the bundle so failures can still be reported with a proper status code
+		var buf bytes.Buffer
+		err := Write(ctx, &buf, Options{
+			DB:           db,
+			Config:       cfgStore.Config(),
+			RecentErrors: logger.RecentErrors(),
+		})
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="goalert-debug-%s.tar.gz"`, time.Now().UTC().Format("20060102T150405Z")))
+		_, _ = w.Write(buf.Bytes())
+	}
+}
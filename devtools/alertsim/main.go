@@ -0,0 +1,269 @@
+// Command alertsim replays alert arrival patterns against GoAlert integration keys
+// and reports the end-to-end time-to-notification using mocktwilio.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/devtools/mocktwilio"
+)
+
+type sim struct {
+	apiURL string
+	keys   []string
+	runID  string
+	client *http.Client
+
+	mx       sync.Mutex
+	sentAt   map[int]time.Time
+	latency  map[int]time.Duration
+	failed   int
+	notified chan struct{}
+}
+
+func main() {
+	log.SetFlags(log.Lshortfile)
+	apiURL := flag.String("url", "http://localhost:3030", "GoAlert base URL.")
+	keys := flag.String("keys", "", "Comma-separated list of generic API integration key tokens to send alerts to.")
+	twilioAddr := flag.String("twilio-addr", "localhost:3099", "Listen address for the mock Twilio server (GoAlert should be started with --twilio-base-url pointing here).")
+	twilioSID := flag.String("twilio-sid", "AC00000000000000000000000000000000", "Twilio account SID configured in GoAlert.")
+	twilioToken := flag.String("twilio-token", "", "Twilio auth token configured in GoAlert.")
+	minQueue := flag.Duration("twilio-queue-time", 100*time.Millisecond, "Minimum time messages and calls sit in the mock Twilio queue.")
+
+	var cfg PatternConfig
+	flag.StringVar((*string)(&cfg.Pattern), "pattern", string(PatternSteady), "Arrival pattern: steady, burst, or storm.")
+	flag.Float64Var(&cfg.Rate, "rate", 1, "Base alert rate (alerts per second).")
+	flag.DurationVar(&cfg.Duration, "duration", time.Minute, "How long to send alerts for.")
+	flag.IntVar(&cfg.BurstSize, "burst-size", 50, "Number of alerts sent at once for each burst (burst pattern).")
+	flag.DurationVar(&cfg.BurstEvery, "burst-every", 15*time.Second, "Time between bursts (burst pattern).")
+	flag.Float64Var(&cfg.StormFactor, "storm-factor", 20, "Multiple of the base rate reached at the end of the run (storm pattern).")
+
+	concurrency := flag.Int("concurrency", 50, "Maximum number of in-flight alert requests.")
+	wait := flag.Duration("wait", 2*time.Minute, "Time to wait for outstanding notifications after all alerts are sent.")
+	jsonOut := flag.String("json", "", "If set, write the report as JSON to the given file.")
+	flag.Parse()
+
+	if *keys == "" {
+		log.Fatal("at least one integration key is required (-keys)")
+	}
+	if *twilioToken == "" {
+		log.Fatal("twilio auth token is required (-twilio-token)")
+	}
+	schedule, err := cfg.Schedule()
+	if err != nil {
+		log.Fatal("invalid pattern:", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	srv := mocktwilio.NewServer(mocktwilio.Config{
+		AccountSID:   *twilioSID,
+		AuthToken:    *twilioToken,
+		MinQueueTime: *minQueue,
+	})
+	defer srv.Close()
+	l, err := net.Listen("tcp", *twilioAddr)
+	if err != nil {
+		log.Fatal("listen:", err)
+	}
+	go func() { _ = http.Serve(l, srv) }()
+
+	var runID [4]byte
+	_, err = rand.Read(runID[:])
+	if err != nil {
+		log.Fatal("generate run ID:", err)
+	}
+
+	s := &sim{
+		apiURL:   strings.TrimSuffix(*apiURL, "/"),
+		keys:     strings.Split(*keys, ","),
+		runID:    hex.EncodeToString(runID[:]),
+		client:   &http.Client{Timeout: 30 * time.Second},
+		sentAt:   make(map[int]time.Time, len(schedule)),
+		latency:  make(map[int]time.Duration, len(schedule)),
+		notified: make(chan struct{}, 1),
+	}
+	go s.receive(ctx, srv)
+
+	log.Printf("run %s: sending %d alerts (%s pattern) over %s", s.runID, len(schedule), cfg.Pattern, cfg.Duration)
+	s.send(ctx, schedule, *concurrency)
+
+	log.Printf("all alerts sent, waiting up to %s for notifications", *wait)
+	s.waitNotified(ctx, *wait)
+
+	s.mx.Lock()
+	latencies := make([]time.Duration, 0, len(s.latency))
+	for _, dur := range s.latency {
+		latencies = append(latencies, dur)
+	}
+	rep := newReport(cfg.Pattern, cfg.Duration, len(s.sentAt), s.failed, latencies)
+	s.mx.Unlock()
+
+	_, err = rep.WriteTo(os.Stdout)
+	if err != nil {
+		log.Fatal("write report:", err)
+	}
+
+	if *jsonOut != "" {
+		data, err := json.MarshalIndent(rep, "", "  ")
+		if err != nil {
+			log.Fatal("encode report:", err)
+		}
+		err = os.WriteFile(*jsonOut, data, 0o644)
+		if err != nil {
+			log.Fatal("write report:", err)
+		}
+	}
+}
+
+// send will create alerts according to the schedule, blocking until all requests have completed.
+func (s *sim) send(ctx context.Context, schedule []time.Duration, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i, offset := range schedule {
+		t := time.NewTimer(time.Until(start.Add(offset)))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			wg.Wait()
+			return
+		case <-t.C:
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.sendAlert(ctx, n)
+		}(i + 1)
+	}
+	wg.Wait()
+}
+
+func (s *sim) sendAlert(ctx context.Context, n int) {
+	v := make(url.Values)
+	v.Set("token", s.keys[n%len(s.keys)])
+	v.Set("summary", fmt.Sprintf("alertsim-%s-%d", s.runID, n))
+	v.Set("details", "Generated by alertsim.")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.apiURL+"/api/v2/generic/incoming", strings.NewReader(v.Encode()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	sent := time.Now()
+	s.mx.Lock()
+	s.sentAt[n] = sent
+	s.mx.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			err = fmt.Errorf("non-2xx response: %s", resp.Status)
+		}
+	}
+	if err != nil {
+		log.Printf("send alert %d: %v", n, err)
+		s.mx.Lock()
+		delete(s.sentAt, n)
+		s.failed++
+		s.mx.Unlock()
+	}
+}
+
+// receive will record notifications as they arrive at the mock Twilio server.
+func (s *sim) receive(ctx context.Context, srv *mocktwilio.Server) {
+	rx := regexp.MustCompile(`alertsim-` + s.runID + `-(\d+)`)
+	record := func(body string) {
+		at := time.Now()
+		m := rx.FindStringSubmatch(body)
+		if m == nil {
+			return
+		}
+		n, _ := strconv.Atoi(m[1])
+
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		sent, ok := s.sentAt[n]
+		if !ok {
+			return
+		}
+		if _, ok := s.latency[n]; ok {
+			// only the first notification counts
+			return
+		}
+		s.latency[n] = at.Sub(sent)
+		select {
+		case s.notified <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-srv.Errors():
+			log.Println("mocktwilio:", err)
+		case sms := <-srv.SMS():
+			record(sms.Body())
+			go sms.Accept()
+		case vc := <-srv.VoiceCalls():
+			go func() {
+				record(vc.Body())
+				vc.Accept()
+				vc.Hangup()
+			}()
+		}
+	}
+}
+
+// waitNotified will block until every sent alert has been notified, or the timeout expires.
+func (s *sim) waitNotified(ctx context.Context, timeout time.Duration) {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	for {
+		s.mx.Lock()
+		done := len(s.latency) >= len(s.sentAt)
+		s.mx.Unlock()
+		if done {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			return
+		case <-s.notified:
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Pattern describes how alerts arrive over the course of a simulation.
+type Pattern string
+
+// Supported arrival patterns.
+const (
+	// PatternSteady sends alerts evenly spaced at the base rate.
+	PatternSteady Pattern = "steady"
+
+	// PatternBurst sends alerts at the base rate, with BurstSize additional alerts
+	// sent all at once every BurstEvery.
+	PatternBurst Pattern = "burst"
+
+	// PatternStorm ramps linearly from the base rate to StormFactor times the base
+	// rate over the duration of the simulation.
+	PatternStorm Pattern = "storm"
+)
+
+// PatternConfig configures an arrival pattern.
+type PatternConfig struct {
+	Pattern  Pattern
+	Rate     float64 // alerts per second
+	Duration time.Duration
+
+	BurstSize  int
+	BurstEvery time.Duration
+
+	StormFactor float64
+}
+
+// Schedule will return the offset (from the start of the simulation) at which
+// each alert should be sent, in ascending order.
+func (cfg PatternConfig) Schedule() ([]time.Duration, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("rate must be > 0")
+	}
+	if cfg.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be > 0")
+	}
+
+	var result []time.Duration
+	switch cfg.Pattern {
+	case PatternSteady:
+		result = steady(cfg.Rate, cfg.Duration)
+	case PatternBurst:
+		if cfg.BurstSize <= 0 || cfg.BurstEvery <= 0 {
+			return nil, fmt.Errorf("burst size and interval must be > 0")
+		}
+		base := steady(cfg.Rate, cfg.Duration)
+		var bursts []time.Duration
+		for t := cfg.BurstEvery; t < cfg.Duration; t += cfg.BurstEvery {
+			for i := 0; i < cfg.BurstSize; i++ {
+				bursts = append(bursts, t)
+			}
+		}
+		result = merge(base, bursts)
+	case PatternStorm:
+		if cfg.StormFactor < 1 {
+			return nil, fmt.Errorf("storm factor must be >= 1")
+		}
+		// rate(t) = r0 + (r1-r0)*t/d; walk forward one alert at a time
+		r0 := cfg.Rate
+		r1 := cfg.Rate * cfg.StormFactor
+		d := cfg.Duration.Seconds()
+		var t float64
+		for {
+			t += 1 / (r0 + (r1-r0)*t/d)
+			if t >= d {
+				break
+			}
+			result = append(result, time.Duration(t*float64(time.Second)))
+		}
+	default:
+		return nil, fmt.Errorf("unknown pattern '%s'", cfg.Pattern)
+	}
+
+	return result, nil
+}
+
+func steady(rate float64, dur time.Duration) []time.Duration {
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval <= 0 {
+		interval = 1
+	}
+	var result []time.Duration
+	for t := time.Duration(0); t < dur; t += interval {
+		result = append(result, t)
+	}
+	return result
+}
+
+// merge will combine two sorted slices, preserving order.
+func merge(a, b []time.Duration) []time.Duration {
+	result := make([]time.Duration, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] <= b[0] {
+			result = append(result, a[0])
+			a = a[1:]
+		} else {
+			result = append(result, b[0])
+			b = b[1:]
+		}
+	}
+	result = append(result, a...)
+	return append(result, b...)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternConfig_Schedule(t *testing.T) {
+	check := func(name string, cfg PatternConfig, expCount int) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			sched, err := cfg.Schedule()
+			require.NoError(t, err)
+			assert.Len(t, sched, expCount)
+			assert.IsNonDecreasing(t, sched)
+			for _, d := range sched {
+				assert.Less(t, d, cfg.Duration)
+			}
+		})
+	}
+
+	check("steady", PatternConfig{Pattern: PatternSteady, Rate: 2, Duration: 10 * time.Second}, 20)
+	check("burst", PatternConfig{Pattern: PatternBurst, Rate: 1, Duration: 10 * time.Second, BurstSize: 5, BurstEvery: 3 * time.Second}, 10+3*5)
+
+	sched, err := PatternConfig{Pattern: PatternStorm, Rate: 1, Duration: time.Minute, StormFactor: 10}.Schedule()
+	require.NoError(t, err)
+	// average rate is 5.5/s
+	assert.InDelta(t, 330, len(sched), 5)
+	assert.IsNonDecreasing(t, sched)
+
+	_, err = PatternConfig{Pattern: "nope", Rate: 1, Duration: time.Second}.Schedule()
+	assert.Error(t, err)
+}
+
+func TestNewReport(t *testing.T) {
+	var lat []time.Duration
+	for i := 100; i > 0; i-- {
+		lat = append(lat, time.Duration(i)*time.Millisecond)
+	}
+	r := newReport(PatternSteady, time.Minute, 105, 1, lat)
+	assert.Equal(t, 100, r.Notified)
+	assert.Equal(t, 5, r.Missing)
+	assert.Equal(t, time.Millisecond, r.Min)
+	assert.Equal(t, 50*time.Millisecond, r.P50)
+	assert.Equal(t, 99*time.Millisecond, r.P99)
+	assert.Equal(t, 100*time.Millisecond, r.Max)
+}
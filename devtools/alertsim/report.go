@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Report summarizes the results of a simulation run.
+type Report struct {
+	Pattern  Pattern
+	Duration time.Duration
+
+	Sent     int // alerts accepted by the API
+	Failed   int // alert requests that returned an error
+	Notified int // alerts that produced at least one notification
+	Missing  int // alerts sent that never produced a notification
+
+	// Latency percentiles for time-to-notification, measured from the
+	// alert being sent to the first SMS or voice call arriving at the mock.
+	Min, P50, P90, P95, P99, Max time.Duration
+}
+
+// newReport will build a Report from the given latencies.
+func newReport(p Pattern, dur time.Duration, sent, failed int, latencies []time.Duration) Report {
+	r := Report{
+		Pattern:  p,
+		Duration: dur,
+		Sent:     sent,
+		Failed:   failed,
+		Notified: len(latencies),
+		Missing:  sent - len(latencies),
+	}
+	if len(latencies) == 0 {
+		return r
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	r.Min = sorted[0]
+	r.P50 = percentile(sorted, 50)
+	r.P90 = percentile(sorted, 90)
+	r.P95 = percentile(sorted, 95)
+	r.P99 = percentile(sorted, 99)
+	r.Max = sorted[len(sorted)-1]
+
+	return r
+}
+
+// percentile returns the nearest-rank percentile of a sorted slice.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := (p*len(sorted)+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// WriteTo will write a human-readable version of the report to w.
+func (r Report) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	tw := tabwriter.NewWriter(cw, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Pattern:\t%s\n", r.Pattern)
+	fmt.Fprintf(tw, "Duration:\t%s\n", r.Duration)
+	fmt.Fprintf(tw, "Sent:\t%d\n", r.Sent)
+	fmt.Fprintf(tw, "Failed:\t%d\n", r.Failed)
+	fmt.Fprintf(tw, "Notified:\t%d\n", r.Notified)
+	fmt.Fprintf(tw, "Missing:\t%d\n", r.Missing)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Time-to-notification\t")
+	fmt.Fprintf(tw, "  min\t%s\n", r.Min)
+	fmt.Fprintf(tw, "  p50\t%s\n", r.P50)
+	fmt.Fprintf(tw, "  p90\t%s\n", r.P90)
+	fmt.Fprintf(tw, "  p95\t%s\n", r.P95)
+	fmt.Fprintf(tw, "  p99\t%s\n", r.P99)
+	fmt.Fprintf(tw, "  max\t%s\n", r.Max)
+	err := tw.Flush()
+	return cw.n, err
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
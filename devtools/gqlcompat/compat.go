@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// A Change is a breaking change between two versions of the schema.
+type Change struct {
+	// ID identifies the changed schema element, and is used for allowlist entries.
+	//
+	// It is one of "Type", "Type.field", "Type.field(arg)", or "Enum.VALUE".
+	ID      string
+	Message string
+}
+
+func (c Change) String() string { return c.ID + ": " + c.Message }
+
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) add(id, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{ID: id, Message: fmt.Sprintf(format, args...)})
+}
+
+// definitions returns all definitions by name, with extensions merged in.
+func definitions(doc *ast.SchemaDocument) map[string]*ast.Definition {
+	defs := make(map[string]*ast.Definition, len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		cpy := *def
+		defs[def.Name] = &cpy
+	}
+	for _, ext := range doc.Extensions {
+		def := defs[ext.Name]
+		if def == nil {
+			cpy := *ext
+			defs[ext.Name] = &cpy
+			continue
+		}
+		def.Fields = append(append(ast.FieldList{}, def.Fields...), ext.Fields...)
+		def.EnumValues = append(append(ast.EnumValueList{}, def.EnumValues...), ext.EnumValues...)
+		def.Types = append(append([]string{}, def.Types...), ext.Types...)
+	}
+	return defs
+}
+
+// Compare returns all breaking changes from oldDoc to newDoc, sorted by ID.
+//
+// A change is breaking if a client that works with the old schema could fail with
+// the new one: removing a type, field, argument, enum value, or union member, adding
+// a required argument or input field, or changing the type of a field or argument
+// (other than making an output non-null, or an input nullable).
+func Compare(oldDoc, newDoc *ast.SchemaDocument) []Change {
+	var c comparer
+
+	oldDefs := definitions(oldDoc)
+	newDefs := definitions(newDoc)
+	for name, oldDef := range oldDefs {
+		newDef := newDefs[name]
+		if newDef == nil {
+			c.add(name, "%s removed", kindName(oldDef.Kind))
+			continue
+		}
+		if newDef.Kind != oldDef.Kind {
+			c.add(name, "changed from %s to %s", kindName(oldDef.Kind), kindName(newDef.Kind))
+			continue
+		}
+
+		switch oldDef.Kind {
+		case ast.Object, ast.Interface:
+			c.compareOutputFields(oldDef, newDef)
+		case ast.InputObject:
+			c.compareInputFields(oldDef, newDef)
+		case ast.Enum:
+			for _, v := range oldDef.EnumValues {
+				if newDef.EnumValues.ForName(v.Name) == nil {
+					c.add(name+"."+v.Name, "enum value removed")
+				}
+			}
+		case ast.Union:
+			for _, t := range oldDef.Types {
+				if !contains(newDef.Types, t) {
+					c.add(name, "union member %s removed", t)
+				}
+			}
+		}
+	}
+
+	sort.Slice(c.changes, func(i, j int) bool {
+		if c.changes[i].ID != c.changes[j].ID {
+			return c.changes[i].ID < c.changes[j].ID
+		}
+		return c.changes[i].Message < c.changes[j].Message
+	})
+	return c.changes
+}
+
+func (c *comparer) compareOutputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		id := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			c.add(id, "field removed")
+			continue
+		}
+		if !outputCompatible(oldField.Type, newField.Type) {
+			c.add(id, "type changed from %s to %s", oldField.Type, newField.Type)
+		}
+
+		for _, oldArg := range oldField.Arguments {
+			argID := id + "(" + oldArg.Name + ")"
+			newArg := newField.Arguments.ForName(oldArg.Name)
+			if newArg == nil {
+				c.add(argID, "argument removed")
+				continue
+			}
+			if !inputCompatible(oldArg.Type, newArg.Type) {
+				c.add(argID, "type changed from %s to %s", oldArg.Type, newArg.Type)
+			}
+		}
+		for _, newArg := range newField.Arguments {
+			if oldField.Arguments.ForName(newArg.Name) == nil && newArg.Type.NonNull && newArg.DefaultValue == nil {
+				c.add(id+"("+newArg.Name+")", "required argument added")
+			}
+		}
+	}
+}
+
+func (c *comparer) compareInputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		id := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			c.add(id, "input field removed")
+			continue
+		}
+		if !inputCompatible(oldField.Type, newField.Type) {
+			c.add(id, "type changed from %s to %s", oldField.Type, newField.Type)
+		}
+	}
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) == nil && newField.Type.NonNull && newField.DefaultValue == nil {
+			c.add(newDef.Name+"."+newField.Name, "required input field added")
+		}
+	}
+}
+
+// outputCompatible returns true if clients expecting oldType can read newType, i.e., they
+// are the same or newType is only stricter about null values.
+func outputCompatible(oldType, newType *ast.Type) bool {
+	if oldType.NonNull && !newType.NonNull {
+		return false
+	}
+	return elemCompatible(oldType, newType, outputCompatible)
+}
+
+// inputCompatible returns true if values valid for oldType are valid for newType, i.e., they
+// are the same or newType only allows null where oldType did not.
+func inputCompatible(oldType, newType *ast.Type) bool {
+	if newType.NonNull && !oldType.NonNull {
+		return false
+	}
+	return elemCompatible(oldType, newType, inputCompatible)
+}
+
+func elemCompatible(oldType, newType *ast.Type, compat func(a, b *ast.Type) bool) bool {
+	if (oldType.Elem == nil) != (newType.Elem == nil) {
+		return false
+	}
+	if oldType.Elem != nil {
+		return compat(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+func kindName(k ast.DefinitionKind) string {
+	switch k {
+	case ast.InputObject:
+		return "input"
+	case ast.Object:
+		return "type"
+	}
+	return strings.ToLower(string(k))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
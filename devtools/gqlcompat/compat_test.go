@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func mustParse(t *testing.T, input string) *ast.SchemaDocument {
+	t.Helper()
+	doc, err := parser.ParseSchema(&ast.Source{Name: "schema.graphql", Input: input})
+	require.NoError(t, err)
+	return doc
+}
+
+func TestCompare(t *testing.T) {
+	oldDoc := mustParse(t, `
+		type Query {
+			alert(id: Int!): Alert
+			alerts(first: Int, search: String): [Alert!]!
+			removed: String
+		}
+		type Alert {
+			id: Int!
+			summary: String
+			status: AlertStatus!
+		}
+		enum AlertStatus { OPEN CLOSED ACKED }
+		input AlertInput {
+			summary: String!
+			details: String
+		}
+		union Target = Alert
+		type Gone { id: ID! }
+	`)
+	newDoc := mustParse(t, `
+		type Query {
+			alert(id: Int!, extra: String): Alert!
+			alerts(first: Int, search: String!, mode: String!, opt: String! = "x"): [Alert]!
+			added: String
+		}
+		type Alert {
+			id: String!
+			summary: String!
+			status: AlertStatus!
+		}
+		extend type Alert { details: String }
+		enum AlertStatus { OPEN CLOSED }
+		input AlertInput {
+			summary: String
+			details: String
+			dedup: String!
+		}
+		union Target = Query
+	`)
+
+	var ids []string
+	for _, c := range Compare(oldDoc, newDoc) {
+		ids = append(ids, c.String())
+	}
+	assert.Equal(t, []string{
+		"Alert.id: type changed from Int! to String!",
+		"AlertInput.dedup: required input field added",
+		"AlertStatus.ACKED: enum value removed",
+		"Gone: type removed",
+		"Query.alerts: type changed from [Alert!]! to [Alert]!",
+		"Query.alerts(mode): required argument added",
+		"Query.alerts(search): type changed from String to String!",
+		"Query.removed: field removed",
+		"Target: union member Alert removed",
+	}, ids)
+}
+
+func TestCompare_Identical(t *testing.T) {
+	schema := `
+		type Query { alert(id: Int!): Alert }
+		type Alert { id: Int! }
+	`
+	assert.Empty(t, Compare(mustParse(t, schema), mustParse(t, schema)))
+}
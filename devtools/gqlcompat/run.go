@@ -0,0 +1,100 @@
+// gqlcompat checks the GraphQL schema for breaking changes since the last release.
+//
+// Intentional breaking changes (e.g., removing a field after its deprecation period) can be
+// listed in the allowlist file, one ID per line (e.g., "Query.oldField" or "Alert.status(arg)").
+// Blank lines and lines starting with # are ignored.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func main() {
+	log.SetFlags(log.Lshortfile)
+	base := flag.String("base", "", "Git ref of the schema to compare against. Defaults to the most recent release tag.")
+	schemaFile := flag.String("schema", "graphql2/schema.graphql", "Path to the GraphQL schema, relative to the repository root.")
+	allowFile := flag.String("allow", "graphql2/schema-compat-allow.txt", "Allowlist of intentional breaking changes.")
+	flag.Parse()
+
+	if *base == "" {
+		*base = git("describe", "--tags", "--abbrev=0", "--match", "v*")
+	}
+
+	oldDoc := parse(*base+":"+*schemaFile, git("show", *base+":"+*schemaFile))
+	data, err := os.ReadFile(*schemaFile)
+	if err != nil {
+		log.Fatal("ERROR: ", err)
+	}
+	newDoc := parse(*schemaFile, string(data))
+
+	allowed, err := readAllowlist(*allowFile)
+	if err != nil {
+		log.Fatal("ERROR: ", err)
+	}
+
+	var failed bool
+	for _, c := range Compare(oldDoc, newDoc) {
+		if allowed[c.ID] {
+			fmt.Println("allowed:", c)
+			continue
+		}
+		fmt.Println("BREAKING:", c)
+		failed = true
+	}
+	if failed {
+		fmt.Printf("\nBreaking GraphQL schema changes since %s.\n", *base)
+		fmt.Printf("If intentional (e.g., removing a deprecated field), add the IDs above to %s.\n", *allowFile)
+		os.Exit(1)
+	}
+}
+
+func git(args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = os.Stderr
+	data, err := cmd.Output()
+	if err != nil {
+		log.Fatalf("ERROR: git %s: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func parse(name, input string) *ast.SchemaDocument {
+	doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: input})
+	if err != nil {
+		log.Fatal("ERROR: ", err)
+	}
+	return doc
+}
+
+func readAllowlist(file string) (map[string]bool, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		allowed[line] = true
+	}
+
+	return allowed, s.Err()
+}
@@ -84,6 +84,7 @@ func NewServer(cfg Config) *Server {
 
 	base := "/2010-04-01/Accounts/" + cfg.AccountSID
 
+	s.mux.HandleFunc(base+".json", s.serveAccount)
 	s.mux.HandleFunc(base+"/Calls.json", s.serveNewCall)
 	s.mux.HandleFunc(base+"/Messages.json", s.serveNewMessage)
 	s.mux.HandleFunc(base+"/Calls/", s.serveCallStatus)
@@ -134,6 +135,14 @@ func (s *Server) id(prefix string) string {
 	return fmt.Sprintf("%s%032d", prefix, atomic.AddUint64(&s.sidSeq, 1))
 }
 
+func (s *Server) serveAccount(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		SID    string `json:"sid"`
+		Status string `json:"status"`
+	}{SID: s.cfg.AccountSID, Status: "active"})
+}
+
 // Close will shutdown the server loop.
 func (s *Server) Close() error {
 	close(s.shutdown)
@@ -24,4 +24,16 @@ const (
 	UniqueLabelKeys      = 20    // select count(distinct key) from labels
 	LabelValueMax        = 13    // select count(distinct value) from labels group by key order by count desc limit 1
 	MsgPerAlertMax       = 2
+	AlertHistoryDays     = 180
+	AlertBatchSize       = 10000
+)
+
+// Counts used by the "large" profile, intended for load testing and UI pagination.
+//
+// Other types (e.g., rotations, schedules, policies) are scaled by the same
+// ratio as users.
+const (
+	LargeUserCount        = 10000
+	LargeSvcCount         = 2000
+	LargeAlertClosedCount = 2000000
 )
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"sort"
 	"strings"
 	"time"
@@ -79,6 +80,7 @@ type datagenConfig struct {
 	UniqueLabelKeys      int
 	LabelValueMax        int
 	MsgPerAlertMax       int
+	AlertHistoryDays     int
 
 	AdminID string
 }
@@ -120,11 +122,15 @@ type datagen struct {
 	Labels             []label.Label
 	AlertMessages      []AlertMsg
 
-	ids          *uniqGen
-	ints         *uniqIntGen
-	alertDetails []string
-	labelKeyVal  map[string][]string
-	labelKeys    []string
+	ids            *uniqGen
+	ints           *uniqIntGen
+	alertDetails   []string
+	alertSummaries []string
+	alertIDOffset  int
+	alertHistory   time.Duration
+	svcZipf        *rand.Zipf
+	labelKeyVal    map[string][]string
+	labelKeys      []string
 
 	*gofakeit.Faker
 }
@@ -364,24 +370,47 @@ func (d *datagen) NewAlert(status alert.Status) {
 	case 4:
 		src = alert.SourceSite24x7
 	}
-	var serviceID string
-	if status == alert.StatusTriggered {
-		serviceID = d.ids.GenN(200, func() string { return d.Services[d.Intn(len(d.Services))].ID }, "active-alerts")
+	var serviceID, summary string
+	if status == alert.StatusClosed {
+		// unlimited closed alerts, a handful of noisy services account for most of them
+		serviceID = d.Services[d.svcZipf.Uint64()].ID
+
+		// closed alerts don't need to be unique, and real ones tend to repeat
+		summary = d.RandomString(d.alertSummaries)
 	} else {
-		// unlimited closed alerts
-		serviceID = d.Services[d.Intn(len(d.Services))].ID
+		serviceID = d.ids.GenN(200, func() string { return d.Services[d.Intn(len(d.Services))].ID }, "active-alerts")
+		summary = d.ids.Gen(func() string { return d.LoremIpsumSentence(d.Intn(10) + 3) }, serviceID)
 	}
 	d.Alerts = append(d.Alerts, alert.Alert{
-		ID:        len(d.Alerts) + 1,
-		CreatedAt: d.DateRange(time.Now().Add(-180*24*time.Hour), time.Now().Add(-1*time.Hour)),
+		ID:        d.alertIDOffset + len(d.Alerts) + 1,
+		CreatedAt: d.alertTime(),
 		Status:    status,
 		ServiceID: serviceID,
-		Summary:   d.ids.Gen(func() string { return d.LoremIpsumSentence(d.Intn(10) + 3) }, serviceID),
+		Summary:   summary,
 		Details:   details,
 		Source:    src,
 	})
 }
 
+// alertTime will return a random time within the alert history window, weighted
+// toward weekday business hours (US Central) when most alerts are created.
+func (d *datagen) alertTime() time.Time {
+	var t time.Time
+	for i := 0; i < 3; i++ {
+		t = d.DateRange(time.Now().Add(-d.alertHistory), time.Now().Add(-1*time.Hour))
+		hour := t.UTC().Hour()
+		isWeekday := t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+		if isWeekday && hour >= 14 && hour < 23 {
+			return t
+		}
+		if d.Float64() < 0.35 {
+			return t
+		}
+	}
+
+	return t
+}
+
 func (d *datagen) NewAlertMessages(a alert.Alert, max int) {
 	getEPID := func(svcID string) string {
 		idx := sort.Search(len(d.Services), func(n int) bool {
@@ -541,6 +570,7 @@ func (cfg *datagenConfig) SetDefaults() {
 	setDefault(&cfg.UniqueLabelKeys, UniqueLabelKeys)
 	setDefault(&cfg.LabelValueMax, LabelValueMax)
 	setDefault(&cfg.MsgPerAlertMax, MsgPerAlertMax)
+	setDefault(&cfg.AlertHistoryDays, AlertHistoryDays)
 }
 
 // Multiply will multiply the following counts:
@@ -567,10 +597,11 @@ func (cfg datagenConfig) Generate() datagen {
 	d := datagen{
 		Faker: f,
 
-		ids:         newGen(f),
-		ints:        newUniqIntGen(f),
-		labelKeyVal: make(map[string][]string),
-		Alerts:      make([]alert.Alert, 0, cfg.AlertClosedCount+cfg.AlertActiveCount),
+		ids:          newGen(f),
+		ints:         newUniqIntGen(f),
+		labelKeyVal:  make(map[string][]string),
+		Alerts:       make([]alert.Alert, 0, cfg.AlertActiveCount),
+		alertHistory: time.Duration(cfg.AlertHistoryDays) * 24 * time.Hour,
 	}
 
 	run := func(times int, fn func()) int {
@@ -653,8 +684,13 @@ func (cfg datagenConfig) Generate() datagen {
 	for i := range d.alertDetails {
 		d.alertDetails[i] = d.LoremIpsumParagraph(2, 4, 10, "\n\n")
 	}
+	d.alertSummaries = make([]string, 500)
+	for i := range d.alertSummaries {
+		d.alertSummaries[i] = d.LoremIpsumSentence(d.Intn(10) + 3)
+	}
 
-	run(cfg.AlertClosedCount, func() { d.NewAlert(alert.StatusClosed) })
+	// closed alerts are generated separately (see GenerateClosedAlerts) and take the first IDs
+	d.alertIDOffset = cfg.AlertClosedCount
 	run(cfg.AlertActiveCount, func() { d.NewAlert(alert.StatusActive) })
 
 	for _, alert := range d.Alerts {
@@ -665,3 +701,51 @@ func (cfg datagenConfig) Generate() datagen {
 
 	return d
 }
+
+// GenerateClosedAlerts will generate closed alerts for the dataset d, along with their logs, messages, and feedback,
+// calling fn with batches of at most batchSize alerts.
+//
+// Batches are generated lazily so that millions of alerts can be created without holding
+// them all in memory; the provided batch is only valid until fn returns.
+func (cfg datagenConfig) GenerateClosedAlerts(d *datagen, batchSize int, fn func(batch *datagen) error) error {
+	if cfg.AlertClosedCount == 0 {
+		return nil
+	}
+
+	f := gofakeit.New(cfg.Seed + 1)
+	b := &datagen{
+		Faker: f,
+
+		Services:       d.Services,
+		ContactMethods: d.ContactMethods,
+		alertDetails:   d.alertDetails,
+		alertSummaries: d.alertSummaries,
+		alertHistory:   d.alertHistory,
+		svcZipf:        rand.NewZipf(f.Rand, 1.1, 4, uint64(len(d.Services)-1)),
+	}
+
+	for b.alertIDOffset < cfg.AlertClosedCount {
+		b.Alerts = b.Alerts[:0]
+		b.AlertLogs = b.AlertLogs[:0]
+		b.AlertMessages = b.AlertMessages[:0]
+		b.AlertFeedback = b.AlertFeedback[:0]
+
+		n := min(batchSize, cfg.AlertClosedCount-b.alertIDOffset)
+		for i := 0; i < n; i++ {
+			b.NewAlert(alert.StatusClosed)
+		}
+		for _, a := range b.Alerts {
+			b.NewAlertLogs(a)
+			b.NewAlertMessages(a, cfg.MsgPerAlertMax)
+			b.NewAlertFeedback(a)
+		}
+
+		err := fn(b)
+		if err != nil {
+			return err
+		}
+		b.alertIDOffset += n
+	}
+
+	return nil
+}
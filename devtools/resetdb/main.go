@@ -26,6 +26,12 @@ func main() {
 	flag.StringVar(&adminID, "admin-id", "", "Generate an admin user with the given ID.")
 	seedVal := flag.Int64("seed", 1, "Change the random seed used to generate data.")
 	mult := flag.Float64("mult", 1, "Multiply base type counts (e.g., alerts, users, services).")
+	profile := flag.String("profile", "default", "Base data profile to use (default or large). The large profile generates 10k users, 2k services, and 2M closed alerts.")
+	userCount := flag.Int("users", 0, "If set, overrides the number of users to generate.")
+	svcCount := flag.Int("services", 0, "If set, overrides the number of services to generate.")
+	closedCount := flag.Int("closed-alerts", 0, "If set, overrides the number of closed alerts to generate.")
+	activeCount := flag.Int("active-alerts", 0, "If set, overrides the number of active alerts to generate.")
+	historyDays := flag.Int("alert-history-days", AlertHistoryDays, "Number of days of alert history to generate.")
 	genData := flag.Bool("with-rand-data", false, "Repopulates the DB with random data.")
 	skipMigrate := flag.Bool("no-migrate", false, "Disables UP migration.")
 	skipDrop := flag.Bool("skip-drop", false, "Skip database drop/create step.")
@@ -66,9 +72,28 @@ func main() {
 	if !*genData {
 		return
 	}
-	dataCfg := &datagenConfig{AdminID: adminID, Seed: *seedVal}
+	dataCfg := &datagenConfig{AdminID: adminID, Seed: *seedVal, AlertHistoryDays: *historyDays}
 	dataCfg.SetDefaults()
+	switch *profile {
+	case "default":
+	case "large":
+		dataCfg.Multiply(float64(LargeUserCount) / float64(UserCount))
+		dataCfg.UserCount = LargeUserCount
+		dataCfg.SvcCount = LargeSvcCount
+		dataCfg.AlertClosedCount = LargeAlertClosedCount
+	default:
+		log.Fatalf("unknown profile '%s'", *profile)
+	}
 	dataCfg.Multiply(*mult)
+	setCount := func(val *int, n int) {
+		if n > 0 {
+			*val = n
+		}
+	}
+	setCount(&dataCfg.UserCount, *userCount)
+	setCount(&dataCfg.SvcCount, *svcCount)
+	setCount(&dataCfg.AlertClosedCount, *closedCount)
+	setCount(&dataCfg.AlertActiveCount, *activeCount)
 	err = fillDB(ctx, dataCfg, *dbURL)
 	if err != nil {
 		log.Fatal("insert random data:", err)
@@ -257,33 +282,73 @@ func fillDB(ctx context.Context, dataCfg *datagenConfig, url string) error {
 
 	_, err = pool.Exec(ctx, "alter table alerts disable trigger trg_enforce_alert_limit")
 	must(err)
-	copyFrom("alerts", []string{"id", "created_at", "status", "summary", "details", "dedup_key", "service_id", "source"}, len(data.Alerts), func(n int) []interface{} {
-		a := data.Alerts[n]
+
+	alertCols := []string{"id", "created_at", "status", "summary", "details", "dedup_key", "service_id", "source"}
+	alertRow := func(a alert.Alert) []interface{} {
 		var dedup *alert.DedupID
 		if a.Status != alert.StatusClosed {
 			dedup = a.DedupKey()
 		}
 		return []interface{}{a.ID, a.CreatedAt, a.Status, a.Summary, a.Details, dedup, asUUID(a.ServiceID), a.Source}
-	}, "services")
-
-	copyFrom("alert_logs", []string{"alert_id", "timestamp", "event", "message", "sub_type", "sub_user_id", "sub_classifier", "meta"}, len(data.AlertLogs), func(n int) []interface{} {
-		a := data.AlertLogs[n]
+	}
+	logCols := []string{"alert_id", "timestamp", "event", "message", "sub_type", "sub_user_id", "sub_classifier", "meta"}
+	logRow := func(a AlertLog) []interface{} {
 		var subType interface{}
 		if a.UserID != "" {
 			subType = "user"
 		}
 		return []interface{}{a.AlertID, a.Timestamp, a.Event, a.Message, subType, asUUIDPtr(a.UserID), a.Class, a.Meta}
-	}, "alerts", "outgoing_messages", "users")
-
-	copyFrom("alert_feedback", []string{"alert_id", "noise_reason"}, len(data.AlertFeedback), func(n int) []interface{} {
-		f := data.AlertFeedback[n]
+	}
+	feedbackCols := []string{"alert_id", "noise_reason"}
+	feedbackRow := func(f alert.Feedback) []interface{} {
 		return []interface{}{f.ID, f.NoiseReason}
-	}, "alerts")
-
-	copyFrom("outgoing_messages", []string{"id", "created_at", "alert_id", "service_id", "escalation_policy_id", "contact_method_id", "user_id", "message_type", "last_status", "sent_at"}, len(data.AlertMessages), func(n int) []interface{} {
-		msg := data.AlertMessages[n]
+	}
+	msgCols := []string{"id", "created_at", "alert_id", "service_id", "escalation_policy_id", "contact_method_id", "user_id", "message_type", "last_status", "sent_at"}
+	msgRow := func(msg AlertMsg) []interface{} {
 		return []interface{}{asUUID(msg.ID), msg.CreatedAt, msg.AlertID, asUUID(msg.ServiceID), asUUID(msg.EPID), asUUID(msg.CMID), asUUID(msg.UserID), "alert_notification", msg.Status, msg.SentAt}
-	}, "alerts", "services", "users", "user_contact_methods")
+	}
+
+	copyFrom("alerts", alertCols, len(data.Alerts), func(n int) []interface{} { return alertRow(data.Alerts[n]) }, "services")
+	copyFrom("alert_logs", logCols, len(data.AlertLogs), func(n int) []interface{} { return logRow(data.AlertLogs[n]) }, "alerts", "outgoing_messages", "users")
+	copyFrom("alert_feedback", feedbackCols, len(data.AlertFeedback), func(n int) []interface{} { return feedbackRow(data.AlertFeedback[n]) }, "alerts")
+	copyFrom("outgoing_messages", msgCols, len(data.AlertMessages), func(n int) []interface{} { return msgRow(data.AlertMessages[n]) }, "alerts", "services", "users", "user_contact_methods")
+
+	// Closed alerts are streamed in batches, since there may be millions of them.
+	dt.Start("closed_alerts")
+	go func() {
+		defer dt.Done("closed_alerts")
+		dt.WaitFor("services", "users", "user_contact_methods")
+
+		s := time.Now()
+		var total int
+		copyRows := func(table string, cols []string, n int, get func(int) []interface{}) error {
+			_, err := pool.CopyFrom(ctx, pgx.Identifier{table}, cols, pgx.CopyFromSlice(n, func(i int) ([]interface{}, error) { return get(i), nil }))
+			return errors.Wrap(err, table)
+		}
+		err := dataCfg.GenerateClosedAlerts(&data, AlertBatchSize, func(b *datagen) error {
+			err := copyRows("alerts", alertCols, len(b.Alerts), func(n int) []interface{} { return alertRow(b.Alerts[n]) })
+			if err != nil {
+				return err
+			}
+			err = copyRows("outgoing_messages", msgCols, len(b.AlertMessages), func(n int) []interface{} { return msgRow(b.AlertMessages[n]) })
+			if err != nil {
+				return err
+			}
+			err = copyRows("alert_logs", logCols, len(b.AlertLogs), func(n int) []interface{} { return logRow(b.AlertLogs[n]) })
+			if err != nil {
+				return err
+			}
+			err = copyRows("alert_feedback", feedbackCols, len(b.AlertFeedback), func(n int) []interface{} { return feedbackRow(b.AlertFeedback[n]) })
+			if err != nil {
+				return err
+			}
+
+			total += len(b.Alerts)
+			log.Printf("inserted %d/%d closed alerts (%s)", total, dataCfg.AlertClosedCount, time.Since(s).String())
+			return nil
+		})
+		must(errors.Wrap(err, "closed alerts"))
+	}()
 
 	dt.Wait()
 	_, err = pool.Exec(ctx, "alter table alerts enable trigger all")
@@ -0,0 +1,368 @@
+// Package doctor runs diagnostic checks against a GoAlert installation and reports
+// actionable findings.
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/permission"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+// Check statuses.
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// checkTimeout is the maximum time a single network check may take.
+const checkTimeout = 10 * time.Second
+
+// Postgres versions, as reported by server_version_num.
+const (
+	minPostgresVersion         = 110000
+	recommendedPostgresVersion = 130000
+)
+
+// requiredExtensions are the Postgres extensions created by migrations.
+var requiredExtensions = []string{"pgcrypto", "pg_trgm"}
+
+// Finding is the result of a single check.
+type Finding struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+
+	// Fix describes how to resolve a warning or failure.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Options configures the checks to run.
+type Options struct {
+	DB *sql.DB
+
+	// Keys are the data encryption keys used to read the config.
+	Keys keyring.Keys
+
+	// ExplicitURL is the public URL set with --public-url, if any.
+	ExplicitURL string
+
+	TwilioBaseURL string
+	SlackBaseURL  string
+
+	// SkipNetwork disables checks that connect to the public URL or notification providers.
+	SkipNetwork bool
+}
+
+// Run will run all checks and return the findings in order.
+//
+// Checks that depend on an earlier, failed, check are reported as skipped.
+func Run(ctx context.Context, opts Options) []Finding {
+	ctx = permission.SystemContext(ctx, "Doctor")
+	var res []Finding
+	add := func(f Finding) { res = append(res, f) }
+	skip := func(checks ...string) {
+		for _, c := range checks {
+			add(Finding{Check: c, Status: StatusSkip, Message: "skipped (requires an earlier check to pass)"})
+		}
+	}
+
+	err := opts.DB.PingContext(ctx)
+	if err != nil {
+		add(Finding{
+			Check:   "database",
+			Status:  StatusFail,
+			Message: "unable to connect: " + err.Error(),
+			Fix:     "Verify --db-url and that Postgres is running and reachable from this host.",
+		})
+		skip("postgres-version", "extensions", "migrations", "config", "public-url", "twilio", "slack", "smtp")
+		return res
+	}
+	add(Finding{Check: "database", Status: StatusOK, Message: "connected"})
+
+	add(checkVersion(ctx, opts.DB))
+	add(checkExtensions(ctx, opts.DB))
+
+	mig, schema := checkMigrations(ctx, opts.DB)
+	add(mig)
+	if mig.Status == StatusFail || schema.Latest == "" {
+		skip("config", "public-url", "twilio", "slack", "smtp")
+		return res
+	}
+
+	store, err := config.NewStore(ctx, config.StoreConfig{
+		DB:          opts.DB,
+		Keys:        opts.Keys,
+		ExplicitURL: opts.ExplicitURL,
+	})
+	if err != nil {
+		add(Finding{
+			Check:   "config",
+			Status:  StatusFail,
+			Message: "unable to load config: " + err.Error(),
+			Fix:     "Verify --data-encryption-key (and --data-encryption-key-old, if rotating) match the key used to save the config.",
+		})
+		skip("public-url", "twilio", "slack", "smtp")
+		return res
+	}
+	defer store.Shutdown(context.WithoutCancel(ctx))
+	cfg := store.Config()
+	ctx = cfg.Context(ctx)
+
+	if err := cfg.Validate(); err != nil {
+		add(Finding{
+			Check:   "config",
+			Status:  StatusFail,
+			Message: "invalid config: " + err.Error(),
+			Fix:     "Correct the listed fields in Admin > Config or with `goalert set-config`.",
+		})
+	} else {
+		add(Finding{Check: "config", Status: StatusOK, Message: "valid"})
+	}
+
+	add(checkPublicURL(ctx, cfg, opts))
+
+	if opts.SkipNetwork {
+		add(Finding{Check: "twilio", Status: StatusSkip, Message: "network checks disabled"})
+		add(Finding{Check: "slack", Status: StatusSkip, Message: "network checks disabled"})
+		add(Finding{Check: "smtp", Status: StatusSkip, Message: "network checks disabled"})
+		return res
+	}
+
+	add(checkProvider(ctx, "twilio", cfg.Twilio.Enable, "Verify Twilio.AccountSID and Twilio.AuthToken, and that api.twilio.com is reachable from this host.", func(ctx context.Context) error {
+		return (&twilio.Config{BaseURL: opts.TwilioBaseURL}).CheckAccount(ctx)
+	}))
+	add(checkProvider(ctx, "slack", cfg.Slack.Enable, "Verify Slack.AccessToken is a valid bot token, and that slack.com is reachable from this host.", func(ctx context.Context) error {
+		s, err := slack.NewChannelSender(ctx, slack.Config{BaseURL: opts.SlackBaseURL})
+		if err != nil {
+			return err
+		}
+		return s.CheckAuth(ctx)
+	}))
+	add(checkProvider(ctx, "smtp", cfg.SMTP.Enable, "Verify SMTP.Address (and SMTP.DisableTLS for servers on port 25), and that the server is reachable from this host.", func(ctx context.Context) error {
+		return email.NewSender(ctx).CheckServer(ctx)
+	}))
+
+	return res
+}
+
+func checkVersion(ctx context.Context, db *sql.DB) Finding {
+	var num int
+	var version string
+	err := db.QueryRowContext(ctx, `select current_setting('server_version_num')::int, current_setting('server_version')`).Scan(&num, &version)
+	if err != nil {
+		return Finding{Check: "postgres-version", Status: StatusFail, Message: "unable to read server version: " + err.Error()}
+	}
+
+	return versionFinding(num, version)
+}
+
+func versionFinding(num int, version string) Finding {
+	f := Finding{Check: "postgres-version", Message: "Postgres " + version}
+	switch {
+	case num < minPostgresVersion:
+		f.Status = StatusFail
+		f.Message += " is not supported"
+		f.Fix = "Upgrade to Postgres 13 or newer."
+	case num < recommendedPostgresVersion:
+		f.Status = StatusWarn
+		f.Message += " is older than the tested version (13)"
+		f.Fix = "Upgrade to Postgres 13 or newer; future releases may require it."
+	default:
+		f.Status = StatusOK
+	}
+	return f
+}
+
+func checkExtensions(ctx context.Context, db *sql.DB) Finding {
+	installed := make(map[string]bool)
+	available := make(map[string]bool)
+	rows, err := db.QueryContext(ctx, `select name, installed_version is not null from pg_available_extensions`)
+	if err != nil {
+		return Finding{Check: "extensions", Status: StatusFail, Message: "unable to list extensions: " + err.Error()}
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var ok bool
+		err = rows.Scan(&name, &ok)
+		if err != nil {
+			return Finding{Check: "extensions", Status: StatusFail, Message: "unable to list extensions: " + err.Error()}
+		}
+		available[name] = true
+		installed[name] = ok
+	}
+	if err := rows.Err(); err != nil {
+		return Finding{Check: "extensions", Status: StatusFail, Message: "unable to list extensions: " + err.Error()}
+	}
+
+	var missing, notInstalled []string
+	for _, name := range requiredExtensions {
+		switch {
+		case !available[name]:
+			missing = append(missing, name)
+		case !installed[name]:
+			notInstalled = append(notInstalled, name)
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		return Finding{
+			Check:   "extensions",
+			Status:  StatusFail,
+			Message: "not available on the server: " + strings.Join(missing, ", "),
+			Fix:     "Install the Postgres contrib package (e.g., postgresql-contrib) or enable the extensions with your database provider.",
+		}
+	case len(notInstalled) > 0:
+		return Finding{
+			Check:   "extensions",
+			Status:  StatusWarn,
+			Message: "not yet installed: " + strings.Join(notInstalled, ", "),
+			Fix:     "Migrations will create them, which requires a role allowed to CREATE EXTENSION; otherwise have an administrator create them first.",
+		}
+	}
+
+	return Finding{Check: "extensions", Status: StatusOK, Message: strings.Join(requiredExtensions, ", ") + " installed"}
+}
+
+func checkMigrations(ctx context.Context, db *sql.DB) (Finding, *migrate.SchemaStatus) {
+	s, err := migrate.Status(ctx, db)
+	if err != nil {
+		return Finding{
+			Check:   "migrations",
+			Status:  StatusFail,
+			Message: "unable to read migration status: " + err.Error(),
+			Fix:     "Verify the database user has access to the gorp_migrations table.",
+		}, &migrate.SchemaStatus{}
+	}
+
+	switch {
+	case len(s.Unknown) > 0:
+		return Finding{
+			Check:   "migrations",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%d applied migration(s) are unknown to this version (latest: %s)", len(s.Unknown), s.Latest),
+			Fix:     "The database was migrated by a newer version of GoAlert; upgrade this binary to match.",
+		}, s
+	case s.Latest == "":
+		return Finding{
+			Check:   "migrations",
+			Status:  StatusWarn,
+			Message: "database has not been initialized",
+			Fix:     "Run `goalert migrate`, or start GoAlert to initialize it.",
+		}, s
+	case len(s.Pending) > 0:
+		return Finding{
+			Check:   "migrations",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("%d pending migration(s) (applied: %s, expected: %s)", len(s.Pending), s.Latest, s.Expected),
+			Fix:     "Run `goalert migrate`, or start GoAlert to apply them automatically.",
+		}, s
+	}
+
+	return Finding{Check: "migrations", Status: StatusOK, Message: "up to date (" + s.Latest + ")"}, s
+}
+
+func checkPublicURL(ctx context.Context, cfg config.Config, opts Options) Finding {
+	if cfg.General.PublicURL == "" && opts.ExplicitURL == "" {
+		return Finding{
+			Check:   "public-url",
+			Status:  StatusWarn,
+			Message: "General.PublicURL is not set; links and provider callbacks will use the listen address",
+			Fix:     "Set General.PublicURL (or --public-url) to the URL users and providers use to reach GoAlert.",
+		}
+	}
+	if opts.SkipNetwork {
+		return Finding{Check: "public-url", Status: StatusSkip, Message: "network checks disabled"}
+	}
+
+	u := cfg.CallbackURL("/health")
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return Finding{Check: "public-url", Status: StatusFail, Message: "invalid callback URL: " + err.Error()}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Finding{
+			Check:   "public-url",
+			Status:  StatusFail,
+			Message: u + " is unreachable: " + err.Error(),
+			Fix:     "Ensure GoAlert is running and the public URL resolves and is reachable; Twilio and Slack callbacks require it.",
+		}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return Finding{
+			Check:   "public-url",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s returned %s", u, resp.Status),
+			Fix:     "Verify the public URL (and --http-prefix, if used) routes to GoAlert.",
+		}
+	}
+
+	return Finding{Check: "public-url", Status: StatusOK, Message: u + " is reachable"}
+}
+
+func checkProvider(ctx context.Context, name string, enabled bool, fix string, check func(context.Context) error) Finding {
+	if !enabled {
+		return Finding{Check: name, Status: StatusSkip, Message: "disabled"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	err := check(ctx)
+	if err != nil {
+		return Finding{Check: name, Status: StatusFail, Message: err.Error(), Fix: fix}
+	}
+
+	return Finding{Check: name, Status: StatusOK, Message: "credentials valid"}
+}
+
+// Failed returns the number of findings with StatusFail.
+func Failed(findings []Finding) int {
+	var n int
+	for _, f := range findings {
+		if f.Status == StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteText will write findings in a human-readable format.
+func WriteText(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		_, err := fmt.Fprintf(w, "[%-4s] %-16s %s\n", strings.ToUpper(string(f.Status)), f.Check, f.Message)
+		if err != nil {
+			return err
+		}
+		if f.Fix == "" {
+			continue
+		}
+		_, err = fmt.Fprintf(w, "       %-16s -> %s\n", "", f.Fix)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package doctor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionFinding(t *testing.T) {
+	assert.Equal(t, StatusFail, versionFinding(100012, "10.12").Status)
+	assert.Equal(t, StatusWarn, versionFinding(120005, "12.5").Status)
+	assert.Equal(t, StatusOK, versionFinding(130000, "13.0").Status)
+	assert.Equal(t, StatusOK, versionFinding(160001, "16.1").Status)
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteText(&buf, []Finding{
+		{Check: "database", Status: StatusOK, Message: "connected"},
+		{Check: "migrations", Status: StatusWarn, Message: "1 pending", Fix: "Run it."},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"[OK  ] database         connected\n"+
+		"[WARN] migrations       1 pending\n"+
+		"                        -> Run it.\n",
+		buf.String())
+}
+
+func TestFailed(t *testing.T) {
+	assert.Equal(t, 1, Failed([]Finding{{Status: StatusOK}, {Status: StatusFail}, {Status: StatusSkip}}))
+}
@@ -0,0 +1,194 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Action is the type of difference between the live state and an export.
+type Action string
+
+// Actions are from the perspective of the live instance, compared to the export.
+const (
+	// ActionAdd means an object exists but is not in the export.
+	ActionAdd Action = "add"
+
+	// ActionChange means a field of an object differs from the export.
+	ActionChange Action = "change"
+
+	// ActionDelete means an object in the export does not exist.
+	ActionDelete Action = "delete"
+)
+
+// Change is a single difference between the live state and an export.
+type Change struct {
+	Action Action `json:"action"`
+
+	// Kind is the type of object (e.g., service, escalationPolicy, rotation, schedule).
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Field, Expected, and Actual are only set for ActionChange. Expected is the
+	// value from the export, and Actual is the live value.
+	Field    string `json:"field,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// String returns a single-line description of the change.
+func (c Change) String() string {
+	switch c.Action {
+	case ActionAdd:
+		return fmt.Sprintf("+ %s %q", c.Kind, c.Name)
+	case ActionDelete:
+		return fmt.Sprintf("- %s %q", c.Kind, c.Name)
+	}
+	return fmt.Sprintf("~ %s %q %s: %q -> %q", c.Kind, c.Name, c.Field, c.Expected, c.Actual)
+}
+
+type object struct {
+	name   string
+	fields map[string]string
+}
+
+// Diff will return all differences between the expected state (e.g., from an export
+// kept in source control) and the actual (live) state. Names are compared case-insensitively.
+func Diff(expected, actual *State) []Change {
+	var changes []Change
+	changes = append(changes, diffKind("service", expected.serviceObjects(), actual.serviceObjects())...)
+	changes = append(changes, diffKind("escalationPolicy", expected.policyObjects(), actual.policyObjects())...)
+	changes = append(changes, diffKind("rotation", expected.rotationObjects(), actual.rotationObjects())...)
+	changes = append(changes, diffKind("schedule", expected.scheduleObjects(), actual.scheduleObjects())...)
+	return changes
+}
+
+func diffKind(kind string, expected, actual []object) []Change {
+	byName := make(map[string]object, len(actual))
+	for _, o := range actual {
+		byName[strings.ToLower(o.name)] = o
+	}
+
+	var changes []Change
+	for _, exp := range expected {
+		key := strings.ToLower(exp.name)
+		act, ok := byName[key]
+		if !ok {
+			changes = append(changes, Change{Action: ActionDelete, Kind: kind, Name: exp.name})
+			continue
+		}
+		delete(byName, key)
+
+		fields := make(map[string]struct{}, len(exp.fields))
+		for f := range exp.fields {
+			fields[f] = struct{}{}
+		}
+		for f := range act.fields {
+			fields[f] = struct{}{}
+		}
+		names := make([]string, 0, len(fields))
+		for f := range fields {
+			names = append(names, f)
+		}
+		sort.Strings(names)
+
+		if exp.name != act.name {
+			changes = append(changes, Change{Action: ActionChange, Kind: kind, Name: exp.name, Field: "name", Expected: exp.name, Actual: act.name})
+		}
+		for _, f := range names {
+			if exp.fields[f] == act.fields[f] {
+				continue
+			}
+			changes = append(changes, Change{
+				Action:   ActionChange,
+				Kind:     kind,
+				Name:     exp.name,
+				Field:    f,
+				Expected: exp.fields[f],
+				Actual:   act.fields[f],
+			})
+		}
+	}
+
+	var added []Change
+	for _, o := range byName {
+		added = append(added, Change{Action: ActionAdd, Kind: kind, Name: o.name})
+	}
+	sort.Slice(added, func(i, j int) bool { return lessName(added[i].Name, added[j].Name) })
+
+	return append(changes, added...)
+}
+
+func (s *State) serviceObjects() []object {
+	objs := make([]object, 0, len(s.Services))
+	for _, svc := range s.Services {
+		f := map[string]string{
+			"description":      svc.Description,
+			"escalationPolicy": svc.EscalationPolicy,
+		}
+		for k, v := range svc.Labels {
+			f["labels."+k] = v
+		}
+		for _, key := range svc.IntegrationKeys {
+			f["integrationKeys."+key.Name] = key.Type
+		}
+		for _, hb := range svc.HeartbeatMonitors {
+			f["heartbeatMonitors."+hb.Name] = strconv.Itoa(hb.TimeoutMinutes) + "m"
+		}
+		objs = append(objs, object{name: svc.Name, fields: f})
+	}
+	return objs
+}
+
+func (s *State) policyObjects() []object {
+	objs := make([]object, 0, len(s.EscalationPolicies))
+	for _, ep := range s.EscalationPolicies {
+		f := map[string]string{
+			"description": ep.Description,
+			"repeat":      strconv.Itoa(ep.Repeat),
+			"steps":       strconv.Itoa(len(ep.Steps)),
+		}
+		for i, step := range ep.Steps {
+			prefix := fmt.Sprintf("steps[%d].", i)
+			f[prefix+"delayMinutes"] = strconv.Itoa(step.DelayMinutes)
+			f[prefix+"targets"] = strings.Join(step.Targets, ", ")
+		}
+		objs = append(objs, object{name: ep.Name, fields: f})
+	}
+	return objs
+}
+
+func (s *State) rotationObjects() []object {
+	objs := make([]object, 0, len(s.Rotations))
+	for _, rot := range s.Rotations {
+		objs = append(objs, object{name: rot.Name, fields: map[string]string{
+			"description":  rot.Description,
+			"type":         rot.Type,
+			"shiftLength":  strconv.Itoa(rot.ShiftLength),
+			"start":        rot.Start.UTC().Format(time.RFC3339),
+			"timeZone":     rot.TimeZone,
+			"participants": strings.Join(rot.Participants, ", "),
+		}})
+	}
+	return objs
+}
+
+func (s *State) scheduleObjects() []object {
+	objs := make([]object, 0, len(s.Schedules))
+	for _, sched := range s.Schedules {
+		rules := make([]string, 0, len(sched.Rules))
+		for _, r := range sched.Rules {
+			rules = append(rules, r.String())
+		}
+		sort.Strings(rules)
+
+		objs = append(objs, object{name: sched.Name, fields: map[string]string{
+			"description": sched.Description,
+			"timeZone":    sched.TimeZone,
+			"rules":       strings.Join(rules, "; "),
+		}})
+	}
+	return objs
+}
@@ -0,0 +1,143 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportYAML = `
+services:
+  - name: Web
+    escalationPolicy: Primary
+    labels:
+      team: web
+    integrationKeys:
+      - name: Grafana
+        type: generic
+escalationPolicies:
+  - name: Primary
+    repeat: 3
+    steps:
+      - delayMinutes: 5
+        targets: [rotation:Web Oncall, user:joe@example.com]
+rotations:
+  - name: Web Oncall
+    type: weekly
+    shiftLength: 1
+    start: 2023-01-02T15:00:00Z
+    timeZone: America/Chicago
+    participants: [joe@example.com, ann@example.com]
+schedules:
+  - name: Business Hours
+    timeZone: America/Chicago
+    rules:
+      - target: rotation:Web Oncall
+        start: "09:00"
+        end: "17:00"
+        weekdays: [Friday, monday, tuesday, wednesday, thursday]
+      - target: user:ann@example.com
+        start: "00:00"
+        end: "00:00"
+        weekdays: [sunday, monday, tuesday, wednesday, thursday, friday, saturday]
+`
+
+func liveState() *State {
+	return &State{
+		Services: []Service{{
+			Name:             "Web",
+			EscalationPolicy: "Primary",
+			Labels:           map[string]string{"team": "web"},
+			IntegrationKeys:  []IntegrationKey{{Name: "Grafana", Type: "generic"}},
+		}},
+		EscalationPolicies: []EscalationPolicy{{
+			Name:   "Primary",
+			Repeat: 3,
+			Steps: []Step{{
+				DelayMinutes: 5,
+				Targets:      []string{"user:joe@example.com", "rotation:Web Oncall"},
+			}},
+		}},
+		Rotations: []Rotation{{
+			Name:         "Web Oncall",
+			Type:         "weekly",
+			ShiftLength:  1,
+			Start:        time.Date(2023, 1, 2, 15, 0, 0, 0, time.UTC),
+			TimeZone:     "America/Chicago",
+			Participants: []string{"joe@example.com", "ann@example.com"},
+		}},
+		Schedules: []Schedule{{
+			Name:     "Business Hours",
+			TimeZone: "America/Chicago",
+			Rules: []ScheduleRule{
+				{Target: "user:ann@example.com", Start: "00:00", End: "00:00"},
+				{Target: "rotation:Web Oncall", Start: "09:00", End: "17:00", Weekdays: []string{"monday", "tuesday", "wednesday", "thursday", "friday"}},
+			},
+		}},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	expected, err := Parse([]byte(exportYAML))
+	require.NoError(t, err)
+
+	t.Run("no drift", func(t *testing.T) {
+		live := liveState()
+		live.normalize()
+		assert.Empty(t, Diff(expected, live))
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		live := liveState()
+		live.Services[0].Labels["team"] = "platform"
+		live.Services[0].IntegrationKeys = nil
+		live.EscalationPolicies[0].Steps[0].DelayMinutes = 10
+		live.Rotations[0].Participants = []string{"ann@example.com", "joe@example.com"}
+		live.Schedules = nil
+		live.Services = append(live.Services, Service{Name: "API", EscalationPolicy: "Primary"})
+		live.normalize()
+
+		assert.Equal(t, []Change{
+			{Action: ActionChange, Kind: "service", Name: "Web", Field: "integrationKeys.Grafana", Expected: "generic"},
+			{Action: ActionChange, Kind: "service", Name: "Web", Field: "labels.team", Expected: "web", Actual: "platform"},
+			{Action: ActionAdd, Kind: "service", Name: "API"},
+			{Action: ActionChange, Kind: "escalationPolicy", Name: "Primary", Field: "steps[0].delayMinutes", Expected: "5", Actual: "10"},
+			{Action: ActionChange, Kind: "rotation", Name: "Web Oncall", Field: "participants", Expected: "joe@example.com, ann@example.com", Actual: "ann@example.com, joe@example.com"},
+			{Action: ActionDelete, Kind: "schedule", Name: "Business Hours"},
+		}, Diff(expected, live))
+	})
+
+	t.Run("case-insensitive names", func(t *testing.T) {
+		live := liveState()
+		live.Services[0].Name = "WEB"
+		live.normalize()
+
+		assert.Equal(t, []Change{
+			{Action: ActionChange, Kind: "service", Name: "Web", Field: "name", Expected: "Web", Actual: "WEB"},
+		}, Diff(expected, live))
+	})
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("services:\n  - name: Web\n    escalationPolicy: Primary\n    lables: {}\n"))
+	assert.Error(t, err, "unknown fields should be rejected")
+
+	s, err := Parse([]byte(exportYAML))
+	require.NoError(t, err)
+
+	data, err := s.Marshal()
+	require.NoError(t, err)
+	s2, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, s, s2, "round trip")
+	assert.Empty(t, Diff(s, s2))
+}
+
+func TestNormalizeWeekdays(t *testing.T) {
+	assert.Nil(t, normalizeWeekdays(nil))
+	assert.Nil(t, normalizeWeekdays([]string{"saturday", "friday", "thursday", "wednesday", "tuesday", "monday", "sunday"}))
+	assert.Equal(t, []string{"monday", "friday"}, normalizeWeekdays([]string{"Friday", "monday", "MONDAY"}))
+	assert.Equal(t, []string{"none"}, normalizeWeekdays([]string{"none"}))
+}
@@ -0,0 +1,346 @@
+package drift
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/util/sqlutil"
+)
+
+var weekdayCols = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+type loader struct {
+	ctx context.Context
+	tx  *sql.Tx
+
+	users     map[string]string
+	rotations map[string]string
+	schedules map[string]string
+	channels  map[string]string
+}
+
+// Load will read the current state from db, in a single read-only transaction.
+func Load(ctx context.Context, db *sql.DB) (*State, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "drift: load", tx)
+
+	l := &loader{ctx: ctx, tx: tx}
+	l.users, err = l.names(`select id::text, coalesce(nullif(email, ''), id::text) from users`)
+	if err != nil {
+		return nil, fmt.Errorf("load users: %w", err)
+	}
+	l.rotations, err = l.names(`select id::text, name from rotations`)
+	if err != nil {
+		return nil, fmt.Errorf("load rotations: %w", err)
+	}
+	l.schedules, err = l.names(`select id::text, name from schedules`)
+	if err != nil {
+		return nil, fmt.Errorf("load schedules: %w", err)
+	}
+	l.channels, err = l.names(`select id::text, name from notification_channels`)
+	if err != nil {
+		return nil, fmt.Errorf("load notification channels: %w", err)
+	}
+
+	var s State
+	s.Services, err = l.services()
+	if err != nil {
+		return nil, fmt.Errorf("load services: %w", err)
+	}
+	s.EscalationPolicies, err = l.policies()
+	if err != nil {
+		return nil, fmt.Errorf("load escalation policies: %w", err)
+	}
+	s.Rotations, err = l.loadRotations()
+	if err != nil {
+		return nil, fmt.Errorf("load rotations: %w", err)
+	}
+	s.Schedules, err = l.loadSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("load schedules: %w", err)
+	}
+	s.normalize()
+
+	return &s, nil
+}
+
+// each will call fn for every row returned by query.
+func (l *loader) each(query string, fn func(*sql.Rows) error) error {
+	rows, err := l.tx.QueryContext(l.ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = fn(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// names returns a map of ID to name from a query returning (id, name) rows.
+func (l *loader) names(query string) (map[string]string, error) {
+	m := make(map[string]string)
+	err := l.each(query, func(rows *sql.Rows) error {
+		var id, name string
+		err := rows.Scan(&id, &name)
+		m[id] = name
+		return err
+	})
+
+	return m, err
+}
+
+func (l *loader) services() ([]Service, error) {
+	var result []Service
+	idx := make(map[string]int)
+	err := l.each(`
+		select svc.id::text, svc.name, svc.description, ep.name
+		from services svc
+		join escalation_policies ep on ep.id = svc.escalation_policy_id
+	`, func(rows *sql.Rows) error {
+		var id string
+		var svc Service
+		err := rows.Scan(&id, &svc.Name, &svc.Description, &svc.EscalationPolicy)
+		idx[id] = len(result)
+		result = append(result, svc)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.each(`select tgt_service_id::text, key, value from labels`, func(rows *sql.Rows) error {
+		var id, key, value string
+		err := rows.Scan(&id, &key, &value)
+		if err != nil {
+			return err
+		}
+		svc := &result[idx[id]]
+		if svc.Labels == nil {
+			svc.Labels = make(map[string]string)
+		}
+		svc.Labels[key] = value
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("labels: %w", err)
+	}
+
+	err = l.each(`select service_id::text, name, type::text from integration_keys`, func(rows *sql.Rows) error {
+		var id string
+		var key IntegrationKey
+		err := rows.Scan(&id, &key.Name, &key.Type)
+		if err != nil {
+			return err
+		}
+		svc := &result[idx[id]]
+		svc.IntegrationKeys = append(svc.IntegrationKeys, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("integration keys: %w", err)
+	}
+
+	err = l.each(`
+		select service_id::text, name, (extract(epoch from heartbeat_interval) / 60)::int
+		from heartbeat_monitors
+	`, func(rows *sql.Rows) error {
+		var id string
+		var hb HeartbeatMonitor
+		err := rows.Scan(&id, &hb.Name, &hb.TimeoutMinutes)
+		if err != nil {
+			return err
+		}
+		svc := &result[idx[id]]
+		svc.HeartbeatMonitors = append(svc.HeartbeatMonitors, hb)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("heartbeat monitors: %w", err)
+	}
+
+	return result, nil
+}
+
+func (l *loader) policies() ([]EscalationPolicy, error) {
+	var result []EscalationPolicy
+	idx := make(map[string]int)
+	err := l.each(`select id::text, name, description, repeat from escalation_policies`, func(rows *sql.Rows) error {
+		var id string
+		var ep EscalationPolicy
+		err := rows.Scan(&id, &ep.Name, &ep.Description, &ep.Repeat)
+		idx[id] = len(result)
+		result = append(result, ep)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type stepIndex struct{ ep, step int }
+	steps := make(map[string]stepIndex)
+	err = l.each(`
+		select id::text, escalation_policy_id::text, delay
+		from escalation_policy_steps
+		order by escalation_policy_id, step_number
+	`, func(rows *sql.Rows) error {
+		var id, epID string
+		var step Step
+		err := rows.Scan(&id, &epID, &step.DelayMinutes)
+		if err != nil {
+			return err
+		}
+		ep := &result[idx[epID]]
+		steps[id] = stepIndex{ep: idx[epID], step: len(ep.Steps)}
+		ep.Steps = append(ep.Steps, step)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("steps: %w", err)
+	}
+
+	err = l.each(`
+		select escalation_policy_step_id::text, user_id::text, rotation_id::text, schedule_id::text, channel_id::text
+		from escalation_policy_actions
+	`, func(rows *sql.Rows) error {
+		var stepID string
+		var userID, rotID, schedID, chanID sql.NullString
+		err := rows.Scan(&stepID, &userID, &rotID, &schedID, &chanID)
+		if err != nil {
+			return err
+		}
+
+		var tgt string
+		switch {
+		case userID.Valid:
+			tgt = "user:" + l.users[userID.String]
+		case rotID.Valid:
+			tgt = "rotation:" + l.rotations[rotID.String]
+		case schedID.Valid:
+			tgt = "schedule:" + l.schedules[schedID.String]
+		case chanID.Valid:
+			tgt = "channel:" + l.channels[chanID.String]
+		default:
+			return nil
+		}
+
+		si := steps[stepID]
+		step := &result[si.ep].Steps[si.step]
+		step.Targets = append(step.Targets, tgt)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("actions: %w", err)
+	}
+
+	return result, nil
+}
+
+func (l *loader) loadRotations() ([]Rotation, error) {
+	var result []Rotation
+	idx := make(map[string]int)
+	err := l.each(`
+		select id::text, name, description, type::text, shift_length, start_time, time_zone
+		from rotations
+	`, func(rows *sql.Rows) error {
+		var id string
+		var rot Rotation
+		err := rows.Scan(&id, &rot.Name, &rot.Description, &rot.Type, &rot.ShiftLength, &rot.Start, &rot.TimeZone)
+		rot.Start = rot.Start.UTC()
+		idx[id] = len(result)
+		result = append(result, rot)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.each(`
+		select rotation_id::text, user_id::text
+		from rotation_participants
+		order by rotation_id, position
+	`, func(rows *sql.Rows) error {
+		var rotID, userID string
+		err := rows.Scan(&rotID, &userID)
+		if err != nil {
+			return err
+		}
+		rot := &result[idx[rotID]]
+		rot.Participants = append(rot.Participants, l.users[userID])
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("participants: %w", err)
+	}
+
+	return result, nil
+}
+
+func (l *loader) loadSchedules() ([]Schedule, error) {
+	var result []Schedule
+	idx := make(map[string]int)
+	err := l.each(`select id::text, name, description, time_zone from schedules`, func(rows *sql.Rows) error {
+		var id string
+		var sched Schedule
+		err := rows.Scan(&id, &sched.Name, &sched.Description, &sched.TimeZone)
+		idx[id] = len(result)
+		result = append(result, sched)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.each(`
+		select
+			schedule_id::text, tgt_user_id::text, tgt_rotation_id::text,
+			to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'),
+			sunday, monday, tuesday, wednesday, thursday, friday, saturday
+		from schedule_rules
+	`, func(rows *sql.Rows) error {
+		var schedID string
+		var userID, rotID sql.NullString
+		var rule ScheduleRule
+		var days [7]bool
+		err := rows.Scan(&schedID, &userID, &rotID, &rule.Start, &rule.End,
+			&days[0], &days[1], &days[2], &days[3], &days[4], &days[5], &days[6])
+		if err != nil {
+			return err
+		}
+
+		if userID.Valid {
+			rule.Target = "user:" + l.users[userID.String]
+		} else {
+			rule.Target = "rotation:" + l.rotations[rotID.String]
+		}
+		if days != [7]bool{true, true, true, true, true, true, true} {
+			rule.Weekdays = []string{}
+			for i, on := range days {
+				if on {
+					rule.Weekdays = append(rule.Weekdays, weekdayCols[i])
+				}
+			}
+			if len(rule.Weekdays) == 0 {
+				rule.Weekdays = []string{"none"}
+			}
+		}
+
+		sched := &result[idx[schedID]]
+		sched.Rules = append(sched.Rules, rule)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rules: %w", err)
+	}
+
+	return result, nil
+}
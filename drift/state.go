@@ -0,0 +1,200 @@
+// Package drift exports the configuration of a GoAlert instance (services, escalation
+// policies, rotations, and schedules) as declarative YAML, and compares it against a
+// previous export to detect changes made outside of source control.
+package drift
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is a declarative representation of GoAlert configuration.
+//
+// Objects are identified by name, and reference each other by name. Users are referenced
+// by email address, or by ID if they have none.
+type State struct {
+	Services           []Service          `yaml:"services,omitempty"`
+	EscalationPolicies []EscalationPolicy `yaml:"escalationPolicies,omitempty"`
+	Rotations          []Rotation         `yaml:"rotations,omitempty"`
+	Schedules          []Schedule         `yaml:"schedules,omitempty"`
+}
+
+// Service is a service and its labels, integration keys, and heartbeat monitors.
+type Service struct {
+	Name              string             `yaml:"name"`
+	Description       string             `yaml:"description,omitempty"`
+	EscalationPolicy  string             `yaml:"escalationPolicy"`
+	Labels            map[string]string  `yaml:"labels,omitempty"`
+	IntegrationKeys   []IntegrationKey   `yaml:"integrationKeys,omitempty"`
+	HeartbeatMonitors []HeartbeatMonitor `yaml:"heartbeatMonitors,omitempty"`
+}
+
+// IntegrationKey is an integration key of a service. Key IDs (secrets) are not exported.
+type IntegrationKey struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// HeartbeatMonitor is a heartbeat monitor of a service.
+type HeartbeatMonitor struct {
+	Name           string `yaml:"name"`
+	TimeoutMinutes int    `yaml:"timeoutMinutes"`
+}
+
+// EscalationPolicy is an escalation policy and its steps.
+type EscalationPolicy struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Repeat      int    `yaml:"repeat"`
+	Steps       []Step `yaml:"steps,omitempty"`
+}
+
+// Step is a single escalation policy step.
+//
+// Targets are of the form "user:<email>", "rotation:<name>", "schedule:<name>", or "channel:<name>".
+type Step struct {
+	DelayMinutes int      `yaml:"delayMinutes"`
+	Targets      []string `yaml:"targets,omitempty"`
+}
+
+// Rotation is a rotation and its participants, in order.
+type Rotation struct {
+	Name         string    `yaml:"name"`
+	Description  string    `yaml:"description,omitempty"`
+	Type         string    `yaml:"type"`
+	ShiftLength  int       `yaml:"shiftLength"`
+	Start        time.Time `yaml:"start"`
+	TimeZone     string    `yaml:"timeZone"`
+	Participants []string  `yaml:"participants,omitempty"`
+}
+
+// Schedule is a schedule and its rules. Temporary schedules and overrides are not included.
+type Schedule struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description,omitempty"`
+	TimeZone    string         `yaml:"timeZone"`
+	Rules       []ScheduleRule `yaml:"rules,omitempty"`
+}
+
+// ScheduleRule is a single schedule rule.
+//
+// Target is of the form "user:<email>" or "rotation:<name>". Start and End are in "15:04" format,
+// and Weekdays is omitted if the rule is active every day (or is "none" if it is never active).
+type ScheduleRule struct {
+	Target   string   `yaml:"target"`
+	Start    string   `yaml:"start"`
+	End      string   `yaml:"end"`
+	Weekdays []string `yaml:"weekdays,omitempty"`
+}
+
+// Parse will parse a YAML export. Unknown fields are an error, to catch typos.
+func Parse(data []byte) (*State, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var s State
+	err := dec.Decode(&s)
+	if err != nil {
+		return nil, fmt.Errorf("parse state: %w", err)
+	}
+	s.normalize()
+
+	return &s, nil
+}
+
+// Marshal will return the state as YAML.
+func (s *State) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err := enc.Encode(s)
+	if err != nil {
+		return nil, err
+	}
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func lessName(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+
+// normalize sorts everything where order has no meaning, so exports are stable
+// and can be compared directly.
+func (s *State) normalize() {
+	sort.Slice(s.Services, func(i, j int) bool { return lessName(s.Services[i].Name, s.Services[j].Name) })
+	for _, svc := range s.Services {
+		sort.Slice(svc.IntegrationKeys, func(i, j int) bool {
+			return lessName(svc.IntegrationKeys[i].Name, svc.IntegrationKeys[j].Name)
+		})
+		sort.Slice(svc.HeartbeatMonitors, func(i, j int) bool {
+			return lessName(svc.HeartbeatMonitors[i].Name, svc.HeartbeatMonitors[j].Name)
+		})
+	}
+
+	sort.Slice(s.EscalationPolicies, func(i, j int) bool {
+		return lessName(s.EscalationPolicies[i].Name, s.EscalationPolicies[j].Name)
+	})
+	for _, ep := range s.EscalationPolicies {
+		for _, step := range ep.Steps {
+			sort.Strings(step.Targets)
+		}
+	}
+
+	sort.Slice(s.Rotations, func(i, j int) bool { return lessName(s.Rotations[i].Name, s.Rotations[j].Name) })
+
+	sort.Slice(s.Schedules, func(i, j int) bool { return lessName(s.Schedules[i].Name, s.Schedules[j].Name) })
+	for _, sched := range s.Schedules {
+		for i := range sched.Rules {
+			sched.Rules[i].Weekdays = normalizeWeekdays(sched.Rules[i].Weekdays)
+		}
+		sort.Slice(sched.Rules, func(i, j int) bool { return sched.Rules[i].String() < sched.Rules[j].String() })
+	}
+}
+
+// normalizeWeekdays will return days in lower case and week order, or nil if every day is present.
+// Unknown values (e.g., "none") are kept, at the end.
+func normalizeWeekdays(days []string) []string {
+	if len(days) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool, len(days))
+	for _, d := range days {
+		set[strings.ToLower(d)] = true
+	}
+
+	result := make([]string, 0, len(set))
+	for _, d := range weekdayCols {
+		if set[d] {
+			result = append(result, d)
+			delete(set, d)
+		}
+	}
+	if len(result) == len(weekdayCols) && len(set) == 0 {
+		return nil
+	}
+	var other []string
+	for d := range set {
+		other = append(other, d)
+	}
+	sort.Strings(other)
+
+	return append(result, other...)
+}
+
+// String returns a single-line description of the rule.
+func (r ScheduleRule) String() string {
+	days := "every day"
+	if len(r.Weekdays) > 0 {
+		days = strings.Join(r.Weekdays, ",")
+	}
+	return fmt.Sprintf("%s %s-%s %s", r.Target, r.Start, r.End, days)
+}
@@ -21,6 +21,8 @@ type backend struct {
 
 	validCM *sql.Stmt
 	validNC *sql.Stmt
+
+	queueDepth *sql.Stmt
 }
 
 func newBackend(db *sql.DB) (*backend, error) {
@@ -36,6 +38,7 @@ func newBackend(db *sql.DB) (*backend, error) {
 				alert_id,
 				service_id,
 				contact_method_id,
+				channel_id,
 				created_at
 			FROM outgoing_messages
 			WHERE id = $1
@@ -47,7 +50,28 @@ func newBackend(db *sql.DB) (*backend, error) {
 		`),
 
 		validCM: p.P(`select true from user_contact_methods where disabled = false and type = $1 and value = $2`),
-		validNC: p.P(`select true from notification_channels where type = $1 and value = $2`),
+		validNC: p.P(`select true from notification_channels where disabled = false and type = $1 and value = $2`),
+
+		// module names must match the values returned by Name() for each updater
+		queueDepth: p.P(`
+			select 'Engine.EscalationManager', count(*)
+			from escalation_policy_state
+			where force_escalation or next_escalation <= now()
+			union all
+			select 'Engine.Message', count(*)
+			from outgoing_messages
+			where last_status = 'pending'
+			union all
+			select 'Engine.StatusUpdateManager', count(*)
+			from alert_status_subscriptions sub
+			join alerts a on a.id = sub.alert_id
+			where sub.last_alert_status != a.status
+			union all
+			select kind, count(*)
+			from engine_jobs
+			where state in ('pending', 'running') and run_at <= now()
+			group by kind
+		`),
 	}, p.Err
 }
 
@@ -60,13 +84,36 @@ func (b *backend) FindOne(ctx context.Context, id string) (*callback, error) {
 	var c callback
 	var alertID sql.NullInt64
 	var serviceID sql.NullString
-	var cmID sql.NullString
-	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &c.CreatedAt)
+	var cmID, chanID sql.NullString
+	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &chanID, &c.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 	c.AlertID = int(alertID.Int64)
 	c.ServiceID = serviceID.String
 	c.ContactMethodID = cmID.String
+	c.ChannelID = chanID.String
 	return &c, nil
 }
+
+// QueueDepth returns the number of items waiting to be processed, by module name.
+func (b *backend) QueueDepth(ctx context.Context) (map[string]int, error) {
+	rows, err := b.queueDepth.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depth := make(map[string]int)
+	for rows.Next() {
+		var name string
+		var n int
+		err = rows.Scan(&name, &n)
+		if err != nil {
+			return nil, err
+		}
+		depth[name] = n
+	}
+
+	return depth, rows.Err()
+}
@@ -13,6 +13,7 @@ type callback struct {
 	AlertID         int
 	ServiceID       string
 	ContactMethodID string
+	ChannelID       string
 	CreatedAt       time.Time
 }
 
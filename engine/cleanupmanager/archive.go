@@ -0,0 +1,90 @@
+package cleanupmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/alert/archive"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// archiveClosed will write closed alerts older than the given number of days to the archive, and then
+// delete them (their logs are removed later by the alert log cleanup).
+//
+// Archives are written durably, and recorded in the archive index, before the transaction commits; if it
+// fails, the same alerts are archived again on the next run, replacing the earlier files.
+func (db *DB) archiveClosed(ctx context.Context, tx *sql.Tx, now time.Time, days int) error {
+	var dur pgtype.Interval
+	dur.Days = int32(days)
+	dur.Status = pgtype.Present
+
+	rows, err := tx.StmtContext(ctx, db.archiveAlerts).QueryContext(ctx, &dur)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var alerts []archive.Alert
+	index := make(map[int]int)
+	for rows.Next() {
+		var a archive.Alert
+		var svcID sql.NullString
+		err = rows.Scan(&a.ID, &svcID, &a.Summary, &a.Details, &a.Source, &a.Severity, &a.CreatedAt)
+		if err != nil {
+			return err
+		}
+		a.ServiceID = svcID.String
+		a.ArchivedAt = now
+		index[a.ID] = len(alerts)
+		alerts = append(alerts, a)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	ids := make(sqlutil.IntArray, len(alerts))
+	for i, a := range alerts {
+		ids[i] = a.ID
+	}
+
+	rows, err = tx.StmtContext(ctx, db.archiveAlertLogs).QueryContext(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("query logs: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l archive.Log
+		var alertID int
+		var ts sql.NullTime
+		err = rows.Scan(&l.ID, &alertID, &ts, &l.Event, &l.Message)
+		if err != nil {
+			return fmt.Errorf("scan log: %w", err)
+		}
+		l.Timestamp = ts.Time
+		a := &alerts[index[alertID]]
+		a.Logs = append(a.Logs, l)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("query logs: %w", err)
+	}
+
+	for _, a := range alerts {
+		err = db.archiveStore.PutTx(ctx, tx, a)
+		if err != nil {
+			return fmt.Errorf("write alert %d: %w", a.ID, err)
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, db.deleteArchived).ExecContext(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("delete archived alerts: %w", err)
+	}
+
+	return nil
+}
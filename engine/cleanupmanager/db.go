@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/alert/archive"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/util"
 )
@@ -24,16 +25,28 @@ type DB struct {
 	schedData    *sql.Stmt
 	setSchedData *sql.Stmt
 
-	cleanupSessions *sql.Stmt
+	cleanupSessions   *sql.Stmt
+	cleanupSchedCache *sql.Stmt
 
 	cleanupAlertLogs *sql.Stmt
 
 	cleanupOverrides   *sql.Stmt
 	cleanupSchedOnCall *sql.Stmt
+	cleanupSchedShadow *sql.Stmt
 	cleanupEPOnCall    *sql.Stmt
 	unackAlerts        *sql.Stmt
 	alertStore         *alert.Store
 
+	cleanupMessages     *sql.Stmt
+	cleanupSMSCallbacks *sql.Stmt
+	cleanupSMSErrors    *sql.Stmt
+	cleanupVoiceErrors  *sql.Stmt
+
+	archiveAlerts    *sql.Stmt
+	archiveAlertLogs *sql.Stmt
+	deleteArchived   *sql.Stmt
+	archiveStore     *archive.Store
+
 	logIndex int
 }
 
@@ -41,9 +54,11 @@ type DB struct {
 func (db *DB) Name() string { return "Engine.CleanupManager" }
 
 // NewDB creates a new DB.
-func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error) {
+//
+// If archiveStore is nil, closed alerts are never archived.
+func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store, archiveStore *archive.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 1,
+		Version: 3,
 		Type:    processinglock.TypeCleanup,
 	})
 	if err != nil {
@@ -75,6 +90,9 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 		setSchedData:    p.P(`update schedule_data set last_cleanup_at = now(), data = $2 where schedule_id = $1`),
 		cleanupSessions: p.P(`DELETE FROM auth_user_sessions WHERE id = any(select id from auth_user_sessions where last_access_at < (now() - '30 days'::interval) LIMIT 100 for update skip locked)`),
 
+		// change rows are only needed to detect changes between engine cycles
+		cleanupSchedCache: p.P(`DELETE FROM schedule_cache_changes WHERE id = ANY(SELECT id FROM schedule_cache_changes WHERE changed_at < (now() - '1 hour'::interval) LIMIT 1000 FOR UPDATE SKIP LOCKED)`),
+
 		cleanupAlertLogs: p.P(`
 			with
 				scope as (select id from alert_logs where id > $1 order by id limit 100),
@@ -93,6 +111,7 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 
 		cleanupOverrides:   p.P(`DELETE FROM user_overrides WHERE id = ANY(SELECT id FROM user_overrides WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupSchedOnCall: p.P(`DELETE FROM schedule_on_call_users WHERE id = ANY(SELECT id FROM schedule_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+		cleanupSchedShadow: p.P(`DELETE FROM schedule_on_call_shadows WHERE id = ANY(SELECT id FROM schedule_on_call_shadows WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupEPOnCall:    p.P(`DELETE FROM ep_step_on_call_users WHERE id = ANY(SELECT id FROM ep_step_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		unackAlerts: p.P(`
 			select id from alerts a
@@ -106,5 +125,43 @@ func NewDB(ctx context.Context, db *sql.DB, alertstore *alert.Store) (*DB, error
 				)
 			limit 100`),
 		alertStore: alertstore,
+
+		cleanupMessages: p.P(`
+			delete from outgoing_messages where id = any(
+				select id from outgoing_messages
+				where
+					last_status in ('bundled', 'delivered', 'failed', 'sent') and
+					next_retry_at isnull and
+					created_at < (now() - $1::interval)
+				order by created_at
+				limit 100
+				for update skip locked
+			)
+		`),
+		cleanupSMSCallbacks: p.P(`
+			delete from twilio_sms_callbacks where id = any(
+				select cb.id from twilio_sms_callbacks cb
+				left join alerts a on a.id = cb.alert_id
+				where
+					cb.sent_at < (now() - $1::interval) and
+					(a.id isnull or a.status = 'closed')
+				limit 100
+				for update of cb skip locked
+			)
+		`),
+		cleanupSMSErrors:   p.P(`DELETE FROM twilio_sms_errors WHERE id = ANY(SELECT id FROM twilio_sms_errors WHERE occurred_at < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+		cleanupVoiceErrors: p.P(`DELETE FROM twilio_voice_errors WHERE id = ANY(SELECT id FROM twilio_voice_errors WHERE occurred_at < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+
+		archiveAlerts: p.P(`
+			select id, service_id, summary, details, source, severity, created_at
+			from alerts
+			where status = 'closed' and created_at < (now() - $1::interval)
+			order by id
+			limit 100
+			for update skip locked
+		`),
+		archiveAlertLogs: p.P(`select id, alert_id, timestamp, event, message from alert_logs where alert_id = any($1) order by id`),
+		deleteArchived:   p.P(`delete from alerts where id = any($1)`),
+		archiveStore:     archiveStore,
 	}, p.Err
 }
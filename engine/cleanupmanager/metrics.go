@@ -0,0 +1,13 @@
+package cleanupmanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricDeletedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "engine",
+	Name:      "cleanup_deleted_total",
+	Help:      "Total number of rows deleted by the cleanup manager, by table.",
+}, []string{"table"})
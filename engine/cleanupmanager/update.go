@@ -54,7 +54,19 @@ func (db *DB) update(ctx context.Context) error {
 		return fmt.Errorf("cleanup sessions: %w", err)
 	}
 
+	_, err = tx.StmtContext(ctx, db.cleanupSchedCache).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("cleanup schedule cache changes: %w", err)
+	}
+
 	cfg := config.FromContext(ctx)
+	if cfg.Maintenance.AlertArchiveDays > 0 && db.archiveStore != nil {
+		err = db.archiveClosed(ctx, tx, now, cfg.Maintenance.AlertArchiveDays)
+		if err != nil {
+			return fmt.Errorf("archive alerts: %w", err)
+		}
+	}
+
 	if cfg.Maintenance.AlertCleanupDays > 0 {
 		var dur pgtype.Interval
 		dur.Days = int32(cfg.Maintenance.AlertCleanupDays)
@@ -111,12 +123,40 @@ func (db *DB) update(ctx context.Context) error {
 			return fmt.Errorf("cleanup schedule on-call: %w", err)
 		}
 
+		_, err = tx.StmtContext(ctx, db.cleanupSchedShadow).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("cleanup schedule shadows: %w", err)
+		}
+
 		_, err = tx.StmtContext(ctx, db.cleanupEPOnCall).ExecContext(ctx, &dur)
 		if err != nil {
 			return fmt.Errorf("cleanup escalation policy on-call: %w", err)
 		}
 	}
 
+	if cfg.Maintenance.MessageLogCleanupDays > 0 {
+		var dur pgtype.Interval
+		dur.Days = int32(cfg.Maintenance.MessageLogCleanupDays)
+		dur.Status = pgtype.Present
+
+		err = db.cleanupTable(ctx, tx, db.cleanupMessages, "outgoing_messages", &dur)
+		if err != nil {
+			return err
+		}
+		err = db.cleanupTable(ctx, tx, db.cleanupSMSCallbacks, "twilio_sms_callbacks", &dur)
+		if err != nil {
+			return err
+		}
+		err = db.cleanupTable(ctx, tx, db.cleanupSMSErrors, "twilio_sms_errors", &dur)
+		if err != nil {
+			return err
+		}
+		err = db.cleanupTable(ctx, tx, db.cleanupVoiceErrors, "twilio_voice_errors", &dur)
+		if err != nil {
+			return err
+		}
+	}
+
 	rows, err := tx.StmtContext(ctx, db.schedData).QueryContext(ctx)
 	if err != nil {
 		return err
@@ -175,6 +215,22 @@ func (db *DB) update(ctx context.Context) error {
 	return tx.Commit()
 }
 
+// cleanupTable will execute a cleanup statement, recording the number of deleted rows.
+func (db *DB) cleanupTable(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt, table string, args ...interface{}) error {
+	res, err := tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("cleanup %s: %w", table, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cleanup %s: %w", table, err)
+	}
+	metricDeletedTotal.WithLabelValues(table).Add(float64(n))
+
+	return nil
+}
+
 func lookupMap(users []string) map[string]struct{} {
 	userLookup := make(map[string]struct{}, len(users))
 	for _, id := range users {
@@ -192,6 +248,18 @@ func cleanupScheduleData(data *schedule.Data, userMap map[string]struct{}, cutof
 		filtered = append(filtered, temp)
 	}
 	data.V1.TemporarySchedules = filtered
+
+	shadows := data.V1.Shadows[:0]
+	for _, s := range data.V1.Shadows {
+		if s.End.Before(cutoff) {
+			continue
+		}
+		if _, ok := userMap[s.UserID]; !ok {
+			continue
+		}
+		shadows = append(shadows, s)
+	}
+	data.V1.Shadows = shadows
 }
 
 // getUsers retrieves the current set of user IDs
@@ -0,0 +1,91 @@
+package conferencemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util"
+)
+
+// DB starts conference bridges for alerts that reach an escalation step with a conference bridge enabled.
+type DB struct {
+	lock *processinglock.Lock
+
+	twilio *twilio.Config
+	slack  *slack.ChannelSender
+
+	cleanup  *sql.Stmt
+	pending  *sql.Stmt
+	insert   *sql.Stmt
+	numbers  *sql.Stmt
+	channels *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.ConferenceManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, tw *twilio.Config, sl *slack.ChannelSender) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeConference,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		twilio: tw,
+		slack:  sl,
+
+		cleanup: p.P(`
+			delete from twilio_conference_bridges b
+			using alerts a
+			where a.id = b.alert_id and a.status = 'closed'
+		`),
+
+		// Only recent escalations are considered, so enabling the option on an existing
+		// step does not start bridges for older alerts.
+		pending: p.P(`
+			select state.alert_id, state.escalation_policy_step_id, a.summary
+			from escalation_policy_state state
+			join escalation_policy_steps step on
+				step.id = state.escalation_policy_step_id and
+				step.conference_bridge
+			join alerts a on a.id = state.alert_id and a.status = 'triggered'
+			where
+				state.last_escalation > now() - '5 minutes'::interval and
+				not exists (select 1 from twilio_conference_bridges b where b.alert_id = state.alert_id)
+			order by state.alert_id
+			limit $1
+		`),
+		insert: p.P(`
+			insert into twilio_conference_bridges (alert_id, ep_step_id, access_code)
+			values ($1, $2, $3)
+			on conflict (alert_id) do nothing
+		`),
+		numbers: p.P(`
+			select distinct cm.value
+			from ep_step_on_call_users oc
+			join user_contact_methods cm on
+				cm.user_id = oc.user_id and
+				cm.type = 'VOICE' and
+				not cm.disabled and
+				not cm.pending
+			where oc.ep_step_id = $1 and oc.end_time isnull
+			limit $2
+		`),
+		channels: p.P(`
+			select nc.value
+			from escalation_policy_actions act
+			join notification_channels nc on nc.id = act.channel_id and nc.type = 'SLACK'
+			where act.escalation_policy_step_id = $1
+		`),
+	}, p.Err
+}
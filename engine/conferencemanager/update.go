@@ -0,0 +1,185 @@
+package conferencemanager
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// maxBridges limits the number of bridges started per cycle.
+	maxBridges = 5
+
+	// maxParticipants limits the number of numbers dialed into a single bridge.
+	maxParticipants = 20
+
+	// minRemaining is the time that must remain before the module deadline to start another bridge.
+	minRemaining = 10 * time.Second
+)
+
+type bridge struct {
+	AlertID    int
+	StepID     sql.NullString
+	Summary    string
+	AccessCode string
+}
+
+func newAccessCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < twilio.ConferenceAccessCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", twilio.ConferenceAccessCodeDigits, n), nil
+}
+
+// UpdateAll will start conference bridges for alerts that have reached a step with the option enabled.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Twilio.Enable || db.twilio == nil {
+		return nil
+	}
+	log.Debugf(ctx, "Processing conference bridges.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "conference manager", tx)
+
+	_, err = tx.StmtContext(ctx, db.cleanup).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("cleanup closed bridges: %w", err)
+	}
+
+	rows, err := tx.StmtContext(ctx, db.pending).QueryContext(ctx, maxBridges)
+	if err != nil {
+		return fmt.Errorf("query pending bridges: %w", err)
+	}
+	defer rows.Close()
+
+	var bridges []bridge
+	for rows.Next() {
+		var b bridge
+		err = rows.Scan(&b.AlertID, &b.StepID, &b.Summary)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		bridges = append(bridges, b)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for i := range bridges {
+		bridges[i].AccessCode, err = newAccessCode()
+		if err != nil {
+			return fmt.Errorf("generate access code: %w", err)
+		}
+		_, err = tx.StmtContext(ctx, db.insert).ExecContext(ctx, bridges[i].AlertID, bridges[i].StepID, bridges[i].AccessCode)
+		if err != nil {
+			return fmt.Errorf("insert bridge: %w", err)
+		}
+	}
+
+	// Commit before dialing anyone, so a failed commit never results in duplicate calls.
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bridges {
+		if !hasTime(ctx) {
+			log.Logf(ctx, "conference manager: out of time, remaining bridges will not be dialed")
+			break
+		}
+		db.start(log.WithField(ctx, log.FieldAlertID, b.AlertID), b)
+	}
+
+	return nil
+}
+
+func hasTime(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > minRemaining
+}
+
+// start will dial the on-call users of the step into the bridge and post the dial-in details to the
+// step's Slack channels. Failures are logged, as the bridge can still be joined by calling in.
+func (db *DB) start(ctx context.Context, b bridge) {
+	cfg := config.FromContext(ctx)
+	if !b.StepID.Valid {
+		return
+	}
+
+	numbers, err := db.stepValues(ctx, db.numbers, b.StepID.String, maxParticipants)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("lookup conference participants: %w", err))
+	}
+	announcement := fmt.Sprintf("This is %s with a conference bridge for alert number %d: %s. Connecting you now.", cfg.ApplicationName(), b.AlertID, b.Summary)
+	for _, num := range numbers {
+		_, err = db.twilio.DialConference(ctx, num, b.AlertID, announcement)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("dial conference participant: %w", err))
+		}
+	}
+
+	if db.slack == nil || !cfg.Slack.Enable {
+		return
+	}
+	channels, err := db.stepValues(ctx, db.channels, b.StepID.String)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("lookup conference Slack channels: %w", err))
+		return
+	}
+	text := fmt.Sprintf("Conference bridge started for alert #%d: %s\nTo join, call %s, press %s, then enter access code %s.\n<%s>",
+		b.AlertID, b.Summary,
+		cfg.Twilio.FromNumber, twilio.ConferenceMenuDigit, b.AccessCode,
+		cfg.CallbackURL(fmt.Sprintf("/alerts/%d", b.AlertID)),
+	)
+	for _, ch := range channels {
+		err = db.slack.PostText(ctx, ch, text)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("post conference bridge to Slack: %w", err))
+		}
+	}
+}
+
+func (db *DB) stepValues(ctx context.Context, stmt *sql.Stmt, args ...interface{}) ([]string, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, rows.Err()
+}
@@ -5,11 +5,15 @@ import (
 
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/alert/archive"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/engine/eventstream"
+	"github.com/target/goalert/engine/jobqueue"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/schedule"
@@ -21,6 +25,8 @@ import (
 type Config struct {
 	AlertLogStore       *alertlog.Store
 	AlertStore          *alert.Store
+	AlertArchiveStore   *archive.Store
+	JobStore            *jobqueue.Store
 	ContactMethodStore  *contactmethod.Store
 	NotificationManager *notification.Manager
 	UserStore           *user.Store
@@ -30,9 +36,13 @@ type Config struct {
 	ScheduleStore       *schedule.Store
 	AuthLinkStore       *authlink.Store
 	SlackStore          *slack.ChannelSender
+	TwilioConfig        *twilio.Config
 
 	ConfigSource config.Source
 
+	// Events, if set, receives engine events for live debugging.
+	Events *eventstream.Broker
+
 	Keys keyring.Keys
 
 	MaxMessages int
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/validation"
@@ -13,13 +14,30 @@ const cycleHist = 10
 type cycleMonitor struct {
 	mx sync.Mutex
 
-	cycles  map[uuid.UUID]chan struct{}
+	cycles  map[uuid.UUID]*cycleInfo
 	history [cycleHist]uuid.UUID
 }
 
+type cycleInfo struct {
+	done       chan struct{}
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+// CycleStatus describes the progress of an engine cycle.
+type CycleStatus struct {
+	ID uuid.UUID
+
+	// StartedAt is zero if the cycle has not started yet.
+	StartedAt time.Time
+
+	// FinishedAt is zero if the cycle has not finished yet.
+	FinishedAt time.Time
+}
+
 func newCycleMonitor() *cycleMonitor {
 	m := &cycleMonitor{
-		cycles: make(map[uuid.UUID]chan struct{}, cycleHist),
+		cycles: make(map[uuid.UUID]*cycleInfo, cycleHist),
 	}
 	m._newID()
 	return m
@@ -34,7 +52,7 @@ func (c *cycleMonitor) _newID() {
 
 	// add new cycle
 	c.history[0] = uuid.New()
-	c.cycles[c.history[0]] = make(chan struct{})
+	c.cycles[c.history[0]] = &cycleInfo{done: make(chan struct{})}
 }
 
 // startNextCycle marks the beginning of the next engine cycle.
@@ -43,10 +61,16 @@ func (c *cycleMonitor) startNextCycle() func() {
 	c.mx.Lock()
 	defer c.mx.Unlock()
 
-	ch := c.cycles[c.history[0]]
+	info := c.cycles[c.history[0]]
+	info.startedAt = time.Now()
 	c._newID()
 
-	return func() { close(ch) }
+	return func() {
+		c.mx.Lock()
+		info.finishedAt = time.Now()
+		c.mx.Unlock()
+		close(info.done)
+	}
 }
 
 // WaitCycleID waits for the engine cycle with the given UUID to finish.
@@ -57,14 +81,14 @@ func (c *cycleMonitor) WaitCycleID(ctx context.Context, cycleID uuid.UUID) error
 	}
 
 	c.mx.Lock()
-	ch, ok := c.cycles[cycleID]
+	info, ok := c.cycles[cycleID]
 	c.mx.Unlock()
 	if !ok {
 		return validation.NewGenericError("unknown cycle ID")
 	}
 
 	select {
-	case <-ch:
+	case <-info.done:
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -83,3 +107,27 @@ func (c *cycleMonitor) NextCycleID() uuid.UUID {
 
 	return c.history[0]
 }
+
+// CycleStatus returns the progress of the engine cycle with the given UUID.
+//
+// Only the most recent cycles are tracked.
+func (c *cycleMonitor) CycleStatus(cycleID uuid.UUID) (*CycleStatus, error) {
+	if c == nil {
+		// engine is disabled
+		return nil, validation.NewGenericError("engine is disabled")
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	info, ok := c.cycles[cycleID]
+	if !ok {
+		return nil, validation.NewGenericError("unknown cycle ID")
+	}
+
+	return &CycleStatus{
+		ID:         cycleID,
+		StartedAt:  info.startedAt,
+		FinishedAt: info.finishedAt,
+	}, nil
+}
@@ -3,31 +3,45 @@ package engine
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine/cleanupmanager"
 	"github.com/target/goalert/engine/compatmanager"
+	"github.com/target/goalert/engine/conferencemanager"
 	"github.com/target/goalert/engine/escalationmanager"
 	"github.com/target/goalert/engine/heartbeatmanager"
+	"github.com/target/goalert/engine/jiramanager"
+	"github.com/target/goalert/engine/jobqueue"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/engine/metricsmanager"
 	"github.com/target/goalert/engine/npcyclemanager"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/engine/rotationmanager"
 	"github.com/target/goalert/engine/schedulemanager"
+	"github.com/target/goalert/engine/servicenowmanager"
+	"github.com/target/goalert/engine/slomanager"
+	"github.com/target/goalert/engine/statuscallbackmanager"
 	"github.com/target/goalert/engine/statusmgr"
+	"github.com/target/goalert/engine/statuspagemanager"
 	"github.com/target/goalert/engine/verifymanager"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type updater interface {
@@ -51,12 +65,22 @@ type Engine struct {
 	runLoopExit chan struct{}
 
 	modules []updater
+	jobs    *jobqueue.Runner
 	msg     *message.DB
 
 	a   *alert.Store
 	cfg *Config
 
 	triggerPauseCh chan *pauseReq
+
+	// draining is set once Drain is called, after which no new work will be started.
+	draining atomic.Bool
+
+	// logCycles is initialized from Config.LogCycles, and can be changed with SetLogCycles.
+	logCycles atomic.Bool
+
+	// lastDepth is the last time queue depth metrics were updated, it is only accessed by the run loop.
+	lastDepth time.Time
 }
 
 var _ notification.ResultReceiver = &Engine{}
@@ -83,6 +107,7 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 
 		a: c.AlertStore,
 	}
+	p.logCycles.Store(c.LogCycles)
 
 	p.mgr = lifecycle.NewManager(p._run, p._shutdown)
 	err = p.mgr.SetPauseResumer(lifecycle.PauseResumerFunc(
@@ -121,7 +146,7 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "heartbeat processing backend")
 	}
-	cleanMgr, err := cleanupmanager.NewDB(ctx, db, c.AlertStore)
+	cleanMgr, err := cleanupmanager.NewDB(ctx, db, c.AlertStore, c.AlertArchiveStore)
 	if err != nil {
 		return nil, errors.Wrap(err, "cleanup backend")
 	}
@@ -133,6 +158,30 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "compatibility backend")
 	}
+	jiraMgr, err := jiramanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "jira backend")
+	}
+	snowMgr, err := servicenowmanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "servicenow backend")
+	}
+	statuspageMgr, err := statuspagemanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "statuspage backend")
+	}
+	callbackMgr, err := statuscallbackmanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "status callback backend")
+	}
+	confMgr, err := conferencemanager.NewDB(ctx, db, c.TwilioConfig, c.SlackStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "conference bridge backend")
+	}
+	sloMgr, err := slomanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "slo backend")
+	}
 
 	p.modules = []updater{
 		compatMgr,
@@ -143,10 +192,19 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 		statMgr,
 		verifyMgr,
 		hbMgr,
-		cleanMgr,
-		metricsMgr,
+		jiraMgr,
+		snowMgr,
+		statuspageMgr,
+		confMgr,
+		callbackMgr,
 	}
 
+	// cleanup, metrics, and SLOs can be slow, so they run as jobs rather than blocking engine cycles
+	p.jobs = jobqueue.NewRunner(c.JobStore, p.isStopping)
+	p.jobs.Register(p.moduleJob(cleanMgr))
+	p.jobs.Register(p.moduleJob(metricsMgr))
+	p.jobs.Register(p.moduleJob(sloMgr))
+
 	p.msg, err = message.NewDB(ctx, db, c.AlertLogStore, p.mgr)
 	if err != nil {
 		return nil, errors.Wrap(err, "messaging backend")
@@ -172,8 +230,14 @@ func (p *Engine) processModule(ctx context.Context, m updater) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	ctx = log.WithSubsystem(ctx, m.Name())
+	ctx, span := tracer.Start(ctx, m.Name())
+	var err error
+	defer func() { endSpan(span, err) }()
+	defer p.publishModule(ctx, m.Name(), time.Now(), &err)
+
 	for {
-		err := m.UpdateAll(ctx)
+		err = m.UpdateAll(ctx)
 		if sqlErr := sqlutil.MapError(err); ctx.Err() == nil && sqlErr != nil && strings.HasPrefix(sqlErr.Code, "40") {
 			// Class `40` is a transaction failure.
 			// In that case we will retry, so long
@@ -182,27 +246,56 @@ func (p *Engine) processModule(ctx context.Context, m updater) {
 			// https://www.postgresql.org/docs/9.6/static/errcodes-appendix.html
 			continue
 		}
-		if err != nil && !errors.Is(err, processinglock.ErrNoLock) {
+		if errors.Is(err, processinglock.ErrNoLock) {
+			span.SetAttributes(attribute.Bool("engine.no_lock", true))
+			err = nil
+		}
+		if err != nil {
 			log.Log(ctx, errors.Wrap(err, m.Name()))
 		}
 		break
 	}
 }
 
+// moduleJob returns a periodic job that runs m every cycle interval.
+func (p *Engine) moduleJob(m updater) jobqueue.Worker {
+	return jobqueue.Worker{
+		Kind:     m.Name(),
+		Interval: p.cycleTime(),
+		Work: func(ctx context.Context, _ json.RawMessage) error {
+			ctx = p.cfg.ConfigSource.Config().Context(ctx)
+			ctx = log.WithSubsystem(ctx, m.Name())
+			err := m.UpdateAll(ctx)
+			if errors.Is(err, processinglock.ErrNoLock) {
+				return fmt.Errorf("%w: %w", jobqueue.ErrNotReady, err)
+			}
+			return err
+		},
+	}
+}
+
+func (p *Engine) cycleTime() time.Duration {
+	if p.cfg.CycleTime == 0 {
+		return 5 * time.Second
+	}
+	return p.cfg.CycleTime
+}
+
 func (p *Engine) processMessages(ctx context.Context) {
 	defer recoverPanic(ctx, "MessageManager")
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	err := p.msg.SendMessages(ctx, p.sendMessage, p.cfg.NotificationManager.MessageStatus)
-	if errors.Is(err, processinglock.ErrNoLock) {
-		return
-	}
-	if errors.Is(err, message.ErrAbort) {
-		return
+	ctx = log.WithSubsystem(ctx, "Engine.MessageManager")
+	ctx, span := tracer.Start(ctx, "Engine.Message")
+	err := p.msg.SendMessages(ctx, p.sendMessage, p.findSent, p.cfg.NotificationManager.MessageStatus)
+	if errors.Is(err, processinglock.ErrNoLock) || errors.Is(err, message.ErrAbort) {
+		err = nil
 	}
+	endSpan(span, err)
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "send outgoing messages"))
+		p.publishError(ctx, "Engine.MessageManager", err)
 	}
 }
 
@@ -222,6 +315,37 @@ func recoverPanic(ctx context.Context, name string) {
 // Trigger will force notifications to be processed immediately.
 func (p *Engine) Trigger() { <-p.triggerCh }
 
+// TriggerCycle will request an engine cycle to start as soon as possible without waiting for it,
+// returning the ID of the cycle that will run.
+func (p *Engine) TriggerCycle(ctx context.Context) (uuid.UUID, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if p.cfg.DisableCycle {
+		return uuid.Nil, validation.NewGenericError("engine is in API-only mode")
+	}
+
+	id := p.NextCycleID()
+	go p.Trigger()
+
+	return id, nil
+}
+
+// ConfigChanged implements config.ChangeFunc by starting a new cycle immediately, so the new config takes
+// effect (e.g., pending messages are sent with new provider credentials) without waiting for the next cycle.
+func (p *Engine) ConfigChanged(ctx context.Context, oldCfg, newCfg config.Config) {
+	if p.cfg.DisableCycle {
+		return
+	}
+
+	log.Debugf(ctx, "Config changed, triggering engine cycle.")
+	go p.Trigger()
+}
+
+// SetLogCycles will enable or disable logging the start and end of each engine cycle.
+func (p *Engine) SetLogCycles(enable bool) { p.logCycles.Store(enable) }
+
 // Pause will attempt to gracefully stop engine processing.
 func (p *Engine) Pause(ctx context.Context) error {
 	return p.mgr.Pause(ctx)
@@ -246,6 +370,34 @@ func (p *Engine) _pause(ctx context.Context) error {
 	}
 }
 
+// Drain will permanently stop the engine from starting new work (unlike Pause, it is not undone by Resume)
+// and return once any in-progress cycle and jobs, including outgoing messages, have finished.
+func (p *Engine) Drain(ctx context.Context) error {
+	p.draining.Store(true)
+
+	ch := make(chan error, 1)
+	select {
+	case <-p.shutdownCh:
+		return errors.New("shutting down")
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.triggerPauseCh <- &pauseReq{ch: ch, ctx: ctx}:
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-ch:
+		return err
+	}
+}
+
+// IsDraining will return true if Drain has been called.
+func (p *Engine) IsDraining() bool { return p.draining.Load() }
+
+// isStopping returns true if no new work should be started.
+func (p *Engine) isStopping() bool { return p.mgr.IsPausing() || p.draining.Load() }
+
 // Resume will allow the engine to resume processing.
 func (p *Engine) Resume(ctx context.Context) error {
 	return p.mgr.Resume(ctx)
@@ -293,10 +445,10 @@ func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, call
 		return err
 	}
 	if cb.ServiceID != "" {
-		ctx = log.WithField(ctx, "ServiceID", cb.ServiceID)
+		ctx = log.WithField(ctx, log.FieldServiceID, cb.ServiceID)
 	}
 	if cb.AlertID != 0 {
-		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
+		ctx = log.WithField(ctx, log.FieldAlertID, cb.AlertID)
 	}
 
 	var usr *user.User
@@ -317,30 +469,7 @@ func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, call
 		ID:   callbackID,
 	})
 
-	var newStatus alert.Status
-	switch result {
-	case notification.ResultAcknowledge:
-		newStatus = alert.StatusActive
-	case notification.ResultResolve:
-		newStatus = alert.StatusClosed
-	case notification.ResultEscalate:
-		err = p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
-		if err != nil {
-			return fmt.Errorf("escalate alert: %w", err)
-		}
-		return nil
-	default:
-		return errors.New("unknown result type")
-	}
-
-	if cb.AlertID != 0 {
-		return errors.Wrap(p.a.UpdateStatus(ctx, cb.AlertID, newStatus), "update alert")
-	}
-	if cb.ServiceID != "" {
-		return errors.Wrap(p.a.UpdateStatusByService(ctx, cb.ServiceID, newStatus), "update all alerts")
-	}
-
-	return errors.New("unknown callback type")
+	return p.receiveResult(ctx, cb, result)
 }
 
 // Receive will process a notification result.
@@ -350,10 +479,20 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 		return err
 	}
 	if cb.ServiceID != "" {
-		ctx = log.WithField(ctx, "ServiceID", cb.ServiceID)
+		ctx = log.WithField(ctx, log.FieldServiceID, cb.ServiceID)
 	}
 	if cb.AlertID != 0 {
-		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
+		ctx = log.WithField(ctx, log.FieldAlertID, cb.AlertID)
+	}
+	if cb.ContactMethodID == "" && cb.ChannelID != "" {
+		// Responses from a team contact method (e.g., a shared phone) are attributed to the team's
+		// channel, rather than a user.
+		ctx = permission.SystemContext(ctx, "TeamContactMethod")
+		ctx = permission.SourceContext(ctx, &permission.SourceInfo{
+			Type: permission.SourceTypeNotificationChannel,
+			ID:   cb.ChannelID,
+		})
+		return p.receiveResult(ctx, cb, result)
 	}
 
 	var usr *user.User
@@ -376,6 +515,12 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 		ID:   callbackID,
 	})
 
+	return p.receiveResult(ctx, cb, result)
+}
+
+// receiveResult will apply a notification result for the callback. Shadow users are prevented
+// from acknowledging or closing alerts by the alert store.
+func (p *Engine) receiveResult(ctx context.Context, cb *callback, result notification.Result) error {
 	var newStatus alert.Status
 	switch result {
 	case notification.ResultAcknowledge:
@@ -383,7 +528,7 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	case notification.ResultResolve:
 		newStatus = alert.StatusClosed
 	case notification.ResultEscalate:
-		err = p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
+		err := p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
 		if err != nil {
 			return fmt.Errorf("escalate alert: %w", err)
 		}
@@ -434,7 +579,7 @@ func (p *Engine) Stop(ctx context.Context, d notification.Dest) error {
 
 func (p *Engine) processAll(ctx context.Context) bool {
 	for _, m := range p.modules {
-		if p.mgr.IsPausing() {
+		if p.isStopping() {
 			return true
 		}
 
@@ -445,6 +590,37 @@ func (p *Engine) processAll(ctx context.Context) bool {
 	return false
 }
 
+// queueDepthInterval is the minimum time between queue depth metric updates.
+const queueDepthInterval = 30 * time.Second
+
+// updateQueueDepth will update queue depth metrics, at most once every queueDepthInterval.
+func (p *Engine) updateQueueDepth(ctx context.Context) {
+	if time.Since(p.lastDepth) < queueDepthInterval {
+		return
+	}
+	p.lastDepth = time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	depth, err := p.b.QueueDepth(ctx)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "update queue depth metrics"))
+		return
+	}
+
+	// modules and jobs with nothing pending return no rows
+	for _, m := range p.modules {
+		metricQueueDepth.WithLabelValues(m.Name()).Set(float64(depth[m.Name()]))
+	}
+	for _, kind := range p.jobs.Kinds() {
+		metricQueueDepth.WithLabelValues(kind).Set(float64(depth[kind]))
+	}
+	for name, n := range depth {
+		metricQueueDepth.WithLabelValues(name).Set(float64(n))
+	}
+}
+
 func monitorCycle(ctx context.Context, start time.Time) (cancel func()) {
 	ctx, cancel = context.WithCancel(ctx)
 
@@ -477,44 +653,63 @@ func monitorCycle(ctx context.Context, start time.Time) (cancel func()) {
 	return cancel
 }
 
-func (p *Engine) cycle(ctx context.Context) {
+func (p *Engine) cycle(ctx context.Context, direct bool) {
+	// only the run loop starts cycles, so the next ID is the one for this cycle
+	cycleID := p.NextCycleID()
+
 	// track start of next cycle, and defer the call to the returned sfinish function
 	defer p.startNextCycle()()
 	ctx = p.cfg.ConfigSource.Config().Context(ctx)
+	ctx = withCycleID(ctx, cycleID)
 
-	if p.mgr.IsPausing() {
-		log.Logf(ctx, "Engine cycle disabled (paused or shutting down).")
+	if p.isStopping() {
+		log.Logf(ctx, "Engine cycle disabled (paused, draining, or shutting down).")
 		return
 	}
 
-	if p.cfg.LogCycles {
+	if p.logCycles.Load() {
 		log.Logf(ctx, "Engine cycle start.")
 		defer log.Logf(ctx, "Engine cycle end.")
 	}
 
 	startAll := time.Now()
 	defer monitorCycle(ctx, startAll)()
+	defer p.publishCycle(ctx, direct, startAll)()
+
+	ctx, span := tracer.Start(ctx, "Engine.Cycle", trace.WithAttributes(attribute.Bool("engine.direct", direct)))
+	defer span.End()
 
 	aborted := p.processAll(ctx)
-	if aborted || p.mgr.IsPausing() {
-		log.Logf(ctx, "Engine cycle aborted (paused or shutting down).")
+	if aborted || p.isStopping() {
+		log.Logf(ctx, "Engine cycle aborted (paused, draining, or shutting down).")
 		return
 	}
+	if direct {
+		// direct triggers expect all processing to be complete, including jobs
+		err := p.jobs.RunAll(ctx)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "run jobs"))
+		}
+	}
 	startMsg := time.Now()
 	p.processMessages(ctx)
 	metricModuleDuration.WithLabelValues("Engine.Message").Observe(time.Since(startMsg).Seconds())
 	metricModuleDuration.WithLabelValues("Engine").Observe(time.Since(startAll).Seconds())
 	metricCycleTotal.Inc()
+
+	p.updateQueueDepth(ctx)
 }
 
 func (p *Engine) handlePause(ctx context.Context, respCh chan error) {
-	// nothing special to do currently
+	// no new jobs will be claimed while pausing, but one may be in progress
+	p.jobs.Wait()
 	respCh <- nil
 }
 
 func (p *Engine) _run(ctx context.Context) error {
 	defer close(p.runLoopExit)
 	ctx = permission.SystemContext(ctx, "Engine")
+	ctx = log.WithSubsystem(ctx, "Engine")
 	if p.cfg.DisableCycle {
 		log.Logf(ctx, "Engine started in API-only mode.")
 		ch := make(chan struct{})
@@ -533,16 +728,26 @@ func (p *Engine) _run(ctx context.Context) error {
 		}
 	}
 
-	dur := p.cfg.CycleTime
-	if dur == 0 {
-		dur = 5 * time.Second
-	}
-	alertTicker := time.NewTicker(dur)
+	jobCtx, cancelJobs := context.WithCancel(ctx)
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		err := p.jobs.Run(jobCtx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Log(ctx, errors.Wrap(err, "run jobs"))
+		}
+	}()
+	defer func() {
+		cancelJobs()
+		<-jobsDone
+	}()
+
+	alertTicker := time.NewTicker(p.cycleTime())
 	defer alertTicker.Stop()
 
 	defer close(p.triggerCh)
 
-	p.cycle(ctx)
+	p.cycle(ctx, false)
 
 	for {
 		// give priority to pending shutdown signals
@@ -564,9 +769,9 @@ func (p *Engine) _run(ctx context.Context) error {
 		case req := <-p.triggerPauseCh:
 			p.handlePause(req.ctx, req.ch)
 		case p.triggerCh <- struct{}{}:
-			p.cycle(log.WithField(ctx, "Trigger", "DIRECT"))
+			p.cycle(log.WithField(ctx, "Trigger", "DIRECT"), true)
 		case <-alertTicker.C:
-			p.cycle(log.WithField(ctx, "Trigger", "INTERVAL"))
+			p.cycle(log.WithField(ctx, "Trigger", "INTERVAL"), false)
 		case <-ctx.Done():
 			// context canceled or something
 			return ctx.Err()
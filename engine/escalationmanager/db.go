@@ -22,6 +22,7 @@ type DB struct {
 	newPolicies      *sql.Stmt
 	deletedSteps     *sql.Stmt
 	normalEscalation *sql.Stmt
+	giveUp           *sql.Stmt
 
 	log *alertlog.Store
 }
@@ -32,7 +33,7 @@ func (db *DB) Name() string { return "Engine.EscalationManager" }
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 4,
+		Version: 7,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -49,23 +50,41 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 
 		updateOnCall: p.P(`
 			with on_call as (
-				select
-					step.id step_id,
-					coalesce(act.user_id, part.user_id, sched.user_id) user_id
-				from escalation_policy_steps step
-				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
-				left join rotation_state rState on rState.rotation_id = act.rotation_id
-				left join rotation_participants part on part.id = rState.rotation_participant_id
-				left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
-				where coalesce(act.user_id, part.user_id, sched.user_id) notnull
+				select distinct on (step_id, user_id) step_id, user_id, is_shadow
+				from (
+					select
+						step.id step_id,
+						coalesce(act.user_id, part.user_id, sched.user_id, team_sched.user_id) user_id,
+						false is_shadow
+					from escalation_policy_steps step
+					join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+					left join rotation_state rState on rState.rotation_id = act.rotation_id
+					left join rotation_participants part on part.id = rState.rotation_participant_id
+					left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
+					left join teams team on team.id = act.team_id
+					left join schedule_on_call_users team_sched on team_sched.schedule_id = team.default_schedule_id and team_sched.end_time isnull
+					where coalesce(act.user_id, part.user_id, sched.user_id, team_sched.user_id) notnull
+					union all
+					select
+						act.escalation_policy_step_id step_id,
+						shadow.user_id,
+						true is_shadow
+					from escalation_policy_actions act
+					left join teams team on team.id = act.team_id
+					join schedule_on_call_shadows shadow on
+						shadow.schedule_id = coalesce(act.schedule_id, team.default_schedule_id) and
+						shadow.end_time isnull
+				) all_on_call
+				order by step_id, user_id, is_shadow
 			), ended as (
 				select
 				ep_step_id step_id,
-					user_id
+					user_id,
+					is_shadow
 				from ep_step_on_call_users
 				where end_time isnull
 				except
-				select step_id, user_id
+				select step_id, user_id, is_shadow
 				from on_call
 			), _end as (
 				update ep_step_on_call_users ep
@@ -76,8 +95,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					ep.user_id = ended.user_id and
 					ep.end_time isnull
 			) 
-			insert into ep_step_on_call_users (ep_step_id, user_id)
-			select step_id, user_id
+			insert into ep_step_on_call_users (ep_step_id, user_id, is_shadow)
+			select step_id, user_id, is_shadow
 			from on_call
 			on conflict do nothing
 			returning ep_step_id, user_id
@@ -146,6 +165,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					last_escalation = now(),
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_id = esc.ep_step_id,
+					notification_count = 1,
 					force_escalation = false
 				from
 					to_escalate esc
@@ -164,7 +184,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					alert_id,
 					step.id ep_step_id,
 					step.step_number,
-					step.delay,
+					least(round(step.delay * power(ep.backoff_multiplier, state.loop_count)), 1440) delay,
 					state.escalation_policy_step_number >= ep.step_count repeated,
 					a.service_id,
 					step.escalation_policy_id
@@ -180,7 +200,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
 				where
 					state.last_escalation notnull and
-					escalation_policy_step_id isnull
+					escalation_policy_step_id isnull and
+					(ep.max_notifications = 0 or state.notification_count < ep.max_notifications)
 				for update skip locked
 				limit 100
 			), _step_cycles as (
@@ -221,6 +242,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
+					notification_count = notification_count + 1,
 					force_escalation = false
 				from
 					to_escalate esc
@@ -237,7 +259,12 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				select
 					alert_id,
 					nextStep.id ep_step_id,
-					nextStep.delay,
+					least(
+						round(nextStep.delay * power(ep.backoff_multiplier,
+							CASE WHEN oldStep.step_number + 1 >= ep.step_count THEN state.loop_count + 1 ELSE state.loop_count END
+						)),
+						1440
+					) delay,
 					nextStep.step_number,
 					force_escalation forced,
 					oldStep.delay old_delay,
@@ -261,7 +288,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id notnull and
-					(next_escalation < now() or force_escalation)
+					(next_escalation < now() or force_escalation) and
+					(ep.max_notifications = 0 or state.notification_count < ep.max_notifications or force_escalation)
 				order by next_escalation - now()
 				for update skip locked
 				limit 500
@@ -304,6 +332,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
 					loop_count = CASE WHEN esc.repeated THEN loop_count + 1 ELSE loop_count END,
+					notification_count = notification_count + 1,
 					force_escalation = false
 				from
 					to_escalate esc
@@ -315,5 +344,45 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
 		`),
+
+		giveUp: p.P(`
+			with to_give_up as (
+				select
+					state.alert_id,
+					a.service_id,
+					state.escalation_policy_id,
+					ep.give_up_channel_id
+				from escalation_policy_state state
+				join escalation_policies ep on
+					ep.id = state.escalation_policy_id and
+					ep.give_up_channel_id notnull
+				join escalation_policy_steps step on step.id = state.escalation_policy_step_id
+				join alerts a on a.id = state.alert_id and a.status = 'triggered'
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+				where
+					not state.gave_up and
+					not state.force_escalation and
+					state.next_escalation < now() and
+					(
+						(ep.max_notifications > 0 and state.notification_count >= ep.max_notifications) or
+						(step.step_number + 1 >= ep.step_count and ep.repeat <> -1 and state.loop_count >= ep.repeat)
+					)
+				for update of state skip locked
+				limit 500
+			), _channels as (
+				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
+				select
+					cast('alert_notification' as enum_outgoing_messages_type),
+					alert_id,
+					service_id,
+					escalation_policy_id,
+					give_up_channel_id
+				from to_give_up
+			)
+			update escalation_policy_state state
+			set gave_up = true
+			from to_give_up
+			where state.alert_id = to_give_up.alert_id
+		`),
 	}, p.Err
 }
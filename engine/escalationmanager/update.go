@@ -84,6 +84,11 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "escalate forced or expired")
 	}
 
+	_, err = db.lock.Exec(ctx, db.giveUp)
+	if err != nil {
+		return errors.Wrap(err, "notify give-up channels")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/engine/eventstream"
+	"github.com/target/goalert/engine/message"
+	"github.com/target/goalert/notification"
+)
+
+type cycleIDKey struct{}
+
+func withCycleID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, cycleIDKey{}, id)
+}
+
+func cycleIDString(ctx context.Context) string {
+	id, ok := ctx.Value(cycleIDKey{}).(uuid.UUID)
+	if !ok {
+		return ""
+	}
+	return id.String()
+}
+
+func durationMS(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+
+// publishCycle publishes a cycle start event and returns a func that publishes the matching end event.
+func (p *Engine) publishCycle(ctx context.Context, direct bool, start time.Time) func() {
+	if !p.cfg.Events.Active() {
+		return func() {}
+	}
+
+	cycleID := cycleIDString(ctx)
+	p.cfg.Events.Publish(eventstream.Event{
+		Time:    start,
+		Type:    eventstream.TypeCycleStart,
+		CycleID: cycleID,
+		Fields:  map[string]string{"direct": strconv.FormatBool(direct)},
+	})
+
+	return func() {
+		p.cfg.Events.Publish(eventstream.Event{
+			Type:       eventstream.TypeCycleEnd,
+			CycleID:    cycleID,
+			DurationMS: durationMS(time.Since(start)),
+		})
+	}
+}
+
+// publishModule publishes the result of a module update (e.g., escalations processed by EscalationManager).
+func (p *Engine) publishModule(ctx context.Context, name string, start time.Time, err *error) {
+	if !p.cfg.Events.Active() {
+		return
+	}
+
+	p.cfg.Events.Publish(eventstream.Event{
+		Type:       eventstream.TypeModule,
+		CycleID:    cycleIDString(ctx),
+		Module:     name,
+		DurationMS: durationMS(time.Since(start)),
+	})
+	if *err != nil {
+		p.publishError(ctx, name, *err)
+	}
+}
+
+func (p *Engine) publishError(ctx context.Context, name string, err error) {
+	p.cfg.Events.Publish(eventstream.Event{
+		Type:    eventstream.TypeError,
+		CycleID: cycleIDString(ctx),
+		Module:  name,
+		Message: err.Error(),
+	})
+}
+
+// publishSent publishes the outcome of sending msg. Destination values are not included.
+func (p *Engine) publishSent(ctx context.Context, msg *message.Message, res *notification.SendResult, err error) {
+	if !p.cfg.Events.Active() {
+		return
+	}
+
+	fields := map[string]string{
+		"messageID":   msg.ID,
+		"messageType": msg.Type.String(),
+		"destType":    msg.Dest.Type.String(),
+	}
+	if msg.AlertID != 0 {
+		fields["alertID"] = strconv.Itoa(msg.AlertID)
+	}
+	if err != nil {
+		p.publishError(ctx, "Engine.SendMessage", err)
+		return
+	}
+	if res != nil {
+		fields["state"] = stateName(res.State)
+		if res.Details != "" {
+			fields["details"] = res.Details
+		}
+	}
+
+	p.cfg.Events.Publish(eventstream.Event{
+		Type:    eventstream.TypeMessageSent,
+		CycleID: cycleIDString(ctx),
+		Module:  "Engine.SendMessage",
+		Fields:  fields,
+	})
+}
+
+func stateName(s notification.State) string {
+	switch s {
+	case notification.StateSending:
+		return "sending"
+	case notification.StatePending:
+		return "pending"
+	case notification.StateSent:
+		return "sent"
+	case notification.StateDelivered:
+		return "delivered"
+	case notification.StateFailedTemp:
+		return "failed_temp"
+	case notification.StateFailedPerm:
+		return "failed"
+	case notification.StateBundled:
+		return "bundled"
+	}
+	return "unknown"
+}
@@ -0,0 +1,101 @@
+package eventstream
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber before events are dropped.
+const subscriberBuffer = 256
+
+// Broker fans out engine events to subscribers.
+//
+// Publishing never blocks; if a subscriber is not keeping up, events for that subscriber are dropped.
+// A nil *Broker is valid and discards all events.
+type Broker struct {
+	mx   sync.Mutex
+	subs map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	ch      chan Event
+	dropped int
+}
+
+// NewBroker creates a new Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*subscriber]struct{})}
+}
+
+// Publish sends e to all current subscribers. If e.Time is zero, it is set to the current time.
+func (b *Broker) Publish(e Event) {
+	if b == nil {
+		return
+	}
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	if len(b.subs) == 0 {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for s := range b.subs {
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped++
+		}
+	}
+}
+
+// Active returns true if there is at least one subscriber.
+//
+// It can be used to skip building events that would be discarded.
+func (b *Broker) Active() bool {
+	if b == nil {
+		return false
+	}
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return len(b.subs) > 0
+}
+
+// Subscribe returns a channel of events and a func to end the subscription.
+//
+// The returned channel is closed when the subscription ends.
+func (b *Broker) Subscribe() (<-chan Event, func()) {
+	s := &subscriber{ch: make(chan Event, subscriberBuffer)}
+
+	b.mx.Lock()
+	b.subs[s] = struct{}{}
+	b.mx.Unlock()
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			b.mx.Lock()
+			delete(b.subs, s)
+			b.mx.Unlock()
+			close(s.ch)
+		})
+	}
+}
+
+// takeDropped returns and resets the number of events dropped for the subscription owning ch.
+func (b *Broker) takeDropped(ch <-chan Event) int {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	for s := range b.subs {
+		if s.ch == ch {
+			n := s.dropped
+			s.dropped = 0
+			return n
+		}
+	}
+	return 0
+}
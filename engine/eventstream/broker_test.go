@@ -0,0 +1,47 @@
+package eventstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker(t *testing.T) {
+	b := NewBroker()
+	assert.False(t, b.Active())
+
+	// no subscribers, should not block
+	b.Publish(Event{Type: TypeCycleStart})
+
+	ch, unsub := b.Subscribe()
+	assert.True(t, b.Active())
+
+	b.Publish(Event{Type: TypeModule, Module: "EscalationManager"})
+	e := <-ch
+	assert.Equal(t, TypeModule, e.Type)
+	assert.Equal(t, "EscalationManager", e.Module)
+	assert.False(t, e.Time.IsZero(), "time should be set")
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		b.Publish(Event{Type: TypeMessageSent})
+	}
+	assert.Equal(t, 5, b.takeDropped(ch))
+	assert.Equal(t, 0, b.takeDropped(ch))
+
+	unsub()
+	unsub() // should be safe to call twice
+	assert.False(t, b.Active())
+
+	n := 0
+	for range ch {
+		n++
+	}
+	require.Equal(t, subscriberBuffer, n)
+}
+
+func TestBroker_Nil(t *testing.T) {
+	var b *Broker
+	assert.False(t, b.Active())
+	b.Publish(Event{Type: TypeError})
+}
@@ -0,0 +1,33 @@
+package eventstream
+
+import "time"
+
+// Type identifies the kind of engine event.
+type Type string
+
+// Engine event types.
+const (
+	TypeCycleStart  Type = "cycle_start"
+	TypeCycleEnd    Type = "cycle_end"
+	TypeModule      Type = "module"
+	TypeMessageSent Type = "message_sent"
+	TypeError       Type = "error"
+)
+
+// Event is a single engine event.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type Type      `json:"type"`
+
+	// CycleID is the ID of the engine cycle the event occurred in, if any.
+	CycleID string `json:"cycleID,omitempty"`
+
+	// Module is the name of the engine module (e.g., EscalationManager) the event relates to, if any.
+	Module string `json:"module,omitempty"`
+
+	// DurationMS is the elapsed time for cycle_end and module events.
+	DurationMS float64 `json:"durationMS,omitempty"`
+
+	Message string            `json:"message,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
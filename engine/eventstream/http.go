@@ -0,0 +1,85 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+)
+
+// keepAliveInterval is how often a comment is sent to keep idle connections open through proxies.
+const keepAliveInterval = 15 * time.Second
+
+// ServeHTTP streams engine events as Server-Sent Events. Only admins may subscribe.
+//
+// The optional `type` query parameter (comma-separated) limits the stream to the given event types.
+// Only events from this instance are streamed.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var filter map[Type]bool
+	if t := req.FormValue("type"); t != "" {
+		filter = make(map[Type]bool)
+		for _, name := range strings.Split(t, ",") {
+			filter[Type(strings.TrimSpace(name))] = true
+		}
+	}
+
+	events, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	// requests are subject to server timeouts, so clients should expect to reconnect
+	fmt.Fprint(w, "retry: 1000\n: connected\n\n")
+	flusher.Flush()
+
+	t := time.NewTicker(keepAliveInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if n := b.takeDropped(events); n > 0 {
+				fmt.Fprintf(w, ": dropped %d events\n\n", n)
+			} else {
+				fmt.Fprint(w, ": keep-alive\n\n")
+			}
+			flusher.Flush()
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if filter != nil && !filter[e.Type] {
+				continue
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -55,8 +55,8 @@ func (db *DB) processAll(ctx context.Context) error {
 		if isNew {
 			// Store contexts with alert info for each alert that was newly-created.
 			newAlertCtx = append(newAlertCtx, log.WithFields(row.Context(ctx), log.Fields{
-				"AlertID":   a.ID,
-				"ServiceID": a.ServiceID,
+				log.FieldAlertID:   a.ID,
+				log.FieldServiceID: a.ServiceID,
 			}))
 		}
 	}
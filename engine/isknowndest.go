@@ -19,6 +19,13 @@ func (n *Engine) IsKnownDest(ctx context.Context, destType notification.DestType
 	if errors.Is(err, sql.ErrNoRows) {
 		err = nil
 	}
+	if err == nil && !isKnown && destType == notification.DestTypeSMS {
+		// may be a shared team phone
+		err = n.b.validNC.QueryRowContext(ctx, notification.DestTypeChanSMS.NCType(), destValue).Scan(&isKnown)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil
+		}
+	}
 
 	return isKnown, err
 }
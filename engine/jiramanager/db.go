@@ -0,0 +1,101 @@
+package jiramanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/util"
+)
+
+// DB creates and updates Jira issues for alerts on configured services.
+type DB struct {
+	lock *processinglock.Lock
+
+	client *jira.Client
+
+	pendingCreate *sql.Stmt
+	pendingClose  *sql.Stmt
+	setIssue      *sql.Stmt
+	createFailed  *sql.Stmt
+	setClosed     *sql.Stmt
+	closeFailed   *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.JiraManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeJira,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		client: &jira.Client{},
+
+		// Only alerts created after the service was configured are considered, so enabling
+		// the integration doesn't create issues for every open alert.
+		pendingCreate: p.P(`
+			select
+				a.id, a.summary, a.details, a.service_id, svc.name,
+				cfg.project_key, cfg.issue_type, cfg.summary_template, cfg.description_template, cfg.fields
+			from alerts a
+			join service_jira_configs cfg on cfg.service_id = a.service_id
+			join services svc on svc.id = a.service_id
+			left join alert_jira_issues iss on iss.alert_id = a.id
+			where
+				a.status != 'closed' and
+				a.created_at >= cfg.created_at and
+				(
+					iss.alert_id isnull or
+					(iss.issue_key isnull and iss.attempts < $1 and iss.last_attempt < now() - $2::interval)
+				)
+			order by a.id
+			limit 10
+		`),
+		pendingClose: p.P(`
+			select iss.alert_id, iss.issue_key, cfg.close_transition
+			from alert_jira_issues iss
+			join alerts a on a.id = iss.alert_id and a.status = 'closed'
+			join service_jira_configs cfg on cfg.service_id = a.service_id
+			where
+				iss.issue_key notnull and
+				not iss.closed and
+				iss.attempts < $1 and
+				(iss.last_attempt isnull or iss.last_error = '' or iss.last_attempt < now() - $2::interval)
+			order by iss.alert_id
+			limit 10
+		`),
+		setIssue: p.P(`
+			insert into alert_jira_issues (alert_id, issue_key, last_attempt)
+			values ($1, $2, now())
+			on conflict (alert_id) do update
+			set issue_key = $2, attempts = 0, last_attempt = now(), last_error = ''
+		`),
+		createFailed: p.P(`
+			insert into alert_jira_issues (alert_id, attempts, last_attempt, last_error)
+			values ($1, 1, now(), $2)
+			on conflict (alert_id) do update
+			set attempts = alert_jira_issues.attempts + 1, last_attempt = now(), last_error = $2
+		`),
+		setClosed: p.P(`
+			update alert_jira_issues
+			set closed = true, attempts = 0, last_attempt = now(), last_error = ''
+			where alert_id = $1
+		`),
+		closeFailed: p.P(`
+			update alert_jira_issues
+			set attempts = attempts + 1, last_attempt = now(), last_error = $2
+			where alert_id = $1
+		`),
+	}, p.Err
+}
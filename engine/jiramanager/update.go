@@ -0,0 +1,217 @@
+package jiramanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// maxAttempts is the number of times a failed request is attempted before giving up.
+	maxAttempts = 10
+
+	// retryDelay is the minimum time between attempts for a failed request.
+	retryDelay = 5 * time.Minute
+
+	// minRemaining is the time that must remain before the module deadline to start another request,
+	// so results are always committed, rather than rolled back after the issue was created.
+	minRemaining = 12 * time.Second
+)
+
+// UpdateAll will create Jira issues for new alerts and update issues for closed alerts.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Jira.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Processing Jira issues.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "jira manager", tx)
+
+	var delay pgtype.Interval
+	delay.Microseconds = retryDelay.Microseconds()
+	delay.Status = pgtype.Present
+
+	err = db.createIssues(ctx, tx, &delay)
+	if err != nil {
+		return fmt.Errorf("create issues: %w", err)
+	}
+
+	err = db.closeIssues(ctx, tx, &delay)
+	if err != nil {
+		return fmt.Errorf("close issues: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func hasTime(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > minRemaining
+}
+
+type pendingIssue struct {
+	Data jira.TemplateData
+	Cfg  jira.ServiceConfig
+}
+
+func (db *DB) createIssues(ctx context.Context, tx *sql.Tx, delay *pgtype.Interval) error {
+	rows, err := tx.StmtContext(ctx, db.pendingCreate).QueryContext(ctx, maxAttempts, delay)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var pending []pendingIssue
+	for rows.Next() {
+		var p pendingIssue
+		var fields json.RawMessage
+		err = rows.Scan(
+			&p.Data.AlertID, &p.Data.Summary, &p.Data.Details, &p.Data.ServiceID, &p.Data.ServiceName,
+			&p.Cfg.ProjectKey, &p.Cfg.IssueType, &p.Cfg.SummaryTemplate, &p.Cfg.DescriptionTemplate, &fields,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		err = json.Unmarshal(fields, &p.Cfg.Fields)
+		if err != nil {
+			return fmt.Errorf("parse fields: %w", err)
+		}
+		p.Cfg.ServiceID = p.Data.ServiceID
+		pending = append(pending, p)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	for _, p := range pending {
+		if !hasTime(ctx) {
+			break
+		}
+		p.Data.URL = cfg.CallbackURL("/alerts/" + strconv.Itoa(p.Data.AlertID))
+		ctx := log.WithFields(ctx, log.Fields{
+			log.FieldAlertID:   p.Data.AlertID,
+			log.FieldServiceID: p.Data.ServiceID,
+		})
+
+		key, err := db.createIssue(ctx, p)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("create Jira issue: %w", err))
+			_, err = tx.StmtContext(ctx, db.createFailed).ExecContext(ctx, p.Data.AlertID, err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setIssue).ExecContext(ctx, p.Data.AlertID, key)
+		if err != nil {
+			return fmt.Errorf("save issue key: %w", err)
+		}
+		log.Logf(log.WithField(ctx, "JiraIssue", key), "Jira issue created.")
+	}
+
+	return nil
+}
+
+func (db *DB) createIssue(ctx context.Context, p pendingIssue) (string, error) {
+	fields, err := p.Cfg.IssueFields(p.Data)
+	if err != nil {
+		return "", fmt.Errorf("render fields: %w", err)
+	}
+
+	key, err := db.client.CreateIssue(ctx, fields)
+	if err != nil {
+		return "", err
+	}
+
+	err = db.client.AddRemoteLink(ctx, key, p.Data.URL, fmt.Sprintf("GoAlert Alert #%d", p.Data.AlertID))
+	if err != nil {
+		// the issue exists at this point, so just log the failure
+		log.Log(ctx, fmt.Errorf("link Jira issue %s: %w", key, err))
+	}
+
+	return key, nil
+}
+
+func (db *DB) closeIssues(ctx context.Context, tx *sql.Tx, delay *pgtype.Interval) error {
+	rows, err := tx.StmtContext(ctx, db.pendingClose).QueryContext(ctx, maxAttempts, delay)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type closeIssue struct {
+		AlertID    int
+		Key        string
+		Transition string
+	}
+	var pending []closeIssue
+	for rows.Next() {
+		var c closeIssue
+		err = rows.Scan(&c.AlertID, &c.Key, &c.Transition)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		pending = append(pending, c)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range pending {
+		if !hasTime(ctx) {
+			break
+		}
+		ctx := log.WithFields(ctx, log.Fields{
+			log.FieldAlertID: c.AlertID,
+			"JiraIssue":      c.Key,
+		})
+
+		// transition first, since retrying it is a no-op once applied
+		var err error
+		if c.Transition != "" {
+			err = db.client.TransitionIssue(ctx, c.Key, c.Transition)
+		}
+		if err == nil {
+			err = db.client.AddComment(ctx, c.Key, "The GoAlert alert was closed.")
+		}
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("update Jira issue for closed alert: %w", err))
+			_, err = tx.StmtContext(ctx, db.closeFailed).ExecContext(ctx, c.AlertID, err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setClosed).ExecContext(ctx, c.AlertID)
+		if err != nil {
+			return fmt.Errorf("mark closed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package jobqueue
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// State is the current state of a job.
+type State string
+
+// Job states.
+const (
+	// StatePending indicates the job is waiting to run at RunAt.
+	StatePending State = "pending"
+
+	// StateRunning indicates the job is currently being run by an engine instance.
+	StateRunning State = "running"
+
+	// StateCompleted indicates the job finished successfully; periodic jobs never complete.
+	StateCompleted State = "completed"
+
+	// StateDead indicates the job failed MaxAttempts times and will not be retried automatically.
+	StateDead State = "dead"
+)
+
+// A Job is a unit of background work run by the engine.
+type Job struct {
+	ID   int
+	Kind string
+	Args json.RawMessage
+
+	State State
+
+	// Interval is non-zero for periodic jobs.
+	Interval time.Duration
+
+	Attempts    int
+	MaxAttempts int
+
+	RunAt      time.Time
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	LastError  string
+}
+
+// IsPeriodic returns true if the job is rescheduled after each run.
+func (j Job) IsPeriodic() bool { return j.Interval > 0 }
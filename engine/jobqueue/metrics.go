@@ -0,0 +1,29 @@
+package jobqueue
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricJobTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "job_total",
+		Help:      "Total number of job attempts by kind and result.",
+	}, []string{"kind", "result"})
+
+	metricJobDeadTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "job_dead_total",
+		Help:      "Total number of jobs that failed all attempts.",
+	}, []string{"kind"})
+
+	metricJobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "job_duration_seconds",
+		Help:      "Job duration in seconds by kind.",
+	}, []string{"kind"})
+)
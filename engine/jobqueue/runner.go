@@ -0,0 +1,309 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/util/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultTimeout     = 30 * time.Second
+
+	// pollInterval is how often the runner checks for ready jobs when idle.
+	pollInterval = time.Second
+
+	// leaseGrace is added to the job timeout before a running job is considered abandoned
+	// (e.g., the instance running it crashed) and may be claimed by another instance.
+	leaseGrace = 30 * time.Second
+
+	// maxBackoff is the maximum delay before retrying a failed job.
+	maxBackoff = 10 * time.Minute
+
+	pruneKind = "JobQueue.Prune"
+)
+
+var tracer = otel.Tracer("github.com/target/goalert/engine/jobqueue")
+
+// ErrNotReady can be returned (or wrapped) by a WorkFunc to reschedule the job without counting the attempt,
+// e.g., if a processing lock is not available.
+var ErrNotReady = errors.New("not ready")
+
+// WorkFunc performs the work for a single job.
+type WorkFunc func(ctx context.Context, args json.RawMessage) error
+
+// A Worker handles all jobs of a particular kind.
+type Worker struct {
+	Kind string
+
+	// Interval, if set, will run the job periodically, Interval after each run finishes.
+	Interval time.Duration
+
+	// MaxAttempts is the number of times a failing job is attempted before it is marked dead, defaults to 5.
+	//
+	// Periodic jobs are never marked dead, instead they return to their normal schedule.
+	MaxAttempts int
+
+	// Timeout is the maximum duration of a single attempt, defaults to 30 seconds.
+	Timeout time.Duration
+
+	Work WorkFunc
+}
+
+func (w Worker) maxAttempts() int {
+	if w.MaxAttempts == 0 {
+		return defaultMaxAttempts
+	}
+	return w.MaxAttempts
+}
+
+func (w Worker) timeout() time.Duration {
+	if w.Timeout == 0 {
+		return defaultTimeout
+	}
+	return w.Timeout
+}
+
+// backoff returns the delay before the next attempt of a job that has failed the given number of times.
+func backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 10 {
+		return maxBackoff
+	}
+
+	d := time.Duration(1<<uint(attempts-1)) * 5 * time.Second
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+// Runner claims and runs jobs, one at a time, for registered workers.
+type Runner struct {
+	s       *Store
+	workers map[string]Worker
+	kinds   []string
+
+	// lease is the duration a claimed job is reserved for, it must exceed the longest worker timeout.
+	lease time.Duration
+
+	// mx is held while a job is running, so Wait can block until the runner is idle.
+	mx       sync.Mutex
+	initDone bool
+
+	paused func() bool
+}
+
+// NewRunner creates a new Runner. If paused is non-nil, no new jobs will be claimed while it returns true.
+//
+// A built-in periodic job is registered to prune old completed and dead jobs.
+func NewRunner(s *Store, paused func() bool) *Runner {
+	r := &Runner{
+		s:       s,
+		workers: make(map[string]Worker),
+		paused:  paused,
+	}
+	r.Register(Worker{
+		Kind:     pruneKind,
+		Interval: time.Hour,
+		Work: func(ctx context.Context, _ json.RawMessage) error {
+			n, err := s.pruneJobs(ctx)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				log.Debugf(ctx, "Pruned %d old jobs.", n)
+			}
+			return nil
+		},
+	})
+
+	return r
+}
+
+// Register will add a worker. It must be called before Run.
+func (r *Runner) Register(w Worker) {
+	if w.Kind == "" || w.Work == nil {
+		panic("jobqueue: worker Kind and Work are required")
+	}
+	if _, ok := r.workers[w.Kind]; ok {
+		panic(fmt.Sprintf("jobqueue: worker '%s' already registered", w.Kind))
+	}
+
+	r.workers[w.Kind] = w
+	r.kinds = append(r.kinds, w.Kind)
+	sort.Strings(r.kinds)
+	if l := w.timeout() + leaseGrace; l > r.lease {
+		r.lease = l
+	}
+}
+
+// Kinds returns the kinds of all registered workers, sorted.
+func (r *Runner) Kinds() []string { return append([]string(nil), r.kinds...) }
+
+// Wait will block until any in-progress job finishes.
+func (r *Runner) Wait() {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+}
+
+// initPeriodic will create or update the job for each periodic worker, it must be called with mx held.
+func (r *Runner) initPeriodic(ctx context.Context) error {
+	if r.initDone {
+		return nil
+	}
+
+	for _, w := range r.workers {
+		if w.Interval == 0 {
+			continue
+		}
+		err := r.s.ensurePeriodic(ctx, w)
+		if err != nil {
+			return fmt.Errorf("register periodic job %s: %w", w.Kind, err)
+		}
+	}
+	r.initDone = true
+
+	return nil
+}
+
+// Run will process jobs until the context is canceled.
+func (r *Runner) Run(ctx context.Context) error {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+	for {
+		// drain all ready jobs before waiting
+		for r.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// RunAll will run all periodic jobs immediately, along with any other ready jobs, returning once they have finished.
+func (r *Runner) RunAll(ctx context.Context) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	err := r.initPeriodic(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.s.expedite(ctx, r.kinds)
+	if err != nil {
+		return fmt.Errorf("expedite periodic jobs: %w", err)
+	}
+
+	for r.runNextLocked(ctx) {
+	}
+
+	return nil
+}
+
+// runNext runs the next ready job, returning false if there was none.
+func (r *Runner) runNext(ctx context.Context) bool {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.runNextLocked(ctx)
+}
+
+func (r *Runner) runNextLocked(ctx context.Context) bool {
+	if ctx.Err() != nil || (r.paused != nil && r.paused()) {
+		return false
+	}
+
+	err := r.initPeriodic(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Log(ctx, err)
+		}
+		return false
+	}
+
+	j, err := r.s.claimNext(ctx, r.kinds, r.lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Log(ctx, fmt.Errorf("claim job: %w", err))
+		}
+		return false
+	}
+	if j == nil {
+		return false
+	}
+
+	r.run(ctx, r.workers[j.Kind], j)
+	return true
+}
+
+func (r *Runner) run(ctx context.Context, w Worker, j *Job) {
+	ctx = log.WithFields(ctx, log.Fields{"JobID": j.ID, "JobKind": j.Kind, "Attempt": j.Attempts})
+
+	spanCtx, span := tracer.Start(ctx, j.Kind, trace.WithAttributes(
+		attribute.Int("job.id", j.ID),
+		attribute.Int("job.attempt", j.Attempts),
+	))
+	start := time.Now()
+	err := r.work(spanCtx, w, j)
+	metricJobDuration.WithLabelValues(j.Kind).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, ErrNotReady) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	// use a new context so the result is recorded even if ctx was canceled
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	switch {
+	case err == nil:
+		metricJobTotal.WithLabelValues(j.Kind, "success").Inc()
+		err = r.s.markComplete(saveCtx, j.ID)
+	case errors.Is(err, ErrNotReady), ctx.Err() != nil:
+		metricJobTotal.WithLabelValues(j.Kind, "not_ready").Inc()
+		err = r.s.releaseJob(saveCtx, j.ID, pollInterval)
+	default:
+		metricJobTotal.WithLabelValues(j.Kind, "error").Inc()
+		log.Log(ctx, fmt.Errorf("job %s failed: %w", j.Kind, err))
+		if !j.IsPeriodic() && j.Attempts >= j.MaxAttempts {
+			metricJobDeadTotal.WithLabelValues(j.Kind).Inc()
+		}
+		err = r.s.markFailed(saveCtx, j.ID, err, backoff(j.Attempts))
+	}
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("save job result: %w", err))
+	}
+}
+
+func (r *Runner) work(ctx context.Context, w Worker, j *Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout())
+	defer cancel()
+
+	defer func() {
+		p := recover()
+		if p == nil {
+			return
+		}
+		err = fmt.Errorf("PANIC: %v", p)
+	}()
+
+	return w.Work(ctx, j.Args)
+}
@@ -0,0 +1,17 @@
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, backoff(0))
+	assert.Equal(t, 5*time.Second, backoff(1))
+	assert.Equal(t, 10*time.Second, backoff(2))
+	assert.Equal(t, 40*time.Second, backoff(4))
+	assert.Equal(t, maxBackoff, backoff(8))
+	assert.Equal(t, maxBackoff, backoff(100))
+}
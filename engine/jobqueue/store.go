@@ -0,0 +1,307 @@
+package jobqueue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages the engine_jobs table.
+type Store struct {
+	db *sql.DB
+
+	enqueue      *sql.Stmt
+	findMany     *sql.Stmt
+	retry        *sql.Stmt
+	ensurePeriod *sql.Stmt
+	claim        *sql.Stmt
+	complete     *sql.Stmt
+	fail         *sql.Stmt
+	release      *sql.Stmt
+	prune        *sql.Stmt
+	expediteAll  *sql.Stmt
+}
+
+// SearchOptions filters the result of FindMany.
+type SearchOptions struct {
+	// State, if set, limits results to jobs in the given state.
+	State State
+
+	// Kind, if set, limits results to jobs of the given kind.
+	Kind string
+
+	// Limit is the maximum number of results, defaults to 50.
+	Limit int
+}
+
+const jobColumns = `
+	id, kind, args, state, coalesce(extract(epoch from periodic_interval), 0),
+	attempts, max_attempts, run_at, created_at, started_at, finished_at, coalesce(last_error, '')
+`
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		enqueue: p.P(`
+			insert into engine_jobs (kind, args, max_attempts, run_at)
+			values ($1, $2, $3, now() + make_interval(secs => $4))
+			returning id
+		`),
+		findMany: p.P(`
+			select ` + jobColumns + `
+			from engine_jobs
+			where
+				($1 = '' or state = $1::enum_engine_job_state) and
+				($2 = '' or kind = $2)
+			order by coalesce(started_at, run_at) desc, id desc
+			limit $3
+		`),
+		retry: p.P(`
+			update engine_jobs
+			set state = 'pending', attempts = 0, run_at = now(), finished_at = null
+			where id = $1 and state in ('pending', 'dead')
+		`),
+		ensurePeriod: p.P(`
+			insert into engine_jobs (kind, periodic_interval, max_attempts)
+			values ($1, make_interval(secs => $2), $3)
+			on conflict (kind) where periodic_interval notnull
+			do update set periodic_interval = excluded.periodic_interval, max_attempts = excluded.max_attempts
+		`),
+		claim: p.P(`
+			update engine_jobs
+			set
+				state = 'running',
+				attempts = attempts + 1,
+				started_at = now(),
+				lease_expires_at = now() + make_interval(secs => $2)
+			where id = (
+				select id from engine_jobs
+				where
+					kind = any($1) and (
+						(state = 'pending' and run_at <= now()) or
+						(state = 'running' and lease_expires_at < now())
+					)
+				order by run_at
+				limit 1
+				for update skip locked
+			)
+			returning ` + jobColumns + `
+		`),
+		complete: p.P(`
+			update engine_jobs
+			set
+				state = case when periodic_interval notnull then 'pending' else 'completed' end::enum_engine_job_state,
+				run_at = case when periodic_interval notnull then now() + periodic_interval else run_at end,
+				attempts = 0,
+				finished_at = now(),
+				lease_expires_at = null,
+				last_error = null
+			where id = $1 and state = 'running'
+		`),
+		fail: p.P(`
+			update engine_jobs
+			set
+				state = case
+					when attempts >= max_attempts and periodic_interval isnull then 'dead'
+					else 'pending'
+				end::enum_engine_job_state,
+				run_at = case
+					when attempts >= max_attempts and periodic_interval notnull then now() + periodic_interval
+					else now() + make_interval(secs => $3)
+				end,
+				attempts = case
+					when attempts >= max_attempts and periodic_interval notnull then 0
+					else attempts
+				end,
+				finished_at = now(),
+				lease_expires_at = null,
+				last_error = $2
+			where id = $1 and state = 'running'
+		`),
+		release: p.P(`
+			update engine_jobs
+			set state = 'pending', attempts = attempts - 1, lease_expires_at = null, run_at = now() + make_interval(secs => $2)
+			where id = $1 and state = 'running'
+		`),
+		expediteAll: p.P(`
+			update engine_jobs
+			set run_at = now()
+			where kind = any($1) and periodic_interval notnull and state = 'pending' and run_at > now()
+		`),
+		prune: p.P(`
+			delete from engine_jobs
+			where id = any(
+				select id from engine_jobs
+				where
+					(state = 'completed' and finished_at < now() - '7 days'::interval) or
+					(state = 'dead' and finished_at < now() - '30 days'::interval)
+				limit 1000
+				for update skip locked
+			)
+		`),
+	}, p.Err
+}
+
+// Enqueue will add an ad-hoc job of the given kind to run after delay. Args are encoded as JSON.
+//
+// If tx is non-nil, the job is only created if tx is committed.
+func (s *Store) Enqueue(ctx context.Context, tx *sql.Tx, kind string, args interface{}, delay time.Duration) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return 0, err
+	}
+	err = validate.Text("Kind", kind, 1, 255)
+	if err != nil {
+		return 0, err
+	}
+	if args == nil {
+		args = struct{}{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := s.enqueue
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	var id int
+	err = stmt.QueryRowContext(ctx, kind, data, defaultMaxAttempts, delay.Seconds()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func scanJob(scan func(...interface{}) error) (*Job, error) {
+	var j Job
+	var interval float64
+	var started, finished sql.NullTime
+	err := scan(&j.ID, &j.Kind, &j.Args, &j.State, &interval, &j.Attempts, &j.MaxAttempts, &j.RunAt, &j.CreatedAt, &started, &finished, &j.LastError)
+	if err != nil {
+		return nil, err
+	}
+	j.Interval = time.Duration(interval * float64(time.Second))
+	j.StartedAt = started.Time
+	j.FinishedAt = finished.Time
+
+	return &j, nil
+}
+
+// FindMany returns jobs matching the search options, most recently run first.
+func (s *Store) FindMany(ctx context.Context, opts SearchOptions) ([]Job, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 50
+	}
+	err = validate.Many(
+		validate.Range("Limit", opts.Limit, 1, 1000),
+		validate.OneOf("State", opts.State, "", StatePending, StateRunning, StateCompleted, StateDead),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMany.QueryContext(ctx, opts.State, opts.Kind, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Job
+	for rows.Next() {
+		j, err := scanJob(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *j)
+	}
+
+	return result, rows.Err()
+}
+
+// Retry will schedule a dead or pending job to run immediately, resetting its attempts.
+func (s *Store) Retry(ctx context.Context, id int) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.retry.ExecContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("ID", "job not found, or is currently running")
+	}
+
+	return nil
+}
+
+func (s *Store) ensurePeriodic(ctx context.Context, w Worker) error {
+	_, err := s.ensurePeriod.ExecContext(ctx, w.Kind, w.Interval.Seconds(), w.maxAttempts())
+	return err
+}
+
+// claimNext returns the next ready job of one of the given kinds, or nil if there are none.
+func (s *Store) claimNext(ctx context.Context, kinds []string, lease time.Duration) (*Job, error) {
+	j, err := scanJob(s.claim.QueryRowContext(ctx, sqlutil.StringArray(kinds), lease.Seconds()).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return j, err
+}
+
+func (s *Store) markComplete(ctx context.Context, id int) error {
+	_, err := s.complete.ExecContext(ctx, id)
+	return err
+}
+
+func (s *Store) markFailed(ctx context.Context, id int, jobErr error, backoff time.Duration) error {
+	_, err := s.fail.ExecContext(ctx, id, jobErr.Error(), backoff.Seconds())
+	return err
+}
+
+// releaseJob returns a running job to pending without counting the attempt.
+func (s *Store) releaseJob(ctx context.Context, id int, delay time.Duration) error {
+	_, err := s.release.ExecContext(ctx, id, delay.Seconds())
+	return err
+}
+
+// expedite will schedule all pending periodic jobs of the given kinds to run now.
+func (s *Store) expedite(ctx context.Context, kinds []string) error {
+	_, err := s.expediteAll.ExecContext(ctx, sqlutil.StringArray(kinds))
+	return err
+}
+
+func (s *Store) pruneJobs(ctx context.Context) (int64, error) {
+	res, err := s.prune.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
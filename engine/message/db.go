@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/config"
@@ -25,7 +27,8 @@ import (
 
 // DB implements a priority message sender using Postgres.
 type DB struct {
-	lock *processinglock.Lock
+	lock       *processinglock.Lock
+	sharedLock *processinglock.Lock
 
 	pausable lifecycle.Pausable
 
@@ -33,44 +36,47 @@ type DB struct {
 
 	setSending *sql.Stmt
 
-	lockStmt    *sql.Stmt
-	messages    *sql.Stmt
-	currentTime *sql.Stmt
-	retryReset  *sql.Stmt
-	retryClear  *sql.Stmt
+	beginRequest    *sql.Stmt
+	completeRequest *sql.Stmt
+	unknownRequests *sql.Stmt
+	resolveRequests *sql.Stmt
 
-	sendDeadlineExpired *sql.Stmt
-
-	failDisabledCM *sql.Stmt
-	alertlogstore  *alertlog.Store
-
-	failSMSVoice *sql.Stmt
+	alertlogstore *alertlog.Store
 
 	sentByCMType *sql.Stmt
 
-	cleanupStatusUpdateOptOut *sql.Stmt
-
 	tempFail     *sql.Stmt
 	permFail     *sql.Stmt
 	updateStatus *sql.Stmt
 
 	advLock        *sql.Stmt
+	advLockShared  *sql.Stmt
 	advLockCleanup *sql.Stmt
 
-	createAlertBundle *sql.Stmt
-	bundleMessages    *sql.Stmt
-
-	deleteAny *sql.Stmt
+	partitions int
+	sent       map[int]*sentCache
+}
 
-	lastSent     time.Time
-	sentMessages map[string]Message
+// sentCache holds recently sent messages for a single partition, so only
+// newly sent messages need to be fetched each cycle.
+type sentCache struct {
+	lastSent time.Time
+	messages map[string]Message
 }
 
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycle.Pausable) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeMessage,
-		Version: 9,
+		Version: 10,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sharedLock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeMessage,
+		Version: 10,
+		Shared:  true,
 	})
 	if err != nil {
 		return nil, err
@@ -122,6 +128,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 	}
 	return &DB{
 		lock:          lock,
+		sharedLock:    sharedLock,
 		pausable:      pausable,
 		alertlogstore: a,
 
@@ -129,9 +136,10 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 		tempFail:     tempFail,
 		permFail:     permFail,
 
-		sentMessages: make(map[string]Message),
+		sent: make(map[int]*sentCache),
 
-		advLock: p.P(`select pg_advisory_lock($1)`),
+		advLock:       p.P(`select pg_advisory_lock($1)`),
+		advLockShared: p.P(`select pg_advisory_lock_shared($1)`),
 		advLockCleanup: p.P(`
 			select pg_terminate_backend(lock.pid)
 			from pg_locks lock
@@ -143,7 +151,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				act.pid = lock.pid and
 				act.state = 'idle' and
 				act.state_change < now() - '1 minute'::interval
-			where objid = $1 and locktype = 'advisory' and granted
+			where (objid = $1 or objid between $2 and $3) and locktype = 'advisory' and granted
 		`),
 
 		stuckMessages: p.P(`
@@ -173,17 +181,6 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			where msg.sent_at > $1 and cm.type = $2
 		`),
 
-		cleanupStatusUpdateOptOut: p.P(`
-			delete from outgoing_messages msg
-			using user_contact_methods cm
-			where
-				msg.message_type = 'alert_status_update' and
-				(
-					msg.last_status = 'pending' or
-					(msg.last_status = 'failed' and msg.next_retry_at notnull)
-				) and
-				not cm.enable_status_updates and cm.id = msg.contact_method_id
-		`),
 		setSending: p.P(`
 			update outgoing_messages
 			set
@@ -198,159 +195,56 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			where id = $1
 		`),
 
-		sendDeadlineExpired: p.P(`
-			update outgoing_messages
-			set
-				last_status = 'failed',
-				last_status_at = now(),
-				status_details = 'send deadline expired',
-				cycle_id = null,
-				next_retry_at = null
-			where
-				last_status = 'sending' and
-				sending_deadline <= now()
-		`),
-		retryReset: p.P(`
-			update outgoing_messages
-			set
-				last_status = 'pending',
-				status_details = '',
-				next_retry_at = null,
-				retry_count = retry_count + 1,
-				fired_at = null,
-				sent_at = null,
-				provider_msg_id = null,
-				provider_seq = 0
-			where
-				last_status = 'failed' and
-				now() > next_retry_at and
-				retry_count < 3
+		// outbox of provider requests, recorded before each request is made
+		beginRequest: p.P(`
+			insert into outgoing_message_requests (idempotency_key, message_id)
+			values ($1, $2)
 		`),
-		retryClear: p.P(`
-			update outgoing_messages
+		completeRequest: p.P(`
+			update outgoing_message_requests
 			set
-				next_retry_at = null,
-				cycle_id = null
-			where
-				last_status = 'failed' and
-				retry_count >= 3 and
-				(cycle_id notnull or next_retry_at notnull)
+				completed_at = now(),
+				provider_msg_id = $2
+			where idempotency_key = $1
 		`),
 
-		lockStmt:    p.P(`lock outgoing_messages in exclusive mode`),
-		currentTime: p.P(`select now()`),
-
-		failDisabledCM: p.P(`
-			with disabled as (
-				update outgoing_messages msg
-				set
-					last_status = 'failed',
-					last_status_at = now(),
-					status_details = 'contact method disabled',
-					cycle_id = null,
-					next_retry_at = null
-				from user_contact_methods cm
-				where
-					msg.last_status = 'pending' and
-					msg.message_type != 'verification_message' and
-					cm.id = msg.contact_method_id and
-					cm.disabled
-				returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
-			) select distinct msg_id, alert_id, user_id, cm_id from disabled where alert_id notnull
+		// requests left incomplete because the outcome was unknown
+		unknownRequests: p.P(`
+			select min(created_at)
+			from outgoing_message_requests
+			where message_id = $1 and completed_at isnull
 		`),
-
-		failSMSVoice: p.P(`
-			update outgoing_messages msg
+		resolveRequests: p.P(`
+			update outgoing_message_requests
 			set
-				last_status = 'failed',
-				last_status_at = now(),
-				status_details = 'SMS/Voice support not enabled by administrator',
-				cycle_id = null,
-				next_retry_at = null
-			from user_contact_methods cm
-			where
-				msg.last_status = 'pending' and
-				cm.type in ('SMS', 'VOICE') and
-				cm.id = msg.contact_method_id
-			returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
-		`),
-
-		createAlertBundle: p.P(`
-			insert into outgoing_messages (
-				id,
-				created_at,
-				message_type,
-				contact_method_id,
-				channel_id,
-				user_id,
-				service_id
-			) values (
-				$1, $2, 'alert_notification_bundle', $3, $4, $5, $6
-			)
-		`),
-
-		bundleMessages: p.P(`
-			update outgoing_messages
-			set
-				last_status = 'bundled',
-				last_status_at = now(),
-				status_details = $1,
-				cycle_id = null
-			where id = any($2::uuid[])
+				completed_at = now(),
+				provider_msg_id = $2
+			where message_id = $1 and completed_at isnull
 		`),
-
-		messages: p.P(`
-			select
-				msg.id,
-				msg.message_type,
-				cm.type,
-				chan.type,
-				coalesce(msg.contact_method_id, msg.channel_id),
-				coalesce(cm.value, chan.value),
-				msg.alert_id,
-				msg.alert_log_id,
-				msg.user_verification_code_id,
-				cm.user_id,
-				msg.service_id,
-				msg.created_at,
-				msg.sent_at,
-				msg.status_alert_ids,
-				msg.schedule_id
-			from outgoing_messages msg
-			left join user_contact_methods cm on cm.id = msg.contact_method_id
-			left join notification_channels chan on chan.id = msg.channel_id
-			where
-				sent_at >= $1 or
-				last_status = 'pending' and
-				(msg.contact_method_id isnull or msg.message_type = 'verification_message' or not cm.disabled)
-		`),
-
-		deleteAny: p.P(`delete from outgoing_messages where id = any($1)`),
 	}, p.Err
 }
 
-func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*queue, error) {
-	cutoff := now.Add(-maxThrottleDuration(PerCMThrottle, GlobalCMThrottle))
-	sentSince := db.lastSent
-	if sentSince.IsZero() {
-		sentSince = cutoff
+// sentCache returns the sent message cache for the given partition.
+func (db *DB) sentCache(partition int) *sentCache {
+	sent := db.sent[partition]
+	if sent == nil {
+		sent = &sentCache{messages: make(map[string]Message)}
+		db.sent[partition] = sent
 	}
 
-	rows, err := tx.StmtContext(ctx, db.messages).QueryContext(ctx, sentSince)
-	if err != nil {
-		return nil, errors.Wrap(err, "fetch outgoing messages")
-	}
-	defer rows.Close()
+	return sent
+}
 
-	result := make([]Message, 0, len(db.sentMessages))
+// scanMessages will scan the results of messagesQuery.
+func scanMessages(ctx context.Context, rows pgx.Rows) ([]Message, error) {
+	var result []Message
 	for rows.Next() {
 		var msg Message
-		var destID, destValue, verifyID, userID, serviceID, scheduleID sql.NullString
+		var destID, destValue, verifyID, userID, serviceID, scheduleID, requestID, locale sql.NullString
 		var dstType notification.ScannableDestType
-		var alertID, logID sql.NullInt64
-		var statusAlertIDs sqlutil.IntArray
+		var alertID, logID, digestMinutes sql.NullInt64
 		var createdAt, sentAt sql.NullTime
-		err = rows.Scan(
+		err := rows.Scan(
 			&msg.ID,
 			&msg.Type,
 			&dstType.CM,
@@ -364,8 +258,12 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 			&serviceID,
 			&createdAt,
 			&sentAt,
-			&statusAlertIDs,
+			&msg.StatusAlertIDs,
 			&scheduleID,
+			&digestMinutes,
+			&msg.Severity,
+			&requestID,
+			&locale,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row")
@@ -379,8 +277,10 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		msg.SentAt = sentAt.Time
 		msg.Dest.ID = destID.String
 		msg.Dest.Value = destValue.String
-		msg.StatusAlertIDs = statusAlertIDs
+		msg.Dest.Locale = locale.String
 		msg.ScheduleID = scheduleID.String
+		msg.RequestID = requestID.String
+		msg.DigestInterval = time.Duration(digestMinutes.Int64) * time.Minute
 
 		msg.Dest.Type = dstType.DestType()
 		if msg.Dest.Type == notification.DestTypeUnknown {
@@ -388,47 +288,56 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 			continue
 		}
 
+		result = append(result, msg)
+	}
+
+	return result, rows.Err()
+}
+
+// currentQueue will build the queue from fetched messages (the results of messagesQuery) and the sent cache. Any
+// required updates (e.g., bundling or deleting duplicates) are queued to b, which must be sent before the queue
+// is used.
+func (db *DB) currentQueue(ctx context.Context, b *pgx.Batch, fetched []Message, now time.Time, partition int) (*queue, error) {
+	sent := db.sentCache(partition)
+
+	cfg := config.FromContext(ctx)
+	perCM := perCMThrottle(cfg)
+	cutoff := now.Add(-maxThrottleDuration(perCM, GlobalCMThrottle))
+
+	result := make([]Message, 0, len(fetched)+len(sent.messages))
+	for _, msg := range fetched {
 		if !msg.SentAt.IsZero() {
 			// if the message was sent, just add it to the map
-			db.sentMessages[msg.ID] = msg
+			sent.messages[msg.ID] = msg
 			continue
 		}
 
 		result = append(result, msg)
 	}
 
-	for id, msg := range db.sentMessages {
+	for id, msg := range sent.messages {
 		if msg.SentAt.Before(cutoff) {
-			delete(db.sentMessages, id)
+			delete(sent.messages, id)
 			continue
 		}
 		result = append(result, msg)
 	}
-	db.lastSent = now
+	sent.lastSent = now
+
+	result = holdDigestMessages(result, now)
 
 	result, toDelete := dedupOnCallNotifications(result)
 	if len(toDelete) > 0 {
-		_, err = tx.StmtContext(ctx, db.deleteAny).ExecContext(ctx, sqlutil.UUIDArray(toDelete))
-		if err != nil {
-			return nil, fmt.Errorf("delete duplicate on-call notifications: %w", err)
-		}
+		b.Queue(deleteMessagesQuery, toDelete)
 	}
 
-	cfg := config.FromContext(ctx)
 	result, toDelete = dedupStatusMessages(result)
 	if len(toDelete) > 0 {
-		_, err = tx.StmtContext(ctx, db.deleteAny).ExecContext(ctx, sqlutil.UUIDArray(toDelete))
-		if err != nil {
-			return nil, fmt.Errorf("delete duplicate status updates: %w", err)
-		}
+		b.Queue(deleteMessagesQuery, toDelete)
 	}
 
-	result, err = dedupAlerts(result, func(parentID string, duplicateIDs []string) error {
-		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(duplicateIDs))
-		if err != nil {
-			return fmt.Errorf("bundle '%v' by pointing to '%s': %w", duplicateIDs, parentID, err)
-		}
-
+	result, err := dedupAlerts(result, func(parentID string, duplicateIDs []string) error {
+		b.Queue(bundleMessagesQuery, parentID, duplicateIDs)
 		return nil
 	})
 	if err != nil {
@@ -436,7 +345,7 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 	}
 
 	if cfg.General.DisableMessageBundles {
-		return newQueue(result, now), nil
+		return newPartitionQueue(result, now, db.partitions, perCM), nil
 	}
 
 	result, err = bundleAlertMessages(result, func(msg Message) (string, error) {
@@ -454,21 +363,18 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		}
 
 		newID := uuid.NewString()
-		_, err := tx.StmtContext(ctx, db.createAlertBundle).ExecContext(ctx, newID, msg.CreatedAt, cmID, chanID, userID, msg.ServiceID)
-		if err != nil {
-			return "", err
-		}
+		b.Queue(createAlertBundleQuery, newID, msg.CreatedAt, cmID, chanID, userID, msg.ServiceID)
 
 		return newID, nil
 	}, func(parentID string, ids []string) error {
-		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(ids))
-		return err
+		b.Queue(bundleMessagesQuery, parentID, ids)
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return newQueue(result, now), nil
+	return newPartitionQueue(result, now, db.partitions, perCM), nil
 }
 
 // UpdateMessageStatus will update the state of a message.
@@ -532,19 +438,24 @@ type SendFunc func(context.Context, *Message) (*notification.SendResult, error)
 // ErrAbort is returned when an early-abort is returned due to pause.
 var ErrAbort = errors.New("aborted due to pause")
 
+// FindSentFunc is used to check with the provider whether a request with an unknown outcome sent the message.
+// It returns nil if the sent message could not be identified, and notification.ErrStatusUnsupported if the
+// provider can't check.
+type FindSentFunc func(ctx context.Context, msg *Message, since time.Time) (*notification.SendResult, error)
+
 // StatusFunc is used to fetch the latest status of a message.
 type StatusFunc func(ctx context.Context, providerID notification.ProviderMessageID) (*notification.Status, notification.DestType, error)
 
 // SendMessages will send notifications using SendFunc.
-func (db *DB) SendMessages(ctx context.Context, send SendFunc, status StatusFunc) error {
-	err := db._SendMessages(ctx, send, status)
+func (db *DB) SendMessages(ctx context.Context, send SendFunc, find FindSentFunc, status StatusFunc) error {
+	err := db._SendMessages(ctx, send, find, status)
 	if db.pausable.IsPausing() {
 		return ErrAbort
 	}
 	return err
 }
 
-func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFunc) error {
+func (db *DB) _SendMessages(ctx context.Context, send SendFunc, find FindSentFunc, status StatusFunc) error {
 	err := permission.LimitCheckAny(ctx, permission.System)
 	if err != nil {
 		return err
@@ -562,7 +473,22 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		execCancel()
 	}()
 
-	res, err := db.advLockCleanup.ExecContext(execCtx, lock.GlobalMessageSending)
+	partitions := config.FromContext(ctx).General.MessageSendingPartitions
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	if db.partitions != partitions {
+		// cached sent messages are only valid for the partitioning they were fetched with
+		db.partitions = partitions
+		db.sent = make(map[int]*sentCache)
+	}
+
+	res, err := db.advLockCleanup.ExecContext(execCtx,
+		lock.GlobalMessageSending,
+		lock.MessageSendingPartition,
+		lock.MessageSendingPartition+uint32(partitions)-1,
+	)
 	if err != nil {
 		return errors.Wrap(err, "terminate stale backend locks")
 	}
@@ -571,41 +497,80 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		log.Log(execCtx, errors.Errorf("terminated %d stale backend instance(s) holding message sending lock", rowsCount))
 	}
 
-	cLock, err := db.lock.Conn(execCtx)
+	if partitions == 1 {
+		cLock, err := db.lock.Conn(execCtx)
+		if err != nil {
+			return errors.Wrap(err, "get DB conn")
+		}
+		defer cLock.Close()
+
+		_, err = cLock.Exec(execCtx, db.advLock, lock.GlobalMessageSending)
+		if err != nil {
+			return errors.Wrap(err, "acquire global sending advisory lock")
+		}
+		defer func() {
+			_, _ = cLock.ExecWithoutLock(log.FromContext(execCtx).BackgroundContext(), `select pg_advisory_unlock(4912)`)
+		}()
+
+		err = db.sendPartition(ctx, execCtx, cLock, send, find, 0)
+		if err != nil {
+			return err
+		}
+
+		return db.updateStuckMessages(ctx, status)
+	}
+
+	cLock, err := db.sharedLock.Conn(execCtx)
 	if err != nil {
 		return errors.Wrap(err, "get DB conn")
 	}
 	defer cLock.Close()
 
-	_, err = cLock.Exec(execCtx, db.advLock, lock.GlobalMessageSending)
+	// The global lock is held in shared mode by all partitioned senders, so that an instance
+	// that has not yet picked up the config change can't send at the same time.
+	_, err = cLock.Exec(execCtx, db.advLockShared, lock.GlobalMessageSending)
 	if err != nil {
-		return errors.Wrap(err, "acquire global sending advisory lock")
+		return errors.Wrap(err, "acquire shared global sending advisory lock")
 	}
 	defer func() {
-		_, _ = cLock.ExecWithoutLock(log.FromContext(execCtx).BackgroundContext(), `select pg_advisory_unlock(4912)`)
+		_, _ = cLock.ExecWithoutLock(log.FromContext(execCtx).BackgroundContext(), `select pg_advisory_unlock_shared(4912)`)
 	}()
 
-	tx, err := cLock.BeginTx(execCtx, nil)
-	if err != nil {
-		return errors.Wrap(err, "begin transaction")
-	}
-	defer sqlutil.Rollback(ctx, "engine: message: send", tx)
+	// Start at a random partition so that instances spread out, rather than
+	// all contending for the same one.
+	start := rand.Intn(partitions)
+	for i := 0; i < partitions; i++ {
+		partition := (start + i) % partitions
+		key := int64(lock.MessageSendingPartition) + int64(partition)
 
-	_, err = tx.Stmt(db.lockStmt).ExecContext(execCtx)
-	if err != nil {
-		return errors.Wrap(err, "acquire exclusive locks")
-	}
+		var gotLock bool
+		err = cLock.QueryRowWithoutLock(execCtx, `select pg_try_advisory_lock($1)`, []interface{}{key}, &gotLock)
+		if err != nil {
+			return errors.Wrap(err, "acquire partition sending advisory lock")
+		}
+		if !gotLock {
+			// another instance is sending this partition
+			continue
+		}
 
-	var t time.Time
-	err = tx.Stmt(db.currentTime).QueryRowContext(execCtx).Scan(&t)
-	if err != nil {
-		return errors.Wrap(err, "get current time")
+		err = db.sendPartition(ctx, execCtx, cLock, send, find, partition)
+		_, _ = cLock.ExecWithoutLock(log.FromContext(execCtx).BackgroundContext(), `select pg_advisory_unlock($1)`, key)
+		if err != nil {
+			return errors.Wrapf(err, "send partition %d", partition)
+		}
 	}
 
-	_, err = tx.Stmt(db.cleanupStatusUpdateOptOut).ExecContext(execCtx)
+	return db.updateStuckMessages(ctx, status)
+}
+
+// sendPartition will send all pending messages for a single partition. The caller
+// must hold the appropriate advisory lock for the partition.
+func (db *DB) sendPartition(ctx, execCtx context.Context, cLock *processinglock.Conn, send SendFunc, find FindSentFunc, partition int) error {
+	tx, err := cLock.BeginTx(execCtx, nil)
 	if err != nil {
-		return errors.Wrap(err, "clear disabled status updates")
+		return errors.Wrap(err, "begin transaction")
 	}
+	defer sqlutil.Rollback(ctx, "engine: message: send", tx)
 
 	type msgMeta struct {
 		MessageID string
@@ -614,23 +579,22 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		CMID      string
 	}
 
-	var msgs []msgMeta
-
-	// if twilio is disable, create an entry to notify the user
-	cfg := config.FromContext(ctx)
-	if !cfg.Twilio.Enable {
-		rows, err := tx.StmtContext(ctx, db.failSMSVoice).QueryContext(execCtx)
-		if err != nil {
-			return errors.Wrap(err, "check for failed message")
-		}
-		defer rows.Close()
+	type failoverMeta struct {
+		alertlog.NotificationFailoverMetaData
+		AlertID int
+		UserID  string
+		CMID    string
+	}
 
+	var msgs []msgMeta
+	var failovers []failoverMeta
+	scanFailed := func(rows pgx.Rows) error {
 		for rows.Next() {
 			var alertID sql.NullInt64
 			var msg msgMeta
-			err = rows.Scan(&msg.MessageID, &alertID, &msg.UserID, &msg.CMID)
+			err := rows.Scan(&msg.MessageID, &alertID, &msg.UserID, &msg.CMID)
 			if err != nil {
-				return errors.Wrap(err, "scan all failed messages")
+				return err
 			}
 			if !alertID.Valid {
 				continue
@@ -638,22 +602,79 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 			msg.AlertID = int(alertID.Int64)
 			msgs = append(msgs, msg)
 		}
+		return rows.Err()
 	}
 
-	// processes disabled CMs and writes to alert log if disabled
-	rows, err := tx.Stmt(db.failDisabledCM).QueryContext(execCtx)
-	if err != nil {
-		return errors.Wrap(err, "check for disabled CMs")
+	cfg := config.FromContext(ctx)
+	sent := db.sentCache(partition)
+	var sentSince sql.NullTime
+	if !sent.lastSent.IsZero() {
+		sentSince.Time = sent.lastSent
+		sentSince.Valid = true
 	}
-	defer rows.Close()
+	maxAge := maxThrottleDuration(perCMThrottle(cfg), GlobalCMThrottle)
 
-	for rows.Next() {
-		var msg msgMeta
-		err = rows.Scan(&msg.MessageID, &msg.AlertID, &msg.UserID, &msg.CMID)
-		if err != nil {
-			return errors.Wrap(err, "scan all disabled CM messages")
-		}
-		msgs = append(msgs, msg)
+	var t time.Time
+	var fetched []Message
+
+	// lock, clean up, and fetch the queue in a single round trip
+	var b pgx.Batch
+	b.Queue(lockMessagesQuery)
+	b.Queue(currentTimeQuery).QueryRow(func(row pgx.Row) error {
+		return errors.Wrap(row.Scan(&t), "get current time")
+	})
+	b.Queue(cleanupStatusUpdateOptOutQuery)
+	if !cfg.Twilio.Enable {
+		// if twilio is disabled, create an entry to notify the user
+		b.Queue(failSMSVoiceQuery).Query(func(rows pgx.Rows) error {
+			return errors.Wrap(scanFailed(rows), "scan all failed messages")
+		})
+	}
+	// processes disabled CMs and writes to alert log if disabled
+	b.Queue(failDisabledCMQuery).Query(func(rows pgx.Rows) error {
+		return errors.Wrap(scanFailed(rows), "scan all disabled CM messages")
+	})
+	b.Queue(failDisabledChannelQuery)
+	b.Queue(recoverExpiredQuery)
+	b.Queue(sendDeadlineExpiredQuery)
+	b.Queue(retryClearQuery)
+	b.Queue(retryResetQuery)
+	if cfg.Failover.Enable {
+		b.Queue(failoverQuery, cfg.Failover.ProviderFailures, cfg.Failover.DestinationFailures).Query(func(rows pgx.Rows) error {
+			for rows.Next() {
+				var f failoverMeta
+				err := rows.Scan(&f.MessageID, &f.FailedMessageID, &f.AlertID, &f.UserID, &f.CMID, &f.ProviderFailing)
+				if err != nil {
+					return errors.Wrap(err, "scan failover messages")
+				}
+				failovers = append(failovers, f)
+			}
+			return errors.Wrap(rows.Err(), "fail over messages")
+		})
+	}
+	paused := pausedDestTypes(cfg)
+	if cmTypes := pausedCMTypes(cfg); cfg.Pause.FailoverAlerts && len(cmTypes) > 0 {
+		b.Queue(pauseFailoverQuery, cmTypes).Query(func(rows pgx.Rows) error {
+			for rows.Next() {
+				var f failoverMeta
+				err := rows.Scan(&f.MessageID, &f.FailedMessageID, &f.AlertID, &f.UserID, &f.CMID)
+				if err != nil {
+					return errors.Wrap(err, "scan paused failover messages")
+				}
+				f.ProviderFailing = true
+				f.Paused = true
+				failovers = append(failovers, f)
+			}
+			return errors.Wrap(rows.Err(), "fail over paused messages")
+		})
+	}
+	b.Queue(messagesQuery, sentSince, db.partitions, partition, maxAge.Seconds()).Query(func(rows pgx.Rows) error {
+		fetched, err = scanMessages(ctx, rows)
+		return errors.Wrap(err, "fetch outgoing messages")
+	})
+	err = cLock.SendBatch(execCtx, &b)
+	if err != nil {
+		return errors.Wrap(err, "update and fetch pending messages")
 	}
 
 	for _, m := range msgs {
@@ -668,24 +689,30 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		}), tx, m.AlertID, alertlog.TypeNotificationSent, meta)
 	}
 
-	_, err = tx.Stmt(db.sendDeadlineExpired).ExecContext(ctx)
-	if err != nil {
-		return errors.Wrap(err, "fail expired messages")
-	}
-
-	_, err = tx.Stmt(db.retryClear).ExecContext(ctx)
-	if err != nil {
-		return errors.Wrap(err, "clear max retries")
+	for _, f := range failovers {
+		db.alertlogstore.MustLogTx(permission.UserSourceContext(ctx, f.UserID, permission.RoleUser, &permission.SourceInfo{
+			Type: permission.SourceTypeContactMethod,
+			ID:   f.CMID,
+		}), tx, f.AlertID, alertlog.TypeNotificationFailover, f.NotificationFailoverMetaData)
+
+		reason := "destination"
+		switch {
+		case f.Paused:
+			reason = "paused"
+		case f.ProviderFailing:
+			reason = "provider"
+		}
+		metricFailoverTotal.WithLabelValues(reason).Inc()
 	}
 
-	_, err = tx.Stmt(db.retryReset).ExecContext(execCtx)
+	var updates pgx.Batch
+	q, err := db.currentQueue(ctx, &updates, fetched, t, partition)
 	if err != nil {
-		return errors.Wrap(err, "reset retry messages")
+		return errors.Wrap(err, "get pending messages")
 	}
-
-	q, err := db.currentQueue(ctx, tx, t)
+	err = cLock.SendBatch(execCtx, &updates)
 	if err != nil {
-		return errors.Wrap(err, "get pending messages")
+		return errors.Wrap(err, "update pending messages")
 	}
 
 	err = tx.Commit()
@@ -695,10 +722,14 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 
 	var wg sync.WaitGroup
 	for _, t := range q.Types() {
+		if paused[t] {
+			// held until resumed
+			continue
+		}
 		wg.Add(1)
 		go func(typ notification.DestType) {
 			defer wg.Done()
-			err := db.sendMessagesByType(ctx, cLock, send, q, typ)
+			err := db.sendMessagesByType(ctx, cLock, send, find, q, typ)
 			if err != nil && !errors.Is(err, processinglock.ErrNoLock) {
 				log.Log(ctx, errors.Wrap(err, "send"))
 			}
@@ -706,7 +737,7 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 	}
 	wg.Wait()
 
-	return db.updateStuckMessages(ctx, status)
+	return nil
 }
 
 func (db *DB) refreshMessageState(ctx context.Context, statusFn StatusFunc, id string, providerID notification.ProviderMessageID, res chan *notification.SendResult) {
@@ -781,7 +812,7 @@ func (db *DB) updateStuckMessages(ctx context.Context, statusFn StatusFunc) erro
 	return nil
 }
 
-func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn, send SendFunc, q *queue, typ notification.DestType) error {
+func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn, send SendFunc, find FindSentFunc, q *queue, typ notification.DestType) error {
 	ch := make(chan error)
 	var count int
 	for {
@@ -791,7 +822,7 @@ func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn
 		}
 		count++
 		go func() {
-			_, err := db.sendMessage(ctx, cLock, send, msg)
+			_, err := db.sendMessage(ctx, cLock, send, find, msg)
 			ch <- err
 		}()
 	}
@@ -817,24 +848,83 @@ func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn
 	return nil
 }
 
-func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send SendFunc, m *Message) (bool, error) {
+func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send SendFunc, find FindSentFunc, m *Message) (bool, error) {
 	ctx = log.WithFields(ctx, log.Fields{
-		"DestTypeID": m.Dest.ID,
-		"DestType":   m.Dest.Type.String(),
-		"CallbackID": m.ID,
+		"DestTypeID":       m.Dest.ID,
+		"DestType":         m.Dest.Type.String(),
+		log.FieldMessageID: m.ID,
 	})
 
 	if m.AlertID != 0 {
-		ctx = log.WithField(ctx, "AlertID", m.AlertID)
+		ctx = log.WithField(ctx, log.FieldAlertID, m.AlertID)
 	}
 	_, err := cLock.Exec(ctx, db.setSending, m.ID)
 	if err != nil {
 		return false, err
 	}
-	sCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	retryExec := func(s *sql.Stmt, args ...interface{}) error {
+		return retry.DoTemporaryError(func(int) error {
+			_, err := s.ExecContext(ctx, args...)
+			return err
+		},
+			retry.Limit(15),
+			retry.FibBackoff(time.Millisecond*50),
+		)
+	}
+
+	if !resendSafe(m.Dest.Type) {
+		// A previous request may have been accepted by the provider even though no response was received,
+		// so check with the provider before sending again.
+		var since sql.NullTime
+		err = db.unknownRequests.QueryRowContext(ctx, m.ID).Scan(&since)
+		if err != nil {
+			return false, errors.Wrap(err, "check for requests with unknown outcome")
+		}
+		if since.Valid {
+			res, err := find(ctx, m, since.Time)
+			if errors.Is(err, notification.ErrStatusUnsupported) {
+				// The provider can't identify the message, so send it again rather than risk dropping it.
+				res, err = nil, nil
+			}
+			if err != nil {
+				// Leave the requests incomplete, so they are checked again before the next attempt.
+				log.Log(ctx, errors.Wrap(err, "check previous send request"))
+
+				err = retryExec(db.tempFail, m.ID, nil, "provider request outcome unknown and could not be verified: "+err.Error())
+				return false, errors.Wrap(err, "mark failed message (unverified outcome)")
+			}
+
+			var pID notification.ProviderMessageID
+			if res != nil {
+				pID = res.ProviderMessageID
+			}
+			err = retryExec(db.resolveRequests, m.ID, pID)
+			if err != nil {
+				return false, errors.Wrap(err, "resolve provider requests")
+			}
+			if res != nil {
+				log.Logf(ctx, "previous send request with unknown outcome was accepted by provider")
+				return true, errors.Wrap(db.UpdateMessageStatus(ctx, res), "update message status")
+			}
+		}
+	}
+
+	// Record the request before making it, so a message the provider accepted can be recovered, rather than
+	// sent again, if the engine stops before the result is saved. The same key is used for all retries below.
+	key := uuid.New()
+	_, err = cLock.Exec(ctx, db.beginRequest, key, m.ID)
+	if err != nil {
+		return false, err
+	}
+
+	sCtx, cancel := context.WithTimeout(notification.WithIdempotencyKey(ctx, key.String()), 5*time.Second)
 	var status *notification.SendResult
 	err = retry.DoTemporaryError(func(int) error {
 		status, err = send(sCtx, m)
+		if isUnknownOutcome(err) && !resendSafe(m.Dest.Type) {
+			return unknownOutcomeError{err: err}
+		}
 		return err
 	},
 		retry.Log(ctx),
@@ -848,15 +938,21 @@ func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send
 		pID = status.ProviderMessageID
 	}
 
-	retryExec := func(s *sql.Stmt, args ...interface{}) error {
-		return retry.DoTemporaryError(func(int) error {
-			_, err := s.ExecContext(ctx, args...)
-			return err
-		},
-			retry.Limit(15),
-			retry.FibBackoff(time.Millisecond*50),
-		)
+	var unknown unknownOutcomeError
+	if errors.As(err, &unknown) {
+		// Leave the request incomplete, as it may have been accepted. It will be checked with the provider
+		// before the message is retried.
+		log.Log(ctx, errors.Wrap(err, "send message"))
+
+		err = retryExec(db.tempFail, m.ID, pID, unknown.Error())
+		return false, errors.Wrap(err, "mark failed message (unknown outcome)")
 	}
+
+	err2 := retryExec(db.completeRequest, key, pID)
+	if err2 != nil {
+		log.Log(ctx, errors.Wrap(err2, "complete provider request"))
+	}
+
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "send message"))
 
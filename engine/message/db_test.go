@@ -0,0 +1,51 @@
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil/sqlbench"
+)
+
+type neverPause struct{}
+
+func (neverPause) IsPausing() bool            { return false }
+func (neverPause) PauseWait() <-chan struct{} { return nil }
+
+// BenchmarkDB_SendMessages measures the round trips made each engine cycle to build the message queue.
+func BenchmarkDB_SendMessages(b *testing.B) {
+	db, rt := sqlbench.DB(b)
+	ctx := permission.SystemContext(context.Background(), "Benchmark")
+	ctx = config.Config{}.Context(ctx)
+
+	logStore, err := alertlog.NewStore(ctx, db)
+	require.NoError(b, err)
+	mdb, err := NewDB(ctx, db, logStore, neverPause{})
+	require.NoError(b, err)
+
+	send := func(context.Context, *Message) (*notification.SendResult, error) {
+		return &notification.SendResult{Status: notification.Status{State: notification.StateSent}}, nil
+	}
+	find := func(context.Context, *Message, time.Time) (*notification.SendResult, error) {
+		return nil, nil
+	}
+	status := func(context.Context, notification.ProviderMessageID) (*notification.Status, notification.DestType, error) {
+		return &notification.Status{State: notification.StateSent}, notification.DestTypeUnknown, nil
+	}
+
+	// warm up (prepare statements)
+	require.NoError(b, mdb.SendMessages(ctx, send, find, status))
+
+	rt.Reset()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, mdb.SendMessages(ctx, send, find, status))
+	}
+	b.ReportMetric(float64(rt.RoundTrips())/float64(b.N), "roundtrips/op")
+}
@@ -0,0 +1,33 @@
+package message
+
+import (
+	"time"
+
+	"github.com/target/goalert/notification"
+)
+
+// holdDigestMessages will remove pending alert notifications that are being held for an alert digest.
+//
+// Held notifications for a destination are released together once the oldest has waited for the
+// digest interval, so that they can be bundled into as few messages as possible.
+func holdDigestMessages(messages []Message, now time.Time) []Message {
+	due := make(map[notification.Dest]bool)
+	for _, msg := range messages {
+		if msg.DigestInterval == 0 || !msg.SentAt.IsZero() {
+			continue
+		}
+		if now.Sub(msg.CreatedAt) >= msg.DigestInterval {
+			due[msg.Dest] = true
+		}
+	}
+
+	result := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.DigestInterval > 0 && msg.SentAt.IsZero() && !due[msg.Dest] {
+			continue
+		}
+		result = append(result, msg)
+	}
+
+	return result
+}
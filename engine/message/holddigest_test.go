@@ -0,0 +1,34 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestHoldDigestMessages(t *testing.T) {
+	n := time.Now()
+	smsA := notification.Dest{Type: notification.DestTypeSMS, ID: "A"}
+	smsB := notification.Dest{Type: notification.DestTypeSMS, ID: "B"}
+
+	msgs := []Message{
+		// A: oldest held message is due, all are released
+		{ID: "1", Dest: smsA, CreatedAt: n.Add(-20 * time.Minute), DigestInterval: 15 * time.Minute},
+		{ID: "2", Dest: smsA, CreatedAt: n.Add(-time.Minute), DigestInterval: 15 * time.Minute},
+
+		// B: nothing due yet
+		{ID: "3", Dest: smsB, CreatedAt: n.Add(-10 * time.Minute), DigestInterval: 15 * time.Minute},
+		{ID: "4", Dest: smsB, CreatedAt: n.Add(-10 * time.Minute), SentAt: n.Add(-10 * time.Minute), DigestInterval: 15 * time.Minute},
+
+		// not digested
+		{ID: "5", Dest: smsB, CreatedAt: n},
+	}
+
+	var ids []string
+	for _, msg := range holdDigestMessages(msgs, n) {
+		ids = append(ids, msg.ID)
+	}
+	assert.ElementsMatch(t, []string{"1", "2", "4", "5"}, ids)
+}
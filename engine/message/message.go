@@ -3,6 +3,7 @@ package message
 import (
 	"time"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/notification"
 )
 
@@ -22,4 +23,13 @@ type Message struct {
 	SentAt     time.Time
 
 	StatusAlertIDs []int
+
+	// Severity is the severity of the alert for alert notifications.
+	Severity alert.Severity
+
+	// DigestInterval is set for pending alert notifications that should be held for the user's alert digest.
+	DigestInterval time.Duration
+
+	// RequestID is the ID of the API request that caused the message (e.g., by creating the alert), if any.
+	RequestID string
 }
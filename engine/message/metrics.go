@@ -0,0 +1,20 @@
+package message
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricThrottledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "engine",
+	Name:      "message_throttled_total",
+	Help:      "Total number of pending messages delayed until a later cycle by a rate limit. Throttled messages are delayed, never dropped.",
+}, []string{"dest_type", "message_type", "limit"})
+
+var metricFailoverTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "engine",
+	Name:      "message_failover_total",
+	Help:      "Total number of failed (or paused) alert notifications sent to the next contact method of a user.",
+}, []string{"reason"})
@@ -0,0 +1,50 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/target/goalert/notification"
+)
+
+// unknownOutcomeError indicates a provider request failed in a way that it may still have been
+// accepted (e.g., a timeout waiting for the response).
+//
+// It intentionally does not unwrap, so that it is never treated as a temporary error and retried immediately.
+type unknownOutcomeError struct {
+	err error
+}
+
+func (e unknownOutcomeError) Error() string {
+	return "provider request outcome unknown; will check with provider before retrying: " + e.err.Error()
+}
+
+// isUnknownOutcome returns true if err means a provider request may have been accepted
+// even though no response was received.
+func isUnknownOutcome(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// resendSafe returns true if messages to the given dest type may be re-sent after a request with an
+// unknown outcome. Providers for SMS and voice do not support idempotency keys, so a retry could
+// notify the user twice unless the provider is checked for the message first. If the provider can't
+// identify the message (e.g., voice calls), it is sent again rather than risk dropping it.
+func resendSafe(t notification.DestType) bool {
+	switch t.SenderType() {
+	case notification.DestTypeSMS, notification.DestTypeVoice:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/retry"
+)
+
+type timeoutErr struct{ timeout bool }
+
+func (e timeoutErr) Error() string   { return "net error" }
+func (e timeoutErr) Timeout() bool   { return e.timeout }
+func (e timeoutErr) Temporary() bool { return true }
+
+func TestIsUnknownOutcome(t *testing.T) {
+	assert.False(t, isUnknownOutcome(nil))
+	assert.False(t, isUnknownOutcome(errors.New("bad request")))
+	assert.False(t, isUnknownOutcome(timeoutErr{timeout: false}), "connection errors are not ambiguous")
+
+	assert.True(t, isUnknownOutcome(fmt.Errorf("post: %w", context.DeadlineExceeded)))
+	assert.True(t, isUnknownOutcome(fmt.Errorf("read: %w", io.ErrUnexpectedEOF)))
+	assert.True(t, isUnknownOutcome(timeoutErr{timeout: true}))
+}
+
+func TestUnknownOutcomeError_NotTemporary(t *testing.T) {
+	err := unknownOutcomeError{err: timeoutErr{timeout: true}}
+	assert.True(t, retry.IsTemporaryError(err.err))
+	assert.False(t, retry.IsTemporaryError(err), "must not be retried")
+}
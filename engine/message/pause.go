@@ -0,0 +1,57 @@
+package message
+
+import (
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+// pausedDestTypes returns the set of destination types paused by the Pause config section.
+func pausedDestTypes(cfg config.Config) map[notification.DestType]bool {
+	m := make(map[notification.DestType]bool)
+	if cfg.Pause.Voice {
+		m[notification.DestTypeVoice] = true
+		m[notification.DestTypeChanVoice] = true
+	}
+	if cfg.Pause.SMS {
+		m[notification.DestTypeSMS] = true
+		m[notification.DestTypeChanSMS] = true
+	}
+	if cfg.Pause.Email {
+		m[notification.DestTypeUserEmail] = true
+		m[notification.DestTypeChanEmail] = true
+	}
+	if cfg.Pause.Slack {
+		m[notification.DestTypeSlackChannel] = true
+		m[notification.DestTypeSlackDM] = true
+		m[notification.DestTypeSlackUG] = true
+	}
+	if cfg.Pause.Webhook {
+		m[notification.DestTypeUserWebhook] = true
+		m[notification.DestTypeChanWebhook] = true
+	}
+
+	return m
+}
+
+// pausedCMTypes returns the contact method types (as stored in user_contact_methods) paused by the
+// Pause config section.
+func pausedCMTypes(cfg config.Config) []string {
+	var types []string
+	if cfg.Pause.Voice {
+		types = append(types, "VOICE")
+	}
+	if cfg.Pause.SMS {
+		types = append(types, "SMS")
+	}
+	if cfg.Pause.Email {
+		types = append(types, "EMAIL")
+	}
+	if cfg.Pause.Slack {
+		types = append(types, "SLACK_DM")
+	}
+	if cfg.Pause.Webhook {
+		types = append(types, "WEBHOOK")
+	}
+
+	return types
+}
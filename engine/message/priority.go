@@ -0,0 +1,47 @@
+package message
+
+import (
+	"time"
+
+	"github.com/target/goalert/notification"
+)
+
+// Priority is the class of an outgoing message. Lower values are sent first.
+type Priority int
+
+const (
+	// PriorityHigh is used for verification codes, test messages, and the first alert
+	// notification for a service.
+	PriorityHigh Priority = iota
+
+	// PriorityNormal is used for other alert notifications and on-call notifications.
+	PriorityNormal
+
+	// PriorityLow is used for alert status updates.
+	PriorityLow
+)
+
+// PriorityThrottle limits how much of the global rate limit each priority class may use,
+// for each destination type, so that a flood of low-priority messages can't delay new pages.
+//
+// Classes without an entry are only limited by GlobalCMThrottle.
+var PriorityThrottle = map[Priority]ThrottleConfig{
+	PriorityNormal: ThrottleRules{{Count: 4, Per: 5 * time.Second}},
+	PriorityLow:    ThrottleRules{{Count: 2, Per: 5 * time.Second}},
+}
+
+// priority returns the priority class of a message.
+func (q *queue) priority(msg Message) Priority {
+	switch msg.Type {
+	case notification.MessageTypeVerification, notification.MessageTypeTest:
+		return PriorityHigh
+	case notification.MessageTypeAlert, notification.MessageTypeAlertBundle:
+		if _, ok := q.firstAlert[destID{ID: msg.ServiceID, DestType: msg.Dest.Type}]; !ok {
+			return PriorityHigh
+		}
+	case notification.MessageTypeAlertStatus:
+		return PriorityLow
+	}
+
+	return PriorityNormal
+}
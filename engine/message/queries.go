@@ -0,0 +1,355 @@
+package message
+
+// Queries for building the current queue. The lock and fetch queries are sent as a single
+// batch, so they only take one round trip each engine cycle.
+const (
+	lockMessagesQuery = `lock outgoing_messages in exclusive mode`
+
+	currentTimeQuery = `select now()`
+
+	cleanupStatusUpdateOptOutQuery = `
+	delete from outgoing_messages msg
+	using user_contact_methods cm
+	where
+		msg.message_type = 'alert_status_update' and
+		(
+			msg.last_status = 'pending' or
+			(msg.last_status = 'failed' and msg.next_retry_at notnull)
+		) and
+		not cm.enable_status_updates and cm.id = msg.contact_method_id
+	`
+
+	failSMSVoiceQuery = `
+	update outgoing_messages msg
+	set
+		last_status = 'failed',
+		last_status_at = now(),
+		status_details = 'SMS/Voice support not enabled by administrator',
+		cycle_id = null,
+		next_retry_at = null
+	from user_contact_methods cm
+	where
+		msg.last_status = 'pending' and
+		cm.type in ('SMS', 'VOICE') and
+		cm.id = msg.contact_method_id
+	returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
+	`
+
+	failDisabledCMQuery = `
+	with disabled as (
+		update outgoing_messages msg
+		set
+			last_status = 'failed',
+			last_status_at = now(),
+			status_details = 'contact method disabled',
+			cycle_id = null,
+			next_retry_at = null
+		from user_contact_methods cm
+		where
+			msg.last_status = 'pending' and
+			msg.message_type != 'verification_message' and
+			cm.id = msg.contact_method_id and
+			cm.disabled
+		returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
+	) select distinct msg_id, alert_id, user_id, cm_id from disabled where alert_id notnull
+	`
+
+	// team contact methods that have not been verified
+	failDisabledChannelQuery = `
+	update outgoing_messages msg
+	set
+		last_status = 'failed',
+		last_status_at = now(),
+		status_details = 'contact method not verified',
+		cycle_id = null,
+		next_retry_at = null
+	from notification_channels nc
+	where
+		msg.last_status = 'pending' and
+		msg.message_type != 'verification_message' and
+		nc.id = msg.channel_id and
+		nc.disabled
+	`
+
+	// messages where the provider accepted the request, but the engine stopped before updating the status
+	recoverExpiredQuery = `
+	update outgoing_messages msg
+	set
+		last_status = 'queued_remotely',
+		last_status_at = now(),
+		status_details = 'recovered after send deadline expired',
+		cycle_id = null,
+		sending_deadline = null,
+		sent_at = coalesce(msg.fired_at, now()),
+		fired_at = null,
+		provider_msg_id = req.provider_msg_id,
+		next_retry_at = null
+	from outgoing_message_requests req
+	where
+		msg.last_status = 'sending' and
+		msg.sending_deadline <= now() and
+		req.message_id = msg.id and
+		req.completed_at notnull and
+		req.provider_msg_id notnull
+	`
+
+	sendDeadlineExpiredQuery = `
+	update outgoing_messages
+	set
+		last_status = 'failed',
+		last_status_at = now(),
+		status_details = 'send deadline expired',
+		cycle_id = null,
+		next_retry_at = null
+	where
+		last_status = 'sending' and
+		sending_deadline <= now()
+	`
+
+	retryClearQuery = `
+	update outgoing_messages
+	set
+		next_retry_at = null,
+		cycle_id = null
+	where
+		last_status = 'failed' and
+		retry_count >= 3 and
+		(cycle_id notnull or next_retry_at notnull)
+	`
+
+	retryResetQuery = `
+	update outgoing_messages
+	set
+		last_status = 'pending',
+		status_details = '',
+		next_retry_at = null,
+		retry_count = retry_count + 1,
+		fired_at = null,
+		sent_at = null,
+		provider_msg_id = null,
+		provider_seq = 0
+	where
+		last_status = 'failed' and
+		now() > next_retry_at and
+		retry_count < 3
+	`
+
+	// alert notifications that failed for good, where the contact method type has failed $1 times in a row,
+	// or the contact method itself has failed $2 times in a row, are sent to the next contact method of the user
+	failoverQuery = `
+	with failed as (
+		select
+			msg.id,
+			msg.alert_id,
+			msg.service_id,
+			msg.escalation_policy_id,
+			msg.user_id,
+			msg.created_at,
+			cm.id as cm_id,
+			cm.type as cm_type
+		from outgoing_messages msg
+		join user_contact_methods cm on cm.id = msg.contact_method_id
+		join alerts a on a.id = msg.alert_id and a.status = 'triggered'
+		where
+			msg.message_type = 'alert_notification' and
+			msg.last_status = 'failed' and
+			msg.next_retry_at isnull and
+			msg.last_status_at > now() - '15 minutes'::interval and
+			not exists (select 1 from outgoing_messages fo where fo.failover_of = msg.id)
+	), failing_types as (
+		select t.cm_type
+		from (select distinct cm_type from failed) t
+		where $1 > 0 and $1 = (
+			select count(*) filter (where recent.last_status = 'failed')
+			from (
+				select msg.last_status
+				from outgoing_messages msg
+				join user_contact_methods cm on cm.id = msg.contact_method_id and cm.type = t.cm_type
+				where
+					msg.last_status in ('delivered', 'failed', 'sent') and
+					msg.created_at > now() - '1 hour'::interval
+				order by msg.last_status_at desc
+				limit $1
+			) recent
+		)
+	), failing_cms as (
+		select c.cm_id
+		from (select distinct cm_id from failed) c
+		where $2 > 0 and $2 = (
+			select count(*) filter (where recent.last_status = 'failed')
+			from (
+				select msg.last_status
+				from outgoing_messages msg
+				where
+					msg.contact_method_id = c.cm_id and
+					msg.last_status in ('delivered', 'failed', 'sent')
+				order by msg.last_status_at desc
+				limit $2
+			) recent
+		)
+	), next as (
+		select distinct on (f.id)
+			f.id,
+			f.alert_id,
+			f.service_id,
+			f.escalation_policy_id,
+			f.user_id,
+			f.cm_id,
+			cm.id as next_cm_id,
+			f.cm_type in (select cm_type from failing_types) as provider_failing
+		from failed f
+		join user_contact_methods cm on
+			cm.user_id = f.user_id and
+			cm.id != f.cm_id and
+			not cm.disabled
+		left join user_notification_rules r on r.contact_method_id = cm.id
+		where
+			(f.cm_type in (select cm_type from failing_types) or f.cm_id in (select cm_id from failing_cms)) and
+			cm.type not in (select cm_type from failing_types) and
+			not exists (
+				select 1
+				from outgoing_messages prev
+				where
+					prev.alert_id = f.alert_id and
+					prev.contact_method_id = cm.id and
+					(prev.last_status = 'failed' or prev.created_at >= f.created_at)
+			)
+		order by f.id, r.delay_minutes nulls last, cm.name
+	), inserted as (
+		insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, failover_of)
+		select 'alert_notification', alert_id, service_id, escalation_policy_id, user_id, next_cm_id, id
+		from next
+		on conflict (failover_of) where failover_of notnull do nothing
+		returning id, failover_of
+	)
+	select ins.id, n.id, n.alert_id, n.user_id, n.next_cm_id, n.provider_failing
+	from inserted ins
+	join next n on n.id = ins.failover_of
+	`
+
+	// pending alert notifications to contact methods of a paused type ($1) are sent to the next contact
+	// method of the user that is not paused; messages with no such contact method are left pending
+	pauseFailoverQuery = `
+	with next as (
+		select distinct on (msg.id)
+			msg.id,
+			msg.alert_id,
+			msg.service_id,
+			msg.escalation_policy_id,
+			msg.user_id,
+			cm.id as next_cm_id
+		from outgoing_messages msg
+		join user_contact_methods paused on
+			paused.id = msg.contact_method_id and
+			paused.type::text = any($1::text[])
+		join user_contact_methods cm on
+			cm.user_id = msg.user_id and
+			cm.id != msg.contact_method_id and
+			not cm.disabled and
+			not cm.type::text = any($1::text[])
+		left join user_notification_rules r on r.contact_method_id = cm.id
+		where
+			msg.message_type = 'alert_notification' and
+			msg.last_status = 'pending' and
+			not exists (
+				select 1
+				from outgoing_messages prev
+				where
+					prev.alert_id = msg.alert_id and
+					prev.contact_method_id = cm.id and
+					(prev.last_status = 'failed' or prev.created_at >= msg.created_at)
+			)
+		order by msg.id, r.delay_minutes nulls last, cm.name
+	), inserted as (
+		insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, user_id, contact_method_id, failover_of)
+		select 'alert_notification', alert_id, service_id, escalation_policy_id, user_id, next_cm_id, id
+		from next
+		on conflict (failover_of) where failover_of notnull do nothing
+		returning id, failover_of
+	), failed as (
+		update outgoing_messages msg
+		set
+			last_status = 'failed',
+			last_status_at = now(),
+			status_details = 'contact method type paused by administrator',
+			cycle_id = null,
+			next_retry_at = null
+		from inserted ins
+		where msg.id = ins.failover_of
+	)
+	select ins.id, n.id, n.alert_id, n.user_id, n.next_cm_id
+	from inserted ins
+	join next n on n.id = ins.failover_of
+	`
+
+	// pending messages, and those sent since $1 (or the last $4 seconds, if null), for partition $3 of $2
+	messagesQuery = `
+	select
+		msg.id,
+		msg.message_type,
+		case when vc.voice and cm.type = 'SMS' then 'VOICE' else cm.type end,
+		chan.type,
+		coalesce(msg.contact_method_id, msg.channel_id),
+		coalesce(cm.value, chan.value),
+		msg.alert_id,
+		msg.alert_log_id,
+		msg.user_verification_code_id,
+		cm.user_id,
+		msg.service_id,
+		msg.created_at,
+		msg.sent_at,
+		msg.status_alert_ids,
+		msg.schedule_id,
+		case when
+			msg.message_type = 'alert_notification' and
+			a.severity in ('medium', 'low') and
+			cm.type in ('SMS', 'EMAIL')
+		then dig.interval_minutes end,
+		a.severity,
+		msg.request_id,
+		u.locale
+	from outgoing_messages msg
+	left join user_contact_methods cm on cm.id = msg.contact_method_id
+	left join users u on u.id = cm.user_id
+	left join notification_channels chan on chan.id = msg.channel_id
+	left join alerts a on a.id = msg.alert_id
+	left join user_alert_digests dig on dig.user_id = msg.user_id
+	left join user_verification_codes vc on vc.id = msg.user_verification_code_id
+	where
+		(
+			$2 <= 1 or
+			(hashtext(coalesce(msg.contact_method_id, msg.channel_id)::text) & 2147483647) % $2 = $3
+		) and
+		(
+			sent_at >= coalesce($1, now() - $4::float8 * '1 second'::interval) or
+			last_status = 'pending' and
+			(msg.contact_method_id isnull or msg.message_type = 'verification_message' or not cm.disabled)
+		)
+	`
+
+	deleteMessagesQuery = `delete from outgoing_messages where id = any($1::uuid[])`
+
+	createAlertBundleQuery = `
+	insert into outgoing_messages (
+		id,
+		created_at,
+		message_type,
+		contact_method_id,
+		channel_id,
+		user_id,
+		service_id
+	) values (
+		$1, $2, 'alert_notification_bundle', $3, $4, $5, $6
+	)
+	`
+
+	bundleMessagesQuery = `
+	update outgoing_messages
+	set
+		last_status = 'bundled',
+		last_status_at = now(),
+		status_details = $1,
+		cycle_id = null
+	where id = any($2::uuid[])
+	`
+)
@@ -33,8 +33,9 @@ type queue struct {
 	userSent    map[string]time.Time
 	destSent    map[notification.Dest]time.Time
 
-	cmThrottle     *Throttle
-	globalThrottle *Throttle
+	cmThrottle       *Throttle
+	globalThrottle   *Throttle
+	priorityThrottle map[Priority]*Throttle
 
 	mx sync.Mutex
 }
@@ -45,6 +46,13 @@ type destID struct {
 }
 
 func newQueue(msgs []Message, now time.Time) *queue {
+	return newPartitionQueue(msgs, now, 1, PerCMThrottle)
+}
+
+// newPartitionQueue returns a queue for a single partition of outgoing messages. Since each partition
+// is sent independently, the global rate limits are divided between them. Per-contact-method limits are
+// set by perCM, as a contact method always belongs to a single partition.
+func newPartitionQueue(msgs []Message, now time.Time, partitions int, perCM ThrottleConfig) *queue {
 	q := &queue{
 		sent:    make([]Message, 0, len(msgs)),
 		pending: make(map[notification.DestType][]Message),
@@ -55,8 +63,12 @@ func newQueue(msgs []Message, now time.Time) *queue {
 		userSent:    make(map[string]time.Time),
 		destSent:    make(map[notification.Dest]time.Time),
 
-		cmThrottle:     NewThrottle(PerCMThrottle, now, false),
-		globalThrottle: NewThrottle(GlobalCMThrottle, now, true),
+		cmThrottle:       NewThrottle(perCM, now, false),
+		globalThrottle:   NewThrottle(partitionThrottle(GlobalCMThrottle, partitions), now, true),
+		priorityThrottle: make(map[Priority]*Throttle, len(PriorityThrottle)),
+	}
+	for p, cfg := range PriorityThrottle {
+		q.priorityThrottle[p] = NewThrottle(partitionThrottle(cfg, partitions), now, true)
 	}
 
 	for _, m := range msgs {
@@ -76,6 +88,10 @@ func (q *queue) addSent(m Message) {
 
 	q.cmThrottle.Record(m)
 	q.globalThrottle.Record(m)
+	if th := q.priorityThrottle[q.priority(m)]; th != nil {
+		// must be recorded before updating firstAlert, as it affects the priority
+		th.Record(m)
+	}
 	q.firstAlert[destID{ID: m.ServiceID, DestType: m.Dest.Type}] = struct{}{}
 	if t := q.serviceSent[m.ServiceID]; m.SentAt.After(t) {
 		q.serviceSent[m.ServiceID] = m.SentAt
@@ -123,9 +139,15 @@ func (q *queue) filterPending(destType notification.DestType) {
 	filtered := pending[:0]
 	for _, p := range pending {
 		if q.globalThrottle.InCooldown(p) {
+			metricThrottledTotal.WithLabelValues(destType.String(), p.Type.String(), "global").Inc()
 			continue
 		}
 		if q.cmThrottle.InCooldown(p) {
+			metricThrottledTotal.WithLabelValues(destType.String(), p.Type.String(), "contact_method").Inc()
+			continue
+		}
+		if th := q.priorityThrottle[q.priority(p)]; th != nil && th.InCooldown(p) {
+			metricThrottledTotal.WithLabelValues(destType.String(), p.Type.String(), "priority").Inc()
 			continue
 		}
 		filtered = append(filtered, p)
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 )
 
@@ -151,3 +153,59 @@ func TestQueue_Sort(t *testing.T) {
 	assert.Nil(t, msg)
 
 }
+
+func TestPartitionThrottle(t *testing.T) {
+	rules := ThrottleRules{{Count: 10, Per: time.Minute}, {Count: 3, Per: time.Hour, Smooth: true}}
+
+	assert.Equal(t, rules, partitionThrottle(rules, 1))
+	assert.Equal(t, ThrottleRules{{Count: 2, Per: time.Minute}, {Count: 1, Per: time.Hour, Smooth: true}}, partitionThrottle(rules, 4))
+	assert.Equal(t, rules, ThrottleRules{{Count: 10, Per: time.Minute}, {Count: 3, Per: time.Hour, Smooth: true}}, "original rules should not be modified")
+
+	var builder ThrottleConfigBuilder
+	builder.AddRules([]ThrottleRule{{Count: 10, Per: time.Minute}})
+	cfg := builder.Config()
+	assert.Equal(t, cfg, partitionThrottle(cfg, 4), "non-ThrottleRules configs are unchanged")
+}
+
+func TestQueue_PriorityThrottle(t *testing.T) {
+	n := time.Now()
+
+	sms := func(id string) notification.Dest {
+		return notification.Dest{Type: notification.DestTypeSMS, ID: id}
+	}
+
+	q := newQueue([]Message{
+		// status updates have already used the low-priority budget
+		{ID: "1", Type: notification.MessageTypeAlertStatus, ServiceID: "A", Dest: sms("A"), SentAt: n.Add(-time.Second)},
+		{ID: "2", Type: notification.MessageTypeAlertStatus, ServiceID: "A", Dest: sms("B"), SentAt: n.Add(-time.Second)},
+
+		{ID: "3", Type: notification.MessageTypeAlertStatus, ServiceID: "A", Dest: sms("C"), CreatedAt: n.Add(-time.Minute)},
+		{ID: "4", Type: notification.MessageTypeAlert, ServiceID: "B", Dest: sms("D"), CreatedAt: n},
+	}, n)
+
+	msg := q.NextByType(notification.DestTypeSMS)
+	require.NotNil(t, msg)
+	assert.Equal(t, "4", msg.ID, "alert should be sent")
+
+	assert.Nil(t, q.NextByType(notification.DestTypeSMS), "status update should be throttled")
+}
+
+func TestPerCMThrottle_Config(t *testing.T) {
+	var cfg config.Config
+	assert.Equal(t, PerCMThrottle, perCMThrottle(cfg), "zero config should use defaults")
+
+	cfg.RateLimit.VoiceAlertsPerHour = 14
+	cfg.RateLimit.LowSeverityAlertsPerHour = 2
+	th := perCMThrottle(cfg)
+
+	voice := Message{Type: notification.MessageTypeAlert, Dest: notification.Dest{Type: notification.DestTypeVoice}, Severity: alert.SeverityHigh}
+	assert.Equal(t, []ThrottleRule{
+		{Count: 1, Per: time.Minute},
+		{Count: 6, Per: 15 * time.Minute},
+		{Count: 14, Per: time.Hour, Smooth: true},
+		{Count: 30, Per: 3 * time.Hour, Smooth: true},
+	}, th.Rules(voice))
+
+	voice.Severity = alert.SeverityLow
+	assert.Equal(t, ThrottleRule{Count: 2, Per: time.Hour}, th.Rules(voice)[4])
+}
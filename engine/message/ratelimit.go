@@ -3,21 +3,66 @@ package message
 import (
 	"time"
 
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 )
 
 // GlobalCMThrottle represents the rate limits for each notification type.
 var GlobalCMThrottle ThrottleConfig = ThrottleRules{{Count: 5, Per: 5 * time.Second}}
 
-// PerCMThrottle configures rate limits for individual contact methods.
-var PerCMThrottle ThrottleConfig
+// PerCMThrottle configures the default rate limits for individual contact methods.
+var PerCMThrottle = perCMThrottle(config.Config{})
 
-func init() {
+// partitionThrottle divides the rules of cfg between the given number of partitions, allowing at least
+// one message per rule for each partition. Configs other than ThrottleRules are returned unchanged.
+func partitionThrottle(cfg ThrottleConfig, partitions int) ThrottleConfig {
+	rules, ok := cfg.(ThrottleRules)
+	if !ok || partitions <= 1 {
+		return cfg
+	}
+
+	result := make(ThrottleRules, len(rules))
+	for i, r := range rules {
+		r.Count = max(1, r.Count/partitions)
+		result[i] = r
+	}
+
+	return result
+}
+
+// defaultAlertsPerHour is the default number of alert notifications per hour for each rate-limited dest type.
+var defaultAlertsPerHour = map[notification.DestType]int{
+	notification.DestTypeVoice: 7,
+	notification.DestTypeSMS:   11,
+}
+
+// scaleRules will scale the counts of rules so that an hourly rate of `defaultPerHour` becomes `perHour`,
+// allowing at least one message per rule. If perHour is zero, rules are returned unchanged.
+func scaleRules(rules []ThrottleRule, defaultPerHour, perHour int) []ThrottleRule {
+	if perHour == 0 || perHour == defaultPerHour {
+		return rules
+	}
+
+	result := make([]ThrottleRule, len(rules))
+	for i, r := range rules {
+		r.Count = max(1, (r.Count*perHour+defaultPerHour/2)/defaultPerHour)
+		result[i] = r
+	}
+
+	return result
+}
+
+// perCMThrottle returns the per-contact-method rate limits, adjusted by the RateLimit config.
+func perCMThrottle(cfg config.Config) ThrottleConfig {
 	var perCM ThrottleConfigBuilder
 
-	// Rate limit sms, voice and email types
+	// Rate limit sms, voice and email types (for users and teams)
 	perCM.
-		WithDestTypes(notification.DestTypeVoice, notification.DestTypeSMS, notification.DestTypeUserEmail).
+		WithDestTypes(
+			notification.DestTypeVoice, notification.DestTypeSMS, notification.DestTypeUserEmail,
+			notification.DestTypeChanVoice, notification.DestTypeChanSMS, notification.DestTypeChanEmail,
+		).
 		AddRules([]ThrottleRule{{Count: 1, Per: time.Minute}})
 
 	// On-Call Status Notifications
@@ -31,7 +76,10 @@ func init() {
 	// status notifications
 	perCM.
 		WithMsgTypes(notification.MessageTypeAlertStatus).
-		WithDestTypes(notification.DestTypeVoice, notification.DestTypeSMS, notification.DestTypeUserEmail).
+		WithDestTypes(
+			notification.DestTypeVoice, notification.DestTypeSMS, notification.DestTypeUserEmail,
+			notification.DestTypeChanVoice, notification.DestTypeChanSMS, notification.DestTypeChanEmail,
+		).
 		AddRules([]ThrottleRule{
 			{Count: 1, Per: 3 * time.Minute},
 			{Count: 3, Per: 20 * time.Minute},
@@ -42,20 +90,34 @@ func init() {
 	alertMessages := perCM.WithMsgTypes(notification.MessageTypeAlert, notification.MessageTypeAlertBundle)
 
 	alertMessages.
-		WithDestTypes(notification.DestTypeVoice).
-		AddRules([]ThrottleRule{
+		WithDestTypes(notification.DestTypeVoice, notification.DestTypeChanVoice).
+		AddRules(scaleRules([]ThrottleRule{
 			{Count: 3, Per: 15 * time.Minute},
 			{Count: 7, Per: time.Hour, Smooth: true},
 			{Count: 15, Per: 3 * time.Hour, Smooth: true},
-		})
+		}, defaultAlertsPerHour[notification.DestTypeVoice], cfg.RateLimit.VoiceAlertsPerHour))
 
 	alertMessages.
-		WithDestTypes(notification.DestTypeSMS).
-		AddRules([]ThrottleRule{
+		WithDestTypes(notification.DestTypeSMS, notification.DestTypeChanSMS).
+		AddRules(scaleRules([]ThrottleRule{
 			{Count: 5, Per: 15 * time.Minute},
 			{Count: 11, Per: time.Hour, Smooth: true},
 			{Count: 21, Per: 3 * time.Hour, Smooth: true},
-		})
+		}, defaultAlertsPerHour[notification.DestTypeSMS], cfg.RateLimit.SMSAlertsPerHour))
+
+	if cfg.RateLimit.EmailAlertsPerHour > 0 {
+		alertMessages.
+			WithDestTypes(notification.DestTypeUserEmail, notification.DestTypeChanEmail).
+			AddRules([]ThrottleRule{{Count: cfg.RateLimit.EmailAlertsPerHour, Per: time.Hour}})
+	}
+
+	if cfg.RateLimit.LowSeverityAlertsPerHour > 0 {
+		// bundles are not limited, as they may contain alerts of any severity
+		perCM.
+			WithMsgTypes(notification.MessageTypeAlert).
+			WithSeverities(alert.SeverityMedium, alert.SeverityLow).
+			AddRules([]ThrottleRule{{Count: cfg.RateLimit.LowSeverityAlertsPerHour, Per: time.Hour}})
+	}
 
-	PerCMThrottle = perCM.Config()
+	return perCM.Config()
 }
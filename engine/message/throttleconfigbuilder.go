@@ -3,6 +3,7 @@ package message
 import (
 	"time"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/notification"
 )
 
@@ -10,8 +11,9 @@ import (
 type ThrottleConfigBuilder struct {
 	parent *ThrottleConfigBuilder
 
-	msgTypes []notification.MessageType
-	dstTypes []notification.DestType
+	msgTypes   []notification.MessageType
+	dstTypes   []notification.DestType
+	severities []alert.Severity
 
 	rules []builderRules
 	max   time.Duration
@@ -29,8 +31,9 @@ func (b *ThrottleConfigBuilder) WithMsgTypes(msgTypes ...notification.MessageTyp
 	return &ThrottleConfigBuilder{
 		parent: b.top(),
 
-		msgTypes: msgTypes,
-		dstTypes: b.dstTypes,
+		msgTypes:   msgTypes,
+		dstTypes:   b.dstTypes,
+		severities: b.severities,
 	}
 }
 
@@ -39,10 +42,23 @@ func (b *ThrottleConfigBuilder) WithDestTypes(destTypes ...notification.DestType
 	return &ThrottleConfigBuilder{
 		parent: b.top(),
 
-		msgTypes: b.msgTypes,
-		dstTypes: destTypes,
+		msgTypes:   b.msgTypes,
+		dstTypes:   destTypes,
+		severities: b.severities,
 	}
 }
+
+// WithSeverities allows adding rules for messages matching at least one alert Severity.
+func (b *ThrottleConfigBuilder) WithSeverities(severities ...alert.Severity) *ThrottleConfigBuilder {
+	return &ThrottleConfigBuilder{
+		parent: b.top(),
+
+		msgTypes:   b.msgTypes,
+		dstTypes:   b.dstTypes,
+		severities: severities,
+	}
+}
+
 func (b *ThrottleConfigBuilder) setMax(rules []ThrottleRule) {
 	for _, r := range rules {
 		if r.Per > b.max {
@@ -54,9 +70,10 @@ func (b *ThrottleConfigBuilder) setMax(rules []ThrottleRule) {
 // AddRules will append a set of rules for the current filter (if any).
 func (b *ThrottleConfigBuilder) AddRules(rules []ThrottleRule) {
 	b.top().rules = append(b.top().rules, builderRules{
-		msgTypes: b.msgTypes,
-		dstTypes: b.dstTypes,
-		rules:    rules,
+		msgTypes:   b.msgTypes,
+		dstTypes:   b.dstTypes,
+		severities: b.severities,
+		rules:      rules,
 	})
 	b.top().setMax(rules)
 }
@@ -72,9 +89,10 @@ func (b *ThrottleConfigBuilder) Config() ThrottleConfig {
 }
 
 type builderRules struct {
-	msgTypes []notification.MessageType
-	dstTypes []notification.DestType
-	rules    []ThrottleRule
+	msgTypes   []notification.MessageType
+	dstTypes   []notification.DestType
+	severities []alert.Severity
+	rules      []ThrottleRule
 }
 
 func (r builderRules) match(msg Message) bool {
@@ -100,8 +118,21 @@ func (r builderRules) match(msg Message) bool {
 		destMatch = true
 		break
 	}
+	if !destMatch {
+		return false
+	}
+
+	sevMatch := len(r.severities) == 0
+	for _, sev := range r.severities {
+		if sev != msg.Severity {
+			continue
+		}
+
+		sevMatch = true
+		break
+	}
 
-	return destMatch
+	return sevMatch
 }
 
 type builderConfig struct {
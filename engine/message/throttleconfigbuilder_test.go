@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/notification"
 )
@@ -75,3 +76,23 @@ func TestThrottleConfigBuilder(t *testing.T) {
 	)
 
 }
+
+func TestThrottleConfigBuilder_Severities(t *testing.T) {
+	var b message.ThrottleConfigBuilder
+
+	b.WithDestTypes(notification.DestTypeSMS).AddRules([]message.ThrottleRule{{Count: 2, Per: 3 * time.Minute}})
+
+	b.WithDestTypes(notification.DestTypeSMS).WithSeverities(alert.SeverityLow).AddRules([]message.ThrottleRule{{Count: 1, Per: time.Hour}})
+
+	cfg := b.Config()
+
+	assert.EqualValues(t,
+		[]message.ThrottleRule{{Count: 2, Per: 3 * time.Minute}},
+		cfg.Rules(message.Message{Dest: notification.Dest{Type: notification.DestTypeSMS}, Severity: alert.SeverityHigh}),
+	)
+	assert.EqualValues(t,
+		[]message.ThrottleRule{{Count: 2, Per: 3 * time.Minute}, {Count: 1, Per: time.Hour}},
+		cfg.Rules(message.Message{Dest: notification.Dest{Type: notification.DestTypeSMS}, Severity: alert.SeverityLow}),
+	)
+	assert.Empty(t, cfg.Rules(message.Message{Dest: notification.Dest{Type: notification.DestTypeVoice}, Severity: alert.SeverityLow}))
+}
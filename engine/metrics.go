@@ -19,4 +19,11 @@ var (
 		Name:      "cycle_duration_seconds",
 		Help:      "Engine cycle duration in seconds by module.",
 	}, []string{"module"})
+
+	metricQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "goalert",
+		Subsystem: "engine",
+		Name:      "queue_depth",
+		Help:      "Number of items waiting to be processed by module.",
+	}, []string{"module"})
 )
@@ -15,8 +15,9 @@ import (
 type DB struct {
 	lock *processinglock.Lock
 
-	queueMessages *sql.Stmt
-	log           *alertlog.Store
+	queueMessages  *sql.Stmt
+	queueFallbacks *sql.Stmt
+	log            *alertlog.Store
 }
 
 // Name returns the name of the module.
@@ -52,10 +53,23 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					user_id,
 					started_at,
 					last_tick
-				from notification_policy_cycles
+				from notification_policy_cycles cycle
 				where
-					last_tick isnull or
-					last_tick < now() - '1 minute'::interval
+					(
+						last_tick isnull or
+						last_tick < now() - '1 minute'::interval
+					) and
+					-- hold medium and low severity alerts during the user's quiet hours; the cycle
+					-- is not ticked, so any rules that came due are sent once quiet hours end
+					not exists (
+						select null
+						from alerts a
+						where
+							a.id = cycle.alert_id and
+							a.status = 'triggered' and
+							a.severity in ('medium', 'low') and
+							fn_user_quiet_hours_active(cycle.user_id)
+					)
 				order by
 					last_tick nulls first,
 					started_at
@@ -99,6 +113,7 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				join services svc on svc.id = a.service_id
 				join user_notification_rules rule on
 					rule.user_id = cycle.user_id and
+					(rule.severities isnull or a.severity = any(rule.severities)) and
 					(
 						cycle.last_tick isnull or
 						concat(rule.delay_minutes,' minutes')::interval > (cycle.last_tick - cycle.started_at)
@@ -116,5 +131,61 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			)
 			select user_id, alert_id from no_first_notif_sent
 		`),
+
+		// add messages for the next contact method in a notification rule's fallback chain, once the
+		// message to the previous one has failed (with no retry pending), or was sent at least the
+		// fallback's delay ago (since the alert is still unacknowledged).
+		//
+		// The first fallback follows the rule's own message, which is not itself a fallback message.
+		// Like rules, fallbacks for medium and low severity alerts are held during quiet hours.
+		queueFallbacks: p.P(`
+			insert into outgoing_messages (
+				message_type,
+				contact_method_id,
+				alert_id,
+				cycle_id,
+				user_id,
+				service_id,
+				escalation_policy_id,
+				fallback_id
+			)
+			select distinct on (cycle.id, fb.id)
+				cast('alert_notification' as enum_outgoing_messages_type),
+				fb.contact_method_id,
+				cycle.alert_id,
+				cycle.id,
+				cycle.user_id,
+				a.service_id,
+				svc.escalation_policy_id,
+				fb.id
+			from user_notification_rule_fallbacks fb
+			join user_notification_rules rule on rule.id = fb.rule_id
+			join notification_policy_cycles cycle on cycle.user_id = rule.user_id
+			join alerts a on
+				a.id = cycle.alert_id and
+				a.status = 'triggered' and
+				(a.severity not in ('medium', 'low') or not fn_user_quiet_hours_active(cycle.user_id))
+			join services svc on svc.id = a.service_id
+			left join user_notification_rule_fallbacks prev_fb on
+				prev_fb.rule_id = fb.rule_id and
+				prev_fb.position = fb.position - 1
+			join outgoing_messages prev on
+				prev.cycle_id = cycle.id and
+				prev.contact_method_id = coalesce(prev_fb.contact_method_id, rule.contact_method_id) and
+				(
+					(prev_fb.id isnull and prev.fallback_id isnull) or
+					prev.fallback_id = prev_fb.id
+				)
+			where
+				not exists (
+					select null
+					from outgoing_messages om
+					where om.cycle_id = cycle.id and om.fallback_id = fb.id
+				) and (
+					(prev.last_status = 'failed' and prev.next_retry_at isnull) or
+					prev.sent_at <= now() - make_interval(mins => fb.delay_minutes)
+				)
+			on conflict (cycle_id, fallback_id) where fallback_id notnull do nothing
+		`),
 	}, p.Err
 }
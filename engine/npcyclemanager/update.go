@@ -18,7 +18,7 @@ func (db *DB) UpdateAll(ctx context.Context) error {
 
 // UpdateOneAlert will update and cleanup all notification cycles for the given alert.
 func (db *DB) UpdateOneAlert(ctx context.Context, alertID int) error {
-	ctx = log.WithField(ctx, "AlertID", alertID)
+	ctx = log.WithField(ctx, log.FieldAlertID, alertID)
 	return db.update(ctx, false, &alertID)
 }
 
@@ -56,6 +56,13 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		data = append(data, rec)
 	}
 
+	rows.Close()
+
+	_, err = tx.StmtContext(ctx, db.queueFallbacks).ExecContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "queue fallback messages")
+	}
+
 	for _, rec := range data {
 		logCtx := permission.UserSourceContext(ctx, rec.userID, permission.RoleUser, &permission.SourceInfo{
 			Type: permission.SourceTypeContactMethod,
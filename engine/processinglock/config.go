@@ -8,6 +8,10 @@ import (
 type Config struct {
 	Type    Type
 	Version int // Version must match the value in engine_processing_versions exactly or no lock will be obtained.
+
+	// Shared allows multiple instances to hold the lock at the same time, for
+	// modules that partition their work between instances.
+	Shared bool
 }
 
 // String returns the string representation of Config.
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/target/goalert/util/sqlutil"
 )
 
 // Conn allows using locked transactions over a single connection.
@@ -55,5 +58,21 @@ func (c *Conn) ExecWithoutLock(ctx context.Context, query string, args ...interf
 	return c.conn.ExecContext(ctx, query, args...)
 }
 
+// QueryRowWithoutLock will run a single-row query directly on the connection (no Tx or locking), scanning
+// the result into dest.
+func (c *Conn) QueryRowWithoutLock(ctx context.Context, query string, args []interface{}, dest ...interface{}) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.conn.QueryRowContext(ctx, query, args...).Scan(dest...)
+}
+
+// SendBatch will send all statements in b with a single round trip. Statements are part of any
+// transaction started with BeginTx that has not been committed or rolled back.
+func (c *Conn) SendBatch(ctx context.Context, b *pgx.Batch) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return sqlutil.SendBatch(ctx, c.conn, b)
+}
+
 // Close returns the connection to the pool.
 func (c *Conn) Close() error { return c.conn.Close() }
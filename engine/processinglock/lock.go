@@ -27,6 +27,10 @@ type txBeginner interface {
 // NewLock will return a new Lock for the given Config.
 func NewLock(ctx context.Context, db *sql.DB, cfg Config) (*Lock, error) {
 	p := &util.Prepare{Ctx: ctx, DB: db}
+	lockMode := "update"
+	if cfg.Shared {
+		lockMode = "share"
+	}
 	return &Lock{
 		db:          db,
 		cfg:         cfg,
@@ -35,7 +39,7 @@ func NewLock(ctx context.Context, db *sql.DB, cfg Config) (*Lock, error) {
 			select version
 			from engine_processing_versions
 			where type_id = $1
-			for update nowait
+			for ` + lockMode + ` nowait
 		`),
 		loadState: p.P(`select state from engine_processing_versions where type_id = $1 for update nowait`),
 		saveState: p.P(`update engine_processing_versions set state = $2 where type_id = $1`),
@@ -126,6 +130,25 @@ func (l *Lock) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error
 	return l._BeginTx(ctx, l.db, opts)
 }
 
+// BeginConnTx is like BeginTx, but starts the transaction on a dedicated connection that is also returned, so
+// statements can be pipelined within the transaction (e.g., with sqlutil.SendBatch).
+//
+// The connection must be closed once the transaction is finished.
+func (l *Lock) BeginConnTx(ctx context.Context, opts *sql.TxOptions) (*sql.Conn, *sql.Tx, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := l._BeginTx(ctx, conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, tx, nil
+}
+
 // Exec will run ExecContext on the statement, wrapped in a locked transaction.
 func (l *Lock) Exec(ctx context.Context, stmt *sql.Stmt, args ...interface{}) (sql.Result, error) {
 	return l._Exec(ctx, l.db, stmt, args...)
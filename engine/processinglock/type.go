@@ -5,15 +5,21 @@ type Type string
 
 // Recognized types
 const (
-	TypeEscalation   Type = "escalation"
-	TypeHeartbeat    Type = "heartbeat"
-	TypeNPCycle      Type = "np_cycle"
-	TypeRotation     Type = "rotation"
-	TypeSchedule     Type = "schedule"
-	TypeStatusUpdate Type = "status_update"
-	TypeVerify       Type = "verify"
-	TypeMessage      Type = "message"
-	TypeCleanup      Type = "cleanup"
-	TypeMetrics      Type = "metrics"
-	TypeCompat       Type = "compat"
+	TypeEscalation     Type = "escalation"
+	TypeHeartbeat      Type = "heartbeat"
+	TypeNPCycle        Type = "np_cycle"
+	TypeRotation       Type = "rotation"
+	TypeSchedule       Type = "schedule"
+	TypeStatusUpdate   Type = "status_update"
+	TypeVerify         Type = "verify"
+	TypeMessage        Type = "message"
+	TypeCleanup        Type = "cleanup"
+	TypeMetrics        Type = "metrics"
+	TypeCompat         Type = "compat"
+	TypeJira           Type = "jira"
+	TypeServiceNow     Type = "servicenow"
+	TypeStatusCallback Type = "status_callback"
+	TypeStatuspage     Type = "statuspage"
+	TypeSLO            Type = "slo"
+	TypeConference     Type = "conference"
 )
@@ -0,0 +1,142 @@
+package schedulemanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/log"
+)
+
+// cacheVersion identifies the state of the schedule tables. Triggers add a row to schedule_cache_changes
+// for every modifying statement, so any committed change results in a different count or max ID.
+//
+// Old rows are removed by the cleanup manager, which also changes the count, resulting in one extra reload.
+type cacheVersion struct {
+	Count int64
+	MaxID int64
+}
+
+type userRule struct {
+	rule.Rule
+	UserID string
+}
+
+// scheduleCache holds parsed schedule data, rules, and time zones between cycles, so they are
+// only re-read and parsed after the underlying tables change.
+type scheduleCache struct {
+	version cacheVersion
+
+	data    map[string]*schedule.Data
+	rawData map[string]json.RawMessage
+	rules   []userRule
+	tz      map[string]*time.Location
+}
+
+// loadCache will read all schedule data, rules, and time zones within tx.
+func (db *DB) loadCache(ctx context.Context, tx *sql.Tx, version cacheVersion) (*scheduleCache, error) {
+	c := &scheduleCache{
+		version: version,
+		data:    make(map[string]*schedule.Data),
+		rawData: make(map[string]json.RawMessage),
+		tz:      make(map[string]*time.Location),
+	}
+
+	rows, err := tx.StmtContext(ctx, db.data).QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get schedule data")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var data json.RawMessage
+		err = rows.Scan(&id, &data)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan schedule data")
+		}
+		c.rawData[id] = data
+
+		var sData schedule.Data
+		err = json.Unmarshal(data, &sData)
+		if err != nil {
+			log.Log(log.WithField(ctx, "ScheduleID", id), errors.Wrap(err, "unmarshal schedule data"))
+			continue
+		}
+		c.data[id] = &sData
+	}
+
+	rows, err = tx.Stmt(db.rules).QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get rules")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r userRule
+		err = rows.Scan(
+			&r.ScheduleID,
+			&r.WeekdayFilter,
+			&r.Start,
+			&r.End,
+			&r.UserID,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan rule")
+		}
+
+		c.rules = append(c.rules, r)
+	}
+
+	rows, err = tx.StmtContext(ctx, db.schedTZ).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch schedule TZ info: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, tzName string
+		err = rows.Scan(&id, &tzName)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule TZ info: %w", err)
+		}
+		c.tz[id], err = util.LoadLocation(tzName)
+		if err != nil {
+			return nil, fmt.Errorf("load TZ info '%s' for schedule '%s': %w", tzName, id, err)
+		}
+	}
+
+	return c, nil
+}
+
+// currentCache returns the cached schedule state, reloading it if anything changed since it was read.
+func (db *DB) currentCache(ctx context.Context, tx *sql.Tx) (*scheduleCache, error) {
+	var version cacheVersion
+	err := tx.StmtContext(ctx, db.cacheVersion).QueryRowContext(ctx).Scan(&version.Count, &version.MaxID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cache version")
+	}
+
+	if db.cache != nil && db.cache.version == version {
+		// Schedule data is updated at the end of the cycle, so the rows are still locked as they would be by
+		// loadCache; concurrent updates (e.g., from the API) wait for this transaction, and an update committed
+		// after our snapshot was taken fails this transaction rather than being overwritten.
+		_, err = tx.StmtContext(ctx, db.lockData).ExecContext(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "lock schedule data")
+		}
+		metricCacheTotal.WithLabelValues("hit").Inc()
+		return db.cache, nil
+	}
+	metricCacheTotal.WithLabelValues("miss").Inc()
+
+	db.cache, err = db.loadCache(ctx, tx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.cache, nil
+}
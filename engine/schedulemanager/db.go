@@ -8,22 +8,60 @@ import (
 	"github.com/target/goalert/util"
 )
 
+// On-call resolution queries, sent as batches to minimize round trips.
+const (
+	currentTimeQuery = `select now()`
+
+	overridesQuery = `
+	select
+		add_user_id,
+		remove_user_id,
+		tgt_schedule_id
+	from user_overrides
+	where now() between start_time and end_time
+	`
+
+	getOnCallQuery = `
+	select schedule_id, user_id
+	from schedule_on_call_users
+	where
+		end_time isnull
+	`
+
+	startOnCallQuery = `
+	insert into schedule_on_call_users (schedule_id, start_time, user_id)
+	select $1, now(), $2 from users where id = $2
+	`
+
+	endOnCallQuery = `
+	update schedule_on_call_users
+	set end_time = now()
+	where
+		schedule_id = $1 and
+		user_id = $2 and
+		end_time isnull
+	`
+)
+
 // DB will manage schedules and schedule rules in Postgres.
 type DB struct {
 	lock *processinglock.Lock
 
-	overrides   *sql.Stmt
 	rules       *sql.Stmt
-	currentTime *sql.Stmt
-	getOnCall   *sql.Stmt
-	endOnCall   *sql.Stmt
-	startOnCall *sql.Stmt
+	getShadows  *sql.Stmt
+	endShadow   *sql.Stmt
+	startShadow *sql.Stmt
 	data        *sql.Stmt
+	lockData    *sql.Stmt
 	updateData  *sql.Stmt
 
 	schedTZ *sql.Stmt
 
 	scheduleOnCallNotification *sql.Stmt
+
+	cacheVersion *sql.Stmt
+
+	cache *scheduleCache
 }
 
 // Name returns the name of the module.
@@ -33,7 +71,7 @@ func (db *DB) Name() string { return "Engine.ScheduleManager" }
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeSchedule,
-		Version: 3,
+		Version: 4,
 	})
 	if err != nil {
 		return nil, err
@@ -43,15 +81,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	return &DB{
 		lock: lock,
 
-		overrides: p.P(`
-			select
-				add_user_id,
-				remove_user_id,
-				tgt_schedule_id
-			from user_overrides
-			where now() between start_time and end_time
-		`),
 		data:       p.P(`select schedule_id, data from schedule_data where data notnull for update`),
+		lockData:   p.P(`select schedule_id from schedule_data where data notnull for update`),
 		updateData: p.P(`update schedule_data set data = $2 where schedule_id = $1`),
 		schedTZ:    p.P(`select id, time_zone from schedules`),
 		rules: p.P(`
@@ -75,18 +106,18 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			where
 				coalesce(rule.tgt_user_id, part.user_id) notnull
 		`),
-		getOnCall: p.P(`
+		getShadows: p.P(`
 			select schedule_id, user_id
-			from schedule_on_call_users
+			from schedule_on_call_shadows
 			where
 				end_time isnull
 		`),
-		startOnCall: p.P(`
-			insert into schedule_on_call_users (schedule_id, start_time, user_id)
+		startShadow: p.P(`
+			insert into schedule_on_call_shadows (schedule_id, start_time, user_id)
 			select $1, now(), $2 from users where id = $2
 		`),
-		endOnCall: p.P(`
-			update schedule_on_call_users
+		endShadow: p.P(`
+			update schedule_on_call_shadows
 			set end_time = now()
 			where
 				schedule_id = $1 and
@@ -96,6 +127,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		scheduleOnCallNotification: p.P(`
 			insert into outgoing_messages (id, message_type, channel_id, schedule_id) values ($1, 'schedule_on_call_notification', $2, $3)
 		`),
-		currentTime: p.P(`select now()`),
+
+		cacheVersion: p.P(`select count(*), coalesce(max(id), 0) from schedule_cache_changes`),
 	}, p.Err
 }
@@ -0,0 +1,13 @@
+package schedulemanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricCacheTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "engine",
+	Name:      "schedule_cache_total",
+	Help:      "Total number of schedule cache lookups by result (hit or miss).",
+}, []string{"result"})
@@ -0,0 +1,69 @@
+package schedulemanager
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/schedule"
+)
+
+// updateShadows will record the start and end of shadow shifts based on the current on-call users
+// for each schedule.
+func (db *DB) updateShadows(ctx context.Context, tx *sql.Tx, now time.Time, scheduleData map[string]*schedule.Data, onCall map[string][]string) error {
+	type shadow struct {
+		ScheduleID string
+		UserID     string
+	}
+
+	rows, err := tx.StmtContext(ctx, db.getShadows).QueryContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get shadows")
+	}
+	defer rows.Close()
+
+	oldShadows := make(map[shadow]bool)
+	for rows.Next() {
+		var s shadow
+		err = rows.Scan(&s.ScheduleID, &s.UserID)
+		if err != nil {
+			return errors.Wrap(err, "scan shadow user")
+		}
+		oldShadows[s] = true
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "read shadows")
+	}
+
+	newShadows := make(map[shadow]bool)
+	for id, data := range scheduleData {
+		for _, userID := range data.ActiveShadows(now, onCall[id]) {
+			newShadows[shadow{ScheduleID: id, UserID: userID}] = true
+		}
+	}
+
+	start := tx.StmtContext(ctx, db.startShadow)
+	for s := range newShadows {
+		if oldShadows[s] {
+			continue
+		}
+		_, err = start.ExecContext(ctx, s.ScheduleID, s.UserID)
+		if err != nil && !isScheduleDeleted(err) {
+			return errors.Wrap(err, "record shadow start")
+		}
+	}
+
+	end := tx.StmtContext(ctx, db.endShadow)
+	for s := range oldShadows {
+		if newShadows[s] {
+			continue
+		}
+		_, err = end.ExecContext(ctx, s.ScheduleID, s.UserID)
+		if err != nil {
+			return errors.Wrap(err, "record shadow end")
+		}
+	}
+
+	return nil
+}
@@ -3,19 +3,16 @@ package schedulemanager
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
-	"fmt"
 	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
-	"github.com/target/goalert/schedule/rule"
-	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/jsonutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
@@ -28,138 +25,76 @@ func (db *DB) UpdateAll(ctx context.Context) error {
 		return err
 	}
 	err = db.update(ctx)
+	if err != nil {
+		// cached data may have been modified before the transaction was rolled back
+		db.cache = nil
+	}
 	return err
 }
 
 func (db *DB) update(ctx context.Context) error {
-	tx, err := db.lock.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	conn, tx, err := db.lock.BeginConnTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
 		return errors.Wrap(err, "start transaction")
 	}
+	defer conn.Close()
 	defer sqlutil.Rollback(ctx, "schedule manager", tx)
 
 	log.Debugf(ctx, "Updating schedule rules.")
 
-	var now time.Time
-	err = tx.Stmt(db.currentTime).QueryRowContext(ctx).Scan(&now)
-	if err != nil {
-		return errors.Wrap(err, "get DB time")
-	}
-
-	scheduleData := make(map[string]*schedule.Data)
-	rawScheduleData := make(map[string]json.RawMessage)
-	rows, err := tx.StmtContext(ctx, db.data).QueryContext(ctx)
-	if err != nil {
-		return errors.Wrap(err, "get schedule data")
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var id string
-		var data json.RawMessage
-		err = rows.Scan(&id, &data)
-		if err != nil {
-			return errors.Wrap(err, "scan schedule data")
-		}
-		rawScheduleData[id] = data
-
-		var sData schedule.Data
-		err = json.Unmarshal(data, &sData)
-		if err != nil {
-			log.Log(log.WithField(ctx, "ScheduleID", id), errors.Wrap(err, "unmarshal schedule data"))
-			continue
-		}
-		scheduleData[id] = &sData
-	}
-
-	rows, err = tx.Stmt(db.overrides).QueryContext(ctx)
-	if err != nil {
-		return errors.Wrap(err, "get active overrides")
+	type onCall struct {
+		UserID     string
+		ScheduleID string
 	}
-	defer rows.Close()
 
+	var now time.Time
 	var overrides []override.UserOverride
-	for rows.Next() {
-		var o override.UserOverride
-		var schedTgt sql.NullString
-		var add, rem sql.NullString
-		err = rows.Scan(&add, &rem, &schedTgt)
-		if err != nil {
-			return errors.Wrap(err, "scan override")
-		}
-		o.AddUserID = add.String
-		o.RemoveUserID = rem.String
-		if !schedTgt.Valid {
-			continue
-		}
-		o.Target = assignment.ScheduleTarget(schedTgt.String)
-		overrides = append(overrides, o)
-	}
-
-	rows, err = tx.Stmt(db.rules).QueryContext(ctx)
-	if err != nil {
-		return errors.Wrap(err, "get rules")
-	}
-	defer rows.Close()
-
-	type userRule struct {
-		rule.Rule
-		UserID string
-	}
-
-	var rules []userRule
-	for rows.Next() {
-		var r userRule
-		err = rows.Scan(
-			&r.ScheduleID,
-			&r.WeekdayFilter,
-			&r.Start,
-			&r.End,
-			&r.UserID,
-		)
-		if err != nil {
-			return errors.Wrap(err, "scan rule")
-		}
-
-		rules = append(rules, r)
-	}
+	oldOnCall := make(map[onCall]bool)
 
-	rows, err = tx.StmtContext(ctx, db.schedTZ).QueryContext(ctx)
-	if err != nil {
-		return fmt.Errorf("fetch schedule TZ info: %w", err)
-	}
-	defer rows.Close()
-	tz := make(map[string]*time.Location)
-	for rows.Next() {
-		var id, tzName string
-		err = rows.Scan(&id, &tzName)
-		if err != nil {
-			return fmt.Errorf("scan schedule TZ info: %w", err)
+	var b pgx.Batch
+	b.Queue(currentTimeQuery).QueryRow(func(row pgx.Row) error {
+		return errors.Wrap(row.Scan(&now), "get DB time")
+	})
+	b.Queue(overridesQuery).Query(func(rows pgx.Rows) error {
+		for rows.Next() {
+			var o override.UserOverride
+			var schedTgt sql.NullString
+			var add, rem sql.NullString
+			err := rows.Scan(&add, &rem, &schedTgt)
+			if err != nil {
+				return errors.Wrap(err, "scan override")
+			}
+			o.AddUserID = add.String
+			o.RemoveUserID = rem.String
+			if !schedTgt.Valid {
+				continue
+			}
+			o.Target = assignment.ScheduleTarget(schedTgt.String)
+			overrides = append(overrides, o)
 		}
-		tz[id], err = util.LoadLocation(tzName)
-		if err != nil {
-			return fmt.Errorf("load TZ info '%s' for schedule '%s': %w", tzName, id, err)
+		return errors.Wrap(rows.Err(), "get active overrides")
+	})
+	b.Queue(getOnCallQuery).Query(func(rows pgx.Rows) error {
+		var oc onCall
+		for rows.Next() {
+			err := rows.Scan(&oc.ScheduleID, &oc.UserID)
+			if err != nil {
+				return errors.Wrap(err, "scan on call user")
+			}
+			oldOnCall[oc] = true
 		}
-	}
-
-	rows, err = tx.Stmt(db.getOnCall).QueryContext(ctx)
+		return errors.Wrap(rows.Err(), "get on call")
+	})
+	err = sqlutil.SendBatch(ctx, conn, &b)
 	if err != nil {
-		return errors.Wrap(err, "get on call")
+		return err
 	}
-	defer rows.Close()
 
-	type onCall struct {
-		UserID     string
-		ScheduleID string
-	}
-	oldOnCall := make(map[onCall]bool)
-	var oc onCall
-	for rows.Next() {
-		err = rows.Scan(&oc.ScheduleID, &oc.UserID)
-		if err != nil {
-			return errors.Wrap(err, "scan on call user")
-		}
-		oldOnCall[oc] = true
+	c, err := db.currentCache(ctx, tx)
+	if err != nil {
+		return err
 	}
+	scheduleData, rawScheduleData, rules, tz := c.data, c.rawData, c.rules, c.tz
 
 	// Calculate new state
 	newOnCall := make(map[onCall]bool, len(rules))
@@ -210,30 +145,35 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 
-	start := tx.Stmt(db.startOnCall)
-
 	changedSchedules := make(map[string]struct{})
+	var shifts pgx.Batch
 	for oc := range newOnCall {
 		// not on call in DB, but are now
 		if !oldOnCall[oc] {
 			changedSchedules[oc.ScheduleID] = struct{}{}
-			_, err = start.ExecContext(ctx, oc.ScheduleID, oc.UserID)
-			if err != nil && !isScheduleDeleted(err) {
-				return errors.Wrap(err, "record shift start")
-			}
+			shifts.Queue(startOnCallQuery, oc.ScheduleID, oc.UserID)
 		}
 	}
-	end := tx.Stmt(db.endOnCall)
 	for oc := range oldOnCall {
 		// on call in DB, but no longer
 		if !newOnCall[oc] {
 			changedSchedules[oc.ScheduleID] = struct{}{}
-			_, err = end.ExecContext(ctx, oc.ScheduleID, oc.UserID)
-			if err != nil {
-				return errors.Wrap(err, "record shift end")
-			}
+			shifts.Queue(endOnCallQuery, oc.ScheduleID, oc.UserID)
 		}
 	}
+	err = sqlutil.SendBatch(ctx, conn, &shifts)
+	if err != nil && !isScheduleDeleted(err) {
+		return errors.Wrap(err, "record shift changes")
+	}
+
+	onCallUsers := make(map[string][]string)
+	for oc := range newOnCall {
+		onCallUsers[oc.ScheduleID] = append(onCallUsers[oc.ScheduleID], oc.UserID)
+	}
+	err = db.updateShadows(ctx, tx, now, scheduleData, onCallUsers)
+	if err != nil {
+		return err
+	}
 
 	// Notify changed schedules
 	needsOnCallNotification := make(map[string][]uuid.UUID)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert/alertlog"
@@ -11,10 +12,64 @@ import (
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// alertTraceLinks returns a link to the trace of the request that created the alert for msg, if any.
+func (p *Engine) alertTraceLinks(ctx context.Context, msg *message.Message) []trace.Link {
+	if msg.AlertID == 0 || !trace.SpanFromContext(ctx).IsRecording() {
+		return nil
+	}
+
+	e, err := p.cfg.AlertLogStore.FindLatestByType(ctx, msg.AlertID, alertlog.TypeCreated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "lookup alert created log entry"))
+		return nil
+	}
+
+	meta, ok := e.Meta(ctx).(*alertlog.CreatedMetaData)
+	if !ok || meta == nil {
+		return nil
+	}
+	sc := meta.SpanContext()
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []trace.Link{{SpanContext: sc, Attributes: []attribute.KeyValue{attribute.Int("alert.id", msg.AlertID)}}}
+}
+
+// sendMessage will send msg, recording a span linked to the request that created the alert (if any).
 func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notification.SendResult, error) {
-	ctx = log.WithField(ctx, "CallbackID", msg.ID)
+	ctx, span := tracer.Start(ctx, "Engine.SendMessage",
+		trace.WithAttributes(
+			attribute.String("message.id", msg.ID),
+			attribute.String("message.type", msg.Type.String()),
+			attribute.String("message.dest_type", msg.Dest.Type.String()),
+		),
+		trace.WithLinks(p.alertTraceLinks(ctx, msg)...),
+	)
+	res, err := p._sendMessage(ctx, msg)
+	if err == nil && res != nil && res.State == notification.StateFailedPerm {
+		span.SetStatus(codes.Error, res.Details)
+	}
+	endSpan(span, err)
+	p.publishSent(ctx, msg, res, err)
+
+	return res, err
+}
+
+// messageContext returns the context used to send msg.
+func messageContext(ctx context.Context, msg *message.Message) context.Context {
+	ctx = log.WithField(ctx, log.FieldMessageID, msg.ID)
+	if msg.RequestID != "" {
+		ctx = log.WithRequestID(ctx, msg.RequestID)
+	}
 
 	if msg.Dest.Type.IsUserCM() {
 		ctx = permission.UserSourceContext(ctx, msg.UserID, permission.RoleUser, &permission.SourceInfo{
@@ -29,17 +84,20 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		})
 	}
 
-	var notifMsg notification.Message
-	var isFirstAlertMessage bool
+	return ctx
+}
+
+// buildMessage returns the notification for msg. If res is non-nil, msg should not be sent and res is its result.
+func (p *Engine) buildMessage(ctx context.Context, msg *message.Message) (notifMsg notification.Message, res *notification.SendResult, err error) {
 	switch msg.Type {
 	case notification.MessageTypeAlertBundle:
 		name, count, err := p.a.ServiceInfo(ctx, msg.ServiceID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup service info")
+			return nil, nil, errors.Wrap(err, "lookup service info")
 		}
 		if count == 0 {
 			// already acked/closed, don't send bundled notification
-			return &notification.SendResult{
+			return nil, &notification.SendResult{
 				ID: msg.ID,
 				Status: notification.Status{
 					Details: "alerts acked/closed before message sent",
@@ -57,15 +115,15 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 	case notification.MessageTypeAlert:
 		name, _, err := p.a.ServiceInfo(ctx, msg.ServiceID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup service info")
+			return nil, nil, errors.Wrap(err, "lookup service info")
 		}
 		a, err := p.a.FindOne(ctx, msg.AlertID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup alert")
+			return nil, nil, errors.Wrap(err, "lookup alert")
 		}
 		stat, err := p.cfg.NotificationStore.OriginalMessageStatus(ctx, msg.AlertID, msg.Dest)
 		if err != nil {
-			return nil, fmt.Errorf("lookup original message: %w", err)
+			return nil, nil, fmt.Errorf("lookup original message: %w", err)
 		}
 		if stat != nil && stat.ID == msg.ID {
 			// set to nil if it's the current message
@@ -82,22 +140,21 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 
 			OriginalStatus: stat,
 		}
-		isFirstAlertMessage = stat == nil
 	case notification.MessageTypeAlertStatus:
 		e, err := p.cfg.AlertLogStore.FindOne(ctx, msg.AlertLogID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup alert log entry")
+			return nil, nil, errors.Wrap(err, "lookup alert log entry")
 		}
 		a, err := p.cfg.AlertStore.FindOne(ctx, msg.AlertID)
 		if err != nil {
-			return nil, fmt.Errorf("lookup original alert: %w", err)
+			return nil, nil, fmt.Errorf("lookup original alert: %w", err)
 		}
 		stat, err := p.cfg.NotificationStore.OriginalMessageStatus(ctx, msg.AlertID, msg.Dest)
 		if err != nil {
-			return nil, fmt.Errorf("lookup original message: %w", err)
+			return nil, nil, fmt.Errorf("lookup original message: %w", err)
 		}
 		if stat == nil {
-			return nil, fmt.Errorf("could not find original notification for alert %d to %s", msg.AlertID, msg.Dest.String())
+			return nil, nil, fmt.Errorf("could not find original notification for alert %d to %s", msg.AlertID, msg.Dest.String())
 		}
 
 		var status notification.AlertState
@@ -128,7 +185,7 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 	case notification.MessageTypeVerification:
 		code, err := p.cfg.NotificationStore.Code(ctx, msg.VerifyID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup verification code")
+			return nil, nil, errors.Wrap(err, "lookup verification code")
 		}
 		notifMsg = notification.Verification{
 			Dest:       msg.Dest,
@@ -138,11 +195,11 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 	case notification.MessageTypeScheduleOnCallUsers:
 		users, err := p.cfg.OnCallStore.OnCallUsersBySchedule(ctx, msg.ScheduleID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup on call users by schedule")
+			return nil, nil, errors.Wrap(err, "lookup on call users by schedule")
 		}
 		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
 		if err != nil {
-			return nil, errors.Wrap(err, "lookup schedule by id")
+			return nil, nil, errors.Wrap(err, "lookup schedule by id")
 		}
 
 		var onCallUsers []notification.User
@@ -164,14 +221,41 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		}
 	default:
 		log.Log(ctx, errors.New("SEND NOT IMPLEMENTED FOR MESSAGE TYPE"))
-		return &notification.SendResult{ID: msg.ID, Status: notification.Status{State: notification.StateFailedPerm}}, nil
+		return nil, &notification.SendResult{ID: msg.ID, Status: notification.Status{State: notification.StateFailedPerm}}, nil
+	}
+
+	return notifMsg, nil, nil
+}
+
+// findSent checks with the provider whether msg was sent by a request with an unknown outcome made at or after since.
+func (p *Engine) findSent(ctx context.Context, msg *message.Message, since time.Time) (*notification.SendResult, error) {
+	ctx = messageContext(ctx, msg)
+	notifMsg, res, err := p.buildMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if res != nil {
+		// would not be sent now, so it could not have been identified
+		return nil, nil
+	}
+
+	return p.cfg.NotificationManager.FindSent(ctx, notifMsg, since)
+}
+
+func (p *Engine) _sendMessage(ctx context.Context, msg *message.Message) (*notification.SendResult, error) {
+	ctx = messageContext(ctx, msg)
+	notifMsg, res, err := p.buildMessage(ctx, msg)
+	if err != nil || res != nil {
+		return res, err
 	}
+	alertMsg, isAlert := notifMsg.(notification.Alert)
+	isFirstAlertMessage := isAlert && alertMsg.OriginalStatus == nil
 
 	meta := alertlog.NotificationMetaData{
 		MessageID: msg.ID,
 	}
 
-	res, err := p.cfg.NotificationManager.SendMessage(ctx, notifMsg)
+	res, err = p.cfg.NotificationManager.SendMessage(ctx, notifMsg)
 	if err != nil {
 		return nil, err
 	}
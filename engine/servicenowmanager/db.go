@@ -0,0 +1,104 @@
+package servicenowmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/servicenow"
+	"github.com/target/goalert/util"
+)
+
+// DB keeps ServiceNow incidents in sync with alerts on configured services.
+type DB struct {
+	lock *processinglock.Lock
+
+	client *servicenow.Client
+
+	pendingCreate *sql.Stmt
+	pendingUpdate *sql.Stmt
+	setIncident   *sql.Stmt
+	createFailed  *sql.Stmt
+	setSynced     *sql.Stmt
+	updateFailed  *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.ServiceNowManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeServiceNow,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		client: &servicenow.Client{},
+
+		// Only alerts created after the service was configured are considered, so enabling
+		// the integration doesn't open incidents for every open alert.
+		pendingCreate: p.P(`
+			select
+				a.id, a.summary, a.details, a.service_id, svc.name,
+				cfg.assignment_group, cfg.category, cfg.caller_id, cfg.impact, cfg.urgency
+			from alerts a
+			join service_servicenow_configs cfg on cfg.service_id = a.service_id
+			join services svc on svc.id = a.service_id
+			left join alert_servicenow_incidents inc on inc.alert_id = a.id
+			where
+				a.status != 'closed' and
+				a.created_at >= cfg.created_at and
+				(
+					inc.alert_id isnull or
+					(inc.sys_id isnull and inc.attempts < $1 and inc.last_attempt < now() - $2::interval)
+				)
+			order by a.id
+			limit 10
+		`),
+
+		// Incidents continue to be updated if the service config is removed.
+		pendingUpdate: p.P(`
+			select inc.alert_id, inc.sys_id, a.status, coalesce(cfg.resolve_code, '')
+			from alert_servicenow_incidents inc
+			join alerts a on a.id = inc.alert_id
+			left join service_servicenow_configs cfg on cfg.service_id = a.service_id
+			where
+				inc.sys_id notnull and
+				not inc.closed and
+				(a.status = 'closed' or (a.status = 'active' and not inc.acked)) and
+				inc.attempts < $1 and
+				(inc.last_error = '' or inc.last_attempt < now() - $2::interval)
+			order by inc.alert_id
+			limit 10
+		`),
+		setIncident: p.P(`
+			insert into alert_servicenow_incidents (alert_id, sys_id, number, last_attempt)
+			values ($1, $2, $3, now())
+			on conflict (alert_id) do update
+			set sys_id = $2, number = $3, attempts = 0, last_attempt = now(), last_error = ''
+		`),
+		createFailed: p.P(`
+			insert into alert_servicenow_incidents (alert_id, attempts, last_attempt, last_error)
+			values ($1, 1, now(), $2)
+			on conflict (alert_id) do update
+			set attempts = alert_servicenow_incidents.attempts + 1, last_attempt = now(), last_error = $2
+		`),
+		setSynced: p.P(`
+			update alert_servicenow_incidents
+			set acked = true, closed = $2, attempts = 0, last_attempt = now(), last_error = ''
+			where alert_id = $1
+		`),
+		updateFailed: p.P(`
+			update alert_servicenow_incidents
+			set attempts = attempts + 1, last_attempt = now(), last_error = $2
+			where alert_id = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,195 @@
+package servicenowmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/servicenow"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// maxAttempts is the number of times a failed request is attempted before giving up.
+	maxAttempts = 10
+
+	// retryDelay is the minimum time between attempts for a failed request.
+	retryDelay = 5 * time.Minute
+
+	// minRemaining is the time that must remain before the module deadline to start another request,
+	// so results are always committed, rather than rolled back after the incident was created.
+	minRemaining = 12 * time.Second
+)
+
+// UpdateAll will open ServiceNow incidents for new alerts and update incidents for acknowledged and closed alerts.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.ServiceNow.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Processing ServiceNow incidents.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "servicenow manager", tx)
+
+	var delay pgtype.Interval
+	delay.Microseconds = retryDelay.Microseconds()
+	delay.Status = pgtype.Present
+
+	err = db.createIncidents(ctx, tx, &delay)
+	if err != nil {
+		return fmt.Errorf("create incidents: %w", err)
+	}
+
+	err = db.updateIncidents(ctx, tx, &delay)
+	if err != nil {
+		return fmt.Errorf("update incidents: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func hasTime(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > minRemaining
+}
+
+type pendingIncident struct {
+	ServiceID string
+	Info      servicenow.AlertInfo
+	Cfg       servicenow.ServiceConfig
+}
+
+func (db *DB) createIncidents(ctx context.Context, tx *sql.Tx, delay *pgtype.Interval) error {
+	rows, err := tx.StmtContext(ctx, db.pendingCreate).QueryContext(ctx, maxAttempts, delay)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var pending []pendingIncident
+	for rows.Next() {
+		var p pendingIncident
+		err = rows.Scan(
+			&p.Info.AlertID, &p.Info.Summary, &p.Info.Details, &p.ServiceID, &p.Info.ServiceName,
+			&p.Cfg.AssignmentGroup, &p.Cfg.Category, &p.Cfg.CallerID, &p.Cfg.Impact, &p.Cfg.Urgency,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	for _, p := range pending {
+		if !hasTime(ctx) {
+			break
+		}
+		p.Info.URL = cfg.CallbackURL("/alerts/" + strconv.Itoa(p.Info.AlertID))
+		ctx := log.WithFields(ctx, log.Fields{
+			log.FieldAlertID:   p.Info.AlertID,
+			log.FieldServiceID: p.ServiceID,
+		})
+
+		inc, err := db.client.CreateIncident(ctx, p.Cfg.IncidentFields(p.Info))
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("create ServiceNow incident: %w", err))
+			_, err = tx.StmtContext(ctx, db.createFailed).ExecContext(ctx, p.Info.AlertID, err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setIncident).ExecContext(ctx, p.Info.AlertID, inc.SysID, inc.Number)
+		if err != nil {
+			return fmt.Errorf("save incident: %w", err)
+		}
+		log.Logf(log.WithField(ctx, "ServiceNowIncident", inc.Number), "ServiceNow incident created.")
+	}
+
+	return nil
+}
+
+func (db *DB) updateIncidents(ctx context.Context, tx *sql.Tx, delay *pgtype.Interval) error {
+	rows, err := tx.StmtContext(ctx, db.pendingUpdate).QueryContext(ctx, maxAttempts, delay)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type update struct {
+		AlertID int
+		SysID   string
+		Status  alert.Status
+		Cfg     servicenow.ServiceConfig
+	}
+	var pending []update
+	for rows.Next() {
+		var u update
+		err = rows.Scan(&u.AlertID, &u.SysID, &u.Status, &u.Cfg.ResolveCode)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		if u.Cfg.ResolveCode == "" {
+			u.Cfg.ResolveCode = servicenow.DefaultResolveCode
+		}
+		pending = append(pending, u)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, u := range pending {
+		if !hasTime(ctx) {
+			break
+		}
+		ctx := log.WithFields(ctx, log.Fields{
+			log.FieldAlertID:     u.AlertID,
+			"ServiceNowIncident": u.SysID,
+		})
+
+		closed := u.Status == alert.StatusClosed
+		fields := servicenow.AckFields()
+		if closed {
+			fields = u.Cfg.ResolveFields()
+		}
+
+		err := db.client.UpdateIncident(ctx, u.SysID, fields)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("update ServiceNow incident: %w", err))
+			_, err = tx.StmtContext(ctx, db.updateFailed).ExecContext(ctx, u.AlertID, err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setSynced).ExecContext(ctx, u.AlertID, closed)
+		if err != nil {
+			return fmt.Errorf("mark synced: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package slomanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB computes compliance and burn rates for service SLOs.
+type DB struct {
+	lock *processinglock.Lock
+
+	stale     *sql.Stmt
+	count     *sql.Stmt
+	setStatus *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.SLOManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeSLO,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock: lock,
+
+		stale: p.P(`
+			select s.id, s.target_percent, s.window_days
+			from service_slos s
+			left join service_slo_status st on st.slo_id = s.id
+			where st.computed_at isnull or st.computed_at < now() - $1::interval
+			order by st.computed_at nulls first
+			limit 100
+		`),
+
+		// Alerts still waiting for an ack that are not yet past the deadline are not counted.
+		count: p.P(`
+			select
+				count(*) filter (where r.ack_at notnull or a.created_at < now() - make_interval(mins => s.ack_within_minutes)),
+				count(*) filter (where r.ack_at <= a.created_at + make_interval(mins => s.ack_within_minutes))
+			from service_slos s
+			join alerts a on
+				a.service_id = s.service_id and
+				a.created_at >= now() - $2::interval and
+				(s.severity isnull or a.severity = s.severity)
+			left join lateral (
+				select min(l.timestamp) ack_at
+				from alert_logs l
+				where l.alert_id = a.id and l.event in ('acknowledged', 'closed')
+			) r on true
+			where s.id = $1
+		`),
+
+		setStatus: p.P(`
+			insert into service_slo_status (slo_id, total, met, burn_rate_1h, burn_rate_6h, burn_rate_24h, computed_at)
+			values ($1, $2, $3, $4, $5, $6, now())
+			on conflict (slo_id) do update
+			set
+				total = $2,
+				met = $3,
+				burn_rate_1h = $4,
+				burn_rate_6h = $5,
+				burn_rate_24h = $6,
+				computed_at = now()
+		`),
+	}, p.Err
+}
@@ -0,0 +1,95 @@
+package slomanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/slo"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// recomputeInterval is the minimum time between status updates for an SLO.
+const recomputeInterval = time.Minute
+
+type staleSLO struct {
+	ID            string
+	TargetPercent float64
+	WindowDays    int
+}
+
+func interval(d time.Duration) *pgtype.Interval {
+	return &pgtype.Interval{Microseconds: d.Microseconds(), Status: pgtype.Present}
+}
+
+// UpdateAll will update the compliance status of all SLOs that have not been computed recently.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Updating SLO status.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "slo manager", tx)
+
+	rows, err := tx.StmtContext(ctx, db.stale).QueryContext(ctx, interval(recomputeInterval))
+	if err != nil {
+		return fmt.Errorf("query stale: %w", err)
+	}
+	defer rows.Close()
+
+	var stale []staleSLO
+	for rows.Next() {
+		var s staleSLO
+		err = rows.Scan(&s.ID, &s.TargetPercent, &s.WindowDays)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		stale = append(stale, s)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("read stale: %w", err)
+	}
+
+	count := tx.StmtContext(ctx, db.count)
+	for _, s := range stale {
+		var total, met, total1h, met1h, total6h, met6h, total24h, met24h int
+		for _, w := range []struct {
+			dur        time.Duration
+			total, met *int
+		}{
+			{time.Duration(s.WindowDays) * 24 * time.Hour, &total, &met},
+			{time.Hour, &total1h, &met1h},
+			{6 * time.Hour, &total6h, &met6h},
+			{24 * time.Hour, &total24h, &met24h},
+		} {
+			err = count.QueryRowContext(ctx, s.ID, interval(w.dur)).Scan(w.total, w.met)
+			if err != nil {
+				return fmt.Errorf("count alerts for SLO %s: %w", s.ID, err)
+			}
+		}
+
+		_, err = tx.StmtContext(ctx, db.setStatus).ExecContext(ctx, s.ID, total, met,
+			slo.BurnRate(total1h, met1h, s.TargetPercent),
+			slo.BurnRate(total6h, met6h, s.TargetPercent),
+			slo.BurnRate(total24h, met24h, s.TargetPercent),
+		)
+		if err != nil {
+			return fmt.Errorf("set status for SLO %s: %w", s.ID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package statuscallbackmanager
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB sends status callbacks for alert notifications on services with a callback URL.
+type DB struct {
+	lock *processinglock.Lock
+
+	client *http.Client
+
+	pending      *sql.Stmt
+	setDelivered *sql.Stmt
+	setFailed    *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.StatusCallbackManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeStatusCallback,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		client: &http.Client{Timeout: 10 * time.Second},
+
+		// Only messages created after the callback was configured are considered, so enabling
+		// callbacks doesn't report on every recent notification.
+		pending: p.P(`
+			select
+				om.id, e.event, om.alert_id, om.service_id, coalesce(om.user_id::text, ''),
+				coalesce(cm.type::text, nc.type::text, ''), om.status_details, cb.url, cb.secret
+			from outgoing_messages om
+			join service_status_callbacks cb on cb.service_id = om.service_id
+			join alerts a on a.id = om.alert_id
+			cross join lateral (
+				select 'delivered' as event where om.last_status = 'delivered'
+				union all
+				select 'failed' where om.last_status = 'failed'
+				union all
+				select 'unanswered' where
+					om.last_status in ('sent', 'delivered') and
+					a.status = 'triggered' and
+					om.sent_at < now() - make_interval(mins => cb.unanswered_minutes)
+			) e
+			left join user_contact_methods cm on cm.id = om.contact_method_id
+			left join notification_channels nc on nc.id = om.channel_id
+			left join status_callback_deliveries d on d.message_id = om.id and d.event = e.event
+			where
+				om.message_type = 'alert_notification' and
+				om.last_status in ('sent', 'delivered', 'failed') and
+				om.created_at >= cb.created_at and
+				(
+					d.message_id isnull or
+					(not d.delivered and d.attempts < $1 and d.last_attempt < now() - $2::interval)
+				)
+			order by om.created_at
+			limit 10
+		`),
+		setDelivered: p.P(`
+			insert into status_callback_deliveries (message_id, event, delivered, attempts, last_attempt)
+			values ($1, $2, true, 1, now())
+			on conflict (message_id, event) do update
+			set delivered = true, attempts = status_callback_deliveries.attempts + 1, last_attempt = now(), last_error = ''
+		`),
+		setFailed: p.P(`
+			insert into status_callback_deliveries (message_id, event, attempts, last_attempt, last_error)
+			values ($1, $2, $3, now(), $4)
+			on conflict (message_id, event) do update
+			set attempts = greatest(status_callback_deliveries.attempts + 1, $3), last_attempt = now(), last_error = $4
+		`),
+	}, p.Err
+}
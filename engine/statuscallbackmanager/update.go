@@ -0,0 +1,151 @@
+package statuscallbackmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/statuscallback"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// maxAttempts is the number of times a failed callback is attempted before giving up.
+	maxAttempts = 5
+
+	// retryDelay is the minimum time between attempts for a failed callback.
+	retryDelay = time.Minute
+
+	// minRemaining is the time that must remain before the module deadline to start another request.
+	minRemaining = 12 * time.Second
+)
+
+type pendingCallback struct {
+	Payload statuscallback.Payload
+	URL     string
+	Secret  string
+}
+
+// UpdateAll will send status callbacks for delivered, failed, and unanswered alert notifications.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Processing status callbacks.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "status callback manager", tx)
+
+	var delay pgtype.Interval
+	delay.Microseconds = retryDelay.Microseconds()
+	delay.Status = pgtype.Present
+
+	rows, err := tx.StmtContext(ctx, db.pending).QueryContext(ctx, maxAttempts, &delay)
+	if err != nil {
+		return fmt.Errorf("query pending: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []pendingCallback
+	for rows.Next() {
+		var p pendingCallback
+		err = rows.Scan(
+			&p.Payload.MessageID, &p.Payload.Event, &p.Payload.AlertID, &p.Payload.ServiceID, &p.Payload.UserID,
+			&p.Payload.DestType, &p.Payload.Details, &p.URL, &p.Secret,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	for _, p := range pending {
+		if !hasTime(ctx) {
+			break
+		}
+		ctx := log.WithFields(ctx, log.Fields{
+			log.FieldAlertID:   p.Payload.AlertID,
+			log.FieldServiceID: p.Payload.ServiceID,
+			"MessageID":        p.Payload.MessageID,
+			"Event":            p.Payload.Event,
+		})
+
+		attempts := 0
+		if !cfg.ValidWebhookURL(p.URL) {
+			// don't retry if the URL is no longer allowed
+			attempts = maxAttempts
+			err = fmt.Errorf("invalid or not allowed URL")
+		} else {
+			p.Payload.AlertURL = cfg.CallbackURL("/alerts/" + strconv.Itoa(p.Payload.AlertID))
+			err = db.send(ctx, p)
+		}
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("send status callback: %w", err))
+			_, err = tx.StmtContext(ctx, db.setFailed).ExecContext(ctx, p.Payload.MessageID, p.Payload.Event, attempts, err.Error())
+			if err != nil {
+				return fmt.Errorf("record failure: %w", err)
+			}
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setDelivered).ExecContext(ctx, p.Payload.MessageID, p.Payload.Event)
+		if err != nil {
+			return fmt.Errorf("mark delivered: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func hasTime(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > minRemaining
+}
+
+func (db *DB) send(ctx context.Context, p pendingCallback) error {
+	now := time.Now()
+	p.Payload.Time = now
+	data, err := json.Marshal(p.Payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(statuscallback.HeaderEvent, string(p.Payload.Event))
+	req.Header.Set(statuscallback.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(statuscallback.HeaderSignature, statuscallback.Sign(p.Secret, now, data))
+	req.Header.Set("Idempotency-Key", p.Payload.MessageID+":"+string(p.Payload.Event))
+
+	resp, err := db.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
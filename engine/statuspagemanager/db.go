@@ -0,0 +1,69 @@
+package statuspagemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/statuspage"
+	"github.com/target/goalert/util"
+)
+
+// DB updates Statuspage component statuses from open alerts.
+type DB struct {
+	lock *processinglock.Lock
+
+	client *statuspage.Client
+
+	components    *sql.Stmt
+	setStatus     *sql.Stmt
+	setOverridden *sql.Stmt
+	setError      *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.StatuspageManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeStatuspage,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		client: &statuspage.Client{},
+
+		components: p.P(`
+			select
+				cmp.service_id, cmp.component_id,
+				cmp.degraded_at, cmp.partial_outage_at, cmp.major_outage_at,
+				cmp.last_status, cmp.overridden,
+				(cmp.overridden or cmp.last_error != '') and cmp.last_check > now() - $1::interval,
+				(select count(*) from alerts a where a.service_id = cmp.service_id and a.status != 'closed')
+			from service_statuspage_components cmp
+			order by cmp.last_check nulls first
+		`),
+		setStatus: p.P(`
+			update service_statuspage_components
+			set last_status = $2, overridden = false, last_check = now(), last_error = ''
+			where service_id = $1
+		`),
+		setOverridden: p.P(`
+			update service_statuspage_components
+			set overridden = true, last_check = now(), last_error = ''
+			where service_id = $1
+		`),
+		setError: p.P(`
+			update service_statuspage_components
+			set last_check = now(), last_error = $2
+			where service_id = $1
+		`),
+	}, p.Err
+}
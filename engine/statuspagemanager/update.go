@@ -0,0 +1,145 @@
+package statuspagemanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/statuspage"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// recheckDelay is the minimum time between checks of components that are overridden or failed to update.
+	recheckDelay = time.Minute
+
+	// maxUpdates limits API requests per cycle, as Statuspage rate limits are per-page.
+	maxUpdates = 10
+
+	// minRemaining is the time that must remain before the module deadline to start another request.
+	minRemaining = 12 * time.Second
+)
+
+type component struct {
+	statuspage.ServiceComponent
+	LastStatus statuspage.ComponentStatus
+	Overridden bool
+	Wait       bool
+	OpenAlerts int
+}
+
+// UpdateAll will update the status of all mapped Statuspage components.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Statuspage.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Processing Statuspage components.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "statuspage manager", tx)
+
+	var delay pgtype.Interval
+	delay.Microseconds = recheckDelay.Microseconds()
+	delay.Status = pgtype.Present
+
+	rows, err := tx.StmtContext(ctx, db.components).QueryContext(ctx, &delay)
+	if err != nil {
+		return fmt.Errorf("query components: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []component
+	for rows.Next() {
+		var c component
+		err = rows.Scan(
+			&c.ServiceID, &c.ComponentID,
+			&c.DegradedAt, &c.PartialOutageAt, &c.MajorOutageAt,
+			&c.LastStatus, &c.Overridden, &c.Wait, &c.OpenAlerts,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		if c.Wait || c.DesiredStatus(c.OpenAlerts) == c.LastStatus {
+			continue
+		}
+		pending = append(pending, c)
+	}
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for i, c := range pending {
+		if i >= maxUpdates || !hasTime(ctx) {
+			break
+		}
+
+		err = db.update(log.WithFields(ctx, log.Fields{
+			log.FieldServiceID:      c.ServiceID,
+			"StatuspageComponentID": c.ComponentID,
+		}), tx, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func hasTime(ctx context.Context) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > minRemaining
+}
+
+func (db *DB) update(ctx context.Context, tx *sql.Tx, c component) error {
+	desired := c.DesiredStatus(c.OpenAlerts)
+
+	comp, err := db.client.Component(ctx, c.ComponentID)
+	if err == nil {
+		switch {
+		case comp.Status == desired:
+			// already correct (e.g., set manually), nothing to do
+		case c.LastStatus != "" && comp.Status != c.LastStatus:
+			// Someone else changed the status since GoAlert last set it; leave it alone.
+			if !c.Overridden {
+				log.Logf(ctx, "Statuspage component status was changed manually to '%s'; updates paused.", comp.Status)
+			}
+			_, err = tx.StmtContext(ctx, db.setOverridden).ExecContext(ctx, c.ServiceID)
+			if err != nil {
+				return fmt.Errorf("mark overridden: %w", err)
+			}
+			return nil
+		default:
+			err = db.client.SetComponentStatus(ctx, c.ComponentID, desired)
+		}
+	}
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("update Statuspage component: %w", err))
+		_, err = tx.StmtContext(ctx, db.setError).ExecContext(ctx, c.ServiceID, err.Error())
+		if err != nil {
+			return fmt.Errorf("record failure: %w", err)
+		}
+		return nil
+	}
+
+	_, err = tx.StmtContext(ctx, db.setStatus).ExecContext(ctx, c.ServiceID, desired)
+	if err != nil {
+		return fmt.Errorf("save status: %w", err)
+	}
+
+	return nil
+}
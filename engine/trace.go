@@ -0,0 +1,20 @@
+package engine
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer is used for engine cycle and module spans. Spans are discarded unless a
+// tracer provider is configured.
+var tracer = otel.Tracer("github.com/target/goalert/engine")
+
+// endSpan will record err (if any) on the span, and end it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
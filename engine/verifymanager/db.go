@@ -34,13 +34,13 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		lock: lock,
 		insertMessages: p.P(`
 			with rows as (
-				insert into outgoing_messages (message_type, contact_method_id, user_id, user_verification_code_id)
-				select 'verification_message', contact_method_id, cm.user_id, code.id
+				insert into outgoing_messages (message_type, contact_method_id, channel_id, user_id, user_verification_code_id)
+				select 'verification_message', code.contact_method_id, code.channel_id, cm.user_id, code.id
 				from user_verification_codes code
-				join user_contact_methods cm on cm.id = contact_method_id
+				left join user_contact_methods cm on cm.id = code.contact_method_id
 				where not sent and now() < expires_at
 				limit 100
-				for update skip locked
+				for update of code skip locked
 				returning user_verification_code_id id
 			)
 			update user_verification_codes code
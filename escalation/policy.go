@@ -1,23 +1,52 @@
 package escalation
 
 import (
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxBackoffMultiplier is the largest allowed delay growth factor between repeats.
+const MaxBackoffMultiplier = 4
+
 type Policy struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	Repeat         int    `json:"repeat"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Repeat      int    `json:"repeat"`
+
+	// BackoffMultiplier is applied to step delays once per completed loop of the
+	// policy, so that repeated escalations happen less frequently. A value of 1
+	// (or 0) disables backoff.
+	BackoffMultiplier float64 `json:"backoff_multiplier"`
+
+	// MaxNotifications limits the total number of escalation steps processed for
+	// a single alert. Zero means no limit.
+	MaxNotifications int `json:"max_notifications"`
+
+	// GiveUpChannelID, if set, is a notification channel that will be notified
+	// once when the policy has been exhausted for an alert that is still unacknowledged.
+	GiveUpChannelID string `json:"give_up_channel_id"`
+
 	isUserFavorite bool
 }
 
 func (p Policy) Normalize() (*Policy, error) {
+	if p.BackoffMultiplier == 0 {
+		p.BackoffMultiplier = 1
+	}
+
 	err := validate.Many(
 		validate.IDName("Name", p.Name),
 		validate.Text("Description", p.Description, 1, 255),
 		validate.Range("Repeat", p.Repeat, 0, 5),
+		validate.Range("MaxNotifications", p.MaxNotifications, 0, 100),
 	)
+	if p.BackoffMultiplier < 1 || p.BackoffMultiplier > MaxBackoffMultiplier {
+		err = validate.Many(err, validation.NewFieldError("BackoffMultiplier", "must be between 1 and 4"))
+	}
+	if p.GiveUpChannelID != "" {
+		err = validate.Many(err, validate.UUID("GiveUpChannelID", p.GiveUpChannelID))
+	}
 	if err != nil {
 		return nil, err
 	}
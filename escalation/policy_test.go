@@ -34,3 +34,29 @@ func TestPolicy_Normalize(t *testing.T) {
 		test(false, p)
 	}
 }
+
+func TestPolicy_Normalize_Backoff(t *testing.T) {
+	p := Policy{Name: "SampleEscPolicy", Repeat: 1}
+	n, err := p.Normalize()
+	if err != nil {
+		t.Fatalf("got %v; want nil", err)
+	}
+	if n.BackoffMultiplier != 1 {
+		t.Errorf("BackoffMultiplier = %v; want 1", n.BackoffMultiplier)
+	}
+
+	for _, m := range []float64{0.5, 4.5} {
+		p.BackoffMultiplier = m
+		_, err = p.Normalize()
+		if err == nil {
+			t.Errorf("BackoffMultiplier=%v: got nil err; want non-nil", m)
+		}
+	}
+
+	p.BackoffMultiplier = 2
+	p.MaxNotifications = 101
+	_, err = p.Normalize()
+	if err == nil {
+		t.Errorf("MaxNotifications=101: got nil err; want non-nil")
+	}
+}
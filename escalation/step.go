@@ -22,6 +22,10 @@ type Step struct {
 	DelayMinutes int    `json:"delay_minutes"`
 	StepNumber   int    `json:"step_number"`
 
+	// ConferenceBridge indicates a Twilio conference is started, and on-call users dialed into it,
+	// when an alert reaches this step.
+	ConferenceBridge bool `json:"conference_bridge"`
+
 	Targets []assignment.Target
 }
 
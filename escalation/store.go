@@ -49,6 +49,7 @@ type Store struct {
 	createStep           *sql.Stmt
 	updateStepDelay      *sql.Stmt
 	updateStepNumber     *sql.Stmt
+	updateStepConf       *sql.Stmt
 	deleteStep           *sql.Stmt
 
 	addStepTarget      *sql.Stmt
@@ -80,6 +81,9 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				e.name,
 				e.description,
 				e.repeat,
+				e.backoff_multiplier,
+				e.max_notifications,
+				e.give_up_channel_id,
 				fav is distinct from null
 			FROM
 				escalation_policies e
@@ -87,13 +91,16 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				fav.tgt_escalation_policy_id = e.id AND fav.user_id = $2
 			WHERE e.id = $1
 		`),
-		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat FROM escalation_policies WHERE id = $1 FOR UPDATE`),
+		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat, backoff_multiplier, max_notifications, give_up_channel_id FROM escalation_policies WHERE id = $1 FOR UPDATE`),
 		findManyPolicies: p.P(`
             SELECT
                 e.id,
                 e.name,
                 e.description,
                 e.repeat,
+                e.backoff_multiplier,
+                e.max_notifications,
+                e.give_up_channel_id,
                 fav is distinct from null
             FROM
                 escalation_policies e
@@ -106,7 +113,10 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				step.escalation_policy_id,
 				pol.name,
 				pol.description,
-				pol.repeat
+				pol.repeat,
+				pol.backoff_multiplier,
+				pol.max_notifications,
+				pol.give_up_channel_id
 			FROM
 				escalation_policy_actions as act
 			JOIN
@@ -116,13 +126,13 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 			WHERE
 				act.schedule_id = $1
 		`),
-		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat) VALUES ($1, $2, $3, $4)`),
-		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4 WHERE id = $1`),
+		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat, backoff_multiplier, max_notifications, give_up_channel_id) VALUES ($1, $2, $3, $4, $5, $6, $7)`),
+		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4, backoff_multiplier = $5, max_notifications = $6, give_up_channel_id = $7 WHERE id = $1`),
 		deletePolicy: p.P(`DELETE FROM escalation_policies WHERE id = any($1)`),
 
 		addStepTarget: p.P(`
-			INSERT INTO escalation_policy_actions (id, escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO escalation_policy_actions (id, escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id, team_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`),
 		deleteStepTarget: p.P(`
 			DELETE FROM escalation_policy_actions
@@ -132,7 +142,8 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 					user_id = $2 OR
 					schedule_id = $3 OR
 					rotation_id = $4 OR
-					channel_id = $5
+					channel_id = $5 OR
+					team_id = $6
 				)
 		`),
 		findAllStepTargets: p.P(`
@@ -141,9 +152,10 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				schedule_id,
 				rotation_id,
 				channel_id,
+				team_id,
 				chan.type,
 				chan.value,
-				COALESCE(users.name, rot.name, sched.name, chan.name)
+				COALESCE(users.name, rot.name, sched.name, chan.name, team.name)
 			FROM
 				escalation_policy_actions act
 			LEFT JOIN users
@@ -154,28 +166,31 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				on act.schedule_id = sched.id
 			LEFT JOIN notification_channels chan
 				on act.channel_id = chan.id
+			LEFT JOIN teams team
+				on act.team_id = team.id
 			WHERE
 				escalation_policy_step_id = $1
 		`),
 
-		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
-		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
+		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number, conference_bridge FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
+		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number, conference_bridge FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
 		findAllOnCallSteps: p.P(`
-			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number
+			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number, step.conference_bridge
 			FROM ep_step_on_call_users oc
 			JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
-			WHERE oc.user_id = $1 AND oc.end_time isnull
+			WHERE oc.user_id = $1 AND oc.end_time isnull AND NOT oc.is_shadow
 			ORDER BY step.escalation_policy_id, step.step_number
 		`),
 
 		createStep: p.P(`
 			INSERT INTO escalation_policy_steps
-				(id, escalation_policy_id, delay, step_number)
-			VALUES ($1, $2, $3, DEFAULT)
+				(id, escalation_policy_id, delay, step_number, conference_bridge)
+			VALUES ($1, $2, $3, DEFAULT, $4)
 			RETURNING step_number
 		`),
 		updateStepDelay:  p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
 		updateStepNumber: p.P(`UPDATE escalation_policy_steps SET step_number = $2 WHERE id = $1`),
+		updateStepConf:   p.P(`UPDATE escalation_policy_steps SET conference_bridge = $2 WHERE id = $1`),
 		deleteStep:       p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
 	}, p.Err
 }
@@ -195,12 +210,13 @@ func validStepTarget(tgt assignment.Target) error {
 			assignment.TargetTypeSchedule,
 			assignment.TargetTypeRotation,
 			assignment.TargetTypeNotificationChannel,
+			assignment.TargetTypeTeam,
 		),
 	)
 }
 
 func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
-	var usr, sched, rot, ch sql.NullString
+	var usr, sched, rot, ch, team sql.NullString
 	switch tgt.TargetType() {
 	case assignment.TargetTypeUser:
 		usr.Valid = true
@@ -214,6 +230,9 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 	case assignment.TargetTypeNotificationChannel:
 		ch.Valid = true
 		ch.String = tgt.TargetID()
+	case assignment.TargetTypeTeam:
+		team.Valid = true
+		team.String = tgt.TargetID()
 	}
 	if insert {
 		return []interface{}{
@@ -223,6 +242,7 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 			sched,
 			rot,
 			ch,
+			team,
 		}
 	}
 	return []interface{}{
@@ -231,6 +251,7 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 		sched,
 		rot,
 		ch,
+		team,
 	}
 }
 
@@ -258,10 +279,12 @@ func (s *Store) FindManyPolicies(ctx context.Context, ids []string) ([]Policy, e
 	var result []Policy
 	var p Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.isUserFavorite)
+		var giveUp sql.NullString
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.BackoffMultiplier, &p.MaxNotifications, &giveUp, &p.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
+		p.GiveUpChannelID = giveUp.String
 		result = append(result, p)
 	}
 
@@ -397,10 +420,10 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 
 	var tgts []assignment.Target
 	for rows.Next() {
-		var usr, sched, rot, ch, chValue sql.NullString
+		var usr, sched, rot, ch, team, chValue sql.NullString
 		var chType *notificationchannel.Type
 		var tgt assignment.RawTarget
-		err = rows.Scan(&usr, &sched, &rot, &ch, &chType, &chValue, &tgt.Name)
+		err = rows.Scan(&usr, &sched, &rot, &ch, &team, &chType, &chValue, &tgt.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -415,6 +438,9 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 		case rot.Valid:
 			tgt.ID = rot.String
 			tgt.Type = assignment.TargetTypeRotation
+		case team.Valid:
+			tgt.ID = team.String
+			tgt.Type = assignment.TargetTypeTeam
 		case ch.Valid:
 			switch *chType {
 			case notificationchannel.TypeSlackChan:
@@ -455,7 +481,7 @@ func (s *Store) CreatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) (*Pol
 
 	n.ID = uuid.New().String()
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.BackoffMultiplier, n.MaxNotifications, sql.NullString{String: n.GiveUpChannelID, Valid: n.GiveUpChannelID != ""})
 	if err != nil {
 		return nil, err
 	}
@@ -483,7 +509,7 @@ func (s *Store) UpdatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) error
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.BackoffMultiplier, n.MaxNotifications, sql.NullString{String: n.GiveUpChannelID, Valid: n.GiveUpChannelID != ""})
 	if err != nil {
 		return err
 	}
@@ -529,9 +555,11 @@ func (s *Store) FindOnePolicyTx(ctx context.Context, tx *sql.Tx, id string) (*Po
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	row := stmt.QueryRowContext(ctx, id)
+	row := stmt.QueryRowContext(ctx, id, permission.UserID(ctx))
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	var giveUp sql.NullString
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.BackoffMultiplier, &p.MaxNotifications, &giveUp, &p.isUserFavorite)
+	p.GiveUpChannelID = giveUp.String
 	return &p, err
 }
 
@@ -554,7 +582,9 @@ func (s *Store) FindOnePolicyForUpdateTx(ctx context.Context, tx *sql.Tx, id str
 
 	row := stmt.QueryRowContext(ctx, id)
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	var giveUp sql.NullString
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.BackoffMultiplier, &p.MaxNotifications, &giveUp)
+	p.GiveUpChannelID = giveUp.String
 	return &p, err
 }
 
@@ -577,10 +607,12 @@ func (s *Store) FindAllPoliciesBySchedule(ctx context.Context, scheduleID string
 	var p Policy
 	var policies []Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+		var giveUp sql.NullString
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.BackoffMultiplier, &p.MaxNotifications, &giveUp)
 		if err != nil {
 			return nil, err
 		}
+		p.GiveUpChannelID = giveUp.String
 		policies = append(policies, p)
 	}
 
@@ -606,7 +638,7 @@ func (s *Store) FindOneStepForUpdateTx(ctx context.Context, tx *sql.Tx, id strin
 
 	row := stmt.QueryRowContext(ctx, id)
 	var st Step
-	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber)
+	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber, &st.ConferenceBridge)
 	if err != nil {
 		return nil, err
 	}
@@ -643,7 +675,7 @@ func (s *Store) FindAllOnCallStepsForUserTx(ctx context.Context, tx *sql.Tx, use
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber, &s.ConferenceBridge)
 		if err != nil {
 			return nil, err
 		}
@@ -678,7 +710,7 @@ func (s *Store) FindAllStepsTx(ctx context.Context, tx *sql.Tx, policyID string)
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber, &s.ConferenceBridge)
 		if err != nil {
 			return nil, err
 		}
@@ -706,7 +738,7 @@ func (s *Store) CreateStepTx(ctx context.Context, tx *sql.Tx, st *Step) (*Step,
 
 	n.ID = uuid.New().String()
 
-	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes).Scan(&n.StepNumber)
+	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes, n.ConferenceBridge).Scan(&n.StepNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -770,6 +802,31 @@ func (s *Store) UpdateStepDelayTx(ctx context.Context, tx *sql.Tx, stepID string
 	return nil
 }
 
+// UpdateStepConferenceBridgeTx sets whether a conference bridge is started when an alert reaches the step.
+func (s *Store) UpdateStepConferenceBridgeTx(ctx context.Context, tx *sql.Tx, stepID string, enable bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", stepID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepConf
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, stepID, enable)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DeleteStepTx deletes a step from an escalation policy.
 func (s *Store) DeleteStepTx(ctx context.Context, tx *sql.Tx, id string) (string, error) {
 	err := validate.UUID("EscalationPolicyStepID", id)
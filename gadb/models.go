@@ -69,19 +69,20 @@ func (ns NullEngineProcessingType) Value() (driver.Value, error) {
 type EnumAlertLogEvent string
 
 const (
-	EnumAlertLogEventAcknowledged        EnumAlertLogEvent = "acknowledged"
-	EnumAlertLogEventAssignmentChanged   EnumAlertLogEvent = "assignment_changed"
-	EnumAlertLogEventClosed              EnumAlertLogEvent = "closed"
-	EnumAlertLogEventCreated             EnumAlertLogEvent = "created"
-	EnumAlertLogEventDuplicateSuppressed EnumAlertLogEvent = "duplicate_suppressed"
-	EnumAlertLogEventEscalated           EnumAlertLogEvent = "escalated"
-	EnumAlertLogEventEscalationRequest   EnumAlertLogEvent = "escalation_request"
-	EnumAlertLogEventNoNotificationSent  EnumAlertLogEvent = "no_notification_sent"
-	EnumAlertLogEventNotificationSent    EnumAlertLogEvent = "notification_sent"
-	EnumAlertLogEventPolicyUpdated       EnumAlertLogEvent = "policy_updated"
-	EnumAlertLogEventReopened            EnumAlertLogEvent = "reopened"
-	EnumAlertLogEventResponseReceived    EnumAlertLogEvent = "response_received"
-	EnumAlertLogEventStatusChanged       EnumAlertLogEvent = "status_changed"
+	EnumAlertLogEventAcknowledged         EnumAlertLogEvent = "acknowledged"
+	EnumAlertLogEventAssignmentChanged    EnumAlertLogEvent = "assignment_changed"
+	EnumAlertLogEventClosed               EnumAlertLogEvent = "closed"
+	EnumAlertLogEventCreated              EnumAlertLogEvent = "created"
+	EnumAlertLogEventDuplicateSuppressed  EnumAlertLogEvent = "duplicate_suppressed"
+	EnumAlertLogEventEscalated            EnumAlertLogEvent = "escalated"
+	EnumAlertLogEventEscalationRequest    EnumAlertLogEvent = "escalation_request"
+	EnumAlertLogEventNoNotificationSent   EnumAlertLogEvent = "no_notification_sent"
+	EnumAlertLogEventNotificationFailover EnumAlertLogEvent = "notification_failover"
+	EnumAlertLogEventNotificationSent     EnumAlertLogEvent = "notification_sent"
+	EnumAlertLogEventPolicyUpdated        EnumAlertLogEvent = "policy_updated"
+	EnumAlertLogEventReopened             EnumAlertLogEvent = "reopened"
+	EnumAlertLogEventResponseReceived     EnumAlertLogEvent = "response_received"
+	EnumAlertLogEventStatusChanged        EnumAlertLogEvent = "status_changed"
 )
 
 func (e *EnumAlertLogEvent) Scan(src interface{}) error {
@@ -163,15 +164,75 @@ func (ns NullEnumAlertLogSubjectType) Value() (driver.Value, error) {
 	return string(ns.EnumAlertLogSubjectType), nil
 }
 
+type EnumAlertSeverity string
+
+const (
+	EnumAlertSeverityCritical EnumAlertSeverity = "critical"
+	EnumAlertSeverityHigh     EnumAlertSeverity = "high"
+	EnumAlertSeverityLow      EnumAlertSeverity = "low"
+	EnumAlertSeverityMedium   EnumAlertSeverity = "medium"
+)
+
+func (e *EnumAlertSeverity) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumAlertSeverity(s)
+	case string:
+		*e = EnumAlertSeverity(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumAlertSeverity: %T", src)
+	}
+	return nil
+}
+
+type NullEnumAlertSeverity struct {
+	EnumAlertSeverity EnumAlertSeverity
+	Valid             bool // Valid is true if EnumAlertSeverity is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumAlertSeverity) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumAlertSeverity, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumAlertSeverity.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumAlertSeverity) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumAlertSeverity), nil
+}
+
 type EnumAlertSource string
 
 const (
+	EnumAlertSourceAmazonSNS              EnumAlertSource = "amazonSNS"
+	EnumAlertSourceAzureMonitor           EnumAlertSource = "azureMonitor"
+	EnumAlertSourceDatadog                EnumAlertSource = "datadog"
 	EnumAlertSourceEmail                  EnumAlertSource = "email"
+	EnumAlertSourceGCPMonitoring          EnumAlertSource = "gcpMonitoring"
 	EnumAlertSourceGeneric                EnumAlertSource = "generic"
+	EnumAlertSourceGitHubActions          EnumAlertSource = "githubActions"
 	EnumAlertSourceGrafana                EnumAlertSource = "grafana"
+	EnumAlertSourceGrafanaOnCall          EnumAlertSource = "grafanaOnCall"
+	EnumAlertSourceKafka                  EnumAlertSource = "kafka"
+	EnumAlertSourceMQTT                   EnumAlertSource = "mqtt"
 	EnumAlertSourceManual                 EnumAlertSource = "manual"
+	EnumAlertSourceNagios                 EnumAlertSource = "nagios"
+	EnumAlertSourceNewRelic               EnumAlertSource = "newRelic"
+	EnumAlertSourcePagerDutyEvents        EnumAlertSource = "pagerDutyEvents"
 	EnumAlertSourcePrometheusAlertmanager EnumAlertSource = "prometheusAlertmanager"
+	EnumAlertSourceSNMP                   EnumAlertSource = "snmp"
+	EnumAlertSourceSentry                 EnumAlertSource = "sentry"
 	EnumAlertSourceSite24x7               EnumAlertSource = "site24x7"
+	EnumAlertSourceSplunk                 EnumAlertSource = "splunk"
+	EnumAlertSourceSyslog                 EnumAlertSource = "syslog"
+	EnumAlertSourceZabbix                 EnumAlertSource = "zabbix"
 )
 
 func (e *EnumAlertSource) Scan(src interface{}) error {
@@ -298,11 +359,27 @@ func (ns NullEnumHeartbeatState) Value() (driver.Value, error) {
 type EnumIntegrationKeysType string
 
 const (
+	EnumIntegrationKeysTypeAmazonSNS              EnumIntegrationKeysType = "amazonSNS"
+	EnumIntegrationKeysTypeAzureMonitor           EnumIntegrationKeysType = "azureMonitor"
+	EnumIntegrationKeysTypeDatadog                EnumIntegrationKeysType = "datadog"
 	EnumIntegrationKeysTypeEmail                  EnumIntegrationKeysType = "email"
+	EnumIntegrationKeysTypeGCPMonitoring          EnumIntegrationKeysType = "gcpMonitoring"
 	EnumIntegrationKeysTypeGeneric                EnumIntegrationKeysType = "generic"
+	EnumIntegrationKeysTypeGitHubActions          EnumIntegrationKeysType = "githubActions"
 	EnumIntegrationKeysTypeGrafana                EnumIntegrationKeysType = "grafana"
+	EnumIntegrationKeysTypeGrafanaOnCall          EnumIntegrationKeysType = "grafanaOnCall"
+	EnumIntegrationKeysTypeKafka                  EnumIntegrationKeysType = "kafka"
+	EnumIntegrationKeysTypeMQTT                   EnumIntegrationKeysType = "mqtt"
+	EnumIntegrationKeysTypeNagios                 EnumIntegrationKeysType = "nagios"
+	EnumIntegrationKeysTypeNewRelic               EnumIntegrationKeysType = "newRelic"
+	EnumIntegrationKeysTypePagerDutyEvents        EnumIntegrationKeysType = "pagerDutyEvents"
 	EnumIntegrationKeysTypePrometheusAlertmanager EnumIntegrationKeysType = "prometheusAlertmanager"
+	EnumIntegrationKeysTypeSNMP                   EnumIntegrationKeysType = "snmp"
+	EnumIntegrationKeysTypeSentry                 EnumIntegrationKeysType = "sentry"
 	EnumIntegrationKeysTypeSite24x7               EnumIntegrationKeysType = "site24x7"
+	EnumIntegrationKeysTypeSplunk                 EnumIntegrationKeysType = "splunk"
+	EnumIntegrationKeysTypeSyslog                 EnumIntegrationKeysType = "syslog"
+	EnumIntegrationKeysTypeZabbix                 EnumIntegrationKeysType = "zabbix"
 )
 
 func (e *EnumIntegrationKeysType) Scan(src interface{}) error {
@@ -756,6 +833,7 @@ type Alert struct {
 	LastEscalation  sql.NullTime
 	LastProcessed   sql.NullTime
 	ServiceID       uuid.NullUUID
+	Severity        EnumAlertSeverity
 	Source          EnumAlertSource
 	Status          EnumAlertStatus
 	Summary         string
@@ -894,6 +972,7 @@ type EscalationPolicyState struct {
 }
 
 type EscalationPolicyStep struct {
+	ConferenceBridge   bool
 	Delay              int32
 	EscalationPolicyID uuid.UUID
 	ID                 uuid.UUID
@@ -943,6 +1022,57 @@ type IntegrationKey struct {
 	Type      EnumIntegrationKeysType
 }
 
+type IntegrationKeyEmailRule struct {
+	AllowedSenders   []string
+	DedupPattern     string
+	IntegrationKeyID uuid.UUID
+	MaxDetailsLength int32
+	ResolvePattern   string
+}
+
+type IntegrationKeyGithubFilter struct {
+	Branches         []string
+	IntegrationKeyID uuid.UUID
+	Repositories     []string
+}
+
+type IntegrationKeyMqttRule struct {
+	AutoClose        bool
+	ID               int64
+	IntegrationKeyID uuid.UUID
+	Operator         string
+	Position         int32
+	Threshold        float64
+	TopicFilter      string
+	ValueExpr        string
+}
+
+type IntegrationKeySnmpRule struct {
+	Action           string
+	DedupOid         string
+	ID               int64
+	IntegrationKeyID uuid.UUID
+	Position         int32
+	SummaryOid       string
+	TrapOid          string
+}
+
+type IntegrationKeySyslogFilter struct {
+	AppNames         []string
+	Facilities       []int32
+	Hostnames        []string
+	IntegrationKeyID uuid.UUID
+	MaxSeverity      int32
+}
+
+type IntegrationKeyTransform struct {
+	Action           string
+	Dedup            string
+	Details          string
+	IntegrationKeyID uuid.UUID
+	Summary          string
+}
+
 type Keyring struct {
 	ID               string
 	NextKey          []byte
@@ -1006,6 +1136,14 @@ type OutgoingMessage struct {
 	UserVerificationCodeID uuid.NullUUID
 }
 
+type OutgoingMessageRequest struct {
+	CompletedAt    sql.NullTime
+	CreatedAt      time.Time
+	IdempotencyKey uuid.UUID
+	MessageID      uuid.UUID
+	ProviderMsgID  sql.NullString
+}
+
 type RegionID struct {
 	ID   int32
 	Name string
@@ -1047,6 +1185,11 @@ type Schedule struct {
 	TimeZone      string
 }
 
+type ScheduleCacheChange struct {
+	ChangedAt time.Time
+	ID        int64
+}
+
 type ScheduleDatum struct {
 	Data          json.RawMessage
 	ID            int64
@@ -1100,6 +1243,13 @@ type SwitchoverState struct {
 	Ok           bool
 }
 
+type TwilioConferenceBridge struct {
+	AccessCode string
+	AlertID    int64
+	CreatedAt  time.Time
+	EpStepID   uuid.NullUUID
+}
+
 type TwilioSmsCallback struct {
 	AlertID     sql.NullInt64
 	CallbackID  uuid.UUID
@@ -1136,6 +1286,11 @@ type User struct {
 	Role                          EnumUserRole
 }
 
+type UserAlertDigest struct {
+	IntervalMinutes int32
+	UserID          uuid.UUID
+}
+
 type UserCalendarSubscription struct {
 	Config     json.RawMessage
 	CreatedAt  time.Time
@@ -1172,6 +1172,23 @@ func (q *Queries) DeleteManyCalSub(ctx context.Context, arg DeleteManyCalSubPara
 	return err
 }
 
+const engineEscalationLag = `-- name: EngineEscalationLag :one
+SELECT
+    coalesce(extract(epoch FROM now() - min(next_escalation)), 0)::float8 AS lag_seconds
+FROM
+    escalation_policy_state
+WHERE
+    next_escalation <= now()
+`
+
+// EngineEscalationLag returns how long, in seconds, the oldest due escalation has been waiting to be processed.
+func (q *Queries) EngineEscalationLag(ctx context.Context) (float64, error) {
+	row := q.db.QueryRowContext(ctx, engineEscalationLag)
+	var lag_seconds float64
+	err := row.Scan(&lag_seconds)
+	return lag_seconds, err
+}
+
 const findManyCalSubByUser = `-- name: FindManyCalSubByUser :many
 SELECT
     id,
@@ -1309,6 +1326,60 @@ func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (Fin
 	return i, err
 }
 
+const intKeyAllMQTTRules = `-- name: IntKeyAllMQTTRules :many
+SELECT
+    integration_key_id,
+    topic_filter,
+    value_expr,
+    operator,
+    threshold,
+    auto_close
+FROM
+    integration_key_mqtt_rules
+ORDER BY
+    integration_key_id,
+    position
+`
+
+type IntKeyAllMQTTRulesRow struct {
+	IntegrationKeyID uuid.UUID
+	TopicFilter      string
+	ValueExpr        string
+	Operator         string
+	Threshold        float64
+	AutoClose        bool
+}
+
+func (q *Queries) IntKeyAllMQTTRules(ctx context.Context) ([]IntKeyAllMQTTRulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, intKeyAllMQTTRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntKeyAllMQTTRulesRow
+	for rows.Next() {
+		var i IntKeyAllMQTTRulesRow
+		if err := rows.Scan(
+			&i.IntegrationKeyID,
+			&i.TopicFilter,
+			&i.ValueExpr,
+			&i.Operator,
+			&i.Threshold,
+			&i.AutoClose,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const intKeyCreate = `-- name: IntKeyCreate :exec
 INSERT INTO integration_keys(id, name, type, service_id)
     VALUES ($1, $2, $3, $4)
@@ -1341,6 +1412,66 @@ func (q *Queries) IntKeyDelete(ctx context.Context, ids []uuid.UUID) error {
 	return err
 }
 
+const intKeyDeleteEmailRules = `-- name: IntKeyDeleteEmailRules :exec
+DELETE FROM integration_key_email_rules
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteEmailRules(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteEmailRules, integrationKeyID)
+	return err
+}
+
+const intKeyDeleteGitHubFilter = `-- name: IntKeyDeleteGitHubFilter :exec
+DELETE FROM integration_key_github_filters
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteGitHubFilter(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteGitHubFilter, integrationKeyID)
+	return err
+}
+
+const intKeyDeleteMQTTRules = `-- name: IntKeyDeleteMQTTRules :exec
+DELETE FROM integration_key_mqtt_rules
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteMQTTRules(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteMQTTRules, integrationKeyID)
+	return err
+}
+
+const intKeyDeleteSNMPRules = `-- name: IntKeyDeleteSNMPRules :exec
+DELETE FROM integration_key_snmp_rules
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteSNMPRules(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteSNMPRules, integrationKeyID)
+	return err
+}
+
+const intKeyDeleteSyslogFilter = `-- name: IntKeyDeleteSyslogFilter :exec
+DELETE FROM integration_key_syslog_filters
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteSyslogFilter(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteSyslogFilter, integrationKeyID)
+	return err
+}
+
+const intKeyDeleteTransform = `-- name: IntKeyDeleteTransform :exec
+DELETE FROM integration_key_transforms
+WHERE integration_key_id = $1
+`
+
+func (q *Queries) IntKeyDeleteTransform(ctx context.Context, integrationKeyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, intKeyDeleteTransform, integrationKeyID)
+	return err
+}
+
 const intKeyFindByService = `-- name: IntKeyFindByService :many
 SELECT
     id,
@@ -1419,6 +1550,59 @@ func (q *Queries) IntKeyFindOne(ctx context.Context, id uuid.UUID) (IntKeyFindOn
 	return i, err
 }
 
+const intKeyGetEmailRules = `-- name: IntKeyGetEmailRules :one
+SELECT
+    dedup_pattern,
+    resolve_pattern,
+    allowed_senders,
+    max_details_length
+FROM
+    integration_key_email_rules
+WHERE
+    integration_key_id = $1
+`
+
+type IntKeyGetEmailRulesRow struct {
+	DedupPattern     string
+	ResolvePattern   string
+	AllowedSenders   []string
+	MaxDetailsLength int32
+}
+
+func (q *Queries) IntKeyGetEmailRules(ctx context.Context, integrationKeyID uuid.UUID) (IntKeyGetEmailRulesRow, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetEmailRules, integrationKeyID)
+	var i IntKeyGetEmailRulesRow
+	err := row.Scan(
+		&i.DedupPattern,
+		&i.ResolvePattern,
+		pq.Array(&i.AllowedSenders),
+		&i.MaxDetailsLength,
+	)
+	return i, err
+}
+
+const intKeyGetGitHubFilter = `-- name: IntKeyGetGitHubFilter :one
+SELECT
+    repositories,
+    branches
+FROM
+    integration_key_github_filters
+WHERE
+    integration_key_id = $1
+`
+
+type IntKeyGetGitHubFilterRow struct {
+	Repositories []string
+	Branches     []string
+}
+
+func (q *Queries) IntKeyGetGitHubFilter(ctx context.Context, integrationKeyID uuid.UUID) (IntKeyGetGitHubFilterRow, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetGitHubFilter, integrationKeyID)
+	var i IntKeyGetGitHubFilterRow
+	err := row.Scan(pq.Array(&i.Repositories), pq.Array(&i.Branches))
+	return i, err
+}
+
 const intKeyGetServiceID = `-- name: IntKeyGetServiceID :one
 SELECT
     service_id
@@ -1441,6 +1625,358 @@ func (q *Queries) IntKeyGetServiceID(ctx context.Context, arg IntKeyGetServiceID
 	return service_id, err
 }
 
+const intKeyGetSyslogFilter = `-- name: IntKeyGetSyslogFilter :one
+SELECT
+    integration_key_id,
+    max_severity,
+    facilities,
+    app_names,
+    hostnames
+FROM
+    integration_key_syslog_filters
+WHERE
+    integration_key_id = $1
+`
+
+func (q *Queries) IntKeyGetSyslogFilter(ctx context.Context, integrationKeyID uuid.UUID) (IntegrationKeySyslogFilter, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetSyslogFilter, integrationKeyID)
+	var i IntegrationKeySyslogFilter
+	err := row.Scan(
+		&i.IntegrationKeyID,
+		&i.MaxSeverity,
+		pq.Array(&i.Facilities),
+		pq.Array(&i.AppNames),
+		pq.Array(&i.Hostnames),
+	)
+	return i, err
+}
+
+const intKeyGetTransform = `-- name: IntKeyGetTransform :one
+SELECT
+    summary,
+    details,
+    dedup,
+    action
+FROM
+    integration_key_transforms
+WHERE
+    integration_key_id = $1
+`
+
+type IntKeyGetTransformRow struct {
+	Summary string
+	Details string
+	Dedup   string
+	Action  string
+}
+
+func (q *Queries) IntKeyGetTransform(ctx context.Context, integrationKeyID uuid.UUID) (IntKeyGetTransformRow, error) {
+	row := q.db.QueryRowContext(ctx, intKeyGetTransform, integrationKeyID)
+	var i IntKeyGetTransformRow
+	err := row.Scan(
+		&i.Summary,
+		&i.Details,
+		&i.Dedup,
+		&i.Action,
+	)
+	return i, err
+}
+
+const intKeyInsertMQTTRule = `-- name: IntKeyInsertMQTTRule :exec
+INSERT INTO integration_key_mqtt_rules(integration_key_id, position, topic_filter, value_expr, operator, threshold, auto_close)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type IntKeyInsertMQTTRuleParams struct {
+	IntegrationKeyID uuid.UUID
+	Position         int32
+	TopicFilter      string
+	ValueExpr        string
+	Operator         string
+	Threshold        float64
+	AutoClose        bool
+}
+
+func (q *Queries) IntKeyInsertMQTTRule(ctx context.Context, arg IntKeyInsertMQTTRuleParams) error {
+	_, err := q.db.ExecContext(ctx, intKeyInsertMQTTRule,
+		arg.IntegrationKeyID,
+		arg.Position,
+		arg.TopicFilter,
+		arg.ValueExpr,
+		arg.Operator,
+		arg.Threshold,
+		arg.AutoClose,
+	)
+	return err
+}
+
+const intKeyInsertSNMPRule = `-- name: IntKeyInsertSNMPRule :exec
+INSERT INTO integration_key_snmp_rules(integration_key_id, position, trap_oid, action, summary_oid, dedup_oid)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type IntKeyInsertSNMPRuleParams struct {
+	IntegrationKeyID uuid.UUID
+	Position         int32
+	TrapOid          string
+	Action           string
+	SummaryOid       string
+	DedupOid         string
+}
+
+func (q *Queries) IntKeyInsertSNMPRule(ctx context.Context, arg IntKeyInsertSNMPRuleParams) error {
+	_, err := q.db.ExecContext(ctx, intKeyInsertSNMPRule,
+		arg.IntegrationKeyID,
+		arg.Position,
+		arg.TrapOid,
+		arg.Action,
+		arg.SummaryOid,
+		arg.DedupOid,
+	)
+	return err
+}
+
+const intKeyMQTTRules = `-- name: IntKeyMQTTRules :many
+SELECT
+    topic_filter,
+    value_expr,
+    operator,
+    threshold,
+    auto_close
+FROM
+    integration_key_mqtt_rules
+WHERE
+    integration_key_id = $1
+ORDER BY
+    position
+`
+
+type IntKeyMQTTRulesRow struct {
+	TopicFilter string
+	ValueExpr   string
+	Operator    string
+	Threshold   float64
+	AutoClose   bool
+}
+
+func (q *Queries) IntKeyMQTTRules(ctx context.Context, integrationKeyID uuid.UUID) ([]IntKeyMQTTRulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, intKeyMQTTRules, integrationKeyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntKeyMQTTRulesRow
+	for rows.Next() {
+		var i IntKeyMQTTRulesRow
+		if err := rows.Scan(
+			&i.TopicFilter,
+			&i.ValueExpr,
+			&i.Operator,
+			&i.Threshold,
+			&i.AutoClose,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const intKeySNMPRules = `-- name: IntKeySNMPRules :many
+SELECT
+    trap_oid,
+    action,
+    summary_oid,
+    dedup_oid
+FROM
+    integration_key_snmp_rules
+WHERE
+    integration_key_id = $1
+ORDER BY
+    position
+`
+
+type IntKeySNMPRulesRow struct {
+	TrapOid    string
+	Action     string
+	SummaryOid string
+	DedupOid   string
+}
+
+func (q *Queries) IntKeySNMPRules(ctx context.Context, integrationKeyID uuid.UUID) ([]IntKeySNMPRulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, intKeySNMPRules, integrationKeyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntKeySNMPRulesRow
+	for rows.Next() {
+		var i IntKeySNMPRulesRow
+		if err := rows.Scan(
+			&i.TrapOid,
+			&i.Action,
+			&i.SummaryOid,
+			&i.DedupOid,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const intKeySetEmailRules = `-- name: IntKeySetEmailRules :exec
+INSERT INTO integration_key_email_rules(integration_key_id, dedup_pattern, resolve_pattern, allowed_senders, max_details_length)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        dedup_pattern = $2, resolve_pattern = $3, allowed_senders = $4, max_details_length = $5
+`
+
+type IntKeySetEmailRulesParams struct {
+	IntegrationKeyID uuid.UUID
+	DedupPattern     string
+	ResolvePattern   string
+	AllowedSenders   []string
+	MaxDetailsLength int32
+}
+
+func (q *Queries) IntKeySetEmailRules(ctx context.Context, arg IntKeySetEmailRulesParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetEmailRules,
+		arg.IntegrationKeyID,
+		arg.DedupPattern,
+		arg.ResolvePattern,
+		pq.Array(arg.AllowedSenders),
+		arg.MaxDetailsLength,
+	)
+	return err
+}
+
+const intKeySetGitHubFilter = `-- name: IntKeySetGitHubFilter :exec
+INSERT INTO integration_key_github_filters(integration_key_id, repositories, branches)
+    VALUES ($1, $2, $3)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        repositories = $2, branches = $3
+`
+
+type IntKeySetGitHubFilterParams struct {
+	IntegrationKeyID uuid.UUID
+	Repositories     []string
+	Branches         []string
+}
+
+func (q *Queries) IntKeySetGitHubFilter(ctx context.Context, arg IntKeySetGitHubFilterParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetGitHubFilter, arg.IntegrationKeyID, pq.Array(arg.Repositories), pq.Array(arg.Branches))
+	return err
+}
+
+const intKeySetSyslogFilter = `-- name: IntKeySetSyslogFilter :exec
+INSERT INTO integration_key_syslog_filters(integration_key_id, max_severity, facilities, app_names, hostnames)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        max_severity = $2, facilities = $3, app_names = $4, hostnames = $5
+`
+
+type IntKeySetSyslogFilterParams struct {
+	IntegrationKeyID uuid.UUID
+	MaxSeverity      int32
+	Facilities       []int32
+	AppNames         []string
+	Hostnames        []string
+}
+
+func (q *Queries) IntKeySetSyslogFilter(ctx context.Context, arg IntKeySetSyslogFilterParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetSyslogFilter,
+		arg.IntegrationKeyID,
+		arg.MaxSeverity,
+		pq.Array(arg.Facilities),
+		pq.Array(arg.AppNames),
+		pq.Array(arg.Hostnames),
+	)
+	return err
+}
+
+const intKeySetTransform = `-- name: IntKeySetTransform :exec
+INSERT INTO integration_key_transforms(integration_key_id, summary, details, dedup, action)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        summary = $2, details = $3, dedup = $4, action = $5
+`
+
+type IntKeySetTransformParams struct {
+	IntegrationKeyID uuid.UUID
+	Summary          string
+	Details          string
+	Dedup            string
+	Action           string
+}
+
+func (q *Queries) IntKeySetTransform(ctx context.Context, arg IntKeySetTransformParams) error {
+	_, err := q.db.ExecContext(ctx, intKeySetTransform,
+		arg.IntegrationKeyID,
+		arg.Summary,
+		arg.Details,
+		arg.Dedup,
+		arg.Action,
+	)
+	return err
+}
+
+const intKeySyslogFilters = `-- name: IntKeySyslogFilters :many
+SELECT
+    integration_key_id,
+    max_severity,
+    facilities,
+    app_names,
+    hostnames
+FROM
+    integration_key_syslog_filters
+`
+
+func (q *Queries) IntKeySyslogFilters(ctx context.Context) ([]IntegrationKeySyslogFilter, error) {
+	rows, err := q.db.QueryContext(ctx, intKeySyslogFilters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IntegrationKeySyslogFilter
+	for rows.Next() {
+		var i IntegrationKeySyslogFilter
+		if err := rows.Scan(
+			&i.IntegrationKeyID,
+			&i.MaxSeverity,
+			pq.Array(&i.Facilities),
+			pq.Array(&i.AppNames),
+			pq.Array(&i.Hostnames),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const lockOneAlertService = `-- name: LockOneAlertService :one
 SELECT
     maintenance_expires_at NOTNULL::bool AS is_maint_mode,
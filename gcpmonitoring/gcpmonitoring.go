@@ -0,0 +1,166 @@
+package gcpmonitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+type postBody struct {
+	Version  string
+	Incident incident
+}
+
+type incident struct {
+	IncidentID       string `json:"incident_id"`
+	ScopingProjectID string `json:"scoping_project_id"`
+	URL              string
+	State            string
+	Summary          string
+	PolicyName       string `json:"policy_name"`
+	ConditionName    string `json:"condition_name"`
+	ResourceName     string `json:"resource_name"`
+	ObservedValue    string `json:"observed_value"`
+	ThresholdValue   string `json:"threshold_value"`
+	Documentation    struct {
+		Content  string
+		MimeType string `json:"mime_type"`
+	}
+}
+
+var linkRx = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+
+// documentationLinks returns all unique absolute URLs found in the documentation content.
+func documentationLinks(content string) []string {
+	var links []string
+	seen := make(map[string]bool)
+	for _, l := range linkRx.FindAllString(content, -1) {
+		l = strings.TrimRight(l, ".,;:")
+		if seen[l] || validate.AbsoluteURL("Link", l) != nil {
+			continue
+		}
+		seen[l] = true
+		links = append(links, l)
+	}
+	return links
+}
+
+func (i incident) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("URL", i.URL) == nil {
+		fmt.Fprintf(&s, "[View Incident](%s)\n\n", i.URL)
+	}
+	if i.Summary != "" {
+		s.WriteString(i.Summary + "\n\n")
+	}
+	if i.ResourceName != "" {
+		fmt.Fprintf(&s, "Resource: %s\n\n", i.ResourceName)
+	}
+	if i.ConditionName != "" {
+		fmt.Fprintf(&s, "Condition: %s\n\n", i.ConditionName)
+	}
+	if i.ObservedValue != "" {
+		fmt.Fprintf(&s, "Observed: %s (threshold %s)\n\n", i.ObservedValue, i.ThresholdValue)
+	}
+	if links := documentationLinks(i.Documentation.Content); len(links) > 0 {
+		s.WriteString("## Documentation Links\n\n")
+		for _, l := range links {
+			fmt.Fprintf(&s, "- %s\n", l)
+		}
+		s.WriteString("\n")
+	}
+	if i.Documentation.Content != "" {
+		s.WriteString("## Documentation\n\n" + i.Documentation.Content)
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func GCPMonitoringToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var body postBody
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from gcp monitoring: %v", err)
+			return
+		}
+
+		i := body.Incident
+		ctx = log.WithFields(ctx, log.Fields{
+			"IncidentID": i.IncidentID,
+			"State":      i.State,
+		})
+		if i.IncidentID == "" {
+			log.Logf(ctx, "bad request from gcp monitoring: missing incident_id")
+			http.Error(w, "missing incident_id", http.StatusBadRequest)
+			return
+		}
+
+		var status alert.Status
+		switch i.State {
+		case "open":
+			status = alert.StatusTriggered
+		case "closed":
+			status = alert.StatusClosed
+		default:
+			log.Logf(ctx, "bad request from gcp monitoring: missing or invalid state")
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		summary := i.PolicyName
+		if summary == "" {
+			summary = i.Summary
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(i.details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceGCPMonitoring,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(i.IncidentID),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for gcp monitoring")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package gcpmonitoring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentationLinks(t *testing.T) {
+	content := "See the [runbook](https://wiki.example.com/runbooks/db) or https://status.example.com.\n" +
+		"Dashboard: https://console.cloud.google.com/monitoring?project=foo (duplicate https://wiki.example.com/runbooks/db)"
+
+	assert.Equal(t, []string{
+		"https://wiki.example.com/runbooks/db",
+		"https://status.example.com",
+		"https://console.cloud.google.com/monitoring?project=foo",
+	}, documentationLinks(content))
+	assert.Empty(t, documentationLinks("no links here"))
+}
@@ -0,0 +1,140 @@
+package genericapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+)
+
+// maxBatchBodySize is the maximum request size for a batch of alerts.
+const maxBatchBodySize = 10 << 20
+
+type batchResult struct {
+	AlertID   int    `json:",omitempty"`
+	ServiceID string `json:",omitempty"`
+	IsNew     bool
+	Error     string `json:",omitempty"`
+}
+
+// parseBatch returns the alerts for a JSON array of generic API request bodies. If t is
+// non-nil, it is applied to each element.
+func parseBatch(serviceID string, data []byte, t *integrationkey.Transform) ([]alert.Alert, error) {
+	var items []json.RawMessage
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New("at least one alert is required")
+	}
+	if len(items) > alert.MaxBatchSize {
+		return nil, errors.Errorf("too many alerts: %d (max %d)", len(items), alert.MaxBatchSize)
+	}
+
+	alerts := make([]alert.Alert, 0, len(items))
+	for i, item := range items {
+		if t != nil {
+			res, err := t.Apply(item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			alerts = append(alerts, *newAlert(serviceID, res.Summary, res.Details, res.Action, res.Dedup, ""))
+			continue
+		}
+
+		var b struct {
+			Summary, Details, Action, Dedup, Severity string
+		}
+		err = json.Unmarshal(item, &b)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		alerts = append(alerts, *newAlert(serviceID, b.Summary, b.Details, b.Action, b.Dedup, b.Severity))
+	}
+
+	return alerts, nil
+}
+
+// ServeCreateAlertBatch allows creating or closing multiple alerts in a single request.
+//
+// The body must be a JSON array of objects using the same fields as ServeCreateAlert. All alerts
+// are processed in a single transaction, and a result is returned for each, in order.
+func (h *Handler) ServeCreateAlertBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBatchBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var t *integrationkey.Transform
+	if src := permission.Source(ctx); src != nil && src.Type == permission.SourceTypeIntegrationKey {
+		t, err = h.c.IntegrationKeyStore.FindTransform(ctx, src.ID)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "lookup payload transform")) {
+			return
+		}
+	}
+
+	alerts, err := parseBatch(serviceID, data, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var results []alert.BatchResult
+	err = retry.DoTemporaryError(func(int) error {
+		results, err = h.c.AlertStore.CreateOrUpdateBatch(ctx, alerts)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create alert batch")) {
+		return
+	}
+
+	var resp struct {
+		Results []batchResult
+	}
+	resp.Results = make([]batchResult, len(results))
+	for i, res := range results {
+		if res.Err != nil {
+			resp.Results[i].Error = res.Err.Error()
+			continue
+		}
+		if res.Alert == nil {
+			continue
+		}
+		resp.Results[i].AlertID = res.Alert.ID
+		resp.Results[i].ServiceID = res.Alert.ServiceID
+		resp.Results[i].IsNew = res.IsNew
+	}
+
+	data, err = json.Marshal(&resp)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/auth"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/errutil"
@@ -75,6 +76,24 @@ func (h *Handler) ServeHeartbeatCheck(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// newAlert returns the alert for a generic API request.
+func newAlert(serviceID, summary, details, action, dedup, severity string) *alert.Alert {
+	status := alert.StatusTriggered
+	if action == "close" {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Source:    alert.SourceGeneric,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+		Status:    status,
+		Severity:  alert.Severity(severity),
+	}
+}
+
 // ServeCreateAlert allows creating or closing an alert.
 func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -89,6 +108,7 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	details := r.FormValue("details")
 	action := r.FormValue("action")
 	dedup := r.FormValue("dedup")
+	severity := r.FormValue("severity")
 
 	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
 	if ct == "application/json" {
@@ -98,45 +118,50 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		var b struct {
-			Summary, Details, Action, Dedup *string
-		}
-		err = json.Unmarshal(data, &b)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
+		var t *integrationkey.Transform
+		if src := permission.Source(ctx); src != nil && src.Type == permission.SourceTypeIntegrationKey {
+			t, err = h.c.IntegrationKeyStore.FindTransform(ctx, src.ID)
+			if errutil.HTTPError(ctx, w, errors.Wrap(err, "lookup payload transform")) {
+				return
+			}
 		}
 
-		if b.Summary != nil {
-			summary = *b.Summary
-		}
-		if b.Details != nil {
-			details = *b.Details
-		}
-		if b.Dedup != nil {
-			dedup = *b.Dedup
-		}
-		if b.Action != nil {
-			action = *b.Action
+		if t != nil {
+			res, err := t.Apply(data)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			summary, details, dedup, action = res.Summary, res.Details, res.Dedup, res.Action
+		} else {
+			var b struct {
+				Summary, Details, Action, Dedup, Severity *string
+			}
+			err = json.Unmarshal(data, &b)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if b.Summary != nil {
+				summary = *b.Summary
+			}
+			if b.Details != nil {
+				details = *b.Details
+			}
+			if b.Dedup != nil {
+				dedup = *b.Dedup
+			}
+			if b.Action != nil {
+				action = *b.Action
+			}
+			if b.Severity != nil {
+				severity = *b.Severity
+			}
 		}
 	}
 
-	status := alert.StatusTriggered
-	if action == "close" {
-		status = alert.StatusClosed
-	}
-
-	summary = validate.SanitizeText(summary, alert.MaxSummaryLength)
-	details = validate.SanitizeText(details, alert.MaxDetailsLength)
-
-	a := &alert.Alert{
-		Summary:   summary,
-		Details:   details,
-		Source:    alert.SourceGeneric,
-		ServiceID: serviceID,
-		Dedup:     alert.NewUserDedup(dedup),
-		Status:    status,
-	}
+	a := newAlert(serviceID, summary, details, action, dedup, severity)
 
 	var resp struct {
 		AlertID   int
@@ -0,0 +1,286 @@
+package githubactions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxBodySize is the maximum webhook payload size; GitHub caps deliveries at 25MB, but
+// workflow_run and check_suite events are far smaller.
+const maxBodySize = 5 << 20
+
+// githubActionsSlug is the app slug for check suites created by GitHub Actions. They
+// are reported through workflow_run events instead, so they are ignored.
+const githubActionsSlug = "github-actions"
+
+type repository struct {
+	FullName string `json:"full_name"`
+}
+
+type workflowRunPayload struct {
+	Action      string
+	WorkflowRun struct {
+		ID         int64
+		Name       string
+		WorkflowID int64  `json:"workflow_id"`
+		HeadBranch string `json:"head_branch"`
+		HeadSHA    string `json:"head_sha"`
+		RunNumber  int    `json:"run_number"`
+		Event      string
+		Conclusion string
+		HTMLURL    string `json:"html_url"`
+		Title      string `json:"display_title"`
+	} `json:"workflow_run"`
+	Repository repository
+}
+
+type checkSuitePayload struct {
+	Action     string
+	CheckSuite struct {
+		ID         int64
+		HeadBranch string `json:"head_branch"`
+		HeadSHA    string `json:"head_sha"`
+		Conclusion string
+		App        struct {
+			Slug string
+			Name string
+		}
+	} `json:"check_suite"`
+	Repository repository
+}
+
+// result is a completed workflow run or check suite.
+type result struct {
+	Repo   string
+	Branch string
+
+	// Name is the workflow or app name.
+	Name string
+
+	Dedup  string
+	Status alert.Status
+
+	Conclusion string
+	SHA        string
+	Link       string
+	Event      string
+	Title      string
+}
+
+func (r result) summary() string {
+	return fmt.Sprintf("%s: %s %s on %s", r.Repo, r.Name, strings.ReplaceAll(r.Conclusion, "_", " "), r.Branch)
+}
+
+func (r result) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("Link", r.Link) == nil {
+		fmt.Fprintf(&s, "[View on GitHub](%s)\n\n", r.Link)
+	}
+	if r.Title != "" {
+		fmt.Fprintf(&s, "%s\n\n", r.Title)
+	}
+	fmt.Fprintf(&s, "Repository: %s\n\n", r.Repo)
+	fmt.Fprintf(&s, "Branch: %s\n\n", r.Branch)
+	if r.SHA != "" {
+		fmt.Fprintf(&s, "Commit: %s\n\n", r.SHA)
+	}
+	if r.Event != "" {
+		fmt.Fprintf(&s, "Trigger: %s\n\n", r.Event)
+	}
+	fmt.Fprintf(&s, "Conclusion: %s", r.Conclusion)
+
+	return s.String()
+}
+
+// conclusionStatus returns the alert status for a completed run's conclusion, or
+// an empty string if it should be ignored (e.g., cancelled or skipped).
+func conclusionStatus(conclusion string) alert.Status {
+	switch conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return alert.StatusTriggered
+	case "success":
+		return alert.StatusClosed
+	}
+
+	return ""
+}
+
+// parse will return the result for the given event, or nil if it should be ignored.
+//
+// The event is the value of the `X-GitHub-Event` header.
+func parse(event string, data []byte) (*result, error) {
+	switch event {
+	case "workflow_run":
+		var p workflowRunPayload
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, err
+		}
+		run := p.WorkflowRun
+		status := conclusionStatus(run.Conclusion)
+		if p.Action != "completed" || status == "" {
+			return nil, nil
+		}
+		if p.Repository.FullName == "" || run.WorkflowID == 0 {
+			return nil, errors.New("missing repository or workflow ID")
+		}
+
+		return &result{
+			Repo:       p.Repository.FullName,
+			Branch:     run.HeadBranch,
+			Name:       run.Name,
+			Dedup:      fmt.Sprintf("github-workflow:%s:%d:%s", strings.ToLower(p.Repository.FullName), run.WorkflowID, run.HeadBranch),
+			Status:     status,
+			Conclusion: run.Conclusion,
+			SHA:        run.HeadSHA,
+			Link:       run.HTMLURL,
+			Event:      run.Event,
+			Title:      run.Title,
+		}, nil
+	case "check_suite":
+		var p checkSuitePayload
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, err
+		}
+		cs := p.CheckSuite
+		status := conclusionStatus(cs.Conclusion)
+		if p.Action != "completed" || status == "" || cs.App.Slug == githubActionsSlug {
+			return nil, nil
+		}
+		if p.Repository.FullName == "" || cs.App.Slug == "" {
+			return nil, errors.New("missing repository or app")
+		}
+
+		name := cs.App.Name
+		if name == "" {
+			name = cs.App.Slug
+		}
+		return &result{
+			Repo:       p.Repository.FullName,
+			Branch:     cs.HeadBranch,
+			Name:       name,
+			Dedup:      fmt.Sprintf("github-check-suite:%s:%s:%s", strings.ToLower(p.Repository.FullName), cs.App.Slug, cs.HeadBranch),
+			Status:     status,
+			Conclusion: cs.Conclusion,
+			SHA:        cs.HeadSHA,
+		}, nil
+	}
+
+	// ping, installation, and any other subscribed events
+	return nil, nil
+}
+
+// validSignature returns true if sig (the `X-Hub-Signature-256` header) matches the body.
+func validSignature(secret, sig string, body []byte) bool {
+	hexSig, ok := strings.CutPrefix(sig, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func GitHubActionsToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		cfg := config.FromContext(ctx)
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from github: %v", err)
+			return
+		}
+
+		if cfg.GitHub.WebhookSecret != "" && !validSignature(cfg.GitHub.WebhookSecret, r.Header.Get("X-Hub-Signature-256"), data) {
+			log.Logf(ctx, "bad request from github: invalid signature")
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		event := r.Header.Get("X-GitHub-Event")
+		ctx = log.WithFields(ctx, log.Fields{
+			"Event":    event,
+			"Delivery": r.Header.Get("X-GitHub-Delivery"),
+		})
+
+		res, err := parse(event, data)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from github: %v", err)
+			return
+		}
+		if res == nil {
+			return
+		}
+
+		var f *integrationkey.GitHubFilter
+		if src := permission.Source(ctx); src != nil && src.Type == permission.SourceTypeIntegrationKey {
+			f, err = intDB.FindGitHubFilter(ctx, src.ID)
+			if errutil.HTTPError(ctx, w, errors.Wrap(err, "lookup github filter")) {
+				return
+			}
+		}
+		if f != nil && !f.Matches(res.Repo, res.Branch) {
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(res.summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(res.details(), alert.MaxDetailsLength),
+			Status:    res.Status,
+			Source:    alert.SourceGitHubActions,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(res.Dedup),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for github")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestParse(t *testing.T) {
+	res, err := parse("workflow_run", []byte(`{
+		"action": "completed",
+		"workflow_run": {"name": "CI", "workflow_id": 42, "head_branch": "main", "head_sha": "abc123", "event": "push", "conclusion": "failure", "html_url": "https://github.com/my-org/api/actions/runs/1"},
+		"repository": {"full_name": "My-Org/api"}
+	}`))
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, alert.StatusTriggered, res.Status)
+	assert.Equal(t, "github-workflow:my-org/api:42:main", res.Dedup)
+	assert.Equal(t, "My-Org/api: CI failure on main", res.summary())
+
+	res, err = parse("workflow_run", []byte(`{"action": "completed", "workflow_run": {"workflow_id": 42, "head_branch": "main", "conclusion": "success"}, "repository": {"full_name": "my-org/api"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, alert.StatusClosed, res.Status)
+	assert.Equal(t, "github-workflow:my-org/api:42:main", res.Dedup)
+
+	// in-progress and cancelled runs are ignored
+	res, err = parse("workflow_run", []byte(`{"action": "requested", "workflow_run": {"workflow_id": 42}, "repository": {"full_name": "my-org/api"}}`))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+	res, err = parse("workflow_run", []byte(`{"action": "completed", "workflow_run": {"workflow_id": 42, "conclusion": "cancelled"}, "repository": {"full_name": "my-org/api"}}`))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = parse("check_suite", []byte(`{"action": "completed", "check_suite": {"head_branch": "main", "conclusion": "timed_out", "app": {"slug": "circleci", "name": "CircleCI"}}, "repository": {"full_name": "my-org/api"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, alert.StatusTriggered, res.Status)
+	assert.Equal(t, "github-check-suite:my-org/api:circleci:main", res.Dedup)
+
+	// GitHub Actions check suites are reported via workflow_run
+	res, err = parse("check_suite", []byte(`{"action": "completed", "check_suite": {"conclusion": "failure", "app": {"slug": "github-actions"}}, "repository": {"full_name": "my-org/api"}}`))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = parse("ping", []byte(`{"zen": "Keep it logically awesome."}`))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestValidSignature(t *testing.T) {
+	// Values pulled directly from: https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+	assert.True(t, validSignature("It's a Secret to Everybody", "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17", []byte("Hello, World!")))
+	assert.False(t, validSignature("It's a Secret to Everybody", "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e18", []byte("Hello, World!")))
+	assert.False(t, validSignature("It's a Secret to Everybody", "", []byte("Hello, World!")))
+}
@@ -0,0 +1,145 @@
+package goalerttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/user/contactmethod"
+)
+
+// UUID returns the stable generated UUID for name.
+func (i *Instance) UUID(name string) string { return i.h.UUID(name) }
+
+// PhoneNumber returns the stable generated phone number for name.
+func (i *Instance) PhoneNumber(name string) string { return i.h.Phone(name) }
+
+// EmailAddress returns the stable generated email address for name.
+func (i *Instance) EmailAddress(name string) string { return i.h.Email(name) }
+
+// CreateUser will create a new user, returning its ID.
+func (i *Instance) CreateUser() string {
+	i.t.Helper()
+	return i.h.CreateUser().ID
+}
+
+// AddSMS will add an SMS contact method to the user, with a rule to notify immediately,
+// and return the phone number.
+func (i *Instance) AddSMS(userID string) string {
+	i.t.Helper()
+	return i.addContactMethod(userID, contactmethod.TypeSMS, i.h.Phone(userID+"-sms"))
+}
+
+// AddVoice will add a voice contact method to the user, with a rule to notify immediately,
+// and return the phone number.
+func (i *Instance) AddVoice(userID string) string {
+	i.t.Helper()
+	return i.addContactMethod(userID, contactmethod.TypeVoice, i.h.Phone(userID+"-voice"))
+}
+
+// AddEmail will add an email contact method to the user, with a rule to notify immediately,
+// and return the address.
+func (i *Instance) AddEmail(userID string) string {
+	i.t.Helper()
+	return i.addContactMethod(userID, contactmethod.TypeEmail, i.h.Email(userID+"-email"))
+}
+
+func (i *Instance) addContactMethod(userID string, typ contactmethod.Type, value string) string {
+	i.t.Helper()
+	cmID := i.h.AddContactMethod(userID, typ, value)
+	i.h.AddNotificationRule(userID, cmID, 0)
+	return value
+}
+
+// Service is a service created with CreateService.
+type Service struct {
+	ID                 string
+	EscalationPolicyID string
+
+	// IntegrationKey is the token of a generic API integration key for the service.
+	IntegrationKey string
+}
+
+// CreateService will create a new service with an escalation policy that notifies
+// the given users immediately, and a generic API integration key.
+func (i *Instance) CreateService(name string, userIDs ...string) Service {
+	i.t.Helper()
+
+	targets := make([]string, len(userIDs))
+	for n, id := range userIDs {
+		targets[n] = fmt.Sprintf(`{type: user, id: %s}`, quote(id))
+	}
+
+	resp := i.GraphQL(fmt.Sprintf(`mutation {
+		createService(input: {
+			name: %s,
+			newEscalationPolicy: {name: %s, repeat: 0, steps: [{delayMinutes: 1, targets: [%s]}]},
+			newIntegrationKeys: [{type: generic, name: "Generic"}]
+		}) { id escalationPolicyID integrationKeys { id } }
+	}`, quote(name), quote(name+" Policy"), strings.Join(targets, ", ")))
+	i.requireNoErrors(resp)
+
+	var res struct {
+		CreateService struct {
+			ID                 string
+			EscalationPolicyID string
+			IntegrationKeys    []struct{ ID string }
+		}
+	}
+	err := json.Unmarshal(resp.Data, &res)
+	if err != nil {
+		i.t.Fatal("parse createService response:", err)
+	}
+	if len(res.CreateService.IntegrationKeys) == 0 {
+		i.t.Fatal("createService: no integration key returned")
+	}
+
+	return Service{
+		ID:                 res.CreateService.ID,
+		EscalationPolicyID: res.CreateService.EscalationPolicyID,
+		IntegrationKey:     res.CreateService.IntegrationKeys[0].ID,
+	}
+}
+
+// CreateAlert will create a new alert directly for the given service.
+func (i *Instance) CreateAlert(serviceID, summary string) Alert {
+	i.t.Helper()
+	return i.h.CreateAlert(serviceID, summary)
+}
+
+// SendAlert will create an alert through the generic API using the given integration key,
+// the same way an external monitoring system would.
+func (i *Instance) SendAlert(integrationKey, summary, details string) {
+	i.t.Helper()
+	v := make(url.Values)
+	v.Set("token", integrationKey)
+	v.Set("summary", summary)
+	v.Set("details", details)
+
+	resp, err := http.PostForm(i.URL()+"/api/v2/generic/incoming", v)
+	if err != nil {
+		i.t.Fatal("send alert:", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		i.t.Fatal("send alert: non-2xx response:", resp.Status)
+	}
+}
+
+func (i *Instance) requireNoErrors(resp *Response) {
+	i.t.Helper()
+	for _, err := range resp.Errors {
+		i.t.Error("GraphQL Error:", err.Message)
+	}
+	if len(resp.Errors) > 0 {
+		i.t.FailNow()
+	}
+}
+
+// quote returns s as a GraphQL string literal.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
@@ -0,0 +1,140 @@
+// Package goalerttest runs a real GoAlert instance, backed by mock Twilio, Slack, and SMTP
+// providers, for use in Go tests.
+//
+// It is intended for third parties building integrations or notification channels that
+// need to test against GoAlert end-to-end. The exported API of this package is kept
+// stable; the underlying smoke-test harness it wraps is not.
+//
+// A Postgres server is required, configured with the DB_URL environment variable
+// (default: postgres://goalert@127.0.0.1:5432?sslmode=disable). Tests are skipped when
+// run with -short.
+//
+// Example:
+//
+//	gt := goalerttest.New(t)
+//	userID := gt.CreateUser()
+//	phone := gt.AddSMS(userID)
+//	svc := gt.CreateService("Disk Monitor", userID)
+//
+//	gt.SendAlert(svc.IntegrationKey, "disk full", "")
+//	gt.Phone(phone).ExpectSMS("disk full")
+package goalerttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/expflag"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+type (
+	// PhoneDevice imitates a phone, used to await SMS messages and voice calls.
+	PhoneDevice = harness.PhoneDevice
+
+	// ExpectedSMS is an SMS message that was received.
+	ExpectedSMS = harness.ExpectedSMS
+
+	// ExpectedCall is a voice call that was received.
+	ExpectedCall = harness.ExpectedCall
+
+	// EmailServer is used to await email messages.
+	EmailServer = harness.EmailServer
+
+	// SlackServer is the mock Slack server, used to await Slack messages.
+	SlackServer = harness.SlackServer
+
+	// Alert is an alert created with CreateAlert.
+	Alert = harness.TestAlert
+
+	// Response is a GraphQL response.
+	Response = harness.QLResponse
+)
+
+// Instance is a running GoAlert instance.
+type Instance struct {
+	t *testing.T
+	h *harness.Harness
+}
+
+type options struct {
+	sql      string
+	data     interface{}
+	expFlags expflag.FlagSet
+}
+
+// Option configures a new Instance.
+type Option func(*options)
+
+// WithSQL will execute the given SQL before GoAlert is started.
+//
+// The query is a Go text/template, rendered with data, and has access to the
+// `uuid`, `phone`, and `email` functions which return a quoted SQL literal of a
+// stable generated value for a given name (e.g., `{{uuid "alice"}}`). The same
+// values are available in tests via UUID, PhoneNumber, and EmailAddress.
+func WithSQL(query string, data interface{}) Option {
+	return func(o *options) {
+		o.sql = query
+		o.data = data
+	}
+}
+
+// WithExpFlags will enable the given experimental flags.
+func WithExpFlags(flags ...expflag.Flag) Option {
+	return func(o *options) { o.expFlags = append(o.expFlags, flags...) }
+}
+
+// New will start a new GoAlert instance with its own database. It is stopped, and
+// any unmet expectations reported, when the test completes.
+func New(t *testing.T, opts ...Option) *Instance {
+	t.Helper()
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	h := harness.NewStoppedHarnessWithFlags(t, o.sql, o.data, "", o.expFlags)
+	h.Start()
+	t.Cleanup(func() { _ = h.Close() })
+
+	return &Instance{t: t, h: h}
+}
+
+// URL returns the base URL of the GoAlert instance.
+func (i *Instance) URL() string { return i.h.URL() }
+
+// GraphQL will perform a GraphQL query as an admin user.
+func (i *Instance) GraphQL(query string) *Response {
+	i.t.Helper()
+	return i.h.GraphQLQueryT(i.t, query)
+}
+
+// GraphQLAsUser will perform a GraphQL query as the given user.
+func (i *Instance) GraphQLAsUser(userID, query string) *Response {
+	i.t.Helper()
+	return i.h.GraphQLQueryUserT(i.t, userID, query)
+}
+
+// SetConfigValue will update a config value (e.g., "General.PublicURL").
+func (i *Instance) SetConfigValue(id, value string) {
+	i.t.Helper()
+	i.h.SetConfigValue(id, value)
+}
+
+// Phone returns the mock device for a phone number, used to await SMS messages and voice calls.
+func (i *Instance) Phone(number string) PhoneDevice { return i.h.Twilio(i.t).Device(number) }
+
+// Email returns the mock SMTP server, used to await email messages.
+func (i *Instance) Email() EmailServer { return i.h.SMTP() }
+
+// Slack returns the mock Slack server.
+func (i *Instance) Slack() SlackServer { return i.h.Slack() }
+
+// Trigger will run an engine cycle and wait for it to complete.
+func (i *Instance) Trigger() { i.h.Trigger() }
+
+// FastForward will advance the database clock by d.
+func (i *Instance) FastForward(d time.Duration) { i.h.FastForward(d) }
+
+// IgnoreErrorsWith will prevent backend errors containing substr from failing the test.
+func (i *Instance) IgnoreErrorsWith(substr string) { i.h.IgnoreErrorsWith(substr) }
@@ -0,0 +1,20 @@
+package goalerttest_test
+
+import (
+	"testing"
+
+	"github.com/target/goalert/goalerttest"
+)
+
+func TestInstance_SendAlert(t *testing.T) {
+	t.Parallel()
+
+	gt := goalerttest.New(t)
+	userID := gt.CreateUser()
+	phone := gt.AddSMS(userID)
+	svc := gt.CreateService("Disk Monitor", userID)
+
+	gt.SendAlert(svc.IntegrationKey, "disk full", "/var is at 99%")
+
+	gt.Phone(phone).ExpectSMS("disk full")
+}
@@ -0,0 +1,287 @@
+package grafana
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxGroupAlerts is the maximum number of alerts from a group listed in the details.
+const maxGroupAlerts = 20
+
+var groupDetailsTmpl = template.Must(template.New("groupDetails").Funcs(template.FuncMap{
+	"escapeTableCell": func(s string) string {
+		s = strings.Replace(s, "\n", "<br />", -1)
+		s = strings.Replace(s, "|", "\\|", -1)
+		return s
+	},
+}).Parse(`
+{{- if .ExternalURL }}[View in Grafana]({{ .ExternalURL }})
+
+{{ end }}
+{{- if .Message }}{{ .Message }}
+
+{{ end }}
+{{- if .CommonLabels }}
+| Label | Value |
+| ----- | ----- |
+{{- range $k, $v := .CommonLabels }}
+| {{ $k }} | {{escapeTableCell $v }} |
+{{- end }}
+
+{{ end }}
+{{- if .Alerts }}
+| Status | Alert | Source |
+| ------ | ----- | ------ |
+{{- range .Alerts }}
+| {{ .Status }} | {{escapeTableCell .Name }} | {{if .GeneratorURL}}[link]({{ .GeneratorURL }}){{end}} |
+{{- end }}
+{{- end }}
+`))
+
+// unifiedPayload is the Grafana Alerting (unified) and Grafana OnCall webhook format.
+type unifiedPayload struct {
+	Receiver          string
+	Status            string
+	GroupKey          string
+	GroupLabels       map[string]string
+	CommonLabels      map[string]string
+	CommonAnnotations map[string]string
+	ExternalURL       string
+	Title             string
+	Message           string
+	Alerts            []struct {
+		Status       string
+		Labels       map[string]string
+		Annotations  map[string]string
+		GeneratorURL string
+	}
+}
+
+// formattedPayload is the Grafana OnCall "Formatted webhook" format.
+type formattedPayload struct {
+	AlertUID string `json:"alert_uid"`
+	Title    string
+	ImageURL string `json:"image_url"`
+	State    string
+	Link     string `json:"link_to_upstream_details"`
+	Message  string
+}
+
+type groupAlert struct {
+	Status       string
+	Name         string
+	GeneratorURL string
+}
+
+// groupDedup returns a dedup key for a group; group keys can be arbitrarily long, so they are hashed.
+func groupDedup(groupKey string) string {
+	sum := sha256.Sum256([]byte(groupKey))
+	return "grafana-group:" + hex.EncodeToString(sum[:])
+}
+
+func groupSummary(p unifiedPayload) string {
+	if s := p.CommonAnnotations["summary"]; s != "" {
+		return s
+	}
+	if s := p.CommonLabels["alertname"]; s != "" {
+		return s
+	}
+	if s := p.GroupLabels["alertname"]; s != "" {
+		return s
+	}
+	if p.Title != "" {
+		return p.Title
+	}
+
+	keys := make([]string, 0, len(p.GroupLabels))
+	for k, v := range p.GroupLabels {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		return "Grafana alert group: " + strings.Join(keys, ", ")
+	}
+
+	return "Grafana alert group"
+}
+
+func alertFromUnified(serviceID string, p unifiedPayload) (*alert.Alert, error) {
+	var status alert.Status
+	switch p.Status {
+	case "firing":
+		status = alert.StatusTriggered
+	case "resolved":
+		status = alert.StatusClosed
+	default:
+		return nil, errors.Errorf("grafana: unknown status: %s", p.Status)
+	}
+
+	var data struct {
+		ExternalURL  string
+		Message      string
+		CommonLabels map[string]string
+		Alerts       []groupAlert
+	}
+	if validate.AbsoluteURL("ExternalURL", p.ExternalURL) == nil {
+		data.ExternalURL = p.ExternalURL
+	}
+	data.Message = p.CommonAnnotations["description"]
+	if data.Message == "" {
+		data.Message = p.Message
+	}
+	data.CommonLabels = p.CommonLabels
+	for _, a := range p.Alerts {
+		if len(data.Alerts) == maxGroupAlerts {
+			break
+		}
+		name := a.Annotations["summary"]
+		if name == "" {
+			name = a.Labels["alertname"]
+		}
+		ga := groupAlert{Status: a.Status, Name: name}
+		if validate.AbsoluteURL("GeneratorURL", a.GeneratorURL) == nil {
+			ga.GeneratorURL = a.GeneratorURL
+		}
+		data.Alerts = append(data.Alerts, ga)
+	}
+
+	var buf strings.Builder
+	err := groupDetailsTmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, err
+	}
+
+	details := strings.TrimSpace(buf.String())
+	if len(p.Alerts) > maxGroupAlerts {
+		details += fmt.Sprintf("\n\n%d more alerts not shown.", len(p.Alerts)-maxGroupAlerts)
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(groupSummary(p), alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:    status,
+		ServiceID: serviceID,
+		Source:    alert.SourceGrafanaOnCall,
+		Dedup:     alert.NewUserDedup(groupDedup(p.GroupKey)),
+	}, nil
+}
+
+func alertFromFormatted(serviceID string, p formattedPayload) (*alert.Alert, error) {
+	var status alert.Status
+	switch p.State {
+	case "alerting":
+		status = alert.StatusTriggered
+	case "ok", "resolved":
+		status = alert.StatusClosed
+	default:
+		return nil, errors.Errorf("grafana: unknown state: %s", p.State)
+	}
+
+	var body strings.Builder
+	if validate.AbsoluteURL("Link", p.Link) == nil {
+		fmt.Fprintf(&body, "[View source](%s)\n\n", p.Link)
+	}
+	body.WriteString(p.Message)
+	if validate.AbsoluteURL("ImageURL", p.ImageURL) == nil {
+		fmt.Fprintf(&body, "\n\n![Panel Snapshot](%s)", p.ImageURL)
+	}
+
+	summary := p.Title
+	if summary == "" {
+		summary = "Grafana OnCall alert " + p.AlertUID
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(strings.TrimSpace(body.String()), alert.MaxDetailsLength),
+		Status:    status,
+		ServiceID: serviceID,
+		Source:    alert.SourceGrafanaOnCall,
+		Dedup:     alert.NewUserDedup("grafana-oncall:" + p.AlertUID),
+	}, nil
+}
+
+// parseOnCall will return the alert for a grouped Grafana Alerting/OnCall webhook payload.
+func parseOnCall(serviceID string, data []byte) (*alert.Alert, error) {
+	var kind struct {
+		GroupKey string
+		AlertUID string `json:"alert_uid"`
+	}
+	err := json.Unmarshal(data, &kind)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case kind.GroupKey != "":
+		var p unifiedPayload
+		err = json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, err
+		}
+		return alertFromUnified(serviceID, p)
+	case kind.AlertUID != "":
+		var p formattedPayload
+		err = json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, err
+		}
+		return alertFromFormatted(serviceID, p)
+	}
+
+	return nil, errors.New("grafana: missing groupKey or alert_uid")
+}
+
+// GrafanaOnCallToEventsAPI accepts grouped Grafana Alerting and Grafana OnCall webhook payloads, creating
+// or closing a single alert for each alert group.
+func GrafanaOnCallToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		data, err := io.ReadAll(r.Body)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+
+		a, err := parseOnCall(serviceID, data)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from grafana oncall: %v", err)
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, a)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for grafana oncall")) {
+			return
+		}
+	}
+}
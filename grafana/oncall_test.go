@@ -0,0 +1,85 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestParseOnCall(t *testing.T) {
+	a, err := parseOnCall("svc", []byte(`{
+		"receiver": "goalert",
+		"status": "firing",
+		"groupKey": "{}:{alertname=\"HighLatency\"}",
+		"groupLabels": {"alertname": "HighLatency"},
+		"commonLabels": {"alertname": "HighLatency", "env": "prod"},
+		"commonAnnotations": {"summary": "Latency is high"},
+		"externalURL": "https://grafana.example.com",
+		"alerts": [
+			{"status": "firing", "labels": {"alertname": "HighLatency", "instance": "a"}, "generatorURL": "https://grafana.example.com/alerting/1"},
+			{"status": "firing", "labels": {"alertname": "HighLatency", "instance": "b"}}
+		]
+	}`))
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "Latency is high", a.Summary)
+	assert.Equal(t, "svc", a.ServiceID)
+	dedup := groupDedup(`{}:{alertname="HighLatency"}`)
+	assert.Equal(t, alert.NewUserDedup(dedup), a.Dedup)
+	assert.Contains(t, a.Details, "[View in Grafana](https://grafana.example.com)")
+	assert.Contains(t, a.Details, "| env | prod |")
+
+	// resolving the group closes the same alert, regardless of the individual alerts
+	a, err = parseOnCall("svc", []byte(`{"status": "resolved", "groupKey": "{}:{alertname=\"HighLatency\"}", "alerts": []}`))
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup(dedup), a.Dedup)
+
+	a, err = parseOnCall("svc", []byte(`{"alert_uid": "08d6891a-835c-e661-39fa-96b6a9e26552", "title": "TestAlert", "state": "alerting", "message": "This alert was sent by user for demonstration purposes"}`))
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "TestAlert", a.Summary)
+	assert.Equal(t, alert.NewUserDedup("grafana-oncall:08d6891a-835c-e661-39fa-96b6a9e26552"), a.Dedup)
+
+	a, err = parseOnCall("svc", []byte(`{"alert_uid": "08d6891a-835c-e661-39fa-96b6a9e26552", "state": "ok"}`))
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("grafana-oncall:08d6891a-835c-e661-39fa-96b6a9e26552"), a.Dedup)
+
+	_, err = parseOnCall("svc", []byte(`{"status": "firing"}`))
+	assert.Error(t, err, "missing groupKey and alert_uid")
+	_, err = parseOnCall("svc", []byte(`{"status": "pending", "groupKey": "a"}`))
+	assert.Error(t, err, "unknown status")
+	_, err = parseOnCall("svc", []byte(`{"alert_uid": "a", "state": "no_data"}`))
+	assert.Error(t, err, "unknown state")
+	_, err = parseOnCall("svc", []byte(`{`))
+	assert.Error(t, err, "invalid json")
+}
+
+func TestGrafanaOnCallToEventsAPI_BadRequest(t *testing.T) {
+	h := GrafanaOnCallToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/grafanaoncall/incoming", strings.NewReader(body)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", `{`)
+	check("missing groupKey and alert_uid", `{"status": "firing"}`)
+	check("unknown status", `{"status": "pending", "groupKey": "a"}`)
+}
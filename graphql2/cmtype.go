@@ -6,6 +6,7 @@ import (
 
 	graphql "github.com/99designs/gqlgen/graphql"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/user/contactmethod"
 )
 
@@ -23,3 +24,18 @@ func UnmarshalContactMethodType(v interface{}) (contactmethod.Type, error) {
 
 	return contactmethod.Type(str), nil
 }
+
+func MarshalTeamContactMethodType(t notificationchannel.Type) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, `"`+string(t)+`"`)
+	})
+}
+
+func UnmarshalTeamContactMethodType(v interface{}) (notificationchannel.Type, error) {
+	str, ok := v.(string)
+	if !ok {
+		return "", errors.New("team contact method types must be strings")
+	}
+
+	return notificationchannel.Type(strings.Trim(str, `"`)), nil
+}
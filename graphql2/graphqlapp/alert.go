@@ -154,6 +154,25 @@ func (a *AlertLogEntry) notificationSentState(ctx context.Context, obj *alertlog
 	return notificationStateFromSendResult(s.Status, a.FormatDestFunc(ctx, s.DestType, s.SrcValue)), nil
 }
 
+func (a *AlertLogEntry) failoverState(ctx context.Context, obj *alertlog.Entry) (*graphql2.NotificationState, error) {
+	e := *obj
+	meta, ok := e.Meta(ctx).(*alertlog.NotificationFailoverMetaData)
+	if !ok || meta == nil {
+		return nil, nil
+	}
+
+	// show why the original notification failed
+	s, err := (*App)(a).FindOneNotificationMessageStatus(ctx, meta.FailedMessageID)
+	if err != nil {
+		return nil, errors.Wrap(err, "find failed message state")
+	}
+	if s == nil {
+		return nil, nil
+	}
+
+	return notificationStateFromSendResult(s.Status, a.FormatDestFunc(ctx, s.DestType, s.SrcValue)), nil
+}
+
 func (a *AlertLogEntry) createdState(ctx context.Context, obj *alertlog.Entry) (*graphql2.NotificationState, error) {
 	e := *obj
 	meta, ok := e.Meta(ctx).(*alertlog.CreatedMetaData)
@@ -174,6 +193,8 @@ func (a *AlertLogEntry) State(ctx context.Context, obj *alertlog.Entry) (*graphq
 		return a.createdState(ctx, obj)
 	case alertlog.TypeNotificationSent:
 		return a.notificationSentState(ctx, obj)
+	case alertlog.TypeNotificationFailover:
+		return a.failoverState(ctx, obj)
 	case alertlog.TypeEscalated:
 		return a.escalationState(ctx, obj)
 	}
@@ -184,6 +205,39 @@ func (q *Query) Alert(ctx context.Context, alertID int) (*alert.Alert, error) {
 	return (*App)(q).FindOneAlert(ctx, alertID)
 }
 
+func (q *Query) ArchivedAlert(ctx context.Context, alertID int) (*graphql2.ArchivedAlert, error) {
+	if q.AlertArchiveStore == nil {
+		// archival not enabled
+		return nil, nil
+	}
+
+	a, err := q.AlertArchiveStore.Get(ctx, alertID)
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	res := &graphql2.ArchivedAlert{
+		AlertID:    a.ID,
+		ServiceID:  a.ServiceID,
+		Summary:    a.Summary,
+		Details:    a.Details,
+		Severity:   graphql2.AlertSeverity(a.Severity),
+		CreatedAt:  a.CreatedAt,
+		ArchivedAt: a.ArchivedAt,
+		Logs:       make([]graphql2.ArchivedAlertLog, 0, len(a.Logs)),
+	}
+	for _, l := range a.Logs {
+		res.Logs = append(res.Logs, graphql2.ArchivedAlertLog{
+			ID:        l.ID,
+			Timestamp: l.Timestamp,
+			Event:     l.Event,
+			Message:   l.Message,
+		})
+	}
+
+	return res, nil
+}
+
 /*
  * Merges favorites and user-specified serviceIDs in opts.FilterByServiceID
  */
@@ -346,6 +400,10 @@ func (a *Alert) Status(ctx context.Context, raw *alert.Alert) (graphql2.AlertSta
 	return "", errors.New("unknown alert status " + string(raw.Status))
 }
 
+func (a *Alert) Severity(ctx context.Context, raw *alert.Alert) (graphql2.AlertSeverity, error) {
+	return graphql2.AlertSeverity(raw.Severity), nil
+}
+
 func (a *Alert) AlertID(ctx context.Context, raw *alert.Alert) (int, error) {
 	return raw.ID, nil
 }
@@ -362,7 +420,7 @@ func (a *Alert) Metrics(ctx context.Context, raw *alert.Alert) (*alertmetrics.Me
 	return (*App)(a).FindOneAlertMetric(ctx, raw.ID)
 }
 
-func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertInput) (*alert.Alert, error) {
+func alertFromInput(input graphql2.CreateAlertInput) *alert.Alert {
 	// An alert when created will always have triggered status
 	a := &alert.Alert{
 		ServiceID: input.ServiceID,
@@ -373,13 +431,45 @@ func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertIn
 	if input.Details != nil {
 		a.Details = *input.Details
 	}
+	if input.Severity != nil {
+		a.Severity = alert.Severity(*input.Severity)
+	}
 
 	if input.Sanitize != nil && *input.Sanitize {
 		a.Summary = validate.SanitizeText(a.Summary, alert.MaxSummaryLength)
 		a.Details = validate.SanitizeText(a.Details, alert.MaxDetailsLength)
 	}
 
-	return m.AlertStore.Create(ctx, a)
+	return a
+}
+
+func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertInput) (*alert.Alert, error) {
+	return m.AlertStore.Create(ctx, alertFromInput(input))
+}
+
+func (m *Mutation) CreateAlerts(ctx context.Context, input []graphql2.CreateAlertInput) ([]graphql2.CreateAlertResult, error) {
+	alerts := make([]alert.Alert, len(input))
+	for i, in := range input {
+		alerts[i] = *alertFromInput(in)
+	}
+
+	res, err := m.AlertStore.CreateOrUpdateBatch(ctx, alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.CreateAlertResult, len(res))
+	for i, r := range res {
+		if r.Err != nil {
+			msg := r.Err.Error()
+			result[i].Error = &msg
+			continue
+		}
+		result[i].Alert = r.Alert
+		result[i].IsNew = r.IsNew
+	}
+
+	return result, nil
 }
 
 func (a *Alert) NoiseReason(ctx context.Context, raw *alert.Alert) (*string, error) {
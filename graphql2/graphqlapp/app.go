@@ -17,16 +17,20 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/archive"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/engine"
+	"github.com/target/goalert/engine/jobqueue"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notice"
@@ -36,17 +40,28 @@ import (
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
+	"github.com/target/goalert/pdimport"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/requesttrace"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/servicenow"
+	"github.com/target/goalert/slo"
+	"github.com/target/goalert/statuscallback"
+	"github.com/target/goalert/statuspage"
+	"github.com/target/goalert/svctemplate"
 	"github.com/target/goalert/swo"
+	"github.com/target/goalert/team"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/delegation"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/unavailability"
+	"github.com/target/goalert/userimport"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
@@ -54,32 +69,43 @@ import (
 )
 
 type App struct {
-	DB                *sql.DB
-	AuthBasicStore    *basic.Store
-	UserStore         *user.Store
-	CMStore           *contactmethod.Store
-	NRStore           *notificationrule.Store
-	NCStore           *notificationchannel.Store
-	AlertStore        *alert.Store
-	AlertMetricsStore *alertmetrics.Store
-	AlertLogStore     *alertlog.Store
-	ServiceStore      *service.Store
-	FavoriteStore     *favorite.Store
-	PolicyStore       *escalation.Store
-	ScheduleStore     *schedule.Store
-	CalSubStore       *calsub.Store
-	RotationStore     *rotation.Store
-	OnCallStore       *oncall.Store
-	IntKeyStore       *integrationkey.Store
-	LabelStore        *label.Store
-	RuleStore         *rule.Store
-	OverrideStore     *override.Store
-	ConfigStore       *config.Store
-	LimitStore        *limit.Store
-	SlackStore        *slack.ChannelSender
-	HeartbeatStore    *heartbeat.Store
-	NoticeStore       *notice.Store
-	APIKeyStore       *apikey.Store
+	DB                  *sql.DB
+	AuthBasicStore      *basic.Store
+	UserStore           *user.Store
+	CMStore             *contactmethod.Store
+	NRStore             *notificationrule.Store
+	NCStore             *notificationchannel.Store
+	AlertStore          *alert.Store
+	AlertMetricsStore   *alertmetrics.Store
+	AlertLogStore       *alertlog.Store
+	AlertArchiveStore   *archive.Store
+	JobStore            *jobqueue.Store
+	ServiceStore        *service.Store
+	FavoriteStore       *favorite.Store
+	UnavailabilityStore *unavailability.Store
+	DelegationStore     *delegation.Store
+	TeamStore           *team.Store
+	PolicyStore         *escalation.Store
+	ScheduleStore       *schedule.Store
+	CalSubStore         *calsub.Store
+	RotationStore       *rotation.Store
+	OnCallStore         *oncall.Store
+	IntKeyStore         *integrationkey.Store
+	JiraStore           *jira.Store
+	ServiceNowStore     *servicenow.Store
+	StatuspageStore     *statuspage.Store
+	StatusCallbackStore *statuscallback.Store
+	SLOStore            *slo.Store
+	RequestTraceStore   *requesttrace.Store
+	LabelStore          *label.Store
+	RuleStore           *rule.Store
+	OverrideStore       *override.Store
+	ConfigStore         *config.Store
+	LimitStore          *limit.Store
+	SlackStore          *slack.ChannelSender
+	HeartbeatStore      *heartbeat.Store
+	NoticeStore         *notice.Store
+	APIKeyStore         *apikey.Store
 
 	AuthLinkStore *authlink.Store
 
@@ -94,6 +120,13 @@ type App struct {
 
 	SWO *swo.Manager
 
+	Engine *engine.Engine
+
+	PDImporter   *pdimport.Importer
+	UserImporter *userimport.Importer
+
+	ServiceTemplateStore *svctemplate.Store
+
 	FormatDestFunc func(context.Context, notification.DestType, string) string
 }
 
@@ -156,6 +189,10 @@ func (a *App) Handler() http.Handler {
 		return ok && enabled
 	}})
 
+	h.Use(complexityStats())
+	h.AroundOperations(traceOperation)
+	h.AroundOperations(metricsOperation)
+
 	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 		src := permission.Source(ctx)
 		if src.Type != permission.SourceTypeGQLAPIKey {
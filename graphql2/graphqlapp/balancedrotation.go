@@ -0,0 +1,107 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+func (m *Mutation) GenerateBalancedRotation(ctx context.Context, input graphql2.GenerateBalancedRotationInput) (*schedule.TemporarySchedule, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	end := input.Start.AddDate(0, 0, 13*7)
+	if input.End != nil {
+		end = *input.End
+	}
+	shiftHours := 168
+	if input.ShiftLengthHours != nil {
+		shiftHours = *input.ShiftLengthHours
+	}
+	historyDays := 90
+	if input.HistoryDays != nil {
+		historyDays = *input.HistoryDays
+	}
+
+	err = validate.Many(
+		validate.ManyUUID("UserIDs", input.UserIDs, 50),
+		validate.Range("ShiftLengthHours", shiftHours, 1, 24*14),
+		validate.Range("HistoryDays", historyDays, 0, 365),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.UserIDs) == 0 {
+		return nil, validation.NewFieldError("UserIDs", "must not be empty")
+	}
+	if !end.After(input.Start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+	if end.After(input.Start.AddDate(0, 0, 100)) {
+		return nil, validation.NewFieldError("End", "cannot be more than 100 days past Start")
+	}
+
+	sched, err := (*App)(m).FindOneSchedule(ctx, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if sched == nil {
+		return nil, validation.NewFieldError("ScheduleID", "schedule not found")
+	}
+
+	loc := sched.TimeZone
+	if input.TimeZone != nil && *input.TimeZone != "" {
+		loc, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, validation.NewFieldError("TimeZone", "unknown time zone")
+		}
+	}
+
+	var history []oncall.Shift
+	if historyDays > 0 {
+		history, err = m.OnCallStore.HistoryBySchedule(ctx, input.ScheduleID, input.Start.AddDate(0, 0, -historyDays), input.Start)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	away, err := m.UnavailabilityStore.FindAllByUsers(ctx, input.UserIDs, input.Start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := schedule.TemporarySchedule{
+		Start: input.Start,
+		End:   end,
+		Shifts: oncall.BalancedShifts(oncall.BalanceOptions{
+			ParticipantIDs: input.UserIDs,
+			Start:          input.Start,
+			End:            end,
+			ShiftLength:    time.Duration(shiftHours) * time.Hour,
+			Location:       loc,
+		}, history, away),
+	}
+
+	if input.Apply == nil || !*input.Apply {
+		return &tmp, nil
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetTemporarySchedule(ctx, tx, schedID, tmp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	(*App)(m).warnRestViolations(ctx, input.ScheduleID, tmp.Start, tmp.End)
+	return &tmp, nil
+}
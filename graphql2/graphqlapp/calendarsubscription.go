@@ -21,6 +21,15 @@ func (a *App) UserCalendarSubscription() graphql2.UserCalendarSubscriptionResolv
 func (a *UserCalendarSubscription) ReminderMinutes(ctx context.Context, obj *calsub.Subscription) ([]int, error) {
 	return obj.Config.ReminderMinutes, nil
 }
+func (a *UserCalendarSubscription) EndReminderMinutes(ctx context.Context, obj *calsub.Subscription) ([]int, error) {
+	return obj.Config.EndReminderMinutes, nil
+}
+func (a *UserCalendarSubscription) RotationID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	if obj.Config.RotationID == "" {
+		return nil, nil
+	}
+	return &obj.Config.RotationID, nil
+}
 func (a *UserCalendarSubscription) FullSchedule(ctx context.Context, obj *calsub.Subscription) (bool, error) {
 	return obj.Config.FullSchedule, nil
 }
@@ -58,9 +67,13 @@ func (m *Mutation) CreateUserCalendarSubscription(ctx context.Context, input gra
 		cs.Disabled = *input.Disabled
 	}
 	cs.Config.ReminderMinutes = input.ReminderMinutes
+	cs.Config.EndReminderMinutes = input.EndReminderMinutes
 	if input.FullSchedule != nil {
 		cs.Config.FullSchedule = *input.FullSchedule
 	}
+	if input.RotationID != nil {
+		cs.Config.RotationID = *input.RotationID
+	}
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		cs, err = m.CalSubStore.CreateTx(ctx, tx, cs)
@@ -86,9 +99,15 @@ func (m *Mutation) UpdateUserCalendarSubscription(ctx context.Context, input gra
 		if input.ReminderMinutes != nil {
 			cs.Config.ReminderMinutes = input.ReminderMinutes
 		}
+		if input.EndReminderMinutes != nil {
+			cs.Config.EndReminderMinutes = input.EndReminderMinutes
+		}
 		if input.FullSchedule != nil {
 			cs.Config.FullSchedule = *input.FullSchedule
 		}
+		if input.RotationID != nil {
+			cs.Config.RotationID = *input.RotationID
+		}
 
 		return m.CalSubStore.UpdateTx(ctx, tx, cs)
 	})
@@ -0,0 +1,249 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/validation"
+)
+
+// cloneScheduleInput will return a CreateScheduleInput that will create a copy of the given schedule.
+//
+// If includeTargets is false, the new schedule will have no rules.
+func (a *App) cloneScheduleInput(ctx context.Context, id, name string, includeTargets bool) (*graphql2.CreateScheduleInput, error) {
+	sched, err := a.FindOneSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sched == nil {
+		return nil, validation.NewFieldError("ID", "schedule not found")
+	}
+
+	input := &graphql2.CreateScheduleInput{
+		Name:        name,
+		Description: &sched.Description,
+		TimeZone:    sched.TimeZone.String(),
+	}
+	if !includeTargets {
+		return input, nil
+	}
+
+	rules, err := a.RuleStore.FindAll(ctx, sched.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := make(map[assignment.RawTarget]int)
+	for _, r := range rules {
+		tgt := assignment.RawTarget{ID: r.Target.TargetID(), Type: r.Target.TargetType()}
+		i, ok := idx[tgt]
+		if !ok {
+			t := tgt // need to make a copy so we can take a pointer
+			i = len(input.Targets)
+			idx[tgt] = i
+			input.Targets = append(input.Targets, graphql2.ScheduleTargetInput{Target: &t})
+		}
+
+		start, end, filter := r.Start, r.End, r.WeekdayFilter
+		input.Targets[i].Rules = append(input.Targets[i].Rules, graphql2.ScheduleRuleInput{
+			Start:         &start,
+			End:           &end,
+			WeekdayFilter: &filter,
+		})
+	}
+
+	return input, nil
+}
+
+// clonePolicyInput will return a CreateEscalationPolicyInput that will create a copy of the given escalation policy.
+//
+// If includeTargets is false, steps will be created without any targets.
+func (a *App) clonePolicyInput(ctx context.Context, id, name string, includeTargets bool) (*graphql2.CreateEscalationPolicyInput, error) {
+	pol, err := a.FindOnePolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pol == nil {
+		return nil, validation.NewFieldError("ID", "escalation policy not found")
+	}
+
+	steps, err := a.PolicyStore.FindAllSteps(ctx, pol.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &graphql2.CreateEscalationPolicyInput{
+		Name:        name,
+		Description: &pol.Description,
+		Repeat:      &pol.Repeat,
+
+		BackoffMultiplier: &pol.BackoffMultiplier,
+		MaxNotifications:  &pol.MaxNotifications,
+	}
+	if pol.GiveUpChannelID != "" {
+		input.GiveUpChannelID = &pol.GiveUpChannelID
+	}
+	for _, step := range steps {
+		stepInput := graphql2.CreateEscalationPolicyStepInput{
+			DelayMinutes: step.DelayMinutes,
+		}
+		if includeTargets {
+			tgts, err := a.PolicyStore.FindAllStepTargetsTx(ctx, nil, step.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, tgt := range tgts {
+				stepInput.Targets = append(stepInput.Targets, assignment.NewRawTarget(tgt))
+			}
+		}
+		input.Steps = append(input.Steps, stepInput)
+	}
+
+	return input, nil
+}
+
+// cloneServiceInput will return a CreateServiceInput that will create a copy of the given service, including
+// labels, heartbeat monitors, and (new) integration keys of the same name and type.
+//
+// If clonePolicy is set, a copy of the escalation policy will be created, using includeTargets. Otherwise the
+// existing escalation policy will be used.
+func (a *App) cloneServiceInput(ctx context.Context, id, name string, clonePolicy, includeTargets bool) (*graphql2.CreateServiceInput, error) {
+	svc, err := a.FindOneService(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if svc == nil {
+		return nil, validation.NewFieldError("ID", "service not found")
+	}
+
+	input := &graphql2.CreateServiceInput{
+		Name:        name,
+		Description: &svc.Description,
+	}
+	if clonePolicy {
+		input.NewEscalationPolicy, err = a.clonePolicyInput(ctx, svc.EscalationPolicyID, name+" Policy", includeTargets)
+		if err != nil {
+			return nil, validation.AddPrefix("escalationPolicy.", err)
+		}
+	} else {
+		input.EscalationPolicyID = &svc.EscalationPolicyID
+	}
+
+	labels, err := a.LabelStore.FindAllByService(ctx, svc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		input.Labels = append(input.Labels, graphql2.SetLabelInput{Key: l.Key, Value: l.Value})
+	}
+
+	monitors, err := a.HeartbeatStore.FindAllByService(ctx, svc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range monitors {
+		input.NewHeartbeatMonitors = append(input.NewHeartbeatMonitors, graphql2.CreateHeartbeatMonitorInput{
+			Name:           m.Name,
+			TimeoutMinutes: int(m.Timeout.Minutes()),
+		})
+	}
+
+	keys, err := a.IntKeyStore.FindAllByService(ctx, svc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		input.NewIntegrationKeys = append(input.NewIntegrationKeys, graphql2.CreateIntegrationKeyInput{
+			Name: k.Name,
+			Type: graphql2.IntegrationKeyType(k.Type),
+		})
+	}
+
+	return input, nil
+}
+
+func (m *Mutation) CloneSchedule(ctx context.Context, input graphql2.CloneScheduleInput) (sched *schedule.Schedule, err error) {
+	schedID, err := parseUUID("ID", input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		create, err := (*App)(m).cloneScheduleInput(ctx, input.ID, input.Name, input.IncludeTargets)
+		if err != nil {
+			return err
+		}
+
+		sched, err = m.CreateSchedule(ctx, *create)
+		if err != nil {
+			return err
+		}
+		newID, err := parseUUID("ID", sched.ID)
+		if err != nil {
+			return err
+		}
+
+		c, err := m.ScheduleStore.RestConstraints(ctx, tx, schedID)
+		if err != nil {
+			return err
+		}
+		if !c.IsZero() {
+			err = m.ScheduleStore.SetRestConstraints(ctx, tx, newID, *c)
+			if err != nil {
+				return err
+			}
+		}
+
+		minCoverage, err := m.ScheduleStore.MinCoverage(ctx, tx, schedID)
+		if err != nil {
+			return err
+		}
+		if minCoverage > 0 {
+			err = m.ScheduleStore.SetMinCoverage(ctx, tx, newID, minCoverage)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !input.IncludeTargets {
+			return nil
+		}
+
+		rules, err := m.ScheduleStore.OnCallNotificationRules(ctx, tx, schedID)
+		if err != nil {
+			return err
+		}
+		for i := range rules {
+			// rule IDs and next notification times are per-schedule
+			rules[i].ID = schedule.RuleID{}
+			rules[i].NextNotification = nil
+		}
+
+		return m.ScheduleStore.SetOnCallNotificationRules(ctx, tx, newID, rules)
+	})
+
+	return sched, err
+}
+
+func (m *Mutation) CloneEscalationPolicy(ctx context.Context, input graphql2.CloneEscalationPolicyInput) (*escalation.Policy, error) {
+	create, err := (*App)(m).clonePolicyInput(ctx, input.ID, input.Name, input.IncludeTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.CreateEscalationPolicy(ctx, *create)
+}
+
+func (m *Mutation) CloneService(ctx context.Context, input graphql2.CloneServiceInput) (*service.Service, error) {
+	create, err := (*App)(m).cloneServiceInput(ctx, input.ID, input.Name, input.CloneEscalationPolicy != nil && *input.CloneEscalationPolicy, input.IncludeTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.CreateService(ctx, *create)
+}
@@ -16,6 +16,22 @@ func (a *Query) IntegrationKeyTypes(ctx context.Context) ([]graphql2.Integration
 		{ID: "grafana", Name: "Grafana", Label: "Grafana Webhook URL", Enabled: true},
 		{ID: "site24x7", Name: "Generic", Label: "Site24x7 Webhook URL", Enabled: true},
 		{ID: "prometheusAlertmanager", Label: "Alertmanager Webhook URL", Name: "Prometheus Alertmanager", Enabled: true},
+		{ID: "datadog", Name: "Datadog", Label: "Datadog Webhook URL", Enabled: true},
+		{ID: "amazonSNS", Name: "Amazon SNS", Label: "Amazon SNS Subscription URL", Enabled: true},
+		{ID: "sentry", Name: "Sentry", Label: "Sentry Webhook URL", Enabled: true},
+		{ID: "zabbix", Name: "Zabbix", Label: "Zabbix Webhook URL", Enabled: true},
+		{ID: "azureMonitor", Name: "Azure Monitor", Label: "Azure Monitor Webhook URL", Enabled: true},
+		{ID: "gcpMonitoring", Name: "Google Cloud Monitoring", Label: "Google Cloud Monitoring Webhook URL", Enabled: true},
+		{ID: "newRelic", Name: "New Relic", Label: "New Relic Webhook URL", Enabled: true},
+		{ID: "splunk", Name: "Splunk", Label: "Splunk Webhook URL", Enabled: true},
+		{ID: "pagerDutyEvents", Name: "PagerDuty Events API", Label: "PagerDuty Events API v2 URL", Enabled: true},
+		{ID: "nagios", Name: "Nagios/Icinga", Label: "Nagios/Icinga Notification URL", Enabled: true},
+		{ID: "snmp", Name: "SNMP Trap", Label: "SNMP Community String", Enabled: cfg.SNMPTrapEnabled()},
+		{ID: "syslog", Name: "Syslog", Label: "Syslog Integration Key", Enabled: cfg.SyslogEnabled()},
+		{ID: "kafka", Name: "Kafka", Label: "Kafka Integration Key", Enabled: cfg.KafkaEnabled()},
+		{ID: "mqtt", Name: "MQTT", Label: "MQTT Integration Key", Enabled: cfg.MQTTEnabled()},
+		{ID: "githubActions", Name: "GitHub Actions", Label: "GitHub Webhook URL", Enabled: true},
+		{ID: "grafanaOnCall", Name: "Grafana OnCall", Label: "Grafana OnCall Webhook URL", Enabled: true},
 	}, nil
 }
 
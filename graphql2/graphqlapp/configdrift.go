@@ -0,0 +1,58 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/drift"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+func (q *Query) ConfigExport(ctx context.Context) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return "", err
+	}
+
+	s, err := drift.Load(ctx, q.DB)
+	if err != nil {
+		return "", err
+	}
+	data, err := s.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (q *Query) ConfigDrift(ctx context.Context, export string) ([]graphql2.ConfigDriftChange, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := drift.Parse([]byte(export))
+	if err != nil {
+		return nil, validation.NewFieldError("Export", err.Error())
+	}
+	actual, err := drift.Load(ctx, q.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []graphql2.ConfigDriftChange{}
+	for _, c := range drift.Diff(expected, actual) {
+		res = append(res, graphql2.ConfigDriftChange{
+			Action:   string(c.Action),
+			Kind:     c.Kind,
+			Name:     c.Name,
+			Field:    c.Field,
+			Expected: c.Expected,
+			Actual:   c.Actual,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,117 @@
+package graphqlapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/auth/oidc"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/permission"
+)
+
+// configTestTimeout is the maximum time allowed for each provider test.
+const configTestTimeout = 10 * time.Second
+
+type configTest struct {
+	Section string
+	FieldID string
+	Enabled func(config.Config) bool
+	Check   func(context.Context) (fieldID string, err error)
+}
+
+func (m *Mutation) configTests() []configTest {
+	return []configTest{
+		{
+			Section: "Twilio",
+			FieldID: "Twilio.AccountSID",
+			Enabled: func(cfg config.Config) bool { return cfg.Twilio.Enable },
+			Check: func(ctx context.Context) (string, error) {
+				err := m.Twilio.CheckAccount(ctx)
+				var e *twilio.Exception
+				if errors.As(err, &e) && e.Status == 401 {
+					return "Twilio.AuthToken", err
+				}
+				return "", err
+			},
+		},
+		{
+			Section: "Slack",
+			FieldID: "Slack.AccessToken",
+			Enabled: func(cfg config.Config) bool { return cfg.Slack.Enable },
+			Check: func(ctx context.Context) (string, error) {
+				missing, err := m.SlackStore.MissingScopes(ctx)
+				if err != nil {
+					return "", err
+				}
+				if len(missing) > 0 {
+					return "", fmt.Errorf("token is missing required scopes: %s", strings.Join(missing, ", "))
+				}
+				return "", nil
+			},
+		},
+		{
+			Section: "SMTP",
+			FieldID: "SMTP.Address",
+			Enabled: func(cfg config.Config) bool { return cfg.SMTP.Enable },
+			Check: func(ctx context.Context) (string, error) {
+				return "", email.NewSender(ctx).CheckServer(ctx)
+			},
+		},
+		{
+			Section: "OIDC",
+			FieldID: "OIDC.IssuerURL",
+			Enabled: func(cfg config.Config) bool { return cfg.OIDC.Enable },
+			Check: func(ctx context.Context) (string, error) {
+				return "", oidc.CheckDiscovery(ctx)
+			},
+		},
+	}
+}
+
+// TestConfig will apply the input to the current config, validate it, and then test connectivity
+// to each enabled provider. Nothing is saved.
+func (m *Mutation) TestConfig(ctx context.Context, input []graphql2.ConfigValueInput) ([]graphql2.ConfigTestResult, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := graphql2.ApplyConfigValues(m.ConfigStore.Config(), input)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	ctx = cfg.Context(ctx)
+
+	res := []graphql2.ConfigTestResult{}
+	for _, t := range m.configTests() {
+		if !t.Enabled(cfg) {
+			continue
+		}
+
+		tCtx, cancel := context.WithTimeout(ctx, configTestTimeout)
+		fieldID, err := t.Check(tCtx)
+		cancel()
+
+		r := graphql2.ConfigTestResult{Section: t.Section, Ok: err == nil, Message: "OK"}
+		if err != nil {
+			if fieldID == "" {
+				fieldID = t.FieldID
+			}
+			r.FieldID = &fieldID
+			r.Message = err.Error()
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
@@ -142,6 +142,18 @@ func (m *Mutation) CreateUserContactMethod(ctx context.Context, input graphql2.C
 	return cm, nil
 }
 
+// validateVoiceVerification returns an error if verification codes for the given type can't be
+// delivered by voice call.
+func validateVoiceVerification(cfg config.Config, fieldName string, t contactmethod.Type) error {
+	if t != contactmethod.TypeSMS && t != contactmethod.TypeVoice {
+		return validation.NewFieldError(fieldName, "only available for SMS and voice contact methods")
+	}
+	if !cfg.Twilio.Enable {
+		return validation.NewFieldError(fieldName, "requires Twilio to be enabled")
+	}
+	return nil
+}
+
 func (m *Mutation) UpdateUserContactMethod(ctx context.Context, input graphql2.UpdateUserContactMethodInput) (bool, error) {
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		cm, err := m.CMStore.FindOne(ctx, tx, input.ID)
@@ -167,7 +179,26 @@ func (m *Mutation) UpdateUserContactMethod(ctx context.Context, input graphql2.U
 }
 
 func (m *Mutation) SendContactMethodVerification(ctx context.Context, input graphql2.SendContactMethodVerificationInput) (bool, error) {
-	err := m.NotificationStore.SendContactMethodVerification(ctx, input.ContactMethodID)
+	if input.Voice == nil {
+		err := m.NotificationStore.SendContactMethodVerification(ctx, input.ContactMethodID)
+		return err == nil, err
+	}
+
+	if *input.Voice {
+		cm, err := m.CMStore.FindOne(ctx, m.DB, input.ContactMethodID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, validation.NewFieldError("contactMethodID", "contact method not found")
+		}
+		if err != nil {
+			return false, err
+		}
+		err = validateVoiceVerification(config.FromContext(ctx), "voice", cm.Type)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err := m.NotificationStore.SendContactMethodVerificationVoice(ctx, input.ContactMethodID, *input.Voice)
 	return err == nil, err
 }
 
@@ -0,0 +1,45 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/delegation"
+)
+
+func (a *User) Delegations(ctx context.Context, obj *user.User) ([]delegation.Delegation, error) {
+	return a.DelegationStore.FindAllByUser(ctx, obj.ID)
+}
+
+func (m *Mutation) CreateUserDelegation(ctx context.Context, input graphql2.CreateUserDelegationInput) (result *delegation.Delegation, err error) {
+	d := &delegation.Delegation{
+		UserID:         permission.UserID(ctx),
+		DelegateUserID: input.DelegateUserID,
+		Start:          input.Start,
+		End:            input.End,
+	}
+	if input.UserID != nil {
+		d.UserID = *input.UserID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		result, err = m.DelegationStore.CreateTx(ctx, tx, d)
+		return err
+	})
+
+	return result, err
+}
+
+func (m *Mutation) DeleteUserDelegation(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.DelegationStore.DeleteTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
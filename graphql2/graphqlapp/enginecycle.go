@@ -0,0 +1,75 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/engine"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+func gqlEngineCycle(s *engine.CycleStatus) *graphql2.EngineCycle {
+	c := &graphql2.EngineCycle{
+		ID:    s.ID.String(),
+		State: graphql2.EngineCycleStatePending,
+	}
+	if !s.StartedAt.IsZero() {
+		c.State = graphql2.EngineCycleStateRunning
+		c.StartedAt = &s.StartedAt
+	}
+	if !s.FinishedAt.IsZero() {
+		c.State = graphql2.EngineCycleStateFinished
+		c.FinishedAt = &s.FinishedAt
+	}
+
+	return c
+}
+
+func (q *Query) EngineCycle(ctx context.Context, id *string) (*graphql2.EngineCycle, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if q.Engine == nil {
+		return nil, validation.NewGenericError("engine is disabled")
+	}
+
+	cycleID := q.Engine.NextCycleID()
+	if id != nil {
+		cycleID, err = validate.ParseUUID("ID", *id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := q.Engine.CycleStatus(cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlEngineCycle(s), nil
+}
+
+func (m *Mutation) TriggerEngineCycle(ctx context.Context) (*graphql2.EngineCycle, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if m.Engine == nil {
+		return nil, validation.NewGenericError("engine is disabled")
+	}
+
+	cycleID, err := m.Engine.TriggerCycle(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := m.Engine.CycleStatus(cycleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gqlEngineCycle(s), nil
+}
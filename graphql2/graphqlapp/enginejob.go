@@ -0,0 +1,66 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/engine/jobqueue"
+	"github.com/target/goalert/graphql2"
+)
+
+func (q *Query) EngineJobs(ctx context.Context, input *graphql2.EngineJobSearchOptions) ([]graphql2.EngineJob, error) {
+	if input == nil {
+		input = &graphql2.EngineJobSearchOptions{}
+	}
+
+	var opts jobqueue.SearchOptions
+	if input.State != nil {
+		opts.State = jobqueue.State(*input.State)
+	}
+	if input.Kind != nil {
+		opts.Kind = *input.Kind
+	}
+	if input.First != nil {
+		opts.Limit = *input.First
+	}
+
+	jobs, err := q.JobStore.FindMany(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.EngineJob, len(jobs))
+	for i, j := range jobs {
+		j := j
+		result[i] = graphql2.EngineJob{
+			ID:              j.ID,
+			Kind:            j.Kind,
+			State:           graphql2.EngineJobState(j.State),
+			Args:            string(j.Args),
+			IntervalSeconds: int(j.Interval.Seconds()),
+			Attempts:        j.Attempts,
+			MaxAttempts:     j.MaxAttempts,
+			RunAt:           j.RunAt,
+			CreatedAt:       j.CreatedAt,
+		}
+		if !j.StartedAt.IsZero() {
+			result[i].StartedAt = &j.StartedAt
+		}
+		if !j.FinishedAt.IsZero() {
+			result[i].FinishedAt = &j.FinishedAt
+		}
+		if j.LastError != "" {
+			result[i].LastError = &j.LastError
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Mutation) RetryEngineJob(ctx context.Context, id int) (bool, error) {
+	err := m.JobStore.Retry(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -71,6 +71,9 @@ func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql
 		if input.EscalationPolicyID != nil {
 			s.PolicyID = *input.EscalationPolicyID
 		}
+		if input.ConferenceBridge != nil {
+			s.ConferenceBridge = *input.ConferenceBridge
+		}
 
 		step, err = m.PolicyStore.CreateStepTx(ctx, tx, s)
 		if err != nil {
@@ -135,6 +138,15 @@ func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.Cr
 		if input.Description != nil {
 			p.Description = *input.Description
 		}
+		if input.BackoffMultiplier != nil {
+			p.BackoffMultiplier = *input.BackoffMultiplier
+		}
+		if input.MaxNotifications != nil {
+			p.MaxNotifications = *input.MaxNotifications
+		}
+		if input.GiveUpChannelID != nil {
+			p.GiveUpChannelID = *input.GiveUpChannelID
+		}
 
 		pol, err = m.PolicyStore.CreatePolicyTx(ctx, tx, p)
 		if err != nil {
@@ -179,6 +191,18 @@ func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.Up
 			ep.Repeat = *input.Repeat
 		}
 
+		if input.BackoffMultiplier != nil {
+			ep.BackoffMultiplier = *input.BackoffMultiplier
+		}
+
+		if input.MaxNotifications != nil {
+			ep.MaxNotifications = *input.MaxNotifications
+		}
+
+		if input.GiveUpChannelID != nil {
+			ep.GiveUpChannelID = *input.GiveUpChannelID
+		}
+
 		err = m.PolicyStore.UpdatePolicyTx(ctx, tx, ep)
 		if err != nil {
 			return err
@@ -244,6 +268,13 @@ func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql
 			}
 		}
 
+		if input.ConferenceBridge != nil {
+			err = m.PolicyStore.UpdateStepConferenceBridgeTx(ctx, tx, step.ID, *input.ConferenceBridge)
+			if err != nil {
+				return err
+			}
+		}
+
 		// update targets if provided
 		if input.Targets != nil {
 			step.Targets = make([]assignment.Target, len(input.Targets))
@@ -348,6 +379,14 @@ func (ep *EscalationPolicy) Steps(ctx context.Context, raw *escalation.Policy) (
 	return ep.PolicyStore.FindAllSteps(ctx, raw.ID)
 }
 
+func (ep *EscalationPolicy) GiveUpChannelID(ctx context.Context, raw *escalation.Policy) (*string, error) {
+	if raw.GiveUpChannelID == "" {
+		return nil, nil
+	}
+
+	return &raw.GiveUpChannelID, nil
+}
+
 func (ep *EscalationPolicy) Notices(ctx context.Context, raw *escalation.Policy) ([]notice.Notice, error) {
 	return ep.NoticeStore.FindAllPolicyNotices(ctx, raw.ID)
 }
@@ -34,6 +34,168 @@ func (m *Mutation) CreateIntegrationKey(ctx context.Context, input graphql2.Crea
 	})
 	return key, err
 }
+func (m *Mutation) SetIntegrationKeyTransform(ctx context.Context, input graphql2.SetIntegrationKeyTransformInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetTransform(ctx, tx, input.ID, &integrationkey.Transform{
+			Summary: input.Summary,
+			Details: input.Details,
+			Dedup:   input.Dedup,
+			Action:  input.Action,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (m *Mutation) SetIntegrationKeyEmailRules(ctx context.Context, input graphql2.SetIntegrationKeyEmailRulesInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetEmailRules(ctx, tx, input.ID, &integrationkey.EmailRules{
+			DedupPattern:     input.DedupPattern,
+			ResolvePattern:   input.ResolvePattern,
+			AllowedSenders:   input.AllowedSenders,
+			MaxDetailsLength: input.MaxDetailsLength,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (m *Mutation) SetIntegrationKeySNMPRules(ctx context.Context, input graphql2.SetIntegrationKeySNMPRulesInput) (bool, error) {
+	rules := make([]integrationkey.SNMPRule, len(input.Rules))
+	for i, r := range input.Rules {
+		rules[i] = integrationkey.SNMPRule{
+			TrapOID:    r.TrapOid,
+			Action:     integrationkey.SNMPAction(r.Action),
+			SummaryOID: r.SummaryOid,
+			DedupOID:   r.DedupOid,
+		}
+	}
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetSNMPRules(ctx, tx, input.ID, rules)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (key *IntegrationKey) SnmpRules(ctx context.Context, raw *integrationkey.IntegrationKey) ([]graphql2.IntegrationKeySNMPRule, error) {
+	if raw.Type != integrationkey.TypeSNMP {
+		return []graphql2.IntegrationKeySNMPRule{}, nil
+	}
+	rules, err := key.IntKeyStore.FindSNMPRules(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]graphql2.IntegrationKeySNMPRule, len(rules))
+	for i, r := range rules {
+		result[i] = graphql2.IntegrationKeySNMPRule{
+			TrapOid:    r.TrapOID,
+			Action:     graphql2.SNMPRuleAction(r.Action),
+			SummaryOid: r.SummaryOID,
+			DedupOid:   r.DedupOID,
+		}
+	}
+	return result, nil
+}
+func (m *Mutation) SetIntegrationKeySyslogFilter(ctx context.Context, input graphql2.SetIntegrationKeySyslogFilterInput) (bool, error) {
+	var f *integrationkey.SyslogFilter
+	if input.Filter != nil {
+		f = &integrationkey.SyslogFilter{
+			MaxSeverity: input.Filter.MaxSeverity,
+			Facilities:  input.Filter.Facilities,
+			AppNames:    input.Filter.AppNames,
+			Hostnames:   input.Filter.Hostnames,
+		}
+	}
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetSyslogFilter(ctx, tx, input.ID, f)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (key *IntegrationKey) SyslogFilter(ctx context.Context, raw *integrationkey.IntegrationKey) (*integrationkey.SyslogFilter, error) {
+	if raw.Type != integrationkey.TypeSyslog {
+		return nil, nil
+	}
+	return key.IntKeyStore.FindSyslogFilter(ctx, raw.ID)
+}
+func (m *Mutation) SetIntegrationKeyMQTTRules(ctx context.Context, input graphql2.SetIntegrationKeyMQTTRulesInput) (bool, error) {
+	rules := make([]integrationkey.MQTTRule, len(input.Rules))
+	for i, r := range input.Rules {
+		rules[i] = integrationkey.MQTTRule{
+			TopicFilter: r.TopicFilter,
+			ValueExpr:   r.ValueExpr,
+			Operator:    integrationkey.MQTTOperator(r.Operator),
+			Threshold:   r.Threshold,
+			AutoClose:   r.AutoClose,
+		}
+	}
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetMQTTRules(ctx, tx, input.ID, rules)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (key *IntegrationKey) MqttRules(ctx context.Context, raw *integrationkey.IntegrationKey) ([]graphql2.IntegrationKeyMQTTRule, error) {
+	if raw.Type != integrationkey.TypeMQTT {
+		return []graphql2.IntegrationKeyMQTTRule{}, nil
+	}
+	rules, err := key.IntKeyStore.FindMQTTRules(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]graphql2.IntegrationKeyMQTTRule, len(rules))
+	for i, r := range rules {
+		result[i] = graphql2.IntegrationKeyMQTTRule{
+			TopicFilter: r.TopicFilter,
+			ValueExpr:   r.ValueExpr,
+			Operator:    graphql2.MQTTRuleOperator(r.Operator),
+			Threshold:   r.Threshold,
+			AutoClose:   r.AutoClose,
+		}
+	}
+	return result, nil
+}
+func (m *Mutation) SetIntegrationKeyGitHubFilter(ctx context.Context, input graphql2.SetIntegrationKeyGitHubFilterInput) (bool, error) {
+	var f *integrationkey.GitHubFilter
+	if input.Filter != nil {
+		f = &integrationkey.GitHubFilter{
+			Repositories: input.Filter.Repositories,
+			Branches:     input.Filter.Branches,
+		}
+	}
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IntKeyStore.SetGitHubFilter(ctx, tx, input.ID, f)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+func (key *IntegrationKey) GithubFilter(ctx context.Context, raw *integrationkey.IntegrationKey) (*integrationkey.GitHubFilter, error) {
+	if raw.Type != integrationkey.TypeGitHubActions {
+		return nil, nil
+	}
+	return key.IntKeyStore.FindGitHubFilter(ctx, raw.ID)
+}
+func (key *IntegrationKey) EmailRules(ctx context.Context, raw *integrationkey.IntegrationKey) (*integrationkey.EmailRules, error) {
+	if raw.Type != integrationkey.TypeEmail {
+		return nil, nil
+	}
+	return key.IntKeyStore.FindEmailRules(ctx, raw.ID)
+}
+func (key *IntegrationKey) Transform(ctx context.Context, raw *integrationkey.IntegrationKey) (*integrationkey.Transform, error) {
+	if raw.Type != integrationkey.TypeGeneric {
+		return nil, nil
+	}
+	return key.IntKeyStore.FindTransform(ctx, raw.ID)
+}
 func (key *IntegrationKey) Type(ctx context.Context, raw *integrationkey.IntegrationKey) (graphql2.IntegrationKeyType, error) {
 	return graphql2.IntegrationKeyType(raw.Type), nil
 }
@@ -50,6 +212,42 @@ func (key *IntegrationKey) Href(ctx context.Context, raw *integrationkey.Integra
 		return cfg.CallbackURL("/api/v2/site24x7/incoming", q), nil
 	case integrationkey.TypePrometheusAlertmanager:
 		return cfg.CallbackURL("/api/v2/prometheusalertmanager/incoming", q), nil
+	case integrationkey.TypeDatadog:
+		return cfg.CallbackURL("/api/v2/datadog/incoming", q), nil
+	case integrationkey.TypeAmazonSNS:
+		return cfg.CallbackURL("/api/v2/amazonsns/incoming", q), nil
+	case integrationkey.TypeSentry:
+		return cfg.CallbackURL("/api/v2/sentry/incoming", q), nil
+	case integrationkey.TypeZabbix:
+		return cfg.CallbackURL("/api/v2/zabbix/incoming", q), nil
+	case integrationkey.TypeAzureMonitor:
+		return cfg.CallbackURL("/api/v2/azuremonitor/incoming", q), nil
+	case integrationkey.TypeGCPMonitoring:
+		return cfg.CallbackURL("/api/v2/gcpmonitoring/incoming", q), nil
+	case integrationkey.TypeNewRelic:
+		return cfg.CallbackURL("/api/v2/newrelic/incoming", q), nil
+	case integrationkey.TypeSplunk:
+		return cfg.CallbackURL("/api/v2/splunk/incoming", q), nil
+	case integrationkey.TypePagerDutyEvents:
+		return cfg.CallbackURL("/api/v2/pagerduty/enqueue", q), nil
+	case integrationkey.TypeNagios:
+		return cfg.CallbackURL("/api/v2/nagios/incoming", q), nil
+	case integrationkey.TypeSNMP:
+		if !cfg.SNMPTrapEnabled() {
+			return "", nil
+		}
+		// the key ID is used as the community string (or v3 user name)
+		return raw.ID, nil
+	case integrationkey.TypeKafka:
+		if !cfg.KafkaEnabled() {
+			return "", nil
+		}
+		// the key ID is included in each event (or the integration-key header)
+		return raw.ID, nil
+	case integrationkey.TypeGitHubActions:
+		return cfg.CallbackURL("/api/v2/githubactions/incoming", q), nil
+	case integrationkey.TypeGrafanaOnCall:
+		return cfg.CallbackURL("/api/v2/grafanaoncall/incoming", q), nil
 	case integrationkey.TypeEmail:
 		if !cfg.EmailIngressEnabled() {
 			return "", nil
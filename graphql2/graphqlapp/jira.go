@@ -0,0 +1,88 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"sort"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/service"
+)
+
+func (m *Mutation) SetServiceJiraConfig(ctx context.Context, input graphql2.SetServiceJiraConfigInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.Config == nil {
+			return m.JiraStore.DeleteServiceConfigTx(ctx, tx, input.ServiceID)
+		}
+
+		cfg := jira.ServiceConfig{
+			ServiceID:           input.ServiceID,
+			ProjectKey:          input.Config.ProjectKey,
+			IssueType:           input.Config.IssueType,
+			SummaryTemplate:     input.Config.SummaryTemplate,
+			DescriptionTemplate: input.Config.DescriptionTemplate,
+			CloseTransition:     input.Config.CloseTransition,
+			Fields:              make(map[string]string, len(input.Config.Fields)),
+		}
+		for _, f := range input.Config.Fields {
+			cfg.Fields[f.FieldID] = f.Template
+		}
+
+		return m.JiraStore.SetServiceConfigTx(ctx, tx, cfg)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Service) JiraConfig(ctx context.Context, raw *service.Service) (*graphql2.ServiceJiraConfig, error) {
+	cfg, err := s.JiraStore.ServiceConfig(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+
+	fields := make([]graphql2.JiraFieldMapping, 0, len(cfg.Fields))
+	for id, tmpl := range cfg.Fields {
+		fields = append(fields, graphql2.JiraFieldMapping{FieldID: id, Template: tmpl})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].FieldID < fields[j].FieldID })
+
+	return &graphql2.ServiceJiraConfig{
+		ProjectKey:          cfg.ProjectKey,
+		IssueType:           cfg.IssueType,
+		SummaryTemplate:     cfg.SummaryTemplate,
+		DescriptionTemplate: cfg.DescriptionTemplate,
+		Fields:              fields,
+		CloseTransition:     cfg.CloseTransition,
+	}, nil
+}
+
+func (a *Alert) JiraIssue(ctx context.Context, raw *alert.Alert) (*graphql2.AlertJiraIssue, error) {
+	iss, err := a.JiraStore.AlertIssue(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if iss == nil {
+		return nil, nil
+	}
+
+	var url string
+	if iss.Key != "" {
+		url = jira.IssueURL(config.FromContext(ctx), iss.Key)
+	}
+
+	return &graphql2.AlertJiraIssue{
+		Key:       iss.Key,
+		URL:       url,
+		Closed:    iss.Closed,
+		LastError: iss.LastError,
+	}, nil
+}
@@ -130,6 +130,23 @@ func (q *MessageLogConnectionStats) TimeSeries(ctx context.Context, opts *notifi
 	return out, nil
 }
 
+func (q *Query) MessageLogRetention(ctx context.Context) (*graphql2.MessageLogRetention, error) {
+	stat, err := q.NotificationStore.MessageLogRetention(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.MessageLogRetention{
+		RetentionDays:  stat.RetentionDays,
+		PendingCleanup: stat.PendingCleanup,
+	}
+	if !stat.OldestMessageAt.IsZero() {
+		res.OldestMessageAt = &stat.OldestMessageAt
+	}
+
+	return res, nil
+}
+
 func (q *Query) MessageLogs(ctx context.Context, opts *graphql2.MessageLogSearchOptions) (conn *graphql2.MessageLogConnection, err error) {
 	if opts == nil {
 		opts = &graphql2.MessageLogSearchOptions{}
@@ -275,3 +292,24 @@ func (q *Query) DebugMessages(ctx context.Context, input *graphql2.DebugMessages
 
 	return conn.Nodes, nil
 }
+
+func (m *Mutation) RequeueMessages(ctx context.Context, ids []string) (int, error) {
+	return m.NotificationStore.RequeueMessages(ctx, ids)
+}
+
+func (m *Mutation) RequeueFailedMessages(ctx context.Context, input graphql2.RequeueFailedMessagesInput) (int, error) {
+	opts := notification.RequeueOptions{
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		CMTypes:       input.ContactMethodTypes,
+	}
+	if input.ErrorCode != nil {
+		opts.ErrorCode = *input.ErrorCode
+	}
+
+	return m.NotificationStore.RequeueFailedMessages(ctx, opts)
+}
+
+func (m *Mutation) CancelMessages(ctx context.Context, ids []string) (int, error) {
+	return m.NotificationStore.CancelMessages(ctx, ids)
+}
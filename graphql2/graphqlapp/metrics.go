@@ -12,4 +12,26 @@ var (
 		Name:      "resolver_",
 		Help:      "GraphQL resolver statistics.",
 	}, []string{"name", "error"})
+
+	metricOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "graphql",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of GraphQL operations by operation name, type, and identity class.",
+	}, []string{"operation", "type", "identity", "error"})
+
+	metricOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "graphql",
+		Name:      "operation_errors_total",
+		Help:      "Total number of GraphQL operations that returned one or more errors.",
+	}, []string{"operation", "type", "identity"})
+
+	metricOperationComplexity = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "graphql",
+		Name:      "operation_complexity",
+		Help:      "Calculated complexity of GraphQL operations.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"operation", "type", "identity"})
 )
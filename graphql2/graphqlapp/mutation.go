@@ -155,8 +155,12 @@ func (a *Mutation) SetTemporarySchedule(ctx context.Context, input graphql2.SetT
 
 		return a.ScheduleStore.SetTemporarySchedule(ctx, tx, schedID, tmp)
 	})
+	if err != nil {
+		return false, err
+	}
 
-	return err == nil, err
+	(*App)(a).warnRestViolations(ctx, input.ScheduleID, input.Start, input.End)
+	return true, nil
 }
 
 func (a *Mutation) ClearTemporarySchedules(ctx context.Context, input graphql2.ClearTemporarySchedulesInput) (bool, error) {
@@ -234,6 +238,7 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 	order := []assignment.TargetType{
 		assignment.TargetTypeRotation,
 		assignment.TargetTypeUserOverride,
+		assignment.TargetTypeTeam,
 		assignment.TargetTypeSchedule,
 		assignment.TargetTypeCalendarSubscription,
 		assignment.TargetTypeUser,
@@ -262,6 +267,8 @@ func (a *Mutation) tryDeleteAll(ctx context.Context, input []assignment.RawTarge
 			err = errors.Wrap(a.PolicyStore.DeleteManyPoliciesTx(ctx, tx, ids), "delete escalation policies")
 		case assignment.TargetTypeIntegrationKey:
 			err = errors.Wrap(a.IntKeyStore.DeleteMany(ctx, tx, ids), "delete integration keys")
+		case assignment.TargetTypeTeam:
+			err = errors.Wrap(a.TeamStore.DeleteManyTx(ctx, tx, ids), "delete teams")
 		case assignment.TargetTypeSchedule:
 			err = errors.Wrap(a.ScheduleStore.DeleteManyTx(ctx, tx, ids), "delete schedules")
 		case assignment.TargetTypeCalendarSubscription:
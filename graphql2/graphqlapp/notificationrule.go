@@ -4,6 +4,7 @@ import (
 	context "context"
 	"database/sql"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
@@ -26,6 +27,8 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 	if input.ContactMethodID != nil {
 		nr.ContactMethodID = *input.ContactMethodID
 	}
+	nr.Severities = severitiesFromInput(input.Severities)
+	nr.Fallbacks = fallbacksFromInput(input.Fallbacks)
 
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
@@ -40,6 +43,55 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 	return nr, nil
 }
 
+func fallbacksFromInput(input []graphql2.UserNotificationRuleFallbackInput) []notificationrule.Fallback {
+	fbs := make([]notificationrule.Fallback, 0, len(input))
+	for _, fb := range input {
+		fbs = append(fbs, notificationrule.Fallback{
+			ContactMethodID: fb.ContactMethodID,
+			DelayMinutes:    fb.DelayMinutes,
+		})
+	}
+	return fbs
+}
+
+func (m *Mutation) SetUserNotificationRuleFallbacks(ctx context.Context, input graphql2.SetUserNotificationRuleFallbacksInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.NRStore.SetFallbacksTx(ctx, tx, input.RuleID, fallbacksFromInput(input.Fallbacks))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func severitiesFromInput(input []graphql2.AlertSeverity) []alert.Severity {
+	sevs := make([]alert.Severity, 0, len(input))
+	for _, sev := range input {
+		sevs = append(sevs, alert.Severity(sev))
+	}
+	return sevs
+}
+
+func (m *Mutation) SetUserNotificationRuleSeverities(ctx context.Context, input graphql2.SetUserNotificationRuleSeveritiesInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.NRStore.SetSeveritiesTx(ctx, tx, input.RuleID, severitiesFromInput(input.Severities))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (nr *UserNotificationRule) Severities(ctx context.Context, raw *notificationrule.NotificationRule) ([]graphql2.AlertSeverity, error) {
+	sevs := make([]graphql2.AlertSeverity, 0, len(raw.Severities))
+	for _, sev := range raw.Severities {
+		sevs = append(sevs, graphql2.AlertSeverity(sev))
+	}
+	return sevs, nil
+}
+
 func (nr *UserNotificationRule) ContactMethod(ctx context.Context, raw *notificationrule.NotificationRule) (*contactmethod.ContactMethod, error) {
 	return (*App)(nr).FindOneCM(ctx, raw.ContactMethodID)
 }
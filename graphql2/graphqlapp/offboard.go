@@ -0,0 +1,38 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/user"
+)
+
+func (a *User) Deactivated(ctx context.Context, obj *user.User) (bool, error) {
+	return a.UserStore.IsDeactivated(ctx, obj.ID)
+}
+
+func (q *Query) UserReferences(ctx context.Context, id string) ([]user.Reference, error) {
+	return q.UserStore.References(ctx, id)
+}
+
+func (m *Mutation) OffboardUser(ctx context.Context, input graphql2.OffboardUserInput) (bool, error) {
+	var replacementID string
+	if input.ReplacementUserID != nil {
+		replacementID = *input.ReplacementUserID
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := m.UserStore.OffboardTx(ctx, tx, input.UserID, replacementID)
+		if err != nil {
+			return err
+		}
+
+		return m.ScheduleStore.ReplaceUserTx(ctx, tx, input.UserID, replacementID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,94 @@
+package graphqlapp
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/target/goalert/permission"
+)
+
+// opNameRx limits operation names used as metric labels, since they are
+// provided by the client.
+var opNameRx = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// metricOpName returns a label-safe operation name.
+func metricOpName(name string) string {
+	switch {
+	case name == "":
+		return "anonymous"
+	case !opNameRx.MatchString(name):
+		return "invalid"
+	}
+	return name
+}
+
+// identityClass returns a coarse description of the caller for use as a metric label.
+func identityClass(ctx context.Context) string {
+	src := permission.Source(ctx)
+	switch {
+	case src != nil && src.Type == permission.SourceTypeGQLAPIKey:
+		if permission.Admin(ctx) {
+			return "api_key_admin"
+		}
+		return "api_key_user"
+	case permission.System(ctx):
+		return "system"
+	case permission.Admin(ctx):
+		return "user_admin"
+	case permission.User(ctx):
+		return "user"
+	case permission.Service(ctx):
+		return "service"
+	case permission.All(ctx):
+		return "other"
+	}
+	return "anonymous"
+}
+
+// complexityStats returns an extension that calculates operation complexity
+// for metrics without enforcing a limit.
+func complexityStats() *extension.ComplexityLimit {
+	return &extension.ComplexityLimit{
+		Func: func(context.Context, *graphql.OperationContext) int { return math.MaxInt },
+	}
+}
+
+// metricsOperation records duration, error, and complexity metrics for each GraphQL operation.
+func metricsOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	name := metricOpName(oc.OperationName)
+	var opType string
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+	}
+	id := identityClass(ctx)
+
+	if stats := extension.GetComplexityStats(ctx); stats != nil {
+		metricOperationComplexity.WithLabelValues(name, opType, id).Observe(float64(stats.Complexity))
+	}
+
+	start := time.Now()
+	respFn := next(ctx)
+
+	var done bool
+	return func(ctx context.Context) *graphql.Response {
+		resp := respFn(ctx)
+		if done {
+			// subsequent responses (e.g., subscriptions) are not timed
+			return resp
+		}
+		done = true
+
+		hasErr := resp != nil && len(resp.Errors) > 0
+		if hasErr {
+			metricOperationErrors.WithLabelValues(name, opType, id).Inc()
+		}
+		metricOperationDuration.WithLabelValues(name, opType, id, strconv.FormatBool(hasErr)).Observe(time.Since(start).Seconds())
+		return resp
+	}
+}
@@ -0,0 +1,67 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/pdimport"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+// errDryRun is used to roll back the import transaction without returning an error.
+var errDryRun = errors.New("dry run")
+
+func (m *Mutation) ImportPagerDuty(ctx context.Context, input graphql2.ImportPagerDutyInput) (*graphql2.PagerDutyImportReport, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := pdimport.ParseExport(strings.NewReader(input.Data))
+	if err != nil {
+		return nil, validation.NewFieldError("Data", err.Error())
+	}
+
+	var rep *pdimport.Report
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		rep, err = m.PDImporter.Import(ctx, tx, exp)
+		if err != nil {
+			return err
+		}
+		if input.DryRun != nil && *input.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	result := &graphql2.PagerDutyImportReport{
+		Mappings: make([]graphql2.PagerDutyImportMapping, 0, len(rep.Mappings)),
+		Issues:   make([]graphql2.PagerDutyImportIssue, 0, len(rep.Issues)),
+	}
+	for _, mp := range rep.Mappings {
+		result.Mappings = append(result.Mappings, graphql2.PagerDutyImportMapping{
+			Type:        string(mp.Type),
+			PagerDutyID: mp.PagerDutyID,
+			ID:          mp.ID,
+			Name:        mp.Name,
+			Existing:    mp.Existing,
+		})
+	}
+	for _, is := range rep.Issues {
+		result.Issues = append(result.Issues, graphql2.PagerDutyImportIssue{
+			Type:        string(is.Type),
+			PagerDutyID: is.PagerDutyID,
+			Name:        is.Name,
+			Message:     is.Message,
+		})
+	}
+
+	return result, nil
+}
@@ -16,3 +16,12 @@ WHERE
         OR (om.message_type = 'alert_notification_bundle'
             AND om.service_id = @service_id::uuid));
 
+
+-- name: EngineEscalationLag :one
+-- EngineEscalationLag returns how long, in seconds, the oldest due escalation has been waiting to be processed.
+SELECT
+    coalesce(extract(epoch FROM now() - min(next_escalation)), 0)::float8 AS lag_seconds
+FROM
+    escalation_policy_state
+WHERE
+    next_escalation <= now();
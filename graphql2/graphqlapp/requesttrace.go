@@ -0,0 +1,50 @@
+package graphqlapp
+
+import (
+	context "context"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+)
+
+func (q *Query) RequestTrace(ctx context.Context, id string) (*graphql2.RequestTrace, error) {
+	t, err := q.RequestTraceStore.Lookup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.RequestTrace{
+		RequestID: t.RequestID,
+		Messages:  make([]graphql2.RequestTraceMessage, 0, len(t.Messages)),
+	}
+	res.Alerts, err = q.AlertStore.FindMany(ctx, t.AlertIDs)
+	if err != nil {
+		return nil, err
+	}
+	if res.Alerts == nil {
+		res.Alerts = []alert.Alert{}
+	}
+
+	for _, m := range t.Messages {
+		msg := graphql2.RequestTraceMessage{
+			ID:                m.ID,
+			Type:              m.Type,
+			DestType:          m.DestType,
+			Status:            m.Status,
+			StatusDetails:     m.StatusDetails,
+			ProviderMessageID: m.ProviderMessageID,
+			CreatedAt:         m.CreatedAt,
+		}
+		if m.AlertID != 0 {
+			alertID := m.AlertID
+			msg.AlertID = &alertID
+		}
+		if !m.SentAt.IsZero() {
+			sentAt := m.SentAt
+			msg.SentAt = &sentAt
+		}
+		res.Messages = append(res.Messages, msg)
+	}
+
+	return res, nil
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/notificationchannel"
@@ -19,8 +21,11 @@ import (
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/timeutil"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 type (
@@ -91,6 +96,289 @@ func (s *Schedule) Shifts(ctx context.Context, raw *schedule.Schedule, start, en
 	return s.OnCallStore.HistoryBySchedule(ctx, raw.ID, start, end)
 }
 
+func validateLoadRange(start, end time.Time, nightStart, nightEnd *timeutil.Clock) (ns, ne timeutil.Clock, err error) {
+	if end.Before(start) {
+		return 0, 0, validation.NewFieldError("EndTime", "must be after StartTime")
+	}
+	if end.After(start.AddDate(0, 0, 92)) {
+		return 0, 0, validation.NewFieldError("EndTime", "cannot be more than 92 days past StartTime")
+	}
+
+	ns, ne = oncall.DefaultNightStart, oncall.DefaultNightEnd
+	if nightStart != nil {
+		ns = *nightStart
+	}
+	if nightEnd != nil {
+		ne = *nightEnd
+	}
+
+	return ns, ne, nil
+}
+
+func (a *App) userLoads(ctx context.Context, loads []oncall.UserLoad) ([]graphql2.OnCallUserLoad, error) {
+	result := make([]graphql2.OnCallUserLoad, 0, len(loads))
+	for _, l := range loads {
+		u, err := a.FindOneUser(ctx, l.UserID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, graphql2.OnCallUserLoad{
+			UserID:       l.UserID,
+			User:         u,
+			OnCallHours:  l.OnCall.Hours(),
+			NightHours:   l.Night.Hours(),
+			WeekendHours: l.Weekend.Hours(),
+			ShiftCount:   l.Shifts,
+			PageCount:    l.Pages,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Schedule) OnCallLoad(ctx context.Context, raw *schedule.Schedule, start, end time.Time, nightStart, nightEnd *timeutil.Clock) ([]graphql2.OnCallUserLoad, error) {
+	ns, ne, err := validateLoadRange(start, end, nightStart, nightEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	loads, err := s.OnCallStore.LoadBySchedule(ctx, raw.ID, start, end, ns, ne)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(s).userLoads(ctx, loads)
+}
+
+func (s *Schedule) RestConstraints(ctx context.Context, raw *schedule.Schedule) (*graphql2.ScheduleRestConstraints, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.ScheduleStore.RestConstraints(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphql2.ScheduleRestConstraints{
+		MaxConsecutiveHours: int(c.MaxConsecutive / time.Hour),
+		MinRestHours:        int(c.MinRest / time.Hour),
+	}, nil
+}
+
+func (s *Schedule) RestViolations(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]graphql2.ScheduleRestViolation, error) {
+	if end.Before(start) {
+		return nil, validation.NewFieldError("EndTime", "must be after StartTime")
+	}
+	if end.After(start.AddDate(0, 0, 50)) {
+		return nil, validation.NewFieldError("EndTime", "cannot be more than 50 days past StartTime")
+	}
+
+	violations, err := s.OnCallStore.RestViolationsBySchedule(ctx, raw.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.ScheduleRestViolation, 0, len(violations))
+	for _, v := range violations {
+		u, err := (*App)(s).FindOneUser(ctx, v.UserID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, graphql2.ScheduleRestViolation{
+			UserID: v.UserID,
+			User:   u,
+			Type:   graphql2.ScheduleRestViolationType(v.Type),
+			Start:  v.Start,
+			End:    v.End,
+		})
+	}
+
+	return result, nil
+}
+
+// warnRestViolations will add a non-fatal error to the response for each rest constraint
+// violation on the schedule between start and end.
+func (a *App) warnRestViolations(ctx context.Context, scheduleID string, start, end time.Time) {
+	if max := start.AddDate(0, 0, 50); end.After(max) {
+		end = max
+	}
+
+	violations, err := a.OnCallStore.RestViolationsBySchedule(ctx, scheduleID, start, end)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("check rest constraints for schedule '%s': %w", scheduleID, err))
+		return
+	}
+
+	for _, v := range violations {
+		name := v.UserID
+		u, err := a.FindOneUser(ctx, v.UserID)
+		if err == nil && u != nil {
+			name = u.Name
+		}
+
+		var msg string
+		switch v.Type {
+		case oncall.RestViolationMaxConsecutive:
+			msg = fmt.Sprintf("%s would be on-call for %s continuously (starting %s)", name, v.End.Sub(v.Start), v.Start.Format(time.RFC3339))
+		case oncall.RestViolationMinRest:
+			msg = fmt.Sprintf("%s would only have %s off between shifts (starting %s)", name, v.End.Sub(v.Start), v.Start.Format(time.RFC3339))
+		}
+
+		graphql.AddError(ctx, &gqlerror.Error{
+			Message: msg,
+			Extensions: map[string]interface{}{
+				"code":      "REST_CONSTRAINT_WARNING",
+				"isWarning": true,
+			},
+		})
+	}
+}
+
+func (m *Mutation) SetScheduleRestConstraints(ctx context.Context, input graphql2.SetScheduleRestConstraintsInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetRestConstraints(ctx, tx, schedID, schedule.RestConstraints{
+			MaxConsecutive: time.Duration(input.MaxConsecutiveHours) * time.Hour,
+			MinRest:        time.Duration(input.MinRestHours) * time.Hour,
+		})
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) SetScheduleMinCoverage(ctx context.Context, input graphql2.SetScheduleMinCoverageInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetMinCoverage(ctx, tx, schedID, input.MinCoverage)
+	})
+
+	return err == nil, err
+}
+
+func (s *Schedule) MinCoverage(ctx context.Context, raw *schedule.Schedule) (int, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.ScheduleStore.MinCoverage(ctx, nil, id)
+}
+
+func (s *Schedule) CoverageGaps(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]graphql2.ScheduleCoverageGap, error) {
+	if end.Before(start) {
+		return nil, validation.NewFieldError("EndTime", "must be after StartTime")
+	}
+	if end.After(start.AddDate(0, 0, 50)) {
+		return nil, validation.NewFieldError("EndTime", "cannot be more than 50 days past StartTime")
+	}
+
+	gaps, err := s.OnCallStore.CoverageGapsBySchedule(ctx, raw.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.ScheduleCoverageGap, 0, len(gaps))
+	for _, g := range gaps {
+		result = append(result, graphql2.ScheduleCoverageGap{
+			Start:       g.Start,
+			End:         g.End,
+			OnCallCount: g.OnCall,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *Schedule) Shadows(ctx context.Context, raw *schedule.Schedule) ([]graphql2.ScheduleShadow, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	shadows, err := s.ScheduleStore.Shadows(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.ScheduleShadow, 0, len(shadows))
+	for _, sh := range shadows {
+		u, err := (*App)(s).FindOneUser(ctx, sh.UserID)
+		if err != nil {
+			return nil, err
+		}
+		gqlShadow := graphql2.ScheduleShadow{
+			ID:     sh.ID.String(),
+			UserID: sh.UserID,
+			User:   u,
+			Start:  sh.Start,
+			End:    sh.End,
+		}
+		if sh.ShadowUserID != "" {
+			shadowUserID := sh.ShadowUserID
+			gqlShadow.ShadowUserID = &shadowUserID
+			gqlShadow.ShadowUser, err = (*App)(s).FindOneUser(ctx, sh.ShadowUserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, gqlShadow)
+	}
+
+	return result, nil
+}
+
+func (m *Mutation) AddScheduleShadow(ctx context.Context, input graphql2.AddScheduleShadowInput) (string, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return "", err
+	}
+
+	sh := schedule.Shadow{
+		UserID: input.UserID,
+		Start:  input.Start,
+		End:    input.End,
+	}
+	if input.ShadowUserID != nil {
+		sh.ShadowUserID = *input.ShadowUserID
+	}
+
+	var id uuid.UUID
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		id, err = m.ScheduleStore.AddShadow(ctx, tx, schedID, sh)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+func (m *Mutation) DeleteScheduleShadow(ctx context.Context, input graphql2.DeleteScheduleShadowInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+	id, err := parseUUID("ID", input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.DeleteShadow(ctx, tx, schedID, id)
+	})
+
+	return err == nil, err
+}
+
 func (s *Schedule) TemporarySchedules(ctx context.Context, raw *schedule.Schedule) ([]schedule.TemporarySchedule, error) {
 	id, err := parseUUID("ScheduleID", raw.ID)
 	if err != nil {
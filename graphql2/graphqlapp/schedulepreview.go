@@ -0,0 +1,101 @@
+package graphqlapp
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+func (s *Schedule) ShiftsPreview(ctx context.Context, raw *schedule.Schedule, start, end time.Time, timeZones []string) ([]graphql2.ScheduleTimeZonePreview, error) {
+	err := validate.Range("TimeZones", len(timeZones), 1, 10)
+	if err != nil {
+		return nil, err
+	}
+	locs := make([]*time.Location, len(timeZones))
+	for i, tz := range timeZones {
+		locs[i], err = util.LoadLocation(tz)
+		if err != nil {
+			return nil, validation.NewFieldError(fmt.Sprintf("TimeZones[%d]", i), "unknown time zone")
+		}
+	}
+
+	shifts, err := s.Shifts(ctx, raw, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// shifts may extend beyond the requested period
+	spanStart, spanEnd := start, end
+	for _, sh := range shifts {
+		if sh.Start.Before(spanStart) {
+			spanStart = sh.Start
+		}
+		if sh.End.After(spanEnd) {
+			spanEnd = sh.End
+		}
+	}
+
+	result := make([]graphql2.ScheduleTimeZonePreview, 0, len(locs))
+	for i, loc := range locs {
+		preview := graphql2.ScheduleTimeZonePreview{
+			TimeZone:    timeZones[i],
+			Shifts:      make([]graphql2.ScheduleShiftPreview, 0, len(shifts)),
+			Transitions: []graphql2.TimeZoneTransition{},
+		}
+		allTransitions := timeutil.ZoneTransitions(loc, spanStart, spanEnd)
+		for _, t := range allTransitions {
+			if t.At.Before(start) || !t.At.Before(end) {
+				continue
+			}
+			preview.Transitions = append(preview.Transitions, graphql2.TimeZoneTransition{
+				At:                t.At,
+				AtLocal:           t.At.In(loc).Format(time.RFC3339),
+				FromZone:          t.FromName,
+				FromOffsetMinutes: t.FromOffset / 60,
+				ToZone:            t.ToName,
+				ToOffsetMinutes:   t.ToOffset / 60,
+			})
+		}
+
+		for _, sh := range shifts {
+			u, err := (*App)(s).FindOneUser(ctx, sh.UserID)
+			if err != nil {
+				return nil, err
+			}
+
+			shStart, shEnd := sh.Start.In(loc), sh.End.In(loc)
+			startZone, _ := shStart.Zone()
+			endZone, _ := shEnd.Zone()
+			var spans bool
+			for _, t := range allTransitions {
+				if t.At.After(sh.Start) && !t.At.After(sh.End) {
+					spans = true
+					break
+				}
+			}
+			preview.Shifts = append(preview.Shifts, graphql2.ScheduleShiftPreview{
+				UserID:          sh.UserID,
+				User:            u,
+				Start:           sh.Start,
+				End:             sh.End,
+				Truncated:       sh.Truncated,
+				StartLocal:      shStart.Format(time.RFC3339),
+				EndLocal:        shEnd.Format(time.RFC3339),
+				StartZone:       startZone,
+				EndZone:         endZone,
+				SpansTransition: spans,
+			})
+		}
+
+		result = append(result, preview)
+	}
+
+	return result, nil
+}
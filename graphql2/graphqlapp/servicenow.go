@@ -0,0 +1,78 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/servicenow"
+)
+
+func (m *Mutation) SetServiceServiceNowConfig(ctx context.Context, input graphql2.SetServiceServiceNowConfigInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.Config == nil {
+			return m.ServiceNowStore.DeleteServiceConfigTx(ctx, tx, input.ServiceID)
+		}
+
+		return m.ServiceNowStore.SetServiceConfigTx(ctx, tx, servicenow.ServiceConfig{
+			ServiceID:       input.ServiceID,
+			AssignmentGroup: input.Config.AssignmentGroup,
+			Category:        input.Config.Category,
+			CallerID:        input.Config.CallerID,
+			Impact:          input.Config.Impact,
+			Urgency:         input.Config.Urgency,
+			ResolveCode:     input.Config.ResolveCode,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Service) ServiceNowConfig(ctx context.Context, raw *service.Service) (*graphql2.ServiceServiceNowConfig, error) {
+	cfg, err := s.ServiceNowStore.ServiceConfig(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+
+	return &graphql2.ServiceServiceNowConfig{
+		AssignmentGroup: cfg.AssignmentGroup,
+		Category:        cfg.Category,
+		CallerID:        cfg.CallerID,
+		Impact:          cfg.Impact,
+		Urgency:         cfg.Urgency,
+		ResolveCode:     cfg.ResolveCode,
+	}, nil
+}
+
+func (a *Alert) ServiceNowIncident(ctx context.Context, raw *alert.Alert) (*graphql2.AlertServiceNowIncident, error) {
+	inc, err := a.ServiceNowStore.AlertIncident(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inc == nil {
+		return nil, nil
+	}
+
+	var url string
+	if inc.SysID != "" {
+		url = servicenow.IncidentURL(config.FromContext(ctx), inc.SysID)
+	}
+
+	return &graphql2.AlertServiceNowIncident{
+		SysID:        inc.SysID,
+		Number:       inc.Number,
+		URL:          url,
+		Acknowledged: inc.Acked,
+		Closed:       inc.Closed,
+		LastError:    inc.LastError,
+	}, nil
+}
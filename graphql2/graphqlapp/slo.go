@@ -0,0 +1,144 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/slo"
+	"github.com/target/goalert/validation"
+)
+
+func (a *App) serviceSLO(ctx context.Context, o slo.SLO) (*graphql2.ServiceSlo, error) {
+	res := &graphql2.ServiceSlo{
+		ID:               o.ID,
+		ServiceID:        o.ServiceID,
+		Name:             o.Name,
+		TargetPercent:    o.TargetPercent,
+		AckWithinMinutes: o.AckWithinMinutes,
+		WindowDays:       o.WindowDays,
+	}
+	if o.Severity != "" {
+		sev := graphql2.AlertSeverity(o.Severity)
+		res.Severity = &sev
+	}
+
+	st, err := a.SLOStore.Status(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	if st != nil {
+		res.Status = &graphql2.ServiceSLOStatus{
+			Total:                       st.Total,
+			Met:                         st.Met,
+			CompliancePercent:           st.Compliance(),
+			ErrorBudgetRemainingPercent: slo.BudgetRemaining(st.Total, st.Met, o.TargetPercent),
+			BurnRate1h:                  st.BurnRate1h,
+			BurnRate6h:                  st.BurnRate6h,
+			BurnRate24h:                 st.BurnRate24h,
+			ComputedAt:                  st.ComputedAt,
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Service) Slos(ctx context.Context, raw *service.Service) ([]graphql2.ServiceSlo, error) {
+	slos, err := s.SLOStore.FindAllByService(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graphql2.ServiceSlo, 0, len(slos))
+	for _, o := range slos {
+		res, err := (*App)(s).serviceSLO(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *res)
+	}
+
+	return result, nil
+}
+
+func (q *Query) ServiceSLOBurnRate(ctx context.Context, id string, windowHours int) (float64, error) {
+	return q.SLOStore.BurnRate(ctx, id, time.Duration(windowHours)*time.Hour)
+}
+
+func (m *Mutation) CreateServiceSlo(ctx context.Context, input graphql2.CreateServiceSLOInput) (result *graphql2.ServiceSlo, err error) {
+	o := slo.SLO{
+		ServiceID:        input.ServiceID,
+		Name:             input.Name,
+		TargetPercent:    input.TargetPercent,
+		AckWithinMinutes: input.AckWithinMinutes,
+	}
+	if input.Severity != nil {
+		o.Severity = alert.Severity(*input.Severity)
+	}
+	if input.WindowDays != nil {
+		o.WindowDays = *input.WindowDays
+	}
+
+	var created *slo.SLO
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		created, err = m.SLOStore.CreateTx(ctx, tx, o)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(m).serviceSLO(ctx, *created)
+}
+
+func (m *Mutation) UpdateServiceSlo(ctx context.Context, input graphql2.UpdateServiceSLOInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		o, err := m.SLOStore.FindOne(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if o == nil {
+			return validation.NewFieldError("ID", "not found")
+		}
+
+		if input.Name != nil {
+			o.Name = *input.Name
+		}
+		if input.Severity != nil {
+			o.Severity = alert.Severity(*input.Severity)
+		}
+		if input.ClearSeverity != nil && *input.ClearSeverity {
+			o.Severity = ""
+		}
+		if input.TargetPercent != nil {
+			o.TargetPercent = *input.TargetPercent
+		}
+		if input.AckWithinMinutes != nil {
+			o.AckWithinMinutes = *input.AckWithinMinutes
+		}
+		if input.WindowDays != nil {
+			o.WindowDays = *input.WindowDays
+		}
+
+		return m.SLOStore.UpdateTx(ctx, tx, *o)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *Mutation) DeleteServiceSlo(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.SLOStore.DeleteTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
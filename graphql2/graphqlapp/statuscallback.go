@@ -0,0 +1,45 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/statuscallback"
+)
+
+func (m *Mutation) SetServiceStatusCallback(ctx context.Context, input graphql2.SetServiceStatusCallbackInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.Callback == nil {
+			return m.StatusCallbackStore.DeleteServiceConfigTx(ctx, tx, input.ServiceID)
+		}
+
+		return m.StatusCallbackStore.SetServiceConfigTx(ctx, tx, statuscallback.ServiceConfig{
+			ServiceID:         input.ServiceID,
+			URL:               input.Callback.URL,
+			UnansweredMinutes: input.Callback.UnansweredMinutes,
+		}, input.RegenerateSecret != nil && *input.RegenerateSecret)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Service) StatusCallback(ctx context.Context, raw *service.Service) (*graphql2.ServiceStatusCallback, error) {
+	cfg, err := s.StatusCallbackStore.ServiceConfig(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+
+	return &graphql2.ServiceStatusCallback{
+		URL:               cfg.URL,
+		Secret:            cfg.Secret,
+		UnansweredMinutes: cfg.UnansweredMinutes,
+	}, nil
+}
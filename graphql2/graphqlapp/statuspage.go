@@ -0,0 +1,51 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/statuspage"
+)
+
+func (m *Mutation) SetServiceStatuspageComponent(ctx context.Context, input graphql2.SetServiceStatuspageComponentInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.Component == nil {
+			return m.StatuspageStore.DeleteServiceComponentTx(ctx, tx, input.ServiceID)
+		}
+
+		return m.StatuspageStore.SetServiceComponentTx(ctx, tx, statuspage.ServiceComponent{
+			ServiceID:       input.ServiceID,
+			ComponentID:     input.Component.ComponentID,
+			DegradedAt:      input.Component.DegradedAt,
+			PartialOutageAt: input.Component.PartialOutageAt,
+			MajorOutageAt:   input.Component.MajorOutageAt,
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Service) StatuspageComponent(ctx context.Context, raw *service.Service) (*graphql2.ServiceStatuspageComponent, error) {
+	st, err := s.StatuspageStore.ServiceComponent(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, nil
+	}
+
+	return &graphql2.ServiceStatuspageComponent{
+		ComponentID:     st.ComponentID,
+		DegradedAt:      st.DegradedAt,
+		PartialOutageAt: st.PartialOutageAt,
+		MajorOutageAt:   st.MajorOutageAt,
+		LastStatus:      string(st.LastStatus),
+		Overridden:      st.Overridden,
+		LastError:       st.LastError,
+	}, nil
+}
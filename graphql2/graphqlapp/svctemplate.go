@@ -0,0 +1,117 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/svctemplate"
+	"github.com/target/goalert/validation"
+)
+
+func templateParams(params []graphql2.TemplateParamInput) (map[string]string, error) {
+	m := make(map[string]string, len(params))
+	for i, p := range params {
+		if _, ok := m[p.Name]; ok {
+			return nil, validation.NewFieldError(fmt.Sprintf("Params[%d].Name", i), "duplicate parameter name")
+		}
+		m[p.Name] = p.Value
+	}
+
+	return m, nil
+}
+
+func (q *Query) ServiceTemplates(ctx context.Context) ([]svctemplate.Template, error) {
+	return q.ServiceTemplateStore.FindAll(ctx)
+}
+
+func (m *Mutation) CreateServiceTemplate(ctx context.Context, input graphql2.CreateServiceTemplateInput) (tmpl *svctemplate.Template, err error) {
+	params, err := templateParams(input.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := (*App)(m).FindOneService(ctx, input.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if svc == nil {
+		return nil, validation.NewFieldError("ServiceID", "service not found")
+	}
+
+	// The escalation policy is always copied so that services created from the template are independent.
+	create, err := (*App)(m).cloneServiceInput(ctx, svc.ID, svc.Name, true, input.IncludeTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(create)
+	if err != nil {
+		return nil, err
+	}
+	data, err = svctemplate.Parameterize(data, params)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &svctemplate.Template{
+		Name: input.Name,
+		Data: data,
+	}
+	if input.Description != nil {
+		t.Description = *input.Description
+	}
+	for name := range params {
+		t.Params = append(t.Params, name)
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		tmpl, err = m.ServiceTemplateStore.CreateTx(ctx, tx, t)
+		return err
+	})
+
+	return tmpl, err
+}
+
+func (m *Mutation) CreateServiceFromTemplate(ctx context.Context, input graphql2.CreateServiceFromTemplateInput) (*service.Service, error) {
+	params, err := templateParams(input.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := m.ServiceTemplateStore.FindOne(ctx, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, validation.NewFieldError("TemplateID", "template not found")
+	}
+
+	data, err := t.Render(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var create graphql2.CreateServiceInput
+	err = json.Unmarshal(data, &create)
+	if err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+	create.Favorite = input.Favorite
+
+	return m.CreateService(ctx, create)
+}
+
+func (m *Mutation) DeleteServiceTemplate(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ServiceTemplateStore.DeleteManyTx(ctx, tx, []string{id})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
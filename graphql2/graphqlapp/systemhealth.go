@@ -0,0 +1,76 @@
+package graphqlapp
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/engine/message"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+)
+
+func (q *Query) SystemHealth(ctx context.Context) (*graphql2.SystemHealth, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	// the global rate limit is shared by all destinations of the same type
+	var limit message.ThrottleRule
+	if rules, ok := message.GlobalCMThrottle.(message.ThrottleRules); ok && len(rules) > 0 {
+		limit = rules[0]
+	}
+
+	now := time.Now()
+	stats, err := q.NotificationStore.MessageQueueStats(ctx, now.Add(-limit.Per))
+	if err != nil {
+		return nil, err
+	}
+
+	lag, err := gadb.New(q.DB).EngineEscalationLag(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStats := q.DB.Stats()
+	res := &graphql2.SystemHealth{
+		EngineLagSeconds: lag,
+		MessageQueues:    make([]graphql2.MessageQueueState, 0, len(stats)),
+		DbPool: &graphql2.DBPoolStats{
+			MaxOpen:     dbStats.MaxOpenConnections,
+			Open:        dbStats.OpenConnections,
+			InUse:       dbStats.InUse,
+			Idle:        dbStats.Idle,
+			WaitCount:   int(dbStats.WaitCount),
+			WaitSeconds: dbStats.WaitDuration.Seconds(),
+		},
+	}
+	for _, s := range stats {
+		s := s
+		state := graphql2.MessageQueueState{
+			DestType:               strings.TrimPrefix(s.DestType.String(), "DestType"),
+			Pending:                s.Pending,
+			RecentlySent:           s.RecentlySent,
+			RateLimit:              limit.Count,
+			RateLimitWindowSeconds: int(limit.Per.Seconds()),
+			Throttled:              limit.Count > 0 && s.Pending > 0 && s.RecentlySent >= limit.Count,
+		}
+		res.PendingMessages += s.Pending
+		if !s.OldestPendingAt.IsZero() {
+			state.OldestPendingAt = &s.OldestPendingAt
+			if res.OldestPendingMessageAt == nil || s.OldestPendingAt.Before(*res.OldestPendingMessageAt) {
+				res.OldestPendingMessageAt = &s.OldestPendingAt
+			}
+		}
+		res.MessageQueues = append(res.MessageQueues, state)
+	}
+	if res.OldestPendingMessageAt != nil {
+		res.OldestPendingMessageAgeSeconds = now.Sub(*res.OldestPendingMessageAt).Seconds()
+	}
+	sort.Slice(res.MessageQueues, func(i, j int) bool { return res.MessageQueues[i].DestType < res.MessageQueues[j].DestType })
+
+	return res, nil
+}
@@ -0,0 +1,110 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notice"
+)
+
+func systemNotice(n notice.SystemNotice) graphql2.SystemNotice {
+	res := graphql2.SystemNotice{
+		ID:        n.ID,
+		Type:      n.Type,
+		Message:   n.Message,
+		Details:   n.Details,
+		AdminOnly: n.AdminOnly,
+		CreatedAt: n.CreatedAt,
+	}
+	if !n.ExpiresAt.IsZero() {
+		t := n.ExpiresAt
+		res.ExpiresAt = &t
+	}
+
+	return res
+}
+
+func (q *Query) Notices(ctx context.Context) ([]notice.Notice, error) {
+	sysNotices, err := q.NoticeStore.ActiveSystemNotices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notices := make([]notice.Notice, 0, len(sysNotices))
+	for _, n := range sysNotices {
+		notices = append(notices, n.Notice)
+	}
+
+	return notices, nil
+}
+
+func (q *Query) SystemNotices(ctx context.Context) ([]graphql2.SystemNotice, error) {
+	sysNotices, err := q.NoticeStore.SystemNotices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]graphql2.SystemNotice, 0, len(sysNotices))
+	for _, n := range sysNotices {
+		res = append(res, systemNotice(n))
+	}
+
+	return res, nil
+}
+
+func (m *Mutation) CreateSystemNotice(ctx context.Context, input graphql2.CreateSystemNoticeInput) (*graphql2.SystemNotice, error) {
+	n := notice.SystemNotice{
+		Notice: notice.Notice{
+			Type:    input.Type,
+			Message: input.Message,
+		},
+	}
+	if input.Details != nil {
+		n.Details = *input.Details
+	}
+	if input.AdminOnly != nil {
+		n.AdminOnly = *input.AdminOnly
+	}
+	if input.ExpiresAt != nil {
+		n.ExpiresAt = *input.ExpiresAt
+	}
+
+	created, err := m.NoticeStore.CreateSystemNotice(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	res := systemNotice(*created)
+	return &res, nil
+}
+
+func (m *Mutation) UpdateSystemNotice(ctx context.Context, input graphql2.UpdateSystemNoticeInput) (bool, error) {
+	n := notice.SystemNotice{
+		ID: input.ID,
+		Notice: notice.Notice{
+			Type:    input.Type,
+			Message: input.Message,
+			Details: input.Details,
+		},
+		AdminOnly: input.AdminOnly,
+	}
+	if input.ExpiresAt != nil {
+		n.ExpiresAt = *input.ExpiresAt
+	}
+
+	err := m.NoticeStore.UpdateSystemNotice(ctx, n)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *Mutation) DeleteSystemNotice(ctx context.Context, id string) (bool, error) {
+	err := m.NoticeStore.DeleteSystemNotices(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
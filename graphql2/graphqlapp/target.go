@@ -48,7 +48,15 @@ func (t *Target) Name(ctx context.Context, raw *assignment.RawTarget) (string, e
 			return "", err
 		}
 		return svc.Name, nil
-
+	case assignment.TargetTypeTeam:
+		tm, err := t.TeamStore.FindOne(ctx, raw.ID)
+		if err != nil {
+			return "", err
+		}
+		if tm == nil {
+			return "", errors.New("team not found")
+		}
+		return tm.Name, nil
 	}
 
 	return "", errors.New("unhandled target type " + raw.Type.String())
@@ -0,0 +1,254 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/team"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+type Team App
+
+func (a *App) Team() graphql2.TeamResolver { return (*Team)(a) }
+
+func (t *Team) DefaultScheduleID(ctx context.Context, raw *team.Team) (*string, error) {
+	if raw.DefaultScheduleID == "" {
+		return nil, nil
+	}
+
+	return &raw.DefaultScheduleID, nil
+}
+
+func (t *Team) DefaultSchedule(ctx context.Context, raw *team.Team) (*schedule.Schedule, error) {
+	if raw.DefaultScheduleID == "" {
+		return nil, nil
+	}
+
+	return (*App)(t).FindOneSchedule(ctx, raw.DefaultScheduleID)
+}
+
+func (t *Team) OnCallUsers(ctx context.Context, raw *team.Team) ([]user.User, error) {
+	ids, err := t.TeamStore.OnCallUserIDs(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]user.User, 0, len(ids))
+	for _, id := range ids {
+		u, err := (*App)(t).FindOneUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			continue
+		}
+		result = append(result, *u)
+	}
+
+	return result, nil
+}
+
+func (t *Team) ContactMethods(ctx context.Context, raw *team.Team) ([]notificationchannel.Channel, error) {
+	return t.NCStore.FindAllByTeam(ctx, raw.ID)
+}
+
+func (t *Team) OnCallLoad(ctx context.Context, raw *team.Team, start, end time.Time, nightStart, nightEnd *timeutil.Clock) (*graphql2.TeamOnCallLoad, error) {
+	ns, ne, err := validateLoadRange(start, end, nightStart, nightEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	loads, err := t.OnCallStore.LoadByTeam(ctx, raw.ID, start, end, ns, ne)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := (*App)(t).userLoads(ctx, loads)
+	if err != nil {
+		return nil, err
+	}
+
+	total := oncall.TotalLoad(loads)
+	return &graphql2.TeamOnCallLoad{
+		Users:        users,
+		OnCallHours:  total.OnCall.Hours(),
+		NightHours:   total.Night.Hours(),
+		WeekendHours: total.Weekend.Hours(),
+		ShiftCount:   total.Shifts,
+		PageCount:    total.Pages,
+	}, nil
+}
+
+func (q *Query) Teams(ctx context.Context) ([]team.Team, error) {
+	return q.TeamStore.FindAll(ctx)
+}
+
+func (q *Query) Team(ctx context.Context, id string) (*team.Team, error) {
+	return q.TeamStore.FindOne(ctx, id)
+}
+
+func (m *Mutation) CreateTeam(ctx context.Context, input graphql2.CreateTeamInput) (result *team.Team, err error) {
+	t := &team.Team{
+		Name: input.Name,
+	}
+	if input.Description != nil {
+		t.Description = *input.Description
+	}
+	if input.DefaultScheduleID != nil {
+		t.DefaultScheduleID = *input.DefaultScheduleID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		result, err = m.TeamStore.CreateTeamTx(ctx, tx, t)
+		return err
+	})
+
+	return result, err
+}
+
+func (m *Mutation) UpdateTeam(ctx context.Context, input graphql2.UpdateTeamInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		t, err := m.TeamStore.FindOne(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return validation.NewFieldError("ID", "team not found")
+		}
+
+		if input.Name != nil {
+			t.Name = *input.Name
+		}
+		if input.Description != nil {
+			t.Description = *input.Description
+		}
+		if input.DefaultScheduleID != nil {
+			t.DefaultScheduleID = *input.DefaultScheduleID
+		}
+
+		return m.TeamStore.UpdateTeamTx(ctx, tx, t)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) CreateTeamContactMethod(ctx context.Context, input graphql2.CreateTeamContactMethodInput) (result *notificationchannel.Channel, err error) {
+	cfg := config.FromContext(ctx)
+	switch input.Type {
+	case notificationchannel.TypeSMS, notificationchannel.TypeVoice:
+		if !cfg.Twilio.Enable {
+			return nil, validation.NewFieldError("type", "Twilio must be enabled by an administrator")
+		}
+	case notificationchannel.TypeEmail:
+		if !cfg.SMTP.Enable {
+			return nil, validation.NewFieldError("type", "SMTP must be enabled by an administrator")
+		}
+	case notificationchannel.TypeSlackChan:
+		if !cfg.Slack.Enable {
+			return nil, validation.NewFieldError("type", "Slack must be enabled by an administrator")
+		}
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		t, err := m.TeamStore.FindOne(ctx, input.TeamID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return validation.NewFieldError("teamID", "team not found")
+		}
+		err = m.TeamStore.CheckMemberTx(ctx, tx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = m.NCStore.CreateTeamChannelTx(ctx, tx, &notificationchannel.Channel{
+			TeamID: t.ID,
+			Name:   input.Name,
+			Type:   input.Type,
+			Value:  input.Value,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func (m *Mutation) DeleteTeamContactMethod(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := (*App)(m).checkTeamChannel(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return m.NCStore.DeleteTeamChannelTx(ctx, tx, id)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) SendTeamContactMethodVerification(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		c, err := (*App)(m).checkTeamChannel(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if !c.Type.RequiresVerification() {
+			return validation.NewFieldError("id", "only phone numbers and email addresses need to be verified")
+		}
+		return m.NotificationStore.SendTeamChannelVerificationTx(ctx, tx, id)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) VerifyTeamContactMethod(ctx context.Context, input graphql2.VerifyTeamContactMethodInput) (bool, error) {
+	err := validate.Range("Code", input.Code, 100000, 999999)
+	if err != nil {
+		return false, validation.NewFieldError("code", "must be 6 digits")
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := (*App)(m).checkTeamChannel(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		return m.NotificationStore.VerifyTeamChannelTx(ctx, tx, input.ID, input.Code)
+	})
+
+	return err == nil, err
+}
+
+// checkTeamChannel returns the team contact method with the given ID, if the current user is an admin or
+// a member of the team that owns it.
+func (a *App) checkTeamChannel(ctx context.Context, tx *sql.Tx, id string) (*notificationchannel.Channel, error) {
+	chanID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, validation.NewFieldError("id", "invalid ID")
+	}
+	c, err := a.NCStore.FindOne(ctx, chanID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && c.TeamID == "") {
+		return nil, validation.NewFieldError("id", "team contact method not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.TeamStore.CheckMemberTx(ctx, tx, c.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,180 @@
+package graphqlapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	testNotificationDefaultWait  = 15 * time.Second
+	testNotificationPollInterval = 2 * time.Second
+)
+
+type testNotificationTrace struct {
+	steps []graphql2.TestNotificationStep
+}
+
+func (t *testNotificationTrace) add(step string, err error, msg string) bool {
+	s := graphql2.TestNotificationStep{At: time.Now(), Step: step, Ok: err == nil, Message: msg}
+	if err != nil {
+		s.Message = err.Error()
+	}
+	t.steps = append(t.steps, s)
+	return err == nil
+}
+
+func (t *testNotificationTrace) addState(step string, s notification.Status, formattedSrc string) {
+	state := notificationStateFromSendResult(s, formattedSrc)
+	t.steps = append(t.steps, graphql2.TestNotificationStep{
+		At:      time.Now(),
+		Step:    step,
+		Ok:      s.State != notification.StateFailedTemp && s.State != notification.StateFailedPerm,
+		Message: state.Details,
+		State:   state,
+	})
+}
+
+func (t *testNotificationTrace) result(providerMsgID string) *graphql2.TestNotificationResult {
+	res := &graphql2.TestNotificationResult{Ok: true, Trace: t.steps}
+	for _, s := range t.steps {
+		if !s.Ok {
+			res.Ok = false
+		}
+	}
+	if providerMsgID != "" {
+		res.ProviderMessageID = &providerMsgID
+	}
+
+	return res
+}
+
+// isFinalState returns true if no further status updates are expected for the destination type.
+func isFinalState(s notification.State, destType notification.DestType) bool {
+	switch s {
+	case notification.StateDelivered, notification.StateFailedTemp, notification.StateFailedPerm:
+		return true
+	case notification.StateSent:
+		// only SMS and voice report delivery after being sent
+		return destType != notification.DestTypeSMS && destType != notification.DestTypeVoice
+	}
+	return false
+}
+
+// TestNotification will send a test message directly through the configured provider for the
+// destination, bypassing the message queue, and report each step of delivery.
+func (m *Mutation) TestNotification(ctx context.Context, input graphql2.TestNotificationInput) (*graphql2.TestNotificationResult, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := testNotificationDefaultWait
+	if input.WaitSeconds != nil {
+		err = validate.Range("WaitSeconds", *input.WaitSeconds, 0, 60)
+		if err != nil {
+			return nil, err
+		}
+		wait = time.Duration(*input.WaitSeconds) * time.Second
+	}
+
+	cfg := config.FromContext(ctx)
+	var dest notification.Dest
+	var enabled bool
+	var section string
+	switch input.Type {
+	case graphql2.TestNotificationDestTypeSms:
+		err = validate.Phone("Value", input.Value)
+		dest.Type, enabled, section = notification.DestTypeSMS, cfg.Twilio.Enable, "Twilio"
+	case graphql2.TestNotificationDestTypeVoice:
+		err = validate.Phone("Value", input.Value)
+		dest.Type, enabled, section = notification.DestTypeVoice, cfg.Twilio.Enable, "Twilio"
+	case graphql2.TestNotificationDestTypeEmail:
+		err = validate.Email("Value", input.Value)
+		dest.Type, enabled, section = notification.DestTypeUserEmail, cfg.SMTP.Enable, "SMTP"
+	case graphql2.TestNotificationDestTypeSLACkChannel:
+		err = validate.ASCII("Value", input.Value, 1, 32)
+		dest.Type, enabled, section = notification.DestTypeSlackChannel, cfg.Slack.Enable, "Slack"
+	default:
+		err = validation.NewFieldError("Type", "unsupported type")
+	}
+	if err != nil {
+		return nil, err
+	}
+	dest.Value = input.Value
+
+	var trace testNotificationTrace
+	if !enabled {
+		trace.add("config", fmt.Errorf("%s is disabled", section), "")
+		return trace.result(""), nil
+	}
+	trace.add("config", nil, section+" is enabled")
+
+	if dest.Type == notification.DestTypeSlackChannel {
+		ch, err := m.SlackStore.Channel(ctx, dest.Value)
+		if err != nil {
+			trace.add("lookup", err, "")
+			return trace.result(""), nil
+		}
+		trace.add("lookup", nil, "Found channel #"+ch.Name)
+	}
+
+	msg := notification.Test{Dest: dest, CallbackID: uuid.New().String()}
+	sendRes, err := m.NotificationManager.SendMessage(ctx, msg)
+	if !trace.add("send", err, "Accepted by "+section) {
+		return trace.result(""), nil
+	}
+	trace.addState("status", sendRes.Status, m.FormatDestFunc(ctx, dest.Type, sendRes.Status.SrcValue))
+
+	providerMsgID := sendRes.ProviderMessageID.String()
+	if sendRes.ProviderMessageID.ExternalID == "" {
+		// nothing to poll
+		providerMsgID = ""
+	}
+	last := sendRes.Status
+	if providerMsgID == "" || isFinalState(last.State, dest.Type) || wait == 0 {
+		return trace.result(providerMsgID), nil
+	}
+
+	t := time.NewTicker(testNotificationPollInterval)
+	defer t.Stop()
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return trace.result(providerMsgID), nil
+		case <-timeout.C:
+			trace.add("status", nil, fmt.Sprintf("No final status after %s, use debugMessageStatus to check later", wait))
+			return trace.result(providerMsgID), nil
+		case <-t.C:
+		}
+
+		status, _, err := m.NotificationManager.MessageStatus(ctx, sendRes.ProviderMessageID)
+		if errors.Is(err, notification.ErrStatusUnsupported) {
+			trace.add("status", nil, "Status updates are not supported by "+section)
+			return trace.result(providerMsgID), nil
+		}
+		if err != nil {
+			trace.add("status", err, "")
+			return trace.result(providerMsgID), nil
+		}
+		if status.State == last.State && status.Details == last.Details {
+			continue
+		}
+		last = *status
+		trace.addState("status", last, m.FormatDestFunc(ctx, dest.Type, last.SrcValue))
+		if isFinalState(last.State, dest.Type) {
+			return trace.result(providerMsgID), nil
+		}
+	}
+}
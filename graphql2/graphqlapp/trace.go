@@ -0,0 +1,41 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/target/goalert/graphql2/graphqlapp")
+
+// traceOperation will record a span for each GraphQL operation.
+func traceOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	name := oc.OperationName
+	if name == "" {
+		name = "anonymous"
+	}
+	var opType string
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+	}
+
+	ctx, span := tracer.Start(ctx, "GraphQL "+name, trace.WithAttributes(
+		attribute.String("graphql.operation.name", name),
+		attribute.String("graphql.operation.type", opType),
+	))
+	respFn := next(ctx)
+
+	return func(ctx context.Context) *graphql.Response {
+		resp := respFn(ctx)
+		if resp != nil && len(resp.Errors) > 0 {
+			span.SetStatus(codes.Error, resp.Errors.Error())
+		}
+		span.End()
+		return resp
+	}
+}
@@ -0,0 +1,48 @@
+package graphqlapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+)
+
+func (q *Query) TwilioSpend(ctx context.Context, input *graphql2.TwilioSpendOptions) (*graphql2.TwilioSpend, error) {
+	if input == nil {
+		input = &graphql2.TwilioSpendOptions{}
+	}
+
+	end := time.Now()
+	if input.End != nil {
+		end = *input.End
+	}
+	start := end.AddDate(0, 0, -30)
+	if input.Start != nil {
+		start = *input.Start
+	}
+
+	s, err := q.Twilio.Spend(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.TwilioSpend{
+		Start:       s.Start,
+		End:         s.End,
+		TotalCost:   s.Total,
+		TodayCost:   s.Today,
+		DailyBudget: config.FromContext(ctx).Twilio.DailyBudget,
+		Regions:     make([]graphql2.TwilioRegionSpend, 0, len(s.Regions)),
+	}
+	for _, r := range s.Regions {
+		res.Regions = append(res.Regions, graphql2.TwilioRegionSpend{
+			Type:   r.Type,
+			Region: r.Region,
+			Count:  r.Count,
+			Cost:   r.Cost,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/unavailability"
+	"github.com/target/goalert/validation"
+)
+
+func (a *User) Unavailability(ctx context.Context, obj *user.User) ([]unavailability.Unavailability, error) {
+	now := time.Now()
+	return a.UnavailabilityStore.FindAllByUsers(ctx, []string{obj.ID}, now, now.AddDate(10, 0, 0))
+}
+
+func (s *Schedule) CoverageConflicts(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]graphql2.ScheduleCoverageConflict, error) {
+	if end.Before(start) {
+		return nil, validation.NewFieldError("EndTime", "must be after StartTime")
+	}
+	if end.After(start.AddDate(0, 0, 50)) {
+		return nil, validation.NewFieldError("EndTime", "cannot be more than 50 days past StartTime")
+	}
+
+	shifts, err := s.OnCallStore.HistoryBySchedule(ctx, raw.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(shifts) == 0 {
+		return []graphql2.ScheduleCoverageConflict{}, nil
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, s := range shifts {
+		if seen[s.UserID] {
+			continue
+		}
+		seen[s.UserID] = true
+		userIDs = append(userIDs, s.UserID)
+	}
+
+	away, err := s.UnavailabilityStore.FindAllByUsers(ctx, userIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := oncall.CoverageConflicts(shifts, away)
+	result := make([]graphql2.ScheduleCoverageConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		u, err := (*App)(s).FindOneUser(ctx, c.UserID)
+		if err != nil {
+			return nil, err
+		}
+		conflict := graphql2.ScheduleCoverageConflict{
+			UserID:           c.UserID,
+			User:             u,
+			UnavailabilityID: c.UnavailabilityID,
+			Start:            c.Start,
+			End:              c.End,
+			SuggestedUsers:   make([]user.User, 0, len(c.SuggestedUserIDs)),
+		}
+		for _, id := range c.SuggestedUserIDs {
+			su, err := (*App)(s).FindOneUser(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if su == nil {
+				continue
+			}
+			conflict.SuggestedUsers = append(conflict.SuggestedUsers, *su)
+		}
+		result = append(result, conflict)
+	}
+
+	return result, nil
+}
+
+func (m *Mutation) CreateUserUnavailability(ctx context.Context, input graphql2.CreateUserUnavailabilityInput) (result *unavailability.Unavailability, err error) {
+	u := &unavailability.Unavailability{
+		UserID: permission.UserID(ctx),
+		Start:  input.Start,
+		End:    input.End,
+	}
+	if input.UserID != nil {
+		u.UserID = *input.UserID
+	}
+	if input.Note != nil {
+		u.Note = *input.Note
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		result, err = m.UnavailabilityStore.CreateTx(ctx, tx, u)
+		return err
+	})
+
+	return result, err
+}
+
+func (m *Mutation) DeleteUserUnavailability(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.UnavailabilityStore.DeleteTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *Mutation) ImportUserUnavailability(ctx context.Context, input graphql2.ImportUserUnavailabilityInput) (int, error) {
+	userID := permission.UserID(ctx)
+	if input.UserID != nil {
+		userID = *input.UserID
+	}
+
+	entries, err := unavailability.ParseICS(strings.NewReader(input.Ics), time.Now())
+	if err != nil {
+		return 0, validation.NewFieldError("ICS", err.Error())
+	}
+
+	var n int
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		result, err := m.UnavailabilityStore.ReplaceICSTx(ctx, tx, userID, entries)
+		n = len(result)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
@@ -3,6 +3,7 @@ package graphqlapp
 import (
 	context "context"
 	"database/sql"
+	"time"
 
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/calsub"
@@ -13,11 +14,14 @@ import (
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notice"
+	"github.com/target/goalert/notification/i18n"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/util"
 )
 
 type (
@@ -46,6 +50,60 @@ func (a *User) Sessions(ctx context.Context, obj *user.User) ([]graphql2.UserSes
 
 	return out, nil
 }
+func (a *User) AlertDigestMinutes(ctx context.Context, obj *user.User) (int, error) {
+	return a.UserStore.AlertDigestMinutes(ctx, obj.ID)
+}
+
+func (a *User) Locale(ctx context.Context, obj *user.User) (string, error) {
+	return a.UserStore.Locale(ctx, obj.ID)
+}
+
+func (a *User) QuietHours(ctx context.Context, obj *user.User) (*graphql2.UserQuietHours, error) {
+	q, err := a.UserStore.QuietHours(ctx, obj.ID)
+	if err != nil || q == nil {
+		return nil, err
+	}
+
+	return &graphql2.UserQuietHours{
+		Start:    q.Start,
+		End:      q.End,
+		TimeZone: q.TimeZone.String(),
+		Active:   q.Active(time.Now()),
+	}, nil
+}
+
+func (a *User) Notices(ctx context.Context, obj *user.User) ([]notice.Notice, error) {
+	return a.NoticeStore.FindAllUserNotices(ctx, obj.ID)
+}
+
+func (a *User) NotificationReliability(ctx context.Context, obj *user.User, since *time.Time) ([]graphql2.ContactMethodReliability, error) {
+	start := time.Now().AddDate(0, 0, -30)
+	if since != nil {
+		start = *since
+	}
+
+	stats, err := a.NotificationStore.UserReliability(ctx, obj.ID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]graphql2.ContactMethodReliability, len(stats))
+	for i, s := range stats {
+		out[i] = graphql2.ContactMethodReliability{
+			ContactMethodID:     s.ContactMethodID,
+			Name:                s.Name,
+			Type:                s.DestType.CMType(),
+			Delivered:           s.Delivered,
+			Sent:                s.Sent,
+			Failed:              s.Failed,
+			NoResponse:          s.NoResponse,
+			ConsecutiveFailures: s.ConsecutiveFailures,
+		}
+	}
+
+	return out, nil
+}
+
 func isCurrentSession(ctx context.Context, sessID string) bool {
 	src := permission.Source(ctx)
 	if src == nil {
@@ -194,6 +252,44 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 			}
 		}
 
+		if input.AlertDigestMinutes != nil {
+			err = a.UserStore.SetAlertDigestMinutesTx(ctx, tx, input.ID, *input.AlertDigestMinutes)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.QuietHours != nil && input.ClearQuietHours != nil && *input.ClearQuietHours {
+			return validation.NewFieldError("clearQuietHours", "cannot be used with quietHours")
+		}
+		if input.QuietHours != nil {
+			loc, err := util.LoadLocation(input.QuietHours.TimeZone)
+			if err != nil {
+				return validation.NewFieldError("quietHours.timeZone", err.Error())
+			}
+			err = a.UserStore.SetQuietHoursTx(ctx, tx, input.ID, &user.QuietHours{
+				Start:    input.QuietHours.Start,
+				End:      input.QuietHours.End,
+				TimeZone: loc,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if input.ClearQuietHours != nil && *input.ClearQuietHours {
+			err = a.UserStore.SetQuietHoursTx(ctx, tx, input.ID, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Locale != nil {
+			err = a.UserStore.SetLocaleTx(ctx, tx, input.ID, *input.Locale)
+			if err != nil {
+				return err
+			}
+		}
+
 		if input.Name != nil {
 			usr.Name = *input.Name
 		}
@@ -206,6 +302,10 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 	return err == nil, err
 }
 
+func (q *Query) NotificationLocales(ctx context.Context) ([]string, error) {
+	return i18n.Locales(), nil
+}
+
 func (q *Query) Users(ctx context.Context, opts *graphql2.UserSearchOptions, first *int, after, searchStr *string) (conn *graphql2.UserConnection, err error) {
 	if opts == nil {
 		opts = &graphql2.UserSearchOptions{
@@ -0,0 +1,83 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/userimport"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+func (m *Mutation) ImportUsers(ctx context.Context, input graphql2.ImportUsersInput) ([]graphql2.UserImportResult, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := input.DryRun != nil && *input.DryRun
+	sendVerification := input.SendVerification != nil && *input.SendVerification
+	if sendVerification && !config.FromContext(ctx).Twilio.Enable {
+		return nil, validation.NewFieldError("sendVerification", "requires Twilio to be enabled")
+	}
+
+	rows, err := userimport.ParseCSV(strings.NewReader(input.CSV))
+	if err != nil {
+		return nil, validation.NewFieldError("csv", err.Error())
+	}
+
+	var results []userimport.Result
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		results, err = m.UserImporter.Import(ctx, tx, rows)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	out := make([]graphql2.UserImportResult, 0, len(results))
+	for _, r := range results {
+		res := graphql2.UserImportResult{
+			Line:    r.Line,
+			Name:    r.Name,
+			Email:   r.Email,
+			Status:  graphql2.UserImportStatus(r.Status),
+			Message: r.Message,
+		}
+		if r.UserID != "" && (!dryRun || r.Status != userimport.StatusCreated) {
+			id := r.UserID
+			res.UserID = &id
+		}
+		if r.ContactMethodID != "" && !dryRun {
+			id := r.ContactMethodID
+			res.ContactMethodID = &id
+
+			if sendVerification {
+				// sent after commit, like contact methods created individually
+				err = m.NotificationStore.SendContactMethodVerification(ctx, id)
+				if err != nil {
+					log.Log(ctx, err)
+					_, err = errutil.ScrubError(err)
+					res.Message = "verification not sent: " + err.Error()
+				} else {
+					res.VerificationSent = true
+				}
+			}
+		}
+		out = append(out, res)
+	}
+
+	return out, nil
+}
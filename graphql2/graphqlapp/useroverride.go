@@ -20,6 +20,7 @@ func (q *Query) UserOverride(ctx context.Context, id string) (*override.UserOver
 }
 
 func (m *Mutation) UpdateUserOverride(ctx context.Context, input graphql2.UpdateUserOverrideInput) (bool, error) {
+	var updated *override.UserOverride
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		u, err := m.OverrideStore.FindOneUserOverrideTx(ctx, tx, input.ID, true)
 		if err != nil {
@@ -42,11 +43,19 @@ func (m *Mutation) UpdateUserOverride(ctx context.Context, input graphql2.Update
 			u.RemoveUserID = *input.RemoveUserID
 		}
 
-		return m.OverrideStore.UpdateUserOverrideTx(ctx, tx, u)
+		err = m.OverrideStore.UpdateUserOverrideTx(ctx, tx, u)
+		if err != nil {
+			return err
+		}
+		updated = u
+		return nil
 	})
 	if err != nil {
 		return false, err
 	}
+	if updated.Target.TargetType() == assignment.TargetTypeSchedule {
+		(*App)(m).warnRestViolations(ctx, updated.Target.TargetID(), updated.Start, updated.End)
+	}
 	return true, nil
 }
 
@@ -73,6 +82,7 @@ func (m *Mutation) CreateUserOverride(ctx context.Context, input graphql2.Create
 	if err != nil {
 		return nil, err
 	}
+	(*App)(m).warnRestViolations(ctx, *input.ScheduleID, u.Start, u.End)
 	return u, nil
 }
 
@@ -19,6 +19,7 @@ func MapConfigHints(cfg config.Hints) []ConfigHint {
 		{ID: "Twilio.MessageWebhookURL", Value: cfg.Twilio.MessageWebhookURL},
 		{ID: "Twilio.VoiceWebhookURL", Value: cfg.Twilio.VoiceWebhookURL},
 		{ID: "Slack.InteractivityResponseURL", Value: cfg.Slack.InteractivityResponseURL},
+		{ID: "Slack.SlashCommandURL", Value: cfg.Slack.SlashCommandURL},
 	}
 }
 
@@ -34,10 +35,30 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableSMSLinks", Type: ConfigTypeBoolean, Description: "If set, SMS messages will not contain a URL pointing to GoAlert.", Value: fmt.Sprintf("%t", cfg.General.DisableSMSLinks)},
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
+		{ID: "General.MessageSendingPartitions", Type: ConfigTypeInteger, Description: "Split outgoing message sending into this many partitions (by destination) so multiple engine instances can send concurrently. Other engine processing is not partitioned. 0 or 1 means a single instance sends all messages.", Value: fmt.Sprintf("%d", cfg.General.MessageSendingPartitions)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "Maintenance.AlertArchiveDays", Type: ConfigTypeInteger, Description: "Closed alerts will be moved to the alert archive after this many days (0 means disable archival). Requires --alert-archive-dir or --alert-archive-s3-url.", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertArchiveDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.MessageLogCleanupDays", Type: ConfigTypeInteger, Description: "Sent and failed outgoing messages, SMS reply codes for closed alerts, and Twilio error logs will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.MessageLogCleanupDays)},
+		{ID: "Database.MaxOpenConns", Type: ConfigTypeInteger, Description: "If set, overrides the --db-max-open flag on all instances without a restart.", Value: fmt.Sprintf("%d", cfg.Database.MaxOpenConns)},
+		{ID: "Database.MaxIdleConns", Type: ConfigTypeInteger, Description: "If set, overrides the --db-max-idle flag on all instances without a restart.", Value: fmt.Sprintf("%d", cfg.Database.MaxIdleConns)},
+		{ID: "RateLimit.VoiceAlertsPerHour", Type: ConfigTypeInteger, Description: "Maximum alert notifications per hour to a single voice contact method. 0 uses the default of 7.", Value: fmt.Sprintf("%d", cfg.RateLimit.VoiceAlertsPerHour)},
+		{ID: "RateLimit.SMSAlertsPerHour", Type: ConfigTypeInteger, Description: "Maximum alert notifications per hour to a single SMS contact method. 0 uses the default of 11.", Value: fmt.Sprintf("%d", cfg.RateLimit.SMSAlertsPerHour)},
+		{ID: "RateLimit.EmailAlertsPerHour", Type: ConfigTypeInteger, Description: "Maximum alert notifications per hour to a single email contact method. 0 means email is only limited to one message per minute.", Value: fmt.Sprintf("%d", cfg.RateLimit.EmailAlertsPerHour)},
+		{ID: "RateLimit.LowSeverityAlertsPerHour", Type: ConfigTypeInteger, Description: "Maximum notifications per hour to a single contact method for medium and low severity alerts. 0 means no additional limit.", Value: fmt.Sprintf("%d", cfg.RateLimit.LowSeverityAlertsPerHour)},
+		{ID: "RateLimit.IntegrationRequestsPerMinute", Type: ConfigTypeInteger, Description: "Maximum requests per minute accepted from a single integration key, allowing bursts up to the same amount. Additional requests get a 429 response. 0 means no limit.", Value: fmt.Sprintf("%d", cfg.RateLimit.IntegrationRequestsPerMinute)},
+		{ID: "RateLimit.IntegrationMaxConcurrent", Type: ConfigTypeInteger, Description: "Maximum integration key requests processed at once by each GoAlert instance. Additional requests get a 429 response. 0 means no limit.", Value: fmt.Sprintf("%d", cfg.RateLimit.IntegrationMaxConcurrent)},
+		{ID: "Failover.Enable", Type: ConfigTypeBoolean, Description: "Automatically notify the next contact method of a user when alert notifications fail, according to the thresholds below.", Value: fmt.Sprintf("%t", cfg.Failover.Enable)},
+		{ID: "Failover.ProviderFailures", Type: ConfigTypeInteger, Description: "Fail over when this many consecutive messages to a contact method type (e.g., SMS) have failed. 0 means disabled.", Value: fmt.Sprintf("%d", cfg.Failover.ProviderFailures)},
+		{ID: "Failover.DestinationFailures", Type: ConfigTypeInteger, Description: "Fail over when this many consecutive messages to a single contact method have failed. 0 means disabled.", Value: fmt.Sprintf("%d", cfg.Failover.DestinationFailures)},
+		{ID: "Pause.Voice", Type: ConfigTypeBoolean, Description: "Hold all outgoing voice calls on every instance (e.g., during a provider incident). Held messages are sent once resumed.", Value: fmt.Sprintf("%t", cfg.Pause.Voice)},
+		{ID: "Pause.SMS", Type: ConfigTypeBoolean, Description: "Hold all outgoing SMS messages on every instance. Held messages are sent once resumed.", Value: fmt.Sprintf("%t", cfg.Pause.SMS)},
+		{ID: "Pause.Email", Type: ConfigTypeBoolean, Description: "Hold all outgoing email messages on every instance. Held messages are sent once resumed.", Value: fmt.Sprintf("%t", cfg.Pause.Email)},
+		{ID: "Pause.Slack", Type: ConfigTypeBoolean, Description: "Hold all outgoing Slack messages (channels, DMs, and user groups) on every instance. Held messages are sent once resumed.", Value: fmt.Sprintf("%t", cfg.Pause.Slack)},
+		{ID: "Pause.Webhook", Type: ConfigTypeBoolean, Description: "Hold all outgoing webhook requests (user and channel) on every instance. Held messages are sent once resumed.", Value: fmt.Sprintf("%t", cfg.Pause.Webhook)},
+		{ID: "Pause.FailoverAlerts", Type: ConfigTypeBoolean, Description: "Instead of holding alert notifications for paused contact method types, send them to the user's next contact method that is not paused.", Value: fmt.Sprintf("%t", cfg.Pause.FailoverAlerts)},
 		{ID: "Auth.RefererURLs", Type: ConfigTypeStringList, Description: "Allowed referer URLs for auth and redirects.", Value: strings.Join(cfg.Auth.RefererURLs, "\n"), Deprecated: "Use --public-url flag instead, which takes precedence."},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
@@ -47,6 +68,7 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "GitHub.AllowedUsers", Type: ConfigTypeStringList, Description: "Allow any of the listed GitHub usernames to authenticate. Use '*' to allow any user.", Value: strings.Join(cfg.GitHub.AllowedUsers, "\n")},
 		{ID: "GitHub.AllowedOrgs", Type: ConfigTypeStringList, Description: "Allow any member of any listed GitHub org (or team, using the format 'org/team') to authenticate.", Value: strings.Join(cfg.GitHub.AllowedOrgs, "\n")},
 		{ID: "GitHub.EnterpriseURL", Type: ConfigTypeString, Description: "GitHub URL (without /api) when used with GitHub Enterprise.", Value: cfg.GitHub.EnterpriseURL},
+		{ID: "GitHub.WebhookSecret", Type: ConfigTypeString, Description: "Secret used to verify webhook deliveries from the GitHub App to GitHub Actions integration keys. If empty, signatures are not checked.", Value: cfg.GitHub.WebhookSecret, Password: true},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
 		{ID: "OIDC.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via OIDC authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.NewUsers)},
 		{ID: "OIDC.OverrideName", Type: ConfigTypeString, Description: "Set the name/label on the login page to something other than OIDC.", Value: cfg.OIDC.OverrideName},
@@ -66,6 +88,7 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Slack.AccessToken", Type: ConfigTypeString, Description: "Slack app bot user OAuth access token (should start with xoxb-).", Value: cfg.Slack.AccessToken, Password: true},
 		{ID: "Slack.SigningSecret", Type: ConfigTypeString, Description: "Signing secret to verify requests from slack.", Value: cfg.Slack.SigningSecret, Password: true},
 		{ID: "Slack.InteractiveMessages", Type: ConfigTypeBoolean, Description: "Enable interactive messages (e.g. buttons).", Value: fmt.Sprintf("%t", cfg.Slack.InteractiveMessages)},
+		{ID: "Slack.SlashCommands", Type: ConfigTypeBoolean, Description: "Enable the /goalert slash command. The command's Request URL must be set to the SlashCommandURL hint.", Value: fmt.Sprintf("%t", cfg.Slack.SlashCommands)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
 		{ID: "Twilio.VoiceName", Type: ConfigTypeString, Description: "The Twilio voice to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceName},
 		{ID: "Twilio.VoiceLanguage", Type: ConfigTypeString, Description: "The Twilio voice language to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceLanguage},
@@ -77,6 +100,13 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Twilio.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.Twilio.DisableTwoWaySMS)},
 		{ID: "Twilio.SMSCarrierLookup", Type: ConfigTypeBoolean, Description: "Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply.", Value: fmt.Sprintf("%t", cfg.Twilio.SMSCarrierLookup)},
 		{ID: "Twilio.SMSFromNumberOverride", Type: ConfigTypeStringList, Description: "List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number.", Value: strings.Join(cfg.Twilio.SMSFromNumberOverride, "\n")},
+		{ID: "Twilio.CallForwardServiceID", Type: ConfigTypeString, Description: "If set, inbound callers can be connected to the current on-call users of this service (by ID), falling back to the next escalation step if unanswered.", Value: cfg.Twilio.CallForwardServiceID},
+		{ID: "Twilio.CallForwardSkipMenu", Type: ConfigTypeBoolean, Description: "Forward inbound calls to the on-call users immediately, instead of presenting a menu.", Value: fmt.Sprintf("%t", cfg.Twilio.CallForwardSkipMenu)},
+		{ID: "Twilio.IncidentReportServiceID", Type: ConfigTypeString, Description: "If set, callers to the Twilio number can record an incident report that creates an alert on this service (by ID).", Value: cfg.Twilio.IncidentReportServiceID},
+		{ID: "Twilio.SMSRates", Type: ConfigTypeStringList, Description: "List of 'region=cost' pairs used to estimate the cost of each outgoing SMS, by ISO region code of the destination (e.g., US=0.0079). Use * for all other regions.", Value: strings.Join(cfg.Twilio.SMSRates, "\n")},
+		{ID: "Twilio.VoiceRates", Type: ConfigTypeStringList, Description: "List of 'region=cost' pairs used to estimate the cost of each outgoing voice call (per call), by ISO region code of the destination (e.g., US=0.014). Use * for all other regions.", Value: strings.Join(cfg.Twilio.VoiceRates, "\n")},
+		{ID: "Twilio.DailyBudget", Type: ConfigTypeInteger, Description: "If set, an alert is created on the Budget Alert Service when the estimated spend for the current day (UTC) exceeds this amount.", Value: fmt.Sprintf("%d", cfg.Twilio.DailyBudget)},
+		{ID: "Twilio.BudgetAlertServiceID", Type: ConfigTypeString, Description: "The service (by ID) to create an alert on when the Daily Budget is exceeded.", Value: cfg.Twilio.BudgetAlertServiceID},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "SMTP.Address", Type: ConfigTypeString, Description: "The server address to use for sending email. Port is optional and defaults to 465, or 25 if Disable TLS is set. Common ports are: 25 or 587 for STARTTLS (or unencrypted) and 465 for TLS.", Value: cfg.SMTP.Address},
@@ -86,6 +116,18 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.Password", Type: ConfigTypeString, Description: "Password for authentication.", Value: cfg.SMTP.Password, Password: true},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Jira.Enable", Type: ConfigTypeBoolean, Description: "Enables creating Jira issues for alerts on services with a Jira config.", Value: fmt.Sprintf("%t", cfg.Jira.Enable)},
+		{ID: "Jira.BaseURL", Type: ConfigTypeString, Description: "Base URL of the Jira instance (e.g. https://example.atlassian.net).", Value: cfg.Jira.BaseURL},
+		{ID: "Jira.Username", Type: ConfigTypeString, Description: "Jira user (email address for Jira Cloud) used for API requests.", Value: cfg.Jira.Username},
+		{ID: "Jira.APIToken", Type: ConfigTypeString, Description: "API token (or password) for the Jira user.", Value: cfg.Jira.APIToken, Password: true},
+		{ID: "ServiceNow.Enable", Type: ConfigTypeBoolean, Description: "Enables creating ServiceNow incidents for alerts on services with a ServiceNow config.", Value: fmt.Sprintf("%t", cfg.ServiceNow.Enable)},
+		{ID: "ServiceNow.InstanceURL", Type: ConfigTypeString, Description: "Base URL of the ServiceNow instance (e.g. https://example.service-now.com).", Value: cfg.ServiceNow.InstanceURL},
+		{ID: "ServiceNow.Username", Type: ConfigTypeString, Description: "ServiceNow user used for Table API requests.", Value: cfg.ServiceNow.Username},
+		{ID: "ServiceNow.Password", Type: ConfigTypeString, Description: "Password for the ServiceNow user.", Value: cfg.ServiceNow.Password, Password: true},
+		{ID: "ServiceNow.CallbackSecret", Type: ConfigTypeString, Description: "Shared secret ServiceNow must send as a Bearer token to the incident callback endpoint.", Value: cfg.ServiceNow.CallbackSecret, Password: true},
+		{ID: "Statuspage.Enable", Type: ConfigTypeBoolean, Description: "Enables updating Statuspage components from open alerts on mapped services.", Value: fmt.Sprintf("%t", cfg.Statuspage.Enable)},
+		{ID: "Statuspage.PageID", Type: ConfigTypeString, Description: "ID of the Statuspage page containing the mapped components.", Value: cfg.Statuspage.PageID},
+		{ID: "Statuspage.APIKey", Type: ConfigTypeString, Description: "Statuspage API key.", Value: cfg.Statuspage.APIKey, Password: true},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -104,9 +146,11 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
+		{ID: "Maintenance.AlertArchiveDays", Type: ConfigTypeInteger, Description: "Closed alerts will be moved to the alert archive after this many days (0 means disable archival). Requires --alert-archive-dir or --alert-archive-s3-url.", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertArchiveDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.MessageLogCleanupDays", Type: ConfigTypeInteger, Description: "Sent and failed outgoing messages, SMS reply codes for closed alerts, and Twilio error logs will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.MessageLogCleanupDays)},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
@@ -119,6 +163,9 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Jira.Enable", Type: ConfigTypeBoolean, Description: "Enables creating Jira issues for alerts on services with a Jira config.", Value: fmt.Sprintf("%t", cfg.Jira.Enable)},
+		{ID: "ServiceNow.Enable", Type: ConfigTypeBoolean, Description: "Enables creating ServiceNow incidents for alerts on services with a ServiceNow config.", Value: fmt.Sprintf("%t", cfg.ServiceNow.Enable)},
+		{ID: "Statuspage.Enable", Type: ConfigTypeBoolean, Description: "Enables updating Statuspage components from open alerts on mapped services.", Value: fmt.Sprintf("%t", cfg.Statuspage.Enable)},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -188,12 +235,24 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.General.DisableCalendarSubscriptions = val
+		case "General.MessageSendingPartitions":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.General.MessageSendingPartitions = val
 		case "Maintenance.AlertCleanupDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
 				return cfg, err
 			}
 			cfg.Maintenance.AlertCleanupDays = val
+		case "Maintenance.AlertArchiveDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.AlertArchiveDays = val
 		case "Maintenance.AlertAutoCloseDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
@@ -212,6 +271,114 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Maintenance.ScheduleCleanupDays = val
+		case "Maintenance.MessageLogCleanupDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.MessageLogCleanupDays = val
+		case "Database.MaxOpenConns":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Database.MaxOpenConns = val
+		case "Database.MaxIdleConns":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Database.MaxIdleConns = val
+		case "RateLimit.VoiceAlertsPerHour":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.VoiceAlertsPerHour = val
+		case "RateLimit.SMSAlertsPerHour":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.SMSAlertsPerHour = val
+		case "RateLimit.EmailAlertsPerHour":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.EmailAlertsPerHour = val
+		case "RateLimit.LowSeverityAlertsPerHour":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.LowSeverityAlertsPerHour = val
+		case "RateLimit.IntegrationRequestsPerMinute":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.IntegrationRequestsPerMinute = val
+		case "RateLimit.IntegrationMaxConcurrent":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.RateLimit.IntegrationMaxConcurrent = val
+		case "Failover.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Failover.Enable = val
+		case "Failover.ProviderFailures":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Failover.ProviderFailures = val
+		case "Failover.DestinationFailures":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Failover.DestinationFailures = val
+		case "Pause.Voice":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.Voice = val
+		case "Pause.SMS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.SMS = val
+		case "Pause.Email":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.Email = val
+		case "Pause.Slack":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.Slack = val
+		case "Pause.Webhook":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.Webhook = val
+		case "Pause.FailoverAlerts":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Pause.FailoverAlerts = val
 		case "Auth.RefererURLs":
 			cfg.Auth.RefererURLs = parseStringList(v.Value)
 		case "Auth.DisableBasic":
@@ -242,6 +409,8 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.GitHub.AllowedOrgs = parseStringList(v.Value)
 		case "GitHub.EnterpriseURL":
 			cfg.GitHub.EnterpriseURL = v.Value
+		case "GitHub.WebhookSecret":
+			cfg.GitHub.WebhookSecret = v.Value
 		case "OIDC.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -300,6 +469,12 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Slack.InteractiveMessages = val
+		case "Slack.SlashCommands":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Slack.SlashCommands = val
 		case "Twilio.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -334,6 +509,28 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Twilio.SMSCarrierLookup = val
 		case "Twilio.SMSFromNumberOverride":
 			cfg.Twilio.SMSFromNumberOverride = parseStringList(v.Value)
+		case "Twilio.CallForwardServiceID":
+			cfg.Twilio.CallForwardServiceID = v.Value
+		case "Twilio.CallForwardSkipMenu":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Twilio.CallForwardSkipMenu = val
+		case "Twilio.IncidentReportServiceID":
+			cfg.Twilio.IncidentReportServiceID = v.Value
+		case "Twilio.SMSRates":
+			cfg.Twilio.SMSRates = parseStringList(v.Value)
+		case "Twilio.VoiceRates":
+			cfg.Twilio.VoiceRates = parseStringList(v.Value)
+		case "Twilio.DailyBudget":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Twilio.DailyBudget = val
+		case "Twilio.BudgetAlertServiceID":
+			cfg.Twilio.BudgetAlertServiceID = v.Value
 		case "SMTP.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -368,6 +565,42 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Webhook.Enable = val
 		case "Webhook.AllowedURLs":
 			cfg.Webhook.AllowedURLs = parseStringList(v.Value)
+		case "Jira.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Jira.Enable = val
+		case "Jira.BaseURL":
+			cfg.Jira.BaseURL = v.Value
+		case "Jira.Username":
+			cfg.Jira.Username = v.Value
+		case "Jira.APIToken":
+			cfg.Jira.APIToken = v.Value
+		case "ServiceNow.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.ServiceNow.Enable = val
+		case "ServiceNow.InstanceURL":
+			cfg.ServiceNow.InstanceURL = v.Value
+		case "ServiceNow.Username":
+			cfg.ServiceNow.Username = v.Value
+		case "ServiceNow.Password":
+			cfg.ServiceNow.Password = v.Value
+		case "ServiceNow.CallbackSecret":
+			cfg.ServiceNow.CallbackSecret = v.Value
+		case "Statuspage.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Statuspage.Enable = val
+		case "Statuspage.PageID":
+			cfg.Statuspage.PageID = v.Value
+		case "Statuspage.APIKey":
+			cfg.Statuspage.APIKey = v.Value
 		case "Feedback.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
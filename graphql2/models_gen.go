@@ -15,8 +15,10 @@ import (
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
@@ -27,6 +29,14 @@ import (
 	"github.com/target/goalert/util/timeutil"
 )
 
+type AddScheduleShadowInput struct {
+	ScheduleID   string    `json:"scheduleID"`
+	UserID       string    `json:"userID"`
+	ShadowUserID *string   `json:"shadowUserID,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
 type AlertConnection struct {
 	Nodes    []alert.Alert `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -37,6 +47,13 @@ type AlertDataPoint struct {
 	AlertCount int       `json:"alertCount"`
 }
 
+type AlertJiraIssue struct {
+	Key       string `json:"key"`
+	URL       string `json:"url"`
+	Closed    bool   `json:"closed"`
+	LastError string `json:"lastError"`
+}
+
 type AlertLogEntryConnection struct {
 	Nodes    []alertlog.Entry `json:"nodes"`
 	PageInfo *PageInfo        `json:"pageInfo"`
@@ -72,6 +89,33 @@ type AlertSearchOptions struct {
 	NotClosedBefore   *time.Time       `json:"notClosedBefore,omitempty"`
 }
 
+type AlertServiceNowIncident struct {
+	SysID        string `json:"sysID"`
+	Number       string `json:"number"`
+	URL          string `json:"url"`
+	Acknowledged bool   `json:"acknowledged"`
+	Closed       bool   `json:"closed"`
+	LastError    string `json:"lastError"`
+}
+
+type ArchivedAlert struct {
+	AlertID    int                `json:"alertID"`
+	ServiceID  string             `json:"serviceID"`
+	Summary    string             `json:"summary"`
+	Details    string             `json:"details"`
+	Severity   AlertSeverity      `json:"severity"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	ArchivedAt time.Time          `json:"archivedAt"`
+	Logs       []ArchivedAlertLog `json:"logs"`
+}
+
+type ArchivedAlertLog struct {
+	ID        int       `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Event     string    `json:"event"`
+	Message   string    `json:"message"`
+}
+
 type AuthSubjectConnection struct {
 	Nodes    []user.AuthSubject `json:"nodes"`
 	PageInfo *PageInfo          `json:"pageInfo"`
@@ -92,11 +136,46 @@ type ClearTemporarySchedulesInput struct {
 	End        time.Time `json:"end"`
 }
 
+type CloneEscalationPolicyInput struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	IncludeTargets bool   `json:"includeTargets"`
+}
+
+type CloneScheduleInput struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	IncludeTargets bool   `json:"includeTargets"`
+}
+
+type CloneServiceInput struct {
+	ID                    string `json:"id"`
+	Name                  string `json:"name"`
+	CloneEscalationPolicy *bool  `json:"cloneEscalationPolicy,omitempty"`
+	IncludeTargets        bool   `json:"includeTargets"`
+}
+
+type ConfigDriftChange struct {
+	Action   string `json:"action"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
 type ConfigHint struct {
 	ID    string `json:"id"`
 	Value string `json:"value"`
 }
 
+type ConfigTestResult struct {
+	Section string  `json:"section"`
+	FieldID *string `json:"fieldID,omitempty"`
+	Ok      bool    `json:"ok"`
+	Message string  `json:"message"`
+}
+
 type ConfigValue struct {
 	ID          string     `json:"id"`
 	Description string     `json:"description"`
@@ -111,11 +190,29 @@ type ConfigValueInput struct {
 	Value string `json:"value"`
 }
 
+type ContactMethodReliability struct {
+	ContactMethodID     string             `json:"contactMethodID"`
+	Name                string             `json:"name"`
+	Type                contactmethod.Type `json:"type"`
+	Delivered           int                `json:"delivered"`
+	Sent                int                `json:"sent"`
+	Failed              int                `json:"failed"`
+	NoResponse          int                `json:"noResponse"`
+	ConsecutiveFailures int                `json:"consecutiveFailures"`
+}
+
 type CreateAlertInput struct {
-	Summary   string  `json:"summary"`
-	Details   *string `json:"details,omitempty"`
-	ServiceID string  `json:"serviceID"`
-	Sanitize  *bool   `json:"sanitize,omitempty"`
+	Summary   string         `json:"summary"`
+	Details   *string        `json:"details,omitempty"`
+	ServiceID string         `json:"serviceID"`
+	Sanitize  *bool          `json:"sanitize,omitempty"`
+	Severity  *AlertSeverity `json:"severity,omitempty"`
+}
+
+type CreateAlertResult struct {
+	Alert *alert.Alert `json:"alert,omitempty"`
+	IsNew bool         `json:"isNew"`
+	Error *string      `json:"error,omitempty"`
 }
 
 type CreateBasicAuthInput struct {
@@ -125,16 +222,20 @@ type CreateBasicAuthInput struct {
 }
 
 type CreateEscalationPolicyInput struct {
-	Name        string                            `json:"name"`
-	Description *string                           `json:"description,omitempty"`
-	Repeat      *int                              `json:"repeat,omitempty"`
-	Favorite    *bool                             `json:"favorite,omitempty"`
-	Steps       []CreateEscalationPolicyStepInput `json:"steps,omitempty"`
+	Name              string                            `json:"name"`
+	Description       *string                           `json:"description,omitempty"`
+	Repeat            *int                              `json:"repeat,omitempty"`
+	BackoffMultiplier *float64                          `json:"backoffMultiplier,omitempty"`
+	MaxNotifications  *int                              `json:"maxNotifications,omitempty"`
+	GiveUpChannelID   *string                           `json:"giveUpChannelID,omitempty"`
+	Favorite          *bool                             `json:"favorite,omitempty"`
+	Steps             []CreateEscalationPolicyStepInput `json:"steps,omitempty"`
 }
 
 type CreateEscalationPolicyStepInput struct {
 	EscalationPolicyID *string                `json:"escalationPolicyID,omitempty"`
 	DelayMinutes       int                    `json:"delayMinutes"`
+	ConferenceBridge   *bool                  `json:"conferenceBridge,omitempty"`
 	Targets            []assignment.RawTarget `json:"targets,omitempty"`
 	NewRotation        *CreateRotationInput   `json:"newRotation,omitempty"`
 	NewSchedule        *CreateScheduleInput   `json:"newSchedule,omitempty"`
@@ -180,6 +281,12 @@ type CreateScheduleInput struct {
 	NewUserOverrides []CreateUserOverrideInput `json:"newUserOverrides,omitempty"`
 }
 
+type CreateServiceFromTemplateInput struct {
+	TemplateID string               `json:"templateID"`
+	Params     []TemplateParamInput `json:"params,omitempty"`
+	Favorite   *bool                `json:"favorite,omitempty"`
+}
+
 type CreateServiceInput struct {
 	Name                 string                        `json:"name"`
 	Description          *string                       `json:"description,omitempty"`
@@ -191,12 +298,52 @@ type CreateServiceInput struct {
 	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors,omitempty"`
 }
 
+type CreateServiceSLOInput struct {
+	ServiceID        string         `json:"serviceID"`
+	Name             string         `json:"name"`
+	Severity         *AlertSeverity `json:"severity,omitempty"`
+	TargetPercent    float64        `json:"targetPercent"`
+	AckWithinMinutes int            `json:"ackWithinMinutes"`
+	WindowDays       *int           `json:"windowDays,omitempty"`
+}
+
+type CreateServiceTemplateInput struct {
+	ServiceID      string               `json:"serviceID"`
+	Name           string               `json:"name"`
+	Description    *string              `json:"description,omitempty"`
+	IncludeTargets bool                 `json:"includeTargets"`
+	Params         []TemplateParamInput `json:"params,omitempty"`
+}
+
+type CreateSystemNoticeInput struct {
+	Type      notice.Type `json:"type"`
+	Message   string      `json:"message"`
+	Details   *string     `json:"details,omitempty"`
+	AdminOnly *bool       `json:"adminOnly,omitempty"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+}
+
+type CreateTeamContactMethodInput struct {
+	TeamID string                   `json:"teamID"`
+	Name   string                   `json:"name"`
+	Type   notificationchannel.Type `json:"type"`
+	Value  string                   `json:"value"`
+}
+
+type CreateTeamInput struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description,omitempty"`
+	DefaultScheduleID *string `json:"defaultScheduleID,omitempty"`
+}
+
 type CreateUserCalendarSubscriptionInput struct {
-	Name            string `json:"name"`
-	ReminderMinutes []int  `json:"reminderMinutes,omitempty"`
-	ScheduleID      string `json:"scheduleID"`
-	Disabled        *bool  `json:"disabled,omitempty"`
-	FullSchedule    *bool  `json:"fullSchedule,omitempty"`
+	Name               string  `json:"name"`
+	ReminderMinutes    []int   `json:"reminderMinutes,omitempty"`
+	EndReminderMinutes []int   `json:"endReminderMinutes,omitempty"`
+	ScheduleID         string  `json:"scheduleID"`
+	Disabled           *bool   `json:"disabled,omitempty"`
+	FullSchedule       *bool   `json:"fullSchedule,omitempty"`
+	RotationID         *string `json:"rotationID,omitempty"`
 }
 
 type CreateUserContactMethodInput struct {
@@ -207,6 +354,13 @@ type CreateUserContactMethodInput struct {
 	NewUserNotificationRule *CreateUserNotificationRuleInput `json:"newUserNotificationRule,omitempty"`
 }
 
+type CreateUserDelegationInput struct {
+	UserID         *string   `json:"userID,omitempty"`
+	DelegateUserID string    `json:"delegateUserID"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+}
+
 type CreateUserInput struct {
 	Username string    `json:"username"`
 	Password string    `json:"password"`
@@ -217,9 +371,11 @@ type CreateUserInput struct {
 }
 
 type CreateUserNotificationRuleInput struct {
-	UserID          *string `json:"userID,omitempty"`
-	ContactMethodID *string `json:"contactMethodID,omitempty"`
-	DelayMinutes    int     `json:"delayMinutes"`
+	UserID          *string                             `json:"userID,omitempty"`
+	ContactMethodID *string                             `json:"contactMethodID,omitempty"`
+	DelayMinutes    int                                 `json:"delayMinutes"`
+	Severities      []AlertSeverity                     `json:"severities,omitempty"`
+	Fallbacks       []UserNotificationRuleFallbackInput `json:"fallbacks,omitempty"`
 }
 
 type CreateUserOverrideInput struct {
@@ -230,11 +386,27 @@ type CreateUserOverrideInput struct {
 	RemoveUserID *string   `json:"removeUserID,omitempty"`
 }
 
+type CreateUserUnavailabilityInput struct {
+	UserID *string   `json:"userID,omitempty"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Note   *string   `json:"note,omitempty"`
+}
+
 type CreatedGQLAPIKey struct {
 	ID    string `json:"id"`
 	Token string `json:"token"`
 }
 
+type DBPoolStats struct {
+	MaxOpen     int     `json:"maxOpen"`
+	Open        int     `json:"open"`
+	InUse       int     `json:"inUse"`
+	Idle        int     `json:"idle"`
+	WaitCount   int     `json:"waitCount"`
+	WaitSeconds float64 `json:"waitSeconds"`
+}
+
 type DebugCarrierInfoInput struct {
 	Number string `json:"number"`
 }
@@ -283,6 +455,39 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type DeleteScheduleShadowInput struct {
+	ScheduleID string `json:"scheduleID"`
+	ID         string `json:"id"`
+}
+
+type EngineCycle struct {
+	ID         string           `json:"id"`
+	State      EngineCycleState `json:"state"`
+	StartedAt  *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt *time.Time       `json:"finishedAt,omitempty"`
+}
+
+type EngineJob struct {
+	ID              int            `json:"id"`
+	Kind            string         `json:"kind"`
+	State           EngineJobState `json:"state"`
+	Args            string         `json:"args"`
+	IntervalSeconds int            `json:"intervalSeconds"`
+	Attempts        int            `json:"attempts"`
+	MaxAttempts     int            `json:"maxAttempts"`
+	RunAt           time.Time      `json:"runAt"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	StartedAt       *time.Time     `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time     `json:"finishedAt,omitempty"`
+	LastError       *string        `json:"lastError,omitempty"`
+}
+
+type EngineJobSearchOptions struct {
+	State *EngineJobState `json:"state,omitempty"`
+	Kind  *string         `json:"kind,omitempty"`
+	First *int            `json:"first,omitempty"`
+}
+
 type EscalationPolicyConnection struct {
 	Nodes    []escalation.Policy `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -317,11 +522,73 @@ type GQLAPIKeyUsage struct {
 	IP   string    `json:"ip"`
 }
 
+type GenerateBalancedRotationInput struct {
+	ScheduleID       string     `json:"scheduleID"`
+	UserIDs          []string   `json:"userIDs"`
+	Start            time.Time  `json:"start"`
+	End              *time.Time `json:"end,omitempty"`
+	ShiftLengthHours *int       `json:"shiftLengthHours,omitempty"`
+	TimeZone         *string    `json:"timeZone,omitempty"`
+	HistoryDays      *int       `json:"historyDays,omitempty"`
+	Apply            *bool      `json:"apply,omitempty"`
+}
+
+type ImportPagerDutyInput struct {
+	Data   string `json:"data"`
+	DryRun *bool  `json:"dryRun,omitempty"`
+}
+
+type ImportUserUnavailabilityInput struct {
+	UserID *string `json:"userID,omitempty"`
+	Ics    string  `json:"ics"`
+}
+
+type ImportUsersInput struct {
+	CSV              string `json:"csv"`
+	DryRun           *bool  `json:"dryRun,omitempty"`
+	SendVerification *bool  `json:"sendVerification,omitempty"`
+}
+
 type IntegrationKeyConnection struct {
 	Nodes    []integrationkey.IntegrationKey `json:"nodes"`
 	PageInfo *PageInfo                       `json:"pageInfo"`
 }
 
+type IntegrationKeyGitHubFilterInput struct {
+	Repositories []string `json:"repositories"`
+	Branches     []string `json:"branches"`
+}
+
+type IntegrationKeyMQTTRule struct {
+	TopicFilter string           `json:"topicFilter"`
+	ValueExpr   string           `json:"valueExpr"`
+	Operator    MQTTRuleOperator `json:"operator"`
+	Threshold   float64          `json:"threshold"`
+	AutoClose   bool             `json:"autoClose"`
+}
+
+type IntegrationKeyMQTTRuleInput struct {
+	TopicFilter string           `json:"topicFilter"`
+	ValueExpr   string           `json:"valueExpr"`
+	Operator    MQTTRuleOperator `json:"operator"`
+	Threshold   float64          `json:"threshold"`
+	AutoClose   bool             `json:"autoClose"`
+}
+
+type IntegrationKeySNMPRule struct {
+	TrapOid    string         `json:"trapOID"`
+	Action     SNMPRuleAction `json:"action"`
+	SummaryOid string         `json:"summaryOID"`
+	DedupOid   string         `json:"dedupOID"`
+}
+
+type IntegrationKeySNMPRuleInput struct {
+	TrapOid    string         `json:"trapOID"`
+	Action     SNMPRuleAction `json:"action"`
+	SummaryOid string         `json:"summaryOID"`
+	DedupOid   string         `json:"dedupOID"`
+}
+
 type IntegrationKeySearchOptions struct {
 	First  *int     `json:"first,omitempty"`
 	After  *string  `json:"after,omitempty"`
@@ -329,6 +596,13 @@ type IntegrationKeySearchOptions struct {
 	Omit   []string `json:"omit,omitempty"`
 }
 
+type IntegrationKeySyslogFilterInput struct {
+	MaxSeverity int      `json:"maxSeverity"`
+	Facilities  []int    `json:"facilities"`
+	AppNames    []string `json:"appNames"`
+	Hostnames   []string `json:"hostnames"`
+}
+
 type IntegrationKeyTypeInfo struct {
 	ID      string `json:"id"`
 	Name    string `json:"name"`
@@ -336,6 +610,16 @@ type IntegrationKeyTypeInfo struct {
 	Enabled bool   `json:"enabled"`
 }
 
+type JiraFieldMapping struct {
+	FieldID  string `json:"fieldID"`
+	Template string `json:"template"`
+}
+
+type JiraFieldMappingInput struct {
+	FieldID  string `json:"fieldID"`
+	Template string `json:"template"`
+}
+
 type LabelConnection struct {
 	Nodes    []label.Label `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -376,6 +660,12 @@ type MessageLogConnection struct {
 	Stats    *notification.SearchOptions `json:"stats"`
 }
 
+type MessageLogRetention struct {
+	RetentionDays   int        `json:"retentionDays"`
+	OldestMessageAt *time.Time `json:"oldestMessageAt,omitempty"`
+	PendingCleanup  int        `json:"pendingCleanup"`
+}
+
 type MessageLogSearchOptions struct {
 	First         *int       `json:"first,omitempty"`
 	After         *string    `json:"after,omitempty"`
@@ -385,17 +675,62 @@ type MessageLogSearchOptions struct {
 	Omit          []string   `json:"omit,omitempty"`
 }
 
+type MessageQueueState struct {
+	DestType               string     `json:"destType"`
+	Pending                int        `json:"pending"`
+	OldestPendingAt        *time.Time `json:"oldestPendingAt,omitempty"`
+	RecentlySent           int        `json:"recentlySent"`
+	RateLimit              int        `json:"rateLimit"`
+	RateLimitWindowSeconds int        `json:"rateLimitWindowSeconds"`
+	Throttled              bool       `json:"throttled"`
+}
+
 type NotificationState struct {
 	Details           string              `json:"details"`
 	Status            *NotificationStatus `json:"status,omitempty"`
 	FormattedSrcValue string              `json:"formattedSrcValue"`
 }
 
+type OffboardUserInput struct {
+	UserID            string  `json:"userID"`
+	ReplacementUserID *string `json:"replacementUserID,omitempty"`
+}
+
+type OnCallUserLoad struct {
+	UserID       string     `json:"userID"`
+	User         *user.User `json:"user,omitempty"`
+	OnCallHours  float64    `json:"onCallHours"`
+	NightHours   float64    `json:"nightHours"`
+	WeekendHours float64    `json:"weekendHours"`
+	ShiftCount   int        `json:"shiftCount"`
+	PageCount    int        `json:"pageCount"`
+}
+
 type PageInfo struct {
 	EndCursor   *string `json:"endCursor,omitempty"`
 	HasNextPage bool    `json:"hasNextPage"`
 }
 
+type PagerDutyImportIssue struct {
+	Type        string `json:"type"`
+	PagerDutyID string `json:"pagerDutyID"`
+	Name        string `json:"name"`
+	Message     string `json:"message"`
+}
+
+type PagerDutyImportMapping struct {
+	Type        string `json:"type"`
+	PagerDutyID string `json:"pagerDutyID"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Existing    bool   `json:"existing"`
+}
+
+type PagerDutyImportReport struct {
+	Mappings []PagerDutyImportMapping `json:"mappings"`
+	Issues   []PagerDutyImportIssue   `json:"issues"`
+}
+
 type PhoneNumberInfo struct {
 	ID          string `json:"id"`
 	CountryCode string `json:"countryCode"`
@@ -405,6 +740,31 @@ type PhoneNumberInfo struct {
 	Error       string `json:"error"`
 }
 
+type RequestTrace struct {
+	RequestID string                `json:"requestID"`
+	Alerts    []alert.Alert         `json:"alerts"`
+	Messages  []RequestTraceMessage `json:"messages"`
+}
+
+type RequestTraceMessage struct {
+	ID                string     `json:"id"`
+	AlertID           *int       `json:"alertID,omitempty"`
+	Type              string     `json:"type"`
+	DestType          string     `json:"destType"`
+	Status            string     `json:"status"`
+	StatusDetails     string     `json:"statusDetails"`
+	ProviderMessageID string     `json:"providerMessageID"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	SentAt            *time.Time `json:"sentAt,omitempty"`
+}
+
+type RequeueFailedMessagesInput struct {
+	CreatedAfter       time.Time            `json:"createdAfter"`
+	CreatedBefore      time.Time            `json:"createdBefore"`
+	ContactMethodTypes []contactmethod.Type `json:"contactMethodTypes,omitempty"`
+	ErrorCode          *int                 `json:"errorCode,omitempty"`
+}
+
 type RotationConnection struct {
 	Nodes    []rotation.Rotation `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -451,6 +811,34 @@ type ScheduleConnection struct {
 	PageInfo *PageInfo           `json:"pageInfo"`
 }
 
+type ScheduleCoverageConflict struct {
+	UserID           string      `json:"userID"`
+	User             *user.User  `json:"user,omitempty"`
+	UnavailabilityID string      `json:"unavailabilityID"`
+	Start            time.Time   `json:"start"`
+	End              time.Time   `json:"end"`
+	SuggestedUsers   []user.User `json:"suggestedUsers"`
+}
+
+type ScheduleCoverageGap struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	OnCallCount int       `json:"onCallCount"`
+}
+
+type ScheduleRestConstraints struct {
+	MaxConsecutiveHours int `json:"maxConsecutiveHours"`
+	MinRestHours        int `json:"minRestHours"`
+}
+
+type ScheduleRestViolation struct {
+	UserID string                    `json:"userID"`
+	User   *user.User                `json:"user,omitempty"`
+	Type   ScheduleRestViolationType `json:"type"`
+	Start  time.Time                 `json:"start"`
+	End    time.Time                 `json:"end"`
+}
+
 type ScheduleRuleInput struct {
 	ID            *string                 `json:"id,omitempty"`
 	Start         *timeutil.Clock         `json:"start,omitempty"`
@@ -467,6 +855,29 @@ type ScheduleSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst,omitempty"`
 }
 
+type ScheduleShadow struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"userID"`
+	User         *user.User `json:"user,omitempty"`
+	ShadowUserID *string    `json:"shadowUserID,omitempty"`
+	ShadowUser   *user.User `json:"shadowUser,omitempty"`
+	Start        time.Time  `json:"start"`
+	End          time.Time  `json:"end"`
+}
+
+type ScheduleShiftPreview struct {
+	UserID          string     `json:"userID"`
+	User            *user.User `json:"user,omitempty"`
+	Start           time.Time  `json:"start"`
+	End             time.Time  `json:"end"`
+	Truncated       bool       `json:"truncated"`
+	StartLocal      string     `json:"startLocal"`
+	EndLocal        string     `json:"endLocal"`
+	StartZone       string     `json:"startZone"`
+	EndZone         string     `json:"endZone"`
+	SpansTransition bool       `json:"spansTransition"`
+}
+
 type ScheduleTarget struct {
 	ScheduleID string                `json:"scheduleID"`
 	Target     *assignment.RawTarget `json:"target"`
@@ -480,8 +891,15 @@ type ScheduleTargetInput struct {
 	Rules       []ScheduleRuleInput   `json:"rules"`
 }
 
+type ScheduleTimeZonePreview struct {
+	TimeZone    string                 `json:"timeZone"`
+	Shifts      []ScheduleShiftPreview `json:"shifts"`
+	Transitions []TimeZoneTransition   `json:"transitions"`
+}
+
 type SendContactMethodVerificationInput struct {
 	ContactMethodID string `json:"contactMethodID"`
+	Voice           *bool  `json:"voice,omitempty"`
 }
 
 type ServiceConnection struct {
@@ -489,6 +907,46 @@ type ServiceConnection struct {
 	PageInfo *PageInfo         `json:"pageInfo"`
 }
 
+type ServiceJiraConfig struct {
+	ProjectKey          string             `json:"projectKey"`
+	IssueType           string             `json:"issueType"`
+	SummaryTemplate     string             `json:"summaryTemplate"`
+	DescriptionTemplate string             `json:"descriptionTemplate"`
+	Fields              []JiraFieldMapping `json:"fields"`
+	CloseTransition     string             `json:"closeTransition"`
+}
+
+type ServiceJiraConfigInput struct {
+	ProjectKey          string                  `json:"projectKey"`
+	IssueType           string                  `json:"issueType"`
+	SummaryTemplate     string                  `json:"summaryTemplate"`
+	DescriptionTemplate string                  `json:"descriptionTemplate"`
+	Fields              []JiraFieldMappingInput `json:"fields"`
+	CloseTransition     string                  `json:"closeTransition"`
+}
+
+type ServiceSlo struct {
+	ID               string            `json:"id"`
+	ServiceID        string            `json:"serviceID"`
+	Name             string            `json:"name"`
+	Severity         *AlertSeverity    `json:"severity,omitempty"`
+	TargetPercent    float64           `json:"targetPercent"`
+	AckWithinMinutes int               `json:"ackWithinMinutes"`
+	WindowDays       int               `json:"windowDays"`
+	Status           *ServiceSLOStatus `json:"status,omitempty"`
+}
+
+type ServiceSLOStatus struct {
+	Total                       int       `json:"total"`
+	Met                         int       `json:"met"`
+	CompliancePercent           float64   `json:"compliancePercent"`
+	ErrorBudgetRemainingPercent float64   `json:"errorBudgetRemainingPercent"`
+	BurnRate1h                  float64   `json:"burnRate1h"`
+	BurnRate6h                  float64   `json:"burnRate6h"`
+	BurnRate24h                 float64   `json:"burnRate24h"`
+	ComputedAt                  time.Time `json:"computedAt"`
+}
+
 type ServiceSearchOptions struct {
 	First          *int     `json:"first,omitempty"`
 	After          *string  `json:"after,omitempty"`
@@ -498,6 +956,52 @@ type ServiceSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst,omitempty"`
 }
 
+type ServiceServiceNowConfig struct {
+	AssignmentGroup string `json:"assignmentGroup"`
+	Category        string `json:"category"`
+	CallerID        string `json:"callerID"`
+	Impact          int    `json:"impact"`
+	Urgency         int    `json:"urgency"`
+	ResolveCode     string `json:"resolveCode"`
+}
+
+type ServiceServiceNowConfigInput struct {
+	AssignmentGroup string `json:"assignmentGroup"`
+	Category        string `json:"category"`
+	CallerID        string `json:"callerID"`
+	Impact          int    `json:"impact"`
+	Urgency         int    `json:"urgency"`
+	ResolveCode     string `json:"resolveCode"`
+}
+
+type ServiceStatusCallback struct {
+	URL               string `json:"url"`
+	Secret            string `json:"secret"`
+	UnansweredMinutes int    `json:"unansweredMinutes"`
+}
+
+type ServiceStatusCallbackInput struct {
+	URL               string `json:"url"`
+	UnansweredMinutes int    `json:"unansweredMinutes"`
+}
+
+type ServiceStatuspageComponent struct {
+	ComponentID     string `json:"componentID"`
+	DegradedAt      int    `json:"degradedAt"`
+	PartialOutageAt int    `json:"partialOutageAt"`
+	MajorOutageAt   int    `json:"majorOutageAt"`
+	LastStatus      string `json:"lastStatus"`
+	Overridden      bool   `json:"overridden"`
+	LastError       string `json:"lastError"`
+}
+
+type ServiceStatuspageComponentInput struct {
+	ComponentID     string `json:"componentID"`
+	DegradedAt      int    `json:"degradedAt"`
+	PartialOutageAt int    `json:"partialOutageAt"`
+	MajorOutageAt   int    `json:"majorOutageAt"`
+}
+
 type SetAlertNoiseReasonInput struct {
 	AlertID     int    `json:"alertID"`
 	NoiseReason string `json:"noiseReason"`
@@ -508,17 +1012,85 @@ type SetFavoriteInput struct {
 	Favorite bool                  `json:"favorite"`
 }
 
+type SetIntegrationKeyEmailRulesInput struct {
+	ID               string   `json:"id"`
+	DedupPattern     string   `json:"dedupPattern"`
+	ResolvePattern   string   `json:"resolvePattern"`
+	AllowedSenders   []string `json:"allowedSenders"`
+	MaxDetailsLength int      `json:"maxDetailsLength"`
+}
+
+type SetIntegrationKeyGitHubFilterInput struct {
+	ID     string                           `json:"id"`
+	Filter *IntegrationKeyGitHubFilterInput `json:"filter,omitempty"`
+}
+
+type SetIntegrationKeyMQTTRulesInput struct {
+	ID    string                        `json:"id"`
+	Rules []IntegrationKeyMQTTRuleInput `json:"rules"`
+}
+
+type SetIntegrationKeySNMPRulesInput struct {
+	ID    string                        `json:"id"`
+	Rules []IntegrationKeySNMPRuleInput `json:"rules"`
+}
+
+type SetIntegrationKeySyslogFilterInput struct {
+	ID     string                           `json:"id"`
+	Filter *IntegrationKeySyslogFilterInput `json:"filter,omitempty"`
+}
+
+type SetIntegrationKeyTransformInput struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+	Details string `json:"details"`
+	Dedup   string `json:"dedup"`
+	Action  string `json:"action"`
+}
+
 type SetLabelInput struct {
 	Target *assignment.RawTarget `json:"target,omitempty"`
 	Key    string                `json:"key"`
 	Value  string                `json:"value"`
 }
 
+type SetScheduleMinCoverageInput struct {
+	ScheduleID  string `json:"scheduleID"`
+	MinCoverage int    `json:"minCoverage"`
+}
+
 type SetScheduleOnCallNotificationRulesInput struct {
 	ScheduleID string                        `json:"scheduleID"`
 	Rules      []OnCallNotificationRuleInput `json:"rules"`
 }
 
+type SetScheduleRestConstraintsInput struct {
+	ScheduleID          string `json:"scheduleID"`
+	MaxConsecutiveHours int    `json:"maxConsecutiveHours"`
+	MinRestHours        int    `json:"minRestHours"`
+}
+
+type SetServiceJiraConfigInput struct {
+	ServiceID string                  `json:"serviceID"`
+	Config    *ServiceJiraConfigInput `json:"config,omitempty"`
+}
+
+type SetServiceServiceNowConfigInput struct {
+	ServiceID string                        `json:"serviceID"`
+	Config    *ServiceServiceNowConfigInput `json:"config,omitempty"`
+}
+
+type SetServiceStatusCallbackInput struct {
+	ServiceID        string                      `json:"serviceID"`
+	Callback         *ServiceStatusCallbackInput `json:"callback,omitempty"`
+	RegenerateSecret *bool                       `json:"regenerateSecret,omitempty"`
+}
+
+type SetServiceStatuspageComponentInput struct {
+	ServiceID string                           `json:"serviceID"`
+	Component *ServiceStatuspageComponentInput `json:"component,omitempty"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	ClearStart *time.Time            `json:"clearStart,omitempty"`
@@ -528,6 +1100,16 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type SetUserNotificationRuleFallbacksInput struct {
+	RuleID    string                              `json:"ruleID"`
+	Fallbacks []UserNotificationRuleFallbackInput `json:"fallbacks"`
+}
+
+type SetUserNotificationRuleSeveritiesInput struct {
+	RuleID     string          `json:"ruleID"`
+	Severities []AlertSeverity `json:"severities"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -557,6 +1139,15 @@ type StringConnection struct {
 	PageInfo *PageInfo `json:"pageInfo"`
 }
 
+type SystemHealth struct {
+	PendingMessages                int                 `json:"pendingMessages"`
+	OldestPendingMessageAt         *time.Time          `json:"oldestPendingMessageAt,omitempty"`
+	OldestPendingMessageAgeSeconds float64             `json:"oldestPendingMessageAgeSeconds"`
+	EngineLagSeconds               float64             `json:"engineLagSeconds"`
+	MessageQueues                  []MessageQueueState `json:"messageQueues"`
+	DbPool                         *DBPoolStats        `json:"dbPool"`
+}
+
 type SystemLimit struct {
 	ID          limit.ID `json:"id"`
 	Description string   `json:"description"`
@@ -568,6 +1159,50 @@ type SystemLimitInput struct {
 	Value int      `json:"value"`
 }
 
+type SystemNotice struct {
+	ID        string      `json:"id"`
+	Type      notice.Type `json:"type"`
+	Message   string      `json:"message"`
+	Details   string      `json:"details"`
+	AdminOnly bool        `json:"adminOnly"`
+	CreatedAt time.Time   `json:"createdAt"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+}
+
+type TeamOnCallLoad struct {
+	Users        []OnCallUserLoad `json:"users"`
+	OnCallHours  float64          `json:"onCallHours"`
+	NightHours   float64          `json:"nightHours"`
+	WeekendHours float64          `json:"weekendHours"`
+	ShiftCount   int              `json:"shiftCount"`
+	PageCount    int              `json:"pageCount"`
+}
+
+type TemplateParamInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TestNotificationInput struct {
+	Type        TestNotificationDestType `json:"type"`
+	Value       string                   `json:"value"`
+	WaitSeconds *int                     `json:"waitSeconds,omitempty"`
+}
+
+type TestNotificationResult struct {
+	Ok                bool                   `json:"ok"`
+	ProviderMessageID *string                `json:"providerMessageID,omitempty"`
+	Trace             []TestNotificationStep `json:"trace"`
+}
+
+type TestNotificationStep struct {
+	At      time.Time          `json:"at"`
+	Step    string             `json:"step"`
+	Ok      bool               `json:"ok"`
+	Message string             `json:"message"`
+	State   *NotificationState `json:"state,omitempty"`
+}
+
 type TimeSeriesBucket struct {
 	Start time.Time `json:"start"`
 	End   time.Time `json:"end"`
@@ -595,6 +1230,36 @@ type TimeZoneSearchOptions struct {
 	Omit   []string `json:"omit,omitempty"`
 }
 
+type TimeZoneTransition struct {
+	At                time.Time `json:"at"`
+	AtLocal           string    `json:"atLocal"`
+	FromZone          string    `json:"fromZone"`
+	FromOffsetMinutes int       `json:"fromOffsetMinutes"`
+	ToZone            string    `json:"toZone"`
+	ToOffsetMinutes   int       `json:"toOffsetMinutes"`
+}
+
+type TwilioRegionSpend struct {
+	Type   string  `json:"type"`
+	Region string  `json:"region"`
+	Count  int     `json:"count"`
+	Cost   float64 `json:"cost"`
+}
+
+type TwilioSpend struct {
+	Start       time.Time           `json:"start"`
+	End         time.Time           `json:"end"`
+	TotalCost   float64             `json:"totalCost"`
+	TodayCost   float64             `json:"todayCost"`
+	DailyBudget int                 `json:"dailyBudget"`
+	Regions     []TwilioRegionSpend `json:"regions"`
+}
+
+type TwilioSpendOptions struct {
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
 type UpdateAlertsByServiceInput struct {
 	ServiceID string      `json:"serviceID"`
 	NewStatus AlertStatus `json:"newStatus"`
@@ -613,17 +1278,21 @@ type UpdateBasicAuthInput struct {
 }
 
 type UpdateEscalationPolicyInput struct {
-	ID          string   `json:"id"`
-	Name        *string  `json:"name,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Repeat      *int     `json:"repeat,omitempty"`
-	StepIDs     []string `json:"stepIDs,omitempty"`
+	ID                string   `json:"id"`
+	Name              *string  `json:"name,omitempty"`
+	Description       *string  `json:"description,omitempty"`
+	Repeat            *int     `json:"repeat,omitempty"`
+	BackoffMultiplier *float64 `json:"backoffMultiplier,omitempty"`
+	MaxNotifications  *int     `json:"maxNotifications,omitempty"`
+	GiveUpChannelID   *string  `json:"giveUpChannelID,omitempty"`
+	StepIDs           []string `json:"stepIDs,omitempty"`
 }
 
 type UpdateEscalationPolicyStepInput struct {
-	ID           string                 `json:"id"`
-	DelayMinutes *int                   `json:"delayMinutes,omitempty"`
-	Targets      []assignment.RawTarget `json:"targets,omitempty"`
+	ID               string                 `json:"id"`
+	DelayMinutes     *int                   `json:"delayMinutes,omitempty"`
+	Targets          []assignment.RawTarget `json:"targets,omitempty"`
+	ConferenceBridge *bool                  `json:"conferenceBridge,omitempty"`
 }
 
 type UpdateGQLAPIKeyInput struct {
@@ -665,12 +1334,40 @@ type UpdateServiceInput struct {
 	MaintenanceExpiresAt *time.Time `json:"maintenanceExpiresAt,omitempty"`
 }
 
+type UpdateServiceSLOInput struct {
+	ID               string         `json:"id"`
+	Name             *string        `json:"name,omitempty"`
+	Severity         *AlertSeverity `json:"severity,omitempty"`
+	ClearSeverity    *bool          `json:"clearSeverity,omitempty"`
+	TargetPercent    *float64       `json:"targetPercent,omitempty"`
+	AckWithinMinutes *int           `json:"ackWithinMinutes,omitempty"`
+	WindowDays       *int           `json:"windowDays,omitempty"`
+}
+
+type UpdateSystemNoticeInput struct {
+	ID        string      `json:"id"`
+	Type      notice.Type `json:"type"`
+	Message   string      `json:"message"`
+	Details   string      `json:"details"`
+	AdminOnly bool        `json:"adminOnly"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+}
+
+type UpdateTeamInput struct {
+	ID                string  `json:"id"`
+	Name              *string `json:"name,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	DefaultScheduleID *string `json:"defaultScheduleID,omitempty"`
+}
+
 type UpdateUserCalendarSubscriptionInput struct {
-	ID              string  `json:"id"`
-	Name            *string `json:"name,omitempty"`
-	ReminderMinutes []int   `json:"reminderMinutes,omitempty"`
-	Disabled        *bool   `json:"disabled,omitempty"`
-	FullSchedule    *bool   `json:"fullSchedule,omitempty"`
+	ID                 string  `json:"id"`
+	Name               *string `json:"name,omitempty"`
+	ReminderMinutes    []int   `json:"reminderMinutes,omitempty"`
+	EndReminderMinutes []int   `json:"endReminderMinutes,omitempty"`
+	Disabled           *bool   `json:"disabled,omitempty"`
+	FullSchedule       *bool   `json:"fullSchedule,omitempty"`
+	RotationID         *string `json:"rotationID,omitempty"`
 }
 
 type UpdateUserContactMethodInput struct {
@@ -681,11 +1378,15 @@ type UpdateUserContactMethodInput struct {
 }
 
 type UpdateUserInput struct {
-	ID                          string    `json:"id"`
-	Name                        *string   `json:"name,omitempty"`
-	Email                       *string   `json:"email,omitempty"`
-	Role                        *UserRole `json:"role,omitempty"`
-	StatusUpdateContactMethodID *string   `json:"statusUpdateContactMethodID,omitempty"`
+	ID                          string               `json:"id"`
+	Name                        *string              `json:"name,omitempty"`
+	Email                       *string              `json:"email,omitempty"`
+	Role                        *UserRole            `json:"role,omitempty"`
+	StatusUpdateContactMethodID *string              `json:"statusUpdateContactMethodID,omitempty"`
+	AlertDigestMinutes          *int                 `json:"alertDigestMinutes,omitempty"`
+	QuietHours                  *UserQuietHoursInput `json:"quietHours,omitempty"`
+	ClearQuietHours             *bool                `json:"clearQuietHours,omitempty"`
+	Locale                      *string              `json:"locale,omitempty"`
 }
 
 type UpdateUserOverrideInput struct {
@@ -701,6 +1402,22 @@ type UserConnection struct {
 	PageInfo *PageInfo   `json:"pageInfo"`
 }
 
+type UserImportResult struct {
+	Line             int              `json:"line"`
+	Name             string           `json:"name"`
+	Email            string           `json:"email"`
+	Status           UserImportStatus `json:"status"`
+	UserID           *string          `json:"userID,omitempty"`
+	ContactMethodID  *string          `json:"contactMethodID,omitempty"`
+	VerificationSent bool             `json:"verificationSent"`
+	Message          string           `json:"message"`
+}
+
+type UserNotificationRuleFallbackInput struct {
+	ContactMethodID string `json:"contactMethodID"`
+	DelayMinutes    int    `json:"delayMinutes"`
+}
+
 type UserOverrideConnection struct {
 	Nodes    []override.UserOverride `json:"nodes"`
 	PageInfo *PageInfo               `json:"pageInfo"`
@@ -718,6 +1435,19 @@ type UserOverrideSearchOptions struct {
 	End                *time.Time `json:"end,omitempty"`
 }
 
+type UserQuietHours struct {
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone string         `json:"timeZone"`
+	Active   bool           `json:"active"`
+}
+
+type UserQuietHoursInput struct {
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone string         `json:"timeZone"`
+}
+
 type UserSearchOptions struct {
 	First          *int                `json:"first,omitempty"`
 	After          *string             `json:"after,omitempty"`
@@ -742,6 +1472,11 @@ type VerifyContactMethodInput struct {
 	Code            int    `json:"code"`
 }
 
+type VerifyTeamContactMethodInput struct {
+	ID   string `json:"id"`
+	Code int    `json:"code"`
+}
+
 type AlertSearchSort string
 
 const (
@@ -785,6 +1520,51 @@ func (e AlertSearchSort) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type AlertSeverity string
+
+const (
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityHigh     AlertSeverity = "high"
+	AlertSeverityMedium   AlertSeverity = "medium"
+	AlertSeverityLow      AlertSeverity = "low"
+)
+
+var AllAlertSeverity = []AlertSeverity{
+	AlertSeverityCritical,
+	AlertSeverityHigh,
+	AlertSeverityMedium,
+	AlertSeverityLow,
+}
+
+func (e AlertSeverity) IsValid() bool {
+	switch e {
+	case AlertSeverityCritical, AlertSeverityHigh, AlertSeverityMedium, AlertSeverityLow:
+		return true
+	}
+	return false
+}
+
+func (e AlertSeverity) String() string {
+	return string(e)
+}
+
+func (e *AlertSeverity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlertSeverity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlertSeverity", str)
+	}
+	return nil
+}
+
+func (e AlertSeverity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AlertStatus string
 
 const (
@@ -873,6 +1653,94 @@ func (e ConfigType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type EngineCycleState string
+
+const (
+	EngineCycleStatePending  EngineCycleState = "pending"
+	EngineCycleStateRunning  EngineCycleState = "running"
+	EngineCycleStateFinished EngineCycleState = "finished"
+)
+
+var AllEngineCycleState = []EngineCycleState{
+	EngineCycleStatePending,
+	EngineCycleStateRunning,
+	EngineCycleStateFinished,
+}
+
+func (e EngineCycleState) IsValid() bool {
+	switch e {
+	case EngineCycleStatePending, EngineCycleStateRunning, EngineCycleStateFinished:
+		return true
+	}
+	return false
+}
+
+func (e EngineCycleState) String() string {
+	return string(e)
+}
+
+func (e *EngineCycleState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EngineCycleState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EngineCycleState", str)
+	}
+	return nil
+}
+
+func (e EngineCycleState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type EngineJobState string
+
+const (
+	EngineJobStatePending   EngineJobState = "pending"
+	EngineJobStateRunning   EngineJobState = "running"
+	EngineJobStateCompleted EngineJobState = "completed"
+	EngineJobStateDead      EngineJobState = "dead"
+)
+
+var AllEngineJobState = []EngineJobState{
+	EngineJobStatePending,
+	EngineJobStateRunning,
+	EngineJobStateCompleted,
+	EngineJobStateDead,
+}
+
+func (e EngineJobState) IsValid() bool {
+	switch e {
+	case EngineJobStatePending, EngineJobStateRunning, EngineJobStateCompleted, EngineJobStateDead:
+		return true
+	}
+	return false
+}
+
+func (e EngineJobState) String() string {
+	return string(e)
+}
+
+func (e *EngineJobState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EngineJobState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EngineJobState", str)
+	}
+	return nil
+}
+
+func (e EngineJobState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type IntegrationKeyType string
 
 const (
@@ -880,6 +1748,22 @@ const (
 	IntegrationKeyTypeGrafana                IntegrationKeyType = "grafana"
 	IntegrationKeyTypeSite24x7               IntegrationKeyType = "site24x7"
 	IntegrationKeyTypePrometheusAlertmanager IntegrationKeyType = "prometheusAlertmanager"
+	IntegrationKeyTypeDatadog                IntegrationKeyType = "datadog"
+	IntegrationKeyTypeAmazonSns              IntegrationKeyType = "amazonSNS"
+	IntegrationKeyTypeSentry                 IntegrationKeyType = "sentry"
+	IntegrationKeyTypeZabbix                 IntegrationKeyType = "zabbix"
+	IntegrationKeyTypeAzureMonitor           IntegrationKeyType = "azureMonitor"
+	IntegrationKeyTypeGcpMonitoring          IntegrationKeyType = "gcpMonitoring"
+	IntegrationKeyTypeNewRelic               IntegrationKeyType = "newRelic"
+	IntegrationKeyTypeSplunk                 IntegrationKeyType = "splunk"
+	IntegrationKeyTypePagerDutyEvents        IntegrationKeyType = "pagerDutyEvents"
+	IntegrationKeyTypeNagios                 IntegrationKeyType = "nagios"
+	IntegrationKeyTypeSnmp                   IntegrationKeyType = "snmp"
+	IntegrationKeyTypeSyslog                 IntegrationKeyType = "syslog"
+	IntegrationKeyTypeKafka                  IntegrationKeyType = "kafka"
+	IntegrationKeyTypeMqtt                   IntegrationKeyType = "mqtt"
+	IntegrationKeyTypeGithubActions          IntegrationKeyType = "githubActions"
+	IntegrationKeyTypeGrafanaOnCall          IntegrationKeyType = "grafanaOnCall"
 	IntegrationKeyTypeEmail                  IntegrationKeyType = "email"
 )
 
@@ -888,12 +1772,28 @@ var AllIntegrationKeyType = []IntegrationKeyType{
 	IntegrationKeyTypeGrafana,
 	IntegrationKeyTypeSite24x7,
 	IntegrationKeyTypePrometheusAlertmanager,
+	IntegrationKeyTypeDatadog,
+	IntegrationKeyTypeAmazonSns,
+	IntegrationKeyTypeSentry,
+	IntegrationKeyTypeZabbix,
+	IntegrationKeyTypeAzureMonitor,
+	IntegrationKeyTypeGcpMonitoring,
+	IntegrationKeyTypeNewRelic,
+	IntegrationKeyTypeSplunk,
+	IntegrationKeyTypePagerDutyEvents,
+	IntegrationKeyTypeNagios,
+	IntegrationKeyTypeSnmp,
+	IntegrationKeyTypeSyslog,
+	IntegrationKeyTypeKafka,
+	IntegrationKeyTypeMqtt,
+	IntegrationKeyTypeGithubActions,
+	IntegrationKeyTypeGrafanaOnCall,
 	IntegrationKeyTypeEmail,
 }
 
 func (e IntegrationKeyType) IsValid() bool {
 	switch e {
-	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail:
+	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeDatadog, IntegrationKeyTypeAmazonSns, IntegrationKeyTypeSentry, IntegrationKeyTypeZabbix, IntegrationKeyTypeAzureMonitor, IntegrationKeyTypeGcpMonitoring, IntegrationKeyTypeNewRelic, IntegrationKeyTypeSplunk, IntegrationKeyTypePagerDutyEvents, IntegrationKeyTypeNagios, IntegrationKeyTypeSnmp, IntegrationKeyTypeSyslog, IntegrationKeyTypeKafka, IntegrationKeyTypeMqtt, IntegrationKeyTypeGithubActions, IntegrationKeyTypeGrafanaOnCall, IntegrationKeyTypeEmail:
 		return true
 	}
 	return false
@@ -920,6 +1820,57 @@ func (e IntegrationKeyType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type MQTTRuleOperator string
+
+const (
+	MQTTRuleOperatorAny MQTTRuleOperator = "any"
+	MQTTRuleOperatorGt  MQTTRuleOperator = "gt"
+	MQTTRuleOperatorGte MQTTRuleOperator = "gte"
+	MQTTRuleOperatorLt  MQTTRuleOperator = "lt"
+	MQTTRuleOperatorLte MQTTRuleOperator = "lte"
+	MQTTRuleOperatorEq  MQTTRuleOperator = "eq"
+	MQTTRuleOperatorNe  MQTTRuleOperator = "ne"
+)
+
+var AllMQTTRuleOperator = []MQTTRuleOperator{
+	MQTTRuleOperatorAny,
+	MQTTRuleOperatorGt,
+	MQTTRuleOperatorGte,
+	MQTTRuleOperatorLt,
+	MQTTRuleOperatorLte,
+	MQTTRuleOperatorEq,
+	MQTTRuleOperatorNe,
+}
+
+func (e MQTTRuleOperator) IsValid() bool {
+	switch e {
+	case MQTTRuleOperatorAny, MQTTRuleOperatorGt, MQTTRuleOperatorGte, MQTTRuleOperatorLt, MQTTRuleOperatorLte, MQTTRuleOperatorEq, MQTTRuleOperatorNe:
+		return true
+	}
+	return false
+}
+
+func (e MQTTRuleOperator) String() string {
+	return string(e)
+}
+
+func (e *MQTTRuleOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MQTTRuleOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MQTTRuleOperator", str)
+	}
+	return nil
+}
+
+func (e MQTTRuleOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type NotificationStatus string
 
 const (
@@ -963,6 +1914,49 @@ func (e NotificationStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type SNMPRuleAction string
+
+const (
+	SNMPRuleActionTrigger SNMPRuleAction = "trigger"
+	SNMPRuleActionClose   SNMPRuleAction = "close"
+	SNMPRuleActionIgnore  SNMPRuleAction = "ignore"
+)
+
+var AllSNMPRuleAction = []SNMPRuleAction{
+	SNMPRuleActionTrigger,
+	SNMPRuleActionClose,
+	SNMPRuleActionIgnore,
+}
+
+func (e SNMPRuleAction) IsValid() bool {
+	switch e {
+	case SNMPRuleActionTrigger, SNMPRuleActionClose, SNMPRuleActionIgnore:
+		return true
+	}
+	return false
+}
+
+func (e SNMPRuleAction) String() string {
+	return string(e)
+}
+
+func (e *SNMPRuleAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SNMPRuleAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SNMPRuleAction", str)
+	}
+	return nil
+}
+
+func (e SNMPRuleAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type SWOAction string
 
 const (
@@ -1055,6 +2049,47 @@ func (e SWOState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ScheduleRestViolationType string
+
+const (
+	ScheduleRestViolationTypeMaxConsecutive ScheduleRestViolationType = "maxConsecutive"
+	ScheduleRestViolationTypeMinRest        ScheduleRestViolationType = "minRest"
+)
+
+var AllScheduleRestViolationType = []ScheduleRestViolationType{
+	ScheduleRestViolationTypeMaxConsecutive,
+	ScheduleRestViolationTypeMinRest,
+}
+
+func (e ScheduleRestViolationType) IsValid() bool {
+	switch e {
+	case ScheduleRestViolationTypeMaxConsecutive, ScheduleRestViolationTypeMinRest:
+		return true
+	}
+	return false
+}
+
+func (e ScheduleRestViolationType) String() string {
+	return string(e)
+}
+
+func (e *ScheduleRestViolationType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduleRestViolationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduleRestViolationType", str)
+	}
+	return nil
+}
+
+func (e ScheduleRestViolationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type StatusUpdateState string
 
 const (
@@ -1100,6 +2135,94 @@ func (e StatusUpdateState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type TestNotificationDestType string
+
+const (
+	TestNotificationDestTypeSms          TestNotificationDestType = "SMS"
+	TestNotificationDestTypeVoice        TestNotificationDestType = "VOICE"
+	TestNotificationDestTypeEmail        TestNotificationDestType = "EMAIL"
+	TestNotificationDestTypeSLACkChannel TestNotificationDestType = "SLACK_CHANNEL"
+)
+
+var AllTestNotificationDestType = []TestNotificationDestType{
+	TestNotificationDestTypeSms,
+	TestNotificationDestTypeVoice,
+	TestNotificationDestTypeEmail,
+	TestNotificationDestTypeSLACkChannel,
+}
+
+func (e TestNotificationDestType) IsValid() bool {
+	switch e {
+	case TestNotificationDestTypeSms, TestNotificationDestTypeVoice, TestNotificationDestTypeEmail, TestNotificationDestTypeSLACkChannel:
+		return true
+	}
+	return false
+}
+
+func (e TestNotificationDestType) String() string {
+	return string(e)
+}
+
+func (e *TestNotificationDestType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TestNotificationDestType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TestNotificationDestType", str)
+	}
+	return nil
+}
+
+func (e TestNotificationDestType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UserImportStatus string
+
+const (
+	UserImportStatusCreated   UserImportStatus = "CREATED"
+	UserImportStatusDuplicate UserImportStatus = "DUPLICATE"
+	UserImportStatusInvalid   UserImportStatus = "INVALID"
+)
+
+var AllUserImportStatus = []UserImportStatus{
+	UserImportStatusCreated,
+	UserImportStatusDuplicate,
+	UserImportStatusInvalid,
+}
+
+func (e UserImportStatus) IsValid() bool {
+	switch e {
+	case UserImportStatusCreated, UserImportStatusDuplicate, UserImportStatusInvalid:
+		return true
+	}
+	return false
+}
+
+func (e UserImportStatus) String() string {
+	return string(e)
+}
+
+func (e *UserImportStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserImportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserImportStatus", str)
+	}
+	return nil
+}
+
+func (e UserImportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type UserRole string
 
 const (
@@ -0,0 +1,163 @@
+package integrationkey
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// MaxAllowedSenders is the maximum number of sender allowlist entries for a single key.
+	MaxAllowedSenders = 50
+
+	// MaxEmailDetailsLength is the maximum value for EmailRules.MaxDetailsLength.
+	MaxEmailDetailsLength = 6 * 1024
+)
+
+// EmailRules controls how incoming email for an email integration key is converted to alerts.
+type EmailRules struct {
+	// DedupPattern is a regular expression applied to the subject. The first
+	// capture group (or the full match, if there are none) is used as the dedup key.
+	DedupPattern string
+
+	// ResolvePattern is a regular expression that, if it matches the subject, will
+	// close the alert instead of creating one.
+	ResolvePattern string
+
+	// AllowedSenders limits which senders may create alerts. Entries are either full
+	// addresses or domains prefixed with `@`. If empty, all senders are allowed.
+	AllowedSenders []string
+
+	// MaxDetailsLength truncates the message body used for alert details. If zero,
+	// the default limit is used.
+	MaxDetailsLength int
+}
+
+func validRegexp(fname, expr string) error {
+	if expr == "" {
+		return nil
+	}
+	err := validate.Text(fname, expr, 1, 255)
+	if err != nil {
+		return err
+	}
+	_, err = regexp.Compile(expr)
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+	return nil
+}
+
+func validSender(fname, s string) error {
+	if domain, ok := strings.CutPrefix(s, "@"); ok {
+		return validate.Email(fname, "user@"+domain)
+	}
+	_, err := mail.ParseAddress(s)
+	if err != nil {
+		return validation.NewFieldError(fname, "must be an email address or @domain")
+	}
+	return nil
+}
+
+// Normalize will validate and normalize EmailRules.
+func (r EmailRules) Normalize() (*EmailRules, error) {
+	r.DedupPattern = strings.TrimSpace(r.DedupPattern)
+	r.ResolvePattern = strings.TrimSpace(r.ResolvePattern)
+
+	senders := make([]string, 0, len(r.AllowedSenders))
+	for _, s := range r.AllowedSenders {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		senders = append(senders, s)
+	}
+	r.AllowedSenders = senders
+
+	err := validate.Many(
+		validRegexp("DedupPattern", r.DedupPattern),
+		validRegexp("ResolvePattern", r.ResolvePattern),
+		validate.Range("AllowedSenders", len(r.AllowedSenders), 0, MaxAllowedSenders),
+		validate.Range("MaxDetailsLength", r.MaxDetailsLength, 0, MaxEmailDetailsLength),
+	)
+	for i, s := range r.AllowedSenders {
+		err = validate.Many(err, validSender(fmt.Sprintf("AllowedSenders[%d]", i), s))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// IsEmpty returns true if no rules are configured.
+func (r EmailRules) IsEmpty() bool {
+	return r.DedupPattern == "" && r.ResolvePattern == "" && len(r.AllowedSenders) == 0 && r.MaxDetailsLength == 0
+}
+
+// IsSenderAllowed returns true if the given address is permitted by the sender allowlist.
+func (r EmailRules) IsSenderAllowed(from string) bool {
+	if len(r.AllowedSenders) == 0 {
+		return true
+	}
+
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return false
+	}
+	from = strings.ToLower(addr.Address)
+	_, domain, _ := strings.Cut(from, "@")
+	for _, s := range r.AllowedSenders {
+		if s == from || s == "@"+domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Dedup returns the dedup key extracted from the subject, or an empty string if there is no match.
+func (r EmailRules) Dedup(subject string) string {
+	if r.DedupPattern == "" {
+		return ""
+	}
+	re, err := regexp.Compile(r.DedupPattern)
+	if err != nil {
+		return ""
+	}
+	m := re.FindStringSubmatch(subject)
+	switch len(m) {
+	case 0:
+		return ""
+	case 1:
+		return m[0]
+	}
+
+	return m[1]
+}
+
+// IsResolved returns true if the subject matches the resolve pattern.
+func (r EmailRules) IsResolved(subject string) bool {
+	if r.ResolvePattern == "" {
+		return false
+	}
+	re, err := regexp.Compile(r.ResolvePattern)
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(subject)
+}
+
+// DetailsLength returns the maximum details length to use, limited to max.
+func (r EmailRules) DetailsLength(max int) int {
+	if r.MaxDetailsLength <= 0 || r.MaxDetailsLength > max {
+		return max
+	}
+
+	return r.MaxDetailsLength
+}
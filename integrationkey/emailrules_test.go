@@ -0,0 +1,41 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailRules(t *testing.T) {
+	r := EmailRules{
+		DedupPattern:   `host=(\S+)`,
+		ResolvePattern: `(?i)^resolved`,
+		AllowedSenders: []string{"alerts@example.com", "@monitoring.example.com"},
+	}
+
+	assert.True(t, r.IsSenderAllowed("Alerts <ALERTS@example.com>"))
+	assert.True(t, r.IsSenderAllowed("nagios@monitoring.example.com"))
+	assert.False(t, r.IsSenderAllowed("other@example.com"))
+
+	assert.Equal(t, "db1", r.Dedup("PROBLEM host=db1 disk full"))
+	assert.Equal(t, "", r.Dedup("PROBLEM disk full"))
+
+	assert.True(t, r.IsResolved("Resolved: host=db1"))
+	assert.False(t, r.IsResolved("PROBLEM host=db1"))
+
+	assert.Equal(t, 100, r.DetailsLength(100))
+	r.MaxDetailsLength = 50
+	assert.Equal(t, 50, r.DetailsLength(100))
+}
+
+func TestEmailRules_Normalize(t *testing.T) {
+	n, err := EmailRules{AllowedSenders: []string{" Foo@Example.com ", "", "@example.org"}}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo@example.com", "@example.org"}, n.AllowedSenders)
+
+	_, err = EmailRules{DedupPattern: "("}.Normalize()
+	assert.Error(t, err)
+
+	_, err = EmailRules{AllowedSenders: []string{"not an address"}}.Normalize()
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package integrationkey
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxGitHubFilterPatterns is the maximum number of repository or branch patterns for a single key.
+const MaxGitHubFilterPatterns = 50
+
+// GitHubFilter limits which workflow runs and check suites create alerts for a GitHub Actions key.
+//
+// Patterns use shell glob syntax (e.g., `my-org/*` or `release/*`), where `*` does not match `/`.
+type GitHubFilter struct {
+	// Repositories are matched against the full repository name (`owner/name`). If empty, all repositories are allowed.
+	Repositories []string
+
+	// Branches are matched against the head branch. If empty, all branches are allowed.
+	Branches []string
+}
+
+func validGlobs(fname string, patterns []string) error {
+	err := validate.Range(fname, len(patterns), 0, MaxGitHubFilterPatterns)
+	if err != nil {
+		return err
+	}
+
+	for i, p := range patterns {
+		name := fmt.Sprintf("%s[%d]", fname, i)
+		err = validate.Text(name, p, 1, 255)
+		if err != nil {
+			return err
+		}
+		_, err = path.Match(p, "")
+		if err != nil {
+			return validation.NewFieldError(name, "invalid pattern")
+		}
+	}
+
+	return nil
+}
+
+func normalizeGlobs(patterns []string, lower bool) []string {
+	result := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if lower {
+			p = strings.ToLower(p)
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// IsEmpty returns true if the filter allows everything.
+func (f GitHubFilter) IsEmpty() bool {
+	return len(normalizeGlobs(f.Repositories, false)) == 0 && len(normalizeGlobs(f.Branches, false)) == 0
+}
+
+// Normalize will validate and normalize the GitHubFilter.
+func (f GitHubFilter) Normalize() (*GitHubFilter, error) {
+	// GitHub repository names are case-insensitive, branch names are not
+	f.Repositories = normalizeGlobs(f.Repositories, true)
+	f.Branches = normalizeGlobs(f.Branches, false)
+
+	err := validate.Many(
+		validGlobs("Repositories", f.Repositories),
+		validGlobs("Branches", f.Branches),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func matchAny(patterns []string, s string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, s); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the repository (`owner/name`) and branch are allowed by the filter.
+func (f GitHubFilter) Matches(repo, branch string) bool {
+	return matchAny(f.Repositories, strings.ToLower(repo)) && matchAny(f.Branches, branch)
+}
@@ -0,0 +1,31 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubFilter_Matches(t *testing.T) {
+	f, err := GitHubFilter{
+		Repositories: []string{" My-Org/* ", "other/app"},
+		Branches:     []string{"main", "release/*", ""},
+	}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my-org/*", "other/app"}, f.Repositories)
+	assert.Equal(t, []string{"main", "release/*"}, f.Branches)
+
+	assert.True(t, f.Matches("my-org/api", "main"))
+	assert.True(t, f.Matches("My-Org/API", "release/1.2"))
+	assert.True(t, f.Matches("other/app", "main"))
+	assert.False(t, f.Matches("other/api", "main"))
+	assert.False(t, f.Matches("my-org/api", "Main"))
+	assert.False(t, f.Matches("my-org/api", "release/1.2/hotfix"))
+
+	assert.True(t, GitHubFilter{}.Matches("any/repo", "any-branch"))
+	assert.True(t, GitHubFilter{Branches: []string{" "}}.IsEmpty())
+
+	_, err = GitHubFilter{Branches: []string{"[main"}}.Normalize()
+	assert.Error(t, err)
+}
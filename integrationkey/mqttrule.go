@@ -0,0 +1,226 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxMQTTRules is the maximum number of MQTT rules for a single key.
+const MaxMQTTRules = 50
+
+// MQTTOperator compares a telemetry value against a rule threshold.
+type MQTTOperator string
+
+// Available MQTT rule operators.
+const (
+	// MQTTOperatorAny triggers an alert for every message, ignoring the value.
+	MQTTOperatorAny MQTTOperator = "any"
+
+	MQTTOperatorGT  MQTTOperator = "gt"
+	MQTTOperatorGTE MQTTOperator = "gte"
+	MQTTOperatorLT  MQTTOperator = "lt"
+	MQTTOperatorLTE MQTTOperator = "lte"
+	MQTTOperatorEQ  MQTTOperator = "eq"
+	MQTTOperatorNE  MQTTOperator = "ne"
+)
+
+// MQTTRule maps messages published to matching topics to alerts.
+type MQTTRule struct {
+	// IntegrationKeyID is set when rules are loaded for all keys, and ignored when setting rules.
+	IntegrationKeyID string
+
+	// TopicFilter selects topics using MQTT wildcards (`+` for a single level, `#` for all remaining levels).
+	TopicFilter string
+
+	// ValueExpr is a JMESPath expression that selects the value from a JSON payload. If empty,
+	// the entire payload is used as the value.
+	ValueExpr string
+
+	Operator  MQTTOperator
+	Threshold float64
+
+	// AutoClose will close the alert once the value no longer crosses the threshold.
+	AutoClose bool
+}
+
+func validTopicFilter(fname, filter string) error {
+	err := validate.RequiredText(fname, filter, 1, 255)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(filter, "$") {
+		return validation.NewFieldError(fname, "system topics ($) are not supported")
+	}
+
+	levels := strings.Split(filter, "/")
+	for i, l := range levels {
+		switch {
+		case l == "#" && i != len(levels)-1:
+			return validation.NewFieldError(fname, "# must be the last level")
+		case l == "#", l == "+":
+		case strings.ContainsAny(l, "#+"):
+			return validation.NewFieldError(fname, "wildcards must occupy an entire level")
+		}
+	}
+
+	return nil
+}
+
+// Normalize will validate and normalize the MQTTRule.
+func (r MQTTRule) Normalize() (*MQTTRule, error) {
+	r.TopicFilter = strings.TrimSpace(r.TopicFilter)
+	r.ValueExpr = strings.TrimSpace(r.ValueExpr)
+	if r.Operator == MQTTOperatorAny {
+		r.Threshold = 0
+		r.AutoClose = false
+	}
+
+	err := validate.Many(
+		validTopicFilter("TopicFilter", r.TopicFilter),
+		validExpr("ValueExpr", r.ValueExpr),
+		validate.OneOf("Operator", r.Operator, MQTTOperatorAny, MQTTOperatorGT, MQTTOperatorGTE, MQTTOperatorLT, MQTTOperatorLTE, MQTTOperatorEQ, MQTTOperatorNE),
+	)
+	if err == nil && (math.IsNaN(r.Threshold) || math.IsInf(r.Threshold, 0)) {
+		err = validation.NewFieldError("Threshold", "must be a finite number")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// MatchesTopic returns true if the topic matches the rule's topic filter.
+func (r MQTTRule) MatchesTopic(topic string) bool {
+	if strings.HasPrefix(topic, "$") {
+		return false
+	}
+
+	filter := strings.Split(r.TopicFilter, "/")
+	levels := strings.Split(topic, "/")
+	for i, f := range filter {
+		if f == "#" {
+			return true
+		}
+		if i >= len(levels) {
+			return false
+		}
+		if f != "+" && f != levels[i] {
+			return false
+		}
+	}
+
+	return len(filter) == len(levels)
+}
+
+func numericValue(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case nil:
+		return 0, errors.New("no value")
+	}
+
+	return 0, fmt.Errorf("unsupported value type %T", v)
+}
+
+// Value extracts the numeric value from a message payload.
+func (r MQTTRule) Value(payload []byte) (float64, error) {
+	var data interface{}
+	err := json.Unmarshal(payload, &data)
+	if err != nil && r.ValueExpr == "" {
+		// plain-text payloads are common for simple sensors
+		return strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "parse payload")
+	}
+	if r.ValueExpr == "" {
+		return numericValue(data)
+	}
+
+	res, err := jmespath.Search(r.ValueExpr, data)
+	if err != nil {
+		return 0, errors.Wrap(err, "evaluate value")
+	}
+
+	return numericValue(res)
+}
+
+// Crosses returns true if the value crosses the rule's threshold.
+func (r MQTTRule) Crosses(value float64) bool {
+	switch r.Operator {
+	case MQTTOperatorAny:
+		return true
+	case MQTTOperatorGT:
+		return value > r.Threshold
+	case MQTTOperatorGTE:
+		return value >= r.Threshold
+	case MQTTOperatorLT:
+		return value < r.Threshold
+	case MQTTOperatorLTE:
+		return value <= r.Threshold
+	case MQTTOperatorEQ:
+		return value == r.Threshold
+	case MQTTOperatorNE:
+		return value != r.Threshold
+	}
+
+	return false
+}
+
+// String returns a short description of the rule condition, e.g., "> 80".
+func (r MQTTRule) String() string {
+	var op string
+	switch r.Operator {
+	case MQTTOperatorAny:
+		return "any message"
+	case MQTTOperatorGT:
+		op = ">"
+	case MQTTOperatorGTE:
+		op = ">="
+	case MQTTOperatorLT:
+		op = "<"
+	case MQTTOperatorLTE:
+		op = "<="
+	case MQTTOperatorEQ:
+		op = "=="
+	case MQTTOperatorNE:
+		op = "!="
+	}
+
+	return op + " " + strconv.FormatFloat(r.Threshold, 'g', -1, 64)
+}
+
+func normalizeMQTTRules(rules []MQTTRule) ([]MQTTRule, error) {
+	err := validate.Range("Rules", len(rules), 0, MaxMQTTRules)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]MQTTRule, 0, len(rules))
+	for i, r := range rules {
+		n, err := r.Normalize()
+		if err != nil {
+			return nil, validation.AddPrefix(fmt.Sprintf("Rules[%d].", i), err)
+		}
+		result = append(result, *n)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMQTTRule_MatchesTopic(t *testing.T) {
+	check := func(filter, topic string, expected bool) {
+		t.Helper()
+		assert.Equal(t, expected, MQTTRule{TopicFilter: filter}.MatchesTopic(topic), "%s ~ %s", filter, topic)
+	}
+
+	check("plant/+/temp", "plant/line1/temp", true)
+	check("plant/+/temp", "plant/line1/humidity", false)
+	check("plant/+/temp", "plant/line1/temp/raw", false)
+	check("plant/#", "plant", true)
+	check("plant/#", "plant/line1/temp", true)
+	check("#", "plant/line1", true)
+	check("#", "$SYS/broker/uptime", false)
+	check("plant/line1", "plant/line1", true)
+	check("plant/line1", "plant/line1/", false)
+}
+
+func TestMQTTRule_Value(t *testing.T) {
+	v, err := MQTTRule{}.Value([]byte(" 87.5\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 87.5, v)
+
+	v, err = MQTTRule{}.Value([]byte(`true`))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, v)
+
+	v, err = MQTTRule{ValueExpr: "sensors.temp"}.Value([]byte(`{"sensors":{"temp":"42"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, v)
+
+	_, err = MQTTRule{ValueExpr: "sensors.temp"}.Value([]byte(`{"sensors":{}}`))
+	assert.Error(t, err)
+
+	_, err = MQTTRule{}.Value([]byte(`hot`))
+	assert.Error(t, err)
+}
+
+func TestMQTTRule_Crosses(t *testing.T) {
+	r := MQTTRule{Operator: MQTTOperatorGTE, Threshold: 80}
+	assert.True(t, r.Crosses(80))
+	assert.False(t, r.Crosses(79.9))
+	assert.Equal(t, ">= 80", r.String())
+
+	r = MQTTRule{Operator: MQTTOperatorAny}
+	assert.True(t, r.Crosses(0))
+}
+
+func TestMQTTRule_Normalize(t *testing.T) {
+	n, err := MQTTRule{TopicFilter: " plant/+/temp ", Operator: MQTTOperatorGT, Threshold: 80, AutoClose: true}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "plant/+/temp", n.TopicFilter)
+
+	n, err = MQTTRule{TopicFilter: "alarms/#", Operator: MQTTOperatorAny, Threshold: 5, AutoClose: true}.Normalize()
+	require.NoError(t, err)
+	assert.Zero(t, n.Threshold)
+	assert.False(t, n.AutoClose)
+
+	for _, filter := range []string{"", "plant/#/temp", "plant/line+", "$SYS/#"} {
+		_, err = MQTTRule{TopicFilter: filter, Operator: MQTTOperatorGT}.Normalize()
+		assert.Error(t, err, filter)
+	}
+
+	_, err = MQTTRule{TopicFilter: "a", Operator: "between"}.Normalize()
+	assert.Error(t, err)
+}
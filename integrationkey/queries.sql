@@ -37,3 +37,158 @@ WHERE
 DELETE FROM integration_keys
 WHERE id = ANY (@ids::uuid[]);
 
+
+-- name: IntKeyGetTransform :one
+SELECT
+    summary,
+    details,
+    dedup,
+    action
+FROM
+    integration_key_transforms
+WHERE
+    integration_key_id = $1;
+
+-- name: IntKeySetTransform :exec
+INSERT INTO integration_key_transforms(integration_key_id, summary, details, dedup, action)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        summary = $2, details = $3, dedup = $4, action = $5;
+
+-- name: IntKeyDeleteTransform :exec
+DELETE FROM integration_key_transforms
+WHERE integration_key_id = $1;
+
+-- name: IntKeyGetEmailRules :one
+SELECT
+    dedup_pattern,
+    resolve_pattern,
+    allowed_senders,
+    max_details_length
+FROM
+    integration_key_email_rules
+WHERE
+    integration_key_id = $1;
+
+-- name: IntKeySetEmailRules :exec
+INSERT INTO integration_key_email_rules(integration_key_id, dedup_pattern, resolve_pattern, allowed_senders, max_details_length)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        dedup_pattern = $2, resolve_pattern = $3, allowed_senders = $4, max_details_length = $5;
+
+-- name: IntKeyDeleteEmailRules :exec
+DELETE FROM integration_key_email_rules
+WHERE integration_key_id = $1;
+
+-- name: IntKeySNMPRules :many
+SELECT
+    trap_oid,
+    action,
+    summary_oid,
+    dedup_oid
+FROM
+    integration_key_snmp_rules
+WHERE
+    integration_key_id = $1
+ORDER BY
+    position;
+
+-- name: IntKeyDeleteSNMPRules :exec
+DELETE FROM integration_key_snmp_rules
+WHERE integration_key_id = $1;
+
+-- name: IntKeyInsertSNMPRule :exec
+INSERT INTO integration_key_snmp_rules(integration_key_id, position, trap_oid, action, summary_oid, dedup_oid)
+    VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: IntKeySyslogFilters :many
+SELECT
+    integration_key_id,
+    max_severity,
+    facilities,
+    app_names,
+    hostnames
+FROM
+    integration_key_syslog_filters;
+
+-- name: IntKeyGetSyslogFilter :one
+SELECT
+    integration_key_id,
+    max_severity,
+    facilities,
+    app_names,
+    hostnames
+FROM
+    integration_key_syslog_filters
+WHERE
+    integration_key_id = $1;
+
+-- name: IntKeySetSyslogFilter :exec
+INSERT INTO integration_key_syslog_filters(integration_key_id, max_severity, facilities, app_names, hostnames)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        max_severity = $2, facilities = $3, app_names = $4, hostnames = $5;
+
+-- name: IntKeyDeleteSyslogFilter :exec
+DELETE FROM integration_key_syslog_filters
+WHERE integration_key_id = $1;
+
+
+-- name: IntKeyMQTTRules :many
+SELECT
+    topic_filter,
+    value_expr,
+    operator,
+    threshold,
+    auto_close
+FROM
+    integration_key_mqtt_rules
+WHERE
+    integration_key_id = $1
+ORDER BY
+    position;
+
+-- name: IntKeyAllMQTTRules :many
+SELECT
+    integration_key_id,
+    topic_filter,
+    value_expr,
+    operator,
+    threshold,
+    auto_close
+FROM
+    integration_key_mqtt_rules
+ORDER BY
+    integration_key_id,
+    position;
+
+-- name: IntKeyDeleteMQTTRules :exec
+DELETE FROM integration_key_mqtt_rules
+WHERE integration_key_id = $1;
+
+-- name: IntKeyInsertMQTTRule :exec
+INSERT INTO integration_key_mqtt_rules(integration_key_id, position, topic_filter, value_expr, operator, threshold, auto_close)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: IntKeyGetGitHubFilter :one
+SELECT
+    repositories,
+    branches
+FROM
+    integration_key_github_filters
+WHERE
+    integration_key_id = $1;
+
+-- name: IntKeySetGitHubFilter :exec
+INSERT INTO integration_key_github_filters(integration_key_id, repositories, branches)
+    VALUES ($1, $2, $3)
+ON CONFLICT (integration_key_id)
+    DO UPDATE SET
+        repositories = $2, branches = $3;
+
+-- name: IntKeyDeleteGitHubFilter :exec
+DELETE FROM integration_key_github_filters
+WHERE integration_key_id = $1;
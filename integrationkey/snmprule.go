@@ -0,0 +1,102 @@
+package integrationkey
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxSNMPRules is the maximum number of SNMP rules for a single key.
+const MaxSNMPRules = 50
+
+// SNMPAction is the action taken when an SNMP rule matches a trap.
+type SNMPAction string
+
+// Available SNMP rule actions.
+const (
+	SNMPActionTrigger SNMPAction = "trigger"
+	SNMPActionClose   SNMPAction = "close"
+	SNMPActionIgnore  SNMPAction = "ignore"
+)
+
+// SNMPRule maps traps with a matching OID to an alert action.
+type SNMPRule struct {
+	// TrapOID matches the trap OID exactly, or any OID below it.
+	TrapOID string
+	Action  SNMPAction
+
+	// SummaryOID, if set, selects the first varbind at or below it to use as the alert summary.
+	SummaryOID string
+
+	// DedupOID, if set, selects the first varbind at or below it to include in the dedup key.
+	DedupOID string
+}
+
+var oidRx = regexp.MustCompile(`^[0-2](\.[0-9]+)+$`)
+
+func validOID(fname, oid string, required bool) error {
+	if oid == "" && !required {
+		return nil
+	}
+	if len(oid) > 255 || !oidRx.MatchString(oid) {
+		return validation.NewFieldError(fname, "must be a numeric OID (e.g., 1.3.6.1.4.1)")
+	}
+	return nil
+}
+
+// Normalize will validate and normalize the SNMPRule.
+func (r SNMPRule) Normalize() (*SNMPRule, error) {
+	r.TrapOID = strings.TrimPrefix(strings.TrimSpace(r.TrapOID), ".")
+	r.SummaryOID = strings.TrimPrefix(strings.TrimSpace(r.SummaryOID), ".")
+	r.DedupOID = strings.TrimPrefix(strings.TrimSpace(r.DedupOID), ".")
+
+	err := validate.Many(
+		validOID("TrapOID", r.TrapOID, true),
+		validOID("SummaryOID", r.SummaryOID, false),
+		validOID("DedupOID", r.DedupOID, false),
+		validate.OneOf("Action", r.Action, SNMPActionTrigger, SNMPActionClose, SNMPActionIgnore),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Matches returns true if the rule applies to the given trap OID.
+func (r SNMPRule) Matches(trapOID string) bool {
+	trapOID = strings.TrimPrefix(trapOID, ".")
+	return trapOID == r.TrapOID || strings.HasPrefix(trapOID, r.TrapOID+".")
+}
+
+// MatchSNMPRule returns the first rule matching the trap OID, or nil if none match.
+func MatchSNMPRule(rules []SNMPRule, trapOID string) *SNMPRule {
+	for _, r := range rules {
+		if r.Matches(trapOID) {
+			return &r
+		}
+	}
+
+	return nil
+}
+
+func normalizeSNMPRules(rules []SNMPRule) ([]SNMPRule, error) {
+	err := validate.Range("Rules", len(rules), 0, MaxSNMPRules)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SNMPRule, 0, len(rules))
+	for i, r := range rules {
+		n, err := r.Normalize()
+		if err != nil {
+			return nil, validation.AddPrefix(fmt.Sprintf("Rules[%d].", i), err)
+		}
+		result = append(result, *n)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSNMPRule(t *testing.T) {
+	rules := []SNMPRule{
+		{TrapOID: "1.3.6.1.6.3.1.1.5.4", Action: SNMPActionClose},
+		{TrapOID: "1.3.6.1.6.3.1.1.5", Action: SNMPActionTrigger},
+	}
+
+	assert.Equal(t, SNMPActionClose, MatchSNMPRule(rules, "1.3.6.1.6.3.1.1.5.4").Action)
+	assert.Equal(t, SNMPActionTrigger, MatchSNMPRule(rules, ".1.3.6.1.6.3.1.1.5.3").Action)
+	assert.Nil(t, MatchSNMPRule(rules, "1.3.6.1.6.3.1.1.50"))
+}
+
+func TestSNMPRule_Normalize(t *testing.T) {
+	n, err := SNMPRule{TrapOID: " .1.3.6.1.4.1 ", Action: SNMPActionIgnore}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.6.1.4.1", n.TrapOID)
+
+	_, err = SNMPRule{TrapOID: "ifDown", Action: SNMPActionTrigger}.Normalize()
+	assert.Error(t, err)
+
+	_, err = SNMPRule{TrapOID: "1.3.6", Action: "page"}.Normalize()
+	assert.Error(t, err)
+}
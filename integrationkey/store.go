@@ -7,6 +7,7 @@ import (
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
@@ -44,7 +45,7 @@ func (s *Store) GetServiceID(ctx context.Context, id string, t Type) (string, er
 	keyUUID, err := validate.ParseUUID("IntegrationKeyID", id)
 	err = validate.Many(
 		err,
-		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeDatadog, TypeAmazonSNS, TypeSentry, TypeZabbix, TypeAzureMonitor, TypeGCPMonitoring, TypeNewRelic, TypeSplunk, TypePagerDutyEvents, TypeNagios, TypeSNMP, TypeSyslog, TypeKafka, TypeMQTT, TypeGitHubActions, TypeGrafanaOnCall, TypeGeneric, TypeEmail),
 	)
 	if err != nil {
 		return "", err
@@ -171,3 +172,510 @@ func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Integ
 	}
 	return keys, nil
 }
+
+// FindTransform will return the payload transform for the given key, or nil if none is set.
+func (s *Store) FindTransform(ctx context.Context, keyID string) (*Transform, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IntKeyGetTransform(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transform{
+		Summary: row.Summary,
+		Details: row.Details,
+		Dedup:   row.Dedup,
+		Action:  row.Action,
+	}, nil
+}
+
+// SetTransform will set the payload transform for the given key. A nil or empty transform removes it.
+//
+// Transforms are only supported for generic keys.
+func (s *Store) SetTransform(ctx context.Context, dbtx gadb.DBTX, keyID string, t *Transform) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	if t == nil || t.IsEmpty() {
+		return q.IntKeyDeleteTransform(ctx, keyUUID)
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return err
+	}
+
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeGeneric {
+		return validation.NewFieldError("IntegrationKeyID", "transforms are only supported for generic keys")
+	}
+
+	return q.IntKeySetTransform(ctx, gadb.IntKeySetTransformParams{
+		IntegrationKeyID: keyUUID,
+		Summary:          n.Summary,
+		Details:          n.Details,
+		Dedup:            n.Dedup,
+		Action:           n.Action,
+	})
+}
+
+// FindEmailRules will return the email rules for the given key, or nil if none are set.
+func (s *Store) FindEmailRules(ctx context.Context, keyID string) (*EmailRules, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IntKeyGetEmailRules(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailRules{
+		DedupPattern:     row.DedupPattern,
+		ResolvePattern:   row.ResolvePattern,
+		AllowedSenders:   append([]string{}, row.AllowedSenders...),
+		MaxDetailsLength: int(row.MaxDetailsLength),
+	}, nil
+}
+
+// SetEmailRules will set the email rules for the given key. Nil or empty rules remove them.
+//
+// Email rules are only supported for email keys.
+func (s *Store) SetEmailRules(ctx context.Context, dbtx gadb.DBTX, keyID string, r *EmailRules) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	if r == nil || r.IsEmpty() {
+		return q.IntKeyDeleteEmailRules(ctx, keyUUID)
+	}
+
+	n, err := r.Normalize()
+	if err != nil {
+		return err
+	}
+
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeEmail {
+		return validation.NewFieldError("IntegrationKeyID", "email rules are only supported for email keys")
+	}
+
+	return q.IntKeySetEmailRules(ctx, gadb.IntKeySetEmailRulesParams{
+		IntegrationKeyID: keyUUID,
+		DedupPattern:     n.DedupPattern,
+		ResolvePattern:   n.ResolvePattern,
+		AllowedSenders:   n.AllowedSenders,
+		MaxDetailsLength: int32(n.MaxDetailsLength),
+	})
+}
+
+// FindGitHubFilter will return the GitHub filter for the given key, or nil if none is set.
+func (s *Store) FindGitHubFilter(ctx context.Context, keyID string) (*GitHubFilter, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IntKeyGetGitHubFilter(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubFilter{
+		Repositories: append([]string{}, row.Repositories...),
+		Branches:     append([]string{}, row.Branches...),
+	}, nil
+}
+
+// SetGitHubFilter will set the GitHub filter for the given key. A nil or empty filter removes it.
+//
+// GitHub filters are only supported for GitHub Actions keys.
+func (s *Store) SetGitHubFilter(ctx context.Context, dbtx gadb.DBTX, keyID string, f *GitHubFilter) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	if f == nil || f.IsEmpty() {
+		return q.IntKeyDeleteGitHubFilter(ctx, keyUUID)
+	}
+
+	n, err := f.Normalize()
+	if err != nil {
+		return err
+	}
+
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeGitHubActions {
+		return validation.NewFieldError("IntegrationKeyID", "GitHub filters are only supported for GitHub Actions keys")
+	}
+
+	return q.IntKeySetGitHubFilter(ctx, gadb.IntKeySetGitHubFilterParams{
+		IntegrationKeyID: keyUUID,
+		Repositories:     n.Repositories,
+		Branches:         n.Branches,
+	})
+}
+
+// FindSNMPRules will return the SNMP rules for the given key, in order.
+func (s *Store) FindSNMPRules(ctx context.Context, keyID string) ([]SNMPRule, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IntKeySNMPRules(ctx, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]SNMPRule, len(rows))
+	for i, row := range rows {
+		rules[i] = SNMPRule{
+			TrapOID:    row.TrapOid,
+			Action:     SNMPAction(row.Action),
+			SummaryOID: row.SummaryOid,
+			DedupOID:   row.DedupOid,
+		}
+	}
+	return rules, nil
+}
+
+// SetSNMPRules will replace the SNMP rules for the given key.
+//
+// SNMP rules are only supported for SNMP keys.
+func (s *Store) SetSNMPRules(ctx context.Context, dbtx gadb.DBTX, keyID string, rules []SNMPRule) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	rules, err = normalizeSNMPRules(rules)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeSNMP {
+		return validation.NewFieldError("IntegrationKeyID", "SNMP rules are only supported for SNMP keys")
+	}
+
+	err = q.IntKeyDeleteSNMPRules(ctx, keyUUID)
+	if err != nil {
+		return errors.Wrap(err, "delete existing rules")
+	}
+	for i, r := range rules {
+		err = q.IntKeyInsertSNMPRule(ctx, gadb.IntKeyInsertSNMPRuleParams{
+			IntegrationKeyID: keyUUID,
+			Position:         int32(i),
+			TrapOid:          r.TrapOID,
+			Action:           string(r.Action),
+			SummaryOid:       r.SummaryOID,
+			DedupOid:         r.DedupOID,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "insert rule %d", i)
+		}
+	}
+
+	return nil
+}
+
+func syslogFilterFromDB(row gadb.IntegrationKeySyslogFilter) SyslogFilter {
+	f := SyslogFilter{
+		IntegrationKeyID: row.IntegrationKeyID.String(),
+		MaxSeverity:      int(row.MaxSeverity),
+		Facilities:       make([]int, len(row.Facilities)),
+		AppNames:         append([]string{}, row.AppNames...),
+		Hostnames:        append([]string{}, row.Hostnames...),
+	}
+	for i, fac := range row.Facilities {
+		f.Facilities[i] = int(fac)
+	}
+	return f
+}
+
+// AllSyslogFilters returns the syslog filters for all keys.
+func (s *Store) AllSyslogFilters(ctx context.Context) ([]SyslogFilter, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IntKeySyslogFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]SyslogFilter, len(rows))
+	for i, row := range rows {
+		filters[i] = syslogFilterFromDB(row)
+	}
+	return filters, nil
+}
+
+// FindSyslogFilter will return the syslog filter for the given key, or nil if none is set.
+func (s *Store) FindSyslogFilter(ctx context.Context, keyID string) (*SyslogFilter, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).IntKeyGetSyslogFilter(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f := syslogFilterFromDB(row)
+	return &f, nil
+}
+
+// SetSyslogFilter will set the syslog filter for a key. A nil filter removes it.
+//
+// Syslog filters are only supported for syslog keys.
+func (s *Store) SetSyslogFilter(ctx context.Context, dbtx gadb.DBTX, keyID string, f *SyslogFilter) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	if f == nil {
+		return q.IntKeyDeleteSyslogFilter(ctx, keyUUID)
+	}
+
+	f.IntegrationKeyID = keyID
+	n, err := f.Normalize()
+	if err != nil {
+		return err
+	}
+
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeSyslog {
+		return validation.NewFieldError("IntegrationKeyID", "syslog filters are only supported for syslog keys")
+	}
+
+	facilities := make([]int32, len(n.Facilities))
+	for i, fac := range n.Facilities {
+		facilities[i] = int32(fac)
+	}
+
+	return q.IntKeySetSyslogFilter(ctx, gadb.IntKeySetSyslogFilterParams{
+		IntegrationKeyID: keyUUID,
+		MaxSeverity:      int32(n.MaxSeverity),
+		Facilities:       facilities,
+		AppNames:         n.AppNames,
+		Hostnames:        n.Hostnames,
+	})
+}
+
+func mqttRuleFromDB(keyID uuid.UUID, topicFilter, valueExpr, op string, threshold float64, autoClose bool) MQTTRule {
+	return MQTTRule{
+		IntegrationKeyID: keyID.String(),
+		TopicFilter:      topicFilter,
+		ValueExpr:        valueExpr,
+		Operator:         MQTTOperator(op),
+		Threshold:        threshold,
+		AutoClose:        autoClose,
+	}
+}
+
+// AllMQTTRules returns the MQTT rules for all keys.
+func (s *Store) AllMQTTRules(ctx context.Context) ([]MQTTRule, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IntKeyAllMQTTRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]MQTTRule, len(rows))
+	for i, row := range rows {
+		rules[i] = mqttRuleFromDB(row.IntegrationKeyID, row.TopicFilter, row.ValueExpr, row.Operator, row.Threshold, row.AutoClose)
+	}
+	return rules, nil
+}
+
+// FindMQTTRules will return the MQTT rules for the given key, in order.
+func (s *Store) FindMQTTRules(ctx context.Context, keyID string) ([]MQTTRule, error) {
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).IntKeyMQTTRules(ctx, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]MQTTRule, len(rows))
+	for i, row := range rows {
+		rules[i] = mqttRuleFromDB(keyUUID, row.TopicFilter, row.ValueExpr, row.Operator, row.Threshold, row.AutoClose)
+	}
+	return rules, nil
+}
+
+// SetMQTTRules will replace the MQTT rules for the given key.
+//
+// MQTT rules are only supported for MQTT keys.
+func (s *Store) SetMQTTRules(ctx context.Context, dbtx gadb.DBTX, keyID string, rules []MQTTRule) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	keyUUID, err := validate.ParseUUID("IntegrationKeyID", keyID)
+	if err != nil {
+		return err
+	}
+
+	rules, err = normalizeMQTTRules(rules)
+	if err != nil {
+		return err
+	}
+
+	q := gadb.New(dbtx)
+	row, err := q.IntKeyFindOne(ctx, keyUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	if Type(row.Type) != TypeMQTT {
+		return validation.NewFieldError("IntegrationKeyID", "MQTT rules are only supported for MQTT keys")
+	}
+
+	err = q.IntKeyDeleteMQTTRules(ctx, keyUUID)
+	if err != nil {
+		return errors.Wrap(err, "delete existing rules")
+	}
+	for i, r := range rules {
+		err = q.IntKeyInsertMQTTRule(ctx, gadb.IntKeyInsertMQTTRuleParams{
+			IntegrationKeyID: keyUUID,
+			Position:         int32(i),
+			TopicFilter:      r.TopicFilter,
+			ValueExpr:        r.ValueExpr,
+			Operator:         string(r.Operator),
+			Threshold:        r.Threshold,
+			AutoClose:        r.AutoClose,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "insert rule %d", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package integrationkey
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxSyslogFilterValues is the maximum number of entries in each SyslogFilter list.
+const MaxSyslogFilterValues = 50
+
+// SyslogFilter selects which syslog messages create alerts for an integration key.
+type SyslogFilter struct {
+	IntegrationKeyID string
+
+	// MaxSeverity is the least severe level that matches, from 0 (emergency) to 7 (debug).
+	MaxSeverity int
+
+	// Facilities limits matching to the given facility codes (0-23). If empty, all facilities match.
+	Facilities []int
+
+	// AppNames limits matching to the given program/APP-NAME values (case-insensitive).
+	AppNames []string
+
+	// Hostnames limits matching to the given HOSTNAME values (case-insensitive).
+	Hostnames []string
+}
+
+func normalizeNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+// Normalize will validate and normalize the SyslogFilter.
+//
+// At least one AppName or Hostname is required so that a key can't match every message.
+func (f SyslogFilter) Normalize() (*SyslogFilter, error) {
+	f.AppNames = normalizeNames(f.AppNames)
+	f.Hostnames = normalizeNames(f.Hostnames)
+
+	err := validate.Many(
+		validate.UUID("IntegrationKeyID", f.IntegrationKeyID),
+		validate.Range("MaxSeverity", f.MaxSeverity, 0, 7),
+		validate.Range("Facilities", len(f.Facilities), 0, MaxSyslogFilterValues),
+		validate.Range("AppNames", len(f.AppNames), 0, MaxSyslogFilterValues),
+		validate.Range("Hostnames", len(f.Hostnames), 0, MaxSyslogFilterValues),
+	)
+	for i, fac := range f.Facilities {
+		err = validate.Many(err, validate.Range(fmt.Sprintf("Facilities[%d]", i), fac, 0, 23))
+	}
+	for i, n := range f.AppNames {
+		err = validate.Many(err, validate.ASCII(fmt.Sprintf("AppNames[%d]", i), n, 1, 48))
+	}
+	for i, n := range f.Hostnames {
+		err = validate.Many(err, validate.ASCII(fmt.Sprintf("Hostnames[%d]", i), n, 1, 255))
+	}
+	if err == nil && len(f.AppNames) == 0 && len(f.Hostnames) == 0 {
+		err = validation.NewFieldError("AppNames", "at least one app name or hostname is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if a message with the given attributes matches the filter.
+func (f SyslogFilter) Matches(facility, severity int, hostname, appName string) bool {
+	if severity > f.MaxSeverity {
+		return false
+	}
+	if len(f.Facilities) > 0 {
+		var found bool
+		for _, fac := range f.Facilities {
+			if fac == facility {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.AppNames) > 0 && !containsFold(f.AppNames, appName) {
+		return false
+	}
+	if len(f.Hostnames) > 0 && !containsFold(f.Hostnames, hostname) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,42 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyslogFilter_Matches(t *testing.T) {
+	f := SyslogFilter{
+		MaxSeverity: 3,
+		Facilities:  []int{4, 10},
+		AppNames:    []string{"sshd"},
+	}
+
+	assert.True(t, f.Matches(4, 2, "host1", "SSHD"))
+	assert.True(t, f.Matches(10, 3, "", "sshd"))
+	assert.False(t, f.Matches(4, 4, "host1", "sshd"), "severity")
+	assert.False(t, f.Matches(1, 2, "host1", "sshd"), "facility")
+	assert.False(t, f.Matches(4, 2, "host1", "cron"), "app name")
+
+	f = SyslogFilter{MaxSeverity: 7, Hostnames: []string{"fw01"}}
+	assert.True(t, f.Matches(23, 7, "FW01", "anything"))
+	assert.False(t, f.Matches(23, 7, "fw02", "anything"))
+}
+
+func TestSyslogFilter_Normalize(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	n, err := SyslogFilter{IntegrationKeyID: id, MaxSeverity: 2, AppNames: []string{" sshd ", ""}}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sshd"}, n.AppNames)
+
+	_, err = SyslogFilter{IntegrationKeyID: id, MaxSeverity: 2}.Normalize()
+	assert.Error(t, err, "no app names or hostnames")
+
+	_, err = SyslogFilter{IntegrationKeyID: id, MaxSeverity: 8, Hostnames: []string{"fw01"}}.Normalize()
+	assert.Error(t, err, "severity")
+
+	_, err = SyslogFilter{IntegrationKeyID: id, Facilities: []int{24}, Hostnames: []string{"fw01"}}.Normalize()
+	assert.Error(t, err, "facility")
+}
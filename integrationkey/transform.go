@@ -0,0 +1,171 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MaxTransformExprLength is the maximum length of a single transform expression.
+const MaxTransformExprLength = 1024
+
+// transformCostLimit bounds the runtime cost of evaluating a single expression.
+const transformCostLimit = 100000
+
+// Transform maps an arbitrary JSON payload to alert fields using CEL expressions.
+//
+// The decoded JSON payload is available to each expression as `payload`. Empty expressions
+// are ignored, leaving the corresponding field unset.
+type Transform struct {
+	Summary string
+	Details string
+	Dedup   string
+
+	// Action should evaluate to "close" or "resolve" to close an alert. Any other value will trigger an alert.
+	Action string
+}
+
+// TransformResult contains the alert fields produced by a Transform.
+type TransformResult struct {
+	Summary string
+	Details string
+	Dedup   string
+	Action  string
+}
+
+// IsEmpty returns true if no expressions are set.
+func (t Transform) IsEmpty() bool {
+	return t.Summary == "" && t.Details == "" && t.Dedup == "" && t.Action == ""
+}
+
+var transformEnv = func() *cel.Env {
+	env, err := cel.NewEnv(cel.Variable("payload", cel.DynType))
+	if err != nil {
+		panic(err)
+	}
+	return env
+}()
+
+func compileExpr(expr string) (cel.Program, error) {
+	ast, iss := transformEnv.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+
+	return transformEnv.Program(ast, cel.CostLimit(transformCostLimit))
+}
+
+func validExpr(fname, expr string) error {
+	if expr == "" {
+		return nil
+	}
+	if len(expr) > MaxTransformExprLength {
+		return validation.NewFieldError(fname, fmt.Sprintf("must be at most %d characters", MaxTransformExprLength))
+	}
+
+	_, err := compileExpr(expr)
+	if err != nil {
+		return validation.NewFieldError(fname, "invalid CEL expression: "+err.Error())
+	}
+
+	return nil
+}
+
+// Normalize will validate and trim all expressions.
+func (t Transform) Normalize() (*Transform, error) {
+	t.Summary = strings.TrimSpace(t.Summary)
+	t.Details = strings.TrimSpace(t.Details)
+	t.Dedup = strings.TrimSpace(t.Dedup)
+	t.Action = strings.TrimSpace(t.Action)
+
+	err := validate.Many(
+		validExpr("Summary", t.Summary),
+		validExpr("Details", t.Details),
+		validExpr("Dedup", t.Dedup),
+		validExpr("Action", t.Action),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func evalExpr(expr string, data interface{}) (string, error) {
+	if expr == "" {
+		return "", nil
+	}
+
+	prg, err := compileExpr(expr)
+	if err != nil {
+		return "", err
+	}
+
+	res, _, err := prg.Eval(map[string]interface{}{"payload": data})
+	if err != nil {
+		return "", err
+	}
+
+	switch v := res.(type) {
+	case types.Null:
+		return "", nil
+	case types.String:
+		return string(v), nil
+	}
+
+	native, err := res.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return "", err
+	}
+	buf, err := json.Marshal(native.(*structpb.Value).AsInterface())
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// Apply will evaluate all expressions against the provided JSON payload.
+//
+// Non-string results are encoded as JSON.
+func (t Transform) Apply(payload []byte) (*TransformResult, error) {
+	var data interface{}
+	err := json.Unmarshal(payload, &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode payload")
+	}
+
+	var res TransformResult
+	res.Summary, err = evalExpr(t.Summary, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluate summary")
+	}
+	res.Details, err = evalExpr(t.Details, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluate details")
+	}
+	res.Dedup, err = evalExpr(t.Dedup, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluate dedup")
+	}
+	res.Action, err = evalExpr(t.Action, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluate action")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(res.Action)) {
+	case "close", "closed", "resolve", "resolved":
+		res.Action = "close"
+	default:
+		res.Action = ""
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,56 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform_Apply(t *testing.T) {
+	tr := Transform{
+		Summary: `payload.incident.title + " on " + payload.incident.labels.host`,
+		Details: "payload.incident.labels",
+		Dedup:   `has(payload.incident.id) ? payload.incident.id : null`,
+		Action:  "payload.incident.state",
+	}
+
+	res, err := tr.Apply([]byte(`{"incident":{"id":"abc","title":"Disk full","state":"RESOLVED","labels":{"host":"db1"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Disk full on db1", res.Summary)
+	assert.Equal(t, `{"host":"db1"}`, res.Details)
+	assert.Equal(t, "abc", res.Dedup)
+	assert.Equal(t, "close", res.Action)
+
+	res, err = tr.Apply([]byte(`{"incident":{"title":"Disk full","state":"firing","labels":{"host":"db1"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "", res.Dedup)
+	assert.Equal(t, "", res.Action)
+
+	// acknowledging is not supported by transforms
+	res, err = Transform{Action: `"ack"`}.Apply([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, "", res.Action)
+
+	res, err = Transform{Summary: `payload.count > 1 ? "many" : "one"`, Details: "[payload.count, true]"}.Apply([]byte(`{"count":2}`))
+	require.NoError(t, err)
+	assert.Equal(t, "many", res.Summary)
+	assert.Equal(t, "[2,true]", res.Details)
+
+	_, err = tr.Apply([]byte(`{"incident":{}}`))
+	assert.Error(t, err, "missing key")
+
+	_, err = tr.Apply([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestTransform_Normalize(t *testing.T) {
+	_, err := Transform{Summary: " payload.a.b "}.Normalize()
+	assert.NoError(t, err)
+
+	_, err = Transform{Summary: "payload.a.["}.Normalize()
+	assert.Error(t, err)
+
+	_, err = Transform{Summary: "unknown.a"}.Normalize()
+	assert.Error(t, err, "undeclared variable")
+}
@@ -13,6 +13,22 @@ const (
 	TypeGrafana                Type = "grafana"
 	TypeSite24x7               Type = "site24x7"
 	TypePrometheusAlertmanager Type = "prometheusAlertmanager"
+	TypeDatadog                Type = "datadog"
+	TypeAmazonSNS              Type = "amazonSNS"
+	TypeSentry                 Type = "sentry"
+	TypeZabbix                 Type = "zabbix"
+	TypeAzureMonitor           Type = "azureMonitor"
+	TypeGCPMonitoring          Type = "gcpMonitoring"
+	TypeNewRelic               Type = "newRelic"
+	TypeSplunk                 Type = "splunk"
+	TypePagerDutyEvents        Type = "pagerDutyEvents"
+	TypeNagios                 Type = "nagios"
+	TypeSNMP                   Type = "snmp"
+	TypeSyslog                 Type = "syslog"
+	TypeKafka                  Type = "kafka"
+	TypeMQTT                   Type = "mqtt"
+	TypeGitHubActions          Type = "githubActions"
+	TypeGrafanaOnCall          Type = "grafanaOnCall"
 	TypeGeneric                Type = "generic"
 	TypeEmail                  Type = "email"
 )
@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// Client makes requests to the Jira REST API using the credentials from the current config.
+type Client struct {
+	HTTP *http.Client
+}
+
+// APIError is returned when Jira responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Messages   []string
+}
+
+func (err *APIError) Error() string {
+	if len(err.Messages) == 0 {
+		return fmt.Sprintf("jira: unexpected status %d", err.StatusCode)
+	}
+
+	return fmt.Sprintf("jira: unexpected status %d: %s", err.StatusCode, strings.Join(err.Messages, "; "))
+}
+
+// Temporary returns true for rate-limit and server errors.
+func (err *APIError) Temporary() bool {
+	return err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= 500
+}
+
+// IssueURL returns the browser URL for the given issue key.
+func IssueURL(cfg config.Config, key string) string {
+	return strings.TrimSuffix(cfg.Jira.BaseURL, "/") + "/browse/" + url.PathEscape(key)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	cfg := config.FromContext(ctx)
+	if !cfg.Jira.Enable {
+		return fmt.Errorf("jira: disabled")
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(cfg.Jira.BaseURL, "/")+path, r)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(cfg.Jira.Username, cfg.Jira.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp struct {
+			ErrorMessages []string
+			Errors        map[string]string
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&errResp)
+		apiErr := &APIError{StatusCode: resp.StatusCode, Messages: errResp.ErrorMessages}
+		for field, msg := range errResp.Errors {
+			apiErr.Messages = append(apiErr.Messages, field+": "+msg)
+		}
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(result)
+}
+
+// CreateIssue creates a new issue with the provided fields and returns its key.
+func (c *Client) CreateIssue(ctx context.Context, fields map[string]interface{}) (string, error) {
+	var resp struct{ Key string }
+	err := c.do(ctx, "POST", "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.Key == "" {
+		return "", fmt.Errorf("jira: create issue: missing key in response")
+	}
+
+	return resp.Key, nil
+}
+
+// AddRemoteLink links the issue to an external URL (e.g., the alert in GoAlert).
+func (c *Client) AddRemoteLink(ctx context.Context, key, linkURL, title string) error {
+	return c.do(ctx, "POST", "/rest/api/2/issue/"+url.PathEscape(key)+"/remotelink", map[string]interface{}{
+		"globalId": linkURL,
+		"object": map[string]string{
+			"url":   linkURL,
+			"title": title,
+		},
+	}, nil)
+}
+
+// AddComment adds a plain-text comment to the issue.
+func (c *Client) AddComment(ctx context.Context, key, body string) error {
+	return c.do(ctx, "POST", "/rest/api/2/issue/"+url.PathEscape(key)+"/comment", map[string]string{"body": body}, nil)
+}
+
+// TransitionIssue moves the issue using the transition with the given name (case-insensitive).
+//
+// If the transition is not currently available (e.g., the issue was already resolved manually),
+// nil is returned.
+func (c *Client) TransitionIssue(ctx context.Context, key, name string) error {
+	var resp struct {
+		Transitions []struct {
+			ID   string
+			Name string
+		}
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/transitions"
+	err := c.do(ctx, "GET", path, nil, &resp)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range resp.Transitions {
+		if !strings.EqualFold(t.Name, name) {
+			continue
+		}
+
+		return c.do(ctx, "POST", path, map[string]interface{}{
+			"transition": map[string]string{"id": t.ID},
+		}, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxFields is the maximum number of additional field mappings for a single service.
+const MaxFields = 20
+
+// Default templates used when none are provided.
+const (
+	DefaultSummaryTemplate     = `{{.Summary}}`
+	DefaultDescriptionTemplate = "{{.Details}}\n\nGoAlert: {{.URL}}"
+)
+
+var (
+	projectKeyRx = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	fieldIDRx    = regexp.MustCompile(`^([a-z][a-zA-Z0-9]*|customfield_[0-9]+)$`)
+)
+
+// reserved fields are always set from the dedicated config values.
+var reservedFields = map[string]bool{
+	"project":     true,
+	"issuetype":   true,
+	"summary":     true,
+	"description": true,
+}
+
+// ServiceConfig controls how Jira issues are created for alerts on a service.
+type ServiceConfig struct {
+	ServiceID string
+
+	ProjectKey string
+	IssueType  string
+
+	SummaryTemplate     string
+	DescriptionTemplate string
+
+	// Fields maps additional Jira field IDs (e.g., `priority`, `labels`, or `customfield_10010`) to templates.
+	//
+	// Values are sent as strings, except `labels` (split on whitespace) and `priority`, `components`,
+	// and `assignee` (an account ID) which are wrapped in the object form Jira expects.
+	Fields map[string]string
+
+	// CloseTransition is the name of the workflow transition applied when the alert is closed (e.g., "Done").
+	// If empty, closed alerts only add a comment to the issue.
+	CloseTransition string
+}
+
+// TemplateData is available to all field templates.
+type TemplateData struct {
+	AlertID     int
+	Summary     string
+	Details     string
+	ServiceID   string
+	ServiceName string
+
+	// URL is the link to the alert in GoAlert.
+	URL string
+}
+
+var exampleData = TemplateData{
+	AlertID:     1,
+	Summary:     "Example summary",
+	Details:     "Example details",
+	ServiceID:   "00000000-0000-0000-0000-000000000000",
+	ServiceName: "Example Service",
+	URL:         "http://localhost/alerts/1",
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+func validTemplate(fname, text string, required bool) error {
+	var err error
+	if required {
+		err = validate.RequiredText(fname, text, 1, 4096)
+	} else {
+		err = validate.Text(fname, text, 1, 4096)
+	}
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := parseTemplate(fname, text)
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+	err = tmpl.Execute(new(strings.Builder), exampleData)
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	return nil
+}
+
+// Normalize will validate and normalize the ServiceConfig, applying default templates where empty.
+func (cfg ServiceConfig) Normalize() (*ServiceConfig, error) {
+	cfg.ProjectKey = strings.ToUpper(strings.TrimSpace(cfg.ProjectKey))
+	cfg.IssueType = strings.TrimSpace(cfg.IssueType)
+	cfg.CloseTransition = strings.TrimSpace(cfg.CloseTransition)
+	if cfg.SummaryTemplate == "" {
+		cfg.SummaryTemplate = DefaultSummaryTemplate
+	}
+	if cfg.DescriptionTemplate == "" {
+		cfg.DescriptionTemplate = DefaultDescriptionTemplate
+	}
+
+	err := validate.Many(
+		validate.UUID("ServiceID", cfg.ServiceID),
+		validate.RequiredText("ProjectKey", cfg.ProjectKey, 1, 255),
+		validate.RequiredText("IssueType", cfg.IssueType, 1, 255),
+		validate.Text("CloseTransition", cfg.CloseTransition, 1, 255),
+		validTemplate("SummaryTemplate", cfg.SummaryTemplate, true),
+		validTemplate("DescriptionTemplate", cfg.DescriptionTemplate, true),
+		validate.Range("Fields", len(cfg.Fields), 0, MaxFields),
+	)
+	if err == nil && !projectKeyRx.MatchString(cfg.ProjectKey) {
+		err = validation.NewFieldError("ProjectKey", "must start with a letter and contain only letters, numbers, and underscores")
+	}
+	for _, id := range cfg.fieldIDs() {
+		fname := fmt.Sprintf("Fields[%s]", id)
+		switch {
+		case reservedFields[id]:
+			err = validate.Many(err, validation.NewFieldError(fname, "field is set by a dedicated option"))
+		case !fieldIDRx.MatchString(id):
+			err = validate.Many(err, validation.NewFieldError(fname, "invalid field ID"))
+		default:
+			err = validate.Many(err, validTemplate(fname, cfg.Fields[id], true))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (cfg ServiceConfig) fieldIDs() []string {
+	ids := make([]string, 0, len(cfg.Fields))
+	for id := range cfg.Fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func render(name, text string, data TemplateData) (string, error) {
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// IssueFields renders the Jira issue fields for a new issue.
+func (cfg ServiceConfig) IssueFields(data TemplateData) (map[string]interface{}, error) {
+	summary, err := render("SummaryTemplate", cfg.SummaryTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	if summary == "" {
+		summary = data.Summary
+	}
+	// Jira limits summaries to 255 characters and rejects newlines
+	summary = strings.Join(strings.Fields(summary), " ")
+	if r := []rune(summary); len(r) > 255 {
+		summary = string(r[:254]) + "…"
+	}
+
+	desc, err := render("DescriptionTemplate", cfg.DescriptionTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": cfg.ProjectKey},
+		"issuetype":   map[string]string{"name": cfg.IssueType},
+		"summary":     summary,
+		"description": desc,
+	}
+
+	for _, id := range cfg.fieldIDs() {
+		val, err := render(id, cfg.Fields[id], data)
+		if err != nil {
+			return nil, err
+		}
+		if val == "" {
+			continue
+		}
+
+		switch id {
+		case "labels":
+			fields[id] = strings.Fields(val)
+		case "priority":
+			fields[id] = map[string]string{"name": val}
+		case "assignee":
+			fields[id] = map[string]string{"accountId": val}
+		case "components":
+			fields[id] = []map[string]string{{"name": val}}
+		default:
+			fields[id] = val
+		}
+	}
+
+	return fields, nil
+}
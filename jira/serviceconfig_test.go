@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceConfig_Normalize(t *testing.T) {
+	valid := ServiceConfig{
+		ServiceID:  "a1b2c3d4-0000-0000-0000-000000000000",
+		ProjectKey: " ops ",
+		IssueType:  "Incident",
+		Fields:     map[string]string{"priority": "High", "customfield_10010": "{{.ServiceName}}"},
+	}
+
+	n, err := valid.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "OPS", n.ProjectKey)
+	assert.Equal(t, DefaultSummaryTemplate, n.SummaryTemplate)
+	assert.Equal(t, DefaultDescriptionTemplate, n.DescriptionTemplate)
+
+	check := func(desc string, fn func(cfg *ServiceConfig)) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			cfg := valid
+			fn(&cfg)
+			_, err := cfg.Normalize()
+			assert.Error(t, err)
+		})
+	}
+
+	check("bad project key", func(cfg *ServiceConfig) { cfg.ProjectKey = "1OPS" })
+	check("missing issue type", func(cfg *ServiceConfig) { cfg.IssueType = "" })
+	check("template syntax", func(cfg *ServiceConfig) { cfg.SummaryTemplate = "{{.Summary" })
+	check("unknown template field", func(cfg *ServiceConfig) { cfg.SummaryTemplate = "{{.Nope}}" })
+	check("reserved field", func(cfg *ServiceConfig) { cfg.Fields = map[string]string{"summary": "x"} })
+	check("invalid field ID", func(cfg *ServiceConfig) { cfg.Fields = map[string]string{"bad field": "x"} })
+}
+
+func TestServiceConfig_IssueFields(t *testing.T) {
+	cfg := ServiceConfig{
+		ProjectKey:          "OPS",
+		IssueType:           "Incident",
+		SummaryTemplate:     "[{{.ServiceName}}] {{.Summary}}",
+		DescriptionTemplate: DefaultDescriptionTemplate,
+		Fields: map[string]string{
+			"labels":            "goalert alert-{{.AlertID}}",
+			"priority":          "High",
+			"customfield_10010": "{{.ServiceID}}",
+			"environment":       "{{if false}}x{{end}}",
+		},
+	}
+
+	fields, err := cfg.IssueFields(TemplateData{
+		AlertID:     42,
+		Summary:     "disk\nfull",
+		Details:     "sda1 at 99%",
+		ServiceID:   "svc-id",
+		ServiceName: "Storage",
+		URL:         "https://goalert.example.com/alerts/42",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"project":           map[string]string{"key": "OPS"},
+		"issuetype":         map[string]string{"name": "Incident"},
+		"summary":           "[Storage] disk full",
+		"description":       "sda1 at 99%\n\nGoAlert: https://goalert.example.com/alerts/42",
+		"labels":            []string{"goalert", "alert-42"},
+		"priority":          map[string]string{"name": "High"},
+		"customfield_10010": "svc-id",
+	}, fields)
+}
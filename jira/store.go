@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Issue is the Jira issue linked to an alert.
+type Issue struct {
+	AlertID int
+
+	// Key is the issue key (e.g., `OPS-123`), empty if the issue has not been created yet.
+	Key string
+
+	// Closed indicates the issue was updated for the closed alert.
+	Closed bool
+
+	// LastError is the most recent error from Jira, if any.
+	LastError string
+}
+
+// Store manages Jira service configs and alert issue links.
+type Store struct {
+	db *sql.DB
+
+	findConfig   *sql.Stmt
+	setConfig    *sql.Stmt
+	deleteConfig *sql.Stmt
+	findIssue    *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		findConfig: p.P(`
+			select project_key, issue_type, summary_template, description_template, fields, close_transition
+			from service_jira_configs
+			where service_id = $1
+		`),
+		setConfig: p.P(`
+			insert into service_jira_configs (service_id, project_key, issue_type, summary_template, description_template, fields, close_transition)
+			values ($1, $2, $3, $4, $5, $6, $7)
+			on conflict (service_id) do update
+			set
+				project_key = $2,
+				issue_type = $3,
+				summary_template = $4,
+				description_template = $5,
+				fields = $6,
+				close_transition = $7
+		`),
+		deleteConfig: p.P(`delete from service_jira_configs where service_id = $1`),
+		findIssue: p.P(`
+			select coalesce(issue_key, ''), closed, last_error
+			from alert_jira_issues
+			where alert_id = $1
+		`),
+	}, p.Err
+}
+
+// ServiceConfig returns the Jira config for the service, or nil if none is set.
+func (s *Store) ServiceConfig(ctx context.Context, serviceID string) (*ServiceConfig, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := ServiceConfig{ServiceID: serviceID}
+	var fields json.RawMessage
+	err = s.findConfig.QueryRowContext(ctx, serviceID).Scan(&cfg.ProjectKey, &cfg.IssueType, &cfg.SummaryTemplate, &cfg.DescriptionTemplate, &fields, &cfg.CloseTransition)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(fields, &cfg.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// SetServiceConfigTx will create or replace the Jira config for a service.
+//
+// Only alerts created after the config is first set will have issues created.
+func (s *Store) SetServiceConfigTx(ctx context.Context, tx *sql.Tx, cfg ServiceConfig) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := cfg.Normalize()
+	if err != nil {
+		return err
+	}
+	if n.Fields == nil {
+		n.Fields = map[string]string{}
+	}
+	fields, err := json.Marshal(n.Fields)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setConfig).ExecContext(ctx, n.ServiceID, n.ProjectKey, n.IssueType, n.SummaryTemplate, n.DescriptionTemplate, fields, n.CloseTransition)
+	return err
+}
+
+// DeleteServiceConfigTx will remove the Jira config for a service. Existing issues are left as-is.
+func (s *Store) DeleteServiceConfigTx(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.deleteConfig).ExecContext(ctx, serviceID)
+	return err
+}
+
+// AlertIssue returns the Jira issue linked to the alert, or nil if there is none.
+func (s *Store) AlertIssue(ctx context.Context, alertID int) (*Issue, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	iss := Issue{AlertID: alertID}
+	err = s.findIssue.QueryRowContext(ctx, alertID).Scan(&iss.Key, &iss.Closed, &iss.LastError)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &iss, nil
+}
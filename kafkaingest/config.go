@@ -0,0 +1,43 @@
+package kafkaingest
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/target/goalert/alert"
+)
+
+// SASL mechanisms supported for broker authentication.
+const (
+	SASLPlain       = "plain"
+	SASLScramSHA256 = "scram-sha-256"
+	SASLScramSHA512 = "scram-sha-512"
+)
+
+// Config is used to configure the Kafka consumer.
+type Config struct {
+	BackgroundContext func() context.Context
+
+	// Brokers is the list of bootstrap broker addresses (host:port).
+	Brokers []string
+
+	// Topic is the topic to consume alert events from.
+	Topic string
+
+	// GroupID is the consumer group used to track offsets. All GoAlert
+	// instances should share the same group so each event is processed once.
+	GroupID string
+
+	// TLS, if set, enables TLS when connecting to brokers.
+	TLS *tls.Config
+
+	// SASLMechanism is one of SASLPlain, SASLScramSHA256, SASLScramSHA512, or empty to disable SASL.
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+
+	// AuthorizeFunc authorizes the integration key ID referenced by an event.
+	AuthorizeFunc func(ctx context.Context, id string) (context.Context, error)
+
+	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+}
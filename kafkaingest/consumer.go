@@ -0,0 +1,188 @@
+package kafkaingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+)
+
+// Consumer reads alert events from a Kafka topic and creates alerts.
+//
+// Messages are processed in order and offsets are committed after each
+// message is handled, so events are delivered at-least-once. Events that
+// can't be parsed or authorized are logged and skipped.
+type Consumer struct {
+	cfg Config
+	r   *kafka.Reader
+
+	ctx    context.Context
+	cancel func()
+	done   chan struct{}
+}
+
+func saslMechanism(cfg Config) (sasl.Mechanism, error) {
+	switch cfg.SASLMechanism {
+	case "":
+		return nil, nil
+	case SASLPlain:
+		return plain.Mechanism{Username: cfg.SASLUsername, Password: cfg.SASLPassword}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, cfg.SASLUsername, cfg.SASLPassword)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, cfg.SASLUsername, cfg.SASLPassword)
+	}
+
+	return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.SASLMechanism)
+}
+
+// NewConsumer creates a new Consumer.
+func NewConsumer(cfg Config) (*Consumer, error) {
+	if cfg.BackgroundContext == nil {
+		panic("kafkaingest: BackgroundContext is required")
+	}
+	if cfg.AuthorizeFunc == nil {
+		panic("kafkaingest: AuthorizeFunc is required")
+	}
+	if cfg.CreateAlertFunc == nil {
+		panic("kafkaingest: CreateAlertFunc is required")
+	}
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafkaingest: at least one broker is required")
+	}
+	if cfg.Topic == "" {
+		return nil, errors.New("kafkaingest: topic is required")
+	}
+	if cfg.GroupID == "" {
+		return nil, errors.New("kafkaingest: group ID is required")
+	}
+
+	mech, err := saslMechanism(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafkaingest: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(cfg.BackgroundContext())
+	ctx = log.WithFields(ctx, log.Fields{
+		"KafkaTopic": cfg.Topic,
+		"KafkaGroup": cfg.GroupID,
+	})
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Topic:   cfg.Topic,
+		GroupID: cfg.GroupID,
+		Dialer: &kafka.Dialer{
+			Timeout:       10 * time.Second,
+			DualStack:     true,
+			TLS:           cfg.TLS,
+			SASLMechanism: mech,
+		},
+		MaxBytes: 10e6,
+
+		// new consumer groups start with new events, rather than replaying the entire topic
+		StartOffset: kafka.LastOffset,
+
+		ErrorLogger: kafka.LoggerFunc(func(msg string, args ...interface{}) {
+			log.Log(ctx, fmt.Errorf("kafkaingest: "+msg, args...))
+		}),
+	})
+
+	return &Consumer{
+		cfg:    cfg,
+		r:      r,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Consume processes messages until Shutdown is called.
+func (c *Consumer) Consume() error {
+	defer close(c.done)
+
+	for {
+		m, err := c.r.FetchMessage(c.ctx)
+		if c.ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("kafkaingest: fetch message: %w", err)
+		}
+
+		c.handleMessage(m)
+
+		err = c.r.CommitMessages(c.ctx, m)
+		if c.ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			// the message may be redelivered, which is safe since alerts are de-duplicated
+			log.Log(c.ctx, fmt.Errorf("kafkaingest: commit offset: %w", err))
+		}
+	}
+}
+
+// Shutdown stops consuming, waits for the current message to be processed, and closes the reader.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	c.cancel()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+	}
+
+	return c.r.Close()
+}
+
+func headerValue(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+func (c *Consumer) handleMessage(m kafka.Message) {
+	ctx := log.WithFields(c.ctx, log.Fields{
+		"KafkaPartition": m.Partition,
+		"KafkaOffset":    m.Offset,
+	})
+
+	e, err := ParseEvent(m.Value, headerValue(m, HeaderIntegrationKey))
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("kafkaingest: skipping invalid event: %w", err))
+		return
+	}
+	ctx = log.WithField(ctx, "IntegrationKeyID", e.IntegrationKey)
+
+	ctx, err = c.cfg.AuthorizeFunc(ctx, e.IntegrationKey)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("kafkaingest: authorize: %w", err))
+		return
+	}
+
+	a := e.Alert()
+	a.ServiceID = permission.ServiceID(ctx)
+	err = retry.DoTemporaryError(func(_ int) error {
+		return c.cfg.CreateAlertFunc(ctx, a)
+	},
+		retry.Log(ctx),
+		retry.Limit(12),
+		retry.FibBackoff(time.Second),
+	)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("kafkaingest: create alert: %w", err))
+	}
+}
@@ -0,0 +1,95 @@
+package kafkaingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation/validate"
+)
+
+// HeaderIntegrationKey is the message header that may be used to provide the
+// integration key instead of including it in the event body.
+const HeaderIntegrationKey = "integration-key"
+
+// Event is the JSON payload of a single message.
+//
+//	{
+//	  "integrationKey": "<key ID>",
+//	  "summary": "Disk full on db01",
+//	  "details": "Optional details (markdown).",
+//	  "dedup": "optional-dedup-key",
+//	  "action": "close"
+//	}
+//
+// Action may be "trigger" (default), "ack", or "close". Summary is always
+// required. If Dedup is omitted, the summary and details are used to
+// de-duplicate alerts.
+type Event struct {
+	IntegrationKey string `json:"integrationKey"`
+	Summary        string `json:"summary"`
+	Details        string `json:"details"`
+	Dedup          string `json:"dedup"`
+	Action         string `json:"action"`
+}
+
+// ParseEvent decodes and validates a message. If headerKey is non-empty, it is
+// used when the event does not specify an integration key.
+func ParseEvent(data []byte, headerKey string) (*Event, error) {
+	var e Event
+	err := json.Unmarshal(data, &e)
+	if err != nil {
+		return nil, fmt.Errorf("decode event: %w", err)
+	}
+
+	e.IntegrationKey = strings.TrimSpace(e.IntegrationKey)
+	if e.IntegrationKey == "" {
+		e.IntegrationKey = strings.TrimSpace(headerKey)
+	}
+	if e.IntegrationKey == "" {
+		return nil, errors.New("missing integration key")
+	}
+	err = validate.UUID("IntegrationKey", e.IntegrationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Action = strings.ToLower(strings.TrimSpace(e.Action))
+	switch e.Action {
+	case "", "trigger":
+		e.Action = ""
+	case "close", "resolve":
+		e.Action = "close"
+	case "ack":
+	default:
+		return nil, fmt.Errorf("unsupported action %q", e.Action)
+	}
+
+	e.Summary = strings.TrimSpace(e.Summary)
+	if e.Summary == "" {
+		return nil, errors.New("missing summary")
+	}
+
+	return &e, nil
+}
+
+// Alert returns the alert described by the event.
+func (e Event) Alert() *alert.Alert {
+	status := alert.StatusTriggered
+	switch e.Action {
+	case "close":
+		status = alert.StatusClosed
+	case "ack":
+		status = alert.StatusActive
+	}
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(e.Summary, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(e.Details, alert.MaxDetailsLength),
+		Status:  status,
+		Source:  alert.SourceKafka,
+		Dedup:   alert.NewUserDedup(e.Dedup),
+	}
+}
@@ -0,0 +1,45 @@
+package kafkaingest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestParseEvent(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	e, err := ParseEvent([]byte(`{"integrationKey":"`+id+`","summary":" Disk full ","details":"d","dedup":"db01","extra":1}`), "")
+	require.NoError(t, err)
+	assert.Equal(t, id, e.IntegrationKey)
+	assert.Equal(t, "Disk full", e.Summary)
+
+	a := e.Alert()
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.SourceKafka, a.Source)
+	assert.Equal(t, "db01", a.Dedup.Payload)
+
+	e, err = ParseEvent([]byte(`{"summary":"Disk full","action":"Resolve"}`), id)
+	require.NoError(t, err)
+	assert.Equal(t, id, e.IntegrationKey, "header key")
+	assert.Equal(t, alert.StatusClosed, e.Alert().Status)
+	assert.Nil(t, e.Alert().Dedup)
+
+	e, err = ParseEvent([]byte(`{"summary":"Disk full","action":"ack"}`), id)
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusActive, e.Alert().Status)
+
+	invalid := []string{
+		`not json`,
+		`{"summary":"Disk full"}`,
+		`{"integrationKey":"foo","summary":"Disk full"}`,
+		`{"integrationKey":"` + id + `"}`,
+		`{"integrationKey":"` + id + `","summary":"Disk full","action":"page"}`,
+	}
+	for _, s := range invalid {
+		_, err = ParseEvent([]byte(s), "")
+		assert.Error(t, err, s)
+	}
+}
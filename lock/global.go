@@ -12,6 +12,11 @@ const (
 	// this includes out-of-transaction processes.
 	GlobalMessageSending = uint32(0x1330) // 4912
 
+	// Ensures only a single instance is sending messages for a partition,
+	// when message sending is partitioned. Partition N uses the value
+	// MessageSendingPartition+N.
+	MessageSendingPartition = uint32(0x13300000) // 321912832
+
 	// Currently unused.
 	RegionalEngineProcessing = uint32(0x1342) // 4930
 
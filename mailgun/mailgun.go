@@ -1,6 +1,7 @@
 package mailgun
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
@@ -8,15 +9,18 @@ import (
 	"fmt"
 	"io"
 	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
-	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/integrationkey"
@@ -26,6 +30,7 @@ import (
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
+	"github.com/target/goalert/webhooksig"
 )
 
 // httpError is used to respond in a standard way to Mailgun when err != nil. If
@@ -62,23 +67,88 @@ func httpError(ctx context.Context, w http.ResponseWriter, err error) bool {
 	return true
 }
 
-// validSignature is used to validate the request from Mailgun.
-// If request is validated true is returned, false otherwise.
+// signatureFields are the form fields used to sign a request.
+var signatureFields = []string{"timestamp", "token", "signature"}
+
+// maxFieldSize is the maximum size of a signature field.
+const maxFieldSize = 1024
+
+// signatureValues returns the signature fields from a raw request body, without parsing the
+// rest of the form (e.g., attachments) before the request is verified.
+func signatureValues(req *http.Request, body []byte) (url.Values, error) {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return url.ParseQuery(string(body))
+	}
+
+	vals := make(url.Values)
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for len(vals) < len(signatureFields) {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := part.FormName()
+		if part.FileName() != "" || !slices.Contains(signatureFields, name) || vals.Has(name) {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxFieldSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxFieldSize {
+			return nil, fmt.Errorf("field %s too large", name)
+		}
+		vals.Set(name, string(data))
+	}
+
+	return vals, nil
+}
+
+// verifySignature is used to validate the request from Mailgun.
 // https://documentation.mailgun.com/en/latest/user_manual.html#securing-webhooks
-func validSignature(ctx context.Context, req *http.Request, apikey string) bool {
-	h := hmac.New(sha256.New, []byte(apikey))
-	_, _ = io.WriteString(h, req.FormValue("timestamp"))
-	_, _ = io.WriteString(h, req.FormValue("token"))
+func verifySignature(req *http.Request, body []byte) (*webhooksig.Signed, error) {
+	cfg := config.FromContext(req.Context())
 
-	calculatedSignature := h.Sum(nil)
-	signature, err := hex.DecodeString(req.FormValue("signature"))
+	vals, err := signatureValues(req, body)
 	if err != nil {
-		return false
+		return nil, webhooksig.ErrInvalid
 	}
 
-	return hmac.Equal(signature, calculatedSignature)
+	ts := vals.Get("timestamp")
+	token := vals.Get("token")
+	sigStr := vals.Get("signature")
+	if sigStr == "" {
+		return nil, webhooksig.ErrMissing
+	}
+	signature, err := hex.DecodeString(sigStr)
+	if err != nil {
+		return nil, webhooksig.ErrInvalid
+	}
+
+	h := hmac.New(sha256.New, []byte(cfg.Mailgun.APIKey))
+	_, _ = io.WriteString(h, ts)
+	_, _ = io.WriteString(h, token)
+	if !hmac.Equal(signature, h.Sum(nil)) {
+		return nil, webhooksig.ErrInvalid
+	}
+
+	unixSec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, webhooksig.ErrInvalid
+	}
+
+	return &webhooksig.Signed{Time: time.Unix(unixSec, 0), Nonce: token}, nil
 }
 
+// maxBodySize is the maximum size of an inbound message, including attachments.
+const maxBodySize = 25 << 20
+
 type ingressHandler struct {
 	alerts  *alert.Store
 	intKeys *integrationkey.Store
@@ -115,13 +185,6 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !validSignature(ctx, r, cfg.Mailgun.APIKey) {
-		log.Log(ctx, errors.New("invalid Mailgun signature"))
-		auth.Delay(ctx)
-		http.Error(w, "Invalid Signature", http.StatusNotAcceptable)
-		return
-	}
-
 	recipient := r.FormValue("recipient")
 
 	m, err := mail.ParseAddress(recipient)
@@ -201,9 +264,21 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // IngressWebhooks is used to accept webhooks from Mailgun to support email as an alert creation mechanism.
 // Will read POST form parameters, validate, sanitize and use to create a new alert.
 // https://documentation.mailgun.com/en/latest/user_manual.html#parsed-messages-parameters
-func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
-	return (&ingressHandler{
+//
+// If nonces is non-nil, it is used to reject replayed requests.
+func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store, nonces webhooksig.NonceStore) http.Handler {
+	return webhooksig.Wrap(&ingressHandler{
 		alerts:  aDB,
 		intKeys: intDB,
-	}).ServeHTTP
+	}, webhooksig.Options{
+		Provider: "mailgun",
+		Verifier: webhooksig.VerifierFunc(verifySignature),
+		Nonces:   nonces,
+
+		// Inbound messages may include attachments.
+		MaxBodySize: maxBodySize,
+
+		// Mailgun will not retry rejected requests.
+		RejectStatus: http.StatusNotAcceptable,
+	})
 }
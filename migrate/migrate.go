@@ -3,7 +3,9 @@ package migrate
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -309,6 +311,30 @@ func readMigration(id string) ([]byte, error) {
 	return data, nil
 }
 
+// Checksum will return a hash of the IDs and contents of all embedded migrations
+// up to, and including, targetName. If targetName is empty, all migrations are included.
+//
+// It can be used to detect when a previously-migrated database (e.g., a template) is stale.
+func Checksum(targetName string) (string, error) {
+	h := sha256.New()
+	for _, id := range migrationIDs() {
+		data, err := readMigration(id)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\n%d\n", id, len(data))
+		h.Write(data)
+		if migrationName(id) == targetName {
+			return hex.EncodeToString(h.Sum(nil)), nil
+		}
+	}
+	if targetName != "" {
+		return "", errors.Errorf("unknown migration target name '%s'", targetName)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // DumpMigrations will attempt to write all migration files to the specified directory
 func DumpMigrations(dest string) error {
 	for _, id := range migrationIDs() {
@@ -389,6 +415,13 @@ func (step migrationStep) applyNoTx(ctx context.Context, c *pgx.Conn) error {
 		}
 	}
 
+	for i, stmt := range onlineStmts(step) {
+		_, err := c.Exec(ctx, stmt)
+		if err != nil {
+			return errors.Wrapf(err, "online migration statement #%d", i+1)
+		}
+	}
+
 	_, err := c.Exec(ctx, step.doneStmt(), step.ID)
 	if err != nil {
 		return errors.Wrap(err, "update gorp_migrations")
@@ -430,6 +463,13 @@ func performMigrations(ctx context.Context, c *pgx.Conn, applyUp bool, migration
 			step = m.Up
 		}
 
+		if applyUp {
+			err := ensureBackfilled(ctx, c, m)
+			if err != nil {
+				return i, err
+			}
+		}
+
 		s := time.Now()
 		err := step.apply(ctx, c)
 		if err != nil {
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE schedule_on_call_shadows (
+    id bigserial PRIMARY KEY,
+    schedule_id uuid NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL DEFAULT now(),
+    end_time timestamp with time zone,
+    CHECK (end_time > start_time)
+);
+
+CREATE UNIQUE INDEX idx_schedule_on_call_shadows_once ON schedule_on_call_shadows (schedule_id, user_id)
+WHERE
+    end_time IS NULL;
+
+ALTER TABLE ep_step_on_call_users
+    ADD COLUMN is_shadow boolean NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE ep_step_on_call_users
+    DROP COLUMN is_shadow;
+
+DROP TABLE schedule_on_call_shadows;
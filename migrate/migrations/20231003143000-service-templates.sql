@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE service_templates (
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    description text NOT NULL DEFAULT '',
+    params text[] NOT NULL DEFAULT '{}',
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_by uuid REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- +migrate Down
+DROP TABLE service_templates;
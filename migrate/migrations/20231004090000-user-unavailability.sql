@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE user_unavailability (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    note text NOT NULL DEFAULT '',
+    source text NOT NULL DEFAULT 'manual',
+    external_id text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK (end_time > start_time),
+    CHECK (source IN ('manual', 'ics'))
+);
+
+CREATE INDEX idx_user_unavailability_user_time ON user_unavailability (user_id, end_time);
+
+-- +migrate Down
+DROP TABLE user_unavailability;
@@ -0,0 +1,38 @@
+-- +migrate Up
+
+CREATE TABLE teams (
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    description text NOT NULL DEFAULT '',
+    default_schedule_id uuid REFERENCES schedules (id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE escalation_policy_actions
+    ADD COLUMN team_id uuid REFERENCES teams (id) ON DELETE CASCADE,
+    DROP CONSTRAINT epa_there_can_only_be_one,
+    ADD CONSTRAINT epa_there_can_only_be_one CHECK (
+        (case when user_id notnull then 1 else 0 end +
+        case when schedule_id notnull then 1 else 0 end +
+        case when rotation_id notnull then 1 else 0 end +
+        case when channel_id notnull then 1 else 0 end +
+        case when team_id notnull then 1 else 0 end) = 1
+    ),
+    ADD CONSTRAINT epa_no_duplicate_teams UNIQUE (escalation_policy_step_id, team_id);
+
+-- +migrate Down
+
+DELETE FROM escalation_policy_actions WHERE team_id NOTNULL;
+
+ALTER TABLE escalation_policy_actions
+    DROP CONSTRAINT epa_no_duplicate_teams,
+    DROP CONSTRAINT epa_there_can_only_be_one,
+    DROP COLUMN team_id,
+    ADD CONSTRAINT epa_there_can_only_be_one CHECK (
+        (case when user_id notnull then 1 else 0 end +
+        case when schedule_id notnull then 1 else 0 end +
+        case when rotation_id notnull then 1 else 0 end +
+        case when channel_id notnull then 1 else 0 end) = 1
+    );
+
+DROP TABLE teams;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+ALTER TABLE escalation_policies
+    ADD COLUMN backoff_multiplier double precision NOT NULL DEFAULT 1,
+    ADD COLUMN max_notifications integer NOT NULL DEFAULT 0,
+    ADD COLUMN give_up_channel_id uuid REFERENCES notification_channels (id) ON DELETE SET NULL,
+    ADD CONSTRAINT ep_backoff_multiplier_range CHECK (backoff_multiplier >= 1 AND backoff_multiplier <= 4),
+    ADD CONSTRAINT ep_max_notifications_range CHECK (max_notifications >= 0);
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN notification_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN gave_up boolean NOT NULL DEFAULT false;
+
+-- +migrate Down
+
+ALTER TABLE escalation_policy_state
+    DROP COLUMN gave_up,
+    DROP COLUMN notification_count;
+
+ALTER TABLE escalation_policies
+    DROP COLUMN give_up_channel_id,
+    DROP COLUMN max_notifications,
+    DROP COLUMN backoff_multiplier;
@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'amazonSNS'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'amazonSNS';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'amazonSNS';
+
+-- +migrate Down
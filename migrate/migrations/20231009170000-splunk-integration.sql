@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'splunk'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'splunk';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'splunk';
+
+-- +migrate Down
@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE integration_key_transforms (
+    integration_key_id uuid PRIMARY KEY REFERENCES integration_keys (id) ON DELETE CASCADE,
+    summary text NOT NULL DEFAULT '',
+    details text NOT NULL DEFAULT '',
+    dedup text NOT NULL DEFAULT '',
+    action text NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+DROP TABLE integration_key_transforms;
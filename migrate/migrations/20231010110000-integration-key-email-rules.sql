@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE integration_key_email_rules (
+    integration_key_id uuid PRIMARY KEY REFERENCES integration_keys (id) ON DELETE CASCADE,
+    dedup_pattern text NOT NULL DEFAULT '',
+    resolve_pattern text NOT NULL DEFAULT '',
+    allowed_senders text[] NOT NULL DEFAULT '{}',
+    max_details_length int NOT NULL DEFAULT 0
+);
+
+-- +migrate Down
+DROP TABLE integration_key_email_rules;
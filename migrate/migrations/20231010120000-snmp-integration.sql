@@ -0,0 +1,19 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'snmp'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'snmp';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'snmp';
+
+CREATE TABLE IF NOT EXISTS integration_key_snmp_rules (
+    id bigserial PRIMARY KEY,
+    integration_key_id uuid NOT NULL REFERENCES integration_keys (id) ON DELETE CASCADE,
+    position int NOT NULL,
+    trap_oid text NOT NULL,
+    action text NOT NULL CHECK (action IN ('trigger', 'close', 'ignore')),
+    summary_oid text NOT NULL DEFAULT '',
+    dedup_oid text NOT NULL DEFAULT '',
+    UNIQUE (integration_key_id, position)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS integration_key_snmp_rules;
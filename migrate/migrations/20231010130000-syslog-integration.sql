@@ -0,0 +1,16 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'syslog'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'syslog';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'syslog';
+
+CREATE TABLE IF NOT EXISTS integration_key_syslog_filters (
+    integration_key_id uuid PRIMARY KEY REFERENCES integration_keys (id) ON DELETE CASCADE,
+    max_severity int NOT NULL DEFAULT 3 CHECK (max_severity BETWEEN 0 AND 7),
+    facilities int[] NOT NULL DEFAULT '{}',
+    app_names text[] NOT NULL DEFAULT '{}',
+    hostnames text[] NOT NULL DEFAULT '{}'
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS integration_key_syslog_filters;
@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'kafka'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'kafka';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'kafka';
+
+-- +migrate Down
@@ -0,0 +1,20 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'mqtt'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'mqtt';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'mqtt';
+
+CREATE TABLE IF NOT EXISTS integration_key_mqtt_rules (
+    id bigserial PRIMARY KEY,
+    integration_key_id uuid NOT NULL REFERENCES integration_keys (id) ON DELETE CASCADE,
+    position int NOT NULL,
+    topic_filter text NOT NULL,
+    value_expr text NOT NULL DEFAULT '',
+    operator text NOT NULL CHECK (operator IN ('any', 'gt', 'gte', 'lt', 'lte', 'eq', 'ne')),
+    threshold double precision NOT NULL DEFAULT 0,
+    auto_close boolean NOT NULL DEFAULT FALSE,
+    UNIQUE (integration_key_id, position)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS integration_key_mqtt_rules;
@@ -0,0 +1,32 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'jira';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('jira', 1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS service_jira_configs (
+    service_id uuid PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    project_key text NOT NULL,
+    issue_type text NOT NULL,
+    summary_template text NOT NULL,
+    description_template text NOT NULL,
+    fields jsonb NOT NULL DEFAULT '{}',
+    close_transition text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS alert_jira_issues (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    issue_key text,
+    closed boolean NOT NULL DEFAULT FALSE,
+    attempts int NOT NULL DEFAULT 0,
+    last_attempt timestamp with time zone,
+    last_error text NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS alert_jira_issues;
+DROP TABLE IF EXISTS service_jira_configs;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'jira';
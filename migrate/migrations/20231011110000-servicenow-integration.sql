@@ -0,0 +1,34 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'servicenow';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('servicenow', 1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS service_servicenow_configs (
+    service_id uuid PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    assignment_group text NOT NULL DEFAULT '',
+    category text NOT NULL DEFAULT '',
+    caller_id text NOT NULL DEFAULT '',
+    impact int NOT NULL DEFAULT 2 CHECK (impact BETWEEN 1 AND 3),
+    urgency int NOT NULL DEFAULT 2 CHECK (urgency BETWEEN 1 AND 3),
+    resolve_code text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS alert_servicenow_incidents (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    sys_id text UNIQUE,
+    number text NOT NULL DEFAULT '',
+    acked boolean NOT NULL DEFAULT FALSE,
+    closed boolean NOT NULL DEFAULT FALSE,
+    attempts int NOT NULL DEFAULT 0,
+    last_attempt timestamp with time zone,
+    last_error text NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS alert_servicenow_incidents;
+DROP TABLE IF EXISTS service_servicenow_configs;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'servicenow';
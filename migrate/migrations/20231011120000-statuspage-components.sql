@@ -0,0 +1,23 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'statuspage';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('statuspage', 1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS service_statuspage_components (
+    service_id uuid PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    component_id text NOT NULL,
+    degraded_at int NOT NULL DEFAULT 1,
+    partial_outage_at int NOT NULL DEFAULT 0,
+    major_outage_at int NOT NULL DEFAULT 0,
+    last_status text NOT NULL DEFAULT '',
+    overridden boolean NOT NULL DEFAULT FALSE,
+    last_check timestamp with time zone,
+    last_error text NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS service_statuspage_components;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'statuspage';
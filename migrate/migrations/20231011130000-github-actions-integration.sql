@@ -0,0 +1,14 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'githubActions'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'githubActions';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'githubActions';
+
+CREATE TABLE IF NOT EXISTS integration_key_github_filters (
+    integration_key_id uuid PRIMARY KEY REFERENCES integration_keys (id) ON DELETE CASCADE,
+    repositories text[] NOT NULL DEFAULT '{}',
+    branches text[] NOT NULL DEFAULT '{}'
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS integration_key_github_filters;
@@ -0,0 +1,24 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'conference';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('conference', 1) ON CONFLICT DO NOTHING;
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN IF NOT EXISTS conference_bridge boolean NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS twilio_conference_bridges (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    ep_step_id uuid REFERENCES escalation_policy_steps (id) ON DELETE SET NULL,
+    access_code text NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS twilio_conference_bridges;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN IF EXISTS conference_bridge;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'conference';
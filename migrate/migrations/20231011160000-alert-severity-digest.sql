@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TYPE enum_alert_severity AS ENUM (
+    'critical',
+    'high',
+    'medium',
+    'low'
+);
+
+ALTER TABLE alerts
+    ADD COLUMN severity enum_alert_severity NOT NULL DEFAULT 'high';
+
+CREATE TABLE user_alert_digests (
+    user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    interval_minutes integer NOT NULL CHECK (interval_minutes > 0)
+);
+
+-- +migrate Down
+DROP TABLE user_alert_digests;
+
+ALTER TABLE alerts
+    DROP COLUMN severity;
+
+DROP TYPE enum_alert_severity;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE outgoing_message_requests (
+    idempotency_key uuid PRIMARY KEY,
+    message_id uuid NOT NULL REFERENCES outgoing_messages (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    completed_at timestamptz,
+    provider_msg_id text
+);
+
+CREATE INDEX idx_om_requests_message_id ON outgoing_message_requests (message_id);
+
+-- +migrate Down
+DROP TABLE outgoing_message_requests;
@@ -0,0 +1,49 @@
+-- +migrate Up
+CREATE TABLE schedule_cache_changes (
+    id bigserial PRIMARY KEY,
+    changed_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_schedule_cache_change() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        INSERT INTO schedule_cache_changes DEFAULT VALUES;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_schedule_cache_schedules
+    AFTER INSERT OR UPDATE OR DELETE ON schedules
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_schedule_cache_change();
+
+CREATE TRIGGER trg_schedule_cache_schedule_rules
+    AFTER INSERT OR UPDATE OR DELETE ON schedule_rules
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_schedule_cache_change();
+
+CREATE TRIGGER trg_schedule_cache_schedule_data
+    AFTER INSERT OR UPDATE OR DELETE ON schedule_data
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_schedule_cache_change();
+
+CREATE TRIGGER trg_schedule_cache_rotation_state
+    AFTER INSERT OR UPDATE OR DELETE ON rotation_state
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_schedule_cache_change();
+
+CREATE TRIGGER trg_schedule_cache_rotation_participants
+    AFTER INSERT OR UPDATE OR DELETE ON rotation_participants
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_schedule_cache_change();
+
+-- +migrate Down
+DROP TRIGGER trg_schedule_cache_rotation_participants ON rotation_participants;
+DROP TRIGGER trg_schedule_cache_rotation_state ON rotation_state;
+DROP TRIGGER trg_schedule_cache_schedule_data ON schedule_data;
+DROP TRIGGER trg_schedule_cache_schedule_rules ON schedule_rules;
+DROP TRIGGER trg_schedule_cache_schedules ON schedules;
+DROP FUNCTION fn_schedule_cache_change();
+DROP TABLE schedule_cache_changes;
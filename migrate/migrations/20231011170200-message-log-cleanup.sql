@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE INDEX idx_om_cleanup ON outgoing_messages (created_at)
+WHERE last_status IN ('bundled', 'delivered', 'failed', 'sent');
+
+CREATE INDEX idx_twilio_sms_callbacks_sent_at ON twilio_sms_callbacks (sent_at);
+
+CREATE INDEX idx_twilio_sms_errors_occurred_at ON twilio_sms_errors (occurred_at);
+
+CREATE INDEX idx_twilio_voice_errors_occurred_at ON twilio_voice_errors (occurred_at);
+
+-- +migrate Down
+DROP INDEX idx_twilio_voice_errors_occurred_at;
+
+DROP INDEX idx_twilio_sms_errors_occurred_at;
+
+DROP INDEX idx_twilio_sms_callbacks_sent_at;
+
+DROP INDEX idx_om_cleanup;
@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TYPE enum_engine_job_state AS ENUM (
+    'pending',
+    'running',
+    'completed',
+    'dead'
+);
+
+CREATE TABLE engine_jobs (
+    id bigserial PRIMARY KEY,
+    kind text NOT NULL,
+    args jsonb NOT NULL DEFAULT '{}',
+    state enum_engine_job_state NOT NULL DEFAULT 'pending',
+    periodic_interval interval,
+    attempts integer NOT NULL DEFAULT 0,
+    max_attempts integer NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    run_at timestamptz NOT NULL DEFAULT now(),
+    lease_expires_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz,
+    finished_at timestamptz,
+    last_error text
+);
+
+CREATE UNIQUE INDEX idx_engine_jobs_periodic ON engine_jobs (kind)
+WHERE periodic_interval IS NOT NULL;
+
+CREATE INDEX idx_engine_jobs_ready ON engine_jobs (run_at)
+WHERE state IN ('pending', 'running');
+
+CREATE INDEX idx_engine_jobs_finished ON engine_jobs (finished_at)
+WHERE state IN ('completed', 'dead');
+
+-- +migrate Down
+DROP TABLE engine_jobs;
+
+DROP TYPE enum_engine_job_state;
@@ -0,0 +1,19 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'notification_failover';
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN failover_of uuid REFERENCES outgoing_messages (id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_om_failover_of ON outgoing_messages (failover_of)
+WHERE failover_of NOTNULL;
+
+CREATE INDEX IF NOT EXISTS idx_om_cm_status_at ON outgoing_messages (contact_method_id, last_status_at)
+WHERE last_status IN ('delivered', 'failed', 'sent');
+
+-- +migrate Down notransaction
+DROP INDEX IF EXISTS idx_om_cm_status_at;
+
+DROP INDEX IF EXISTS idx_om_failover_of;
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN IF EXISTS failover_of;
@@ -0,0 +1,5 @@
+-- +migrate Up
+-- required for message log search; indexes are created concurrently in 20231011172000-message-log-search-indexes
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- +migrate Down
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE twilio_spend (
+    day DATE NOT NULL,
+    message_type TEXT NOT NULL CHECK (message_type IN ('sms', 'voice')),
+    region TEXT NOT NULL,
+    message_count INT NOT NULL DEFAULT 0,
+    estimated_cost NUMERIC NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, message_type, region)
+);
+
+-- +migrate Down
+DROP TABLE twilio_spend;
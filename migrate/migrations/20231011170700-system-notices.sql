@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE system_notices (
+    id UUID PRIMARY KEY,
+    type TEXT NOT NULL CHECK (type IN ('WARNING', 'ERROR', 'INFO')),
+    message TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    admin_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ
+);
+
+-- +migrate Down
+DROP TABLE system_notices;
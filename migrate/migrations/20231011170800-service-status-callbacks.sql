@@ -0,0 +1,30 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'status_callback';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('status_callback', 1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS service_status_callbacks (
+    service_id uuid PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    url text NOT NULL,
+    secret text NOT NULL,
+    unanswered_minutes int NOT NULL DEFAULT 15 CHECK (unanswered_minutes BETWEEN 1 AND 1440),
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS status_callback_deliveries (
+    message_id uuid NOT NULL REFERENCES outgoing_messages (id) ON DELETE CASCADE,
+    event text NOT NULL CHECK (event IN ('delivered', 'failed', 'unanswered')),
+    delivered boolean NOT NULL DEFAULT FALSE,
+    attempts int NOT NULL DEFAULT 0,
+    last_attempt timestamp with time zone,
+    last_error text NOT NULL DEFAULT '',
+    PRIMARY KEY (message_id, event)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS status_callback_deliveries;
+DROP TABLE IF EXISTS service_status_callbacks;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'status_callback';
@@ -0,0 +1,34 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'slo';
+
+INSERT INTO engine_processing_versions (type_id, version)
+VALUES ('slo', 1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS service_slos (
+    id uuid PRIMARY KEY,
+    service_id uuid NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    severity enum_alert_severity,
+    target_percent double precision NOT NULL CHECK (target_percent > 0 AND target_percent < 100),
+    ack_within_minutes int NOT NULL CHECK (ack_within_minutes BETWEEN 1 AND 1440),
+    window_days int NOT NULL DEFAULT 30 CHECK (window_days BETWEEN 1 AND 90),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (service_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS service_slo_status (
+    slo_id uuid PRIMARY KEY REFERENCES service_slos (id) ON DELETE CASCADE,
+    total int NOT NULL,
+    met int NOT NULL,
+    burn_rate_1h double precision NOT NULL,
+    burn_rate_6h double precision NOT NULL,
+    burn_rate_24h double precision NOT NULL,
+    computed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS service_slo_status;
+DROP TABLE IF EXISTS service_slos;
+
+DELETE FROM engine_processing_versions
+WHERE type_id = 'slo';
@@ -0,0 +1,40 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN request_id text;
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN request_id text;
+
+CREATE INDEX idx_alerts_request_id ON alerts (request_id)
+WHERE request_id NOTNULL;
+
+CREATE INDEX idx_outgoing_messages_request_id ON outgoing_messages (request_id)
+WHERE request_id NOTNULL;
+
+-- Messages for an alert inherit the request ID of the request that created the alert,
+-- so every message type (including ones inserted by engine modules) is covered.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_outgoing_message_request_id() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        SELECT request_id INTO NEW.request_id FROM alerts WHERE id = NEW.alert_id;
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_outgoing_message_request_id
+    BEFORE INSERT ON outgoing_messages
+    FOR EACH ROW
+    WHEN (NEW.alert_id NOTNULL AND NEW.request_id ISNULL)
+    EXECUTE PROCEDURE fn_outgoing_message_request_id();
+
+-- +migrate Down
+DROP TRIGGER trg_outgoing_message_request_id ON outgoing_messages;
+DROP FUNCTION fn_outgoing_message_request_id();
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN request_id;
+
+ALTER TABLE alerts
+    DROP COLUMN request_id;
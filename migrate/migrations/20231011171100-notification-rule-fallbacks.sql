@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE user_notification_rule_fallbacks (
+    id uuid PRIMARY KEY,
+    rule_id uuid NOT NULL REFERENCES user_notification_rules (id) ON DELETE CASCADE,
+    position integer NOT NULL,
+    contact_method_id uuid NOT NULL REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    delay_minutes integer NOT NULL,
+    UNIQUE (rule_id, position),
+    CHECK (position >= 0),
+    CHECK (delay_minutes BETWEEN 0 AND 9000)
+);
+
+CREATE INDEX idx_notification_rule_fallbacks_cm ON user_notification_rule_fallbacks (contact_method_id);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN fallback_id uuid REFERENCES user_notification_rule_fallbacks (id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_om_cycle_fallback ON outgoing_messages (cycle_id, fallback_id)
+WHERE fallback_id NOTNULL;
+
+-- +migrate Down
+DROP INDEX idx_om_cycle_fallback;
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN fallback_id;
+
+DROP TABLE user_notification_rule_fallbacks;
@@ -0,0 +1,30 @@
+-- +migrate Up
+CREATE TABLE user_quiet_hours (
+    user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    start_time time NOT NULL,
+    end_time time NOT NULL,
+    time_zone text NOT NULL,
+    CHECK (start_time != end_time)
+);
+
+-- Quiet hours that end before they start (e.g., 22:00 to 07:00) span midnight.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_user_quiet_hours_active(_user_id uuid) RETURNS boolean AS
+    $$
+        SELECT EXISTS (
+            SELECT NULL
+            FROM user_quiet_hours q,
+                LATERAL (SELECT (now() AT TIME ZONE q.time_zone)::time AS t) loc
+            WHERE
+                q.user_id = _user_id AND
+                CASE WHEN q.start_time < q.end_time
+                    THEN loc.t >= q.start_time AND loc.t < q.end_time
+                    ELSE loc.t >= q.start_time OR loc.t < q.end_time
+                END
+        )
+    $$ LANGUAGE 'sql' STABLE;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP FUNCTION fn_user_quiet_hours_active(uuid);
+DROP TABLE user_quiet_hours;
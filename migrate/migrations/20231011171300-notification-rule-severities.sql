@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE user_notification_rules
+    ADD COLUMN severities enum_alert_severity[];
+
+-- +migrate Down
+ALTER TABLE user_notification_rules
+    DROP COLUMN severities;
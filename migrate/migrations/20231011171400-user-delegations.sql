@@ -0,0 +1,50 @@
+-- +migrate Up
+CREATE TABLE user_delegations (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    delegate_user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK (end_time > start_time),
+    CHECK (user_id != delegate_user_id)
+);
+
+CREATE INDEX idx_user_delegations_user_time ON user_delegations (user_id, end_time);
+CREATE INDEX idx_user_delegations_delegate_time ON user_delegations (delegate_user_id, end_time);
+
+-- Notification cycles for a user with an active delegation are started for the delegate instead.
+-- Delegations are not followed further (i.e., the delegate's own delegation does not apply).
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_delegate_notification_cycle() RETURNS TRIGGER AS
+    $$
+    DECLARE
+        _delegate uuid;
+    BEGIN
+        SELECT delegate_user_id INTO _delegate
+        FROM user_delegations
+        WHERE
+            user_id = NEW.user_id AND
+            start_time <= now() AND
+            end_time > now()
+        ORDER BY created_at DESC
+        LIMIT 1;
+
+        IF _delegate NOTNULL THEN
+            NEW.user_id = _delegate;
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_delegate_notification_cycle
+    BEFORE INSERT ON notification_policy_cycles
+    FOR EACH ROW
+    EXECUTE PROCEDURE fn_delegate_notification_cycle();
+
+-- +migrate Down
+DROP TRIGGER trg_delegate_notification_cycle ON notification_policy_cycles;
+DROP FUNCTION fn_delegate_notification_cycle();
+DROP TABLE user_delegations;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE user_verification_codes
+    ADD COLUMN voice boolean NOT NULL DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE user_verification_codes
+    DROP COLUMN voice;
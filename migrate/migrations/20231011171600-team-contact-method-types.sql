@@ -0,0 +1,9 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_notif_channel_type
+    ADD VALUE IF NOT EXISTS 'SMS';
+ALTER TYPE enum_notif_channel_type
+    ADD VALUE IF NOT EXISTS 'VOICE';
+ALTER TYPE enum_notif_channel_type
+    ADD VALUE IF NOT EXISTS 'EMAIL';
+
+-- +migrate Down
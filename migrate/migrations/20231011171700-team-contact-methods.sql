@@ -0,0 +1,13 @@
+-- +migrate Up
+ALTER TABLE notification_channels
+    ADD COLUMN team_id uuid REFERENCES teams (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_notification_channels_team ON notification_channels (team_id)
+WHERE team_id NOTNULL;
+
+-- +migrate Down
+DELETE FROM notification_channels
+WHERE team_id NOTNULL;
+
+ALTER TABLE notification_channels
+    DROP COLUMN team_id;
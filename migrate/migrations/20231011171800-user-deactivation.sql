@@ -0,0 +1,31 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN deactivated_at timestamp with time zone;
+
+-- Contact methods of deactivated users stay disabled, even if re-enabled (e.g., by an SMS START reply).
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_cm_deactivated_user_disabled() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        IF NOT NEW.disabled AND EXISTS (
+            SELECT 1 FROM users WHERE id = NEW.user_id AND deactivated_at NOTNULL
+        ) THEN
+            NEW.disabled = true;
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_cm_deactivated_user_disabled
+    BEFORE INSERT OR UPDATE ON user_contact_methods
+    FOR EACH ROW
+    EXECUTE PROCEDURE fn_cm_deactivated_user_disabled();
+
+-- +migrate Down
+DROP TRIGGER trg_cm_deactivated_user_disabled ON user_contact_methods;
+DROP FUNCTION fn_cm_deactivated_user_disabled();
+
+ALTER TABLE users
+    DROP COLUMN deactivated_at;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN locale text;
+
+-- +migrate Down
+ALTER TABLE users
+    DROP COLUMN locale;
@@ -0,0 +1,21 @@
+-- +migrate Up notransaction
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_om_status_details_trgm ON outgoing_messages USING gin (status_details gin_trgm_ops);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_om_provider_msg_id_trgm ON outgoing_messages USING gin (provider_msg_id gin_trgm_ops);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_om_src_value_trgm ON outgoing_messages USING gin (src_value gin_trgm_ops);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_cm_value_trgm ON user_contact_methods USING gin (value gin_trgm_ops);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_nc_value_trgm ON notification_channels USING gin (value gin_trgm_ops);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_nc_value_trgm;
+
+DROP INDEX IF EXISTS idx_cm_value_trgm;
+
+DROP INDEX IF EXISTS idx_om_src_value_trgm;
+
+DROP INDEX IF EXISTS idx_om_provider_msg_id_trgm;
+
+DROP INDEX IF EXISTS idx_om_status_details_trgm;
@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE notification_channels
+    ADD COLUMN disabled boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN last_test_verify_at timestamp with time zone;
+
+-- team phones and email addresses must be verified before they are used
+UPDATE notification_channels
+SET disabled = TRUE
+WHERE team_id NOTNULL AND type IN ('SMS', 'VOICE', 'EMAIL');
+
+ALTER TABLE user_verification_codes
+    ALTER COLUMN contact_method_id DROP NOT NULL,
+    ADD COLUMN channel_id uuid REFERENCES notification_channels (id) ON DELETE CASCADE UNIQUE,
+    ADD CONSTRAINT user_verification_codes_cm_or_channel CHECK ((contact_method_id NOTNULL) <> (channel_id NOTNULL));
+
+-- +migrate Down
+DELETE FROM user_verification_codes
+WHERE channel_id NOTNULL;
+
+ALTER TABLE user_verification_codes
+    DROP CONSTRAINT user_verification_codes_cm_or_channel,
+    DROP COLUMN channel_id,
+    ALTER COLUMN contact_method_id SET NOT NULL;
+
+ALTER TABLE notification_channels
+    DROP COLUMN disabled,
+    DROP COLUMN last_test_verify_at;
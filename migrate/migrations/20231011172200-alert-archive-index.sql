@@ -0,0 +1,9 @@
+-- +migrate Up
+CREATE TABLE alert_archive (
+    alert_id bigint PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL,
+    archived_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE alert_archive;
@@ -0,0 +1,266 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// An OnlineMigration describes a schema change to a large table that is applied in expand/contract phases,
+// so it never requires a long-running lock or downtime:
+//
+//  1. The Expand migration adds the new schema (e.g., a nullable column). Once applied, a trigger keeps
+//     the new schema up to date for every inserted or updated row (dual writes).
+//  2. Existing rows are backfilled in small batches, in the background, until none are pending.
+//  3. The Contract migration (typically in a later release) removes the old schema. It will not be applied
+//     until the backfill is verified complete, after which the trigger is dropped.
+type OnlineMigration struct {
+	// Name uniquely identifies the online migration.
+	Name string
+
+	// Expand and Contract are the names of the SQL migrations (without timestamp) for each phase.
+	//
+	// Contract may refer to a migration that does not exist yet (i.e., one planned for a future release).
+	Expand, Contract string
+
+	// Table is the table being migrated, it must have an integer `id` column.
+	Table string
+
+	// DualWrite is PL/pgSQL executed for each inserted or updated row, e.g., `NEW.new_col = NEW.old_col;`.
+	DualWrite string
+
+	// Set is the SET clause used to backfill a row, e.g., `new_col = old_col`.
+	Set string
+
+	// Pending is a condition matching rows that have not been backfilled, e.g., `new_col isnull`.
+	Pending string
+
+	// BatchSize is the number of rows updated per transaction, defaults to 1000.
+	BatchSize int
+}
+
+// onlineMigrations is the list of all online migrations, in order.
+var onlineMigrations []OnlineMigration
+
+func (o OnlineMigration) triggerName() string { return sqlutil.QuoteID("zz_online_" + o.Name) }
+func (o OnlineMigration) funcName() string    { return sqlutil.QuoteID("fn_online_" + o.Name) }
+
+func (o OnlineMigration) createTriggerStmts() []string {
+	return []string{
+		fmt.Sprintf(`
+			create or replace function %s() returns trigger as $$
+			begin
+				%s
+				return new;
+			end;
+			$$ language plpgsql
+		`, o.funcName(), o.DualWrite),
+		fmt.Sprintf(`drop trigger if exists %s on %s`, o.triggerName(), sqlutil.QuoteID(o.Table)),
+		fmt.Sprintf(`
+			create trigger %s before insert or update on %s
+			for each row execute procedure %s()
+		`, o.triggerName(), sqlutil.QuoteID(o.Table), o.funcName()),
+	}
+}
+
+func (o OnlineMigration) dropTriggerStmts() []string {
+	return []string{
+		fmt.Sprintf(`drop trigger if exists %s on %s`, o.triggerName(), sqlutil.QuoteID(o.Table)),
+		fmt.Sprintf(`drop function if exists %s()`, o.funcName()),
+	}
+}
+
+// backfillQuery updates the next batch of pending rows after $1 (by id), returning the
+// largest updated id.
+func (o OnlineMigration) backfillQuery() string {
+	return fmt.Sprintf(`
+		with batch as (
+			select id from %[1]s
+			where id > $1 and (%[2]s)
+			order by id
+			limit $2
+			for update skip locked
+		)
+		update %[1]s t set %[3]s
+		from batch
+		where t.id = batch.id
+		returning t.id
+	`, sqlutil.QuoteID(o.Table), o.Pending, o.Set)
+}
+
+func (o OnlineMigration) verifyQuery() string {
+	return fmt.Sprintf(`select not exists(select 1 from %s where %s)`, sqlutil.QuoteID(o.Table), o.Pending)
+}
+
+// onlineStmts returns additional statements to run after the given migration step is applied.
+func onlineStmts(step migrationStep) []string {
+	var stmts []string
+	for _, o := range onlineMigrations {
+		switch {
+		case step.Name == o.Expand && step.isUp, step.Name == o.Contract && !step.isUp:
+			stmts = append(stmts, o.createTriggerStmts()...)
+		case step.Name == o.Expand && !step.isUp:
+			stmts = append(stmts, o.dropTriggerStmts()...)
+			stmts = append(stmts, fmt.Sprintf(`delete from migrate_backfills where name = '%s'`, o.Name))
+		case step.Name == o.Contract && step.isUp:
+			stmts = append(stmts, o.dropTriggerStmts()...)
+		}
+	}
+	return stmts
+}
+
+func ensureBackfillTable(ctx context.Context, conn *pgx.Conn) error {
+	_, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS migrate_backfills (
+			name text PRIMARY KEY,
+			last_id bigint NOT NULL DEFAULT 0,
+			completed_at timestamp with time zone
+		)
+	`)
+	return err
+}
+
+// isApplied returns true if the named migration has been applied, unknown migrations are never applied.
+func isApplied(ctx context.Context, conn *pgx.Conn, name string) (bool, error) {
+	_, id := migrationID(name)
+	if id == "" {
+		return false, nil
+	}
+
+	var ok bool
+	err := conn.QueryRow(ctx, `select exists(select 1 from gorp_migrations where id = $1)`, id).Scan(&ok)
+	return ok, err
+}
+
+// backfill will update all pending rows for o, in batches, and then verify none remain.
+func backfill(ctx context.Context, conn *pgx.Conn, o OnlineMigration) error {
+	err := ensureBackfillTable(ctx, conn)
+	if err != nil {
+		return errors.Wrap(err, "create migrate_backfills")
+	}
+
+	var lastID int64
+	var done bool
+	err = conn.QueryRow(ctx, `
+		insert into migrate_backfills (name) values ($1)
+		on conflict (name) do update set name = excluded.name
+		returning last_id, completed_at notnull
+	`, o.Name).Scan(&lastID, &done)
+	if err != nil {
+		return errors.Wrap(err, "read backfill state")
+	}
+	if done {
+		return nil
+	}
+
+	batchSize := o.BatchSize
+	if batchSize == 0 {
+		batchSize = 1000
+	}
+
+	s := time.Now()
+	var total int
+	for {
+		rows, err := conn.Query(ctx, o.backfillQuery(), lastID, batchSize)
+		if err != nil {
+			return errors.Wrap(err, "backfill batch")
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return errors.Wrap(err, "backfill batch")
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			if id > lastID {
+				lastID = id
+			}
+		}
+		total += len(ids)
+
+		_, err = conn.Exec(ctx, `update migrate_backfills set last_id = $2 where name = $1`, o.Name, lastID)
+		if err != nil {
+			return errors.Wrap(err, "save backfill progress")
+		}
+	}
+
+	// rows locked by other transactions are skipped, so verify before marking complete
+	err = conn.QueryRow(ctx, o.verifyQuery()).Scan(&done)
+	if err != nil {
+		return errors.Wrap(err, "verify backfill")
+	}
+	if !done {
+		// start over to pick up skipped rows
+		_, err = conn.Exec(ctx, `update migrate_backfills set last_id = 0 where name = $1`, o.Name)
+		if err != nil {
+			return errors.Wrap(err, "reset backfill progress")
+		}
+		return errors.Errorf("backfill '%s' incomplete: rows still pending after %d updated", o.Name, total)
+	}
+
+	_, err = conn.Exec(ctx, `update migrate_backfills set completed_at = now() where name = $1`, o.Name)
+	if err != nil {
+		return errors.Wrap(err, "mark backfill complete")
+	}
+	log.Debugf(ctx, "Backfilled %d rows for online migration '%s' in %s", total, o.Name, time.Since(s).Truncate(time.Millisecond))
+
+	return nil
+}
+
+// ensureBackfilled will backfill and verify any online migration contracted by m, it must be called
+// before m is applied.
+func ensureBackfilled(ctx context.Context, conn *pgx.Conn, m migration) error {
+	for _, o := range onlineMigrations {
+		if o.Contract != m.Name {
+			continue
+		}
+
+		err := backfill(ctx, conn, o)
+		if err != nil {
+			return errors.Wrapf(err, "contract '%s'", m.Name)
+		}
+	}
+
+	return nil
+}
+
+// Backfill will run all pending backfills for online migrations that have been expanded, but not
+// contracted. It is safe to run while the application is in use.
+func Backfill(ctx context.Context, url string) error {
+	if len(onlineMigrations) == 0 {
+		return nil
+	}
+
+	conn, err := getConn(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	for _, o := range onlineMigrations {
+		expanded, err := isApplied(ctx, conn, o.Expand)
+		if err != nil {
+			return err
+		}
+		contracted, err := isApplied(ctx, conn, o.Contract)
+		if err != nil {
+			return err
+		}
+		if !expanded || contracted {
+			continue
+		}
+
+		err = backfill(ctx, conn, o)
+		if err != nil {
+			return errors.Wrapf(err, "online migration '%s'", o.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnlineStmts(t *testing.T) {
+	orig := onlineMigrations
+	defer func() { onlineMigrations = orig }()
+
+	onlineMigrations = []OnlineMigration{{
+		Name:      "alerts-foo",
+		Expand:    "alerts-add-foo",
+		Contract:  "alerts-drop-bar",
+		Table:     "alerts",
+		DualWrite: "NEW.foo = NEW.bar;",
+		Set:       "foo = bar",
+		Pending:   "foo isnull",
+	}}
+
+	step := func(name string, up bool) migrationStep {
+		return migrationStep{isUp: up, migration: &migration{Name: name}}
+	}
+
+	stmts := onlineStmts(step("alerts-add-foo", true))
+	assert.Len(t, stmts, 3, "expand up creates function and trigger")
+	assert.Contains(t, stmts[0], "NEW.foo = NEW.bar;")
+	assert.Contains(t, stmts[2], `create trigger "zz_online_alerts-foo"`)
+
+	stmts = onlineStmts(step("alerts-drop-bar", true))
+	assert.Equal(t, []string{
+		`drop trigger if exists "zz_online_alerts-foo" on "alerts"`,
+		`drop function if exists "fn_online_alerts-foo"()`,
+	}, stmts, "contract up drops trigger")
+
+	assert.Len(t, onlineStmts(step("alerts-drop-bar", false)), 3, "contract down restores trigger")
+	assert.Len(t, onlineStmts(step("alerts-add-foo", false)), 3, "expand down drops trigger and state")
+	assert.Empty(t, onlineStmts(step("other", true)))
+}
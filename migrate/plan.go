@@ -0,0 +1,278 @@
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LockImpact is the estimated locking impact of applying a migration.
+type LockImpact int
+
+// Lock impacts, in increasing order of severity.
+const (
+	// LockNone means no locks are taken on existing tables that would block other queries.
+	LockNone LockImpact = iota
+
+	// LockBrief means an exclusive lock is taken on an existing table, but only long enough
+	// to update the catalog (i.e., it does not scale with table size).
+	LockBrief
+
+	// LockBlocking means reads or writes to an existing table are blocked for a duration
+	// proportional to its size (e.g., building an index or rewriting the table).
+	LockBlocking
+)
+
+func (l LockImpact) String() string {
+	switch l {
+	case LockNone:
+		return "none"
+	case LockBrief:
+		return "brief"
+	case LockBlocking:
+		return "blocking"
+	}
+	return fmt.Sprintf("LockImpact(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l LockImpact) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+// PlanStep describes a single pending UP migration.
+type PlanStep struct {
+	ID   string
+	Name string
+
+	// Statements is the number of SQL statements in the migration.
+	Statements int
+
+	// NoTransaction is true if the migration is not applied in a transaction
+	// (e.g., to create an index concurrently).
+	NoTransaction bool
+
+	// Lock is the highest LockImpact of any statement, and LockReasons describes each
+	// statement that takes a lock.
+	Lock        LockImpact
+	LockReasons []string `json:",omitempty"`
+
+	// SwitchoverSafe is true if the migration is backwards compatible: instances running
+	// the previous version can keep operating against the migrated schema, so it can be
+	// applied ahead of a rolling deploy or switchover.
+	//
+	// UnsafeReasons describes each statement that is not.
+	SwitchoverSafe bool
+	UnsafeReasons  []string `json:",omitempty"`
+}
+
+// Plan returns a PlanStep for each of the pending migration names (as returned by Status),
+// in order, up to and including targetName. If targetName is empty, all pending migrations
+// are included. If targetName has already been applied, the plan is empty.
+//
+// Lock impact and switchover safety are estimated from the SQL statements of each migration
+// and should be reviewed before applying to large databases.
+func Plan(pending []string, targetName string) ([]PlanStep, error) {
+	if targetName != "" {
+		if idx, _ := migrationID(targetName); idx == -1 {
+			return nil, errors.Errorf("unknown migration target name '%s'", targetName)
+		}
+	}
+
+	migrations, err := parseMigrations()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]migration, len(migrations))
+	for _, m := range migrations {
+		byName[m.Name] = m
+	}
+
+	var isPending bool
+	for _, name := range pending {
+		if name == targetName {
+			isPending = true
+		}
+	}
+	if targetName != "" && !isPending {
+		return nil, nil
+	}
+
+	var steps []PlanStep
+	for _, name := range pending {
+		m, ok := byName[name]
+		if !ok {
+			return nil, errors.Errorf("unknown migration name '%s'", name)
+		}
+
+		steps = append(steps, planStep(m))
+		if name == targetName {
+			break
+		}
+	}
+
+	return steps, nil
+}
+
+func planStep(m migration) PlanStep {
+	step := PlanStep{
+		ID:             m.ID,
+		Name:           m.Name,
+		Statements:     len(m.Up.statements),
+		NoTransaction:  m.Up.disableTx,
+		SwitchoverSafe: true,
+	}
+
+	// tables created by this migration are empty and unused, so locks on them don't matter
+	created := make(map[string]bool)
+	for _, stmt := range m.Up.statements {
+		s := normalizeStmt(stmt)
+		if sqlCreateTable.MatchString(s) {
+			created[submatch(sqlCreateTable, s)] = true
+		}
+	}
+
+	for _, stmt := range m.Up.statements {
+		a := analyzeStmt(stmt)
+		if created[a.table] {
+			continue
+		}
+		if a.lock > step.Lock {
+			step.Lock = a.lock
+		}
+		if a.lockReason != "" {
+			step.LockReasons = append(step.LockReasons, a.lockReason)
+		}
+		if a.unsafeReason != "" {
+			step.SwitchoverSafe = false
+			step.UnsafeReasons = append(step.UnsafeReasons, a.unsafeReason)
+		}
+	}
+
+	for _, o := range onlineMigrations {
+		if o.Contract == m.Name {
+			step.LockReasons = append(step.LockReasons, fmt.Sprintf("waits for the %s backfill of %s to complete", o.Name, o.Table))
+		}
+	}
+
+	return step
+}
+
+type stmtAnalysis struct {
+	table        string
+	lock         LockImpact
+	lockReason   string
+	unsafeReason string
+}
+
+var (
+	sqlComment     = regexp.MustCompile(`--[^\n]*`)
+	sqlCreateTable = regexp.MustCompile(`^create table (?:if not exists )?([\w."]+)`)
+	sqlSpace       = regexp.MustCompile(`\s+`)
+	sqlIndexTable  = regexp.MustCompile(` on (?:only )?([\w."]+)`)
+	sqlDMLTable    = regexp.MustCompile(`^(?:update|delete from) (?:only )?([\w."]+)`)
+	sqlAlterTable  = regexp.MustCompile(`^alter table (?:if exists )?(?:only )?([\w."]+)`)
+	sqlDropTable   = regexp.MustCompile(`^drop table (?:if exists )?([\w.", ]+?)(?: cascade| restrict)?;?$`)
+)
+
+// normalizeStmt will strip comments, lowercase, and collapse whitespace.
+func normalizeStmt(stmt string) string {
+	stmt = sqlComment.ReplaceAllString(stmt, "")
+	stmt = sqlSpace.ReplaceAllString(stmt, " ")
+	return strings.ToLower(strings.TrimSpace(stmt))
+}
+
+func submatch(re *regexp.Regexp, s string) string {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return "?"
+	}
+	return strings.Trim(m[1], `"`)
+}
+
+// analyzeStmt estimates the lock impact and backwards compatibility of a single statement.
+//
+// Only the leading command is considered; function bodies are not inspected.
+func analyzeStmt(stmt string) (a stmtAnalysis) {
+	s := normalizeStmt(stmt)
+	has := func(substr string) bool { return strings.Contains(s, substr) }
+
+	switch {
+	case strings.HasPrefix(s, "create index"), strings.HasPrefix(s, "create unique index"):
+		if has(" concurrently ") {
+			return a
+		}
+		tbl := submatch(sqlIndexTable, s)
+		a.table = tbl
+		a.lock = LockBlocking
+		a.lockReason = fmt.Sprintf("CREATE INDEX blocks writes to %s until built", tbl)
+
+	case strings.HasPrefix(s, "alter table"):
+		tbl := submatch(sqlAlterTable, s)
+		a.table = tbl
+		a.lock = LockBrief
+		a.lockReason = fmt.Sprintf("ALTER TABLE takes a brief exclusive lock on %s", tbl)
+		switch {
+		case has(" alter column ") && has(" type "):
+			a.lock = LockBlocking
+			a.lockReason = fmt.Sprintf("changing a column type rewrites %s", tbl)
+			a.unsafeReason = fmt.Sprintf("changes a column type on %s", tbl)
+		case has(" set not null"):
+			a.lock = LockBlocking
+			a.lockReason = fmt.Sprintf("SET NOT NULL scans %s", tbl)
+			a.unsafeReason = fmt.Sprintf("adds a NOT NULL constraint on %s", tbl)
+		case (has(" add constraint ") || has(" add foreign key") || has(" add check") || has(" add primary key") || has(" add unique")) && !has(" not valid"):
+			a.lock = LockBlocking
+			a.lockReason = fmt.Sprintf("adding a constraint scans %s to validate it", tbl)
+		}
+		switch {
+		case has(" drop column "):
+			a.unsafeReason = fmt.Sprintf("drops a column on %s", tbl)
+		case has(" rename "):
+			a.unsafeReason = fmt.Sprintf("renames a column or table (%s)", tbl)
+		case has(" add column ") && has(" not null") && !has(" default "):
+			a.unsafeReason = fmt.Sprintf("adds a NOT NULL column without a default to %s", tbl)
+		}
+
+	case strings.HasPrefix(s, "drop table"):
+		tbl := submatch(sqlDropTable, s)
+		a.lock = LockBrief
+		a.lockReason = fmt.Sprintf("DROP TABLE takes a brief exclusive lock on %s", tbl)
+		a.unsafeReason = fmt.Sprintf("drops %s", tbl)
+
+	case strings.HasPrefix(s, "drop index"):
+		if has(" concurrently ") {
+			return a
+		}
+		a.lock = LockBrief
+		a.lockReason = "DROP INDEX takes a brief exclusive lock on its table"
+
+	case strings.HasPrefix(s, "update "), strings.HasPrefix(s, "delete from "):
+		tbl := submatch(sqlDMLTable, s)
+		a.table = tbl
+		if has(" where ") {
+			a.lock = LockBrief
+			a.lockReason = fmt.Sprintf("locks matching rows of %s", tbl)
+			return a
+		}
+		a.lock = LockBlocking
+		a.lockReason = fmt.Sprintf("locks every row of %s", tbl)
+
+	case strings.HasPrefix(s, "lock "), strings.HasPrefix(s, "vacuum full"), strings.HasPrefix(s, "cluster"):
+		a.lock = LockBlocking
+		a.lockReason = fmt.Sprintf("explicit lock or rewrite (%s)", strings.SplitN(s, " ", 3)[0])
+
+	case strings.HasPrefix(s, "reindex"), strings.HasPrefix(s, "refresh materialized view"):
+		if has(" concurrently ") {
+			return a
+		}
+		a.lock = LockBlocking
+		a.lockReason = "REINDEX or REFRESH blocks access until complete"
+
+	case strings.HasPrefix(s, "do "), strings.HasPrefix(s, "do$"):
+		a.lock = LockBrief
+		a.lockReason = "DO block (not inspected; review manually)"
+	}
+
+	return a
+}
@@ -0,0 +1,73 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeStmt(t *testing.T) {
+	check := func(stmt string, lock LockImpact, safe bool) {
+		t.Helper()
+		a := analyzeStmt(stmt)
+		assert.Equal(t, lock, a.lock, "lock: %s", stmt)
+		assert.Equal(t, safe, a.unsafeReason == "", "safe: %s", stmt)
+	}
+
+	check("create table foo (id int)", LockNone, true)
+	check("CREATE INDEX CONCURRENTLY idx_foo ON alerts (foo)", LockNone, true)
+	check("create index idx_foo on alerts (foo)", LockBlocking, true)
+	check("ALTER TABLE alerts ADD COLUMN foo TEXT", LockBrief, true)
+	check("alter table alerts add column foo text not null default ''", LockBrief, true)
+	check("alter table alerts add column foo text not null", LockBrief, false)
+	check("alter table alerts drop column foo", LockBrief, false)
+	check("alter table alerts rename column foo to bar", LockBrief, false)
+	check("alter table alerts alter column foo type bigint", LockBlocking, false)
+	check("alter table alerts alter column foo set not null", LockBlocking, false)
+	check("alter table alerts add constraint foo_fk foreign key (foo) references users (id)", LockBlocking, true)
+	check("alter table alerts add constraint foo_fk foreign key (foo) references users (id) not valid", LockBrief, true)
+	check("drop table foo", LockBrief, false)
+	check("update alerts set foo = 1", LockBlocking, true)
+	check("update alerts set foo = 1 where id = 2", LockBrief, true)
+	check("-- comment\nALTER TYPE enum_foo ADD VALUE IF NOT EXISTS 'bar'", LockNone, true)
+	check("create or replace function fn() returns trigger as $$ begin update alerts set foo = 1; end; $$ language plpgsql", LockNone, true)
+
+	assert.Contains(t, analyzeStmt("create index idx_foo on public.alerts (foo)").lockReason, "public.alerts")
+}
+
+func TestPlan(t *testing.T) {
+	names := Names()
+
+	steps, err := Plan(names, "")
+	require.NoError(t, err)
+	require.Len(t, steps, len(names), "all pending")
+	assert.Equal(t, names[0], steps[0].Name)
+
+	steps, err = Plan(names[2:], names[4])
+	require.NoError(t, err)
+	require.Len(t, steps, 3, "up to target")
+	assert.Equal(t, names[4], steps[2].Name)
+
+	steps, err = Plan(names[5:], names[4])
+	require.NoError(t, err)
+	assert.Empty(t, steps, "target already applied")
+
+	_, err = Plan(names, "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestPlanStep_NewTable(t *testing.T) {
+	var m migration
+	m.Name = "foo"
+	m.Up.statements = []string{
+		"create table foo (id int)",
+		"create index idx_foo on foo (id)",
+		"create index idx_bar on bar (id)",
+	}
+
+	step := planStep(m)
+	assert.Equal(t, LockBlocking, step.Lock)
+	assert.Equal(t, []string{"CREATE INDEX blocks writes to bar until built"}, step.LockReasons, "index on new table is ignored")
+	assert.True(t, step.SwitchoverSafe)
+}
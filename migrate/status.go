@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SchemaStatus describes the migrations applied to a database.
+type SchemaStatus struct {
+	// Latest is the name of the most recently applied migration.
+	Latest          string
+	LatestAppliedAt time.Time
+
+	// Expected is the name of the latest migration known to this version of GoAlert.
+	Expected string
+
+	// Pending lists the names of known migrations that have not been applied.
+	Pending []string
+
+	// Unknown lists the IDs of applied migrations that are not known to this version of
+	// GoAlert (e.g., applied by a newer version).
+	Unknown []string
+}
+
+// Status returns the SchemaStatus of the database.
+//
+// A database that has never been migrated reports all known migrations as pending.
+func Status(ctx context.Context, db *sql.DB) (*SchemaStatus, error) {
+	applied := make(map[string]bool)
+	var s SchemaStatus
+	rows, err := db.QueryContext(ctx, `select id, applied_at from gorp_migrations order by applied_at, id`)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "42P01" { // undefined_table
+		return s.withKnown(applied), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var appliedAt time.Time
+		err = rows.Scan(&id, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		applied[id] = true
+		s.Latest = id
+		if len(id) > 15 {
+			s.Latest = migrationName(id)
+		}
+		s.LatestAppliedAt = appliedAt
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withKnown(applied), nil
+}
+
+// withKnown will fill in the expected, pending, and unknown migrations given the set of applied IDs.
+func (s SchemaStatus) withKnown(applied map[string]bool) *SchemaStatus {
+	ids := migrationIDs()
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+		s.Expected = migrationName(id)
+		if !applied[id] {
+			s.Pending = append(s.Pending, s.Expected)
+		}
+	}
+	for id := range applied {
+		if !known[id] {
+			s.Unknown = append(s.Unknown, id)
+		}
+	}
+	sort.Strings(s.Unknown)
+
+	return &s
+}
@@ -0,0 +1,33 @@
+package mqttsub
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+)
+
+// Config is used to configure the MQTT subscriber.
+type Config struct {
+	BackgroundContext func() context.Context
+
+	// Broker is the broker URL, e.g., tcp://mqtt.example.com:1883 or tls://mqtt.example.com:8883.
+	Broker string
+
+	// ClientID must be unique for each connection to the broker.
+	ClientID string
+	Username string
+	Password string
+
+	// TLS is used for tls:// (or ssl://, mqtts://) brokers. If nil, the default config is used.
+	TLS *tls.Config
+
+	// RulesFunc returns the MQTT rules for all integration keys.
+	RulesFunc func(ctx context.Context) ([]integrationkey.MQTTRule, error)
+
+	// AuthorizeFunc authorizes the integration key ID of a matching rule.
+	AuthorizeFunc func(ctx context.Context, id string) (context.Context, error)
+
+	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+}
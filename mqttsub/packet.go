@@ -0,0 +1,210 @@
+package mqttsub
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MQTT 3.1.1 control packet types.
+const (
+	typeConnect    = 1
+	typeConnAck    = 2
+	typePublish    = 3
+	typePubAck     = 4
+	typeSubscribe  = 8
+	typeSubAck     = 9
+	typePingReq    = 12
+	typePingResp   = 13
+	typeDisconnect = 14
+)
+
+// protocolLevel is the CONNECT protocol level for MQTT 3.1.1.
+const protocolLevel = 4
+
+// packet is a single MQTT control packet.
+type packet struct {
+	Type  byte
+	Flags byte
+	Body  []byte
+}
+
+// readPacket reads a single control packet, rejecting packets larger than maxSize.
+func readPacket(r *bufio.Reader, maxSize int) (*packet, error) {
+	h, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var n, shift int
+	for i := 0; ; i++ {
+		if i == 4 {
+			return nil, errors.New("malformed remaining length")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, noEOF(err)
+		}
+		n |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+	}
+	if n > maxSize {
+		return nil, fmt.Errorf("packet too large (%d bytes)", n)
+	}
+
+	p := &packet{Type: h >> 4, Flags: h & 0x0f, Body: make([]byte, n)}
+	_, err = io.ReadFull(r, p.Body)
+	if err != nil {
+		return nil, noEOF(err)
+	}
+
+	return p, nil
+}
+
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// encode returns the wire representation of the packet.
+func (p packet) encode() []byte {
+	b := []byte{p.Type<<4 | p.Flags}
+	n := len(p.Body)
+	for {
+		c := byte(n & 0x7f)
+		n >>= 7
+		if n > 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if n == 0 {
+			break
+		}
+	}
+
+	return append(b, p.Body...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < n {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+
+	return string(b[:n]), b[n:], nil
+}
+
+// connectPacket builds a CONNECT packet with a clean session.
+func connectPacket(clientID, username, password string, keepAlive uint16) packet {
+	var flags byte = 0x02 // clean session
+	if username != "" {
+		flags |= 0x80
+	}
+	if password != "" {
+		flags |= 0x40
+	}
+
+	var b []byte
+	b = appendString(b, "MQTT")
+	b = append(b, protocolLevel, flags)
+	b = binary.BigEndian.AppendUint16(b, keepAlive)
+	b = appendString(b, clientID)
+	if username != "" {
+		b = appendString(b, username)
+	}
+	if password != "" {
+		b = appendString(b, password)
+	}
+
+	return packet{Type: typeConnect, Body: b}
+}
+
+// connAckError returns an error for a non-successful CONNACK.
+func connAckError(p *packet) error {
+	if p.Type != typeConnAck || len(p.Body) != 2 {
+		return errors.New("expected CONNACK")
+	}
+
+	switch p.Body[1] {
+	case 0:
+		return nil
+	case 1:
+		return errors.New("connection refused: unacceptable protocol version")
+	case 2:
+		return errors.New("connection refused: identifier rejected")
+	case 3:
+		return errors.New("connection refused: server unavailable")
+	case 4:
+		return errors.New("connection refused: bad user name or password")
+	case 5:
+		return errors.New("connection refused: not authorized")
+	}
+
+	return fmt.Errorf("connection refused: code %d", p.Body[1])
+}
+
+// subscribePacket builds a SUBSCRIBE packet requesting QoS 1 for each filter.
+func subscribePacket(id uint16, filters []string) packet {
+	b := binary.BigEndian.AppendUint16(nil, id)
+	for _, f := range filters {
+		b = appendString(b, f)
+		b = append(b, 1)
+	}
+
+	return packet{Type: typeSubscribe, Flags: 0x02, Body: b}
+}
+
+// message is a received PUBLISH packet.
+type message struct {
+	Topic    string
+	QoS      byte
+	PacketID uint16
+	Payload  []byte
+}
+
+func parsePublish(p *packet) (*message, error) {
+	var m message
+	m.QoS = (p.Flags >> 1) & 0x03
+	if m.QoS > 1 {
+		// QoS 2 is never granted since subscriptions request QoS 1
+		return nil, fmt.Errorf("unsupported QoS %d", m.QoS)
+	}
+
+	var err error
+	var rest []byte
+	m.Topic, rest, err = readString(p.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read topic: %w", err)
+	}
+	if m.QoS > 0 {
+		if len(rest) < 2 {
+			return nil, fmt.Errorf("read packet ID: %w", io.ErrUnexpectedEOF)
+		}
+		m.PacketID = binary.BigEndian.Uint16(rest)
+		rest = rest[2:]
+	}
+	m.Payload = rest
+
+	return &m, nil
+}
+
+// pubAckPacket acknowledges a QoS 1 PUBLISH.
+func pubAckPacket(id uint16) packet {
+	return packet{Type: typePubAck, Body: binary.BigEndian.AppendUint16(nil, id)}
+}
@@ -0,0 +1,72 @@
+package mqttsub
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacket_RoundTrip(t *testing.T) {
+	for _, size := range []int{0, 127, 128, 16383, 16384, 200000} {
+		p := packet{Type: typePublish, Flags: 0x02, Body: bytes.Repeat([]byte{'x'}, size)}
+		r := bufio.NewReader(bytes.NewReader(p.encode()))
+
+		got, err := readPacket(r, 256*1024)
+		require.NoError(t, err, size)
+		assert.Equal(t, p.Type, got.Type)
+		assert.Equal(t, p.Flags, got.Flags)
+		assert.Len(t, got.Body, size)
+	}
+
+	p := packet{Type: typePublish, Body: make([]byte, 1000)}
+	_, err := readPacket(bufio.NewReader(bytes.NewReader(p.encode())), 999)
+	assert.Error(t, err, "too large")
+
+	_, err = readPacket(bufio.NewReader(bytes.NewReader(p.encode()[:500])), 1000)
+	assert.Error(t, err, "truncated")
+}
+
+func TestConnectPacket(t *testing.T) {
+	p := connectPacket("goalert", "user", "pass", 60)
+	assert.Equal(t, []byte{
+		0x10, 31,
+		0, 4, 'M', 'Q', 'T', 'T',
+		4,     // protocol level
+		0xc2,  // username, password, clean session
+		0, 60, // keep alive
+		0, 7, 'g', 'o', 'a', 'l', 'e', 'r', 't',
+		0, 4, 'u', 's', 'e', 'r',
+		0, 4, 'p', 'a', 's', 's',
+	}, p.encode())
+
+	assert.NoError(t, connAckError(&packet{Type: typeConnAck, Body: []byte{0, 0}}))
+	assert.ErrorContains(t, connAckError(&packet{Type: typeConnAck, Body: []byte{0, 4}}), "bad user name or password")
+}
+
+func TestParsePublish(t *testing.T) {
+	body := appendString(nil, "plant/line1/temp")
+	body = append(body, 0x12, 0x34)
+	body = append(body, "87.5"...)
+
+	m, err := parsePublish(&packet{Type: typePublish, Flags: 0x02, Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, "plant/line1/temp", m.Topic)
+	assert.Equal(t, byte(1), m.QoS)
+	assert.Equal(t, uint16(0x1234), m.PacketID)
+	assert.Equal(t, "87.5", string(m.Payload))
+
+	m, err = parsePublish(&packet{Type: typePublish, Body: append(appendString(nil, "a/b"), "on"...)})
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), m.QoS)
+	assert.Equal(t, "on", string(m.Payload))
+
+	_, err = parsePublish(&packet{Type: typePublish, Flags: 0x04, Body: body})
+	assert.Error(t, err, "QoS 2")
+
+	assert.Equal(t, []byte{0x40, 2, 0x12, 0x34}, pubAckPacket(0x1234).encode())
+	assert.True(t, strings.HasPrefix(string(subscribePacket(1, []string{"a/#"}).encode()), "\x82\x08\x00\x01\x00\x03a/#\x01"))
+}
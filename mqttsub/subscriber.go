@@ -0,0 +1,451 @@
+package mqttsub
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// keepAlive is the keep alive interval sent to the broker.
+	keepAlive = 60 * time.Second
+
+	// pingInterval is how often a PINGREQ is sent and rules are checked for changes.
+	pingInterval = keepAlive / 2
+
+	// ruleRefreshInterval is how often rules are re-read from the DB.
+	ruleRefreshInterval = 30 * time.Second
+
+	// maxPacketSize is the largest packet accepted from the broker.
+	maxPacketSize = 256 * 1024
+
+	// maxBackoff is the longest delay between reconnect attempts.
+	maxBackoff = time.Minute
+
+	// maxStates limits the number of topics tracked for threshold transitions.
+	maxStates = 10000
+)
+
+// Subscriber subscribes to topics used by MQTT rules and creates alerts from matching messages.
+type Subscriber struct {
+	cfg    Config
+	addr   string
+	useTLS bool
+
+	ctx    context.Context
+	cancel func()
+	done   chan struct{}
+
+	mx      sync.Mutex
+	rules   []integrationkey.MQTTRule
+	rulesAt time.Time
+
+	// state tracks whether each rule/topic was last above the threshold, and is only
+	// accessed by the read loop.
+	state map[string]bool
+}
+
+// NewSubscriber creates a new Subscriber.
+func NewSubscriber(cfg Config) (*Subscriber, error) {
+	if cfg.BackgroundContext == nil {
+		panic("mqttsub: BackgroundContext is required")
+	}
+	if cfg.RulesFunc == nil {
+		panic("mqttsub: RulesFunc is required")
+	}
+	if cfg.AuthorizeFunc == nil {
+		panic("mqttsub: AuthorizeFunc is required")
+	}
+	if cfg.CreateAlertFunc == nil {
+		panic("mqttsub: CreateAlertFunc is required")
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("mqttsub: client ID is required")
+	}
+
+	u, err := url.Parse(cfg.Broker)
+	if err != nil {
+		return nil, fmt.Errorf("mqttsub: parse broker URL: %w", err)
+	}
+	s := &Subscriber{
+		cfg:   cfg,
+		addr:  u.Host,
+		state: make(map[string]bool),
+		done:  make(chan struct{}),
+	}
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		if u.Port() == "" {
+			s.addr = net.JoinHostPort(u.Hostname(), "1883")
+		}
+	case "tls", "ssl", "mqtts":
+		s.useTLS = true
+		if u.Port() == "" {
+			s.addr = net.JoinHostPort(u.Hostname(), "8883")
+		}
+	default:
+		return nil, fmt.Errorf("mqttsub: unsupported broker URL scheme %q (use tcp:// or tls://)", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("mqttsub: broker URL must include a host")
+	}
+
+	s.ctx, s.cancel = context.WithCancel(log.WithField(cfg.BackgroundContext(), "MQTTBroker", s.addr))
+	return s, nil
+}
+
+// Run will connect to the broker and process messages until Shutdown is called,
+// reconnecting as needed.
+func (s *Subscriber) Run() error {
+	defer close(s.done)
+
+	var attempt int
+	for {
+		connected, err := s.session()
+		if s.ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			attempt = 0
+		}
+		log.Log(s.ctx, fmt.Errorf("mqttsub: %w", err))
+
+		attempt++
+		delay := maxBackoff
+		if attempt < 7 {
+			delay = min(time.Second<<attempt, maxBackoff)
+		}
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Shutdown disconnects from the broker and waits for the current message to be processed.
+func (s *Subscriber) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return nil
+	}
+}
+
+func (s *Subscriber) dial() (net.Conn, error) {
+	d := net.Dialer{Timeout: 10 * time.Second}
+	c, err := d.DialContext(s.ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	if !s.useTLS {
+		return c, nil
+	}
+
+	var cfg *tls.Config
+	if s.cfg.TLS != nil {
+		cfg = s.cfg.TLS.Clone()
+	} else {
+		cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName, _, _ = net.SplitHostPort(s.addr)
+	}
+
+	return tls.Client(c, cfg), nil
+}
+
+// conn wraps a broker connection, serializing writes.
+type conn struct {
+	net.Conn
+	wmx    sync.Mutex
+	nextID uint16
+}
+
+func (c *conn) write(p packet) error {
+	c.wmx.Lock()
+	defer c.wmx.Unlock()
+
+	_ = c.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := c.Write(p.encode())
+	return err
+}
+
+func (c *conn) subscribe(filters []string) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	c.wmx.Lock()
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID = 1
+	}
+	id := c.nextID
+	c.wmx.Unlock()
+
+	return c.write(subscribePacket(id, filters))
+}
+
+// session runs a single broker connection until it fails or the subscriber is shut down.
+func (s *Subscriber) session() (connected bool, err error) {
+	nc, err := s.dial()
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	c := &conn{Conn: nc}
+	defer c.Close()
+
+	stop := context.AfterFunc(s.ctx, func() {
+		_ = c.write(packet{Type: typeDisconnect})
+		_ = c.Close()
+	})
+	defer stop()
+
+	err = c.write(connectPacket(s.cfg.ClientID, s.cfg.Username, s.cfg.Password, uint16(keepAlive/time.Second)))
+	if err != nil {
+		return false, fmt.Errorf("send CONNECT: %w", err)
+	}
+
+	r := bufio.NewReader(c)
+	_ = c.SetReadDeadline(time.Now().Add(10 * time.Second))
+	p, err := readPacket(r, maxPacketSize)
+	if err != nil {
+		return false, fmt.Errorf("read CONNACK: %w", err)
+	}
+	err = connAckError(p)
+	if err != nil {
+		return false, err
+	}
+	log.Debugf(s.ctx, "mqttsub: connected")
+
+	subscribed := make(map[string]bool)
+	err = c.subscribe(newFilters(s.currentRules(), subscribed))
+	if err != nil {
+		return true, fmt.Errorf("subscribe: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	sessCtx, cancel := context.WithCancel(s.ctx)
+	defer wg.Wait()
+	defer cancel()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.maintain(sessCtx, c, subscribed)
+	}()
+
+	for {
+		_ = c.SetReadDeadline(time.Now().Add(keepAlive + pingInterval))
+		p, err := readPacket(r, maxPacketSize)
+		if err != nil {
+			return true, fmt.Errorf("read: %w", err)
+		}
+
+		switch p.Type {
+		case typePublish:
+			m, err := parsePublish(p)
+			if err != nil {
+				return true, fmt.Errorf("read PUBLISH: %w", err)
+			}
+			s.handleMessage(m)
+			if m.QoS == 1 {
+				err = c.write(pubAckPacket(m.PacketID))
+				if err != nil {
+					return true, fmt.Errorf("send PUBACK: %w", err)
+				}
+			}
+		case typeSubAck:
+			if len(p.Body) < 2 {
+				return true, errors.New("read SUBACK: invalid packet")
+			}
+			for _, code := range p.Body[2:] {
+				if code == 0x80 {
+					log.Log(s.ctx, errors.New("mqttsub: broker rejected a subscription"))
+				}
+			}
+		case typePingResp:
+		default:
+			return true, fmt.Errorf("unexpected packet type %d", p.Type)
+		}
+	}
+}
+
+// maintain sends keep alive pings and subscribes to new topic filters as rules change.
+func (s *Subscriber) maintain(ctx context.Context, c *conn, subscribed map[string]bool) {
+	t := time.NewTicker(pingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		err := c.write(packet{Type: typePingReq})
+		if err != nil {
+			// the read loop will notice the broken connection
+			return
+		}
+
+		err = c.subscribe(newFilters(s.currentRules(), subscribed))
+		if err != nil {
+			return
+		}
+	}
+}
+
+// newFilters returns the topic filters not yet subscribed to, marking them as subscribed.
+//
+// Filters from removed rules remain subscribed until the next reconnect; their messages
+// are ignored since no rule matches.
+func newFilters(rules []integrationkey.MQTTRule, subscribed map[string]bool) []string {
+	var result []string
+	for _, r := range rules {
+		if subscribed[r.TopicFilter] {
+			continue
+		}
+		subscribed[r.TopicFilter] = true
+		result = append(result, r.TopicFilter)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// currentRules returns the cached rules, refreshing them if stale.
+func (s *Subscriber) currentRules() []integrationkey.MQTTRule {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if time.Since(s.rulesAt) < ruleRefreshInterval {
+		return s.rules
+	}
+
+	rules, err := s.cfg.RulesFunc(s.ctx)
+	if err != nil {
+		// keep using the previous rules until the next refresh
+		log.Log(s.ctx, fmt.Errorf("mqttsub: refresh rules: %w", err))
+		return s.rules
+	}
+	s.rules = rules
+	s.rulesAt = time.Now()
+
+	return s.rules
+}
+
+func (s *Subscriber) setState(key string, crossed bool) {
+	if _, ok := s.state[key]; !ok && len(s.state) >= maxStates {
+		// start over rather than grow without bound; at worst an extra close is sent
+		s.state = make(map[string]bool)
+	}
+	s.state[key] = crossed
+}
+
+func (s *Subscriber) handleMessage(m *message) {
+	ctx := log.WithField(s.ctx, "MQTTTopic", m.Topic)
+
+	for _, r := range s.currentRules() {
+		if !r.MatchesTopic(m.Topic) {
+			continue
+		}
+
+		var value float64
+		if r.Operator != integrationkey.MQTTOperatorAny {
+			var err error
+			value, err = r.Value(m.Payload)
+			if err != nil {
+				log.Debugf(ctx, "mqttsub: ignoring message for rule '%s %s': %v", r.TopicFilter, r, err)
+				continue
+			}
+		}
+
+		crossed := r.Crosses(value)
+		stateKey := strings.Join([]string{r.IntegrationKeyID, r.TopicFilter, r.ValueExpr, r.String(), m.Topic}, "\x00")
+		prev, known := s.state[stateKey]
+		s.setState(stateKey, crossed)
+
+		switch {
+		case crossed && r.Operator != integrationkey.MQTTOperatorAny && known && prev:
+			// already alerting
+			continue
+		case !crossed && (!r.AutoClose || (known && !prev)):
+			continue
+		}
+
+		err := s.sendAlert(ctx, r, newAlert(r, m, value, crossed))
+		if err != nil {
+			delete(s.state, stateKey)
+			log.Log(ctx, err)
+		}
+	}
+}
+
+func (s *Subscriber) sendAlert(ctx context.Context, r integrationkey.MQTTRule, a *alert.Alert) error {
+	ctx = log.WithField(ctx, "IntegrationKeyID", r.IntegrationKeyID)
+	ctx, err := s.cfg.AuthorizeFunc(ctx, r.IntegrationKeyID)
+	if err != nil {
+		return fmt.Errorf("mqttsub: authorize key %s: %w", r.IntegrationKeyID, err)
+	}
+
+	a.ServiceID = permission.ServiceID(ctx)
+	err = retry.DoTemporaryError(func(_ int) error {
+		return s.cfg.CreateAlertFunc(ctx, a)
+	},
+		retry.Log(ctx),
+		retry.Limit(12),
+		retry.FibBackoff(time.Second),
+	)
+	if err != nil {
+		return fmt.Errorf("mqttsub: create alert: %w", err)
+	}
+
+	return nil
+}
+
+func newAlert(r integrationkey.MQTTRule, m *message, value float64, crossed bool) *alert.Alert {
+	payload := strings.TrimSpace(string(m.Payload))
+
+	var summary, dedup string
+	if r.Operator == integrationkey.MQTTOperatorAny {
+		summary = m.Topic + ": " + payload
+		dedup = m.Topic
+	} else {
+		summary = fmt.Sprintf("%s: %s %s", m.Topic, strconv.FormatFloat(value, 'g', -1, 64), r)
+		dedup = m.Topic + " " + r.String()
+	}
+
+	details := fmt.Sprintf("Topic: %s\nRule: %s (%s)\n\n%s\n", m.Topic, r.TopicFilter, r, payload)
+
+	status := alert.StatusTriggered
+	if !crossed {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:  status,
+		Source:  alert.SourceMQTT,
+		Dedup:   alert.NewUserDedup(dedup),
+	}
+}
@@ -0,0 +1,184 @@
+package nagios
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// notification contains the values of the Nagios/Icinga notification macros.
+type notification struct {
+	Type        string `json:"type"`
+	Host        string `json:"host"`
+	HostAddress string `json:"host_address"`
+	Service     string `json:"service"`
+	State       string `json:"state"`
+	Output      string `json:"output"`
+	LongOutput  string `json:"long_output"`
+	URL         string `json:"url"`
+}
+
+func parseForm(r *http.Request) notification {
+	return notification{
+		Type:        r.FormValue("type"),
+		Host:        r.FormValue("host"),
+		HostAddress: r.FormValue("host_address"),
+		Service:     r.FormValue("service"),
+		State:       r.FormValue("state"),
+		Output:      r.FormValue("output"),
+		LongOutput:  r.FormValue("long_output"),
+		URL:         r.FormValue("url"),
+	}
+}
+
+// status maps the notification type to an alert status. Types that should
+// not change the alert (e.g., flapping or downtime) return an empty status.
+func (n notification) status() (alert.Status, error) {
+	switch strings.ToUpper(n.Type) {
+	case "PROBLEM":
+		return alert.StatusTriggered, nil
+	case "RECOVERY":
+		return alert.StatusClosed, nil
+	case "ACKNOWLEDGEMENT":
+		return alert.StatusActive, nil
+	case "FLAPPINGSTART", "FLAPPINGSTOP", "FLAPPINGDISABLED", "DOWNTIMESTART", "DOWNTIMEEND", "DOWNTIMECANCELLED", "DOWNTIMEREMOVED", "CUSTOM":
+		return "", nil
+	}
+
+	return "", errors.Errorf("nagios: unknown notification type: %s", n.Type)
+}
+
+func (n notification) dedup() string {
+	if n.Service == "" {
+		return n.Host
+	}
+	return n.Host + "/" + n.Service
+}
+
+func (n notification) summary() string {
+	if n.State == "" {
+		return n.dedup()
+	}
+	return n.dedup() + " is " + n.State
+}
+
+func (n notification) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("URL", n.URL) == nil {
+		fmt.Fprintf(&s, "[View Status](%s)\n\n", n.URL)
+	}
+	fmt.Fprintf(&s, "Host: %s", n.Host)
+	if n.HostAddress != "" {
+		fmt.Fprintf(&s, " (%s)", n.HostAddress)
+	}
+	s.WriteString("\n\n")
+	if n.Service != "" {
+		fmt.Fprintf(&s, "Service: %s\n\n", n.Service)
+	}
+	if n.State != "" {
+		fmt.Fprintf(&s, "State: %s\n\n", n.State)
+	}
+	if n.Output != "" {
+		fmt.Fprintf(&s, "```\n%s\n```\n\n", strings.TrimSpace(n.Output+"\n"+strings.ReplaceAll(n.LongOutput, `\n`, "\n")))
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// alertFromNotification will return the alert for a notification, or nil if the notification
+// type should not change the alert.
+func alertFromNotification(serviceID string, n notification) (*alert.Alert, error) {
+	if n.Host == "" {
+		return nil, errors.New("nagios: missing host")
+	}
+
+	status, err := n.status()
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		return nil, nil
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(n.summary(), alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(n.details(), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceNagios,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(n.dedup()),
+	}, nil
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// NagiosToEventsAPI handles notifications from a Nagios or Icinga notification command. Values
+// may be sent as form fields or as a JSON object.
+func NagiosToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var n notification
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/json" {
+			err = json.NewDecoder(r.Body).Decode(&n)
+			if clientError(w, http.StatusBadRequest, err) {
+				log.Logf(ctx, "bad request from nagios: %v", err)
+				return
+			}
+		} else {
+			n = parseForm(r)
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"NotificationType": n.Type,
+			"Host":             n.Host,
+			"Service":          n.Service,
+		})
+		msg, err := alertFromNotification(serviceID, n)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from nagios: %v", err)
+			return
+		}
+		if msg == nil {
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for nagios")) {
+			return
+		}
+	}
+}
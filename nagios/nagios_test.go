@@ -0,0 +1,69 @@
+package nagios
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestAlertFromNotification(t *testing.T) {
+	a, err := alertFromNotification("svc", notification{Type: "PROBLEM", Host: "web-01", Service: "HTTP", State: "CRITICAL", Output: "connection refused"})
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "web-01/HTTP is CRITICAL", a.Summary)
+	assert.Equal(t, alert.NewUserDedup("web-01/HTTP"), a.Dedup)
+
+	a, err = alertFromNotification("svc", notification{Type: "RECOVERY", Host: "web-01", Service: "HTTP", State: "OK"})
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("web-01/HTTP"), a.Dedup)
+
+	a, err = alertFromNotification("svc", notification{Type: "acknowledgement", Host: "web-01", Service: "HTTP"})
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusActive, a.Status)
+
+	// host notifications dedup on the host alone
+	a, err = alertFromNotification("svc", notification{Type: "PROBLEM", Host: "web-01", State: "DOWN"})
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, "web-01 is DOWN", a.Summary)
+	assert.Equal(t, alert.NewUserDedup("web-01"), a.Dedup)
+
+	a, err = alertFromNotification("svc", notification{Type: "DOWNTIMESTART", Host: "web-01"})
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	_, err = alertFromNotification("svc", notification{Type: "PROBLEM"})
+	assert.Error(t, err, "missing host")
+	_, err = alertFromNotification("svc", notification{Type: "BOGUS", Host: "web-01"})
+	assert.Error(t, err, "unknown type")
+}
+
+func TestNagiosToEventsAPI_BadRequest(t *testing.T) {
+	h := NagiosToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, contentType, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/nagios/incoming", strings.NewReader(body)).WithContext(ctx)
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", "application/json", `{`)
+	check("missing host", "application/json", `{"type": "PROBLEM"}`)
+	check("unknown type", "application/x-www-form-urlencoded", url.Values{"type": {"BOGUS"}, "host": {"web-01"}}.Encode())
+}
@@ -0,0 +1,191 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+/* Expected payload (New Relic's default webhook workflow template)
+
+```
+{
+  "id": {{ json issueId }},
+  "issueUrl": {{ json issuePageUrl }},
+  "title": {{ json annotations.title.[0] }},
+  "priority": {{ json priority }},
+  "impactedEntities": {{ json entitiesData.names }},
+  "totalIncidents": {{ json totalIncidents }},
+  "state": {{ json state }},
+  "trigger": {{ json triggerEvent }},
+  "alertPolicyNames": {{ json accumulations.policyName }},
+  "alertConditionNames": {{ json accumulations.conditionName }},
+  "workflowName": {{ json workflowName }}
+}
+```
+*/
+
+// Priorities are the New Relic issue priorities, in increasing order.
+var Priorities = []string{"LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+func priorityIndex(p string) int {
+	for i, name := range Priorities {
+		if strings.EqualFold(name, p) {
+			return i
+		}
+	}
+	return -1
+}
+
+type post struct {
+	ID                  string
+	IssueURL            string
+	Title               string
+	Priority            string
+	ImpactedEntities    []string
+	TotalIncidents      int
+	State               string
+	Trigger             string
+	AlertPolicyNames    []string
+	AlertConditionNames []string
+	WorkflowName        string
+}
+
+func (p post) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("IssueURL", p.IssueURL) == nil {
+		fmt.Fprintf(&s, "[View in New Relic](%s)\n\n", p.IssueURL)
+	}
+	if p.Priority != "" {
+		fmt.Fprintf(&s, "Priority: %s\n\n", p.Priority)
+	}
+	if len(p.ImpactedEntities) > 0 {
+		fmt.Fprintf(&s, "Impacted entities: %s\n\n", strings.Join(p.ImpactedEntities, ", "))
+	}
+	if len(p.AlertPolicyNames) > 0 {
+		fmt.Fprintf(&s, "Policies: %s\n\n", strings.Join(p.AlertPolicyNames, ", "))
+	}
+	if len(p.AlertConditionNames) > 0 {
+		fmt.Fprintf(&s, "Conditions: %s\n\n", strings.Join(p.AlertConditionNames, ", "))
+	}
+	if p.TotalIncidents > 0 {
+		fmt.Fprintf(&s, "Incidents: %d\n\n", p.TotalIncidents)
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// alertFromPost will return the alert for a New Relic issue, or nil if the update should be
+// ignored (e.g., acknowledgement, or an activated issue with a priority below minPri).
+func alertFromPost(serviceID string, p post, minPri int) (*alert.Alert, error) {
+	if p.ID == "" {
+		return nil, errors.New("new relic: missing issue id")
+	}
+
+	var status alert.Status
+	switch p.State {
+	case "CREATED", "ACTIVATED":
+		status = alert.StatusTriggered
+		if priorityIndex(p.Priority) < minPri {
+			return nil, nil
+		}
+	case "CLOSED":
+		status = alert.StatusClosed
+	case "ACKNOWLEDGED":
+		// acknowledgement in New Relic does not change the alert
+		return nil, nil
+	default:
+		return nil, errors.Errorf("new relic: missing or invalid state: %s", p.State)
+	}
+
+	summary := p.Title
+	if p.Priority != "" {
+		summary = "[" + p.Priority + "] " + summary
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(p.details(), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceNewRelic,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup("newrelic-issue:" + p.ID),
+	}, nil
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// NewRelicToEventsAPI handles New Relic workflow webhooks.
+//
+// The optional `minPriority` query parameter (e.g., `HIGH`) causes activated issues
+// of lower priority to be ignored.
+func NewRelicToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		minPri := 0
+		if s := r.FormValue("minPriority"); s != "" {
+			minPri = priorityIndex(s)
+			if minPri == -1 {
+				http.Error(w, "invalid minPriority", http.StatusBadRequest)
+				return
+			}
+		}
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from new relic: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"IssueID": p.ID,
+			"State":   p.State,
+		})
+		msg, err := alertFromPost(serviceID, p, minPri)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from new relic: %v", err)
+			return
+		}
+		if msg == nil {
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for new relic")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package newrelic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestAlertFromPost(t *testing.T) {
+	a, err := alertFromPost("svc", post{ID: "abc", Title: "High error rate", Priority: "CRITICAL", State: "ACTIVATED", ImpactedEntities: []string{"api"}}, 0)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "[CRITICAL] High error rate", a.Summary)
+	assert.Equal(t, alert.NewUserDedup("newrelic-issue:abc"), a.Dedup)
+	assert.Contains(t, a.Details, "Impacted entities: api")
+
+	a, err = alertFromPost("svc", post{ID: "abc", Priority: "LOW", State: "CREATED"}, 0)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+
+	a, err = alertFromPost("svc", post{ID: "abc", Priority: "CRITICAL", State: "CLOSED"}, 0)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("newrelic-issue:abc"), a.Dedup)
+
+	// unknown priorities are ignored
+	a, err = alertFromPost("svc", post{ID: "abc", State: "ACTIVATED"}, 0)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	a, err = alertFromPost("svc", post{ID: "abc", State: "ACKNOWLEDGED"}, 0)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	// below minimum priority; closing is never filtered
+	a, err = alertFromPost("svc", post{ID: "abc", Priority: "LOW", State: "ACTIVATED"}, priorityIndex("HIGH"))
+	require.NoError(t, err)
+	assert.Nil(t, a)
+	a, err = alertFromPost("svc", post{ID: "abc", Priority: "LOW", State: "CLOSED"}, priorityIndex("HIGH"))
+	require.NoError(t, err)
+	assert.NotNil(t, a)
+
+	_, err = alertFromPost("svc", post{State: "ACTIVATED"}, 0)
+	assert.Error(t, err, "missing id")
+	_, err = alertFromPost("svc", post{ID: "abc", State: "UNKNOWN"}, 0)
+	assert.Error(t, err, "invalid state")
+}
+
+func TestNewRelicToEventsAPI_BadRequest(t *testing.T) {
+	h := NewRelicToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, query, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/newrelic/incoming"+query, strings.NewReader(body)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", "", `{`)
+	check("missing id", "", `{"state": "ACTIVATED"}`)
+	check("invalid state", "", `{"id": "abc", "state": "bogus"}`)
+	check("invalid minPriority", "?minPriority=bogus", `{"id": "abc", "state": "ACTIVATED"}`)
+}
@@ -0,0 +1,210 @@
+package notice
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SystemNotice is an admin-authored notice displayed to all users (or only admins), such as a
+// maintenance banner or provider-degradation warning.
+type SystemNotice struct {
+	ID string
+	Notice
+
+	// AdminOnly indicates the notice should only be displayed to admins.
+	AdminOnly bool
+
+	CreatedAt time.Time
+
+	// ExpiresAt is the time the notice will no longer be displayed, if set.
+	ExpiresAt time.Time
+}
+
+func (t Type) dbValue() string {
+	switch t {
+	case TypeError:
+		return "ERROR"
+	case TypeInfo:
+		return "INFO"
+	}
+	return "WARNING"
+}
+
+func parseDBType(s string) Type {
+	switch s {
+	case "ERROR":
+		return TypeError
+	case "INFO":
+		return TypeInfo
+	}
+	return TypeWarning
+}
+
+// Normalize will validate and return a normalized SystemNotice.
+func (n SystemNotice) Normalize() (*SystemNotice, error) {
+	if n.ID == "" {
+		n.ID = uuid.NewString()
+	}
+
+	err := validate.Many(
+		validate.UUID("ID", n.ID),
+		validate.RequiredText("Message", n.Message, 1, 255),
+		validate.Text("Details", n.Details, 0, 2000),
+	)
+	if n.Type < TypeWarning || n.Type > TypeInfo {
+		err = validate.Many(err, validation.NewFieldError("Type", "unknown type"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+const systemNoticeColumns = `id, type, message, details, admin_only, created_at, expires_at`
+
+func scanSystemNotice(scan func(...interface{}) error) (*SystemNotice, error) {
+	var n SystemNotice
+	var typ string
+	var expires sql.NullTime
+	err := scan(&n.ID, &typ, &n.Message, &n.Details, &n.AdminOnly, &n.CreatedAt, &expires)
+	if err != nil {
+		return nil, err
+	}
+	n.Type = parseDBType(typ)
+	n.ExpiresAt = expires.Time
+
+	return &n, nil
+}
+
+func nullTime(t time.Time) sql.NullTime { return sql.NullTime{Time: t, Valid: !t.IsZero()} }
+
+// CreateSystemNotice will create a new SystemNotice, admin only.
+func (s *Store) CreateSystemNotice(ctx context.Context, n SystemNotice) (*SystemNotice, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	norm, err := n.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if !norm.ExpiresAt.IsZero() && norm.ExpiresAt.Before(time.Now()) {
+		return nil, validation.NewFieldError("ExpiresAt", "must be in the future")
+	}
+
+	row := s.db.QueryRowContext(ctx, `
+		insert into system_notices (id, type, message, details, admin_only, expires_at)
+		values ($1, $2, $3, $4, $5, $6)
+		returning `+systemNoticeColumns,
+		norm.ID, norm.Type.dbValue(), norm.Message, norm.Details, norm.AdminOnly, nullTime(norm.ExpiresAt),
+	)
+
+	return scanSystemNotice(row.Scan)
+}
+
+// UpdateSystemNotice will replace the content of an existing SystemNotice, admin only.
+func (s *Store) UpdateSystemNotice(ctx context.Context, n SystemNotice) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+	norm, err := n.Normalize()
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		update system_notices
+		set type = $2, message = $3, details = $4, admin_only = $5, expires_at = $6
+		where id = $1
+	`, norm.ID, norm.Type.dbValue(), norm.Message, norm.Details, norm.AdminOnly, nullTime(norm.ExpiresAt))
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return validation.NewFieldError("ID", "not found")
+	}
+
+	return nil
+}
+
+// DeleteSystemNotices will delete the SystemNotices with the given IDs, admin only.
+func (s *Store) DeleteSystemNotices(ctx context.Context, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.ManyUUID("ID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `delete from system_notices where id = any($1)`, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// SystemNotices returns all SystemNotices (including expired), newest first, admin only.
+func (s *Store) SystemNotices(ctx context.Context) ([]SystemNotice, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.querySystemNotices(ctx, `select `+systemNoticeColumns+` from system_notices order by created_at desc`)
+}
+
+// ActiveSystemNotices returns the unexpired SystemNotices visible to the current user.
+func (s *Store) ActiveSystemNotices(ctx context.Context) ([]SystemNotice, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.querySystemNotices(ctx, `
+		select `+systemNoticeColumns+`
+		from system_notices
+		where
+			(expires_at isnull or expires_at > now()) and
+			(not admin_only or $1)
+		order by created_at desc
+	`, permission.Admin(ctx))
+}
+
+func (s *Store) querySystemNotices(ctx context.Context, query string, args ...interface{}) ([]SystemNotice, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query system notices")
+	}
+	defer rows.Close()
+
+	var result []SystemNotice
+	for rows.Next() {
+		n, err := scanSystemNotice(rows.Scan)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan system notice")
+		}
+		result = append(result, *n)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,128 @@
+package notice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user/delegation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// cmFailureThreshold is the number of consecutive failed messages to a contact method before a warning is shown.
+const cmFailureThreshold = 3
+
+// noticeTimeFormat is used for times in notice details.
+const noticeTimeFormat = "2006-01-02 15:04 MST"
+
+// FindAllUserNotices returns any relevant notices for the given user. Currently returns a warning
+// for each enabled contact method with repeated failed messages, and info about delegations.
+func (s *Store) FindAllUserNotices(ctx context.Context, userID string) ([]Notice, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		select cm.name, cm.type, count(*)
+		from user_contact_methods cm
+		join outgoing_messages om on om.contact_method_id = cm.id
+		where
+			cm.user_id = $1 and
+			not cm.disabled and
+			om.last_status = 'failed' and
+			om.created_at > coalesce((
+				select max(ok.created_at)
+				from outgoing_messages ok
+				where ok.contact_method_id = cm.id and ok.last_status in ('sent', 'delivered')
+			), '-infinity')
+		group by cm.id, cm.name, cm.type
+		having count(*) >= $2
+		order by cm.name
+	`, userID, cmFailureThreshold)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notices []Notice
+	for rows.Next() {
+		var name, typ string
+		var count int
+		err = rows.Scan(&name, &typ, &count)
+		if err != nil {
+			return nil, err
+		}
+		notices = append(notices, Notice{
+			Type:    TypeWarning,
+			Message: fmt.Sprintf("Contact method '%s' (%s) is failing", name, typ),
+			Details: fmt.Sprintf("The last %d messages to this contact method failed. Verify the contact method is correct, or add another contact method to your notification rules.", count),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	delegations, err := s.userDelegationNotices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(notices, delegations...), nil
+}
+
+// userDelegationNotices returns a notice for each current or upcoming delegation from or to the user,
+// so that both the user and the delegate are aware that notifications are being forwarded.
+func (s *Store) userDelegationNotices(ctx context.Context, userID string) ([]Notice, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		select d.start_time, d.end_time, d.user_id = $1, other.name
+		from user_delegations d
+		join users other on other.id = case when d.user_id = $1 then d.delegate_user_id else d.user_id end
+		where
+			(d.user_id = $1 or d.delegate_user_id = $1) and
+			d.end_time > now()
+		order by d.start_time
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var notices []Notice
+	for rows.Next() {
+		var d delegation.Delegation
+		var isFrom bool
+		var name string
+		err = rows.Scan(&d.Start, &d.End, &isFrom, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		var msg string
+		switch {
+		case isFrom && d.Active(now):
+			msg = "Alert notifications are being forwarded to " + name
+		case isFrom:
+			msg = "Alert notifications will be forwarded to " + name
+		case d.Active(now):
+			msg = "Receiving alert notifications for " + name
+		default:
+			msg = "Will receive alert notifications for " + name
+		}
+		notices = append(notices, Notice{
+			Type:    TypeInfo,
+			Message: msg,
+			Details: fmt.Sprintf("From %s until %s.", d.Start.UTC().Format(noticeTimeFormat), d.End.UTC().Format(noticeTimeFormat)),
+		})
+	}
+
+	return notices, rows.Err()
+}
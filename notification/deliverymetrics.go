@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"errors"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// Delivery outcomes used for metrics.
+const (
+	outcomeSent      = "sent"
+	outcomeDelivered = "delivered"
+	outcomeFailed    = "failed"
+	outcomeOptedOut  = "opted_out"
+)
+
+type providerCodeErr interface {
+	ProviderErrorCode() string
+}
+
+type optOutErr interface {
+	OptedOut() bool
+}
+
+// destCountry returns the region code (e.g., "US") for SMS and voice destinations, or an empty string.
+func destCountry(t DestType, value string) string {
+	t = t.SenderType()
+	if t != DestTypeSMS && t != DestTypeVoice || value == "" {
+		return ""
+	}
+
+	num, err := phonenumbers.Parse(value, "")
+	if err != nil {
+		return ""
+	}
+
+	return phonenumbers.GetRegionCodeForNumber(num)
+}
+
+// errOutcome returns the outcome and provider error code for a failed send.
+func errOutcome(err error) (outcome, code string) {
+	outcome = outcomeFailed
+	var oErr optOutErr
+	if errors.As(err, &oErr) && oErr.OptedOut() {
+		outcome = outcomeOptedOut
+	}
+
+	var pErr providerCodeErr
+	if errors.As(err, &pErr) {
+		code = pErr.ProviderErrorCode()
+	}
+
+	return outcome, code
+}
+
+// statusOutcome returns the outcome for a status update, or an empty string if it is not a final outcome.
+//
+// Intermediate states (e.g., sending or sent) are not counted, since every accepted message is counted
+// as sent by the Manager.
+func statusOutcome(s Status) string {
+	switch {
+	case s.OptedOut:
+		return outcomeOptedOut
+	case s.State == StateDelivered:
+		return outcomeDelivered
+	case s.State == StateFailedPerm, s.State == StateFailedTemp:
+		return outcomeFailed
+	}
+
+	return ""
+}
@@ -13,6 +13,9 @@ type Dest struct {
 	ID    string
 	Type  DestType
 	Value string
+
+	// Locale is the preferred locale of the recipient for message text, if known (see package i18n).
+	Locale string
 }
 
 // DestFromPair will return a Dest for a notification channel/contact method pair.
@@ -45,6 +48,11 @@ const (
 	DestTypeUserWebhook
 	DestTypeChanWebhook
 	DestTypeSlackUG
+
+	// Team contact methods, delivered by the same providers as their user counterparts.
+	DestTypeChanSMS
+	DestTypeChanVoice
+	DestTypeChanEmail
 )
 
 func (d Dest) String() string { return fmt.Sprintf("%s(%s)", d.Type.String(), d.ID) }
@@ -83,6 +91,12 @@ func (t ScannableDestType) DestType() DestType {
 		return DestTypeChanWebhook
 	case notificationchannel.TypeSlackUG:
 		return DestTypeSlackUG
+	case notificationchannel.TypeSMS:
+		return DestTypeChanSMS
+	case notificationchannel.TypeVoice:
+		return DestTypeChanVoice
+	case notificationchannel.TypeEmail:
+		return DestTypeChanEmail
 	}
 
 	return DestTypeUnknown
@@ -97,11 +111,32 @@ func (t DestType) NCType() notificationchannel.Type {
 		return notificationchannel.TypeWebhook
 	case DestTypeSlackUG:
 		return notificationchannel.TypeSlackUG
+	case DestTypeChanSMS:
+		return notificationchannel.TypeSMS
+	case DestTypeChanVoice:
+		return notificationchannel.TypeVoice
+	case DestTypeChanEmail:
+		return notificationchannel.TypeEmail
 	}
 
 	return notificationchannel.TypeUnknown
 }
 
+// SenderType returns the DestType of the senders that deliver messages of type t. Team contact
+// methods are delivered by the same senders as their user contact method counterparts.
+func (t DestType) SenderType() DestType {
+	switch t {
+	case DestTypeChanSMS:
+		return DestTypeSMS
+	case DestTypeChanVoice:
+		return DestTypeVoice
+	case DestTypeChanEmail:
+		return DestTypeUserEmail
+	}
+
+	return t
+}
+
 // CMType returns the contactmethod.Type associated with the DestType.
 func (t DestType) CMType() contactmethod.Type {
 	switch t {
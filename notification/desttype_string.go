@@ -17,11 +17,14 @@ func _() {
 	_ = x[DestTypeUserWebhook-6]
 	_ = x[DestTypeChanWebhook-7]
 	_ = x[DestTypeSlackUG-8]
+	_ = x[DestTypeChanSMS-9]
+	_ = x[DestTypeChanVoice-10]
+	_ = x[DestTypeChanEmail-11]
 }
 
-const _DestType_name = "DestTypeUnknownDestTypeVoiceDestTypeSMSDestTypeSlackChannelDestTypeSlackDMDestTypeUserEmailDestTypeUserWebhookDestTypeChanWebhookDestTypeSlackUG"
+const _DestType_name = "DestTypeUnknownDestTypeVoiceDestTypeSMSDestTypeSlackChannelDestTypeSlackDMDestTypeUserEmailDestTypeUserWebhookDestTypeChanWebhookDestTypeSlackUGDestTypeChanSMSDestTypeChanVoiceDestTypeChanEmail"
 
-var _DestType_index = [...]uint8{0, 15, 28, 39, 59, 74, 91, 110, 129, 144}
+var _DestType_index = [...]uint8{0, 15, 28, 39, 59, 74, 91, 110, 129, 144, 159, 176, 193}
 
 func (i DestType) String() string {
 	if i < 0 || i >= DestType(len(_DestType_index)-1) {
@@ -15,6 +15,7 @@ import (
 	"github.com/matcornic/hermes/v2"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/i18n"
 	"gopkg.in/gomail.v2"
 )
 
@@ -26,6 +27,34 @@ func NewSender(ctx context.Context) *Sender {
 
 var _ notification.Sender = &Sender{}
 
+// serverAddr returns the host and address (with the default port, if unset) of the SMTP server.
+func serverAddr(cfg config.Config) (host, addr string) {
+	host, port, _ := net.SplitHostPort(cfg.SMTP.Address)
+	if host == "" {
+		host = cfg.SMTP.Address
+	}
+	if port == "" && cfg.SMTP.DisableTLS {
+		port = "25"
+	} else if port == "" {
+		port = "465"
+	}
+
+	return host, net.JoinHostPort(host, port)
+}
+
+// CheckServer will verify that the configured SMTP server is accepting connections.
+func (s *Sender) CheckServer(ctx context.Context) error {
+	_, addr := serverAddr(config.FromContext(ctx))
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
 // Send will send an for the provided message type.
 func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
 	cfg := config.FromContext(ctx)
@@ -49,52 +78,55 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			Logo: cfg.CallbackURL("/static/goalert-alt-logo.png"),
 		},
 	}
+	p := i18n.NewPrinter(msg.Destination().Locale)
 	var e hermes.Email
+	e.Body.Greeting = p.Text("Hi")
+	e.Body.Signature = p.Text("Yours truly")
 	var subject string
 	switch m := msg.(type) {
 	case notification.Test:
-		subject = "Test Message"
-		e.Body.Title = "Test Message"
-		e.Body.Intros = []string{"This is a test message."}
+		subject = p.Text("Test Message")
+		e.Body.Title = p.Text("Test Message")
+		e.Body.Intros = []string{p.Text("This is a test message.")}
 	case notification.Verification:
-		subject = "Verification Message"
-		e.Body.Title = "Verification Message"
-		e.Body.Intros = []string{"This is your contact method verification code."}
+		subject = p.Text("Verification Message")
+		e.Body.Title = p.Text("Verification Message")
+		e.Body.Intros = []string{p.Text("This is your contact method verification code.")}
 		e.Body.Actions = []hermes.Action{{
-			Instructions: "Click the REACTIVATE link on your profile page and enter the verification code.",
+			Instructions: p.Text("Click the REACTIVATE link on your profile page and enter the verification code."),
 			InviteCode:   strconv.Itoa(m.Code),
 		}}
 	case notification.Alert:
-		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
-		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
+		subject = p.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
+		e.Body.Title = p.Sprintf("Alert #%d", m.AlertID)
 		e.Body.Intros = []string{m.Summary, m.Details}
 		e.Body.Actions = []hermes.Action{{
 			Button: hermes.Button{
-				Text: "Open Alert Details",
+				Text: p.Text("Open Alert Details"),
 				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
 			},
 		}}
 	case notification.AlertBundle:
-		subject = fmt.Sprintf("Service %s has %d unacknowledged alerts", m.ServiceName, m.Count)
-		e.Body.Title = "Multiple Unacknowledged Alerts"
-		e.Body.Intros = []string{fmt.Sprintf("The service %s has %d unacknowledged alerts.", m.ServiceName, m.Count)}
+		subject = p.Sprintf("Service %s has %d unacknowledged alerts", m.ServiceName, m.Count)
+		e.Body.Title = p.Text("Multiple Unacknowledged Alerts")
+		e.Body.Intros = []string{p.Sprintf("The service %s has %d unacknowledged alerts.", m.ServiceName, m.Count)}
 		e.Body.Actions = []hermes.Action{{
 			Button: hermes.Button{
-				Text: "Open Alert List",
+				Text: p.Text("Open Alert List"),
 				Link: cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", m.ServiceID)),
 			},
 		}}
 	case notification.AlertStatus:
-		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry)
-		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
+		subject = p.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry)
+		e.Body.Title = p.Sprintf("Alert #%d", m.AlertID)
 		e.Body.Intros = []string{m.LogEntry}
 		e.Body.Actions = []hermes.Action{{
 			Button: hermes.Button{
-				Text: "Open Alert Details",
+				Text: p.Text("Open Alert Details"),
 				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
 			},
 		}}
-		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
+		e.Body.Outros = []string{p.Text("You are receiving this message because you have status updates enabled. Visit your Profile page to change this.")}
 	default:
 		return nil, errors.New("message type not supported")
 	}
@@ -122,10 +154,7 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 		return nil, err
 	}
 
-	host, port, _ := net.SplitHostPort(cfg.SMTP.Address)
-	if host == "" {
-		host = cfg.SMTP.Address
-	}
+	host, addr := serverAddr(cfg)
 	tlsCfg := &tls.Config{
 		InsecureSkipVerify: cfg.SMTP.SkipVerify,
 		ServerName:         host,
@@ -133,11 +162,6 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 	sendFn := SendMailTLS
 	if cfg.SMTP.DisableTLS {
 		sendFn = SendMail
-		if port == "" {
-			port = "25"
-		}
-	} else if port == "" {
-		port = "465"
 	}
 
 	var authFn NegotiateAuth
@@ -157,7 +181,7 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 		}
 	}
 
-	err = sendFn(ctx, net.JoinHostPort(host, port), authFn, fromAddr.Address, []string{toAddr.Address}, buf.Bytes(), tlsCfg)
+	err = sendFn(ctx, addr, authFn, fromAddr.Address, []string{toAddr.Address}, buf.Bytes(), tlsCfg)
 	if err != nil {
 		return nil, err
 	}
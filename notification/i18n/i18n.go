@@ -0,0 +1,254 @@
+// Package i18n provides translations of notification text (SMS, voice, and email).
+//
+// Messages are identified by their English text, which is also used as a fmt format string.
+// A Catalog maps each English message to its translation for a locale; any message missing
+// from a catalog (or any locale without one) falls back to English. Translations may use
+// explicit argument indexes (e.g., "%[2]d ... %[1]s") to reorder arguments.
+//
+// Catalogs for some locales are built in; more can be added with Register or LoadDir.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the untranslated (English) messages.
+const DefaultLocale = "en"
+
+// A Catalog contains translated messages for a single locale.
+type Catalog struct {
+	// VoiceLanguage is the language used for text-to-speech of voice calls (e.g., "es-MX").
+	VoiceLanguage string `json:"voiceLanguage"`
+
+	// Messages maps English messages to their translation.
+	Messages map[string]string `json:"messages"`
+}
+
+//go:embed catalogs/*.json
+var builtin embed.FS
+
+var (
+	mx       sync.RWMutex
+	catalogs = make(map[string]*Catalog)
+)
+
+func init() {
+	files, err := builtin.ReadDir("catalogs")
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range files {
+		data, err := builtin.ReadFile("catalogs/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		err = registerJSON(strings.TrimSuffix(f.Name(), ".json"), data)
+		if err != nil {
+			panic(fmt.Sprintf("built-in catalog %s: %v", f.Name(), err))
+		}
+	}
+}
+
+var localeRx = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z]{2}|[0-9]{3}))?$`)
+
+// Normalize returns the canonical form of a locale (e.g., "pt_br" becomes "pt-BR").
+func Normalize(locale string) (string, error) {
+	m := localeRx.FindStringSubmatch(strings.TrimSpace(locale))
+	if m == nil {
+		return "", fmt.Errorf("invalid locale '%s'", locale)
+	}
+	if m[2] == "" {
+		return strings.ToLower(m[1]), nil
+	}
+
+	return strings.ToLower(m[1]) + "-" + strings.ToUpper(m[2]), nil
+}
+
+// Register will add (or replace) the catalog for a locale. All translations must use the same
+// arguments, with the same verbs, as their English message.
+func Register(locale string, c Catalog) error {
+	locale, err := Normalize(locale)
+	if err != nil {
+		return err
+	}
+	if locale == DefaultLocale {
+		return fmt.Errorf("cannot replace default locale '%s'", DefaultLocale)
+	}
+	for key, val := range c.Messages {
+		if !sameArgs(key, val) {
+			return fmt.Errorf("translation of '%s' must use the same arguments as the original", key)
+		}
+	}
+
+	mx.Lock()
+	defer mx.Unlock()
+	catalogs[locale] = &c
+
+	return nil
+}
+
+func registerJSON(locale string, data []byte) error {
+	var c Catalog
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return err
+	}
+
+	return Register(locale, c)
+}
+
+// LoadDir will register a catalog for each <locale>.json file in dir, replacing any
+// built-in catalog for the same locale.
+func LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		err = registerJSON(strings.TrimSuffix(filepath.Base(file), ".json"), data)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// Locales returns all locales with a catalog, including the default locale.
+func Locales() []string {
+	mx.RLock()
+	defer mx.RUnlock()
+
+	result := []string{DefaultLocale}
+	for locale := range catalogs {
+		result = append(result, locale)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// lookup returns the catalog for a normalized locale, falling back to its base language.
+func lookup(locale string) *Catalog {
+	mx.RLock()
+	defer mx.RUnlock()
+
+	if c := catalogs[locale]; c != nil {
+		return c
+	}
+	lang, _, _ := strings.Cut(locale, "-")
+
+	return catalogs[lang]
+}
+
+// IsSupported returns true if the locale is the default locale, or has a catalog for it
+// (or its base language).
+func IsSupported(locale string) bool {
+	locale, err := Normalize(locale)
+	if err != nil {
+		return false
+	}
+	lang, _, _ := strings.Cut(locale, "-")
+
+	return lang == DefaultLocale || lookup(locale) != nil
+}
+
+// A Printer formats messages for a locale. A nil Printer uses the default locale.
+type Printer struct {
+	cat *Catalog
+}
+
+// NewPrinter returns a Printer for the locale. Unknown or empty locales use the default locale.
+func NewPrinter(locale string) *Printer {
+	locale, err := Normalize(locale)
+	if err != nil {
+		return nil
+	}
+	cat := lookup(locale)
+	if cat == nil {
+		return nil
+	}
+
+	return &Printer{cat: cat}
+}
+
+// Text returns the translation of msg, or msg itself if there is none.
+func (p *Printer) Text(msg string) string {
+	if p == nil {
+		return msg
+	}
+	if t, ok := p.cat.Messages[msg]; ok && t != "" {
+		return t
+	}
+
+	return msg
+}
+
+// Sprintf formats the translation of format with args.
+func (p *Printer) Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(p.Text(format), args...)
+}
+
+// VoiceLanguage returns the text-to-speech language for the locale, or an empty string
+// for the default.
+func (p *Printer) VoiceLanguage() string {
+	if p == nil {
+		return ""
+	}
+
+	return p.cat.VoiceLanguage
+}
+
+type fmtArg struct {
+	Index int
+	Verb  byte
+}
+
+var verbRx = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*[0-9]*(?:\.[0-9]+)?([a-zA-Z%])`)
+
+// fmtArgs returns the set of arguments (and their verbs) used by a format string.
+func fmtArgs(format string) map[fmtArg]bool {
+	args := make(map[fmtArg]bool)
+	next := 0
+	for _, m := range verbRx.FindAllStringSubmatch(format, -1) {
+		if m[2] == "%" {
+			continue
+		}
+		if m[1] != "" {
+			n, _ := strconv.Atoi(m[1])
+			next = n - 1
+		}
+		args[fmtArg{Index: next, Verb: m[2][0]}] = true
+		next++
+	}
+
+	return args
+}
+
+func sameArgs(a, b string) bool {
+	argsA, argsB := fmtArgs(a), fmtArgs(b)
+	if len(argsA) != len(argsB) {
+		return false
+	}
+	for arg := range argsA {
+		if !argsB[arg] {
+			return false
+		}
+	}
+
+	return true
+}
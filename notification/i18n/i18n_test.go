@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	check := func(input, expected string) {
+		t.Helper()
+		res, err := Normalize(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	}
+	check("en", "en")
+	check("ES", "es")
+	check("pt_br", "pt-BR")
+	check(" es-419 ", "es-419")
+
+	_, err := Normalize("english")
+	assert.Error(t, err)
+	_, err = Normalize("")
+	assert.Error(t, err)
+}
+
+func TestPrinter(t *testing.T) {
+	var p *Printer
+	assert.Equal(t, "Alert #5", p.Sprintf("Alert #%d", 5), "nil printer should use English")
+	assert.Empty(t, p.VoiceLanguage())
+
+	assert.Nil(t, NewPrinter(""))
+	assert.Nil(t, NewPrinter("en-US"))
+	assert.Nil(t, NewPrinter("xx"), "unknown locale should use English")
+
+	p = NewPrinter("es-AR")
+	require.NotNil(t, p, "should fall back to base language")
+	assert.Equal(t, "Alerta #5", p.Sprintf("Alert #%d", 5))
+	assert.Equal(t, "untranslated 5", p.Sprintf("untranslated %d", 5))
+	assert.Equal(t, "es-MX", p.VoiceLanguage())
+}
+
+func TestRegister(t *testing.T) {
+	err := Register("zz", Catalog{Messages: map[string]string{"Svc '%s': %d unacked alerts": "%[2]d alerts for '%[1]s'"}})
+	require.NoError(t, err, "reordered arguments should be allowed")
+	assert.Equal(t, "3 alerts for 'foo'", NewPrinter("zz").Sprintf("Svc '%s': %d unacked alerts", "foo", 3))
+	assert.True(t, IsSupported("zz-YY"))
+	assert.Contains(t, Locales(), "zz")
+
+	err = Register("zz", Catalog{Messages: map[string]string{"Alert #%d": "Alert #%s"}})
+	assert.Error(t, err, "changed verb")
+	err = Register("zz", Catalog{Messages: map[string]string{"Alert #%d: %s": "Alert #%d"}})
+	assert.Error(t, err, "missing argument")
+	err = Register("en", Catalog{})
+	assert.Error(t, err, "default locale")
+}
+
+func TestBuiltinCatalogs(t *testing.T) {
+	mx.RLock()
+	defer mx.RUnlock()
+
+	// all built-in catalogs should translate the same messages
+	ref := catalogs["es"]
+	require.NotNil(t, ref)
+	for _, locale := range []string{"de", "fr"} {
+		c := catalogs[locale]
+		require.NotNil(t, c, locale)
+		assert.NotEmpty(t, c.VoiceLanguage, locale)
+		for key := range ref.Messages {
+			assert.Contains(t, c.Messages, key, locale)
+		}
+		assert.Len(t, c.Messages, len(ref.Messages), locale)
+	}
+}
@@ -0,0 +1,17 @@
+package notification
+
+import "context"
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context carrying the idempotency key for an outgoing provider request.
+// Retries of the same request use the same key, so providers that support it can ignore duplicates.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key for the current provider request, if any.
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
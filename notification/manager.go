@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/util/log"
 )
 
@@ -49,7 +51,7 @@ func (mgr *Manager) FormatDestValue(ctx context.Context, destType DestType, valu
 	defer mgr.mx.RUnlock()
 
 	for _, s := range mgr.searchOrder {
-		if s.destType != destType {
+		if s.destType != destType.SenderType() {
 			continue
 		}
 
@@ -69,6 +71,20 @@ func (mgr *Manager) FormatDestValue(ctx context.Context, destType DestType, valu
 	return value
 }
 
+// ConfigChanged will notify all registered senders that implement ConfigWatcher of a config change.
+func (mgr *Manager) ConfigChanged(ctx context.Context, oldCfg, newCfg config.Config) {
+	mgr.mx.RLock()
+	defer mgr.mx.RUnlock()
+
+	for _, s := range mgr.searchOrder {
+		w, ok := s.Sender.(ConfigWatcher)
+		if !ok {
+			continue
+		}
+		w.ConfigChanged(ctx, oldCfg, newCfg)
+	}
+}
+
 // MessageStatus will return the current status of a message.
 func (mgr *Manager) MessageStatus(ctx context.Context, providerMsgID ProviderMessageID) (*Status, DestType, error) {
 	provider := mgr.providers[providerMsgID.ProviderName]
@@ -85,6 +101,39 @@ func (mgr *Manager) MessageStatus(ctx context.Context, providerMsgID ProviderMes
 	return status, provider.destType, err
 }
 
+// FindSent checks the senders for the destination type for msg having been sent at or after since,
+// after a request with an unknown outcome. It returns nil if the sent message could not be identified,
+// or ErrStatusUnsupported if none of the senders support the check.
+func (mgr *Manager) FindSent(ctx context.Context, msg Message, since time.Time) (*SendResult, error) {
+	mgr.mx.RLock()
+	defer mgr.mx.RUnlock()
+
+	var checked bool
+	for _, s := range mgr.searchOrder {
+		if s.destType != msg.Destination().Type.SenderType() {
+			continue
+		}
+		finder, ok := s.Sender.(SentFinder)
+		if !ok {
+			continue
+		}
+		checked = true
+
+		sent, err := finder.FindSent(log.WithField(ctx, "ProviderName", s.name), msg, since)
+		if err != nil {
+			return nil, err
+		}
+		if sent != nil {
+			return s.result(msg.ID(), sent), nil
+		}
+	}
+	if !checked {
+		return nil, ErrStatusUnsupported
+	}
+
+	return nil, nil
+}
+
 // RegisterSender will register a sender under a given DestType and name.
 // A sender for the same name and type will replace an existing one, if any.
 func (mgr *Manager) RegisterSender(t DestType, name string, s Sender) {
@@ -128,23 +177,28 @@ func (mgr *Manager) SendMessage(ctx context.Context, msg Message) (*SendResult,
 	destType := msg.Destination().Type
 
 	ctx = log.WithFields(ctx, log.Fields{
-		"ProviderType": destType,
-		"CallbackID":   msg.ID(),
+		"ProviderType":     destType,
+		log.FieldMessageID: msg.ID(),
 	})
 	if a, ok := msg.(Alert); ok {
-		ctx = log.WithField(ctx, "AlertID", a.AlertID)
+		ctx = log.WithField(ctx, log.FieldAlertID, a.AlertID)
 	}
 
+	country := destCountry(destType, msg.Destination().Value)
 	var tried bool
 	for _, s := range mgr.searchOrder {
-		if s.destType != destType {
+		if s.destType != destType.SenderType() {
 			continue
 		}
 		tried = true
 
 		sendCtx := log.WithField(ctx, "ProviderName", s.name)
+		start := time.Now()
 		res, err := s.Send(sendCtx, msg)
 		if err != nil {
+			outcome, code := errOutcome(err)
+			metricSendDuration.WithLabelValues(destType.String(), s.name, outcome).Observe(time.Since(start).Seconds())
+			metricDeliveryTotal.WithLabelValues(destType.String(), s.name, outcome, code, country).Inc()
 			log.Log(sendCtx, errors.Wrap(err, "send notification"))
 			continue
 		}
@@ -152,6 +206,12 @@ func (mgr *Manager) SendMessage(ctx context.Context, msg Message) (*SendResult,
 		metricSentTotal.
 			WithLabelValues(msg.Destination().Type.String(), msg.Type().String()).
 			Inc()
+		metricSendDuration.WithLabelValues(destType.String(), s.name, outcomeSent).Observe(time.Since(start).Seconds())
+		metricDeliveryTotal.WithLabelValues(destType.String(), s.name, outcomeSent, "", country).Inc()
+		if outcome := statusOutcome(res.Status); outcome != "" {
+			// some providers (e.g., Slack) confirm delivery immediately
+			metricDeliveryTotal.WithLabelValues(destType.String(), s.name, outcome, res.ErrorCode, country).Inc()
+		}
 		// status already wrapped via namedSender
 		return res, nil
 	}
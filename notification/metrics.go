@@ -18,4 +18,17 @@ var (
 		Name:      "recv_total",
 		Help:      "Total number of received notification responses.",
 	}, []string{"dest_type", "response_type"})
+	metricDeliveryTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "notification",
+		Name:      "delivery_total",
+		Help:      "Total number of notification delivery outcomes (sent, delivered, failed, or opted_out) by provider. The country label is set for SMS and voice destinations.",
+	}, []string{"dest_type", "provider", "outcome", "error_code", "country"})
+	metricSendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "notification",
+		Name:      "send_duration_seconds",
+		Help:      "Time taken for a provider to accept (or reject) a notification.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{"dest_type", "provider", "outcome"})
 )
@@ -21,6 +21,10 @@ func (nr *namedReceiver) IsKnownDest(ctx context.Context, value string) (bool, e
 // SetMessageStatus calls the underlying ResultReceiver's SetSendResult method after wrapping the status for the
 // namedSender.
 func (nr *namedReceiver) SetMessageStatus(ctx context.Context, externalID string, status *Status) error {
+	if outcome := statusOutcome(*status); outcome != "" {
+		metricDeliveryTotal.WithLabelValues(nr.ns.destType.String(), nr.ns.name, outcome, status.ErrorCode, destCountry(nr.ns.destType, status.DestValue)).Inc()
+	}
+
 	res := &SendResult{Status: *status}
 	res.ProviderMessageID.ProviderName = nr.ns.name
 	res.ProviderMessageID.ExternalID = externalID
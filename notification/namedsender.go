@@ -16,8 +16,12 @@ func (s *namedSender) Send(ctx context.Context, msg Message) (*SendResult, error
 		return nil, err
 	}
 
+	return s.result(msg.ID(), sent), nil
+}
+
+func (s *namedSender) result(msgID string, sent *SentMessage) *SendResult {
 	return &SendResult{
-		ID: msg.ID(),
+		ID: msgID,
 		Status: Status{
 			State:    sent.State,
 			Details:  sent.StateDetails,
@@ -27,5 +31,5 @@ func (s *namedSender) Send(ctx context.Context, msg Message) (*SendResult, error
 			ProviderName: s.name,
 			ExternalID:   sent.ExternalID,
 		},
-	}, nil
+	}
 }
@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/permission"
+)
+
+// QueueStats describes the outgoing message queue for a single destination type.
+type QueueStats struct {
+	DestType DestType
+
+	// Pending is the number of messages waiting to be sent.
+	Pending int
+
+	// OldestPendingAt is the creation time of the oldest pending message, or zero if there are none.
+	OldestPendingAt time.Time
+
+	// RecentlySent is the number of messages sent since the time provided to MessageQueueStats.
+	RecentlySent int
+}
+
+// MessageQueueStats returns QueueStats for each destination type with pending messages, or messages sent
+// since the provided time.
+func (s *Store) MessageQueueStats(ctx context.Context, since time.Time) ([]QueueStats, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.readDB.QueryContext(ctx, `
+		select
+			cm.type,
+			nc.type,
+			count(*) filter (where om.last_status = 'pending'),
+			min(om.created_at) filter (where om.last_status = 'pending'),
+			count(*) filter (where om.sent_at >= $1)
+		from outgoing_messages om
+		left join user_contact_methods cm on cm.id = om.contact_method_id
+		left join notification_channels nc on nc.id = om.channel_id
+		where om.last_status = 'pending' or om.sent_at >= $1
+		group by cm.type, nc.type
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []QueueStats
+	for rows.Next() {
+		var dt ScannableDestType
+		var stat QueueStats
+		var oldest sql.NullTime
+		err = rows.Scan(&dt.CM, &dt.NC, &stat.Pending, &oldest, &stat.RecentlySent)
+		if err != nil {
+			return nil, err
+		}
+		stat.DestType = dt.DestType()
+		stat.OldestPendingAt = oldest.Time
+		result = append(result, stat)
+	}
+
+	return result, rows.Err()
+}
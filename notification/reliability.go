@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ContactMethodReliability summarizes alert notification outcomes for a single contact method.
+type ContactMethodReliability struct {
+	ContactMethodID string
+	Name            string
+	DestType        DestType
+
+	// Delivered is the number of notifications the provider confirmed were delivered.
+	Delivered int
+
+	// Sent is the number of notifications accepted by the provider without delivery confirmation.
+	Sent int
+
+	// Failed is the number of notifications that could not be sent or delivered.
+	Failed int
+
+	// NoResponse is the number of sent or delivered notifications for alerts the user
+	// did not acknowledge or close.
+	NoResponse int
+
+	// ConsecutiveFailures is the number of messages of any kind (including tests) that have failed
+	// since the last successful message to the contact method.
+	ConsecutiveFailures int
+}
+
+// UserReliability returns ContactMethodReliability for each of the user's contact methods that
+// were sent alert notifications since the provided time.
+func (s *Store) UserReliability(ctx context.Context, userID string, since time.Time) ([]ContactMethodReliability, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.readDB.QueryContext(ctx, `
+		select
+			cm.id,
+			cm.name,
+			cm.type,
+			count(*) filter (where om.last_status = 'delivered'),
+			count(*) filter (where om.last_status = 'sent'),
+			count(*) filter (where om.last_status = 'failed'),
+			count(*) filter (where
+				om.last_status in ('sent', 'delivered') and
+				not exists (
+					select 1
+					from alert_logs log
+					where
+						log.alert_id = om.alert_id and
+						log.sub_user_id = om.user_id and
+						log.event in ('acknowledged', 'closed')
+				)
+			),
+			(
+				select count(*)
+				from outgoing_messages f
+				where
+					f.contact_method_id = cm.id and
+					f.last_status = 'failed' and
+					f.created_at > coalesce((
+						select max(ok.created_at)
+						from outgoing_messages ok
+						where ok.contact_method_id = cm.id and ok.last_status in ('sent', 'delivered')
+					), '-infinity')
+			)
+		from outgoing_messages om
+		join user_contact_methods cm on cm.id = om.contact_method_id
+		where
+			om.user_id = $1 and
+			om.message_type = 'alert_notification' and
+			om.created_at >= $2
+		group by cm.id, cm.name, cm.type
+		order by cm.name
+	`, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []ContactMethodReliability
+	for rows.Next() {
+		var dt ScannableDestType
+		var r ContactMethodReliability
+		err = rows.Scan(&r.ContactMethodID, &r.Name, &dt.CM, &r.Delivered, &r.Sent, &r.Failed, &r.NoResponse, &r.ConsecutiveFailures)
+		if err != nil {
+			return nil, err
+		}
+		r.DestType = dt.DestType()
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxRequeueRange is the maximum time range for RequeueFailedMessages.
+const MaxRequeueRange = 7 * 24 * time.Hour
+
+// RequeueOptions select failed messages to be sent again.
+type RequeueOptions struct {
+	// CreatedAfter and CreatedBefore limit messages to those created within the range.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// CMTypes, if set, limits messages to those sent to contact methods of the given types.
+	CMTypes []contactmethod.Type
+
+	// ErrorCode, if non-zero, limits messages to those that failed with the given provider error code.
+	ErrorCode int
+}
+
+func countAffected(res sql.Result, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+// RequeueMessages will reset the given failed messages to pending so they are sent again,
+// returning the number of messages requeued. Alert notifications for closed alerts are skipped.
+func (s *Store) RequeueMessages(ctx context.Context, ids []string) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+	err = validate.ManyUUID("IDs", ids, search.MaxResults)
+	if err != nil {
+		return 0, err
+	}
+
+	return countAffected(s.requeueMessages.ExecContext(ctx, sqlutil.UUIDArray(ids)))
+}
+
+// RequeueFailedMessages will reset all failed messages matching opts to pending so they are sent again,
+// returning the number of messages requeued. Alert notifications for closed alerts are skipped.
+func (s *Store) RequeueFailedMessages(ctx context.Context, opts RequeueOptions) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+	if !opts.CreatedBefore.After(opts.CreatedAfter) {
+		return 0, validation.NewFieldError("CreatedBefore", "must be after CreatedAfter")
+	}
+	if opts.CreatedBefore.Sub(opts.CreatedAfter) > MaxRequeueRange {
+		return 0, validation.NewFieldError("CreatedBefore", "time range must not exceed 7 days")
+	}
+	err = validate.Range("ErrorCode", opts.ErrorCode, 0, 999999)
+	if err != nil {
+		return 0, err
+	}
+
+	cmTypes := make(sqlutil.StringArray, 0, len(opts.CMTypes))
+	for _, t := range opts.CMTypes {
+		cmTypes = append(cmTypes, string(t))
+	}
+	var code string
+	if opts.ErrorCode != 0 {
+		code = strconv.Itoa(opts.ErrorCode)
+	}
+
+	return countAffected(s.requeueFailedRange.ExecContext(ctx, opts.CreatedAfter, opts.CreatedBefore, cmTypes, code))
+}
+
+// CancelMessages will mark the given pending messages as failed so they are not sent,
+// returning the number of messages canceled. Messages already being sent are not affected.
+func (s *Store) CancelMessages(ctx context.Context, ids []string) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+	err = validate.ManyUUID("IDs", ids, search.MaxResults)
+	if err != nil {
+		return 0, err
+	}
+
+	return countAffected(s.cancelMessages.ExecContext(ctx, sqlutil.UUIDArray(ids)))
+}
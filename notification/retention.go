@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+// RetentionStatus describes the progress of message log cleanup (Maintenance.MessageLogCleanupDays).
+type RetentionStatus struct {
+	// RetentionDays is the configured number of days; 0 means cleanup is disabled.
+	RetentionDays int
+
+	// OldestMessageAt is the creation time of the oldest outgoing message, or zero if there are none.
+	OldestMessageAt time.Time
+
+	// PendingCleanup is the number of outgoing messages that are past the retention period and
+	// waiting to be deleted.
+	PendingCleanup int
+}
+
+// MessageLogRetention returns the current RetentionStatus.
+func (s *Store) MessageLogRetention(ctx context.Context) (*RetentionStatus, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	var stat RetentionStatus
+	stat.RetentionDays = config.FromContext(ctx).Maintenance.MessageLogCleanupDays
+
+	var oldest sql.NullTime
+	err = s.readDB.QueryRowContext(ctx, `select min(created_at) from outgoing_messages`).Scan(&oldest)
+	if err != nil {
+		return nil, err
+	}
+	stat.OldestMessageAt = oldest.Time
+
+	if stat.RetentionDays == 0 || !oldest.Valid {
+		return &stat, nil
+	}
+
+	var dur pgtype.Interval
+	dur.Days = int32(stat.RetentionDays)
+	dur.Status = pgtype.Present
+	err = s.readDB.QueryRowContext(ctx, `
+		select count(*)
+		from outgoing_messages
+		where
+			last_status in ('bundled', 'delivered', 'failed', 'sent') and
+			next_retry_at isnull and
+			created_at < (now() - $1::interval)
+	`, &dur).Scan(&stat.PendingCleanup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stat, nil
+}
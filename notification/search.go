@@ -44,6 +44,8 @@ type MessageLog struct {
 
 // SearchOptions allow filtering and paginating the list of messages.
 type SearchOptions struct {
+	// Search will match user, service, or channel names, the destination, provider message ID,
+	// status details (e.g., error text), or the alert summary.
 	Search string       `json:"s,omitempty"`
 	After  SearchCursor `json:"a,omitempty"`
 
@@ -79,6 +81,9 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 	LEFT JOIN services s ON om.service_id = s.id
 	LEFT JOIN user_contact_methods cm ON om.contact_method_id = cm.id
 	LEFT JOIN notification_channels nc ON om.channel_id = nc.id
+	{{if .Search}}
+	LEFT JOIN alerts a ON om.alert_id = a.id
+	{{end}}
 	WHERE true
 	{{if .Omit}}
 		AND NOT om.id = any(:omit)
@@ -102,6 +107,16 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 				lower(cm.type::text) = lower(:search)
 			OR
 				lower(nc.type::text) = lower(:search)
+			OR
+				nc.value ILIKE '%' || :search || '%'
+			OR
+				om.src_value ILIKE '%' || :search || '%'
+			OR
+				om.provider_msg_id ILIKE '%' || :search || '%'
+			OR
+				om.status_details ILIKE '%' || :search || '%'
+			OR
+				{{textSearch "search" "a.summary"}}
 		)
 	{{end}}
 	{{if .After.ID}}
@@ -208,7 +223,7 @@ func (s *Store) TimeSeries(ctx context.Context, opts TimeSeriesOpts) ([]TimeSeri
 		return nil, errors.Wrap(err, "render query")
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.readDB.QueryContext(ctx, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -269,7 +284,7 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]MessageLog,
 		return nil, errors.Wrap(err, "render query")
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.readDB.QueryContext(ctx, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -2,8 +2,10 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
 )
 
 // SentMessage contains information about a message that was sent to a remote
@@ -30,6 +32,14 @@ type StatusChecker interface {
 	Status(ctx context.Context, externalID string) (*Status, error)
 }
 
+// A SentFinder is an optional interface a Sender can implement that allows checking whether a request
+// with an unknown outcome (e.g., a timeout waiting for the response) resulted in a message being sent.
+type SentFinder interface {
+	// FindSent returns the provider's record of msg having been sent at or after since. It must only match
+	// something unique to msg (e.g., the exact content), and return nil if no such record is found.
+	FindSent(ctx context.Context, msg Message, since time.Time) (*SentMessage, error)
+}
+
 // A FriendlyValuer is an optional interface a Sender can implement that
 // allows retrieving a friendly name for a destination value.
 //
@@ -41,6 +51,12 @@ type FriendlyValuer interface {
 // ErrStatusUnsupported should be returned when a Status() check is not supported by the provider.
 var ErrStatusUnsupported = errors.New("status check unsupported by provider")
 
+// A ConfigWatcher is an optional interface a Sender can implement to be notified of config changes,
+// for example, to discard data cached using old credentials.
+type ConfigWatcher interface {
+	ConfigChanged(ctx context.Context, oldCfg, newCfg config.Config)
+}
+
 // ReceiverSetter is an optional interface a Sender can implement for use with two-way interactions.
 type ReceiverSetter interface {
 	SetReceiver(Receiver)
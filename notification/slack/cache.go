@@ -40,6 +40,14 @@ func (c *ttlCache[K, V]) Add(key lru.Key, value V) {
 	})
 }
 
+// Purge will remove all items from the cache.
+func (c *ttlCache[K, V]) Purge() {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.Cache.Clear()
+}
+
 func (c *ttlCache[K, V]) Get(key K) (val V, ok bool) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
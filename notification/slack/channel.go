@@ -49,6 +49,7 @@ const (
 var (
 	_ notification.Sender         = &ChannelSender{}
 	_ notification.ReceiverSetter = &ChannelSender{}
+	_ notification.ConfigWatcher  = &ChannelSender{}
 )
 
 func NewChannelSender(ctx context.Context, cfg Config) (*ChannelSender, error) {
@@ -436,6 +437,44 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 	}, nil
 }
 
+// PostText will post a plain text message to the given channel.
+func (s *ChannelSender) PostText(ctx context.Context, channelID, text string) error {
+	return s.withClient(ctx, func(c *slack.Client) error {
+		_, _, err := c.PostMessageContext(ctx, channelID, slack.MsgOptionText(text, false))
+		return err
+	})
+}
+
+// ConfigChanged implements notification.ConfigWatcher by discarding cached data when the Slack config changes,
+// so a new access token (or workspace) is used immediately.
+//
+// It may be called multiple times for the same change, since the ChannelSender is registered for multiple types.
+func (s *ChannelSender) ConfigChanged(ctx context.Context, oldCfg, newCfg config.Config) {
+	if !config.SectionChanged(oldCfg, newCfg, "Slack") {
+		return
+	}
+
+	s.teamMx.Lock()
+	s.teamID = ""
+	s.token = ""
+	s.teamMx.Unlock()
+
+	s.chanCache.Purge()
+	s.listCache.Purge()
+	s.ugCache.Purge()
+	s.teamInfoCache.Purge()
+	s.userInfoCache.Purge()
+	s.ugInfoCache.Purge()
+}
+
+// CheckAuth will verify that Slack is reachable and the configured access token is valid.
+func (s *ChannelSender) CheckAuth(ctx context.Context) error {
+	return s.withClient(ctx, func(c *slack.Client) error {
+		_, err := c.AuthTestContext(ctx)
+		return err
+	})
+}
+
 func (s *ChannelSender) lookupTeamIDForToken(ctx context.Context, token string) (string, error) {
 	var teamID string
 
@@ -1,6 +1,10 @@
 package slack
 
 import (
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
 	"github.com/target/goalert/user"
 )
 
@@ -8,4 +12,10 @@ import (
 type Config struct {
 	BaseURL   string
 	UserStore *user.Store
+
+	// The following stores are used to handle slash commands.
+	AlertStore    *alert.Store
+	ServiceStore  *service.Store
+	ScheduleStore *schedule.Store
+	OnCallStore   *oncall.Store
 }
@@ -0,0 +1,89 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util"
+)
+
+// RequiredScopes are the bot token scopes used by GoAlert (as listed in the generated app manifest).
+var RequiredScopes = []string{
+	"commands",
+	"links:read",
+	"chat:write",
+	"channels:read",
+	"groups:read",
+	"im:read",
+	"im:write",
+	"users:read",
+	"users:read.email",
+	"usergroups:read",
+	"usergroups:write",
+	"team:read",
+}
+
+// MissingScopes will verify the configured access token, returning any RequiredScopes it has not been granted.
+//
+// If Slack does not report granted scopes, nil is returned.
+func (s *ChannelSender) MissingScopes(ctx context.Context) ([]string, error) {
+	base := "https://slack.com/api/"
+	if s.cfg.BaseURL != "" {
+		var err error
+		base, err = util.JoinURL(s.cfg.BaseURL, "/api/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid Slack.BaseURL: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(base, "/")+"/auth.test", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+config.FromContext(ctx).Slack.AccessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var body struct {
+		OK    bool
+		Error string
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if !body.OK {
+		return nil, errors.New(body.Error)
+	}
+
+	granted := resp.Header.Get("X-OAuth-Scopes")
+	if granted == "" {
+		return nil, nil
+	}
+
+	has := make(map[string]bool)
+	for _, scope := range strings.Split(granted, ",") {
+		has[strings.TrimSpace(scope)] = true
+	}
+
+	var missing []string
+	for _, scope := range RequiredScopes {
+		if !has[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing, nil
+}
@@ -1,65 +1,21 @@
 package slack
 
 import (
-	"bytes"
-	"crypto/hmac"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/slack-go/slack"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
-	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
 )
 
-func validateRequestSignature(now time.Time, req *http.Request) error {
-	cfg := config.FromContext(req.Context())
-
-	if req.Form != nil {
-		return errors.New("request already parsed, can't validate signature")
-	}
-
-	// copy body data
-	var buf bytes.Buffer
-	if req.Body != nil {
-		orig := req.Body
-		req.Body = io.NopCloser(io.TeeReader(req.Body, &buf))
-		err := req.ParseForm()
-		orig.Close()
-		if err != nil {
-			return err
-		}
-	}
-
-	// read ts
-	tsStr := req.Header.Get("X-Slack-Request-Timestamp")
-	unixSec, err := strconv.ParseInt(tsStr, 10, 64)
-	if err != nil {
-		return permission.Unauthorized()
-	}
-	ts := time.Unix(unixSec, 0)
-	if now.Sub(ts).Abs() > 5*time.Minute {
-		return permission.Unauthorized()
-	}
-
-	properSig := Signature(cfg.Slack.SigningSecret, ts, buf.Bytes())
-	if !hmac.Equal([]byte(req.Header.Get("X-Slack-Signature")), []byte(properSig)) {
-		return permission.Unauthorized()
-	}
-
-	return nil
-}
-
 func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	cfg := config.FromContext(ctx)
@@ -69,11 +25,6 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 		return
 	}
 
-	err := validateRequestSignature(time.Now(), req)
-	if errutil.HTTPError(ctx, w, err) {
-		return
-	}
-
 	var payload struct {
 		Type        string
 		ResponseURL string `json:"response_url"`
@@ -95,7 +46,7 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 			Value    string `json:"value"`
 		}
 	}
-	err = json.Unmarshal([]byte(req.FormValue("payload")), &payload)
+	err := json.Unmarshal([]byte(req.FormValue("payload")), &payload)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
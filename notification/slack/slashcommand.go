@@ -0,0 +1,394 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+const slashCommandHelp = "Usage:\n" +
+	"• `create \"<service>\" <summary>` create a new alert\n" +
+	"• `list` list your unresolved alerts\n" +
+	"• `ack <alert ID>` acknowledge an alert\n" +
+	"• `close <alert ID>` close an alert\n" +
+	"• `oncall <service or schedule>` show who is on-call"
+
+// maxListAlerts is the maximum number of alerts returned by the `list` command.
+const maxListAlerts = 15
+
+type slashCommand struct {
+	Name string
+	Args []string
+}
+
+// splitCommandText splits the text into whitespace-separated tokens, allowing
+// double quotes to group tokens that contain whitespace.
+func splitCommandText(text string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	var inQuote, hasToken bool
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			inQuote = !inQuote
+			hasToken = true
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n'):
+			if hasToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				hasToken = false
+			}
+		default:
+			cur.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if inQuote {
+		return nil, validation.NewFieldError("text", "unterminated quote")
+	}
+	if hasToken {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
+
+// parseSlashCommand will parse and validate the text of a slash command.
+func parseSlashCommand(text string) (*slashCommand, error) {
+	tokens, err := splitCommandText(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return &slashCommand{Name: "help"}, nil
+	}
+
+	cmd := &slashCommand{Name: strings.ToLower(tokens[0]), Args: tokens[1:]}
+	switch cmd.Name {
+	case "help", "list":
+		if len(cmd.Args) > 0 {
+			return nil, validation.NewFieldErrorf("text", "`%s` does not take any arguments", cmd.Name)
+		}
+	case "create":
+		if len(cmd.Args) < 2 {
+			return nil, validation.NewFieldError("text", "`create` requires a service and a summary")
+		}
+		cmd.Args = []string{cmd.Args[0], strings.Join(cmd.Args[1:], " ")}
+	case "ack", "close":
+		if len(cmd.Args) != 1 {
+			return nil, validation.NewFieldErrorf("text", "`%s` requires a single alert ID", cmd.Name)
+		}
+		_, err = parseAlertID(cmd.Args[0])
+		if err != nil {
+			return nil, err
+		}
+	case "oncall":
+		if len(cmd.Args) == 0 {
+			return nil, validation.NewFieldError("text", "`oncall` requires a service or schedule name")
+		}
+		cmd.Args = []string{strings.Join(cmd.Args, " ")}
+	default:
+		return nil, validation.NewFieldErrorf("text", "unknown command `%s`", cmd.Name)
+	}
+
+	return cmd, nil
+}
+
+func parseAlertID(s string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(s, "#"))
+	if err != nil || id <= 0 {
+		return 0, validation.NewFieldErrorf("text", "invalid alert ID `%s`", s)
+	}
+
+	return id, nil
+}
+
+// slashErrorText returns a user-facing description of err, logging anything unexpected.
+func slashErrorText(ctx context.Context, err error) string {
+	var fErr validation.FieldError
+	switch {
+	case errors.As(err, &fErr):
+		return fErr.Reason()
+	case validation.IsClientError(err), permission.IsPermissionError(err):
+		return err.Error()
+	}
+
+	log.Log(ctx, err)
+	return "something went wrong, please try again later."
+}
+
+func writeSlashResponse(ctx context.Context, w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(struct {
+		ResponseType string `json:"response_type"`
+		Text         string `json:"text"`
+	}{
+		ResponseType: "ephemeral",
+		Text:         text,
+	})
+	if err != nil {
+		log.Log(ctx, err)
+	}
+}
+
+// ServeSlashCommand handles requests for the `/goalert` slash command.
+func (s *ChannelSender) ServeSlashCommand(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !cfg.Slack.SlashCommands {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	teamID := req.FormValue("team_id")
+	teamDomain := req.FormValue("team_domain")
+	userID := req.FormValue("user_id")
+	userName := req.FormValue("user_name")
+	ctx = log.WithFields(ctx, log.Fields{
+		"SlackTeamID": teamID,
+		"SlackUserID": userID,
+	})
+
+	cmd, err := parseSlashCommand(req.FormValue("text"))
+	if err != nil {
+		writeSlashResponse(ctx, w, fmt.Sprintf("%s\n\n%s", slashErrorText(ctx, err), slashCommandHelp))
+		return
+	}
+	if cmd.Name == "help" {
+		writeSlashResponse(ctx, w, slashCommandHelp)
+		return
+	}
+
+	var usr *user.User
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		usr, err = s.cfg.UserStore.FindOneBySubject(ctx, "slack:"+teamID, userID)
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("find user: %w", err))
+		writeSlashResponse(ctx, w, "Failed to look up your GoAlert account, please try again later.")
+		return
+	}
+	if usr == nil {
+		writeSlashResponse(ctx, w, s.slashLinkMessage(ctx, teamID, teamDomain, userID, userName))
+		return
+	}
+
+	ctx = permission.UserContext(ctx, usr.ID, usr.Role)
+	msg, err := s.runSlashCommand(ctx, cmd)
+	if err != nil {
+		msg = "Error: " + slashErrorText(ctx, err)
+	}
+
+	writeSlashResponse(ctx, w, msg)
+}
+
+func (s *ChannelSender) slashLinkMessage(ctx context.Context, teamID, teamDomain, userID, userName string) string {
+	if teamID == "" || teamDomain == "" || userID == "" || userName == "" {
+		// missing data, don't allow linking
+		log.Log(ctx, errors.New("slack slash command missing required data"))
+		return "Your Slack account isn't currently linked to GoAlert, please try again later."
+	}
+
+	linkURL, err := s.recv.AuthLinkURL(ctx, "slack:"+teamID, userID, authlink.Metadata{
+		UserDetails: fmt.Sprintf("Slack user @%s from %s.slack.com", userName, teamDomain),
+	})
+	if err != nil {
+		log.Log(ctx, err)
+	}
+	if linkURL == "" {
+		return "Your Slack account isn't currently linked to GoAlert, please try again later."
+	}
+
+	return fmt.Sprintf("Please <%s|link your Slack account> with GoAlert, then try again.", linkURL)
+}
+
+func (s *ChannelSender) runSlashCommand(ctx context.Context, cmd *slashCommand) (string, error) {
+	cfg := config.FromContext(ctx)
+
+	switch cmd.Name {
+	case "create":
+		svc, err := s.findService(ctx, cmd.Args[0])
+		if err != nil {
+			return "", err
+		}
+		if svc == nil {
+			return fmt.Sprintf("No service found matching `%s`.", cmd.Args[0]), nil
+		}
+
+		a, err := s.cfg.AlertStore.Create(ctx, &alert.Alert{
+			ServiceID: svc.ID,
+			Summary:   cmd.Args[1],
+			Status:    alert.StatusTriggered,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Created alert <%s|#%d> on %s.", cfg.CallbackURL("/alerts/"+strconv.Itoa(a.ID)), a.ID, svc.Name), nil
+	case "list":
+		alerts, err := s.cfg.AlertStore.Search(ctx, &alert.SearchOptions{
+			Status: []alert.Status{alert.StatusTriggered, alert.StatusActive},
+			// only include alerts the user has been notified for
+			ServiceFilter:  alert.IDFilter{Valid: true},
+			NotifiedUserID: permission.UserID(ctx),
+			Limit:          maxListAlerts,
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(alerts) == 0 {
+			return "You have no unresolved alerts.", nil
+		}
+
+		var buf strings.Builder
+		buf.WriteString("Your unresolved alerts:\n")
+		for _, a := range alerts {
+			fmt.Fprintf(&buf, "• <%s|#%d> [%s] %s\n", cfg.CallbackURL("/alerts/"+strconv.Itoa(a.ID)), a.ID, statusName(a.Status), a.Summary)
+		}
+		return buf.String(), nil
+	case "ack", "close":
+		id, err := parseAlertID(cmd.Args[0])
+		if err != nil {
+			return "", err
+		}
+
+		status, verb := alert.StatusActive, "Acknowledged"
+		if cmd.Name == "close" {
+			status, verb = alert.StatusClosed, "Closed"
+		}
+
+		err = s.cfg.AlertStore.UpdateStatus(ctx, id, status)
+		if alert.IsAlreadyAcknowledged(err) {
+			return fmt.Sprintf("Alert #%d is already acknowledged.", id), nil
+		}
+		if alert.IsAlreadyClosed(err) {
+			return fmt.Sprintf("Alert #%d is already closed.", id), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s alert #%d.", verb, id), nil
+	case "oncall":
+		return s.onCallMessage(ctx, cmd.Args[0])
+	}
+
+	return "", fmt.Errorf("unhandled command %q", cmd.Name)
+}
+
+func statusName(s alert.Status) string {
+	switch s {
+	case alert.StatusTriggered:
+		return "Unacknowledged"
+	case alert.StatusActive:
+		return "Acknowledged"
+	case alert.StatusClosed:
+		return "Closed"
+	}
+
+	return string(s)
+}
+
+// findService returns the service matching the provided name, or nil if
+// there is no single match.
+func (s *ChannelSender) findService(ctx context.Context, name string) (*service.Service, error) {
+	svcs, err := s.cfg.ServiceStore.Search(ctx, &service.SearchOptions{
+		Search: name,
+		Limit:  10,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range svcs {
+		if strings.EqualFold(svc.Name, name) {
+			return &svc, nil
+		}
+	}
+	if len(svcs) == 1 {
+		return &svcs[0], nil
+	}
+
+	return nil, nil
+}
+
+// findSchedule returns the schedule matching the provided name, or nil if
+// there is no single match.
+func (s *ChannelSender) findSchedule(ctx context.Context, name string) (*schedule.Schedule, error) {
+	scheds, err := s.cfg.ScheduleStore.Search(ctx, &schedule.SearchOptions{
+		Search: name,
+		Limit:  10,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sched := range scheds {
+		if strings.EqualFold(sched.Name, name) {
+			return &sched, nil
+		}
+	}
+	if len(scheds) == 1 {
+		return &scheds[0], nil
+	}
+
+	return nil, nil
+}
+
+func (s *ChannelSender) onCallMessage(ctx context.Context, name string) (string, error) {
+	svc, err := s.findService(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if svc != nil {
+		users, err := s.cfg.OnCallStore.OnCallUsersByService(ctx, svc.ID)
+		if err != nil {
+			return "", err
+		}
+		if len(users) == 0 {
+			return fmt.Sprintf("Nobody is on-call for %s.", svc.Name), nil
+		}
+
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "On-call for %s:\n", svc.Name)
+		for _, u := range users {
+			fmt.Fprintf(&buf, "• Step %d: %s\n", u.StepNumber+1, u.UserName)
+		}
+		return buf.String(), nil
+	}
+
+	sched, err := s.findSchedule(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if sched == nil {
+		return fmt.Sprintf("No service or schedule found matching `%s`.", name), nil
+	}
+
+	users, err := s.cfg.OnCallStore.OnCallUsersBySchedule(ctx, sched.ID)
+	if err != nil {
+		return "", err
+	}
+	if len(users) == 0 {
+		return fmt.Sprintf("Nobody is on-call for %s.", sched.Name), nil
+	}
+
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	return fmt.Sprintf("On-call for %s: %s", sched.Name, strings.Join(names, ", ")), nil
+}
@@ -0,0 +1,42 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlashCommand(t *testing.T) {
+	check := func(text string, exp *slashCommand) {
+		t.Helper()
+		cmd, err := parseSlashCommand(text)
+		require.NoError(t, err)
+		assert.Equal(t, exp, cmd)
+	}
+	checkErr := func(text string) {
+		t.Helper()
+		_, err := parseSlashCommand(text)
+		assert.Error(t, err, text)
+	}
+
+	check("", &slashCommand{Name: "help"})
+	check("  HELP ", &slashCommand{Name: "help", Args: []string{}})
+	check("list", &slashCommand{Name: "list", Args: []string{}})
+	check(`create "My Service" disk  is full`, &slashCommand{Name: "create", Args: []string{"My Service", "disk is full"}})
+	check(`create “My Service” disk full`, &slashCommand{Name: "create", Args: []string{"My Service", "disk full"}})
+	check("create svc hi", &slashCommand{Name: "create", Args: []string{"svc", "hi"}})
+	check("ack 123", &slashCommand{Name: "ack", Args: []string{"123"}})
+	check("close #5", &slashCommand{Name: "close", Args: []string{"#5"}})
+	check("oncall Primary  Schedule", &slashCommand{Name: "oncall", Args: []string{"Primary Schedule"}})
+
+	checkErr("list foo")
+	checkErr("create svc")
+	checkErr(`create "svc hi`)
+	checkErr("ack")
+	checkErr("ack 1 2")
+	checkErr("ack abc")
+	checkErr("close -1")
+	checkErr("oncall")
+	checkErr("foo")
+}
@@ -0,0 +1,46 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/webhooksig"
+)
+
+// verifySignature checks the X-Slack-Signature header of a request.
+// https://api.slack.com/authentication/verifying-requests-from-slack
+func verifySignature(req *http.Request, body []byte) (*webhooksig.Signed, error) {
+	cfg := config.FromContext(req.Context())
+
+	sig := req.Header.Get("X-Slack-Signature")
+	if sig == "" {
+		return nil, webhooksig.ErrMissing
+	}
+
+	unixSec, err := strconv.ParseInt(req.Header.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil {
+		return nil, webhooksig.ErrInvalid
+	}
+	ts := time.Unix(unixSec, 0)
+
+	if !hmac.Equal([]byte(sig), []byte(Signature(cfg.Slack.SigningSecret, ts, body))) {
+		return nil, webhooksig.ErrInvalid
+	}
+
+	// Slack doesn't provide a nonce, but the signature covers the timestamp and
+	// body so it is unique for each request.
+	return &webhooksig.Signed{Time: ts, Nonce: sig}, nil
+}
+
+// WrapValidation will wrap an http.Handler to do X-Slack-Signature checking. Signatures are recorded
+// in nonces so each request is only accepted once.
+func WrapValidation(h http.Handler, nonces webhooksig.NonceStore) http.Handler {
+	return webhooksig.Wrap(h, webhooksig.Options{
+		Provider: "slack",
+		Verifier: webhooksig.VerifierFunc(verifySignature),
+		Nonces:   nonces,
+	})
+}
@@ -22,6 +22,16 @@ type Status struct {
 	// SrcValue can be used to set/update the source value of the message.
 	SrcValue string
 
+	// DestValue is the destination value (e.g., phone number) of the message, if provided by the status update. It is
+	// only used for metrics.
+	DestValue string
+
+	// ErrorCode is the provider-specific error code of a failed message, if available (e.g., Twilio error 30003).
+	ErrorCode string
+
+	// OptedOut indicates the message failed because the destination has opted out of messages (e.g., by replying STOP).
+	OptedOut bool
+
 	age time.Duration
 }
 
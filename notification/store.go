@@ -26,6 +26,7 @@ const minTimeBetweenTests = time.Minute
 
 type Store struct {
 	db                           *sql.DB
+	readDB                       *sql.DB
 	getCMUserID                  *sql.Stmt
 	setVerificationCode          *sql.Stmt
 	verifyAndEnableContactMethod *sql.Stmt
@@ -36,6 +37,13 @@ type Store struct {
 	sendTestLock                 *sql.Stmt
 	findManyMessageStatuses      *sql.Stmt
 	lastMessageStatus            *sql.Stmt
+	requeueMessages              *sql.Stmt
+	requeueFailedRange           *sql.Stmt
+	cancelMessages               *sql.Stmt
+
+	updateChanLastSendTime  *sql.Stmt
+	setChanVerificationCode *sql.Stmt
+	verifyAndEnableChannel  *sql.Stmt
 
 	origAlertMessage *sql.Stmt
 
@@ -52,7 +60,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	}
 
 	return &Store{
-		db: db,
+		db:     db,
+		readDB: db,
 
 		rand: rand.New(rand.NewSource(seed)),
 
@@ -95,14 +104,50 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		// should result in sending a verification code to the specified contact method
+		// voice ($4) is only changed if set, so a resent code uses the same method as before
 		setVerificationCode: p.P(`
-			insert into user_verification_codes (id, contact_method_id, code, expires_at)
-			values ($1, $2, $3, NOW() + '15 minutes'::interval)
+			insert into user_verification_codes (id, contact_method_id, code, expires_at, voice)
+			values ($1, $2, $3, NOW() + '15 minutes'::interval, coalesce($4, false))
 			on conflict (contact_method_id) do update
+			set
+				sent = false,
+				expires_at = EXCLUDED.expires_at,
+				voice = coalesce($4, user_verification_codes.voice)
+		`),
+
+		// team contact methods are verified the same way, with a code for the channel
+		setChanVerificationCode: p.P(`
+			insert into user_verification_codes (id, channel_id, code, expires_at)
+			values ($1, $2, $3, NOW() + '15 minutes'::interval)
+			on conflict (channel_id) do update
 			set
 				sent = false,
 				expires_at = EXCLUDED.expires_at
 		`),
+		verifyAndEnableChannel: p.P(`
+			with v as (
+				delete from user_verification_codes
+				where channel_id = $1 and code = $2
+				returning channel_id id
+			)
+			update notification_channels nc
+			set disabled = false
+			from v
+			where nc.id = v.id
+			returning nc.id
+		`),
+		updateChanLastSendTime: p.P(`
+			update notification_channels
+			set last_test_verify_at = now()
+			where
+				id = $1 and
+				team_id notnull and
+				(
+					last_test_verify_at + cast($2 as interval) < now()
+					or
+					last_test_verify_at isnull
+				)
+		`),
 
 		// should reactivate a contact method if specified code matches what was set
 		verifyAndEnableContactMethod: p.P(`
@@ -131,12 +176,13 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 
 		insertTestNotification: p.P(`
-			insert into outgoing_messages (id, message_type, contact_method_id, user_id)
+			insert into outgoing_messages (id, message_type, contact_method_id, user_id, request_id)
 			select
 				$1,
 				'test_notification',
 				$2,
-				cm.user_id
+				cm.user_id,
+				nullif($3, '')
 			from user_contact_methods cm
 			where cm.id = $2
 		`),
@@ -173,9 +219,77 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			from outgoing_messages om
 			where message_type = $1 and contact_method_id = $2 and created_at >= $3
 		`),
+
+		requeueMessages: p.P(`
+			update outgoing_messages om
+			set
+				last_status = 'pending',
+				last_status_at = now(),
+				status_details = '',
+				cycle_id = null,
+				next_retry_at = null,
+				retry_count = 0,
+				fired_at = null,
+				sent_at = null,
+				sending_deadline = null,
+				provider_msg_id = null,
+				provider_seq = 0
+			where
+				id = any($1) and
+				last_status = 'failed' and
+				message_type != 'alert_status_update_bundle' and
+				(
+					message_type != 'alert_notification' or
+					exists (select 1 from alerts a where a.id = om.alert_id and a.status != 'closed')
+				)
+		`),
+		requeueFailedRange: p.P(`
+			update outgoing_messages om
+			set
+				last_status = 'pending',
+				last_status_at = now(),
+				status_details = '',
+				cycle_id = null,
+				next_retry_at = null,
+				retry_count = 0,
+				fired_at = null,
+				sent_at = null,
+				sending_deadline = null,
+				provider_msg_id = null,
+				provider_seq = 0
+			where
+				last_status = 'failed' and
+				message_type != 'alert_status_update_bundle' and
+				created_at >= $1 and
+				created_at < $2 and
+				(
+					cardinality($3::text[]) = 0 or
+					exists (select 1 from user_contact_methods cm where cm.id = om.contact_method_id and cm.type::text = any($3))
+				) and
+				($4 = '' or status_details like '%[' || $4 || ']%') and
+				(
+					message_type != 'alert_notification' or
+					exists (select 1 from alerts a where a.id = om.alert_id and a.status != 'closed')
+				)
+		`),
+		cancelMessages: p.P(`
+			update outgoing_messages
+			set
+				last_status = 'failed',
+				last_status_at = now(),
+				status_details = 'canceled by administrator',
+				cycle_id = null,
+				next_retry_at = null
+			where
+				id = any($1) and
+				last_status = 'pending'
+		`),
 	}, p.Err
 }
 
+// UseReadDB will route message log searches to db (e.g., a read replica). Results may lag slightly behind the primary.
+func (s *Store) UseReadDB(db *sql.DB) { s.readDB = db }
+
 // OriginalMessageStatus will return the status of the first alert notification sent to `dest` for the given `alertID`.
 func (s *Store) OriginalMessageStatus(ctx context.Context, alertID int, dst Dest) (*SendResult, error) {
 	err := permission.LimitCheckAny(ctx, permission.System)
@@ -299,7 +413,7 @@ func (s *Store) SendContactMethodTest(ctx context.Context, id string) error {
 	}
 
 	vID := uuid.New().String()
-	_, err = tx.StmtContext(ctx, s.insertTestNotification).ExecContext(ctx, vID, id)
+	_, err = tx.StmtContext(ctx, s.insertTestNotification).ExecContext(ctx, vID, id, log.RequestID(ctx))
 	if err != nil {
 		return err
 	}
@@ -307,7 +421,19 @@ func (s *Store) SendContactMethodTest(ctx context.Context, id string) error {
 	return tx.Commit()
 }
 
+// SendContactMethodVerification will send a new verification code to the contact method, using
+// the same delivery method as the previous code (if any).
 func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string) error {
+	return s.sendContactMethodVerification(ctx, cmID, sql.NullBool{})
+}
+
+// SendContactMethodVerificationVoice will send a new verification code to the contact method. If voice
+// is true, the code is delivered by voice call (e.g., for an SMS contact method that is a landline).
+func (s *Store) SendContactMethodVerificationVoice(ctx context.Context, cmID string, voice bool) error {
+	return s.sendContactMethodVerification(ctx, cmID, sql.NullBool{Bool: voice, Valid: true})
+}
+
+func (s *Store) sendContactMethodVerification(ctx context.Context, cmID string, voice sql.NullBool) error {
 	_, err := s.cmUserID(ctx, cmID)
 	if err != nil {
 		return err
@@ -333,7 +459,7 @@ func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string)
 
 	vcID := uuid.New().String()
 	code := s.rand.Intn(900000) + 100000
-	_, err = tx.StmtContext(ctx, s.setVerificationCode).ExecContext(ctx, vcID, cmID, code)
+	_, err = tx.StmtContext(ctx, s.setVerificationCode).ExecContext(ctx, vcID, cmID, code, voice)
 	if err != nil {
 		return errors.Wrap(err, "set verification code")
 	}
@@ -341,6 +467,69 @@ func (s *Store) SendContactMethodVerification(ctx context.Context, cmID string)
 	return tx.Commit()
 }
 
+// SendTeamChannelVerificationTx will send a new verification code to the team contact method. The
+// caller is responsible for checking the user can manage the team.
+func (s *Store) SendTeamChannelVerificationTx(ctx context.Context, tx *sql.Tx, chanID string) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", chanID)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.StmtContext(ctx, s.updateChanLastSendTime).ExecContext(ctx, chanID, fmt.Sprintf("%f seconds", minTimeBetweenTests.Seconds()))
+	if err != nil {
+		return err
+	}
+	rows, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows != 1 {
+		return validation.NewFieldError("ID", fmt.Sprintf("Too many messages! Please try again in %.0f minute(s)", minTimeBetweenTests.Minutes()))
+	}
+
+	vcID := uuid.New().String()
+	code := s.rand.Intn(900000) + 100000
+	_, err = tx.StmtContext(ctx, s.setChanVerificationCode).ExecContext(ctx, vcID, chanID, code)
+	if err != nil {
+		return errors.Wrap(err, "set verification code")
+	}
+
+	return nil
+}
+
+// VerifyTeamChannelTx will enable the team contact method if code matches the one that was sent. The
+// caller is responsible for checking the user can manage the team.
+func (s *Store) VerifyTeamChannelTx(ctx context.Context, tx *sql.Tx, chanID string, code int) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", chanID)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.StmtContext(ctx, s.verifyAndEnableChannel).ExecContext(ctx, chanID, code)
+	if err != nil {
+		return err
+	}
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if num != 1 {
+		return validation.NewFieldError("code", "invalid code")
+	}
+
+	log.Logf(log.WithField(ctx, "channelID", chanID), "Team contact method ENABLED/VERIFIED.")
+
+	return nil
+}
+
 func (s *Store) VerifyContactMethod(ctx context.Context, cmID string, code int) error {
 	_, err := s.cmUserID(ctx, cmID)
 	if err != nil {
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"text/template"
 	"unicode"
 
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/i18n"
 	"github.com/target/goalert/util"
 )
 
@@ -21,27 +23,35 @@ import (
 // then be 70 or 67 characters for single or multi-segmented messages, respectively.
 const maxGSMLen = 160
 
-var alertTempl = template.Must(template.New("alertSMS").Parse(`{{.AppName}}: Alert #{{.AlertID}}: {{.Summary}}
+// smsFuncs are the template functions for SMS messages; "t" is replaced by localize.
+var smsFuncs = template.FuncMap{"t": fmt.Sprintf}
+
+var alertTempl = template.Must(template.New("alertSMS").Funcs(smsFuncs).Parse(`{{.AppName}}: {{t "Alert #%d" .AlertID}}: {{.Summary}}
 {{- if .Link }}
 
 {{.Link}}{{end}}
 {{- if .Code}}
 
-Reply '{{.Code}}a' to ack, '{{.Code}}e' to escalate, '{{.Code}}c' to close.{{end}}`))
+{{t "Reply '%[1]da' to ack, '%[1]de' to escalate, '%[1]dc' to close." .Code}}{{end}}`))
 
-var bundleTempl = template.Must(template.New("alertBundleSMS").Parse(`{{.AppName}}: Svc '{{.ServiceName}}': {{.Count}} unacked alert{{if gt .Count 1}}s{{end}}
+var bundleTempl = template.Must(template.New("alertBundleSMS").Funcs(smsFuncs).Parse(`{{.AppName}}: {{if gt .Count 1}}{{t "Svc '%s': %d unacked alerts" .ServiceName .Count}}{{else}}{{t "Svc '%s': %d unacked alert" .ServiceName .Count}}{{end}}
 
 {{- if .Link }}
 
 	{{.Link}}
 {{end}}
 {{- if .Code}}
-	Reply '{{.Code}}aa' to ack all, '{{.Code}}cc' to close all.{{end}}`))
+	{{t "Reply '%[1]daa' to ack all, '%[1]dcc' to close all." .Code}}{{end}}`))
 
-var statusTempl = template.Must(template.New("alertStatusSMS").Parse(`{{.AppName}}: Alert #{{.AlertID}}{{- if .Summary }}: {{.Summary}}{{end}}
+var statusTempl = template.Must(template.New("alertStatusSMS").Funcs(smsFuncs).Parse(`{{.AppName}}: {{t "Alert #%d" .AlertID}}{{- if .Summary }}: {{.Summary}}{{end}}
 
 	{{.LogEntry}}`))
 
+// localize returns a copy of tmpl that translates text for the locale of dest.
+func localize(tmpl *template.Template, dest notification.Dest) *template.Template {
+	return template.Must(tmpl.Clone()).Funcs(template.FuncMap{"t": i18n.NewPrinter(dest.Locale).Sprintf})
+}
+
 const gsmAlphabet = "@∆ 0¡P¿p£!1AQaq$Φ\"2BRbr¥Γ#3CScsèΛ¤4DTdtéΩ%5EUeuùΠ&6FVfvìΨ'7GWgwòΣ(8HXhxÇΘ)9IYiy\n Ξ *:JZjzØ+;KÄkäøÆ,<LÖlö\ræ-=MÑmñÅß.>NÜnüåÉ/?O§oà"
 
 var gsmChr = make(map[rune]bool, len(gsmAlphabet))
@@ -183,10 +193,11 @@ func renderAlertMessage(appName string, a notification.Alert, link string, code
 	data.Link = link
 	data.Code = code
 
+	tmpl := localize(alertTempl, a.Dest)
 	result, err := renderMinGSMSegments([]string{a.Summary}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.Summary = inputs[0]
-		err := alertTempl.Execute(&buf, data)
+		err := tmpl.Execute(&buf, data)
 		if err != nil {
 			return "", err
 		}
@@ -211,11 +222,12 @@ func renderAlertStatusMessage(appName string, a notification.AlertStatus) (strin
 	}
 	data.AppName = appName
 	data.AlertStatus = a
+	tmpl := localize(statusTempl, a.Dest)
 	result, err := renderMinGSMSegments([]string{a.Summary, a.LogEntry}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.Summary = inputs[0]
 		data.LogEntry = inputs[1]
-		err := statusTempl.Execute(&buf, data)
+		err := tmpl.Execute(&buf, data)
 		if err != nil {
 			return "", err
 		}
@@ -246,10 +258,11 @@ func renderAlertBundleMessage(appName string, a notification.AlertBundle, link s
 	data.Link = link
 	data.Code = code
 
+	tmpl := localize(bundleTempl, a.Dest)
 	result, err := renderMinGSMSegments([]string{data.AlertBundle.ServiceName}, func(inputs []string) (string, error) {
 		buf.Reset()
 		data.ServiceName = inputs[0]
-		err := bundleTempl.Execute(&buf, data)
+		err := tmpl.Execute(&buf, data)
 		if err != nil {
 			return "", err
 		}
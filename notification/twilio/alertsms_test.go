@@ -131,6 +131,19 @@ https://example.com/alerts/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff/123ff
 
 Reply '123456789a' to ack, '123456789e' to escalate, '123456789c' to close.`,
 	)
+
+	check("localized",
+		notification.Alert{
+			Dest:    notification.Dest{Locale: "es"},
+			AlertID: 123,
+			Summary: "Testing",
+		},
+		"",
+		1,
+		`TestApp: Alerta #123: Testing
+
+Responda '1a' para reconocer, '1e' para escalar, '1c' para cerrar.`,
+	)
 }
 
 func TestSMS_RenderAlertBundle(t *testing.T) {
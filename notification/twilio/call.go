@@ -2,6 +2,7 @@ package twilio
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/target/goalert/notification"
@@ -78,6 +79,9 @@ func (call *Call) messageStatus() *notification.Status {
 	} else {
 		status.Details = string(call.Status)
 	}
+	if call.ErrorCode != nil {
+		status.ErrorCode = strconv.Itoa(int(*call.ErrorCode))
+	}
 	if call.SequenceNumber != nil {
 		status.Sequence = *call.SequenceNumber
 	}
@@ -96,5 +100,6 @@ func (call *Call) messageStatus() *notification.Status {
 	}
 
 	status.SrcValue = call.From
+	status.DestValue = call.To
 	return &status
 }
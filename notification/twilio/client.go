@@ -99,6 +99,9 @@ func (c *Config) get(ctx context.Context, urlStr string) (*http.Response, error)
 	req.Header.Set("X-Twilio-Signature", string(Signature(cfg.Twilio.AuthToken, urlStr, nil)))
 	req.SetBasicAuth(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken)
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	if id := log.RequestID(ctx); id != "" {
+		req.Header.Set("X-Request-ID", id)
+	}
 
 	return c.httpClient().Do(req)
 }
@@ -113,9 +116,33 @@ func (c *Config) post(ctx context.Context, urlStr string, v url.Values) (*http.R
 	req.Header.Set("X-Twilio-Signature", string(Signature(cfg.Twilio.AuthToken, urlStr, v)))
 	req.SetBasicAuth(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken)
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	if id := log.RequestID(ctx); id != "" {
+		req.Header.Set("X-Request-ID", id)
+	}
 	return c.httpClient().Do(req)
 }
 
+// CheckAccount will verify that Twilio is reachable and the configured credentials are valid.
+func (c *Config) CheckAccount(ctx context.Context) error {
+	cfg := config.FromContext(ctx)
+	resp, err := c.get(ctx, c.url("Accounts", cfg.Twilio.AccountSID+".json"))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var e Exception
+		err = json.NewDecoder(resp.Body).Decode(&e)
+		if err != nil {
+			return errors.Errorf("unexpected response: %s", resp.Status)
+		}
+		return &e
+	}
+
+	return nil
+}
+
 // GetSMS will return the current state of a Message from Twilio.
 func (c *Config) GetSMS(ctx context.Context, sid string) (*Message, error) {
 	cfg := config.FromContext(ctx)
@@ -181,6 +208,78 @@ func (c *Config) GetVoice(ctx context.Context, sid string) (*Call, error) {
 	return &v, nil
 }
 
+// outboundSince returns true if a listed resource was created by an API request at or after since.
+func outboundSince(direction, dateCreated string, since time.Time) (bool, error) {
+	if direction != "outbound-api" {
+		return false, nil
+	}
+	created, err := time.Parse(time.RFC1123Z, dateCreated)
+	if err != nil {
+		return false, errors.Wrap(err, "parse date_created")
+	}
+
+	// date_created only has second precision
+	return !created.Before(since.Truncate(time.Second)), nil
+}
+
+// list fetches the most recent page of resources (e.g., Messages) to the given number into v.
+func (c *Config) list(ctx context.Context, resource, to string, v interface{}) error {
+	cfg := config.FromContext(ctx)
+	q := make(url.Values)
+	q.Set("To", to)
+	q.Set("PageSize", "50")
+	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, resource+".json") + "?" + q.Encode()
+	resp, err := c.get(ctx, urlStr)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != 200 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return errors.Wrap(err, "parse error response")
+		}
+		return &e
+	}
+
+	return errors.Wrap(json.Unmarshal(data, v), "parse list response")
+}
+
+// FindSMS will return the most recent Message with the given body sent via the API to the given number at or after since, if any.
+func (c *Config) FindSMS(ctx context.Context, to, body string, since time.Time) (*Message, error) {
+	var res struct {
+		Messages []struct {
+			Message
+			Body        string
+			Direction   string
+			DateCreated string `json:"date_created"`
+		}
+	}
+	err := c.list(ctx, "Messages", to, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range res.Messages {
+		ok, err := outboundSince(m.Direction, m.DateCreated, since)
+		if err != nil {
+			return nil, err
+		}
+		if ok && m.Body == body {
+			return &m.Message, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // CallbackURL will return the callback url for the given configuration.
 func (voice *VoiceOptions) CallbackURL(cfg config.Config) (string, error) {
 	if voice == nil {
@@ -232,6 +331,9 @@ func (voice *VoiceOptions) setMsgParams(msg notification.Message) (err error) {
 	}
 
 	voice.Params.Set(msgParamSubID, strconv.Itoa(subID))
+	if locale := msg.Destination().Locale; locale != "" {
+		voice.Params.Set(msgParamLocale, locale)
+	}
 	voice.CallbackParams.Set(msgParamID, msg.ID())
 
 	return nil
@@ -1,10 +1,17 @@
 package twilio
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 )
 
@@ -182,3 +189,35 @@ func TestSetMsgBody(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestConfig_FindSMS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", req.URL.Path)
+		assert.Equal(t, "+16125551234", req.URL.Query().Get("To"))
+		_, _ = io.WriteString(w, `{"messages": [
+			{"sid": "SM4", "to": "+16125551234", "body": "GoAlert: Verification code: 123456", "status": "queued", "direction": "outbound-api", "date_created": "Sun, 01 Oct 2023 12:00:10 +0000"},
+			{"sid": "SM3", "to": "+16125551234", "body": "GoAlert: Alert #1: test", "status": "received", "direction": "inbound", "date_created": "Sun, 01 Oct 2023 12:00:09 +0000"},
+			{"sid": "SM2", "to": "+16125551234", "body": "GoAlert: Alert #1: test", "status": "queued", "direction": "outbound-api", "date_created": "Sun, 01 Oct 2023 12:00:05 +0000"},
+			{"sid": "SM1", "to": "+16125551234", "body": "GoAlert: Alert #1: test", "status": "delivered", "direction": "outbound-api", "date_created": "Sun, 01 Oct 2023 11:00:00 +0000"}
+		]}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Twilio.AccountSID = "AC123"
+	ctx := cfg.Context(context.Background())
+	c := &Config{BaseURL: srv.URL}
+
+	msg, err := c.FindSMS(ctx, "+16125551234", "GoAlert: Alert #1: test", time.Date(2023, 10, 1, 12, 0, 5, 500, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Equal(t, "SM2", msg.SID)
+
+	msg, err = c.FindSMS(ctx, "+16125551234", "GoAlert: Alert #1: test", time.Date(2023, 10, 1, 12, 0, 6, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, msg, "inbound and older messages are ignored")
+
+	msg, err = c.FindSMS(ctx, "+16125551234", "GoAlert: Alert #2: other", time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, msg, "unrelated messages to the same number are not delivery of this one")
+}
@@ -0,0 +1,143 @@
+package twilio
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+)
+
+const (
+	// ConferenceAccessCodeDigits is the number of digits in a conference bridge access code.
+	ConferenceAccessCodeDigits = 6
+
+	// ConferenceMenuDigit is pressed from the inbound call menu to join a conference bridge.
+	ConferenceMenuDigit = digitConference
+)
+
+// ConferenceName returns the name of the Twilio conference for the given alert.
+func ConferenceName(alertID int) string {
+	return fmt.Sprintf("goalert-alert-%d", alertID)
+}
+
+// DialConference will call the given number and, once answered, read the announcement
+// and join the callee to the conference bridge for the alert.
+func (c *Config) DialConference(ctx context.Context, to string, alertID int, announcement string) (*Call, error) {
+	cfg := config.FromContext(ctx)
+
+	say := (&twiMLResponse{
+		voiceName:     cfg.Twilio.VoiceName,
+		voiceLanguage: cfg.Twilio.VoiceLanguage,
+	}).sayVerb(announcement)
+	doc := twimlResponse{Verbs: []any{
+		say,
+		verbDial{Nouns: []any{verbConference{Name: ConferenceName(alertID)}}},
+	}}
+	var buf bytes.Buffer
+	err := xml.NewEncoder(&buf).Encode(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode TwiML")
+	}
+
+	v := make(url.Values)
+	v.Set("To", to)
+	v.Set("From", cfg.Twilio.FromNumber)
+	v.Set("Twiml", buf.String())
+	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Calls.json")
+
+	resp, err := c.post(ctx, urlStr, v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 201 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse error response")
+		}
+		return nil, &e
+	}
+
+	var call Call
+	err = json.Unmarshal(data, &call)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse voice call response")
+	}
+
+	return &call, nil
+}
+
+// hasActiveBridges returns true if any open alert has a conference bridge.
+func (v *Voice) hasActiveBridges(ctx context.Context) bool {
+	var ok bool
+	err := v.activeBridges.QueryRowContext(ctx).Scan(&ok)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "check for active conference bridges"))
+		return false
+	}
+
+	return ok
+}
+
+// ServeConference joins an inbound caller to a conference bridge using its access code.
+func (v *Voice) ServeConference(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, errResp := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	resp := newTwiMLResponse(ctx, w)
+	if call.Outbound {
+		resp.Say("Conference bridges are not available.").Hangup()
+		return
+	}
+
+	if call.Digits == "" {
+		resp.Sayf("Please enter the %d-digit access code.", ConferenceAccessCodeDigits)
+		resp.GatherDigits(v.callbackURL(ctx, call.Q, CallTypeConference), ConferenceAccessCodeDigits)
+		return
+	}
+
+	var alertID int
+	err := doDeadline(ctx, func() error {
+		return v.bridgeByCode.QueryRowContext(ctx, call.Digits).Scan(&alertID)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		attempts, _ := strconv.Atoi(call.Q.Get("attempts"))
+		attempts++
+		if attempts >= 3 {
+			resp.Say("That access code is not valid.").Hangup()
+			return
+		}
+		call.Q.Set("attempts", strconv.Itoa(attempts))
+		resp.Say("That access code is not valid.")
+		resp.Sayf("Please enter the %d-digit access code.", ConferenceAccessCodeDigits)
+		resp.GatherDigits(v.callbackURL(ctx, call.Q, CallTypeConference), ConferenceAccessCodeDigits)
+		return
+	}
+	if errResp(false, errors.Wrap(err, "lookup conference bridge"), "Failed to lookup conference bridge.") {
+		return
+	}
+
+	resp.Sayf("Joining the conference bridge for alert number %s.", spellNumber(alertID))
+	resp.DialConference(ConferenceName(alertID))
+}
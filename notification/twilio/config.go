@@ -4,7 +4,10 @@ import (
 	"database/sql"
 	"net/http"
 
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/webhooksig"
 )
 
 const (
@@ -12,6 +15,7 @@ const (
 	msgParamSubID  = "msgSubjectID"
 	msgParamBody   = "msgBody"
 	msgParamBundle = "msgBundle"
+	msgParamLocale = "msgLocale"
 )
 
 // Config contains the details needed to interact with Twilio for SMS
@@ -28,4 +32,13 @@ type Config struct {
 
 	// DB is used for storing DB connection data (needed for carrier metadata dbtx).
 	DB *sql.DB
+
+	// AlertStore is used for creating alerts from inbound incident reports.
+	AlertStore *alert.Store
+
+	// OnCallStore is used for forwarding inbound calls to on-call users.
+	OnCallStore *oncall.Store
+
+	// NonceStore is used to reject replayed message and status callback requests. If nil, replays are not checked.
+	NonceStore webhooksig.NonceStore
 }
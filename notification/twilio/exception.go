@@ -1,6 +1,9 @@
 package twilio
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 // Exception contains information on a Twilio error.
 type Exception struct {
@@ -13,3 +16,9 @@ type Exception struct {
 func (e Exception) Error() string {
 	return fmt.Sprintf("%d: %s", e.Code, e.Message)
 }
+
+// ProviderErrorCode returns the Twilio error code.
+func (e Exception) ProviderErrorCode() string { return strconv.Itoa(e.Code) }
+
+// OptedOut returns true if the recipient has opted out of messages (i.e., replied STOP).
+func (e Exception) OptedOut() bool { return e.Code == int(MessageErrorCodeUnsubscribed) }
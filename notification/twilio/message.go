@@ -2,6 +2,7 @@ package twilio
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/target/goalert/notification"
 )
@@ -57,6 +58,9 @@ const (
 	MessageErrorCodeUnknown             = MessageErrorCode(30008)
 	MessageErrorCodeMissingSegment      = MessageErrorCode(30009)
 	MessageErrorCodeExceedsMaxPrice     = MessageErrorCode(30010)
+
+	// MessageErrorCodeUnsubscribed is returned when the recipient has opted out (e.g., by replying STOP).
+	MessageErrorCodeUnsubscribed = MessageErrorCode(21610)
 )
 
 // Message represents a Twilio message.
@@ -92,6 +96,10 @@ func (msg *Message) messageStatus() *notification.Status {
 	} else {
 		status.Details = string(msg.Status)
 	}
+	if msg.ErrorCode != nil {
+		status.ErrorCode = strconv.Itoa(int(*msg.ErrorCode))
+		status.OptedOut = *msg.ErrorCode == MessageErrorCodeUnsubscribed
+	}
 	switch msg.Status {
 	case MessageStatusFailed:
 		if msg.ErrorCode != nil &&
@@ -109,5 +117,6 @@ func (msg *Message) messageStatus() *notification.Status {
 	}
 
 	status.SrcValue = msg.From
+	status.DestValue = msg.To
 	return &status
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/i18n"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
@@ -79,24 +80,26 @@ func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Stat
 	return msg.messageStatus(), nil
 }
 
-// Send implements the notification.Sender interface.
-func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
-	cfg := config.FromContext(ctx)
-	if !cfg.Twilio.Enable {
-		return nil, errors.New("Twilio provider is disabled")
-	}
-	if msg.Destination().Type != notification.DestTypeSMS {
-		return nil, errors.Errorf("unsupported destination type %s; expected SMS", msg.Destination().Type)
-	}
+// FindSent implements the notification.SentFinder interface. Messages are matched by their exact body.
+func (s *SMS) FindSent(ctx context.Context, msg notification.Message, since time.Time) (*notification.SentMessage, error) {
 	destNumber := msg.Destination().Value
-	if destNumber == cfg.Twilio.FromNumber {
-		return nil, errors.New("refusing to send outgoing SMS to FromNumber")
+	body, err := s.render(ctx, msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "render message")
 	}
 
-	ctx = log.WithFields(ctx, log.Fields{
-		"Phone": destNumber,
-		"Type":  "TwilioSMS",
-	})
+	sent, err := s.c.FindSMS(ctx, destNumber, body, since)
+	if err != nil || sent == nil {
+		return nil, err
+	}
+
+	return sent.sentMessage(), nil
+}
+
+// render returns the SMS body for msg.
+func (s *SMS) render(ctx context.Context, msg notification.Message) (string, error) {
+	cfg := config.FromContext(ctx)
+	destNumber := msg.Destination().Value
 
 	makeSMSCode := func(alertID int, serviceID string) int {
 		if !hasTwoWaySMSSupport(ctx, destNumber) {
@@ -112,32 +115,52 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification
 		return code
 	}
 
-	var message string
-	var err error
 	switch t := msg.(type) {
 	case notification.AlertStatus:
-		message, err = renderAlertStatusMessage(cfg.ApplicationName(), t)
+		return renderAlertStatusMessage(cfg.ApplicationName(), t)
 	case notification.AlertBundle:
 		var link string
 		if canContainURL(ctx, destNumber) {
 			link = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
 		}
 
-		message, err = renderAlertBundleMessage(cfg.ApplicationName(), t, link, makeSMSCode(0, t.ServiceID))
+		return renderAlertBundleMessage(cfg.ApplicationName(), t, link, makeSMSCode(0, t.ServiceID))
 	case notification.Alert:
 		var link string
 		if canContainURL(ctx, destNumber) {
 			link = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
 		}
 
-		message, err = renderAlertMessage(cfg.ApplicationName(), t, link, makeSMSCode(t.AlertID, ""))
+		return renderAlertMessage(cfg.ApplicationName(), t, link, makeSMSCode(t.AlertID, ""))
 	case notification.Test:
-		message = fmt.Sprintf("%s: Test message.", cfg.ApplicationName())
+		return i18n.NewPrinter(t.Dest.Locale).Sprintf("%s: Test message.", cfg.ApplicationName()), nil
 	case notification.Verification:
-		message = fmt.Sprintf("%s: Verification code: %d", cfg.ApplicationName(), t.Code)
-	default:
-		return nil, errors.Errorf("unhandled message type %T", t)
+		return i18n.NewPrinter(t.Dest.Locale).Sprintf("%s: Verification code: %d", cfg.ApplicationName(), t.Code), nil
+	}
+
+	return "", errors.Errorf("unhandled message type %T", msg)
+}
+
+// Send implements the notification.Sender interface.
+func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Twilio.Enable {
+		return nil, errors.New("Twilio provider is disabled")
+	}
+	if msg.Destination().Type.SenderType() != notification.DestTypeSMS {
+		return nil, errors.Errorf("unsupported destination type %s; expected SMS", msg.Destination().Type)
 	}
+	destNumber := msg.Destination().Value
+	if destNumber == cfg.Twilio.FromNumber {
+		return nil, errors.New("refusing to send outgoing SMS to FromNumber")
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Phone": destNumber,
+		"Type":  "TwilioSMS",
+	})
+
+	message, err := s.render(ctx, msg)
 	if err != nil {
 		return nil, errors.Wrap(err, "render message")
 	}
@@ -155,6 +178,7 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification
 
 	// If the message was sent successfully, reset reply limits.
 	s.limit.Reset(destNumber)
+	s.c.recordSpend(ctx, "sms", destNumber)
 
 	return resp.sentMessage(), nil
 }
@@ -178,7 +202,10 @@ func (s *SMS) ServeStatusCallback(w http.ResponseWriter, req *http.Request) {
 		"Phone":  number,
 		"Type":   "TwilioSMS",
 	})
-	msg := Message{SID: sid, Status: status, From: req.FormValue("From")}
+	msg := Message{SID: sid, Status: status, To: number, From: req.FormValue("From")}
+	if code, err := strconv.Atoi(req.FormValue("ErrorCode")); err == nil {
+		msg.ErrorCode = (*MessageErrorCode)(&code)
+	}
 
 	log.Debugf(ctx, "Got Twilio SMS status callback.")
 
@@ -338,7 +365,7 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 		if err != nil {
 			log.Debug(ctx, errors.Wrap(err, "parse alertID"))
 		} else {
-			ctx = log.WithField(ctx, "AlertID", alertID)
+			ctx = log.WithField(ctx, log.FieldAlertID, alertID)
 			lookupFn = func() (*codeInfo, error) { return s.b.LookupByAlertID(ctx, from, alertID) }
 		}
 	} else if m := svcReplyRx.FindStringSubmatch(body); len(m) == 3 {
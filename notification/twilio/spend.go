@@ -0,0 +1,179 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/phonenumbers"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Spend is the estimated cost of messages sent to a region, by message type.
+type Spend struct {
+	Type   string // "sms" or "voice"
+	Region string
+	Count  int
+	Cost   float64
+}
+
+// SpendSummary is the estimated Twilio spend over a time range.
+type SpendSummary struct {
+	Start, End time.Time
+
+	// Total is the estimated cost of all messages in the range.
+	Total float64
+
+	// Today is the estimated cost of all messages for the current day (UTC).
+	Today float64
+
+	Regions []Spend
+}
+
+// budgetAlert tracks the last day (UTC) a budget alert was created, to avoid creating it for every message.
+var budgetAlert struct {
+	sync.Mutex
+	day string
+}
+
+func regionCode(number string) string {
+	num, err := phonenumbers.Parse(number, "")
+	if err != nil {
+		return "ZZ"
+	}
+	region := phonenumbers.GetRegionCodeForNumber(num)
+	if region == "" {
+		return "ZZ"
+	}
+
+	return region
+}
+
+// recordSpend will add the estimated cost of a message to the daily spend totals, creating
+// a budget alert if the daily budget has been exceeded.
+//
+// Errors are logged, as the message has already been sent.
+func (c *Config) recordSpend(ctx context.Context, msgType, number string) {
+	if c.DB == nil {
+		return
+	}
+	cfg := config.FromContext(ctx)
+	rates := cfg.Twilio.SMSRates
+	if msgType == "voice" {
+		rates = cfg.Twilio.VoiceRates
+	}
+	region := regionCode(number)
+	cost := config.TwilioRate(rates, region)
+
+	_, err := c.DB.ExecContext(ctx, `
+		insert into twilio_spend (day, message_type, region, message_count, estimated_cost)
+		values ((now() at time zone 'UTC')::date, $1, $2, 1, $3)
+		on conflict (day, message_type, region) do update
+		set
+			message_count = twilio_spend.message_count + 1,
+			estimated_cost = twilio_spend.estimated_cost + excluded.estimated_cost
+	`, msgType, region, cost)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "record twilio spend"))
+		return
+	}
+
+	if cfg.Twilio.DailyBudget <= 0 || cfg.Twilio.BudgetAlertServiceID == "" || c.AlertStore == nil || cost == 0 {
+		return
+	}
+
+	var day string
+	var total float64
+	err = c.DB.QueryRowContext(ctx, `
+		select (now() at time zone 'UTC')::date::text, coalesce(sum(estimated_cost), 0)::float8
+		from twilio_spend
+		where day = (now() at time zone 'UTC')::date
+	`).Scan(&day, &total)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "read twilio spend"))
+		return
+	}
+	if total <= float64(cfg.Twilio.DailyBudget) {
+		return
+	}
+
+	budgetAlert.Lock()
+	alerted := budgetAlert.day == day
+	budgetAlert.day = day
+	budgetAlert.Unlock()
+	if alerted {
+		return
+	}
+
+	serviceID := cfg.Twilio.BudgetAlertServiceID
+	_, _, err = c.AlertStore.CreateOrUpdate(permission.ServiceContext(ctx, serviceID), &alert.Alert{
+		Summary:   fmt.Sprintf("Twilio daily budget exceeded: estimated spend %.2f of %d", total, cfg.Twilio.DailyBudget),
+		Details:   fmt.Sprintf("The estimated Twilio spend for %s (UTC) has exceeded the configured daily budget (Twilio.DailyBudget).\n\nEstimates are based on Twilio.SMSRates and Twilio.VoiceRates and may differ from actual charges.", day),
+		Status:    alert.StatusTriggered,
+		Source:    alert.SourceManual,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup("twilio-budget:" + day),
+	})
+	if err != nil {
+		// allow another attempt on the next message
+		budgetAlert.Lock()
+		budgetAlert.day = ""
+		budgetAlert.Unlock()
+		log.Log(ctx, errors.Wrap(err, "create twilio budget alert"))
+	}
+}
+
+// Spend returns a summary of the estimated Twilio spend for all days (UTC) overlapping the given range.
+func (c *Config) Spend(ctx context.Context, start, end time.Time) (*SpendSummary, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Range("Days", int(end.Sub(start).Hours()/24), 0, 366)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.DB.QueryContext(ctx, `
+		select message_type, region, sum(message_count)::int, sum(estimated_cost)::float8
+		from twilio_spend
+		where day between ($1 at time zone 'UTC')::date and ($2 at time zone 'UTC')::date
+		group by message_type, region
+		order by 4 desc, 1, 2
+	`, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "query twilio spend")
+	}
+	defer rows.Close()
+
+	s := &SpendSummary{Start: start, End: end}
+	for rows.Next() {
+		var sp Spend
+		err = rows.Scan(&sp.Type, &sp.Region, &sp.Count, &sp.Cost)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan twilio spend")
+		}
+		s.Total += sp.Cost
+		s.Regions = append(s.Regions, sp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "query twilio spend")
+	}
+
+	err = c.DB.QueryRowContext(ctx, `
+		select coalesce(sum(estimated_cost), 0)::float8
+		from twilio_spend
+		where day = (now() at time zone 'UTC')::date
+	`).Scan(&s.Today)
+	if err != nil {
+		return nil, errors.Wrap(err, "read twilio spend")
+	}
+
+	return s, nil
+}
@@ -4,11 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/xml"
-	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/i18n"
 )
 
 type twiMLResponse struct {
@@ -17,11 +17,20 @@ type twiMLResponse struct {
 	voiceName     string
 	voiceLanguage string
 
+	p *i18n.Printer
+
 	gatherURL        string
+	gatherDigits     int
 	redirectURL      string
 	redirectPauseSec int
 	hangup           bool
 
+	recordURL          string
+	transcribeURL      string
+	recordMaxLengthSec int
+
+	dial *verbDial
+
 	hasOptions     bool
 	expectResponse bool
 
@@ -32,11 +41,30 @@ type twiMLResponse struct {
 
 func newTwiMLResponse(ctx context.Context, w http.ResponseWriter) *twiMLResponse {
 	cfg := config.FromContext(ctx)
-	return &twiMLResponse{
+	t := &twiMLResponse{
 		voiceName:     cfg.Twilio.VoiceName,
 		voiceLanguage: cfg.Twilio.VoiceLanguage,
+		p:             i18n.NewPrinter(localeFromContext(ctx)),
 		w:             w,
 	}
+	if lang := t.p.VoiceLanguage(); lang != "" {
+		// the configured voice may not support the language
+		t.voiceLanguage = lang
+		t.voiceName = ""
+	}
+	return t
+}
+
+type localeKey struct{}
+
+// withLocale returns a context that will render responses for the given locale.
+func withLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func localeFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
 }
 
 func (t *twiMLResponse) Redirect(url string) {
@@ -63,6 +91,9 @@ const (
 	optionCloseAll
 	optionStop
 	optionRepeat
+	optionReport
+	optionForward
+	optionConference
 )
 
 func (t *twiMLResponse) AddOptions(options ...menuOption) {
@@ -78,7 +109,16 @@ func (t *twiMLResponse) AddOptions(options ...menuOption) {
 		case optionStop:
 			t.Sayf("To disable voice notifications to this number, press %s.", digitStop)
 		case optionRepeat:
-			t.Sayf("To repeat this message, press %s.", sayRepeat)
+			t.Sayf("To repeat this message, press %s.", t.p.Text(sayRepeat))
+		case optionForward:
+			t.expectResponse = true
+			t.Sayf("To speak with the on-call responder, press %s.", digitForward)
+		case optionConference:
+			t.expectResponse = true
+			t.Sayf("To join a conference bridge, press %s.", digitConference)
+		case optionReport:
+			t.expectResponse = true
+			t.Sayf("To report an incident, press %s.", digitReport)
 		case optionAck:
 			t.expectResponse = true
 			t.Sayf("To acknowledge, press %s.", digitAck)
@@ -109,19 +149,59 @@ func (t *twiMLResponse) Gather(url string) {
 	t.sendResponse()
 }
 
+// GatherDigits will collect a fixed number of digits (e.g., an access code) and post them to url.
+func (t *twiMLResponse) GatherDigits(url string, numDigits int) {
+	t.gatherURL = url
+	t.gatherDigits = numDigits
+	t.sendResponse()
+}
+
 func (t *twiMLResponse) SayUnknownDigit() *twiMLResponse {
 	t.Say("Sorry, I didn't understand that.")
 	return t
 }
 
+// Say will add text to the response, translated if a translation exists.
 func (t *twiMLResponse) Say(text string) *twiMLResponse {
-	t.say = append(t.say, text)
+	t.say = append(t.say, t.p.Text(text))
 
 	return t
 }
 
 func (t *twiMLResponse) Sayf(format string, args ...interface{}) *twiMLResponse {
-	return t.Say(fmt.Sprintf(format, args...))
+	return t.Say(t.p.Sprintf(format, args...))
+}
+
+// Record will record the caller until they press the pound key or maxLengthSec is reached,
+// then post the recording to url. If transcribeURL is set, the transcription will be posted
+// to it once available.
+//
+// If nothing is recorded, the call is ended.
+func (t *twiMLResponse) Record(url, transcribeURL string, maxLengthSec int) {
+	t.recordURL = url
+	t.transcribeURL = transcribeURL
+	t.recordMaxLengthSec = maxLengthSec
+	t.hangup = true
+	t.sendResponse()
+}
+
+// Dial will connect the caller to the first of the given numbers to answer, ringing them
+// simultaneously. Once the call ends, or no one answers within timeoutSec, the result is
+// posted to url.
+func (t *twiMLResponse) Dial(url string, timeoutSec int, numbers ...string) {
+	d := &verbDial{Action: url, TimeoutSec: timeoutSec}
+	for _, n := range numbers {
+		d.Nouns = append(d.Nouns, verbNumber{Number: n})
+	}
+	t.dial = d
+	t.sendResponse()
+}
+
+// DialConference will connect the caller to the named conference.
+func (t *twiMLResponse) DialConference(name string) {
+	t.dial = &verbDial{Nouns: []any{verbConference{Name: name}}}
+	t.hangup = true
+	t.sendResponse()
 }
 
 func (t *twiMLResponse) Hangup() {
@@ -158,6 +238,29 @@ type verbRedirect struct {
 type verbHangup struct {
 	XMLName xml.Name `xml:"Hangup"`
 }
+type verbRecord struct {
+	XMLName            xml.Name `xml:"Record"`
+	Action             string   `xml:"action,attr"`
+	MaxLengthSec       int      `xml:"maxLength,attr"`
+	FinishOnKey        string   `xml:"finishOnKey,attr"`
+	PlayBeep           bool     `xml:"playBeep,attr"`
+	Transcribe         bool     `xml:"transcribe,attr,omitempty"`
+	TranscribeCallback string   `xml:"transcribeCallback,attr,omitempty"`
+}
+type verbNumber struct {
+	XMLName xml.Name `xml:"Number"`
+	Number  string   `xml:",chardata"`
+}
+type verbConference struct {
+	XMLName xml.Name `xml:"Conference"`
+	Name    string   `xml:",chardata"`
+}
+type verbDial struct {
+	XMLName    xml.Name `xml:"Dial"`
+	Action     string   `xml:"action,attr,omitempty"`
+	TimeoutSec int      `xml:"timeout,attr,omitempty"`
+	Nouns      []any    `xml:",any"`
+}
 type verbGather struct {
 	XMLName    xml.Name `xml:"Gather"`
 	NumDigits  int      `xml:"numDigits,attr"`
@@ -166,6 +269,17 @@ type verbGather struct {
 	Verbs      []any    `xml:",any"`
 }
 
+func (t *twiMLResponse) sayVerb(text string) verbSay {
+	return verbSay{
+		Language: t.voiceLanguage,
+		Voice:    t.voiceName,
+		Prosody: &prosody{
+			Rate: "slow",
+			Text: text,
+		},
+	}
+}
+
 func (t *twiMLResponse) sendResponse() {
 	if t.sent {
 		panic("Response already sent")
@@ -179,17 +293,7 @@ func (t *twiMLResponse) sendResponse() {
 
 	var doc twimlResponse
 	for _, text := range t.say {
-		doc.Verbs = append(
-			doc.Verbs,
-			verbSay{
-				Language: t.voiceLanguage,
-				Voice:    t.voiceName,
-				Prosody: &prosody{
-					Rate: "slow",
-					Text: text,
-				},
-			},
-		)
+		doc.Verbs = append(doc.Verbs, t.sayVerb(text))
 	}
 
 	if t.redirectPauseSec > 0 {
@@ -201,14 +305,37 @@ func (t *twiMLResponse) sendResponse() {
 	}
 
 	if t.gatherURL != "" {
+		numDigits := t.gatherDigits
+		if numDigits == 0 {
+			numDigits = 1
+		}
 		doc.Verbs = []any{verbGather{
 			Action:     t.gatherURL,
 			TimeoutSec: 10,
-			NumDigits:  1,
+			NumDigits:  numDigits,
 			Verbs:      doc.Verbs,
 		}}
 	}
 
+	if t.dial != nil {
+		doc.Verbs = append(doc.Verbs, *t.dial)
+	}
+
+	if t.recordURL != "" {
+		doc.Verbs = append(doc.Verbs,
+			verbRecord{
+				Action:             t.recordURL,
+				MaxLengthSec:       t.recordMaxLengthSec,
+				FinishOnKey:        "#",
+				PlayBeep:           true,
+				Transcribe:         t.transcribeURL != "",
+				TranscribeCallback: t.transcribeURL,
+			},
+			// only reached if nothing was recorded
+			t.sayVerb(t.p.Text("No message was recorded. Goodbye.")),
+		)
+	}
+
 	if t.hangup {
 		doc.Verbs = append(doc.Verbs, verbHangup{})
 	}
@@ -180,6 +180,103 @@ func TestTwiMLResponse(t *testing.T) {
 			<prosody rate="slow">To repeat this message, press star.</prosody>
 		</Say>
 	</Gather>
+</Response>`, string(data))
+	})
+	t.Run("record", func(t *testing.T) {
+		var mockConfig config.Config
+		ctx := mockConfig.Context(context.Background())
+		rec := httptest.NewRecorder()
+
+		r := newTwiMLResponse(ctx, rec)
+		r.Say("Hello")
+		r.Record("http://example.com/recorded", "http://example.com/transcribed", 120)
+
+		resp := rec.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/xml")
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+	<Say>
+		<prosody rate="slow">Hello</prosody>
+	</Say>
+	<Record action="http://example.com/recorded" maxLength="120" finishOnKey="#" playBeep="true" transcribe="true" transcribeCallback="http://example.com/transcribed"></Record>
+	<Say>
+		<prosody rate="slow">No message was recorded. Goodbye.</prosody>
+	</Say>
+	<Hangup></Hangup>
+</Response>`, string(data))
+	})
+	t.Run("dial", func(t *testing.T) {
+		var mockConfig config.Config
+		ctx := mockConfig.Context(context.Background())
+		rec := httptest.NewRecorder()
+
+		r := newTwiMLResponse(ctx, rec)
+		r.Say("Hello")
+		r.Dial("http://example.com", 20, "+17633333333", "+17634444444")
+
+		resp := rec.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "application/xml")
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+	<Say>
+		<prosody rate="slow">Hello</prosody>
+	</Say>
+	<Dial action="http://example.com" timeout="20">
+		<Number>+17633333333</Number>
+		<Number>+17634444444</Number>
+	</Dial>
+</Response>`, string(data))
+	})
+	t.Run("conference", func(t *testing.T) {
+		var mockConfig config.Config
+		ctx := mockConfig.Context(context.Background())
+		rec := httptest.NewRecorder()
+
+		r := newTwiMLResponse(ctx, rec)
+		r.Say("Hello")
+		r.DialConference("goalert-alert-123")
+
+		resp := rec.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+	<Say>
+		<prosody rate="slow">Hello</prosody>
+	</Say>
+	<Dial>
+		<Conference>goalert-alert-123</Conference>
+	</Dial>
+	<Hangup></Hangup>
+</Response>`, string(data))
+	})
+	t.Run("gather-digits", func(t *testing.T) {
+		var mockConfig config.Config
+		ctx := mockConfig.Context(context.Background())
+		rec := httptest.NewRecorder()
+
+		r := newTwiMLResponse(ctx, rec)
+		r.Say("Enter the code.")
+		r.GatherDigits("http://example.com", 6)
+
+		resp := rec.Result()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+	<Gather numDigits="6" timeout="10" action="http://example.com">
+		<Say>
+			<prosody rate="slow">Enter the code.</prosody>
+		</Say>
+	</Gather>
 </Response>`, string(data))
 	})
 }
@@ -6,53 +6,70 @@ import (
 	"regexp"
 
 	"github.com/target/goalert/config"
-	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/webhooksig"
 
 	"github.com/pkg/errors"
 )
 
-func validateRequest(req *http.Request) error {
+// verifySignature checks the X-Twilio-Signature header of a request.
+func verifySignature(req *http.Request, _ []byte) (*webhooksig.Signed, error) {
+	sig := req.Header.Get("X-Twilio-Signature")
+	if sig == "" {
+		return nil, webhooksig.ErrMissing
+	}
+
 	if req.Method == "POST" {
 		if err := req.ParseForm(); err != nil {
-			return errors.New("unable to parse form input")
+			return nil, errors.Wrap(err, "parse form input")
 		}
 	}
 	ctx := req.Context()
 	cfg := config.FromContext(ctx)
 
-	sig := req.Header.Get("X-Twilio-Signature")
-	if sig == "" {
-		return errors.New("missing X-Twilio-Signature")
-	}
-
 	calcSig := Signature(cfg.Twilio.AuthToken, config.RequestURL(req), req.PostForm)
 	if !hmac.Equal([]byte(sig), calcSig) {
 		if cfg.Twilio.AlternateAuthToken == "" {
-			return errors.New("invalid X-Twilio-Signature")
+			return nil, webhooksig.ErrInvalid
 		}
 
 		calcSig = Signature(cfg.Twilio.AlternateAuthToken, config.RequestURL(req), req.PostForm)
 		if !hmac.Equal([]byte(sig), calcSig) {
-			return errors.New("invalid X-Twilio-Signature")
+			return nil, webhooksig.ErrInvalid
 		}
 	}
 
-	return nil
+	return &webhooksig.Signed{Nonce: requestNonce(req)}, nil
 }
 
-// WrapValidation will wrap an http.Handler to do X-Twilio-Signature checking.
-func WrapValidation(h http.Handler, c Config) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		ctx := req.Context()
-
-		err := validateRequest(req)
-		if err != nil {
-			log.Log(ctx, err)
-			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
-			return
+// requestNonce returns a value that is unique for each message and status callback request.
+//
+// Twilio requests are not timestamped, and requests made during a voice call (e.g., menu selections)
+// may legitimately repeat, so those are not checked for replay.
+func requestNonce(req *http.Request) string {
+	if sid := req.PostForm.Get("MessageSid"); sid != "" {
+		status := req.PostForm.Get("MessageStatus")
+		if status == "" {
+			status = req.PostForm.Get("SmsStatus")
 		}
+		return req.URL.Path + ":" + sid + ":" + status
+	}
+
+	// only call status callbacks include a sequence number
+	if seq := req.PostForm.Get("SequenceNumber"); seq != "" {
+		return req.URL.Path + ":" + req.PostForm.Get("CallSid") + ":" + seq
+	}
 
-		h.ServeHTTP(w, req)
+	return ""
+}
+
+// WrapValidation will wrap an http.Handler to do X-Twilio-Signature checking. Message and status
+// callback requests are recorded in c.NonceStore, if set, so they are only accepted once.
+func WrapValidation(h http.Handler, c Config) http.Handler {
+	return webhooksig.Wrap(h, webhooksig.Options{
+		Provider:     "twilio",
+		Verifier:     webhooksig.VerifierFunc(verifySignature),
+		Nonces:       c.NonceStore,
+		RejectStatus: http.StatusBadRequest,
 	})
 }
 
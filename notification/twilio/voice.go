@@ -19,8 +19,10 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/i18n"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
 )
@@ -35,6 +37,9 @@ type KeyPressed string
 type Voice struct {
 	c *Config
 	r notification.Receiver
+
+	activeBridges *sql.Stmt
+	bridgeByCode  *sql.Stmt
 }
 
 const (
@@ -45,17 +50,26 @@ const (
 	CallTypeVerify      = CallType("verify")
 	CallTypeStop        = CallType("stop")
 
+	CallTypeConference          = CallType("conference")
+	CallTypeForward             = CallType("forward")
+	CallTypeReport              = CallType("report")
+	CallTypeReportRecorded      = CallType("report-recorded")
+	CallTypeReportTranscription = CallType("report-transcription")
+
 	// Possible keys pressed from the Menu mapped to their actions.
-	digitAck      = "4"
-	digitClose    = "6"
-	digitStop     = "1"
-	digitGoBack   = "1"
-	digitRepeat   = "*"
-	digitConfirm  = "3"
-	digitOldAck   = "8"
-	digitOldClose = "9"
-	digitEscalate = "5"
-	sayRepeat     = "star"
+	digitAck        = "4"
+	digitClose      = "6"
+	digitStop       = "1"
+	digitReport     = "2"
+	digitForward    = "0"
+	digitConference = "9"
+	digitGoBack     = "1"
+	digitRepeat     = "*"
+	digitConfirm    = "3"
+	digitOldAck     = "8"
+	digitOldClose   = "9"
+	digitEscalate   = "5"
+	sayRepeat       = "star"
 )
 
 var (
@@ -109,11 +123,24 @@ func voiceErrorMessage(ctx context.Context, err error) (string, error) {
 // It performs operations like validating essential parameters, registering the Twilio client and db
 // and adding routes for successful and unsuccessful call connections to Twilio
 func NewVoice(ctx context.Context, db *sql.DB, c *Config) (*Voice, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
 	v := &Voice{
 		c: c,
+
+		activeBridges: p.P(`
+			SELECT count(*) > 0
+			FROM twilio_conference_bridges b
+			JOIN alerts a ON a.id = b.alert_id AND a.status != 'closed'
+		`),
+		bridgeByCode: p.P(`
+			SELECT b.alert_id
+			FROM twilio_conference_bridges b
+			JOIN alerts a ON a.id = b.alert_id AND a.status != 'closed'
+			WHERE b.access_code = $1
+		`),
 	}
 
-	return v, nil
+	return v, p.Err
 }
 
 // SetReceiver sets the notification.Receiver for incoming calls and status updates.
@@ -134,6 +161,16 @@ func (v *Voice) ServeCall(w http.ResponseWriter, req *http.Request) {
 		v.ServeStop(w, req)
 	case CallTypeVerify:
 		v.ServeVerify(w, req)
+	case CallTypeConference:
+		v.ServeConference(w, req)
+	case CallTypeForward:
+		v.ServeForward(w, req)
+	case CallTypeReport:
+		v.ServeReport(w, req)
+	case CallTypeReportRecorded:
+		v.ServeReportRecorded(w, req)
+	case CallTypeReportTranscription:
+		v.ServeReportTranscription(w, req)
 	default:
 		_, call, _ := v.getCall(w, req)
 		if !call.Outbound {
@@ -192,7 +229,8 @@ func (v *Voice) Send(ctx context.Context, msg notification.Message) (*notificati
 		return nil, err
 	}
 
-	msgBody, err := buildMessage(fmt.Sprintf("Hello! This is %s", cfg.ApplicationName()), msg)
+	p := i18n.NewPrinter(msg.Destination().Locale)
+	msgBody, err := buildMessage(p, p.Sprintf("Hello! This is %s", cfg.ApplicationName()), msg)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +241,7 @@ func (v *Voice) Send(ctx context.Context, msg notification.Message) (*notificati
 		log.Log(ctx, errors.Wrap(err, "call user"))
 		return nil, err
 	}
+	v.c.recordSpend(ctx, "voice", toNumber)
 
 	return voiceResponse.sentMessage(), nil
 }
@@ -386,6 +425,7 @@ func (v *Voice) getCall(w http.ResponseWriter, req *http.Request) (context.Conte
 	}
 	q.Del("retry_digits")
 
+	ctx = withLocale(ctx, q.Get(msgParamLocale))
 	ctx = log.WithFields(ctx, log.Fields{
 		"SID":    callSID,
 		"Phone":  phoneNumber,
@@ -516,6 +556,11 @@ func (v *Voice) ServeInbound(w http.ResponseWriter, req *http.Request) {
 	cfg := config.FromContext(ctx)
 
 	resp := newTwiMLResponse(ctx, w)
+	if call.Digits == "" && cfg.Twilio.CallForwardSkipMenu && v.forwardEnabled(ctx) {
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeForward))
+		return
+	}
+
 	switch call.Digits {
 	default:
 		resp.SayUnknownDigit()
@@ -523,6 +568,15 @@ func (v *Voice) ServeInbound(w http.ResponseWriter, req *http.Request) {
 	case "", digitRepeat:
 		resp.Sayf("Hello! This is %s. ", cfg.ApplicationName())
 		resp.Say("Please use the application dashboard to manage alerts.")
+		if v.forwardEnabled(ctx) {
+			resp.AddOptions(optionForward)
+		}
+		if v.reportEnabled(ctx) {
+			resp.AddOptions(optionReport)
+		}
+		if v.hasActiveBridges(ctx) {
+			resp.AddOptions(optionConference)
+		}
 		resp.AddOptions(optionStop)
 		resp.Gather(v.callbackURL(ctx, call.Q, ""))
 		return
@@ -530,6 +584,25 @@ func (v *Voice) ServeInbound(w http.ResponseWriter, req *http.Request) {
 		call.Q.Set("previous", "")
 		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeStop))
 		return
+	case digitForward:
+		if !v.forwardEnabled(ctx) {
+			resp.SayUnknownDigit()
+			resp.Redirect(v.callbackURL(ctx, call.Q, ""))
+			return
+		}
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeForward))
+		return
+	case digitConference:
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeConference))
+		return
+	case digitReport:
+		if !v.reportEnabled(ctx) {
+			resp.SayUnknownDigit()
+			resp.Redirect(v.callbackURL(ctx, call.Q, ""))
+			return
+		}
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeReport))
+		return
 	}
 }
 
@@ -613,27 +686,28 @@ func (v *Voice) FriendlyValue(ctx context.Context, value string) (string, error)
 }
 
 // buildMessage is a function that will build the VoiceOptions object with the proper message contents
-func buildMessage(prefix string, msg notification.Message) (message string, err error) {
+// translated by p.
+func buildMessage(p *i18n.Printer, prefix string, msg notification.Message) (message string, err error) {
 	if prefix == "" {
 		return "", errors.New("buildMessage error: no prefix provided")
 	}
 
 	switch t := msg.(type) {
 	case notification.AlertBundle:
-		message = fmt.Sprintf("%s with alert notifications. Service '%s' has %d unacknowledged alerts.", prefix, t.ServiceName, t.Count)
+		message = p.Sprintf("%s with alert notifications. Service '%s' has %d unacknowledged alerts.", prefix, t.ServiceName, t.Count)
 	case notification.Alert:
 		if t.Summary == "" {
-			t.Summary = "No summary provided"
+			t.Summary = p.Text("No summary provided")
 		}
-		message = fmt.Sprintf("%s with an alert notification. %s.", prefix, t.Summary)
+		message = p.Sprintf("%s with an alert notification. %s.", prefix, t.Summary)
 	case notification.AlertStatus:
 		message = rmParen.ReplaceAllString(t.LogEntry, "")
-		message = fmt.Sprintf("%s with a status update for alert '%s'. %s", prefix, t.Summary, message)
+		message = p.Sprintf("%s with a status update for alert '%s'. %s", prefix, t.Summary, message)
 	case notification.Test:
-		message = fmt.Sprintf("%s with a test message.", prefix)
+		message = p.Sprintf("%s with a test message.", prefix)
 	case notification.Verification:
 		count := int(math.Log10(float64(t.Code)) + 1)
-		message = fmt.Sprintf(
+		message = p.Sprintf(
 			"%s with your %d-digit verification code. The code is: %s. Again, your %d-digit verification code is: %s.",
 			prefix, count, spellNumber(t.Code), count, spellNumber(t.Code),
 		)
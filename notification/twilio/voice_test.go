@@ -18,6 +18,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// Test Notification
 	result, err := buildMessage(
+		nil,
 		prefix,
 		notification.Test{},
 	)
@@ -26,6 +27,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// AlertBundle Notification
 	result, err = buildMessage(
+		nil,
 		prefix,
 		notification.AlertBundle{
 			CallbackID:  "2",
@@ -39,6 +41,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// Alert Notification
 	result, err = buildMessage(
+		nil,
 		prefix,
 		notification.Alert{
 			CallbackID: "2",
@@ -52,6 +55,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// AlertStatus Notification
 	result, err = buildMessage(
+		nil,
 		prefix,
 		notification.AlertStatus{
 			CallbackID: "2",
@@ -66,6 +70,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// Verification Notification
 	result, err = buildMessage(
+		nil,
 		prefix,
 		notification.Verification{
 			CallbackID: "2",
@@ -77,6 +82,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// Bad Type
 	result, err = buildMessage(
+		nil,
 		prefix,
 		notification.ScheduleOnCallUsers{
 			CallbackID:   "2",
@@ -90,6 +96,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// Missing prefix
 	result, err = buildMessage(
+		nil,
 		"",
 		notification.Test{},
 	)
@@ -98,6 +105,7 @@ func TestBuildMessage(t *testing.T) {
 
 	// no input
 	result, err = buildMessage(
+		nil,
 		prefix,
 		nil,
 	)
@@ -108,6 +116,7 @@ func TestBuildMessage(t *testing.T) {
 func BenchmarkBuildMessage(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = buildMessage(
+			nil,
 			fmt.Sprintf("%d", i),
 			notification.Test{
 				Dest: notification.Dest{
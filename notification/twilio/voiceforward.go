@@ -0,0 +1,134 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user/contactmethod"
+)
+
+const (
+	// forwardRingSec is how long the users of each escalation step are rung before moving on.
+	forwardRingSec = 20
+
+	// maxForwardNumbers is the maximum number of phone numbers rung at once (a Twilio limit).
+	maxForwardNumbers = 10
+)
+
+func (v *Voice) forwardEnabled(ctx context.Context) bool {
+	cfg := config.FromContext(ctx)
+	return cfg.Twilio.CallForwardServiceID != "" && v.c.OnCallStore != nil
+}
+
+// forwardNumbers returns the voice numbers of the users on-call for the first escalation step,
+// after the provided step number, that has at least one reachable user.
+//
+// The caller's own number is excluded. If no step is found, numbers will be empty.
+func (v *Voice) forwardNumbers(ctx context.Context, serviceID string, afterStep int, caller string) (step int, numbers []string, err error) {
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		var users []oncall.ServiceOnCallUser
+		users, err = v.c.OnCallStore.OnCallUsersByService(ctx, serviceID)
+		if err != nil {
+			return
+		}
+
+		seen := make(map[string]bool)
+		for _, u := range users {
+			if u.StepNumber <= afterStep {
+				continue
+			}
+			if len(numbers) > 0 && u.StepNumber != step {
+				// already found a reachable step
+				break
+			}
+			step = u.StepNumber
+
+			var cms []contactmethod.ContactMethod
+			cms, err = v.c.CMStore.FindAll(ctx, v.c.DB, u.UserID)
+			if err != nil {
+				return
+			}
+			for _, cm := range cms {
+				if cm.Type != contactmethod.TypeVoice || cm.Disabled || cm.Pending {
+					continue
+				}
+				if cm.Value == caller || seen[cm.Value] || len(numbers) == maxForwardNumbers {
+					continue
+				}
+				seen[cm.Value] = true
+				numbers = append(numbers, cm.Value)
+			}
+		}
+	})
+
+	return step, numbers, err
+}
+
+// ServeForward connects an inbound caller to the users currently on-call for the configured service,
+// ringing each escalation step in turn until someone answers.
+func (v *Voice) ServeForward(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, errResp := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	resp := newTwiMLResponse(ctx, w)
+	if !v.forwardEnabled(ctx) || call.Outbound {
+		resp.Say("Call forwarding is not available.").Hangup()
+		return
+	}
+	cfg := config.FromContext(ctx)
+
+	// See https://www.twilio.com/docs/voice/twiml/dial#action
+	switch req.FormValue("DialCallStatus") {
+	case "completed", "answered":
+		resp.Hangup()
+		return
+	}
+
+	afterStep := -1
+	if s := call.Q.Get("step"); s != "" {
+		afterStep, _ = strconv.Atoi(s)
+	}
+
+	var step int
+	var numbers []string
+	err := doDeadline(ctx, func() error {
+		var err error
+		step, numbers, err = v.forwardNumbers(ctx, cfg.Twilio.CallForwardServiceID, afterStep, call.Number)
+		return err
+	})
+	if errResp(false, errors.Wrap(err, "lookup on-call numbers for call forwarding"), "Failed to lookup on-call users.") {
+		return
+	}
+
+	if len(numbers) == 0 {
+		if afterStep == -1 {
+			resp.Say("Sorry, no one is currently available.")
+		} else {
+			resp.Say("Sorry, no one was able to answer.")
+		}
+		if v.reportEnabled(ctx) {
+			resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeReport))
+			return
+		}
+		resp.Say("Please try again later.").Hangup()
+		return
+	}
+
+	if afterStep == -1 {
+		resp.Say("Please hold while you are connected to the on-call responder.")
+	} else {
+		resp.Say("Trying the next responder. Please hold.")
+	}
+	call.Q.Set("step", strconv.Itoa(step))
+	resp.Dial(v.callbackURL(ctx, call.Q, CallTypeForward), forwardRingSec, numbers...)
+}
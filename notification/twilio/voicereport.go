@@ -0,0 +1,152 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxReportLengthSec is the maximum length of a recorded incident report.
+const maxReportLengthSec = 120
+
+func (v *Voice) reportEnabled(ctx context.Context) bool {
+	cfg := config.FromContext(ctx)
+	return cfg.Twilio.IncidentReportServiceID != "" && v.c.AlertStore != nil
+}
+
+// ServeReport prompts an inbound caller to record a description of an incident.
+func (v *Voice) ServeReport(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, _ := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	resp := newTwiMLResponse(ctx, w)
+	if !v.reportEnabled(ctx) || call.Outbound {
+		resp.Say("Incident reporting is not available.").Hangup()
+		return
+	}
+
+	resp.Sayf("After the tone, please describe the incident. You have %d seconds. When you are finished, press pound or hang up.", maxReportLengthSec)
+	resp.Record(
+		v.callbackURL(ctx, call.Q, CallTypeReportRecorded),
+		v.callbackURL(ctx, call.Q, CallTypeReportTranscription),
+		maxReportLengthSec,
+	)
+}
+
+// reportDedup returns the dedup ID for an incident report; the call SID ensures retries
+// and the transcription callback refer to the same alert.
+func reportDedup(callSID string) *alert.DedupID {
+	return alert.NewUserDedup("twilio-call:" + callSID)
+}
+
+func reportDetails(number, recordingURL, duration string) string {
+	details := fmt.Sprintf("Reported by phone from %s.", number)
+	if validate.AbsoluteURL("RecordingUrl", recordingURL) == nil {
+		details += fmt.Sprintf("\n\n[Listen to recording](%s)", recordingURL)
+		if sec, err := strconv.Atoi(duration); err == nil {
+			details += fmt.Sprintf(" (%d seconds)", sec)
+		}
+	}
+
+	return details
+}
+
+// ServeReportRecorded creates an alert for a recorded incident report.
+func (v *Voice) ServeReportRecorded(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, errResp := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+
+	resp := newTwiMLResponse(ctx, w)
+	if !v.reportEnabled(ctx) || call.Outbound {
+		resp.Say("Incident reporting is not available.").Hangup()
+		return
+	}
+	cfg := config.FromContext(ctx)
+	serviceID := cfg.Twilio.IncidentReportServiceID
+
+	// keep the recording info in the query in case of a retry
+	recordingURL := req.FormValue("RecordingUrl")
+	if recordingURL == "" {
+		recordingURL = call.Q.Get("recording_url")
+	}
+	duration := req.FormValue("RecordingDuration")
+	if duration == "" {
+		duration = call.Q.Get("recording_duration")
+	}
+	call.Q.Set("recording_url", recordingURL)
+	call.Q.Set("recording_duration", duration)
+
+	a := &alert.Alert{
+		Summary:   fmt.Sprintf("Incident reported by phone from %s", call.Number),
+		Details:   reportDetails(call.Number, recordingURL, duration),
+		Status:    alert.StatusTriggered,
+		Source:    alert.SourceManual,
+		ServiceID: serviceID,
+		Dedup:     reportDedup(call.SID),
+	}
+
+	var newAlert *alert.Alert
+	err := doDeadline(ctx, func() error {
+		var err error
+		newAlert, _, err = v.c.AlertStore.CreateOrUpdate(permission.ServiceContext(ctx, serviceID), a)
+		return err
+	})
+	if errResp(false, errors.Wrap(err, "create alert for incident report"), "Failed to create alert.") {
+		return
+	}
+
+	log.Logf(ctx, "created alert #%d for voice incident report", newAlert.ID)
+	resp.Sayf("Thank you. Alert number %s has been created.", spellNumber(newAlert.ID)).Hangup()
+}
+
+// ServeReportTranscription adds the transcription of a recorded incident report to the alert
+// details, once available.
+func (v *Voice) ServeReportTranscription(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, _ := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+	if !v.reportEnabled(ctx) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	cfg := config.FromContext(ctx)
+	serviceID := cfg.Twilio.IncidentReportServiceID
+
+	text := req.FormValue("TranscriptionText")
+	if req.FormValue("TranscriptionStatus") != "completed" || text == "" {
+		log.Logf(ctx, "no transcription for voice incident report: status=%s", req.FormValue("TranscriptionStatus"))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err := v.c.AlertStore.AppendDetails(permission.ServiceContext(ctx, serviceID), serviceID, reportDedup(call.SID), "Transcription:\n\n"+text)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "append incident report transcription"))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
 )
 
 type Sender struct{}
@@ -168,6 +169,12 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 	}
 
 	req.Header.Add("Content-Type", "application/json")
+	if key := notification.IdempotencyKey(ctx); key != "" {
+		req.Header.Add("Idempotency-Key", key)
+	}
+	if id := log.RequestID(ctx); id != "" {
+		req.Header.Add("X-Request-ID", id)
+	}
 
 	_, err = http.DefaultClient.Do(req)
 	if err != nil {
@@ -12,6 +12,12 @@ type Channel struct {
 	Name  string
 	Type  Type
 	Value string
+
+	// TeamID is set if the channel is a contact method owned by a team.
+	TeamID string
+
+	// Disabled is set for a team contact method that has not been verified.
+	Disabled bool
 }
 
 func (c Channel) Normalize() (*Channel, error) {
@@ -22,8 +28,15 @@ func (c Channel) Normalize() (*Channel, error) {
 	err := validate.Many(
 		validate.UUID("ID", c.ID),
 		validate.Text("Name", c.Name, 1, 255),
-		validate.OneOf("Type", c.Type, TypeSlackChan, TypeWebhook, TypeSlackUG),
 	)
+	if c.TeamID != "" {
+		err = validate.Many(err,
+			validate.UUID("TeamID", c.TeamID),
+			validate.OneOf("Type", c.Type, TypeSlackChan, TypeSMS, TypeVoice, TypeEmail),
+		)
+	} else {
+		err = validate.Many(err, validate.OneOf("Type", c.Type, TypeSlackChan, TypeWebhook, TypeSlackUG))
+	}
 
 	switch c.Type {
 	case TypeSlackUG:
@@ -36,6 +49,10 @@ func (c Channel) Normalize() (*Channel, error) {
 		err = validate.Many(err, validate.RequiredText("Value", c.Value, 1, 32))
 	case TypeWebhook:
 		err = validate.Many(err, validate.URL("Value", c.Value))
+	case TypeSMS, TypeVoice:
+		err = validate.Many(err, validate.Phone("Value", c.Value))
+	case TypeEmail:
+		err = validate.Many(err, validate.Email("Value", c.Value))
 	}
 
 	return &c, err
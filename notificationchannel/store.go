@@ -26,6 +26,10 @@ type Store struct {
 	updateName  *sql.Stmt
 	findByValue *sql.Stmt
 	lock        *sql.Stmt
+
+	createTeam  *sql.Stmt
+	findAllTeam *sql.Stmt
+	deleteTeam  *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -35,13 +39,13 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		db: db,
 
 		findAll: p.P(`
-			select id, name, type, value from notification_channels
+			select id, name, type, value, team_id, disabled from notification_channels
 		`),
 		findOne: p.P(`
-			select id, name, type, value from notification_channels where id = $1
+			select id, name, type, value, team_id, disabled from notification_channels where id = $1
 		`),
 		findMany: p.P(`
-			select id, name, type, value from notification_channels where id = any($1)
+			select id, name, type, value, team_id, disabled from notification_channels where id = any($1)
 		`),
 		create: p.P(`
 			insert into notification_channels (id, name, type, value)
@@ -50,11 +54,23 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		updateName: p.P(`update notification_channels set name = $2 where id = $1`),
 		deleteMany: p.P(`DELETE FROM notification_channels WHERE id = any($1)`),
 
-		findByValue: p.P(`select id, name from notification_channels where type = $1 and value = $2`),
+		findByValue: p.P(`select id, name from notification_channels where type = $1 and value = $2 and team_id isnull`),
 
 		// Lock the table so only one tx can insert/update at a time, but allows the above SELECT FOR UPDATE to run
 		// so only required changes block.
 		lock: p.P(`LOCK notification_channels IN SHARE ROW EXCLUSIVE MODE`),
+
+		createTeam: p.P(`
+			insert into notification_channels (id, name, type, value, team_id, disabled)
+			values ($1, $2, $3, $4, $5, $6)
+		`),
+		findAllTeam: p.P(`
+			select id, name, type, value, team_id, disabled
+			from notification_channels
+			where team_id = $1
+			order by lower(name)
+		`),
+		deleteTeam: p.P(`delete from notification_channels where id = $1 and team_id notnull`),
 	}, p.Err
 }
 
@@ -86,18 +102,24 @@ func (s *Store) FindMany(ctx context.Context, ids []string) ([]Channel, error) {
 	}
 	defer rows.Close()
 
+	return scanChannels(rows)
+}
+
+func scanChannels(rows *sql.Rows) ([]Channel, error) {
 	var channels []Channel
 	for rows.Next() {
 		var c Channel
-		err = rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value)
+		var teamID sql.NullString
+		err := rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value, &teamID, &c.Disabled)
 		if err != nil {
 			return nil, err
 		}
+		c.TeamID = teamID.String
 
 		channels = append(channels, c)
 	}
 
-	return channels, nil
+	return channels, rows.Err()
 }
 
 func (s *Store) MapToID(ctx context.Context, tx *sql.Tx, c *Channel) (uuid.UUID, error) {
@@ -202,10 +224,12 @@ func (s *Store) FindOne(ctx context.Context, id uuid.UUID) (*Channel, error) {
 	}
 
 	var c Channel
-	err = s.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &c.Name, &c.Type, &c.Value)
+	var teamID sql.NullString
+	err = s.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &c.Name, &c.Type, &c.Value, &teamID, &c.Disabled)
 	if err != nil {
 		return nil, err
 	}
+	c.TeamID = teamID.String
 	return &c, nil
 }
 
@@ -221,15 +245,68 @@ func (s *Store) FindAll(ctx context.Context) ([]Channel, error) {
 	}
 	defer rows.Close()
 
-	var channels []Channel
-	for rows.Next() {
-		var c Channel
-		err = rows.Scan(&c.ID, &c.Name, &c.Type, &c.Value)
-		if err != nil {
-			return nil, err
-		}
-		channels = append(channels, c)
+	return scanChannels(rows)
+}
+
+// CreateTeamChannelTx will create a new contact method owned by the team c.TeamID. Team contact methods
+// can be used as escalation policy step targets like any other notification channel.
+//
+// Phone numbers and email addresses are created disabled, and are only notified once verified.
+func (s *Store) CreateTeamChannelTx(ctx context.Context, tx *sql.Tx, c *Channel) (*Channel, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ID = ""
+	n, err := c.Normalize()
+	if err == nil {
+		err = validate.UUID("TeamID", n.TeamID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	n.Disabled = n.Type.RequiresVerification()
+	_, err = stmt(ctx, tx, s.createTeam).ExecContext(ctx, n.ID, n.Name, n.Type, n.Value, n.TeamID, n.Disabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// FindAllByTeam will return all contact methods owned by the given team, sorted by name.
+func (s *Store) FindAllByTeam(ctx context.Context, teamID string) ([]Channel, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("TeamID", teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllTeam.QueryContext(ctx, teamID)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return channels, nil
+	return scanChannels(rows)
+}
+
+// DeleteTeamChannelTx will delete a team contact method, removing it from any escalation policy steps.
+func (s *Store) DeleteTeamChannelTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt(ctx, tx, s.deleteTeam).ExecContext(ctx, id)
+	return err
 }
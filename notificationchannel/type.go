@@ -12,8 +12,22 @@ const (
 	TypeSlackChan Type = "SLACK"
 	TypeWebhook   Type = "WEBHOOK"
 	TypeSlackUG   Type = "SLACK_USER_GROUP"
+
+	// Types only used for team contact methods.
+	TypeSMS   Type = "SMS"
+	TypeVoice Type = "VOICE"
+	TypeEmail Type = "EMAIL"
 )
 
+// RequiresVerification returns true if a team contact method of type t must be verified before it is used.
+func (t Type) RequiresVerification() bool {
+	switch t {
+	case TypeSMS, TypeVoice, TypeEmail:
+		return true
+	}
+	return false
+}
+
 // Valid returns true if t is a known Type.
 func (t Type) Valid() bool {
 	return t == TypeSlackChan
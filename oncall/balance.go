@@ -0,0 +1,154 @@
+package oncall
+
+import (
+	"time"
+
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user/unavailability"
+)
+
+// BalanceOptions configure the generation of a balanced rotation.
+type BalanceOptions struct {
+	// ParticipantIDs is the list of users to assign shifts to. Order is used
+	// to break ties.
+	ParticipantIDs []string
+
+	// Start and End define the time span to generate shifts for.
+	Start, End time.Time
+
+	// ShiftLength is the length of each shift (e.g., 24 hours or 1 week).
+	ShiftLength time.Duration
+
+	// Location is used to determine weekend boundaries.
+	Location *time.Location
+}
+
+// weekendPenalty is the extra load, in shift-lengths, applied to a candidate that
+// would be on-call for two consecutive weekends.
+const weekendPenalty = 4
+
+// BalancedShifts will generate a set of fixed shifts covering the span in opts, assigning each
+// shift to an available participant. Candidates are chosen to keep total on-call time (including
+// history) even, to avoid consecutive weekends, and to avoid back-to-back shifts where possible.
+//
+// Shifts where no participant is available are left uncovered.
+func BalancedShifts(opts BalanceOptions, history []Shift, away []unavailability.Unavailability) []schedule.FixedShift {
+	if len(opts.ParticipantIDs) == 0 || opts.ShiftLength <= 0 || !opts.End.After(opts.Start) {
+		return nil
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	isParticipant := make(map[string]bool, len(opts.ParticipantIDs))
+	for _, id := range opts.ParticipantIDs {
+		isParticipant[id] = true
+	}
+
+	load := make(map[string]time.Duration)
+	lastWeekend := make(map[string]int)
+	var lastUserID string
+	var lastEnd time.Time
+	for _, s := range history {
+		if !isParticipant[s.UserID] || !s.Start.Before(opts.Start) {
+			continue
+		}
+		end := s.End
+		if end.IsZero() || end.After(opts.Start) {
+			end = opts.Start
+		}
+		load[s.UserID] += end.Sub(s.Start)
+		if w, ok := weekendIndex(s.Start, end, loc); ok && w > lastWeekend[s.UserID] {
+			lastWeekend[s.UserID] = w
+		}
+		if !end.Before(lastEnd) {
+			lastEnd = end
+			lastUserID = s.UserID
+		}
+	}
+
+	byUser := make(map[string][]unavailability.Unavailability)
+	for _, u := range away {
+		byUser[u.UserID] = append(byUser[u.UserID], u)
+	}
+	isAway := func(userID string, start, end time.Time) bool {
+		for _, u := range byUser[userID] {
+			if u.Overlaps(start, end) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var result []schedule.FixedShift
+	for start := opts.Start; start.Before(opts.End); start = start.Add(opts.ShiftLength) {
+		end := start.Add(opts.ShiftLength)
+		if end.After(opts.End) {
+			end = opts.End
+		}
+		week, isWeekend := weekendIndex(start, end, loc)
+
+		var best string
+		var bestScore time.Duration
+		for _, id := range opts.ParticipantIDs {
+			if isAway(id, start, end) {
+				continue
+			}
+
+			score := load[id]
+			if id == lastUserID && len(opts.ParticipantIDs) > 1 {
+				score += opts.ShiftLength
+			}
+			if isWeekend && lastWeekend[id] == week-1 {
+				score += weekendPenalty * opts.ShiftLength
+			}
+			if best == "" || score < bestScore {
+				best = id
+				bestScore = score
+			}
+		}
+
+		lastUserID = best
+		if best == "" {
+			continue
+		}
+
+		load[best] += end.Sub(start)
+		if isWeekend {
+			lastWeekend[best] = week
+		}
+
+		n := len(result)
+		if n > 0 && result[n-1].UserID == best && result[n-1].End.Equal(start) {
+			result[n-1].End = end
+			continue
+		}
+		result = append(result, schedule.FixedShift{Start: start, End: end, UserID: best})
+	}
+
+	return result
+}
+
+// weekendIndex returns a sequential index for the weekend overlapped by the given span, and
+// true if the span overlaps a Saturday or Sunday in loc.
+func weekendIndex(start, end time.Time, loc *time.Location) (int, bool) {
+	t := start.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for day.Before(end) {
+		switch day.Weekday() {
+		case time.Saturday, time.Sunday:
+			// index weekends by the number of weeks since the Unix epoch, so
+			// that Saturday and Sunday share the same index
+			sat := day
+			if day.Weekday() == time.Sunday {
+				sat = day.AddDate(0, 0, -1)
+			}
+			days := sat.Sub(time.Date(1970, 1, 3, 0, 0, 0, 0, loc)).Hours() / 24
+			return int(days+0.5) / 7, true
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return 0, false
+}
@@ -0,0 +1,50 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user/unavailability"
+)
+
+func TestBalancedShifts(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2023, m, d, 0, 0, 0, 0, time.UTC) }
+
+	opts := oncall.BalanceOptions{
+		ParticipantIDs: []string{"a", "b", "c"},
+		Start:          day(10, 7),
+		End:            day(11, 4),
+		ShiftLength:    7 * 24 * time.Hour,
+	}
+	history := []oncall.Shift{
+		{UserID: "a", Start: day(9, 30), End: day(10, 7)},
+		{UserID: "z", Start: day(9, 23), End: day(9, 30)}, // not a participant
+	}
+	away := []unavailability.Unavailability{
+		{UserID: "b", Start: day(10, 29), End: day(10, 30)},
+	}
+
+	assert.Equal(t, []schedule.FixedShift{
+		{UserID: "b", Start: day(10, 7), End: day(10, 14)},
+		{UserID: "c", Start: day(10, 14), End: day(10, 21)},
+		{UserID: "a", Start: day(10, 21), End: day(10, 28)},
+		{UserID: "c", Start: day(10, 28), End: day(11, 4)}, // b is away
+	}, oncall.BalancedShifts(opts, history, away))
+}
+
+func TestBalancedShifts_Merge(t *testing.T) {
+	start := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC) // Monday
+	opts := oncall.BalanceOptions{
+		ParticipantIDs: []string{"a"},
+		Start:          start,
+		End:            start.AddDate(0, 0, 3),
+		ShiftLength:    24 * time.Hour,
+	}
+
+	assert.Equal(t, []schedule.FixedShift{
+		{UserID: "a", Start: start, End: start.AddDate(0, 0, 3)},
+	}, oncall.BalancedShifts(opts, nil, nil))
+}
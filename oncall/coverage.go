@@ -0,0 +1,128 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/user/unavailability"
+)
+
+// A CoverageConflict is a period where an on-call user is marked as unavailable, and their
+// shift needs to be covered by someone else (e.g., via a replace override).
+type CoverageConflict struct {
+	UserID           string
+	UnavailabilityID string
+	Start            time.Time
+	End              time.Time
+
+	// SuggestedUserIDs are other users from the same set of shifts that are available, and not
+	// already on-call, for the entire conflict. Users with the least on-call time are listed first.
+	SuggestedUserIDs []string
+}
+
+// AvailableShifts will return shifts with the periods their user is unavailable removed, splitting
+// shifts that are only partially covered by an unavailability.
+func AvailableShifts(shifts []Shift, away []unavailability.Unavailability) []Shift {
+	byUser := make(map[string][]unavailability.Unavailability)
+	for _, u := range away {
+		byUser[u.UserID] = append(byUser[u.UserID], u)
+	}
+
+	result := make([]Shift, 0, len(shifts))
+	for _, s := range shifts {
+		parts := []Shift{s}
+		for _, u := range byUser[s.UserID] {
+			var next []Shift
+			for _, p := range parts {
+				if !u.Overlaps(p.Start, p.End) {
+					next = append(next, p)
+					continue
+				}
+				if p.Start.Before(u.Start) {
+					before := p
+					before.End = u.Start
+					next = append(next, before)
+				}
+				if p.End.After(u.End) {
+					after := p
+					after.Start = u.End
+					next = append(next, after)
+				}
+			}
+			parts = next
+		}
+		result = append(result, parts...)
+	}
+
+	return result
+}
+
+// CoverageConflicts will return all overlaps between shifts and the unavailability of the shift's user.
+func CoverageConflicts(shifts []Shift, away []unavailability.Unavailability) []CoverageConflict {
+	byUser := make(map[string][]unavailability.Unavailability)
+	for _, u := range away {
+		byUser[u.UserID] = append(byUser[u.UserID], u)
+	}
+
+	// candidates are all users with shifts, ordered by total on-call time
+	load := make(map[string]time.Duration)
+	for _, s := range shifts {
+		load[s.UserID] += s.End.Sub(s.Start)
+	}
+	candidates := make([]string, 0, len(load))
+	for id := range load {
+		candidates = append(candidates, id)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if load[candidates[i]] == load[candidates[j]] {
+			return candidates[i] < candidates[j]
+		}
+		return load[candidates[i]] < load[candidates[j]]
+	})
+
+	isFree := func(userID string, start, end time.Time) bool {
+		for _, u := range byUser[userID] {
+			if u.Overlaps(start, end) {
+				return false
+			}
+		}
+		for _, s := range shifts {
+			if s.UserID == userID && s.Start.Before(end) && s.End.After(start) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var result []CoverageConflict
+	for _, s := range shifts {
+		for _, u := range byUser[s.UserID] {
+			if !u.Overlaps(s.Start, s.End) {
+				continue
+			}
+
+			c := CoverageConflict{
+				UserID:           s.UserID,
+				UnavailabilityID: u.ID,
+				Start:            s.Start,
+				End:              s.End,
+			}
+			if u.Start.After(c.Start) {
+				c.Start = u.Start
+			}
+			if u.End.Before(c.End) {
+				c.End = u.End
+			}
+			for _, id := range candidates {
+				if id == s.UserID || !isFree(id, c.Start, c.End) {
+					continue
+				}
+				c.SuggestedUserIDs = append(c.SuggestedUserIDs, id)
+			}
+			result = append(result, c)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
@@ -0,0 +1,65 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/user/unavailability"
+)
+
+func TestCoverageConflicts(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	shifts := []oncall.Shift{
+		{UserID: "a", Start: day(1), End: day(8)},
+		{UserID: "b", Start: day(8), End: day(15)},
+		{UserID: "c", Start: day(15), End: day(22)},
+		{UserID: "d", Start: day(22), End: day(29)},
+		{UserID: "d", Start: day(29), End: day(30)},
+	}
+	away := []unavailability.Unavailability{
+		{ID: "1", UserID: "b", Start: day(10), End: day(12)},
+		{ID: "2", UserID: "c", Start: day(11), End: day(13)},
+		{ID: "3", UserID: "a", Start: day(20), End: day(25)}, // not on-call
+	}
+
+	assert.Equal(t, []oncall.CoverageConflict{{
+		UserID:           "b",
+		UnavailabilityID: "1",
+		Start:            day(10),
+		End:              day(12),
+		SuggestedUserIDs: []string{"a", "d"}, // c is away, d has more on-call time than a
+	}}, oncall.CoverageConflicts(shifts, away))
+}
+
+func TestAvailableShifts(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2023, 10, 2, h, 0, 0, 0, time.UTC) }
+
+	shifts := []oncall.Shift{
+		{UserID: "a", Start: hour(0), End: hour(12)},
+		{UserID: "b", Start: hour(0), End: hour(12)},
+		{UserID: "c", Start: hour(0), End: hour(12), Truncated: true},
+	}
+	away := []unavailability.Unavailability{
+		{UserID: "a", Start: hour(2), End: hour(4)},
+		{UserID: "a", Start: hour(6), End: hour(8)},
+		{UserID: "b", Start: hour(10), End: hour(14)},
+		{UserID: "c", Start: hour(0), End: hour(24)},
+	}
+
+	assert.Equal(t, []oncall.Shift{
+		{UserID: "a", Start: hour(0), End: hour(2)},
+		{UserID: "a", Start: hour(4), End: hour(6)},
+		{UserID: "a", Start: hour(8), End: hour(12)},
+		{UserID: "b", Start: hour(0), End: hour(10)},
+	}, oncall.AvailableShifts(shifts, away))
+
+	// an away primary leaves a gap for a schedule requiring two users
+	assert.Equal(t, []oncall.CoverageGap{
+		{Start: hour(2), End: hour(4), OnCall: 1},
+		{Start: hour(6), End: hour(8), OnCall: 1},
+		{Start: hour(10), End: hour(12), OnCall: 1},
+	}, oncall.CoverageGaps(oncall.AvailableShifts(shifts[:2], away), hour(0), hour(12), 2))
+}
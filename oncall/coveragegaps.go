@@ -0,0 +1,107 @@
+package oncall
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// A CoverageGap is a period where fewer users than required are on-call.
+type CoverageGap struct {
+	Start  time.Time
+	End    time.Time
+	OnCall int
+}
+
+// CoverageGaps will return all periods between start and end where fewer than required
+// distinct users are on-call. Adjacent periods with the same number of on-call users
+// are combined.
+func CoverageGaps(shifts []Shift, start, end time.Time, required int) []CoverageGap {
+	if required <= 0 || !start.Before(end) {
+		return nil
+	}
+
+	bounds := []time.Time{start, end}
+	for _, s := range shifts {
+		if s.Start.After(start) && s.Start.Before(end) {
+			bounds = append(bounds, s.Start)
+		}
+		if s.End.After(start) && s.End.Before(end) {
+			bounds = append(bounds, s.End)
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	var result []CoverageGap
+	for i := 1; i < len(bounds); i++ {
+		segStart, segEnd := bounds[i-1], bounds[i]
+		if !segStart.Before(segEnd) {
+			continue
+		}
+
+		users := make(map[string]struct{})
+		for _, s := range shifts {
+			if s.Start.After(segStart) || !s.End.After(segStart) {
+				continue
+			}
+			users[s.UserID] = struct{}{}
+		}
+		if len(users) >= required {
+			continue
+		}
+
+		if n := len(result); n > 0 && result[n-1].End.Equal(segStart) && result[n-1].OnCall == len(users) {
+			result[n-1].End = segEnd
+			continue
+		}
+		result = append(result, CoverageGap{Start: segStart, End: segEnd, OnCall: len(users)})
+	}
+
+	return result
+}
+
+// CoverageGapsBySchedule will return all periods between start and end where fewer users than
+// the schedule's minimum coverage are on-call. Users do not count toward coverage while they are
+// marked as unavailable.
+func (s *Store) CoverageGapsBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]CoverageGap, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	id, err := validate.ParseUUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.schedStore.MinCoverage(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if required == 0 {
+		return nil, nil
+	}
+
+	shifts, err := s.HistoryBySchedule(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, sh := range shifts {
+		if seen[sh.UserID] {
+			continue
+		}
+		seen[sh.UserID] = true
+		userIDs = append(userIDs, sh.UserID)
+	}
+	away, err := s.awayStore.FindAllByUsers(ctx, userIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return CoverageGaps(AvailableShifts(shifts, away), start, end, required), nil
+}
@@ -0,0 +1,34 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+)
+
+func TestCoverageGaps(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2023, 10, 2, h, 0, 0, 0, time.UTC) }
+
+	shifts := []oncall.Shift{
+		// primary
+		{UserID: "a", Start: hour(0), End: hour(12)},
+		{UserID: "b", Start: hour(12), End: hour(24)},
+		// secondary
+		{UserID: "c", Start: hour(0), End: hour(6)},
+		{UserID: "d", Start: hour(8), End: hour(20)},
+		// same user twice does not count
+		{UserID: "b", Start: hour(20), End: hour(22)},
+	}
+
+	assert.Equal(t, []oncall.CoverageGap{
+		{Start: hour(6), End: hour(8), OnCall: 1},
+		{Start: hour(20), End: hour(24), OnCall: 1},
+	}, oncall.CoverageGaps(shifts, hour(0), hour(24), 2))
+
+	assert.Empty(t, oncall.CoverageGaps(shifts, hour(0), hour(24), 1))
+	assert.Equal(t, []oncall.CoverageGap{
+		{Start: hour(24), End: hour(25), OnCall: 0},
+	}, oncall.CoverageGaps(shifts, hour(23), hour(25), 1))
+}
@@ -0,0 +1,291 @@
+package oncall
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Default boundaries used to classify on-call time as "night" time.
+const (
+	DefaultNightStart = timeutil.Clock(22 * time.Hour)
+	DefaultNightEnd   = timeutil.Clock(6 * time.Hour)
+)
+
+// UserLoad summarizes the on-call burden of a single user over a period of time.
+type UserLoad struct {
+	UserID string
+
+	// OnCall is the total amount of time the user was on-call.
+	OnCall time.Duration
+
+	// Night is the portion of OnCall that fell between the night start and end clock times.
+	Night time.Duration
+
+	// Weekend is the portion of OnCall that fell on a Saturday or Sunday.
+	Weekend time.Duration
+
+	// Shifts is the number of distinct shifts the user worked. Overlapping shifts are
+	// counted once.
+	Shifts int
+
+	// Pages is the number of alert notifications sent to the user while on-call.
+	Pages int
+}
+
+// LoadOptions configure how on-call time is classified.
+type LoadOptions struct {
+	// Loc is the time zone used to determine night and weekend time.
+	Loc *time.Location
+
+	NightStart timeutil.Clock
+	NightEnd   timeutil.Clock
+}
+
+func (opts LoadOptions) isNight(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t)
+	if opts.NightStart == opts.NightEnd {
+		return false
+	}
+	if opts.NightStart < opts.NightEnd {
+		return c >= opts.NightStart && c < opts.NightEnd
+	}
+
+	// wraps midnight
+	return c >= opts.NightStart || c < opts.NightEnd
+}
+
+// nextBoundary returns the next time after t that the night/weekend classification could change.
+func (opts LoadOptions) nextBoundary(t time.Time) time.Time {
+	y, m, d := t.Date()
+	next := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	for _, c := range []timeutil.Clock{opts.NightStart, opts.NightEnd} {
+		b := c.FirstOfDay(t)
+		if b.After(t) && b.Before(next) {
+			next = b
+		}
+	}
+
+	return next
+}
+
+// mergeShifts will clip each shift to start and end and merge overlapping or adjacent
+// shifts for the same user, so that concurrent shifts are only counted once.
+func mergeShifts(start, end time.Time, shifts []Shift) []Shift {
+	clipped := make([]Shift, 0, len(shifts))
+	for _, s := range shifts {
+		if s.Start.Before(start) {
+			s.Start = start
+		}
+		if s.End.IsZero() || s.End.After(end) {
+			s.End = end
+		}
+		if !s.End.After(s.Start) {
+			continue
+		}
+		clipped = append(clipped, s)
+	}
+	sort.Slice(clipped, func(i, j int) bool {
+		if clipped[i].UserID != clipped[j].UserID {
+			return clipped[i].UserID < clipped[j].UserID
+		}
+		return clipped[i].Start.Before(clipped[j].Start)
+	})
+
+	merged := clipped[:0]
+	for _, s := range clipped {
+		if n := len(merged); n > 0 && merged[n-1].UserID == s.UserID && !s.Start.After(merged[n-1].End) {
+			if s.End.After(merged[n-1].End) {
+				merged[n-1].End = s.End
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// CalculateLoad will compute per-user load from the provided shifts, clipped to the
+// start and end time. Overlapping shifts for the same user are merged and counted as
+// a single shift. Pages are counted if they fall within one of the user's shifts.
+//
+// The result is sorted by total on-call time, descending.
+func CalculateLoad(opts LoadOptions, start, end time.Time, shifts []Shift, pages map[string][]time.Time) []UserLoad {
+	if opts.Loc == nil {
+		opts.Loc = time.UTC
+	}
+
+	loads := make(map[string]*UserLoad)
+	getLoad := func(id string) *UserLoad {
+		l := loads[id]
+		if l == nil {
+			l = &UserLoad{UserID: id}
+			loads[id] = l
+		}
+		return l
+	}
+
+	for _, iv := range mergeShifts(start, end, shifts) {
+		sStart, sEnd := iv.Start, iv.End
+
+		l := getLoad(iv.UserID)
+		l.Shifts++
+		l.OnCall += sEnd.Sub(sStart)
+
+		t := sStart.In(opts.Loc)
+		for t.Before(sEnd) {
+			next := opts.nextBoundary(t)
+			if next.After(sEnd) {
+				next = sEnd.In(opts.Loc)
+			}
+
+			dur := next.Sub(t)
+			if opts.isNight(t) {
+				l.Night += dur
+			}
+			if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+				l.Weekend += dur
+			}
+
+			t = next
+		}
+
+		for _, p := range pages[iv.UserID] {
+			if p.Before(sStart) || !p.Before(sEnd) {
+				continue
+			}
+			l.Pages++
+		}
+	}
+
+	result := make([]UserLoad, 0, len(loads))
+	for _, l := range loads {
+		result = append(result, *l)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OnCall == result[j].OnCall {
+			return result[i].UserID < result[j].UserID
+		}
+		return result[i].OnCall > result[j].OnCall
+	})
+
+	return result
+}
+
+// LoadBySchedule will return the on-call load for each user that was on-call for the given schedule
+// between start and end. Night and weekend time is calculated in the schedule's time zone.
+//
+// Only pages sent by escalation policies that target the schedule are counted.
+func (s *Store) LoadBySchedule(ctx context.Context, scheduleID string, start, end time.Time, nightStart, nightEnd timeutil.Clock) ([]UserLoad, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	shifts, err := s.HistoryBySchedule(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedTZ string
+	var now time.Time
+	err = s.schedTZ.QueryRowContext(ctx, scheduleID).Scan(&schedTZ, &now)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup schedule time zone")
+	}
+	loc, err := util.LoadLocation(schedTZ)
+	if err != nil {
+		return nil, errors.Wrap(err, "load time zone info")
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, sh := range shifts {
+		if seen[sh.UserID] {
+			continue
+		}
+		seen[sh.UserID] = true
+		userIDs = append(userIDs, sh.UserID)
+	}
+
+	pages := make(map[string][]time.Time, len(userIDs))
+	if len(userIDs) > 0 {
+		rows, err := s.userPages.QueryContext(ctx, sqlutil.UUIDArray(userIDs), start, end, scheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup pages")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var userID string
+			var sentAt time.Time
+			err = rows.Scan(&userID, &sentAt)
+			if err != nil {
+				return nil, errors.Wrap(err, "scan page info")
+			}
+			pages[userID] = append(pages[userID], sentAt)
+		}
+		if err = rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "read page info")
+		}
+	}
+
+	return CalculateLoad(LoadOptions{
+		Loc:        loc,
+		NightStart: nightStart,
+		NightEnd:   nightEnd,
+	}, start, end, shifts, pages), nil
+}
+
+// TotalLoad will return the sum of all provided loads. The UserID of the result is left empty.
+func TotalLoad(loads []UserLoad) UserLoad {
+	var total UserLoad
+	for _, l := range loads {
+		total.OnCall += l.OnCall
+		total.Night += l.Night
+		total.Weekend += l.Weekend
+		total.Shifts += l.Shifts
+		total.Pages += l.Pages
+	}
+
+	return total
+}
+
+// LoadByTeam will return the on-call load for each user that was on-call for the given team's
+// default schedule between start and end. If the team has no default schedule, the result is empty.
+func (s *Store) LoadByTeam(ctx context.Context, teamID string, start, end time.Time, nightStart, nightEnd timeutil.Clock) ([]UserLoad, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("TeamID", teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedID sql.NullString
+	err = s.teamSched.QueryRowContext(ctx, teamID).Scan(&schedID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup team schedule")
+	}
+	if !schedID.Valid {
+		return nil, nil
+	}
+
+	return s.LoadBySchedule(ctx, schedID.String, start, end, nightStart, nightEnd)
+}
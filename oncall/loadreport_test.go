@@ -0,0 +1,126 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/oncall"
+)
+
+func TestCalculateLoad(t *testing.T) {
+	opts := oncall.LoadOptions{
+		Loc:        time.UTC,
+		NightStart: oncall.DefaultNightStart,
+		NightEnd:   oncall.DefaultNightEnd,
+	}
+
+	// Friday
+	start := time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	shifts := []oncall.Shift{
+		// Fri 20:00 -> Sat 08:00
+		{UserID: "foo", Start: start.Add(20 * time.Hour), End: start.Add(32 * time.Hour)},
+		// Mon 09:00 -> Mon 17:00
+		{UserID: "bar", Start: start.AddDate(0, 0, 3).Add(9 * time.Hour), End: start.AddDate(0, 0, 3).Add(17 * time.Hour)},
+		// starts before the report window
+		{UserID: "bar", Start: start.Add(-2 * time.Hour), End: start.Add(2 * time.Hour)},
+	}
+	pages := map[string][]time.Time{
+		"foo": {
+			start.Add(21 * time.Hour),
+			start.Add(40 * time.Hour), // not on-call
+		},
+		"bar": {start.AddDate(0, 0, 3).Add(10 * time.Hour)},
+	}
+
+	result := oncall.CalculateLoad(opts, start, end, shifts, pages)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, oncall.UserLoad{
+		UserID:  "foo",
+		OnCall:  12 * time.Hour,
+		Night:   8 * time.Hour,
+		Weekend: 8 * time.Hour,
+		Shifts:  1,
+		Pages:   1,
+	}, result[0])
+
+	assert.Equal(t, oncall.UserLoad{
+		UserID: "bar",
+		OnCall: 10 * time.Hour,
+		Night:  2 * time.Hour,
+		Shifts: 2,
+		Pages:  1,
+	}, result[1])
+}
+
+func TestCalculateLoad_Overlap(t *testing.T) {
+	opts := oncall.LoadOptions{
+		Loc:        time.UTC,
+		NightStart: oncall.DefaultNightStart,
+		NightEnd:   oncall.DefaultNightEnd,
+	}
+
+	// Monday
+	start := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	shifts := []oncall.Shift{
+		// 09:00 -> 17:00, e.g., via a rotation
+		{UserID: "foo", Start: start.Add(9 * time.Hour), End: start.Add(17 * time.Hour)},
+		// 12:00 -> 18:00, e.g., via a direct rule
+		{UserID: "foo", Start: start.Add(12 * time.Hour), End: start.Add(18 * time.Hour)},
+		// 10:00 -> 11:00, fully contained
+		{UserID: "foo", Start: start.Add(10 * time.Hour), End: start.Add(11 * time.Hour)},
+		// 18:00 -> 19:00, adjacent
+		{UserID: "foo", Start: start.Add(18 * time.Hour), End: start.Add(19 * time.Hour)},
+		// 21:00 -> still on-call
+		{UserID: "foo", Start: start.Add(21 * time.Hour)},
+	}
+	pages := map[string][]time.Time{
+		"foo": {start.Add(13 * time.Hour)},
+	}
+
+	result := oncall.CalculateLoad(opts, start, end, shifts, pages)
+	require.Len(t, result, 1)
+	assert.Equal(t, oncall.UserLoad{
+		UserID: "foo",
+		OnCall: 13 * time.Hour,
+		Night:  2 * time.Hour,
+		Shifts: 2,
+		Pages:  1,
+	}, result[0])
+}
+
+func TestCalculateLoad_DayNight(t *testing.T) {
+	// night does not wrap midnight
+	opts := oncall.LoadOptions{
+		Loc:        time.UTC,
+		NightStart: oncall.DefaultNightEnd,
+		NightEnd:   oncall.DefaultNightStart,
+	}
+	start := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+	result := oncall.CalculateLoad(opts, start, start.Add(24*time.Hour), []oncall.Shift{
+		{UserID: "foo", Start: start, End: start.Add(24 * time.Hour)},
+	}, nil)
+	require.Len(t, result, 1)
+	assert.Equal(t, 16*time.Hour, result[0].Night)
+}
+
+func TestTotalLoad(t *testing.T) {
+	total := oncall.TotalLoad([]oncall.UserLoad{
+		{UserID: "foo", OnCall: 12 * time.Hour, Night: 8 * time.Hour, Weekend: 8 * time.Hour, Shifts: 1, Pages: 1},
+		{UserID: "bar", OnCall: 10 * time.Hour, Night: 2 * time.Hour, Shifts: 2, Pages: 1},
+	})
+
+	assert.Equal(t, oncall.UserLoad{
+		OnCall:  22 * time.Hour,
+		Night:   10 * time.Hour,
+		Weekend: 8 * time.Hour,
+		Shifts:  3,
+		Pages:   2,
+	}, total)
+}
@@ -0,0 +1,142 @@
+package oncall
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/validation/validate"
+)
+
+// RestViolationType indicates which rest constraint was violated.
+type RestViolationType string
+
+// Known violation types.
+const (
+	RestViolationMaxConsecutive RestViolationType = "maxConsecutive"
+	RestViolationMinRest        RestViolationType = "minRest"
+)
+
+// A RestViolation represents a single instance of a user being scheduled
+// in violation of a schedule's RestConstraints.
+//
+// For RestViolationMaxConsecutive, Start and End are the bounds of the on-call period
+// that was too long. For RestViolationMinRest, they are the bounds of the break
+// between shifts that was too short.
+type RestViolation struct {
+	UserID string
+	Type   RestViolationType
+	Start  time.Time
+	End    time.Time
+}
+
+// RestViolations will return all violations of the given constraints for the provided shifts.
+//
+// Overlapping or adjacent shifts for the same user are treated as a single, continuous, on-call period.
+func RestViolations(c schedule.RestConstraints, shifts []Shift) []RestViolation {
+	if c.IsZero() {
+		return nil
+	}
+
+	byUser := make(map[string][]Shift)
+	for _, s := range shifts {
+		byUser[s.UserID] = append(byUser[s.UserID], s)
+	}
+
+	var result []RestViolation
+	for userID, userShifts := range byUser {
+		sort.Slice(userShifts, func(i, j int) bool { return userShifts[i].Start.Before(userShifts[j].Start) })
+
+		// merge overlapping/adjacent shifts
+		spans := userShifts[:1]
+		for _, s := range userShifts[1:] {
+			last := &spans[len(spans)-1]
+			if !s.Start.After(last.End) {
+				if s.End.After(last.End) {
+					last.End = s.End
+					last.Truncated = s.Truncated
+				}
+				continue
+			}
+			spans = append(spans, s)
+		}
+
+		for i, s := range spans {
+			if c.MaxConsecutive > 0 && s.End.Sub(s.Start) > c.MaxConsecutive {
+				result = append(result, RestViolation{
+					UserID: userID,
+					Type:   RestViolationMaxConsecutive,
+					Start:  s.Start,
+					End:    s.End,
+				})
+			}
+			if i == 0 || c.MinRest == 0 {
+				continue
+			}
+
+			prev := spans[i-1]
+			if s.Start.Sub(prev.End) < c.MinRest {
+				result = append(result, RestViolation{
+					UserID: userID,
+					Type:   RestViolationMinRest,
+					Start:  prev.End,
+					End:    s.Start,
+				})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Start.Equal(result[j].Start) {
+			return result[i].UserID < result[j].UserID
+		}
+		return result[i].Start.Before(result[j].Start)
+	})
+
+	return result
+}
+
+// RestViolationsBySchedule will return all rest constraint violations for the given schedule that
+// overlap the start and end time. Past violations are calculated from on-call history, future ones
+// from the current configuration.
+func (s *Store) RestViolationsBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]RestViolation, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	id, err := validate.ParseUUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.schedStore.RestConstraints(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsZero() {
+		return nil, nil
+	}
+
+	// pad the range so that constraints spanning the start/end are detected
+	pad := c.MaxConsecutive
+	if c.MinRest > pad {
+		pad = c.MinRest
+	}
+
+	shifts, err := s.HistoryBySchedule(ctx, scheduleID, start.Add(-pad), end.Add(pad))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []RestViolation
+	for _, v := range RestViolations(*c, shifts) {
+		if !v.End.After(start) || !v.Start.Before(end) {
+			continue
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package oncall_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+)
+
+func TestRestViolations(t *testing.T) {
+	start := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	c := schedule.RestConstraints{
+		MaxConsecutive: 12 * time.Hour,
+		MinRest:        8 * time.Hour,
+	}
+
+	shifts := []oncall.Shift{
+		// adjacent shifts are merged into a single 14 hour span
+		{UserID: "foo", Start: at(0), End: at(8)},
+		{UserID: "foo", Start: at(8), End: at(14)},
+
+		// only 4 hours off
+		{UserID: "foo", Start: at(18), End: at(20)},
+
+		// plenty of rest
+		{UserID: "bar", Start: at(0), End: at(4)},
+		{UserID: "bar", Start: at(12), End: at(16)},
+	}
+
+	assert.Equal(t, []oncall.RestViolation{
+		{UserID: "foo", Type: oncall.RestViolationMaxConsecutive, Start: at(0), End: at(14)},
+		{UserID: "foo", Type: oncall.RestViolationMinRest, Start: at(14), End: at(18)},
+	}, oncall.RestViolations(c, shifts))
+
+	assert.Empty(t, oncall.RestViolations(schedule.RestConstraints{}, shifts), "no constraints")
+}
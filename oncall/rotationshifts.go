@@ -0,0 +1,98 @@
+package oncall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Shifts will return the list of rotation turns that overlap the start and end time. Schedule
+// rules and overrides are not considered.
+//
+// If the rotation has a single participant, a single truncated shift is returned.
+func (r ResolvedRotation) Shifts(start, end time.Time) []Shift {
+	if len(r.Users) == 0 || !start.Before(end) {
+		return nil
+	}
+	if len(r.Users) == 1 {
+		return []Shift{{UserID: r.Users[0], Start: start, End: end, Truncated: true}}
+	}
+
+	var result []Shift
+	t := start
+	for t.Before(end) {
+		userID := r.UserID(t)
+		result = append(result, Shift{
+			UserID: userID,
+			Start:  r.CurrentStart,
+			End:    r.CurrentEnd,
+		})
+		t = r.CurrentEnd
+	}
+
+	return result
+}
+
+// ScheduleRotation will return the current state of a rotation assigned to the given schedule.
+func (s *Store) ScheduleRotation(ctx context.Context, scheduleID, rotationID string) (*ResolvedRotation, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Many(
+		validate.UUID("ScheduleID", scheduleID),
+		validate.UUID("RotationID", rotationID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "oncall: fetch schedule rotation", tx)
+
+	var rot ResolvedRotation
+	var rotTZ string
+	err = tx.StmtContext(ctx, s.schedRotByID).QueryRowContext(ctx, scheduleID, rotationID).
+		Scan(&rot.ID, &rot.Name, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("RotationID", "rotation is not assigned to schedule")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup rotation info: %w", err)
+	}
+	loc, err := util.LoadLocation(rotTZ)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone info: %w", err)
+	}
+	rot.Start = rot.Start.In(loc)
+
+	rows, err := tx.StmtContext(ctx, s.rotParts).QueryContext(ctx, sqlutil.UUIDArray{rotationID})
+	if err != nil {
+		return nil, fmt.Errorf("lookup rotation participants: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rotID, userID string
+		err = rows.Scan(&rotID, &userID)
+		if err != nil {
+			return nil, fmt.Errorf("scan rotation participant info: %w", err)
+		}
+		rot.Users = append(rot.Users, userID)
+	}
+
+	return &rot, rows.Err()
+}
@@ -0,0 +1,35 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/schedule/rotation"
+)
+
+func TestResolvedRotation_Shifts(t *testing.T) {
+	rot := ResolvedRotation{
+		Rotation: rotation.Rotation{
+			Type:        rotation.TypeHourly,
+			ShiftLength: 1,
+			Start:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Users:        []string{"a", "b", "c"},
+		CurrentIndex: 1,
+		CurrentStart: time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	hour := func(h int) time.Time { return time.Date(2020, 1, 1, h, 0, 0, 0, time.UTC) }
+	shifts := rot.Shifts(hour(10).Add(30*time.Minute), hour(12).Add(30*time.Minute))
+	assert.Equal(t, []Shift{
+		{UserID: "b", Start: hour(10), End: hour(11)},
+		{UserID: "c", Start: hour(11), End: hour(12)},
+		{UserID: "a", Start: hour(12), End: hour(13)},
+	}, shifts)
+
+	rot.Users = []string{"a"}
+	assert.Equal(t, []Shift{
+		{UserID: "a", Start: hour(10), End: hour(12), Truncated: true},
+	}, rot.Shifts(hour(10), hour(12)))
+}
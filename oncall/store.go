@@ -13,6 +13,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/user/unavailability"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
 	"github.com/target/goalert/validation/validate"
@@ -55,20 +56,27 @@ type Store struct {
 	schedRot    *sql.Stmt
 	rotParts    *sql.Stmt
 
+	schedRotByID *sql.Stmt
+
+	userPages *sql.Stmt
+	teamSched *sql.Stmt
+
 	ruleStore  *rule.Store
 	schedStore *schedule.Store
+	awayStore  *unavailability.Store
 
 	histLim chan struct{}
 }
 
 // NewStore will create a new DB, preparing required statements using the provided context.
-func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore *schedule.Store) (*Store, error) {
+func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore *schedule.Store, awayStore *unavailability.Store) (*Store, error) {
 	p := &util.Prepare{DB: db, Ctx: ctx}
 
 	return &Store{
 		db:         db,
 		ruleStore:  ruleStore,
 		schedStore: schedStore,
+		awayStore:  awayStore,
 
 		histLim: make(chan struct{}, 3), // limit concurrent history queries to 3
 
@@ -89,7 +97,7 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			select step.step_number, oc.user_id, u.name as user_name
 			from services svc
 			join escalation_policy_steps step on step.escalation_policy_id = svc.escalation_policy_id
-			join ep_step_on_call_users oc on oc.ep_step_id = step.id and oc.end_time isnull
+			join ep_step_on_call_users oc on oc.ep_step_id = step.id and oc.end_time isnull and not oc.is_shadow
 			join users u on oc.user_id = u.id
 			where svc.id = $1
 			order by step.step_number, oc.start_time
@@ -126,6 +134,22 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
 			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull
 		`),
+		schedRotByID: p.P(`
+			select
+				rot.id,
+				rot.name,
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.time_zone,
+				state.position,
+				state.shift_start
+			from rotations rot
+			join rotation_state state on state.rotation_id = rot.id
+			where
+				rot.id = $2 and
+				exists (select 1 from schedule_rules rule where rule.schedule_id = $1 and rule.tgt_rotation_id = rot.id)
+		`),
 		rotParts: p.P(`
 			select
 				rotation_id,
@@ -136,6 +160,23 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 				rotation_id,
 				position
 		`),
+		userPages: p.P(`
+			select
+				user_id,
+				sent_at
+			from outgoing_messages
+			where
+				user_id = any($1) and
+				message_type in ('alert_notification', 'alert_notification_bundle') and
+				sent_at between $2 and $3 and
+				coalesce(escalation_policy_id, (select svc.escalation_policy_id from services svc where svc.id = service_id)) in (
+					select step.escalation_policy_id
+					from escalation_policy_actions act
+					join escalation_policy_steps step on step.id = act.escalation_policy_step_id
+					where act.schedule_id = $4
+				)
+		`),
+		teamSched: p.P(`select default_schedule_id from teams where id = $1`),
 	}, p.Err
 }
 
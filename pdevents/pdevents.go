@@ -0,0 +1,251 @@
+// Package pdevents implements an endpoint compatible with the PagerDuty Events API v2,
+// allowing tools that already send PagerDuty events to target GoAlert unchanged.
+package pdevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxBodySize matches the PagerDuty Events API limit of 512KB per event.
+const maxBodySize = 512 * 1024
+
+type event struct {
+	RoutingKey  string `json:"routing_key"`
+	EventAction string `json:"event_action"`
+	DedupKey    string `json:"dedup_key"`
+	Payload     struct {
+		Summary       string          `json:"summary"`
+		Source        string          `json:"source"`
+		Severity      string          `json:"severity"`
+		Timestamp     string          `json:"timestamp"`
+		Component     string          `json:"component"`
+		Group         string          `json:"group"`
+		Class         string          `json:"class"`
+		CustomDetails json.RawMessage `json:"custom_details"`
+	} `json:"payload"`
+	Links []struct {
+		Href string `json:"href"`
+		Text string `json:"text"`
+	} `json:"links"`
+}
+
+func (e event) validate() []string {
+	var errs []string
+	switch e.EventAction {
+	case "trigger":
+		if e.Payload.Summary == "" {
+			errs = append(errs, "'payload.summary' is missing or blank")
+		}
+		if e.Payload.Source == "" {
+			errs = append(errs, "'payload.source' is missing or blank")
+		}
+		switch e.Payload.Severity {
+		case "critical", "error", "warning", "info":
+		default:
+			errs = append(errs, "'payload.severity' must be one of critical, error, warning, or info")
+		}
+	case "acknowledge", "resolve":
+		if e.DedupKey == "" {
+			errs = append(errs, "'dedup_key' is required for "+e.EventAction+" events")
+		}
+	default:
+		errs = append(errs, "'event_action' must be one of trigger, acknowledge, or resolve")
+	}
+	if len(e.DedupKey) > 255 {
+		errs = append(errs, "'dedup_key' cannot exceed 255 characters")
+	}
+
+	return errs
+}
+
+func (e event) details() string {
+	var s strings.Builder
+	for _, l := range e.Links {
+		if validate.AbsoluteURL("Href", l.Href) != nil {
+			continue
+		}
+		text := l.Text
+		if text == "" {
+			text = l.Href
+		}
+		fmt.Fprintf(&s, "[%s](%s)\n\n", text, l.Href)
+	}
+
+	p := e.Payload
+	fmt.Fprintf(&s, "Severity: %s\n\nSource: %s\n\n", p.Severity, p.Source)
+	if p.Component != "" {
+		fmt.Fprintf(&s, "Component: %s\n\n", p.Component)
+	}
+	if p.Group != "" {
+		fmt.Fprintf(&s, "Group: %s\n\n", p.Group)
+	}
+	if p.Class != "" {
+		fmt.Fprintf(&s, "Class: %s\n\n", p.Class)
+	}
+	if len(p.CustomDetails) > 0 && string(p.CustomDetails) != "null" {
+		var buf bytes.Buffer
+		if json.Indent(&buf, p.CustomDetails, "", "  ") == nil {
+			fmt.Fprintf(&s, "## Custom Details\n\n```json\n%s\n```\n", buf.String())
+		}
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+type response struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func writeResponse(w http.ResponseWriter, code int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// processedResponse returns the response for a successfully processed event. If n is nil, there was no
+// open alert matching the event's dedup_key to acknowledge or resolve, and the event was ignored.
+//
+// Ignored events still return 202 Accepted (matching PagerDuty, so existing senders don't treat them as
+// failures) but are identified by an "ignored" status.
+func processedResponse(e event, n *alert.Alert) (int, response) {
+	if n == nil && e.EventAction != "trigger" {
+		return http.StatusAccepted, response{
+			Status:   "ignored",
+			Message:  "No open alert matches dedup_key",
+			DedupKey: e.DedupKey,
+		}
+	}
+
+	return http.StatusAccepted, response{
+		Status:   "success",
+		Message:  "Event processed",
+		DedupKey: e.DedupKey,
+	}
+}
+
+func invalidEvent(w http.ResponseWriter, errs ...string) {
+	writeResponse(w, http.StatusBadRequest, response{
+		Status:  "invalid event",
+		Message: "Event object is invalid",
+		Errors:  errs,
+	})
+}
+
+// EnqueueHandler handles requests in the format of the PagerDuty Events API v2 `enqueue` endpoint.
+//
+// The integration key is taken from the `routing_key` field, or the `token` query parameter
+// if it is not set. Both the dashed UUID and the 32-character form are accepted.
+func EnqueueHandler(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if r.Method != "POST" {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var e event
+		err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&e)
+		if err != nil {
+			invalidEvent(w, "invalid JSON: "+err.Error())
+			return
+		}
+
+		key := e.RoutingKey
+		if key == "" {
+			key = r.URL.Query().Get("token")
+		}
+		keyID, err := uuid.Parse(key)
+		if err != nil {
+			invalidEvent(w, "'routing_key' is missing or invalid")
+			return
+		}
+
+		ctx, err = intDB.Authorize(ctx, authtoken.Token{ID: keyID}, integrationkey.TypePagerDutyEvents)
+		if permission.IsUnauthorized(err) {
+			invalidEvent(w, "'routing_key' is missing or invalid")
+			return
+		}
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "pagerduty events: authorize"))
+			writeResponse(w, http.StatusInternalServerError, response{Status: "error", Message: "internal server error"})
+			return
+		}
+
+		if errs := e.validate(); len(errs) > 0 {
+			invalidEvent(w, errs...)
+			return
+		}
+
+		if e.DedupKey == "" {
+			e.DedupKey = strings.ReplaceAll(uuid.NewString(), "-", "")
+		}
+		ctx = log.WithFields(ctx, log.Fields{
+			"EventAction": e.EventAction,
+			"DedupKey":    e.DedupKey,
+		})
+
+		var status alert.Status
+		switch e.EventAction {
+		case "trigger":
+			status = alert.StatusTriggered
+		case "acknowledge":
+			status = alert.StatusActive
+		case "resolve":
+			status = alert.StatusClosed
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(e.Payload.Summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(e.details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourcePagerDutyEvents,
+			ServiceID: permission.ServiceID(ctx),
+			Dedup:     alert.NewUserDedup(e.DedupKey),
+		}
+		if msg.Summary == "" {
+			// summary is only required when triggering
+			msg.Summary = "PagerDuty event"
+		}
+
+		var n *alert.Alert
+		err = retry.DoTemporaryError(func(int) error {
+			n, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "create or update alert for pagerduty events"))
+			writeResponse(w, http.StatusInternalServerError, response{Status: "error", Message: "internal server error"})
+			return
+		}
+
+		code, resp := processedResponse(e, n)
+		if resp.Status == "ignored" {
+			log.Logf(ctx, "pagerduty events: ignored %s, no open alert for dedup_key", e.EventAction)
+		}
+		writeResponse(w, code, resp)
+	}
+}
@@ -0,0 +1,46 @@
+package pdevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestEvent_Validate(t *testing.T) {
+	check := func(data string, expErrs int) {
+		t.Helper()
+		var e event
+		require.NoError(t, json.Unmarshal([]byte(data), &e))
+		assert.Len(t, e.validate(), expErrs, data)
+	}
+
+	check(`{"event_action":"trigger","payload":{"summary":"disk full","source":"db1","severity":"critical"}}`, 0)
+	check(`{"event_action":"trigger","payload":{"summary":"","source":"","severity":"bad"}}`, 3)
+	check(`{"event_action":"resolve","dedup_key":"abc"}`, 0)
+	check(`{"event_action":"acknowledge"}`, 1)
+	check(`{"event_action":"snooze","dedup_key":"abc"}`, 1)
+}
+
+func TestProcessedResponse(t *testing.T) {
+	open := &alert.Alert{ID: 1}
+
+	check := func(action string, n *alert.Alert, expStatus string) {
+		t.Helper()
+		code, resp := processedResponse(event{EventAction: action, DedupKey: "abc"}, n)
+		assert.Equal(t, http.StatusAccepted, code, action)
+		assert.Equal(t, expStatus, resp.Status, action)
+		assert.Equal(t, "abc", resp.DedupKey, action)
+	}
+
+	check("trigger", open, "success")
+	check("acknowledge", open, "success")
+	check("resolve", open, "success")
+
+	// unknown or already-closed dedup_key
+	check("acknowledge", nil, "ignored")
+	check("resolve", nil, "ignored")
+}
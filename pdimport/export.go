@@ -0,0 +1,105 @@
+package pdimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Export is a collection of PagerDuty REST API resources to import.
+//
+// Each field is expected to contain the (combined) results of the corresponding
+// list endpoint (e.g., `GET /users` and `GET /schedules?include[]=schedule_layers`).
+type Export struct {
+	Users              []User             `json:"users"`
+	Schedules          []Schedule         `json:"schedules"`
+	EscalationPolicies []EscalationPolicy `json:"escalation_policies"`
+	Services           []Service          `json:"services"`
+}
+
+// Reference is a reference to another PagerDuty resource.
+type Reference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// User is a PagerDuty user.
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Schedule is a PagerDuty schedule.
+type Schedule struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	TimeZone       string          `json:"time_zone"`
+	ScheduleLayers []ScheduleLayer `json:"schedule_layers"`
+}
+
+// ScheduleLayer is a single layer of a PagerDuty schedule.
+type ScheduleLayer struct {
+	ID                        string        `json:"id"`
+	Name                      string        `json:"name"`
+	Start                     time.Time     `json:"start"`
+	End                       *time.Time    `json:"end"`
+	RotationVirtualStart      time.Time     `json:"rotation_virtual_start"`
+	RotationTurnLengthSeconds int           `json:"rotation_turn_length_seconds"`
+	Users                     []LayerUser   `json:"users"`
+	Restrictions              []Restriction `json:"restrictions"`
+}
+
+// LayerUser is a user entry of a ScheduleLayer.
+type LayerUser struct {
+	User Reference `json:"user"`
+}
+
+// Restriction limits when a ScheduleLayer is active.
+type Restriction struct {
+	// Type is either `daily_restriction` or `weekly_restriction`.
+	Type            string `json:"type"`
+	StartTimeOfDay  string `json:"start_time_of_day"`
+	DurationSeconds int    `json:"duration_seconds"`
+
+	// StartDayOfWeek is only used for weekly restrictions, 1 is Monday and 7 is Sunday.
+	StartDayOfWeek int `json:"start_day_of_week"`
+}
+
+// EscalationPolicy is a PagerDuty escalation policy.
+type EscalationPolicy struct {
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	NumLoops        int              `json:"num_loops"`
+	EscalationRules []EscalationRule `json:"escalation_rules"`
+}
+
+// EscalationRule is a single step of a PagerDuty escalation policy.
+type EscalationRule struct {
+	ID                       string      `json:"id"`
+	EscalationDelayInMinutes int         `json:"escalation_delay_in_minutes"`
+	Targets                  []Reference `json:"targets"`
+}
+
+// Service is a PagerDuty service.
+type Service struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	EscalationPolicy Reference `json:"escalation_policy"`
+}
+
+// ParseExport will parse a JSON-encoded Export from r.
+func ParseExport(r io.Reader) (*Export, error) {
+	var exp Export
+	err := json.NewDecoder(r).Decode(&exp)
+	if err != nil {
+		return nil, fmt.Errorf("decode PagerDuty export: %w", err)
+	}
+
+	return &exp, nil
+}
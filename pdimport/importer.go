@@ -0,0 +1,356 @@
+package pdimport
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/errutil"
+)
+
+// Config contains the stores used to create imported resources.
+type Config struct {
+	UserStore       *user.Store
+	ScheduleStore   *schedule.Store
+	RotationStore   *rotation.Store
+	RuleStore       *rule.Store
+	EscalationStore *escalation.Store
+	ServiceStore    *service.Store
+}
+
+// Importer will create GoAlert resources from a PagerDuty export.
+type Importer struct {
+	cfg Config
+
+	findUserByEmail *sql.Stmt
+}
+
+// NewImporter will create a new Importer, preparing all statements.
+func NewImporter(ctx context.Context, db *sql.DB, cfg Config) (*Importer, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Importer{
+		cfg: cfg,
+
+		findUserByEmail: p.P(`select id from users where lower(email) = lower($1) order by id limit 1`),
+	}, p.Err
+}
+
+type importState struct {
+	*Importer
+	tx  *sql.Tx
+	now time.Time
+
+	rep *Report
+
+	users     map[string]string
+	schedules map[string]string
+	policies  map[string]string
+}
+
+// Import will create all resources from exp using the provided transaction. Resources that can not be
+// translated are skipped and recorded as issues in the returned Report.
+//
+// The caller is responsible for committing (or rolling back, e.g., for a dry-run) tx.
+func (imp *Importer) Import(ctx context.Context, tx *sql.Tx, exp *Export) (*Report, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("transaction required")
+	}
+
+	s := &importState{
+		Importer: imp,
+		tx:       tx,
+		now:      time.Now(),
+		rep:      &Report{},
+
+		users:     make(map[string]string),
+		schedules: make(map[string]string),
+		policies:  make(map[string]string),
+	}
+
+	for _, u := range exp.Users {
+		err = s.try(ctx, ResourceTypeUser, u.ID, u.Name, func() error { return s.importUser(ctx, u) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, sched := range exp.Schedules {
+		err = s.try(ctx, ResourceTypeSchedule, sched.ID, sched.Name, func() error { return s.importSchedule(ctx, sched) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, ep := range exp.EscalationPolicies {
+		err = s.try(ctx, ResourceTypeEscalationPolicy, ep.ID, ep.Name, func() error { return s.importPolicy(ctx, ep) })
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, svc := range exp.Services {
+		err = s.try(ctx, ResourceTypeService, svc.ID, svc.Name, func() error { return s.importService(ctx, svc) })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.rep, nil
+}
+
+// try will run fn within a savepoint. If fn fails, all of its changes are rolled back and
+// the error is recorded as an issue. Only errors managing the savepoint itself are returned.
+func (s *importState) try(ctx context.Context, t ResourceType, pdID, name string, fn func() error) error {
+	_, err := s.tx.ExecContext(ctx, "savepoint pd_import")
+	if err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	err = errutil.MapDBError(fn())
+	if err != nil {
+		s.rep.addIssue(t, pdID, name, "not imported: "+err.Error())
+		_, err = s.tx.ExecContext(ctx, "rollback to savepoint pd_import")
+		if err != nil {
+			return fmt.Errorf("rollback to savepoint: %w", err)
+		}
+		return nil
+	}
+
+	_, err = s.tx.ExecContext(ctx, "release savepoint pd_import")
+	if err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+func (s *importState) importUser(ctx context.Context, u User) error {
+	if u.Email != "" {
+		var id string
+		err := s.tx.StmtContext(ctx, s.findUserByEmail).QueryRowContext(ctx, u.Email).Scan(&id)
+		if err == nil {
+			s.users[u.ID] = id
+			s.rep.addMapping(ResourceTypeUser, u.ID, id, u.Name, true)
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+
+	newUser := &user.User{
+		Name:  u.Name,
+		Email: u.Email,
+		Role:  permission.RoleUser,
+	}
+	switch u.Role {
+	case "admin", "owner":
+		newUser.Role = permission.RoleAdmin
+	}
+
+	newUser, err := s.cfg.UserStore.InsertTx(ctx, s.tx, newUser)
+	if err != nil {
+		return err
+	}
+
+	s.users[u.ID] = newUser.ID
+	s.rep.addMapping(ResourceTypeUser, u.ID, newUser.ID, newUser.Name, false)
+	return nil
+}
+
+func (s *importState) importSchedule(ctx context.Context, pdSched Schedule) error {
+	loc, err := util.LoadLocation(pdSched.TimeZone)
+	if err != nil {
+		s.rep.addIssue(ResourceTypeSchedule, pdSched.ID, pdSched.Name, fmt.Sprintf("unknown time zone '%s', using UTC", pdSched.TimeZone))
+		loc = time.UTC
+	}
+
+	sched, err := s.cfg.ScheduleStore.CreateScheduleTx(ctx, s.tx, &schedule.Schedule{
+		Name:        sanitizeName(pdSched.Name),
+		Description: truncDesc(pdSched.Description),
+		TimeZone:    loc,
+	})
+	if err != nil {
+		return err
+	}
+
+	var layerCount int
+	for _, layer := range pdSched.ScheduleLayers {
+		if layer.End != nil && layer.End.Before(s.now) {
+			// layer is no longer active
+			continue
+		}
+		layerCount++
+
+		err = s.try(ctx, ResourceTypeScheduleLayer, layer.ID, layer.Name, func() error {
+			return s.importLayer(ctx, sched, pdSched, layer)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if layerCount > 1 {
+		s.rep.addIssue(ResourceTypeSchedule, pdSched.ID, pdSched.Name, fmt.Sprintf("%d layers were combined; GoAlert does not support layer precedence, so overlapping layers will result in multiple on-call users", layerCount))
+	}
+
+	s.schedules[pdSched.ID] = sched.ID
+	s.rep.addMapping(ResourceTypeSchedule, pdSched.ID, sched.ID, sched.Name, false)
+	return nil
+}
+
+func (s *importState) importLayer(ctx context.Context, sched *schedule.Schedule, pdSched Schedule, layer ScheduleLayer) error {
+	if layer.End != nil {
+		s.rep.addIssue(ResourceTypeScheduleLayer, layer.ID, layer.Name, "layer end time is not supported and was ignored")
+	}
+
+	typ, shiftLength, ok := rotationType(layer.RotationTurnLengthSeconds)
+	if !ok {
+		return fmt.Errorf("unsupported rotation turn length of %d seconds (must be a whole number of hours)", layer.RotationTurnLengthSeconds)
+	}
+
+	specs, err := restrictionRules(layer.Restrictions)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []string
+	for _, lu := range layer.Users {
+		id, ok := s.users[lu.User.ID]
+		if !ok {
+			return fmt.Errorf("unknown user '%s'", lu.User.ID)
+		}
+		userIDs = append(userIDs, id)
+	}
+	if len(userIDs) == 0 {
+		return errors.New("layer has no users")
+	}
+
+	rot, err := s.cfg.RotationStore.CreateRotationTx(ctx, s.tx, &rotation.Rotation{
+		Name:        sanitizeName(strings.TrimSpace(pdSched.Name + " " + layer.Name)),
+		Description: truncDesc(fmt.Sprintf("Imported from PagerDuty schedule layer %s (%s).", layer.ID, pdSched.Name)),
+		Type:        typ,
+		ShiftLength: shiftLength,
+		Start:       layer.RotationVirtualStart.In(sched.TimeZone),
+	})
+	if err != nil {
+		return fmt.Errorf("create rotation: %w", err)
+	}
+
+	err = s.cfg.RotationStore.AddRotationUsersTx(ctx, s.tx, rot.ID, userIDs)
+	if err != nil {
+		return fmt.Errorf("add rotation users: %w", err)
+	}
+
+	idx := activeIndex(s.now, layer.RotationVirtualStart, layer.RotationTurnLengthSeconds, len(userIDs))
+	if idx != 0 {
+		err = s.cfg.RotationStore.SetActiveIndexTx(ctx, s.tx, rot.ID, idx)
+		if err != nil {
+			return fmt.Errorf("set active user: %w", err)
+		}
+	}
+
+	for _, spec := range specs {
+		_, err = s.cfg.RuleStore.CreateRuleTx(ctx, s.tx, &rule.Rule{
+			ScheduleID:    sched.ID,
+			WeekdayFilter: spec.WeekdayFilter,
+			Start:         spec.Start,
+			End:           spec.End,
+			Target:        assignment.RotationTarget(rot.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("create schedule rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *importState) importPolicy(ctx context.Context, pdEP EscalationPolicy) error {
+	repeat := pdEP.NumLoops
+	if repeat > 5 {
+		s.rep.addIssue(ResourceTypeEscalationPolicy, pdEP.ID, pdEP.Name, fmt.Sprintf("repeat count of %d reduced to 5", repeat))
+		repeat = 5
+	}
+
+	ep, err := s.cfg.EscalationStore.CreatePolicyTx(ctx, s.tx, &escalation.Policy{
+		Name:        sanitizeName(pdEP.Name),
+		Description: truncDesc(pdEP.Description),
+		Repeat:      repeat,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, r := range pdEP.EscalationRules {
+		step, err := s.cfg.EscalationStore.CreateStepTx(ctx, s.tx, &escalation.Step{
+			PolicyID:     ep.ID,
+			DelayMinutes: r.EscalationDelayInMinutes,
+		})
+		if err != nil {
+			return fmt.Errorf("create step %d: %w", i, err)
+		}
+
+		for _, t := range r.Targets {
+			var tgt assignment.Target
+			switch t.Type {
+			case "user_reference", "user":
+				if id, ok := s.users[t.ID]; ok {
+					tgt = assignment.UserTarget(id)
+				}
+			case "schedule_reference", "schedule":
+				if id, ok := s.schedules[t.ID]; ok {
+					tgt = assignment.ScheduleTarget(id)
+				}
+			default:
+				s.rep.addIssue(ResourceTypeEscalationPolicy, pdEP.ID, pdEP.Name, fmt.Sprintf("step %d: unsupported target type '%s' (%s)", i, t.Type, t.ID))
+				continue
+			}
+			if tgt == nil {
+				s.rep.addIssue(ResourceTypeEscalationPolicy, pdEP.ID, pdEP.Name, fmt.Sprintf("step %d: target %s '%s' was not imported", i, t.Type, t.ID))
+				continue
+			}
+
+			err = s.cfg.EscalationStore.AddStepTargetTx(ctx, s.tx, step.ID, tgt)
+			if err != nil {
+				return fmt.Errorf("add step %d target: %w", i, err)
+			}
+		}
+	}
+
+	s.policies[pdEP.ID] = ep.ID
+	s.rep.addMapping(ResourceTypeEscalationPolicy, pdEP.ID, ep.ID, ep.Name, false)
+	return nil
+}
+
+func (s *importState) importService(ctx context.Context, pdSvc Service) error {
+	epID, ok := s.policies[pdSvc.EscalationPolicy.ID]
+	if !ok {
+		return fmt.Errorf("escalation policy '%s' was not imported", pdSvc.EscalationPolicy.ID)
+	}
+
+	svc, err := s.cfg.ServiceStore.CreateServiceTx(ctx, s.tx, &service.Service{
+		Name:               sanitizeName(pdSvc.Name),
+		Description:        truncDesc(pdSvc.Description),
+		EscalationPolicyID: epID,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.rep.addMapping(ResourceTypeService, pdSvc.ID, svc.ID, svc.Name, false)
+	return nil
+}
@@ -0,0 +1,48 @@
+package pdimport
+
+// ResourceType identifies the kind of PagerDuty resource.
+type ResourceType string
+
+// Supported resource types.
+const (
+	ResourceTypeUser             ResourceType = "user"
+	ResourceTypeSchedule         ResourceType = "schedule"
+	ResourceTypeScheduleLayer    ResourceType = "schedule_layer"
+	ResourceTypeEscalationPolicy ResourceType = "escalation_policy"
+	ResourceTypeService          ResourceType = "service"
+)
+
+// Mapping records a PagerDuty resource and the GoAlert resource it was translated to.
+type Mapping struct {
+	Type        ResourceType
+	PagerDutyID string
+
+	// ID is the GoAlert ID of the created (or existing) resource.
+	ID   string
+	Name string
+
+	// Existing indicates the resource already existed and was reused (e.g., a user with a matching email).
+	Existing bool
+}
+
+// Issue records something that could not be translated, or was only partially translated.
+type Issue struct {
+	Type        ResourceType
+	PagerDutyID string
+	Name        string
+	Message     string
+}
+
+// Report describes the result of an import.
+type Report struct {
+	Mappings []Mapping
+	Issues   []Issue
+}
+
+func (r *Report) addIssue(t ResourceType, pdID, name, msg string) {
+	r.Issues = append(r.Issues, Issue{Type: t, PagerDutyID: pdID, Name: name, Message: msg})
+}
+
+func (r *Report) addMapping(t ResourceType, pdID, id, name string, existing bool) {
+	r.Mappings = append(r.Mappings, Mapping{Type: t, PagerDutyID: pdID, ID: id, Name: name, Existing: existing})
+}
@@ -0,0 +1,165 @@
+package pdimport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/util/timeutil"
+)
+
+const day = 24 * time.Hour
+
+// ruleSpec describes a schedule rule, without a schedule or target.
+type ruleSpec struct {
+	timeutil.WeekdayFilter
+	Start, End timeutil.Clock
+}
+
+var alwaysActive = ruleSpec{WeekdayFilter: timeutil.EveryDay()}
+
+// restrictionRules will translate PagerDuty layer restrictions into equivalent schedule rules.
+//
+// No restrictions results in a single always-active rule.
+func restrictionRules(restrictions []Restriction) ([]ruleSpec, error) {
+	if len(restrictions) == 0 {
+		return []ruleSpec{alwaysActive}, nil
+	}
+
+	var result []ruleSpec
+	for i, r := range restrictions {
+		start, err := timeutil.ParseClock(r.StartTimeOfDay)
+		if err != nil {
+			return nil, fmt.Errorf("restriction %d: parse start_time_of_day: %w", i, err)
+		}
+		dur := time.Duration(r.DurationSeconds) * time.Second
+		if dur <= 0 {
+			continue
+		}
+
+		switch r.Type {
+		case "daily_restriction":
+			if dur >= day {
+				return []ruleSpec{alwaysActive}, nil
+			}
+			result = append(result, ruleSpec{
+				WeekdayFilter: timeutil.EveryDay(),
+				Start:         start,
+				End:           addClock(start, dur),
+			})
+		case "weekly_restriction":
+			if dur >= 7*day {
+				return []ruleSpec{alwaysActive}, nil
+			}
+			if r.StartDayOfWeek < 1 || r.StartDayOfWeek > 7 {
+				return nil, fmt.Errorf("restriction %d: invalid start_day_of_week %d", i, r.StartDayOfWeek)
+			}
+			result = append(result, weeklyRules(time.Weekday(r.StartDayOfWeek%7), start, dur)...)
+		default:
+			return nil, fmt.Errorf("restriction %d: unsupported type '%s'", i, r.Type)
+		}
+	}
+
+	return result, nil
+}
+
+// weeklyRules will return rules active starting at the given weekday and time, for dur.
+func weeklyRules(wd time.Weekday, start timeutil.Clock, dur time.Duration) []ruleSpec {
+	var f timeutil.WeekdayFilter
+	if dur < day {
+		f.SetDay(wd, true)
+		return []ruleSpec{{WeekdayFilter: f, Start: start, End: addClock(start, dur)}}
+	}
+
+	var result []ruleSpec
+	if start != 0 {
+		// remainder of the first day
+		f.SetDay(wd, true)
+		result = append(result, ruleSpec{WeekdayFilter: f, Start: start})
+		dur -= day - time.Duration(start)
+		wd = (wd + 1) % 7
+	}
+
+	var full timeutil.WeekdayFilter
+	for dur >= day {
+		full.SetDay(wd, true)
+		dur -= day
+		wd = (wd + 1) % 7
+	}
+	if !full.IsNever() {
+		result = append(result, ruleSpec{WeekdayFilter: full})
+	}
+
+	if dur > 0 {
+		var last timeutil.WeekdayFilter
+		last.SetDay(wd, true)
+		result = append(result, ruleSpec{WeekdayFilter: last, End: timeutil.Clock(dur.Truncate(time.Minute))})
+	}
+
+	return result
+}
+
+func addClock(c timeutil.Clock, dur time.Duration) timeutil.Clock {
+	return timeutil.Clock((time.Duration(c) + dur.Truncate(time.Minute)) % day)
+}
+
+// rotationType will return the rotation type and shift length equivalent to the given
+// turn length. If there is no equivalent, ok will be false.
+func rotationType(turnSeconds int) (typ rotation.Type, shiftLength int, ok bool) {
+	turn := time.Duration(turnSeconds) * time.Second
+	switch {
+	case turn <= 0:
+		return "", 0, false
+	case turn%(7*day) == 0:
+		return rotation.TypeWeekly, int(turn / (7 * day)), true
+	case turn%day == 0:
+		return rotation.TypeDaily, int(turn / day), true
+	case turn%time.Hour == 0:
+		return rotation.TypeHourly, int(turn / time.Hour), true
+	}
+
+	return "", 0, false
+}
+
+// activeIndex returns the index of the user that is currently on-call for a layer.
+func activeIndex(now, virtualStart time.Time, turnSeconds, userCount int) int {
+	if userCount == 0 || turnSeconds <= 0 || now.Before(virtualStart) {
+		return 0
+	}
+
+	turns := int64(now.Sub(virtualStart) / (time.Duration(turnSeconds) * time.Second))
+	return int(turns % int64(userCount))
+}
+
+// sanitizeName will convert a PagerDuty name into a valid GoAlert name.
+func sanitizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '\'':
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	name = strings.Join(strings.Fields(b.String()), " ")
+	if name == "" || !(name[0] >= 'a' && name[0] <= 'z' || name[0] >= 'A' && name[0] <= 'Z') {
+		name = strings.TrimSpace("PD " + name)
+	}
+	if len(name) > 64 {
+		name = strings.TrimSpace(name[:64])
+	}
+
+	return name
+}
+
+// truncDesc will truncate a description to the maximum length allowed.
+func truncDesc(desc string) string {
+	r := []rune(desc)
+	if len(r) <= 255 {
+		return desc
+	}
+
+	return string(r[:255])
+}
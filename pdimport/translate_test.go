@@ -0,0 +1,87 @@
+package pdimport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestRestrictionRules(t *testing.T) {
+	days := func(d ...time.Weekday) timeutil.WeekdayFilter {
+		var f timeutil.WeekdayFilter
+		for _, d := range d {
+			f.SetDay(d, true)
+		}
+		return f
+	}
+
+	check := func(desc string, r []Restriction, expected ...ruleSpec) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			res, err := restrictionRules(r)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	check("none", nil, alwaysActive)
+	check("daily",
+		[]Restriction{{Type: "daily_restriction", StartTimeOfDay: "08:00:00", DurationSeconds: 9 * 3600}},
+		ruleSpec{WeekdayFilter: timeutil.EveryDay(), Start: timeutil.NewClock(8, 0), End: timeutil.NewClock(17, 0)},
+	)
+	check("daily-overnight",
+		[]Restriction{{Type: "daily_restriction", StartTimeOfDay: "17:00:00", DurationSeconds: 15 * 3600}},
+		ruleSpec{WeekdayFilter: timeutil.EveryDay(), Start: timeutil.NewClock(17, 0), End: timeutil.NewClock(8, 0)},
+	)
+	check("weekly-short",
+		[]Restriction{{Type: "weekly_restriction", StartDayOfWeek: 7, StartTimeOfDay: "09:00:00", DurationSeconds: 3600}},
+		ruleSpec{WeekdayFilter: days(time.Sunday), Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(10, 0)},
+	)
+	check("weekly-weekdays",
+		// Monday 09:00 through Friday 17:00
+		[]Restriction{{Type: "weekly_restriction", StartDayOfWeek: 1, StartTimeOfDay: "09:00:00", DurationSeconds: 4*86400 + 8*3600}},
+		ruleSpec{WeekdayFilter: days(time.Monday), Start: timeutil.NewClock(9, 0)},
+		ruleSpec{WeekdayFilter: days(time.Tuesday, time.Wednesday, time.Thursday)},
+		ruleSpec{WeekdayFilter: days(time.Friday), End: timeutil.NewClock(17, 0)},
+	)
+
+	_, err := restrictionRules([]Restriction{{Type: "monthly_restriction", StartTimeOfDay: "00:00:00", DurationSeconds: 60}})
+	assert.Error(t, err)
+}
+
+func TestRotationType(t *testing.T) {
+	typ, n, ok := rotationType(2 * 7 * 86400)
+	assert.True(t, ok)
+	assert.Equal(t, rotation.TypeWeekly, typ)
+	assert.Equal(t, 2, n)
+
+	typ, n, ok = rotationType(86400)
+	assert.True(t, ok)
+	assert.Equal(t, rotation.TypeDaily, typ)
+	assert.Equal(t, 1, n)
+
+	typ, n, ok = rotationType(12 * 3600)
+	assert.True(t, ok)
+	assert.Equal(t, rotation.TypeHourly, typ)
+	assert.Equal(t, 12, n)
+
+	_, _, ok = rotationType(90 * 60)
+	assert.False(t, ok)
+}
+
+func TestActiveIndex(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 0, activeIndex(start.Add(-time.Hour), start, 86400, 3))
+	assert.Equal(t, 1, activeIndex(start.Add(25*time.Hour), start, 86400, 3))
+	assert.Equal(t, 0, activeIndex(start.Add(73*time.Hour), start, 86400, 3))
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "Ops Primary", sanitizeName("Ops / Primary"))
+	assert.Equal(t, "PD 24x7 Support", sanitizeName("24x7 Support!"))
+	assert.Equal(t, "PD", sanitizeName("***"))
+}
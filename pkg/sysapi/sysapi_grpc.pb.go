@@ -23,6 +23,12 @@ const (
 	SysAPI_DeleteUser_FullMethodName               = "/goalert.v1.SysAPI/DeleteUser"
 	SysAPI_UsersWithoutAuthProvider_FullMethodName = "/goalert.v1.SysAPI/UsersWithoutAuthProvider"
 	SysAPI_SetAuthSubject_FullMethodName           = "/goalert.v1.SysAPI/SetAuthSubject"
+	SysAPI_TriggerEngineCycle_FullMethodName       = "/goalert.v1.SysAPI/TriggerEngineCycle"
+	SysAPI_EngineCycleStatus_FullMethodName        = "/goalert.v1.SysAPI/EngineCycleStatus"
+	SysAPI_Drain_FullMethodName                    = "/goalert.v1.SysAPI/Drain"
+	SysAPI_DrainStatus_FullMethodName              = "/goalert.v1.SysAPI/DrainStatus"
+	SysAPI_SetLogLevel_FullMethodName              = "/goalert.v1.SysAPI/SetLogLevel"
+	SysAPI_LogLevels_FullMethodName                = "/goalert.v1.SysAPI/LogLevels"
 )
 
 // SysAPIClient is the client API for SysAPI service.
@@ -33,6 +39,12 @@ type SysAPIClient interface {
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	UsersWithoutAuthProvider(ctx context.Context, in *UsersWithoutAuthProviderRequest, opts ...grpc.CallOption) (SysAPI_UsersWithoutAuthProviderClient, error)
 	SetAuthSubject(ctx context.Context, in *SetAuthSubjectRequest, opts ...grpc.CallOption) (*SetAuthSubjectResponse, error)
+	TriggerEngineCycle(ctx context.Context, in *TriggerEngineCycleRequest, opts ...grpc.CallOption) (*TriggerEngineCycleResponse, error)
+	EngineCycleStatus(ctx context.Context, in *EngineCycleStatusRequest, opts ...grpc.CallOption) (*EngineCycleStatusResponse, error)
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error)
 }
 
 type sysAPIClient struct {
@@ -125,6 +137,60 @@ func (c *sysAPIClient) SetAuthSubject(ctx context.Context, in *SetAuthSubjectReq
 	return out, nil
 }
 
+func (c *sysAPIClient) TriggerEngineCycle(ctx context.Context, in *TriggerEngineCycleRequest, opts ...grpc.CallOption) (*TriggerEngineCycleResponse, error) {
+	out := new(TriggerEngineCycleResponse)
+	err := c.cc.Invoke(ctx, SysAPI_TriggerEngineCycle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysAPIClient) EngineCycleStatus(ctx context.Context, in *EngineCycleStatusRequest, opts ...grpc.CallOption) (*EngineCycleStatusResponse, error) {
+	out := new(EngineCycleStatusResponse)
+	err := c.cc.Invoke(ctx, SysAPI_EngineCycleStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysAPIClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, SysAPI_Drain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysAPIClient) DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error) {
+	out := new(DrainStatusResponse)
+	err := c.cc.Invoke(ctx, SysAPI_DrainStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysAPIClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, SysAPI_SetLogLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sysAPIClient) LogLevels(ctx context.Context, in *LogLevelsRequest, opts ...grpc.CallOption) (*LogLevelsResponse, error) {
+	out := new(LogLevelsResponse)
+	err := c.cc.Invoke(ctx, SysAPI_LogLevels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SysAPIServer is the server API for SysAPI service.
 // All implementations must embed UnimplementedSysAPIServer
 // for forward compatibility
@@ -133,6 +199,12 @@ type SysAPIServer interface {
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	UsersWithoutAuthProvider(*UsersWithoutAuthProviderRequest, SysAPI_UsersWithoutAuthProviderServer) error
 	SetAuthSubject(context.Context, *SetAuthSubjectRequest) (*SetAuthSubjectResponse, error)
+	TriggerEngineCycle(context.Context, *TriggerEngineCycleRequest) (*TriggerEngineCycleResponse, error)
+	EngineCycleStatus(context.Context, *EngineCycleStatusRequest) (*EngineCycleStatusResponse, error)
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+	DrainStatus(context.Context, *DrainStatusRequest) (*DrainStatusResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	LogLevels(context.Context, *LogLevelsRequest) (*LogLevelsResponse, error)
 	mustEmbedUnimplementedSysAPIServer()
 }
 
@@ -152,6 +224,24 @@ func (UnimplementedSysAPIServer) UsersWithoutAuthProvider(*UsersWithoutAuthProvi
 func (UnimplementedSysAPIServer) SetAuthSubject(context.Context, *SetAuthSubjectRequest) (*SetAuthSubjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetAuthSubject not implemented")
 }
+func (UnimplementedSysAPIServer) TriggerEngineCycle(context.Context, *TriggerEngineCycleRequest) (*TriggerEngineCycleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerEngineCycle not implemented")
+}
+func (UnimplementedSysAPIServer) EngineCycleStatus(context.Context, *EngineCycleStatusRequest) (*EngineCycleStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EngineCycleStatus not implemented")
+}
+func (UnimplementedSysAPIServer) Drain(context.Context, *DrainRequest) (*DrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedSysAPIServer) DrainStatus(context.Context, *DrainStatusRequest) (*DrainStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainStatus not implemented")
+}
+func (UnimplementedSysAPIServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedSysAPIServer) LogLevels(context.Context, *LogLevelsRequest) (*LogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogLevels not implemented")
+}
 func (UnimplementedSysAPIServer) mustEmbedUnimplementedSysAPIServer() {}
 
 // UnsafeSysAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -243,6 +333,114 @@ func _SysAPI_SetAuthSubject_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SysAPI_TriggerEngineCycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerEngineCycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).TriggerEngineCycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_TriggerEngineCycle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).TriggerEngineCycle(ctx, req.(*TriggerEngineCycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SysAPI_EngineCycleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EngineCycleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).EngineCycleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_EngineCycleStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).EngineCycleStatus(ctx, req.(*EngineCycleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SysAPI_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SysAPI_DrainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).DrainStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_DrainStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).DrainStatus(ctx, req.(*DrainStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SysAPI_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SysAPI_LogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SysAPIServer).LogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SysAPI_LogLevels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SysAPIServer).LogLevels(ctx, req.(*LogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SysAPI_ServiceDesc is the grpc.ServiceDesc for SysAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -258,6 +456,30 @@ var SysAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetAuthSubject",
 			Handler:    _SysAPI_SetAuthSubject_Handler,
 		},
+		{
+			MethodName: "TriggerEngineCycle",
+			Handler:    _SysAPI_TriggerEngineCycle_Handler,
+		},
+		{
+			MethodName: "EngineCycleStatus",
+			Handler:    _SysAPI_EngineCycleStatus_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _SysAPI_Drain_Handler,
+		},
+		{
+			MethodName: "DrainStatus",
+			Handler:    _SysAPI_DrainStatus_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _SysAPI_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "LogLevels",
+			Handler:    _SysAPI_LogLevels_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,118 @@
+package requesttrace
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+// maxResults is the maximum number of alerts or messages returned for a single request ID.
+const maxResults = 500
+
+// Message is an outgoing message caused by a request.
+//
+// Destination values are not included.
+type Message struct {
+	ID                string
+	AlertID           int
+	Type              string
+	DestType          string
+	Status            string
+	StatusDetails     string
+	ProviderMessageID string
+	CreatedAt         time.Time
+	SentAt            time.Time
+}
+
+// Trace is everything recorded for a single request ID.
+type Trace struct {
+	RequestID string
+	AlertIDs  []int
+	Messages  []Message
+}
+
+// Store looks up records by request ID.
+type Store struct {
+	alertIDs *sql.Stmt
+	messages *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		alertIDs: p.P(`select id from alerts where request_id = $1 order by id limit $2`),
+		messages: p.P(`
+			select
+				om.id,
+				coalesce(om.alert_id, 0),
+				om.message_type::text,
+				coalesce(cm.type::text, nc.type::text, ''),
+				om.last_status::text,
+				om.status_details,
+				coalesce(om.provider_msg_id, ''),
+				om.created_at,
+				om.sent_at
+			from outgoing_messages om
+			left join user_contact_methods cm on cm.id = om.contact_method_id
+			left join notification_channels nc on nc.id = om.channel_id
+			where om.request_id = $1
+			order by om.created_at, om.id
+			limit $2
+		`),
+	}, p.Err
+}
+
+// Lookup returns the alerts and outgoing messages recorded for a request ID.
+//
+// Messages sent for an alert are included when the alert was created by the request.
+func (s *Store) Lookup(ctx context.Context, requestID string) (*Trace, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if requestID == "" {
+		return nil, validation.NewFieldError("RequestID", "must not be empty")
+	}
+
+	t := Trace{RequestID: requestID}
+	rows, err := s.alertIDs.QueryContext(ctx, requestID, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		t.AlertIDs = append(t.AlertIDs, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = s.messages.QueryContext(ctx, requestID, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m Message
+		var sentAt sql.NullTime
+		err = rows.Scan(&m.ID, &m.AlertID, &m.Type, &m.DestType, &m.Status, &m.StatusDetails, &m.ProviderMessageID, &m.CreatedAt, &sentAt)
+		if err != nil {
+			return nil, err
+		}
+		m.SentAt = sentAt.Time
+		t.Messages = append(t.Messages, m)
+	}
+
+	return &t, rows.Err()
+}
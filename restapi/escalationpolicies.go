@@ -0,0 +1,247 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/validation"
+)
+
+// EscalationPolicy is the v1 representation of an escalation policy.
+type EscalationPolicy struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	Repeat            int     `json:"repeat"`
+	BackoffMultiplier float64 `json:"backoff_multiplier"`
+	MaxNotifications  int     `json:"max_notifications"`
+	GiveUpChannelID   string  `json:"give_up_channel_id"`
+
+	// Steps are replaced in order on update.
+	Steps []EscalationStep `json:"steps"`
+}
+
+// EscalationStep is a single step of an escalation policy.
+type EscalationStep struct {
+	DelayMinutes     int      `json:"delay_minutes"`
+	ConferenceBridge bool     `json:"conference_bridge"`
+	Targets          []Target `json:"targets"`
+}
+
+// Target is an escalation step target (e.g., a user, schedule, or rotation).
+type Target struct {
+	Type assignment.TargetType `json:"type"`
+	ID   string                `json:"id"`
+}
+
+func (h *Handler) findPolicy(ctx context.Context, tx *sql.Tx, id string) (*EscalationPolicy, error) {
+	var p *escalation.Policy
+	var err error
+	if tx == nil {
+		p, err = h.cfg.PolicyStore.FindOnePolicyTx(ctx, nil, id)
+	} else {
+		p, err = h.cfg.PolicyStore.FindOnePolicyForUpdateTx(ctx, tx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &EscalationPolicy{
+		ID:                p.ID,
+		Name:              p.Name,
+		Description:       p.Description,
+		Repeat:            p.Repeat,
+		BackoffMultiplier: p.BackoffMultiplier,
+		MaxNotifications:  p.MaxNotifications,
+		GiveUpChannelID:   p.GiveUpChannelID,
+		Steps:             []EscalationStep{},
+	}
+
+	steps, err := h.cfg.PolicyStore.FindAllStepsTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range steps {
+		tgts, err := h.cfg.PolicyStore.FindAllStepTargetsTx(ctx, tx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		step := EscalationStep{
+			DelayMinutes:     s.DelayMinutes,
+			ConferenceBridge: s.ConferenceBridge,
+			Targets:          make([]Target, len(tgts)),
+		}
+		for i, t := range tgts {
+			step.Targets[i] = Target{Type: t.TargetType(), ID: t.TargetID()}
+		}
+		res.Steps = append(res.Steps, step)
+	}
+
+	return res, nil
+}
+
+// setSteps will update the steps of a policy to match the provided list, updating
+// existing steps in place so alerts currently escalating are not affected.
+func (h *Handler) setSteps(ctx context.Context, tx *sql.Tx, policyID string, steps []EscalationStep) error {
+	cfg := config.FromContext(ctx)
+	for i, s := range steps {
+		seen := make(map[assignment.RawTarget]bool, len(s.Targets))
+		for j, t := range s.Targets {
+			rt := assignment.RawTarget{Type: t.Type, ID: t.ID}
+			if seen[rt] {
+				return validation.NewFieldError(fmt.Sprintf("steps[%d].targets[%d]", i, j), "duplicate target")
+			}
+			seen[rt] = true
+			if t.Type == assignment.TargetTypeChanWebhook && !cfg.ValidWebhookURL(t.ID) {
+				return validation.NewFieldError(fmt.Sprintf("steps[%d].targets[%d]", i, j), "URL not allowed by administrator")
+			}
+		}
+	}
+
+	cur, err := h.cfg.PolicyStore.FindAllStepsTx(ctx, tx, policyID)
+	if err != nil {
+		return err
+	}
+
+	for i, s := range steps {
+		var stepID string
+		var currentTargets []assignment.Target
+		if i < len(cur) {
+			stepID = cur[i].ID
+			if cur[i].DelayMinutes != s.DelayMinutes {
+				err = h.cfg.PolicyStore.UpdateStepDelayTx(ctx, tx, stepID, s.DelayMinutes)
+				if err != nil {
+					return err
+				}
+			}
+			if cur[i].ConferenceBridge != s.ConferenceBridge {
+				err = h.cfg.PolicyStore.UpdateStepConferenceBridgeTx(ctx, tx, stepID, s.ConferenceBridge)
+				if err != nil {
+					return err
+				}
+			}
+			currentTargets, err = h.cfg.PolicyStore.FindAllStepTargetsTx(ctx, tx, stepID)
+			if err != nil {
+				return err
+			}
+		} else {
+			st, err := h.cfg.PolicyStore.CreateStepTx(ctx, tx, &escalation.Step{
+				PolicyID:         policyID,
+				DelayMinutes:     s.DelayMinutes,
+				ConferenceBridge: s.ConferenceBridge,
+			})
+			if err != nil {
+				return err
+			}
+			stepID = st.ID
+		}
+
+		wanted := make(map[assignment.RawTarget]bool, len(s.Targets))
+		for _, t := range s.Targets {
+			wanted[assignment.RawTarget{Type: t.Type, ID: t.ID}] = true
+		}
+		existing := make(map[assignment.RawTarget]bool, len(currentTargets))
+		for _, t := range currentTargets {
+			rt := assignment.RawTarget{Type: t.TargetType(), ID: t.TargetID()}
+			existing[rt] = true
+			if wanted[rt] {
+				continue
+			}
+			err = h.cfg.PolicyStore.DeleteStepTargetTx(ctx, tx, stepID, rt)
+			if err != nil {
+				return err
+			}
+		}
+		for _, t := range s.Targets {
+			rt := assignment.RawTarget{Type: t.Type, ID: t.ID}
+			if existing[rt] {
+				continue
+			}
+			err = h.cfg.PolicyStore.AddStepTargetTx(ctx, tx, stepID, rt)
+			if err != nil {
+				return validation.AddPrefix(fmt.Sprintf("steps[%d].", i), err)
+			}
+		}
+	}
+
+	for _, s := range cur[min(len(steps), len(cur)):] {
+		_, err = h.cfg.PolicyStore.DeleteStepTx(ctx, tx, s.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) escalationPolicies() http.Handler {
+	return resource[EscalationPolicy]{
+		fields: map[string]string{
+			"LIST":            "Query.escalationPolicies",
+			http.MethodGet:    "Query.escalationPolicy",
+			http.MethodPost:   "Mutation.createEscalationPolicy",
+			http.MethodPut:    "Mutation.updateEscalationPolicy",
+			http.MethodDelete: "Mutation.deleteAll",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]EscalationPolicy, error) {
+			pols, err := h.cfg.PolicyStore.Search(ctx, &escalation.SearchOptions{
+				Search: opts.Search,
+				After:  escalation.SearchCursor{Name: opts.After},
+				Limit:  opts.Limit + 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res := make([]EscalationPolicy, 0, len(pols))
+			for _, p := range pols {
+				pol, err := h.findPolicy(ctx, nil, p.ID)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, *pol)
+			}
+			return res, nil
+		},
+		find: h.findPolicy,
+		create: func(ctx context.Context, tx *sql.Tx, v *EscalationPolicy) (string, error) {
+			p, err := h.cfg.PolicyStore.CreatePolicyTx(ctx, tx, &escalation.Policy{
+				Name:              v.Name,
+				Description:       v.Description,
+				Repeat:            v.Repeat,
+				BackoffMultiplier: v.BackoffMultiplier,
+				MaxNotifications:  v.MaxNotifications,
+				GiveUpChannelID:   v.GiveUpChannelID,
+			})
+			if err != nil {
+				return "", err
+			}
+
+			return p.ID, h.setSteps(ctx, tx, p.ID, v.Steps)
+		},
+		update: func(ctx context.Context, tx *sql.Tx, _, v *EscalationPolicy) error {
+			err := h.cfg.PolicyStore.UpdatePolicyTx(ctx, tx, &escalation.Policy{
+				ID:                v.ID,
+				Name:              v.Name,
+				Description:       v.Description,
+				Repeat:            v.Repeat,
+				BackoffMultiplier: v.BackoffMultiplier,
+				MaxNotifications:  v.MaxNotifications,
+				GiveUpChannelID:   v.GiveUpChannelID,
+			})
+			if err != nil {
+				return err
+			}
+
+			return h.setSteps(ctx, tx, v.ID, v.Steps)
+		},
+		delete: func(ctx context.Context, tx *sql.Tx, id string) error {
+			return h.cfg.PolicyStore.DeleteManyPoliciesTx(ctx, tx, []string{id})
+		},
+		setID: func(v *EscalationPolicy, id string) { v.ID = id },
+	}.serveHTTP(h, Prefix+"escalation-policies")
+}
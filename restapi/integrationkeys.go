@@ -0,0 +1,81 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/validation"
+)
+
+// IntegrationKey is the v1 representation of an integration key.
+//
+// Integration keys can not be modified, so a change requires replacing the key.
+type IntegrationKey struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	Type      integrationkey.Type `json:"type"`
+	ServiceID string              `json:"service_id"`
+}
+
+func newIntegrationKey(k integrationkey.IntegrationKey) IntegrationKey {
+	return IntegrationKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Type:      k.Type,
+		ServiceID: k.ServiceID,
+	}
+}
+
+func (h *Handler) integrationKeys() http.Handler {
+	return resource[IntegrationKey]{
+		fields: map[string]string{
+			"LIST":            "Service.integrationKeys",
+			http.MethodGet:    "Query.integrationKey",
+			http.MethodPost:   "Mutation.createIntegrationKey",
+			http.MethodDelete: "Mutation.deleteAll",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]IntegrationKey, error) {
+			svcID := opts.Query.Get("service_id")
+			if svcID == "" {
+				return nil, validation.NewFieldError("service_id", "is required")
+			}
+			keys, err := h.cfg.IntKeyStore.FindAllByService(ctx, svcID)
+			if err != nil {
+				return nil, err
+			}
+			res := make([]IntegrationKey, len(keys))
+			for i, k := range keys {
+				res[i] = newIntegrationKey(k)
+			}
+			return res, nil
+		},
+		find: func(ctx context.Context, _ *sql.Tx, id string) (*IntegrationKey, error) {
+			k, err := h.cfg.IntKeyStore.FindOne(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if k == nil {
+				return nil, errNotFound
+			}
+			res := newIntegrationKey(*k)
+			return &res, nil
+		},
+		create: func(ctx context.Context, tx *sql.Tx, v *IntegrationKey) (string, error) {
+			k, err := h.cfg.IntKeyStore.Create(ctx, tx, &integrationkey.IntegrationKey{
+				Name:      v.Name,
+				Type:      v.Type,
+				ServiceID: v.ServiceID,
+			})
+			if err != nil {
+				return "", err
+			}
+			return k.ID, nil
+		},
+		delete: func(ctx context.Context, tx *sql.Tx, id string) error {
+			return h.cfg.IntKeyStore.Delete(ctx, tx, id)
+		},
+		setID: func(v *IntegrationKey, id string) { v.ID = id },
+	}.serveHTTP(h, Prefix+"integration-keys")
+}
@@ -0,0 +1,335 @@
+package restapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+// errNotFound is returned when a resource does not exist.
+var errNotFound = errors.New("not found")
+
+// listOptions are the query parameters for listing resources.
+type listOptions struct {
+	Search string
+	After  string
+	Limit  int
+	Query  url.Values
+}
+
+// listResult is the response body for listing resources.
+type listResult[T any] struct {
+	Items   []T  `json:"items"`
+	HasMore bool `json:"has_more"`
+}
+
+// resource implements the REST methods for a single resource type. Nil
+// functions indicate the method is not supported.
+type resource[T any] struct {
+	// fields are the GraphQL fields (e.g., Query.service) an API key must
+	// allow to use each method, by HTTP method, with "LIST" for collections.
+	fields map[string]string
+
+	// list returns up to opts.Limit+1 items, so that has_more can be determined.
+	list func(ctx context.Context, opts listOptions) ([]T, error)
+
+	// find returns errNotFound (or sql.ErrNoRows) if the resource doesn't exist. If tx
+	// is non-nil the resource should be locked for update.
+	find func(ctx context.Context, tx *sql.Tx, id string) (*T, error)
+
+	// create returns the ID of the new resource.
+	create func(ctx context.Context, tx *sql.Tx, v *T) (string, error)
+
+	// update applies v to the existing resource.
+	update func(ctx context.Context, tx *sql.Tx, cur, v *T) error
+
+	delete func(ctx context.Context, tx *sql.Tx, id string) error
+
+	// setID sets the ID field of v, since it is taken from the URL for updates.
+	setID func(v *T, id string)
+}
+
+// etag returns a strong ETag for the resource representation.
+func etag(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchETag reports if the header value (If-Match or If-None-Match) matches tag.
+func matchETag(header, tag string) bool {
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAPIKeyField ensures GraphQL API keys are only used for methods
+// equivalent to the fields they allow.
+func checkAPIKeyField(ctx context.Context, field string) error {
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeGQLAPIKey {
+		return nil
+	}
+
+	p := apikey.PolicyFromContext(ctx)
+	if p == nil || p.Version != 1 {
+		return permission.NewAccessDenied("invalid API key")
+	}
+	if !slices.Contains(p.AllowedFields, field) {
+		return permission.NewAccessDenied("API key does not allow " + field)
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func readJSON(req *http.Request, v interface{}) error {
+	dec := json.NewDecoder(req.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err != nil {
+		return validation.NewGenericError("invalid request body: " + err.Error())
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, errNotFound) || errors.Is(err, sql.ErrNoRows)
+}
+
+func httpError(ctx context.Context, w http.ResponseWriter, err error) bool {
+	if isNotFound(err) {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return true
+	}
+
+	return errutil.HTTPError(ctx, w, err)
+}
+
+// serveHTTP will handle requests for the collection at base (e.g., /api/rest/v1/services) and
+// for individual items (e.g., /api/rest/v1/services/<id>).
+func (r resource[T]) serveHTTP(h *Handler, base string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id := strings.Trim(strings.TrimPrefix(req.URL.Path, base), "/")
+		if strings.Contains(id, "/") {
+			http.NotFound(w, req)
+			return
+		}
+
+		method := req.Method
+		if id == "" && method == http.MethodGet {
+			method = "LIST"
+		}
+
+		var supported bool
+		switch {
+		case method == "LIST":
+			supported = r.list != nil
+		case id == "" && method == http.MethodPost:
+			supported = r.create != nil
+		case id != "" && method == http.MethodGet:
+			supported = r.find != nil
+		case id != "" && method == http.MethodPut:
+			supported = r.update != nil
+		case id != "" && method == http.MethodDelete:
+			supported = r.delete != nil
+		}
+		if !supported {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if httpError(ctx, w, checkAPIKeyField(ctx, r.fields[method])) {
+			return
+		}
+
+		switch method {
+		case "LIST":
+			r.serveList(ctx, w, req)
+		case http.MethodPost:
+			r.serveCreate(ctx, h, w, req, base)
+		case http.MethodGet:
+			r.serveGet(ctx, w, req, id)
+		case http.MethodPut:
+			r.serveUpdate(ctx, h, w, req, id)
+		case http.MethodDelete:
+			r.serveDelete(ctx, h, w, req, id)
+		}
+	})
+}
+
+func (r resource[T]) serveList(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	opts := listOptions{
+		Search: q.Get("search"),
+		After:  q.Get("after"),
+		Limit:  50,
+		Query:  q,
+	}
+	if l := q.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > 100 {
+			httpError(ctx, w, validation.NewFieldError("limit", "must be between 1 and 100"))
+			return
+		}
+		opts.Limit = n
+	}
+
+	items, err := r.list(ctx, opts)
+	if httpError(ctx, w, err) {
+		return
+	}
+
+	res := listResult[T]{Items: items}
+	if len(res.Items) > opts.Limit {
+		res.Items = res.Items[:opts.Limit]
+		res.HasMore = true
+	}
+	if res.Items == nil {
+		res.Items = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (r resource[T]) serveGet(ctx context.Context, w http.ResponseWriter, req *http.Request, id string) {
+	v, err := r.find(ctx, nil, id)
+	if httpError(ctx, w, err) {
+		return
+	}
+
+	tag := etag(v)
+	w.Header().Set("ETag", tag)
+	if inm := req.Header.Get("If-None-Match"); inm != "" && matchETag(inm, tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (r resource[T]) serveCreate(ctx context.Context, h *Handler, w http.ResponseWriter, req *http.Request, base string) {
+	var v T
+	if httpError(ctx, w, readJSON(req, &v)) {
+		return
+	}
+
+	var res *T
+	err := h.withTx(ctx, func(tx *sql.Tx) error {
+		id, err := r.create(ctx, tx, &v)
+		if err != nil {
+			return err
+		}
+
+		res, err = r.find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Location", path.Join(base, id))
+		return nil
+	})
+	if httpError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("ETag", etag(res))
+	writeJSON(w, http.StatusCreated, res)
+}
+
+// serveUpdate replaces the resource with the request body. Repeating the same
+// request has no further effect, and a request matching the current state is a no-op.
+func (r resource[T]) serveUpdate(ctx context.Context, h *Handler, w http.ResponseWriter, req *http.Request, id string) {
+	var v T
+	if httpError(ctx, w, readJSON(req, &v)) {
+		return
+	}
+	r.setID(&v, id)
+
+	var res *T
+	var preconditionFailed bool
+	err := h.withTx(ctx, func(tx *sql.Tx) error {
+		cur, err := r.find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if im := req.Header.Get("If-Match"); im != "" && !matchETag(im, etag(cur)) {
+			preconditionFailed = true
+			return nil
+		}
+		if etag(cur) == etag(&v) {
+			res = cur
+			return nil
+		}
+
+		err = r.update(ctx, tx, cur, &v)
+		if err != nil {
+			return err
+		}
+
+		res, err = r.find(ctx, tx, id)
+		return err
+	})
+	if httpError(ctx, w, err) {
+		return
+	}
+	if preconditionFailed {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	w.Header().Set("ETag", etag(res))
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (r resource[T]) serveDelete(ctx context.Context, h *Handler, w http.ResponseWriter, req *http.Request, id string) {
+	var preconditionFailed bool
+	err := h.withTx(ctx, func(tx *sql.Tx) error {
+		cur, err := r.find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if im := req.Header.Get("If-Match"); im != "" && !matchETag(im, etag(cur)) {
+			preconditionFailed = true
+			return nil
+		}
+
+		return r.delete(ctx, tx, id)
+	})
+	if httpError(ctx, w, err) {
+		return
+	}
+	if preconditionFailed {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/apikey"
+	"github.com/target/goalert/permission"
+)
+
+type testItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func newTestResource() http.Handler {
+	items := []testItem{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}, {ID: "c", Name: "C"}}
+	return resource[testItem]{
+		fields: map[string]string{
+			"LIST":         "Query.items",
+			http.MethodGet: "Query.item",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]testItem, error) {
+			if len(items) > opts.Limit+1 {
+				return items[:opts.Limit+1], nil
+			}
+			return items, nil
+		},
+		find: func(ctx context.Context, tx *sql.Tx, id string) (*testItem, error) {
+			for _, it := range items {
+				if it.ID == id {
+					return &it, nil
+				}
+			}
+			return nil, errNotFound
+		},
+		setID: func(v *testItem, id string) { v.ID = id },
+	}.serveHTTP(nil, "/items")
+}
+
+func TestResource(t *testing.T) {
+	h := newTestResource()
+	ctx := permission.UserContext(context.Background(), "00000000-0000-0000-0000-000000000001", permission.RoleAdmin)
+
+	do := func(method, target string, hdr map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, target, nil).WithContext(ctx)
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("GET", "/items?limit=2", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"items":[{"id":"a","name":"A"},{"id":"b","name":"B"}],"has_more":true}`, rec.Body.String())
+
+	rec = do("GET", "/items?limit=0", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do("GET", "/items/b", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	tag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, tag)
+
+	rec = do("GET", "/items/b", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = do("GET", "/items/z", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = do("PUT", "/items/b", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// API keys must allow the equivalent GraphQL field
+	ctx = permission.SourceContext(ctx, &permission.SourceInfo{Type: permission.SourceTypeGQLAPIKey, ID: "key"})
+	ctx = apikey.ContextWithPolicy(ctx, &apikey.GQLPolicy{Version: 1, AllowedFields: []string{"Query.item"}})
+
+	rec = do("GET", "/items/b", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do("GET", "/items", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, matchETag(`"abc"`, `"abc"`))
+	assert.True(t, matchETag(`"x", W/"abc"`, `"abc"`))
+	assert.True(t, matchETag(`*`, `"abc"`))
+	assert.False(t, matchETag(`"abcd"`, `"abc"`))
+}
@@ -0,0 +1,83 @@
+// Package restapi implements a versioned REST API for managing services, escalation policies,
+// schedules, integration keys, and users.
+//
+// It is intended as a stable surface for infrastructure-as-code tools (e.g., Terraform);
+// fields are only ever added to v1 resources, never renamed or removed.
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// Prefix is the path prefix for all v1 REST API requests.
+const Prefix = "/api/rest/v1/"
+
+// Config contains the stores used by the REST API.
+type Config struct {
+	DB *sql.DB
+
+	ServiceStore  *service.Store
+	PolicyStore   *escalation.Store
+	ScheduleStore *schedule.Store
+	IntKeyStore   *integrationkey.Store
+	UserStore     *user.Store
+}
+
+// Handler serves the v1 REST API.
+type Handler struct {
+	cfg Config
+
+	routes map[string]http.Handler
+}
+
+// NewHandler will create a new Handler for the REST API.
+func NewHandler(cfg Config) *Handler {
+	h := &Handler{cfg: cfg}
+	h.routes = map[string]http.Handler{
+		"services":            h.services(),
+		"escalation-policies": h.escalationPolicies(),
+		"schedules":           h.schedules(),
+		"integration-keys":    h.integrationKeys(),
+		"users":               h.users(),
+	}
+
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, Prefix), "/")
+	route, ok := h.routes[name]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	route.ServeHTTP(w, req)
+}
+
+// withTx runs fn in a transaction, committing if no error is returned.
+func (h *Handler) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := h.cfg.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "restapi", tx)
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
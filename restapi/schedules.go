@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+// Schedule is the v1 representation of a schedule.
+type Schedule struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TimeZone    string `json:"time_zone"`
+}
+
+func newSchedule(s schedule.Schedule) Schedule {
+	return Schedule{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		TimeZone:    s.TimeZone.String(),
+	}
+}
+
+func (v Schedule) toSchedule() (*schedule.Schedule, error) {
+	tz, err := util.LoadLocation(v.TimeZone)
+	if err != nil {
+		return nil, validation.NewFieldError("time_zone", err.Error())
+	}
+
+	return &schedule.Schedule{
+		ID:          v.ID,
+		Name:        v.Name,
+		Description: v.Description,
+		TimeZone:    tz,
+	}, nil
+}
+
+func (h *Handler) schedules() http.Handler {
+	return resource[Schedule]{
+		fields: map[string]string{
+			"LIST":            "Query.schedules",
+			http.MethodGet:    "Query.schedule",
+			http.MethodPost:   "Mutation.createSchedule",
+			http.MethodPut:    "Mutation.updateSchedule",
+			http.MethodDelete: "Mutation.deleteAll",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]Schedule, error) {
+			scheds, err := h.cfg.ScheduleStore.Search(ctx, &schedule.SearchOptions{
+				Search: opts.Search,
+				After:  schedule.SearchCursor{Name: opts.After},
+				Limit:  opts.Limit + 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res := make([]Schedule, len(scheds))
+			for i, s := range scheds {
+				res[i] = newSchedule(s)
+			}
+			return res, nil
+		},
+		find: func(ctx context.Context, tx *sql.Tx, id string) (*Schedule, error) {
+			var s *schedule.Schedule
+			var err error
+			if tx == nil {
+				s, err = h.cfg.ScheduleStore.FindOne(ctx, id)
+			} else {
+				s, err = h.cfg.ScheduleStore.FindOneForUpdate(ctx, tx, id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			res := newSchedule(*s)
+			return &res, nil
+		},
+		create: func(ctx context.Context, tx *sql.Tx, v *Schedule) (string, error) {
+			s, err := v.toSchedule()
+			if err != nil {
+				return "", err
+			}
+			s, err = h.cfg.ScheduleStore.CreateScheduleTx(ctx, tx, s)
+			if err != nil {
+				return "", err
+			}
+			return s.ID, nil
+		},
+		update: func(ctx context.Context, tx *sql.Tx, _, v *Schedule) error {
+			s, err := v.toSchedule()
+			if err != nil {
+				return err
+			}
+			return h.cfg.ScheduleStore.UpdateTx(ctx, tx, s)
+		},
+		delete: func(ctx context.Context, tx *sql.Tx, id string) error {
+			return h.cfg.ScheduleStore.DeleteManyTx(ctx, tx, []string{id})
+		},
+		setID: func(v *Schedule, id string) { v.ID = id },
+	}.serveHTTP(h, Prefix+"schedules")
+}
@@ -0,0 +1,93 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/service"
+)
+
+// Service is the v1 representation of a service.
+type Service struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	EscalationPolicyID string `json:"escalation_policy_id"`
+}
+
+func newService(s service.Service) Service {
+	return Service{
+		ID:                 s.ID,
+		Name:               s.Name,
+		Description:        s.Description,
+		EscalationPolicyID: s.EscalationPolicyID,
+	}
+}
+
+func (h *Handler) services() http.Handler {
+	return resource[Service]{
+		fields: map[string]string{
+			"LIST":            "Query.services",
+			http.MethodGet:    "Query.service",
+			http.MethodPost:   "Mutation.createService",
+			http.MethodPut:    "Mutation.updateService",
+			http.MethodDelete: "Mutation.deleteAll",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]Service, error) {
+			svcs, err := h.cfg.ServiceStore.Search(ctx, &service.SearchOptions{
+				Search: opts.Search,
+				After:  service.SearchCursor{Name: opts.After},
+				Limit:  opts.Limit + 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res := make([]Service, len(svcs))
+			for i, s := range svcs {
+				res[i] = newService(s)
+			}
+			return res, nil
+		},
+		find: func(ctx context.Context, tx *sql.Tx, id string) (*Service, error) {
+			var s *service.Service
+			var err error
+			if tx == nil {
+				s, err = h.cfg.ServiceStore.FindOne(ctx, id)
+			} else {
+				s, err = h.cfg.ServiceStore.FindOneForUpdate(ctx, tx, id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			res := newService(*s)
+			return &res, nil
+		},
+		create: func(ctx context.Context, tx *sql.Tx, v *Service) (string, error) {
+			s, err := h.cfg.ServiceStore.CreateServiceTx(ctx, tx, &service.Service{
+				Name:               v.Name,
+				Description:        v.Description,
+				EscalationPolicyID: v.EscalationPolicyID,
+			})
+			if err != nil {
+				return "", err
+			}
+			return s.ID, nil
+		},
+		update: func(ctx context.Context, tx *sql.Tx, _, v *Service) error {
+			// re-fetch to keep fields not managed by the REST API (e.g., maintenance mode)
+			s, err := h.cfg.ServiceStore.FindOne(ctx, v.ID)
+			if err != nil {
+				return err
+			}
+			s.Name = v.Name
+			s.Description = v.Description
+			s.EscalationPolicyID = v.EscalationPolicyID
+			return h.cfg.ServiceStore.UpdateTx(ctx, tx, s)
+		},
+		delete: func(ctx context.Context, tx *sql.Tx, id string) error {
+			return h.cfg.ServiceStore.DeleteManyTx(ctx, tx, []string{id})
+		},
+		setID: func(v *Service, id string) { v.ID = id },
+	}.serveHTTP(h, Prefix+"services")
+}
@@ -0,0 +1,84 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+)
+
+// User is the v1 representation of a user.
+//
+// Users are created by logging in (or with basic auth), so they can not be
+// created through the REST API.
+type User struct {
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Email string          `json:"email"`
+	Role  permission.Role `json:"role"`
+}
+
+func newUser(u user.User) User {
+	return User{
+		ID:    u.ID,
+		Name:  u.Name,
+		Email: u.Email,
+		Role:  u.Role,
+	}
+}
+
+func (h *Handler) users() http.Handler {
+	return resource[User]{
+		fields: map[string]string{
+			"LIST":            "Query.users",
+			http.MethodGet:    "Query.user",
+			http.MethodPut:    "Mutation.updateUser",
+			http.MethodDelete: "Mutation.deleteAll",
+		},
+		list: func(ctx context.Context, opts listOptions) ([]User, error) {
+			users, err := h.cfg.UserStore.Search(ctx, &user.SearchOptions{
+				Search: opts.Search,
+				After:  user.SearchCursor{Name: opts.After},
+				Limit:  opts.Limit + 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res := make([]User, len(users))
+			for i, u := range users {
+				res[i] = newUser(u)
+			}
+			return res, nil
+		},
+		find: func(ctx context.Context, tx *sql.Tx, id string) (*User, error) {
+			u, err := h.cfg.UserStore.FindOneTx(ctx, tx, id, tx != nil)
+			if err != nil {
+				return nil, err
+			}
+			res := newUser(*u)
+			return &res, nil
+		},
+		update: func(ctx context.Context, tx *sql.Tx, cur, v *User) error {
+			if v.Role != cur.Role {
+				err := h.cfg.UserStore.SetUserRoleTx(ctx, tx, v.ID, v.Role)
+				if err != nil {
+					return err
+				}
+			}
+
+			u, err := h.cfg.UserStore.FindOneTx(ctx, tx, v.ID, true)
+			if err != nil {
+				return err
+			}
+			u.Name = v.Name
+			u.Email = v.Email
+			return h.cfg.UserStore.UpdateTx(ctx, tx, u)
+		},
+		delete: func(ctx context.Context, tx *sql.Tx, id string) error {
+			return h.cfg.UserStore.DeleteManyTx(ctx, tx, []string{id})
+		},
+		setID: func(v *User, id string) { v.ID = id },
+	}.serveHTTP(h, Prefix+"users")
+}
@@ -7,6 +7,11 @@ type Data struct {
 	V1 struct {
 		TemporarySchedules      []TemporarySchedule
 		OnCallNotificationRules []OnCallNotificationRule
+		RestConstraints         RestConstraints
+		Shadows                 []Shadow
+
+		// MinCoverage is the number of users that should be on-call at all times, 0 means no requirement.
+		MinCoverage int `json:",omitempty"`
 	}
 }
 
@@ -0,0 +1,46 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxMinCoverage is the largest supported minimum coverage requirement.
+const MaxMinCoverage = 10
+
+// MinCoverage returns the number of users required to be on-call simultaneously for the provided scheduleID.
+func (store *Store) MinCoverage(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return 0, err
+	}
+
+	return data.V1.MinCoverage, nil
+}
+
+// SetMinCoverage will set the number of users required to be on-call simultaneously for the given schedule ID.
+func (store *Store) SetMinCoverage(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, n int) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Range("MinCoverage", n, 0, MaxMinCoverage)
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.MinCoverage = n
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// replaceUser will replace userID with replacementID in all temporary schedule shifts and shadows. If
+// replacementID is empty, the shifts and shadows are removed instead.
+func (data *Data) replaceUser(userID, replacementID string) {
+	for i, temp := range data.V1.TemporarySchedules {
+		shifts := temp.Shifts[:0]
+		for _, s := range temp.Shifts {
+			if s.UserID == userID {
+				if replacementID == "" {
+					continue
+				}
+				s.UserID = replacementID
+			}
+			shifts = append(shifts, s)
+		}
+		data.V1.TemporarySchedules[i].Shifts = shifts
+	}
+
+	shadows := data.V1.Shadows[:0]
+	for _, s := range data.V1.Shadows {
+		if s.UserID == userID {
+			s.UserID = replacementID
+		}
+		if s.ShadowUserID == userID {
+			s.ShadowUserID = replacementID
+			if replacementID == "" {
+				// only shadowed the removed user, not everyone on-call
+				continue
+			}
+		}
+		if s.UserID == "" || s.UserID == s.ShadowUserID {
+			continue
+		}
+		shadows = append(shadows, s)
+	}
+	data.V1.Shadows = shadows
+}
+
+// ReplaceUserTx will replace userID with replacementID in the temporary schedules and shadows of all
+// schedules. If replacementID is empty, the user's shifts and shadows are removed instead.
+func (store *Store) ReplaceUserTx(ctx context.Context, tx *sql.Tx, userID, replacementID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("UserID", userID)
+	if replacementID != "" {
+		err = validate.Many(err, validate.UUID("ReplacementUserID", replacementID))
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.StmtContext(ctx, store.findDataUser).QueryContext(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("lookup schedules referencing user: %w", err)
+	}
+	defer rows.Close()
+
+	var scheduleIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		scheduleIDs = append(scheduleIDs, id)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range scheduleIDs {
+		err = store.updateScheduleData(ctx, tx, id, func(data *Data) error {
+			data.replaceUser(userID, replacementID)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("update schedule '%s': %w", id, err)
+		}
+	}
+
+	return nil
+}
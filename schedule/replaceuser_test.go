@@ -0,0 +1,53 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_ReplaceUser(t *testing.T) {
+	start := time.Date(2000, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+	newData := func() *Data {
+		var data Data
+		data.V1.TemporarySchedules = []TemporarySchedule{{
+			Start: start,
+			End:   end,
+			Shifts: []FixedShift{
+				{Start: start, End: end, UserID: "old"},
+				{Start: start, End: end, UserID: "other"},
+			},
+		}}
+		data.V1.Shadows = []Shadow{
+			{UserID: "old", Start: start, End: end},
+			{UserID: "trainee", ShadowUserID: "old", Start: start, End: end},
+			{UserID: "new", ShadowUserID: "old", Start: start, End: end},
+			{UserID: "trainee", Start: start, End: end},
+		}
+		return &data
+	}
+
+	data := newData()
+	data.replaceUser("old", "new")
+	assert.Equal(t, []FixedShift{
+		{Start: start, End: end, UserID: "new"},
+		{Start: start, End: end, UserID: "other"},
+	}, data.V1.TemporarySchedules[0].Shifts)
+	assert.Equal(t, []Shadow{
+		{UserID: "new", Start: start, End: end},
+		{UserID: "trainee", ShadowUserID: "new", Start: start, End: end},
+		// can't shadow self
+		{UserID: "trainee", Start: start, End: end},
+	}, data.V1.Shadows)
+
+	data = newData()
+	data.replaceUser("old", "")
+	assert.Equal(t, []FixedShift{
+		{Start: start, End: end, UserID: "other"},
+	}, data.V1.TemporarySchedules[0].Shifts)
+	assert.Equal(t, []Shadow{
+		{UserID: "trainee", Start: start, End: end},
+	}, data.V1.Shadows)
+}
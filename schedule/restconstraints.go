@@ -0,0 +1,75 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// RestConstraints limit how long a single user can be on-call without a break, and how
+// much time off they must have between shifts.
+//
+// A zero value for either field disables that constraint.
+type RestConstraints struct {
+	// MaxConsecutive is the longest amount of time a user may be continuously on-call.
+	MaxConsecutive time.Duration
+
+	// MinRest is the minimum amount of time required between shifts for the same user.
+	MinRest time.Duration
+}
+
+// IsZero returns true if no constraints are configured.
+func (c RestConstraints) IsZero() bool { return c.MaxConsecutive == 0 && c.MinRest == 0 }
+
+// Normalize will validate the RestConstraints and return a normalized copy.
+func (c RestConstraints) Normalize() (*RestConstraints, error) {
+	err := validate.Many(
+		validate.Duration("MaxConsecutive", c.MaxConsecutive, 0, 30*24*time.Hour),
+		validate.Duration("MinRest", c.MinRest, 0, 7*24*time.Hour),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.MaxConsecutive = c.MaxConsecutive.Truncate(time.Minute)
+	c.MinRest = c.MinRest.Truncate(time.Minute)
+
+	return &c, nil
+}
+
+// RestConstraints returns the current RestConstraints for the provided scheduleID.
+func (store *Store) RestConstraints(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*RestConstraints, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data.V1.RestConstraints, nil
+}
+
+// SetRestConstraints will set/replace the RestConstraints for the given schedule ID.
+func (store *Store) SetRestConstraints(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, c RestConstraints) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.RestConstraints = *n
+		return nil
+	})
+}
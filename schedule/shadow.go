@@ -0,0 +1,180 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const shadowLimit = 50
+
+// A Shadow is a (trainee) user that receives copies of all notifications while on-call
+// for a schedule, but is not considered a responder themselves.
+type Shadow struct {
+	ID uuid.UUID
+
+	// UserID is the user who will shadow the schedule.
+	UserID string
+
+	// ShadowUserID, if set, limits shadowing to shifts where the given user is on-call.
+	// Otherwise, the shadow is active any time someone is on-call for the schedule.
+	ShadowUserID string `json:",omitempty"`
+
+	Start, End time.Time
+}
+
+// Normalize will validate and normalize the Shadow. Times will be truncated to the minute.
+func (s Shadow) Normalize(checkUser user.ExistanceChecker) (*Shadow, error) {
+	s.Start = s.Start.Truncate(time.Minute)
+	s.End = s.End.Truncate(time.Minute)
+
+	err := validate.Many(
+		validateFuture("End", s.End),
+		validateTimeRange("", s.Start, s.End),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !checkUser.UserExistsString(s.UserID) {
+		return nil, validation.NewFieldError("UserID", "user does not exist")
+	}
+	if s.ShadowUserID != "" && !checkUser.UserExistsString(s.ShadowUserID) {
+		return nil, validation.NewFieldError("ShadowUserID", "user does not exist")
+	}
+	if s.ShadowUserID == s.UserID {
+		return nil, validation.NewFieldError("ShadowUserID", "cannot shadow self")
+	}
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+
+	return &s, nil
+}
+
+// ActiveShadows will return the IDs of all shadow users that should be on-call
+// at time t, given the set of users currently on-call for the schedule.
+//
+// Users that are already on-call are never returned as shadows.
+func (data *Data) ActiveShadows(t time.Time, onCall []string) []string {
+	if data == nil || len(onCall) == 0 {
+		return nil
+	}
+
+	isOnCall := make(map[string]bool, len(onCall))
+	for _, id := range onCall {
+		isOnCall[id] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, s := range data.V1.Shadows {
+		if t.Before(s.Start) || !t.Before(s.End) {
+			continue
+		}
+		if s.ShadowUserID != "" && !isOnCall[s.ShadowUserID] {
+			continue
+		}
+		if isOnCall[s.UserID] || seen[s.UserID] {
+			continue
+		}
+		seen[s.UserID] = true
+		result = append(result, s.UserID)
+	}
+
+	return result
+}
+
+// Shadows will return all current and future shadows for the given schedule.
+func (store *Store) Shadows(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) ([]Shadow, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]Shadow, 0, len(data.V1.Shadows))
+	for _, s := range data.V1.Shadows {
+		if !s.End.After(now) {
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// AddShadow will add a new shadow to the given schedule, returning the new shadow ID.
+func (store *Store) AddShadow(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, s Shadow) (uuid.UUID, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	check, err := store.usr.UserExists(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	s.ID = uuid.Nil
+	n, err := s.Normalize(check)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	err = store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		now := time.Now()
+		shadows := data.V1.Shadows[:0]
+		for _, s := range data.V1.Shadows {
+			// drop expired entries
+			if !s.End.After(now) {
+				continue
+			}
+			shadows = append(shadows, s)
+		}
+		if len(shadows) >= shadowLimit {
+			return validation.NewFieldError("Shadows", fmt.Sprintf("cannot have more than %d shadows", shadowLimit))
+		}
+		shadows = append(shadows, *n)
+		sort.Slice(shadows, func(i, j int) bool { return shadows[i].Start.Before(shadows[j].Start) })
+		data.V1.Shadows = shadows
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return n.ID, nil
+}
+
+// DeleteShadow will remove a shadow from the given schedule.
+func (store *Store) DeleteShadow(ctx context.Context, tx *sql.Tx, scheduleID, shadowID uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		shadows := data.V1.Shadows[:0]
+		for _, s := range data.V1.Shadows {
+			if s.ID == shadowID {
+				continue
+			}
+			shadows = append(shadows, s)
+		}
+		data.V1.Shadows = shadows
+		return nil
+	})
+}
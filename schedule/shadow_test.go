@@ -0,0 +1,24 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_ActiveShadows(t *testing.T) {
+	start := time.Date(2000, 1, 1, 10, 0, 0, 0, time.UTC)
+	var data Data
+	data.V1.Shadows = []Shadow{
+		{UserID: "trainee", Start: start, End: start.Add(8 * time.Hour)},
+		{UserID: "mentee", ShadowUserID: "mentor", Start: start, End: start.Add(8 * time.Hour)},
+		{UserID: "later", Start: start.Add(10 * time.Hour), End: start.Add(12 * time.Hour)},
+	}
+
+	assert.Equal(t, []string{"trainee"}, data.ActiveShadows(start.Add(time.Hour), []string{"foo"}))
+	assert.Equal(t, []string{"trainee", "mentee"}, data.ActiveShadows(start.Add(time.Hour), []string{"mentor"}))
+	assert.Empty(t, data.ActiveShadows(start.Add(time.Hour), nil), "no shadows without on-call users")
+	assert.Empty(t, data.ActiveShadows(start.Add(time.Hour), []string{"trainee"}), "on-call users are never shadows")
+	assert.Empty(t, data.ActiveShadows(start.Add(9*time.Hour), []string{"foo"}))
+}
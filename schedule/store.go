@@ -26,6 +26,9 @@ type Store struct {
 	updateData  *sql.Stmt
 	insertData  *sql.Stmt
 
+	// findDataUser returns the schedules whose data may reference a user, for ReplaceUserTx
+	findDataUser *sql.Stmt
+
 	findOneUp *sql.Stmt
 
 	findMany *sql.Stmt
@@ -45,6 +48,13 @@ func NewStore(ctx context.Context, db *sql.DB, usr *user.Store) (*Store, error)
 		insertData:  p.P(`INSERT INTO schedule_data (schedule_id, data) VALUES ($1, '{}')`),
 		updateData:  p.P(`UPDATE schedule_data SET data = $2 WHERE schedule_id = $1`),
 
+		findDataUser: p.P(`
+			SELECT schedule_id
+			FROM schedule_data
+			WHERE data::text LIKE '%' || $1::text || '%'
+			FOR UPDATE
+		`),
+
 		create:  p.P(`INSERT INTO schedules (id, name, description, time_zone) VALUES (DEFAULT, $1, $2, $3) RETURNING id`),
 		update:  p.P(`UPDATE schedules SET name = $2, description = $3, time_zone = $4 WHERE id = $1`),
 		findAll: p.P(`SELECT id, name, description, time_zone FROM schedules`),
@@ -0,0 +1,178 @@
+// Package scrub anonymizes personal data in a GoAlert database, so a copy of production
+// data can be used safely in a staging environment.
+//
+// Rows are updated in place (or deleted, for credentials and short-lived records), so IDs,
+// and therefore all references between tables, are preserved.
+package scrub
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// A step is a single scrubbing statement.
+type step struct {
+	desc  string
+	query string
+}
+
+// steps are run in order, in a single transaction.
+//
+// Replacement phone numbers use the 555 area code, which is never assigned, and emails and
+// URLs use example.com, so scrubbed contact methods and channels can never reach a real
+// destination.
+var steps = []step{
+	{"users", `
+		update users u
+		set
+			name = 'User ' || n.rn,
+			email = 'user' || n.rn || '@example.com',
+			avatar_url = '',
+			bio = ''
+		from (select id, row_number() over (order by id) rn from users) n
+		where u.id = n.id
+	`},
+	{"contact methods", `
+		update user_contact_methods cm
+		set
+			name = initcap(cm.type::text) || ' ' || n.rn,
+			value = case cm.type::text
+				when 'SMS' then '+1555' || lpad(n.rn::text, 7, '0')
+				when 'VOICE' then '+1555' || lpad(n.rn::text, 7, '0')
+				when 'EMAIL' then 'cm' || n.rn || '@example.com'
+				when 'WEBHOOK' then 'https://example.com/webhook/cm-' || n.rn
+				else 'scrubbed-' || n.rn
+			end,
+			metadata = null
+		from (select id, row_number() over (order by id) rn from user_contact_methods) n
+		where cm.id = n.id
+	`},
+	{"notification channels", `
+		update notification_channels nc
+		set
+			name = 'Channel ' || n.rn,
+			value = case nc.type::text
+				when 'SMS' then '+1555' || lpad(n.rn::text, 7, '0')
+				when 'VOICE' then '+1555' || lpad(n.rn::text, 7, '0')
+				when 'EMAIL' then 'channel' || n.rn || '@example.com'
+				when 'WEBHOOK' then 'https://example.com/webhook/channel-' || n.rn
+				else 'scrubbed-' || n.rn
+			end,
+			meta = '{}'
+		from (select id, row_number() over (order by id) rn from notification_channels) n
+		where nc.id = n.id
+	`},
+	{"alerts", `
+		update alerts
+		set
+			summary = 'Alert ' || id,
+			details = '',
+			dedup_key = case
+				when dedup_key is null or dedup_key like 'heartbeat:%' then dedup_key
+				else split_part(dedup_key, ':', 1) || ':' || split_part(dedup_key, ':', 2) || ':' || md5(dedup_key)
+			end
+	`},
+	{"alert logs", `update alert_logs set message = '' where message != ''`},
+	{"alert feedback", `update alert_feedback set noise_reason = 'scrubbed' where noise_reason != ''`},
+	{"message status details", `update outgoing_messages set status_details = '' where status_details != ''`},
+	{"user unavailability notes", `update user_unavailability set note = '' where note != ''`},
+	{"status callbacks", `
+		update service_status_callbacks
+		set
+			url = 'https://example.com/status-callback/' || service_id,
+			secret = md5(random()::text)
+	`},
+	{"status callback errors", `update status_callback_deliveries set last_error = '' where last_error != ''`},
+	{"Jira configs", `
+		update service_jira_configs
+		set
+			summary_template = '{{.Summary}}',
+			description_template = E'{{.Details}}\n\nGoAlert: {{.URL}}',
+			fields = '{}'
+	`},
+	{"Jira issues", `
+		update alert_jira_issues
+		set
+			issue_key = case when issue_key is null then null else 'SCRUBBED-' || alert_id end,
+			last_error = ''
+	`},
+	{"ServiceNow configs", `
+		update service_servicenow_configs
+		set
+			assignment_group = '',
+			category = '',
+			caller_id = ''
+	`},
+	{"ServiceNow incidents", `
+		update alert_servicenow_incidents
+		set
+			sys_id = case when sys_id is null then null else md5(sys_id) end,
+			number = case when number = '' then '' else 'INC' || lpad(alert_id::text, 7, '0') end,
+			last_error = ''
+	`},
+	{"email integration rules", `delete from integration_key_email_rules`},
+	{"system notices", `delete from system_notices`},
+
+	// provider credentials (Twilio, Slack, SMTP, etc.) are only stored in the encrypted config,
+	// so the whole config is reset to defaults
+	{"config", `delete from config`},
+	{"API key usage", `update gql_api_key_usage set ip_address = null, user_agent = null`},
+	{"basic auth logins", `delete from auth_basic_users`},
+	{"auth subjects", `delete from auth_subjects`},
+	{"auth sessions", `delete from auth_user_sessions`},
+	{"auth link requests", `delete from auth_link_requests`},
+	{"verification codes", `delete from user_verification_codes`},
+	{"Slack user tokens", `delete from user_slack_data`},
+	{"Twilio SMS callbacks", `delete from twilio_sms_callbacks`},
+	{"Twilio SMS errors", `delete from twilio_sms_errors`},
+	{"Twilio voice errors", `delete from twilio_voice_errors`},
+}
+
+// Result is the number of rows changed by a single step.
+type Result struct {
+	Description string
+	Rows        int64
+}
+
+// Run will scrub all personal data from db in a single transaction.
+//
+// The database must be fully migrated so all scrubbed tables exist.
+func Run(ctx context.Context, db *sql.DB) ([]Result, error) {
+	s, err := migrate.Status(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("get migration status: %w", err)
+	}
+	if len(s.Pending) > 0 || len(s.Unknown) > 0 {
+		return nil, fmt.Errorf("database must be migrated to '%s' (the latest migration for this version) before scrubbing, but is at '%s'", s.Expected, s.Latest)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlutil.Rollback(ctx, "scrub", tx)
+
+	results := make([]Result, 0, len(steps))
+	for _, st := range steps {
+		res, err := tx.ExecContext(ctx, st.query)
+		if err != nil {
+			return nil, fmt.Errorf("scrub %s: %w", st.desc, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("scrub %s: %w", st.desc, err)
+		}
+		results = append(results, Result{Description: st.desc, Rows: n})
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return results, nil
+}
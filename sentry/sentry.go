@@ -0,0 +1,213 @@
+package sentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// issue contains the fields common to Sentry issues and events that we care about.
+type issue struct {
+	ID      string
+	Title   string
+	Culprit string
+	Level   string
+	Link    string
+	Project string
+}
+
+func (i issue) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("Link", i.Link) == nil {
+		fmt.Fprintf(&s, "[View in Sentry](%s)\n\n", i.Link)
+	}
+	if i.Project != "" {
+		fmt.Fprintf(&s, "Project: %s\n\n", i.Project)
+	}
+	if i.Level != "" {
+		fmt.Fprintf(&s, "Level: %s\n\n", i.Level)
+	}
+	if i.Culprit != "" {
+		fmt.Fprintf(&s, "Culprit: %s\n\n", i.Culprit)
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+// dedup returns the dedup key for the issue, so that issue and event alert webhooks for
+// the same issue update the same alert.
+func (i issue) dedup() string {
+	return "sentry-issue:" + i.ID
+}
+
+type issuePayload struct {
+	Action string
+	Data   struct {
+		Issue struct {
+			ID        string
+			Title     string
+			Culprit   string
+			Level     string
+			Permalink string
+			WebURL    string `json:"web_url"`
+			Project   struct{ Slug string }
+		}
+	}
+}
+
+type eventAlertPayload struct {
+	Action string
+	Data   struct {
+		Event struct {
+			IssueID string `json:"issue_id"`
+			Title   string
+			Culprit string
+			Level   string
+			WebURL  string `json:"web_url"`
+		}
+		TriggeredRule string `json:"triggered_rule"`
+	}
+}
+
+type legacyPayload struct {
+	ID          string
+	ProjectName string `json:"project_name"`
+	Culprit     string
+	Level       string
+	URL         string
+	Message     string
+}
+
+// parse will return the issue and status for the given payload, or nil if it should be ignored.
+//
+// The resource is the value of the `Sentry-Hook-Resource` header. If empty, the legacy
+// webhook plugin format is assumed.
+func parse(resource string, data []byte) (*issue, alert.Status, error) {
+	switch resource {
+	case "issue":
+		var p issuePayload
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, "", err
+		}
+		var status alert.Status
+		switch p.Action {
+		case "created", "unresolved":
+			status = alert.StatusTriggered
+		case "resolved":
+			status = alert.StatusClosed
+		default:
+			// assigned, ignored, archived, etc.
+			return nil, "", nil
+		}
+		i := p.Data.Issue
+		link := i.WebURL
+		if link == "" {
+			link = i.Permalink
+		}
+		return &issue{ID: i.ID, Title: i.Title, Culprit: i.Culprit, Level: i.Level, Link: link, Project: i.Project.Slug}, status, nil
+	case "event_alert":
+		var p eventAlertPayload
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, "", err
+		}
+		e := p.Data.Event
+		return &issue{ID: e.IssueID, Title: e.Title, Culprit: e.Culprit, Level: e.Level, Link: e.WebURL}, alert.StatusTriggered, nil
+	case "":
+		var p legacyPayload
+		err := json.Unmarshal(data, &p)
+		if err != nil {
+			return nil, "", err
+		}
+		return &issue{ID: p.ID, Title: p.Message, Culprit: p.Culprit, Level: p.Level, Link: p.URL, Project: p.ProjectName}, alert.StatusTriggered, nil
+	case "installation", "metric_alert", "error", "comment":
+		return nil, "", nil
+	}
+
+	return nil, "", errors.Errorf("sentry: unknown resource: %s", resource)
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func SentryToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var data json.RawMessage
+		err = json.NewDecoder(r.Body).Decode(&data)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from sentry: %v", err)
+			return
+		}
+
+		resource := r.Header.Get("Sentry-Hook-Resource")
+		ctx = log.WithFields(ctx, log.Fields{
+			"Resource": resource,
+		})
+
+		i, status, err := parse(resource, data)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from sentry: %v", err)
+			return
+		}
+		if i == nil {
+			return
+		}
+		if i.ID == "" {
+			log.Logf(ctx, "bad request from sentry: missing issue ID")
+			http.Error(w, "missing issue ID", http.StatusBadRequest)
+			return
+		}
+
+		summary := i.Title
+		if summary == "" {
+			summary = "Sentry issue " + i.ID
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(i.details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceSentry,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(i.dedup()),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for sentry")) {
+			return
+		}
+	}
+}
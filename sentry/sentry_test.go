@@ -0,0 +1,85 @@
+package sentry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestParse(t *testing.T) {
+	i, status, err := parse("issue", []byte(`{
+		"action": "created",
+		"data": {"issue": {"id": "1170820242", "title": "Error: oops", "culprit": "app.js", "level": "error", "web_url": "https://sentry.io/issues/1170820242/", "project": {"slug": "web"}}}
+	}`))
+	require.NoError(t, err)
+	require.NotNil(t, i)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "sentry-issue:1170820242", i.dedup())
+	assert.Equal(t, "Error: oops", i.Title)
+	assert.Equal(t, "[View in Sentry](https://sentry.io/issues/1170820242/)\n\nProject: web\n\nLevel: error\n\nCulprit: app.js", i.details())
+
+	i, status, err = parse("issue", []byte(`{"action": "resolved", "data": {"issue": {"id": "1170820242"}}}`))
+	require.NoError(t, err)
+	require.NotNil(t, i)
+	assert.Equal(t, alert.StatusClosed, status)
+	assert.Equal(t, "sentry-issue:1170820242", i.dedup())
+
+	i, status, err = parse("issue", []byte(`{"action": "unresolved", "data": {"issue": {"id": "1170820242"}}}`))
+	require.NoError(t, err)
+	require.NotNil(t, i)
+	assert.Equal(t, alert.StatusTriggered, status)
+
+	// event alerts for the same issue share the dedup key
+	i, status, err = parse("event_alert", []byte(`{"action": "triggered", "data": {"event": {"issue_id": "1170820242", "title": "Error: oops"}, "triggered_rule": "rule"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, i)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "sentry-issue:1170820242", i.dedup())
+
+	i, status, err = parse("", []byte(`{"id": "42", "project_name": "web", "message": "legacy", "url": "https://sentry.io/issues/42/"}`))
+	require.NoError(t, err)
+	require.NotNil(t, i)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "sentry-issue:42", i.dedup())
+	assert.Equal(t, "legacy", i.Title)
+
+	// assignment and installation events are ignored
+	i, _, err = parse("issue", []byte(`{"action": "assigned", "data": {"issue": {"id": "1170820242"}}}`))
+	require.NoError(t, err)
+	assert.Nil(t, i)
+	i, _, err = parse("installation", []byte(`{"action": "created"}`))
+	require.NoError(t, err)
+	assert.Nil(t, i)
+
+	_, _, err = parse("unknown", []byte(`{}`))
+	assert.Error(t, err)
+	_, _, err = parse("issue", []byte(`{"action": 1}`))
+	assert.Error(t, err)
+}
+
+func TestSentryToEventsAPI_BadRequest(t *testing.T) {
+	h := SentryToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, resource, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/sentry/incoming", strings.NewReader(body)).WithContext(ctx)
+		if resource != "" {
+			req.Header.Set("Sentry-Hook-Resource", resource)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", "issue", `{`)
+	check("unknown resource", "unknown", `{}`)
+	check("missing issue id", "issue", `{"action": "created", "data": {"issue": {"title": "oops"}}}`)
+}
@@ -0,0 +1,101 @@
+package servicenow
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// CallbackPayload is sent by ServiceNow (e.g., from a business rule) when an incident is updated.
+type CallbackPayload struct {
+	SysID string `json:"sys_id"`
+
+	// State may be sent as a number or string.
+	State json.RawMessage `json:"state"`
+}
+
+func (p CallbackPayload) state() (int, error) {
+	s := strings.Trim(string(p.State), `"`)
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, validation.NewFieldError("state", "must be an incident state number")
+	}
+
+	return n, nil
+}
+
+// CallbackHandler returns an http.HandlerFunc that closes alerts when their linked
+// ServiceNow incident is resolved, closed, or canceled.
+//
+// Requests must include the configured callback secret as a Bearer token.
+func CallbackHandler(s *Store, alertStore *alert.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		cfg := config.FromContext(ctx)
+		if !cfg.ServiceNow.Enable || cfg.ServiceNow.CallbackSecret == "" {
+			http.Error(w, "not enabled", http.StatusNotFound)
+			return
+		}
+		if req.Method != "POST" {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		tok := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(tok), []byte(cfg.ServiceNow.CallbackSecret)) != 1 {
+			errutil.HTTPError(ctx, w, permission.Unauthorized())
+			return
+		}
+
+		var p CallbackPayload
+		err := json.NewDecoder(io.LimitReader(req.Body, 64<<10)).Decode(&p)
+		if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
+			return
+		}
+		state, err := p.state()
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		if !IsResolvedState(state) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var alertID int
+		var serviceID string
+		permission.SudoContext(ctx, func(ctx context.Context) {
+			alertID, serviceID, err = s.ResolveIncident(ctx, p.SysID)
+		})
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		if alertID == 0 {
+			// not an incident created by GoAlert
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		ctx = log.WithField(permission.ServiceContext(ctx, serviceID), log.FieldAlertID, alertID)
+		err = alertStore.UpdateStatus(ctx, alertID, alert.StatusClosed)
+		if alert.IsAlreadyClosed(err) {
+			err = nil
+		}
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		log.Logf(ctx, "Alert closed by ServiceNow incident resolution.")
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,125 @@
+package servicenow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// Client makes requests to the ServiceNow Table API using the credentials from the current config.
+type Client struct {
+	HTTP *http.Client
+}
+
+// Incident identifies a ServiceNow incident record.
+type Incident struct {
+	SysID  string `json:"sys_id"`
+	Number string `json:"number"`
+}
+
+// APIError is returned when ServiceNow responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Detail     string
+}
+
+func (err *APIError) Error() string {
+	msg := fmt.Sprintf("servicenow: unexpected status %d", err.StatusCode)
+	if err.Message != "" {
+		msg += ": " + err.Message
+	}
+	if err.Detail != "" {
+		msg += " (" + err.Detail + ")"
+	}
+	return msg
+}
+
+// Temporary returns true for rate-limit and server errors.
+func (err *APIError) Temporary() bool {
+	return err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= 500
+}
+
+// IncidentURL returns the browser URL for the given incident.
+func IncidentURL(cfg config.Config, sysID string) string {
+	return strings.TrimSuffix(cfg.ServiceNow.InstanceURL, "/") + "/nav_to.do?uri=" + url.QueryEscape("incident.do?sys_id="+sysID)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body interface{}) (*Incident, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.ServiceNow.Enable {
+		return nil, fmt.Errorf("servicenow: disabled")
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(cfg.ServiceNow.InstanceURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(cfg.ServiceNow.Username, cfg.ServiceNow.Password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp struct {
+			Error struct {
+				Message string
+				Detail  string
+			}
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&errResp)
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: errResp.Error.Message, Detail: errResp.Error.Detail}
+	}
+
+	var result struct{ Result Incident }
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("servicenow: decode response: %w", err)
+	}
+
+	return &result.Result, nil
+}
+
+// CreateIncident creates a new incident with the provided field values.
+func (c *Client) CreateIncident(ctx context.Context, fields map[string]string) (*Incident, error) {
+	inc, err := c.do(ctx, "POST", "/api/now/table/incident?sysparm_fields=sys_id,number", fields)
+	if err != nil {
+		return nil, err
+	}
+	if inc.SysID == "" {
+		return nil, fmt.Errorf("servicenow: create incident: missing sys_id in response")
+	}
+
+	return inc, nil
+}
+
+// UpdateIncident updates the incident with the provided field values.
+func (c *Client) UpdateIncident(ctx context.Context, sysID string, fields map[string]string) error {
+	_, err := c.do(ctx, "PATCH", "/api/now/table/incident/"+url.PathEscape(sysID)+"?sysparm_fields=sys_id,number", fields)
+	return err
+}
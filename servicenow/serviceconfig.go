@@ -0,0 +1,141 @@
+package servicenow
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// Incident states used by the default ServiceNow incident workflow.
+const (
+	StateNew        = 1
+	StateInProgress = 2
+	StateOnHold     = 3
+	StateResolved   = 6
+	StateClosed     = 7
+	StateCanceled   = 8
+)
+
+// DefaultResolveCode is used for the close_code of resolved incidents if none is configured.
+const DefaultResolveCode = "Solved (Permanently)"
+
+// ServiceConfig controls how ServiceNow incidents are created for alerts on a service.
+type ServiceConfig struct {
+	ServiceID string
+
+	// AssignmentGroup, Category, and CallerID are set on new incidents, if provided.
+	// AssignmentGroup and CallerID accept a sys_id or display value.
+	AssignmentGroup string
+	Category        string
+	CallerID        string
+
+	// Impact and Urgency are 1 (high) through 3 (low).
+	Impact  int
+	Urgency int
+
+	// ResolveCode is the close_code used when resolving the incident for a closed alert.
+	ResolveCode string
+}
+
+// AlertInfo is the alert data used to create an incident.
+type AlertInfo struct {
+	AlertID     int
+	Summary     string
+	Details     string
+	ServiceName string
+	URL         string
+}
+
+// Normalize will validate and normalize the ServiceConfig, applying defaults where empty.
+func (cfg ServiceConfig) Normalize() (*ServiceConfig, error) {
+	cfg.AssignmentGroup = strings.TrimSpace(cfg.AssignmentGroup)
+	cfg.Category = strings.TrimSpace(cfg.Category)
+	cfg.CallerID = strings.TrimSpace(cfg.CallerID)
+	cfg.ResolveCode = strings.TrimSpace(cfg.ResolveCode)
+	if cfg.Impact == 0 {
+		cfg.Impact = 2
+	}
+	if cfg.Urgency == 0 {
+		cfg.Urgency = 2
+	}
+	if cfg.ResolveCode == "" {
+		cfg.ResolveCode = DefaultResolveCode
+	}
+
+	err := validate.Many(
+		validate.UUID("ServiceID", cfg.ServiceID),
+		validate.Text("AssignmentGroup", cfg.AssignmentGroup, 1, 255),
+		validate.Text("Category", cfg.Category, 1, 255),
+		validate.Text("CallerID", cfg.CallerID, 1, 255),
+		validate.Range("Impact", cfg.Impact, 1, 3),
+		validate.Range("Urgency", cfg.Urgency, 1, 3),
+		validate.RequiredText("ResolveCode", cfg.ResolveCode, 1, 255),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// CorrelationID returns the correlation_id used for the alert's incident.
+func CorrelationID(alertID int) string {
+	return "goalert-" + strconv.Itoa(alertID)
+}
+
+// IncidentFields returns the field values for a new incident.
+func (cfg ServiceConfig) IncidentFields(a AlertInfo) map[string]string {
+	summary := strings.Join(strings.Fields(a.Summary), " ")
+	if r := []rune(summary); len(r) > 160 {
+		summary = string(r[:159]) + "…"
+	}
+
+	desc := strings.TrimSpace(a.Details)
+	if desc != "" {
+		desc += "\n\n"
+	}
+	desc += "Service: " + a.ServiceName + "\nGoAlert: " + a.URL
+
+	fields := map[string]string{
+		"short_description":   summary,
+		"description":         desc,
+		"impact":              strconv.Itoa(cfg.Impact),
+		"urgency":             strconv.Itoa(cfg.Urgency),
+		"correlation_id":      CorrelationID(a.AlertID),
+		"correlation_display": "GoAlert",
+	}
+	if cfg.AssignmentGroup != "" {
+		fields["assignment_group"] = cfg.AssignmentGroup
+	}
+	if cfg.Category != "" {
+		fields["category"] = cfg.Category
+	}
+	if cfg.CallerID != "" {
+		fields["caller_id"] = cfg.CallerID
+	}
+
+	return fields
+}
+
+// AckFields returns the field values to update the incident for an acknowledged alert.
+func AckFields() map[string]string {
+	return map[string]string{
+		"state":      strconv.Itoa(StateInProgress),
+		"work_notes": "Alert acknowledged in GoAlert.",
+	}
+}
+
+// ResolveFields returns the field values to resolve the incident for a closed alert.
+func (cfg ServiceConfig) ResolveFields() map[string]string {
+	return map[string]string{
+		"state":       strconv.Itoa(StateResolved),
+		"close_code":  cfg.ResolveCode,
+		"close_notes": "Alert closed in GoAlert.",
+	}
+}
+
+// IsResolvedState returns true if the incident state indicates the incident is no longer open.
+func IsResolvedState(state int) bool {
+	return state == StateResolved || state == StateClosed || state == StateCanceled
+}
@@ -0,0 +1,61 @@
+package servicenow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceConfig_Normalize(t *testing.T) {
+	cfg, err := ServiceConfig{ServiceID: "a1b2c3d4-0000-0000-0000-000000000000", AssignmentGroup: " Ops "}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "Ops", cfg.AssignmentGroup)
+	assert.Equal(t, 2, cfg.Impact)
+	assert.Equal(t, 2, cfg.Urgency)
+	assert.Equal(t, DefaultResolveCode, cfg.ResolveCode)
+
+	_, err = ServiceConfig{ServiceID: "a1b2c3d4-0000-0000-0000-000000000000", Impact: 4}.Normalize()
+	assert.Error(t, err)
+}
+
+func TestServiceConfig_IncidentFields(t *testing.T) {
+	cfg := ServiceConfig{AssignmentGroup: "Ops", Impact: 1, Urgency: 3}
+	fields := cfg.IncidentFields(AlertInfo{
+		AlertID:     42,
+		Summary:     "disk\nfull",
+		Details:     "sda1 at 99%",
+		ServiceName: "Storage",
+		URL:         "https://goalert.example.com/alerts/42",
+	})
+
+	assert.Equal(t, map[string]string{
+		"short_description":   "disk full",
+		"description":         "sda1 at 99%\n\nService: Storage\nGoAlert: https://goalert.example.com/alerts/42",
+		"impact":              "1",
+		"urgency":             "3",
+		"correlation_id":      "goalert-42",
+		"correlation_display": "GoAlert",
+		"assignment_group":    "Ops",
+	}, fields)
+}
+
+func TestCallbackPayload_State(t *testing.T) {
+	check := func(body string, exp int) {
+		t.Helper()
+		var p CallbackPayload
+		require.NoError(t, json.Unmarshal([]byte(body), &p))
+		state, err := p.state()
+		require.NoError(t, err)
+		assert.Equal(t, exp, state)
+	}
+
+	check(`{"sys_id":"abc","state":"6"}`, StateResolved)
+	check(`{"sys_id":"abc","state":7}`, StateClosed)
+
+	var p CallbackPayload
+	require.NoError(t, json.Unmarshal([]byte(`{"sys_id":"abc"}`), &p))
+	_, err := p.state()
+	assert.Error(t, err)
+}
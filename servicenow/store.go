@@ -0,0 +1,175 @@
+package servicenow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// IncidentLink is the ServiceNow incident linked to an alert.
+type IncidentLink struct {
+	AlertID int
+
+	// SysID and Number are empty if the incident has not been created yet.
+	SysID  string
+	Number string
+
+	Acked  bool
+	Closed bool
+
+	// LastError is the most recent error from ServiceNow, if any.
+	LastError string
+}
+
+// Store manages ServiceNow service configs and alert incident links.
+type Store struct {
+	db *sql.DB
+
+	findConfig      *sql.Stmt
+	setConfig       *sql.Stmt
+	deleteConfig    *sql.Stmt
+	findIncident    *sql.Stmt
+	resolveIncident *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		findConfig: p.P(`
+			select assignment_group, category, caller_id, impact, urgency, resolve_code
+			from service_servicenow_configs
+			where service_id = $1
+		`),
+		setConfig: p.P(`
+			insert into service_servicenow_configs (service_id, assignment_group, category, caller_id, impact, urgency, resolve_code)
+			values ($1, $2, $3, $4, $5, $6, $7)
+			on conflict (service_id) do update
+			set
+				assignment_group = $2,
+				category = $3,
+				caller_id = $4,
+				impact = $5,
+				urgency = $6,
+				resolve_code = $7
+		`),
+		deleteConfig: p.P(`delete from service_servicenow_configs where service_id = $1`),
+		findIncident: p.P(`
+			select coalesce(sys_id, ''), number, acked, closed, last_error
+			from alert_servicenow_incidents
+			where alert_id = $1
+		`),
+		resolveIncident: p.P(`
+			update alert_servicenow_incidents inc
+			set closed = true
+			from alerts a
+			where inc.sys_id = $1 and a.id = inc.alert_id
+			returning a.id, a.service_id
+		`),
+	}, p.Err
+}
+
+// ServiceConfig returns the ServiceNow config for the service, or nil if none is set.
+func (s *Store) ServiceConfig(ctx context.Context, serviceID string) (*ServiceConfig, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := ServiceConfig{ServiceID: serviceID}
+	err = s.findConfig.QueryRowContext(ctx, serviceID).Scan(&cfg.AssignmentGroup, &cfg.Category, &cfg.CallerID, &cfg.Impact, &cfg.Urgency, &cfg.ResolveCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// SetServiceConfigTx will create or replace the ServiceNow config for a service.
+//
+// Only alerts created after the config is first set will have incidents created.
+func (s *Store) SetServiceConfigTx(ctx context.Context, tx *sql.Tx, cfg ServiceConfig) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := cfg.Normalize()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setConfig).ExecContext(ctx, n.ServiceID, n.AssignmentGroup, n.Category, n.CallerID, n.Impact, n.Urgency, n.ResolveCode)
+	return err
+}
+
+// DeleteServiceConfigTx will remove the ServiceNow config for a service. Existing incidents continue to be synchronized.
+func (s *Store) DeleteServiceConfigTx(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.deleteConfig).ExecContext(ctx, serviceID)
+	return err
+}
+
+// AlertIncident returns the ServiceNow incident linked to the alert, or nil if there is none.
+func (s *Store) AlertIncident(ctx context.Context, alertID int) (*IncidentLink, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	inc := IncidentLink{AlertID: alertID}
+	err = s.findIncident.QueryRowContext(ctx, alertID).Scan(&inc.SysID, &inc.Number, &inc.Acked, &inc.Closed, &inc.LastError)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &inc, nil
+}
+
+// ResolveIncident marks the incident as closed, so it is not updated again, and returns
+// the linked alert ID and service ID. If the incident is not linked to an alert, alertID is 0.
+func (s *Store) ResolveIncident(ctx context.Context, sysID string) (alertID int, serviceID string, err error) {
+	err = permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return 0, "", err
+	}
+	err = validate.RequiredText("SysID", sysID, 1, 255)
+	if err != nil {
+		return 0, "", err
+	}
+
+	err = s.resolveIncident.QueryRowContext(ctx, sysID).Scan(&alertID, &serviceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	return alertID, serviceID, nil
+}
@@ -0,0 +1,38 @@
+package slo
+
+// Compliance returns the percent of alerts that met the objective, or 100 if there were none.
+func Compliance(total, met int) float64 {
+	if total <= 0 {
+		return 100
+	}
+
+	return 100 * float64(met) / float64(total)
+}
+
+// BurnRate returns how quickly the error budget is being consumed, relative to the target.
+//
+// A burn rate of 1 means the budget will be exactly used up by the end of the window if
+// the current rate continues; 2 means it will be used up in half the window, and so on.
+func BurnRate(total, met int, targetPercent float64) float64 {
+	if total <= 0 || targetPercent >= 100 {
+		return 0
+	}
+
+	errRate := float64(total-met) / float64(total)
+	return errRate / (1 - targetPercent/100)
+}
+
+// BudgetRemaining returns the percent of the error budget left for the window.
+//
+// It is negative if the objective has already been missed.
+func BudgetRemaining(total, met int, targetPercent float64) float64 {
+	if total <= 0 {
+		return 100
+	}
+	if targetPercent >= 100 {
+		return 0
+	}
+
+	budget := float64(total) * (1 - targetPercent/100)
+	return 100 * (1 - float64(total-met)/budget)
+}
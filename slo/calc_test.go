@@ -0,0 +1,35 @@
+package slo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompliance(t *testing.T) {
+	assert.Equal(t, 100.0, Compliance(0, 0))
+	assert.Equal(t, 50.0, Compliance(4, 2))
+	assert.Equal(t, 100.0, Compliance(3, 3))
+}
+
+func TestBurnRate(t *testing.T) {
+	assert.Equal(t, 0.0, BurnRate(0, 0, 99))
+
+	// 1% errors against a 99% target uses the budget at exactly the expected rate
+	assert.InDelta(t, 1.0, BurnRate(100, 99, 99), 0.0001)
+
+	// 10% errors against a 99% target burns 10x faster
+	assert.InDelta(t, 10.0, BurnRate(100, 90, 99), 0.0001)
+
+	assert.Equal(t, 0.0, BurnRate(100, 100, 99))
+}
+
+func TestBudgetRemaining(t *testing.T) {
+	assert.Equal(t, 100.0, BudgetRemaining(0, 0, 99))
+	assert.Equal(t, 100.0, BudgetRemaining(200, 200, 99))
+
+	// 200 alerts at 99% allows 2 misses
+	assert.InDelta(t, 50.0, BudgetRemaining(200, 199, 99), 0.0001)
+	assert.InDelta(t, 0.0, BudgetRemaining(200, 198, 99), 0.0001)
+	assert.InDelta(t, -50.0, BudgetRemaining(200, 197, 99), 0.0001)
+}
@@ -0,0 +1,90 @@
+package slo
+
+import (
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Defaults and limits for SLO definitions.
+const (
+	DefaultWindowDays = 30
+	MaxWindowDays     = 90
+	MaxAckMinutes     = 1440
+)
+
+// SLO is a service level objective for how quickly alerts on a service are acknowledged.
+//
+// For example, "99% of critical alerts are acknowledged within 5 minutes over 30 days".
+// Closing an alert without acknowledging it first counts as a response.
+type SLO struct {
+	ID        string
+	ServiceID string
+	Name      string
+
+	// Severity limits the objective to alerts of a single severity, if set.
+	Severity alert.Severity
+
+	// TargetPercent is the percent of alerts that must be acknowledged in time (e.g., 99.5).
+	TargetPercent float64
+
+	// AckWithinMinutes is the time after an alert is created that it must be acknowledged.
+	AckWithinMinutes int
+
+	// WindowDays is the rolling window the objective is measured over.
+	WindowDays int
+}
+
+// Normalize will validate and produce a normalized SLO.
+func (s SLO) Normalize() (*SLO, error) {
+	if s.WindowDays == 0 {
+		s.WindowDays = DefaultWindowDays
+	}
+
+	err := validate.Many(
+		validate.UUID("ServiceID", s.ServiceID),
+		validate.IDName("Name", s.Name),
+		validate.Range("AckWithinMinutes", s.AckWithinMinutes, 1, MaxAckMinutes),
+		validate.Range("WindowDays", s.WindowDays, 1, MaxWindowDays),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if s.TargetPercent <= 0 || s.TargetPercent >= 100 {
+		return nil, validation.NewFieldError("TargetPercent", "must be greater than 0 and less than 100")
+	}
+	if s.Severity != "" {
+		err = validate.OneOf("Severity", s.Severity, alert.SeverityCritical, alert.SeverityHigh, alert.SeverityMedium, alert.SeverityLow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &s, nil
+}
+
+// Window returns the duration of the rolling window.
+func (s SLO) Window() time.Duration { return time.Duration(s.WindowDays) * 24 * time.Hour }
+
+// Status is the most recently computed compliance of an SLO.
+type Status struct {
+	SLOID string
+
+	// Total is the number of alerts in the window that were acknowledged, or are
+	// past the deadline without being acknowledged.
+	Total int
+
+	// Met is the number of alerts acknowledged within the deadline.
+	Met int
+
+	BurnRate1h  float64
+	BurnRate6h  float64
+	BurnRate24h float64
+
+	ComputedAt time.Time
+}
+
+// Compliance returns the percent of alerts that met the objective.
+func (s Status) Compliance() float64 { return Compliance(s.Total, s.Met) }
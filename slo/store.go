@@ -0,0 +1,265 @@
+package slo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages SLO definitions for services.
+type Store struct {
+	db *sql.DB
+
+	create     *sql.Stmt
+	update     *sql.Stmt
+	delete     *sql.Stmt
+	findOne    *sql.Stmt
+	findAll    *sql.Stmt
+	findStatus *sql.Stmt
+	count      *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		create: p.P(`
+			insert into service_slos (id, service_id, name, severity, target_percent, ack_within_minutes, window_days)
+			values ($1, $2, $3, $4, $5, $6, $7)
+		`),
+		update: p.P(`
+			update service_slos
+			set name = $2, severity = $3, target_percent = $4, ack_within_minutes = $5, window_days = $6
+			where id = $1
+		`),
+		delete: p.P(`delete from service_slos where id = $1`),
+		findOne: p.P(`
+			select id, service_id, name, severity, target_percent, ack_within_minutes, window_days
+			from service_slos
+			where id = $1
+		`),
+		findAll: p.P(`
+			select id, service_id, name, severity, target_percent, ack_within_minutes, window_days
+			from service_slos
+			where service_id = $1
+			order by lower(name)
+		`),
+		findStatus: p.P(`
+			select total, met, burn_rate_1h, burn_rate_6h, burn_rate_24h, computed_at
+			from service_slo_status
+			where slo_id = $1
+		`),
+
+		// Alerts still waiting for an ack that are not yet past the deadline are not counted.
+		count: p.P(`
+			select
+				count(*) filter (where r.ack_at notnull or a.created_at < now() - make_interval(mins => s.ack_within_minutes)),
+				count(*) filter (where r.ack_at <= a.created_at + make_interval(mins => s.ack_within_minutes))
+			from service_slos s
+			join alerts a on
+				a.service_id = s.service_id and
+				a.created_at >= $2 and
+				(s.severity isnull or a.severity = s.severity)
+			left join lateral (
+				select min(l.timestamp) ack_at
+				from alert_logs l
+				where l.alert_id = a.id and l.event in ('acknowledged', 'closed')
+			) r on true
+			where s.id = $1
+		`),
+	}, p.Err
+}
+
+type scanner interface {
+	Scan(...interface{}) error
+}
+
+func scan(row scanner) (*SLO, error) {
+	var s SLO
+	var sev sql.NullString
+	err := row.Scan(&s.ID, &s.ServiceID, &s.Name, &sev, &s.TargetPercent, &s.AckWithinMinutes, &s.WindowDays)
+	if err != nil {
+		return nil, err
+	}
+	s.Severity = alert.Severity(sev.String)
+
+	return &s, nil
+}
+
+func nullSeverity(sev alert.Severity) sql.NullString {
+	return sql.NullString{String: string(sev), Valid: sev != ""}
+}
+
+// CreateTx will create a new SLO for a service.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, o SLO) (*SLO, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	n, err := o.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.New().String()
+
+	_, err = tx.StmtContext(ctx, s.create).ExecContext(ctx, n.ID, n.ServiceID, n.Name, nullSeverity(n.Severity), n.TargetPercent, n.AckWithinMinutes, n.WindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateTx will update an existing SLO. The ServiceID of an SLO cannot be changed.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, o SLO) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", o.ID)
+	if err != nil {
+		return err
+	}
+	n, err := o.Normalize()
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.StmtContext(ctx, s.update).ExecContext(ctx, n.ID, n.Name, nullSeverity(n.Severity), n.TargetPercent, n.AckWithinMinutes, n.WindowDays)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return validation.NewFieldError("ID", "not found")
+	}
+
+	return nil
+}
+
+// DeleteTx will delete an SLO.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, id)
+	return err
+}
+
+// FindOne returns the SLO with the given ID, or nil if it does not exist.
+func (s *Store) FindOne(ctx context.Context, id string) (*SLO, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := scan(s.findOne.QueryRowContext(ctx, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// FindAllByService returns all SLOs for a service, sorted by name.
+func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]SLO, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []SLO
+	for rows.Next() {
+		o, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *o)
+	}
+
+	return result, rows.Err()
+}
+
+// Status returns the last status computed by the engine for an SLO, or nil if it has not been computed yet.
+func (s *Store) Status(ctx context.Context, id string) (*Status, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	st := Status{SLOID: id}
+	err = s.findStatus.QueryRowContext(ctx, id).Scan(&st.Total, &st.Met, &st.BurnRate1h, &st.BurnRate6h, &st.BurnRate24h, &st.ComputedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &st, nil
+}
+
+// BurnRate computes the current error budget burn rate for an SLO over the given lookback window.
+func (s *Store) BurnRate(ctx context.Context, id string, window time.Duration) (float64, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return 0, err
+	}
+	err = validate.Range("WindowHours", int(window/time.Hour), 1, MaxWindowDays*24)
+	if err != nil {
+		return 0, err
+	}
+
+	o, err := s.FindOne(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if o == nil {
+		return 0, validation.NewFieldError("ID", "not found")
+	}
+
+	var total, met int
+	err = s.count.QueryRowContext(ctx, id, time.Now().Add(-window)).Scan(&total, &met)
+	if err != nil {
+		return 0, err
+	}
+
+	return BurnRate(total, met, o.TargetPercent), nil
+}
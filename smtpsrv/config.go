@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/util/log"
 )
 
@@ -20,4 +21,7 @@ type Config struct {
 
 	AuthorizeFunc   func(ctx context.Context, id string) (context.Context, error)
 	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+
+	// RulesFunc, if set, returns the email rules for the given integration key ID.
+	RulesFunc func(ctx context.Context, id string) (*integrationkey.EmailRules, error)
 }
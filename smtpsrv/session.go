@@ -11,6 +11,7 @@ import (
 	"github.com/emersion/go-smtp"
 	"github.com/mnako/letters"
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
@@ -24,6 +25,7 @@ type Session struct {
 	from    string
 	dedup   string
 	authCtx []context.Context
+	rules   []*integrationkey.EmailRules
 }
 
 func (s *Session) isValidDomain(d string) bool {
@@ -125,7 +127,28 @@ func (s *Session) Rcpt(recipient string, opts *smtp.RcptOptions) error {
 		}
 	}
 
+	var rules *integrationkey.EmailRules
+	if s.cfg.RulesFunc != nil {
+		rules, err = s.cfg.RulesFunc(ctx, id)
+		if err != nil {
+			log.Log(ctx, err)
+			return &smtp.SMTPError{
+				Code:         451,
+				EnhancedCode: smtp.EnhancedCode{4, 3, 0},
+				Message:      "Temporary local error, please try again",
+			}
+		}
+	}
+	if rules != nil && !rules.IsSenderAllowed(s.from) {
+		return &smtp.SMTPError{
+			Code:         550,
+			EnhancedCode: smtp.EnhancedCode{5, 7, 1},
+			Message:      "Sender not allowed",
+		}
+	}
+
 	s.authCtx = append(s.authCtx, ctx)
+	s.rules = append(s.rules, rules)
 	return nil
 }
 
@@ -148,21 +171,36 @@ func (s *Session) Data(r io.Reader) error {
 		}
 	}
 	body := email.Text
+	subject := email.Headers.Subject
 
-	summary := validate.SanitizeText(email.Headers.Subject, alert.MaxSummaryLength)
+	summary := validate.SanitizeText(subject, alert.MaxSummaryLength)
 	details := fmt.Sprintf("From: %s\n\n%s", s.from, body)
-	details = validate.SanitizeText(details, alert.MaxDetailsLength)
-	var dedup *alert.DedupID
-	if s.dedup != "" {
-		dedup = alert.NewUserDedup(s.dedup)
-	}
 
-	for _, authCtx := range s.authCtx {
+	for i, authCtx := range s.authCtx {
+		var rules integrationkey.EmailRules
+		if i < len(s.rules) && s.rules[i] != nil {
+			rules = *s.rules[i]
+		}
+
+		dedupStr := s.dedup
+		if dedupStr == "" {
+			dedupStr = rules.Dedup(subject)
+		}
+		var dedup *alert.DedupID
+		if dedupStr != "" {
+			dedup = alert.NewUserDedup(dedupStr)
+		}
+
+		status := alert.StatusTriggered
+		if rules.IsResolved(subject) {
+			status = alert.StatusClosed
+		}
+
 		newAlert := &alert.Alert{
 			Summary:   summary,
-			Details:   details,
+			Details:   validate.SanitizeText(details, rules.DetailsLength(alert.MaxDetailsLength)),
 			ServiceID: permission.ServiceID(authCtx),
-			Status:    alert.StatusTriggered,
+			Status:    status,
 			Source:    alert.SourceEmail,
 			Dedup:     dedup,
 		}
@@ -191,6 +229,7 @@ func (s *Session) Reset() {
 	s.dedup = ""
 	s.from = ""
 	s.authCtx = nil
+	s.rules = nil
 }
 
 // Logout is called when the client requests to log out.
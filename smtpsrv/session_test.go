@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 )
@@ -98,3 +99,28 @@ func TestSession_Data(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, createdAlert, "CreateAlertFunc not called")
 }
+
+func TestSession_DataRules(t *testing.T) {
+	var sess Session
+	sess.authCtx = []context.Context{permission.ServiceContext(context.Background(), "svc")}
+	sess.rules = []*integrationkey.EmailRules{{
+		DedupPattern:     `Disk full on (\w+)`,
+		ResolvePattern:   `^RESOLVED`,
+		MaxDetailsLength: 10,
+	}}
+	sess.from = "test@localhost"
+
+	var createdAlert bool
+	sess.cfg.CreateAlertFunc = func(ctx context.Context, a *alert.Alert) error {
+		t.Helper()
+		createdAlert = true
+		assert.Equal(t, "db1", a.DedupKey().Payload)
+		assert.Equal(t, alert.StatusClosed, a.Status)
+		assert.Equal(t, "From: tes…", a.Details)
+		return nil
+	}
+
+	err := sess.Data(strings.NewReader("Subject: RESOLVED: Disk full on db1\r\n\r\nHello, world!"))
+	assert.NoError(t, err)
+	assert.True(t, createdAlert, "CreateAlertFunc not called")
+}
@@ -0,0 +1,225 @@
+package snmptrap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// BER tags used by SNMP.
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagNull        = 0x05
+	tagOID         = 0x06
+	tagSequence    = 0x30
+
+	tagIPAddress = 0x40
+	tagCounter32 = 0x41
+	tagGauge32   = 0x42
+	tagTimeTicks = 0x43
+	tagOpaque    = 0x44
+	tagCounter64 = 0x46
+
+	tagNoSuchObject   = 0x80
+	tagNoSuchInstance = 0x81
+	tagEndOfMibView   = 0x82
+
+	tagTrapV1 = 0xa4
+	tagInform = 0xa6
+	tagTrapV2 = 0xa7
+)
+
+// maxLengthOctets is the maximum number of octets supported for long-form lengths.
+const maxLengthOctets = 4
+
+var errTruncated = errors.New("truncated message")
+
+// tlv is a single decoded BER element.
+type tlv struct {
+	Tag   byte
+	Value []byte
+}
+
+// readTLV reads a single BER element from b, returning it and the remaining bytes.
+func readTLV(b []byte) (tlv, []byte, error) {
+	if len(b) < 2 {
+		return tlv{}, nil, errTruncated
+	}
+
+	tag := b[0]
+	if tag&0x1f == 0x1f {
+		return tlv{}, nil, fmt.Errorf("unsupported multi-byte tag 0x%x", tag)
+	}
+
+	l := int(b[1])
+	b = b[2:]
+	if l&0x80 != 0 {
+		n := l & 0x7f
+		if n == 0 || n > maxLengthOctets {
+			return tlv{}, nil, fmt.Errorf("unsupported length encoding")
+		}
+		if len(b) < n {
+			return tlv{}, nil, errTruncated
+		}
+		l = 0
+		for _, c := range b[:n] {
+			l = l<<8 | int(c)
+		}
+		b = b[n:]
+	}
+	if l < 0 || len(b) < l {
+		return tlv{}, nil, errTruncated
+	}
+
+	return tlv{Tag: tag, Value: b[:l]}, b[l:], nil
+}
+
+// expect reads a single element and ensures it has the given tag.
+func expect(b []byte, tag byte) (tlv, []byte, error) {
+	t, rest, err := readTLV(b)
+	if err != nil {
+		return t, nil, err
+	}
+	if t.Tag != tag {
+		return t, nil, fmt.Errorf("unexpected tag 0x%x; want 0x%x", t.Tag, tag)
+	}
+
+	return t, rest, nil
+}
+
+func parseInt(v []byte) (int64, error) {
+	if len(v) == 0 || len(v) > 8 {
+		return 0, fmt.Errorf("invalid integer length %d", len(v))
+	}
+
+	var n int64
+	if v[0]&0x80 != 0 {
+		n = -1
+	}
+	for _, c := range v {
+		n = n<<8 | int64(c)
+	}
+
+	return n, nil
+}
+
+func parseUint(v []byte) (uint64, error) {
+	if len(v) > 0 && v[0] == 0 {
+		v = v[1:]
+	}
+	if len(v) > 8 {
+		return 0, fmt.Errorf("invalid unsigned integer length %d", len(v))
+	}
+
+	var n uint64
+	for _, c := range v {
+		n = n<<8 | uint64(c)
+	}
+
+	return n, nil
+}
+
+func parseOID(v []byte) (string, error) {
+	if len(v) == 0 {
+		return "", errors.New("empty OID")
+	}
+
+	var parts []string
+	var cur uint64
+	for i, c := range v {
+		if cur > math.MaxUint32 {
+			return "", errors.New("OID sub-identifier too large")
+		}
+		cur = cur<<7 | uint64(c&0x7f)
+		if c&0x80 != 0 {
+			if i == len(v)-1 {
+				return "", errTruncated
+			}
+			continue
+		}
+
+		if parts == nil {
+			// the first sub-identifier encodes the first two arcs
+			switch {
+			case cur < 40:
+				parts = append(parts, "0", strconv.FormatUint(cur, 10))
+			case cur < 80:
+				parts = append(parts, "1", strconv.FormatUint(cur-40, 10))
+			default:
+				parts = append(parts, "2", strconv.FormatUint(cur-80, 10))
+			}
+		} else {
+			parts = append(parts, strconv.FormatUint(cur, 10))
+		}
+		cur = 0
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// formatValue returns a human-readable representation of a varbind value.
+func formatValue(t tlv) (string, error) {
+	switch t.Tag {
+	case tagInteger:
+		n, err := parseInt(t.Value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+	case tagCounter32, tagGauge32, tagTimeTicks, tagCounter64:
+		n, err := parseUint(t.Value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(n, 10), nil
+	case tagOctetString, tagOpaque:
+		return string(t.Value), nil
+	case tagOID:
+		return parseOID(t.Value)
+	case tagIPAddress:
+		if len(t.Value) != 4 {
+			return "", errors.New("invalid IpAddress")
+		}
+		return net.IP(t.Value).String(), nil
+	case tagNull, tagNoSuchObject, tagNoSuchInstance, tagEndOfMibView:
+		return "", nil
+	}
+
+	return "", fmt.Errorf("unsupported value type 0x%x", t.Tag)
+}
+
+// encodeTLV encodes a single BER element.
+func encodeTLV(tag byte, value []byte) []byte {
+	b := []byte{tag}
+	l := len(value)
+	switch {
+	case l < 0x80:
+		b = append(b, byte(l))
+	case l <= 0xff:
+		b = append(b, 0x81, byte(l))
+	case l <= 0xffff:
+		b = append(b, 0x82, byte(l>>8), byte(l))
+	default:
+		b = append(b, 0x84, byte(l>>24), byte(l>>16), byte(l>>8), byte(l))
+	}
+
+	return append(b, value...)
+}
+
+// encodeInt encodes a BER INTEGER using the minimum number of octets.
+func encodeInt(n int64) []byte {
+	var b []byte
+	for {
+		b = append([]byte{byte(n)}, b...)
+		n >>= 8
+		if (n == 0 && b[0]&0x80 == 0) || (n == -1 && b[0]&0x80 != 0) {
+			break
+		}
+	}
+
+	return encodeTLV(tagInteger, b)
+}
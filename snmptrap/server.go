@@ -0,0 +1,259 @@
+package snmptrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxPacketSize is the largest UDP payload accepted.
+const maxPacketSize = 65535
+
+// maxInFlight limits the number of traps processed concurrently.
+const maxInFlight = 32
+
+// Config is used to configure the SNMP trap listener.
+type Config struct {
+	BackgroundContext func() context.Context
+
+	// USM contains the SNMPv3 credentials used to authenticate and decrypt v3 traps. If nil,
+	// only noAuthNoPriv v3 traps are accepted.
+	USM *USM
+
+	// AuthorizeFunc authorizes the integration key ID provided as the community string (or v3 user name).
+	AuthorizeFunc func(ctx context.Context, id string) (context.Context, error)
+
+	// RulesFunc returns the SNMP rules for the given integration key ID.
+	RulesFunc func(ctx context.Context, id string) ([]integrationkey.SNMPRule, error)
+
+	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+}
+
+// Server listens for SNMP traps and converts them to alerts.
+type Server struct {
+	cfg Config
+
+	mx     sync.Mutex
+	conn   net.PacketConn
+	closed bool
+	wg     sync.WaitGroup
+	sem    chan struct{}
+}
+
+// NewServer creates a new Server.
+func NewServer(cfg Config) *Server {
+	if cfg.BackgroundContext == nil {
+		panic("snmptrap: BackgroundContext is required")
+	}
+	if cfg.AuthorizeFunc == nil {
+		panic("snmptrap: AuthorizeFunc is required")
+	}
+	if cfg.RulesFunc == nil {
+		panic("snmptrap: RulesFunc is required")
+	}
+	if cfg.CreateAlertFunc == nil {
+		panic("snmptrap: CreateAlertFunc is required")
+	}
+
+	return &Server{cfg: cfg, sem: make(chan struct{}, maxInFlight)}
+}
+
+// ServeSNMP will process traps received on conn until Shutdown is called.
+func (s *Server) ServeSNMP(conn net.PacketConn) error {
+	s.mx.Lock()
+	if s.closed {
+		s.mx.Unlock()
+		return net.ErrClosed
+	}
+	s.conn = conn
+	s.mx.Unlock()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		data := make([]byte, n)
+		copy(data, buf[:n])
+
+		s.sem <- struct{}{}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-s.sem }()
+			s.handlePacket(conn, addr, data)
+		}()
+	}
+}
+
+// Shutdown stops the listener and waits for in-flight traps to be processed.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mx.Lock()
+	s.closed = true
+	conn := s.conn
+	s.mx.Unlock()
+
+	if conn != nil {
+		_ = conn.Close()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+func hostOf(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+func (s *Server) handlePacket(conn net.PacketConn, addr net.Addr, data []byte) {
+	ctx := log.WithFields(s.cfg.BackgroundContext(), log.Fields{
+		"RemoteAddr": addr.String(),
+	})
+
+	t, err := s.cfg.USM.ParseTrap(data)
+	if err != nil {
+		log.Debugf(ctx, "snmptrap: ignoring invalid message: %v", err)
+		return
+	}
+	ctx = log.WithFields(ctx, log.Fields{
+		"SNMPVersion": t.Version.String(),
+		"TrapOID":     t.TrapOID,
+	})
+
+	// community strings that aren't integration keys are ignored (not logged) since
+	// devices are commonly configured with a default community like "public".
+	if validate.UUID("Community", t.Community) != nil {
+		return
+	}
+
+	ctx, err = s.cfg.AuthorizeFunc(ctx, t.Community)
+	if permission.IsUnauthorized(err) {
+		log.Debugf(ctx, "snmptrap: unknown integration key")
+		return
+	}
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("snmptrap: authorize: %w", err))
+		return
+	}
+
+	rules, err := s.cfg.RulesFunc(ctx, t.Community)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("snmptrap: lookup rules: %w", err))
+		return
+	}
+
+	source := t.AgentAddr
+	if source == "" {
+		source = hostOf(addr)
+	}
+
+	a, ok := newAlert(t, source, rules)
+	if !ok {
+		log.Debugf(ctx, "snmptrap: no matching rule")
+		s.respond(ctx, conn, addr, t)
+		return
+	}
+	a.ServiceID = permission.ServiceID(ctx)
+
+	err = retry.DoTemporaryError(func(_ int) error {
+		return s.cfg.CreateAlertFunc(ctx, a)
+	},
+		retry.Log(ctx),
+		retry.Limit(12),
+		retry.FibBackoff(time.Second),
+	)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("snmptrap: create alert: %w", err))
+		return
+	}
+
+	s.respond(ctx, conn, addr, t)
+}
+
+// respond acknowledges informs.
+func (s *Server) respond(ctx context.Context, conn net.PacketConn, addr net.Addr, t *Trap) {
+	resp := t.Response()
+	if resp == nil {
+		return
+	}
+
+	_, err := conn.WriteTo(resp, addr)
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		log.Log(ctx, fmt.Errorf("snmptrap: send inform response: %w", err))
+	}
+}
+
+// newAlert builds an alert from the trap and rules. It returns false if the trap should be ignored.
+//
+// If no rules are configured, all traps will trigger alerts.
+func newAlert(t *Trap, source string, rules []integrationkey.SNMPRule) (*alert.Alert, bool) {
+	rule := &integrationkey.SNMPRule{Action: integrationkey.SNMPActionTrigger}
+	if len(rules) > 0 {
+		rule = integrationkey.MatchSNMPRule(rules, t.TrapOID)
+	}
+	if rule == nil || rule.Action == integrationkey.SNMPActionIgnore {
+		return nil, false
+	}
+
+	summary := fmt.Sprintf("SNMP trap %s from %s", t.TrapOID, source)
+	if v, ok := t.LookupPrefix(rule.SummaryOID); ok && v != "" {
+		summary = v
+	}
+
+	dedup := source
+	if v, ok := t.LookupPrefix(rule.DedupOID); ok {
+		dedup += ":" + v
+	}
+
+	var details strings.Builder
+	fmt.Fprintf(&details, "Trap: %s\nVersion: %s\nSource: %s\n\n", t.TrapOID, t.Version, source)
+	for _, v := range t.Varbinds {
+		fmt.Fprintf(&details, "%s = %s\n", v.OID, v.Value)
+	}
+
+	status := alert.StatusTriggered
+	if rule.Action == integrationkey.SNMPActionClose {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(details.String(), alert.MaxDetailsLength),
+		Status:  status,
+		Source:  alert.SourceSNMP,
+		Dedup:   alert.NewUserDedup(dedup),
+	}, true
+}
@@ -0,0 +1,431 @@
+package snmptrap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the SNMP protocol version of a received trap.
+type Version int
+
+// Supported SNMP versions, using their on-the-wire values.
+const (
+	VersionV1  Version = 0
+	VersionV2c Version = 1
+	VersionV3  Version = 3
+)
+
+func (v Version) String() string {
+	switch v {
+	case VersionV1:
+		return "v1"
+	case VersionV2c:
+		return "v2c"
+	case VersionV3:
+		return "v3"
+	}
+	return "Version(" + strconv.Itoa(int(v)) + ")"
+}
+
+// Well-known OIDs.
+const (
+	OIDSysUpTime   = "1.3.6.1.2.1.1.3.0"
+	OIDSnmpTrapOID = "1.3.6.1.6.3.1.1.4.1.0"
+
+	oidGenericTrapPrefix = "1.3.6.1.6.3.1.1.5."
+)
+
+// usmSecurityModel is the msgSecurityModel value for the User-based Security Model.
+const usmSecurityModel = 3
+
+// Varbind is a single variable binding from a trap.
+type Varbind struct {
+	OID   string
+	Value string
+}
+
+// Trap is a decoded SNMP trap or inform.
+type Trap struct {
+	Version Version
+
+	// Community is the community string (v1/v2c) or the USM user name (v3).
+	Community string
+
+	// TrapOID identifies the trap. For v1 traps it is derived from the
+	// generic/specific trap values as described in RFC 3584.
+	TrapOID string
+
+	// AgentAddr is the agent address included in v1 traps.
+	AgentAddr string
+
+	Varbinds []Varbind
+
+	// IsInform is true if the sender expects a response.
+	IsInform  bool
+	requestID int64
+	varbinds  []byte
+}
+
+// Value returns the value of the varbind with the given OID, and whether it was found.
+func (t Trap) Value(oid string) (string, bool) {
+	oid = strings.TrimPrefix(oid, ".")
+	for _, v := range t.Varbinds {
+		if v.OID == oid {
+			return v.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// LookupPrefix returns the value of the first varbind whose OID equals, or is below, the given OID.
+func (t Trap) LookupPrefix(oid string) (string, bool) {
+	oid = strings.TrimPrefix(oid, ".")
+	if oid == "" {
+		return "", false
+	}
+	for _, v := range t.Varbinds {
+		if v.OID == oid || strings.HasPrefix(v.OID, oid+".") {
+			return v.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// ErrUnsupportedSecurity is returned for SNMPv3 messages using a security level (authentication
+// or privacy) that has no configured credentials.
+var ErrUnsupportedSecurity = errors.New("SNMPv3 security level not configured")
+
+// ParseTrap decodes an SNMP v1, v2c, or v3 (noAuthNoPriv) trap or inform message.
+//
+// Use USM.ParseTrap to accept authenticated or encrypted SNMPv3 messages.
+func ParseTrap(b []byte) (*Trap, error) { return parseTrap(b, nil) }
+
+func parseTrap(b []byte, usm *USM) (*Trap, error) {
+	msg, _, err := expect(b, tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+
+	ver, rest, err := expect(msg.Value, tagInteger)
+	if err != nil {
+		return nil, fmt.Errorf("decode version: %w", err)
+	}
+	v, err := parseInt(ver.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode version: %w", err)
+	}
+
+	var t Trap
+	t.Version = Version(v)
+	switch t.Version {
+	case VersionV1, VersionV2c:
+		comm, r, err := expect(rest, tagOctetString)
+		if err != nil {
+			return nil, fmt.Errorf("decode community: %w", err)
+		}
+		t.Community = string(comm.Value)
+		rest = r
+	case VersionV3:
+		rest, err = t.parseV3Header(b, rest, usm)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SNMP version %d", v)
+	}
+
+	pdu, _, err := readTLV(rest)
+	if err != nil {
+		return nil, fmt.Errorf("decode PDU: %w", err)
+	}
+
+	switch pdu.Tag {
+	case tagTrapV1:
+		if t.Version != VersionV1 {
+			return nil, errors.New("v1 trap PDU in non-v1 message")
+		}
+		err = t.parseV1PDU(pdu.Value)
+	case tagTrapV2, tagInform:
+		if t.Version == VersionV1 {
+			return nil, errors.New("v2 trap PDU in v1 message")
+		}
+		t.IsInform = pdu.Tag == tagInform
+		err = t.parseV2PDU(pdu.Value)
+	default:
+		return nil, fmt.Errorf("unsupported PDU type 0x%x", pdu.Tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// parseV3Header decodes the v3 header and security parameters, returning the PDU from the scoped PDU.
+//
+// Authenticated messages are verified (and decrypted, if encrypted) using usm. The whole message is
+// required to verify the HMAC.
+func (t *Trap) parseV3Header(whole, b []byte, usm *USM) ([]byte, error) {
+	global, rest, err := expect(b, tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("decode header: %w", err)
+	}
+	_, g, err := expect(global.Value, tagInteger) // msgID
+	if err != nil {
+		return nil, fmt.Errorf("decode msgID: %w", err)
+	}
+	_, g, err = expect(g, tagInteger) // msgMaxSize
+	if err != nil {
+		return nil, fmt.Errorf("decode msgMaxSize: %w", err)
+	}
+	flags, g, err := expect(g, tagOctetString)
+	if err != nil || len(flags.Value) != 1 {
+		return nil, errors.New("decode msgFlags: invalid value")
+	}
+	model, _, err := expect(g, tagInteger)
+	if err != nil {
+		return nil, fmt.Errorf("decode msgSecurityModel: %w", err)
+	}
+	m, err := parseInt(model.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode msgSecurityModel: %w", err)
+	}
+	if m != usmSecurityModel {
+		return nil, fmt.Errorf("unsupported security model %d", m)
+	}
+	err = usm.checkLevel(flags.Value[0])
+	if err != nil {
+		return nil, err
+	}
+
+	secParams, rest, err := expect(rest, tagOctetString)
+	if err != nil {
+		return nil, fmt.Errorf("decode security parameters: %w", err)
+	}
+	usmParams, _, err := expect(secParams.Value, tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("decode USM parameters: %w", err)
+	}
+	engineID, u, err := expect(usmParams.Value, tagOctetString)
+	if err != nil {
+		return nil, fmt.Errorf("decode engine ID: %w", err)
+	}
+	bootsVal, u, err := expect(u, tagInteger)
+	if err != nil {
+		return nil, fmt.Errorf("decode engine boots: %w", err)
+	}
+	boots, err := parseInt(bootsVal.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode engine boots: %w", err)
+	}
+	timeVal, u, err := expect(u, tagInteger)
+	if err != nil {
+		return nil, fmt.Errorf("decode engine time: %w", err)
+	}
+	engTime, err := parseInt(timeVal.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decode engine time: %w", err)
+	}
+	user, u, err := expect(u, tagOctetString)
+	if err != nil {
+		return nil, fmt.Errorf("decode user name: %w", err)
+	}
+	t.Community = string(user.Value)
+	authParams, u, err := expect(u, tagOctetString)
+	if err != nil {
+		return nil, fmt.Errorf("decode authentication parameters: %w", err)
+	}
+	privParams, _, err := expect(u, tagOctetString)
+	if err != nil {
+		return nil, fmt.Errorf("decode privacy parameters: %w", err)
+	}
+
+	scopedData := rest
+	if flags.Value[0]&0x01 != 0 {
+		keys := usm.localKeys(engineID.Value)
+		err = usm.authenticate(whole, authParams.Value, keys)
+		if err != nil {
+			return nil, err
+		}
+		err = usm.checkTime(engineID.Value, boots, engTime, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if flags.Value[0]&0x02 != 0 {
+			enc, _, err := expect(rest, tagOctetString)
+			if err != nil {
+				return nil, fmt.Errorf("decode encrypted PDU: %w", err)
+			}
+			scopedData, err = usm.decrypt(enc.Value, privParams.Value, boots, engTime, keys)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	scoped, _, err := expect(scopedData, tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("decode scoped PDU: %w", err)
+	}
+	_, s, err := expect(scoped.Value, tagOctetString) // context engine ID
+	if err != nil {
+		return nil, fmt.Errorf("decode context engine ID: %w", err)
+	}
+	_, s, err = expect(s, tagOctetString) // context name
+	if err != nil {
+		return nil, fmt.Errorf("decode context name: %w", err)
+	}
+
+	return s, nil
+}
+
+func (t *Trap) parseV1PDU(b []byte) error {
+	ent, rest, err := expect(b, tagOID)
+	if err != nil {
+		return fmt.Errorf("decode enterprise: %w", err)
+	}
+	enterprise, err := parseOID(ent.Value)
+	if err != nil {
+		return fmt.Errorf("decode enterprise: %w", err)
+	}
+	addr, rest, err := expect(rest, tagIPAddress)
+	if err != nil {
+		return fmt.Errorf("decode agent address: %w", err)
+	}
+	t.AgentAddr, err = formatValue(addr)
+	if err != nil {
+		return fmt.Errorf("decode agent address: %w", err)
+	}
+	gen, rest, err := expect(rest, tagInteger)
+	if err != nil {
+		return fmt.Errorf("decode generic trap: %w", err)
+	}
+	generic, err := parseInt(gen.Value)
+	if err != nil {
+		return fmt.Errorf("decode generic trap: %w", err)
+	}
+	spec, rest, err := expect(rest, tagInteger)
+	if err != nil {
+		return fmt.Errorf("decode specific trap: %w", err)
+	}
+	specific, err := parseInt(spec.Value)
+	if err != nil {
+		return fmt.Errorf("decode specific trap: %w", err)
+	}
+	_, rest, err = expect(rest, tagTimeTicks)
+	if err != nil {
+		return fmt.Errorf("decode timestamp: %w", err)
+	}
+
+	// RFC 3584 section 3.1
+	if generic >= 0 && generic < 6 {
+		t.TrapOID = oidGenericTrapPrefix + strconv.FormatInt(generic+1, 10)
+	} else {
+		t.TrapOID = enterprise + ".0." + strconv.FormatInt(specific, 10)
+	}
+
+	t.Varbinds, err = parseVarbinds(rest)
+	return err
+}
+
+func (t *Trap) parseV2PDU(b []byte) error {
+	reqID, rest, err := expect(b, tagInteger)
+	if err != nil {
+		return fmt.Errorf("decode request ID: %w", err)
+	}
+	t.requestID, err = parseInt(reqID.Value)
+	if err != nil {
+		return fmt.Errorf("decode request ID: %w", err)
+	}
+	_, rest, err = expect(rest, tagInteger) // error-status
+	if err != nil {
+		return fmt.Errorf("decode error status: %w", err)
+	}
+	_, rest, err = expect(rest, tagInteger) // error-index
+	if err != nil {
+		return fmt.Errorf("decode error index: %w", err)
+	}
+
+	t.varbinds = rest
+	t.Varbinds, err = parseVarbinds(rest)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	t.TrapOID, ok = t.Value(OIDSnmpTrapOID)
+	if !ok {
+		return errors.New("missing snmpTrapOID")
+	}
+
+	return nil
+}
+
+func parseVarbinds(b []byte) ([]Varbind, error) {
+	list, _, err := expect(b, tagSequence)
+	if err != nil {
+		return nil, fmt.Errorf("decode varbinds: %w", err)
+	}
+
+	var result []Varbind
+	rest := list.Value
+	for len(rest) > 0 {
+		var vb tlv
+		vb, rest, err = expect(rest, tagSequence)
+		if err != nil {
+			return nil, fmt.Errorf("decode varbind: %w", err)
+		}
+		name, val, err := expect(vb.Value, tagOID)
+		if err != nil {
+			return nil, fmt.Errorf("decode varbind name: %w", err)
+		}
+		oid, err := parseOID(name.Value)
+		if err != nil {
+			return nil, fmt.Errorf("decode varbind name: %w", err)
+		}
+		value, _, err := readTLV(val)
+		if err != nil {
+			return nil, fmt.Errorf("decode varbind %s: %w", oid, err)
+		}
+		s, err := formatValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode varbind %s: %w", oid, err)
+		}
+
+		result = append(result, Varbind{OID: oid, Value: s})
+	}
+
+	return result, nil
+}
+
+// tagResponse is the PDU tag for a Response-PDU.
+const tagResponse = 0xa2
+
+// Response returns the encoded Response-PDU acknowledging an inform, or nil
+// if no response is required.
+//
+// Responses are only supported for v2c informs.
+func (t Trap) Response() []byte {
+	if !t.IsInform || t.Version != VersionV2c {
+		return nil
+	}
+
+	var pdu []byte
+	pdu = append(pdu, encodeInt(t.requestID)...)
+	pdu = append(pdu, encodeInt(0)...) // error-status
+	pdu = append(pdu, encodeInt(0)...) // error-index
+	pdu = append(pdu, t.varbinds...)
+
+	var msg []byte
+	msg = append(msg, encodeInt(int64(t.Version))...)
+	msg = append(msg, encodeTLV(tagOctetString, []byte(t.Community))...)
+	msg = append(msg, encodeTLV(tagResponse, pdu)...)
+
+	return encodeTLV(tagSequence, msg)
+}
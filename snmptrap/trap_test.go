@@ -0,0 +1,150 @@
+package snmptrap
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encOID(t *testing.T, oid string) []byte {
+	t.Helper()
+	var arcs []uint64
+	for _, p := range strings.Split(oid, ".") {
+		n, err := strconv.ParseUint(p, 10, 32)
+		require.NoError(t, err)
+		arcs = append(arcs, n)
+	}
+
+	b := []byte{byte(arcs[0]*40 + arcs[1])}
+	for _, n := range arcs[2:] {
+		var sub []byte
+		sub = append(sub, byte(n&0x7f))
+		for n >>= 7; n > 0; n >>= 7 {
+			sub = append([]byte{byte(n&0x7f) | 0x80}, sub...)
+		}
+		b = append(b, sub...)
+	}
+	return encodeTLV(tagOID, b)
+}
+
+func seq(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return encodeTLV(tagSequence, b)
+}
+
+func str(s string) []byte { return encodeTLV(tagOctetString, []byte(s)) }
+
+func TestParseTrap_V2c(t *testing.T) {
+	varbinds := seq(
+		seq(encOID(t, OIDSysUpTime), encodeTLV(tagTimeTicks, []byte{0x01, 0x00})),
+		seq(encOID(t, OIDSnmpTrapOID), encOID(t, "1.3.6.1.6.3.1.1.5.3")),
+		seq(encOID(t, "1.3.6.1.2.1.2.2.1.1.2"), encodeInt(2)),
+		seq(encOID(t, "1.3.6.1.2.1.2.2.1.2.2"), str("eth0")),
+		seq(encOID(t, "1.3.6.1.4.1.99999.1"), encodeTLV(tagIPAddress, []byte{10, 0, 0, 1})),
+	)
+	pdu := encodeTLV(tagTrapV2, append(append(append(encodeInt(1234), encodeInt(0)...), encodeInt(0)...), varbinds...))
+	msg := seq(encodeInt(1), str("00000000-0000-0000-0000-000000000001"), pdu)
+
+	trap, err := ParseTrap(msg)
+	require.NoError(t, err)
+	assert.Equal(t, VersionV2c, trap.Version)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", trap.Community)
+	assert.Equal(t, "1.3.6.1.6.3.1.1.5.3", trap.TrapOID)
+	assert.False(t, trap.IsInform)
+	assert.Nil(t, trap.Response())
+
+	v, ok := trap.Value(".1.3.6.1.2.1.2.2.1.2.2")
+	assert.True(t, ok)
+	assert.Equal(t, "eth0", v)
+	v, _ = trap.Value("1.3.6.1.2.1.2.2.1.1.2")
+	assert.Equal(t, "2", v)
+	v, _ = trap.Value("1.3.6.1.4.1.99999.1")
+	assert.Equal(t, "10.0.0.1", v)
+	v, _ = trap.Value(OIDSysUpTime)
+	assert.Equal(t, "256", v)
+
+	v, ok = trap.LookupPrefix("1.3.6.1.2.1.2.2.1.2")
+	assert.True(t, ok)
+	assert.Equal(t, "eth0", v)
+	_, ok = trap.LookupPrefix("1.3.6.1.2.1.2.2.1.2.20")
+	assert.False(t, ok)
+}
+
+func TestParseTrap_Inform(t *testing.T) {
+	varbinds := seq(
+		seq(encOID(t, OIDSnmpTrapOID), encOID(t, "1.3.6.1.4.1.8072.2.3.0.1")),
+	)
+	pdu := encodeTLV(tagInform, append(append(append(encodeInt(-5), encodeInt(0)...), encodeInt(0)...), varbinds...))
+	msg := seq(encodeInt(1), str("public"), pdu)
+
+	trap, err := ParseTrap(msg)
+	require.NoError(t, err)
+	assert.True(t, trap.IsInform)
+
+	resp := trap.Response()
+	require.NotNil(t, resp)
+	expected := seq(encodeInt(1), str("public"), encodeTLV(0xa2, append(append(append(encodeInt(-5), encodeInt(0)...), encodeInt(0)...), varbinds...)))
+	assert.Equal(t, expected, resp)
+}
+
+func TestParseTrap_V1(t *testing.T) {
+	build := func(generic, specific int64) []byte {
+		var pdu []byte
+		pdu = append(pdu, encOID(t, "1.3.6.1.4.1.9")...)
+		pdu = append(pdu, encodeTLV(tagIPAddress, []byte{192, 168, 1, 1})...)
+		pdu = append(pdu, encodeInt(generic)...)
+		pdu = append(pdu, encodeInt(specific)...)
+		pdu = append(pdu, encodeTLV(tagTimeTicks, []byte{0x10})...)
+		pdu = append(pdu, seq(seq(encOID(t, "1.3.6.1.2.1.2.2.1.1.3"), encodeInt(3)))...)
+		return seq(encodeInt(0), str("key"), encodeTLV(tagTrapV1, pdu))
+	}
+
+	trap, err := ParseTrap(build(2, 0))
+	require.NoError(t, err)
+	assert.Equal(t, VersionV1, trap.Version)
+	assert.Equal(t, "192.168.1.1", trap.AgentAddr)
+	assert.Equal(t, "1.3.6.1.6.3.1.1.5.3", trap.TrapOID) // linkDown
+
+	trap, err = ParseTrap(build(6, 42))
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.6.1.4.1.9.0.42", trap.TrapOID)
+}
+
+func TestParseTrap_V3(t *testing.T) {
+	build := func(flags byte) []byte {
+		header := seq(encodeInt(1), encodeInt(65507), str(string([]byte{flags})), encodeInt(3))
+		usm := seq(str("engine"), encodeInt(1), encodeInt(2), str("00000000-0000-0000-0000-000000000002"), str(""), str(""))
+		varbinds := seq(seq(encOID(t, OIDSnmpTrapOID), encOID(t, "1.3.6.1.6.3.1.1.5.4")))
+		pdu := encodeTLV(tagTrapV2, append(append(append(encodeInt(1), encodeInt(0)...), encodeInt(0)...), varbinds...))
+		scoped := seq(str("engine"), str(""), pdu)
+		return seq(encodeInt(3), header, encodeTLV(tagOctetString, usm), scoped)
+	}
+
+	trap, err := ParseTrap(build(0))
+	require.NoError(t, err)
+	assert.Equal(t, VersionV3, trap.Version)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", trap.Community)
+	assert.Equal(t, "1.3.6.1.6.3.1.1.5.4", trap.TrapOID)
+
+	_, err = ParseTrap(build(0x01))
+	assert.ErrorIs(t, err, ErrUnsupportedSecurity)
+}
+
+func TestParseTrap_Invalid(t *testing.T) {
+	for _, b := range [][]byte{
+		nil,
+		{0x30},
+		{0x30, 0x05, 0x02, 0x01},
+		seq(encodeInt(2), str("x")),
+		seq(encodeInt(1), str("x"), encodeTLV(0xa0, nil)),
+	} {
+		_, err := ParseTrap(b)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,310 @@
+package snmptrap
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"sync"
+	"time"
+)
+
+// AuthProtocol is an SNMPv3 USM authentication protocol.
+type AuthProtocol string
+
+// Supported authentication protocols (RFC 3414).
+const (
+	AuthNone AuthProtocol = ""
+	AuthMD5  AuthProtocol = "MD5"
+	AuthSHA  AuthProtocol = "SHA"
+)
+
+// PrivProtocol is an SNMPv3 USM privacy (encryption) protocol.
+type PrivProtocol string
+
+// Supported privacy protocols (RFC 3414 and RFC 3826).
+const (
+	PrivNone PrivProtocol = ""
+	PrivDES  PrivProtocol = "DES"
+	PrivAES  PrivProtocol = "AES"
+)
+
+const (
+	// authParamLen is the length of the truncated HMAC for HMAC-MD5-96 and HMAC-SHA-96.
+	authParamLen = 12
+
+	// timeWindow is the number of seconds a message's engine time may lag behind the sender's
+	// last known time (RFC 3414 section 3.2.7).
+	timeWindow = 150
+
+	maxEngineBoots = 2147483647
+
+	// maxEngines limits the number of remote engines tracked for timeliness checks.
+	maxEngines = 10000
+)
+
+// Errors returned for SNMPv3 messages that fail security processing.
+var (
+	ErrAuthRequired     = errors.New("SNMPv3 message is not authenticated")
+	ErrPrivRequired     = errors.New("SNMPv3 message is not encrypted")
+	ErrWrongDigest      = errors.New("SNMPv3 message authentication failed")
+	ErrNotInTimeWindow  = errors.New("SNMPv3 message is outside the time window")
+	ErrDecryptionFailed = errors.New("SNMPv3 message decryption failed")
+)
+
+// USM holds User-based Security Model (RFC 3414) credentials used to authenticate and
+// decrypt SNMPv3 traps. The same credentials apply to every user name (integration key).
+//
+// When AuthProtocol is set, unauthenticated v3 messages are rejected; when PrivProtocol
+// is set, unencrypted v3 messages are rejected.
+type USM struct {
+	AuthProtocol AuthProtocol
+	AuthPassword string
+	PrivProtocol PrivProtocol
+	PrivPassword string
+
+	initOnce sync.Once
+	authKu   []byte
+	privKu   []byte
+
+	mx      sync.Mutex
+	keys    map[string]*usmKeys
+	engines map[string]*engineTime
+}
+
+type usmKeys struct {
+	auth []byte
+	priv []byte
+}
+
+// engineTime tracks the most recent authentic boots/time of a remote (authoritative) engine.
+type engineTime struct {
+	boots      int64
+	time       int64
+	receivedAt time.Time
+}
+
+// Validate checks the protocols and passwords.
+func (u *USM) Validate() error {
+	switch u.AuthProtocol {
+	case AuthNone:
+		if u.PrivProtocol != PrivNone {
+			return errors.New("privacy requires an authentication protocol")
+		}
+		return nil
+	case AuthMD5, AuthSHA:
+	default:
+		return fmt.Errorf("unsupported authentication protocol %q; must be MD5 or SHA", u.AuthProtocol)
+	}
+	if len(u.AuthPassword) < 8 {
+		return errors.New("authentication password must be at least 8 characters")
+	}
+
+	switch u.PrivProtocol {
+	case PrivNone:
+		return nil
+	case PrivDES, PrivAES:
+	default:
+		return fmt.Errorf("unsupported privacy protocol %q; must be DES or AES", u.PrivProtocol)
+	}
+	if len(u.PrivPassword) < 8 {
+		return errors.New("privacy password must be at least 8 characters")
+	}
+
+	return nil
+}
+
+// ParseTrap decodes an SNMP trap or inform message, authenticating and decrypting
+// SNMPv3 messages using the configured credentials. If u is nil, it is equivalent to ParseTrap.
+func (u *USM) ParseTrap(b []byte) (*Trap, error) { return parseTrap(b, u) }
+
+func (u *USM) hash() func() hash.Hash {
+	if u.AuthProtocol == AuthMD5 {
+		return md5.New
+	}
+
+	return sha1.New
+}
+
+// passwordToKey implements the password to key algorithm from RFC 3414 appendix A.2.
+func passwordToKey(h func() hash.Hash, password string) []byte {
+	hh := h()
+	pw := []byte(password)
+	buf := make([]byte, 64)
+	var idx int
+	for count := 0; count < 1048576; count += len(buf) {
+		for i := range buf {
+			buf[i] = pw[idx%len(pw)]
+			idx++
+		}
+		hh.Write(buf)
+	}
+
+	return hh.Sum(nil)
+}
+
+// localizeKey localizes a key to the given engine ID (RFC 3414 section 2.6).
+func localizeKey(h func() hash.Hash, ku, engineID []byte) []byte {
+	hh := h()
+	hh.Write(ku)
+	hh.Write(engineID)
+	hh.Write(ku)
+
+	return hh.Sum(nil)
+}
+
+// localKeys returns the auth and priv keys localized to the engine ID.
+func (u *USM) localKeys(engineID []byte) *usmKeys {
+	u.initOnce.Do(func() {
+		u.authKu = passwordToKey(u.hash(), u.AuthPassword)
+		if u.PrivProtocol != PrivNone {
+			u.privKu = passwordToKey(u.hash(), u.PrivPassword)
+		}
+	})
+
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	if k, ok := u.keys[string(engineID)]; ok {
+		return k
+	}
+
+	k := &usmKeys{auth: localizeKey(u.hash(), u.authKu, engineID)}
+	if u.privKu != nil {
+		k.priv = localizeKey(u.hash(), u.privKu, engineID)
+	}
+	if u.keys == nil || len(u.keys) >= maxEngines {
+		u.keys = make(map[string]*usmKeys)
+	}
+	u.keys[string(engineID)] = k
+
+	return k
+}
+
+// checkLevel ensures the message security level (msgFlags) matches the configured credentials.
+func (u *USM) checkLevel(flags byte) error {
+	auth, priv := flags&0x01 != 0, flags&0x02 != 0
+	if u == nil || u.AuthProtocol == AuthNone {
+		if auth || priv {
+			return ErrUnsupportedSecurity
+		}
+		return nil
+	}
+	if !auth {
+		return ErrAuthRequired
+	}
+	if priv && u.PrivProtocol == PrivNone {
+		return ErrUnsupportedSecurity
+	}
+	if !priv && u.PrivProtocol != PrivNone {
+		return ErrPrivRequired
+	}
+
+	return nil
+}
+
+// authenticate verifies the HMAC of the whole message. authParams must be a sub-slice of msg.
+func (u *USM) authenticate(msg, authParams []byte, keys *usmKeys) error {
+	if len(authParams) != authParamLen {
+		return ErrWrongDigest
+	}
+
+	// authParams is a sub-slice of msg, so the difference in capacity is its offset
+	off := cap(msg) - cap(authParams)
+	if off < 0 || off+authParamLen > len(msg) {
+		return ErrWrongDigest
+	}
+
+	buf := make([]byte, len(msg))
+	copy(buf, msg)
+	for i := off; i < off+authParamLen; i++ {
+		buf[i] = 0
+	}
+
+	mac := hmac.New(u.hash(), keys.auth)
+	mac.Write(buf)
+	if subtle.ConstantTimeCompare(mac.Sum(nil)[:authParamLen], authParams) != 1 {
+		return ErrWrongDigest
+	}
+
+	return nil
+}
+
+// checkTime implements the timeliness check for messages from an authoritative engine
+// (RFC 3414 section 3.2.7 b).
+func (u *USM) checkTime(engineID []byte, boots, engTime int64, now time.Time) error {
+	if boots >= maxEngineBoots {
+		return ErrNotInTimeWindow
+	}
+
+	u.mx.Lock()
+	defer u.mx.Unlock()
+
+	last, ok := u.engines[string(engineID)]
+	if ok {
+		estimated := last.time + int64(now.Sub(last.receivedAt)/time.Second)
+		if boots < last.boots || (boots == last.boots && engTime < estimated-timeWindow) {
+			return ErrNotInTimeWindow
+		}
+		if boots == last.boots && engTime <= last.time {
+			return nil
+		}
+	}
+
+	if u.engines == nil || (!ok && len(u.engines) >= maxEngines) {
+		u.engines = make(map[string]*engineTime)
+	}
+	u.engines[string(engineID)] = &engineTime{boots: boots, time: engTime, receivedAt: now}
+
+	return nil
+}
+
+// decrypt decrypts the encryptedPDU (RFC 3414 section 8.1.1.3 for DES, RFC 3826 section 3.1.4 for AES).
+func (u *USM) decrypt(data, privParams []byte, boots, engTime int64, keys *usmKeys) ([]byte, error) {
+	if len(privParams) != 8 {
+		return nil, ErrDecryptionFailed
+	}
+
+	out := make([]byte, len(data))
+	switch u.PrivProtocol {
+	case PrivDES:
+		if len(data) == 0 || len(data)%des.BlockSize != 0 || len(keys.priv) < 16 {
+			return nil, ErrDecryptionFailed
+		}
+		block, err := des.NewCipher(keys.priv[:8])
+		if err != nil {
+			return nil, err
+		}
+		iv := make([]byte, des.BlockSize)
+		for i := range iv {
+			iv[i] = keys.priv[8+i] ^ privParams[i]
+		}
+		cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	case PrivAES:
+		block, err := aes.NewCipher(keys.priv[:16])
+		if err != nil {
+			return nil, err
+		}
+		iv := make([]byte, 0, aes.BlockSize)
+		iv = binary.BigEndian.AppendUint32(iv, uint32(boots))
+		iv = binary.BigEndian.AppendUint32(iv, uint32(engTime))
+		iv = append(iv, privParams...)
+		cipher.NewCFBDecrypter(block, iv).XORKeyStream(out, data)
+	default:
+		return nil, ErrUnsupportedSecurity
+	}
+
+	// DES output may include padding after the scoped PDU, which is ignored when decoding.
+	if !bytes.HasPrefix(out, []byte{tagSequence}) {
+		return nil, ErrDecryptionFailed
+	}
+
+	return out, nil
+}
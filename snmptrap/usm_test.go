@@ -0,0 +1,158 @@
+package snmptrap
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUSM_LocalizeKey(t *testing.T) {
+	// RFC 3414 appendix A.3
+	engineID, err := hex.DecodeString("000000000000000000000002")
+	require.NoError(t, err)
+
+	md5 := &USM{AuthProtocol: AuthMD5}
+	key := localizeKey(md5.hash(), passwordToKey(md5.hash(), "maplesyrup"), engineID)
+	assert.Equal(t, "526f5eed9fcce26f8964c2930787d82b", hex.EncodeToString(key))
+
+	sha := &USM{AuthProtocol: AuthSHA}
+	key = localizeKey(sha.hash(), passwordToKey(sha.hash(), "maplesyrup"), engineID)
+	assert.Equal(t, "6695febc9288e36282235fc7151f128497b38f3f", hex.EncodeToString(key))
+}
+
+// buildV3 builds an SNMPv3 trap, authenticated and encrypted according to flags using u's credentials.
+func buildV3(t *testing.T, u *USM, flags byte, boots, engTime int64) []byte {
+	t.Helper()
+
+	engineID := []byte("remote-engine")
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	placeholder := bytes.Repeat([]byte{0xee}, authParamLen)
+
+	varbinds := seq(seq(encOID(t, OIDSnmpTrapOID), encOID(t, "1.3.6.1.6.3.1.1.5.3")))
+	pdu := encodeTLV(tagTrapV2, append(append(append(encodeInt(1), encodeInt(0)...), encodeInt(0)...), varbinds...))
+	scoped := seq(str(string(engineID)), str(""), pdu)
+
+	var keys *usmKeys
+	authParams, privParams := []byte{}, []byte{}
+	if flags&0x01 != 0 {
+		keys = u.localKeys(engineID)
+		authParams = placeholder
+	}
+	if flags&0x02 != 0 {
+		privParams = salt
+		var enc []byte
+		switch u.PrivProtocol {
+		case PrivDES:
+			for len(scoped)%des.BlockSize != 0 {
+				scoped = append(scoped, 0)
+			}
+			block, err := des.NewCipher(keys.priv[:8])
+			require.NoError(t, err)
+			iv := make([]byte, des.BlockSize)
+			for i := range iv {
+				iv[i] = keys.priv[8+i] ^ salt[i]
+			}
+			enc = make([]byte, len(scoped))
+			cipher.NewCBCEncrypter(block, iv).CryptBlocks(enc, scoped)
+		case PrivAES:
+			block, err := aes.NewCipher(keys.priv[:16])
+			require.NoError(t, err)
+			iv := binary.BigEndian.AppendUint32(nil, uint32(boots))
+			iv = binary.BigEndian.AppendUint32(iv, uint32(engTime))
+			iv = append(iv, salt...)
+			enc = make([]byte, len(scoped))
+			cipher.NewCFBEncrypter(block, iv).XORKeyStream(enc, scoped)
+		}
+		scoped = str(string(enc))
+	}
+
+	header := seq(encodeInt(1), encodeInt(65507), str(string([]byte{flags})), encodeInt(3))
+	usm := seq(str(string(engineID)), encodeInt(boots), encodeInt(engTime), str("00000000-0000-0000-0000-000000000002"), str(string(authParams)), str(string(privParams)))
+	msg := seq(encodeInt(3), header, encodeTLV(tagOctetString, usm), scoped)
+
+	if flags&0x01 != 0 {
+		off := bytes.Index(msg, placeholder)
+		require.True(t, off > 0)
+		copy(msg[off:], make([]byte, authParamLen))
+		mac := hmac.New(u.hash(), keys.auth)
+		mac.Write(msg)
+		copy(msg[off:], mac.Sum(nil)[:authParamLen])
+	}
+
+	return msg
+}
+
+func TestUSM_ParseTrap(t *testing.T) {
+	check := func(name string, auth AuthProtocol, priv PrivProtocol) {
+		t.Run(name, func(t *testing.T) {
+			u := &USM{AuthProtocol: auth, AuthPassword: "authpass123", PrivProtocol: priv, PrivPassword: "privpass123"}
+			require.NoError(t, u.Validate())
+
+			flags := byte(0x01)
+			if priv != PrivNone {
+				flags |= 0x02
+			}
+
+			trap, err := u.ParseTrap(buildV3(t, u, flags, 5, 1000))
+			require.NoError(t, err)
+			assert.Equal(t, VersionV3, trap.Version)
+			assert.Equal(t, "00000000-0000-0000-0000-000000000002", trap.Community)
+			assert.Equal(t, "1.3.6.1.6.3.1.1.5.3", trap.TrapOID)
+
+			// wrong password
+			other := &USM{AuthProtocol: auth, AuthPassword: "wrongpass123", PrivProtocol: priv, PrivPassword: "privpass123"}
+			_, err = u.ParseTrap(buildV3(t, other, flags, 5, 1000))
+			assert.ErrorIs(t, err, ErrWrongDigest)
+
+			// tampered
+			msg := buildV3(t, u, flags, 5, 1000)
+			msg[len(msg)-1] ^= 0xff
+			_, err = u.ParseTrap(msg)
+			assert.Error(t, err)
+
+			// lower security levels are rejected
+			_, err = u.ParseTrap(buildV3(t, u, 0, 5, 1000))
+			assert.ErrorIs(t, err, ErrAuthRequired)
+			if priv != PrivNone {
+				_, err = u.ParseTrap(buildV3(t, u, 0x01, 5, 1000))
+				assert.ErrorIs(t, err, ErrPrivRequired)
+			}
+		})
+	}
+
+	check("authNoPriv/MD5", AuthMD5, PrivNone)
+	check("authNoPriv/SHA", AuthSHA, PrivNone)
+	check("authPriv/MD5-DES", AuthMD5, PrivDES)
+	check("authPriv/SHA-DES", AuthSHA, PrivDES)
+	check("authPriv/MD5-AES", AuthMD5, PrivAES)
+	check("authPriv/SHA-AES", AuthSHA, PrivAES)
+
+	_, err := ParseTrap(buildV3(t, &USM{AuthProtocol: AuthSHA, AuthPassword: "authpass123"}, 0x01, 1, 1))
+	assert.ErrorIs(t, err, ErrUnsupportedSecurity, "no credentials configured")
+}
+
+func TestUSM_CheckTime(t *testing.T) {
+	u := &USM{AuthProtocol: AuthSHA, AuthPassword: "authpass123"}
+	id := []byte("engine")
+	now := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, u.checkTime(id, 2, 1000, now))
+	assert.NoError(t, u.checkTime(id, 2, 1000, now), "same message time")
+	assert.NoError(t, u.checkTime(id, 2, 900, now), "within window")
+	assert.ErrorIs(t, u.checkTime(id, 2, 800, now), ErrNotInTimeWindow, "outside window")
+	assert.ErrorIs(t, u.checkTime(id, 1, 5000, now), ErrNotInTimeWindow, "old boots")
+	assert.ErrorIs(t, u.checkTime(id, 2, 1000, now.Add(10*time.Minute)), ErrNotInTimeWindow, "replayed later")
+
+	assert.NoError(t, u.checkTime(id, 3, 1, now), "reboot")
+	assert.ErrorIs(t, u.checkTime(id, 2, 2000, now), ErrNotInTimeWindow, "boots before reboot")
+	assert.ErrorIs(t, u.checkTime(id, maxEngineBoots, 1, now), ErrNotInTimeWindow)
+}
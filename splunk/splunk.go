@@ -0,0 +1,184 @@
+package splunk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// DefaultSummaryTemplate is used to generate the alert summary if no `summary` query parameter is provided.
+const DefaultSummaryTemplate = `{{.SearchName}}{{if .Host}} on {{.Host}}{{end}}{{if .Source}} ({{.Source}}){{end}}`
+
+// maxTemplateLength limits the size of user-provided summary templates.
+const maxTemplateLength = 255
+
+type post struct {
+	SID         string            `json:"sid"`
+	SearchName  string            `json:"search_name"`
+	App         string            `json:"app"`
+	Owner       string            `json:"owner"`
+	ResultsLink string            `json:"results_link"`
+	Result      map[string]string `json:"result"`
+}
+
+// templateData is available to summary templates.
+type templateData struct {
+	SearchName string
+	Host       string
+	Source     string
+	SourceType string
+	App        string
+	Owner      string
+
+	// Result contains all fields from the first result row.
+	Result map[string]string
+}
+
+func (p post) templateData() templateData {
+	return templateData{
+		SearchName: p.SearchName,
+		Host:       p.Result["host"],
+		Source:     p.Result["source"],
+		SourceType: p.Result["sourcetype"],
+		App:        p.App,
+		Owner:      p.Owner,
+		Result:     p.Result,
+	}
+}
+
+func parseSummaryTemplate(s string) (*template.Template, error) {
+	if s == "" {
+		s = DefaultSummaryTemplate
+	}
+	if len(s) > maxTemplateLength {
+		return nil, errors.Errorf("summary template cannot exceed %d characters", maxTemplateLength)
+	}
+
+	return template.New("summary").Option("missingkey=zero").Parse(s)
+}
+
+func (p post) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("ResultsLink", p.ResultsLink) == nil {
+		fmt.Fprintf(&s, "[View Results](%s)\n\n", p.ResultsLink)
+	}
+	if p.App != "" {
+		fmt.Fprintf(&s, "App: %s\n\n", p.App)
+	}
+
+	keys := make([]string, 0, len(p.Result))
+	for k := range p.Result {
+		if k == "_raw" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		s.WriteString("| Field | Value |\n| ----- | ----- |\n")
+		for _, k := range keys {
+			v := strings.ReplaceAll(strings.ReplaceAll(p.Result[k], "|", "\\|"), "\n", " ")
+			fmt.Fprintf(&s, "| %s | %s |\n", k, v)
+		}
+		s.WriteString("\n")
+	}
+	if raw := p.Result["_raw"]; raw != "" {
+		fmt.Fprintf(&s, "```\n%s\n```\n", raw)
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// SplunkToEventsAPI handles Splunk webhook alert actions.
+//
+// The optional `summary` query parameter is a Go template used to build the alert summary
+// (see DefaultSummaryTemplate). Alerts are de-duplicated on the rendered summary, unless
+// the `dedup` query parameter is set.
+func SplunkToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		tmpl, err := parseSummaryTemplate(r.FormValue("summary"))
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from splunk: invalid summary template: %v", err)
+			return
+		}
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from splunk: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"SID":        p.SID,
+			"SearchName": p.SearchName,
+		})
+
+		var buf strings.Builder
+		err = tmpl.Execute(&buf, p.templateData())
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from splunk: render summary: %v", err)
+			return
+		}
+		summary := strings.TrimSpace(buf.String())
+		if summary == "" {
+			summary = "Splunk alert " + p.SID
+		}
+
+		dedup := r.FormValue("dedup")
+		if dedup == "" {
+			dedup = summary
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(p.details(), alert.MaxDetailsLength),
+			Status:    alert.StatusTriggered,
+			Source:    alert.SourceSplunk,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(dedup),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for splunk")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package splunk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryTemplate(t *testing.T) {
+	p := post{
+		SearchName: "High Error Rate",
+		Result:     map[string]string{"host": "web-01", "source": "/var/log/app.log", "status": "500"},
+	}
+
+	render := func(s string) string {
+		t.Helper()
+		tmpl, err := parseSummaryTemplate(s)
+		require.NoError(t, err)
+		var buf strings.Builder
+		require.NoError(t, tmpl.Execute(&buf, p.templateData()))
+		return buf.String()
+	}
+
+	assert.Equal(t, "High Error Rate on web-01 (/var/log/app.log)", render(""))
+	assert.Equal(t, "web-01: 500", render(`{{.Host}}: {{index .Result "status"}}`))
+
+	_, err := parseSummaryTemplate(strings.Repeat("x", maxTemplateLength+1))
+	assert.Error(t, err)
+}
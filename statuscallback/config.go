@@ -0,0 +1,44 @@
+package statuscallback
+
+import (
+	"strings"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// DefaultUnansweredMinutes is used for UnansweredMinutes if none is configured.
+const DefaultUnansweredMinutes = 15
+
+// ServiceConfig controls where status callbacks are sent for alert notifications on a service.
+type ServiceConfig struct {
+	ServiceID string
+
+	// URL receives a signed POST request for each status event.
+	URL string
+
+	// Secret is used to sign each request. It is generated when the config is first set.
+	Secret string
+
+	// UnansweredMinutes is the time after a notification is sent that an unacknowledged
+	// alert is reported as unanswered.
+	UnansweredMinutes int
+}
+
+// Normalize will validate and normalize the ServiceConfig, applying defaults where empty.
+func (cfg ServiceConfig) Normalize() (*ServiceConfig, error) {
+	cfg.URL = strings.TrimSpace(cfg.URL)
+	if cfg.UnansweredMinutes == 0 {
+		cfg.UnansweredMinutes = DefaultUnansweredMinutes
+	}
+
+	err := validate.Many(
+		validate.UUID("ServiceID", cfg.ServiceID),
+		validate.AbsoluteURL("URL", cfg.URL),
+		validate.Range("UnansweredMinutes", cfg.UnansweredMinutes, 1, 1440),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,36 @@
+package statuscallback
+
+import "time"
+
+// Event is the type of status change reported by a callback.
+type Event string
+
+// Recognized events.
+const (
+	// EventDelivered indicates the provider confirmed delivery of the notification.
+	EventDelivered Event = "delivered"
+
+	// EventFailed indicates the notification could not be delivered.
+	EventFailed Event = "failed"
+
+	// EventUnanswered indicates the alert was not acknowledged within the configured time
+	// after the notification was sent.
+	EventUnanswered Event = "unanswered"
+)
+
+// Payload is the JSON body of a status callback request.
+type Payload struct {
+	Event     Event
+	Time      time.Time
+	MessageID string
+	AlertID   int
+	AlertURL  string
+	ServiceID string
+	UserID    string
+
+	// DestType is the contact method or channel type the notification was sent to (e.g., SMS).
+	DestType string
+
+	// Details contains the status details from the provider, if any.
+	Details string
+}
@@ -0,0 +1,56 @@
+package statuscallback
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers set on each status callback request.
+const (
+	HeaderEvent     = "X-GoAlert-Event"
+	HeaderTimestamp = "X-GoAlert-Timestamp"
+	HeaderSignature = "X-GoAlert-Signature"
+)
+
+// signatureVersion prefixes the signature so the scheme can be changed later.
+const signatureVersion = "v1="
+
+// NewSecret returns a new random signing secret.
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Sign returns the signature for a request body sent at the given time.
+//
+// The signature is the hex-encoded HMAC-SHA256 of the unix timestamp, a period,
+// and the body, using the secret as the key.
+func Sign(secret string, t time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(t.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature returns true if signature is valid for the timestamp header value and body.
+// Receivers should also reject timestamps that are too old to prevent replay.
+func ValidSignature(secret, timestamp string, body []byte, signature string) bool {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, time.Unix(sec, 0), body)), []byte(signature))
+}
@@ -0,0 +1,48 @@
+package statuscallback
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	body := []byte(`{"Event":"delivered"}`)
+
+	sig := Sign("secret", ts, body)
+	assert.Equal(t, "v1=", sig[:3])
+	assert.Equal(t, sig, Sign("secret", ts, body), "signature should be deterministic")
+
+	tsStr := strconv.FormatInt(ts.Unix(), 10)
+	assert.True(t, ValidSignature("secret", tsStr, body, sig))
+	assert.False(t, ValidSignature("other", tsStr, body, sig), "wrong secret")
+	assert.False(t, ValidSignature("secret", "1700000001", body, sig), "wrong timestamp")
+	assert.False(t, ValidSignature("secret", tsStr, []byte(`{"Event":"failed"}`), sig), "modified body")
+	assert.False(t, ValidSignature("secret", "bad", body, sig), "invalid timestamp")
+}
+
+func TestServiceConfig_Normalize(t *testing.T) {
+	cfg, err := ServiceConfig{
+		ServiceID: "00000000-0000-0000-0000-000000000001",
+		URL:       " https://example.com/callback ",
+	}.Normalize()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/callback", cfg.URL)
+	assert.Equal(t, DefaultUnansweredMinutes, cfg.UnansweredMinutes)
+
+	_, err = ServiceConfig{
+		ServiceID:         "00000000-0000-0000-0000-000000000001",
+		URL:               "https://example.com/callback",
+		UnansweredMinutes: 2000,
+	}.Normalize()
+	assert.Error(t, err)
+
+	_, err = ServiceConfig{
+		ServiceID: "00000000-0000-0000-0000-000000000001",
+		URL:       "/callback",
+	}.Normalize()
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package statuscallback
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages status callback configs for services.
+type Store struct {
+	db *sql.DB
+
+	findConfig   *sql.Stmt
+	setConfig    *sql.Stmt
+	deleteConfig *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		findConfig: p.P(`
+			select url, secret, unanswered_minutes
+			from service_status_callbacks
+			where service_id = $1
+		`),
+		setConfig: p.P(`
+			insert into service_status_callbacks (service_id, url, secret, unanswered_minutes)
+			values ($1, $2, $3, $4)
+			on conflict (service_id) do update
+			set
+				url = $2,
+				secret = case when $5 then $3 else service_status_callbacks.secret end,
+				unanswered_minutes = $4
+		`),
+		deleteConfig: p.P(`delete from service_status_callbacks where service_id = $1`),
+	}, p.Err
+}
+
+// ServiceConfig returns the status callback config for the service, or nil if none is set.
+func (s *Store) ServiceConfig(ctx context.Context, serviceID string) (*ServiceConfig, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := ServiceConfig{ServiceID: serviceID}
+	err = s.findConfig.QueryRowContext(ctx, serviceID).Scan(&cfg.URL, &cfg.Secret, &cfg.UnansweredMinutes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// SetServiceConfigTx will create or replace the status callback config for a service.
+//
+// A new secret is generated if the config is new or regenerateSecret is true; otherwise the
+// existing secret is kept. Only notifications sent after the config is first set are reported.
+func (s *Store) SetServiceConfigTx(ctx context.Context, tx *sql.Tx, cfg ServiceConfig, regenerateSecret bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := cfg.Normalize()
+	if err != nil {
+		return err
+	}
+	if !config.FromContext(ctx).ValidWebhookURL(n.URL) {
+		return validation.NewFieldError("URL", "URL not allowed by administrator")
+	}
+
+	secret, err := NewSecret()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setConfig).ExecContext(ctx, n.ServiceID, n.URL, secret, n.UnansweredMinutes, regenerateSecret)
+	return err
+}
+
+// DeleteServiceConfigTx will remove the status callback config for a service. Pending callbacks are not sent.
+func (s *Store) DeleteServiceConfigTx(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.deleteConfig).ExecContext(ctx, serviceID)
+	return err
+}
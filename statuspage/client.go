@@ -0,0 +1,129 @@
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// DefaultBaseURL is the Statuspage API base URL.
+const DefaultBaseURL = "https://api.statuspage.io/v1"
+
+// Client makes requests to the Statuspage API using the credentials from the current config.
+type Client struct {
+	HTTP *http.Client
+
+	// BaseURL overrides DefaultBaseURL, if set.
+	BaseURL string
+}
+
+// Component is a Statuspage component.
+type Component struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Status ComponentStatus `json:"status"`
+}
+
+// APIError is returned when Statuspage responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *APIError) Error() string {
+	if err.Message == "" {
+		return fmt.Sprintf("statuspage: unexpected status %d", err.StatusCode)
+	}
+	return fmt.Sprintf("statuspage: unexpected status %d: %s", err.StatusCode, err.Message)
+}
+
+// Temporary returns true for rate-limit and server errors.
+func (err *APIError) Temporary() bool {
+	return err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= 500
+}
+
+func (c *Client) do(ctx context.Context, method, componentID string, body interface{}) (*Component, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Statuspage.Enable {
+		return nil, fmt.Errorf("statuspage: disabled")
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	u := base + "/pages/" + url.PathEscape(cfg.Statuspage.PageID) + "/components/" + url.PathEscape(componentID)
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "OAuth "+cfg.Statuspage.APIKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errResp struct {
+			Error   string
+			Message string
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&errResp)
+		msg := errResp.Message
+		if msg == "" {
+			msg = errResp.Error
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	var comp Component
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&comp)
+	if err != nil {
+		return nil, fmt.Errorf("statuspage: decode response: %w", err)
+	}
+
+	return &comp, nil
+}
+
+// Component returns the current state of a component.
+func (c *Client) Component(ctx context.Context, componentID string) (*Component, error) {
+	return c.do(ctx, "GET", componentID, nil)
+}
+
+// SetComponentStatus updates the status of a component.
+func (c *Client) SetComponentStatus(ctx context.Context, componentID string, status ComponentStatus) error {
+	_, err := c.do(ctx, "PATCH", componentID, map[string]interface{}{
+		"component": map[string]ComponentStatus{"status": status},
+	})
+	return err
+}
@@ -0,0 +1,74 @@
+package statuspage
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ComponentStatus is the status of a Statuspage component.
+type ComponentStatus string
+
+// Component statuses set by GoAlert.
+const (
+	StatusOperational         ComponentStatus = "operational"
+	StatusDegradedPerformance ComponentStatus = "degraded_performance"
+	StatusPartialOutage       ComponentStatus = "partial_outage"
+	StatusMajorOutage         ComponentStatus = "major_outage"
+)
+
+var componentIDRx = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// ServiceComponent maps a service to a Statuspage component.
+//
+// The component status is set from the number of open (unclosed) alerts on the service
+// using the configured thresholds. A threshold of zero disables that status.
+type ServiceComponent struct {
+	ServiceID   string
+	ComponentID string
+
+	DegradedAt      int
+	PartialOutageAt int
+	MajorOutageAt   int
+}
+
+// Normalize will validate and normalize the ServiceComponent.
+func (c ServiceComponent) Normalize() (*ServiceComponent, error) {
+	c.ComponentID = strings.TrimSpace(c.ComponentID)
+
+	err := validate.Many(
+		validate.UUID("ServiceID", c.ServiceID),
+		validate.RequiredText("ComponentID", c.ComponentID, 1, 64),
+		validate.Range("DegradedAt", c.DegradedAt, 0, 10000),
+		validate.Range("PartialOutageAt", c.PartialOutageAt, 0, 10000),
+		validate.Range("MajorOutageAt", c.MajorOutageAt, 0, 10000),
+	)
+	if err == nil && !componentIDRx.MatchString(c.ComponentID) {
+		err = validation.NewFieldError("ComponentID", "must contain only lowercase letters and numbers")
+	}
+	if err == nil && c.DegradedAt == 0 && c.PartialOutageAt == 0 && c.MajorOutageAt == 0 {
+		err = validation.NewFieldError("DegradedAt", "at least one threshold must be set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// DesiredStatus returns the component status for the given number of open alerts. The most
+// severe status whose threshold is reached is used.
+func (c ServiceComponent) DesiredStatus(openAlerts int) ComponentStatus {
+	switch {
+	case c.MajorOutageAt > 0 && openAlerts >= c.MajorOutageAt:
+		return StatusMajorOutage
+	case c.PartialOutageAt > 0 && openAlerts >= c.PartialOutageAt:
+		return StatusPartialOutage
+	case c.DegradedAt > 0 && openAlerts >= c.DegradedAt:
+		return StatusDegradedPerformance
+	}
+
+	return StatusOperational
+}
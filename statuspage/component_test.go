@@ -0,0 +1,39 @@
+package statuspage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceComponent_DesiredStatus(t *testing.T) {
+	c := ServiceComponent{DegradedAt: 1, PartialOutageAt: 3, MajorOutageAt: 5}
+	assert.Equal(t, StatusOperational, c.DesiredStatus(0))
+	assert.Equal(t, StatusDegradedPerformance, c.DesiredStatus(1))
+	assert.Equal(t, StatusDegradedPerformance, c.DesiredStatus(2))
+	assert.Equal(t, StatusPartialOutage, c.DesiredStatus(3))
+	assert.Equal(t, StatusMajorOutage, c.DesiredStatus(10))
+
+	// disabled thresholds are skipped
+	c = ServiceComponent{MajorOutageAt: 2}
+	assert.Equal(t, StatusOperational, c.DesiredStatus(1))
+	assert.Equal(t, StatusMajorOutage, c.DesiredStatus(2))
+}
+
+func TestServiceComponent_Normalize(t *testing.T) {
+	valid := ServiceComponent{ServiceID: "a1b2c3d4-0000-0000-0000-000000000000", ComponentID: " abc123 ", DegradedAt: 1}
+	n, err := valid.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", n.ComponentID)
+
+	bad := valid
+	bad.ComponentID = "ABC-123"
+	_, err = bad.Normalize()
+	assert.Error(t, err)
+
+	bad = valid
+	bad.DegradedAt = 0
+	_, err = bad.Normalize()
+	assert.Error(t, err, "no thresholds")
+}
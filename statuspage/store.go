@@ -0,0 +1,120 @@
+package statuspage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ComponentState is the sync state of a service component mapping.
+type ComponentState struct {
+	ServiceComponent
+
+	// LastStatus is the status most recently set (or confirmed) by GoAlert.
+	LastStatus ComponentStatus
+
+	// Overridden is true if the component status was changed outside of GoAlert. Updates are
+	// paused until the component is set back to LastStatus or to the status GoAlert would set.
+	Overridden bool
+
+	LastError string
+}
+
+// Store manages service to Statuspage component mappings.
+type Store struct {
+	db *sql.DB
+
+	find   *sql.Stmt
+	set    *sql.Stmt
+	delete *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		find: p.P(`
+			select component_id, degraded_at, partial_outage_at, major_outage_at, last_status, overridden, last_error
+			from service_statuspage_components
+			where service_id = $1
+		`),
+
+		// changing the component resets the sync state
+		set: p.P(`
+			insert into service_statuspage_components (service_id, component_id, degraded_at, partial_outage_at, major_outage_at)
+			values ($1, $2, $3, $4, $5)
+			on conflict (service_id) do update
+			set
+				component_id = $2,
+				degraded_at = $3,
+				partial_outage_at = $4,
+				major_outage_at = $5,
+				last_status = case when service_statuspage_components.component_id = $2 then service_statuspage_components.last_status else '' end,
+				overridden = service_statuspage_components.overridden and service_statuspage_components.component_id = $2,
+				last_check = null,
+				last_error = ''
+		`),
+		delete: p.P(`delete from service_statuspage_components where service_id = $1`),
+	}, p.Err
+}
+
+// ServiceComponent returns the component mapping and state for the service, or nil if none is set.
+func (s *Store) ServiceComponent(ctx context.Context, serviceID string) (*ComponentState, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	st := ComponentState{ServiceComponent: ServiceComponent{ServiceID: serviceID}}
+	err = s.find.QueryRowContext(ctx, serviceID).Scan(&st.ComponentID, &st.DegradedAt, &st.PartialOutageAt, &st.MajorOutageAt, &st.LastStatus, &st.Overridden, &st.LastError)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &st, nil
+}
+
+// SetServiceComponentTx will create or replace the component mapping for a service.
+func (s *Store) SetServiceComponentTx(ctx context.Context, tx *sql.Tx, c ServiceComponent) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.set).ExecContext(ctx, n.ServiceID, n.ComponentID, n.DegradedAt, n.PartialOutageAt, n.MajorOutageAt)
+	return err
+}
+
+// DeleteServiceComponentTx will remove the component mapping for a service. The component status is left as-is.
+func (s *Store) DeleteServiceComponentTx(ctx context.Context, tx *sql.Tx, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, serviceID)
+	return err
+}
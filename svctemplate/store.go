@@ -0,0 +1,170 @@
+package svctemplate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages service templates.
+type Store struct {
+	insert  *sql.Stmt
+	findOne *sql.Stmt
+	findAll *sql.Stmt
+	owners  *sql.Stmt
+	delete  *sql.Stmt
+}
+
+// NewStore creates a new Store and prepares all necessary SQL statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		insert: p.P(`
+			insert into service_templates (id, name, description, params, data, created_by)
+			values ($1, $2, $3, $4, $5, $6)
+		`),
+		findOne: p.P(`select id, name, description, params, data from service_templates where id = $1`),
+		findAll: p.P(`select id, name, description, params, data from service_templates order by lower(name)`),
+		owners:  p.P(`select id, created_by from service_templates where id = any($1) for update`),
+		delete:  p.P(`delete from service_templates where id = any($1)`),
+	}, p.Err
+}
+
+// CreateTx will save a new Template.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, t *Template) (*Template, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.New().String()
+
+	var createdBy sql.NullString
+	if id := permission.UserID(ctx); id != "" {
+		createdBy = sql.NullString{String: id, Valid: true}
+	}
+
+	_, err = tx.StmtContext(ctx, s.insert).ExecContext(ctx, n.ID, n.Name, n.Description, pq.StringArray(n.Params), []byte(n.Data), createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func scanFrom(scan func(...interface{}) error) (*Template, error) {
+	var t Template
+	var params pq.StringArray
+	var data []byte
+	err := scan(&t.ID, &t.Name, &t.Description, &params, &data)
+	if err != nil {
+		return nil, err
+	}
+	t.Params = params
+	t.Data = data
+
+	return &t, nil
+}
+
+// FindOne will return the Template with the given ID, or nil if it does not exist.
+func (s *Store) FindOne(ctx context.Context, id string) (*Template, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("TemplateID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := scanFrom(s.findOne.QueryRowContext(ctx, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// FindAll will return all saved templates, sorted by name.
+func (s *Store) FindAll(ctx context.Context) ([]Template, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Template
+	for rows.Next() {
+		t, err := scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *t)
+	}
+
+	return result, rows.Err()
+}
+
+// DeleteManyTx will delete the templates with the given IDs. Templates can only be deleted by an admin
+// or the user that created them.
+func (s *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.ManyUUID("TemplateID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	if !permission.Admin(ctx) {
+		rows, err := tx.StmtContext(ctx, s.owners).QueryContext(ctx, sqlutil.UUIDArray(ids))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id string
+			var createdBy sql.NullString
+			err = rows.Scan(&id, &createdBy)
+			if err != nil {
+				return err
+			}
+			if !createdBy.Valid {
+				return permission.NewAccessDenied("only an admin can delete template " + id)
+			}
+			err = permission.LimitCheckAny(ctx, permission.MatchUser(createdBy.String))
+			if err != nil {
+				return err
+			}
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
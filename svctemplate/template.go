@@ -0,0 +1,159 @@
+package svctemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// A Template is a saved, parameterized, service configuration that can be used to create new services.
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+
+	// Params is the list of parameter names that must be provided when instantiating the template.
+	Params []string
+
+	// Data is the JSON-encoded service configuration. Names and descriptions may
+	// contain parameter references in the form `{{.ParamName}}`.
+	Data json.RawMessage
+}
+
+var paramNameRx = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// templateKeys are the object keys that may contain parameter references.
+var templateKeys = map[string]bool{
+	"name":        true,
+	"description": true,
+	"value":       true,
+}
+
+// Normalize will validate and normalize the Template, returning a copy.
+func (t Template) Normalize() (*Template, error) {
+	err := validate.Many(
+		validate.IDName("Name", t.Name),
+		validate.Text("Description", t.Description, 1, 255),
+		validate.Range("Params", len(t.Params), 0, 10),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range t.Params {
+		if !paramNameRx.MatchString(p) {
+			return nil, validation.NewFieldError(fmt.Sprintf("Params[%d]", i), "must start with a letter and contain only letters, digits, and underscores")
+		}
+	}
+	if !json.Valid(t.Data) {
+		return nil, validation.NewFieldError("Data", "must be valid JSON")
+	}
+
+	t.Params = append([]string(nil), t.Params...)
+	sort.Strings(t.Params)
+
+	return &t, nil
+}
+
+// Parameterize will replace all occurrences of each value in params with a reference to the
+// param name (e.g., "Payments API" with Team=Payments becomes "{{.Team}} API").
+func Parameterize(data json.RawMessage, params map[string]string) (json.RawMessage, error) {
+	names := make([]string, 0, len(params))
+	for name, val := range params {
+		if !paramNameRx.MatchString(name) {
+			return nil, validation.NewFieldError("Params", fmt.Sprintf("invalid name '%s'", name))
+		}
+		if val == "" {
+			return nil, validation.NewFieldError("Params", fmt.Sprintf("missing value for '%s'", name))
+		}
+		names = append(names, name)
+	}
+	// replace longest values first so that overlapping values are handled correctly
+	sort.Slice(names, func(i, j int) bool { return len(params[names[i]]) > len(params[names[j]]) })
+
+	return walkStrings(data, func(s string) (string, error) {
+		s = strings.ReplaceAll(s, "{{", `{{"{{"}}`)
+		for _, name := range names {
+			s = strings.ReplaceAll(s, params[name], "{{."+name+"}}")
+		}
+		return s, nil
+	})
+}
+
+// Render will return the template Data with all parameter references replaced by the provided values.
+func (t Template) Render(params map[string]string) (json.RawMessage, error) {
+	for _, name := range t.Params {
+		if params[name] == "" {
+			return nil, validation.NewFieldError("Params", fmt.Sprintf("missing value for '%s'", name))
+		}
+	}
+
+	return walkStrings(t.Data, func(s string) (string, error) {
+		if !strings.Contains(s, "{{") {
+			return s, nil
+		}
+
+		tmpl, err := template.New("").Option("missingkey=error").Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("parse template: %w", err)
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, params)
+		if err != nil {
+			return "", fmt.Errorf("render template: %w", err)
+		}
+
+		return buf.String(), nil
+	})
+}
+
+// walkStrings will call fn for all string values of templateKeys in data, replacing them with the result.
+func walkStrings(data json.RawMessage, fn func(string) (string, error)) (json.RawMessage, error) {
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var walk func(interface{}) (interface{}, error)
+	walk = func(v interface{}) (interface{}, error) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, val := range v {
+				if s, ok := val.(string); ok && templateKeys[key] {
+					s, err = fn(s)
+					if err != nil {
+						return nil, err
+					}
+					v[key] = s
+					continue
+				}
+				v[key], err = walk(val)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case []interface{}:
+			for i, val := range v {
+				v[i], err = walk(val)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return v, nil
+	}
+
+	v, err = walk(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
@@ -0,0 +1,25 @@
+package svctemplate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterize(t *testing.T) {
+	data := json.RawMessage(`{"name":"Payments API","description":"Owned by {{team}}","labels":[{"key":"team","value":"Payments"}],"escalationPolicyID":"Payments"}`)
+
+	tmplData, err := Parameterize(data, map[string]string{"Team": "Payments", "Svc": "Payments API"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"{{.Svc}}","description":"Owned by {{\"{{\"}}team}}","labels":[{"key":"team","value":"{{.Team}}"}],"escalationPolicyID":"Payments"}`, string(tmplData))
+
+	tmpl := Template{Params: []string{"Svc", "Team"}, Data: tmplData}
+	out, err := tmpl.Render(map[string]string{"Team": "Billing", "Svc": "Billing DB"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Billing DB","description":"Owned by {{team}}","labels":[{"key":"team","value":"Billing"}],"escalationPolicyID":"Payments"}`, string(out))
+
+	_, err = tmpl.Render(map[string]string{"Team": "Billing"})
+	assert.Error(t, err, "missing param")
+}
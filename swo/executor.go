@@ -64,6 +64,21 @@ func (e *Executor) Sync(ctx context.Context) error {
 		return err
 	}
 
+	if e.mgr.LogicalSubscriber {
+		err = rep.CheckSubscriber(ctx)
+		if err != nil {
+			return fmt.Errorf("validate subscriber: %w", err)
+		}
+
+		err = rep.SubscriberSync(ctx)
+		if err != nil {
+			return fmt.Errorf("subscriber sync: %w", err)
+		}
+
+		e.rep = rep
+		return nil
+	}
+
 	err = rep.ResetChangeTracking(ctx)
 	if err != nil {
 		return fmt.Errorf("reset: %w", err)
@@ -102,6 +117,20 @@ func (e *Executor) Exec(ctx context.Context) error {
 	rep := e.rep
 	e.rep = nil
 
+	if e.mgr.LogicalSubscriber {
+		err := rep.SubscriberSync(ctx)
+		if err != nil {
+			return fmt.Errorf("subscriber sync (after pause): %w", err)
+		}
+
+		err = rep.SubscriberFinalSync(ctx)
+		if err != nil {
+			return fmt.Errorf("final sync: %w", err)
+		}
+
+		return nil
+	}
+
 	for i := 0; i < 10; i++ {
 		err := rep.LogicalSync(ctx)
 		if err != nil {
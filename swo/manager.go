@@ -57,6 +57,11 @@ type Config struct {
 	OldDBURL, NewDBURL string
 	CanExec            bool
 	Logger             *log.Logger
+
+	// LogicalSubscriber indicates the new DB is a Postgres logical replication subscriber of the old DB
+	// (e.g., running a newer major version). Data is replicated by Postgres instead of being copied
+	// during the switchover.
+	LogicalSubscriber bool
 }
 
 // NewManager will create a new Manager with the given configuration.
@@ -28,7 +28,7 @@ func ScanTables(ctx context.Context, conn *pgx.Conn) ([]Table, error) {
 	tables := make(map[string]*Table)
 	for _, cRow := range columns {
 		switch cRow.ColTableName {
-		case "engine_processing_versions", "gorp_migrations":
+		case "engine_processing_versions", "gorp_migrations", "migrate_backfills":
 			// skip migrate-only tables
 			continue
 		case "switchover_state", "switchover_log", "change_log":
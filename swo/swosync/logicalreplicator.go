@@ -15,6 +15,9 @@ type LogicalReplicator struct {
 	tables   []swoinfo.Table
 	seqNames []string
 
+	// subName and slotName identify the subscription when the destination is a logical replication subscriber.
+	subName, slotName string
+
 	progFn func(ctx context.Context, format string, args ...interface{})
 }
 
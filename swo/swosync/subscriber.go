@@ -0,0 +1,281 @@
+package swosync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/target/goalert/swo/swoinfo"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// maxSubscriberWait is the maximum time to wait, with the world stopped, for the subscriber
+// to catch up during the final sync.
+const maxSubscriberWait = 3 * time.Second
+
+// CheckSubscriber validates that the destination database is a logical replication subscriber of the source
+// database, with an identical schema, that has finished copying all tables.
+//
+// It must be called before SubscriberSync or SubscriberFinalSync.
+func (l *LogicalReplicator) CheckSubscriber(ctx context.Context) error {
+	l.printf(ctx, "validating subscriber...")
+
+	rows, err := l.dstConn.Query(ctx, `
+		select subname, subslotname, subenabled
+		from pg_subscription
+		where subdbid = (select oid from pg_database where datname = current_database())
+	`)
+	if err != nil {
+		return fmt.Errorf("read subscriptions: %w", err)
+	}
+	var names []string
+	var enabled bool
+	for rows.Next() {
+		var name, slot string
+		err = rows.Scan(&name, &slot, &enabled)
+		if err != nil {
+			return fmt.Errorf("read subscriptions: %w", err)
+		}
+		names = append(names, name)
+		l.subName, l.slotName = name, slot
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("read subscriptions: %w", err)
+	}
+	switch {
+	case len(names) == 0:
+		return fmt.Errorf("destination database has no subscription")
+	case len(names) > 1:
+		return fmt.Errorf("destination database has multiple subscriptions (%s), expected one", strings.Join(names, ", "))
+	case !enabled:
+		return fmt.Errorf("subscription %s is disabled", l.subName)
+	}
+
+	var slotExists bool
+	err = l.srcConn.QueryRow(ctx, `select exists(select 1 from pg_replication_slots where slot_name = $1)`, l.slotName).Scan(&slotExists)
+	if err != nil {
+		return fmt.Errorf("read replication slots: %w", err)
+	}
+	if !slotExists {
+		return fmt.Errorf("replication slot %s for subscription %s not found in source database", l.slotName, l.subName)
+	}
+
+	l.tables, err = swoinfo.ScanTables(ctx, l.srcConn)
+	if err != nil {
+		return fmt.Errorf("scan source tables: %w", err)
+	}
+	dstTables, err := swoinfo.ScanTables(ctx, l.dstConn)
+	if err != nil {
+		return fmt.Errorf("scan destination tables: %w", err)
+	}
+	err = compareTables(l.tables, dstTables)
+	if err != nil {
+		return err
+	}
+
+	l.seqNames, err = swoinfo.ScanSequences(ctx, l.srcConn)
+	if err != nil {
+		return fmt.Errorf("scan sequences: %w", err)
+	}
+
+	rows, err = l.dstConn.Query(ctx, `
+		select c.relname, sr.srsubstate
+		from pg_subscription_rel sr
+		join pg_subscription s on s.oid = sr.srsubid
+		join pg_class c on c.oid = sr.srrelid
+		where s.subname = $1
+	`, l.subName)
+	if err != nil {
+		return fmt.Errorf("read subscription tables: %w", err)
+	}
+	state := make(map[string]string)
+	for rows.Next() {
+		var name, st string
+		err = rows.Scan(&name, &st)
+		if err != nil {
+			return fmt.Errorf("read subscription tables: %w", err)
+		}
+		state[name] = st
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("read subscription tables: %w", err)
+	}
+
+	// the switchover tables identify each DB, so they must not be replicated
+	for _, name := range []string{"switchover_state", "switchover_log"} {
+		if _, ok := state[name]; ok {
+			return fmt.Errorf("table %s must not be part of the subscription", name)
+		}
+	}
+
+	for _, t := range l.tables {
+		st, ok := state[t.Name()]
+		if !ok {
+			return fmt.Errorf("table %s is not part of subscription %s", t.Name(), l.subName)
+		}
+
+		// 'r' (ready) and 's' (synchronized) mean the initial copy is done
+		if st != "r" && st != "s" {
+			return fmt.Errorf("table %s has not finished initial sync (state %s)", t.Name(), st)
+		}
+	}
+
+	return nil
+}
+
+// compareTables returns an error if src and dst do not have the same tables and columns.
+func compareTables(src, dst []swoinfo.Table) error {
+	dstCols := make(map[string][]string, len(dst))
+	for _, t := range dst {
+		dstCols[t.Name()] = t.Columns()
+	}
+
+	for _, t := range src {
+		cols, ok := dstCols[t.Name()]
+		if !ok {
+			return fmt.Errorf("table %s is missing from destination database", t.Name())
+		}
+		delete(dstCols, t.Name())
+
+		if strings.Join(cols, ",") != strings.Join(t.Columns(), ",") {
+			return fmt.Errorf("table %s columns differ: source has (%s), destination has (%s)", t.Name(), strings.Join(t.Columns(), ", "), strings.Join(cols, ", "))
+		}
+	}
+	for name := range dstCols {
+		return fmt.Errorf("table %s is missing from source database", name)
+	}
+
+	return nil
+}
+
+// subscriberLag returns the number of WAL bytes the subscriber has not yet confirmed.
+func (l *LogicalReplicator) subscriberLag(ctx context.Context, lsn string) (lag int64, err error) {
+	err = l.srcConn.QueryRow(ctx, `
+		select coalesce(pg_wal_lsn_diff($2::pg_lsn, confirmed_flush_lsn), 0)::bigint
+		from pg_replication_slots
+		where slot_name = $1
+	`, l.slotName, lsn).Scan(&lag)
+	if err != nil {
+		return 0, fmt.Errorf("read subscriber lag: %w", err)
+	}
+
+	return lag, nil
+}
+
+// SubscriberSync will report how far behind the subscriber is and wait for it to catch up
+// with the current position of the source database.
+func (l *LogicalReplicator) SubscriberSync(ctx context.Context) error {
+	var lsn string
+	err := l.srcConn.QueryRow(ctx, `select pg_current_wal_lsn()::text`).Scan(&lsn)
+	if err != nil {
+		return fmt.Errorf("read current lsn: %w", err)
+	}
+
+	for {
+		lag, err := l.subscriberLag(ctx, lsn)
+		if err != nil {
+			return err
+		}
+		if lag <= 0 {
+			return nil
+		}
+
+		l.printf(ctx, "waiting for subscriber (%d bytes behind)", lag)
+		err = ctxSleep(ctx, time.Second)
+		if err != nil {
+			return fmt.Errorf("wait for subscriber: %w", err)
+		}
+	}
+}
+
+// SubscriberFinalSync will obtain the stop-the-world lock, wait for the subscriber to receive all changes,
+// copy sequence values (they are not replicated), and update switchover_state to use_next_db. The subscription
+// is disabled once the switch is complete.
+func (l *LogicalReplicator) SubscriberFinalSync(ctx context.Context) error {
+	b := new(pgx.Batch)
+	b.Queue(`begin`)
+	b.Queue(txInProgressLock)
+	b.Queue(txStopTheWorld)
+	seqSync := NewSequenceSync(l.seqNames)
+	seqSync.AddBatchReads(b)
+
+	res := l.srcConn.SendBatch(ctx, b)
+	_, err := res.Exec() // begin tx
+	if err != nil {
+		return fmt.Errorf("final sync: begin tx: %w", err)
+	}
+	defer func(srcConn *pgx.Conn) { _, _ = srcConn.Exec(ctx, `rollback`) }(l.srcConn)
+
+	_, err = res.Exec()
+	if err != nil {
+		return fmt.Errorf("final sync: set tx timeout: %w", err)
+	}
+	_, err = res.Exec()
+	if err != nil {
+		return fmt.Errorf("final sync: stop-the-world lock: %w", err)
+	}
+	err = seqSync.ScanBatchReads(res)
+	if err != nil {
+		return fmt.Errorf("final sync: scan seqs: %w", err)
+	}
+	err = res.Close()
+	if err != nil {
+		return fmt.Errorf("final sync: %w", err)
+	}
+
+	// all writes are blocked, so nothing can be committed after this point
+	var lsn string
+	err = l.srcConn.QueryRow(ctx, `select pg_current_wal_lsn()::text`).Scan(&lsn)
+	if err != nil {
+		return fmt.Errorf("final sync: read current lsn: %w", err)
+	}
+
+	deadline := time.Now().Add(maxSubscriberWait)
+	for {
+		lag, err := l.subscriberLag(ctx, lsn)
+		if err != nil {
+			return fmt.Errorf("final sync: %w", err)
+		}
+		if lag <= 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("final sync: subscriber did not catch up in %s (%d bytes behind)", maxSubscriberWait, lag)
+		}
+
+		err = ctxSleep(ctx, 50*time.Millisecond)
+		if err != nil {
+			return fmt.Errorf("final sync: wait for subscriber: %w", err)
+		}
+	}
+
+	var applyChanges pgx.Batch
+	applyChanges.Queue("begin")
+	seqSync.AddBatchWrites(&applyChanges)
+	applyChanges.Queue("commit")
+	err = l.dstConn.SendBatch(ctx, &applyChanges).Close()
+	if err != nil {
+		_, _ = l.dstConn.Exec(ctx, `rollback`)
+		return fmt.Errorf("final sync: apply sequences: %w", err)
+	}
+
+	var finish pgx.Batch
+	finish.Queue("update switchover_state set current_state = 'use_next_db' where current_state = 'in_progress'")
+	finish.Queue("commit")
+	err = l.srcConn.SendBatch(ctx, &finish).Close()
+	if err != nil {
+		return fmt.Errorf("final sync: commit: %w", err)
+	}
+
+	// the old DB is no longer used, so nothing else will be replicated
+	_, err = l.dstConn.Exec(ctx, fmt.Sprintf("alter subscription %s disable", sqlutil.QuoteID(l.subName)))
+	if err != nil {
+		// log but don't return error since switchover is complete
+		log.Log(ctx, fmt.Errorf("disable subscription %s: %w", l.subName, err))
+	}
+
+	return nil
+}
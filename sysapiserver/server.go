@@ -2,14 +2,31 @@ package sysapiserver
 
 import (
 	"context"
+	"time"
 
+	"github.com/target/goalert/engine"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/pkg/sysapi"
 	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
 )
 
+// A Drainer can gracefully drain an instance in preparation for shutdown.
+type Drainer interface {
+	// StartDrain will begin draining, returning a channel that is closed once complete.
+	StartDrain() <-chan struct{}
+
+	// DrainStatus returns the time draining started and finished, and any error encountered.
+	DrainStatus() (startedAt, finishedAt time.Time, err error)
+}
+
 type Server struct {
 	UserStore *user.Store
+	Engine    *engine.Engine
+	Drainer   Drainer
+	Logger    *log.Logger
 	sysapi.UnimplementedSysAPIServer
 }
 
@@ -55,3 +72,111 @@ func (srv *Server) DeleteUser(ctx context.Context, req *sysapi.DeleteUserRequest
 	}
 	return &sysapi.DeleteUserResponse{}, nil
 }
+
+func (srv *Server) TriggerEngineCycle(ctx context.Context, req *sysapi.TriggerEngineCycleRequest) (*sysapi.TriggerEngineCycleResponse, error) {
+	ctx = permission.SystemContext(ctx, "SystemAPI")
+
+	id, err := srv.Engine.TriggerCycle(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Wait {
+		err = srv.Engine.WaitCycleID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &sysapi.TriggerEngineCycleResponse{CycleId: id.String()}, nil
+}
+
+func (srv *Server) EngineCycleStatus(ctx context.Context, req *sysapi.EngineCycleStatusRequest) (*sysapi.EngineCycleStatusResponse, error) {
+	id := srv.Engine.NextCycleID()
+	if req.CycleId != "" {
+		var err error
+		id, err = validate.ParseUUID("CycleID", req.CycleId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := srv.Engine.CycleStatus(id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &sysapi.EngineCycleStatusResponse{CycleId: s.ID.String()}
+	if !s.StartedAt.IsZero() {
+		resp.StartedAt = s.StartedAt.Format(time.RFC3339Nano)
+	}
+	if !s.FinishedAt.IsZero() {
+		resp.FinishedAt = s.FinishedAt.Format(time.RFC3339Nano)
+	}
+
+	return resp, nil
+}
+
+func (srv *Server) Drain(ctx context.Context, req *sysapi.DrainRequest) (*sysapi.DrainResponse, error) {
+	doneCh := srv.Drainer.StartDrain()
+	if !req.Wait {
+		return &sysapi.DrainResponse{}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-doneCh:
+	}
+
+	_, _, err := srv.Drainer.DrainStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sysapi.DrainResponse{}, nil
+}
+
+func (srv *Server) DrainStatus(ctx context.Context, req *sysapi.DrainStatusRequest) (*sysapi.DrainStatusResponse, error) {
+	startedAt, finishedAt, err := srv.Drainer.DrainStatus()
+
+	resp := &sysapi.DrainStatusResponse{
+		Draining: !startedAt.IsZero(),
+		Drained:  !finishedAt.IsZero() && err == nil,
+	}
+	if !startedAt.IsZero() {
+		resp.StartedAt = startedAt.Format(time.RFC3339Nano)
+	}
+	if !finishedAt.IsZero() {
+		resp.FinishedAt = finishedAt.Format(time.RFC3339Nano)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+func (srv *Server) SetLogLevel(ctx context.Context, req *sysapi.SetLogLevelRequest) (*sysapi.SetLogLevelResponse, error) {
+	if req.Subsystem == "" {
+		return nil, validation.NewFieldError("Subsystem", "is required")
+	}
+	lvl, err := log.ParseLevel(req.Level)
+	if err != nil {
+		return nil, validation.NewFieldError("Level", err.Error())
+	}
+
+	srv.Logger.SetLevel(req.Subsystem, lvl)
+	srv.Logger.Printf(ctx, "Log level for subsystem '%s' set to %s.", req.Subsystem, lvl)
+
+	return &sysapi.SetLogLevelResponse{}, nil
+}
+
+func (srv *Server) LogLevels(ctx context.Context, req *sysapi.LogLevelsRequest) (*sysapi.LogLevelsResponse, error) {
+	resp := &sysapi.LogLevelsResponse{DefaultLevel: srv.Logger.DefaultLevel().String()}
+	for _, l := range srv.Logger.Levels() {
+		resp.Levels = append(resp.Levels, &sysapi.LogLevel{Subsystem: l.Subsystem, Level: l.Level.String()})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,22 @@
+package syslogsrv
+
+import (
+	"context"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+)
+
+// Config is used to configure the syslog server.
+type Config struct {
+	BackgroundContext func() context.Context
+
+	// MaxMessageSize is the maximum size of a single message. Defaults to DefaultMaxMessageSize.
+	MaxMessageSize int
+
+	// FiltersFunc returns the filters for all syslog integration keys.
+	FiltersFunc func(ctx context.Context) ([]integrationkey.SyslogFilter, error)
+
+	AuthorizeFunc   func(ctx context.Context, id string) (context.Context, error)
+	CreateAlertFunc func(ctx context.Context, a *alert.Alert) error
+}
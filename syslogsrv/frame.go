@@ -0,0 +1,51 @@
+package syslogsrv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readFrame reads a single message using either octet-counting or
+// newline-delimited framing (RFC 6587).
+func readFrame(r *bufio.Reader, maxSize int) ([]byte, error) {
+	c, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if c[0] >= '1' && c[0] <= '9' {
+		lenStr, err := r.ReadString(' ')
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(lenStr))
+		if err != nil || n <= 0 {
+			return nil, errors.New("invalid frame length")
+		}
+		if n > maxSize {
+			return nil, fmt.Errorf("message too large (%d bytes)", n)
+		}
+		buf := make([]byte, n)
+		_, err = io.ReadFull(r, buf)
+		return buf, err
+	}
+
+	var buf []byte
+	for {
+		line, isPrefix, err := r.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, line...)
+		if len(buf) > maxSize {
+			return nil, fmt.Errorf("message too large (> %d bytes)", maxSize)
+		}
+		if !isPrefix {
+			return buf, nil
+		}
+	}
+}
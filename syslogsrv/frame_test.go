@@ -0,0 +1,40 @@
+package syslogsrv
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFrame(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("11 <13>1 - a b5 <13>1\n<13>1 - - - - - c\r\n"))
+
+	data, err := readFrame(r, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "<13>1 - a b", string(data))
+
+	data, err = readFrame(r, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "<13>1", string(data))
+
+	data, err = readFrame(r, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "", string(data))
+
+	data, err = readFrame(r, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "<13>1 - - - - - c", string(data))
+
+	_, err = readFrame(r, 64)
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = readFrame(bufio.NewReader(strings.NewReader("100 <13>1")), 64)
+	assert.Error(t, err, "too large")
+
+	_, err = readFrame(bufio.NewReader(strings.NewReader(strings.Repeat("<", 100)+"\n")), 64)
+	assert.Error(t, err, "too large")
+}
@@ -0,0 +1,171 @@
+package syslogsrv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// severityNames are the RFC 5424 severity names, indexed by code.
+var severityNames = []string{
+	"Emergency",
+	"Alert",
+	"Critical",
+	"Error",
+	"Warning",
+	"Notice",
+	"Informational",
+	"Debug",
+}
+
+// SeverityName returns the name of the given severity code.
+func SeverityName(sev int) string {
+	if sev < 0 || sev >= len(severityNames) {
+		return strconv.Itoa(sev)
+	}
+	return severityNames[sev]
+}
+
+// Message is a parsed RFC 5424 syslog message. Nil values ("-") are returned as empty strings.
+type Message struct {
+	Facility  int
+	Severity  int
+	Timestamp time.Time
+
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData string
+	Message        string
+}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+func nilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+// nextField splits the next space-delimited header field from b.
+func nextField(b []byte) (string, []byte, error) {
+	idx := bytes.IndexByte(b, ' ')
+	if idx <= 0 {
+		return "", nil, errors.New("missing header field")
+	}
+	return string(b[:idx]), b[idx+1:], nil
+}
+
+// parseSD splits the STRUCTURED-DATA portion from b.
+func parseSD(b []byte) (string, []byte, error) {
+	if len(b) == 0 {
+		return "", nil, errors.New("missing structured data")
+	}
+	if b[0] == '-' {
+		if len(b) > 1 && b[1] != ' ' {
+			return "", nil, errors.New("invalid structured data")
+		}
+		return "", b[1:], nil
+	}
+	if b[0] != '[' {
+		return "", nil, errors.New("invalid structured data")
+	}
+
+	var inElem, inQuote, escaped bool
+	for i, c := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote && c == '\\':
+			escaped = true
+		case c == '"' && inElem:
+			inQuote = !inQuote
+		case c == '[' && !inQuote:
+			if inElem {
+				return "", nil, errors.New("invalid structured data")
+			}
+			inElem = true
+		case c == ']' && !inQuote:
+			if !inElem {
+				return "", nil, errors.New("invalid structured data")
+			}
+			inElem = false
+			if i+1 == len(b) || b[i+1] != '[' {
+				return string(b[:i+1]), b[i+1:], nil
+			}
+		case !inElem:
+			return "", nil, errors.New("invalid structured data")
+		}
+	}
+
+	return "", nil, errors.New("unterminated structured data")
+}
+
+// ParseMessage parses a single RFC 5424 syslog message.
+func ParseMessage(b []byte) (*Message, error) {
+	b = bytes.TrimRight(b, "\r\n\x00")
+	if len(b) < 4 || b[0] != '<' {
+		return nil, errors.New("missing PRI")
+	}
+	end := bytes.IndexByte(b[:min(len(b), 5)], '>')
+	if end < 2 {
+		return nil, errors.New("invalid PRI")
+	}
+	pri, err := strconv.Atoi(string(b[1:end]))
+	if err != nil || pri < 0 || pri > 191 {
+		return nil, errors.New("invalid PRI")
+	}
+	b = b[end+1:]
+
+	var m Message
+	m.Facility = pri / 8
+	m.Severity = pri % 8
+
+	ver, b, err := nextField(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse version: %w", err)
+	}
+	if ver != "1" {
+		return nil, fmt.Errorf("unsupported syslog version %q (only RFC 5424 is supported)", ver)
+	}
+
+	var ts string
+	ts, b, err = nextField(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse timestamp: %w", err)
+	}
+	if ts != "-" {
+		m.Timestamp, err = time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return nil, fmt.Errorf("parse timestamp: %w", err)
+		}
+	}
+
+	fields := make([]string, 4)
+	for i := range fields {
+		fields[i], b, err = nextField(b)
+		if err != nil {
+			return nil, fmt.Errorf("parse header: %w", err)
+		}
+		fields[i] = nilValue(fields[i])
+	}
+	m.Hostname, m.AppName, m.ProcID, m.MsgID = fields[0], fields[1], fields[2], fields[3]
+
+	m.StructuredData, b, err = parseSD(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > 0 && b[0] == ' ' {
+		b = b[1:]
+	}
+	b = bytes.TrimPrefix(b, utf8BOM)
+	m.Message = strings.TrimSpace(string(b))
+
+	return &m, nil
+}
@@ -0,0 +1,52 @@
+package syslogsrv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	m, err := ParseMessage([]byte(`<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - ` + "\xef\xbb\xbf" + `'su root' failed for lonvick on /dev/pts/8` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, m.Facility)
+	assert.Equal(t, 2, m.Severity)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), m.Timestamp)
+	assert.Equal(t, "mymachine.example.com", m.Hostname)
+	assert.Equal(t, "su", m.AppName)
+	assert.Equal(t, "", m.ProcID)
+	assert.Equal(t, "ID47", m.MsgID)
+	assert.Equal(t, "", m.StructuredData)
+	assert.Equal(t, "'su root' failed for lonvick on /dev/pts/8", m.Message)
+
+	m, err = ParseMessage([]byte(`<165>1 - host app 123 - [exampleSDID@32473 iut="3" eventID="1011" note="a \"]\" b"][other@1 x="y"] An application event`))
+	require.NoError(t, err)
+	assert.Equal(t, 20, m.Facility)
+	assert.Equal(t, 5, m.Severity)
+	assert.True(t, m.Timestamp.IsZero())
+	assert.Equal(t, "123", m.ProcID)
+	assert.Equal(t, `[exampleSDID@32473 iut="3" eventID="1011" note="a \"]\" b"][other@1 x="y"]`, m.StructuredData)
+	assert.Equal(t, "An application event", m.Message)
+
+	m, err = ParseMessage([]byte(`<13>1 - host app - - [a@1 b="c"]`))
+	require.NoError(t, err)
+	assert.Equal(t, `[a@1 b="c"]`, m.StructuredData)
+	assert.Equal(t, "", m.Message)
+
+	invalid := []string{
+		"",
+		"hello world",
+		"<192>1 - host app - - - msg",
+		"<13>Oct 11 22:14:15 host su: msg",
+		"<13>1 yesterday host app - - - msg",
+		"<13>1 - host app - -",
+		"<13>1 - host app - - x msg",
+		`<13>1 - host app - - [a@1 b="c" msg`,
+	}
+	for _, s := range invalid {
+		_, err = ParseMessage([]byte(s))
+		assert.Error(t, err, s)
+	}
+}
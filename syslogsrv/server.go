@@ -0,0 +1,251 @@
+package syslogsrv
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// DefaultMaxMessageSize is the default maximum size of a single message.
+	DefaultMaxMessageSize = 8192
+
+	// filterRefreshInterval is how often filters are re-read from the DB.
+	filterRefreshInterval = 30 * time.Second
+
+	// idleTimeout closes connections that have not sent a message.
+	idleTimeout = 5 * time.Minute
+)
+
+// Server implements a syslog listener that creates alerts.
+type Server struct {
+	cfg Config
+
+	mx        sync.Mutex
+	closed    bool
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+
+	filterMx  sync.Mutex
+	filters   []integrationkey.SyslogFilter
+	filtersAt time.Time
+}
+
+// NewServer creates a new Server.
+func NewServer(cfg Config) *Server {
+	if cfg.BackgroundContext == nil {
+		panic("syslogsrv: BackgroundContext is required")
+	}
+	if cfg.FiltersFunc == nil {
+		panic("syslogsrv: FiltersFunc is required")
+	}
+	if cfg.AuthorizeFunc == nil {
+		panic("syslogsrv: AuthorizeFunc is required")
+	}
+	if cfg.CreateAlertFunc == nil {
+		panic("syslogsrv: CreateAlertFunc is required")
+	}
+	if cfg.MaxMessageSize == 0 {
+		cfg.MaxMessageSize = DefaultMaxMessageSize
+	}
+
+	return &Server{
+		cfg:       cfg,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ServeSyslog accepts connections on l until Shutdown is called.
+func (s *Server) ServeSyslog(l net.Listener) error {
+	s.mx.Lock()
+	if s.closed {
+		s.mx.Unlock()
+		return net.ErrClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mx.Unlock()
+
+	for {
+		c, err := l.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		s.mx.Lock()
+		if s.closed {
+			s.mx.Unlock()
+			c.Close()
+			return nil
+		}
+		s.conns[c] = struct{}{}
+		s.wg.Add(1)
+		s.mx.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(c)
+
+			s.mx.Lock()
+			delete(s.conns, c)
+			s.mx.Unlock()
+		}()
+	}
+}
+
+// Shutdown stops all listeners, closes open connections, and waits for in-flight messages.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mx.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		_ = l.Close()
+	}
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.mx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+func (s *Server) handleConn(c net.Conn) {
+	defer c.Close()
+
+	ctx := log.WithField(s.cfg.BackgroundContext(), "RemoteAddr", c.RemoteAddr().String())
+	r := bufio.NewReader(c)
+	for {
+		_ = c.SetReadDeadline(time.Now().Add(idleTimeout))
+		data, err := readFrame(r, s.cfg.MaxMessageSize)
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return
+		}
+		if err != nil {
+			// framing errors are unrecoverable for the stream
+			log.Debugf(ctx, "syslogsrv: closing connection: %v", err)
+			return
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		msg, err := ParseMessage(data)
+		if err != nil {
+			log.Debugf(ctx, "syslogsrv: ignoring invalid message: %v", err)
+			continue
+		}
+
+		s.handleMessage(ctx, msg)
+	}
+}
+
+// currentFilters returns the cached filters, refreshing them if stale.
+func (s *Server) currentFilters(ctx context.Context) []integrationkey.SyslogFilter {
+	s.filterMx.Lock()
+	defer s.filterMx.Unlock()
+
+	if time.Since(s.filtersAt) < filterRefreshInterval {
+		return s.filters
+	}
+
+	filters, err := s.cfg.FiltersFunc(ctx)
+	if err != nil {
+		// keep using the previous filters until the next refresh
+		log.Log(ctx, fmt.Errorf("syslogsrv: refresh filters: %w", err))
+		return s.filters
+	}
+	s.filters = filters
+	s.filtersAt = time.Now()
+
+	return s.filters
+}
+
+func (s *Server) handleMessage(ctx context.Context, msg *Message) {
+	for _, f := range s.currentFilters(ctx) {
+		if !f.Matches(msg.Facility, msg.Severity, msg.Hostname, msg.AppName) {
+			continue
+		}
+
+		keyCtx := log.WithField(ctx, "IntegrationKeyID", f.IntegrationKeyID)
+		keyCtx, err := s.cfg.AuthorizeFunc(keyCtx, f.IntegrationKeyID)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("syslogsrv: authorize key %s: %w", f.IntegrationKeyID, err))
+			continue
+		}
+
+		a := newAlert(msg)
+		a.ServiceID = permission.ServiceID(keyCtx)
+		err = retry.DoTemporaryError(func(_ int) error {
+			return s.cfg.CreateAlertFunc(keyCtx, a)
+		},
+			retry.Log(keyCtx),
+			retry.Limit(12),
+			retry.FibBackoff(time.Second),
+		)
+		if err != nil {
+			log.Log(keyCtx, fmt.Errorf("syslogsrv: create alert: %w", err))
+		}
+	}
+}
+
+func newAlert(msg *Message) *alert.Alert {
+	source := msg.AppName
+	if source == "" {
+		source = msg.Hostname
+	}
+	summary := msg.Message
+	if source != "" {
+		summary = source + ": " + summary
+	}
+
+	var details strings.Builder
+	fmt.Fprintf(&details, "Host: %s\nApp: %s\nFacility: %d\nSeverity: %s\n", msg.Hostname, msg.AppName, msg.Facility, SeverityName(msg.Severity))
+	if !msg.Timestamp.IsZero() {
+		fmt.Fprintf(&details, "Timestamp: %s\n", msg.Timestamp.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&details, "\n%s\n", msg.Message)
+	if msg.StructuredData != "" {
+		fmt.Fprintf(&details, "\n%s\n", msg.StructuredData)
+	}
+
+	dedup := strings.Join([]string{msg.Hostname, msg.AppName, msg.MsgID}, "/")
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(details.String(), alert.MaxDetailsLength),
+		Status:  alert.StatusTriggered,
+		Source:  alert.SourceSyslog,
+		Dedup:   alert.NewUserDedup(dedup),
+	}
+}
@@ -0,0 +1,255 @@
+package team
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of teams.
+type Store struct {
+	db *sql.DB
+
+	create     *sql.Stmt
+	update     *sql.Stmt
+	delete     *sql.Stmt
+	findMany   *sql.Stmt
+	findAll    *sql.Stmt
+	findOnCall *sql.Stmt
+	isMember   *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		create: p.P(`insert into teams (id, name, description, default_schedule_id) values ($1, $2, $3, $4)`),
+		update: p.P(`update teams set name = $2, description = $3, default_schedule_id = $4 where id = $1`),
+		delete: p.P(`delete from teams where id = any($1)`),
+		findMany: p.P(`
+			select id, name, description, default_schedule_id
+			from teams
+			where id = any($1)
+		`),
+		findAll: p.P(`
+			select id, name, description, default_schedule_id
+			from teams
+			order by lower(name)
+		`),
+		findOnCall: p.P(`
+			select oc.user_id
+			from teams t
+			join schedule_on_call_users oc on oc.schedule_id = t.default_schedule_id and oc.end_time isnull
+			where t.id = $1
+			order by oc.start_time
+		`),
+		isMember: p.P(`
+			select exists (
+				select 1
+				from teams t
+				join schedule_rules r on r.schedule_id = t.default_schedule_id
+				left join rotation_participants rp on rp.rotation_id = r.tgt_rotation_id
+				where t.id = $1 and $2 in (r.tgt_user_id, rp.user_id)
+			)
+		`),
+	}, p.Err
+}
+
+func scheduleID(t *Team) sql.NullString {
+	if t.DefaultScheduleID == "" {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: t.DefaultScheduleID, Valid: true}
+}
+
+// CreateTeamTx will create a new Team.
+func (s *Store) CreateTeamTx(ctx context.Context, tx *sql.Tx, t *Team) (*Team, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.New().String()
+
+	_, err = tx.StmtContext(ctx, s.create).ExecContext(ctx, n.ID, n.Name, n.Description, scheduleID(n))
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateTeamTx will update an existing Team.
+func (s *Store) UpdateTeamTx(ctx context.Context, tx *sql.Tx, t *Team) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.update).ExecContext(ctx, n.ID, n.Name, n.Description, scheduleID(n))
+	return err
+}
+
+// DeleteManyTx will delete all teams with the given IDs. Escalation policy step targets referencing
+// the teams are removed.
+func (s *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.ManyUUID("TeamID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// CheckMemberTx will return an error unless the current user is an admin or a member of the team,
+// meaning they are part of its default schedule (directly or through a rotation).
+func (s *Store) CheckMemberTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	if permission.Admin(ctx) {
+		return nil
+	}
+	err = validate.UUID("TeamID", id)
+	if err != nil {
+		return err
+	}
+
+	var isMember bool
+	err = tx.StmtContext(ctx, s.isMember).QueryRowContext(ctx, id, permission.UserID(ctx)).Scan(&isMember)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return permission.NewAccessDenied("must be an admin or a member of the team")
+	}
+
+	return nil
+}
+
+func scanTeams(rows *sql.Rows) ([]Team, error) {
+	defer rows.Close()
+
+	var result []Team
+	for rows.Next() {
+		var t Team
+		var schedID sql.NullString
+		err := rows.Scan(&t.ID, &t.Name, &t.Description, &schedID)
+		if err != nil {
+			return nil, err
+		}
+		t.DefaultScheduleID = schedID.String
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+// FindMany will return all teams with the given IDs.
+func (s *Store) FindMany(ctx context.Context, ids []string) ([]Team, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.ManyUUID("TeamID", ids, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMany.QueryContext(ctx, sqlutil.UUIDArray(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTeams(rows)
+}
+
+// FindOne will return the Team with the given ID, or nil if it does not exist.
+func (s *Store) FindOne(ctx context.Context, id string) (*Team, error) {
+	teams, err := s.FindMany(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, nil
+	}
+
+	return &teams[0], nil
+}
+
+// FindAll will return all teams, sorted by name.
+func (s *Store) FindAll(ctx context.Context) ([]Team, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTeams(rows)
+}
+
+// OnCallUserIDs will return the IDs of all users currently on-call for the team's default schedule.
+func (s *Store) OnCallUserIDs(ctx context.Context, id string) ([]string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("TeamID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findOnCall.QueryContext(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var userID string
+		err = rows.Scan(&userID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, userID)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,33 @@
+package team
+
+import (
+	"github.com/target/goalert/validation/validate"
+)
+
+// A Team is a group of responders whose current on-call users are determined by
+// a default schedule. Teams can be used as escalation policy step targets so that
+// policies do not need to reference a specific schedule.
+type Team struct {
+	ID          string
+	Name        string
+	Description string
+
+	// DefaultScheduleID is the schedule used to determine the team's on-call users.
+	DefaultScheduleID string
+}
+
+// Normalize will validate and return a normalized copy of the Team.
+func (t Team) Normalize() (*Team, error) {
+	err := validate.Many(
+		validate.IDName("Name", t.Name),
+		validate.Text("Description", t.Description, 1, 255),
+	)
+	if t.DefaultScheduleID != "" {
+		err = validate.Many(err, validate.UUID("DefaultScheduleID", t.DefaultScheduleID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
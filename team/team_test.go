@@ -0,0 +1,38 @@
+package team
+
+import (
+	"testing"
+)
+
+func TestTeam_Normalize(t *testing.T) {
+	test := func(valid bool, tm Team) {
+		name := "valid"
+		if !valid {
+			name = "invalid"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Logf("%+v", tm)
+			_, err := tm.Normalize()
+			if valid && err != nil {
+				t.Errorf("got %v; want nil", err)
+			} else if !valid && err == nil {
+				t.Errorf("got nil err; want non-nil")
+			}
+		})
+	}
+
+	valid := []Team{
+		{Name: "Storage"},
+		{Name: "Storage", Description: "Storage team", DefaultScheduleID: "a0a0a0a0-a0a0-a0a0-a0a0-a0a0a0a0a0a0"},
+	}
+	invalid := []Team{
+		{Name: ""},
+		{Name: "Storage", DefaultScheduleID: "not-a-uuid"},
+	}
+	for _, tm := range valid {
+		test(true, tm)
+	}
+	for _, tm := range invalid {
+		test(false, tm)
+	}
+}
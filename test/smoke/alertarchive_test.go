@@ -0,0 +1,76 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestAlertArchive verifies that old closed alerts are moved to the archive, and can be
+// retrieved, when `Maintenance.AlertArchiveDays` is set.
+func TestAlertArchive(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary, status, created_at)
+	values
+		(1, {{uuid "sid"}}, 'recent', 'closed', now()),
+		(2, {{uuid "sid"}}, 'old', 'closed', now() - '40 days'::interval),
+		(3, {{uuid "sid"}}, 'open', 'triggered', now() - '40 days'::interval);
+
+	insert into alert_logs (alert_id, event, message)
+	values
+		(2, 'created', 'created via: manual'),
+		(2, 'closed', 'closed by test');
+`
+	h := harness.NewHarness(t, sql, "alert-archive-index")
+	defer h.Close()
+
+	h.SetConfigValue("Maintenance.AlertArchiveDays", "30")
+	h.Trigger()
+
+	var data struct {
+		A1 *struct{ ID string }
+		A2 *struct{ ID string }
+		A3 *struct{ ID string }
+		R1 *struct{ AlertID int }
+		R2 *struct {
+			AlertID   int
+			ServiceID string
+			Summary   string
+			Logs      []struct{ Event, Message string }
+		}
+		R3 *struct{ AlertID int }
+	}
+	res := h.GraphQLQuery2(`{
+		a1:alert(id: 1){id} a2:alert(id: 2){id} a3:alert(id: 3){id}
+		r1:archivedAlert(id: 1){alertID}
+		r2:archivedAlert(id: 2){alertID serviceID summary logs{event message}}
+		r3:archivedAlert(id: 3){alertID}
+	}`)
+	require.Empty(t, res.Errors, "errors")
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+
+	assert.NotNil(t, data.A1, "recent closed alert should remain")
+	assert.Nil(t, data.A2, "old closed alert should be archived")
+	assert.NotNil(t, data.A3, "open alert should remain")
+
+	assert.Nil(t, data.R1)
+	assert.Nil(t, data.R3)
+	require.NotNil(t, data.R2, "archived alert")
+	assert.Equal(t, 2, data.R2.AlertID)
+	assert.Equal(t, h.UUID("sid"), data.R2.ServiceID)
+	assert.Equal(t, "old", data.R2.Summary)
+	require.Len(t, data.R2.Logs, 2)
+	assert.Equal(t, "closed by test", data.R2.Logs[1].Message)
+}
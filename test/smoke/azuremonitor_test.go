@@ -0,0 +1,99 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestAzureMonitor checks that Azure Monitor common alert schema webhooks create, dedup and close alerts.
+func TestAzureMonitor(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'azureMonitor', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "azure-monitor-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/azuremonitor/incoming?token=" + h.UUID("int_key")
+
+	post := func(alertID, condition, severity string) int {
+		t.Helper()
+		body := `{"schemaId": "azureMonitorCommonAlertSchema", "data": {"essentials": {` +
+			`"alertId": "` + alertID + `", "alertRule": "High CPU", "severity": "` + severity + `", "monitorCondition": "` + condition + `"}}}`
+		resp, err := http.Post(url, "application/json", strings.NewReader(body))
+		require.NoError(t, err, "post to azure monitor endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func(summary string) []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = $1 order by id`, summary)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	assert.Equal(t, 200, post("alert-1", "Fired", "Sev1"))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("High CPU")
+
+	// repeated notifications for the same alert are deduplicated
+	assert.Equal(t, 200, post("alert-1", "Fired", "Sev1"))
+	assert.Equal(t, []string{"triggered"}, statuses("[Sev1] High CPU"))
+
+	assert.Equal(t, 200, post("alert-1", "Resolved", "Sev1"))
+	assert.Equal(t, []string{"closed"}, statuses("[Sev1] High CPU"))
+
+	assert.Equal(t, 400, post("", "Fired", "Sev1"), "missing alertId")
+	assert.Equal(t, 400, post("alert-2", "Unknown", "Sev2"), "invalid monitorCondition")
+	assert.Empty(t, statuses("[Sev2] High CPU"))
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{"schemaId": "AzureMonitorMetricAlert", "data": {}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 400, resp.StatusCode, "legacy schema")
+}
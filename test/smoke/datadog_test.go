@@ -0,0 +1,97 @@
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+func TestDatadog(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'datadog', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "datadog-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/datadog/incoming?token=" + h.UUID("int_key")
+
+	post := func(t *testing.T, key, transition string) int {
+		t.Helper()
+		data, err := json.Marshal(map[string]string{
+			"alert_id":      "1234",
+			"aggregate_key": key,
+			"title":         key,
+			"message":       "test message",
+			"transition":    transition,
+		})
+		require.NoError(t, err)
+
+		resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+		require.NoError(t, err, "post to datadog endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// statuses returns the status of all alerts created for the key, oldest first.
+	statuses := func(t *testing.T, key string) []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = $1 order by id`, key)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	cases := []struct {
+		name        string
+		transitions []string
+		code        int
+		status      []string
+	}{
+		{name: "triggered", transitions: []string{"Triggered"}, code: 200, status: []string{"triggered"}},
+		{name: "recovered", transitions: []string{"Triggered", "Recovered"}, code: 200, status: []string{"closed"}},
+		{name: "warn-recovered", transitions: []string{"Warn", "Warn Recovered"}, code: 200, status: []string{"closed"}},
+		{name: "re-triggered", transitions: []string{"Triggered", "Re-Triggered"}, code: 200, status: []string{"triggered"}},
+		{name: "re-triggered-after-recovery", transitions: []string{"Triggered", "Recovered", "Re-Triggered"}, code: 200, status: []string{"closed", "triggered"}},
+		{name: "no-data", transitions: []string{"No Data"}, code: 200, status: []string{"triggered"}},
+		{name: "recovered-only", transitions: []string{"Recovered"}, code: 200, status: nil},
+		{name: "unknown", transitions: []string{"Something Else"}, code: 400, status: nil},
+		{name: "unknown-after-trigger", transitions: []string{"Triggered", "Muted"}, code: 400, status: []string{"triggered"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var code int
+			for _, tr := range c.transitions {
+				code = post(t, c.name, tr)
+			}
+			assert.Equal(t, c.code, code, "status code of last request")
+			assert.Equal(t, c.status, statuses(t, c.name), "alert statuses")
+		})
+	}
+}
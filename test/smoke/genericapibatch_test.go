@@ -0,0 +1,130 @@
+package smoke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGenericAPIBatch checks that the generic API batch endpoint reports per-item results, enforces size
+// limits, and handles close requests that don't match an alert.
+func TestGenericAPIBatch(t *testing.T) {
+	t.Parallel()
+
+	// no one is on-call, so no notifications are sent
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "ids-to-uuids")
+	defer h.Close()
+
+	type result struct {
+		AlertID   int
+		ServiceID string
+		IsNew     bool
+		Error     string
+	}
+	post := func(t *testing.T, token, body string) (int, []result) {
+		t.Helper()
+		resp, err := http.Post(h.URL()+"/api/v2/generic/batch?token="+token, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return resp.StatusCode, nil
+		}
+
+		var res struct{ Results []result }
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		return resp.StatusCode, res.Results
+	}
+	key := h.UUID("int_key")
+	sid := h.UUID("sid")
+
+	status := func(t *testing.T, id int) string {
+		t.Helper()
+		var s string
+		err := h.App().DB().QueryRowContext(context.Background(), `select status from alerts where id = $1`, id).Scan(&s)
+		require.NoError(t, err)
+		return s
+	}
+
+	code, res := post(t, key, `[
+		{"summary": "first", "dedup": "a"},
+		{"summary": ""},
+		{"summary": "bad severity", "severity": "nope"},
+		{"summary": "second", "severity": "low"}
+	]`)
+	require.Equal(t, 200, code, "mixed valid and invalid items")
+	require.Len(t, res, 4, "one result per item")
+
+	assert.NotZero(t, res[0].AlertID)
+	assert.Equal(t, sid, res[0].ServiceID)
+	assert.True(t, res[0].IsNew)
+	assert.Empty(t, res[0].Error)
+
+	assert.Zero(t, res[1].AlertID)
+	assert.Contains(t, res[1].Error, "Summary", "empty summary")
+	assert.Zero(t, res[2].AlertID)
+	assert.Contains(t, res[2].Error, "Severity", "unknown severity")
+
+	assert.NotZero(t, res[3].AlertID)
+	assert.True(t, res[3].IsNew)
+	assert.Empty(t, res[3].Error)
+	first, second := res[0].AlertID, res[3].AlertID
+
+	var count int
+	err := h.App().DB().QueryRowContext(context.Background(), `select count(*) from alerts where service_id = $1`, sid).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "invalid items are not created")
+
+	// same dedup key as an open alert
+	_, res = post(t, key, `[{"summary": "first again", "dedup": "a"}]`)
+	require.Len(t, res, 1)
+	assert.Equal(t, first, res[0].AlertID, "deduplicated")
+	assert.False(t, res[0].IsNew)
+
+	_, res = post(t, key, `[
+		{"action": "close", "dedup": "a"},
+		{"action": "close", "dedup": "does-not-exist"}
+	]`)
+	require.Len(t, res, 2)
+	assert.Equal(t, first, res[0].AlertID, "closed alert")
+	assert.Empty(t, res[0].Error)
+	assert.Equal(t, result{}, res[1], "close with no matching alert")
+	assert.Equal(t, "closed", status(t, first))
+	assert.Equal(t, "triggered", status(t, second))
+
+	items := make([]string, 101)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"summary": "alert %d"}`, i)
+	}
+	code, _ = post(t, key, "["+strings.Join(items, ",")+"]")
+	assert.Equal(t, 400, code, "too many items")
+	code, _ = post(t, key, "["+strings.Join(items[:100], ",")+"]")
+	assert.Equal(t, 200, code, "max items")
+
+	code, _ = post(t, key, `[]`)
+	assert.Equal(t, 400, code, "empty batch")
+	code, _ = post(t, key, `{"summary": "not an array"}`)
+	assert.Equal(t, 400, code, "not an array")
+
+	code, _ = post(t, "", `[{"summary": "no key"}]`)
+	assert.Equal(t, 401, code, "missing integration key")
+}
@@ -0,0 +1,100 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGrafanaOnCall checks that grouped Grafana Alerting webhooks create a single alert per
+// group, and that Grafana OnCall formatted webhooks create and close alerts.
+func TestGrafanaOnCall(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'grafanaOnCall', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "grafana-oncall-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/grafanaoncall/incoming?token=" + h.UUID("int_key")
+
+	post := func(body string) int {
+		t.Helper()
+		resp, err := http.Post(url, "application/json", strings.NewReader(body))
+		require.NoError(t, err, "post to grafana oncall endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func(summary string) []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = $1 order by id`, summary)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	const group = `"groupKey": "{}:{alertname=\"HighLatency\"}", "commonAnnotations": {"summary": "Latency is high"}`
+	assert.Equal(t, 200, post(`{"status": "firing", `+group+`, "alerts": [{"status": "firing", "labels": {"instance": "a"}}]}`))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("Latency is high")
+
+	// additional alerts in the same group update the same alert
+	assert.Equal(t, 200, post(`{"status": "firing", `+group+`, "alerts": [{"status": "firing", "labels": {"instance": "a"}}, {"status": "firing", "labels": {"instance": "b"}}]}`))
+	assert.Equal(t, []string{"triggered"}, statuses("Latency is high"))
+
+	assert.Equal(t, 200, post(`{"status": "resolved", `+group+`, "alerts": []}`))
+	assert.Equal(t, []string{"closed"}, statuses("Latency is high"))
+
+	assert.Equal(t, 200, post(`{"alert_uid": "08d6891a", "title": "Formatted", "state": "alerting"}`))
+	assert.Equal(t, 200, post(`{"alert_uid": "08d6891a", "title": "Formatted", "state": "alerting"}`))
+	assert.Equal(t, []string{"triggered"}, statuses("Formatted"))
+	assert.Equal(t, 200, post(`{"alert_uid": "08d6891a", "state": "ok"}`))
+	assert.Equal(t, []string{"closed"}, statuses("Formatted"))
+
+	assert.Equal(t, 400, post(`{"status": "firing"}`), "missing groupKey and alert_uid")
+	assert.Equal(t, 400, post(`{"status": "pending", "groupKey": "a"}`), "unknown status")
+	assert.Equal(t, 400, post(`not json`), "invalid JSON")
+}
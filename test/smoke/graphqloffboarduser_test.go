@@ -0,0 +1,105 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLOffboardUser checks that offboarding a user replaces or removes them from temporary
+// schedules and shadows.
+func TestGraphQLOffboardUser(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "old"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "new"}}, 'joe', 'joe@example.com', 'user'),
+		({{uuid "other"}}, 'ann', 'ann@example.com', 'user'),
+		({{uuid "trainee"}}, 'sue', 'sue@example.com', 'user');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched"}}, '{"V1":{
+			"TemporarySchedules": [{"Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z", "Shifts": [
+				{"Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z", "UserID": {{uuidJSON "old"}}},
+				{"Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z", "UserID": {{uuidJSON "other"}}}
+			]}],
+			"Shadows": [
+				{"ID": {{uuidJSON "s1"}}, "UserID": {{uuidJSON "old"}}, "Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z"},
+				{"ID": {{uuidJSON "s2"}}, "UserID": {{uuidJSON "trainee"}}, "ShadowUserID": {{uuidJSON "old"}}, "Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z"},
+				{"ID": {{uuidJSON "s3"}}, "UserID": {{uuidJSON "trainee"}}, "Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z"}
+			]
+		}}');
+`
+
+	type shadow struct {
+		UserID       string
+		ShadowUserID string
+	}
+	offboard := func(t *testing.T, replace bool) (shifts []string, shadows []shadow) {
+		t.Helper()
+		h := harness.NewHarness(t, sql, "ids-to-uuids")
+		defer h.Close()
+
+		repl := ""
+		if replace {
+			repl = fmt.Sprintf(`, replacementUserID: "%s"`, h.UUID("new"))
+		}
+		r := h.GraphQLQueryT(t, fmt.Sprintf(`mutation{offboardUser(input:{userID: "%s"%s})}`, h.UUID("old"), repl))
+		require.Empty(t, r.Errors)
+
+		var resp struct {
+			Schedule struct {
+				TemporarySchedules []struct {
+					Shifts []struct{ UserID string }
+				}
+				Shadows []shadow
+			}
+		}
+		r = h.GraphQLQueryT(t, fmt.Sprintf(`query{schedule(id: "%s"){temporarySchedules{shifts{userID}} shadows{userID shadowUserID}}}`, h.UUID("sched")))
+		require.Empty(t, r.Errors)
+		require.NoError(t, json.Unmarshal(r.Data, &resp))
+		require.Len(t, resp.Schedule.TemporarySchedules, 1)
+
+		// map IDs back to names for comparison
+		names := map[string]string{"": ""}
+		for _, name := range []string{"old", "new", "other", "trainee"} {
+			names[h.UUID(name)] = name
+		}
+		for _, s := range resp.Schedule.TemporarySchedules[0].Shifts {
+			shifts = append(shifts, names[s.UserID])
+		}
+		for _, s := range resp.Schedule.Shadows {
+			shadows = append(shadows, shadow{UserID: names[s.UserID], ShadowUserID: names[s.ShadowUserID]})
+		}
+		return shifts, shadows
+	}
+
+	t.Run("Replace", func(t *testing.T) {
+		t.Parallel()
+		shifts, shadows := offboard(t, true)
+		assert.ElementsMatch(t, []string{"new", "other"}, shifts)
+		assert.ElementsMatch(t, []shadow{
+			{UserID: "new"},
+			{UserID: "trainee", ShadowUserID: "new"},
+			{UserID: "trainee"},
+		}, shadows)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		t.Parallel()
+		shifts, shadows := offboard(t, false)
+		assert.ElementsMatch(t, []string{"other"}, shifts)
+		assert.ElementsMatch(t, []shadow{{UserID: "trainee"}}, shadows)
+	})
+}
@@ -0,0 +1,62 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLServiceTemplate checks that service templates can only be deleted by an admin
+// or the user that created them.
+func TestGraphQLServiceTemplate(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "owner"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "other"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "ids-to-uuids")
+	defer h.Close()
+
+	create := func(userID, name string) string {
+		t.Helper()
+		var resp struct {
+			CreateServiceTemplate struct{ ID string }
+		}
+		r := h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{createServiceTemplate(input:{serviceID: "%s", name: "%s", includeTargets: false}){id}}`, h.UUID("sid"), name))
+		require.Empty(t, r.Errors)
+		require.NoError(t, json.Unmarshal(r.Data, &resp))
+		require.NotEmpty(t, resp.CreateServiceTemplate.ID)
+		return resp.CreateServiceTemplate.ID
+	}
+	del := func(userID, id string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{deleteServiceTemplate(id: "%s")}`, id))
+	}
+
+	tmpl := create(h.UUID("owner"), "owner template")
+
+	resp := del(h.UUID("other"), tmpl)
+	assert.NotEmpty(t, resp.Errors, "other user should not be able to delete template")
+
+	resp = del(h.UUID("owner"), tmpl)
+	assert.Empty(t, resp.Errors, "owner should be able to delete template")
+
+	tmpl = create(h.UUID("owner"), "admin deleted template")
+	resp = del(harness.DefaultGraphQLAdminUserID, tmpl)
+	assert.Empty(t, resp.Errors, "admin should be able to delete template")
+}
@@ -0,0 +1,79 @@
+package smoke
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLShadowAck checks that a user who is only on-call as a shadow cannot
+// acknowledge or close alerts via GraphQL, while the primary on-call user can.
+func TestGraphQLShadowAck(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "primary"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "trainee"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "primary"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "trainee"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "primary"}}, {{uuid "cm1"}}, 0),
+		({{uuid "trainee"}}, {{uuid "cm2"}}, 0);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "primary"}});
+
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched"}}, '{"V1":{"Shadows": [{"ID": {{uuidJSON "shadow"}}, "UserID": {{uuidJSON "trainee"}}, "Start": "2000-01-01T00:00:00Z", "End": "2100-01-01T00:00:00Z"}]}}');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "ids-to-uuids")
+	defer h.Close()
+
+	a := h.CreateAlert(h.UUID("sid"), "test")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("test")
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("test")
+
+	update := func(status string) string {
+		return fmt.Sprintf(`mutation{updateAlerts(input:{alertIDs: [%d], newStatus: %s}){alertID}}`, a.ID(), status)
+	}
+
+	resp := h.GraphQLQueryUserT(t, h.UUID("trainee"), update("StatusAcknowledged"))
+	require.NotEmpty(t, resp.Errors, "shadow user should not be able to acknowledge")
+	assert.Contains(t, resp.Errors[0].Message, "shadow")
+
+	resp = h.GraphQLQueryUserT(t, h.UUID("trainee"), update("StatusClosed"))
+	require.NotEmpty(t, resp.Errors, "shadow user should not be able to close")
+
+	resp = h.GraphQLQueryUserT(t, h.UUID("primary"), update("StatusAcknowledged"))
+	require.Empty(t, resp.Errors, "primary user should be able to acknowledge")
+}
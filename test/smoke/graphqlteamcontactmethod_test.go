@@ -0,0 +1,107 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestGraphQLTeamContactMethod checks that only admins and team members can manage team contact methods,
+// and that a team phone must be verified before it is enabled.
+func TestGraphQLTeamContactMethod(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "member"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "other"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "member"}});
+
+	insert into teams (id, name, description, default_schedule_id)
+	values
+		({{uuid "team"}}, 'Ops', 'ops team', {{uuid "sched"}});
+`
+	h := harness.NewHarness(t, sql, "team-contact-method-verification")
+	defer h.Close()
+
+	create := func(userID string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{createTeamContactMethod(input:{teamID: "%s", name: "NOC Phone", type: SMS, value: "%s"}){id disabled}}`, h.UUID("team"), h.Phone("1")))
+	}
+
+	r := create(h.UUID("other"))
+	assert.NotEmpty(t, r.Errors, "non-member should not be able to create a team contact method")
+
+	r = create(h.UUID("member"))
+	require.Empty(t, r.Errors, "member should be able to create a team contact method")
+	var resp struct {
+		CreateTeamContactMethod struct {
+			ID       string
+			Disabled bool
+		}
+	}
+	require.NoError(t, json.Unmarshal(r.Data, &resp))
+	id := resp.CreateTeamContactMethod.ID
+	assert.True(t, resp.CreateTeamContactMethod.Disabled, "new team phone should be disabled until verified")
+
+	r = h.GraphQLQueryUserT(t, h.UUID("other"), fmt.Sprintf(`mutation{sendTeamContactMethodVerification(id: "%s")}`, id))
+	assert.NotEmpty(t, r.Errors, "non-member should not be able to send a verification code")
+
+	r = h.GraphQLQueryUserT(t, h.UUID("member"), fmt.Sprintf(`mutation{sendTeamContactMethodVerification(id: "%s")}`, id))
+	require.Empty(t, r.Errors)
+
+	msg := h.Twilio(t).Device(h.Phone("1")).ExpectSMS("verification")
+	code, err := strconv.Atoi(strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, msg.Body()))
+	require.NoError(t, err)
+
+	verify := func(userID string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{verifyTeamContactMethod(input:{id: "%s", code: %d})}`, id, code))
+	}
+	r = verify(h.UUID("other"))
+	assert.NotEmpty(t, r.Errors, "non-member should not be able to verify")
+	r = verify(h.UUID("member"))
+	require.Empty(t, r.Errors)
+
+	var team struct {
+		Team struct {
+			ContactMethods []struct {
+				ID       string
+				Disabled bool
+			}
+		}
+	}
+	r = h.GraphQLQueryT(t, fmt.Sprintf(`query{team(id: "%s"){contactMethods{id disabled}}}`, h.UUID("team")))
+	require.Empty(t, r.Errors)
+	require.NoError(t, json.Unmarshal(r.Data, &team))
+	require.Len(t, team.Team.ContactMethods, 1)
+	assert.False(t, team.Team.ContactMethods[0].Disabled, "verified team phone should be enabled")
+
+	del := func(userID string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`mutation{deleteTeamContactMethod(id: "%s")}`, id))
+	}
+	r = del(h.UUID("other"))
+	assert.NotEmpty(t, r.Errors, "non-member should not be able to delete a team contact method")
+	r = del(harness.DefaultGraphQLAdminUserID)
+	assert.Empty(t, r.Errors, "admin should be able to delete a team contact method")
+}
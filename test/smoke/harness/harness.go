@@ -39,6 +39,7 @@ import (
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
@@ -172,6 +173,14 @@ func NewStoppedHarnessWithFlags(t *testing.T, initSQL string, sqlData interface{
 	t.Logf("Using DB URL: %s", dbURL)
 	name := strings.Replace("smoketest_"+time.Now().Format("2006_01_02_15_04_05")+uuid.New().String(), "-", "", -1)
 
+	// building a template runs all migrations, which can take a while
+	tmplCtx, tmplCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer tmplCancel()
+	tmplName, err := templateDB(tmplCtx, migrationName)
+	if err != nil {
+		t.Fatal("template db:", err)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -180,13 +189,13 @@ func NewStoppedHarnessWithFlags(t *testing.T, initSQL string, sqlData interface{
 		t.Fatal("connect to db:", err)
 	}
 	defer conn.Close(ctx)
-	_, err = conn.Exec(ctx, "create database "+sqlutil.QuoteID(name))
+	err = createFromTemplate(ctx, conn, name, tmplName)
 	if err != nil {
 		t.Fatal("create db:", err)
 	}
 	conn.Close(ctx)
 
-	t.Logf("created test database '%s': %s", name, dbURL)
+	t.Logf("created test database '%s' from template '%s': %s", name, tmplName, dbURL)
 
 	twCfg := mocktwilio.Config{
 		AuthToken:    twilioAuthToken,
@@ -229,10 +238,16 @@ func NewStoppedHarnessWithFlags(t *testing.T, initSQL string, sqlData interface{
 
 	h.twS = httptest.NewServer(h.tw)
 
+	// database-level settings (e.g., search_path) are not copied from the template
 	err = h.pgTime.Inject(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
+	// the template's clock was set when it was built
+	err = h.pgTime.Reset(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
 	err = h.pgTime.SetSpeed(ctx, 0)
 	if err != nil {
 		t.Fatal(err)
@@ -297,6 +312,7 @@ func (h *Harness) Start() {
 	appCfg.SlackBaseURL = h.slackS.URL
 	appCfg.SMTPListenAddr = "localhost:0"
 	appCfg.EmailIntegrationDomain = "smoketest.example.com"
+	appCfg.AlertArchiveDir = h.t.TempDir()
 	appCfg.InitialConfig = &h.cfg
 
 	r, w := io.Pipe()
@@ -503,6 +519,27 @@ func (h *Harness) AddNotificationRule(userID, cmID string, delayMinutes int) {
 	})
 }
 
+// AddContactMethod will add an enabled contact method to the database, returning its ID.
+func (h *Harness) AddContactMethod(userID string, typ contactmethod.Type, value string) string {
+	h.t.Helper()
+	cm := &contactmethod.ContactMethod{
+		Name:   fmt.Sprintf("%s %s", typ, uuid.New().String()[:8]),
+		Type:   typ,
+		Value:  value,
+		UserID: userID,
+	}
+	h.t.Logf("insert contact method: %v", cm)
+	permission.SudoContext(context.Background(), func(ctx context.Context) {
+		h.t.Helper()
+		var err error
+		cm, err = h.backend.ContactMethodStore.Create(ctx, h.backend.DB(), cm)
+		if err != nil {
+			h.t.Fatalf("failed to insert contact method: %v", err)
+		}
+	})
+	return cm.ID
+}
+
 // Trigger will trigger, and wait for, an engine cycle.
 func (h *Harness) Trigger() {
 	id := h.backend.Engine.NextCycleID()
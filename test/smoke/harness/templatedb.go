@@ -0,0 +1,140 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/target/goalert/devtools/pgmocktime"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// templateLockID is the advisory lock used to serialize template creation across test processes.
+const templateLockID = 0x736d6f6b65 // "smoke"
+
+var (
+	templateMx  sync.Mutex
+	templateDBs = make(map[string]string)
+)
+
+// templateDB will return the name of a template database migrated up to migrationName,
+// creating it if necessary.
+//
+// Templates are named after a checksum of the migrations they contain, so they can be
+// reused across test runs until a migration is added or changed.
+func templateDB(ctx context.Context, migrationName string) (string, error) {
+	templateMx.Lock()
+	defer templateMx.Unlock()
+	if name, ok := templateDBs[migrationName]; ok {
+		return name, nil
+	}
+
+	sum, err := migrate.Checksum(migrationName)
+	if err != nil {
+		return "", err
+	}
+	name := "smoketest_tmpl_" + sum[:16]
+
+	conn, err := pgx.Connect(ctx, DBURL(""))
+	if err != nil {
+		return "", fmt.Errorf("connect to db: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	// lock is released when the connection is closed
+	_, err = conn.Exec(ctx, "select pg_advisory_lock($1)", templateLockID)
+	if err != nil {
+		return "", fmt.Errorf("acquire template lock: %w", err)
+	}
+
+	var exists bool
+	err = conn.QueryRow(ctx, "select exists(select 1 from pg_database where datname = $1 and datistemplate)", name).Scan(&exists)
+	if err != nil {
+		return "", fmt.Errorf("check template: %w", err)
+	}
+	if !exists {
+		err = buildTemplate(ctx, conn, name, migrationName)
+		if err != nil {
+			return "", fmt.Errorf("build template '%s': %w", name, err)
+		}
+	}
+
+	templateDBs[migrationName] = name
+	return name, nil
+}
+
+// buildTemplate will create a new, migrated, template database. It is built under a
+// temporary name and only renamed once complete so that an interrupted build is never used.
+func buildTemplate(ctx context.Context, conn *pgx.Conn, name, migrationName string) error {
+	buildName := name + "_build"
+	_, err := conn.Exec(ctx, "drop database if exists "+sqlutil.QuoteID(buildName))
+	if err != nil {
+		return fmt.Errorf("drop stale build: %w", err)
+	}
+	_, err = conn.Exec(ctx, "create database "+sqlutil.QuoteID(buildName))
+	if err != nil {
+		return fmt.Errorf("create db: %w", err)
+	}
+
+	pgTime, err := pgmocktime.New(ctx, DBURL(buildName))
+	if err != nil {
+		return fmt.Errorf("create pgmocktime: %w", err)
+	}
+	err = pgTime.Inject(ctx)
+	pgTime.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = migrate.Up(ctx, DBURL(buildName), migrationName)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	err = retryInUse(ctx, func() error {
+		_, err := conn.Exec(ctx, "alter database "+sqlutil.QuoteID(buildName)+" rename to "+sqlutil.QuoteID(name))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+	_, err = conn.Exec(ctx, "alter database "+sqlutil.QuoteID(name)+" is_template true")
+	if err != nil {
+		return fmt.Errorf("mark as template: %w", err)
+	}
+
+	return nil
+}
+
+// createFromTemplate will create a new database as a copy of the given template.
+func createFromTemplate(ctx context.Context, conn *pgx.Conn, name, tmplName string) error {
+	return retryInUse(ctx, func() error {
+		_, err := conn.Exec(ctx, "create database "+sqlutil.QuoteID(name)+" template "+sqlutil.QuoteID(tmplName))
+		return err
+	})
+}
+
+// retryInUse will retry fn while it fails due to the database being accessed by
+// other sessions, which can happen briefly after connections are closed.
+func retryInUse(ctx context.Context, fn func() error) error {
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for {
+		err := fn()
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != "55006" { // object_in_use
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-t.C:
+		}
+	}
+}
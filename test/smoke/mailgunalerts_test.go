@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -61,18 +62,24 @@ func TestMailgunAlerts(t *testing.T) {
 	v.Set("subject", "test alert")
 	v.Set("body-plain", "details")
 
-	timestamp := time.Now().Format(time.RFC3339)
-	token := "some-token"
-	v.Set("timestamp", timestamp)
-	v.Set("token", token)
-
-	hm := hmac.New(sha256.New, []byte(cfg.Mailgun.APIKey))
-	_, _ = io.WriteString(hm, timestamp)
-	_, _ = io.WriteString(hm, token)
-	calculatedSignature := hm.Sum(nil)
-
-	v.Set("signature", hex.EncodeToString(calculatedSignature))
+	// each request needs a unique token, as replayed tokens are rejected
+	var n int
+	sign := func() {
+		n++
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		token := "some-token-" + strconv.Itoa(n)
+		v.Set("timestamp", timestamp)
+		v.Set("token", token)
+
+		hm := hmac.New(sha256.New, []byte(cfg.Mailgun.APIKey))
+		_, _ = io.WriteString(hm, timestamp)
+		_, _ = io.WriteString(hm, token)
+		calculatedSignature := hm.Sum(nil)
+
+		v.Set("signature", hex.EncodeToString(calculatedSignature))
+	}
 
+	sign()
 	resp, err := http.PostForm(h.URL()+"/api/v2/mailgun/incoming", v)
 	assert.Nil(t, err)
 	if !assert.Equal(t, 200, resp.StatusCode, "create alert (v2 URL)") {
@@ -81,7 +88,14 @@ func TestMailgunAlerts(t *testing.T) {
 
 	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("test alert")
 
+	resp, err = http.PostForm(h.URL()+"/api/v2/mailgun/incoming", v)
+	assert.Nil(t, err)
+	if !assert.Equal(t, 406, resp.StatusCode, "reject replayed request") {
+		return
+	}
+
 	v.Set("subject", "second alert")
+	sign()
 	resp, err = http.PostForm(h.URL()+"/v1/webhooks/mailgun", v)
 	assert.Nil(t, err)
 	if !assert.Equal(t, 200, resp.StatusCode, "create alert (v1 URL)") {
@@ -91,6 +105,7 @@ func TestMailgunAlerts(t *testing.T) {
 	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("second alert")
 
 	v.Set("recipient", "w"+h.UUID("intkey")+"@"+cfg.Mailgun.EmailDomain)
+	sign()
 	resp, err = http.PostForm(h.URL()+"/api/v2/mailgun/incoming", v)
 	assert.Nil(t, err)
 	if !assert.Equal(t, 406, resp.StatusCode, "reject invalid address with 406 (v2 URL)") {
@@ -101,6 +116,7 @@ func TestMailgunAlerts(t *testing.T) {
 
 	v.Set("body-plain", strings.Repeat("too big", 1<<20)) // ~7MiB
 
+	sign()
 	resp, err = http.PostForm(h.URL()+"/api/v2/mailgun/incoming", v)
 	assert.Nil(t, err)
 	if !assert.Equal(t, 406, resp.StatusCode, "reject large bodies with 406 (v2 URL)") {
@@ -109,6 +125,7 @@ func TestMailgunAlerts(t *testing.T) {
 
 	v.Set("body-plain", strings.Repeat("too big", 1<<20)) // ~7MiB
 
+	sign()
 	resp, err = http.PostForm(h.URL()+"/v1/webhooks/mailgun", v)
 	assert.Nil(t, err)
 	if !assert.Equal(t, 406, resp.StatusCode, "reject large bodies with 406 (v1 URL)") {
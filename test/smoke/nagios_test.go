@@ -0,0 +1,101 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestNagios checks that Nagios/Icinga notifications create, dedup, acknowledge and close alerts.
+func TestNagios(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'nagios', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "nagios-integration")
+	defer h.Close()
+
+	u := h.URL() + "/api/v2/nagios/incoming?token=" + h.UUID("int_key")
+
+	post := func(typ, host, state string) int {
+		t.Helper()
+		v := url.Values{"type": {typ}, "host": {host}, "service": {"HTTP"}, "state": {state}, "output": {"connection refused"}}
+		resp, err := http.PostForm(u, v)
+		require.NoError(t, err, "post to nagios endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func() []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary like 'web-01/HTTP is %' order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	assert.Equal(t, 200, post("PROBLEM", "web-01", "CRITICAL"))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("web-01/HTTP")
+
+	// re-notification of the same host/service is deduplicated
+	assert.Equal(t, 200, post("PROBLEM", "web-01", "CRITICAL"))
+	assert.Equal(t, []string{"triggered"}, statuses())
+
+	assert.Equal(t, 200, post("ACKNOWLEDGEMENT", "web-01", "CRITICAL"))
+	assert.Equal(t, []string{"active"}, statuses())
+
+	// JSON payloads are also accepted
+	resp, err := http.Post(u, "application/json", strings.NewReader(`{"type": "RECOVERY", "host": "web-01", "service": "HTTP", "state": "OK"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"closed"}, statuses())
+
+	assert.Equal(t, 400, post("PROBLEM", "", "CRITICAL"), "missing host")
+	assert.Equal(t, 400, post("BOGUS", "web-01", "CRITICAL"), "unknown type")
+	assert.Equal(t, []string{"closed"}, statuses())
+}
@@ -0,0 +1,102 @@
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestNewRelic checks that New Relic workflow webhooks create, dedup and close alerts.
+func TestNewRelic(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'newRelic', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "new-relic-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/newrelic/incoming?token=" + h.UUID("int_key")
+
+	post := func(id, state string) int {
+		t.Helper()
+		data, err := json.Marshal(map[string]interface{}{
+			"id":               id,
+			"title":            "High error rate",
+			"priority":         "CRITICAL",
+			"state":            state,
+			"impactedEntities": []string{"api"},
+		})
+		require.NoError(t, err)
+		resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+		require.NoError(t, err, "post to new relic endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func() []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = '[CRITICAL] High error rate' order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	assert.Equal(t, 200, post("abc", "CREATED"))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("High error rate")
+
+	// activation and acknowledgement of the same issue do not create new alerts
+	assert.Equal(t, 200, post("abc", "ACTIVATED"))
+	assert.Equal(t, 200, post("abc", "ACKNOWLEDGED"))
+	assert.Equal(t, []string{"triggered"}, statuses())
+
+	assert.Equal(t, 200, post("abc", "CLOSED"))
+	assert.Equal(t, []string{"closed"}, statuses())
+
+	assert.Equal(t, 400, post("", "ACTIVATED"), "missing id")
+	assert.Equal(t, 400, post("def", "UNKNOWN"), "invalid state")
+	assert.Equal(t, []string{"closed"}, statuses())
+}
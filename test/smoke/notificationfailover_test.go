@@ -0,0 +1,52 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestNotificationFailover checks that a failed alert notification is sent to the user's next contact method
+// when Failover is enabled.
+func TestNotificationFailover(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+		insert into users (id, name, email) 
+		values 
+			({{uuid "user"}}, 'bob', 'joe');
+		insert into user_contact_methods (id, user_id, name, type, value) 
+		values
+			({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+			({{uuid "cm2"}}, {{uuid "user"}}, 'work', 'VOICE', {{phone "2"}});
+		insert into user_notification_rules (user_id, contact_method_id, delay_minutes) 
+		values
+			({{uuid "user"}}, {{uuid "cm1"}}, 0),
+			({{uuid "user"}}, {{uuid "cm2"}}, 30);
+
+		insert into escalation_policies (id, name) 
+		values
+			({{uuid "eid"}}, 'esc policy');
+		insert into escalation_policy_steps (id, escalation_policy_id) 
+		values
+			({{uuid "esid"}}, {{uuid "eid"}});
+		insert into escalation_policy_actions (escalation_policy_step_id, user_id) 
+		values 
+			({{uuid "esid"}}, {{uuid "user"}});
+
+		insert into services (id, escalation_policy_id, name) 
+		values
+			({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "notification-failover")
+	defer h.Close()
+
+	h.SetConfigValue("Failover.Enable", "true")
+	h.SetConfigValue("Failover.DestinationFailures", "1")
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).RejectSMS("testing")
+	tw.Device(h.Phone("2")).ExpectVoice("testing")
+}
@@ -0,0 +1,70 @@
+package smoke
+
+import (
+	"testing"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+const notificationPauseSQL = `
+	insert into users (id, name, email) 
+	values 
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value) 
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "user"}}, 'work', 'VOICE', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes) 
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm2"}}, 30);
+
+	insert into escalation_policies (id, name) 
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id) 
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id) 
+	values 
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name) 
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+// TestNotificationPause checks that messages of a paused type are held until resumed.
+func TestNotificationPause(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, notificationPauseSQL, "twilio-spend")
+	defer h.Close()
+
+	h.SetConfigValue("Pause.SMS", "true")
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+	h.Trigger()
+
+	tw := h.Twilio(t)
+	tw.WaitAndAssert()
+
+	h.SetConfigValue("Pause.SMS", "false")
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+}
+
+// TestNotificationPauseFailover checks that alert notifications of a paused type are sent to the user's next
+// contact method when Pause.FailoverAlerts is enabled.
+func TestNotificationPauseFailover(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, notificationPauseSQL, "twilio-spend")
+	defer h.Close()
+
+	h.SetConfigValue("Pause.SMS", "true")
+	h.SetConfigValue("Pause.FailoverAlerts", "true")
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+
+	h.Twilio(t).Device(h.Phone("2")).ExpectVoice("testing")
+}
@@ -0,0 +1,93 @@
+package smoke
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+func TestPagerDutyEvents(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'pagerDutyEvents', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "pagerduty-events-integration")
+	defer h.Close()
+
+	send := func(action, dedupKey string) (int, string) {
+		t.Helper()
+		data, err := json.Marshal(map[string]interface{}{
+			"routing_key":  h.UUID("int_key"),
+			"event_action": action,
+			"dedup_key":    dedupKey,
+			"payload": map[string]string{
+				"summary":  "disk full",
+				"source":   "db1",
+				"severity": "critical",
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := http.Post(h.URL()+"/v2/enqueue", "application/json", bytes.NewReader(data))
+		require.NoError(t, err, "post to pagerduty events endpoint")
+		defer resp.Body.Close()
+
+		var r struct{ Status string }
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+		return resp.StatusCode, r.Status
+	}
+
+	code, status := send("trigger", "abc")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "success", status)
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("disk full")
+
+	code, status = send("resolve", "abc")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "success", status)
+
+	// already closed
+	code, status = send("resolve", "abc")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "ignored", status)
+
+	code, status = send("acknowledge", "unknown")
+	assert.Equal(t, http.StatusAccepted, code)
+	assert.Equal(t, "ignored", status)
+}
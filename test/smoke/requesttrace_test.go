@@ -0,0 +1,97 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestRequestTrace checks that alerts and messages record the request ID assigned by GoAlert,
+// not one provided by the caller, and can be looked up with the requestTrace query.
+func TestRequestTrace(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "ids-to-uuids")
+	defer h.Close()
+
+	v := make(url.Values)
+	v.Set("summary", "traced")
+	req, err := http.NewRequest("POST", h.URL()+"/v1/api/alerts?key="+h.UUID("int_key"), strings.NewReader(v.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Request-ID", "client-123")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode, "http status code")
+
+	id := resp.Header.Get("X-Request-ID")
+	require.NotEmpty(t, id, "request ID header")
+	assert.NotEqual(t, "client-123", id, "caller-provided ID should not be used")
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("traced")
+
+	trace := func(id string) (alerts []struct{ AlertID int }, msgs []struct{ AlertID int }) {
+		t.Helper()
+		var res struct {
+			RequestTrace struct {
+				Alerts   []struct{ AlertID int }
+				Messages []struct{ AlertID int }
+			}
+		}
+		r := h.GraphQLQueryT(t, fmt.Sprintf(`query{requestTrace(id: %q){alerts{alertID} messages{alertID}}}`, id))
+		require.Empty(t, r.Errors)
+		require.NoError(t, json.Unmarshal(r.Data, &res))
+		return res.RequestTrace.Alerts, res.RequestTrace.Messages
+	}
+
+	alerts, msgs := trace(id)
+	require.Len(t, alerts, 1, "alerts created by request")
+	require.NotEmpty(t, msgs, "messages sent for alert")
+	assert.Equal(t, alerts[0].AlertID, msgs[0].AlertID)
+
+	alerts, msgs = trace("client-123")
+	assert.Empty(t, alerts, "caller-provided ID")
+	assert.Empty(t, msgs, "caller-provided ID")
+}
@@ -0,0 +1,94 @@
+package smoke
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestScheduleCache checks that cached schedule data is reloaded when schedule rules change, and that
+// schedule data rows are still locked by engine cycles that use the cache.
+func TestScheduleCache(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'joe'),
+		({{uuid "u2"}}, 'ben', 'josh');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into schedules (id, name, description, time_zone)
+	values
+		({{uuid "sched"}}, 'test', 'test', 'UTC');
+
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched"}}, '{"V1":{}}');
+
+	insert into schedule_rules (schedule_id, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, '00:00', '00:00', {{uuid "u1"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "schedule-cache-changes")
+	defer h.Close()
+
+	ctx := context.Background()
+	db := h.App().DB()
+	sid := h.UUID("sid")
+
+	h.WaitAndAssertOnCallUsers(sid, h.UUID("u1"))
+
+	// rule changes must invalidate the cache
+	_, err := db.ExecContext(ctx, `update schedule_rules set tgt_user_id = $1 where schedule_id = $2`, h.UUID("u2"), h.UUID("sched"))
+	require.NoError(t, err)
+	h.Trigger()
+	h.WaitAndAssertOnCallUsers(sid, h.UUID("u2"))
+
+	// nothing changed, so the next cycle uses the cache
+	h.Trigger()
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, `select 1 from schedule_data where schedule_id = $1 for update`, h.UUID("sched"))
+	require.NoError(t, err)
+
+	e := h.App().Engine
+	id := e.NextCycleID()
+	go e.Trigger()
+	done := make(chan error, 1)
+	go func() { done <- e.WaitCycleID(ctx, id) }()
+
+	select {
+	case <-done:
+		t.Fatal("engine cycle completed while schedule data was locked")
+	case <-time.After(3 * time.Second):
+	}
+
+	require.NoError(t, tx.Rollback())
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(30 * time.Second):
+		t.Fatal("engine cycle did not complete after schedule data was unlocked")
+	}
+}
@@ -0,0 +1,124 @@
+package smoke
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/scrub"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestScrub checks that no personal data or secrets remain anywhere in the database after scrubbing.
+func TestScrub(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'Jane Doe', 'jane.doe@acme.test');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'Jane Doe cell', 'SMS', {{phone "1"}});
+
+	insert into teams (id, name, description)
+	values
+		({{uuid "team"}}, 'Ops', 'ops team');
+
+	insert into notification_channels (id, team_id, name, type, value)
+	values
+		({{uuid "nc1"}}, {{uuid "team"}}, 'NOC list', 'EMAIL', 'noc@acme.test');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'email', 'my key', {{uuid "sid"}});
+
+	insert into integration_key_email_rules (integration_key_id, dedup_pattern, allowed_senders)
+	values
+		({{uuid "int_key"}}, 'acme.test', '{alerts@acme.test}');
+
+	insert into alerts (id, service_id, summary, details)
+	values
+		(1, {{uuid "sid"}}, 'Jane Doe server down', 'see jane.doe@acme.test');
+
+	insert into service_status_callbacks (service_id, url, secret)
+	values
+		({{uuid "sid"}}, 'https://hooks.acme.test/cb', 'cb-secret-xyz');
+
+	insert into service_jira_configs (service_id, project_key, issue_type, summary_template, description_template, fields)
+	values
+		({{uuid "sid"}}, 'PROJ', 'Task', '{{"{{"}}.Summary{{"}}"}} for Jane Doe', 'ask jane.doe@acme.test', '{"assignee": "jane-account-id"}');
+
+	insert into alert_jira_issues (alert_id, issue_key, last_error)
+	values
+		(1, 'PROJ-4242', 'Jane Doe not found');
+
+	insert into service_servicenow_configs (service_id, assignment_group, category, caller_id, resolve_code)
+	values
+		({{uuid "sid"}}, 'Jane Doe team', 'acme.test', 'jane-caller-id', 'Solved');
+
+	insert into alert_servicenow_incidents (alert_id, sys_id, number, last_error)
+	values
+		(1, 'sys-jane-caller-id', 'INC4242424', 'Jane Doe not found');
+
+	insert into system_notices (id, type, message, details)
+	values
+		({{uuid "notice"}}, 'INFO', 'Call Jane Doe', 'jane.doe@acme.test');
+`
+	h := harness.NewHarness(t, sql, "team-contact-method-verification")
+	defer h.Close()
+
+	db := h.App().DB()
+	ctx := context.Background()
+
+	_, err := scrub.Run(ctx, db)
+	require.NoError(t, err)
+
+	secrets := []string{
+		"%jane%",
+		"%acme.test%",
+		"%" + h.Phone("1") + "%",
+		"%cb-secret-xyz%",
+		"%PROJ-4242%",
+		"%INC4242424%",
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		select table_name
+		from information_schema.tables
+		where table_schema = 'public' and table_type = 'BASE TABLE'
+	`)
+	require.NoError(t, err)
+	var tables []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		tables = append(tables, name)
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	require.NotEmpty(t, tables)
+
+	for _, table := range tables {
+		var n int
+		err = db.QueryRowContext(ctx, fmt.Sprintf(`select count(*) from %s t where t::text ilike any($1)`, pq.QuoteIdentifier(table)), pq.StringArray(secrets)).Scan(&n)
+		require.NoError(t, err, table)
+		assert.Zero(t, n, "personal data or secrets remain in table %s", table)
+	}
+
+	var n int
+	require.NoError(t, db.QueryRowContext(ctx, `select count(*) from config`).Scan(&n))
+	assert.Zero(t, n, "provider config should be removed")
+}
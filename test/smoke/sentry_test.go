@@ -0,0 +1,96 @@
+package smoke
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestSentry checks that Sentry issue webhooks create, dedup and close alerts.
+func TestSentry(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'sentry', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "sentry-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/sentry/incoming?token=" + h.UUID("int_key")
+
+	post := func(resource, body string) int {
+		t.Helper()
+		req, err := http.NewRequest("POST", url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Sentry-Hook-Resource", resource)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, "post to sentry endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func() []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = 'Error: oops' order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	assert.Equal(t, 200, post("issue", `{"action": "created", "data": {"issue": {"id": "1170820242", "title": "Error: oops", "level": "error"}}}`))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("Error: oops")
+
+	// event alert for the same issue is deduplicated
+	assert.Equal(t, 200, post("event_alert", `{"action": "triggered", "data": {"event": {"issue_id": "1170820242", "title": "Error: oops"}}}`))
+	assert.Equal(t, []string{"triggered"}, statuses())
+
+	assert.Equal(t, 200, post("issue", `{"action": "resolved", "data": {"issue": {"id": "1170820242"}}}`))
+	assert.Equal(t, []string{"closed"}, statuses())
+
+	assert.Equal(t, 400, post("issue", `{"action": "created", "data": {"issue": {"title": "no id"}}}`), "missing issue ID")
+	assert.Equal(t, 400, post("unknown", `{}`), "unknown resource")
+	assert.Equal(t, 400, post("issue", `not json`), "invalid JSON")
+}
@@ -0,0 +1,86 @@
+package smoke
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestTeamContactMethod checks that an alert acknowledged from a verified team phone is attributed to the team,
+// that unverified team phones are not notified, and that only verified team phones get replies.
+func TestTeamContactMethod(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into teams (id, name, description)
+	values
+		({{uuid "team"}}, 'Ops', 'ops team');
+
+	insert into notification_channels (id, team_id, name, type, value, disabled)
+	values
+		({{uuid "nc1"}}, {{uuid "team"}}, 'NOC Phone', 'SMS', {{phone "1"}}, false),
+		({{uuid "nc2"}}, {{uuid "team"}}, 'New Phone', 'SMS', {{phone "2"}}, true);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, channel_id)
+	values
+		({{uuid "esid"}}, {{uuid "nc1"}}),
+		({{uuid "esid"}}, {{uuid "nc2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, description)
+	values
+		(198, {{uuid "sid"}}, 'testing');
+`
+	h := harness.NewHarness(t, sql, "team-contact-method-verification")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	d1 := tw.Device(h.Phone("1"))
+	d2 := tw.Device(h.Phone("2"))
+
+	// only the verified phone is notified
+	d1.ExpectSMS("testing").
+		ThenReply("ack198").
+		ThenExpect("acknowledged")
+
+	var resp struct {
+		Alert struct {
+			Status       string
+			RecentEvents struct {
+				Nodes []struct{ Message string }
+			}
+		}
+	}
+	r := h.GraphQLQueryT(t, `query{alert(id: 198){status recentEvents(input:{limit: 15}){nodes{message}}}}`)
+	require.Empty(t, r.Errors)
+	require.NoError(t, json.Unmarshal(r.Data, &resp))
+	assert.Equal(t, "StatusAcknowledged", resp.Alert.Status)
+
+	var ackMsg string
+	for _, n := range resp.Alert.RecentEvents.Nodes {
+		if strings.HasPrefix(n.Message, "Acknowledged") {
+			ackMsg = n.Message
+			break
+		}
+	}
+	assert.Contains(t, ackMsg, "NOC Phone", "ack attributed to the team contact method")
+	assert.Contains(t, ackMsg, "Ops team SMS", "ack attributed to the team")
+
+	// known (verified) team phones get a reply, unverified ones do not
+	d1.SendSMS("nonsense")
+	d1.ExpectSMS("sorry")
+	d2.SendSMS("nonsense")
+}
@@ -1,6 +1,7 @@
 package smoke
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/target/goalert/test/smoke/harness"
 )
 
@@ -99,3 +101,103 @@ func TestTwilioVoiceVerification(t *testing.T) {
 	// voice for the given number should be enabled
 	d1.ExpectVoice("test")
 }
+
+// TestTwilioSMSVerificationByVoice checks that creating an SMS contact method sends nothing, and that a
+// verification code requested by voice (and any resend) is delivered by voice call instead of SMS.
+func TestTwilioSMSVerificationByVoice(t *testing.T) {
+	t.Parallel()
+
+	sqlQuery := `
+		insert into users (id, name, email, role)
+		values
+			({{uuid "user"}}, 'bob', 'joe', 'user');
+	`
+	h := harness.NewHarness(t, sqlQuery, "add-verification-code")
+	defer h.Close()
+
+	doQL := func(query string) json.RawMessage {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("user"), query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		return g.Data
+	}
+
+	var resp struct {
+		CreateUserContactMethod struct{ ID string }
+	}
+	err := json.Unmarshal(doQL(fmt.Sprintf(`
+		mutation {
+			createUserContactMethod(input:{
+				userID: "%s",
+				type: SMS,
+				name: "landline",
+				value: "%s",
+			}){id}
+		}
+	`, h.UUID("user"), h.Phone("1"))), &resp)
+	require.NoError(t, err)
+	cmID := resp.CreateUserContactMethod.ID
+	require.NotEmpty(t, cmID)
+
+	tw := h.Twilio(t)
+	d1 := tw.Device(h.Phone("1"))
+
+	// creating the contact method should not send a code on its own
+	h.Trigger()
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			sendContactMethodVerification(input:{
+				contactMethodID: "%s",
+				voice: true
+			})
+		}
+	`, cmID))
+	d1.ExpectVoice("verification")
+
+	h.FastForward(time.Minute)
+
+	// resending without a method uses the same method as before
+	doQL(fmt.Sprintf(`
+		mutation {
+			sendContactMethodVerification(input:{
+				contactMethodID: "%s"
+			})
+		}
+	`, cmID))
+	call := d1.ExpectVoice("verification")
+
+	codeStr := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ReplaceAll(call.Body(), "6-digit", ""))
+	require.Len(t, codeStr, 12)
+	code, _ := strconv.Atoi(codeStr[:6])
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			verifyContactMethod(input:{
+				contactMethodID: "%s",
+				code: %d
+			})
+		}
+	`, cmID, code))
+
+	h.FastForward(time.Minute)
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			testContactMethod(id: "%s")
+		}
+	`, cmID))
+
+	// the contact method itself is still SMS
+	d1.ExpectSMS("test")
+}
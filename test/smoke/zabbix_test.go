@@ -0,0 +1,107 @@
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestZabbix checks that Zabbix media type events create, dedup and close alerts.
+func TestZabbix(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'zabbix', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "zabbix-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/zabbix/incoming?token=" + h.UUID("int_key")
+
+	post := func(body map[string]string) int {
+		t.Helper()
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+		require.NoError(t, err, "post to zabbix endpoint")
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	statuses := func() []string {
+		t.Helper()
+		rows, err := h.App().DB().QueryContext(context.Background(), `select status from alerts where summary = '[High] CPU load' order by id`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	resp, err := http.Get(url)
+	require.NoError(t, err, "get media type script")
+	script, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, string(script), "/api/v2/zabbix/incoming?token=", "script should include the callback URL")
+
+	problem := map[string]string{"event_id": "123", "event_value": "1", "severity": "High", "subject": "CPU load", "host": "web-01"}
+	assert.Equal(t, 200, post(problem))
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("CPU load")
+
+	// repeated notifications for the same event are deduplicated
+	assert.Equal(t, 200, post(problem))
+	assert.Equal(t, []string{"triggered"}, statuses())
+
+	// below the default minimum severity
+	assert.Equal(t, 200, post(map[string]string{"event_id": "124", "event_value": "1", "severity": "Information", "subject": "CPU load"}))
+
+	assert.Equal(t, 200, post(map[string]string{"event_id": "123", "event_value": "0", "subject": "Resolved: CPU load"}))
+	assert.Equal(t, []string{"closed"}, statuses())
+
+	assert.Equal(t, 400, post(map[string]string{"event_value": "1", "severity": "High"}), "missing event_id")
+	assert.Equal(t, 400, post(map[string]string{"event_id": "125", "event_value": "x"}), "invalid event_value")
+}
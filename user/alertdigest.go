@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxAlertDigestMinutes is the longest allowed alert digest interval.
+const MaxAlertDigestMinutes = 24 * 60
+
+// AlertDigestMinutes returns the alert digest interval for the user, or 0 if digests are disabled.
+func (s *Store) AlertDigestMinutes(ctx context.Context, userID string) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return 0, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var minutes int
+	err = s.alertDigest.QueryRowContext(ctx, userID).Scan(&minutes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return minutes, nil
+}
+
+// SetAlertDigestMinutesTx will set the alert digest interval for the user. A value of 0 disables digests.
+func (s *Store) SetAlertDigestMinutesTx(ctx context.Context, tx *sql.Tx, userID string, minutes int) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.UUID("UserID", userID),
+		validate.Range("AlertDigestMinutes", minutes, 0, MaxAlertDigestMinutes),
+	)
+	if err != nil {
+		return err
+	}
+
+	if minutes == 0 {
+		_, err = withTx(ctx, tx, s.clearAlertDigest).ExecContext(ctx, userID)
+		return err
+	}
+
+	_, err = withTx(ctx, tx, s.setAlertDigest).ExecContext(ctx, userID, minutes)
+	return err
+}
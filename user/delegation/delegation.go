@@ -0,0 +1,55 @@
+package delegation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxDuration is the longest allowed Delegation.
+const MaxDuration = 30 * 24 * time.Hour
+
+// A Delegation forwards alert notifications for a user to another user (the delegate)
+// for a period of time, such as during a short absence.
+type Delegation struct {
+	ID             string
+	UserID         string
+	DelegateUserID string
+	Start          time.Time
+	End            time.Time
+}
+
+// Normalize will validate and return a normalized copy of the Delegation.
+func (d Delegation) Normalize() (*Delegation, error) {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	d.Start = d.Start.Truncate(time.Minute)
+	d.End = d.End.Truncate(time.Minute)
+
+	err := validate.Many(
+		validate.UUID("ID", d.ID),
+		validate.UUID("UserID", d.UserID),
+		validate.UUID("DelegateUserID", d.DelegateUserID),
+	)
+	if d.UserID == d.DelegateUserID {
+		err = validate.Many(err, validation.NewFieldError("DelegateUserID", "must be a different user"))
+	}
+	if !d.Start.Before(d.End) {
+		err = validate.Many(err, validation.NewFieldError("End", "must occur after Start time"))
+	} else if d.End.Sub(d.Start) > MaxDuration {
+		err = validate.Many(err, validation.NewFieldError("End", "must be within 30 days of Start time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// Active returns true if the Delegation is in effect at t.
+func (d Delegation) Active(t time.Time) bool {
+	return !t.Before(d.Start) && t.Before(d.End)
+}
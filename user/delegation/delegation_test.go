@@ -0,0 +1,46 @@
+package delegation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegation_Normalize(t *testing.T) {
+	start := time.Date(2023, 10, 11, 9, 0, 0, 0, time.UTC)
+	valid := Delegation{
+		UserID:         "bcefacc0-4764-012d-7bfb-002500d5decb",
+		DelegateUserID: "bcefacc0-4764-012d-7bfb-002500d5decc",
+		Start:          start,
+		End:            start.Add(48 * time.Hour),
+	}
+	n, err := valid.Normalize()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, n.ID)
+
+	self := valid
+	self.DelegateUserID = self.UserID
+	_, err = self.Normalize()
+	assert.Error(t, err, "delegate to self")
+
+	backwards := valid
+	backwards.End = start.Add(-time.Hour)
+	_, err = backwards.Normalize()
+	assert.Error(t, err, "end before start")
+
+	tooLong := valid
+	tooLong.End = start.Add(MaxDuration + time.Hour)
+	_, err = tooLong.Normalize()
+	assert.Error(t, err, "longer than max duration")
+}
+
+func TestDelegation_Active(t *testing.T) {
+	start := time.Date(2023, 10, 11, 9, 0, 0, 0, time.UTC)
+	d := Delegation{Start: start, End: start.Add(time.Hour)}
+
+	assert.False(t, d.Active(start.Add(-time.Second)))
+	assert.True(t, d.Active(start))
+	assert.True(t, d.Active(start.Add(59*time.Minute)))
+	assert.False(t, d.Active(start.Add(time.Hour)))
+}
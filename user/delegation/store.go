@@ -0,0 +1,143 @@
+package delegation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of user delegations.
+type Store struct {
+	insert   *sql.Stmt
+	overlaps *sql.Stmt
+	findOne  *sql.Stmt
+	delete   *sql.Stmt
+	findMany *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		insert: p.P(`
+			insert into user_delegations (id, user_id, delegate_user_id, start_time, end_time)
+			values ($1, $2, $3, $4, $5)
+		`),
+		overlaps: p.P(`
+			select exists (
+				select null
+				from user_delegations
+				where
+					user_id = $1 and
+					start_time < $3 and
+					end_time > $2
+			)
+		`),
+		findOne: p.P(`select user_id, delegate_user_id from user_delegations where id = $1`),
+		delete:  p.P(`delete from user_delegations where id = $1`),
+		findMany: p.P(`
+			select id, user_id, delegate_user_id, start_time, end_time
+			from user_delegations
+			where
+				(user_id = $1 or delegate_user_id = $1) and
+				end_time > now()
+			order by start_time, id
+		`),
+	}, p.Err
+}
+
+// CreateTx will create a new Delegation. Delegations for the same user may not overlap.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, d *Delegation) (*Delegation, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(d.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	cpy := *d
+	cpy.ID = ""
+	n, err := cpy.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps bool
+	err = tx.StmtContext(ctx, s.overlaps).QueryRowContext(ctx, n.UserID, n.Start, n.End).Scan(&overlaps)
+	if err != nil {
+		return nil, err
+	}
+	if overlaps {
+		return nil, validation.NewFieldError("Start", "overlaps an existing delegation")
+	}
+
+	_, err = tx.StmtContext(ctx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelegateUserID, n.Start, n.End)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// DeleteTx will delete the Delegation with the given ID. Either the user or the delegate
+// may delete a Delegation.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	var userID, delegateUserID string
+	err = tx.StmtContext(ctx, s.findOne).QueryRowContext(ctx, id).Scan(&userID, &delegateUserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID), permission.MatchUser(delegateUserID))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, id)
+	return err
+}
+
+// FindAllByUser will return all current and future delegations from or to the given user.
+func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Delegation, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMany.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []Delegation{}
+	for rows.Next() {
+		var d Delegation
+		err = rows.Scan(&d.ID, &d.UserID, &d.DelegateUserID, &d.Start, &d.End)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
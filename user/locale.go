@@ -0,0 +1,62 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/notification/i18n"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Locale returns the preferred locale for the user's notifications, or an empty string for the default.
+func (s *Store) Locale(ctx context.Context, userID string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return "", err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return "", err
+	}
+
+	var locale string
+	err = s.locale.QueryRowContext(ctx, userID).Scan(&locale)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return locale, nil
+}
+
+// SetLocaleTx will set the preferred locale for the user's notifications. An empty string resets it to the default.
+func (s *Store) SetLocaleTx(ctx context.Context, tx *sql.Tx, userID, locale string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	if locale != "" {
+		locale, err = i18n.Normalize(locale)
+		if err != nil {
+			return validation.NewFieldError("Locale", err.Error())
+		}
+		if !i18n.IsSupported(locale) {
+			return validation.NewFieldError("Locale", fmt.Sprintf("unsupported locale '%s', must be one of: %s", locale, strings.Join(i18n.Locales(), ", ")))
+		}
+	}
+
+	_, err = withTx(ctx, tx, s.setLocale).ExecContext(ctx, userID, locale)
+	return err
+}
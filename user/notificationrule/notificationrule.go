@@ -1,22 +1,87 @@
 package notificationrule
 
 import (
+	"fmt"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxFallbacks is the maximum number of fallback contact methods for a single rule.
+const MaxFallbacks = 5
+
 type NotificationRule struct {
 	ID              string `json:"id"`
 	UserID          string `json:"-"`
 	DelayMinutes    int    `json:"delay"`
 	ContactMethodID string `json:"contact_method_id"`
+
+	// Severities limits the rule to alerts of the given severities. If empty, the rule
+	// applies to all alerts.
+	Severities []alert.Severity `json:"severities,omitempty"`
+
+	// Fallbacks are tried in order when the previous contact method of the rule fails,
+	// or the alert is still unacknowledged after the fallback's delay.
+	Fallbacks []Fallback `json:"fallbacks,omitempty"`
+}
+
+// A Fallback is a contact method to notify if the previous one in the chain did not get a response.
+type Fallback struct {
+	ContactMethodID string `json:"contact_method_id"`
+
+	// DelayMinutes is the time to wait for a response after the previous contact method
+	// was notified, before notifying this one.
+	DelayMinutes int `json:"delay"`
 }
 
 func validateDelay(d int) error {
 	return validate.Range("DelayMinutes", d, 0, 9000)
 }
 
+func validateFallbacks(fbs []Fallback) error {
+	err := validate.Range("Fallbacks", len(fbs), 0, MaxFallbacks)
+	if err != nil {
+		return err
+	}
+
+	for i, fb := range fbs {
+		prefix := fmt.Sprintf("Fallbacks[%d].", i)
+		err = validate.Many(
+			validate.UUID(prefix+"ContactMethodID", fb.ContactMethodID),
+			validate.Range(prefix+"DelayMinutes", fb.DelayMinutes, 0, 9000),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateSeverities(sevs []alert.Severity) error {
+	seen := make(map[alert.Severity]bool, len(sevs))
+	for i, sev := range sevs {
+		field := fmt.Sprintf("Severities[%d]", i)
+		err := validate.OneOf(field, sev, alert.SeverityCritical, alert.SeverityHigh, alert.SeverityMedium, alert.SeverityLow)
+		if err != nil {
+			return err
+		}
+		if seen[sev] {
+			return validation.NewFieldError(field, "duplicate severity")
+		}
+		seen[sev] = true
+	}
+
+	return nil
+}
+
 func (n NotificationRule) Normalize(update bool) (*NotificationRule, error) {
-	err := validateDelay(n.DelayMinutes)
+	err := validate.Many(
+		validateDelay(n.DelayMinutes),
+		validateSeverities(n.Severities),
+		validateFallbacks(n.Fallbacks),
+	)
 
 	if !update {
 		err = validate.Many(
@@ -2,6 +2,8 @@ package notificationrule
 
 import (
 	"testing"
+
+	"github.com/target/goalert/alert"
 )
 
 func TestNotificationRule_Normalize(t *testing.T) {
@@ -23,9 +25,23 @@ func TestNotificationRule_Normalize(t *testing.T) {
 
 	valid := []NotificationRule{
 		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Fallbacks: []Fallback{
+			{ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5decf", DelayMinutes: 2},
+			{ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5ded0", DelayMinutes: 0},
+		}},
+		{DelayMinutes: 10, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Severities: []alert.Severity{alert.SeverityCritical, alert.SeverityHigh}},
 	}
 	invalid := []NotificationRule{
 		{},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Fallbacks: []Fallback{
+			{ContactMethodID: "not-a-uuid", DelayMinutes: 2},
+		}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Fallbacks: []Fallback{
+			{ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5decf", DelayMinutes: -1},
+		}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Fallbacks: make([]Fallback, MaxFallbacks+1)},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Severities: []alert.Severity{"urgent"}},
+		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb", Severities: []alert.Severity{alert.SeverityLow, alert.SeverityLow}},
 	}
 	for _, nr := range valid {
 		test(true, nr)
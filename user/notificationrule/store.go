@@ -3,11 +3,15 @@ package notificationrule
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -19,6 +23,12 @@ type Store struct {
 	delete       *sql.Stmt
 	findAll      *sql.Stmt
 	lookupUserID *sql.Stmt
+
+	setSeverities *sql.Stmt
+
+	findFallbacks   *sql.Stmt
+	deleteFallbacks *sql.Stmt
+	insertFallback  *sql.Stmt
 }
 
 // NewDB will create a DB backend from a sql.DB. An error will be returned if statements fail to prepare.
@@ -27,11 +37,29 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	p := prep.P
 	s := &Store{db: db}
 
-	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id) VALUES ($1,$2,$3,$4)")
-	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id FROM user_notification_rules WHERE user_id = $1")
+	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id,severities) VALUES ($1,$2,$3,$4,$5)")
+	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id,severities FROM user_notification_rules WHERE user_id = $1")
+	s.setSeverities = p("UPDATE user_notification_rules SET severities = $2 WHERE id = $1")
 	s.delete = p("DELETE FROM user_notification_rules WHERE id = any($1)")
 	s.lookupUserID = p("SELECT user_id FROM user_notification_rules WHERE id = any($1)")
 
+	s.findFallbacks = p(`
+		SELECT f.rule_id, f.contact_method_id, f.delay_minutes
+		FROM user_notification_rule_fallbacks f
+		JOIN user_notification_rules r ON r.id = f.rule_id
+		WHERE r.user_id = $1
+		ORDER BY f.rule_id, f.position
+	`)
+	s.deleteFallbacks = p("DELETE FROM user_notification_rule_fallbacks WHERE rule_id = $1")
+	// only contact methods of the rule's user can be used as a fallback
+	s.insertFallback = p(`
+		INSERT INTO user_notification_rule_fallbacks (id, rule_id, position, contact_method_id, delay_minutes)
+		SELECT $1, r.id, $3, cm.id, $5
+		FROM user_notification_rules r
+		JOIN user_contact_methods cm ON cm.id = $4 AND cm.user_id = r.user_id
+		WHERE r.id = $2
+	`)
+
 	return s, prep.Err
 }
 
@@ -56,7 +84,12 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, n *NotificationRule) (
 
 	n.ID = uuid.New().String()
 
-	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID)
+	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID, severityArray(n.Severities))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.setFallbacks(ctx, tx, n.ID, n.Fallbacks)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +97,118 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, n *NotificationRule) (
 	return n, nil
 }
 
+// SetFallbacksTx will replace the fallback chain of a notification rule.
+func (s *Store) SetFallbacksTx(ctx context.Context, tx *sql.Tx, ruleID string, fbs []Fallback) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("NotificationRuleID", ruleID),
+		validateFallbacks(fbs),
+	)
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	err = wrapTx(ctx, tx, s.lookupUserID).QueryRowContext(ctx, sqlutil.UUIDArray{ruleID}).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("NotificationRuleID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	if tx == nil {
+		tx, err = s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer sqlutil.Rollback(ctx, "notification rule: set fallbacks", tx)
+		err = s.setFallbacks(ctx, tx, ruleID, fbs)
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	return s.setFallbacks(ctx, tx, ruleID, fbs)
+}
+
+func (s *Store) setFallbacks(ctx context.Context, tx *sql.Tx, ruleID string, fbs []Fallback) error {
+	_, err := wrapTx(ctx, tx, s.deleteFallbacks).ExecContext(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	for i, fb := range fbs {
+		res, err := wrapTx(ctx, tx, s.insertFallback).ExecContext(ctx, uuid.New().String(), ruleID, i, fb.ContactMethodID, fb.DelayMinutes)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return validation.NewFieldError(fmt.Sprintf("Fallbacks[%d].ContactMethodID", i), "must be a contact method of the same user")
+		}
+	}
+
+	return nil
+}
+
+// SetSeveritiesTx will set the alert severities a notification rule applies to. If empty, the rule
+// applies to all alerts.
+func (s *Store) SetSeveritiesTx(ctx context.Context, tx *sql.Tx, ruleID string, sevs []alert.Severity) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("NotificationRuleID", ruleID),
+		validateSeverities(sevs),
+	)
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	err = wrapTx(ctx, tx, s.lookupUserID).QueryRowContext(ctx, sqlutil.UUIDArray{ruleID}).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("NotificationRuleID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	_, err = wrapTx(ctx, tx, s.setSeverities).ExecContext(ctx, ruleID, severityArray(sevs))
+	return err
+}
+
+// severityArray returns sevs as a DB value, with NULL for all severities.
+func severityArray(sevs []alert.Severity) interface{} {
+	if len(sevs) == 0 {
+		return nil
+	}
+	arr := make(sqlutil.StringArray, len(sevs))
+	for i, sev := range sevs {
+		arr[i] = string(sev)
+	}
+	return arr
+}
+
 func wrapTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
 	if tx == nil {
 		return stmt
@@ -137,13 +282,40 @@ func (s *Store) FindAll(ctx context.Context, userID string) ([]NotificationRule,
 
 	notificationrules := []NotificationRule{}
 	for rows.Next() {
-		var n NotificationRule
-		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID)
+		n := NotificationRule{Fallbacks: []Fallback{}}
+		var sevs sqlutil.StringArray
+		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID, &sevs)
 		if err != nil {
 			return nil, err
 		}
+		for _, sev := range sevs {
+			n.Severities = append(n.Severities, alert.Severity(sev))
+		}
 		notificationrules = append(notificationrules, n)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	idx := make(map[string]int, len(notificationrules))
+	for i, n := range notificationrules {
+		idx[n.ID] = i
+	}
+	rows, err = s.findFallbacks.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ruleID string
+		var fb Fallback
+		err = rows.Scan(&ruleID, &fb.ContactMethodID, &fb.DelayMinutes)
+		if err != nil {
+			return nil, err
+		}
+		n := &notificationrules[idx[ruleID]]
+		n.Fallbacks = append(n.Fallbacks, fb)
+	}
 
-	return notificationrules, nil
+	return notificationrules, rows.Err()
 }
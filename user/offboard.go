@@ -0,0 +1,280 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// A Reference is a place a user is referenced, that is updated when the user is offboarded.
+type Reference struct {
+	// Type is the type of the referencing object (schedule, rotation, escalationPolicy, or user).
+	Type assignment.TargetType
+	ID   string
+	Name string
+
+	// Detail describes how the user is referenced (e.g., "rule" for a schedule, or "step 2" for an
+	// escalation policy).
+	Detail string
+}
+
+func (s *Store) prepareOffboard(p *util.Prepare) {
+	s.references = p.P(`
+		select 'schedule', s.id, s.name, 'rule'
+		from schedule_rules r
+		join schedules s on s.id = r.schedule_id
+		where r.tgt_user_id = $1::uuid
+		union
+		select 'schedule', s.id, s.name, 'override'
+		from user_overrides o
+		join schedules s on s.id = o.tgt_schedule_id
+		where $1::uuid in (o.add_user_id, o.remove_user_id)
+		union
+		select 'schedule', s.id, s.name, 'temporary schedule or shadow'
+		from schedule_data d
+		join schedules s on s.id = d.schedule_id
+		where d.data::text like '%' || $1::text || '%'
+		union
+		select 'rotation', rot.id, rot.name, 'participant'
+		from rotation_participants part
+		join rotations rot on rot.id = part.rotation_id
+		where part.user_id = $1::uuid
+		union
+		select 'escalationPolicy', ep.id, ep.name, 'step ' || (step.step_number + 1)
+		from escalation_policy_actions act
+		join escalation_policy_steps step on step.id = act.escalation_policy_step_id
+		join escalation_policies ep on ep.id = step.escalation_policy_id
+		where act.user_id = $1::uuid
+		union
+		select 'user', u.id, u.name, 'favorite'
+		from user_favorites fav
+		join users u on u.id = fav.user_id
+		where fav.tgt_user_id = $1::uuid
+		union
+		select 'user', u.id, u.name, 'delegation'
+		from user_delegations del
+		join users u on u.id = case when del.user_id = $1::uuid then del.delegate_user_id else del.user_id end
+		where $1::uuid in (del.user_id, del.delegate_user_id) and del.end_time > now()
+		order by 1, 3, 4
+	`)
+	s.isDeactivated = p.P(`select deactivated_at notnull from users where id = $1`)
+
+	// Statements to replace references to user $1 with user $2, in order.
+	s.replaceRefs = []*sql.Stmt{
+		p.P(`update schedule_rules set tgt_user_id = $2 where tgt_user_id = $1`),
+		p.P(`update rotation_participants set user_id = $2 where user_id = $1`),
+
+		// a step may only reference a user once
+		p.P(`
+			delete from escalation_policy_actions act
+			where act.user_id = $1 and exists (
+				select 1 from escalation_policy_actions dup
+				where dup.escalation_policy_step_id = act.escalation_policy_step_id and dup.user_id = $2
+			)
+		`),
+		p.P(`update escalation_policy_actions set user_id = $2 where user_id = $1`),
+
+		// overrides between the two users would have no effect
+		p.P(`
+			delete from user_overrides
+			where (add_user_id = $1 and remove_user_id = $2) or (add_user_id = $2 and remove_user_id = $1)
+		`),
+		p.P(`update user_overrides set add_user_id = $2 where add_user_id = $1`),
+		p.P(`update user_overrides set remove_user_id = $2 where remove_user_id = $1`),
+	}
+
+	// Statements to remove references to user $1, in order. Rotations are handled separately.
+	s.removeRefs = []*sql.Stmt{
+		p.P(`delete from schedule_rules where tgt_user_id = $1`),
+		p.P(`delete from escalation_policy_actions where user_id = $1`),
+		p.P(`delete from user_overrides where $1 in (add_user_id, remove_user_id)`),
+	}
+
+	// Statements to deactivate user $1, in order.
+	s.deactivateSteps = []*sql.Stmt{
+		p.P(`update users set deactivated_at = now() where id = $1`),
+		p.P(`update user_contact_methods set disabled = true where user_id = $1`),
+		p.P(`update user_calendar_subscriptions set disabled = true where user_id = $1`),
+		p.P(`delete from auth_user_sessions where user_id = $1`),
+		p.P(`delete from user_favorites where tgt_user_id = $1`),
+		p.P(`delete from user_delegations where $1 in (user_id, delegate_user_id)`),
+	}
+}
+
+// References returns everywhere the user is referenced in schedules, rotations, escalation policies,
+// favorites, and delegations.
+func (s *Store) References(ctx context.Context, userID string) ([]Reference, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.references.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Reference
+	for rows.Next() {
+		var r Reference
+		var typ string
+		err = rows.Scan(&typ, &r.ID, &r.Name, &r.Detail)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Type.UnmarshalText([]byte(typ))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
+
+// IsDeactivated returns true if the user has been deactivated.
+func (s *Store) IsDeactivated(ctx context.Context, userID string) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return false, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return false, err
+	}
+
+	var deactivated bool
+	err = s.isDeactivated.QueryRowContext(ctx, userID).Scan(&deactivated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	return deactivated, err
+}
+
+// OffboardTx will deactivate a user. All references to the user are replaced with replacementID,
+// or removed if it is empty. Afterwards, the user can no longer log in and all of their contact
+// methods are disabled.
+//
+// Temporary schedules and shadows are not updated; the caller must use the schedule store's
+// ReplaceUserTx in the same transaction.
+func (s *Store) OffboardTx(ctx context.Context, tx *sql.Tx, userID, replacementID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("UserID", userID)
+	if replacementID != "" {
+		err = validate.Many(err, validate.UUID("ReplacementUserID", replacementID))
+	}
+	if err != nil {
+		return err
+	}
+	if userID == permission.UserID(ctx) {
+		return validation.NewFieldError("UserID", "cannot deactivate yourself")
+	}
+	if userID == replacementID {
+		return validation.NewFieldError("ReplacementUserID", "must be a different user")
+	}
+
+	var deactivated bool
+	err = tx.StmtContext(ctx, s.isDeactivated).QueryRowContext(ctx, userID).Scan(&deactivated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("UserID", "user not found")
+	}
+	if err != nil {
+		return err
+	}
+	if deactivated {
+		return validation.NewFieldError("UserID", "user is already deactivated")
+	}
+
+	if replacementID != "" {
+		err = tx.StmtContext(ctx, s.isDeactivated).QueryRowContext(ctx, replacementID).Scan(&deactivated)
+		if errors.Is(err, sql.ErrNoRows) {
+			return validation.NewFieldError("ReplacementUserID", "user not found")
+		}
+		if err != nil {
+			return err
+		}
+		if deactivated {
+			return validation.NewFieldError("ReplacementUserID", "user is deactivated")
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, s.lockRotTables).ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if replacementID != "" {
+		for i, stmt := range s.replaceRefs {
+			_, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, userID, replacementID)
+			if err != nil {
+				return fmt.Errorf("replace references (step %d): %w", i, err)
+			}
+		}
+	} else {
+		err = s.removeFromRotations(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		for i, stmt := range s.removeRefs {
+			_, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("remove references (step %d): %w", i, err)
+			}
+		}
+	}
+
+	for i, stmt := range s.deactivateSteps {
+		_, err = tx.StmtContext(ctx, stmt).ExecContext(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("deactivate user (step %d): %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) removeFromRotations(ctx context.Context, tx *sql.Tx, userID string) error {
+	rows, err := tx.StmtContext(ctx, s.userRotations).QueryContext(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("lookup user rotations: %w", err)
+	}
+	defer rows.Close()
+
+	var rotationIDs []string
+	for rows.Next() {
+		var rID string
+		err = rows.Scan(&rID)
+		if err != nil {
+			return fmt.Errorf("scan user rotation id: %w", err)
+		}
+		rotationIDs = append(rotationIDs, rID)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, rID := range rotationIDs {
+		err = s.removeUserFromRotation(ctx, tx, userID, rID)
+		if err != nil {
+			return fmt.Errorf("remove user '%s' from rotation '%s': %w", userID, rID, err)
+		}
+	}
+
+	return nil
+}
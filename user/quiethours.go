@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// QuietHours is a daily period during which notifications for medium and low severity
+// alerts are held until the period ends. Critical and high severity alerts are not affected.
+type QuietHours struct {
+	Start    timeutil.Clock
+	End      timeutil.Clock
+	TimeZone *time.Location
+}
+
+// Active returns true if t is within the quiet hours. If End is before Start,
+// the quiet hours span midnight.
+func (q QuietHours) Active(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t.In(q.TimeZone))
+	if q.Start < q.End {
+		return c >= q.Start && c < q.End
+	}
+
+	return c >= q.Start || c < q.End
+}
+
+// Normalize will validate and return a normalized copy of the QuietHours.
+func (q QuietHours) Normalize() (*QuietHours, error) {
+	if q.TimeZone == nil {
+		return nil, validation.NewFieldError("TimeZone", "must be specified")
+	}
+	day := timeutil.NewClock(24, 0)
+	if q.Start < 0 || q.Start >= day {
+		return nil, validation.NewFieldError("Start", "must be a time of day")
+	}
+	if q.End < 0 || q.End >= day {
+		return nil, validation.NewFieldError("End", "must be a time of day")
+	}
+	if q.Start == q.End {
+		return nil, validation.NewFieldError("End", "must differ from Start")
+	}
+
+	return &q, nil
+}
+
+// QuietHours returns the quiet hours for the user, or nil if none are configured.
+func (s *Store) QuietHours(ctx context.Context, userID string) (*QuietHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var q QuietHours
+	var tz string
+	err = s.quietHours.QueryRowContext(ctx, userID).Scan(&q.Start, &q.End, &tz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	q.TimeZone, err = util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// SetQuietHoursTx will set the quiet hours for the user. A nil value clears them.
+func (s *Store) SetQuietHoursTx(ctx context.Context, tx *sql.Tx, userID string, q *QuietHours) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	if q == nil {
+		_, err = withTx(ctx, tx, s.clearQuietHours).ExecContext(ctx, userID)
+		return err
+	}
+
+	n, err := q.Normalize()
+	if err != nil {
+		return err
+	}
+
+	_, err = withTx(ctx, tx, s.setQuietHours).ExecContext(ctx, userID, n.Start, n.End, n.TimeZone.String())
+	return err
+}
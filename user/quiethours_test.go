@@ -0,0 +1,55 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestQuietHours_Active(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	at := func(h, m int) time.Time { return time.Date(2023, 10, 11, h, m, 0, 0, loc) }
+
+	overnight := QuietHours{Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(7, 0), TimeZone: loc}
+	assert.False(t, overnight.Active(at(21, 59)))
+	assert.True(t, overnight.Active(at(22, 0)))
+	assert.True(t, overnight.Active(at(0, 0)))
+	assert.True(t, overnight.Active(at(6, 59)))
+	assert.False(t, overnight.Active(at(7, 0)))
+	assert.False(t, overnight.Active(at(12, 0)))
+
+	// compared in the quiet hours' time zone
+	assert.True(t, overnight.Active(at(23, 0).UTC()))
+
+	daytime := QuietHours{Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0), TimeZone: loc}
+	assert.False(t, daytime.Active(at(8, 59)))
+	assert.True(t, daytime.Active(at(9, 0)))
+	assert.False(t, daytime.Active(at(17, 0)))
+	assert.False(t, daytime.Active(at(23, 0)))
+}
+
+func TestQuietHours_Normalize(t *testing.T) {
+	valid := QuietHours{Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(7, 0), TimeZone: time.UTC}
+	_, err := valid.Normalize()
+	assert.NoError(t, err)
+
+	noTZ := valid
+	noTZ.TimeZone = nil
+	_, err = noTZ.Normalize()
+	assert.Error(t, err, "missing time zone")
+
+	same := valid
+	same.End = same.Start
+	_, err = same.Normalize()
+	assert.Error(t, err, "same start and end")
+
+	outOfRange := valid
+	outOfRange.End = timeutil.NewClock(24, 0)
+	_, err = outOfRange.Normalize()
+	assert.Error(t, err, "end out of range")
+}
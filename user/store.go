@@ -28,6 +28,23 @@ type Store struct {
 	setUserRole *sql.Stmt
 	findOne     *sql.Stmt
 
+	alertDigest      *sql.Stmt
+	setAlertDigest   *sql.Stmt
+	clearAlertDigest *sql.Stmt
+
+	quietHours      *sql.Stmt
+	setQuietHours   *sql.Stmt
+	clearQuietHours *sql.Stmt
+
+	locale    *sql.Stmt
+	setLocale *sql.Stmt
+
+	references      *sql.Stmt
+	isDeactivated   *sql.Stmt
+	replaceRefs     []*sql.Stmt
+	removeRefs      []*sql.Stmt
+	deactivateSteps []*sql.Stmt
+
 	findMany *sql.Stmt
 
 	deleteOne          *sql.Stmt
@@ -89,6 +106,26 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		lockRotTables:  p.P(`LOCK TABLE rotation_participants, rotation_state IN EXCLUSIVE MODE`),
 
 		setUserRole: p.P(`UPDATE users SET role = $2 WHERE id = $1`),
+
+		alertDigest: p.P(`SELECT interval_minutes FROM user_alert_digests WHERE user_id = $1`),
+		setAlertDigest: p.P(`
+			INSERT INTO user_alert_digests (user_id, interval_minutes)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE SET interval_minutes = $2
+		`),
+		clearAlertDigest: p.P(`DELETE FROM user_alert_digests WHERE user_id = $1`),
+
+		quietHours: p.P(`SELECT start_time, end_time, time_zone FROM user_quiet_hours WHERE user_id = $1`),
+		setQuietHours: p.P(`
+			INSERT INTO user_quiet_hours (user_id, start_time, end_time, time_zone)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO UPDATE SET start_time = $2, end_time = $3, time_zone = $4
+		`),
+		clearQuietHours: p.P(`DELETE FROM user_quiet_hours WHERE user_id = $1`),
+
+		locale:    p.P(`SELECT coalesce(locale, '') FROM users WHERE id = $1`),
+		setLocale: p.P(`UPDATE users SET locale = nullif($2, '') WHERE id = $1`),
+
 		findAuthSubjects: p.P(`
 			select subject_id, user_id, provider_id
 			from auth_subjects
@@ -166,6 +203,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				subject_id = $3
 		`),
 	}
+	store.prepareOffboard(p)
 	if p.Err != nil {
 		return nil, p.Err
 	}
@@ -386,30 +424,9 @@ func (s *Store) _deleteTx(ctx context.Context, tx *sql.Tx, id string) error {
 	}
 
 	// cleanup rotations first
-	rows, err := tx.StmtContext(ctx, s.userRotations).QueryContext(ctx, id)
-	if errors.Is(err, sql.ErrNoRows) {
-		err = nil
-	}
+	err = s.removeFromRotations(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("lookup user rotations: %w", err)
-	}
-	defer rows.Close()
-
-	var rotationIDs []string
-	for rows.Next() {
-		var rID string
-		err = rows.Scan(&rID)
-		if err != nil {
-			return fmt.Errorf("scan user rotation id: %w", err)
-		}
-		rotationIDs = append(rotationIDs, rID)
-	}
-
-	for _, rID := range rotationIDs {
-		err = s.removeUserFromRotation(ctx, tx, id, rID)
-		if err != nil {
-			return fmt.Errorf("remove user '%s' from rotation '%s': %w", id, rID, err)
-		}
+		return err
 	}
 
 	_, err = tx.StmtContext(ctx, s.deleteOne).ExecContext(ctx, id)
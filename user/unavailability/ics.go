@@ -0,0 +1,144 @@
+package unavailability
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util"
+)
+
+// maxICSEvents is the maximum number of events that will be imported from a single calendar.
+const maxICSEvents = 500
+
+// ParseICS will parse all VEVENT entries from an iCalendar (RFC 5545) file. Events ending
+// before `after` are skipped. Only the UID, SUMMARY, DTSTART, and DTEND properties are used;
+// recurring events are not expanded.
+//
+// The UserID of returned entries is not set.
+func ParseICS(r io.Reader, after time.Time) ([]Unavailability, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Unavailability
+	var cur *Unavailability
+	var allDayEnd bool
+	for _, line := range lines {
+		name, params, value := splitICSLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			cur = &Unavailability{Source: SourceICS}
+			allDayEnd = false
+			continue
+		case cur == nil:
+			continue
+		}
+
+		switch name {
+		case "END":
+			if value != "VEVENT" {
+				continue
+			}
+			if cur.End.IsZero() && allDayEnd {
+				// all-day event with no DTEND lasts one day
+				cur.End = cur.Start.AddDate(0, 0, 1)
+			}
+			if cur.Start.IsZero() || cur.End.IsZero() {
+				return nil, fmt.Errorf("event '%s' is missing DTSTART or DTEND", cur.ExternalID)
+			}
+			if cur.End.After(after) {
+				result = append(result, *cur)
+			}
+			cur = nil
+			if len(result) > maxICSEvents {
+				return nil, fmt.Errorf("too many events (max %d)", maxICSEvents)
+			}
+		case "UID":
+			cur.ExternalID = value
+		case "SUMMARY":
+			cur.Note = unescapeICSText(value)
+			if len(cur.Note) > 255 {
+				cur.Note = cur.Note[:255]
+			}
+		case "DTSTART", "DTEND":
+			t, isDate, err := parseICSTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if name == "DTSTART" {
+				cur.Start = t
+				allDayEnd = isDate
+			} else {
+				cur.End = t
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// unfoldICS will return all content lines, joining folded (continuation) lines.
+func unfoldICS(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, s.Err()
+}
+
+// splitICSLine splits a content line (e.g., `DTSTART;TZID=America/Chicago:20231002T090000`) into
+// its name, parameters, and value.
+func splitICSLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return name, params, value
+}
+
+// parseICSTime parses DATE and DATE-TIME values. Floating times and dates use the TZID
+// parameter if present, otherwise UTC.
+func parseICSTime(params map[string]string, value string) (t time.Time, isDate bool, err error) {
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		loc, err = util.LoadLocation(tzid)
+		if err != nil {
+			return t, false, fmt.Errorf("unknown time zone '%s'", tzid)
+		}
+	}
+
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == 8:
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+		return t, false, err
+	}
+}
+
+var icsTextReplacer = strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeICSText(s string) string { return icsTextReplacer.Replace(s) }
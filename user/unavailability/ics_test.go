@@ -0,0 +1,55 @@
+package unavailability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICS(t *testing.T) {
+	const data = "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:vacation-1\r\n" +
+		"SUMMARY:Vacation\\, beach\r\n" +
+		"DTSTART;VALUE=DATE:20231009\r\n" +
+		"DTEND;VALUE=DATE:20231014\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:appt-\r\n" +
+		" 2\r\n" +
+		"SUMMARY:Doctor\r\n" +
+		"DTSTART:20231002T150000Z\r\n" +
+		"DTEND:20231002T170000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:old\r\n" +
+		"DTSTART:20220101T000000Z\r\n" +
+		"DTEND:20220102T000000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	result, err := ParseICS(strings.NewReader(data), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []Unavailability{
+		{
+			ExternalID: "vacation-1",
+			Note:       "Vacation, beach",
+			Source:     SourceICS,
+			Start:      time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+			End:        time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ExternalID: "appt-2",
+			Note:       "Doctor",
+			Source:     SourceICS,
+			Start:      time.Date(2023, 10, 2, 15, 0, 0, 0, time.UTC),
+			End:        time.Date(2023, 10, 2, 17, 0, 0, 0, time.UTC),
+		},
+	}, result)
+
+	_, err = ParseICS(strings.NewReader("BEGIN:VEVENT\nUID:x\nDTSTART:20231002T150000Z\nEND:VEVENT\n"), time.Time{})
+	assert.Error(t, err, "missing DTEND")
+}
@@ -0,0 +1,29 @@
+package unavailability
+
+import (
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/target/goalert/validation"
+)
+
+// UnmarshalGQL implements the graphql.Marshaler interface
+func (s *Source) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err != nil {
+		return err
+	}
+	switch Source(str) {
+	case SourceManual, SourceICS:
+		*s = Source(str)
+	default:
+		return validation.NewFieldError("Source", "unknown source "+str)
+	}
+
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (s Source) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(s)).MarshalGQL(w)
+}
@@ -0,0 +1,179 @@
+package unavailability
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of user unavailability.
+type Store struct {
+	insert    *sql.Stmt
+	findOne   *sql.Stmt
+	delete    *sql.Stmt
+	deleteICS *sql.Stmt
+	findMany  *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing all statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		insert: p.P(`
+			insert into user_unavailability (id, user_id, start_time, end_time, note, source, external_id)
+			values ($1, $2, $3, $4, $5, $6, $7)
+		`),
+		findOne:   p.P(`select user_id from user_unavailability where id = $1`),
+		delete:    p.P(`delete from user_unavailability where id = $1`),
+		deleteICS: p.P(`delete from user_unavailability where user_id = $1 and source = 'ics'`),
+		findMany: p.P(`
+			select id, user_id, start_time, end_time, note, source, external_id
+			from user_unavailability
+			where
+				user_id = any($1) and
+				start_time < $3 and
+				end_time > $2
+			order by start_time, user_id
+		`),
+	}, p.Err
+}
+
+func (s *Store) insertTx(ctx context.Context, tx *sql.Tx, u *Unavailability) (*Unavailability, error) {
+	n, err := u.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var extID sql.NullString
+	if n.ExternalID != "" {
+		extID = sql.NullString{String: n.ExternalID, Valid: true}
+	}
+
+	_, err = tx.StmtContext(ctx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.Start, n.End, n.Note, n.Source, extID)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// CreateTx will create a new manual Unavailability entry.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, u *Unavailability) (*Unavailability, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(u.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	cpy := *u
+	cpy.ID = ""
+	cpy.Source = SourceManual
+	cpy.ExternalID = ""
+
+	return s.insertTx(ctx, tx, &cpy)
+}
+
+// ReplaceICSTx will replace all previously imported entries for the user with the provided ones.
+func (s *Store) ReplaceICSTx(ctx context.Context, tx *sql.Tx, userID string, entries []Unavailability) ([]Unavailability, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+	err = validate.Many(
+		validate.UUID("UserID", userID),
+		validate.Range("Entries", len(entries), 0, maxICSEvents),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.StmtContext(ctx, s.deleteICS).ExecContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Unavailability, 0, len(entries))
+	for i, e := range entries {
+		e.ID = ""
+		e.UserID = userID
+		e.Source = SourceICS
+		n, err := s.insertTx(ctx, tx, &e)
+		if err != nil {
+			return nil, validation.AddPrefix("Entries["+strconv.Itoa(i)+"].", err)
+		}
+		result = append(result, *n)
+	}
+
+	return result, nil
+}
+
+// DeleteTx will delete the Unavailability with the given ID.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	err = tx.StmtContext(ctx, s.findOne).QueryRowContext(ctx, id).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, id)
+	return err
+}
+
+// FindAllByUsers will return all entries for the given users that overlap the start and end time.
+func (s *Store) FindAllByUsers(ctx context.Context, userIDs []string, start, end time.Time) ([]Unavailability, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	err = validate.ManyUUID("UserID", userIDs, 500)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMany.QueryContext(ctx, sqlutil.UUIDArray(userIDs), start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Unavailability
+	for rows.Next() {
+		var u Unavailability
+		var extID sql.NullString
+		err = rows.Scan(&u.ID, &u.UserID, &u.Start, &u.End, &u.Note, &u.Source, &extID)
+		if err != nil {
+			return nil, err
+		}
+		u.ExternalID = extID.String
+		result = append(result, u)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,65 @@
+package unavailability
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Source indicates how an Unavailability was created.
+type Source string
+
+// Known sources.
+const (
+	SourceManual Source = "manual"
+	SourceICS    Source = "ics"
+)
+
+// Unavailability is a period of time that a user is away (e.g., vacation or leave) and
+// any on-call shifts they have will need to be covered by someone else.
+type Unavailability struct {
+	ID     string
+	UserID string
+	Start  time.Time
+	End    time.Time
+	Note   string
+	Source Source
+
+	// ExternalID is the UID of the imported calendar event, if any.
+	ExternalID string
+}
+
+// Normalize will validate and return a normalized copy of the Unavailability.
+func (u Unavailability) Normalize() (*Unavailability, error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	if u.Source == "" {
+		u.Source = SourceManual
+	}
+	u.Start = u.Start.Truncate(time.Minute)
+	u.End = u.End.Truncate(time.Minute)
+
+	err := validate.Many(
+		validate.UUID("ID", u.ID),
+		validate.UUID("UserID", u.UserID),
+		validate.Text("Note", u.Note, 1, 255),
+		validate.OneOf("Source", u.Source, SourceManual, SourceICS),
+		validate.Text("ExternalID", u.ExternalID, 1, 255),
+	)
+	if !u.Start.Before(u.End) {
+		err = validate.Many(err, validation.NewFieldError("End", "must occur after Start time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// Overlaps returns true if the Unavailability overlaps the given time range.
+func (u Unavailability) Overlaps(start, end time.Time) bool {
+	return u.Start.Before(end) && u.End.After(start)
+}
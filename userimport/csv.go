@@ -0,0 +1,108 @@
+package userimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxRows is the maximum number of users that can be imported at once.
+const MaxRows = 1000
+
+// A Row is a single user entry from a CSV file.
+type Row struct {
+	// Line is the line number of the row in the CSV file, starting at 1 for the header.
+	Line int
+
+	Name  string
+	Email string
+
+	// Role is the raw role value, it is validated during import.
+	Role string
+
+	// Phone, if set, is used to create an SMS contact method for the user.
+	Phone string
+}
+
+var columns = []string{"name", "email", "role", "phone"}
+
+// ParseCSV will parse user rows from r. The first line must be a header naming the
+// columns (name, email, role, and phone), in any order. The name and email columns
+// are required; rows are not otherwise validated.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	idx := make(map[string]int, len(columns))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i == 0 {
+			// tolerate a UTF-8 byte order mark, as written by some spreadsheet programs
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if !contains(columns, name) {
+			return nil, fmt.Errorf("unknown column '%s', expected one of: %s", name, strings.Join(columns, ", "))
+		}
+		if _, ok := idx[name]; ok {
+			return nil, fmt.Errorf("duplicate column '%s'", name)
+		}
+		idx[name] = i
+	}
+	for _, name := range []string{"name", "email"} {
+		if _, ok := idx[name]; !ok {
+			return nil, fmt.Errorf("missing required column '%s'", name)
+		}
+	}
+
+	field := func(rec []string, name string) string {
+		i, ok := idx[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var rows []Row
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("too many rows, at most %d users can be imported at once", MaxRows)
+		}
+
+		line, _ := cr.FieldPos(0)
+		rows = append(rows, Row{
+			Line:  line,
+			Name:  field(rec, "name"),
+			Email: field(rec, "email"),
+			Role:  field(rec, "role"),
+			Phone: field(rec, "phone"),
+		})
+	}
+
+	return rows, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package userimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("\ufeffEmail, Name,phone\n" +
+		"jane@example.com, Jane Doe, +17635550100\n" +
+		"\n" +
+		"\"bob@example.com\",\"Smith, Bob\",\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Line: 2, Name: "Jane Doe", Email: "jane@example.com", Phone: "+17635550100"},
+		{Line: 4, Name: "Smith, Bob", Email: "bob@example.com"},
+	}, rows)
+
+	check := func(desc, input, expErr string) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expErr)
+		})
+	}
+	check("empty", "", "missing header")
+	check("missing-email", "name,role\nJane,user\n", "missing required column 'email'")
+	check("unknown-column", "name,email,team\n", "unknown column 'team'")
+	check("duplicate-column", "name,email,Name\n", "duplicate column 'name'")
+	check("field-count", "name,email\nJane\n", "wrong number of fields")
+}
@@ -0,0 +1,208 @@
+package userimport
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+// Config contains the stores used to create imported users.
+type Config struct {
+	UserStore *user.Store
+	CMStore   *contactmethod.Store
+	NRStore   *notificationrule.Store
+}
+
+// Importer will create users from parsed CSV rows.
+type Importer struct {
+	cfg Config
+
+	findUserByEmail *sql.Stmt
+}
+
+// NewImporter will create a new Importer, preparing all statements.
+func NewImporter(ctx context.Context, db *sql.DB, cfg Config) (*Importer, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Importer{
+		cfg: cfg,
+
+		findUserByEmail: p.P(`select id from users where lower(email) = lower($1) order by id limit 1`),
+	}, p.Err
+}
+
+// Status is the outcome of importing a single row.
+type Status string
+
+// Possible import statuses.
+const (
+	StatusCreated   Status = "CREATED"
+	StatusDuplicate Status = "DUPLICATE"
+	StatusInvalid   Status = "INVALID"
+)
+
+// Result is the outcome of importing a single Row.
+type Result struct {
+	Row
+	Status Status
+
+	// UserID is the ID of the created user, or the existing user for duplicates.
+	UserID string
+
+	// ContactMethodID is the ID of the SMS contact method created for the phone number, if any.
+	ContactMethodID string
+
+	// Message describes why the row was skipped.
+	Message string
+}
+
+// Import will create a user for each row using the provided transaction, skipping rows
+// with an email that matches an existing user (or an earlier row). For rows with a phone
+// number, a disabled SMS contact method and an immediate notification rule are created;
+// the contact method is enabled once verified.
+//
+// Rows that fail validation are skipped and recorded as invalid. The caller is responsible
+// for committing (or rolling back, e.g., for a preview) tx.
+func (imp *Importer) Import(ctx context.Context, tx *sql.Tx, rows []Row) ([]Result, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("transaction required")
+	}
+
+	results := make([]Result, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		res := Result{Row: row}
+		key := strings.ToLower(row.Email)
+		if line, ok := seen[key]; ok && key != "" {
+			res.Status = StatusDuplicate
+			res.Message = fmt.Sprintf("same email as line %d", line)
+			results = append(results, res)
+			continue
+		}
+		seen[key] = row.Line
+
+		err = imp.try(ctx, tx, &res)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// try will import res.Row within a savepoint. If it fails validation, all of its changes are
+// rolled back and the row is recorded as invalid. Unexpected errors are returned.
+func (imp *Importer) try(ctx context.Context, tx *sql.Tx, res *Result) error {
+	_, err := tx.ExecContext(ctx, "savepoint user_import")
+	if err != nil {
+		return fmt.Errorf("create savepoint: %w", err)
+	}
+
+	rowErr := errutil.MapDBError(imp.importRow(ctx, tx, res))
+	if rowErr != nil {
+		if scrubbed, _ := errutil.ScrubError(rowErr); scrubbed {
+			return fmt.Errorf("import line %d: %w", res.Line, rowErr)
+		}
+		*res = Result{Row: res.Row, Status: StatusInvalid, Message: rowErr.Error()}
+
+		_, err = tx.ExecContext(ctx, "rollback to savepoint user_import")
+		if err != nil {
+			return fmt.Errorf("rollback to savepoint: %w", err)
+		}
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, "release savepoint user_import")
+	if err != nil {
+		return fmt.Errorf("release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// importRow will create the user for res.Row, setting the result fields.
+func (imp *Importer) importRow(ctx context.Context, tx *sql.Tx, res *Result) error {
+	if res.Email == "" {
+		return validation.NewFieldError("Email", "is required")
+	}
+
+	var existingID string
+	err := tx.StmtContext(ctx, imp.findUserByEmail).QueryRowContext(ctx, res.Email).Scan(&existingID)
+	if err == nil {
+		res.Status = StatusDuplicate
+		res.UserID = existingID
+		res.Message = "a user with this email already exists"
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	role, err := parseRole(res.Role)
+	if err != nil {
+		return err
+	}
+
+	u, err := imp.cfg.UserStore.InsertTx(ctx, tx, &user.User{
+		Name:  res.Name,
+		Email: res.Email,
+		Role:  role,
+	})
+	if err != nil {
+		return err
+	}
+	res.Status = StatusCreated
+	res.UserID = u.ID
+
+	if res.Phone == "" {
+		return nil
+	}
+
+	cm, err := imp.cfg.CMStore.Create(ctx, tx, &contactmethod.ContactMethod{
+		Name:     "Phone",
+		Type:     contactmethod.TypeSMS,
+		UserID:   u.ID,
+		Value:    res.Phone,
+		Disabled: true,
+	})
+	var fErr validation.FieldError
+	if errors.As(err, &fErr) {
+		return validation.NewFieldError("Phone", fErr.Reason())
+	}
+	if err != nil {
+		return err
+	}
+	res.ContactMethodID = cm.ID
+
+	_, err = imp.cfg.NRStore.CreateTx(ctx, tx, &notificationrule.NotificationRule{
+		UserID:          u.ID,
+		ContactMethodID: cm.ID,
+	})
+	return err
+}
+
+func parseRole(s string) (permission.Role, error) {
+	switch strings.ToLower(s) {
+	case "", "user":
+		return permission.RoleUser, nil
+	case "admin":
+		return permission.RoleAdmin, nil
+	}
+
+	return "", validation.NewFieldError("Role", fmt.Sprintf("must be 'user' or 'admin', got '%s'", s))
+}
@@ -6,6 +6,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// Standard field names, so the same value is logged under the same name by every module.
+const (
+	FieldAlertID   = "alert_id"
+	FieldServiceID = "service_id"
+	FieldMessageID = "message_id"
+	FieldRequestID = "request_id"
+
+	// FieldClientRequestID is a caller-provided request ID, logged alongside the one GoAlert assigns.
+	FieldClientRequestID = "client_request_id"
+	FieldSubsystem = "subsystem"
+)
+
 // Fields are used to add values in structured logging.
 type Fields map[string]interface{}
 type logContextField string
@@ -15,6 +27,48 @@ func SetRequestID(ctx context.Context) context.Context {
 	return context.WithValue(ctx, logContextKeyRequestID, uuid.New().String())
 }
 
+// maxRequestIDLen is the maximum length of a provided request ID.
+const maxRequestIDLen = 64
+
+// WithRequestID will assign the provided ID (e.g., one recorded with an alert) to the context for tracing.
+//
+// If the ID is empty, too long, or contains characters other than letters, digits, '.', '_', ':', or '-',
+// a new unique ID is assigned instead.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if !validRequestID(id) {
+		return SetRequestID(ctx)
+	}
+
+	return context.WithValue(ctx, logContextKeyRequestID, id)
+}
+
+// WithClientRequestID will add a caller-provided request ID (e.g., from an X-Request-ID header) to the
+// context's log fields. It is only logged, never used as the request ID, and is ignored if it is not a
+// valid request ID.
+func WithClientRequestID(ctx context.Context, id string) context.Context {
+	if !validRequestID(id) {
+		return ctx
+	}
+
+	return WithField(ctx, FieldClientRequestID, id)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '_', r == ':', r == '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 // ContextFields will return the current set of fields associated with a context.
 func ContextFields(ctx context.Context) Fields {
 	f, _ := ctx.Value(logContextKeyFieldList).([]string)
@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -60,3 +61,35 @@ func TestWithField(t *testing.T) {
 	}
 
 }
+
+func TestWithRequestID(t *testing.T) {
+	ctx := context.Background()
+
+	if id := RequestID(WithRequestID(ctx, "abc-123_x.y:z")); id != "abc-123_x.y:z" {
+		t.Errorf("RequestID = %s; want abc-123_x.y:z", id)
+	}
+
+	for _, bad := range []string{"", "has space", "quote\"", strings.Repeat("a", 65)} {
+		id := RequestID(WithRequestID(ctx, bad))
+		if id == bad || id == "" {
+			t.Errorf("RequestID for %q = %q; want new ID", bad, id)
+		}
+	}
+}
+
+func TestWithClientRequestID(t *testing.T) {
+	ctx := SetRequestID(context.Background())
+	id := RequestID(ctx)
+
+	ctx = WithClientRequestID(ctx, "abc-123")
+	if RequestID(ctx) != id {
+		t.Errorf("RequestID = %s; want %s", RequestID(ctx), id)
+	}
+	if v := ContextFields(ctx)[FieldClientRequestID]; v != "abc-123" {
+		t.Errorf("client request ID field = %v; want abc-123", v)
+	}
+
+	if _, ok := ContextFields(WithClientRequestID(context.Background(), "has space"))[FieldClientRequestID]; ok {
+		t.Error("invalid client request ID should not be logged")
+	}
+}
@@ -0,0 +1,135 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Level controls which messages are logged. Errors are always logged.
+type Level int
+
+const (
+	// LevelDefault means the default level of the Logger is used.
+	LevelDefault Level = iota
+
+	// LevelError will only log errors.
+	LevelError
+
+	// LevelInfo will log errors and application information.
+	LevelInfo
+
+	// LevelDebug will log everything, including debug messages.
+	LevelDebug
+)
+
+func (lvl Level) String() string {
+	switch lvl {
+	case LevelDefault:
+		return "default"
+	case LevelError:
+		return "error"
+	case LevelInfo:
+		return "info"
+	case LevelDebug:
+		return "debug"
+	}
+	return fmt.Sprintf("Level(%d)", int(lvl))
+}
+
+// ParseLevel will parse a Level from its name (e.g., "debug").
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "default", "":
+		return LevelDefault, nil
+	case "error":
+		return LevelError, nil
+	case "info":
+		return LevelInfo, nil
+	case "debug":
+		return LevelDebug, nil
+	}
+	return LevelDefault, fmt.Errorf("unknown log level '%s'", s)
+}
+
+// WithSubsystem will return a context with the named subsystem set, for use with per-subsystem
+// log levels. Subsystems are hierarchical, separated by dots (e.g., "engine.message"), so the
+// level of "engine" applies to "engine.message" unless it has its own.
+func WithSubsystem(ctx context.Context, name string) context.Context {
+	ctx = context.WithValue(ctx, logContextKeySubsystem, name)
+	return WithField(ctx, FieldSubsystem, name)
+}
+
+// Subsystem will return the subsystem of the context, if any.
+func Subsystem(ctx context.Context) string {
+	name, _ := ctx.Value(logContextKeySubsystem).(string)
+	return name
+}
+
+// SetLevel will set the log level of a subsystem at runtime. LevelDefault will remove the override.
+func (l *Logger) SetLevel(subsystem string, lvl Level) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if lvl == LevelDefault {
+		delete(l.levels, subsystem)
+		return
+	}
+	if l.levels == nil {
+		l.levels = make(map[string]Level)
+	}
+	l.levels[subsystem] = lvl
+}
+
+// SubsystemLevel is the log level of a subsystem.
+type SubsystemLevel struct {
+	Subsystem string
+	Level     Level
+}
+
+// Levels will return all subsystem levels set with SetLevel, sorted by subsystem.
+func (l *Logger) Levels() []SubsystemLevel {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+
+	levels := make([]SubsystemLevel, 0, len(l.levels))
+	for name, lvl := range l.levels {
+		levels = append(levels, SubsystemLevel{Subsystem: name, Level: lvl})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Subsystem < levels[j].Subsystem })
+	return levels
+}
+
+// DefaultLevel returns the level used for subsystems without their own.
+func (l *Logger) DefaultLevel() Level {
+	switch {
+	case l.debug:
+		return LevelDebug
+	case l.info:
+		return LevelInfo
+	}
+	return LevelError
+}
+
+// level returns the effective log level for ctx.
+func (l *Logger) level(ctx context.Context) Level {
+	name := Subsystem(ctx)
+	if name != "" {
+		l.mx.RLock()
+		for {
+			if lvl, ok := l.levels[name]; ok {
+				l.mx.RUnlock()
+				return lvl
+			}
+			idx := strings.LastIndexByte(name, '.')
+			if idx == -1 {
+				break
+			}
+			name = name[:idx]
+		}
+		l.mx.RUnlock()
+	}
+
+	return l.DefaultLevel()
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger()
+	l.SetOutput(&buf)
+	ctx := context.Background()
+
+	engCtx := WithSubsystem(ctx, "Engine")
+	msgCtx := WithSubsystem(engCtx, "Engine.MessageManager")
+
+	check := func(ctx context.Context, debug, info bool) {
+		t.Helper()
+
+		buf.Reset()
+		l.DebugPrintf(ctx, "debug")
+		assert.Equal(t, debug, buf.Len() > 0, "debug")
+
+		buf.Reset()
+		l.Printf(ctx, "info")
+		assert.Equal(t, info, buf.Len() > 0, "info")
+	}
+
+	check(msgCtx, false, true)
+
+	l.SetLevel("Engine", LevelDebug)
+	check(ctx, false, true)
+	check(engCtx, true, true)
+	check(msgCtx, true, true)
+
+	l.SetLevel("Engine.MessageManager", LevelError)
+	check(engCtx, true, true)
+	check(msgCtx, false, false)
+
+	assert.Equal(t, []SubsystemLevel{
+		{Subsystem: "Engine", Level: LevelDebug},
+		{Subsystem: "Engine.MessageManager", Level: LevelError},
+	}, l.Levels())
+
+	l.SetLevel("Engine", LevelDefault)
+	check(engCtx, false, true)
+	assert.Len(t, l.Levels(), 1)
+
+	// errors are always logged
+	buf.Reset()
+	l.Error(msgCtx, assert.AnError)
+	assert.Contains(t, buf.String(), "subsystem=Engine.MessageManager")
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, lvl := range []Level{LevelDefault, LevelError, LevelInfo, LevelDebug} {
+		got, err := ParseLevel(lvl.String())
+		assert.NoError(t, err)
+		assert.Equal(t, lvl, got)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -16,6 +17,7 @@ const (
 	logContextKeyRequestID
 	logContextKeyFieldList
 	logContextKeyLogger
+	logContextKeySubsystem
 )
 
 type Logger struct {
@@ -25,12 +27,19 @@ type Logger struct {
 	l      *logrus.Logger
 
 	errHooks []func(context.Context, error) context.Context
+
+	recent *recentErrors
+
+	mx     sync.RWMutex
+	levels map[string]Level
 }
 
 func NewLogger() *Logger {
 	l := logrus.New()
+	recent := &recentErrors{}
+	l.AddHook(recent)
 
-	return &Logger{l: l, info: true}
+	return &Logger{l: l, info: true, recent: recent}
 }
 
 func (l *Logger) BackgroundContext() context.Context {
@@ -86,7 +95,7 @@ func (l *Logger) entry(ctx context.Context) *logrus.Entry {
 
 	rid := RequestID(ctx)
 	if rid != "" {
-		e = e.WithField("RequestID", rid)
+		e = e.WithField(FieldRequestID, rid)
 	}
 
 	return e
@@ -151,12 +160,12 @@ func Logf(ctx context.Context, format string, args ...interface{}) {
 }
 
 func (l *Logger) Printf(ctx context.Context, format string, args ...interface{}) {
-	if !l.info {
-		return
-	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if l.level(ctx) < LevelInfo {
+		return
+	}
 	l.entry(ctx).Printf(format, args...)
 }
 
@@ -169,7 +178,7 @@ func (l *Logger) DebugPrintf(ctx context.Context, format string, args ...interfa
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if v, _ := ctx.Value(logContextKeyDebug).(bool); !v && !l.debug {
+	if v, _ := ctx.Value(logContextKeyDebug).(bool); !v && l.level(ctx) < LevelDebug {
 		return
 	}
 
@@ -186,7 +195,7 @@ func (l *Logger) DebugError(ctx context.Context, err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if v, _ := ctx.Value(logContextKeyDebug).(bool); !v && !l.debug {
+	if v, _ := ctx.Value(logContextKeyDebug).(bool); !v && l.level(ctx) < LevelDebug {
 		return
 	}
 	ctx = l.addSource(ctx, err)
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// recentErrorsSize is the number of error entries kept in memory by each Logger.
+const recentErrorsSize = 100
+
+// ErrorEntry is an error that was logged.
+type ErrorEntry struct {
+	Time    time.Time
+	Message string
+	Fields  map[string]string
+}
+
+// recentErrors is a logrus hook that keeps the most recent error entries in a ring buffer.
+type recentErrors struct {
+	mx      sync.Mutex
+	entries []ErrorEntry
+	next    int
+}
+
+func (r *recentErrors) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel}
+}
+
+func (r *recentErrors) Fire(e *logrus.Entry) error {
+	entry := ErrorEntry{
+		Time:    e.Time,
+		Message: e.Message,
+		Fields:  make(map[string]string, len(e.Data)),
+	}
+	for k, v := range e.Data {
+		if k == "Source" {
+			// stack traces are too large to keep
+			continue
+		}
+		if k == logrus.ErrorKey {
+			entry.Message = fmt.Sprint(v)
+			continue
+		}
+		entry.Fields[k] = fmt.Sprint(v)
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if len(r.entries) < recentErrorsSize {
+		r.entries = append(r.entries, entry)
+		return nil
+	}
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % recentErrorsSize
+
+	return nil
+}
+
+// RecentErrors returns the most recent errors logged, oldest first.
+func (l *Logger) RecentErrors() []ErrorEntry {
+	l.recent.mx.Lock()
+	defer l.recent.mx.Unlock()
+
+	result := make([]ErrorEntry, 0, len(l.recent.entries))
+	result = append(result, l.recent.entries[l.recent.next:]...)
+	result = append(result, l.recent.entries[:l.recent.next]...)
+
+	return result
+}
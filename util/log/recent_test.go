@@ -0,0 +1,35 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_RecentErrors(t *testing.T) {
+	l := NewLogger()
+	l.SetOutput(io.Discard)
+	ctx := WithField(context.Background(), "AlertID", 123)
+
+	l.Printf(ctx, "not an error")
+	l.Error(ctx, errors.New("first"))
+
+	recent := l.RecentErrors()
+	require.Len(t, recent, 1)
+	assert.Equal(t, "first", recent[0].Message)
+	assert.Equal(t, "123", recent[0].Fields["AlertID"])
+
+	for i := 0; i < recentErrorsSize+5; i++ {
+		l.Error(ctx, fmt.Errorf("err %d", i))
+	}
+
+	recent = l.RecentErrors()
+	require.Len(t, recent, recentErrorsSize)
+	assert.Equal(t, "err 5", recent[0].Message, "oldest first")
+	assert.Equal(t, fmt.Sprintf("err %d", recentErrorsSize+4), recent[len(recent)-1].Message)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
 )
 
@@ -32,11 +33,19 @@ func AppURL(urlStr, appName string) (string, error) {
 }
 
 // NewConnector will create a new driver.Connector with retry enabled and the provided application_name.
+//
+// Query timing is recorded in DefaultQueryStats.
 func NewConnector(urlStr, appName string) (driver.Connector, error) {
 	urlStr, err := AppURL(urlStr, appName)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewRetryDriver(&stdlib.Driver{}, 10).OpenConnector(urlStr)
+	cfg, err := pgx.ParseConfig(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse db url: %w", err)
+	}
+	cfg.Tracer = DefaultQueryStats
+
+	return &retryConnector{dbc: stdlib.GetConnector(*cfg), drv: NewRetryDriver(&stdlib.Driver{}, 10)}, nil
 }
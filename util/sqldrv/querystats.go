@@ -0,0 +1,199 @@
+package sqldrv
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// topQueries is the number of query fingerprints exported, by total time.
+	topQueries = 10
+
+	// maxFingerprints limits the number of distinct fingerprints tracked.
+	maxFingerprints = 1000
+
+	// maxQueryLabel is the max length of the normalized query text used as a label.
+	maxQueryLabel = 120
+)
+
+var (
+	literalRx    = regexp.MustCompile(`'(?:[^']|'')*'|\$\d+|\b\d+(?:\.\d+)?\b`)
+	whitespaceRx = regexp.MustCompile(`\s+`)
+)
+
+// Fingerprint returns a normalized form of the query, with literals replaced and whitespace
+// collapsed, and a short hash identifying it.
+func Fingerprint(sql string) (id, normalized string) {
+	normalized = literalRx.ReplaceAllStringFunc(sql, func(lit string) string {
+		if strings.HasPrefix(lit, "$") {
+			// keep placeholders
+			return lit
+		}
+		return "?"
+	})
+	normalized = strings.TrimSpace(whitespaceRx.ReplaceAllString(normalized, " "))
+
+	h := fnv.New32a()
+	h.Write([]byte(normalized))
+	return fmt.Sprintf("%08x", h.Sum32()), normalized
+}
+
+type queryStat struct {
+	query string
+	calls int64
+	total time.Duration
+	max   time.Duration
+}
+
+type prepKey struct {
+	conn *pgx.Conn
+	name string
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	sql   string
+	start time.Time
+}
+
+// DefaultQueryStats records query timing for all connections created by NewConnector.
+var DefaultQueryStats = NewQueryStats()
+
+// QueryStats records timing for queries by fingerprint, exporting the slowest (by total time) as metrics.
+type QueryStats struct {
+	mx sync.Mutex
+
+	stats    map[string]*queryStat
+	prepared map[prepKey]string
+
+	totalDesc *prometheus.Desc
+	callsDesc *prometheus.Desc
+	maxDesc   *prometheus.Desc
+}
+
+var (
+	_ pgx.QueryTracer      = (*QueryStats)(nil)
+	_ pgx.PrepareTracer    = (*QueryStats)(nil)
+	_ prometheus.Collector = (*QueryStats)(nil)
+)
+
+// NewQueryStats creates a new QueryStats.
+func NewQueryStats() *QueryStats {
+	labels := []string{"fingerprint", "query"}
+	return &QueryStats{
+		stats:    make(map[string]*queryStat),
+		prepared: make(map[prepKey]string),
+
+		totalDesc: prometheus.NewDesc("goalert_db_query_duration_seconds_total", "Total time spent executing the slowest queries, by fingerprint.", labels, nil),
+		callsDesc: prometheus.NewDesc("goalert_db_query_calls_total", "Number of executions of the slowest queries, by fingerprint.", labels, nil),
+		maxDesc:   prometheus.NewDesc("goalert_db_query_max_duration_seconds", "Longest single execution of the slowest queries, by fingerprint.", labels, nil),
+	}
+}
+
+// TracePrepareStart implements pgx.PrepareTracer.
+func (qs *QueryStats) TracePrepareStart(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
+	if data.Name == "" {
+		return ctx
+	}
+
+	// prepared statements are executed by name, so keep the SQL to identify them later
+	qs.mx.Lock()
+	qs.prepared[prepKey{conn: conn, name: data.Name}] = data.SQL
+	qs.mx.Unlock()
+
+	return ctx
+}
+
+// TracePrepareEnd implements pgx.PrepareTracer.
+func (qs *QueryStats) TracePrepareEnd(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareEndData) {
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (qs *QueryStats) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	sql := data.SQL
+	qs.mx.Lock()
+	if prepSQL, ok := qs.prepared[prepKey{conn: conn, name: sql}]; ok {
+		sql = prepSQL
+	}
+	qs.mx.Unlock()
+
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: sql, start: time.Now()})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (qs *QueryStats) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	qStart, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	dur := time.Since(qStart.start)
+	id, normalized := Fingerprint(qStart.sql)
+
+	qs.mx.Lock()
+	defer qs.mx.Unlock()
+
+	s, ok := qs.stats[id]
+	if !ok {
+		if len(qs.stats) >= maxFingerprints {
+			return
+		}
+		if len(normalized) > maxQueryLabel {
+			normalized = normalized[:maxQueryLabel]
+		}
+		s = &queryStat{query: normalized}
+		qs.stats[id] = s
+	}
+	s.calls++
+	s.total += dur
+	if dur > s.max {
+		s.max = dur
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (qs *QueryStats) Describe(ch chan<- *prometheus.Desc) {
+	ch <- qs.totalDesc
+	ch <- qs.callsDesc
+	ch <- qs.maxDesc
+}
+
+// Collect implements prometheus.Collector.
+func (qs *QueryStats) Collect(ch chan<- prometheus.Metric) {
+	type entry struct {
+		id string
+		queryStat
+	}
+
+	qs.mx.Lock()
+	for key := range qs.prepared {
+		if key.conn.IsClosed() {
+			delete(qs.prepared, key)
+		}
+	}
+	entries := make([]entry, 0, len(qs.stats))
+	for id, s := range qs.stats {
+		entries = append(entries, entry{id: id, queryStat: *s})
+	}
+	qs.mx.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].total > entries[j].total })
+	if len(entries) > topQueries {
+		entries = entries[:topQueries]
+	}
+
+	for _, e := range entries {
+		ch <- prometheus.MustNewConstMetric(qs.totalDesc, prometheus.CounterValue, e.total.Seconds(), e.id, e.query)
+		ch <- prometheus.MustNewConstMetric(qs.callsDesc, prometheus.CounterValue, float64(e.calls), e.id, e.query)
+		ch <- prometheus.MustNewConstMetric(qs.maxDesc, prometheus.GaugeValue, e.max.Seconds(), e.id, e.query)
+	}
+}
@@ -0,0 +1,22 @@
+package sqldrv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	id1, norm := Fingerprint("select *\n\tfrom alerts\n\twhere id = 123 and summary = 'it''s down'")
+	assert.Equal(t, "select * from alerts where id = ? and summary = ?", norm)
+	assert.Len(t, id1, 8)
+
+	id2, _ := Fingerprint("select * from alerts where id = 456 and summary = 'other'")
+	assert.Equal(t, id1, id2, "literals should not change the fingerprint")
+
+	id3, _ := Fingerprint("select * from services where id = $1")
+	assert.NotEqual(t, id1, id3)
+
+	_, norm = Fingerprint("select * from alerts where id = $1 and t1.x = 2")
+	assert.Equal(t, "select * from alerts where id = $1 and t1.x = ?", norm)
+}
@@ -0,0 +1,77 @@
+package sqlutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// PgxConn will call fn with the *pgx.Conn underlying conn, which must be from the pgx stdlib driver.
+//
+// Statements sent using the *pgx.Conn are part of any transaction active on conn, so they can be
+// pipelined with a pgx.Batch within a transaction started with conn.BeginTx. The *pgx.Conn must not
+// be used after fn returns, or concurrently with other uses of conn.
+func PgxConn(conn *sql.Conn, fn func(*pgx.Conn) error) error {
+	return conn.Raw(func(dc interface{}) error {
+		c, ok := dc.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("pgx conn: unsupported driver connection type %T", dc)
+		}
+
+		return fn(c.Conn())
+	})
+}
+
+// SendBatch will send all queued statements in b, in order, with a single round trip on conn, returning
+// the first error (including those returned by QueuedQuery callbacks).
+func SendBatch(ctx context.Context, conn *sql.Conn, b *pgx.Batch) error {
+	if b.Len() == 0 {
+		return nil
+	}
+
+	return PgxConn(conn, func(c *pgx.Conn) error {
+		return c.SendBatch(ctx, b).Close()
+	})
+}
+
+// RoundTripCounter is a pgx tracer that counts round trips to the database, where each query or batch
+// is one round trip. It is intended for benchmarks.
+type RoundTripCounter struct {
+	n atomic.Int64
+}
+
+var (
+	_ pgx.QueryTracer = (*RoundTripCounter)(nil)
+	_ pgx.BatchTracer = (*RoundTripCounter)(nil)
+)
+
+// RoundTrips returns the number of round trips since the last call to Reset.
+func (r *RoundTripCounter) RoundTrips() int64 { return r.n.Load() }
+
+// Reset will reset the count to zero.
+func (r *RoundTripCounter) Reset() { r.n.Store(0) }
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (r *RoundTripCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	r.n.Add(1)
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (r *RoundTripCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// TraceBatchStart implements pgx.BatchTracer.
+func (r *RoundTripCounter) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	r.n.Add(1)
+	return ctx
+}
+
+// TraceBatchQuery implements pgx.BatchTracer.
+func (r *RoundTripCounter) TraceBatchQuery(context.Context, *pgx.Conn, pgx.TraceBatchQueryData) {}
+
+// TraceBatchEnd implements pgx.BatchTracer.
+func (r *RoundTripCounter) TraceBatchEnd(context.Context, *pgx.Conn, pgx.TraceBatchEndData) {}
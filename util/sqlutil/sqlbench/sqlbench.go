@@ -0,0 +1,33 @@
+// Package sqlbench provides a database connection for benchmarks that measure round trips.
+package sqlbench
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// DB returns a connection to the (migrated) database at BENCH_DB_URL, and a counter for
+// round trips made with it. The benchmark is skipped if BENCH_DB_URL is not set.
+func DB(b testing.TB) (*sql.DB, *sqlutil.RoundTripCounter) {
+	b.Helper()
+	url := os.Getenv("BENCH_DB_URL")
+	if url == "" {
+		b.Skip("BENCH_DB_URL not set")
+	}
+
+	cfg, err := pgx.ParseConfig(url)
+	require.NoError(b, err)
+	var rt sqlutil.RoundTripCounter
+	cfg.Tracer = &rt
+
+	db := stdlib.OpenDB(*cfg)
+	b.Cleanup(func() { db.Close() })
+
+	return db, &rt
+}
@@ -0,0 +1,63 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// A ZoneTransition is a change in UTC offset (e.g., the start or end of DST) for a location.
+type ZoneTransition struct {
+	// At is the first instant the new offset is in effect.
+	At time.Time
+
+	FromName   string
+	FromOffset int // seconds east of UTC
+	ToName     string
+	ToOffset   int // seconds east of UTC
+}
+
+// transitionStep is the interval used to search for offset changes. Locations
+// never change offset more than once within this period.
+const transitionStep = 12 * time.Hour
+
+// ZoneTransitions will return all UTC offset changes for loc between start and end.
+func ZoneTransitions(loc *time.Location, start, end time.Time) []ZoneTransition {
+	var result []ZoneTransition
+	prev := start.In(loc)
+	for prev.Before(end) {
+		next := prev.Add(transitionStep)
+		if next.After(end) {
+			next = end.In(loc)
+		}
+
+		_, prevOffset := prev.Zone()
+		_, nextOffset := next.Zone()
+		if prevOffset != nextOffset {
+			result = append(result, findTransition(prev, next))
+		}
+		prev = next
+	}
+
+	return result
+}
+
+// findTransition will find the exact (to the second) offset change between a and b.
+func findTransition(a, b time.Time) ZoneTransition {
+	fromName, fromOffset := a.Zone()
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2).Truncate(time.Second)
+		if _, off := mid.Zone(); off == fromOffset {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	toName, toOffset := b.Zone()
+	return ZoneTransition{
+		At:         b,
+		FromName:   fromName,
+		FromOffset: fromOffset,
+		ToName:     toName,
+		ToOffset:   toOffset,
+	}
+}
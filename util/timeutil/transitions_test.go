@@ -0,0 +1,29 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, loc)
+	res := ZoneTransitions(loc, start, start.AddDate(1, 0, 0))
+	require.Len(t, res, 2)
+
+	assert.Equal(t, time.Date(2023, 3, 12, 8, 0, 0, 0, time.UTC), res[0].At.UTC())
+	assert.Equal(t, "CST", res[0].FromName)
+	assert.Equal(t, -6*3600, res[0].FromOffset)
+	assert.Equal(t, "CDT", res[0].ToName)
+	assert.Equal(t, -5*3600, res[0].ToOffset)
+
+	assert.Equal(t, time.Date(2023, 11, 5, 7, 0, 0, 0, time.UTC), res[1].At.UTC())
+	assert.Equal(t, "CST", res[1].ToName)
+
+	assert.Empty(t, ZoneTransitions(time.UTC, start, start.AddDate(1, 0, 0)))
+}
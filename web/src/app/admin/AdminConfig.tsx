@@ -10,6 +10,7 @@ import { Theme } from '@mui/material/styles'
 import _, { startCase, isEmpty, uniq, chain } from 'lodash'
 import AdminSection from './AdminSection'
 import AdminDialog from './AdminDialog'
+import AdminConfigTestDialog from './AdminConfigTestDialog'
 import ExpandMoreIcon from '@mui/icons-material/ExpandMore'
 import { Form } from '../forms'
 import {
@@ -84,6 +85,7 @@ function formatHeading(s = ''): string {
 export default function AdminConfig(): JSX.Element {
   const classes = useStyles()
   const [confirm, setConfirm] = useState(false)
+  const [showTest, setShowTest] = useState(false)
   const [values, setValues] = useState({})
   const [section, setSection] = useState(false as false | string)
 
@@ -144,6 +146,9 @@ export default function AdminConfig(): JSX.Element {
           >
             Reset
           </Button>
+          <Button data-cy='test' onClick={() => setShowTest(true)}>
+            Test
+          </Button>
           <Button
             data-cy='save'
             disabled={isEmpty(values)}
@@ -154,6 +159,13 @@ export default function AdminConfig(): JSX.Element {
         </ButtonGroup>
       </Grid>
 
+      {showTest && (
+        <AdminConfigTestDialog
+          value={values}
+          onClose={() => setShowTest(false)}
+        />
+      )}
+
       {confirm && (
         <AdminDialog
           value={values}
@@ -0,0 +1,81 @@
+import React, { useEffect } from 'react'
+import List from '@mui/material/List'
+import ListItem from '@mui/material/ListItem'
+import ListItemIcon from '@mui/material/ListItemIcon'
+import ListItemText from '@mui/material/ListItemText'
+import Typography from '@mui/material/Typography'
+import CheckCircle from '@mui/icons-material/CheckCircle'
+import Error from '@mui/icons-material/Error'
+import { gql, useMutation } from 'urql'
+import FormDialog from '../dialogs/FormDialog'
+import { nonFieldErrors, fieldErrors } from '../util/errutil'
+import { ConfigTestResult } from '../../schema'
+
+const mutation = gql`
+  mutation ($input: [ConfigValueInput!]) {
+    testConfig(input: $input) {
+      section
+      fieldID
+      ok
+      message
+    }
+  }
+`
+
+interface AdminConfigTestDialogProps {
+  value: { [id: string]: string }
+  onClose: () => void
+}
+
+export default function AdminConfigTestDialog(
+  props: AdminConfigTestDialogProps,
+): JSX.Element {
+  const [{ data, fetching, error }, commit] = useMutation(mutation)
+
+  useEffect(() => {
+    commit({
+      input: Object.entries(props.value).map(([id, value]) => ({ id, value })),
+    })
+  }, [])
+
+  const results: ConfigTestResult[] = data?.testConfig || []
+  const errs = nonFieldErrors(error)
+    .map((e) => ({ message: e.message }))
+    .concat(
+      fieldErrors(error).map((e) => ({ message: `${e.field}: ${e.message}` })),
+    )
+
+  return (
+    <FormDialog
+      alert
+      title='Test Configuration'
+      onClose={props.onClose}
+      loading={fetching}
+      errors={errs}
+      form={
+        <List data-cy='config-test-results'>
+          {results.map((r) => (
+            <ListItem divider key={r.section}>
+              <ListItemIcon>
+                {r.ok ? (
+                  <CheckCircle color='success' />
+                ) : (
+                  <Error color='error' />
+                )}
+              </ListItemIcon>
+              <ListItemText
+                primary={r.section}
+                secondary={
+                  r.fieldID ? `${r.fieldID}: ${r.message}` : r.message
+                }
+              />
+            </ListItem>
+          ))}
+          {!fetching && !error && results.length === 0 && (
+            <Typography>No enabled providers to test.</Typography>
+          )}
+        </List>
+      }
+    />
+  )
+}
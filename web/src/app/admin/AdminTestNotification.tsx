@@ -0,0 +1,151 @@
+import React, { useState } from 'react'
+import {
+  Card,
+  CardActions,
+  CardContent,
+  Grid,
+  List,
+  ListItem,
+  ListItemIcon,
+  ListItemText,
+  MenuItem,
+  TextField,
+} from '@mui/material'
+import CheckCircle from '@mui/icons-material/CheckCircle'
+import Error from '@mui/icons-material/Error'
+import { gql, useMutation } from 'urql'
+import { DateTime } from 'luxon'
+import { Form } from '../forms'
+import TelTextField from '../util/TelTextField'
+import LoadingButton from '../loading/components/LoadingButton'
+import { nonFieldErrors, fieldErrors } from '../util/errutil'
+import {
+  TestNotificationDestType,
+  TestNotificationResult,
+} from '../../schema'
+
+const mutation = gql`
+  mutation ($input: TestNotificationInput!) {
+    testNotification(input: $input) {
+      ok
+      providerMessageID
+      trace {
+        at
+        step
+        ok
+        message
+      }
+    }
+  }
+`
+
+const destTypes: { value: TestNotificationDestType; label: string }[] = [
+  { value: 'SMS', label: 'SMS' },
+  { value: 'VOICE', label: 'Voice' },
+  { value: 'EMAIL', label: 'Email' },
+  { value: 'SLACK_CHANNEL', label: 'Slack Channel ID' },
+]
+
+export default function AdminTestNotification(): JSX.Element {
+  const [type, setType] = useState<TestNotificationDestType>('SMS')
+  const [value, setValue] = useState('')
+  const [{ data, fetching, error }, commit] = useMutation(mutation)
+
+  const result: TestNotificationResult | undefined = data?.testNotification
+  const errs = nonFieldErrors(error)
+    .map((e) => e.message)
+    .concat(fieldErrors(error).map((e) => `${e.field}: ${e.message}`))
+
+  const isPhone = type === 'SMS' || type === 'VOICE'
+
+  return (
+    <Form
+      onSubmit={(e: { preventDefault: () => void }) => {
+        e.preventDefault()
+        commit({ input: { type, value } })
+      }}
+    >
+      <Card>
+        <CardContent>
+          <Grid container spacing={2}>
+            <Grid item xs={12} sm={12} md={12} lg={4}>
+              <TextField
+                select
+                fullWidth
+                label='Type'
+                value={type}
+                onChange={(e) => {
+                  setType(e.target.value as TestNotificationDestType)
+                  setValue('')
+                }}
+              >
+                {destTypes.map((t) => (
+                  <MenuItem key={t.value} value={t.value}>
+                    {t.label}
+                  </MenuItem>
+                ))}
+              </TextField>
+            </Grid>
+            <Grid item xs={12} sm={12} md={12} lg={8}>
+              {isPhone ? (
+                <TelTextField
+                  onChange={(e) => setValue(e.target.value)}
+                  value={value}
+                  fullWidth
+                  label='Destination'
+                />
+              ) : (
+                <TextField
+                  onChange={(e) => setValue(e.target.value)}
+                  value={value}
+                  fullWidth
+                  label='Destination'
+                />
+              )}
+            </Grid>
+          </Grid>
+          {(errs.length > 0 || result) && (
+            <List data-cy='test-notification-trace'>
+              {errs.map((message) => (
+                <ListItem key={message}>
+                  <ListItemIcon>
+                    <Error color='error' />
+                  </ListItemIcon>
+                  <ListItemText primary={message} />
+                </ListItem>
+              ))}
+              {result?.trace.map((s, idx) => (
+                <ListItem divider key={idx}>
+                  <ListItemIcon>
+                    {s.ok ? (
+                      <CheckCircle color='success' />
+                    ) : (
+                      <Error color='error' />
+                    )}
+                  </ListItemIcon>
+                  <ListItemText
+                    primary={s.message}
+                    secondary={`${s.step} at ${DateTime.fromISO(
+                      s.at,
+                    ).toLocaleString(DateTime.TIME_WITH_SECONDS)}`}
+                  />
+                </ListItem>
+              ))}
+              {result?.providerMessageID && (
+                <ListItem>
+                  <ListItemText
+                    primary='Provider Message ID'
+                    secondary={result.providerMessageID}
+                  />
+                </ListItem>
+              )}
+            </List>
+          )}
+        </CardContent>
+        <CardActions>
+          <LoadingButton buttonText='Send Test' loading={fetching} />
+        </CardActions>
+      </Card>
+    </Form>
+  )
+}
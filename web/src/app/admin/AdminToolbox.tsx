@@ -5,6 +5,7 @@ import makeStyles from '@mui/styles/makeStyles'
 import { Theme } from '@mui/material/styles'
 import AdminNumberLookup from './AdminNumberLookup'
 import AdminSMSSend from './AdminSMSSend'
+import AdminTestNotification from './AdminTestNotification'
 
 const useStyles = makeStyles((theme: Theme) => ({
   gridContainer: {
@@ -55,6 +56,21 @@ export default function AdminToolbox(): JSX.Element {
           <AdminSMSSend />
         </Grid>
       </Grid>
+      <Grid container item xs={12}>
+        <Grid item xs={12}>
+          <Typography
+            component='h2'
+            variant='subtitle1'
+            color='textSecondary'
+            classes={{ subtitle1: classes.groupTitle }}
+          >
+            Test Notification
+          </Typography>
+        </Grid>
+        <Grid item xs={12}>
+          <AdminTestNotification />
+        </Grid>
+      </Grid>
     </Grid>
   )
 }
@@ -0,0 +1,125 @@
+import React, { useEffect, useState } from 'react'
+import {
+  Button,
+  Card,
+  CardHeader,
+  Grid,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Typography,
+} from '@mui/material'
+import { pathPrefix } from '../../env'
+
+const maxEvents = 250
+
+type EngineEvent = {
+  time: string
+  type: string
+  cycleID?: string
+  module?: string
+  durationMS?: number
+  message?: string
+  fields?: { [key: string]: string }
+}
+
+const eventTypes = [
+  'cycle_start',
+  'cycle_end',
+  'module',
+  'message_sent',
+  'error',
+]
+
+function describe(e: EngineEvent): string {
+  const parts = []
+  if (e.message) parts.push(e.message)
+  if (e.fields) {
+    Object.entries(e.fields).forEach(([k, v]) => parts.push(`${k}=${v}`))
+  }
+  return parts.join(' ')
+}
+
+export default function AdminEngineEvents(): JSX.Element {
+  const [events, setEvents] = useState<EngineEvent[]>([])
+  const [paused, setPaused] = useState(false)
+  const [connected, setConnected] = useState(false)
+
+  useEffect(() => {
+    if (paused) return
+
+    const src = new EventSource(pathPrefix + '/api/v2/engine/events')
+    src.onopen = () => setConnected(true)
+    src.onerror = () => setConnected(false)
+    const onEvent = (msg: MessageEvent): void => {
+      const e: EngineEvent = JSON.parse(msg.data)
+      setEvents((prev) => [e, ...prev].slice(0, maxEvents))
+    }
+    eventTypes.forEach((t) => src.addEventListener(t, onEvent))
+
+    return () => {
+      src.close()
+      setConnected(false)
+    }
+  }, [paused])
+
+  return (
+    <Grid container spacing={2}>
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='Engine Events'
+            subheader={
+              connected
+                ? 'Streaming live events from this instance.'
+                : paused
+                  ? 'Paused.'
+                  : 'Connecting...'
+            }
+            action={
+              <React.Fragment>
+                <Button onClick={() => setEvents([])}>Clear</Button>
+                <Button variant='contained' onClick={() => setPaused(!paused)}>
+                  {paused ? 'Resume' : 'Pause'}
+                </Button>
+              </React.Fragment>
+            }
+          />
+          <Table size='small'>
+            <TableHead>
+              <TableRow>
+                <TableCell>Time</TableCell>
+                <TableCell>Type</TableCell>
+                <TableCell>Module</TableCell>
+                <TableCell>Duration</TableCell>
+                <TableCell>Details</TableCell>
+              </TableRow>
+            </TableHead>
+            <TableBody>
+              {events.map((e, idx) => (
+                <TableRow key={idx}>
+                  <TableCell>{new Date(e.time).toLocaleTimeString()}</TableCell>
+                  <TableCell>
+                    <Typography
+                      variant='body2'
+                      color={e.type === 'error' ? 'error' : undefined}
+                    >
+                      {e.type}
+                    </Typography>
+                  </TableCell>
+                  <TableCell>{e.module}</TableCell>
+                  <TableCell>
+                    {e.durationMS ? `${Math.round(e.durationMS)}ms` : ''}
+                  </TableCell>
+                  <TableCell>{describe(e)}</TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </Card>
+      </Grid>
+    </Grid>
+  )
+}
@@ -0,0 +1,131 @@
+import React, { useEffect } from 'react'
+import {
+  Button,
+  Card,
+  CardHeader,
+  Grid,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Typography,
+} from '@mui/material'
+import { gql, useMutation, useQuery } from 'urql'
+import { EngineJob } from '../../../schema'
+import { GenericError } from '../../error-pages'
+import Spinner from '../../loading/components/Spinner'
+import { Time } from '../../util/Time'
+
+const query = gql`
+  query {
+    engineJobs(input: { first: 100 }) {
+      id
+      kind
+      state
+      intervalSeconds
+      attempts
+      maxAttempts
+      runAt
+      startedAt
+      finishedAt
+      lastError
+    }
+  }
+`
+
+const mutation = gql`
+  mutation ($id: Int!) {
+    retryEngineJob(id: $id)
+  }
+`
+
+export default function AdminJobs(): JSX.Element {
+  const [{ data, fetching, error }, refetch] = useQuery({ query })
+  const [retryStatus, commitRetry] = useMutation(mutation)
+
+  useEffect(() => {
+    const t = setInterval(() => {
+      if (!fetching) refetch({ requestPolicy: 'network-only' })
+    }, 5000)
+    return () => clearInterval(t)
+  }, [fetching, refetch])
+
+  if (error) return <GenericError error={error.message} />
+  if (!data) return <Spinner />
+
+  const jobs: EngineJob[] = data.engineJobs
+
+  return (
+    <Grid container spacing={2}>
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='Background Jobs'
+            subheader='Scheduled and ad-hoc engine jobs, most recently run first.'
+          />
+          {retryStatus.error && (
+            <Typography color='error' sx={{ px: 2 }}>
+              {retryStatus.error.message}
+            </Typography>
+          )}
+          <Table>
+            <TableHead>
+              <TableRow>
+                <TableCell>Kind</TableCell>
+                <TableCell>State</TableCell>
+                <TableCell>Schedule</TableCell>
+                <TableCell>Attempts</TableCell>
+                <TableCell>Last Run</TableCell>
+                <TableCell>Next Run</TableCell>
+                <TableCell>Last Error</TableCell>
+                <TableCell />
+              </TableRow>
+            </TableHead>
+            <TableBody>
+              {jobs.map((j) => (
+                <TableRow key={j.id}>
+                  <TableCell>{j.kind}</TableCell>
+                  <TableCell>{j.state}</TableCell>
+                  <TableCell>
+                    {j.intervalSeconds
+                      ? `Every ${j.intervalSeconds}s`
+                      : 'One-time'}
+                  </TableCell>
+                  <TableCell>
+                    {j.attempts} / {j.maxAttempts}
+                  </TableCell>
+                  <TableCell>
+                    <Time time={j.startedAt} format='relative' zero='Never' />
+                  </TableCell>
+                  <TableCell>
+                    {j.state === 'pending' && (
+                      <Time time={j.runAt} format='relative' />
+                    )}
+                  </TableCell>
+                  <TableCell>{j.lastError}</TableCell>
+                  <TableCell>
+                    {(j.state === 'pending' || j.state === 'dead') && (
+                      <Button
+                        size='small'
+                        disabled={retryStatus.fetching}
+                        onClick={() =>
+                          commitRetry(
+                            { id: j.id },
+                            { additionalTypenames: ['EngineJob'] },
+                          )
+                        }
+                      >
+                        {j.state === 'dead' ? 'Retry' : 'Run Now'}
+                      </Button>
+                    )}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </Card>
+      </Grid>
+    </Grid>
+  )
+}
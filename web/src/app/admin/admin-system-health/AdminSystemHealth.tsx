@@ -0,0 +1,161 @@
+import React, { useEffect } from 'react'
+import {
+  Button,
+  Card,
+  CardContent,
+  CardHeader,
+  Grid,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Typography,
+} from '@mui/material'
+import { gql, useQuery } from 'urql'
+import { SystemHealth } from '../../../schema'
+import { GenericError } from '../../error-pages'
+import Spinner from '../../loading/components/Spinner'
+import { Time } from '../../util/Time'
+import { pathPrefix } from '../../env'
+
+const query = gql`
+  query {
+    systemHealth {
+      pendingMessages
+      oldestPendingMessageAt
+      oldestPendingMessageAgeSeconds
+      engineLagSeconds
+      messageQueues {
+        destType
+        pending
+        oldestPendingAt
+        recentlySent
+        rateLimit
+        rateLimitWindowSeconds
+        throttled
+      }
+      dbPool {
+        maxOpen
+        open
+        inUse
+        idle
+        waitCount
+        waitSeconds
+      }
+    }
+  }
+`
+
+function Stat(props: { title: string; value: string }): JSX.Element {
+  return (
+    <Grid item xs={12} sm={4}>
+      <Card>
+        <CardContent>
+          <Typography color='textSecondary'>{props.title}</Typography>
+          <Typography variant='h5'>{props.value}</Typography>
+        </CardContent>
+      </Card>
+    </Grid>
+  )
+}
+
+export default function AdminSystemHealth(): JSX.Element {
+  const [{ data, fetching, error }, refetch] = useQuery({ query })
+
+  useEffect(() => {
+    const t = setInterval(() => {
+      if (!fetching) refetch({ requestPolicy: 'network-only' })
+    }, 5000)
+    return () => clearInterval(t)
+  }, [fetching, refetch])
+
+  if (error) return <GenericError error={error.message} />
+  if (!data) return <Spinner />
+
+  const health: SystemHealth = data.systemHealth
+
+  return (
+    <Grid container spacing={2}>
+      <Stat title='Pending Messages' value={health.pendingMessages.toString()} />
+      <Stat
+        title='Oldest Pending Message'
+        value={`${Math.round(health.oldestPendingMessageAgeSeconds)}s`}
+      />
+      <Stat
+        title='Engine Lag'
+        value={`${Math.round(health.engineLagSeconds)}s`}
+      />
+      <Stat
+        title='DB Connections In Use'
+        value={`${health.dbPool.inUse} / ${health.dbPool.maxOpen || '∞'}`}
+      />
+      <Stat
+        title='DB Idle Connections'
+        value={health.dbPool.idle.toString()}
+      />
+      <Stat
+        title='DB Connection Waits'
+        value={`${health.dbPool.waitCount} (${Math.round(
+          health.dbPool.waitSeconds,
+        )}s)`}
+      />
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='Message Queues'
+            subheader='Pending and recently sent messages by destination type.'
+          />
+          <Table>
+            <TableHead>
+              <TableRow>
+                <TableCell>Type</TableCell>
+                <TableCell>Pending</TableCell>
+                <TableCell>Oldest Pending</TableCell>
+                <TableCell>Rate Limit</TableCell>
+                <TableCell>Throttled</TableCell>
+              </TableRow>
+            </TableHead>
+            <TableBody>
+              {health.messageQueues.map((q) => (
+                <TableRow key={q.destType}>
+                  <TableCell>{q.destType}</TableCell>
+                  <TableCell>{q.pending}</TableCell>
+                  <TableCell>
+                    <Time
+                      time={q.oldestPendingAt}
+                      format='relative'
+                      zero='None'
+                    />
+                  </TableCell>
+                  <TableCell>
+                    {q.recentlySent} / {q.rateLimit} per{' '}
+                    {q.rateLimitWindowSeconds}s
+                  </TableCell>
+                  <TableCell>{q.throttled ? 'Yes' : 'No'}</TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </Card>
+      </Grid>
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='Debug Bundle'
+            subheader='Download a diagnostic archive (redacted config, migration status, engine and queue state, and recent errors) to attach to a support request.'
+            action={
+              <Button
+                variant='contained'
+                href={pathPrefix + '/api/v2/debug-bundle'}
+                download
+              >
+                Download Debug Bundle
+              </Button>
+            }
+          />
+        </Card>
+      </Grid>
+    </Grid>
+  )
+}
@@ -0,0 +1,132 @@
+import React, { useState } from 'react'
+import {
+  Checkbox,
+  FormControlLabel,
+  Grid,
+  MenuItem,
+  TextField,
+} from '@mui/material'
+import { gql, useMutation } from 'urql'
+import { DateTime } from 'luxon'
+import FormDialog from '../../dialogs/FormDialog'
+import { FormContainer, FormField } from '../../forms'
+import { fieldErrors, nonFieldErrors } from '../../util/errutil'
+import { ISODateTimePicker } from '../../util/ISOPickers'
+import { NoticeType } from '../../../schema'
+
+const mutation = gql`
+  mutation ($input: CreateSystemNoticeInput!) {
+    createSystemNotice(input: $input) {
+      id
+    }
+  }
+`
+
+interface Value {
+  type: NoticeType
+  message: string
+  details: string
+  adminOnly: boolean
+  expiresAt: string
+}
+
+export default function AdminSystemNoticeCreateDialog(props: {
+  onClose: () => void
+}): JSX.Element {
+  const [value, setValue] = useState<Value>({
+    type: 'INFO',
+    message: '',
+    details: '',
+    adminOnly: false,
+    expiresAt: DateTime.utc().plus({ days: 1 }).startOf('minute').toISO(),
+  })
+  const [status, commit] = useMutation(mutation)
+
+  return (
+    <FormDialog
+      title='Create System Notice'
+      loading={status.fetching}
+      errors={nonFieldErrors(status.error)}
+      onClose={props.onClose}
+      onSubmit={() =>
+        commit(
+          {
+            input: {
+              ...value,
+              expiresAt: value.expiresAt || null,
+            },
+          },
+          { additionalTypenames: ['SystemNotice'] },
+        ).then((result) => {
+          if (!result.error) props.onClose()
+        })
+      }
+      form={
+        <FormContainer
+          value={value}
+          errors={fieldErrors(status.error)}
+          onChange={(value: Value) => setValue(value)}
+          disabled={status.fetching}
+        >
+          <Grid container spacing={2}>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                select
+                required
+                label='Type'
+                name='type'
+              >
+                <MenuItem value='INFO'>Info</MenuItem>
+                <MenuItem value='WARNING'>Warning</MenuItem>
+                <MenuItem value='ERROR'>Error</MenuItem>
+              </FormField>
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                required
+                label='Message'
+                name='message'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                multiline
+                component={TextField}
+                label='Details'
+                name='details'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={ISODateTimePicker}
+                label='Expires At'
+                name='expiresAt'
+                hint='Leave blank to show the notice until it is deleted.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormControlLabel
+                control={
+                  <FormField
+                    component={Checkbox}
+                    checkbox
+                    name='adminOnly'
+                    fieldName='adminOnly'
+                  />
+                }
+                label='Only show to administrators'
+                labelPlacement='end'
+              />
+            </Grid>
+          </Grid>
+        </FormContainer>
+      }
+    />
+  )
+}
@@ -0,0 +1,124 @@
+import React, { useState } from 'react'
+import {
+  Button,
+  Card,
+  CardHeader,
+  Grid,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Typography,
+} from '@mui/material'
+import { gql, useMutation, useQuery } from 'urql'
+import { SystemNotice } from '../../../schema'
+import { GenericError } from '../../error-pages'
+import Spinner from '../../loading/components/Spinner'
+import { Time } from '../../util/Time'
+import AdminSystemNoticeCreateDialog from './AdminSystemNoticeCreateDialog'
+
+const query = gql`
+  query {
+    systemNotices {
+      id
+      type
+      message
+      details
+      adminOnly
+      createdAt
+      expiresAt
+    }
+  }
+`
+
+const deleteMutation = gql`
+  mutation ($id: ID!) {
+    deleteSystemNotice(id: $id)
+  }
+`
+
+export default function AdminSystemNotices(): JSX.Element {
+  const [{ data, error }] = useQuery({ query })
+  const [deleteStatus, commitDelete] = useMutation(deleteMutation)
+  const [showCreate, setShowCreate] = useState(false)
+
+  if (error) return <GenericError error={error.message} />
+  if (!data) return <Spinner />
+
+  const notices: SystemNotice[] = data.systemNotices
+
+  return (
+    <Grid container spacing={2}>
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='System Notices'
+            subheader='Banners displayed at the top of every page.'
+            action={
+              <Button variant='contained' onClick={() => setShowCreate(true)}>
+                Create Notice
+              </Button>
+            }
+          />
+          {deleteStatus.error && (
+            <Typography color='error' sx={{ px: 2 }}>
+              {deleteStatus.error.message}
+            </Typography>
+          )}
+          <Table>
+            <TableHead>
+              <TableRow>
+                <TableCell>Type</TableCell>
+                <TableCell>Message</TableCell>
+                <TableCell>Audience</TableCell>
+                <TableCell>Created</TableCell>
+                <TableCell>Expires</TableCell>
+                <TableCell />
+              </TableRow>
+            </TableHead>
+            <TableBody>
+              {notices.map((n) => (
+                <TableRow key={n.id}>
+                  <TableCell>{n.type}</TableCell>
+                  <TableCell>
+                    {n.message}
+                    {n.details && (
+                      <Typography variant='body2' color='textSecondary'>
+                        {n.details}
+                      </Typography>
+                    )}
+                  </TableCell>
+                  <TableCell>{n.adminOnly ? 'Admins' : 'Everyone'}</TableCell>
+                  <TableCell>
+                    <Time time={n.createdAt} format='relative' />
+                  </TableCell>
+                  <TableCell>
+                    <Time time={n.expiresAt} format='relative' zero='Never' />
+                  </TableCell>
+                  <TableCell>
+                    <Button
+                      size='small'
+                      disabled={deleteStatus.fetching}
+                      onClick={() =>
+                        commitDelete(
+                          { id: n.id },
+                          { additionalTypenames: ['SystemNotice'] },
+                        )
+                      }
+                    >
+                      Delete
+                    </Button>
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </Card>
+      </Grid>
+      {showCreate && (
+        <AdminSystemNoticeCreateDialog onClose={() => setShowCreate(false)} />
+      )}
+    </Grid>
+  )
+}
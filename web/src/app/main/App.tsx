@@ -21,6 +21,7 @@ import { isIOS } from '../util/browsers'
 import UserSettingsPopover from './components/UserSettingsPopover'
 import { Theme } from '@mui/material/styles'
 import AppRoutes from './AppRoutes'
+import SystemNoticeBanner from './components/SystemNoticeBanner'
 import { useURLKey } from '../actions'
 import NavBar from './NavBar'
 import AuthLink from './components/AuthLink'
@@ -143,6 +144,7 @@ export default function App(): JSX.Element {
                   className={classes.mainContainer}
                 >
                   <Grid className={classes.containerClass} item>
+                    <SystemNoticeBanner />
                     <AppRoutes />
                   </Grid>
                 </Grid>
@@ -3,6 +3,10 @@ import { gql, useQuery } from 'urql'
 import { Switch, Route, useLocation, RouteProps, useRoute } from 'wouter'
 import AdminMessageLogsLayout from '../admin/admin-message-logs/AdminMessageLogsLayout'
 import AdminAlertCounts from '../admin/admin-alert-counts/AdminAlertCounts'
+import AdminJobs from '../admin/admin-jobs/AdminJobs'
+import AdminEngineEvents from '../admin/admin-engine-events/AdminEngineEvents'
+import AdminSystemHealth from '../admin/admin-system-health/AdminSystemHealth'
+import AdminSystemNotices from '../admin/admin-system-notices/AdminSystemNotices'
 import AdminConfig from '../admin/AdminConfig'
 import AdminLimits from '../admin/AdminLimits'
 import AdminToolbox from '../admin/AdminToolbox'
@@ -36,6 +40,7 @@ import UserDetails from '../users/UserDetails'
 import UserList from '../users/UserList'
 import UserOnCallAssignmentList from '../users/UserOnCallAssignmentList'
 import UserSessionList from '../users/UserSessionList'
+import UserNotificationReliability from '../users/UserNotificationReliability'
 import { useSessionInfo } from '../util/RequireConfig'
 import WizardRouter from '../wizard/WizardRouter'
 import LocalDev from '../localdev/LocalDev'
@@ -107,6 +112,7 @@ export const routes: Record<string, JSXElementConstructor<any>> = {
   '/users/:userID/schedule-calendar-subscriptions':
     UserCalendarSubscriptionList,
   '/users/:userID/sessions': UserSessionList,
+  '/users/:userID/notification-reliability': UserNotificationReliability,
 
   '/profile': Spinner, // should redirect once user ID loads
   '/profile/*': Spinner, // should redirect once user ID loads
@@ -116,6 +122,10 @@ export const routes: Record<string, JSXElementConstructor<any>> = {
   '/admin/toolbox': AdminToolbox,
   '/admin/message-logs': AdminMessageLogsLayout,
   '/admin/alert-counts': AdminAlertCounts,
+  '/admin/jobs': AdminJobs,
+  '/admin/engine-events': AdminEngineEvents,
+  '/admin/health': AdminSystemHealth,
+  '/admin/notices': AdminSystemNotices,
   '/admin/switchover': AdminSwitchover,
   '/admin/switchover/guide': AdminSwitchoverGuide,
 
@@ -81,6 +81,10 @@ export default function NavBar(): JSX.Element {
               <NavBarSubLink to='/admin/toolbox' title='Toolbox' />
               <NavBarSubLink to='/admin/message-logs' title='Message Logs' />
               <NavBarSubLink to='/admin/alert-counts' title='Alert Counts' />
+              <NavBarSubLink to='/admin/jobs' title='Jobs' />
+              <NavBarSubLink to='/admin/engine-events' title='Engine Events' />
+              <NavBarSubLink to='/admin/health' title='System Health' />
+              <NavBarSubLink to='/admin/notices' title='System Notices' />
               <NavBarSubLink to='/admin/switchover' title='Switchover' />
             </NavBarLink>
           </RequireConfig>
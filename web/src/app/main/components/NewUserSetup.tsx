@@ -8,6 +8,7 @@ export default function NewUserSetup(): JSX.Element {
   const [isFirstLogin] = useURLParam('isFirstLogin', '')
   const clearIsFirstLogin = useResetURLParams('isFirstLogin')
   const [contactMethodID, setContactMethodID] = useState('')
+  const [verifyByVoice, setVerifyByVoice] = useState(false)
   const { userID, ready } = useSessionInfo()
 
   if (!isFirstLogin || !ready) {
@@ -17,6 +18,7 @@ export default function NewUserSetup(): JSX.Element {
     return (
       <UserContactMethodVerificationDialog
         contactMethodID={contactMethodID}
+        verifyByVoice={verifyByVoice}
         onClose={clearIsFirstLogin}
       />
     )
@@ -27,9 +29,10 @@ export default function NewUserSetup(): JSX.Element {
       title='Welcome to GoAlert!'
       subtitle='To get started, please enter a contact method.'
       userID={userID}
-      onClose={(contactMethodID) => {
+      onClose={(contactMethodID, verifyByVoice) => {
         if (contactMethodID) {
           setContactMethodID(contactMethodID)
+          setVerifyByVoice(Boolean(verifyByVoice))
         } else {
           clearIsFirstLogin()
         }
@@ -0,0 +1,30 @@
+import React from 'react'
+import { Grid } from '@mui/material'
+import { gql, useQuery } from 'urql'
+import Notices from '../../details/Notices'
+import { Notice } from '../../../schema'
+
+const query = gql`
+  query {
+    notices {
+      type
+      message
+      details
+    }
+  }
+`
+
+// SystemNoticeBanner displays any active administrator-managed notices
+// above the current page.
+export default function SystemNoticeBanner(): JSX.Element | null {
+  const [{ data }] = useQuery({ query })
+
+  const notices: Notice[] = data?.notices ?? []
+  if (!notices.length) return null
+
+  return (
+    <Grid container sx={{ mb: 2 }}>
+      <Notices notices={notices} />
+    </Grid>
+  )
+}
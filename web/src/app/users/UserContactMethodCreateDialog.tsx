@@ -13,6 +13,7 @@ type Value = {
   name: string
   type: ContactMethodType
   value: string
+  verifyByVoice?: boolean
 }
 
 const createMutation = gql`
@@ -36,7 +37,7 @@ const userConflictQuery = gql`
 
 export default function UserContactMethodCreateDialog(props: {
   userID: string
-  onClose: (contactMethodID?: string) => void
+  onClose: (contactMethodID?: string, verifyByVoice?: boolean) => void
   title?: string
   subtitle?: string
 }): JSX.Element {
@@ -78,14 +79,19 @@ export default function UserContactMethodCreateDialog(props: {
     },
   )
 
+  // verifyByVoice is passed on to the verification dialog, which sends the first code
+  const { verifyByVoice, ...cmInput } = CMValue
   const [createCM, createCMStatus] = useMutation(createMutation, {
     onCompleted: (result) => {
-      props.onClose(result.createUserContactMethod.id)
+      props.onClose(
+        result.createUserContactMethod.id,
+        cmInput.type === 'SMS' && Boolean(verifyByVoice),
+      )
     },
     onError: () => query(),
     variables: {
       input: {
-        ...CMValue,
+        ...cmInput,
         userID: props.userID,
         newUserNotificationRule: {
           delayMinutes: 0,
@@ -18,6 +18,7 @@ type Value = {
   type: ContactMethodType
   value: string
   statusUpdates?: StatusUpdateState
+  verifyByVoice?: boolean
 }
 
 export type UserContactMethodFormProps = {
@@ -225,6 +226,26 @@ export default function UserContactMethodForm(
         <Grid item xs={12}>
           {renderTypeField(value.type, edit)}
         </Grid>
+        {!edit && value.type === 'SMS' && (
+          <Grid item xs={12}>
+            <FormControlLabel
+              label='Send verification code by voice call (e.g., for landlines)'
+              control={
+                <Checkbox
+                  name='verifyByVoice'
+                  checked={Boolean(value.verifyByVoice)}
+                  onChange={(v) =>
+                    props.onChange &&
+                    props.onChange({
+                      ...value,
+                      verifyByVoice: v.target.checked,
+                    })
+                  }
+                />
+              }
+            />
+          </Grid>
+        )}
         <Grid item xs={12}>
           <Typography variant='caption'>{disclaimer}</Typography>
         </Grid>
@@ -62,6 +62,7 @@ export default function UserContactMethodList(
 
   const [showAddDialog, setShowAddDialog] = useState(false)
   const [showVerifyDialogByID, setShowVerifyDialogByID] = useState('')
+  const [verifyByVoice, setVerifyByVoice] = useState(false)
   const [showEditDialogByID, setShowEditDialogByID] = useState('')
   const [showDeleteDialogByID, setShowDeleteDialogByID] = useState('')
   const [showSendTestByID, setShowSendTestByID] = useState('')
@@ -212,16 +213,21 @@ export default function UserContactMethodList(
         {showAddDialog && (
           <UserContactMethodCreateDialog
             userID={props.userID}
-            onClose={(contactMethodID = '') => {
+            onClose={(contactMethodID = '', verifyByVoice) => {
               setShowAddDialog(false)
               setShowVerifyDialogByID(contactMethodID)
+              setVerifyByVoice(Boolean(verifyByVoice))
             }}
           />
         )}
         {showVerifyDialogByID && (
           <UserContactMethodVerificationDialog
             contactMethodID={showVerifyDialogByID}
-            onClose={() => setShowVerifyDialogByID('')}
+            verifyByVoice={verifyByVoice}
+            onClose={() => {
+              setShowVerifyDialogByID('')
+              setVerifyByVoice(false)
+            }}
           />
         )}
         {showEditDialogByID && (
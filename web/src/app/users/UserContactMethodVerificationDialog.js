@@ -83,6 +83,7 @@ export default function UserContactMethodVerificationDialog(props) {
       form={
         <UserContactMethodVerificationForm
           contactMethodID={props.contactMethodID}
+          verifyByVoice={props.verifyByVoice}
           errors={fieldErrs}
           setSendError={setSendError}
           disabled={loading}
@@ -97,4 +98,5 @@ export default function UserContactMethodVerificationDialog(props) {
 UserContactMethodVerificationDialog.propTypes = {
   onClose: p.func.isRequired,
   contactMethodID: p.string.isRequired,
+  verifyByVoice: p.bool,
 }
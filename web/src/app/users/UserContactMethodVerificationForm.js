@@ -49,8 +49,16 @@ export default function UserContactMethodVerificationForm(props) {
   // Attempt to send a code on load, but it's ok if it fails.
   //
   // We only want to display an error in response to a user action.
+  // Resending uses the same delivery method as this first code.
   useEffect(() => {
-    sendCode().catch(() => {})
+    const opts = props.verifyByVoice
+      ? {
+          variables: {
+            input: { contactMethodID: props.contactMethodID, voice: true },
+          },
+        }
+      : undefined
+    sendCode(opts).catch(() => {})
   }, [])
 
   return (
@@ -84,6 +92,7 @@ export default function UserContactMethodVerificationForm(props) {
 
 UserContactMethodVerificationForm.propTypes = {
   contactMethodID: p.string.isRequired,
+  verifyByVoice: p.bool,
   disabled: p.bool.isRequired,
   errors: p.arrayOf(
     p.shape({
@@ -1,6 +1,7 @@
 import React, { useState } from 'react'
 import { useQuery, gql } from 'urql'
 import Delete from '@mui/icons-material/Delete'
+import PersonOff from '@mui/icons-material/PersonOff'
 import LockOpenIcon from '@mui/icons-material/LockOpen'
 import DetailsPage from '../details/DetailsPage'
 import { UserAvatar } from '../util/avatars'
@@ -18,6 +19,7 @@ import { GenericError, ObjectNotFound } from '../error-pages'
 import { useSessionInfo } from '../util/RequireConfig'
 import UserEditDialog from './UserEditDialog'
 import UserDeleteDialog from './UserDeleteDialog'
+import UserOffboardDialog from './UserOffboardDialog'
 import { QuerySetFavoriteButton } from '../util/QuerySetFavoriteButton'
 import { EscalationPolicyStep } from '../../schema'
 import { useIsWidthDown } from '../util/useWidth'
@@ -29,6 +31,7 @@ const userQuery = gql`
       role
       name
       email
+      deactivated
       contactMethods {
         id
       }
@@ -53,6 +56,7 @@ const profileQuery = gql`
       role
       name
       email
+      deactivated
       contactMethods {
         id
       }
@@ -69,6 +73,11 @@ const profileQuery = gql`
       sessions {
         id
       }
+      notices {
+        type
+        message
+        details
+      }
     }
   }
 `
@@ -100,7 +109,9 @@ export default function UserDetails(props: {
   const [showVerifyDialogByID, setShowVerifyDialogByID] = useState<
     string | null | undefined
   >(null)
+  const [verifyByVoice, setVerifyByVoice] = useState(false)
   const [showUserDeleteDialog, setShowUserDeleteDialog] = useState(false)
+  const [showOffboardDialog, setShowOffboardDialog] = useState(false)
   const mobile = useIsWidthDown('md')
 
   const [{ data, fetching: isQueryLoading, error }] = useQuery({
@@ -146,6 +157,11 @@ export default function UserDetails(props: {
         sessCount === 1 ? '' : 's'
       }`,
     })
+    links.push({
+      label: 'Notification Reliability',
+      url: 'notification-reliability',
+      subText: 'Review delivery of recent alert notifications',
+    })
   }
 
   const options: (
@@ -170,6 +186,13 @@ export default function UserDetails(props: {
       handleOnClick: () => setShowEdit(true),
     })
   }
+  if (isAdmin && !user.deactivated && userID !== currentUserID) {
+    options.unshift({
+      label: 'Offboard',
+      icon: <PersonOff />,
+      handleOnClick: () => setShowOffboardDialog(true),
+    })
+  }
   if (isAdmin) {
     options.unshift({
       label: 'Delete',
@@ -187,6 +210,12 @@ export default function UserDetails(props: {
           role={user.role}
         />
       )}
+      {showOffboardDialog && (
+        <UserOffboardDialog
+          userID={userID}
+          onClose={() => setShowOffboardDialog(false)}
+        />
+      )}
       {showUserDeleteDialog && (
         <UserDeleteDialog
           userID={userID}
@@ -216,15 +245,17 @@ export default function UserDetails(props: {
       {createCM && (
         <UserContactMethodCreateDialog
           userID={userID}
-          onClose={(contactMethodID) => {
+          onClose={(contactMethodID, verifyByVoice) => {
             setCreateCM(false)
             setShowVerifyDialogByID(contactMethodID)
+            setVerifyByVoice(Boolean(verifyByVoice))
           }}
         />
       )}
       {showVerifyDialogByID && (
         <UserContactMethodVerificationDialog
           contactMethodID={showVerifyDialogByID}
+          verifyByVoice={verifyByVoice}
           onClose={() => setShowVerifyDialogByID(null)}
         />
       )}
@@ -237,7 +268,10 @@ export default function UserDetails(props: {
       <DetailsPage
         avatar={<UserAvatar userID={userID} />}
         title={user.name + (svcCount ? ' (On-Call)' : '')}
-        subheader={user.email}
+        subheader={
+          user.deactivated ? `${user.email} (deactivated)` : user.email
+        }
+        notices={user.notices}
         pageContent={
           <Grid container spacing={2}>
             <UserContactMethodList userID={userID} readOnly={props.readOnly} />
@@ -0,0 +1,97 @@
+import React from 'react'
+import {
+  Card,
+  CardHeader,
+  Grid,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Typography,
+} from '@mui/material'
+import { gql, useQuery } from 'urql'
+import { ContactMethodReliability } from '../../schema'
+import { GenericError } from '../error-pages'
+import Spinner from '../loading/components/Spinner'
+
+const query = gql`
+  query ($userID: ID!) {
+    user(id: $userID) {
+      id
+      notificationReliability {
+        contactMethodID
+        name
+        type
+        delivered
+        sent
+        failed
+        noResponse
+        consecutiveFailures
+      }
+    }
+  }
+`
+
+export default function UserNotificationReliability(props: {
+  userID: string
+}): JSX.Element {
+  const [{ data, error }] = useQuery({
+    query,
+    variables: { userID: props.userID },
+  })
+
+  if (error) return <GenericError error={error.message} />
+  if (!data) return <Spinner />
+
+  const stats: ContactMethodReliability[] =
+    data.user?.notificationReliability ?? []
+
+  return (
+    <Grid container spacing={2}>
+      <Grid item xs={12}>
+        <Card>
+          <CardHeader
+            title='Notification Reliability'
+            subheader='Alert notification outcomes by contact method over the last 30 days.'
+          />
+          {stats.length === 0 ? (
+            <Typography sx={{ px: 2, pb: 2 }}>
+              No alert notifications have been sent recently.
+            </Typography>
+          ) : (
+            <Table>
+              <TableHead>
+                <TableRow>
+                  <TableCell>Contact Method</TableCell>
+                  <TableCell>Delivered</TableCell>
+                  <TableCell>Sent</TableCell>
+                  <TableCell>Failed</TableCell>
+                  <TableCell>No Response</TableCell>
+                </TableRow>
+              </TableHead>
+              <TableBody>
+                {stats.map((s) => (
+                  <TableRow key={s.contactMethodID}>
+                    <TableCell>
+                      {s.name} ({s.type})
+                      {s.consecutiveFailures > 0 && (
+                        <Typography variant='body2' color='error'>
+                          {s.consecutiveFailures} failed in a row
+                        </Typography>
+                      )}
+                    </TableCell>
+                    <TableCell>{s.delivered}</TableCell>
+                    <TableCell>{s.sent}</TableCell>
+                    <TableCell>{s.failed}</TableCell>
+                    <TableCell>{s.noResponse}</TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          )}
+        </Card>
+      </Grid>
+    </Grid>
+  )
+}
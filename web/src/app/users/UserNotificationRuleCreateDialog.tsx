@@ -4,6 +4,7 @@ import { gql } from 'urql'
 import { fieldErrors, nonFieldErrors } from '../util/errutil'
 import FormDialog from '../dialogs/FormDialog'
 import UserNotificationRuleForm from './UserNotificationRuleForm'
+import { AlertSeverity } from '../../schema'
 
 const mutation = gql`
   mutation ($input: CreateUserNotificationRuleInput!) {
@@ -17,7 +18,11 @@ export default function UserNotificationRuleCreateDialog(props: {
   onClose: () => void
   userID: string
 }): JSX.Element {
-  const [value, setValue] = useState({ contactMethodID: '', delayMinutes: 0 })
+  const [value, setValue] = useState({
+    contactMethodID: '',
+    delayMinutes: 0,
+    severities: [] as AlertSeverity[],
+  })
 
   const [createNotification, { loading, error }] = useMutation(mutation, {
     onCompleted: props.onClose,
@@ -1,13 +1,18 @@
 import React from 'react'
 import Grid from '@mui/material/Grid'
+import MenuItem from '@mui/material/MenuItem'
 import TextField from '@mui/material/TextField'
 import { FormContainer, FormField } from '../forms'
 import UserContactMethodSelect from './UserContactMethodSelect'
 import { FieldError } from '../util/errutil'
+import { AlertSeverity } from '../../schema'
+
+const severities: AlertSeverity[] = ['critical', 'high', 'medium', 'low']
 
 interface CreateNotificationRule {
   contactMethodID: string
   delayMinutes: number
+  severities: AlertSeverity[]
 }
 
 interface UserNotificationRuleFormProps {
@@ -23,7 +28,7 @@ interface UserNotificationRuleFormProps {
 }
 
 interface Error {
-  field: 'delayMinutes' | 'contactMethodID'
+  field: 'delayMinutes' | 'contactMethodID' | 'severities'
   message: string
 }
 
@@ -56,6 +61,24 @@ export default function UserNotificationRuleForm(
             component={TextField}
           />
         </Grid>
+        <Grid item xs={12}>
+          <FormField
+            fullWidth
+            name='severities'
+            label='Alert Severities'
+            select
+            hint='Leave empty to notify for alerts of any severity.'
+            // @ts-expect-error TS2322 -- FormField has not been converted to ts, and inferred type is incorrect.
+            SelectProps={{ multiple: true }}
+            component={TextField}
+          >
+            {severities.map((sev) => (
+              <MenuItem key={sev} value={sev}>
+                {sev.charAt(0).toUpperCase() + sev.slice(1)}
+              </MenuItem>
+            ))}
+          </FormField>
+        </Grid>
       </Grid>
     </FormContainer>
   )
@@ -29,6 +29,7 @@ const query = gql`
       notificationRules {
         id
         delayMinutes
+        severities
         contactMethod {
           id
           type
@@ -83,6 +84,9 @@ export default function UserNotificationRuleList(props: {
             data-cy='notification-rules'
             items={sortNotificationRules(user.notificationRules).map((nr) => ({
               title: formatNotificationRule(nr.delayMinutes, nr.contactMethod),
+              subText: nr.severities.length
+                ? 'Only for ' + nr.severities.join(', ') + ' alerts'
+                : undefined,
               secondaryAction: props.readOnly ? null : (
                 <IconButton
                   aria-label='Delete notification rule'
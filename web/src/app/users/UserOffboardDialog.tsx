@@ -0,0 +1,121 @@
+import React, { useState } from 'react'
+import { gql, useQuery, useMutation } from 'urql'
+import { List, ListItem, ListItemText, Typography } from '@mui/material'
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import { UserSelect } from '../selection'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+import { UserReference } from '../../schema'
+
+const query = gql`
+  query ($id: ID!) {
+    user(id: $id) {
+      id
+      name
+    }
+    userReferences(id: $id) {
+      type
+      id
+      name
+      detail
+    }
+  }
+`
+
+const mutation = gql`
+  mutation ($input: OffboardUserInput!) {
+    offboardUser(input: $input)
+  }
+`
+
+const typeNames: Record<string, string> = {
+  schedule: 'Schedule',
+  rotation: 'Rotation',
+  escalationPolicy: 'Escalation Policy',
+  user: 'User',
+}
+
+interface UserOffboardDialogProps {
+  userID: string
+  onClose: () => void
+}
+
+export default function UserOffboardDialog(
+  props: UserOffboardDialogProps,
+): JSX.Element {
+  const [value, setValue] = useState({ replacementUserID: null })
+  const [{ data, fetching, error }] = useQuery({
+    query,
+    variables: { id: props.userID },
+    requestPolicy: 'network-only',
+  })
+  const [status, offboard] = useMutation(mutation)
+
+  if (!data && fetching) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+
+  const refs: UserReference[] = data?.userReferences ?? []
+  const fieldErrs = fieldErrors(status.error)
+
+  return (
+    <FormDialog
+      title={`Offboard ${data?.user?.name}`}
+      confirm
+      subTitle='This will deactivate the user, disabling login and all of their contact methods. This cannot be undone.'
+      loading={status.fetching}
+      errors={[
+        ...nonFieldErrors(status.error),
+        ...fieldErrs.filter((e) => e.field !== 'replacementUserID'),
+      ]}
+      onClose={props.onClose}
+      onSubmit={() =>
+        offboard(
+          {
+            input: {
+              userID: props.userID,
+              replacementUserID: value.replacementUserID,
+            },
+          },
+          { additionalTypenames: ['User'] },
+        ).then((res) => {
+          if (res.error) return
+          props.onClose()
+        })
+      }
+      form={
+        <FormContainer
+          value={value}
+          onChange={setValue}
+          errors={fieldErrs.filter((e) => e.field === 'replacementUserID')}
+          optionalLabels
+        >
+          <FormField
+            fullWidth
+            component={UserSelect}
+            name='replacementUserID'
+            label='Replacement User'
+            hint='Replaces the user everywhere below. If empty, the user is removed instead.'
+          />
+          {refs.length === 0 ? (
+            <Typography sx={{ pt: 2 }} color='textSecondary'>
+              This user is not referenced anywhere.
+            </Typography>
+          ) : (
+            <List dense>
+              {refs.map((r) => (
+                <ListItem key={r.type + r.id + r.detail}>
+                  <ListItemText
+                    primary={r.name}
+                    secondary={`${typeNames[r.type] ?? r.type}: ${r.detail}`}
+                  />
+                </ListItem>
+              ))}
+            </List>
+          )}
+        </FormContainer>
+      }
+    />
+  )
+}
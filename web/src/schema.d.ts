@@ -5,11 +5,27 @@ export interface Query {
   experimentalFlags: string[]
   messageLogs: MessageLogConnection
   debugMessages: DebugMessage[]
+  messageLogRetention: MessageLogRetention
+  engineJobs: EngineJob[]
+  systemHealth: SystemHealth
+  configExport: string
+  configDrift: ConfigDriftChange[]
+  twilioSpend: TwilioSpend
+  notices: Notice[]
+  systemNotices: SystemNotice[]
+  serviceSLOBurnRate: number
+  requestTrace: RequestTrace
   user?: null | User
+  userReferences: UserReference[]
+  notificationLocales: string[]
   users: UserConnection
   alert?: null | Alert
+  archivedAlert?: null | ArchivedAlert
   alerts: AlertConnection
   service?: null | Service
+  teams: Team[]
+  team?: null | Team
+  serviceTemplates: ServiceTemplate[]
   integrationKey?: null | IntegrationKey
   heartbeatMonitor?: null | HeartbeatMonitor
   services: ServiceConnection
@@ -42,6 +58,7 @@ export interface Query {
   generateSlackAppManifest: string
   linkAccountInfo?: null | LinkAccountInfo
   swoStatus: SWOStatus
+  engineCycle: EngineCycle
   gqlAPIKeys: GQLAPIKey[]
   listGQLFields: string[]
 }
@@ -88,6 +105,15 @@ export interface SWOConnection {
   count: number
 }
 
+export interface EngineCycle {
+  id: string
+  state: EngineCycleState
+  startedAt?: null | ISOTimestamp
+  finishedAt?: null | ISOTimestamp
+}
+
+export type EngineCycleState = 'pending' | 'running' | 'finished'
+
 export interface LinkAccountInfo {
   userDetails: string
   alertID?: null | number
@@ -143,6 +169,84 @@ export interface MessageLogConnection {
   stats: MessageLogConnectionStats
 }
 
+export interface EngineJobSearchOptions {
+  state?: null | EngineJobState
+  kind?: null | string
+  first?: null | number
+}
+
+export type EngineJobState = 'pending' | 'running' | 'completed' | 'dead'
+
+export interface EngineJob {
+  id: number
+  kind: string
+  state: EngineJobState
+  args: string
+  intervalSeconds: number
+  attempts: number
+  maxAttempts: number
+  runAt: ISOTimestamp
+  createdAt: ISOTimestamp
+  startedAt?: null | ISOTimestamp
+  finishedAt?: null | ISOTimestamp
+  lastError?: null | string
+}
+
+export interface SystemHealth {
+  pendingMessages: number
+  oldestPendingMessageAt?: null | ISOTimestamp
+  oldestPendingMessageAgeSeconds: number
+  engineLagSeconds: number
+  messageQueues: MessageQueueState[]
+  dbPool: DBPoolStats
+}
+
+export interface DBPoolStats {
+  maxOpen: number
+  open: number
+  inUse: number
+  idle: number
+  waitCount: number
+  waitSeconds: number
+}
+
+export interface TwilioSpendOptions {
+  start?: null | ISOTimestamp
+  end?: null | ISOTimestamp
+}
+
+export interface TwilioSpend {
+  start: ISOTimestamp
+  end: ISOTimestamp
+  totalCost: number
+  todayCost: number
+  dailyBudget: number
+  regions: TwilioRegionSpend[]
+}
+
+export interface TwilioRegionSpend {
+  type: string
+  region: string
+  count: number
+  cost: number
+}
+
+export interface MessageQueueState {
+  destType: string
+  pending: number
+  oldestPendingAt?: null | ISOTimestamp
+  recentlySent: number
+  rateLimit: number
+  rateLimitWindowSeconds: number
+  throttled: boolean
+}
+
+export interface MessageLogRetention {
+  retentionDays: number
+  oldestMessageAt?: null | ISOTimestamp
+  pendingCleanup: number
+}
+
 export interface MessageLogConnectionStats {
   timeSeries: TimeSeriesBucket[]
 }
@@ -340,6 +444,13 @@ export interface DebugSendSMSInfo {
   fromNumber: string
 }
 
+export interface RequeueFailedMessagesInput {
+  createdAfter: ISOTimestamp
+  createdBefore: ISOTimestamp
+  contactMethodTypes?: null | ContactMethodType[]
+  errorCode?: null | number
+}
+
 export interface DebugMessageStatusInput {
   providerMessageID: string
 }
@@ -379,12 +490,41 @@ export type SWOAction = 'reset' | 'execute'
 
 export interface Mutation {
   swoAction: boolean
+  triggerEngineCycle: EngineCycle
   linkAccount: boolean
   setTemporarySchedule: boolean
   clearTemporarySchedules: boolean
   setScheduleOnCallNotificationRules: boolean
+  setScheduleRestConstraints: boolean
+  setScheduleMinCoverage: boolean
+  generateBalancedRotation: TemporarySchedule
+  createTeam: Team
+  updateTeam: boolean
+  createTeamContactMethod: TeamContactMethod
+  deleteTeamContactMethod: boolean
+  sendTeamContactMethodVerification: boolean
+  verifyTeamContactMethod: boolean
+  addScheduleShadow: string
+  deleteScheduleShadow: boolean
+  createUserUnavailability: UserUnavailability
+  deleteUserUnavailability: boolean
+  importUserUnavailability: number
+  createUserDelegation: UserDelegation
+  deleteUserDelegation: boolean
+  offboardUser: boolean
+  importPagerDuty: PagerDutyImportReport
+  importUsers: UserImportResult[]
+  cloneSchedule: Schedule
+  cloneEscalationPolicy: EscalationPolicy
+  cloneService: Service
+  createServiceTemplate: ServiceTemplate
+  createServiceFromTemplate: Service
+  deleteServiceTemplate: boolean
   debugCarrierInfo: DebugCarrierInfo
   debugSendSMS?: null | DebugSendSMSInfo
+  requeueMessages: number
+  requeueFailedMessages: number
+  cancelMessages: number
   addAuthSubject: boolean
   deleteAuthSubject: boolean
   endAllAuthSessionsByCurrentUser: boolean
@@ -399,12 +539,27 @@ export interface Mutation {
   updateEscalationPolicyStep: boolean
   deleteAll: boolean
   createAlert?: null | Alert
+  retryEngineJob: boolean
+  createAlerts: CreateAlertResult[]
   setAlertNoiseReason: boolean
   createService?: null | Service
   createEscalationPolicy?: null | EscalationPolicy
   createEscalationPolicyStep?: null | EscalationPolicyStep
   createRotation?: null | Rotation
   createIntegrationKey?: null | IntegrationKey
+  setIntegrationKeyTransform: boolean
+  setIntegrationKeyEmailRules: boolean
+  setIntegrationKeySNMPRules: boolean
+  setIntegrationKeySyslogFilter: boolean
+  setIntegrationKeyMQTTRules: boolean
+  setIntegrationKeyGitHubFilter: boolean
+  setServiceJiraConfig: boolean
+  setServiceServiceNowConfig: boolean
+  setServiceStatuspageComponent: boolean
+  setServiceStatusCallback: boolean
+  createServiceSLO: ServiceSLO
+  updateServiceSLO: boolean
+  deleteServiceSLO: boolean
   createHeartbeatMonitor?: null | HeartbeatMonitor
   setLabel: boolean
   createSchedule?: null | Schedule
@@ -415,6 +570,8 @@ export interface Mutation {
   createUserOverride?: null | UserOverride
   createUserContactMethod?: null | UserContactMethod
   createUserNotificationRule?: null | UserNotificationRule
+  setUserNotificationRuleFallbacks: boolean
+  setUserNotificationRuleSeverities: boolean
   updateUserContactMethod: boolean
   sendContactMethodVerification: boolean
   verifyContactMethod: boolean
@@ -423,7 +580,12 @@ export interface Mutation {
   updateHeartbeatMonitor: boolean
   updateAlertsByService: boolean
   setConfig: boolean
+  testConfig: ConfigTestResult[]
+  testNotification: TestNotificationResult
   setSystemLimits: boolean
+  createSystemNotice: SystemNotice
+  updateSystemNotice: boolean
+  deleteSystemNotice: boolean
   createGQLAPIKey: CreatedGQLAPIKey
   updateGQLAPIKey: boolean
   deleteGQLAPIKey: boolean
@@ -487,11 +649,18 @@ export interface UpdateAlertsByServiceInput {
   newStatus: AlertStatus
 }
 
+export interface CreateAlertResult {
+  alert?: null | Alert
+  isNew: boolean
+  error?: null | string
+}
+
 export interface CreateAlertInput {
   summary: string
   details?: null | string
   serviceID: string
   sanitize?: null | boolean
+  severity?: null | AlertSeverity
 }
 
 export interface SetAlertNoiseReasonInput {
@@ -511,24 +680,30 @@ export interface CreateUserInput {
 export interface CreateUserCalendarSubscriptionInput {
   name: string
   reminderMinutes?: null | number[]
+  endReminderMinutes?: null | number[]
   scheduleID: string
   disabled?: null | boolean
   fullSchedule?: null | boolean
+  rotationID?: null | string
 }
 
 export interface UpdateUserCalendarSubscriptionInput {
   id: string
   name?: null | string
   reminderMinutes?: null | number[]
+  endReminderMinutes?: null | number[]
   disabled?: null | boolean
   fullSchedule?: null | boolean
+  rotationID?: null | string
 }
 
 export interface UserCalendarSubscription {
   id: string
   name: string
   reminderMinutes: number[]
+  endReminderMinutes: number[]
   fullSchedule: boolean
+  rotationID?: null | string
   scheduleID: string
   schedule?: null | Schedule
   lastAccess: ISOTimestamp
@@ -541,6 +716,48 @@ export interface ConfigValueInput {
   value: string
 }
 
+export interface ConfigTestResult {
+  section: string
+  fieldID?: null | string
+  ok: boolean
+  message: string
+}
+
+export interface ConfigDriftChange {
+  action: string
+  kind: string
+  name: string
+  field: string
+  expected: string
+  actual: string
+}
+
+export type TestNotificationDestType =
+  | 'SMS'
+  | 'VOICE'
+  | 'EMAIL'
+  | 'SLACK_CHANNEL'
+
+export interface TestNotificationInput {
+  type: TestNotificationDestType
+  value: string
+  waitSeconds?: null | number
+}
+
+export interface TestNotificationResult {
+  ok: boolean
+  providerMessageID?: null | string
+  trace: TestNotificationStep[]
+}
+
+export interface TestNotificationStep {
+  at: ISOTimestamp
+  step: string
+  ok: boolean
+  message: string
+  state?: null | NotificationState
+}
+
 export interface UpdateUserOverrideInput {
   id: string
   start?: null | ISOTimestamp
@@ -617,6 +834,9 @@ export interface CreateEscalationPolicyInput {
   name: string
   description?: null | string
   repeat?: null | number
+  backoffMultiplier?: null | number
+  maxNotifications?: null | number
+  giveUpChannelID?: null | string
   favorite?: null | boolean
   steps?: null | CreateEscalationPolicyStepInput[]
 }
@@ -624,6 +844,7 @@ export interface CreateEscalationPolicyInput {
 export interface CreateEscalationPolicyStepInput {
   escalationPolicyID?: null | string
   delayMinutes: number
+  conferenceBridge?: null | boolean
   targets?: null | TargetInput[]
   newRotation?: null | CreateRotationInput
   newSchedule?: null | CreateScheduleInput
@@ -635,6 +856,7 @@ export interface EscalationPolicyStep {
   delayMinutes: number
   targets: Target[]
   escalationPolicy?: null | EscalationPolicy
+  conferenceBridge: boolean
 }
 
 export interface UpdateScheduleInput {
@@ -657,6 +879,9 @@ export interface UpdateEscalationPolicyInput {
   name?: null | string
   description?: null | string
   repeat?: null | number
+  backoffMultiplier?: null | number
+  maxNotifications?: null | number
+  giveUpChannelID?: null | string
   stepIDs?: null | string[]
 }
 
@@ -664,6 +889,7 @@ export interface UpdateEscalationPolicyStepInput {
   id: string
   delayMinutes?: null | number
   targets?: null | TargetInput[]
+  conferenceBridge?: null | boolean
 }
 
 export interface SetFavoriteInput {
@@ -693,11 +919,162 @@ export interface Schedule {
   timeZone: string
   assignedTo: Target[]
   shifts: OnCallShift[]
+  shiftsPreview: ScheduleTimeZonePreview[]
   targets: ScheduleTarget[]
   target?: null | ScheduleTarget
   isFavorite: boolean
   temporarySchedules: TemporarySchedule[]
   onCallNotificationRules: OnCallNotificationRule[]
+  onCallLoad: OnCallUserLoad[]
+  restConstraints: ScheduleRestConstraints
+  restViolations: ScheduleRestViolation[]
+  minCoverage: number
+  coverageGaps: ScheduleCoverageGap[]
+  coverageConflicts: ScheduleCoverageConflict[]
+  shadows: ScheduleShadow[]
+}
+
+export interface ScheduleShadow {
+  id: string
+  userID: string
+  user?: null | User
+  shadowUserID?: null | string
+  shadowUser?: null | User
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface AddScheduleShadowInput {
+  scheduleID: string
+  userID: string
+  shadowUserID?: null | string
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface DeleteScheduleShadowInput {
+  scheduleID: string
+  id: string
+}
+
+export interface CloneScheduleInput {
+  id: string
+  name: string
+  includeTargets: boolean
+}
+
+export interface CloneEscalationPolicyInput {
+  id: string
+  name: string
+  includeTargets: boolean
+}
+
+export interface CloneServiceInput {
+  id: string
+  name: string
+  cloneEscalationPolicy?: null | boolean
+  includeTargets: boolean
+}
+
+export interface ServiceTemplate {
+  id: string
+  name: string
+  description: string
+  params: string[]
+}
+
+export interface TemplateParamInput {
+  name: string
+  value: string
+}
+
+export interface CreateServiceTemplateInput {
+  serviceID: string
+  name: string
+  description?: null | string
+  includeTargets: boolean
+  params?: null | TemplateParamInput[]
+}
+
+export interface CreateServiceFromTemplateInput {
+  templateID: string
+  params?: null | TemplateParamInput[]
+  favorite?: null | boolean
+}
+
+export interface ImportPagerDutyInput {
+  data: string
+  dryRun?: null | boolean
+}
+
+export interface ImportUsersInput {
+  csv: string
+  dryRun?: null | boolean
+  sendVerification?: null | boolean
+}
+
+export interface UserImportResult {
+  line: number
+  name: string
+  email: string
+  status: UserImportStatus
+  userID?: null | string
+  contactMethodID?: null | string
+  verificationSent: boolean
+  message: string
+}
+
+export type UserImportStatus = 'CREATED' | 'DUPLICATE' | 'INVALID'
+
+export interface PagerDutyImportReport {
+  mappings: PagerDutyImportMapping[]
+  issues: PagerDutyImportIssue[]
+}
+
+export interface PagerDutyImportMapping {
+  type: string
+  pagerDutyID: string
+  id: string
+  name: string
+  existing: boolean
+}
+
+export interface PagerDutyImportIssue {
+  type: string
+  pagerDutyID: string
+  name: string
+  message: string
+}
+
+export interface ScheduleRestConstraints {
+  maxConsecutiveHours: number
+  minRestHours: number
+}
+
+export interface SetScheduleRestConstraintsInput {
+  scheduleID: string
+  maxConsecutiveHours: number
+  minRestHours: number
+}
+
+export type ScheduleRestViolationType = 'maxConsecutive' | 'minRest'
+
+export interface ScheduleRestViolation {
+  userID: string
+  user?: null | User
+  type: ScheduleRestViolationType
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface OnCallUserLoad {
+  userID: string
+  user?: null | User
+  onCallHours: number
+  nightHours: number
+  weekendHours: number
+  shiftCount: number
+  pageCount: number
 }
 
 export interface SetScheduleOnCallNotificationRulesInput {
@@ -889,6 +1266,7 @@ export interface Alert {
   id: string
   alertID: number
   status: AlertStatus
+  severity: AlertSeverity
   summary: string
   details: string
   createdAt: ISOTimestamp
@@ -899,6 +1277,26 @@ export interface Alert {
   pendingNotifications: AlertPendingNotification[]
   metrics?: null | AlertMetric
   noiseReason?: null | string
+  jiraIssue?: null | AlertJiraIssue
+  serviceNowIncident?: null | AlertServiceNowIncident
+}
+
+export interface ArchivedAlert {
+  alertID: number
+  serviceID: string
+  summary: string
+  details: string
+  severity: AlertSeverity
+  createdAt: ISOTimestamp
+  archivedAt: ISOTimestamp
+  logs: ArchivedAlertLog[]
+}
+
+export interface ArchivedAlertLog {
+  id: number
+  timestamp: ISOTimestamp
+  event: string
+  message: string
 }
 
 export interface AlertMetric {
@@ -956,6 +1354,11 @@ export interface Service {
   labels: Label[]
   heartbeatMonitors: HeartbeatMonitor[]
   notices: Notice[]
+  jiraConfig?: null | ServiceJiraConfig
+  serviceNowConfig?: null | ServiceServiceNowConfig
+  statuspageComponent?: null | ServiceStatuspageComponent
+  statusCallback?: null | ServiceStatusCallback
+  slos: ServiceSLO[]
 }
 
 export interface CreateIntegrationKeyInput {
@@ -964,6 +1367,270 @@ export interface CreateIntegrationKeyInput {
   name: string
 }
 
+export interface SetServiceJiraConfigInput {
+  serviceID: string
+  config?: null | ServiceJiraConfigInput
+}
+
+export interface ServiceJiraConfigInput {
+  projectKey: string
+  issueType: string
+  summaryTemplate: string
+  descriptionTemplate: string
+  fields: JiraFieldMappingInput[]
+  closeTransition: string
+}
+
+export interface JiraFieldMappingInput {
+  fieldID: string
+  template: string
+}
+
+export interface ServiceJiraConfig {
+  projectKey: string
+  issueType: string
+  summaryTemplate: string
+  descriptionTemplate: string
+  fields: JiraFieldMapping[]
+  closeTransition: string
+}
+
+export interface JiraFieldMapping {
+  fieldID: string
+  template: string
+}
+
+export interface AlertJiraIssue {
+  key: string
+  url: string
+  closed: boolean
+  lastError: string
+}
+
+export interface SetServiceServiceNowConfigInput {
+  serviceID: string
+  config?: null | ServiceServiceNowConfigInput
+}
+
+export interface ServiceServiceNowConfigInput {
+  assignmentGroup: string
+  category: string
+  callerID: string
+  impact: number
+  urgency: number
+  resolveCode: string
+}
+
+export interface ServiceServiceNowConfig {
+  assignmentGroup: string
+  category: string
+  callerID: string
+  impact: number
+  urgency: number
+  resolveCode: string
+}
+
+export interface AlertServiceNowIncident {
+  sysID: string
+  number: string
+  url: string
+  acknowledged: boolean
+  closed: boolean
+  lastError: string
+}
+
+export interface SetServiceStatuspageComponentInput {
+  serviceID: string
+  component?: null | ServiceStatuspageComponentInput
+}
+
+export interface ServiceStatuspageComponentInput {
+  componentID: string
+  degradedAt: number
+  partialOutageAt: number
+  majorOutageAt: number
+}
+
+export interface ServiceStatuspageComponent {
+  componentID: string
+  degradedAt: number
+  partialOutageAt: number
+  majorOutageAt: number
+  lastStatus: string
+  overridden: boolean
+  lastError: string
+}
+
+export interface SetServiceStatusCallbackInput {
+  serviceID: string
+  callback?: null | ServiceStatusCallbackInput
+  regenerateSecret?: null | boolean
+}
+
+export interface ServiceStatusCallbackInput {
+  url: string
+  unansweredMinutes: number
+}
+
+export interface ServiceStatusCallback {
+  url: string
+  secret: string
+  unansweredMinutes: number
+}
+
+export interface CreateServiceSLOInput {
+  serviceID: string
+  name: string
+  severity?: null | AlertSeverity
+  targetPercent: number
+  ackWithinMinutes: number
+  windowDays?: null | number
+}
+
+export interface UpdateServiceSLOInput {
+  id: string
+  name?: null | string
+  severity?: null | AlertSeverity
+  clearSeverity?: null | boolean
+  targetPercent?: null | number
+  ackWithinMinutes?: null | number
+  windowDays?: null | number
+}
+
+export interface RequestTrace {
+  requestID: string
+  alerts: Alert[]
+  messages: RequestTraceMessage[]
+}
+
+export interface RequestTraceMessage {
+  id: string
+  alertID?: null | number
+  type: string
+  destType: string
+  status: string
+  statusDetails: string
+  providerMessageID: string
+  createdAt: ISOTimestamp
+  sentAt?: null | ISOTimestamp
+}
+
+export interface ServiceSLO {
+  id: string
+  serviceID: string
+  name: string
+  severity?: null | AlertSeverity
+  targetPercent: number
+  ackWithinMinutes: number
+  windowDays: number
+  status?: null | ServiceSLOStatus
+}
+
+export interface ServiceSLOStatus {
+  total: number
+  met: number
+  compliancePercent: number
+  errorBudgetRemainingPercent: number
+  burnRate1h: number
+  burnRate6h: number
+  burnRate24h: number
+  computedAt: ISOTimestamp
+}
+
+export interface SetIntegrationKeyTransformInput {
+  id: string
+  summary: string
+  details: string
+  dedup: string
+  action: string
+}
+
+export interface SetIntegrationKeySNMPRulesInput {
+  id: string
+  rules: IntegrationKeySNMPRuleInput[]
+}
+
+export interface IntegrationKeySNMPRuleInput {
+  trapOID: string
+  action: SNMPRuleAction
+  summaryOID: string
+  dedupOID: string
+}
+
+export interface IntegrationKeySNMPRule {
+  trapOID: string
+  action: SNMPRuleAction
+  summaryOID: string
+  dedupOID: string
+}
+
+export type SNMPRuleAction = 'trigger' | 'close' | 'ignore'
+
+export interface SetIntegrationKeySyslogFilterInput {
+  id: string
+  filter?: null | IntegrationKeySyslogFilterInput
+}
+
+export interface IntegrationKeySyslogFilterInput {
+  maxSeverity: number
+  facilities: number[]
+  appNames: string[]
+  hostnames: string[]
+}
+
+export interface IntegrationKeySyslogFilter {
+  maxSeverity: number
+  facilities: number[]
+  appNames: string[]
+  hostnames: string[]
+}
+
+export interface SetIntegrationKeyMQTTRulesInput {
+  id: string
+  rules: IntegrationKeyMQTTRuleInput[]
+}
+
+export interface IntegrationKeyMQTTRuleInput {
+  topicFilter: string
+  valueExpr: string
+  operator: MQTTRuleOperator
+  threshold: number
+  autoClose: boolean
+}
+
+export interface IntegrationKeyMQTTRule {
+  topicFilter: string
+  valueExpr: string
+  operator: MQTTRuleOperator
+  threshold: number
+  autoClose: boolean
+}
+
+export type MQTTRuleOperator = 'any' | 'gt' | 'gte' | 'lt' | 'lte' | 'eq' | 'ne'
+
+export interface SetIntegrationKeyGitHubFilterInput {
+  id: string
+  filter?: null | IntegrationKeyGitHubFilterInput
+}
+
+export interface IntegrationKeyGitHubFilterInput {
+  repositories: string[]
+  branches: string[]
+}
+
+export interface IntegrationKeyGitHubFilter {
+  repositories: string[]
+  branches: string[]
+}
+
+export interface SetIntegrationKeyEmailRulesInput {
+  id: string
+  dedupPattern: string
+  resolvePattern: string
+  allowedSenders: string[]
+  maxDetailsLength: number
+}
+
 export interface CreateHeartbeatMonitorInput {
   serviceID?: null | string
   name: string
@@ -999,6 +1666,26 @@ export interface IntegrationKey {
   type: IntegrationKeyType
   name: string
   href: string
+  transform?: null | IntegrationKeyTransform
+  emailRules?: null | IntegrationKeyEmailRules
+  snmpRules: IntegrationKeySNMPRule[]
+  syslogFilter?: null | IntegrationKeySyslogFilter
+  mqttRules: IntegrationKeyMQTTRule[]
+  githubFilter?: null | IntegrationKeyGitHubFilter
+}
+
+export interface IntegrationKeyEmailRules {
+  dedupPattern: string
+  resolvePattern: string
+  allowedSenders: string[]
+  maxDetailsLength: number
+}
+
+export interface IntegrationKeyTransform {
+  summary: string
+  details: string
+  dedup: string
+  action: string
 }
 
 export type IntegrationKeyType =
@@ -1006,6 +1693,22 @@ export type IntegrationKeyType =
   | 'grafana'
   | 'site24x7'
   | 'prometheusAlertmanager'
+  | 'datadog'
+  | 'amazonSNS'
+  | 'sentry'
+  | 'zabbix'
+  | 'azureMonitor'
+  | 'gcpMonitoring'
+  | 'newRelic'
+  | 'splunk'
+  | 'pagerDutyEvents'
+  | 'nagios'
+  | 'snmp'
+  | 'syslog'
+  | 'kafka'
+  | 'mqtt'
+  | 'githubActions'
+  | 'grafanaOnCall'
   | 'email'
 
 export interface ServiceOnCallUser {
@@ -1019,6 +1722,9 @@ export interface EscalationPolicy {
   name: string
   description: string
   repeat: number
+  backoffMultiplier: number
+  maxNotifications: number
+  giveUpChannelID?: null | string
   isFavorite: boolean
   assignedTo: Target[]
   steps: EscalationPolicyStep[]
@@ -1030,6 +1736,8 @@ export type AlertStatus =
   | 'StatusClosed'
   | 'StatusUnacknowledged'
 
+export type AlertSeverity = 'critical' | 'high' | 'medium' | 'low'
+
 export interface Target {
   id: string
   type: TargetType
@@ -1059,6 +1767,7 @@ export type TargetType =
   | 'heartbeatMonitor'
   | 'calendarSubscription'
   | 'userSession'
+  | 'team'
 
 export interface ServiceConnection {
   nodes: Service[]
@@ -1086,6 +1795,16 @@ export interface UpdateUserInput {
   email?: null | string
   role?: null | UserRole
   statusUpdateContactMethodID?: null | string
+  alertDigestMinutes?: null | number
+  quietHours?: null | UserQuietHoursInput
+  clearQuietHours?: null | boolean
+  locale?: null | string
+}
+
+export interface UserQuietHoursInput {
+  start: ClockTime
+  end: ClockTime
+  timeZone: string
 }
 
 export interface AuthSubjectInput {
@@ -1108,7 +1827,198 @@ export interface User {
   authSubjects: AuthSubject[]
   sessions: UserSession[]
   onCallSteps: EscalationPolicyStep[]
+  unavailability: UserUnavailability[]
+  delegations: UserDelegation[]
   isFavorite: boolean
+  deactivated: boolean
+  alertDigestMinutes: number
+  quietHours?: null | UserQuietHours
+  locale: string
+  notices: Notice[]
+  notificationReliability: ContactMethodReliability[]
+}
+
+export interface UserQuietHours {
+  start: ClockTime
+  end: ClockTime
+  timeZone: string
+  active: boolean
+}
+
+export interface ContactMethodReliability {
+  contactMethodID: string
+  name: string
+  type: ContactMethodType
+  delivered: number
+  sent: number
+  failed: number
+  noResponse: number
+  consecutiveFailures: number
+}
+
+export type UserUnavailabilitySource = 'manual' | 'ics'
+
+export interface UserUnavailability {
+  id: string
+  userID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  note: string
+  source: UserUnavailabilitySource
+}
+
+export interface CreateUserUnavailabilityInput {
+  userID?: null | string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  note?: null | string
+}
+
+export interface UserDelegation {
+  id: string
+  userID: string
+  delegateUserID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface CreateUserDelegationInput {
+  userID?: null | string
+  delegateUserID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface UserReference {
+  type: TargetType
+  id: string
+  name: string
+  detail: string
+}
+
+export interface OffboardUserInput {
+  userID: string
+  replacementUserID?: null | string
+}
+
+export interface ImportUserUnavailabilityInput {
+  userID?: null | string
+  ics: string
+}
+
+export interface ScheduleTimeZonePreview {
+  timeZone: string
+  shifts: ScheduleShiftPreview[]
+  transitions: TimeZoneTransition[]
+}
+
+export interface ScheduleShiftPreview {
+  userID: string
+  user?: null | User
+  start: ISOTimestamp
+  end: ISOTimestamp
+  truncated: boolean
+  startLocal: string
+  endLocal: string
+  startZone: string
+  endZone: string
+  spansTransition: boolean
+}
+
+export interface TimeZoneTransition {
+  at: ISOTimestamp
+  atLocal: string
+  fromZone: string
+  fromOffsetMinutes: number
+  toZone: string
+  toOffsetMinutes: number
+}
+
+export interface Team {
+  id: string
+  name: string
+  description: string
+  defaultScheduleID?: null | string
+  defaultSchedule?: null | Schedule
+  onCallUsers: User[]
+  contactMethods: TeamContactMethod[]
+  onCallLoad: TeamOnCallLoad
+}
+
+export interface TeamOnCallLoad {
+  users: OnCallUserLoad[]
+  onCallHours: number
+  nightHours: number
+  weekendHours: number
+  shiftCount: number
+  pageCount: number
+}
+
+export interface TeamContactMethod {
+  id: string
+  teamID: string
+  name: string
+  type: TeamContactMethodType
+  value: string
+  disabled: boolean
+}
+
+export type TeamContactMethodType = 'SMS' | 'VOICE' | 'EMAIL' | 'SLACK'
+
+export interface CreateTeamContactMethodInput {
+  teamID: string
+  name: string
+  type: TeamContactMethodType
+  value: string
+}
+
+export interface VerifyTeamContactMethodInput {
+  id: string
+  code: number
+}
+
+export interface CreateTeamInput {
+  name: string
+  description?: null | string
+  defaultScheduleID?: null | string
+}
+
+export interface UpdateTeamInput {
+  id: string
+  name?: null | string
+  description?: null | string
+  defaultScheduleID?: null | string
+}
+
+export interface ScheduleCoverageGap {
+  start: ISOTimestamp
+  end: ISOTimestamp
+  onCallCount: number
+}
+
+export interface SetScheduleMinCoverageInput {
+  scheduleID: string
+  minCoverage: number
+}
+
+export interface GenerateBalancedRotationInput {
+  scheduleID: string
+  userIDs: string[]
+  start: ISOTimestamp
+  end?: null | ISOTimestamp
+  shiftLengthHours?: null | number
+  timeZone?: null | string
+  historyDays?: null | number
+  apply?: null | boolean
+}
+
+export interface ScheduleCoverageConflict {
+  userID: string
+  user?: null | User
+  unavailabilityID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  suggestedUsers: User[]
 }
 
 export interface UserSession {
@@ -1124,6 +2034,13 @@ export interface UserNotificationRule {
   delayMinutes: number
   contactMethodID: string
   contactMethod?: null | UserContactMethod
+  severities: AlertSeverity[]
+  fallbacks: UserNotificationRuleFallback[]
+}
+
+export interface UserNotificationRuleFallback {
+  contactMethodID: string
+  delayMinutes: number
 }
 
 export type ContactMethodType =
@@ -1165,6 +2082,23 @@ export interface CreateUserNotificationRuleInput {
   userID?: null | string
   contactMethodID?: null | string
   delayMinutes: number
+  severities?: null | AlertSeverity[]
+  fallbacks?: null | UserNotificationRuleFallbackInput[]
+}
+
+export interface UserNotificationRuleFallbackInput {
+  contactMethodID: string
+  delayMinutes: number
+}
+
+export interface SetUserNotificationRuleFallbacksInput {
+  ruleID: string
+  fallbacks: UserNotificationRuleFallbackInput[]
+}
+
+export interface SetUserNotificationRuleSeveritiesInput {
+  ruleID: string
+  severities: AlertSeverity[]
 }
 
 export interface UpdateUserContactMethodInput {
@@ -1176,6 +2110,7 @@ export interface UpdateUserContactMethodInput {
 
 export interface SendContactMethodVerificationInput {
   contactMethodID: string
+  voice?: null | boolean
 }
 
 export interface VerifyContactMethodInput {
@@ -1195,6 +2130,33 @@ export interface Notice {
   details: string
 }
 
+export interface SystemNotice {
+  id: string
+  type: NoticeType
+  message: string
+  details: string
+  adminOnly: boolean
+  createdAt: ISOTimestamp
+  expiresAt?: null | ISOTimestamp
+}
+
+export interface CreateSystemNoticeInput {
+  type: NoticeType
+  message: string
+  details?: null | string
+  adminOnly?: null | boolean
+  expiresAt?: null | ISOTimestamp
+}
+
+export interface UpdateSystemNoticeInput {
+  id: string
+  type: NoticeType
+  message: string
+  details: string
+  adminOnly: boolean
+  expiresAt?: null | ISOTimestamp
+}
+
 export type NoticeType = 'WARNING' | 'ERROR' | 'INFO'
 
 type ConfigID =
@@ -1207,10 +2169,30 @@ type ConfigID =
   | 'General.DisableSMSLinks'
   | 'General.DisableLabelCreation'
   | 'General.DisableCalendarSubscriptions'
+  | 'General.MessageSendingPartitions'
   | 'Maintenance.AlertCleanupDays'
+  | 'Maintenance.AlertArchiveDays'
   | 'Maintenance.AlertAutoCloseDays'
   | 'Maintenance.APIKeyExpireDays'
   | 'Maintenance.ScheduleCleanupDays'
+  | 'Maintenance.MessageLogCleanupDays'
+  | 'Database.MaxOpenConns'
+  | 'Database.MaxIdleConns'
+  | 'RateLimit.VoiceAlertsPerHour'
+  | 'RateLimit.SMSAlertsPerHour'
+  | 'RateLimit.EmailAlertsPerHour'
+  | 'RateLimit.LowSeverityAlertsPerHour'
+  | 'RateLimit.IntegrationRequestsPerMinute'
+  | 'RateLimit.IntegrationMaxConcurrent'
+  | 'Failover.Enable'
+  | 'Failover.ProviderFailures'
+  | 'Failover.DestinationFailures'
+  | 'Pause.Voice'
+  | 'Pause.SMS'
+  | 'Pause.Email'
+  | 'Pause.Slack'
+  | 'Pause.Webhook'
+  | 'Pause.FailoverAlerts'
   | 'Auth.RefererURLs'
   | 'Auth.DisableBasic'
   | 'GitHub.Enable'
@@ -1220,6 +2202,7 @@ type ConfigID =
   | 'GitHub.AllowedUsers'
   | 'GitHub.AllowedOrgs'
   | 'GitHub.EnterpriseURL'
+  | 'GitHub.WebhookSecret'
   | 'OIDC.Enable'
   | 'OIDC.NewUsers'
   | 'OIDC.OverrideName'
@@ -1239,6 +2222,7 @@ type ConfigID =
   | 'Slack.AccessToken'
   | 'Slack.SigningSecret'
   | 'Slack.InteractiveMessages'
+  | 'Slack.SlashCommands'
   | 'Twilio.Enable'
   | 'Twilio.VoiceName'
   | 'Twilio.VoiceLanguage'
@@ -1250,6 +2234,13 @@ type ConfigID =
   | 'Twilio.DisableTwoWaySMS'
   | 'Twilio.SMSCarrierLookup'
   | 'Twilio.SMSFromNumberOverride'
+  | 'Twilio.CallForwardServiceID'
+  | 'Twilio.CallForwardSkipMenu'
+  | 'Twilio.IncidentReportServiceID'
+  | 'Twilio.SMSRates'
+  | 'Twilio.VoiceRates'
+  | 'Twilio.DailyBudget'
+  | 'Twilio.BudgetAlertServiceID'
   | 'SMTP.Enable'
   | 'SMTP.From'
   | 'SMTP.Address'
@@ -1259,5 +2250,17 @@ type ConfigID =
   | 'SMTP.Password'
   | 'Webhook.Enable'
   | 'Webhook.AllowedURLs'
+  | 'Jira.Enable'
+  | 'Jira.BaseURL'
+  | 'Jira.Username'
+  | 'Jira.APIToken'
+  | 'ServiceNow.Enable'
+  | 'ServiceNow.InstanceURL'
+  | 'ServiceNow.Username'
+  | 'ServiceNow.Password'
+  | 'ServiceNow.CallbackSecret'
+  | 'Statuspage.Enable'
+  | 'Statuspage.PageID'
+  | 'Statuspage.APIKey'
   | 'Feedback.Enable'
   | 'Feedback.OverrideURL'
@@ -0,0 +1,22 @@
+package webhooksig
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricVerified = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "webhook_signature",
+		Name:      "verified_total",
+		Help:      "Total number of inbound webhook requests with a valid signature.",
+	}, []string{"provider"})
+
+	metricRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "webhook_signature",
+		Name:      "rejected_total",
+		Help:      "Total number of inbound webhook requests rejected during signature verification.",
+	}, []string{"provider", "reason"})
+)
@@ -0,0 +1,185 @@
+package webhooksig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/util/log"
+)
+
+// DefaultMaxAge is the default allowed difference between the signature
+// timestamp and the current time.
+const DefaultMaxAge = 5 * time.Minute
+
+// DefaultMaxBodySize is the default limit for the size of a request body.
+const DefaultMaxBodySize = 1 << 20
+
+// nonceSpace is the UUID namespace used to derive nonce IDs from provider nonces.
+var nonceSpace = uuid.MustParse("3f0dcbd8-3cb5-4a3c-bd1b-7a43b5d0e3c1")
+
+// A NonceStore records nonces that have been used (e.g., *nonce.Store).
+type NonceStore interface {
+	// Consume returns true the first time it is called for a given ID, and false afterward.
+	Consume(ctx context.Context, id [16]byte) (bool, error)
+
+	// Release forgets a consumed ID, so it will be accepted again.
+	Release(ctx context.Context, id [16]byte) error
+}
+
+// Options configure signature verification for a provider.
+type Options struct {
+	// Provider is a short name for the provider, used in logs and metrics.
+	Provider string
+
+	Verifier Verifier
+
+	// Nonces records nonces of verified requests, so they are only accepted once. It should be shared
+	// by all instances (e.g., stored in the database). If nil, requests are not checked for replay.
+	Nonces NonceStore
+
+	// MaxAge is the allowed difference between the signature timestamp and the current time.
+	// Defaults to DefaultMaxAge.
+	MaxAge time.Duration
+
+	// MaxBodySize is the maximum size of the request body, in bytes. Defaults to DefaultMaxBodySize.
+	MaxBodySize int64
+
+	// RejectStatus is the HTTP status code returned for rejected requests.
+	// Defaults to http.StatusUnauthorized.
+	RejectStatus int
+
+	// now is used for testing.
+	now func() time.Time
+}
+
+// Wrap will return an http.Handler that only passes requests to next after
+// verifying their signature.
+func Wrap(next http.Handler, opts Options) http.Handler {
+	if opts.Verifier == nil {
+		panic("webhooksig: Verifier is required")
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = DefaultMaxAge
+	}
+	if opts.MaxBodySize == 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	if opts.RejectStatus == 0 {
+		opts.RejectStatus = http.StatusUnauthorized
+	}
+	if opts.now == nil {
+		opts.now = time.Now
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+
+		nonce, err := opts.verify(w, req)
+		if err != nil {
+			metricRejected.WithLabelValues(opts.Provider, reason(err)).Inc()
+			log.Log(log.WithField(ctx, "Provider", opts.Provider), errors.Wrap(err, "verify webhook signature"))
+			status := opts.RejectStatus
+			if errors.Is(err, ErrTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		metricVerified.WithLabelValues(opts.Provider).Inc()
+
+		if nonce == uuid.Nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		// If the request fails, release the nonce so a retry by the provider is not rejected as a replay.
+		sw := &statusWriter{ResponseWriter: w}
+		defer func() {
+			r := recover()
+			if r != nil || sw.status >= 500 {
+				err := opts.Nonces.Release(context.WithoutCancel(ctx), nonce)
+				if err != nil {
+					log.Log(ctx, errors.Wrap(err, "release nonce"))
+				}
+			}
+			if r != nil {
+				panic(r)
+			}
+		}()
+
+		next.ServeHTTP(sw, req)
+	})
+}
+
+// statusWriter records the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// verify checks the request signature, and returns the ID of the nonce consumed, if any.
+func (opts Options) verify(w http.ResponseWriter, req *http.Request) (uuid.UUID, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(w, req.Body, opts.MaxBodySize))
+		req.Body.Close()
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return uuid.Nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, maxErr.Limit)
+		}
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "read body")
+		}
+	}
+	restore := func() { req.Body = io.NopCloser(bytes.NewReader(body)) }
+
+	restore()
+	s, err := opts.Verifier.Verify(req, body)
+	restore()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if s == nil {
+		return uuid.Nil, nil
+	}
+
+	now := opts.now()
+	if !s.Time.IsZero() && now.Sub(s.Time).Abs() > opts.MaxAge {
+		return uuid.Nil, fmt.Errorf("%w: signed at %s", ErrExpired, s.Time.Format(time.RFC3339))
+	}
+	if s.Nonce == "" || opts.Nonces == nil {
+		return uuid.Nil, nil
+	}
+
+	id := uuid.NewSHA1(nonceSpace, []byte(opts.Provider+"\x00"+s.Nonce))
+	ok, err := opts.Nonces.Consume(req.Context(), id)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "record nonce")
+	}
+	if !ok {
+		return uuid.Nil, ErrReplay
+	}
+
+	return id, nil
+}
@@ -0,0 +1,95 @@
+package webhooksig
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memNonces map[[16]byte]bool
+
+func (m memNonces) Consume(_ context.Context, id [16]byte) (bool, error) {
+	if m[id] {
+		return false, nil
+	}
+	m[id] = true
+	return true, nil
+}
+
+func (m memNonces) Release(_ context.Context, id [16]byte) error {
+	delete(m, id)
+	return nil
+}
+
+func TestWrap(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// signature is the body; the signed time and nonce are set via headers
+	v := VerifierFunc(func(req *http.Request, body []byte) (*Signed, error) {
+		sig := req.Header.Get("X-Sig")
+		if sig == "" {
+			return nil, ErrMissing
+		}
+		if sig != string(body) {
+			return nil, ErrInvalid
+		}
+		var s Signed
+		if ts := req.Header.Get("X-Time"); ts != "" {
+			s.Time, _ = time.Parse(time.RFC3339, ts)
+		}
+		s.Nonce = req.Header.Get("X-Nonce")
+		return &s, nil
+	})
+
+	var gotBody string
+	var fail bool
+	h := Wrap(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		gotBody = string(data)
+		if fail {
+			http.Error(w, "fail", http.StatusInternalServerError)
+		}
+	}), Options{Provider: "test", Verifier: v, Nonces: memNonces{}, MaxBodySize: 16, now: func() time.Time { return now }})
+
+	check := func(desc string, expStatus int, body string, hdr map[string]string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		for k, v := range hdr {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		gotBody = ""
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, expStatus, rec.Code, desc)
+		if expStatus == http.StatusOK {
+			assert.Equal(t, body, gotBody, desc+": body restored")
+		}
+	}
+
+	check("missing", http.StatusUnauthorized, "foo", nil)
+	check("invalid", http.StatusUnauthorized, "foo", map[string]string{"X-Sig": "bar"})
+	check("too large", http.StatusRequestEntityTooLarge, strings.Repeat("a", 17), map[string]string{"X-Sig": strings.Repeat("a", 17)})
+	check("valid", http.StatusOK, "foo", map[string]string{"X-Sig": "foo"})
+	check("valid again without nonce", http.StatusOK, "foo", map[string]string{"X-Sig": "foo"})
+
+	check("expired", http.StatusUnauthorized, "foo", map[string]string{"X-Sig": "foo", "X-Time": now.Add(-10 * time.Minute).Format(time.RFC3339)})
+	check("future", http.StatusUnauthorized, "foo", map[string]string{"X-Sig": "foo", "X-Time": now.Add(10 * time.Minute).Format(time.RFC3339)})
+
+	ts := now.Add(-time.Minute).Format(time.RFC3339)
+	check("nonce", http.StatusOK, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "a"})
+	check("replay", http.StatusUnauthorized, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "a"})
+	check("new nonce", http.StatusOK, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "b"})
+
+	fail = true
+	check("handler error", http.StatusInternalServerError, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "c"})
+	fail = false
+	check("retry after handler error", http.StatusOK, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "c"})
+	check("replay after retry", http.StatusUnauthorized, "foo", map[string]string{"X-Sig": "foo", "X-Time": ts, "X-Nonce": "c"})
+}
@@ -0,0 +1,60 @@
+// Package webhooksig verifies signatures on inbound webhook requests from
+// providers (e.g., Twilio, Slack, Mailgun) with replay protection.
+package webhooksig
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Reasons a request can be rejected.
+var (
+	ErrMissing  = errors.New("missing signature")
+	ErrInvalid  = errors.New("invalid signature")
+	ErrExpired  = errors.New("signature timestamp outside allowed window")
+	ErrReplay   = errors.New("signed request already received")
+	ErrTooLarge = errors.New("request body too large")
+)
+
+// Signed contains details of a verified request used for replay protection.
+type Signed struct {
+	// Time is when the request was signed. If zero, the age of the request is not checked.
+	Time time.Time
+
+	// Nonce uniquely identifies the signed request, and must be covered by the signature. If empty,
+	// duplicate requests are not rejected.
+	Nonce string
+}
+
+// A Verifier checks the signature of an inbound request.
+type Verifier interface {
+	// Verify validates the signature of req. The raw request body is provided, and req.Body
+	// may also be read (e.g., via ParseForm) as it will be restored before the request is handled.
+	//
+	// A nil Signed value (with a nil error) disables replay protection for the request.
+	Verify(req *http.Request, body []byte) (*Signed, error)
+}
+
+// VerifierFunc implements Verifier with a function.
+type VerifierFunc func(req *http.Request, body []byte) (*Signed, error)
+
+// Verify implements the Verifier interface.
+func (fn VerifierFunc) Verify(req *http.Request, body []byte) (*Signed, error) { return fn(req, body) }
+
+// reason returns the metric label for a rejection error.
+func reason(err error) string {
+	switch {
+	case errors.Is(err, ErrMissing):
+		return "missing"
+	case errors.Is(err, ErrInvalid):
+		return "invalid"
+	case errors.Is(err, ErrExpired):
+		return "expired"
+	case errors.Is(err, ErrReplay):
+		return "replay"
+	case errors.Is(err, ErrTooLarge):
+		return "too_large"
+	}
+	return "error"
+}
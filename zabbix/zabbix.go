@@ -0,0 +1,210 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Severities are the Zabbix trigger severities, in increasing order.
+var Severities = []string{"Not classified", "Information", "Warning", "Average", "High", "Disaster"}
+
+func severityIndex(s string) int {
+	for i, name := range Severities {
+		if strings.EqualFold(name, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mediaTypeScript is the Zabbix webhook media type script for a single integration key.
+var mediaTypeScript = template.Must(template.New("mediatype").Parse(`// GoAlert webhook media type for Zabbix.
+//
+// Media type parameters:
+//   event_id       {EVENT.ID}
+//   event_value    {EVENT.VALUE}
+//   event_severity {EVENT.SEVERITY}
+//   alert_subject  {ALERT.SUBJECT}
+//   alert_message  {ALERT.MESSAGE}
+//   host_name      {HOST.NAME}
+//   event_url      {TRIGGER.URL}
+var params = JSON.parse(value),
+    req = new HttpRequest();
+
+req.addHeader('Content-Type: application/json');
+var resp = req.post({{ printf "%q" .URL }}, JSON.stringify({
+    event_id: params.event_id,
+    event_value: params.event_value,
+    severity: params.event_severity,
+    subject: params.alert_subject,
+    message: params.alert_message,
+    host: params.host_name,
+    url: params.event_url
+}));
+
+if (req.getStatus() != 200) {
+    throw 'GoAlert returned ' + req.getStatus() + ': ' + resp;
+}
+
+return 'OK';
+`))
+
+type post struct {
+	EventID    string `json:"event_id"`
+	EventValue string `json:"event_value"`
+	Severity   string `json:"severity"`
+	Subject    string `json:"subject"`
+	Message    string `json:"message"`
+	Host       string `json:"host"`
+	URL        string `json:"url"`
+}
+
+func (p post) details() string {
+	var s strings.Builder
+	if validate.AbsoluteURL("URL", p.URL) == nil {
+		fmt.Fprintf(&s, "[Trigger URL](%s)\n\n", p.URL)
+	}
+	if p.Host != "" {
+		fmt.Fprintf(&s, "Host: %s\n\n", p.Host)
+	}
+	if p.Severity != "" {
+		fmt.Fprintf(&s, "Severity: %s\n\n", p.Severity)
+	}
+	s.WriteString(p.Message)
+
+	return strings.TrimSpace(s.String())
+}
+
+// alertFromPost will return the alert for a Zabbix event, or nil if the problem is below
+// minSev and should be ignored.
+func alertFromPost(serviceID string, p post, minSev int) (*alert.Alert, error) {
+	if p.EventID == "" {
+		return nil, errors.New("zabbix: missing event_id")
+	}
+
+	var status alert.Status
+	switch p.EventValue {
+	case "1":
+		status = alert.StatusTriggered
+		if severityIndex(p.Severity) < minSev {
+			return nil, nil
+		}
+	case "0":
+		status = alert.StatusClosed
+	default:
+		return nil, errors.Errorf("zabbix: missing or invalid event_value: %s", p.EventValue)
+	}
+
+	summary := p.Subject
+	if p.Severity != "" {
+		summary = "[" + p.Severity + "] " + summary
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(p.details(), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceZabbix,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup("zabbix-event:" + p.EventID),
+	}, nil
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// ZabbixToEventsAPI handles problem/resolve events from the Zabbix webhook media type.
+//
+// A GET request will return the media type script pre-configured for the integration key.
+// The optional `minSeverity` query parameter (default "Warning") causes lower severity
+// problems to be ignored.
+func ZabbixToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		minSev := severityIndex("Warning")
+		if s := r.FormValue("minSeverity"); s != "" {
+			minSev = severityIndex(s)
+			if minSev == -1 {
+				http.Error(w, "invalid minSeverity", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if r.Method == "GET" {
+			q := make(url.Values)
+			q.Set("token", permission.Source(ctx).ID)
+			if s := r.FormValue("minSeverity"); s != "" {
+				q.Set("minSeverity", s)
+			}
+			w.Header().Set("Content-Type", "application/javascript")
+			err = mediaTypeScript.Execute(w, struct{ URL string }{
+				URL: config.FromContext(ctx).CallbackURL("/api/v2/zabbix/incoming", q),
+			})
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "render zabbix media type script"))
+			}
+			return
+		}
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from zabbix: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"EventID":    p.EventID,
+			"EventValue": p.EventValue,
+		})
+		msg, err := alertFromPost(serviceID, p, minSev)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from zabbix: %v", err)
+			return
+		}
+		if msg == nil {
+			// ignore unknown or low severity problems
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, _, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for zabbix")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package zabbix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+)
+
+func TestAlertFromPost(t *testing.T) {
+	minSev := severityIndex("Warning")
+
+	a, err := alertFromPost("svc", post{EventID: "123", EventValue: "1", Severity: "High", Subject: "CPU load", Host: "web-01"}, minSev)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, "[High] CPU load", a.Summary)
+	assert.Equal(t, "svc", a.ServiceID)
+	assert.Equal(t, alert.NewUserDedup("zabbix-event:123"), a.Dedup)
+
+	// recovery events only contain the event ID of the problem
+	a, err = alertFromPost("svc", post{EventID: "123", EventValue: "0", Subject: "Resolved: CPU load"}, minSev)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("zabbix-event:123"), a.Dedup)
+
+	// low and unknown severity problems are ignored
+	a, err = alertFromPost("svc", post{EventID: "124", EventValue: "1", Severity: "Information"}, minSev)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+	a, err = alertFromPost("svc", post{EventID: "124", EventValue: "1", Severity: "Bogus"}, minSev)
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	_, err = alertFromPost("svc", post{EventValue: "1", Severity: "High"}, minSev)
+	assert.Error(t, err, "missing event_id")
+	_, err = alertFromPost("svc", post{EventID: "123", EventValue: "2"}, minSev)
+	assert.Error(t, err, "invalid event_value")
+}
+
+func TestZabbixToEventsAPI_BadRequest(t *testing.T) {
+	h := ZabbixToEventsAPI(nil, nil)
+	ctx := permission.ServiceContext(context.Background(), "svc")
+
+	check := func(name, query, body string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v2/zabbix/incoming"+query, strings.NewReader(body)).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+
+	check("invalid json", "", `{`)
+	check("missing event_id", "", `{"event_value": "1", "severity": "High"}`)
+	check("invalid event_value", "", `{"event_id": "123", "event_value": "x"}`)
+	check("invalid minSeverity", "?minSeverity=bogus", `{"event_id": "123", "event_value": "1"}`)
+}